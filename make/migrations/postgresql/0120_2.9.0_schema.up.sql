@@ -0,0 +1,331 @@
+/* support the custom project roles */
+ALTER TABLE role ALTER COLUMN name TYPE varchar(255);
+ALTER TABLE role ADD COLUMN IF NOT EXISTS description text;
+ALTER TABLE role ADD COLUMN IF NOT EXISTS custom boolean NOT NULL DEFAULT false;
+ALTER TABLE role ADD COLUMN IF NOT EXISTS creation_time timestamp default CURRENT_TIMESTAMP;
+ALTER TABLE role ADD COLUMN IF NOT EXISTS update_time timestamp default CURRENT_TIMESTAMP;
+CREATE UNIQUE INDEX IF NOT EXISTS unique_role_name ON role (name);
+SELECT setval('role_role_id_seq', (SELECT MAX(role_id) FROM role));
+
+/* the members of the user groups provisioned via SCIM */
+CREATE TABLE IF NOT EXISTS user_group_member (
+    id SERIAL PRIMARY KEY NOT NULL,
+    group_id int NOT NULL,
+    user_id int NOT NULL,
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    CONSTRAINT user_group_member_group_fk FOREIGN KEY (group_id) REFERENCES user_group(id) ON DELETE CASCADE,
+    CONSTRAINT user_group_member_user_fk FOREIGN KEY (user_id) REFERENCES harbor_user(user_id) ON DELETE CASCADE,
+    CONSTRAINT unique_user_group_member UNIQUE (group_id, user_id)
+);
+
+/* the auditor is a read-only system administrator */
+ALTER TABLE harbor_user ADD COLUMN IF NOT EXISTS auditor_flag boolean NOT NULL DEFAULT false;
+
+/* the short-lived pull tokens which can only pull the specified repositories of a project */
+CREATE TABLE IF NOT EXISTS pull_token (
+    id SERIAL PRIMARY KEY NOT NULL,
+    project_id int NOT NULL,
+    repositories text NOT NULL,
+    description text,
+    secret varchar(2048),
+    salt varchar(64),
+    creator varchar(255),
+    revoked boolean NOT NULL DEFAULT false,
+    expires_at timestamp,
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    CONSTRAINT pull_token_project_fk FOREIGN KEY (project_id) REFERENCES project(project_id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_pull_token_project_id ON pull_token (project_id);
+
+/* the delivery attempts and the dead letters of the webhook jobs */
+ALTER TABLE notification_job ADD COLUMN IF NOT EXISTS job_parameters text;
+CREATE TABLE IF NOT EXISTS notification_job_attempt (
+    id SERIAL PRIMARY KEY NOT NULL,
+    job_id int NOT NULL,
+    success boolean NOT NULL DEFAULT false,
+    status_code int,
+    latency bigint,
+    response text,
+    error text,
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    CONSTRAINT notification_job_attempt_job_fk FOREIGN KEY (job_id) REFERENCES notification_job(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_notification_job_attempt_job_id ON notification_job_attempt (job_id);
+CREATE TABLE IF NOT EXISTS notification_dead_letter (
+    id SERIAL PRIMARY KEY NOT NULL,
+    job_id int NOT NULL,
+    policy_id int NOT NULL,
+    event_type varchar(256),
+    notify_type varchar(256),
+    reason text,
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    CONSTRAINT notification_dead_letter_job_fk FOREIGN KEY (job_id) REFERENCES notification_job(id) ON DELETE CASCADE,
+    CONSTRAINT unique_notification_dead_letter_job UNIQUE (job_id)
+);
+
+/* the secret to sign the webhook payloads */
+ALTER TABLE notification_policy ADD COLUMN IF NOT EXISTS secret varchar(2048);
+
+/* the options to throttle or sample the pull artifact events of the webhook policy */
+ALTER TABLE notification_policy ADD COLUMN IF NOT EXISTS pull_event_options text;
+
+/* the filters of the events sent to the webhook policy */
+ALTER TABLE notification_policy ADD COLUMN IF NOT EXISTS filters text;
+
+/* the durable store of the job stats and periodic policies of the job service */
+CREATE TABLE IF NOT EXISTS job_service_stats (
+    id SERIAL PRIMARY KEY NOT NULL,
+    job_id varchar(64) NOT NULL,
+    status varchar(32) NOT NULL,
+    data text NOT NULL,
+    update_time timestamp default CURRENT_TIMESTAMP,
+    CONSTRAINT unique_job_service_stats_job_id UNIQUE (job_id)
+);
+CREATE INDEX IF NOT EXISTS idx_job_service_stats_update_time ON job_service_stats (update_time);
+CREATE TABLE IF NOT EXISTS job_service_policy (
+    id SERIAL PRIMARY KEY NOT NULL,
+    policy_id varchar(64) NOT NULL,
+    numeric_id bigint NOT NULL,
+    data text NOT NULL,
+    update_time timestamp default CURRENT_TIMESTAMP,
+    CONSTRAINT unique_job_service_policy_policy_id UNIQUE (policy_id)
+);
+
+/* the archive of the jobs in the final status */
+CREATE TABLE IF NOT EXISTS job_archive (
+    id SERIAL PRIMARY KEY NOT NULL,
+    task_id int NOT NULL,
+    execution_id int NOT NULL,
+    job_id varchar(64),
+    job_name varchar(64),
+    vendor_type varchar(64) NOT NULL,
+    vendor_id int,
+    project_id int NOT NULL DEFAULT 0,
+    status varchar(32) NOT NULL,
+    status_message text,
+    trigger varchar(16),
+    parameters text,
+    start_time timestamp,
+    end_time timestamp,
+    duration bigint,
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    CONSTRAINT unique_job_archive_task_id UNIQUE (task_id)
+);
+CREATE INDEX IF NOT EXISTS idx_job_archive_vendor_type ON job_archive (vendor_type);
+CREATE INDEX IF NOT EXISTS idx_job_archive_project_id ON job_archive (project_id);
+CREATE INDEX IF NOT EXISTS idx_job_archive_end_time ON job_archive (end_time);
+
+/* serve the paginated listing of the tag names in the byte order of the distribution spec */
+CREATE INDEX IF NOT EXISTS idx_tag_repository_id_name ON tag (repository_id, name COLLATE "C");
+
+/* the pre-computed statistics of the projects, the row whose project_id is 0 holds the totals of all projects */
+CREATE TABLE IF NOT EXISTS project_statistic (
+    project_id int PRIMARY KEY NOT NULL,
+    repo_count bigint NOT NULL DEFAULT 0,
+    tag_count bigint NOT NULL DEFAULT 0,
+    storage bigint NOT NULL DEFAULT 0,
+    project_admin_count bigint NOT NULL DEFAULT 0,
+    maintainer_count bigint NOT NULL DEFAULT 0,
+    developer_count bigint NOT NULL DEFAULT 0,
+    guest_count bigint NOT NULL DEFAULT 0,
+    limited_guest_count bigint NOT NULL DEFAULT 0,
+    update_time timestamp default CURRENT_TIMESTAMP
+);
+
+INSERT INTO project_statistic (project_id, repo_count, tag_count, storage,
+    project_admin_count, maintainer_count, developer_count, guest_count, limited_guest_count)
+SELECT p.project_id,
+    (SELECT COUNT(*) FROM repository r WHERE r.project_id = p.project_id),
+    (SELECT COUNT(*) FROM tag t JOIN repository r ON t.repository_id = r.repository_id WHERE r.project_id = p.project_id),
+    (SELECT COALESCE(SUM(b.size), 0) FROM blob b JOIN project_blob pb ON b.id = pb.blob_id
+        WHERE pb.project_id = p.project_id AND b.content_type != 'application/vnd.docker.image.rootfs.foreign.diff.tar.gzip'),
+    (SELECT COUNT(*) FROM project_member m WHERE m.project_id = p.project_id AND m.role = 1),
+    (SELECT COUNT(*) FROM project_member m WHERE m.project_id = p.project_id AND m.role = 4),
+    (SELECT COUNT(*) FROM project_member m WHERE m.project_id = p.project_id AND m.role = 2),
+    (SELECT COUNT(*) FROM project_member m WHERE m.project_id = p.project_id AND m.role = 3),
+    (SELECT COUNT(*) FROM project_member m WHERE m.project_id = p.project_id AND m.role = 5)
+FROM project p WHERE p.deleted = false
+ON CONFLICT (project_id) DO NOTHING;
+
+INSERT INTO project_statistic (project_id, repo_count, tag_count, storage,
+    project_admin_count, maintainer_count, developer_count, guest_count, limited_guest_count)
+SELECT 0, COALESCE(SUM(repo_count), 0), COALESCE(SUM(tag_count), 0),
+    (SELECT COALESCE(SUM(size), 0) FROM blob WHERE content_type != 'application/vnd.docker.image.rootfs.foreign.diff.tar.gzip'),
+    COALESCE(SUM(project_admin_count), 0), COALESCE(SUM(maintainer_count), 0), COALESCE(SUM(developer_count), 0),
+    COALESCE(SUM(guest_count), 0), COALESCE(SUM(limited_guest_count), 0)
+FROM project_statistic WHERE project_id != 0
+ON CONFLICT (project_id) DO NOTHING;
+
+/* the snapshots of the changed resource before and after the operation recorded by the audit log */
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS before_snapshot text;
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS after_snapshot text;
+
+/* the daily rollups of the pull and push counts per repository, tag and principal */
+CREATE TABLE IF NOT EXISTS artifact_usage (
+    id SERIAL PRIMARY KEY NOT NULL,
+    day date NOT NULL,
+    project_id int NOT NULL,
+    repository_name varchar(255) NOT NULL,
+    tag varchar(255) NOT NULL DEFAULT '',
+    principal varchar(255) NOT NULL DEFAULT '',
+    pull_count bigint NOT NULL DEFAULT 0,
+    push_count bigint NOT NULL DEFAULT 0,
+    CONSTRAINT unique_artifact_usage UNIQUE (day, repository_name, tag, principal)
+);
+CREATE INDEX IF NOT EXISTS idx_artifact_usage_project_id_day ON artifact_usage (project_id, day);
+CREATE INDEX IF NOT EXISTS idx_artifact_usage_repository_name_day ON artifact_usage (repository_name, day);
+
+/* the last pull and push time of the repository, initialized by the ones of the artifacts inside it */
+ALTER TABLE repository ADD COLUMN IF NOT EXISTS pull_time timestamp;
+ALTER TABLE repository ADD COLUMN IF NOT EXISTS push_time timestamp;
+UPDATE repository AS r
+SET pull_time = a.pull_time, push_time = a.push_time
+FROM (SELECT repository_id, MAX(pull_time) AS pull_time, MAX(push_time) AS push_time FROM artifact GROUP BY repository_id) AS a
+WHERE r.repository_id = a.repository_id;
+CREATE INDEX IF NOT EXISTS idx_repository_pull_time ON repository (pull_time);
+CREATE INDEX IF NOT EXISTS idx_tag_pull_time ON tag (pull_time);
+
+/* the system-wide view of the jobs selects the executions and the webhook jobs by the start time */
+CREATE INDEX IF NOT EXISTS idx_execution_start_time ON execution (start_time);
+CREATE INDEX IF NOT EXISTS idx_notification_job_creation_time ON notification_job (creation_time);
+
+/* the artifact type of the OCI artifacts, initialized by the media type which is the config media type of the existing artifacts */
+ALTER TABLE artifact ADD COLUMN IF NOT EXISTS artifact_type varchar(255);
+UPDATE artifact SET artifact_type = media_type WHERE artifact_type IS NULL;
+CREATE INDEX IF NOT EXISTS idx_artifact_type ON artifact (type);
+CREATE INDEX IF NOT EXISTS idx_artifact_artifact_type ON artifact (artifact_type);
+
+/* the cosign trusted keys of the project are PEM encoded public keys which may exceed 255 characters */
+ALTER TABLE project_metadata ALTER COLUMN value TYPE text;
+
+/* the marks of the artifacts in use by the deployments of the external CD systems, renewed by the heartbeats before the expire time */
+CREATE TABLE IF NOT EXISTS deployment_mark (
+    id SERIAL PRIMARY KEY NOT NULL,
+    project_id int NOT NULL,
+    repository_name varchar(255) NOT NULL,
+    digest varchar(255) NOT NULL,
+    deployment varchar(255) NOT NULL,
+    created_by varchar(255),
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    update_time timestamp default CURRENT_TIMESTAMP,
+    expire_time timestamp NOT NULL,
+    CONSTRAINT unique_deployment_mark UNIQUE (repository_name, digest, deployment)
+);
+CREATE INDEX IF NOT EXISTS idx_deployment_mark_expire_time ON deployment_mark (expire_time);
+
+/* the links and the custom key/values of the repository encoded in JSON */
+ALTER TABLE repository ADD COLUMN IF NOT EXISTS links text;
+ALTER TABLE repository ADD COLUMN IF NOT EXISTS metadata text;
+
+/* the pins protecting the digests of the repositories from the deletions until they are unpinned */
+CREATE TABLE IF NOT EXISTS artifact_pin (
+    id SERIAL PRIMARY KEY NOT NULL,
+    project_id int NOT NULL,
+    repository_name varchar(255) NOT NULL,
+    digest varchar(255) NOT NULL,
+    reason varchar(1024),
+    pinned_by varchar(255),
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    CONSTRAINT unique_artifact_pin UNIQUE (repository_name, digest)
+);
+
+/* how the replication policy handles the foreign layers, "skip", "pull_through" or "rewrite" */
+ALTER TABLE replication_policy ADD COLUMN IF NOT EXISTS foreign_layer_mode varchar(32);
+
+/* the keys signing the registry tokens, the retiring keys still verify the tokens until they expire */
+CREATE TABLE IF NOT EXISTS token_key (
+    id SERIAL PRIMARY KEY NOT NULL,
+    key_id varchar(255) NOT NULL,
+    private_key text NOT NULL,
+    status varchar(16) NOT NULL,
+    expires_at timestamp,
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    CONSTRAINT unique_token_key_id UNIQUE (key_id)
+);
+
+/* the comma separated CIDRs restricting the networks the robot accounts are usable from */
+ALTER TABLE robot ADD COLUMN IF NOT EXISTS ip_allowlist text;
+ALTER TABLE robot ADD COLUMN IF NOT EXISTS ip_denylist text;
+
+/* add the artifact count to the hard limits and the usages of the existing project quotas */
+UPDATE quota SET hard = hard || '{"count": -1}'::jsonb WHERE reference='project' AND NOT hard ? 'count';
+UPDATE quota_usage SET used = used || jsonb_build_object('count', (SELECT COUNT(*) FROM artifact AS a WHERE a.project_id = CAST(quota_usage.reference_id AS int))) WHERE reference='project' AND NOT used ? 'count';
+
+/* the deactivated users can't login while their records are kept for the audit history */
+ALTER TABLE harbor_user ADD COLUMN IF NOT EXISTS deactivated boolean DEFAULT false NOT NULL;
+
+/* the versions of the configuration changes, the passwords aren't recorded */
+CREATE TABLE IF NOT EXISTS config_history (
+    id SERIAL PRIMARY KEY NOT NULL,
+    operator varchar(255),
+    before text,
+    after text,
+    creation_time timestamp default CURRENT_TIMESTAMP
+);
+
+/* the markdown landing pages of the projects */
+CREATE TABLE IF NOT EXISTS project_readme (
+    id SERIAL PRIMARY KEY NOT NULL,
+    project_id int NOT NULL,
+    content text,
+    updated_by varchar(255),
+    update_time timestamp default CURRENT_TIMESTAMP,
+    CONSTRAINT unique_project_readme UNIQUE (project_id),
+    FOREIGN KEY (project_id) REFERENCES project(project_id)
+);
+
+/* the trigram indexes backing the fuzzy search of the project and repository names, the search still works
+   without them when the pg_trgm extension cannot be created by the database user */
+DO $$
+BEGIN
+    CREATE EXTENSION IF NOT EXISTS pg_trgm;
+    CREATE INDEX IF NOT EXISTS idx_project_name_trgm ON project USING gin (name gin_trgm_ops);
+    CREATE INDEX IF NOT EXISTS idx_repository_name_trgm ON repository USING gin (name gin_trgm_ops);
+EXCEPTION WHEN insufficient_privilege OR undefined_file THEN
+    RAISE NOTICE 'pg_trgm is unavailable, skip creating the trigram indexes for the search';
+END $$;
+
+/* the JSON encoded rules naming the destination repositories of the replication policy */
+ALTER TABLE replication_policy ADD COLUMN IF NOT EXISTS namespace_mapping text;
+
+/* the JSON encoded mutual TLS materials and the negotiated capabilities of the peered Harbor registry */
+ALTER TABLE registry ADD COLUMN IF NOT EXISTS peering text;
+
+/* the JSON encoded cloud storage of the registry and the switch copying the blobs by the server-side copy API of the storage */
+ALTER TABLE registry ADD COLUMN IF NOT EXISTS storage text;
+ALTER TABLE replication_policy ADD COLUMN IF NOT EXISTS copy_by_storage boolean DEFAULT false;
+
+/* the promotions of the artifacts to the target projects or to the remote registries waiting for the approval */
+CREATE TABLE IF NOT EXISTS promotion (
+    id SERIAL PRIMARY KEY NOT NULL,
+    project_id int NOT NULL,
+    repository_name varchar(255) NOT NULL,
+    digest varchar(255) NOT NULL,
+    target_project_id int,
+    replication_policy_id int,
+    label_id int,
+    status varchar(32) NOT NULL,
+    status_message text,
+    requester varchar(255),
+    comment text,
+    reviewer varchar(255),
+    review_comment text,
+    execution_id int,
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    update_time timestamp default CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_promotion_project_id ON promotion (project_id);
+
+/* the newly pushed artifacts which aren't pullable except by the admins until the scan and signature policies pass */
+CREATE TABLE IF NOT EXISTS artifact_quarantine (
+    id SERIAL PRIMARY KEY NOT NULL,
+    artifact_id int NOT NULL,
+    project_id int NOT NULL,
+    repository_name varchar(255) NOT NULL,
+    digest varchar(255) NOT NULL,
+    reason text,
+    creation_time timestamp default CURRENT_TIMESTAMP,
+    update_time timestamp default CURRENT_TIMESTAMP,
+    FOREIGN KEY (artifact_id) REFERENCES artifact(id) ON DELETE CASCADE,
+    CONSTRAINT unique_artifact_quarantine UNIQUE (artifact_id)
+);
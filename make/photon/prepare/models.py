@@ -140,10 +140,11 @@ class InternalTLS:
 
 
 class Metric:
-    def __init__(self, enabled: bool = False, port: int = 8080, path: str = "metrics"):
+    def __init__(self, enabled: bool = False, port: int = 8080, path: str = "metrics", token: str = ""):
         self.enabled = enabled
         self.port = port
         self.path = path
+        self.token = token
 
     def validate(self):
         if not port_number_valid(self.port):
@@ -160,6 +160,10 @@ def parse_yaml_config(config_file_path, with_notary, with_trivy, with_chartmuseu
         config_dict['harbor_db_max_open_conns'] = db_configs.get("max_open_conns") or default_db_max_open_conns
         config_dict['harbor_db_conn_max_lifetime'] = db_configs.get("conn_max_lifetime") or '5m'
         config_dict['harbor_db_conn_max_idle_time'] = db_configs.get("conn_max_idle_time") or '0'
+        config_dict['harbor_db_statement_timeout'] = db_configs.get("statement_timeout") or '0'
+        # the internal database has no read replica
+        config_dict['harbor_db_replica_host'] = ''
+        config_dict['harbor_db_replica_port'] = 0
 
         if with_notary:
             # notary signer
@@ -246,6 +250,12 @@ def parse_yaml_config(config_file_path, with_notary, with_trivy, with_chartmuseu
     # jobservice config
     js_config = configs.get('jobservice') or {}
     config_dict['max_job_workers'] = js_config["max_job_workers"]
+    config_dict['job_pools'] = js_config.get("job_pools") or []
+    config_dict['max_job_concurrency'] = js_config.get("max_job_concurrency") or {}
+    config_dict['job_retry'] = js_config.get("job_retry") or {}
+    config_dict['job_limits'] = js_config.get("job_limits") or {}
+    config_dict['job_store'] = js_config.get("job_store") or 'redis'
+    config_dict['leader_election'] = js_config.get("leader_election") or 'redis'
     config_dict['jobservice_secret'] = generate_random_string(16)
 
     # notification config
@@ -261,6 +271,11 @@ def parse_yaml_config(config_file_path, with_notary, with_trivy, with_chartmuseu
         raise Exception('log level must be one of debug, info, warning, error, fatal')
     config_dict['log_level'] = log_level.lower()
 
+    log_format = (log_configs.get('format') or 'text').lower()
+    if log_format not in ['text', 'json']:
+        raise Exception('log format must be one of text, json')
+    config_dict['log_format'] = log_format
+
     # parse local log related configs
     local_logs = log_configs.get('local') or {}
     if local_logs:
@@ -292,6 +307,9 @@ def parse_yaml_config(config_file_path, with_notary, with_trivy, with_chartmuseu
         config_dict['harbor_db_max_open_conns'] = external_db_configs['harbor'].get("max_open_conns") or default_db_max_open_conns
         config_dict['harbor_db_conn_max_lifetime'] = external_db_configs['harbor'].get("conn_max_lifetime") or '5m'
         config_dict['harbor_db_conn_max_idle_time'] = external_db_configs['harbor'].get("conn_max_idle_time") or '0'
+        config_dict['harbor_db_statement_timeout'] = external_db_configs['harbor'].get("statement_timeout") or '0'
+        config_dict['harbor_db_replica_host'] = external_db_configs['harbor'].get("replica_host") or ''
+        config_dict['harbor_db_replica_port'] = external_db_configs['harbor'].get("replica_port") or 0
 
         if with_notary:
             # notary signer
@@ -341,7 +359,8 @@ def parse_yaml_config(config_file_path, with_notary, with_trivy, with_chartmuseu
     # metric configs
     metric_config = configs.get('metric')
     if metric_config:
-        config_dict['metric'] = Metric(metric_config['enabled'], metric_config['port'], metric_config['path'])
+        config_dict['metric'] = Metric(metric_config['enabled'], metric_config['port'], metric_config['path'],
+                                     metric_config.get('token') or '')
     else:
         config_dict['metric'] = Metric()
 
@@ -369,6 +388,13 @@ def parse_yaml_config(config_file_path, with_notary, with_trivy, with_chartmuseu
     cache_config = configs.get('cache')
     config_dict['cache'] = Cache(cache_config or {})
 
+    # registry shards serving the projects assigned to them, in the format of "name1=url1,name2=url2"
+    registry_shards = configs.get('registry_shards') or {}
+    config_dict['registry_shards'] = ','.join('{}={}'.format(name, url) for name, url in registry_shards.items())
+
+    # the external store used to encrypt the secrets, the local key is used if it's not set
+    config_dict['secret_store'] = configs.get('secret_store') or {}
+
     return config_dict
 
 
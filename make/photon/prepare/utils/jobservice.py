@@ -33,6 +33,12 @@ def prepare_job_service(config_dict):
         gid=DEFAULT_GID,
         internal_tls=config_dict['internal_tls'],
         max_job_workers=config_dict['max_job_workers'],
+        job_pools=config_dict['job_pools'],
+        max_job_concurrency=config_dict['max_job_concurrency'],
+        job_retry=config_dict['job_retry'],
+        job_limits=config_dict['job_limits'],
+        job_store=config_dict['job_store'],
+        leader_election=config_dict['leader_election'],
         redis_url=config_dict['redis_url_js'],
         level=log_level,
         metric=config_dict['metric'])
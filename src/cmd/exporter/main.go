@@ -32,19 +32,25 @@ func main() {
 		log.Errorf("Failed to parse database.conn_max_idle_time: %v", err)
 		connMaxIdleTime = 0
 	}
+	statementTimeout, err := time.ParseDuration(viper.GetString("database.statement_timeout"))
+	if err != nil {
+		log.Errorf("Failed to parse database.statement_timeout: %v", err)
+		statementTimeout = 0
+	}
 	dbCfg := &models.Database{
 		Type: "postgresql",
 		PostGreSQL: &models.PostGreSQL{
-			Host:            viper.GetString("database.host"),
-			Port:            viper.GetInt("database.port"),
-			Username:        viper.GetString("database.username"),
-			Password:        viper.GetString("database.password"),
-			Database:        viper.GetString("database.dbname"),
-			SSLMode:         viper.GetString("database.sslmode"),
-			MaxIdleConns:    viper.GetInt("database.max_idle_conns"),
-			MaxOpenConns:    viper.GetInt("database.max_open_conns"),
-			ConnMaxLifetime: connMaxLifetime,
-			ConnMaxIdleTime: connMaxIdleTime,
+			Host:             viper.GetString("database.host"),
+			Port:             viper.GetInt("database.port"),
+			Username:         viper.GetString("database.username"),
+			Password:         viper.GetString("database.password"),
+			Database:         viper.GetString("database.dbname"),
+			SSLMode:          viper.GetString("database.sslmode"),
+			MaxIdleConns:     viper.GetInt("database.max_idle_conns"),
+			MaxOpenConns:     viper.GetInt("database.max_open_conns"),
+			ConnMaxLifetime:  connMaxLifetime,
+			ConnMaxIdleTime:  connMaxIdleTime,
+			StatementTimeout: statementTimeout,
 		},
 	}
 	if err := dao.InitDatabase(dbCfg); err != nil {
@@ -0,0 +1,222 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/beego/beego/v2/client/orm"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/lib/config/metadata"
+	"github.com/goharbor/harbor/src/lib/encrypt"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/notification/policy/model"
+)
+
+// key: env var, value: default value
+var defaultAttrs = map[string]string{
+	"POSTGRESQL_HOST":     "localhost",
+	"POSTGRESQL_PORT":     "5432",
+	"POSTGRESQL_USERNAME": "postgres",
+	"POSTGRESQL_PASSWORD": "password",
+	"POSTGRESQL_DATABASE": "registry",
+	"POSTGRESQL_SSLMODE":  "disable",
+}
+
+var dryRun bool
+
+func init() {
+	flag.BoolVar(&dryRun, "dry-run", false, "Only print the count of the secrets to be migrated without updating them")
+}
+
+type row struct {
+	ID    int64
+	Value string
+}
+
+// secretColumn is a column storing the secrets, convert returns the new value of the column and whether it's changed
+type secretColumn struct {
+	table   string
+	column  string
+	where   string
+	args    []interface{}
+	convert func(string) (string, bool, error)
+}
+
+// The secret-migrator re-encrypts the secrets stored in the database with the secret store set by the env "SECRET_STORE".
+// The secrets are decrypted by the stores which generated them, so the envs of both the source and the target stores must be set.
+func main() {
+	flag.Parse()
+	p, _ := strconv.Atoi(getAttr("POSTGRESQL_PORT"))
+	db := &models.Database{
+		Type: "postgresql",
+		PostGreSQL: &models.PostGreSQL{
+			Host:         getAttr("POSTGRESQL_HOST"),
+			Port:         p,
+			Username:     getAttr("POSTGRESQL_USERNAME"),
+			Password:     getAttr("POSTGRESQL_PASSWORD"),
+			Database:     getAttr("POSTGRESQL_DATABASE"),
+			SSLMode:      getAttr("POSTGRESQL_SSLMODE"),
+			MaxIdleConns: 5,
+			MaxOpenConns: 5,
+		},
+	}
+	if err := dao.InitDatabase(db); err != nil {
+		log.Fatalf("failed to initialize database: %v", err)
+	}
+
+	log.Infof("Migrating the secrets to the secret store %s...", encrypt.CurrentStore())
+	for _, c := range secretColumns() {
+		count, err := migrate(orm.NewOrm(), c)
+		if err != nil {
+			log.Fatalf("failed to migrate the secrets in %s.%s: %v", c.table, c.column, err)
+		}
+		if dryRun {
+			log.Infof("%d secrets in %s.%s to be migrated", count, c.table, c.column)
+		} else {
+			log.Infof("%d secrets in %s.%s migrated", count, c.table, c.column)
+		}
+	}
+	if dryRun {
+		log.Info("Dry run done, no secret is updated.")
+		return
+	}
+	log.Info("Migration done.")
+}
+
+func secretColumns() []*secretColumn {
+	var passwords []string
+	var args []interface{}
+	for _, item := range metadata.Instance().GetAll() {
+		if _, ok := item.ItemType.(*metadata.PasswordType); ok && item.Scope == metadata.UserScope {
+			passwords = append(passwords, "?")
+			args = append(args, item.Name)
+		}
+	}
+	return []*secretColumn{
+		// the credentials of the registries
+		{table: "registry", column: "access_secret", convert: reencrypt},
+		// the secrets used to sign the payloads of the webhooks
+		{table: "notification_policy", column: "secret", convert: reencrypt},
+		// the auth headers of the webhook targets
+		{table: "notification_policy", column: "targets", convert: reencryptTargets},
+		// the passwords in the configurations, e.g. the LDAP search password
+		{table: "properties", column: "v", where: fmt.Sprintf("k IN (%s)", strings.Join(passwords, ",")), args: args, convert: reencrypt},
+	}
+}
+
+func migrate(o orm.Ormer, c *secretColumn) (int, error) {
+	sql := fmt.Sprintf("SELECT id, %s AS value FROM %s WHERE %s IS NOT NULL", c.column, c.table, c.column)
+	if len(c.where) > 0 {
+		sql += " AND " + c.where
+	}
+	var rows []*row
+	if _, err := o.Raw(sql, c.args...).QueryRows(&rows); err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, r := range rows {
+		value, changed, err := c.convert(r.Value)
+		if err != nil {
+			return count, fmt.Errorf("failed to convert the record %d: %v", r.ID, err)
+		}
+		if !changed {
+			continue
+		}
+		count++
+		if dryRun {
+			continue
+		}
+		if _, err = o.Raw(fmt.Sprintf("UPDATE %s SET %s = ? WHERE id = ?", c.table, c.column), value, r.ID).Exec(); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// reencrypt encrypts the secret with the current store if it isn't encrypted by the store yet
+func reencrypt(ciphertext string) (string, bool, error) {
+	if len(ciphertext) == 0 || encrypt.StoreOf(ciphertext) == encrypt.CurrentStore() {
+		return ciphertext, false, nil
+	}
+	plaintext, err := encrypt.Instance().Decrypt(ciphertext)
+	if err != nil {
+		return "", false, err
+	}
+	ciphertext, err = encrypt.Instance().Encrypt(plaintext)
+	if err != nil {
+		return "", false, err
+	}
+	return ciphertext, true, nil
+}
+
+// reencryptTargets encrypts the auth headers of the webhook targets, the ones stored in plain text are encrypted as well
+func reencryptTargets(value string) (string, bool, error) {
+	var targets []model.EventTarget
+	if len(value) == 0 {
+		return value, false, nil
+	}
+	if err := json.Unmarshal([]byte(value), &targets); err != nil {
+		return "", false, err
+	}
+	changed := false
+	for i, target := range targets {
+		header := target.AuthHeader
+		if len(header) == 0 {
+			continue
+		}
+		if len(encrypt.StoreOf(header)) == 0 {
+			// the auth header stored in plain text
+			encrypted, err := encrypt.Instance().Encrypt(header)
+			if err != nil {
+				return "", false, err
+			}
+			targets[i].AuthHeader = encrypted
+			changed = true
+			continue
+		}
+		encrypted, c, err := reencrypt(header)
+		if err != nil {
+			return "", false, err
+		}
+		if c {
+			targets[i].AuthHeader = encrypted
+			changed = true
+		}
+	}
+	if !changed {
+		return value, false, nil
+	}
+	buf, err := json.Marshal(targets)
+	if err != nil {
+		return "", false, err
+	}
+	return string(buf), true, nil
+}
+
+func getAttr(k string) string {
+	v := os.Getenv(k)
+	if len(v) > 0 {
+		return v
+	}
+	return defaultAttrs[k]
+}
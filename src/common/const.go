@@ -61,6 +61,9 @@ const (
 	PostGreSQLMaxOpenConns           = "postgresql_max_open_conns"
 	PostGreSQLConnMaxLifetime        = "postgresql_conn_max_lifetime"
 	PostGreSQLConnMaxIdleTime        = "postgresql_conn_max_idle_time"
+	PostGreSQLStatementTimeout       = "postgresql_statement_timeout"
+	PostGreSQLReplicaHost            = "postgresql_replica_host"
+	PostGreSQLReplicaPort            = "postgresql_replica_port"
 	SelfRegistration                 = "self_registration"
 	CoreURL                          = "core_url"
 	CoreLocalURL                     = "core_local_url"
@@ -164,6 +167,7 @@ const (
 	// Quota setting items for project
 	QuotaPerProjectEnable = "quota_per_project_enable"
 	StoragePerProject     = "storage_per_project"
+	CountPerProject       = "count_per_project"
 
 	// DefaultGCTimeWindowHours is the reserve blob time window used by GC, default is 2 hours
 	DefaultGCTimeWindowHours = int64(2)
@@ -173,6 +177,11 @@ const (
 	MetricPort   = "metric_port"
 	MetricPath   = "metric_path"
 
+	// Profiling setting items
+	ProfilingEnabled = "profiling_enabled"
+	ProfilingWindow  = "profiling_window"
+	ProfilingTopN    = "profiling_top_n"
+
 	// Trace setting items
 	TraceEnabled         = "trace_enabled"
 	TraceServiceName     = "trace_service_name"
@@ -214,8 +223,18 @@ const (
 	PurgeAuditIncludeOperations = "include_operations"
 	PurgeAuditDryRun            = "dry_run"
 	PurgeAuditRetentionHour     = "audit_retention_hour"
+	// PurgeUploadOlderThanHours is the age in hours of the stale uploads to be purged
+	PurgeUploadOlderThanHours = "older_than_hours"
+	// PurgeUploadDryRun indicates only to report the stale uploads without deleting them
+	PurgeUploadDryRun = "dry_run"
+	// DefaultPurgeUploadOlderThanHours is the default age of the stale uploads, default is 7 days
+	DefaultPurgeUploadOlderThanHours = 168
 	// AuditLogForwardEndpoint indicate to forward the audit log to an endpoint
 	AuditLogForwardEndpoint = "audit_log_forward_endpoint"
+	// AuditLogForwardFormat is the format of the forwarded audit log, "text", "cef" or "json"
+	AuditLogForwardFormat = "audit_log_forward_format"
+	// AuditLogForwardToken is the bearer token to access the HTTPS audit log collector
+	AuditLogForwardToken = "audit_log_forward_token"
 	// SkipAuditLogDatabase skip to log audit log in database
 	SkipAuditLogDatabase = "skip_audit_log_database"
 	// MaxAuditRetentionHour allowed in audit log purge
@@ -224,6 +243,53 @@ const (
 	// SessionTimeout defines the web session timeout
 	SessionTimeout = "session_timeout"
 
+	// SCIMToken is the bearer token used by the identity provider to access the SCIM endpoint
+	SCIMToken = "scim_token"
+
+	// JobArchiveRetentionDays is the days to keep the archived jobs
+	JobArchiveRetentionDays = "job_archive_retention_days"
+
+	// IfMatchRequired is the flag to indicate whether the "If-Match" header is required when updating the resources
+	IfMatchRequired = "if_match_required"
+
+	// APIRateLimitUser is the max requests per second of the APIs for each user, 0 means no limit
+	APIRateLimitUser = "api_rate_limit_user"
+	// APIRateLimitRobot is the max requests per second of the APIs for each robot account, 0 means no limit
+	APIRateLimitRobot = "api_rate_limit_robot"
+	// APIRateLimitIP is the max requests per second of the APIs for the anonymous requests from each IP, 0 means no limit
+	APIRateLimitIP = "api_rate_limit_ip"
+	// APIRateLimitBurst is the max burst of the requests allowed by the rate limits
+	APIRateLimitBurst = "api_rate_limit_burst"
+
+	// ReplicationMaxConcurrentTransfers is the max in-flight blob transfers of all the replications in each jobservice, 0 means no limit
+	ReplicationMaxConcurrentTransfers = "replication_max_concurrent_transfers"
+
+	// CORSAllowedOrigins is the comma separated origins allowed to call the APIs cross-origin, empty means CORS is disabled
+	CORSAllowedOrigins = "cors_allowed_origins"
+	// CORSAllowedMethods is the comma separated methods allowed in the cross-origin requests
+	CORSAllowedMethods = "cors_allowed_methods"
+	// CORSAllowedHeaders is the comma separated headers allowed in the cross-origin requests
+	CORSAllowedHeaders = "cors_allowed_headers"
+	// CORSAllowCredentials is the flag to indicate whether the cross-origin requests can carry the credentials
+	CORSAllowCredentials = "cors_allow_credentials"
+
+	// ProjectCreationGroups is the semicolon separated names or LDAP DNs of the groups whose members can create projects
+	// when everyone is allowed to create projects, empty means no restriction
+	ProjectCreationGroups = "project_creation_groups"
+
+	// BannerMessage is the announcement banner shown on the UI in JSON format, empty means no banner
+	BannerMessage = "banner_message"
+	// MaintenanceMode is the flag to indicate whether the maintenance mode is turned on, the writes are rejected in it
+	MaintenanceMode = "maintenance_mode"
+	// MaintenanceStartTime is the time in RFC3339 format when the maintenance mode takes effect, empty means immediately
+	MaintenanceStartTime = "maintenance_start_time"
+	// MaintenanceEndTime is the estimated time in RFC3339 format when the maintenance finishes
+	MaintenanceEndTime = "maintenance_end_time"
+
+	// ConfigSyncRegistryID is the ID of the registry endpoint of the primary Harbor instance whose configurations,
+	// labels, project skeletons and policies are synced to the local instance, 0 means the config sync is disabled
+	ConfigSyncRegistryID = "config_sync_registry_id"
+
 	// UIMaxLengthLimitedOfNumber is the max length that UI limited for type number
 	UIMaxLengthLimitedOfNumber = 10
 )
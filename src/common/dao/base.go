@@ -24,6 +24,9 @@ import (
 
 	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/lib/log"
+	libOrm "github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/profiling"
+	tracelib "github.com/goharbor/harbor/src/lib/trace"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	userModels "github.com/goharbor/harbor/src/pkg/user/models"
 )
@@ -69,10 +72,40 @@ func InitDatabase(database *models.Database) error {
 		return err
 	}
 
+	if database.PostGreSQL != nil && len(database.PostGreSQL.ReplicaHost) > 0 {
+		replica, err := getReplicaDatabase(database)
+		if err != nil {
+			return err
+		}
+		log.Infof("Registering read replica database: %s", replica.String())
+		if err := replica.Register(libOrm.ReplicaAlias); err != nil {
+			return err
+		}
+	}
+
+	if tracelib.Enabled() {
+		libOrm.EnableTracing()
+	}
+	if profiling.Enabled() {
+		libOrm.EnableProfiling()
+	}
+
 	log.Info("Register database completed")
 	return nil
 }
 
+// getReplicaDatabase returns the read replica which shares the settings with the primary except the host and port
+func getReplicaDatabase(database *models.Database) (Database, error) {
+	pg := *database.PostGreSQL
+	pg.Host = pg.ReplicaHost
+	if pg.ReplicaPort > 0 {
+		pg.Port = pg.ReplicaPort
+	}
+	replica := *database
+	replica.PostGreSQL = &pg
+	return getDatabase(&replica)
+}
+
 func getDatabase(database *models.Database) (db Database, err error) {
 	switch database.Type {
 	case "", "postgresql":
@@ -87,6 +120,7 @@ func getDatabase(database *models.Database) (db Database, err error) {
 			database.PostGreSQL.MaxOpenConns,
 			database.PostGreSQL.ConnMaxLifetime,
 			database.PostGreSQL.ConnMaxIdleTime,
+			database.PostGreSQL.StatementTimeout,
 		)
 	default:
 		err = fmt.Errorf("invalid database: %s", database.Type)
@@ -46,6 +46,8 @@ type pgsql struct {
 	maxOpenConns    int
 	connMaxLifetime time.Duration
 	connMaxIdleTime time.Duration
+	// statementTimeout is passed to the server as the "statement_timeout" run-time parameter
+	statementTimeout time.Duration
 }
 
 // Name returns the name of PostgreSQL
@@ -60,21 +62,22 @@ func (p *pgsql) String() string {
 }
 
 // NewPGSQL returns an instance of postgres
-func NewPGSQL(host string, port string, usr string, pwd string, database string, sslmode string, maxIdleConns int, maxOpenConns int, connMaxLifetime time.Duration, connMaxIdleTime time.Duration) Database {
+func NewPGSQL(host string, port string, usr string, pwd string, database string, sslmode string, maxIdleConns int, maxOpenConns int, connMaxLifetime time.Duration, connMaxIdleTime time.Duration, statementTimeout time.Duration) Database {
 	if len(sslmode) == 0 {
 		sslmode = "disable"
 	}
 	return &pgsql{
-		host:            host,
-		port:            port,
-		usr:             usr,
-		pwd:             pwd,
-		database:        database,
-		sslmode:         sslmode,
-		maxIdleConns:    maxIdleConns,
-		maxOpenConns:    maxOpenConns,
-		connMaxLifetime: connMaxLifetime,
-		connMaxIdleTime: connMaxIdleTime,
+		host:             host,
+		port:             port,
+		usr:              usr,
+		pwd:              pwd,
+		database:         database,
+		sslmode:          sslmode,
+		maxIdleConns:     maxIdleConns,
+		maxOpenConns:     maxOpenConns,
+		connMaxLifetime:  connMaxLifetime,
+		connMaxIdleTime:  connMaxIdleTime,
+		statementTimeout: statementTimeout,
 	}
 }
 
@@ -94,6 +97,9 @@ func (p *pgsql) Register(alias ...string) error {
 	}
 	info := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s timezone=UTC",
 		p.host, p.port, p.usr, p.pwd, p.database, p.sslmode)
+	if p.statementTimeout > 0 {
+		info = fmt.Sprintf("%s statement_timeout=%d", info, p.statementTimeout.Milliseconds())
+	}
 
 	if err := orm.RegisterDataBase(an, "pgx", info, orm.MaxIdleConnections(p.maxIdleConns),
 		orm.MaxOpenConnections(p.maxOpenConns), orm.ConnMaxLifetime(p.connMaxLifetime)); err != nil {
@@ -143,6 +149,16 @@ func (p *pgsql) UpgradeSchema() error {
 	return nil
 }
 
+// MigrationPath returns the path of the schema migration scripts
+func MigrationPath() string {
+	// For UT
+	path := os.Getenv("POSTGRES_MIGRATION_SCRIPTS_PATH")
+	if len(path) == 0 {
+		path = defaultMigrationPath
+	}
+	return path
+}
+
 // NewMigrator creates a migrator base on the information
 func NewMigrator(database *models.PostGreSQL) (*migrate.Migrate, error) {
 	dbURL := url.URL{
@@ -153,12 +169,7 @@ func NewMigrator(database *models.PostGreSQL) (*migrate.Migrate, error) {
 		RawQuery: fmt.Sprintf("sslmode=%s", database.SSLMode),
 	}
 
-	// For UT
-	path := os.Getenv("POSTGRES_MIGRATION_SCRIPTS_PATH")
-	if len(path) == 0 {
-		path = defaultMigrationPath
-	}
-	srcURL := fmt.Sprintf("file://%s", path)
+	srcURL := fmt.Sprintf("file://%s", MigrationPath())
 	m, err := migrate.New(srcURL, dbURL.String())
 	if err != nil {
 		return nil, err
@@ -16,10 +16,15 @@ package dao
 
 import (
 	"fmt"
+	"os"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/beego/beego/v2/client/orm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMaxOpenConns(t *testing.T) {
@@ -50,3 +55,18 @@ func TestMaxOpenConns(t *testing.T) {
 		}
 	}
 }
+
+func TestStatementTimeout(t *testing.T) {
+	db := NewPGSQL(os.Getenv("POSTGRESQL_HOST"), os.Getenv("POSTGRESQL_PORT"), os.Getenv("POSTGRESQL_USR"),
+		os.Getenv("POSTGRESQL_PWD"), os.Getenv("POSTGRESQL_DATABASE"), "", 1, 1, 0, 0, time.Second)
+	require.Nil(t, db.Register("statement-timeout"))
+
+	o := orm.NewOrmUsingDB("statement-timeout")
+	// the statement running longer than the timeout is canceled by the database
+	_, err := o.Raw("SELECT pg_sleep(2)").Exec()
+	require.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "statement timeout"))
+
+	_, err = o.Raw("SELECT pg_sleep(0.1)").Exec()
+	assert.Nil(t, err)
+}
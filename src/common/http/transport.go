@@ -87,6 +87,13 @@ func WithInsecureSkipVerify(skipVerify bool) func(*http.Transport) {
 	}
 }
 
+// WithTLSClientConfig returns a TransportOption that configures the transport to use the specified TLS configuration
+func WithTLSClientConfig(cfg *tls.Config) func(*http.Transport) {
+	return func(tr *http.Transport) {
+		tr.TLSClientConfig = cfg
+	}
+}
+
 // WithMaxIdleConnsPerHost returns a TransportOption that configures the transport to use the specified number of idle connections per host
 func WithMaxIdleConns(maxIdleConns int) func(*http.Transport) {
 	return func(tr *http.Transport) {
@@ -32,6 +32,8 @@ var (
 type Client interface {
 	SubmitJob(*models.JobData) (string, error)
 	GetJobLog(uuid string) ([]byte, error)
+	// GetJobLogFrom returns the log of the job after the offset (bytes)
+	GetJobLogFrom(uuid string, offset int64) ([]byte, error)
 	PostAction(uuid, action string) error
 	GetExecutions(uuid string) ([]job.Stats, error)
 	// TODO Redirect joblog when we see there's memory issue.
@@ -139,7 +141,15 @@ func (d *DefaultClient) SubmitJob(jd *models.JobData) (string, error) {
 
 // GetJobLog call jobserivce API to get the log of a job.  It only accepts the UUID of the job
 func (d *DefaultClient) GetJobLog(uuid string) ([]byte, error) {
+	return d.GetJobLogFrom(uuid, 0)
+}
+
+// GetJobLogFrom call jobservice API to get the log of a job after the offset (bytes)
+func (d *DefaultClient) GetJobLogFrom(uuid string, offset int64) ([]byte, error) {
 	url := d.endpoint + "/api/v1/jobs/" + uuid + "/log"
+	if offset > 0 {
+		url = fmt.Sprintf("%s?offset=%d", url, offset)
+	}
 	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
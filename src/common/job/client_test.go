@@ -3,6 +3,7 @@ package job
 import (
 	"errors"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -50,6 +51,13 @@ func TestGetJobLog(t *testing.T) {
 	assert.Contains(text, "The content in this file is for mocking the get log api.")
 }
 
+func TestGetJobLogFrom(t *testing.T) {
+	assert := assert.New(t)
+	b, err := testClient.GetJobLogFrom(ID, 4)
+	assert.Nil(err)
+	assert.True(strings.HasPrefix(string(b), "content in this file"))
+}
+
 func TestGetExecutions(t *testing.T) {
 	assert := assert.New(t)
 	exes, err := testClient.GetExecutions(ID)
@@ -24,6 +24,12 @@ type JobMetadata struct {
 	ScheduleDelay uint64 `json:"schedule_delay,omitempty"`
 	Cron          string `json:"cron_spec,omitempty"`
 	IsUnique      bool   `json:"unique"`
+	PriorityClass string `json:"priority_class,omitempty"`
+	FairShareKey  string `json:"fair_share_key,omitempty"`
+	// The trace context of the request launching the job
+	TraceContext map[string]string `json:"trace_context,omitempty"`
+	// The ID of the request launching the job
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // JobStats keeps the result of job launching.
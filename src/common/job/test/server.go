@@ -9,6 +9,7 @@ import (
 	"os"
 	"path"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -43,6 +44,9 @@ func NewJobServiceServer() *httptest.Server {
 			rw.WriteHeader(http.StatusOK)
 			f := path.Join(currPath(), "test.log")
 			b, _ := os.ReadFile(f)
+			if offset, _ := strconv.Atoi(req.URL.Query().Get("offset")); offset > 0 && offset < len(b) {
+				b = b[offset:]
+			}
 			_, err := rw.Write(b)
 			if err != nil {
 				panic(err)
@@ -39,4 +39,11 @@ type PostGreSQL struct {
 	MaxOpenConns    int           `json:"max_open_conns"`
 	ConnMaxLifetime time.Duration `json:"conn_max_lifetime"`
 	ConnMaxIdleTime time.Duration `json:"conn_max_idle_time"`
+	// StatementTimeout aborts any statement that takes more than the specified duration, 0 disables the timeout
+	StatementTimeout time.Duration `json:"statement_timeout"`
+	// ReplicaHost is the host of the read replica which serves the queries tolerating the replication lag,
+	// the replica shares the credentials and the database name with the primary
+	ReplicaHost string `json:"replica_host"`
+	// ReplicaPort is the port of the read replica, the port of the primary is used if it's 0
+	ReplicaPort int `json:"replica_port"`
 }
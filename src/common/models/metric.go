@@ -14,9 +14,18 @@
 
 package models
 
+import "time"
+
 // Metric wraps the configurations to access UAA service
 type Metric struct {
 	Enabled bool
 	Port    int
 	Path    string
 }
+
+// Profiling wraps the configurations of the collection of the slowest API routes and database queries
+type Profiling struct {
+	Enabled bool
+	Window  time.Duration
+	TopN    int
+}
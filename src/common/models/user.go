@@ -31,6 +31,10 @@ type User struct {
 	Rolename        string `json:"role_name"`
 	Role            int    `json:"role_id"`
 	SysAdminFlag    bool   `json:"sysadmin_flag"`
+	// AuditorFlag indicates the user is a read-only system administrator
+	AuditorFlag bool `json:"auditor_flag"`
+	// Deactivated indicates the user can't login anymore while the records of the user are kept
+	Deactivated bool `json:"deactivated"`
 	// AdminRoleInAuth to store the admin privilege granted by external authentication provider
 	AdminRoleInAuth bool      `json:"admin_role_in_auth"`
 	ResetUUID       string    `json:"reset_uuid"`
@@ -50,6 +50,7 @@ const (
 	ResourceTagRetention          = Resource("tag-retention")
 	ResourceImmutableTag          = Resource("immutable-tag")
 	ResourceRobot                 = Resource("robot")
+	ResourcePullToken             = Resource("pull-token")
 	ResourceNotificationPolicy    = Resource("notification-policy")
 	ResourceScan                  = Resource("scan")
 	ResourceScanner               = Resource("scanner")
@@ -58,6 +59,9 @@ const (
 	ResourceAccessory             = Resource("accessory")
 	ResourceArtifactAddition      = Resource("artifact-addition")
 	ResourceArtifactLabel         = Resource("artifact-label")
+	ResourceArtifactPin           = Resource("artifact-pin")
+	ResourcePromotion             = Resource("promotion")
+	ResourceArtifactQuarantine    = Resource("artifact-quarantine")
 	ResourcePreatPolicy           = Resource("preheat-policy")
 	ResourcePreatInstance         = Resource("preheat-instance")
 	ResourceSelf                  = Resource("") // subresource for self
@@ -68,6 +72,7 @@ const (
 	ResourceUser               = Resource("user")
 	ResourceUserGroup          = Resource("user-group")
 	ResourceRegistry           = Resource("registry")
+	ResourceRole               = Resource("role")
 	ResourceReplication        = Resource("replication")
 	ResourceDistribution       = Resource("distribution")
 	ResourceGarbageCollection  = Resource("garbage-collection")
@@ -78,4 +83,12 @@ const (
 	ResourcePurgeAuditLog      = Resource("purge-audit")
 	ResourceExportCVE          = Resource("export-cve")
 	ResourceJobServiceMonitor  = Resource("jobservice-monitor")
+	ResourceSystemMigration    = Resource("system-migration")
+	ResourceSystemBackup       = Resource("system-backup")
+	ResourceConfigSync         = Resource("config-sync")
+	ResourceSystemHealth       = Resource("system-health")
+	ResourceSystemLogLevel     = Resource("system-log-level")
+	ResourceSystemProfiling    = Resource("system-profiling")
+	ResourceSystemStorage      = Resource("system-storage")
+	ResourceSystemSecret       = Resource("system-secret")
 )
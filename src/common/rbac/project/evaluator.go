@@ -47,10 +47,29 @@ func NewBuilderForUser(user *models.User, ctl project.Controller) RBACUserBuilde
 			return nil
 		}
 
+		var (
+			builtinRoles []int
+			customRoles  []*customRBACRole
+		)
+		for _, roleID := range roles {
+			if IsBuiltinRole(roleID) {
+				builtinRoles = append(builtinRoles, roleID)
+				continue
+			}
+
+			customRole, err := newCustomRBACRole(ctx, p.ProjectID, roleID)
+			if err != nil {
+				log.Errorf("failed to load the custom role %d: %v", roleID, err)
+				continue
+			}
+			customRoles = append(customRoles, customRole)
+		}
+
 		return &rbacUser{
 			project:      p,
 			username:     user.Username,
-			projectRoles: roles,
+			projectRoles: builtinRoles,
+			customRoles:  customRoles,
 		}
 	}
 }
@@ -15,9 +15,14 @@
 package project
 
 import (
+	"context"
+
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/pkg/permission/types"
+	pkgrbac "github.com/goharbor/harbor/src/pkg/rbac"
+	"github.com/goharbor/harbor/src/pkg/role"
+	roleModel "github.com/goharbor/harbor/src/pkg/role/model"
 )
 
 var (
@@ -91,6 +96,10 @@ var (
 			{Resource: rbac.ResourceRobot, Action: rbac.ActionDelete},
 			{Resource: rbac.ResourceRobot, Action: rbac.ActionList},
 
+			{Resource: rbac.ResourcePullToken, Action: rbac.ActionCreate},
+			{Resource: rbac.ResourcePullToken, Action: rbac.ActionDelete},
+			{Resource: rbac.ResourcePullToken, Action: rbac.ActionList},
+
 			{Resource: rbac.ResourceNotificationPolicy, Action: rbac.ActionCreate},
 			{Resource: rbac.ResourceNotificationPolicy, Action: rbac.ActionUpdate},
 			{Resource: rbac.ResourceNotificationPolicy, Action: rbac.ActionDelete},
@@ -110,6 +119,17 @@ var (
 			{Resource: rbac.ResourceArtifact, Action: rbac.ActionList},
 			{Resource: rbac.ResourceArtifactAddition, Action: rbac.ActionRead},
 
+			{Resource: rbac.ResourceArtifactPin, Action: rbac.ActionCreate},
+			{Resource: rbac.ResourceArtifactPin, Action: rbac.ActionDelete},
+			{Resource: rbac.ResourceArtifactPin, Action: rbac.ActionList},
+
+			{Resource: rbac.ResourcePromotion, Action: rbac.ActionCreate},
+			{Resource: rbac.ResourcePromotion, Action: rbac.ActionRead},
+			{Resource: rbac.ResourcePromotion, Action: rbac.ActionList},
+
+			{Resource: rbac.ResourceArtifactQuarantine, Action: rbac.ActionRead},
+			{Resource: rbac.ResourceArtifactQuarantine, Action: rbac.ActionDelete},
+
 			{Resource: rbac.ResourceTag, Action: rbac.ActionList},
 			{Resource: rbac.ResourceTag, Action: rbac.ActionCreate},
 			{Resource: rbac.ResourceTag, Action: rbac.ActionDelete},
@@ -192,6 +212,10 @@ var (
 			{Resource: rbac.ResourceRobot, Action: rbac.ActionRead},
 			{Resource: rbac.ResourceRobot, Action: rbac.ActionList},
 
+			{Resource: rbac.ResourcePullToken, Action: rbac.ActionCreate},
+			{Resource: rbac.ResourcePullToken, Action: rbac.ActionDelete},
+			{Resource: rbac.ResourcePullToken, Action: rbac.ActionList},
+
 			{Resource: rbac.ResourceNotificationPolicy, Action: rbac.ActionList},
 
 			{Resource: rbac.ResourceScan, Action: rbac.ActionCreate},
@@ -206,6 +230,16 @@ var (
 			{Resource: rbac.ResourceArtifact, Action: rbac.ActionList},
 			{Resource: rbac.ResourceArtifactAddition, Action: rbac.ActionRead},
 
+			{Resource: rbac.ResourceArtifactPin, Action: rbac.ActionCreate},
+			{Resource: rbac.ResourceArtifactPin, Action: rbac.ActionDelete},
+			{Resource: rbac.ResourceArtifactPin, Action: rbac.ActionList},
+
+			{Resource: rbac.ResourcePromotion, Action: rbac.ActionCreate},
+			{Resource: rbac.ResourcePromotion, Action: rbac.ActionRead},
+			{Resource: rbac.ResourcePromotion, Action: rbac.ActionList},
+
+			{Resource: rbac.ResourceArtifactQuarantine, Action: rbac.ActionRead},
+
 			{Resource: rbac.ResourceTag, Action: rbac.ActionList},
 			{Resource: rbac.ResourceTag, Action: rbac.ActionCreate},
 			{Resource: rbac.ResourceTag, Action: rbac.ActionDelete},
@@ -271,6 +305,14 @@ var (
 			{Resource: rbac.ResourceArtifact, Action: rbac.ActionList},
 			{Resource: rbac.ResourceArtifactAddition, Action: rbac.ActionRead},
 
+			{Resource: rbac.ResourceArtifactPin, Action: rbac.ActionList},
+
+			{Resource: rbac.ResourcePromotion, Action: rbac.ActionCreate},
+			{Resource: rbac.ResourcePromotion, Action: rbac.ActionRead},
+			{Resource: rbac.ResourcePromotion, Action: rbac.ActionList},
+
+			{Resource: rbac.ResourceArtifactQuarantine, Action: rbac.ActionRead},
+
 			{Resource: rbac.ResourceTag, Action: rbac.ActionList},
 			{Resource: rbac.ResourceTag, Action: rbac.ActionCreate},
 
@@ -322,6 +364,13 @@ var (
 			{Resource: rbac.ResourceArtifact, Action: rbac.ActionRead},
 			{Resource: rbac.ResourceArtifact, Action: rbac.ActionList},
 			{Resource: rbac.ResourceArtifactAddition, Action: rbac.ActionRead},
+
+			{Resource: rbac.ResourceArtifactPin, Action: rbac.ActionList},
+
+			{Resource: rbac.ResourcePromotion, Action: rbac.ActionRead},
+			{Resource: rbac.ResourcePromotion, Action: rbac.ActionList},
+
+			{Resource: rbac.ResourceArtifactQuarantine, Action: rbac.ActionRead},
 		},
 
 		"limitedGuest": {
@@ -398,3 +447,75 @@ func (role *projectRBACRole) GetPolicies() []*types.Policy {
 
 	return policies
 }
+
+// IsBuiltinRole returns true if the role is one of the roles shipped with Harbor
+func IsBuiltinRole(roleID int) bool {
+	switch roleID {
+	case common.RoleProjectAdmin,
+		common.RoleMaintainer,
+		common.RoleDeveloper,
+		common.RoleGuest,
+		common.RoleLimitedGuest:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetPoliciesOfBuiltinRole returns the policies of the built-in role without the project namespace
+func GetPoliciesOfBuiltinRole(roleID int) []*types.Policy {
+	role := &projectRBACRole{roleID: roleID}
+	return rolePoliciesMap[role.GetRoleName()]
+}
+
+// customRBACRole implement the RBACRole interface for the roles defined by the users
+type customRBACRole struct {
+	projectID int64
+	name      string
+	policies  []*types.Policy
+}
+
+// GetRoleName returns role name for the custom role
+func (role *customRBACRole) GetRoleName() string {
+	return role.name
+}
+
+// GetPolicies returns policies for the custom role
+func (role *customRBACRole) GetPolicies() []*types.Policy {
+	policies := []*types.Policy{}
+
+	namespace := NewNamespace(role.projectID)
+	for _, policy := range role.policies {
+		policies = append(policies, &types.Policy{
+			Resource: namespace.Resource(policy.Resource),
+			Action:   policy.Action,
+			Effect:   policy.Effect,
+		})
+	}
+
+	return policies
+}
+
+// newCustomRBACRole loads the definition of the custom role from the database
+func newCustomRBACRole(ctx context.Context, projectID int64, roleID int) (*customRBACRole, error) {
+	r, err := role.Mgr.Get(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := pkgrbac.Mgr.GetPermissionsByRole(ctx, roleModel.RoleType, int64(roleID))
+	if err != nil {
+		return nil, err
+	}
+
+	customRole := &customRBACRole{projectID: projectID, name: r.Name}
+	for _, p := range permissions {
+		customRole.policies = append(customRole.policies, &types.Policy{
+			Resource: types.Resource(p.Resource),
+			Action:   types.Action(p.Action),
+			Effect:   types.Effect(p.Effect),
+		})
+	}
+
+	return customRole, nil
+}
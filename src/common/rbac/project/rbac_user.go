@@ -23,6 +23,7 @@ type rbacUser struct {
 	project      *models.Project
 	username     string
 	projectRoles []int
+	customRoles  []*customRBACRole
 	policies     []*types.Policy
 }
 
@@ -48,6 +49,9 @@ func (pru *rbacUser) GetRoles() []types.RBACRole {
 	for _, roleID := range pru.projectRoles {
 		roles = append(roles, &projectRBACRole{projectID: pru.project.ProjectID, roleID: roleID})
 	}
+	for _, customRole := range pru.customRoles {
+		roles = append(roles, customRole)
+	}
 
 	return roles
 }
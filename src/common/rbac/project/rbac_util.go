@@ -82,6 +82,17 @@ func GetPoliciesOfProject(projectID int64) []*types.Policy {
 	return policies
 }
 
+// IsPolicyOfProject returns true when the resource and action of the policy is one of the sub policies of the project
+func IsPolicyOfProject(policy *types.Policy) bool {
+	for _, p := range subPoliciesForProject {
+		if p.Resource == policy.Resource && p.Action == policy.Action {
+			return true
+		}
+	}
+
+	return false
+}
+
 func computeSubPoliciesForProject() []*types.Policy {
 	var results []*types.Policy
 
@@ -29,6 +29,12 @@ var (
 		{Resource: rbac.ResourceUserGroup, Action: rbac.ActionDelete},
 		{Resource: rbac.ResourceUserGroup, Action: rbac.ActionList},
 
+		{Resource: rbac.ResourceRole, Action: rbac.ActionCreate},
+		{Resource: rbac.ResourceRole, Action: rbac.ActionRead},
+		{Resource: rbac.ResourceRole, Action: rbac.ActionUpdate},
+		{Resource: rbac.ResourceRole, Action: rbac.ActionDelete},
+		{Resource: rbac.ResourceRole, Action: rbac.ActionList},
+
 		{Resource: rbac.ResourceRegistry, Action: rbac.ActionCreate},
 		{Resource: rbac.ResourceRegistry, Action: rbac.ActionRead},
 		{Resource: rbac.ResourceRegistry, Action: rbac.ActionUpdate},
@@ -62,6 +68,31 @@ var (
 
 		{Resource: rbac.ResourceSystemVolumes, Action: rbac.ActionRead},
 
+		{Resource: rbac.ResourceSystemMigration, Action: rbac.ActionRead},
+
+		{Resource: rbac.ResourceSystemBackup, Action: rbac.ActionCreate},
+		{Resource: rbac.ResourceSystemBackup, Action: rbac.ActionRead},
+		{Resource: rbac.ResourceSystemBackup, Action: rbac.ActionUpdate},
+		{Resource: rbac.ResourceSystemBackup, Action: rbac.ActionList},
+
+		{Resource: rbac.ResourceConfigSync, Action: rbac.ActionCreate},
+		{Resource: rbac.ResourceConfigSync, Action: rbac.ActionRead},
+		{Resource: rbac.ResourceConfigSync, Action: rbac.ActionUpdate},
+		{Resource: rbac.ResourceConfigSync, Action: rbac.ActionList},
+
+		{Resource: rbac.ResourceSystemHealth, Action: rbac.ActionRead},
+
+		{Resource: rbac.ResourceSystemLogLevel, Action: rbac.ActionRead},
+		{Resource: rbac.ResourceSystemLogLevel, Action: rbac.ActionUpdate},
+
+		{Resource: rbac.ResourceSystemProfiling, Action: rbac.ActionRead},
+
+		{Resource: rbac.ResourceSystemStorage, Action: rbac.ActionRead},
+		{Resource: rbac.ResourceSystemStorage, Action: rbac.ActionUpdate},
+
+		{Resource: rbac.ResourceSystemSecret, Action: rbac.ActionList},
+		{Resource: rbac.ResourceSystemSecret, Action: rbac.ActionUpdate},
+
 		{Resource: rbac.ResourceLdapUser, Action: rbac.ActionCreate},
 		{Resource: rbac.ResourceLdapUser, Action: rbac.ActionList},
 		{Resource: rbac.ResourceConfiguration, Action: rbac.ActionRead},
@@ -70,5 +101,6 @@ var (
 		{Resource: rbac.ResourceJobServiceMonitor, Action: rbac.ActionRead},
 		{Resource: rbac.ResourceJobServiceMonitor, Action: rbac.ActionList},
 		{Resource: rbac.ResourceJobServiceMonitor, Action: rbac.ActionStop},
+		{Resource: rbac.ResourceJobServiceMonitor, Action: rbac.ActionUpdate},
 	}
 )
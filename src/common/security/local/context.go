@@ -23,6 +23,7 @@ import (
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/pkg/permission/evaluator"
 	"github.com/goharbor/harbor/src/pkg/permission/evaluator/admin"
+	"github.com/goharbor/harbor/src/pkg/permission/evaluator/auditor"
 	"github.com/goharbor/harbor/src/pkg/permission/types"
 )
 
@@ -78,6 +79,14 @@ func (s *SecurityContext) IsSysAdmin() bool {
 	return s.user.SysAdminFlag || s.user.AdminRoleInAuth
 }
 
+// IsAuditor returns whether the authenticated user is the read-only system administrator
+func (s *SecurityContext) IsAuditor() bool {
+	if !s.IsAuthenticated() {
+		return false
+	}
+	return s.user.AuditorFlag
+}
+
 // IsSolutionUser ...
 func (s *SecurityContext) IsSolutionUser() bool {
 	return false
@@ -89,6 +98,8 @@ func (s *SecurityContext) Can(ctx context.Context, action types.Action, resource
 		var evaluators evaluator.Evaluators
 		if s.IsSysAdmin() {
 			evaluators = evaluators.Add(admin.New(s.GetUsername()))
+		} else if s.IsAuditor() {
+			evaluators = evaluators.Add(auditor.New(s.GetUsername()))
 		}
 
 		evaluators = evaluators.Add(rbac_project.NewEvaluator(s.ctl, rbac_project.NewBuilderForUser(s.user, s.ctl)))
@@ -273,3 +273,22 @@ func TestSysadminPerms(t *testing.T) {
 	assert.False(t, ctx.Can(context.TODO(), rbac.ActionScannerPull, resource))
 
 }
+
+func TestAuditorPerms(t *testing.T) {
+	ctl := &projecttesting.Controller{}
+	mock.OnAnything(ctl, "Get").Return(private, nil)
+	mock.OnAnything(ctl, "ListRoles").Return([]int{}, nil)
+
+	ctx := NewSecurityContext(&models.User{
+		Username:    "auditor",
+		AuditorFlag: true,
+	})
+	ctx.ctl = ctl
+	assert.False(t, ctx.IsSysAdmin())
+	assert.True(t, ctx.IsAuditor())
+	resource := rbac_project.NewNamespace(private.ProjectID).Resource(rbac.ResourceRepository)
+	assert.True(t, ctx.Can(context.TODO(), rbac.ActionList, resource))
+	assert.True(t, ctx.Can(context.TODO(), rbac.ActionRead, resource))
+	assert.False(t, ctx.Can(context.TODO(), rbac.ActionPush, resource))
+	assert.False(t, ctx.Can(context.TODO(), rbac.ActionDelete, resource))
+}
@@ -0,0 +1,94 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulltoken
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	rbac_project "github.com/goharbor/harbor/src/common/rbac/project"
+	ctl "github.com/goharbor/harbor/src/controller/pulltoken"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	"github.com/goharbor/harbor/src/pkg/pulltoken/model"
+)
+
+// ContextName the name of the security context.
+const ContextName = "pulltoken"
+
+// SecurityContext implements security.Context interface based on the pull token,
+// which can only pull the specified repositories of the project
+type SecurityContext struct {
+	token *model.PullToken
+}
+
+// NewSecurityContext ...
+func NewSecurityContext(t *model.PullToken) *SecurityContext {
+	return &SecurityContext{
+		token: t,
+	}
+}
+
+// Name returns the name of the security context
+func (s *SecurityContext) Name() string {
+	return ContextName
+}
+
+// IsAuthenticated returns true if the pull token has been authenticated
+func (s *SecurityContext) IsAuthenticated() bool {
+	return s.token != nil
+}
+
+// GetUsername returns the name of the pull token
+func (s *SecurityContext) GetUsername() string {
+	if !s.IsAuthenticated() {
+		return ""
+	}
+	return ctl.Name(s.token.ID)
+}
+
+// IsSysAdmin pull token cannot be a system admin
+func (s *SecurityContext) IsSysAdmin() bool {
+	return false
+}
+
+// IsSolutionUser pull token cannot be a solution user
+func (s *SecurityContext) IsSolutionUser() bool {
+	return false
+}
+
+// Can returns true only when pulling the repositories specified by the pull token
+func (s *SecurityContext) Can(ctx context.Context, action types.Action, resource types.Resource) bool {
+	if !s.IsAuthenticated() || action != rbac.ActionPull {
+		return false
+	}
+	if resource != rbac_project.NewNamespace(s.token.ProjectID).Resource(rbac.ResourceRepository) {
+		return false
+	}
+	// the repository being accessed is populated in the artifact info by the registry API and the token service
+	art := lib.GetArtifactInfo(ctx)
+	if len(art.Repository) == 0 {
+		return false
+	}
+	repository := strings.TrimPrefix(art.Repository, fmt.Sprintf("%s/", art.ProjectName))
+	for _, repo := range s.token.RepositoryList() {
+		if repo == repository {
+			return true
+		}
+	}
+	return false
+}
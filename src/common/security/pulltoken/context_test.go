@@ -0,0 +1,64 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulltoken
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/rbac/project"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/pkg/pulltoken/model"
+)
+
+func TestIsAuthenticated(t *testing.T) {
+	ctx := NewSecurityContext(nil)
+	assert.False(t, ctx.IsAuthenticated())
+	assert.Empty(t, ctx.GetUsername())
+
+	ctx = NewSecurityContext(&model.PullToken{ID: 1})
+	assert.True(t, ctx.IsAuthenticated())
+	assert.Equal(t, "pulltoken$1", ctx.GetUsername())
+	assert.False(t, ctx.IsSysAdmin())
+	assert.False(t, ctx.IsSolutionUser())
+}
+
+func TestCan(t *testing.T) {
+	secCtx := NewSecurityContext(&model.PullToken{
+		ID:           1,
+		ProjectID:    1,
+		Repositories: "nginx,library/busybox",
+		ExpiresAt:    time.Now().Add(time.Minute),
+	})
+	resource := project.NewNamespace(1).Resource(rbac.ResourceRepository)
+
+	// no artifact info
+	assert.False(t, secCtx.Can(context.Background(), rbac.ActionPull, resource))
+
+	ctx := lib.WithArtifactInfo(context.Background(), lib.ArtifactInfo{ProjectName: "library", Repository: "library/nginx"})
+	assert.True(t, secCtx.Can(ctx, rbac.ActionPull, resource))
+	assert.False(t, secCtx.Can(ctx, rbac.ActionPush, resource))
+	assert.False(t, secCtx.Can(ctx, rbac.ActionPull, project.NewNamespace(2).Resource(rbac.ResourceRepository)))
+
+	ctx = lib.WithArtifactInfo(context.Background(), lib.ArtifactInfo{ProjectName: "library", Repository: "library/library/busybox"})
+	assert.True(t, secCtx.Can(ctx, rbac.ActionPull, resource))
+
+	ctx = lib.WithArtifactInfo(context.Background(), lib.ArtifactInfo{ProjectName: "library", Repository: "library/redis"})
+	assert.False(t, secCtx.Can(ctx, rbac.ActionPull, resource))
+}
@@ -34,6 +34,26 @@ import (
 	"github.com/goharbor/harbor/src/pkg/registry"
 )
 
+const (
+	// the media types of the empty config used by the OCI artifacts which specify the "artifactType"
+	mediaTypeEmptyConfig   = "application/vnd.oci.empty.v1+json"
+	mediaTypeScratchConfig = "application/vnd.oci.scratch.v1+json"
+	// the annotation used by CNAB to specify the artifact type of the index
+	annotationArtifactType = "org.opencontainers.artifactType"
+)
+
+// ociManifest is the OCI manifest with the "artifactType" which is introduced by the image spec v1.1
+type ociManifest struct {
+	v1.Manifest
+	ArtifactType string `json:"artifactType,omitempty"`
+}
+
+// ociIndex is the OCI index with the "artifactType" which is introduced by the image spec v1.1
+type ociIndex struct {
+	v1.Index
+	ArtifactType string `json:"artifactType,omitempty"`
+}
+
 // Abstractor abstracts the metadata of artifact
 type Abstractor interface {
 	// AbstractMetadata abstracts the metadata for the specific artifact type into the artifact model,
@@ -93,6 +113,7 @@ func (a *abstractor) abstractManifestV1Metadata(ctx context.Context, artifact *a
 	// as no config layer in the docker v1 manifest, use the "schema1.MediaTypeSignedManifest"
 	// as the media type of artifact
 	artifact.MediaType = schema1.MediaTypeSignedManifest
+	artifact.ArtifactType = schema1.MediaTypeSignedManifest
 
 	manifest := &schema1.Manifest{}
 	if err := json.Unmarshal(content, manifest); err != nil {
@@ -121,12 +142,21 @@ func (a *abstractor) abstractManifestV1Metadata(ctx context.Context, artifact *a
 
 // the artifact is enveloped by OCI manifest or docker manifest v2
 func (a *abstractor) abstractManifestV2Metadata(artifact *artifact.Artifact, content []byte) error {
-	manifest := &v1.Manifest{}
+	manifest := &ociManifest{}
 	if err := json.Unmarshal(content, manifest); err != nil {
 		return err
 	}
 	// use the "manifest.config.mediatype" as the media type of the artifact
 	artifact.MediaType = manifest.Config.MediaType
+	// use the "manifest.artifactType" as the artifact type and fall back to the config media type
+	artifact.ArtifactType = manifest.Config.MediaType
+	if len(manifest.ArtifactType) > 0 {
+		artifact.ArtifactType = manifest.ArtifactType
+		// the config carries nothing, use the artifact type to choose the processor
+		if manifest.Config.MediaType == mediaTypeEmptyConfig || manifest.Config.MediaType == mediaTypeScratchConfig {
+			artifact.MediaType = manifest.ArtifactType
+		}
+	}
 
 	if manifest.Annotations[wasm.AnnotationVariantKey] == wasm.AnnotationVariantValue || manifest.Annotations[wasm.AnnotationHandlerKey] == wasm.AnnotationHandlerValue {
 		artifact.MediaType = wasm.MediaType
@@ -147,8 +177,9 @@ func (a *abstractor) abstractIndexMetadata(ctx context.Context, art *artifact.Ar
 	// the identity of index is still in progress, we use the manifest mediaType
 	// as the media type of artifact
 	art.MediaType = art.ManifestMediaType
+	art.ArtifactType = art.ManifestMediaType
 
-	index := &v1.Index{}
+	index := &ociIndex{}
 	if err := json.Unmarshal(content, index); err != nil {
 		return err
 	}
@@ -178,11 +209,15 @@ func (a *abstractor) abstractIndexMetadata(ctx context.Context, art *artifact.Ar
 	// Currently, CNAB put its media type inside the annotations
 	// try to parse the artifact media type from the annotations
 	if art.Annotations != nil {
-		mediaType := art.Annotations["org.opencontainers.artifactType"]
+		mediaType := art.Annotations[annotationArtifactType]
 		if len(mediaType) > 0 {
 			art.MediaType = mediaType
+			art.ArtifactType = mediaType
 		}
 	}
+	if len(index.ArtifactType) > 0 {
+		art.ArtifactType = index.ArtifactType
+	}
 
 	return nil
 }
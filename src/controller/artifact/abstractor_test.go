@@ -176,6 +176,24 @@ var (
   }
 }`
 
+	ociArtifactManifest = `{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "artifactType": "application/vnd.example.sbom.v1",
+  "config": {
+    "mediaType": "application/vnd.oci.empty.v1+json",
+    "size": 2,
+    "digest": "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"
+  },
+  "layers": [
+    {
+      "mediaType": "application/spdx+json",
+      "size": 1024,
+      "digest": "sha256:1b930d010525941c1d56ec53b97bd057a67ae1865eebf042686d2a2d18271ced"
+    }
+  ]
+}`
+
 	index = `{
   "schemaVersion": 2,
   "manifests": [
@@ -262,6 +280,7 @@ func (a *abstractorTestSuite) TestAbstractMetadataOfV2Manifest() {
 	a.Assert().Equal(int64(1), artifact.ID)
 	a.Assert().Equal(schema2.MediaTypeManifest, artifact.ManifestMediaType)
 	a.Assert().Equal(schema2.MediaTypeImageConfig, artifact.MediaType)
+	a.Assert().Equal(schema2.MediaTypeImageConfig, artifact.ArtifactType)
 	a.Assert().Equal(int64(3043), artifact.Size)
 	a.Require().Len(artifact.Annotations, 1)
 	a.Equal("value1", artifact.Annotations["com.example.key1"])
@@ -284,12 +303,33 @@ func (a *abstractorTestSuite) TestAbstractMetadataOfIndex() {
 	a.Assert().Equal(int64(1), artifact.ID)
 	a.Assert().Equal(v1.MediaTypeImageIndex, artifact.ManifestMediaType)
 	a.Assert().Equal(v1.MediaTypeImageIndex, artifact.MediaType)
+	a.Assert().Equal(v1.MediaTypeImageIndex, artifact.ArtifactType)
 	a.Assert().Equal(int64(668), artifact.Size)
 	a.Require().Len(artifact.Annotations, 1)
 	a.Assert().Equal("value1", artifact.Annotations["com.example.key1"])
 	a.Len(artifact.References, 2)
 }
 
+// OCI artifact with the artifact type and the empty config
+func (a *abstractorTestSuite) TestAbstractMetadataOfOCIArtifact() {
+	manifest, _, err := distribution.UnmarshalManifest(v1.MediaTypeImageManifest, []byte(ociArtifactManifest))
+	a.Require().Nil(err)
+	a.regCli.On("PullManifest", mock.Anything, mock.Anything).Return(manifest, "", nil)
+	// the processor is chosen by the artifact type as the config is empty
+	processor.Registry["application/vnd.example.sbom.v1"] = a.processor
+	a.processor.On("AbstractMetadata", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	artifact := &artifact.Artifact{
+		ID: 1,
+	}
+	err = a.abstractor.AbstractMetadata(nil, artifact)
+	a.Require().Nil(err)
+	a.processor.AssertExpectations(a.T())
+	a.Assert().Equal(v1.MediaTypeImageManifest, artifact.ManifestMediaType)
+	a.Assert().Equal("application/vnd.example.sbom.v1", artifact.MediaType)
+	a.Assert().Equal("application/vnd.example.sbom.v1", artifact.ArtifactType)
+	a.Assert().Equal(int64(len(ociArtifactManifest)+2+1024), artifact.Size)
+}
+
 type unknownManifest struct{}
 
 func (u *unknownManifest) References() []distribution.Descriptor {
@@ -44,11 +44,13 @@ import (
 	"github.com/goharbor/harbor/src/pkg/artifactrash"
 	"github.com/goharbor/harbor/src/pkg/artifactrash/model"
 	"github.com/goharbor/harbor/src/pkg/blob"
+	"github.com/goharbor/harbor/src/pkg/deployment"
 	"github.com/goharbor/harbor/src/pkg/immutable/match"
 	"github.com/goharbor/harbor/src/pkg/immutable/match/rule"
 	"github.com/goharbor/harbor/src/pkg/label"
 	"github.com/goharbor/harbor/src/pkg/notification"
 	"github.com/goharbor/harbor/src/pkg/notifier/event"
+	"github.com/goharbor/harbor/src/pkg/pin"
 	"github.com/goharbor/harbor/src/pkg/registry"
 	"github.com/goharbor/harbor/src/pkg/repository"
 	"github.com/goharbor/harbor/src/pkg/signature"
@@ -60,6 +62,13 @@ var (
 	Ctl = NewController()
 )
 
+const (
+	// the max count of the artifacts assembled concurrently when listing artifacts
+	assembleConcurrency = 10
+	// the timeout for assembling one artifact, the properties that aren't populated in time are left empty
+	assembleTimeout = 30 * time.Second
+)
+
 var (
 	// ErrBreak error to break walk
 	ErrBreak = stderrors.New("break")
@@ -98,6 +107,9 @@ type Controller interface {
 	Delete(ctx context.Context, id int64) (err error)
 	// Copy the artifact specified by "srcRepo" and "reference" into the repository specified by "dstRepo"
 	Copy(ctx context.Context, srcRepo, reference, dstRepo string) (id int64, err error)
+	// CopyWithTag copies the artifact like Copy, but only attaches the specified tag to the copied artifact
+	// rather than the tags of the source artifact
+	CopyWithTag(ctx context.Context, srcRepo, reference, dstRepo, tag string) (id int64, err error)
 	// UpdatePullTime updates the pull time for the artifact. If the tagID is provides, update the pull
 	// time of the tag as well
 	UpdatePullTime(ctx context.Context, artifactID int64, tagID int64, time time.Time) (err error)
@@ -116,32 +128,36 @@ type Controller interface {
 // NewController creates an instance of the default artifact controller
 func NewController() Controller {
 	return &controller{
-		tagCtl:       tag.Ctl,
-		repoMgr:      pkg.RepositoryMgr,
-		artMgr:       pkg.ArtifactMgr,
-		artrashMgr:   artifactrash.Mgr,
-		blobMgr:      blob.Mgr,
-		sigMgr:       signature.GetManager(),
-		labelMgr:     label.Mgr,
-		immutableMtr: rule.NewRuleMatcher(),
-		regCli:       registry.Cli,
-		abstractor:   NewAbstractor(),
-		accessoryMgr: accessory.Mgr,
+		tagCtl:        tag.Ctl,
+		repoMgr:       pkg.RepositoryMgr,
+		artMgr:        pkg.ArtifactMgr,
+		artrashMgr:    artifactrash.Mgr,
+		blobMgr:       blob.Mgr,
+		sigMgr:        signature.GetManager(),
+		labelMgr:      pkg.LabelMgr,
+		immutableMtr:  rule.NewRuleMatcher(),
+		regCli:        registry.Cli,
+		abstractor:    NewAbstractor(),
+		accessoryMgr:  accessory.Mgr,
+		deploymentMgr: deployment.Mgr,
+		pinMgr:        pin.Mgr,
 	}
 }
 
 type controller struct {
-	tagCtl       tag.Controller
-	repoMgr      repository.Manager
-	artMgr       artifact.Manager
-	artrashMgr   artifactrash.Manager
-	blobMgr      blob.Manager
-	sigMgr       signature.Manager
-	labelMgr     label.Manager
-	immutableMtr match.ImmutableTagMatcher
-	regCli       registry.Client
-	abstractor   Abstractor
-	accessoryMgr accessory.Manager
+	tagCtl        tag.Controller
+	repoMgr       repository.Manager
+	artMgr        artifact.Manager
+	artrashMgr    artifactrash.Manager
+	blobMgr       blob.Manager
+	sigMgr        signature.Manager
+	labelMgr      label.Manager
+	immutableMtr  match.ImmutableTagMatcher
+	regCli        registry.Client
+	abstractor    Abstractor
+	accessoryMgr  accessory.Manager
+	deploymentMgr deployment.Manager
+	pinMgr        pin.Manager
 }
 
 type ArtOption struct {
@@ -249,10 +265,17 @@ func (c *controller) List(ctx context.Context, query *q.Query, option *Option) (
 		return nil, err
 	}
 
-	var res []*Artifact
-	for _, art := range arts {
-		res = append(res, c.assembleArtifact(ctx, art, option))
+	res := make([]*Artifact, len(arts))
+	// the queries in a transaction cannot run concurrently
+	if orm.InTransaction(ctx) {
+		for i, art := range arts {
+			res[i] = c.assembleArtifact(ctx, art, option)
+		}
+		return res, nil
 	}
+	lib.RunConcurrently(ctx, assembleConcurrency, len(arts), assembleTimeout, func(ctx context.Context, i int) {
+		res[i] = c.assembleArtifact(ctx, arts[i], option)
+	})
 	return res, nil
 }
 
@@ -335,6 +358,33 @@ func (c *controller) deleteDeeply(ctx context.Context, id int64, isRoot, isAcces
 	if !isRoot && len(art.Tags) > 0 {
 		return nil
 	}
+	// the artifact is in use by the deployments, the deletions by the users, the tag retention and
+	// the garbage collection are all refused until the marks are removed or expired
+	inUse, err := c.deploymentMgr.InUse(ctx, art.RepositoryName, art.Digest)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		if isRoot {
+			return errors.New(nil).WithCode(errors.PreconditionCode).
+				WithMessage("the deleting artifact %s@%s is in use by the deployments", art.RepositoryName, art.Digest)
+		}
+		// the child artifact is in use by the deployments, skip
+		return nil
+	}
+	// the pinned artifact is protected from any deletion until it is unpinned
+	pinned, err := c.pinMgr.IsPinned(ctx, art.RepositoryName, art.Digest)
+	if err != nil {
+		return err
+	}
+	if pinned {
+		if isRoot {
+			return errors.New(nil).WithCode(errors.PreconditionCode).
+				WithMessage("the deleting artifact %s@%s is pinned", art.RepositoryName, art.Digest)
+		}
+		// the child artifact is pinned, skip
+		return nil
+	}
 	parents, err := c.artMgr.ListReferences(ctx, &q.Query{
 		Keywords: map[string]interface{}{
 			"ChildID": id,
@@ -442,14 +492,20 @@ func (c *controller) deleteDeeply(ctx context.Context, id int64, isRoot, isAcces
 
 func (c *controller) Copy(ctx context.Context, srcRepo, reference, dstRepo string) (int64, error) {
 	dstAccs := make([]*accessorymodel.AccessoryData, 0)
-	return c.copyDeeply(ctx, srcRepo, reference, dstRepo, true, false, &dstAccs)
+	return c.copyDeeply(ctx, srcRepo, reference, dstRepo, true, false, &dstAccs, nil)
+}
+
+func (c *controller) CopyWithTag(ctx context.Context, srcRepo, reference, dstRepo, tag string) (int64, error) {
+	dstAccs := make([]*accessorymodel.AccessoryData, 0)
+	return c.copyDeeply(ctx, srcRepo, reference, dstRepo, true, false, &dstAccs, []string{tag})
 }
 
 // as we call the docker registry APIs in the registry client directly,
 // this bypass our own logic(ensure, fire event, etc.) inside the registry handlers,
 // these logic must be covered explicitly here.
 // "copyDeeply" iterates the child artifacts and copy them first
-func (c *controller) copyDeeply(ctx context.Context, srcRepo, reference, dstRepo string, isRoot, isAcc bool, dstAccs *[]*accessorymodel.AccessoryData) (int64, error) {
+// "dstTags" overrides the tags of the outermost artifact if it isn't nil
+func (c *controller) copyDeeply(ctx context.Context, srcRepo, reference, dstRepo string, isRoot, isAcc bool, dstAccs *[]*accessorymodel.AccessoryData, dstTags []string) (int64, error) {
 	var option *Option
 	option = &Option{WithTag: true, WithAccessory: true}
 	if isAcc {
@@ -480,7 +536,7 @@ func (c *controller) copyDeeply(ctx context.Context, srcRepo, reference, dstRepo
 	// the artifact doesn't exist under the destination repository, continue to copy
 	// copy child artifacts if contains any
 	for _, reference := range srcArt.References {
-		if _, err = c.copyDeeply(ctx, srcRepo, reference.ChildDigest, dstRepo, false, false, dstAccs); err != nil {
+		if _, err = c.copyDeeply(ctx, srcRepo, reference.ChildDigest, dstRepo, false, false, dstAccs, nil); err != nil {
 			return 0, err
 		}
 	}
@@ -493,7 +549,7 @@ func (c *controller) copyDeeply(ctx context.Context, srcRepo, reference, dstRepo
 			Size:   acc.GetData().Size,
 		}
 		*dstAccs = append(*dstAccs, dstAcc)
-		id, err := c.copyDeeply(ctx, srcRepo, acc.GetData().Digest, dstRepo, false, true, dstAccs)
+		id, err := c.copyDeeply(ctx, srcRepo, acc.GetData().Digest, dstRepo, false, true, dstAccs, nil)
 		if err != nil {
 			return 0, err
 		}
@@ -511,6 +567,9 @@ ensureArt:
 	for _, tag := range srcArt.Tags {
 		tags = append(tags, tag.Name)
 	}
+	if isRoot && dstTags != nil {
+		tags = dstTags
+	}
 	// ensure the parent artifact exist in the database
 	artopt := &ArtOption{
 		Tags: tags,
@@ -576,12 +635,49 @@ func (c *controller) AddLabel(ctx context.Context, artifactID int64, labelID int
 			}
 		}
 	}()
-	err = c.labelMgr.AddTo(ctx, labelID, artifactID)
-	return
+	if err = c.labelMgr.AddTo(ctx, labelID, artifactID); err != nil {
+		return err
+	}
+	// add the label to the children of the index as well to keep the index and the children
+	// consistent for the label based filters of the replication and retention
+	return c.walkChildren(ctx, artifactID, func(childID int64) error {
+		labels, err := c.labelMgr.ListByArtifact(ctx, childID)
+		if err != nil {
+			return err
+		}
+		for _, l := range labels {
+			if l.ID == labelID {
+				return nil
+			}
+		}
+		return c.labelMgr.AddTo(ctx, labelID, childID)
+	})
 }
 
 func (c *controller) RemoveLabel(ctx context.Context, artifactID int64, labelID int64) error {
-	return c.labelMgr.RemoveFrom(ctx, labelID, artifactID)
+	if err := c.labelMgr.RemoveFrom(ctx, labelID, artifactID); err != nil {
+		return err
+	}
+	return c.walkChildren(ctx, artifactID, func(childID int64) error {
+		if err := c.labelMgr.RemoveFrom(ctx, labelID, childID); err != nil && !errors.IsNotFoundErr(err) {
+			return err
+		}
+		return nil
+	})
+}
+
+// walkChildren calls the function with the IDs of the children if the artifact is an index
+func (c *controller) walkChildren(ctx context.Context, artifactID int64, fn func(childID int64) error) error {
+	references, err := c.artMgr.ListReferences(ctx, q.New(q.KeyWords{"ParentID": artifactID}))
+	if err != nil {
+		return err
+	}
+	for _, reference := range references {
+		if err = fn(reference.ChildID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (c *controller) Walk(ctx context.Context, root *Artifact, walkFn func(*Artifact) error, option *Option) error {
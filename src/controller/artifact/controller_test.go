@@ -45,8 +45,10 @@ import (
 	arttesting "github.com/goharbor/harbor/src/testing/pkg/artifact"
 	artrashtesting "github.com/goharbor/harbor/src/testing/pkg/artifactrash"
 	"github.com/goharbor/harbor/src/testing/pkg/blob"
+	deploymenttesting "github.com/goharbor/harbor/src/testing/pkg/deployment"
 	"github.com/goharbor/harbor/src/testing/pkg/immutable"
 	"github.com/goharbor/harbor/src/testing/pkg/label"
+	pintesting "github.com/goharbor/harbor/src/testing/pkg/pin"
 	"github.com/goharbor/harbor/src/testing/pkg/registry"
 	repotesting "github.com/goharbor/harbor/src/testing/pkg/repository"
 )
@@ -75,6 +77,8 @@ type controllerTestSuite struct {
 	immutableMtr *immutable.FakeMatcher
 	regCli       *registry.Client
 	accMgr       *accessory.Manager
+	deployMgr    *deploymenttesting.Manager
+	pinMgr       *pintesting.Manager
 }
 
 func (c *controllerTestSuite) SetupTest() {
@@ -88,17 +92,23 @@ func (c *controllerTestSuite) SetupTest() {
 	c.immutableMtr = &immutable.FakeMatcher{}
 	c.accMgr = &accessorytesting.Manager{}
 	c.regCli = &registry.Client{}
+	c.deployMgr = &deploymenttesting.Manager{}
+	c.deployMgr.On("InUse", mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Maybe()
+	c.pinMgr = &pintesting.Manager{}
+	c.pinMgr.On("IsPinned", mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Maybe()
 	c.ctl = &controller{
-		repoMgr:      c.repoMgr,
-		artMgr:       c.artMgr,
-		artrashMgr:   c.artrashMgr,
-		blobMgr:      c.blobMgr,
-		tagCtl:       c.tagCtl,
-		labelMgr:     c.labelMgr,
-		abstractor:   c.abstractor,
-		immutableMtr: c.immutableMtr,
-		regCli:       c.regCli,
-		accessoryMgr: c.accMgr,
+		repoMgr:       c.repoMgr,
+		artMgr:        c.artMgr,
+		artrashMgr:    c.artrashMgr,
+		blobMgr:       c.blobMgr,
+		tagCtl:        c.tagCtl,
+		labelMgr:      c.labelMgr,
+		abstractor:    c.abstractor,
+		immutableMtr:  c.immutableMtr,
+		regCli:        c.regCli,
+		accessoryMgr:  c.accMgr,
+		deploymentMgr: c.deployMgr,
+		pinMgr:        c.pinMgr,
 	}
 }
 
@@ -525,6 +535,44 @@ func (c *controllerTestSuite) TestDeleteDeeply() {
 
 }
 
+func (c *controllerTestSuite) TestDeleteDeeplyInUse() {
+	c.deployMgr.ExpectedCalls = nil
+	c.deployMgr.On("InUse", mock.Anything, "library/hello-world", "sha256:123").Return(true, nil)
+	c.artMgr.On("Get", mock.Anything, mock.Anything).Return(&artifact.Artifact{ID: 1, RepositoryName: "library/hello-world", Digest: "sha256:123"}, nil)
+	c.tagCtl.On("List").Return(nil, nil)
+	c.repoMgr.On("Get", mock.Anything, mock.Anything).Return(&repomodel.RepoRecord{}, nil)
+	c.accMgr.On("List", mock.Anything, mock.Anything).Return([]accessorymodel.Accessory{}, nil)
+
+	// root artifact is in use by the deployments
+	err := c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, true, false)
+	c.Require().NotNil(err)
+	c.True(errors.IsErr(err, errors.PreconditionCode))
+
+	// child artifact is in use by the deployments, skip
+	err = c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, false, false)
+	c.Require().Nil(err)
+	c.artMgr.AssertNotCalled(c.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (c *controllerTestSuite) TestDeleteDeeplyPinned() {
+	c.pinMgr.ExpectedCalls = nil
+	c.pinMgr.On("IsPinned", mock.Anything, "library/hello-world", "sha256:123").Return(true, nil)
+	c.artMgr.On("Get", mock.Anything, mock.Anything).Return(&artifact.Artifact{ID: 1, RepositoryName: "library/hello-world", Digest: "sha256:123"}, nil)
+	c.tagCtl.On("List").Return(nil, nil)
+	c.repoMgr.On("Get", mock.Anything, mock.Anything).Return(&repomodel.RepoRecord{}, nil)
+	c.accMgr.On("List", mock.Anything, mock.Anything).Return([]accessorymodel.Accessory{}, nil)
+
+	// root artifact is pinned
+	err := c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, true, false)
+	c.Require().NotNil(err)
+	c.True(errors.IsErr(err, errors.PreconditionCode))
+
+	// child artifact is pinned, skip
+	err = c.ctl.deleteDeeply(orm.NewContext(nil, &ormtesting.FakeOrmer{}), 1, false, false)
+	c.Require().Nil(err)
+	c.artMgr.AssertNotCalled(c.T(), "Delete", mock.Anything, mock.Anything)
+}
+
 func (c *controllerTestSuite) TestCopy() {
 	c.artMgr.On("Get", mock.Anything, mock.Anything).Return(&artifact.Artifact{
 		ID:     1,
@@ -569,6 +617,32 @@ func (c *controllerTestSuite) TestCopy() {
 	c.Require().Nil(err)
 }
 
+func (c *controllerTestSuite) TestCopyWithTag() {
+	c.artMgr.On("Get", mock.Anything, mock.Anything).Return(&artifact.Artifact{
+		ID:     1,
+		Digest: "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180",
+	}, nil)
+	c.repoMgr.On("GetByName", mock.Anything, mock.Anything).Return(&repomodel.RepoRecord{
+		RepositoryID: 1,
+		Name:         "library/hello-world",
+	}, nil)
+	c.artMgr.On("GetByDigest", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.NotFoundError(nil))
+	c.tagCtl.On("List").Return([]*tag.Tag{
+		{Tag: model_tag.Tag{ID: 1, Name: "latest"}},
+		{Tag: model_tag.Tag{ID: 2, Name: "stable"}},
+	}, nil)
+	c.accMgr.On("List", mock.Anything, mock.Anything).Return(nil, nil)
+	c.abstractor.On("AbstractMetadata").Return(nil)
+	c.artMgr.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	c.regCli.On("Copy", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.tagCtl.On("Ensure").Return(nil)
+	id, err := c.ctl.CopyWithTag(orm.NewContext(nil, &ormtesting.FakeOrmer{}), "library/hello-world", "latest", "prod/hello-world", "v1")
+	c.Require().Nil(err)
+	c.Equal(int64(1), id)
+	// only the specified tag is attached rather than the tags of the source artifact
+	c.tagCtl.AssertNumberOfCalls(c.T(), "Ensure", 1)
+}
+
 func (c *controllerTestSuite) TestUpdatePullTime() {
 	// artifact ID and tag ID matches
 	c.tagCtl.On("Get").Return(&tag.Tag{
@@ -618,14 +692,44 @@ func (c *controllerTestSuite) TestGetAddition() {
 
 func (c *controllerTestSuite) TestAddTo() {
 	c.labelMgr.On("AddTo", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.artMgr.On("ListReferences", mock.Anything, mock.Anything).Return([]*artifact.Reference{}, nil)
 	err := c.ctl.AddLabel(context.Background(), 1, 1)
 	c.Require().Nil(err)
 }
 
+func (c *controllerTestSuite) TestAddToIndex() {
+	c.artMgr.On("ListReferences", mock.Anything, mock.Anything).Return([]*artifact.Reference{
+		{ParentID: 1, ChildID: 2},
+		{ParentID: 1, ChildID: 3},
+	}, nil)
+	c.labelMgr.On("AddTo", mock.Anything, int64(1), int64(1)).Return(nil)
+	c.labelMgr.On("AddTo", mock.Anything, int64(1), int64(3)).Return(nil)
+	// the label is already added to the child 2
+	c.labelMgr.On("ListByArtifact", mock.Anything, int64(2)).Return([]*model.Label{{ID: 1}}, nil)
+	c.labelMgr.On("ListByArtifact", mock.Anything, int64(3)).Return([]*model.Label{}, nil)
+	err := c.ctl.AddLabel(context.Background(), 1, 1)
+	c.Require().Nil(err)
+	c.labelMgr.AssertNumberOfCalls(c.T(), "AddTo", 2)
+	c.labelMgr.AssertNotCalled(c.T(), "AddTo", mock.Anything, int64(1), int64(2))
+}
+
 func (c *controllerTestSuite) TestRemoveFrom() {
 	c.labelMgr.On("RemoveFrom", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.artMgr.On("ListReferences", mock.Anything, mock.Anything).Return([]*artifact.Reference{}, nil)
+	err := c.ctl.RemoveLabel(nil, 1, 1)
+	c.Require().Nil(err)
+}
+
+func (c *controllerTestSuite) TestRemoveFromIndex() {
+	c.artMgr.On("ListReferences", mock.Anything, mock.Anything).Return([]*artifact.Reference{
+		{ParentID: 1, ChildID: 2},
+	}, nil)
+	c.labelMgr.On("RemoveFrom", mock.Anything, int64(1), int64(1)).Return(nil)
+	// the label isn't added to the child
+	c.labelMgr.On("RemoveFrom", mock.Anything, int64(1), int64(2)).Return(errors.NotFoundError(nil))
 	err := c.ctl.RemoveLabel(nil, 1, 1)
 	c.Require().Nil(err)
+	c.labelMgr.AssertNumberOfCalls(c.T(), "RemoveFrom", 2)
 }
 
 func (c *controllerTestSuite) TestWalk() {
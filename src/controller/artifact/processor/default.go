@@ -49,10 +49,12 @@ type defaultProcessor struct {
 }
 
 func (d *defaultProcessor) GetArtifactType(ctx context.Context, artifact *artifact.Artifact) string {
-	// try to parse the type from the media type
-	strs := artifactTypeRegExp.FindStringSubmatch(artifact.MediaType)
-	if len(strs) == 2 {
-		return strings.ToUpper(strs[1])
+	// try to parse the type from the media type and then the artifact type
+	for _, mediaType := range []string{artifact.MediaType, artifact.ArtifactType} {
+		strs := artifactTypeRegExp.FindStringSubmatch(mediaType)
+		if len(strs) == 2 {
+			return strings.ToUpper(strs[1])
+		}
 	}
 	// can not get the artifact type from the media type, return unknown
 	return ArtifactTypeUnknown
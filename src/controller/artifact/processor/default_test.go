@@ -169,6 +169,11 @@ func (d *defaultProcessorTestSuite) TestGetArtifactType() {
 	processor = &defaultProcessor{}
 	typee = processor.GetArtifactType(nil, art)
 	d.Equal("MODEL", typee)
+
+	art = &artifact.Artifact{MediaType: "application/vnd.oci.empty.v1+json", ArtifactType: "application/vnd.cncf.openpolicyagent.config.v1+json"}
+	processor = &defaultProcessor{}
+	typee = processor.GetArtifactType(nil, art)
+	d.Equal("OPENPOLICYAGENT", typee)
 }
 
 func (d *defaultProcessorTestSuite) TestAbstractMetadata() {
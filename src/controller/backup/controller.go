@@ -0,0 +1,493 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/controller/config"
+	"github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	libcfg "github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/backup"
+	"github.com/goharbor/harbor/src/pkg/backup/model"
+	"github.com/goharbor/harbor/src/pkg/migration"
+	migrationmodel "github.com/goharbor/harbor/src/pkg/migration/model"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/systemartifact"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+const (
+	// Vendor is the vendor of the system artifacts keeping the backup manifests
+	Vendor = "metadata_backup"
+	// ArtifactType is the type of the system artifacts keeping the backup manifests
+	ArtifactType = "BackupManifest"
+
+	// DefaultHold is the default duration the read-only mode is held for the backup
+	DefaultHold = time.Hour
+	// MaxHold is the max duration the read-only mode is held for the backup
+	MaxHold = 24 * time.Hour
+
+	// StageStarted means the read-only mode is on and the manifest is being created
+	StageStarted = "Started"
+	// StageReady means the manifest is created, the database dump and the storage snapshot can be taken
+	StageReady = "Ready"
+	// StageCompleted means the backup is completed by the operator and the read-only mode is released
+	StageCompleted = "Completed"
+	// StageExpired means the hold expired before the backup was completed and the read-only mode is released
+	StageExpired = "Expired"
+	// StageAborted means the backup job was stopped before the backup was completed
+	StageAborted = "Aborted"
+	// StageFailed means the backup job failed
+	StageFailed = "Failed"
+
+	attrStage            = "stage"
+	attrOperator         = "operator"
+	attrHoldUntil        = "hold_until"
+	attrPreviousReadOnly = "previous_read_only"
+	attrCompleted        = "completed"
+	attrDigest           = "digest"
+	attrSize             = "size"
+
+	// ParamBackupID is the job parameter of the ID of the backup, it's also the repository of the system artifact
+	ParamBackupID = "backup_id"
+	// ParamHoldUntil is the job parameter of the unix time until which the read-only mode is held
+	ParamHoldUntil = "hold_until"
+)
+
+var (
+	defaultCtl = newController()
+	// Ctl is a global backup controller instance
+	Ctl Controller = defaultCtl
+)
+
+func init() {
+	if err := task.RegisterCheckInProcessor(job.MetadataBackup, defaultCtl.checkInProcessor); err != nil {
+		log.Fatalf("failed to register the checkin processor for the backup job, error %v", err)
+	}
+	if err := task.RegisterTaskStatusChangePostFunc(job.MetadataBackup, defaultCtl.taskStatusChange); err != nil {
+		log.Fatalf("failed to register the task status change post for the backup job, error %v", err)
+	}
+}
+
+// Progress is the progress checked in by the backup job, the digest and size of the
+// manifest are checked in once the manifest is stored
+type Progress struct {
+	Stage  string `json:"stage"`
+	Digest string `json:"digest,omitempty"`
+	Size   int64  `json:"size,omitempty"`
+}
+
+// Backup is a metadata backup, the read-only mode is held from the start until the backup is
+// completed by the operator or the hold expires
+type Backup struct {
+	ID             int64     `json:"id"`
+	Status         string    `json:"status"`
+	StatusMessage  string    `json:"status_message,omitempty"`
+	Stage          string    `json:"stage"`
+	Operator       string    `json:"operator,omitempty"`
+	HoldUntil      time.Time `json:"hold_until"`
+	ManifestDigest string    `json:"manifest_digest,omitempty"`
+	ManifestSize   int64     `json:"manifest_size,omitempty"`
+	StartTime      time.Time `json:"start_time"`
+	EndTime        time.Time `json:"end_time"`
+}
+
+// Validation is the result of validating the restore target against the backup manifest
+type Validation struct {
+	// Ready is true when all the blocking checks pass
+	Ready  bool                    `json:"ready"`
+	Checks []*migrationmodel.Check `json:"checks"`
+}
+
+// Controller orchestrates the metadata backups and validates the restore targets
+type Controller interface {
+	// Start a backup, the registry is switched into the read-only mode and the manifest of the
+	// metadata and the storage is created by a job, the read-only mode is held until the backup
+	// is completed or the hold expires
+	Start(ctx context.Context, hold time.Duration) (id int64, err error)
+	// Get the backup specified by ID
+	Get(ctx context.Context, id int64) (b *Backup, err error)
+	// Count the backups according to the query
+	Count(ctx context.Context, query *q.Query) (total int64, err error)
+	// List the backups according to the query, the latest first
+	List(ctx context.Context, query *q.Query) (bs []*Backup, err error)
+	// Complete the backup after the database dump and the storage snapshot are taken,
+	// the read-only mode is released
+	Complete(ctx context.Context, id int64) (err error)
+	// Manifest returns the manifest of the backup
+	Manifest(ctx context.Context, id int64) (manifest *model.Manifest, err error)
+	// Validate the current metadata and storage as the restore target of the backup manifest
+	Validate(ctx context.Context, manifest *model.Manifest) (validation *Validation, err error)
+}
+
+func newController() *controller {
+	return &controller{
+		exeMgr:         task.ExecMgr,
+		taskMgr:        task.Mgr,
+		backupMgr:      backup.Mgr,
+		migrationMgr:   migration.Mgr,
+		sysArtifactMgr: systemartifact.Mgr,
+		configCtl:      config.Ctl,
+		readOnly:       libcfg.ReadOnly,
+	}
+}
+
+type controller struct {
+	exeMgr         task.ExecutionManager
+	taskMgr        task.Manager
+	backupMgr      backup.Manager
+	migrationMgr   migration.Manager
+	sysArtifactMgr systemartifact.Manager
+	configCtl      config.Controller
+	readOnly       func(ctx context.Context) bool
+}
+
+func (c *controller) Start(ctx context.Context, hold time.Duration) (int64, error) {
+	if hold < time.Minute || hold > MaxHold {
+		return 0, errors.BadRequestError(nil).WithMessage("the hold must be between 1 minute and %s", MaxHold)
+	}
+	running, err := c.exeMgr.Count(ctx, q.New(q.KeyWords{
+		"VendorType": job.MetadataBackup,
+		"Status":     job.RunningStatus.String(),
+	}))
+	if err != nil {
+		return 0, err
+	}
+	if running > 0 {
+		return 0, errors.ConflictError(nil).WithMessage("another backup is in progress")
+	}
+
+	// the read-only mode is restored to the previous value when the backup finishes
+	previous := c.readOnly(ctx)
+	holdUntil := time.Now().Add(hold).Unix()
+	attrs := map[string]interface{}{
+		attrStage:            StageStarted,
+		attrHoldUntil:        holdUntil,
+		attrPreviousReadOnly: previous,
+	}
+	if sc, ok := security.FromContext(ctx); ok {
+		attrs[attrOperator] = sc.GetUsername()
+	}
+	id, err := c.exeMgr.Create(ctx, job.MetadataBackup, -1, task.ExecutionTriggerManual, attrs)
+	if err != nil {
+		return 0, err
+	}
+	if !previous {
+		if err = c.configCtl.UpdateUserConfigs(ctx, map[string]interface{}{common.ReadOnly: true}); err != nil {
+			c.markError(ctx, id, err)
+			return 0, err
+		}
+	}
+	_, err = c.taskMgr.Create(ctx, id, &task.Job{
+		Name: job.MetadataBackup,
+		Metadata: &job.Metadata{
+			JobKind: job.KindGeneric,
+		},
+		Parameters: map[string]interface{}{
+			ParamBackupID:  id,
+			ParamHoldUntil: holdUntil,
+		},
+	})
+	if err != nil {
+		c.restoreReadOnly(ctx, id, previous)
+		c.markError(ctx, id, err)
+		return 0, err
+	}
+	c.notify(ctx, id, StageStarted, attrs)
+	return id, nil
+}
+
+func (c *controller) Get(ctx context.Context, id int64) (*Backup, error) {
+	exec, err := c.getExecution(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toBackup(exec), nil
+}
+
+func (c *controller) Count(ctx context.Context, query *q.Query) (int64, error) {
+	query = q.MustClone(query)
+	query.Keywords["VendorType"] = job.MetadataBackup
+	return c.exeMgr.Count(ctx, query)
+}
+
+func (c *controller) List(ctx context.Context, query *q.Query) ([]*Backup, error) {
+	query = q.MustClone(query)
+	query.Keywords["VendorType"] = job.MetadataBackup
+	query.Sorts = []*q.Sort{q.NewSort("ID", true)}
+	execs, err := c.exeMgr.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var bs []*Backup
+	for _, exec := range execs {
+		bs = append(bs, toBackup(exec))
+	}
+	return bs, nil
+}
+
+func (c *controller) Complete(ctx context.Context, id int64) error {
+	exec, err := c.getExecution(ctx, id)
+	if err != nil {
+		return err
+	}
+	b := toBackup(exec)
+	if job.Status(b.Status).Final() {
+		return errors.BadRequestError(nil).WithMessage("the backup %d is already finished", id)
+	}
+	if b.Stage != StageReady {
+		return errors.BadRequestError(nil).WithMessage("the manifest of the backup %d isn't ready", id)
+	}
+	// the read-only mode is released by the task status change post function once the job stops
+	exec.ExtraAttrs[attrCompleted] = true
+	if err = c.exeMgr.UpdateExtraAttrs(ctx, id, exec.ExtraAttrs); err != nil {
+		return err
+	}
+	return c.exeMgr.Stop(ctx, id)
+}
+
+func (c *controller) Manifest(ctx context.Context, id int64) (*model.Manifest, error) {
+	b, err := c.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(b.ManifestDigest) == 0 {
+		return nil, errors.NotFoundError(nil).WithMessage("the manifest of the backup %d not found", id)
+	}
+	reader, err := c.sysArtifactMgr.Read(ctx, Vendor, strconv.FormatInt(id, 10), b.ManifestDigest)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	manifest := &model.Manifest{}
+	if err = json.NewDecoder(reader).Decode(manifest); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode the manifest of the backup %d", id)
+	}
+	return manifest, nil
+}
+
+func (c *controller) Validate(ctx context.Context, manifest *model.Manifest) (*Validation, error) {
+	if manifest == nil || manifest.Storage == nil {
+		return nil, errors.BadRequestError(nil).WithMessage("invalid backup manifest")
+	}
+	target, err := c.backupMgr.Snapshot(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	status, err := c.migrationMgr.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the restored database is migrated to the latest schema when the core starts, the row counts
+	// may be changed by the migrations in this case
+	migrated := target.SchemaVersion != manifest.SchemaVersion
+	var mismatched []string
+	for table, rows := range manifest.Tables {
+		if target.Tables[table] != rows {
+			mismatched = append(mismatched, fmt.Sprintf("%s(%d != %d)", table, target.Tables[table], rows))
+		}
+	}
+	storage := target.Storage
+	checks := []*migrationmodel.Check{
+		newCheck("schema_version", manifest.SchemaVersion <= status.LatestVersion && target.SchemaVersion >= manifest.SchemaVersion, true,
+			fmt.Sprintf("the schema version %d of the target matches the backup of schema version %d", target.SchemaVersion, manifest.SchemaVersion),
+			fmt.Sprintf("the schema version %d of the target doesn't match the backup of schema version %d, the latest version supported is %d",
+				target.SchemaVersion, manifest.SchemaVersion, status.LatestVersion)),
+		newCheck("schema_clean", !status.Dirty, true,
+			"the schema is clean",
+			fmt.Sprintf("the migration to version %d failed in the middle, the schema must be fixed manually", status.CurrentVersion)),
+		newCheck("table_rows", len(mismatched) == 0, !migrated,
+			"the row counts of the tables match the backup",
+			fmt.Sprintf("the row counts of the tables don't match the backup: %s", strings.Join(mismatched, ", "))),
+		newCheck("blobs", storage.BlobCount == manifest.Storage.BlobCount && storage.TotalSize == manifest.Storage.TotalSize &&
+			storage.Checksum == manifest.Storage.Checksum, true,
+			fmt.Sprintf("the %d blobs of %d bytes match the backup", storage.BlobCount, storage.TotalSize),
+			fmt.Sprintf("the %d blobs of %d bytes with checksum %s don't match the %d blobs of %d bytes with checksum %s in the backup",
+				storage.BlobCount, storage.TotalSize, storage.Checksum,
+				manifest.Storage.BlobCount, manifest.Storage.TotalSize, manifest.Storage.Checksum)),
+		newCheck("harbor_version", target.HarborVersion == manifest.HarborVersion, false,
+			fmt.Sprintf("the Harbor version %s matches the backup", target.HarborVersion),
+			fmt.Sprintf("the Harbor version %s differs from the version %s of the backup", target.HarborVersion, manifest.HarborVersion)),
+		// the registry should be kept in the read-only mode until the validation passes
+		newCheck("read_only", c.readOnly(ctx), false,
+			"the registry is in the read-only mode",
+			"the registry isn't in the read-only mode, the target may be changed during the validation"),
+	}
+
+	validation := &Validation{
+		Ready:  true,
+		Checks: checks,
+	}
+	for _, check := range checks {
+		if check.Blocking && !check.Passed {
+			validation.Ready = false
+		}
+	}
+	return validation, nil
+}
+
+func (c *controller) checkInProcessor(ctx context.Context, t *task.Task, sc *job.StatusChange) error {
+	if sc.CheckIn == "" {
+		return nil
+	}
+	progress := &Progress{}
+	if err := json.Unmarshal([]byte(sc.CheckIn), progress); err != nil {
+		log.Errorf("failed to resolve checkin of backup task %d: %v", t.ID, err)
+		return err
+	}
+	exec, err := c.getExecution(ctx, t.ExecutionID)
+	if err != nil {
+		return err
+	}
+	exec.ExtraAttrs[attrStage] = progress.Stage
+	if len(progress.Digest) > 0 {
+		exec.ExtraAttrs[attrDigest] = progress.Digest
+		exec.ExtraAttrs[attrSize] = progress.Size
+	}
+	if err = c.exeMgr.UpdateExtraAttrs(ctx, exec.ID, exec.ExtraAttrs); err != nil {
+		return err
+	}
+	c.notify(ctx, exec.ID, progress.Stage, exec.ExtraAttrs)
+	return nil
+}
+
+// taskStatusChange releases the read-only mode and records the final stage once the backup job finishes
+func (c *controller) taskStatusChange(ctx context.Context, taskID int64, status string) error {
+	if !job.Status(status).Final() {
+		return nil
+	}
+	t, err := c.taskMgr.Get(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	exec, err := c.getExecution(ctx, t.ExecutionID)
+	if err != nil {
+		return err
+	}
+	previous, _ := exec.ExtraAttrs[attrPreviousReadOnly].(bool)
+	c.restoreReadOnly(ctx, exec.ID, previous)
+
+	stage := StageFailed
+	switch {
+	case exec.ExtraAttrs[attrCompleted] == true:
+		stage = StageCompleted
+	case status == job.SuccessStatus.String():
+		// the job exits successfully only when the hold expires
+		stage = StageExpired
+	case status == job.StoppedStatus.String():
+		stage = StageAborted
+	}
+	exec.ExtraAttrs[attrStage] = stage
+	if err = c.exeMgr.UpdateExtraAttrs(ctx, exec.ID, exec.ExtraAttrs); err != nil {
+		return err
+	}
+	c.notify(ctx, exec.ID, stage, exec.ExtraAttrs)
+	return nil
+}
+
+func (c *controller) restoreReadOnly(ctx context.Context, id int64, previous bool) {
+	if previous {
+		return
+	}
+	if err := c.configCtl.UpdateUserConfigs(ctx, map[string]interface{}{common.ReadOnly: false}); err != nil {
+		log.Errorf("failed to release the read-only mode held by the backup %d: %v", id, err)
+	}
+}
+
+func (c *controller) markError(ctx context.Context, id int64, err error) {
+	if e := c.exeMgr.MarkError(ctx, id, err.Error()); e != nil {
+		log.Errorf("failed to mark the error status of the backup %d: %v", id, e)
+	}
+}
+
+func (c *controller) notify(ctx context.Context, id int64, stage string, attrs map[string]interface{}) {
+	operator, _ := attrs[attrOperator].(string)
+	notification.AddEvent(ctx, &metadata.BackupMetaData{
+		BackupID: id,
+		Stage:    stage,
+		Operator: operator,
+	})
+}
+
+func (c *controller) getExecution(ctx context.Context, id int64) (*task.Execution, error) {
+	exec, err := c.exeMgr.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if exec.VendorType != job.MetadataBackup {
+		return nil, errors.NotFoundError(nil).WithMessage("backup %d not found", id)
+	}
+	if exec.ExtraAttrs == nil {
+		exec.ExtraAttrs = map[string]interface{}{}
+	}
+	return exec, nil
+}
+
+func toBackup(exec *task.Execution) *Backup {
+	b := &Backup{
+		ID:            exec.ID,
+		Status:        exec.Status,
+		StatusMessage: exec.StatusMessage,
+		StartTime:     exec.StartTime,
+		EndTime:       exec.EndTime,
+	}
+	b.Stage, _ = exec.ExtraAttrs[attrStage].(string)
+	b.Operator, _ = exec.ExtraAttrs[attrOperator].(string)
+	b.ManifestDigest, _ = exec.ExtraAttrs[attrDigest].(string)
+	b.ManifestSize = int64Attr(exec.ExtraAttrs, attrSize)
+	if holdUntil := int64Attr(exec.ExtraAttrs, attrHoldUntil); holdUntil > 0 {
+		b.HoldUntil = time.Unix(holdUntil, 0)
+	}
+	return b
+}
+
+func int64Attr(attrs map[string]interface{}, key string) int64 {
+	switch v := attrs[key].(type) {
+	case int:
+		return int64(v)
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	default:
+		return 0
+	}
+}
+
+func newCheck(name string, passed, blocking bool, passedMsg, failedMsg string) *migrationmodel.Check {
+	check := &migrationmodel.Check{
+		Name:     name,
+		Passed:   passed,
+		Blocking: blocking,
+		Message:  passedMsg,
+	}
+	if !passed {
+		check.Message = failedMsg
+	}
+	return check
+}
@@ -0,0 +1,231 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/backup/model"
+	migrationmodel "github.com/goharbor/harbor/src/pkg/migration/model"
+	"github.com/goharbor/harbor/src/pkg/task"
+	configtesting "github.com/goharbor/harbor/src/testing/controller/config"
+	"github.com/goharbor/harbor/src/testing/mock"
+	backuptesting "github.com/goharbor/harbor/src/testing/pkg/backup"
+	migrationtesting "github.com/goharbor/harbor/src/testing/pkg/migration"
+	systemartifacttesting "github.com/goharbor/harbor/src/testing/pkg/systemartifact"
+	tasktesting "github.com/goharbor/harbor/src/testing/pkg/task"
+)
+
+type controllerTestSuite struct {
+	suite.Suite
+	ctl            *controller
+	exeMgr         *tasktesting.ExecutionManager
+	taskMgr        *tasktesting.Manager
+	backupMgr      *backuptesting.Manager
+	migrationMgr   *migrationtesting.Manager
+	sysArtifactMgr *systemartifacttesting.Manager
+	configCtl      *configtesting.Controller
+	readOnly       bool
+}
+
+func (c *controllerTestSuite) SetupTest() {
+	c.exeMgr = &tasktesting.ExecutionManager{}
+	c.taskMgr = &tasktesting.Manager{}
+	c.backupMgr = &backuptesting.Manager{}
+	c.migrationMgr = &migrationtesting.Manager{}
+	c.sysArtifactMgr = &systemartifacttesting.Manager{}
+	c.configCtl = &configtesting.Controller{}
+	c.readOnly = false
+	c.ctl = &controller{
+		exeMgr:         c.exeMgr,
+		taskMgr:        c.taskMgr,
+		backupMgr:      c.backupMgr,
+		migrationMgr:   c.migrationMgr,
+		sysArtifactMgr: c.sysArtifactMgr,
+		configCtl:      c.configCtl,
+		readOnly:       func(ctx context.Context) bool { return c.readOnly },
+	}
+}
+
+func (c *controllerTestSuite) execution(status string, attrs map[string]interface{}) {
+	c.exeMgr.On("Get", mock.Anything, int64(1)).Return(&task.Execution{
+		ID:         1,
+		VendorType: job.MetadataBackup,
+		Status:     status,
+		ExtraAttrs: attrs,
+	}, nil)
+}
+
+func (c *controllerTestSuite) TestStart() {
+	// invalid hold
+	_, err := c.ctl.Start(context.Background(), 25*time.Hour)
+	c.Require().NotNil(err)
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// another backup is in progress
+	c.exeMgr.On("Count", mock.Anything, mock.Anything).Return(int64(1), nil).Once()
+	_, err = c.ctl.Start(context.Background(), time.Hour)
+	c.Require().NotNil(err)
+	c.True(errors.IsConflictErr(err))
+
+	// the read-only mode is switched on
+	c.exeMgr.On("Count", mock.Anything, mock.Anything).Return(int64(0), nil)
+	c.exeMgr.On("Create", mock.Anything, job.MetadataBackup, int64(-1), task.ExecutionTriggerManual, mock.Anything).Return(int64(1), nil)
+	c.configCtl.On("UpdateUserConfigs", mock.Anything, map[string]interface{}{common.ReadOnly: true}).Return(nil)
+	c.taskMgr.On("Create", mock.Anything, int64(1), mock.Anything).Return(int64(1), nil)
+	id, err := c.ctl.Start(context.Background(), time.Hour)
+	c.Require().Nil(err)
+	c.Equal(int64(1), id)
+	c.configCtl.AssertExpectations(c.T())
+	c.taskMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestStartAlreadyReadOnly() {
+	c.readOnly = true
+	c.exeMgr.On("Count", mock.Anything, mock.Anything).Return(int64(0), nil)
+	c.exeMgr.On("Create", mock.Anything, job.MetadataBackup, int64(-1), task.ExecutionTriggerManual, mock.Anything).Return(int64(1), nil)
+	c.taskMgr.On("Create", mock.Anything, int64(1), mock.Anything).Return(int64(0), errors.New("failed"))
+	c.exeMgr.On("MarkError", mock.Anything, int64(1), mock.Anything).Return(nil)
+	_, err := c.ctl.Start(context.Background(), time.Hour)
+	c.Require().NotNil(err)
+	// the read-only mode isn't touched as it was on before the backup
+	c.configCtl.AssertNotCalled(c.T(), "UpdateUserConfigs", mock.Anything, mock.Anything)
+	c.exeMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestComplete() {
+	c.execution(job.RunningStatus.String(), map[string]interface{}{"stage": StageStarted})
+	err := c.ctl.Complete(context.Background(), 1)
+	c.Require().NotNil(err)
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	c.SetupTest()
+	c.execution(job.RunningStatus.String(), map[string]interface{}{"stage": StageReady})
+	c.exeMgr.On("UpdateExtraAttrs", mock.Anything, int64(1), mock.MatchedBy(func(attrs map[string]interface{}) bool {
+		return attrs["completed"] == true
+	})).Return(nil)
+	c.exeMgr.On("Stop", mock.Anything, int64(1)).Return(nil)
+	c.Require().Nil(c.ctl.Complete(context.Background(), 1))
+	c.exeMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestCheckInProcessor() {
+	c.execution(job.RunningStatus.String(), map[string]interface{}{"stage": StageStarted})
+	c.exeMgr.On("UpdateExtraAttrs", mock.Anything, int64(1), mock.MatchedBy(func(attrs map[string]interface{}) bool {
+		return attrs["stage"] == StageReady && attrs["digest"] == "sha256:abc" && attrs["size"] == int64(10)
+	})).Return(nil)
+	err := c.ctl.checkInProcessor(context.Background(), &task.Task{ID: 1, ExecutionID: 1},
+		&job.StatusChange{CheckIn: `{"stage":"Ready","digest":"sha256:abc","size":10}`})
+	c.Require().Nil(err)
+	c.exeMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestTaskStatusChange() {
+	// not the final status
+	c.Require().Nil(c.ctl.taskStatusChange(context.Background(), 1, job.RunningStatus.String()))
+
+	// completed
+	c.taskMgr.On("Get", mock.Anything, int64(1)).Return(&task.Task{ID: 1, ExecutionID: 1}, nil)
+	c.execution(job.StoppedStatus.String(), map[string]interface{}{"previous_read_only": false, "completed": true})
+	c.configCtl.On("UpdateUserConfigs", mock.Anything, map[string]interface{}{common.ReadOnly: false}).Return(nil)
+	c.exeMgr.On("UpdateExtraAttrs", mock.Anything, int64(1), mock.MatchedBy(func(attrs map[string]interface{}) bool {
+		return attrs["stage"] == StageCompleted
+	})).Return(nil)
+	c.Require().Nil(c.ctl.taskStatusChange(context.Background(), 1, job.StoppedStatus.String()))
+	c.configCtl.AssertExpectations(c.T())
+	c.exeMgr.AssertExpectations(c.T())
+
+	// expired, the registry was in read-only mode before the backup
+	c.SetupTest()
+	c.taskMgr.On("Get", mock.Anything, int64(1)).Return(&task.Task{ID: 1, ExecutionID: 1}, nil)
+	c.execution(job.SuccessStatus.String(), map[string]interface{}{"previous_read_only": true})
+	c.exeMgr.On("UpdateExtraAttrs", mock.Anything, int64(1), mock.MatchedBy(func(attrs map[string]interface{}) bool {
+		return attrs["stage"] == StageExpired
+	})).Return(nil)
+	c.Require().Nil(c.ctl.taskStatusChange(context.Background(), 1, job.SuccessStatus.String()))
+	c.configCtl.AssertNotCalled(c.T(), "UpdateUserConfigs", mock.Anything, mock.Anything)
+	c.exeMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestManifest() {
+	c.execution(job.RunningStatus.String(), map[string]interface{}{"stage": StageReady, "digest": "sha256:abc"})
+	c.sysArtifactMgr.On("Read", mock.Anything, Vendor, "1", "sha256:abc").
+		Return(io.NopCloser(strings.NewReader(`{"schema_version":100,"storage":{"blob_count":2}}`)), nil)
+	manifest, err := c.ctl.Manifest(context.Background(), 1)
+	c.Require().Nil(err)
+	c.Equal(uint(100), manifest.SchemaVersion)
+	c.Equal(int64(2), manifest.Storage.BlobCount)
+}
+
+func (c *controllerTestSuite) TestValidate() {
+	manifest := &model.Manifest{
+		HarborVersion: "v2.10.0",
+		SchemaVersion: 100,
+		Tables:        map[string]int64{"artifact": 10, "blob": 20},
+		Storage:       &model.Storage{BlobCount: 20, TotalSize: 1024, Checksum: "sha256:abc"},
+	}
+	c.migrationMgr.On("Status", mock.Anything).Return(&migrationmodel.Status{CurrentVersion: 100, LatestVersion: 100}, nil)
+	c.backupMgr.On("Snapshot", mock.Anything, false).Return(&model.Manifest{
+		HarborVersion: "v2.10.0",
+		SchemaVersion: 100,
+		Tables:        map[string]int64{"artifact": 10, "blob": 20},
+		Storage:       &model.Storage{BlobCount: 20, TotalSize: 1024, Checksum: "sha256:abc"},
+	}, nil).Once()
+	c.readOnly = true
+	validation, err := c.ctl.Validate(context.Background(), manifest)
+	c.Require().Nil(err)
+	c.True(validation.Ready)
+	for _, check := range validation.Checks {
+		c.True(check.Passed, check.Name)
+	}
+
+	// the blobs don't match
+	c.backupMgr.On("Snapshot", mock.Anything, false).Return(&model.Manifest{
+		HarborVersion: "v2.10.0",
+		SchemaVersion: 100,
+		Tables:        map[string]int64{"artifact": 10, "blob": 19},
+		Storage:       &model.Storage{BlobCount: 19, TotalSize: 1000, Checksum: "sha256:def"},
+	}, nil).Once()
+	validation, err = c.ctl.Validate(context.Background(), manifest)
+	c.Require().Nil(err)
+	c.False(validation.Ready)
+	for _, check := range validation.Checks {
+		switch check.Name {
+		case "table_rows", "blobs":
+			c.False(check.Passed, check.Name)
+			c.True(check.Blocking, check.Name)
+		default:
+			c.True(check.Passed, check.Name)
+		}
+	}
+
+	// invalid manifest
+	_, err = c.ctl.Validate(context.Background(), &model.Manifest{})
+	c.Require().NotNil(err)
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &controllerTestSuite{})
+}
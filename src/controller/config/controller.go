@@ -18,9 +18,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
 
 	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/rbac"
+	event "github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/controller/event/operator"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/config/metadata"
 	"github.com/goharbor/harbor/src/lib/config/models"
@@ -28,6 +33,9 @@ import (
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/audit"
+	"github.com/goharbor/harbor/src/pkg/config/history"
+	historyModel "github.com/goharbor/harbor/src/pkg/config/history/model"
+	"github.com/goharbor/harbor/src/pkg/notification"
 	"github.com/goharbor/harbor/src/pkg/user"
 )
 
@@ -53,15 +61,23 @@ type Controller interface {
 	ConvertForGet(ctx context.Context, cfg map[string]interface{}, internal bool) (map[string]*models.Value, error)
 	// OverwriteConfig overwrite config in the database and set all configure read only when CONFIG_OVERWRITE_JSON is provided
 	OverwriteConfig(ctx context.Context) error
+	// CountHistories returns the total count of the versions of the configuration changes according to the query
+	CountHistories(ctx context.Context, query *q.Query) (int64, error)
+	// ListHistories lists the versions of the configuration changes according to the query
+	ListHistories(ctx context.Context, query *q.Query) ([]*historyModel.History, error)
+	// Rollback restores the configurations changed after the version to the values of the version,
+	// the rollback itself is recorded as a new version
+	Rollback(ctx context.Context, version int64) error
 }
 
 type controller struct {
 	userManager user.Manager
+	historyMgr  history.Manager
 }
 
 // NewController ...
 func NewController() Controller {
-	return &controller{userManager: user.Mgr}
+	return &controller{userManager: user.Mgr, historyMgr: history.Mgr}
 }
 
 func (c *controller) UserConfigs(ctx context.Context) (map[string]*models.Value, error) {
@@ -89,19 +105,155 @@ func (c *controller) UpdateUserConfigs(ctx context.Context, conf map[string]inte
 	if err != nil {
 		return err
 	}
+	before := mgr.GetUserCfgs(ctx)
 	if err := mgr.UpdateConfig(ctx, conf); err != nil {
 		log.Errorf("failed to upload configurations: %v", err)
 		return fmt.Errorf("failed to validate configuration")
 	}
+	c.auditChanges(ctx, before, conf)
+	if err := c.recordHistory(ctx, before, conf); err != nil {
+		return err
+	}
 	// update the audit logger to point to the new endpoint
 	return c.updateLogEndpoint(ctx, conf)
 }
 
+// auditChanges records the changed configurations, the changes of the auth configurations
+// are recorded separately from the others
+func (c *controller) auditChanges(ctx context.Context, before map[string]interface{}, conf map[string]interface{}) {
+	befores, afters := map[string]map[string]interface{}{}, map[string]map[string]interface{}{}
+	for key, value := range conf {
+		resourceType := event.ResourceTypeConfiguration
+		if isAuthConfig(key) {
+			resourceType = event.ResourceTypeAuthConfiguration
+		}
+		if _, ok := afters[resourceType]; !ok {
+			befores[resourceType], afters[resourceType] = map[string]interface{}{}, map[string]interface{}{}
+		}
+		befores[resourceType][key] = before[key]
+		afters[resourceType][key] = value
+	}
+
+	for resourceType := range afters {
+		notification.AddEvent(ctx, &event.ResourceChangeEventMetadata{
+			ResourceType: resourceType,
+			Resource:     resourceType,
+			Operation:    rbac.ActionUpdate.String(),
+			Operator:     operator.FromContext(ctx),
+			Before:       befores[resourceType],
+			After:        afters[resourceType],
+		})
+	}
+}
+
+// recordHistory records the values of the changed configurations before and after the update as a new version,
+// the passwords aren't recorded
+func (c *controller) recordHistory(ctx context.Context, before map[string]interface{}, conf map[string]interface{}) error {
+	befores, afters := map[string]interface{}{}, map[string]interface{}{}
+	for key, value := range conf {
+		if isPasswordConfig(key) {
+			continue
+		}
+		befores[key] = before[key]
+		afters[key] = value
+	}
+	if len(afters) == 0 {
+		return nil
+	}
+	b, err := json.Marshal(befores)
+	if err != nil {
+		return err
+	}
+	a, err := json.Marshal(afters)
+	if err != nil {
+		return err
+	}
+	_, err = c.historyMgr.Create(ctx, &historyModel.History{
+		Operator: operator.FromContext(ctx),
+		Before:   string(b),
+		After:    string(a),
+	})
+	return err
+}
+
+func (c *controller) CountHistories(ctx context.Context, query *q.Query) (int64, error) {
+	return c.historyMgr.Count(ctx, query)
+}
+
+func (c *controller) ListHistories(ctx context.Context, query *q.Query) ([]*historyModel.History, error) {
+	return c.historyMgr.List(ctx, query)
+}
+
+func (c *controller) Rollback(ctx context.Context, version int64) error {
+	if _, err := c.historyMgr.Get(ctx, version); err != nil {
+		return err
+	}
+	query := q.New(q.KeyWords{"id": &q.Range{Min: version + 1}})
+	query.Sorts = []*q.Sort{q.NewSort("id", false)}
+	histories, err := c.historyMgr.List(ctx, query)
+	if err != nil {
+		return err
+	}
+	conf, err := rollbackValues(histories)
+	if err != nil {
+		return err
+	}
+	if len(conf) == 0 {
+		return nil
+	}
+	return c.UpdateUserConfigs(ctx, conf)
+}
+
+// rollbackValues returns the values of the configurations before the changes which are sorted by the versions
+// in ascending order, the value before the earliest change is used when the configuration is changed several times
+func rollbackValues(histories []*historyModel.History) (map[string]interface{}, error) {
+	conf := map[string]interface{}{}
+	for _, h := range histories {
+		values, err := h.BeforeValues()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the configuration version %d", h.ID)
+		}
+		for key, value := range values {
+			if _, exist := conf[key]; !exist {
+				conf[key] = value
+			}
+		}
+	}
+	return conf, nil
+}
+
+// isPasswordConfig checks whether the configuration is a password
+func isPasswordConfig(key string) bool {
+	item, ok := metadata.Instance().GetByName(key)
+	if !ok {
+		return false
+	}
+	_, ok = item.ItemType.(*metadata.PasswordType)
+	return ok
+}
+
+// isAuthConfig checks whether the configuration belongs to the auth settings
+func isAuthConfig(key string) bool {
+	if key == common.AUTHMode {
+		return true
+	}
+	item, ok := metadata.Instance().GetByName(key)
+	if !ok {
+		return false
+	}
+	switch item.Group {
+	case metadata.LdapBasicGroup, metadata.LdapGroupGroup, metadata.UAAGroup, metadata.HTTPAuthGroup, metadata.OIDCGroup:
+		return true
+	}
+	return false
+}
+
 func (c *controller) updateLogEndpoint(ctx context.Context, cfgs map[string]interface{}) error {
 	// check if the audit log forward endpoint updated
 	if _, ok := cfgs[common.AuditLogForwardEndpoint]; ok {
 		auditEP := config.AuditLogForwardEndpoint(ctx)
-		if len(auditEP) == 0 {
+		// the HTTPS collector is checked when forwarding the audit logs with retry
+		if len(auditEP) == 0 || audit.IsHTTPEndpoint(auditEP) {
 			return nil
 		}
 		if !audit.CheckEndpointActive(auditEP) {
@@ -142,10 +294,93 @@ func (c *controller) validateCfg(ctx context.Context, cfgs map[string]interface{
 	if err = verifyValueLengthCfg(ctx, cfgs); err != nil {
 		return err
 	}
+	// verify the format of the forwarded audit log
+	if format, exist := cfgs[common.AuditLogForwardFormat]; exist {
+		if err = audit.ValidateForwardFormat(fmt.Sprintf("%v", format)); err != nil {
+			return err
+		}
+	}
+	// verify the origins allowed to call the APIs cross-origin
+	if err = verifyCORSCfg(ctx, cfgs, mgr); err != nil {
+		return err
+	}
+	// verify the banner and the times of the maintenance
+	if err = verifyBannerAndMaintenanceCfg(ctx, cfgs, mgr); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// verifyCORSCfg verifies the allowed origins of CORS are "*" or in the format "scheme://host[:port]", and that
+// the wildcard origin isn't used together with the credentials, otherwise any site could read the responses
+// of the APIs with the session of the logged-in user
+func verifyCORSCfg(ctx context.Context, cfgs map[string]interface{}, mgr config.Manager) error {
+	origins, originsUpdated := cfgs[common.CORSAllowedOrigins]
+	credentials, credentialsUpdated := cfgs[common.CORSAllowCredentials]
+	if !originsUpdated && !credentialsUpdated {
+		return nil
+	}
+	if !originsUpdated {
+		origins = mgr.Get(ctx, common.CORSAllowedOrigins).GetString()
+	}
+	allowCredentials := false
+	if credentialsUpdated {
+		allowCredentials, _ = strconv.ParseBool(fmt.Sprintf("%v", credentials))
+	} else {
+		allowCredentials = mgr.Get(ctx, common.CORSAllowCredentials).GetBool()
+	}
+	for _, origin := range config.SplitAndTrim(fmt.Sprintf("%v", origins), ",") {
+		if origin == "*" {
+			if allowCredentials {
+				return errors.BadRequestError(nil).
+					WithMessage("the CORS allowed origin \"*\" cannot be used together with the credentials")
+			}
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 ||
+			len(u.Path) > 0 || len(u.RawQuery) > 0 || len(u.Fragment) > 0 {
+			return errors.BadRequestError(nil).
+				WithMessage("invalid CORS allowed origin %q, it must be \"*\" or in the format \"scheme://host[:port]\"", origin)
+		}
+	}
+	return nil
+}
+
+// verifyBannerAndMaintenanceCfg verifies the format of the banner and that the end time of the maintenance is after the start time
+func verifyBannerAndMaintenanceCfg(ctx context.Context, cfgs map[string]interface{}, mgr config.Manager) error {
+	if banner, exist := cfgs[common.BannerMessage]; exist {
+		if _, err := config.ParseBannerMessage(fmt.Sprintf("%v", banner)); err != nil {
+			return errors.BadRequestError(err)
+		}
+	}
+
+	_, startUpdated := cfgs[common.MaintenanceStartTime]
+	_, endUpdated := cfgs[common.MaintenanceEndTime]
+	if !startUpdated && !endUpdated {
+		return nil
+	}
+	get := func(key string) string {
+		if v, exist := cfgs[key]; exist {
+			return fmt.Sprintf("%v", v)
+		}
+		return mgr.Get(ctx, key).GetString()
+	}
+	start, err := config.ParseMaintenanceTime(get(common.MaintenanceStartTime))
+	if err != nil {
+		return errors.BadRequestError(err)
+	}
+	end, err := config.ParseMaintenanceTime(get(common.MaintenanceEndTime))
+	if err != nil {
+		return errors.BadRequestError(err)
+	}
+	if start != nil && end != nil && !end.After(*start) {
+		return errors.BadRequestError(nil).WithMessage("the end time of the maintenance must be after the start time")
+	}
+	return nil
+}
+
 func verifySkipAuditLogCfg(ctx context.Context, cfgs map[string]interface{}, mgr config.Manager) error {
 	updated := false
 	endPoint := mgr.Get(ctx, common.AuditLogForwardEndpoint).GetString()
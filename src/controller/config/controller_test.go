@@ -18,11 +18,15 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/config/metadata"
+	historyModel "github.com/goharbor/harbor/src/pkg/config/history/model"
 	testCfg "github.com/goharbor/harbor/src/testing/lib/config"
 	"github.com/goharbor/harbor/src/testing/mock"
+	historytesting "github.com/goharbor/harbor/src/testing/pkg/config/history"
 )
 
 func Test_verifySkipAuditLogCfg(t *testing.T) {
@@ -120,3 +124,104 @@ func Test_verifyValueLengthCfg(t *testing.T) {
 		})
 	}
 }
+
+func Test_verifyCORSCfg(t *testing.T) {
+	cfgManager := &testCfg.Manager{}
+	cfgManager.On("Get", mock.Anything, common.CORSAllowedOrigins).
+		Return(&metadata.ConfigureValue{Name: common.CORSAllowedOrigins, Value: "*"})
+	cfgManager.On("Get", mock.Anything, common.CORSAllowCredentials).
+		Return(&metadata.ConfigureValue{Name: common.CORSAllowCredentials, Value: "false"})
+	tests := []struct {
+		name    string
+		cfgs    map[string]interface{}
+		wantErr bool
+	}{
+		{name: "not configured", cfgs: map[string]interface{}{}, wantErr: false},
+		{name: "empty origins", cfgs: map[string]interface{}{common.CORSAllowedOrigins: ""}, wantErr: false},
+		{name: "any origin", cfgs: map[string]interface{}{common.CORSAllowedOrigins: "*"}, wantErr: false},
+		{name: "valid origins", cfgs: map[string]interface{}{common.CORSAllowedOrigins: "https://a.example.com, http://b.example.com:8080"}, wantErr: false},
+		{name: "origin without scheme", cfgs: map[string]interface{}{common.CORSAllowedOrigins: "a.example.com"}, wantErr: true},
+		{name: "origin with path", cfgs: map[string]interface{}{common.CORSAllowedOrigins: "https://a.example.com/tools"}, wantErr: true},
+		{name: "origin with unsupported scheme", cfgs: map[string]interface{}{common.CORSAllowedOrigins: "ftp://a.example.com"}, wantErr: true},
+		{name: "any origin with credentials", cfgs: map[string]interface{}{common.CORSAllowedOrigins: "*", common.CORSAllowCredentials: true}, wantErr: true},
+		{name: "valid origins with credentials", cfgs: map[string]interface{}{common.CORSAllowedOrigins: "https://a.example.com", common.CORSAllowCredentials: true}, wantErr: false},
+		{name: "credentials with the stored any origin", cfgs: map[string]interface{}{common.CORSAllowCredentials: true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyCORSCfg(context.TODO(), tt.cfgs, cfgManager); (err != nil) != tt.wantErr {
+				t.Errorf("verifyCORSCfg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_verifyBannerAndMaintenanceCfg(t *testing.T) {
+	cfgManager := &testCfg.Manager{}
+	cfgManager.On("Get", mock.Anything, common.MaintenanceStartTime).
+		Return(&metadata.ConfigureValue{Name: common.MaintenanceStartTime, Value: "2023-01-01T00:00:00Z"})
+	cfgManager.On("Get", mock.Anything, common.MaintenanceEndTime).
+		Return(&metadata.ConfigureValue{Name: common.MaintenanceEndTime, Value: ""})
+	tests := []struct {
+		name    string
+		cfgs    map[string]interface{}
+		wantErr bool
+	}{
+		{name: "none configured", cfgs: map[string]interface{}{}, wantErr: false},
+		{name: "valid banner", cfgs: map[string]interface{}{common.BannerMessage: `{"message":"upgrade tonight","type":"warning"}`}, wantErr: false},
+		{name: "banner removed", cfgs: map[string]interface{}{common.BannerMessage: ""}, wantErr: false},
+		{name: "invalid banner", cfgs: map[string]interface{}{common.BannerMessage: `{"type":"warning"}`}, wantErr: true},
+		{name: "end time after the stored start time", cfgs: map[string]interface{}{common.MaintenanceEndTime: "2023-01-01T02:00:00Z"}, wantErr: false},
+		{name: "end time before the stored start time", cfgs: map[string]interface{}{common.MaintenanceEndTime: "2022-12-31T00:00:00Z"}, wantErr: true},
+		{name: "invalid start time", cfgs: map[string]interface{}{common.MaintenanceStartTime: "tonight"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyBannerAndMaintenanceCfg(context.TODO(), tt.cfgs, cfgManager); (err != nil) != tt.wantErr {
+				t.Errorf("verifyBannerAndMaintenanceCfg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_rollbackValues(t *testing.T) {
+	histories := []*historyModel.History{
+		{ID: 2, Before: `{"auth_mode":"db_auth","read_only":false}`},
+		{ID: 3, Before: `{"auth_mode":"ldap_auth"}`},
+		{ID: 4, Before: `{"token_expiration":30}`},
+	}
+	conf, err := rollbackValues(histories)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"auth_mode":        "db_auth",
+		"read_only":        false,
+		"token_expiration": float64(30),
+	}, conf)
+
+	_, err = rollbackValues([]*historyModel.History{{ID: 5, Before: "invalid"}})
+	assert.Error(t, err)
+}
+
+func Test_recordHistory(t *testing.T) {
+	historyMgr := &historytesting.Manager{}
+	c := &controller{historyMgr: historyMgr}
+	before := map[string]interface{}{
+		common.AUTHMode:         "db_auth",
+		common.LDAPSearchPwd:    "secret",
+		common.TokenExpiration:  30,
+		common.SelfRegistration: false,
+	}
+
+	historyMgr.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil).Once()
+	assert.Nil(t, c.recordHistory(context.TODO(), before, map[string]interface{}{
+		common.AUTHMode:      "ldap_auth",
+		common.LDAPSearchPwd: "new-secret",
+	}))
+	h := historyMgr.Calls[0].Arguments.Get(1).(*historyModel.History)
+	assert.JSONEq(t, `{"auth_mode":"db_auth"}`, h.Before)
+	assert.JSONEq(t, `{"auth_mode":"ldap_auth"}`, h.After)
+
+	// only the passwords are changed
+	assert.Nil(t, c.recordHistory(context.TODO(), before, map[string]interface{}{common.LDAPSearchPwd: "new-secret"}))
+	historyMgr.AssertNumberOfCalls(t, "Create", 1)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/goharbor/harbor/src/common"
 	. "github.com/goharbor/harbor/src/controller/config"
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
 	_ "github.com/goharbor/harbor/src/pkg/config/db"
 	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
 	htesting "github.com/goharbor/harbor/src/testing"
@@ -129,6 +130,31 @@ func (c *controllerTestSuite) TestUpdateUserCfg() {
 	c.True(errors.IsErr(err2, errors.BadRequestCode))
 }
 
+func (c *controllerTestSuite) TestRollback() {
+	c.Nil(c.controller.UpdateUserConfigs(ctx, map[string]interface{}{common.LDAPURL: "ldap1.example.com"}))
+	histories, err := c.controller.ListHistories(ctx, q.New(q.KeyWords{}))
+	c.Require().Nil(err)
+	c.Require().NotEmpty(histories)
+	version := histories[0].ID
+
+	c.Nil(c.controller.UpdateUserConfigs(ctx, map[string]interface{}{common.LDAPURL: "ldap2.example.com"}))
+	c.Nil(c.controller.UpdateUserConfigs(ctx, map[string]interface{}{common.LDAPURL: "ldap3.example.com", common.LDAPBaseDN: "dc=other,dc=com"}))
+
+	c.Nil(c.controller.Rollback(ctx, version))
+	cfgResp, err := c.controller.UserConfigs(ctx)
+	c.Require().Nil(err)
+	c.Equal("ldap1.example.com", cfgResp[common.LDAPURL].Val)
+	c.Equal("dc=example,dc=com", cfgResp[common.LDAPBaseDN].Val)
+
+	// the rollback is recorded as a new version
+	total, err := c.controller.CountHistories(ctx, q.New(q.KeyWords{"id": &q.Range{Min: version + 1}}))
+	c.Nil(err)
+	c.Equal(int64(3), total)
+
+	err = c.controller.Rollback(ctx, 0)
+	c.True(errors.IsNotFoundErr(err))
+}
+
 /*func (c *controllerTestSuite) TestCheckUnmodifiable() {
 	conf := map[string]interface{}{
 		"ldap_url":     "ldaps.myexample,com",
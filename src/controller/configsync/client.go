@@ -0,0 +1,117 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configsync
+
+import (
+	"fmt"
+	"net/url"
+
+	common_http "github.com/goharbor/harbor/src/common/http"
+	allowlist "github.com/goharbor/harbor/src/pkg/allowlist/models"
+	immumodel "github.com/goharbor/harbor/src/pkg/immutable/model"
+	label "github.com/goharbor/harbor/src/pkg/label/model"
+	"github.com/goharbor/harbor/src/pkg/reg/adapter/harbor/base"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+)
+
+// Project is the project skeleton of the primary instance
+type Project struct {
+	ID           int64                   `json:"project_id"`
+	Name         string                  `json:"name"`
+	RegistryID   int64                   `json:"registry_id"`
+	Metadata     map[string]string       `json:"metadata"`
+	CVEAllowlist *allowlist.CVEAllowlist `json:"cve_allowlist"`
+}
+
+// primary reads the configurations, labels, project skeletons and policies from the primary instance
+type primary interface {
+	// Configurations returns the values of the configurations
+	Configurations() (map[string]interface{}, error)
+	// GlobalLabels returns the system level labels
+	GlobalLabels() ([]*label.Label, error)
+	// Projects returns the project skeletons
+	Projects() ([]*Project, error)
+	// ImmutableRules returns the immutable tag rules of the project
+	ImmutableRules(projectName string) ([]*immumodel.Metadata, error)
+	// SystemCVEAllowlist returns the system level CVE allowlist
+	SystemCVEAllowlist() (*allowlist.CVEAllowlist, error)
+}
+
+// newPrimary creates the client of the primary instance with the credential of the registry endpoint,
+// the mutual TLS channel is used if the registry is peered
+func newPrimary(registry *model.Registry) (primary, error) {
+	adapter, err := base.New(registry)
+	if err != nil {
+		return nil, err
+	}
+	return &client{
+		basePath: adapter.Client.BasePath(),
+		c:        adapter.Client.C,
+	}, nil
+}
+
+type client struct {
+	basePath string
+	c        *common_http.Client
+}
+
+func (c *client) Configurations() (map[string]interface{}, error) {
+	items := map[string]*struct {
+		Value interface{} `json:"value"`
+	}{}
+	if err := c.c.Get(c.basePath+"/configurations", &items); err != nil {
+		return nil, err
+	}
+	values := map[string]interface{}{}
+	for key, item := range items {
+		if item != nil {
+			values[key] = item.Value
+		}
+	}
+	return values, nil
+}
+
+func (c *client) GlobalLabels() ([]*label.Label, error) {
+	labels := []*label.Label{}
+	if err := c.c.GetAndIteratePagination(c.basePath+"/labels?scope=g", &labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+func (c *client) Projects() ([]*Project, error) {
+	projects := []*Project{}
+	if err := c.c.GetAndIteratePagination(c.basePath+"/projects?with_detail=true", &projects); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+func (c *client) ImmutableRules(projectName string) ([]*immumodel.Metadata, error) {
+	rules := []*immumodel.Metadata{}
+	endpoint := fmt.Sprintf("%s/projects/%s/immutabletagrules", c.basePath, url.PathEscape(projectName))
+	if err := c.c.GetAndIteratePagination(endpoint, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (c *client) SystemCVEAllowlist() (*allowlist.CVEAllowlist, error) {
+	list := &allowlist.CVEAllowlist{}
+	if err := c.c.Get(c.basePath+"/system/CVEAllowlist", list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,585 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/controller/config"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/quota"
+	"github.com/goharbor/harbor/src/controller/user"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	libcfg "github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/allowlist"
+	"github.com/goharbor/harbor/src/pkg/immutable"
+	immumodel "github.com/goharbor/harbor/src/pkg/immutable/model"
+	"github.com/goharbor/harbor/src/pkg/label"
+	labelmodel "github.com/goharbor/harbor/src/pkg/label/model"
+	"github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/quota/types"
+	"github.com/goharbor/harbor/src/pkg/reg"
+	regmodel "github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
+	"github.com/goharbor/harbor/src/pkg/task"
+	userModels "github.com/goharbor/harbor/src/pkg/user/models"
+)
+
+const (
+	// VendorType is the vendor type of the executions of the config sync
+	VendorType = "CONFIG_SYNC"
+	// SchedulerCallback is the callback of the config sync schedule
+	SchedulerCallback = "CONFIG_SYNC_CALLBACK"
+
+	attrResult = "result"
+)
+
+var (
+	// SyncedConfigs are the configurations synced from the primary instance, the auth, storage and
+	// endpoint related configurations are instance specific and never synced
+	SyncedConfigs = []string{
+		common.ProjectCreationRestriction,
+		common.RobotNamePrefix,
+		common.RobotTokenDuration,
+		common.QuotaPerProjectEnable,
+		common.StoragePerProject,
+		common.NotificationEnable,
+		common.PullCountUpdateDisable,
+		common.PullTimeUpdateDisable,
+		common.SessionTimeout,
+		common.BannerMessage,
+	}
+	// syncedProjectMetadata are the metadata of the projects synced from the primary instance
+	syncedProjectMetadata = []string{
+		models.ProMetaPublic,
+		models.ProMetaEnableContentTrust,
+		models.ProMetaEnableContentTrustCosign,
+		models.ProMetaCosignTrustedKeys,
+		models.ProMetaContentTrustPolicy,
+		models.ProMetaPreventVul,
+		models.ProMetaSeverity,
+		models.ProMetaAutoScan,
+		models.ProMetaReuseSysCVEAllowlist,
+		models.ProMetaQuarantine,
+	}
+
+	// Ctl is a global config sync controller instance
+	Ctl = NewController()
+)
+
+func init() {
+	if err := scheduler.RegisterCallbackFunc(SchedulerCallback, syncCallback); err != nil {
+		log.Fatalf("failed to register the callback for the config sync schedule, error %v", err)
+	}
+	task.SetExecutionSweeperCount(VendorType, 50)
+}
+
+func syncCallback(ctx context.Context, _ string) error {
+	_, err := Ctl.Start(ctx, task.ExecutionTriggerSchedule)
+	return err
+}
+
+// Result is the result of a config sync, it's recorded in the execution
+type Result struct {
+	Configurations     int      `json:"configurations"`
+	LabelsCreated      int      `json:"labels_created"`
+	LabelsUpdated      int      `json:"labels_updated"`
+	LabelsDeleted      int      `json:"labels_deleted"`
+	ProjectsCreated    int      `json:"projects_created"`
+	ProjectsUpdated    int      `json:"projects_updated"`
+	ImmutableRules     int      `json:"immutable_rules"`
+	SystemCVEAllowlist bool     `json:"system_cve_allowlist"`
+	StaleProjects      []string `json:"stale_projects,omitempty"`
+	Errors             []string `json:"errors,omitempty"`
+}
+
+func (r *Result) addError(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	log.Error(msg)
+	r.Errors = append(r.Errors, msg)
+}
+
+// Controller syncs the configurations, the global labels, the project skeletons and the policies
+// from the primary instance specified by the registry endpoint in the configuration "config_sync_registry_id".
+// The synced resources are read-only locally while the sync is enabled
+type Controller interface {
+	// Start a config sync, it runs in the background and the result is recorded in the execution
+	Start(ctx context.Context, trigger string) (id int64, err error)
+	// Enabled returns whether the config sync is enabled
+	Enabled(ctx context.Context) bool
+	// CheckConfigs returns a forbidden error if any of the configurations is synced from the primary instance
+	CheckConfigs(ctx context.Context, conf map[string]interface{}) error
+	// CheckGlobal returns a forbidden error if the global labels and the system CVE allowlist are synced from the primary instance
+	CheckGlobal(ctx context.Context) error
+	// CheckProject returns a forbidden error if the project is synced from the primary instance
+	CheckProject(ctx context.Context, projectIDOrName interface{}) error
+}
+
+// NewController creates an instance of the default config sync controller
+func NewController() Controller {
+	return &controller{
+		exeMgr:       task.ExecMgr,
+		regMgr:       reg.Mgr,
+		configCtl:    config.Ctl,
+		labelMgr:     label.Mgr,
+		projectCtl:   project.Ctl,
+		quotaCtl:     quota.Ctl,
+		userCtl:      user.Ctl,
+		immutableMgr: immutable.Mgr,
+		allowlistMgr: allowlist.NewDefaultManager(),
+		newPrimary:   newPrimary,
+		makeCtx:      orm.Context,
+	}
+}
+
+type controller struct {
+	exeMgr       task.ExecutionManager
+	regMgr       reg.Manager
+	configCtl    config.Controller
+	labelMgr     label.Manager
+	projectCtl   project.Controller
+	quotaCtl     quota.Controller
+	userCtl      user.Controller
+	immutableMgr immutable.Manager
+	allowlistMgr allowlist.Manager
+	newPrimary   func(registry *regmodel.Registry) (primary, error)
+	makeCtx      func() context.Context
+}
+
+func (c *controller) Start(ctx context.Context, trigger string) (int64, error) {
+	registryID := libcfg.ConfigSyncRegistryID(ctx)
+	if registryID <= 0 {
+		return 0, errors.BadRequestError(nil).WithMessage("the config sync isn't enabled, set the %s first", common.ConfigSyncRegistryID)
+	}
+	registry, err := c.regMgr.Get(ctx, registryID)
+	if err != nil {
+		return 0, err
+	}
+	if registry.Type != regmodel.RegistryTypeHarbor {
+		return 0, errors.BadRequestError(nil).WithMessage("the primary instance %s must be a Harbor registry, got %s", registry.Name, registry.Type)
+	}
+	running, err := c.exeMgr.Count(ctx, q.New(q.KeyWords{
+		"VendorType": VendorType,
+		"Status":     job.RunningStatus.String(),
+	}))
+	if err != nil {
+		return 0, err
+	}
+	if running > 0 {
+		return 0, errors.ConflictError(nil).WithMessage("a config sync is already running")
+	}
+	id, err := c.exeMgr.Create(ctx, VendorType, -1, trigger, map[string]interface{}{
+		"registry_id": registryID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	go c.run(c.makeCtx(), id, registry)
+	return id, nil
+}
+
+// run syncs the resources from the primary instance, a failure of a resource doesn't stop
+// the sync of the others and all the failures are recorded in the result
+func (c *controller) run(ctx context.Context, id int64, registry *regmodel.Registry) {
+	result := &Result{}
+	if err := c.sync(ctx, registry, result); err != nil {
+		result.addError("failed to sync from the primary instance %s: %v", registry.Name, err)
+	}
+	if err := c.exeMgr.UpdateExtraAttrs(ctx, id, map[string]interface{}{
+		"registry_id": registry.ID,
+		attrResult:    result,
+	}); err != nil {
+		log.Errorf("failed to record the result of the config sync %d: %v", id, err)
+	}
+	if len(result.Errors) > 0 {
+		if err := c.exeMgr.MarkError(ctx, id, strings.Join(result.Errors, "; ")); err != nil {
+			log.Errorf("failed to mark the config sync %d as error: %v", id, err)
+		}
+		return
+	}
+	if err := c.exeMgr.MarkDone(ctx, id, "config sync completed"); err != nil {
+		log.Errorf("failed to mark the config sync %d as done: %v", id, err)
+	}
+}
+
+func (c *controller) sync(ctx context.Context, registry *regmodel.Registry, result *Result) error {
+	p, err := c.newPrimary(registry)
+	if err != nil {
+		return err
+	}
+	if err := c.syncConfigs(ctx, p, result); err != nil {
+		result.addError("failed to sync the configurations: %v", err)
+	}
+	if err := c.syncLabels(ctx, p, result); err != nil {
+		result.addError("failed to sync the global labels: %v", err)
+	}
+	if err := c.syncSystemCVEAllowlist(ctx, p, result); err != nil {
+		result.addError("failed to sync the system CVE allowlist: %v", err)
+	}
+	if err := c.syncProjects(ctx, p, result); err != nil {
+		result.addError("failed to sync the projects: %v", err)
+	}
+	return nil
+}
+
+func (c *controller) syncConfigs(ctx context.Context, p primary, result *Result) error {
+	remote, err := p.Configurations()
+	if err != nil {
+		return err
+	}
+	local, err := c.configCtl.AllConfigs(ctx)
+	if err != nil {
+		return err
+	}
+	changed := map[string]interface{}{}
+	for _, key := range SyncedConfigs {
+		value, ok := remote[key]
+		if !ok {
+			continue
+		}
+		if !equal(value, local[key]) {
+			changed[key] = value
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	if err := c.configCtl.UpdateUserConfigs(ctx, changed); err != nil {
+		return err
+	}
+	result.Configurations = len(changed)
+	return nil
+}
+
+func (c *controller) syncLabels(ctx context.Context, p primary, result *Result) error {
+	remote, err := p.GlobalLabels()
+	if err != nil {
+		return err
+	}
+	local, err := c.labelMgr.List(ctx, q.New(q.KeyWords{
+		"Level": common.LabelLevelUser,
+		"Scope": common.LabelScopeGlobal,
+	}))
+	if err != nil {
+		return err
+	}
+	locals := map[string]*labelmodel.Label{}
+	for _, l := range local {
+		locals[l.Name] = l
+	}
+	for _, r := range remote {
+		l, exist := locals[r.Name]
+		delete(locals, r.Name)
+		if !exist {
+			if _, err := c.labelMgr.Create(ctx, &labelmodel.Label{
+				Name:        r.Name,
+				Description: r.Description,
+				Color:       r.Color,
+				Level:       common.LabelLevelUser,
+				Scope:       common.LabelScopeGlobal,
+			}); err != nil {
+				result.addError("failed to create the label %s: %v", r.Name, err)
+				continue
+			}
+			result.LabelsCreated++
+			continue
+		}
+		if l.Description == r.Description && l.Color == r.Color {
+			continue
+		}
+		l.Description, l.Color = r.Description, r.Color
+		if err := c.labelMgr.Update(ctx, l); err != nil {
+			result.addError("failed to update the label %s: %v", r.Name, err)
+			continue
+		}
+		result.LabelsUpdated++
+	}
+	// the labels removed from the primary instance are removed locally as well
+	for _, l := range locals {
+		if err := c.labelMgr.RemoveFromAllArtifacts(ctx, l.ID); err != nil {
+			result.addError("failed to remove the label %s from the artifacts: %v", l.Name, err)
+			continue
+		}
+		if err := c.labelMgr.Delete(ctx, l.ID); err != nil {
+			result.addError("failed to delete the label %s: %v", l.Name, err)
+			continue
+		}
+		result.LabelsDeleted++
+	}
+	return nil
+}
+
+func (c *controller) syncSystemCVEAllowlist(ctx context.Context, p primary, result *Result) error {
+	remote, err := p.SystemCVEAllowlist()
+	if err != nil {
+		return err
+	}
+	local, err := c.allowlistMgr.GetSys(ctx)
+	if err != nil {
+		return err
+	}
+	if sameAllowlist(local.ExpiresAt, remote.ExpiresAt, local.CVESet(), remote.CVESet()) {
+		return nil
+	}
+	remote.ID, remote.ProjectID = 0, 0
+	if err := c.allowlistMgr.SetSys(ctx, *remote); err != nil {
+		return err
+	}
+	result.SystemCVEAllowlist = true
+	return nil
+}
+
+// syncProjects creates the missing projects and updates the metadata, the CVE allowlists and the immutable
+// rules of the synced projects. The proxy cache projects are instance specific and skipped, the projects
+// removed from the primary instance are reported only as they may contain artifacts
+func (c *controller) syncProjects(ctx context.Context, p primary, result *Result) error {
+	remote, err := p.Projects()
+	if err != nil {
+		return err
+	}
+	synced := map[string]struct{}{}
+	for _, r := range remote {
+		if r.RegistryID > 0 {
+			continue
+		}
+		synced[r.Name] = struct{}{}
+		if err := c.syncProject(ctx, p, r, result); err != nil {
+			result.addError("failed to sync the project %s: %v", r.Name, err)
+		}
+	}
+
+	local, err := c.projectCtl.List(ctx, q.New(q.KeyWords{models.ProMetaSyncedFromPrimary: true}))
+	if err != nil {
+		return err
+	}
+	for _, l := range local {
+		if _, ok := synced[l.Name]; !ok {
+			result.StaleProjects = append(result.StaleProjects, l.Name)
+		}
+	}
+	return nil
+}
+
+func (c *controller) syncProject(ctx context.Context, p primary, r *Project, result *Result) error {
+	meta := map[string]string{models.ProMetaSyncedFromPrimary: "true"}
+	for _, key := range syncedProjectMetadata {
+		if value, ok := r.Metadata[key]; ok {
+			meta[key] = value
+		}
+	}
+
+	local, err := c.projectCtl.Get(ctx, r.Name, project.WithCVEAllowlist())
+	if err != nil && !errors.IsNotFoundErr(err) {
+		return err
+	}
+	if local == nil {
+		if local, err = c.createProject(ctx, r.Name, meta); err != nil {
+			return err
+		}
+		result.ProjectsCreated++
+	}
+
+	changed := map[string]string{}
+	for key, value := range meta {
+		if v, ok := local.Metadata[key]; !ok || v != value {
+			changed[key] = value
+		}
+	}
+	update := &models.Project{ProjectID: local.ProjectID, Metadata: changed}
+	if r.CVEAllowlist != nil && !sameAllowlist(local.CVEAllowlist.ExpiresAt, r.CVEAllowlist.ExpiresAt,
+		local.CVEAllowlist.CVESet(), r.CVEAllowlist.CVESet()) {
+		update.CVEAllowlist = *r.CVEAllowlist
+		update.CVEAllowlist.ID = 0
+		update.CVEAllowlist.ProjectID = local.ProjectID
+	}
+	if len(update.Metadata) > 0 || update.CVEAllowlist.ProjectID == local.ProjectID {
+		if err := c.projectCtl.Update(ctx, update); err != nil {
+			return err
+		}
+		result.ProjectsUpdated++
+	}
+
+	replaced, err := c.syncImmutableRules(ctx, p, r.Name, local.ProjectID)
+	if err != nil {
+		return err
+	}
+	if replaced {
+		result.ImmutableRules++
+	}
+	return nil
+}
+
+func (c *controller) createProject(ctx context.Context, name string, meta map[string]string) (*models.Project, error) {
+	// the synced projects are owned by the system admin with the minimum ID, in most case, it's 1
+	admins, err := c.userCtl.List(ctx, &q.Query{
+		Keywords: map[string]interface{}{"sysadmin_flag": true},
+		Sorts:    []*q.Sort{q.NewSort("user_id", false)},
+	}, userModels.WithDefaultAdmin())
+	if err != nil {
+		return nil, err
+	}
+	if len(admins) == 0 {
+		return nil, errors.New(nil).WithMessage("cannot create project as no system admin found")
+	}
+	p := &models.Project{
+		Name:     name,
+		OwnerID:  admins[0].UserID,
+		Metadata: meta,
+	}
+	id, err := c.projectCtl.Create(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	if libcfg.QuotaPerProjectEnable(ctx) {
+		setting, err := libcfg.QuotaSetting(ctx)
+		if err != nil {
+			return nil, err
+		}
+		hardLimits := types.ResourceList{
+			types.ResourceStorage: setting.StoragePerProject,
+			types.ResourceCount:   types.UNLIMITED,
+		}
+		if _, err := c.quotaCtl.Create(ctx, quota.ProjectReference, quota.ReferenceID(id), hardLimits); err != nil {
+			return nil, fmt.Errorf("failed to create quota for project: %v", err)
+		}
+	}
+	p.ProjectID = id
+	return p, nil
+}
+
+// syncImmutableRules replaces the immutable rules of the project with the ones of the primary instance when they differ
+func (c *controller) syncImmutableRules(ctx context.Context, p primary, projectName string, projectID int64) (bool, error) {
+	remote, err := p.ImmutableRules(projectName)
+	if err != nil {
+		return false, err
+	}
+	local, err := c.immutableMgr.ListImmutableRules(ctx, q.New(q.KeyWords{"ProjectID": projectID}))
+	if err != nil {
+		return false, err
+	}
+	if sameRules(local, remote) {
+		return false, nil
+	}
+	for _, rule := range local {
+		if err := c.immutableMgr.DeleteImmutableRule(ctx, rule.ID); err != nil {
+			return false, err
+		}
+	}
+	for _, rule := range remote {
+		rule.ID, rule.ProjectID = 0, projectID
+		if _, err := c.immutableMgr.CreateImmutableRule(ctx, rule); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (c *controller) Enabled(ctx context.Context) bool {
+	return libcfg.ConfigSyncRegistryID(ctx) > 0
+}
+
+func (c *controller) CheckConfigs(ctx context.Context, conf map[string]interface{}) error {
+	if !c.Enabled(ctx) {
+		return nil
+	}
+	for _, key := range SyncedConfigs {
+		if _, ok := conf[key]; ok {
+			return readOnlyError("the configuration %s", key)
+		}
+	}
+	return nil
+}
+
+func (c *controller) CheckGlobal(ctx context.Context) error {
+	if !c.Enabled(ctx) {
+		return nil
+	}
+	return readOnlyError("the global labels and the system CVE allowlist")
+}
+
+func (c *controller) CheckProject(ctx context.Context, projectIDOrName interface{}) error {
+	if !c.Enabled(ctx) {
+		return nil
+	}
+	p, err := c.projectCtl.Get(ctx, projectIDOrName)
+	if err != nil {
+		return err
+	}
+	if synced, _ := p.GetMetadata(models.ProMetaSyncedFromPrimary); synced == "true" {
+		return readOnlyError("the project %s", p.Name)
+	}
+	return nil
+}
+
+func readOnlyError(format string, args ...interface{}) error {
+	return errors.ForbiddenError(nil).WithMessage("%s is managed by the primary instance and read-only", fmt.Sprintf(format, args...))
+}
+
+// equal compares the configuration values in their JSON form, as the numbers read from the primary instance are float64
+func equal(a, b interface{}) bool {
+	x, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	y, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(x) == string(y)
+}
+
+func sameAllowlist(expiresAtA, expiresAtB *int64, a, b map[string]struct{}) bool {
+	if (expiresAtA == nil) != (expiresAtB == nil) {
+		return false
+	}
+	if expiresAtA != nil && *expiresAtA != *expiresAtB {
+		return false
+	}
+	if len(a) != len(b) {
+		return false
+	}
+	for cve := range a {
+		if _, ok := b[cve]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// sameRules compares the immutable rules ignoring the IDs
+func sameRules(a, b []*immumodel.Metadata) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	strip := func(rules []*immumodel.Metadata) string {
+		var list []immumodel.Metadata
+		for _, rule := range rules {
+			r := *rule
+			r.ID, r.ProjectID = 0, 0
+			list = append(list, r)
+		}
+		data, _ := json.Marshal(list)
+		return string(data)
+	}
+	return strip(a) == strip(b)
+}
@@ -0,0 +1,252 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configsync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common"
+	commonmodels "github.com/goharbor/harbor/src/common/models"
+	libcfg "github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	allowlistmodels "github.com/goharbor/harbor/src/pkg/allowlist/models"
+	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
+	immumodel "github.com/goharbor/harbor/src/pkg/immutable/model"
+	labelmodel "github.com/goharbor/harbor/src/pkg/label/model"
+	"github.com/goharbor/harbor/src/pkg/project/models"
+	configtesting "github.com/goharbor/harbor/src/testing/controller/config"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	quotatesting "github.com/goharbor/harbor/src/testing/controller/quota"
+	usertesting "github.com/goharbor/harbor/src/testing/controller/user"
+	"github.com/goharbor/harbor/src/testing/mock"
+	allowlisttesting "github.com/goharbor/harbor/src/testing/pkg/allowlist"
+	immutabletesting "github.com/goharbor/harbor/src/testing/pkg/immutable"
+	labeltesting "github.com/goharbor/harbor/src/testing/pkg/label"
+	regtesting "github.com/goharbor/harbor/src/testing/pkg/reg"
+	tasktesting "github.com/goharbor/harbor/src/testing/pkg/task"
+)
+
+type fakePrimary struct {
+	configs   map[string]interface{}
+	labels    []*labelmodel.Label
+	projects  []*Project
+	rules     map[string][]*immumodel.Metadata
+	allowlist *allowlistmodels.CVEAllowlist
+}
+
+func (f *fakePrimary) Configurations() (map[string]interface{}, error) {
+	return f.configs, nil
+}
+
+func (f *fakePrimary) GlobalLabels() ([]*labelmodel.Label, error) {
+	return f.labels, nil
+}
+
+func (f *fakePrimary) Projects() ([]*Project, error) {
+	return f.projects, nil
+}
+
+func (f *fakePrimary) ImmutableRules(projectName string) ([]*immumodel.Metadata, error) {
+	return f.rules[projectName], nil
+}
+
+func (f *fakePrimary) SystemCVEAllowlist() (*allowlistmodels.CVEAllowlist, error) {
+	return f.allowlist, nil
+}
+
+type controllerTestSuite struct {
+	suite.Suite
+	ctl          *controller
+	exeMgr       *tasktesting.ExecutionManager
+	regMgr       *regtesting.Manager
+	configCtl    *configtesting.Controller
+	labelMgr     *labeltesting.Manager
+	projectCtl   *projecttesting.Controller
+	quotaCtl     *quotatesting.Controller
+	userCtl      *usertesting.Controller
+	immutableMgr *immutabletesting.Manager
+	allowlistMgr *allowlisttesting.Manager
+}
+
+func (c *controllerTestSuite) SetupTest() {
+	libcfg.InitWithSettings(map[string]interface{}{
+		common.ConfigSyncRegistryID:  1,
+		common.QuotaPerProjectEnable: false,
+	})
+	c.exeMgr = &tasktesting.ExecutionManager{}
+	c.regMgr = &regtesting.Manager{}
+	c.configCtl = &configtesting.Controller{}
+	c.labelMgr = &labeltesting.Manager{}
+	c.projectCtl = &projecttesting.Controller{}
+	c.quotaCtl = &quotatesting.Controller{}
+	c.userCtl = &usertesting.Controller{}
+	c.immutableMgr = &immutabletesting.Manager{}
+	c.allowlistMgr = &allowlisttesting.Manager{}
+	c.ctl = &controller{
+		exeMgr:       c.exeMgr,
+		regMgr:       c.regMgr,
+		configCtl:    c.configCtl,
+		labelMgr:     c.labelMgr,
+		projectCtl:   c.projectCtl,
+		quotaCtl:     c.quotaCtl,
+		userCtl:      c.userCtl,
+		immutableMgr: c.immutableMgr,
+		allowlistMgr: c.allowlistMgr,
+		makeCtx:      context.TODO,
+	}
+}
+
+func (c *controllerTestSuite) TestSyncConfigs() {
+	p := &fakePrimary{configs: map[string]interface{}{
+		common.ProjectCreationRestriction: "adminonly",
+		common.RobotTokenDuration:         float64(30),
+		common.AUTHMode:                   "ldap_auth",
+	}}
+	c.configCtl.On("AllConfigs", mock.Anything).Return(map[string]interface{}{
+		common.ProjectCreationRestriction: "everyone",
+		common.RobotTokenDuration:         int64(30),
+		common.AUTHMode:                   "db_auth",
+	}, nil)
+	c.configCtl.On("UpdateUserConfigs", mock.Anything, map[string]interface{}{
+		common.ProjectCreationRestriction: "adminonly",
+	}).Return(nil)
+
+	result := &Result{}
+	c.Require().Nil(c.ctl.syncConfigs(context.TODO(), p, result))
+	c.Equal(1, result.Configurations)
+	c.configCtl.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestSyncLabels() {
+	p := &fakePrimary{labels: []*labelmodel.Label{
+		{Name: "prod", Color: "#ff0000"},
+		{Name: "dev", Color: "#00ff00"},
+	}}
+	c.labelMgr.On("List", mock.Anything, mock.Anything).Return([]*labelmodel.Label{
+		{ID: 1, Name: "dev", Color: "#0000ff"},
+		{ID: 2, Name: "stale"},
+	}, nil)
+	c.labelMgr.On("Create", mock.Anything, mock.MatchedBy(func(l *labelmodel.Label) bool {
+		return l.Name == "prod" && l.Scope == common.LabelScopeGlobal && l.Level == common.LabelLevelUser
+	})).Return(int64(3), nil)
+	c.labelMgr.On("Update", mock.Anything, mock.MatchedBy(func(l *labelmodel.Label) bool {
+		return l.ID == 1 && l.Color == "#00ff00"
+	})).Return(nil)
+	c.labelMgr.On("RemoveFromAllArtifacts", mock.Anything, int64(2)).Return(nil)
+	c.labelMgr.On("Delete", mock.Anything, int64(2)).Return(nil)
+
+	result := &Result{}
+	c.Require().Nil(c.ctl.syncLabels(context.TODO(), p, result))
+	c.Equal(1, result.LabelsCreated)
+	c.Equal(1, result.LabelsUpdated)
+	c.Equal(1, result.LabelsDeleted)
+	c.Empty(result.Errors)
+	c.labelMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestSyncProjects() {
+	rule := &immumodel.Metadata{ID: 10, ProjectID: 5, Action: "immutable", Template: "immutable_template"}
+	p := &fakePrimary{
+		projects: []*Project{
+			{ID: 5, Name: "library", Metadata: map[string]string{
+				models.ProMetaPublic:        "true",
+				models.ProMetaProxyCacheTTL: "60",
+			}},
+			{ID: 6, Name: "proxy", RegistryID: 2},
+		},
+		rules: map[string][]*immumodel.Metadata{"library": {rule}},
+	}
+	c.projectCtl.On("Get", mock.Anything, "library", mock.Anything).Return(nil, errors.NotFoundError(nil))
+	c.userCtl.On("List", mock.Anything, mock.Anything, mock.Anything).Return([]*commonmodels.User{{UserID: 1}}, nil)
+	c.projectCtl.On("Create", mock.Anything, mock.MatchedBy(func(p *models.Project) bool {
+		_, proxy := p.Metadata[models.ProMetaProxyCacheTTL]
+		return p.Name == "library" && p.OwnerID == 1 && !proxy &&
+			p.Metadata[models.ProMetaPublic] == "true" && p.Metadata[models.ProMetaSyncedFromPrimary] == "true"
+	})).Return(int64(1), nil)
+	c.immutableMgr.On("ListImmutableRules", mock.Anything, mock.Anything).Return(nil, nil)
+	c.immutableMgr.On("CreateImmutableRule", mock.Anything, mock.MatchedBy(func(m *immumodel.Metadata) bool {
+		return m.ID == 0 && m.ProjectID == 1
+	})).Return(int64(1), nil)
+	c.projectCtl.On("List", mock.Anything, mock.Anything).Return([]*models.Project{
+		{ProjectID: 1, Name: "library"},
+		{ProjectID: 2, Name: "removed"},
+	}, nil)
+
+	result := &Result{}
+	c.Require().Nil(c.ctl.syncProjects(context.TODO(), p, result))
+	c.Equal(1, result.ProjectsCreated)
+	c.Equal(0, result.ProjectsUpdated)
+	c.Equal(1, result.ImmutableRules)
+	c.Equal([]string{"removed"}, result.StaleProjects)
+	c.Empty(result.Errors)
+	c.projectCtl.AssertExpectations(c.T())
+	c.immutableMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestSyncProjectUnchanged() {
+	rule := &immumodel.Metadata{ID: 10, ProjectID: 5, Action: "immutable", Template: "immutable_template"}
+	p := &fakePrimary{rules: map[string][]*immumodel.Metadata{"library": {rule}}}
+	c.projectCtl.On("Get", mock.Anything, "library", mock.Anything).Return(&models.Project{
+		ProjectID: 1,
+		Name:      "library",
+		Metadata:  map[string]string{models.ProMetaPublic: "true", models.ProMetaSyncedFromPrimary: "true"},
+	}, nil)
+	c.immutableMgr.On("ListImmutableRules", mock.Anything, mock.Anything).Return([]*immumodel.Metadata{
+		{ID: 3, ProjectID: 1, Action: "immutable", Template: "immutable_template"},
+	}, nil)
+
+	result := &Result{}
+	c.Require().Nil(c.ctl.syncProject(context.TODO(), p, &Project{
+		Name:         "library",
+		Metadata:     map[string]string{models.ProMetaPublic: "true"},
+		CVEAllowlist: &allowlistmodels.CVEAllowlist{},
+	}, result))
+	c.Equal(0, result.ProjectsUpdated)
+	c.Equal(0, result.ImmutableRules)
+	c.projectCtl.AssertNotCalled(c.T(), "Update", mock.Anything, mock.Anything)
+	c.immutableMgr.AssertNotCalled(c.T(), "DeleteImmutableRule", mock.Anything, mock.Anything)
+}
+
+func (c *controllerTestSuite) TestCheck() {
+	c.Nil(c.ctl.CheckConfigs(context.TODO(), map[string]interface{}{common.AUTHMode: "ldap_auth"}))
+	err := c.ctl.CheckConfigs(context.TODO(), map[string]interface{}{common.RobotNamePrefix: "bot$"})
+	c.True(errors.IsErr(err, errors.ForbiddenCode))
+	c.True(errors.IsErr(c.ctl.CheckGlobal(context.TODO()), errors.ForbiddenCode))
+
+	c.projectCtl.On("Get", mock.Anything, int64(1), mock.Anything).Return(&models.Project{
+		ProjectID: 1,
+		Metadata:  map[string]string{models.ProMetaSyncedFromPrimary: "true"},
+	}, nil)
+	c.projectCtl.On("Get", mock.Anything, int64(2), mock.Anything).Return(&models.Project{ProjectID: 2}, nil)
+	c.True(errors.IsErr(c.ctl.CheckProject(context.TODO(), int64(1)), errors.ForbiddenCode))
+	c.Nil(c.ctl.CheckProject(context.TODO(), int64(2)))
+
+	libcfg.InitWithSettings(map[string]interface{}{common.ConfigSyncRegistryID: 0})
+	c.Nil(c.ctl.CheckGlobal(context.TODO()))
+	c.Nil(c.ctl.CheckProject(context.TODO(), int64(1)))
+}
+
+func (c *controllerTestSuite) TestStartNotEnabled() {
+	libcfg.InitWithSettings(map[string]interface{}{common.ConfigSyncRegistryID: 0})
+	_, err := c.ctl.Start(context.TODO(), "MANUAL")
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &controllerTestSuite{})
+}
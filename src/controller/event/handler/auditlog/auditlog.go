@@ -45,7 +45,7 @@ func (h *Handler) Handle(ctx context.Context, value interface{}) error {
 	switch v := value.(type) {
 	case *event.PushArtifactEvent, *event.DeleteArtifactEvent,
 		*event.DeleteRepositoryEvent, *event.CreateProjectEvent, *event.DeleteProjectEvent,
-		*event.DeleteTagEvent, *event.CreateTagEvent:
+		*event.DeleteTagEvent, *event.CreateTagEvent, *event.ResourceChangeEvent:
 		addAuditLog = true
 	case *event.PullArtifactEvent:
 		addAuditLog = !config.PullAuditLogDisable(ctx)
@@ -62,10 +62,8 @@ func (h *Handler) Handle(ctx context.Context, value interface{}) error {
 		}
 		auditLog = al
 		if auditLog != nil {
-			_, err := audit.Mgr.Create(ctx, auditLog)
-			if err != nil {
-				log.Debugf("add audit log err: %v", err)
-			}
+			// written in batch in the background to keep the insertion off the request path
+			audit.Writer.Write(ctx, auditLog)
 		}
 	}
 	return nil
@@ -7,11 +7,15 @@ import (
 	"github.com/goharbor/harbor/src/controller/event/handler/auditlog"
 	"github.com/goharbor/harbor/src/controller/event/handler/internal"
 	"github.com/goharbor/harbor/src/controller/event/handler/p2p"
+	"github.com/goharbor/harbor/src/controller/event/handler/quarantine"
 	"github.com/goharbor/harbor/src/controller/event/handler/replication"
+	"github.com/goharbor/harbor/src/controller/event/handler/statistic"
+	"github.com/goharbor/harbor/src/controller/event/handler/usage"
 	"github.com/goharbor/harbor/src/controller/event/handler/webhook/artifact"
 	"github.com/goharbor/harbor/src/controller/event/handler/webhook/chart"
 	"github.com/goharbor/harbor/src/controller/event/handler/webhook/quota"
 	"github.com/goharbor/harbor/src/controller/event/handler/webhook/scan"
+	"github.com/goharbor/harbor/src/controller/event/handler/webhook/system"
 	"github.com/goharbor/harbor/src/controller/event/metadata"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/pkg/notification"
@@ -35,6 +39,10 @@ func init() {
 	_ = notifier.Subscribe(event.TopicDeleteArtifact, &scan.DelArtHandler{})
 	_ = notifier.Subscribe(event.TopicReplication, &artifact.ReplicationHandler{})
 	_ = notifier.Subscribe(event.TopicTagRetention, &artifact.RetentionHandler{})
+	_ = notifier.Subscribe(event.TopicCreateUser, &system.Handler{})
+	_ = notifier.Subscribe(event.TopicCreateProject, &system.Handler{})
+	_ = notifier.Subscribe(event.TopicGarbageCollection, &system.Handler{})
+	_ = notifier.Subscribe(event.TopicBackup, &system.Handler{})
 
 	// replication
 	_ = notifier.Subscribe(event.TopicPushArtifact, &replication.Handler{})
@@ -56,6 +64,24 @@ func init() {
 	_ = notifier.Subscribe(event.TopicDeleteRepository, &auditlog.Handler{})
 	_ = notifier.Subscribe(event.TopicCreateTag, &auditlog.Handler{})
 	_ = notifier.Subscribe(event.TopicDeleteTag, &auditlog.Handler{})
+	_ = notifier.Subscribe(event.TopicResourceChange, &auditlog.Handler{})
+
+	// project statistics
+	_ = notifier.Subscribe(event.TopicCreateProject, &statistic.Handler{})
+	_ = notifier.Subscribe(event.TopicDeleteProject, &statistic.Handler{})
+	_ = notifier.Subscribe(event.TopicDeleteRepository, &statistic.Handler{})
+	_ = notifier.Subscribe(event.TopicPushArtifact, &statistic.Handler{})
+	_ = notifier.Subscribe(event.TopicDeleteArtifact, &statistic.Handler{})
+	_ = notifier.Subscribe(event.TopicCreateTag, &statistic.Handler{})
+	_ = notifier.Subscribe(event.TopicDeleteTag, &statistic.Handler{})
+
+	// quarantine
+	_ = notifier.Subscribe(event.TopicPushArtifact, &quarantine.Handler{})
+	_ = notifier.Subscribe(event.TopicScanningCompleted, &quarantine.Handler{})
+
+	// pull and push usages
+	_ = notifier.Subscribe(event.TopicPullArtifact, &usage.Handler{})
+	_ = notifier.Subscribe(event.TopicPushArtifact, &usage.Handler{})
 
 	// internal
 	_ = notifier.Subscribe(event.TopicPullArtifact, &internal.Handler{})
@@ -71,7 +71,10 @@ type Handler struct {
 	// pullTimeStore caches the latest pull time group by artifact
 	// map[artifactID:tagName]time
 	pullTimeStore map[string]time.Time
-	// pullTimeLock mutex for pullTimeStore
+	// repoPullTimeStore caches the latest pull time group by repository
+	// map[repositoryID]time
+	repoPullTimeStore map[int64]time.Time
+	// pullTimeLock mutex for pullTimeStore and repoPullTimeStore
 	pullTimeLock sync.Mutex
 }
 
@@ -107,7 +110,9 @@ func (a *Handler) onPull(ctx context.Context, event *event.ArtifactEvent) error
 		}
 
 		if !config.PullTimeUpdateDisable(ctx) {
-			a.syncFlushPullTime(ctx, event.Artifact.ID, tagName, time.Now())
+			now := time.Now()
+			a.syncFlushPullTime(ctx, event.Artifact.ID, tagName, now)
+			a.syncFlushRepoPullTime(ctx, event.Artifact.RepositoryID, now)
 		}
 
 		if !config.PullCountUpdateDisable(ctx) {
@@ -121,6 +126,7 @@ func (a *Handler) onPull(ctx context.Context, event *event.ArtifactEvent) error
 	a.once.Do(func() {
 		if !config.PullTimeUpdateDisable(ctx) {
 			a.pullTimeStore = make(map[string]time.Time)
+			a.repoPullTimeStore = make(map[int64]time.Time)
 			go a.asyncFlushPullTime(orm.Context())
 		}
 
@@ -152,7 +158,9 @@ func (a *Handler) updatePullTimeInCache(ctx context.Context, event *event.Artifa
 	a.pullTimeLock.Lock()
 	defer a.pullTimeLock.Unlock()
 
-	a.pullTimeStore[key] = time.Now()
+	now := time.Now()
+	a.pullTimeStore[key] = now
+	a.repoPullTimeStore[event.Artifact.RepositoryID] = now
 }
 
 func (a *Handler) addPullCountInCache(ctx context.Context, event *event.ArtifactEvent) {
@@ -185,6 +193,12 @@ func (a *Handler) syncFlushPullTime(ctx context.Context, artifactID int64, tagNa
 	}
 }
 
+func (a *Handler) syncFlushRepoPullTime(ctx context.Context, repositoryID int64, time time.Time) {
+	if err := repository.Ctl.UpdatePullTime(ctx, repositoryID, time); err != nil {
+		log.Warningf("failed to update pull time for repository %d, %v", repositoryID, err)
+	}
+}
+
 func (a *Handler) syncFlushPullCount(ctx context.Context, repositoryID int64, count uint64) {
 	if err := repository.Ctl.AddPullCount(ctx, repositoryID, count); err != nil {
 		log.Warningf("failed to add pull count repository %d, %v", repositoryID, err)
@@ -212,7 +226,12 @@ func (a *Handler) asyncFlushPullTime(ctx context.Context) {
 			a.syncFlushPullTime(ctx, artifactID, tagName, time)
 		}
 
+		for repositoryID, time := range a.repoPullTimeStore {
+			a.syncFlushRepoPullTime(ctx, repositoryID, time)
+		}
+
 		a.pullTimeStore = make(map[string]time.Time)
+		a.repoPullTimeStore = make(map[int64]time.Time)
 		a.pullTimeLock.Unlock()
 	}
 }
@@ -232,6 +251,17 @@ func (a *Handler) asyncFlushPullCount(ctx context.Context) {
 }
 
 func (a *Handler) onPush(ctx context.Context, event *event.ArtifactEvent) error {
+	// the pushes are much less frequent than the pulls, update the push time of the repository directly
+	if event.Artifact.RepositoryID > 0 {
+		pushTime := event.OccurAt
+		if pushTime.IsZero() {
+			pushTime = time.Now()
+		}
+		if err := repository.Ctl.UpdatePushTime(ctx, event.Artifact.RepositoryID, pushTime); err != nil {
+			log.Warningf("failed to update push time for repository %d, %v", event.Artifact.RepositoryID, err)
+		}
+	}
+
 	go func() {
 		if err := autoScan(ctx, &artifact.Artifact{Artifact: *event.Artifact}, event.Tags...); err != nil {
 			log.Errorf("scan artifact %s@%s failed, error: %v", event.Artifact.RepositoryName, event.Artifact.Digest, err)
@@ -98,6 +98,13 @@ func (suite *ArtifactHandlerTestSuite) TestDefaultAsyncFlushDuration() {
 func (suite *ArtifactHandlerTestSuite) TestOnPush() {
 	err := suite.handler.onPush(context.TODO(), &event.ArtifactEvent{Artifact: &artifact.Artifact{}})
 	suite.Nil(err, "onPush should return nil")
+
+	pushTime := time.Now().Truncate(time.Second)
+	err = suite.handler.onPush(suite.ctx, &event.ArtifactEvent{Artifact: &artifact.Artifact{ID: 1, RepositoryID: 1}, OccurAt: pushTime})
+	suite.Nil(err, "onPush should return nil")
+	repository, err := pkg.RepositoryMgr.Get(suite.ctx, 1)
+	suite.Nil(err)
+	suite.True(pushTime.Equal(repository.PushTime), "update repository push_time")
 }
 
 // TestOnPull tests handler pull events.
@@ -116,6 +123,7 @@ func (suite *ArtifactHandlerTestSuite) TestOnPull() {
 	repository, err := pkg.RepositoryMgr.Get(suite.ctx, 1)
 	suite.Nil(err)
 	suite.Equal(int64(1), repository.PullCount, "sync update pull_count")
+	suite.False(repository.PullTime.IsZero(), "sync update repository pull_time")
 
 	// test async mode
 	asyncFlushDuration = 200 * time.Millisecond
@@ -142,4 +150,10 @@ func (suite *ArtifactHandlerTestSuite) TestOnPull() {
 		suite.Nil(err)
 		return int64(2) == repository.PullCount
 	}, 3*asyncFlushDuration, asyncFlushDuration/2, "wait for pull_count async update")
+
+	suite.Eventually(func() bool {
+		repository, err = pkg.RepositoryMgr.Get(suite.ctx, 1)
+		suite.Nil(err)
+		return repository.PullTime.After(lastPullTime)
+	}, 3*asyncFlushDuration, asyncFlushDuration/2, "wait for repository pull_time async update")
 }
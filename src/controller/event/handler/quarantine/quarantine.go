@@ -0,0 +1,60 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quarantine
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/quarantine"
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+// Handler evaluates the quarantined artifacts of the repository when the artifacts are pushed or scanned,
+// as the signatures are pushed as the separate artifacts after their subject artifacts
+type Handler struct {
+}
+
+// Name ...
+func (h *Handler) Name() string {
+	return "Quarantine"
+}
+
+// Handle ...
+func (h *Handler) Handle(ctx context.Context, value interface{}) error {
+	var repository string
+	switch v := value.(type) {
+	case *event.PushArtifactEvent:
+		if v.Artifact != nil {
+			repository = v.Artifact.RepositoryName
+		}
+	case *event.ScanImageEvent:
+		if v.Artifact != nil {
+			repository = v.Artifact.Repository
+		}
+	default:
+		log.Errorf("Can not handler this event type! %#v", v)
+		return nil
+	}
+	if len(repository) == 0 {
+		return nil
+	}
+	return quarantine.Ctl.EvaluateRepository(ctx, repository)
+}
+
+// IsStateful ...
+func (h *Handler) IsStateful() bool {
+	return false
+}
@@ -0,0 +1,66 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quarantine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/quarantine"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	quarantinetesting "github.com/goharbor/harbor/src/testing/controller/quarantine"
+	"github.com/goharbor/harbor/src/testing/mock"
+)
+
+type handlerTestSuite struct {
+	suite.Suite
+	originCtl quarantine.Controller
+	ctl       *quarantinetesting.Controller
+	handler   *Handler
+}
+
+func (h *handlerTestSuite) SetupTest() {
+	h.originCtl = quarantine.Ctl
+	h.ctl = &quarantinetesting.Controller{}
+	quarantine.Ctl = h.ctl
+	h.handler = &Handler{}
+}
+
+func (h *handlerTestSuite) TearDownTest() {
+	quarantine.Ctl = h.originCtl
+}
+
+func (h *handlerTestSuite) TestHandle() {
+	h.ctl.On("EvaluateRepository", mock.Anything, "library/hello-world").Return(nil)
+	h.ctl.On("EvaluateRepository", mock.Anything, "library/photon").Return(nil)
+
+	h.Nil(h.handler.Handle(context.TODO(), &event.PushArtifactEvent{
+		ArtifactEvent: &event.ArtifactEvent{Artifact: &artifact.Artifact{RepositoryName: "library/hello-world"}},
+	}))
+	h.Nil(h.handler.Handle(context.TODO(), &event.ScanImageEvent{Artifact: &v1.Artifact{Repository: "library/photon"}}))
+	h.ctl.AssertExpectations(h.T())
+
+	// the other events are ignored
+	h.Nil(h.handler.Handle(context.TODO(), &event.PullArtifactEvent{}))
+	h.ctl.AssertNumberOfCalls(h.T(), "EvaluateRepository", 2)
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &handlerTestSuite{})
+}
@@ -23,7 +23,7 @@ import (
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
-	"github.com/goharbor/harbor/src/pkg/label"
+	"github.com/goharbor/harbor/src/pkg"
 	labmodel "github.com/goharbor/harbor/src/pkg/label/model"
 	"github.com/goharbor/harbor/src/pkg/reg/model"
 )
@@ -83,7 +83,7 @@ func (r *Handler) handlePushArtifact(ctx context.Context, event *event.PushArtif
 	}
 	public = prj.IsPublic()
 	// list attached labels
-	labels, err := label.Mgr.ListByArtifact(ctx, art.ID)
+	labels, err := pkg.LabelMgr.ListByArtifact(ctx, art.ID)
 	if err != nil {
 		log.Errorf("failed to list artifact %d labels, error: %v", art.ID, err)
 		return err
@@ -116,7 +116,7 @@ func (r *Handler) handlePushArtifact(ctx context.Context, event *event.PushArtif
 func (r *Handler) handleDeleteArtifact(ctx context.Context, event *event.DeleteArtifactEvent) error {
 	art := event.Artifact
 	// list attached labels
-	labels, err := label.Mgr.ListByArtifact(ctx, art.ID)
+	labels, err := pkg.LabelMgr.ListByArtifact(ctx, art.ID)
 	if err != nil {
 		log.Errorf("failed to list artifact %d labels, error: %v", art.ID, err)
 		return err
@@ -154,7 +154,7 @@ func (r *Handler) handleCreateTag(ctx context.Context, event *event.CreateTagEve
 	}
 	public = prj.IsPublic()
 	// list attached labels
-	labels, err := label.Mgr.ListByArtifact(ctx, art.ID)
+	labels, err := pkg.LabelMgr.ListByArtifact(ctx, art.ID)
 	if err != nil {
 		log.Errorf("failed to list artifact %d labels, error: %v", art.ID, err)
 		return err
@@ -187,7 +187,7 @@ func (r *Handler) handleCreateTag(ctx context.Context, event *event.CreateTagEve
 func (r *Handler) handleDeleteTag(ctx context.Context, event *event.DeleteTagEvent) error {
 	art := event.AttachedArtifact
 	// list attached labels
-	labels, err := label.Mgr.ListByArtifact(ctx, art.ID)
+	labels, err := pkg.LabelMgr.ListByArtifact(ctx, art.ID)
 	if err != nil {
 		log.Errorf("failed to list artifact %d labels, error: %v", art.ID, err)
 		return err
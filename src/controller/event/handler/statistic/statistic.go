@@ -0,0 +1,72 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistic
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/statistic"
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+// Handler marks the statistics of the projects changed by the events to be refreshed
+type Handler struct {
+}
+
+// Name ...
+func (h *Handler) Name() string {
+	return "ProjectStatistic"
+}
+
+// Handle ...
+func (h *Handler) Handle(ctx context.Context, value interface{}) error {
+	var projectID int64
+	switch v := value.(type) {
+	case *event.CreateProjectEvent:
+		projectID = v.ProjectID
+	case *event.DeleteProjectEvent:
+		projectID = v.ProjectID
+	case *event.DeleteRepositoryEvent:
+		projectID = v.ProjectID
+	case *event.PushArtifactEvent:
+		if v.Artifact != nil {
+			projectID = v.Artifact.ProjectID
+		}
+	case *event.DeleteArtifactEvent:
+		if v.Artifact != nil {
+			projectID = v.Artifact.ProjectID
+		}
+	case *event.CreateTagEvent:
+		if v.AttachedArtifact != nil {
+			projectID = v.AttachedArtifact.ProjectID
+		}
+	case *event.DeleteTagEvent:
+		if v.AttachedArtifact != nil {
+			projectID = v.AttachedArtifact.ProjectID
+		}
+	default:
+		log.Errorf("Can not handler this event type! %#v", v)
+		return nil
+	}
+
+	statistic.Ctl.MarkDirty(projectID)
+	return nil
+}
+
+// IsStateful ...
+func (h *Handler) IsStateful() bool {
+	return false
+}
@@ -0,0 +1,66 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/statistic"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	mockStatistic "github.com/goharbor/harbor/src/testing/controller/statistic"
+)
+
+type handlerTestSuite struct {
+	suite.Suite
+	originCtl statistic.Controller
+	ctl       *mockStatistic.Controller
+	handler   *Handler
+}
+
+func (h *handlerTestSuite) SetupTest() {
+	h.originCtl = statistic.Ctl
+	h.ctl = &mockStatistic.Controller{}
+	statistic.Ctl = h.ctl
+	h.handler = &Handler{}
+}
+
+func (h *handlerTestSuite) TearDownTest() {
+	statistic.Ctl = h.originCtl
+}
+
+func (h *handlerTestSuite) TestHandle() {
+	h.ctl.On("MarkDirty", int64(1)).Return()
+	h.ctl.On("MarkDirty", int64(2)).Return()
+	h.ctl.On("MarkDirty", int64(3)).Return()
+
+	h.Nil(h.handler.Handle(context.TODO(), &event.CreateProjectEvent{ProjectID: 1}))
+	h.Nil(h.handler.Handle(context.TODO(), &event.PushArtifactEvent{
+		ArtifactEvent: &event.ArtifactEvent{Artifact: &artifact.Artifact{ProjectID: 2}},
+	}))
+	h.Nil(h.handler.Handle(context.TODO(), &event.DeleteTagEvent{AttachedArtifact: &artifact.Artifact{ProjectID: 3}}))
+	h.ctl.AssertExpectations(h.T())
+
+	// the events which don't change the statistics are ignored
+	h.Nil(h.handler.Handle(context.TODO(), &event.PullArtifactEvent{}))
+	h.ctl.AssertNumberOfCalls(h.T(), "MarkDirty", 3)
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &handlerTestSuite{})
+}
@@ -0,0 +1,76 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/usage"
+	"github.com/goharbor/harbor/src/pkg/usage/model"
+)
+
+// Handler records the pull and push usages of the repositories
+type Handler struct {
+}
+
+// Name ...
+func (h *Handler) Name() string {
+	return "Usage"
+}
+
+// Handle ...
+func (h *Handler) Handle(ctx context.Context, value interface{}) error {
+	var (
+		e    *event.ArtifactEvent
+		pull bool
+	)
+	switch v := value.(type) {
+	case *event.PullArtifactEvent:
+		e, pull = v.ArtifactEvent, true
+	case *event.PushArtifactEvent:
+		e = v.ArtifactEvent
+	default:
+		log.Errorf("Can not handler this event type! %#v", v)
+		return nil
+	}
+	if e == nil || e.Artifact == nil {
+		return nil
+	}
+
+	u := &model.Usage{
+		Day:            e.OccurAt,
+		ProjectID:      e.Artifact.ProjectID,
+		RepositoryName: e.Repository,
+		Principal:      e.Operator,
+	}
+	// the tag is empty when the artifact is pulled or pushed by digest
+	if len(e.Tags) > 0 {
+		u.Tag = e.Tags[0]
+	}
+	if pull {
+		u.PullCount = 1
+	} else {
+		u.PushCount = 1
+	}
+	usage.Mgr.Record(ctx, u)
+	return nil
+}
+
+// IsStateful ...
+func (h *Handler) IsStateful() bool {
+	return false
+}
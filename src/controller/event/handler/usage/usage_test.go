@@ -0,0 +1,83 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/pkg/usage"
+	"github.com/goharbor/harbor/src/pkg/usage/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	usagetesting "github.com/goharbor/harbor/src/testing/pkg/usage"
+)
+
+type handlerTestSuite struct {
+	suite.Suite
+	originMgr usage.Manager
+	mgr       *usagetesting.Manager
+	handler   *Handler
+}
+
+func (h *handlerTestSuite) SetupTest() {
+	h.originMgr = usage.Mgr
+	h.mgr = &usagetesting.Manager{}
+	usage.Mgr = h.mgr
+	h.handler = &Handler{}
+}
+
+func (h *handlerTestSuite) TearDownTest() {
+	usage.Mgr = h.originMgr
+}
+
+func (h *handlerTestSuite) TestHandle() {
+	mock.OnAnything(h.mgr, "Record").Return()
+	now := time.Now()
+
+	h.Nil(h.handler.Handle(context.TODO(), &event.PullArtifactEvent{
+		ArtifactEvent: &event.ArtifactEvent{
+			Repository: "library/hello-world",
+			Artifact:   &artifact.Artifact{ProjectID: 1},
+			Tags:       []string{"latest"},
+			Operator:   "admin",
+			OccurAt:    now,
+		},
+	}))
+	h.Nil(h.handler.Handle(context.TODO(), &event.PushArtifactEvent{
+		ArtifactEvent: &event.ArtifactEvent{
+			Repository: "library/hello-world",
+			Artifact:   &artifact.Artifact{ProjectID: 1},
+			Operator:   "robot$ci",
+			OccurAt:    now,
+		},
+	}))
+	// the events which aren't pull or push are ignored
+	h.Nil(h.handler.Handle(context.TODO(), &event.DeleteArtifactEvent{}))
+
+	h.Require().Len(h.mgr.Calls, 2)
+	pull := h.mgr.Calls[0].Arguments.Get(1).(*model.Usage)
+	h.Equal(&model.Usage{Day: now, ProjectID: 1, RepositoryName: "library/hello-world", Tag: "latest", Principal: "admin", PullCount: 1}, pull)
+	push := h.mgr.Calls[1].Arguments.Get(1).(*model.Usage)
+	h.Equal(&model.Usage{Day: now, ProjectID: 1, RepositoryName: "library/hello-world", Principal: "robot$ci", PushCount: 1}, push)
+}
+
+func TestHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &handlerTestSuite{})
+}
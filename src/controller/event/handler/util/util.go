@@ -1,17 +1,23 @@
 package util
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg"
 	"github.com/goharbor/harbor/src/pkg/distribution"
+	"github.com/goharbor/harbor/src/pkg/notification/policy"
 	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
 	"github.com/goharbor/harbor/src/pkg/notifier/event"
 	notifyModel "github.com/goharbor/harbor/src/pkg/notifier/model"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 )
 
 // SendHookWithPolicies send hook by publishing topic of specified target type(notify type)
@@ -32,6 +38,7 @@ func SendHookWithPolicies(policies []*policy_model.Policy, payload *notifyModel.
 				PolicyID:  ply.ID,
 				Payload:   payload,
 				Target:    &target,
+				Secret:    ply.Secret,
 			}
 			// It should never affect evaluating other policies when one is failed, but error should return
 			if err := evt.Build(hookMetadata); err == nil {
@@ -71,3 +78,85 @@ func BuildImageResourceURL(repoName, reference string) (string, error) {
 
 	return fmt.Sprintf("%s/%s:%s", extURL, repoName, reference), nil
 }
+
+// FilterPolicies returns the policies whose filters match the event, the labels function is used to get the
+// labels of the artifact when the labels are filtered, nil if the event isn't about an artifact
+func FilterPolicies(policies []*policy_model.Policy, payload *notifyModel.Payload, labels func() ([]int64, error)) []*policy_model.Policy {
+	subject := &policy.FilterSubject{}
+	if data := payload.EventData; data != nil {
+		if data.Repository != nil {
+			subject.Repository = data.Repository.RepoFullName
+		}
+		if len(data.Resources) > 0 {
+			subject.Tags = []string{}
+			for _, res := range data.Resources {
+				if len(res.Tag) > 0 {
+					subject.Tags = append(subject.Tags, res.Tag)
+				}
+				if severity := scanSeverity(res.ScanOverview); vuln.Severity(severity).Code() > vuln.Severity(subject.Severity).Code() ||
+					len(subject.Severity) == 0 {
+					subject.Severity = severity
+				}
+			}
+		}
+	}
+	if labels != nil {
+		// get the labels only once for all the policies
+		var ids []int64
+		var err error
+		var once sync.Once
+		subject.Labels = func() ([]int64, error) {
+			once.Do(func() { ids, err = labels() })
+			return ids, err
+		}
+	}
+
+	var result []*policy_model.Policy
+	for _, ply := range policies {
+		matched, err := policy.Match(ply.Filters, subject)
+		if err != nil {
+			log.Errorf("failed to evaluate the filters of the policy %d: %v", ply.ID, err)
+			continue
+		}
+		if matched {
+			result = append(result, ply)
+		}
+	}
+	return result
+}
+
+// scanSeverity returns the highest severity in the scan overview, empty if it isn't a scan overview
+func scanSeverity(overview map[string]interface{}) string {
+	severity := ""
+	for _, summary := range overview {
+		data, err := json.Marshal(summary)
+		if err != nil {
+			continue
+		}
+		s := &struct {
+			Severity string `json:"severity"`
+		}{}
+		if err := json.Unmarshal(data, s); err != nil || len(s.Severity) == 0 {
+			continue
+		}
+		if len(severity) == 0 || vuln.Severity(s.Severity).Code() > vuln.Severity(severity).Code() {
+			severity = s.Severity
+		}
+	}
+	return severity
+}
+
+// ArtifactLabels returns the function to get the IDs of the labels attached to the artifact
+func ArtifactLabels(ctx context.Context, artifactID int64) func() ([]int64, error) {
+	return func() ([]int64, error) {
+		labels, err := pkg.LabelMgr.ListByArtifact(ctx, artifactID)
+		if err != nil {
+			return nil, err
+		}
+		var ids []int64
+		for _, l := range labels {
+			ids = append(ids, l.ID)
+		}
+		return ids, nil
+	}
+}
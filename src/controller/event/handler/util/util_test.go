@@ -4,10 +4,14 @@ import (
 	"os"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/utils/test"
 	"github.com/goharbor/harbor/src/lib/config"
 	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	notifyModel "github.com/goharbor/harbor/src/pkg/notifier/model"
 )
 
 func TestMain(m *testing.M) {
@@ -58,3 +62,33 @@ func TestBuildImageResourceURL(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterPolicies(t *testing.T) {
+	policies := []*policy_model.Policy{
+		{ID: 1},
+		{ID: 2, Filters: &policy_model.EventFilters{Repositories: []string{"library/**"}}},
+		{ID: 3, Filters: &policy_model.EventFilters{TagPattern: "^v\\d+"}},
+		{ID: 4, Filters: &policy_model.EventFilters{LabelIDs: []int64{1}}},
+		{ID: 5, Filters: &policy_model.EventFilters{MinSeverity: "Critical"}},
+	}
+	payload := &notifyModel.Payload{
+		EventData: &notifyModel.EventData{
+			Repository: &notifyModel.Repository{RepoFullName: "library/photon"},
+			Resources: []*notifyModel.Resource{
+				{
+					Tag: "latest",
+					ScanOverview: map[string]interface{}{
+						"application/vnd.security.vulnerability.report; version=1.1": map[string]interface{}{"severity": "High"},
+					},
+				},
+			},
+		},
+	}
+	labels := func() ([]int64, error) { return []int64{1, 2}, nil }
+
+	var ids []int64
+	for _, ply := range FilterPolicies(policies, payload, labels) {
+		ids = append(ids, ply.ID)
+	}
+	assert.Equal(t, []int64{1, 2, 4}, ids)
+}
@@ -25,6 +25,9 @@ import (
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg"
 	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notification/policy"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	"github.com/goharbor/harbor/src/pkg/notification/stream"
 	notifyModel "github.com/goharbor/harbor/src/pkg/notifier/model"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 )
@@ -76,9 +79,10 @@ func (a *Handler) handle(ctx context.Context, event *event.ArtifactEvent) error
 		return err
 	}
 
+	policies = limitPullEvent(policies, event)
 	log.Debugf("find %d policies for %s event", len(policies), event.EventType)
 
-	if len(policies) == 0 {
+	if len(policies) == 0 && !stream.Active(ctx) {
 		log.Debugf("cannot find policy for %s event: %v", event.EventType, event)
 		return nil
 	}
@@ -87,6 +91,17 @@ func (a *Handler) handle(ctx context.Context, event *event.ArtifactEvent) error
 	if err != nil {
 		return err
 	}
+	stream.Publish(ctx, prj.ProjectID, payload)
+
+	var labels func() ([]int64, error)
+	if event.Artifact != nil {
+		labels = util.ArtifactLabels(ctx, event.Artifact.ID)
+	}
+	policies = util.FilterPolicies(policies, payload, labels)
+	if len(policies) == 0 {
+		log.Debugf("no policy matches the filters for %s event: %v", event.EventType, event)
+		return nil
+	}
 
 	err = util.SendHookWithPolicies(policies, payload, event.EventType)
 	if err != nil {
@@ -95,6 +110,21 @@ func (a *Handler) handle(ctx context.Context, event *event.ArtifactEvent) error
 	return nil
 }
 
+// limitPullEvent filters out the policies which the pull event isn't sent to, as the pull events may be
+// throttled or sampled according to the options of the policies
+func limitPullEvent(policies []*policy_model.Policy, e *event.ArtifactEvent) []*policy_model.Policy {
+	if e.EventType != event.TopicPullArtifact {
+		return policies
+	}
+	var allowed []*policy_model.Policy
+	for _, ply := range policies {
+		if policy.PullEventLimiter.Allow(ply, e.Repository) {
+			allowed = append(allowed, ply)
+		}
+	}
+	return allowed
+}
+
 func (a *Handler) constructArtifactPayload(ctx context.Context, event *event.ArtifactEvent, project *proModels.Project) (*notifyModel.Payload, error) {
 	repoName := event.Repository
 	if repoName == "" {
@@ -16,6 +16,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notification/stream"
 	"github.com/goharbor/harbor/src/pkg/notifier/model"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	"github.com/goharbor/harbor/src/pkg/reg"
@@ -50,6 +51,7 @@ func (r *ReplicationHandler) Handle(ctx context.Context, value interface{}) erro
 	if err != nil {
 		return err
 	}
+	stream.Publish(ctx, project.ProjectID, payload)
 
 	policies, err := notification.PolicyMgr.GetRelatedPolices(orm.Context(), project.ProjectID, rpEvent.EventType)
 	if err != nil {
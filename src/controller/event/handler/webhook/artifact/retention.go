@@ -14,6 +14,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notification/stream"
 	"github.com/goharbor/harbor/src/pkg/notifier/model"
 )
 
@@ -54,6 +55,7 @@ func (r *RetentionHandler) Handle(ctx context.Context, value interface{}) error
 		log.Debugf("retention task %v is dry run", trEvent.TaskID)
 		return nil
 	}
+	stream.Publish(ctx, project, payload)
 
 	policies, err := notification.PolicyMgr.GetRelatedPolices(ctx, project, trEvent.EventType)
 	if err != nil {
@@ -25,6 +25,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notification/stream"
 	"github.com/goharbor/harbor/src/pkg/notifier/model"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 )
@@ -60,7 +61,7 @@ func (cph *Handler) Handle(ctx context.Context, value interface{}) error {
 		return err
 	}
 	// if cannot find policy including event type in project, return directly
-	if len(policies) == 0 {
+	if len(policies) == 0 && !stream.Active(ctx) {
 		log.Debugf("cannot find policy for %s event: %v", chartEvent.EventType, chartEvent)
 		return nil
 	}
@@ -69,6 +70,13 @@ func (cph *Handler) Handle(ctx context.Context, value interface{}) error {
 	if err != nil {
 		return err
 	}
+	stream.Publish(ctx, prj.ProjectID, payload)
+
+	policies = util.FilterPolicies(policies, payload, nil)
+	if len(policies) == 0 {
+		log.Debugf("no policy matches the filters for %s event: %v", chartEvent.EventType, chartEvent)
+		return nil
+	}
 
 	err = util.SendHookWithPolicies(policies, payload, chartEvent.EventType)
 	if err != nil {
@@ -25,6 +25,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notification/stream"
 	notifyModel "github.com/goharbor/harbor/src/pkg/notifier/model"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 )
@@ -59,7 +60,7 @@ func (qp *Handler) Handle(ctx context.Context, value interface{}) error {
 		log.Errorf("failed to find policy for %s event: %v", quotaEvent.EventType, err)
 		return err
 	}
-	if len(policies) == 0 {
+	if len(policies) == 0 && !stream.Active(ctx) {
 		log.Debugf("cannot find policy for %s event: %v", quotaEvent.EventType, quotaEvent)
 		return nil
 	}
@@ -68,6 +69,13 @@ func (qp *Handler) Handle(ctx context.Context, value interface{}) error {
 	if err != nil {
 		return err
 	}
+	stream.Publish(ctx, prj.ProjectID, payload)
+
+	policies = util.FilterPolicies(policies, payload, nil)
+	if len(policies) == 0 {
+		log.Debugf("no policy matches the filters for %s event: %v", quotaEvent.EventType, quotaEvent)
+		return nil
+	}
 
 	err = util.SendHookWithPolicies(policies, payload, quotaEvent.EventType)
 	if err != nil {
@@ -29,6 +29,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notification/stream"
 	"github.com/goharbor/harbor/src/pkg/notifier/model"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
@@ -60,7 +61,7 @@ func (si *Handler) Handle(ctx context.Context, value interface{}) error {
 	}
 
 	// If we cannot find policy including event type in project, return directly
-	if len(policies) == 0 {
+	if len(policies) == 0 && !stream.Active(ctx) {
 		log.Debugf("Cannot find policy for %s event: %v", e.EventType, e)
 		return nil
 	}
@@ -75,6 +76,19 @@ func (si *Handler) Handle(ctx context.Context, value interface{}) error {
 	if err != nil {
 		return errors.Wrap(err, "scan preprocess handler")
 	}
+	stream.Publish(ctx, prj.ProjectID, payload)
+
+	policies = util.FilterPolicies(policies, payload, func() ([]int64, error) {
+		art, err := artifact.Ctl.GetByReference(ctx, e.Artifact.Repository, e.Artifact.Digest, nil)
+		if err != nil {
+			return nil, err
+		}
+		return util.ArtifactLabels(ctx, art.ID)()
+	})
+	if len(policies) == 0 {
+		log.Debugf("no policy matches the filters for %s event: %v", e.EventType, e)
+		return nil
+	}
 
 	err = util.SendHookWithPolicies(policies, payload, e.EventType)
 	if err != nil {
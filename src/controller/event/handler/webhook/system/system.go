@@ -0,0 +1,119 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/event/handler/util"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	"github.com/goharbor/harbor/src/pkg/notification/stream"
+	notifyModel "github.com/goharbor/harbor/src/pkg/notifier/model"
+)
+
+// Handler preprocess the system level events, e.g. user created, project created and garbage collection finished,
+// which are only sent to the system level policies
+type Handler struct {
+}
+
+// Name ...
+func (s *Handler) Name() string {
+	return "SystemWebhook"
+}
+
+// Handle ...
+func (s *Handler) Handle(ctx context.Context, value interface{}) error {
+	payload, err := constructPayload(value)
+	if err != nil {
+		return err
+	}
+	stream.Publish(ctx, 0, payload)
+
+	policies, err := notification.PolicyMgr.GetRelatedPolices(ctx, policy_model.SystemLevelProjectID, payload.Type)
+	if err != nil {
+		log.Errorf("failed to find policy for %s event: %v", payload.Type, err)
+		return err
+	}
+	if len(policies) == 0 {
+		log.Debugf("cannot find policy for %s event: %v", payload.Type, value)
+		return nil
+	}
+
+	return util.SendHookWithPolicies(policies, payload, payload.Type)
+}
+
+// IsStateful ...
+func (s *Handler) IsStateful() bool {
+	return false
+}
+
+func constructPayload(value interface{}) (*notifyModel.Payload, error) {
+	switch e := value.(type) {
+	case *event.CreateUserEvent:
+		return &notifyModel.Payload{
+			Type:     e.EventType,
+			OccurAt:  e.OccurAt.Unix(),
+			Operator: e.Operator,
+			EventData: &notifyModel.EventData{
+				Custom: map[string]string{
+					"user_id":  strconv.Itoa(e.UserID),
+					"username": e.Username,
+				},
+			},
+		}, nil
+	case *event.CreateProjectEvent:
+		return &notifyModel.Payload{
+			Type:     e.EventType,
+			OccurAt:  e.OccurAt.Unix(),
+			Operator: e.Operator,
+			EventData: &notifyModel.EventData{
+				Custom: map[string]string{
+					"project_id":   strconv.FormatInt(e.ProjectID, 10),
+					"project_name": e.Project,
+				},
+			},
+		}, nil
+	case *event.BackupEvent:
+		return &notifyModel.Payload{
+			Type:     e.EventType,
+			OccurAt:  e.OccurAt.Unix(),
+			Operator: e.Operator,
+			EventData: &notifyModel.EventData{
+				Custom: map[string]string{
+					"backup_id": strconv.FormatInt(e.BackupID, 10),
+					"stage":     e.Stage,
+				},
+			},
+		}, nil
+	case *event.GarbageCollectionEvent:
+		return &notifyModel.Payload{
+			Type:    e.EventType,
+			OccurAt: e.OccurAt.Unix(),
+			EventData: &notifyModel.EventData{
+				Custom: map[string]string{
+					"execution_id": strconv.FormatInt(e.ExecutionID, 10),
+					"status":       e.Status,
+				},
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid system event type %T", value)
+	}
+}
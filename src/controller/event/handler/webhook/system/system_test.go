@@ -0,0 +1,80 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notification/policy"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	testing_notification "github.com/goharbor/harbor/src/testing/pkg/notification/policy"
+)
+
+type systemHandlerTestSuite struct {
+	suite.Suite
+	om  policy.Manager
+	mgr *testing_notification.Manager
+}
+
+func (s *systemHandlerTestSuite) SetupTest() {
+	s.om = notification.PolicyMgr
+	s.mgr = &testing_notification.Manager{}
+	notification.PolicyMgr = s.mgr
+}
+
+func (s *systemHandlerTestSuite) TearDownTest() {
+	notification.PolicyMgr = s.om
+}
+
+func (s *systemHandlerTestSuite) TestConstructPayload() {
+	now := time.Now()
+	payload, err := constructPayload(&event.CreateUserEvent{EventType: event.TopicCreateUser, UserID: 3, Username: "alice", Operator: "admin", OccurAt: now})
+	s.Require().Nil(err)
+	s.Equal(event.TopicCreateUser, payload.Type)
+	s.Equal("admin", payload.Operator)
+	s.Equal("alice", payload.EventData.Custom["username"])
+	s.Equal("3", payload.EventData.Custom["user_id"])
+
+	payload, err = constructPayload(&event.CreateProjectEvent{EventType: event.TopicCreateProject, ProjectID: 2, Project: "library", OccurAt: now})
+	s.Require().Nil(err)
+	s.Equal("library", payload.EventData.Custom["project_name"])
+	s.Equal("2", payload.EventData.Custom["project_id"])
+
+	payload, err = constructPayload(&event.GarbageCollectionEvent{EventType: event.TopicGarbageCollection, ExecutionID: 5, Status: "Success", OccurAt: now})
+	s.Require().Nil(err)
+	s.Equal("5", payload.EventData.Custom["execution_id"])
+	s.Equal("Success", payload.EventData.Custom["status"])
+
+	_, err = constructPayload(&event.QuotaEvent{})
+	s.NotNil(err)
+}
+
+func (s *systemHandlerTestSuite) TestHandleWithoutPolicy() {
+	s.mgr.On("GetRelatedPolices", mock.Anything, policy_model.SystemLevelProjectID, event.TopicCreateUser).Return(nil, nil)
+	err := (&Handler{}).Handle(context.TODO(), &event.CreateUserEvent{EventType: event.TopicCreateUser, Username: "alice", OccurAt: time.Now()})
+	s.Nil(err)
+	s.mgr.AssertExpectations(s.T())
+}
+
+func TestSystemHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &systemHandlerTestSuite{})
+}
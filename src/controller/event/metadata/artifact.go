@@ -86,6 +86,9 @@ type DeleteArtifactEventMetadata struct {
 	Ctx      context.Context
 	Artifact *artifact.Artifact
 	Tags     []string
+	// Operator is used when the operator cannot be resolved from the context,
+	// e.g. the artifact is deleted by the repository deletion job
+	Operator string
 }
 
 // Resolve to the event from the metadata
@@ -102,6 +105,8 @@ func (d *DeleteArtifactEventMetadata) Resolve(event *event.Event) error {
 	ctx, exist := security.FromContext(d.Ctx)
 	if exist {
 		data.Operator = ctx.GetUsername()
+	} else {
+		data.Operator = d.Operator
 	}
 	event.Topic = event2.TopicDeleteArtifact
 	event.Data = data
@@ -0,0 +1,42 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"time"
+
+	event2 "github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
+)
+
+// BackupMetaData defines the metadata of the backup moving to the next stage
+type BackupMetaData struct {
+	BackupID int64
+	Stage    string
+	Operator string
+}
+
+// Resolve backup metadata into backup event
+func (b *BackupMetaData) Resolve(evt *event.Event) error {
+	evt.Topic = event2.TopicBackup
+	evt.Data = &event2.BackupEvent{
+		EventType: event2.TopicBackup,
+		BackupID:  b.BackupID,
+		Stage:     b.Stage,
+		Operator:  b.Operator,
+		OccurAt:   time.Now(),
+	}
+	return nil
+}
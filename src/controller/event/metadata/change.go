@@ -0,0 +1,65 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"time"
+
+	event2 "github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
+)
+
+// the types of the resources whose changes are audited
+const (
+	ResourceTypeConfiguration     = "configuration"
+	ResourceTypeAuthConfiguration = "auth_configuration"
+	ResourceTypeRegistry          = "registry"
+	ResourceTypeReplicationPolicy = "replication_policy"
+	ResourceTypeRetentionPolicy   = "retention_policy"
+	ResourceTypeRobot             = "robot"
+	ResourceTypeProjectMember     = "project_member"
+	ResourceTypeRegistryStorage   = "registry_storage"
+	ResourceTypeTokenKey          = "token_key"
+	ResourceTypeHTTPSecret        = "registry_http_secret"
+)
+
+// ResourceChangeEventMetadata is the metadata from which the resource change event can be resolved,
+// the "Before" is nil for the creation and the "After" is nil for the deletion
+type ResourceChangeEventMetadata struct {
+	ProjectID    int64
+	ResourceType string
+	Resource     string
+	Operation    string
+	Operator     string
+	Before       interface{}
+	After        interface{}
+}
+
+// Resolve to the event from the metadata
+func (r *ResourceChangeEventMetadata) Resolve(event *event.Event) error {
+	event.Topic = event2.TopicResourceChange
+	event.Data = &event2.ResourceChangeEvent{
+		EventType:    event2.TopicResourceChange,
+		ProjectID:    r.ProjectID,
+		ResourceType: r.ResourceType,
+		Resource:     r.Resource,
+		Operation:    r.Operation,
+		Operator:     r.Operator,
+		Before:       r.Before,
+		After:        r.After,
+		OccurAt:      time.Now(),
+	}
+	return nil
+}
@@ -28,6 +28,9 @@ type DeleteRepositoryEventMetadata struct {
 	Ctx        context.Context
 	Repository string
 	ProjectID  int64
+	// Operator is used when the operator cannot be resolved from the context,
+	// e.g. the repository is deleted by the background job
+	Operator string
 }
 
 // Resolve to the event from the metadata
@@ -40,6 +43,8 @@ func (d *DeleteRepositoryEventMetadata) Resolve(event *event.Event) error {
 	cx, exist := security.FromContext(d.Ctx)
 	if exist {
 		data.Operator = cx.GetUsername()
+	} else {
+		data.Operator = d.Operator
 	}
 	event.Topic = event2.TopicDeleteRepository
 	event.Data = data
@@ -0,0 +1,50 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	event2 "github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
+)
+
+type userEventTestSuite struct {
+	suite.Suite
+}
+
+func (u *userEventTestSuite) TestResolveOfCreateUserEventMetadata() {
+	e := &event.Event{}
+	metadata := &CreateUserEventMetadata{
+		UserID:   3,
+		Username: "alice",
+		Operator: "admin",
+	}
+	err := metadata.Resolve(e)
+	u.Require().Nil(err)
+	u.Equal(event2.TopicCreateUser, e.Topic)
+	u.Require().NotNil(e.Data)
+	data, ok := e.Data.(*event2.CreateUserEvent)
+	u.Require().True(ok)
+	u.Equal(3, data.UserID)
+	u.Equal("alice", data.Username)
+	u.Equal("admin", data.Operator)
+}
+
+func TestUserEventTestSuite(t *testing.T) {
+	suite.Run(t, &userEventTestSuite{})
+}
@@ -0,0 +1,82 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// RedactedValue replaces the values of the sensitive fields in the snapshots
+const RedactedValue = "******"
+
+// the fields whose names contain the keywords are sensitive, e.g. "oidc_client_secret", "access_secret", "client_key"
+var sensitiveKeywords = []string{"password", "secret", "passwd", "pwd", "client_key"}
+
+// Snapshot returns the JSON snapshot of the resource with the values of the sensitive fields redacted,
+// empty string is returned for the nil resource
+func Snapshot(resource interface{}) (string, error) {
+	if resource == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return "", err
+	}
+	var value interface{}
+	if err = json.Unmarshal(data, &value); err != nil {
+		return "", err
+	}
+	if value == nil {
+		return "", nil
+	}
+	data, err = json.Marshal(redact(value))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			if isSensitive(key) {
+				// keep the empty values to tell whether the secret is set
+				if s, ok := val.(string); ok && len(s) == 0 {
+					continue
+				}
+				v[key] = RedactedValue
+				continue
+			}
+			v[key] = redact(val)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redact(v[i])
+		}
+	}
+	return value
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, keyword := range sensitiveKeywords {
+		if strings.Contains(key, keyword) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	// nil resource
+	s, err := Snapshot(nil)
+	require.Nil(t, err)
+	assert.Empty(t, s)
+
+	// typed nil resource
+	var m *struct{ Name string }
+	s, err = Snapshot(m)
+	require.Nil(t, err)
+	assert.Empty(t, s)
+
+	// the sensitive fields are redacted recursively, the empty ones are kept
+	s, err = Snapshot(map[string]interface{}{
+		"name":               "registry",
+		"oidc_client_secret": "",
+		"credential": map[string]interface{}{
+			"access_key":    "admin",
+			"access_secret": "Harbor12345",
+		},
+		"users": []interface{}{
+			map[string]interface{}{"Password": "pwd"},
+		},
+	})
+	require.Nil(t, err)
+	assert.JSONEq(t, `{"name":"registry","oidc_client_secret":"","credential":{"access_key":"admin","access_secret":"******"},"users":[{"Password":"******"}]}`, s)
+}
+
+func TestResourceChangeEventResolveToAuditLog(t *testing.T) {
+	now := time.Now()
+	e := &ResourceChangeEvent{
+		ProjectID:    1,
+		ResourceType: "robot",
+		Resource:     "robot$test",
+		Operation:    "update",
+		Operator:     "admin",
+		Before:       map[string]interface{}{"secret": "abc", "disabled": false},
+		After:        map[string]interface{}{"secret": "def", "disabled": true},
+		OccurAt:      now,
+	}
+	log, err := e.ResolveToAuditLog()
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), log.ProjectID)
+	assert.Equal(t, "robot", log.ResourceType)
+	assert.Equal(t, "robot$test", log.Resource)
+	assert.Equal(t, "update", log.Operation)
+	assert.Equal(t, "admin", log.Username)
+	assert.Equal(t, now, log.OpTime)
+	assert.JSONEq(t, `{"secret":"******","disabled":false}`, log.BeforeSnapshot)
+	assert.JSONEq(t, `{"secret":"******","disabled":true}`, log.AfterSnapshot)
+
+	// deletion
+	e.After = nil
+	log, err = e.ResolveToAuditLog()
+	require.Nil(t, err)
+	assert.Empty(t, log.AfterSnapshot)
+}
@@ -50,6 +50,12 @@ const (
 	TopicReplication     = "REPLICATION"
 	TopicArtifactLabeled = "ARTIFACT_LABELED"
 	TopicTagRetention    = "TAG_RETENTION"
+	// the system level topics which are only notified to the system level webhook policies
+	TopicCreateUser        = "CREATE_USER"
+	TopicGarbageCollection = "GARBAGE_COLLECTION"
+	TopicBackup            = "BACKUP"
+	// TopicResourceChange is the topic for the changes of the configurations and policies which are audited only
+	TopicResourceChange = "RESOURCE_CHANGE"
 )
 
 // CreateProjectEvent is the creating project event
@@ -383,3 +389,85 @@ func (r *RetentionEvent) String() string {
 	return fmt.Sprintf("TaskID-%d Status-%s Deleted-%s OccurAt-%s",
 		r.TaskID, r.Status, candidates, r.OccurAt.Format("2006-01-02 15:04:05"))
 }
+
+// CreateUserEvent is the creating user event
+type CreateUserEvent struct {
+	EventType string
+	UserID    int
+	Username  string
+	Operator  string
+	OccurAt   time.Time
+}
+
+func (c *CreateUserEvent) String() string {
+	return fmt.Sprintf("ID-%d Username-%s Operator-%s OccurAt-%s",
+		c.UserID, c.Username, c.Operator, c.OccurAt.Format("2006-01-02 15:04:05"))
+}
+
+// GarbageCollectionEvent is the event data published when the garbage collection finishes
+type GarbageCollectionEvent struct {
+	EventType   string
+	ExecutionID int64
+	Status      string
+	OccurAt     time.Time
+}
+
+func (g *GarbageCollectionEvent) String() string {
+	return fmt.Sprintf("ExecutionID-%d Status-%s OccurAt-%s",
+		g.ExecutionID, g.Status, g.OccurAt.Format("2006-01-02 15:04:05"))
+}
+
+// BackupEvent is the event data published when the backup moves to the next stage
+type BackupEvent struct {
+	EventType string
+	BackupID  int64
+	Stage     string
+	Operator  string
+	OccurAt   time.Time
+}
+
+func (b *BackupEvent) String() string {
+	return fmt.Sprintf("BackupID-%d Stage-%s Operator-%s OccurAt-%s",
+		b.BackupID, b.Stage, b.Operator, b.OccurAt.Format("2006-01-02 15:04:05"))
+}
+
+// ResourceChangeEvent is the event of changing the configurations, registries, policies, robots or members,
+// it carries the snapshots of the resource before and after the change
+type ResourceChangeEvent struct {
+	EventType    string
+	ProjectID    int64
+	ResourceType string
+	Resource     string
+	Operation    string
+	Operator     string
+	Before       interface{}
+	After        interface{}
+	OccurAt      time.Time
+}
+
+// ResolveToAuditLog ...
+func (r *ResourceChangeEvent) ResolveToAuditLog() (*model.AuditLog, error) {
+	before, err := Snapshot(r.Before)
+	if err != nil {
+		return nil, err
+	}
+	after, err := Snapshot(r.After)
+	if err != nil {
+		return nil, err
+	}
+	return &model.AuditLog{
+		ProjectID:      r.ProjectID,
+		OpTime:         r.OccurAt,
+		Operation:      r.Operation,
+		Username:       r.Operator,
+		ResourceType:   r.ResourceType,
+		Resource:       r.Resource,
+		BeforeSnapshot: before,
+		AfterSnapshot:  after,
+	}, nil
+}
+
+func (r *ResourceChangeEvent) String() string {
+	return fmt.Sprintf("ResourceType-%s Resource-%s Operation-%s Operator-%s OccurAt-%s",
+		r.ResourceType, r.Resource, r.Operation, r.Operator, r.OccurAt.Format("2006-01-02 15:04:05"))
+}
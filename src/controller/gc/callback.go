@@ -19,11 +19,13 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/goharbor/harbor/src/controller/event/metadata"
 	"github.com/goharbor/harbor/src/controller/quota"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg/notification"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
 	"github.com/goharbor/harbor/src/pkg/task"
 )
@@ -37,6 +39,10 @@ func init() {
 	if err := task.RegisterTaskStatusChangePostFunc(GCVendorType, gcTaskStatusChange); err != nil {
 		log.Fatalf("failed to register the task status change post for the gc job, error %v", err)
 	}
+
+	if err := task.RegisterCheckInProcessor(job.GarbageCollection, gcCheckInProcessor); err != nil {
+		log.Fatalf("failed to register the checkin processor for the gc job, error %v", err)
+	}
 }
 
 func gcCallback(ctx context.Context, p string) error {
@@ -48,7 +54,41 @@ func gcCallback(ctx context.Context, p string) error {
 	return err
 }
 
+// gcCheckInProcessor persists the statistics checked in by the gc job into the execution
+func gcCheckInProcessor(ctx context.Context, t *task.Task, sc *job.StatusChange) error {
+	if sc.CheckIn == "" {
+		return nil
+	}
+	statistics := &Statistics{}
+	if err := json.Unmarshal([]byte(sc.CheckIn), statistics); err != nil {
+		log.Errorf("failed to resolve checkin of gc task %d: %v", t.ID, err)
+		return err
+	}
+	exec, err := task.ExecMgr.Get(ctx, t.ExecutionID)
+	if err != nil {
+		return err
+	}
+	if exec.ExtraAttrs == nil {
+		exec.ExtraAttrs = map[string]interface{}{}
+	}
+	exec.ExtraAttrs[attrStatistics] = statistics
+	return task.ExecMgr.UpdateExtraAttrs(ctx, exec.ID, exec.ExtraAttrs)
+}
+
 func gcTaskStatusChange(ctx context.Context, taskID int64, status string) error {
+	// notify the system level webhook policies when the garbage collection finishes
+	if job.Status(status).Final() {
+		t, err := task.Mgr.Get(ctx, taskID)
+		if err != nil {
+			log.Warningf("failed to get the gc task %d, error: %v", taskID, err)
+		} else {
+			notification.AddEvent(ctx, &metadata.GarbageCollectionMetaData{
+				ExecutionID: t.ExecutionID,
+				Status:      status,
+			})
+		}
+	}
+
 	if status == job.SuccessStatus.String() && config.QuotaPerProjectEnable(ctx) {
 		go func() {
 			err := quota.RefreshForProjects(orm.Context())
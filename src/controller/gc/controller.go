@@ -2,9 +2,11 @@ package gc
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
 	"github.com/goharbor/harbor/src/pkg/task"
@@ -25,6 +27,9 @@ const (
 	SchedulerCallback = "GARBAGE_COLLECTION"
 	// GCVendorType ...
 	GCVendorType = "GARBAGE_COLLECTION"
+
+	// the key of the statistics in the extra attributes of the execution
+	attrStatistics = "statistics"
 )
 
 // Controller manages the tags
@@ -211,7 +216,7 @@ func (c *controller) DeleteSchedule(ctx context.Context) error {
 }
 
 func convertExecution(exec *task.Execution) *Execution {
-	return &Execution{
+	execution := &Execution{
 		ID:            exec.ID,
 		Status:        exec.Status,
 		StatusMessage: exec.StatusMessage,
@@ -220,6 +225,23 @@ func convertExecution(exec *task.Execution) *Execution {
 		StartTime:     exec.StartTime,
 		UpdateTime:    exec.UpdateTime,
 	}
+	// split the statistics from the parameters of the gc kept in the extra attributes
+	if statistics, exist := exec.ExtraAttrs[attrStatistics]; exist {
+		execution.ExtraAttrs = make(map[string]interface{}, len(exec.ExtraAttrs))
+		for k, v := range exec.ExtraAttrs {
+			if k != attrStatistics {
+				execution.ExtraAttrs[k] = v
+			}
+		}
+		if data, err := json.Marshal(statistics); err == nil {
+			execution.Statistics = &Statistics{}
+			if err = json.Unmarshal(data, execution.Statistics); err != nil {
+				log.Warningf("failed to resolve the statistics of the gc execution %d: %v", exec.ID, err)
+				execution.Statistics = nil
+			}
+		}
+	}
+	return execution
 }
 
 func convertTask(task *task.Task) *Task {
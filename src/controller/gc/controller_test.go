@@ -91,6 +91,38 @@ func (g *gcCtrTestSuite) TestGetExecution() {
 	g.Equal("Manual", hs.Trigger)
 }
 
+func (g *gcCtrTestSuite) TestGetExecutionWithStatistics() {
+	g.execMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Execution{
+		{
+			ID:         1,
+			Trigger:    "Manual",
+			VendorType: GCVendorType,
+			ExtraAttrs: map[string]interface{}{
+				"dry_run": false,
+				"statistics": map[string]interface{}{
+					"deleted_blobs":     float64(3),
+					"deleted_manifests": float64(1),
+					"reclaimed_bytes":   float64(1024),
+					"errors":            float64(1),
+					"duration":          float64(5),
+				},
+			},
+		},
+	}, nil)
+
+	exec, err := g.ctl.GetExecution(nil, int64(1))
+	g.Require().Nil(err)
+	g.Equal(map[string]interface{}{"dry_run": false}, exec.ExtraAttrs)
+	g.Require().NotNil(exec.Statistics)
+	g.Equal(Statistics{
+		DeletedBlobs:     3,
+		DeletedManifests: 1,
+		ReclaimedBytes:   1024,
+		Errors:           1,
+		Duration:         5,
+	}, *exec.Statistics)
+}
+
 func (g *gcCtrTestSuite) TestListExecutions() {
 	g.execMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Execution{
 		{
@@ -33,10 +33,24 @@ type Execution struct {
 	StatusMessage string
 	Trigger       string
 	ExtraAttrs    map[string]interface{}
+	Statistics    *Statistics
 	StartTime     time.Time
 	UpdateTime    time.Time
 }
 
+// Statistics is the statistics of a gc run checked in by the job,
+// the candidates are reported as the deleted ones for the dry run
+type Statistics struct {
+	DeletedBlobs     int   `json:"deleted_blobs"`
+	DeletedManifests int   `json:"deleted_manifests"`
+	ReclaimedBytes   int64 `json:"reclaimed_bytes"`
+	// Errors is the count of the blobs and manifests failed to be deleted
+	Errors int `json:"errors"`
+	// Duration is the duration of the run in seconds
+	Duration int64 `json:"duration"`
+	DryRun   bool  `json:"dry_run"`
+}
+
 // Task model for gc
 type Task struct {
 	ID             int64
@@ -130,8 +130,8 @@ func jobserviceHealthChecker() health.Checker {
 	return PeriodicHealthChecker(checker, period)
 }
 
-func registryHealthChecker() health.Checker {
-	url := getRegistryURL() + "/"
+func registryHealthChecker(endpoint string) health.Checker {
+	url := endpoint + "/"
 	timeout := 60 * time.Second
 	period := 10 * time.Second
 	checker := HTTPStatusCodeHealthChecker(http.MethodGet, url, nil, timeout, http.StatusOK)
@@ -199,7 +199,10 @@ func RegisterHealthCheckers() {
 	registry["core"] = coreHealthChecker()
 	registry["portal"] = portalHealthChecker()
 	registry["jobservice"] = jobserviceHealthChecker()
-	registry["registry"] = registryHealthChecker()
+	registry["registry"] = registryHealthChecker(getRegistryURL())
+	for name, endpoint := range config.RegistryShards() {
+		registry["registry_shard_"+name] = registryHealthChecker(endpoint)
+	}
 	registry["registryctl"] = registryCtlHealthChecker()
 	registry["database"] = databaseHealthChecker()
 	registry["redis"] = redisHealthChecker()
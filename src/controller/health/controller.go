@@ -37,6 +37,12 @@ func NewController() Controller {
 // Controller defines the health related operations
 type Controller interface {
 	GetHealth(ctx context.Context) *OverallHealthStatus
+	// GetHealthDetail returns the health status of the components and the validation results
+	GetHealthDetail(ctx context.Context) *HealthDetail
+	// Validate the dependencies and configurations that core relies on
+	Validate(ctx context.Context) []*ValidationResult
+	// GetReadiness probes the dependencies that core serves the requests with
+	GetReadiness(ctx context.Context) *Readiness
 }
 
 type controller struct{}
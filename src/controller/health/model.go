@@ -13,6 +13,37 @@ type ComponentHealthStatus struct {
 	Error  string `json:"error,omitempty"`
 }
 
+// HealthDetail defines the health status of the components and the validation results
+// of the dependencies and configurations
+type HealthDetail struct {
+	Status      string                   `json:"status"`
+	Components  []*ComponentHealthStatus `json:"components"`
+	Validations []*ValidationResult      `json:"validations"`
+}
+
+// ValidationResult defines the result of validating one dependency or configuration item
+type ValidationResult struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Fatal      bool   `json:"fatal"`
+	Duration   string `json:"duration"`
+	Error      string `json:"error,omitempty"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// Readiness defines the readiness of core and the probe results of the dependencies
+type Readiness struct {
+	Status       string         `json:"status"`
+	Dependencies []*ProbeResult `json:"dependencies"`
+}
+
+// ProbeResult defines the result of probing one dependency
+type ProbeResult struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+}
+
 type healthy bool
 
 func (h healthy) String() string {
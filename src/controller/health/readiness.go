@@ -0,0 +1,129 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+var (
+	// the probes are called by the load balancers and kubelet frequently, so keep them short
+	probeTimeout = 5 * time.Second
+	probes       = []*validator{
+		{name: "database", validate: validateDatabase},
+		{name: "redis", validate: validateRedis},
+		{name: "registry", validate: validateRegistryURL},
+		{name: "jobservice", validate: probeJobservice},
+		{name: "schema", validate: probeSchema},
+	}
+)
+
+func (c *controller) GetReadiness(ctx context.Context) *Readiness {
+	ch := make(chan *ValidationResult, len(probes))
+	for _, p := range probes {
+		go func(p *validator) {
+			ch <- runValidator(ctx, p, probeTimeout)
+		}(p)
+	}
+	results := map[string]*ValidationResult{}
+	for i := 0; i < len(probes); i++ {
+		result := <-ch
+		results[result.Name] = result
+	}
+
+	var ready healthy = true
+	readiness := &Readiness{}
+	// keep the order of the probes
+	for _, p := range probes {
+		result := results[p.name]
+		if len(result.Error) > 0 {
+			ready = false
+			log.Debugf("the readiness probe %s failed: %s", result.Name, result.Error)
+		}
+		readiness.Dependencies = append(readiness.Dependencies, &ProbeResult{
+			Name:    result.Name,
+			Status:  result.Status,
+			Latency: result.Duration,
+		})
+	}
+	readiness.Status = ready.String()
+	return readiness
+}
+
+func probeJobservice(ctx context.Context) error {
+	url := config.InternalJobServiceURL() + "/api/v1/stats"
+	return HTTPStatusCodeHealthChecker(http.MethodGet, url, nil, probeTimeout, http.StatusOK).Check()
+}
+
+// probeSchema checks the database schema is migrated to the version of the migration scripts
+func probeSchema(ctx context.Context) error {
+	var (
+		version int64
+		dirty   bool
+	)
+	if err := orm.NewOrm().Raw("SELECT version, dirty FROM schema_migrations").QueryRow(&version, &dirty); err != nil {
+		return fmt.Errorf("failed to get the schema version: %v", err)
+	}
+	if dirty {
+		return fmt.Errorf("the migration of the schema version %d is dirty", version)
+	}
+	latest, err := latestSchemaVersion(dao.MigrationPath())
+	if err != nil {
+		return err
+	}
+	if version < latest {
+		return fmt.Errorf("the schema version %d is behind the version %d of the migration scripts", version, latest)
+	}
+	return nil
+}
+
+// latestSchemaVersion returns the version of the latest migration script, e.g. 120 for "0120_2.9.0_schema.up.sql",
+// 0 is returned if the migration scripts don't exist
+func latestSchemaVersion(path string) (int64, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	var latest int64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+		version, err := strconv.ParseInt(strings.SplitN(name, "_", 2)[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		if version > latest {
+			latest = version
+		}
+	}
+	return latest, nil
+}
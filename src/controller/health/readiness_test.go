@@ -0,0 +1,63 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+func TestGetReadiness(t *testing.T) {
+	ctl := controller{}
+	probes = []*validator{
+		fakeValidator("p01", false, nil),
+		fakeValidator("p02", false, nil),
+	}
+	readiness := ctl.GetReadiness(context.TODO())
+	assert.Equal(t, "healthy", readiness.Status)
+	require.Len(t, readiness.Dependencies, 2)
+	assert.Equal(t, "p01", readiness.Dependencies[0].Name)
+	assert.Equal(t, "healthy", readiness.Dependencies[0].Status)
+	assert.NotEmpty(t, readiness.Dependencies[0].Latency)
+	assert.Equal(t, "p02", readiness.Dependencies[1].Name)
+
+	probes = append(probes, fakeValidator("p03", false, errors.New("unreachable")))
+	readiness = ctl.GetReadiness(context.TODO())
+	assert.Equal(t, "unhealthy", readiness.Status)
+	require.Len(t, readiness.Dependencies, 3)
+	assert.Equal(t, "unhealthy", readiness.Dependencies[2].Status)
+}
+
+func TestLatestSchemaVersion(t *testing.T) {
+	// the migration scripts don't exist
+	version, err := latestSchemaVersion(filepath.Join(t.TempDir(), "not-exist"))
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), version)
+
+	dir := t.TempDir()
+	for _, name := range []string{"0001_initial_schema.up.sql", "0120_2.9.0_schema.up.sql", "0110_2.8.0_schema.up.sql", "README.md"} {
+		require.Nil(t, os.WriteFile(filepath.Join(dir, name), nil, 0600))
+	}
+	version, err = latestSchemaVersion(dir)
+	require.Nil(t, err)
+	assert.Equal(t, int64(120), version)
+}
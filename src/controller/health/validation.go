@@ -0,0 +1,211 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/controller/tokenkey"
+	"github.com/goharbor/harbor/src/lib/cache"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+var (
+	validationTimeout = 10 * time.Second
+	validators        = []*validator{
+		{
+			name:       "database",
+			suggestion: "make sure the database is running and the POSTGRESQL_* settings of core point to it",
+			validate:   validateDatabase,
+		},
+		{
+			name:       "redis",
+			suggestion: "make sure redis is running and the _REDIS_URL_CORE setting of core points to it",
+			validate:   validateRedis,
+		},
+		{
+			name:       "registry_url",
+			suggestion: "make sure the registry is running and the REGISTRY_URL setting of core points to it",
+			validate:   validateRegistryURL,
+		},
+		{
+			name:       "token_key",
+			fatal:      true,
+			suggestion: "make sure the TOKEN_PRIVATE_KEY_PATH setting of core points to the PEM encoded RSA private key whose certificate is configured in the registry, or the rotated token keys can be decrypted by the secret key",
+			validate:   validateTokenKey,
+		},
+		{
+			name:       "external_url",
+			fatal:      true,
+			suggestion: "set the EXT_ENDPOINT setting of core to the URL the clients access Harbor with, e.g. https://harbor.example.com",
+			validate:   validateExternalURL,
+		},
+	}
+)
+
+// validator validates one dependency or configuration item
+type validator struct {
+	name string
+	// the misconfiguration which doesn't recover by itself, e.g. an invalid file or setting,
+	// the unreachable dependencies aren't fatal as they may be still starting
+	fatal bool
+	// the action to fix the failure
+	suggestion string
+	validate   func(ctx context.Context) error
+}
+
+func (c *controller) Validate(ctx context.Context) []*ValidationResult {
+	ch := make(chan *ValidationResult, len(validators))
+	for _, v := range validators {
+		go func(v *validator) {
+			ch <- runValidator(ctx, v, validationTimeout)
+		}(v)
+	}
+	var results []*ValidationResult
+	for i := 0; i < len(validators); i++ {
+		results = append(results, <-ch)
+	}
+	// keep the order of the validators
+	index := map[string]int{}
+	for i, v := range validators {
+		index[v.name] = i
+	}
+	sorted := make([]*ValidationResult, len(results))
+	for _, result := range results {
+		sorted[index[result.Name]] = result
+	}
+	return sorted
+}
+
+func (c *controller) GetHealthDetail(ctx context.Context) *HealthDetail {
+	overall := c.GetHealth(ctx)
+	validations := c.Validate(ctx)
+	status := overall.Status
+	for _, v := range validations {
+		if len(v.Error) > 0 {
+			var healthy healthy = false
+			status = healthy.String()
+			break
+		}
+	}
+	return &HealthDetail{
+		Status:      status,
+		Components:  overall.Components,
+		Validations: validations,
+	}
+}
+
+func runValidator(ctx context.Context, v *validator, timeout time.Duration) *ValidationResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	errChan := make(chan error, 1)
+	start := time.Now()
+	go func() {
+		errChan <- v.validate(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errChan:
+	case <-ctx.Done():
+		err = fmt.Errorf("timeout after %s", timeout)
+	}
+	var healthy healthy = err == nil
+	result := &ValidationResult{
+		Name:     v.name,
+		Status:   healthy.String(),
+		Fatal:    v.fatal,
+		Duration: time.Since(start).String(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+		result.Suggestion = v.suggestion
+	}
+	return result
+}
+
+func validateDatabase(ctx context.Context) error {
+	if _, err := orm.NewOrm().Raw("SELECT 1").Exec(); err != nil {
+		return fmt.Errorf("failed to run SQL \"SELECT 1\": %v", err)
+	}
+	return nil
+}
+
+func validateRedis(ctx context.Context) error {
+	c := cache.Default()
+	if c == nil {
+		return errors.New("the cache isn't initialized")
+	}
+	if err := c.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping redis: %v", err)
+	}
+	return nil
+}
+
+func validateRegistryURL(ctx context.Context) error {
+	endpoint, err := config.RegistryURL()
+	if err != nil {
+		return err
+	}
+	u, err := utils.ParseEndpoint(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid registry URL %q: %v", endpoint, err)
+	}
+	return HTTPStatusCodeHealthChecker(http.MethodGet, u.String()+"/", nil, validationTimeout, http.StatusOK).Check()
+}
+
+func validateTokenKey(ctx context.Context) error {
+	options, err := tokenkey.Ctl.SigningOptions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read the token private key: %v", err)
+	}
+	if _, err = options.GetKey(); err != nil {
+		return fmt.Errorf("invalid token private key: %v", err)
+	}
+	return nil
+}
+
+func validateExternalURL(ctx context.Context) error {
+	endpoint, err := config.ExtEndpoint()
+	if err != nil {
+		return err
+	}
+	if len(endpoint) == 0 {
+		return errors.New("the external URL is empty")
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid external URL %q: %v", endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("the scheme of the external URL %q must be http or https", endpoint)
+	}
+	if len(u.Hostname()) == 0 {
+		return fmt.Errorf("no host in the external URL %q", endpoint)
+	}
+	if len(strings.Trim(u.Path, "/")) > 0 {
+		return fmt.Errorf("the external URL %q mustn't contain a path", endpoint)
+	}
+	return nil
+}
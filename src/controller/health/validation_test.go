@@ -0,0 +1,101 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/distribution/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
+)
+
+func fakeValidator(name string, fatal bool, err error) *validator {
+	return &validator{
+		name:       name,
+		fatal:      fatal,
+		suggestion: "fix " + name,
+		validate: func(ctx context.Context) error {
+			return err
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	ctl := controller{}
+	validators = []*validator{
+		fakeValidator("v01", false, nil),
+		fakeValidator("v02", true, errors.New("invalid")),
+		fakeValidator("v03", false, nil),
+	}
+	results := ctl.Validate(context.TODO())
+	require.Len(t, results, 3)
+	assert.Equal(t, "v01", results[0].Name)
+	assert.Equal(t, "healthy", results[0].Status)
+	assert.Empty(t, results[0].Suggestion)
+	assert.Equal(t, "v02", results[1].Name)
+	assert.Equal(t, "unhealthy", results[1].Status)
+	assert.True(t, results[1].Fatal)
+	assert.Equal(t, "invalid", results[1].Error)
+	assert.Equal(t, "fix v02", results[1].Suggestion)
+	assert.Equal(t, "v03", results[2].Name)
+
+	// all components are healthy but the validation fails
+	registry = map[string]health.Checker{}
+	registry["component01"] = fakeHealthChecker(true)
+	detail := ctl.GetHealthDetail(context.TODO())
+	assert.Equal(t, "unhealthy", detail.Status)
+	assert.Len(t, detail.Components, 1)
+	assert.Len(t, detail.Validations, 3)
+}
+
+func TestRunValidatorTimeout(t *testing.T) {
+	v := &validator{
+		name: "slow",
+		validate: func(ctx context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		},
+	}
+	result := runValidator(context.TODO(), v, 10*time.Millisecond)
+	assert.Equal(t, "unhealthy", result.Status)
+	assert.Contains(t, result.Error, "timeout")
+}
+
+func TestValidateExternalURL(t *testing.T) {
+	cases := []struct {
+		endpoint string
+		valid    bool
+	}{
+		{"https://harbor.example.com", true},
+		{"http://harbor.example.com:8080/", true},
+		{"", false},
+		{"harbor.example.com", false},
+		{"ftp://harbor.example.com", false},
+		{"https://harbor.example.com/harbor", false},
+	}
+	for _, c := range cases {
+		config.InitWithSettings(map[string]interface{}{common.ExtEndpoint: c.endpoint})
+		err := validateExternalURL(context.TODO())
+		assert.Equal(t, c.valid, err == nil, c.endpoint)
+	}
+}
@@ -0,0 +1,346 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagecheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/distribution/reference"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	rbac_project "github.com/goharbor/harbor/src/common/rbac/project"
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/cnab"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/image"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/quarantine"
+	"github.com/goharbor/harbor/src/controller/scan"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	accmodel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	"github.com/goharbor/harbor/src/pkg/signature"
+	"github.com/goharbor/harbor/src/pkg/signature/cosign"
+)
+
+const (
+	// SignatureCosign is the signature of Cosign
+	SignatureCosign = "cosign"
+	// SignatureNotary is the signature of Notary
+	SignatureNotary = "notary"
+
+	// ScanStatusNotScanned is the scan status of the scannable artifact without the report
+	ScanStatusNotScanned = "Not Scanned"
+)
+
+// Ctl is a global image check controller instance
+var Ctl = NewController()
+
+// Scan is the scan result of the image after the CVE allowlist of the project is applied
+type Scan struct {
+	Status          string `json:"status"`
+	Severity        string `json:"severity,omitempty"`
+	Vulnerabilities int    `json:"vulnerabilities"`
+}
+
+// Verdict is the state of the image and whether it passes the pull policies of the project,
+// the violations are the reasons why the image cannot be pulled
+type Verdict struct {
+	Image            string   `json:"image"`
+	Exists           bool     `json:"exists"`
+	Project          string   `json:"project,omitempty"`
+	Repository       string   `json:"repository,omitempty"`
+	Digest           string   `json:"digest,omitempty"`
+	Tags             []string `json:"tags,omitempty"`
+	Signed           bool     `json:"signed"`
+	Signatures       []string `json:"signatures,omitempty"`
+	Scan             *Scan    `json:"scan,omitempty"`
+	Labels           []string `json:"labels,omitempty"`
+	Quarantined      bool     `json:"quarantined"`
+	QuarantineReason string   `json:"quarantine_reason,omitempty"`
+	Allowed          bool     `json:"allowed"`
+	Violations       []string `json:"violations,omitempty"`
+}
+
+func (v *Verdict) violate(format string, args ...interface{}) {
+	v.Violations = append(v.Violations, fmt.Sprintf(format, args...))
+}
+
+// Controller checks the images against the data and the pull policies of Harbor, it's designed
+// for the validating admission webhooks of Kubernetes to decide with one call per admission
+type Controller interface {
+	// Check the image specified by the reference, e.g. "harbor.example.com/library/nginx:1.25", the
+	// registry part is ignored. The image is reported as not existing if the caller cannot pull it
+	Check(ctx context.Context, image string) (*Verdict, error)
+}
+
+// NewController creates an instance of the default image check controller
+func NewController() Controller {
+	return &controller{
+		proCtl:        project.Ctl,
+		artCtl:        artifact.Ctl,
+		scanCtl:       scan.DefaultController,
+		quarantineCtl: quarantine.Ctl,
+		scanChecker:   scan.NewChecker,
+		verifier:      cosign.Verifier,
+		notarySigned:  notarySigned,
+	}
+}
+
+type controller struct {
+	proCtl        project.Controller
+	artCtl        artifact.Controller
+	scanCtl       scan.Controller
+	quarantineCtl quarantine.Controller
+	scanChecker   func() scan.Checker
+	verifier      cosign.SignatureVerifier
+	notarySigned  func(ctx context.Context, repository, tag, digest string) (bool, error)
+}
+
+// notarySigned returns whether the tag or the digest when the tag is empty is signed in Notary
+func notarySigned(ctx context.Context, repository, tag, digest string) (bool, error) {
+	checker, err := signature.GetManager().GetCheckerByRepo(ctx, repository)
+	if err != nil {
+		return false, err
+	}
+	if len(tag) > 0 {
+		return checker.IsTagSigned(tag, digest), nil
+	}
+	return checker.IsArtifactSigned(digest), nil
+}
+
+// parse parses the image reference into the repository without the registry part and the tag or digest,
+// the tag is "latest" if neither the tag nor the digest is specified
+func parse(image string) (repository, tag, digest string, err error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", "", "", errors.BadRequestError(err).WithMessage("invalid image reference %s", image)
+	}
+	repository = reference.Path(named)
+	if digested, ok := named.(reference.Digested); ok {
+		digest = digested.Digest().String()
+	}
+	if tagged, ok := named.(reference.Tagged); ok {
+		tag = tagged.Tag()
+	} else if len(digest) == 0 {
+		tag = "latest"
+	}
+	return repository, tag, digest, nil
+}
+
+func (c *controller) Check(ctx context.Context, image string) (*Verdict, error) {
+	repository, tag, digest, err := parse(image)
+	if err != nil {
+		return nil, err
+	}
+	verdict := &Verdict{Image: image}
+	projectName, _ := utils.ParseRepository(repository)
+
+	p, err := c.proCtl.GetByName(ctx, projectName, project.WithEffectCVEAllowlist())
+	if err != nil {
+		if !errors.IsNotFoundErr(err) {
+			return nil, err
+		}
+		verdict.violate("the image doesn't exist")
+		return verdict, nil
+	}
+	if !canPull(ctx, p.ProjectID) {
+		// the existence of the image isn't disclosed to the callers without the pull permission
+		verdict.violate("the image doesn't exist")
+		return verdict, nil
+	}
+
+	ref := tag
+	if len(digest) > 0 {
+		ref = digest
+	}
+	art, err := c.artCtl.GetByReference(ctx, repository, ref, &artifact.Option{
+		WithTag:       true,
+		WithLabel:     true,
+		WithAccessory: true,
+	})
+	if err != nil {
+		if !errors.IsNotFoundErr(err) {
+			return nil, err
+		}
+		verdict.violate("the image doesn't exist")
+		return verdict, nil
+	}
+	verdict.Exists = true
+	verdict.Project = p.Name
+	verdict.Repository = repository
+	verdict.Digest = art.Digest
+	for _, t := range art.Tags {
+		verdict.Tags = append(verdict.Tags, t.Name)
+	}
+	for _, l := range art.Labels {
+		verdict.Labels = append(verdict.Labels, l.Name)
+	}
+
+	if err := c.checkQuarantine(ctx, p, art, verdict); err != nil {
+		return nil, err
+	}
+	if err := c.checkScan(ctx, p, art, verdict); err != nil {
+		return nil, err
+	}
+	if err := c.checkSignatures(ctx, p, art, tag, verdict); err != nil {
+		return nil, err
+	}
+	verdict.Allowed = len(verdict.Violations) == 0
+	return verdict, nil
+}
+
+func canPull(ctx context.Context, projectID int64) bool {
+	secCtx, ok := security.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	resource := rbac_project.NewNamespace(projectID).Resource(rbac.ResourceRepository)
+	return secCtx.Can(ctx, rbac.ActionPull, resource)
+}
+
+func (c *controller) checkQuarantine(ctx context.Context, p *proModels.Project, art *artifact.Artifact, verdict *Verdict) error {
+	if !p.QuarantineEnabled() {
+		return nil
+	}
+	qt, err := c.quarantineCtl.Get(ctx, art.ID)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return nil
+		}
+		return err
+	}
+	verdict.Quarantined = true
+	verdict.QuarantineReason = qt.Reason
+	verdict.violate("the image is quarantined: %s", qt.Reason)
+	return nil
+}
+
+// checkScan reports the scan result and checks it against the vulnerability prevention policy of the project
+// as the "vulnerable" middleware does when pulling
+func (c *controller) checkScan(ctx context.Context, p *proModels.Project, art *artifact.Artifact, verdict *Verdict) error {
+	scannable, err := c.scanChecker().IsScannable(ctx, art)
+	if err != nil {
+		return err
+	}
+	if !scannable {
+		return nil
+	}
+	severity := vuln.ParseSeverityVersion3(p.Severity())
+	// the image index of the images and the CNAB skips the vulnerability prevention
+	prevented := p.VulPrevented() && !(art.IsImageIndex() && (art.Type == image.ArtifactTypeImage || art.Type == cnab.ArtifactTypeCNAB))
+
+	vulnerable, err := c.scanCtl.GetVulnerable(ctx, art, p.CVEAllowlist.CVESet())
+	if err != nil {
+		if !errors.IsNotFoundErr(err) {
+			return err
+		}
+		verdict.Scan = &Scan{Status: ScanStatusNotScanned}
+		if prevented {
+			verdict.violate("the image isn't scanned while the vulnerabilities with the severity of %q or higher are prevented", severity)
+		}
+		return nil
+	}
+	verdict.Scan = &Scan{
+		Status:          vulnerable.ScanStatus,
+		Vulnerabilities: vulnerable.VulnerabilitiesCount,
+	}
+	if vulnerable.Severity != nil {
+		verdict.Scan.Severity = vulnerable.Severity.String()
+	}
+	if !prevented {
+		return nil
+	}
+	if !vulnerable.IsScanSuccess() {
+		verdict.violate("the scanning of the image is %s while the vulnerabilities with the severity of %q or higher are prevented",
+			vulnerable.ScanStatus, severity)
+		return nil
+	}
+	if vulnerable.Severity != nil && vulnerable.Severity.Code() >= severity.Code() {
+		verdict.violate("the image has %d vulnerabilities with the severity of %q or higher", vulnerable.VulnerabilitiesCount, severity)
+	}
+	return nil
+}
+
+// checkSignatures reports the signatures of the image and checks them against the content trust policy
+// of the project as the "contenttrust" middleware does when pulling
+func (c *controller) checkSignatures(ctx context.Context, p *proModels.Project, art *artifact.Artifact, tag string, verdict *Verdict) error {
+	var cosignSignatures []string
+	for _, acc := range art.Accessories {
+		if acc.GetData().Type == accmodel.TypeCosignSignature {
+			cosignSignatures = append(cosignSignatures, acc.GetData().Digest)
+		}
+	}
+	cosignTrusted := len(cosignSignatures) > 0
+	if len(cosignSignatures) > 0 {
+		verdict.Signatures = append(verdict.Signatures, SignatureCosign)
+		if trustedKeys := p.CosignTrustedKeys(); len(trustedKeys) > 0 && p.ContentTrustCosignEnabled() {
+			cosignTrusted = false
+			keys, err := cosign.ParsePublicKeys(trustedKeys)
+			if err != nil {
+				log.G(ctx).Warningf("the trusted keys of Cosign of the project %s are invalid: %v", p.Name, err)
+			} else {
+				for _, sig := range cosignSignatures {
+					if err := c.verifier.Verify(ctx, art.RepositoryName, art.Digest, sig, keys); err == nil {
+						cosignTrusted = true
+						break
+					}
+				}
+			}
+		}
+	}
+
+	notarySigned := false
+	if p.ContentTrustEnabled() {
+		signed, err := c.notarySigned(ctx, art.RepositoryName, tag, art.Digest)
+		if err != nil {
+			return err
+		}
+		if signed {
+			notarySigned = true
+			verdict.Signatures = append(verdict.Signatures, SignatureNotary)
+		}
+	}
+	verdict.Signed = len(verdict.Signatures) > 0
+
+	var missing []string
+	enabled := 0
+	if p.ContentTrustCosignEnabled() {
+		enabled++
+		if !cosignTrusted {
+			missing = append(missing, "Cosign")
+		}
+	}
+	if p.ContentTrustEnabled() {
+		enabled++
+		if !notarySigned {
+			missing = append(missing, "Notary")
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if p.ContentTrustPolicy() == proModels.ContentTrustPolicyAny && len(missing) < enabled {
+		return nil
+	}
+	for _, backend := range missing {
+		verdict.violate("the image isn't signed in %s as required by the content trust policy", backend)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagecheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/controller/artifact"
+	scanctl "github.com/goharbor/harbor/src/controller/scan"
+	"github.com/goharbor/harbor/src/controller/tag"
+	"github.com/goharbor/harbor/src/lib/errors"
+	accmodel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/pkg/accessory/model/cosign"
+	pkgart "github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/pkg/label/model"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	qtmodel "github.com/goharbor/harbor/src/pkg/quarantine/model"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	model_tag "github.com/goharbor/harbor/src/pkg/tag/model/tag"
+	securitytesting "github.com/goharbor/harbor/src/testing/common/security"
+	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	quarantinetesting "github.com/goharbor/harbor/src/testing/controller/quarantine"
+	scantesting "github.com/goharbor/harbor/src/testing/controller/scan"
+	"github.com/goharbor/harbor/src/testing/mock"
+	cosigntesting "github.com/goharbor/harbor/src/testing/pkg/signature/cosign"
+)
+
+type controllerTestSuite struct {
+	suite.Suite
+	ctl           *controller
+	proCtl        *projecttesting.Controller
+	artCtl        *artifacttesting.Controller
+	scanCtl       *scantesting.Controller
+	checker       *scantesting.Checker
+	quarantineCtl *quarantinetesting.Controller
+	verifier      *cosigntesting.SignatureVerifier
+	secCtx        *securitytesting.Context
+	notarySigned  bool
+	art           *artifact.Artifact
+}
+
+func (c *controllerTestSuite) SetupTest() {
+	c.proCtl = &projecttesting.Controller{}
+	c.artCtl = &artifacttesting.Controller{}
+	c.scanCtl = &scantesting.Controller{}
+	c.checker = &scantesting.Checker{}
+	c.quarantineCtl = &quarantinetesting.Controller{}
+	c.verifier = &cosigntesting.SignatureVerifier{}
+	c.secCtx = &securitytesting.Context{}
+	c.notarySigned = false
+	c.ctl = &controller{
+		proCtl:        c.proCtl,
+		artCtl:        c.artCtl,
+		scanCtl:       c.scanCtl,
+		quarantineCtl: c.quarantineCtl,
+		scanChecker:   func() scanctl.Checker { return c.checker },
+		verifier:      c.verifier,
+		notarySigned: func(ctx context.Context, repository, tag, digest string) (bool, error) {
+			return c.notarySigned, nil
+		},
+	}
+	c.art = &artifact.Artifact{
+		Artifact: pkgart.Artifact{ID: 1, ProjectID: 1, RepositoryName: "library/hello-world", Digest: "sha256:123"},
+		Tags:     []*tag.Tag{{Tag: model_tag.Tag{Name: "latest"}}},
+		Labels:   []*model.Label{{Name: "prod"}},
+	}
+}
+
+func (c *controllerTestSuite) context() context.Context {
+	return security.NewContext(context.TODO(), c.secCtx)
+}
+
+func (c *controllerTestSuite) mockProject(metadata map[string]string) {
+	mock.OnAnything(c.proCtl, "GetByName").Return(&proModels.Project{ProjectID: 1, Name: "library", Metadata: metadata}, nil)
+}
+
+func (c *controllerTestSuite) TestParse() {
+	repository, tag, digest, err := parse("nginx")
+	c.Require().Nil(err)
+	c.Equal("library/nginx", repository)
+	c.Equal("latest", tag)
+	c.Empty(digest)
+
+	repository, tag, digest, err = parse("harbor.example.com/library/hello-world:v1@sha256:0000000000000000000000000000000000000000000000000000000000000000")
+	c.Require().Nil(err)
+	c.Equal("library/hello-world", repository)
+	c.Equal("v1", tag)
+	c.Equal("sha256:0000000000000000000000000000000000000000000000000000000000000000", digest)
+
+	_, _, _, err = parse("INVALID:")
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+}
+
+func (c *controllerTestSuite) TestCheckNoPermission() {
+	c.mockProject(nil)
+	mock.OnAnything(c.secCtx, "Can").Return(false)
+	verdict, err := c.ctl.Check(c.context(), "library/hello-world")
+	c.Require().Nil(err)
+	c.False(verdict.Exists)
+	c.False(verdict.Allowed)
+	c.artCtl.AssertNotCalled(c.T(), "GetByReference", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (c *controllerTestSuite) TestCheckNotFound() {
+	c.mockProject(nil)
+	mock.OnAnything(c.secCtx, "Can").Return(true)
+	mock.OnAnything(c.artCtl, "GetByReference").Return(nil, errors.NotFoundError(nil))
+	verdict, err := c.ctl.Check(c.context(), "library/hello-world")
+	c.Require().Nil(err)
+	c.False(verdict.Exists)
+	c.False(verdict.Allowed)
+	c.Len(verdict.Violations, 1)
+}
+
+func (c *controllerTestSuite) TestCheckAllowed() {
+	c.mockProject(nil)
+	mock.OnAnything(c.secCtx, "Can").Return(true)
+	mock.OnAnything(c.artCtl, "GetByReference").Return(c.art, nil)
+	mock.OnAnything(c.checker, "IsScannable").Return(true, nil)
+	mock.OnAnything(c.scanCtl, "GetVulnerable").Return(&scanctl.Vulnerable{
+		ScanStatus:           "Success",
+		Severity:             severityPtr(vuln.High),
+		VulnerabilitiesCount: 3,
+	}, nil)
+	verdict, err := c.ctl.Check(c.context(), "library/hello-world")
+	c.Require().Nil(err)
+	c.True(verdict.Exists)
+	c.True(verdict.Allowed)
+	c.Equal("library", verdict.Project)
+	c.Equal([]string{"latest"}, verdict.Tags)
+	c.Equal([]string{"prod"}, verdict.Labels)
+	c.Equal(&Scan{Status: "Success", Severity: "High", Vulnerabilities: 3}, verdict.Scan)
+	c.False(verdict.Signed)
+	c.quarantineCtl.AssertNotCalled(c.T(), "Get", mock.Anything, mock.Anything)
+}
+
+func (c *controllerTestSuite) TestCheckViolations() {
+	c.mockProject(map[string]string{
+		proModels.ProMetaQuarantine:               "true",
+		proModels.ProMetaPreventVul:               "true",
+		proModels.ProMetaSeverity:                 "high",
+		proModels.ProMetaEnableContentTrust:       "true",
+		proModels.ProMetaEnableContentTrustCosign: "true",
+	})
+	c.art.Accessories = []accmodel.Accessory{cosign.New(accmodel.AccessoryData{Type: accmodel.TypeCosignSignature, Digest: "sha256:456"})}
+	mock.OnAnything(c.secCtx, "Can").Return(true)
+	mock.OnAnything(c.artCtl, "GetByReference").Return(c.art, nil)
+	mock.OnAnything(c.quarantineCtl, "Get").Return(&qtmodel.Quarantine{ArtifactID: 1, Reason: "not scanned"}, nil)
+	mock.OnAnything(c.checker, "IsScannable").Return(true, nil)
+	mock.OnAnything(c.scanCtl, "GetVulnerable").Return(&scanctl.Vulnerable{
+		ScanStatus:           "Success",
+		Severity:             severityPtr(vuln.Critical),
+		VulnerabilitiesCount: 2,
+	}, nil)
+	verdict, err := c.ctl.Check(c.context(), "library/hello-world:latest")
+	c.Require().Nil(err)
+	c.True(verdict.Exists)
+	c.False(verdict.Allowed)
+	c.True(verdict.Quarantined)
+	c.Equal("not scanned", verdict.QuarantineReason)
+	c.True(verdict.Signed)
+	c.Equal([]string{SignatureCosign}, verdict.Signatures)
+	// quarantine, vulnerability and the missing Notary signature under the "all" policy
+	c.Len(verdict.Violations, 3)
+}
+
+func (c *controllerTestSuite) TestCheckContentTrustAny() {
+	c.mockProject(map[string]string{
+		proModels.ProMetaEnableContentTrust:       "true",
+		proModels.ProMetaEnableContentTrustCosign: "true",
+		proModels.ProMetaContentTrustPolicy:       proModels.ContentTrustPolicyAny,
+	})
+	c.notarySigned = true
+	mock.OnAnything(c.secCtx, "Can").Return(true)
+	mock.OnAnything(c.artCtl, "GetByReference").Return(c.art, nil)
+	mock.OnAnything(c.checker, "IsScannable").Return(false, nil)
+	verdict, err := c.ctl.Check(c.context(), "library/hello-world")
+	c.Require().Nil(err)
+	c.True(verdict.Allowed)
+	c.Equal([]string{SignatureNotary}, verdict.Signatures)
+	c.Nil(verdict.Scan)
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &controllerTestSuite{})
+}
+
+func severityPtr(s vuln.Severity) *vuln.Severity {
+	return &s
+}
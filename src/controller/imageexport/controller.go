@@ -0,0 +1,528 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageexport
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/controller/imagecheck"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg"
+	"github.com/goharbor/harbor/src/pkg/project"
+	"github.com/goharbor/harbor/src/pkg/repository"
+	"github.com/goharbor/harbor/src/pkg/systemartifact"
+	"github.com/goharbor/harbor/src/pkg/systemartifact/dao"
+	"github.com/goharbor/harbor/src/pkg/systemartifact/model"
+	"github.com/goharbor/harbor/src/pkg/tag"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+const (
+	// Vendor is the vendor of the system artifacts keeping the exported archives
+	Vendor = "image_export"
+	// ArtifactType is the type of the system artifacts keeping the exported archives
+	ArtifactType = "OCILayout"
+
+	// DefaultTTL is the default lifetime of the download URL of the exported archive
+	DefaultTTL = 24 * time.Hour
+	// MaxTTL is the max lifetime of the download URL of the exported archive
+	MaxTTL = 7 * 24 * time.Hour
+	// the max count of the artifacts exported into one archive
+	maxArtifacts = 500
+
+	attrProjectName = "project_name"
+	attrArtifacts   = "artifacts"
+	attrOperator    = "operator"
+	attrRevoked     = "revoked"
+	attrTotal       = "total"
+	attrExported    = "exported"
+	attrSize        = "size"
+	attrDigest      = "digest"
+	attrExpiresAt   = "expires_at"
+
+	// ParamExportID is the job parameter of the ID of the export, it's also the repository of the system artifact
+	ParamExportID = "export_id"
+	// ParamArtifacts is the job parameter of the artifacts to be exported
+	ParamArtifacts = "artifacts"
+	// ParamRegistry is the job parameter of the registry part of the image names in the archive
+	ParamRegistry = "registry"
+	// ParamTTL is the job parameter of the lifetime in seconds of the exported archive
+	ParamTTL = "ttl"
+)
+
+var (
+	// Ctl is a global image export controller instance
+	Ctl = NewController()
+)
+
+func init() {
+	if err := task.RegisterCheckInProcessor(job.ImageExport, checkInProcessor); err != nil {
+		log.Fatalf("failed to register the checkin processor for the image export job, error %v", err)
+	}
+	// the archives are kept until the download URLs expire rather than the default 24 hours
+	systemartifact.Mgr.RegisterCleanupCriteria(Vendor, ArtifactType, &expirySelector{dao: dao.NewSystemArtifactDao()})
+}
+
+// Selection selects the tags of the repository to be exported, all the tags are selected if none is specified
+type Selection struct {
+	Repository string   `json:"repository"`
+	Tags       []string `json:"tags,omitempty"`
+}
+
+// Artifact is the tagged artifact exported into the archive
+type Artifact struct {
+	Repository string `json:"repository"`
+	Tag        string `json:"tag"`
+}
+
+// Progress is the progress checked in by the image export job, the digest, size and
+// expiration of the archive are checked in once the archive is created
+type Progress struct {
+	Total     int    `json:"total"`
+	Exported  int    `json:"exported"`
+	Size      int64  `json:"size,omitempty"`
+	Digest    string `json:"digest,omitempty"`
+	ExpiresAt int64  `json:"expires_at,omitempty"`
+}
+
+// Export is the export of the artifacts into the OCI layout archive downloadable before it expires
+type Export struct {
+	ID            int64       `json:"id"`
+	ProjectID     int64       `json:"project_id"`
+	ProjectName   string      `json:"project_name"`
+	Status        string      `json:"status"`
+	StatusMessage string      `json:"status_message,omitempty"`
+	Artifacts     []*Artifact `json:"artifacts"`
+	Total         int         `json:"total"`
+	Exported      int         `json:"exported"`
+	Size          int64       `json:"size,omitempty"`
+	Digest        string      `json:"digest,omitempty"`
+	ExpiresAt     *time.Time  `json:"expires_at,omitempty"`
+	Revoked       bool        `json:"revoked"`
+	Operator      string      `json:"operator,omitempty"`
+	StartTime     time.Time   `json:"start_time"`
+	EndTime       time.Time   `json:"end_time"`
+}
+
+// Downloadable returns whether the archive of the export can be downloaded
+func (e *Export) Downloadable() bool {
+	return e.Status == job.SuccessStatus.String() && !e.Revoked && e.ExpiresAt != nil && e.ExpiresAt.After(time.Now())
+}
+
+// Controller defines the operations related with the image exports
+type Controller interface {
+	// Create an export of the selected tags of the repositories under the project, the archive
+	// is created by a job in background and kept downloadable for the "ttl"
+	Create(ctx context.Context, projectID int64, selections []*Selection, ttl time.Duration) (id int64, err error)
+	// Get the export specified by ID
+	Get(ctx context.Context, id int64) (exp *Export, err error)
+	// Count the exports of the project
+	Count(ctx context.Context, projectID int64) (total int64, err error)
+	// List the exports of the project, the latest first
+	List(ctx context.Context, projectID int64, query *q.Query) (exps []*Export, err error)
+	// Revoke the export, the running job is stopped and the created archive is deleted
+	Revoke(ctx context.Context, id int64) (err error)
+	// Sign returns the signature of the download URL of the export
+	Sign(id int64, expiresAt int64) (signature string, err error)
+	// Download returns the reader of the archive of the export after verifying the signature
+	// of the download URL, the reader must be closed by the caller
+	Download(ctx context.Context, id int64, expiresAt int64, signature string) (exp *Export, reader io.ReadCloser, err error)
+}
+
+// NewController creates an instance of the default image export controller
+func NewController() Controller {
+	return &controller{
+		proMgr:         pkg.ProjectMgr,
+		repoMgr:        pkg.RepositoryMgr,
+		tagMgr:         tag.Mgr,
+		exeMgr:         task.ExecMgr,
+		taskMgr:        task.Mgr,
+		sysArtifactMgr: systemartifact.Mgr,
+		checkCtl:       imagecheck.Ctl,
+		secretKey:      config.SecretKey,
+		registry:       config.ExtURL,
+	}
+}
+
+type controller struct {
+	proMgr         project.Manager
+	repoMgr        repository.Manager
+	tagMgr         tag.Manager
+	exeMgr         task.ExecutionManager
+	taskMgr        task.Manager
+	sysArtifactMgr systemartifact.Manager
+	checkCtl       imagecheck.Controller
+	secretKey      func() (string, error)
+	registry       func() (string, error)
+}
+
+func (c *controller) Create(ctx context.Context, projectID int64, selections []*Selection, ttl time.Duration) (int64, error) {
+	if ttl <= 0 || ttl > MaxTTL {
+		return 0, errors.BadRequestError(nil).WithMessage("the TTL must be between 1 second and %s", MaxTTL)
+	}
+	if len(selections) == 0 {
+		return 0, errors.BadRequestError(nil).WithMessage("no repository is selected")
+	}
+	p, err := c.proMgr.Get(ctx, projectID)
+	if err != nil {
+		return 0, err
+	}
+	artifacts, err := c.resolve(ctx, p.Name, selections)
+	if err != nil {
+		return 0, err
+	}
+	registry, err := c.registry()
+	if err != nil {
+		return 0, err
+	}
+
+	attrs := map[string]interface{}{
+		attrProjectName: p.Name,
+		attrArtifacts:   artifacts,
+	}
+	if sc, ok := security.FromContext(ctx); ok {
+		attrs[attrOperator] = sc.GetUsername()
+	}
+	id, err := c.exeMgr.Create(ctx, job.ImageExport, projectID, task.ExecutionTriggerManual, attrs)
+	if err != nil {
+		return 0, err
+	}
+	_, err = c.taskMgr.Create(ctx, id, &task.Job{
+		Name: job.ImageExport,
+		Metadata: &job.Metadata{
+			JobKind: job.KindGeneric,
+		},
+		Parameters: map[string]interface{}{
+			ParamExportID:  id,
+			ParamArtifacts: artifacts,
+			ParamRegistry:  registry,
+			ParamTTL:       int64(ttl / time.Second),
+		},
+	})
+	if err != nil {
+		if e := c.exeMgr.MarkError(ctx, id, err.Error()); e != nil {
+			log.Errorf("failed to mark the error status of the image export %d: %v", id, e)
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+// resolve the selections into the tagged artifacts, all the selected repositories and tags must exist
+// and pass the pull policies of the project
+func (c *controller) resolve(ctx context.Context, projectName string, selections []*Selection) ([]*Artifact, error) {
+	var artifacts []*Artifact
+	for _, selection := range selections {
+		repository := fmt.Sprintf("%s/%s", projectName, selection.Repository)
+		repo, err := c.repoMgr.GetByName(ctx, repository)
+		if err != nil {
+			return nil, err
+		}
+		tags := selection.Tags
+		if len(tags) == 0 {
+			ts, err := c.tagMgr.List(ctx, q.New(q.KeyWords{"RepositoryID": repo.RepositoryID}))
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range ts {
+				tags = append(tags, t.Name)
+			}
+		} else {
+			for _, t := range tags {
+				count, err := c.tagMgr.Count(ctx, q.New(q.KeyWords{"RepositoryID": repo.RepositoryID, "Name": t}))
+				if err != nil {
+					return nil, err
+				}
+				if count == 0 {
+					return nil, errors.NotFoundError(nil).WithMessage("tag %s of the repository %s not found", t, repository)
+				}
+			}
+		}
+		for _, t := range tags {
+			artifacts = append(artifacts, &Artifact{Repository: repository, Tag: t})
+		}
+		if len(artifacts) > maxArtifacts {
+			return nil, errors.BadRequestError(nil).WithMessage("the count of the selected artifacts exceeds %d", maxArtifacts)
+		}
+	}
+	if len(artifacts) == 0 {
+		return nil, errors.BadRequestError(nil).WithMessage("no tagged artifact is selected")
+	}
+	for _, art := range artifacts {
+		if err := c.checkPullPolicies(ctx, art); err != nil {
+			return nil, err
+		}
+	}
+	return artifacts, nil
+}
+
+// checkPullPolicies rejects the artifact which cannot be pulled because it's quarantined, vulnerable or unsigned,
+// as the job reads the artifacts from the registry directly without the policy checks of the pulling. Unlike the
+// pulling, the admins aren't exempted from the quarantine as the archive can be downloaded by anyone with the URL
+func (c *controller) checkPullPolicies(ctx context.Context, art *Artifact) error {
+	verdict, err := c.checkCtl.Check(ctx, fmt.Sprintf("%s:%s", art.Repository, art.Tag))
+	if err != nil {
+		return err
+	}
+	if !verdict.Exists {
+		return errors.NotFoundError(nil).WithMessage("tag %s of the repository %s not found", art.Tag, art.Repository)
+	}
+	if !verdict.Allowed {
+		return errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage("%s:%s cannot be exported due to the policies of the project: %s",
+			art.Repository, art.Tag, strings.Join(verdict.Violations, "; "))
+	}
+	return nil
+}
+
+func (c *controller) Get(ctx context.Context, id int64) (*Export, error) {
+	exec, err := c.getExecution(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	exp := toExport(exec)
+	if err = c.populateProgress(ctx, exp); err != nil {
+		return nil, err
+	}
+	return exp, nil
+}
+
+func (c *controller) Count(ctx context.Context, projectID int64) (int64, error) {
+	return c.exeMgr.Count(ctx, &q.Query{
+		Keywords: map[string]interface{}{
+			"VendorType": job.ImageExport,
+			"VendorID":   projectID,
+		},
+	})
+}
+
+func (c *controller) List(ctx context.Context, projectID int64, query *q.Query) ([]*Export, error) {
+	query = q.MustClone(query)
+	query.Keywords["VendorType"] = job.ImageExport
+	query.Keywords["VendorID"] = projectID
+	query.Sorts = []*q.Sort{q.NewSort("ID", true)}
+	execs, err := c.exeMgr.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var exps []*Export
+	for _, exec := range execs {
+		exp := toExport(exec)
+		if err = c.populateProgress(ctx, exp); err != nil {
+			return nil, err
+		}
+		exps = append(exps, exp)
+	}
+	return exps, nil
+}
+
+func (c *controller) Revoke(ctx context.Context, id int64) error {
+	exec, err := c.getExecution(ctx, id)
+	if err != nil {
+		return err
+	}
+	exp := toExport(exec)
+	if exp.Revoked {
+		return nil
+	}
+	if err = c.populateProgress(ctx, exp); err != nil {
+		return err
+	}
+	if !job.Status(exp.Status).Final() {
+		if err = c.exeMgr.Stop(ctx, id); err != nil {
+			return err
+		}
+	}
+	if len(exp.Digest) > 0 {
+		err = c.sysArtifactMgr.Delete(ctx, Vendor, strconv.FormatInt(id, 10), exp.Digest)
+		if err != nil && !errors.IsNotFoundErr(err) {
+			return err
+		}
+	}
+	exec.ExtraAttrs[attrRevoked] = true
+	return c.exeMgr.UpdateExtraAttrs(ctx, id, exec.ExtraAttrs)
+}
+
+func (c *controller) Sign(id int64, expiresAt int64) (string, error) {
+	key, err := c.secretKey()
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, []byte(key))
+	_, _ = fmt.Fprintf(mac, "%s:%d:%d", Vendor, id, expiresAt)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (c *controller) Download(ctx context.Context, id int64, expiresAt int64, signature string) (*Export, io.ReadCloser, error) {
+	expected, err := c.Sign(id, expiresAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return nil, nil, errors.ForbiddenError(nil).WithMessage("invalid signature of the download URL")
+	}
+	if time.Now().Unix() > expiresAt {
+		return nil, nil, errors.ForbiddenError(nil).WithMessage("the download URL expired")
+	}
+	exp, err := c.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !exp.Downloadable() {
+		return nil, nil, errors.NotFoundError(nil).WithMessage("the archive of the image export %d not found", id)
+	}
+	reader, err := c.sysArtifactMgr.Read(ctx, Vendor, strconv.FormatInt(id, 10), exp.Digest)
+	if err != nil {
+		return nil, nil, err
+	}
+	return exp, reader, nil
+}
+
+func (c *controller) getExecution(ctx context.Context, id int64) (*task.Execution, error) {
+	exec, err := c.exeMgr.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if exec.VendorType != job.ImageExport {
+		return nil, errors.NotFoundError(nil).WithMessage("image export %d not found", id)
+	}
+	if exec.ExtraAttrs == nil {
+		exec.ExtraAttrs = map[string]interface{}{}
+	}
+	return exec, nil
+}
+
+// populateProgress populates the progress checked in by the job of the export
+func (c *controller) populateProgress(ctx context.Context, exp *Export) error {
+	tasks, err := c.taskMgr.List(ctx, &q.Query{
+		Keywords: map[string]interface{}{
+			"ExecutionID": exp.ID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+	attrs := tasks[0].ExtraAttrs
+	exp.Total = int(int64Attr(attrs, attrTotal))
+	exp.Exported = int(int64Attr(attrs, attrExported))
+	exp.Size = int64Attr(attrs, attrSize)
+	exp.Digest, _ = attrs[attrDigest].(string)
+	if expiresAt := int64Attr(attrs, attrExpiresAt); expiresAt > 0 {
+		t := time.Unix(expiresAt, 0)
+		exp.ExpiresAt = &t
+	}
+	return nil
+}
+
+func toExport(exec *task.Execution) *Export {
+	exp := &Export{
+		ID:            exec.ID,
+		ProjectID:     exec.VendorID,
+		Status:        exec.Status,
+		StatusMessage: exec.StatusMessage,
+		StartTime:     exec.StartTime,
+		EndTime:       exec.EndTime,
+	}
+	exp.ProjectName, _ = exec.ExtraAttrs[attrProjectName].(string)
+	exp.Operator, _ = exec.ExtraAttrs[attrOperator].(string)
+	exp.Revoked, _ = exec.ExtraAttrs[attrRevoked].(bool)
+	// the artifacts are stored as JSON in the extra attributes
+	if data, err := json.Marshal(exec.ExtraAttrs[attrArtifacts]); err == nil {
+		_ = json.Unmarshal(data, &exp.Artifacts)
+	}
+	return exp
+}
+
+func int64Attr(attrs map[string]interface{}, key string) int64 {
+	switch v := attrs[key].(type) {
+	case int:
+		return int64(v)
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	default:
+		return 0
+	}
+}
+
+func checkInProcessor(ctx context.Context, t *task.Task, sc *job.StatusChange) error {
+	if sc.CheckIn == "" {
+		return nil
+	}
+	progress := &Progress{}
+	if err := json.Unmarshal([]byte(sc.CheckIn), progress); err != nil {
+		log.Errorf("failed to resolve checkin of image export task %d: %v", t.ID, err)
+		return err
+	}
+	if t.ExtraAttrs == nil {
+		t.ExtraAttrs = map[string]interface{}{}
+	}
+	t.ExtraAttrs[attrTotal] = progress.Total
+	t.ExtraAttrs[attrExported] = progress.Exported
+	if len(progress.Digest) > 0 {
+		t.ExtraAttrs[attrSize] = progress.Size
+		t.ExtraAttrs[attrDigest] = progress.Digest
+		t.ExtraAttrs[attrExpiresAt] = progress.ExpiresAt
+	}
+	return task.Mgr.UpdateExtraAttrs(ctx, t.ID, t.ExtraAttrs)
+}
+
+// ExtraAttrs is the extra attributes of the system artifact keeping the exported archive
+type ExtraAttrs struct {
+	ExpiresAt int64 `json:"expires_at"`
+}
+
+// expirySelector selects the exported archives whose download URLs expire for the clean-up
+type expirySelector struct {
+	dao dao.DAO
+}
+
+func (e *expirySelector) List(ctx context.Context) ([]*model.SystemArtifact, error) {
+	records, err := e.dao.List(ctx, q.New(q.KeyWords{"vendor": Vendor, "type": ArtifactType}))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	var expired []*model.SystemArtifact
+	for _, record := range records {
+		attrs := &ExtraAttrs{}
+		// the archive without the valid expiration is cleaned up as well
+		if err := json.Unmarshal([]byte(record.ExtraAttrs), attrs); err != nil || attrs.ExpiresAt <= now {
+			expired = append(expired, record)
+		}
+	}
+	return expired, nil
+}
+
+func (e *expirySelector) ListWithFilters(ctx context.Context, query *q.Query) ([]*model.SystemArtifact, error) {
+	return e.dao.List(ctx, query)
+}
@@ -0,0 +1,232 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageexport
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/imagecheck"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	repomodel "github.com/goharbor/harbor/src/pkg/repository/model"
+	"github.com/goharbor/harbor/src/pkg/systemartifact/model"
+	tagmodel "github.com/goharbor/harbor/src/pkg/tag/model/tag"
+	"github.com/goharbor/harbor/src/pkg/task"
+	imagechecktesting "github.com/goharbor/harbor/src/testing/controller/imagecheck"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/project"
+	"github.com/goharbor/harbor/src/testing/pkg/repository"
+	systemartifacttesting "github.com/goharbor/harbor/src/testing/pkg/systemartifact"
+	sysartifactdaotesting "github.com/goharbor/harbor/src/testing/pkg/systemartifact/dao"
+	tagtesting "github.com/goharbor/harbor/src/testing/pkg/tag"
+	tasktesting "github.com/goharbor/harbor/src/testing/pkg/task"
+)
+
+type controllerTestSuite struct {
+	suite.Suite
+	ctl            *controller
+	proMgr         *project.Manager
+	repoMgr        *repository.Manager
+	tagMgr         *tagtesting.FakeManager
+	exeMgr         *tasktesting.ExecutionManager
+	taskMgr        *tasktesting.Manager
+	sysArtifactMgr *systemartifacttesting.Manager
+	checkCtl       *imagechecktesting.Controller
+}
+
+func (c *controllerTestSuite) SetupTest() {
+	c.proMgr = &project.Manager{}
+	c.repoMgr = &repository.Manager{}
+	c.tagMgr = &tagtesting.FakeManager{}
+	c.exeMgr = &tasktesting.ExecutionManager{}
+	c.taskMgr = &tasktesting.Manager{}
+	c.sysArtifactMgr = &systemartifacttesting.Manager{}
+	c.checkCtl = &imagechecktesting.Controller{}
+	c.ctl = &controller{
+		proMgr:         c.proMgr,
+		repoMgr:        c.repoMgr,
+		tagMgr:         c.tagMgr,
+		exeMgr:         c.exeMgr,
+		taskMgr:        c.taskMgr,
+		sysArtifactMgr: c.sysArtifactMgr,
+		checkCtl:       c.checkCtl,
+		secretKey:      func() (string, error) { return "secret", nil },
+		registry:       func() (string, error) { return "harbor.example.com", nil },
+	}
+}
+
+func (c *controllerTestSuite) succeededExport(expiresAt int64) {
+	c.exeMgr.On("Get", mock.Anything, int64(1)).Return(&task.Execution{
+		ID:         1,
+		VendorType: job.ImageExport,
+		VendorID:   2,
+		Status:     job.SuccessStatus.String(),
+		ExtraAttrs: map[string]interface{}{
+			"project_name": "library",
+			"artifacts":    []interface{}{map[string]interface{}{"repository": "library/hello-world", "tag": "latest"}},
+		},
+	}, nil)
+	c.taskMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Task{{
+		ID: 1,
+		ExtraAttrs: map[string]interface{}{
+			"total":      float64(1),
+			"exported":   float64(1),
+			"size":       float64(1024),
+			"digest":     "sha256:abc",
+			"expires_at": float64(expiresAt),
+		},
+	}}, nil)
+}
+
+func (c *controllerTestSuite) TestCreate() {
+	// invalid TTL
+	_, err := c.ctl.Create(context.TODO(), 2, []*Selection{{Repository: "hello-world"}}, MaxTTL+time.Second)
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// the tag doesn't exist
+	c.proMgr.On("Get", mock.Anything, int64(2)).Return(&proModels.Project{ProjectID: 2, Name: "library"}, nil)
+	c.repoMgr.On("GetByName", mock.Anything, "library/hello-world").Return(&repomodel.RepoRecord{RepositoryID: 3}, nil)
+	c.repoMgr.On("GetByName", mock.Anything, "library/nginx").Return(&repomodel.RepoRecord{RepositoryID: 4}, nil)
+	c.tagMgr.On("Count").Return(0, nil).Once()
+	_, err = c.ctl.Create(context.TODO(), 2, []*Selection{{Repository: "hello-world", Tags: []string{"v1"}}}, time.Hour)
+	c.True(errors.IsNotFoundErr(err))
+
+	// the selected tag of one repository and all the tags of another
+	c.tagMgr.On("Count").Return(1, nil).Once()
+	c.tagMgr.On("List").Return([]*tagmodel.Tag{{Name: "1.25"}, {Name: "latest"}}, nil).Once()
+	c.checkCtl.On("Check", mock.Anything, mock.Anything).Return(&imagecheck.Verdict{Exists: true, Allowed: true}, nil).Times(3)
+	c.exeMgr.On("Create", mock.Anything, job.ImageExport, int64(2), task.ExecutionTriggerManual, mock.Anything).Return(int64(1), nil).Once()
+	c.taskMgr.On("Create", mock.Anything, int64(1), mock.Anything).Return(int64(1), nil).Once()
+	id, err := c.ctl.Create(context.TODO(), 2, []*Selection{
+		{Repository: "hello-world", Tags: []string{"v1"}},
+		{Repository: "nginx"},
+	}, time.Hour)
+	c.Require().NoError(err)
+	c.Equal(int64(1), id)
+	params := c.taskMgr.Calls[0].Arguments.Get(2).(*task.Job).Parameters
+	c.Equal([]*Artifact{
+		{Repository: "library/hello-world", Tag: "v1"},
+		{Repository: "library/nginx", Tag: "1.25"},
+		{Repository: "library/nginx", Tag: "latest"},
+	}, params[ParamArtifacts])
+	c.Equal("harbor.example.com", params[ParamRegistry])
+	c.Equal(int64(3600), params[ParamTTL])
+}
+
+func (c *controllerTestSuite) TestCreateQuarantined() {
+	c.proMgr.On("Get", mock.Anything, int64(2)).Return(&proModels.Project{ProjectID: 2, Name: "library"}, nil)
+	c.repoMgr.On("GetByName", mock.Anything, "library/hello-world").Return(&repomodel.RepoRecord{RepositoryID: 3}, nil)
+	c.tagMgr.On("Count").Return(1, nil)
+	c.checkCtl.On("Check", mock.Anything, "library/hello-world:latest").Return(&imagecheck.Verdict{
+		Exists:      true,
+		Quarantined: true,
+		Violations:  []string{"the image is quarantined: the artifact isn't scanned"},
+	}, nil)
+	_, err := c.ctl.Create(context.TODO(), 2, []*Selection{{Repository: "hello-world", Tags: []string{"latest"}}}, time.Hour)
+	c.True(errors.IsErr(err, errors.PROJECTPOLICYVIOLATION))
+	c.Contains(err.Error(), "quarantined")
+	c.exeMgr.AssertNotCalled(c.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (c *controllerTestSuite) TestCreateVulnerable() {
+	c.proMgr.On("Get", mock.Anything, int64(2)).Return(&proModels.Project{ProjectID: 2, Name: "library"}, nil)
+	c.repoMgr.On("GetByName", mock.Anything, "library/nginx").Return(&repomodel.RepoRecord{RepositoryID: 4}, nil)
+	c.tagMgr.On("List").Return([]*tagmodel.Tag{{Name: "1.25"}, {Name: "latest"}}, nil)
+	c.checkCtl.On("Check", mock.Anything, "library/nginx:1.25").Return(&imagecheck.Verdict{Exists: true, Allowed: true}, nil)
+	c.checkCtl.On("Check", mock.Anything, "library/nginx:latest").Return(&imagecheck.Verdict{
+		Exists:     true,
+		Scan:       &imagecheck.Scan{Status: "Success", Severity: "Critical", Vulnerabilities: 2},
+		Violations: []string{`the image has 2 vulnerabilities with the severity of "High" or higher`},
+	}, nil)
+	_, err := c.ctl.Create(context.TODO(), 2, []*Selection{{Repository: "nginx"}}, time.Hour)
+	c.True(errors.IsErr(err, errors.PROJECTPOLICYVIOLATION))
+	c.Contains(err.Error(), "library/nginx:latest")
+	c.exeMgr.AssertNotCalled(c.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (c *controllerTestSuite) TestGet() {
+	expiresAt := time.Now().Add(time.Hour).Unix()
+	c.succeededExport(expiresAt)
+	exp, err := c.ctl.Get(context.TODO(), 1)
+	c.Require().NoError(err)
+	c.Equal("library", exp.ProjectName)
+	c.Equal([]*Artifact{{Repository: "library/hello-world", Tag: "latest"}}, exp.Artifacts)
+	c.Equal(int64(1024), exp.Size)
+	c.Equal("sha256:abc", exp.Digest)
+	c.Equal(expiresAt, exp.ExpiresAt.Unix())
+	c.True(exp.Downloadable())
+}
+
+func (c *controllerTestSuite) TestDownload() {
+	expiresAt := time.Now().Add(time.Hour).Unix()
+	c.succeededExport(expiresAt)
+	signature, err := c.ctl.Sign(1, expiresAt)
+	c.Require().NoError(err)
+
+	// invalid signature
+	_, _, err = c.ctl.Download(context.TODO(), 1, expiresAt+1, signature)
+	c.True(errors.IsErr(err, errors.ForbiddenCode))
+
+	// expired
+	expired := time.Now().Add(-time.Hour).Unix()
+	s, _ := c.ctl.Sign(1, expired)
+	_, _, err = c.ctl.Download(context.TODO(), 1, expired, s)
+	c.True(errors.IsErr(err, errors.ForbiddenCode))
+
+	c.sysArtifactMgr.On("Read", mock.Anything, Vendor, "1", "sha256:abc").Return(io.NopCloser(strings.NewReader("archive")), nil).Once()
+	exp, reader, err := c.ctl.Download(context.TODO(), 1, expiresAt, signature)
+	c.Require().NoError(err)
+	defer reader.Close()
+	c.Equal(int64(1), exp.ID)
+	data, _ := io.ReadAll(reader)
+	c.Equal("archive", string(data))
+}
+
+func (c *controllerTestSuite) TestRevoke() {
+	c.succeededExport(time.Now().Add(time.Hour).Unix())
+	c.sysArtifactMgr.On("Delete", mock.Anything, Vendor, "1", "sha256:abc").Return(nil).Once()
+	c.exeMgr.On("UpdateExtraAttrs", mock.Anything, int64(1), mock.MatchedBy(func(attrs map[string]interface{}) bool {
+		return attrs["revoked"] == true && attrs["project_name"] == "library"
+	})).Return(nil).Once()
+	c.Require().NoError(c.ctl.Revoke(context.TODO(), 1))
+	c.exeMgr.AssertNotCalled(c.T(), "Stop", mock.Anything, mock.Anything)
+	c.exeMgr.AssertExpectations(c.T())
+	c.sysArtifactMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestExpirySelector() {
+	d := &sysartifactdaotesting.DAO{}
+	d.On("List", mock.Anything, mock.Anything).Return([]*model.SystemArtifact{
+		{ID: 1, ExtraAttrs: `{"expires_at":1}`},
+		{ID: 2, ExtraAttrs: `{"expires_at":32503680000}`},
+		{ID: 3, ExtraAttrs: ``},
+	}, nil)
+	records, err := (&expirySelector{dao: d}).List(context.TODO())
+	c.Require().NoError(err)
+	c.Require().Len(records, 2)
+	c.Equal(int64(1), records[0].ID)
+	c.Equal(int64(3), records[1].ID)
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &controllerTestSuite{})
+}
@@ -0,0 +1,376 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageimport
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg"
+	"github.com/goharbor/harbor/src/pkg/project"
+	"github.com/goharbor/harbor/src/pkg/systemartifact"
+	"github.com/goharbor/harbor/src/pkg/systemartifact/model"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+const (
+	// Vendor is the vendor of the system artifacts keeping the uploaded archives
+	Vendor = "image_import"
+	// ArtifactType is the type of the system artifacts keeping the uploaded archives
+	ArtifactType = "ImageArchive"
+
+	// StatusUploading is the status of the import whose archive is being uploaded
+	StatusUploading = "Uploading"
+
+	attrProjectName = "project_name"
+	attrRepository  = "repository"
+	attrSize        = "size"
+	attrDigest      = "digest"
+	attrUploaded    = "uploaded"
+	attrLocation    = "location"
+	attrOperator    = "operator"
+	attrTotal       = "total"
+	attrImported    = "imported"
+	attrFailed      = "failed"
+
+	// ParamImportID is the job parameter of the ID of the import, it's also the repository of the system artifact
+	ParamImportID = "import_id"
+	// ParamProjectName is the job parameter of the name of the project the images are imported into
+	ParamProjectName = "project_name"
+	// ParamRepository is the job parameter of the repository used for the images carrying only the tag in the archive
+	ParamRepository = "repository"
+	// ParamDigest is the job parameter of the digest of the archive
+	ParamDigest = "digest"
+)
+
+var (
+	// Ctl is a global image import controller instance
+	Ctl = NewController()
+)
+
+func init() {
+	if err := task.RegisterCheckInProcessor(job.ImageImport, checkInProcessor); err != nil {
+		log.Fatalf("failed to register the checkin processor for the image import job, error %v", err)
+	}
+}
+
+// Progress is the progress checked in by the image import job
+type Progress struct {
+	Total    int `json:"total"`
+	Imported int `json:"imported"`
+	Failed   int `json:"failed"`
+}
+
+// Import is the import of the images from an archive uploaded in chunks
+type Import struct {
+	ID            int64     `json:"id"`
+	ProjectID     int64     `json:"project_id"`
+	ProjectName   string    `json:"project_name"`
+	Repository    string    `json:"repository,omitempty"`
+	Status        string    `json:"status"`
+	StatusMessage string    `json:"status_message,omitempty"`
+	Size          int64     `json:"size"`
+	Digest        string    `json:"digest"`
+	Uploaded      int64     `json:"uploaded"`
+	Total         int       `json:"total"`
+	Imported      int       `json:"imported"`
+	Failed        int       `json:"failed"`
+	Operator      string    `json:"operator,omitempty"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+
+	location string
+}
+
+// Controller defines the operations related with the image imports
+type Controller interface {
+	// Create an import of the archive whose size and digest are specified into the project, the "repository"
+	// is used for the images carrying only the tag in the archive. The archive is uploaded by "Upload" then
+	Create(ctx context.Context, projectID int64, size int64, digest string, repository string) (id int64, err error)
+	// Upload the chunk [start, end] of the archive of the import, the chunks must be uploaded in order.
+	// The job importing the images is submitted once the last chunk is uploaded
+	Upload(ctx context.Context, id int64, chunk io.Reader, start, end int64) (imp *Import, err error)
+	// Get the import specified by ID
+	Get(ctx context.Context, id int64) (imp *Import, err error)
+	// Count the imports of the project
+	Count(ctx context.Context, projectID int64) (total int64, err error)
+	// List the imports of the project, the latest first
+	List(ctx context.Context, projectID int64, query *q.Query) (imps []*Import, err error)
+	// Cancel the uploading or the running import
+	Cancel(ctx context.Context, id int64) (err error)
+}
+
+// NewController creates an instance of the default image import controller
+func NewController() Controller {
+	return &controller{
+		proMgr:         pkg.ProjectMgr,
+		exeMgr:         task.ExecMgr,
+		taskMgr:        task.Mgr,
+		sysArtifactMgr: systemartifact.Mgr,
+	}
+}
+
+type controller struct {
+	proMgr         project.Manager
+	exeMgr         task.ExecutionManager
+	taskMgr        task.Manager
+	sysArtifactMgr systemartifact.Manager
+}
+
+func (c *controller) Create(ctx context.Context, projectID int64, size int64, dgt string, repository string) (int64, error) {
+	if size <= 0 {
+		return 0, errors.BadRequestError(nil).WithMessage("invalid size of the archive: %d", size)
+	}
+	if _, err := digest.Parse(dgt); err != nil {
+		return 0, errors.BadRequestError(err).WithMessage("invalid digest of the archive: %s", dgt)
+	}
+	p, err := c.proMgr.Get(ctx, projectID)
+	if err != nil {
+		return 0, err
+	}
+	if p.IsProxy() {
+		return 0, errors.BadRequestError(nil).WithMessage("can not import images into the proxy cache project %s", p.Name)
+	}
+
+	attrs := map[string]interface{}{
+		attrProjectName: p.Name,
+		attrRepository:  repository,
+		attrSize:        size,
+		attrDigest:      dgt,
+		attrUploaded:    0,
+	}
+	if sc, ok := security.FromContext(ctx); ok {
+		attrs[attrOperator] = sc.GetUsername()
+	}
+	return c.exeMgr.Create(ctx, job.ImageImport, projectID, task.ExecutionTriggerManual, attrs)
+}
+
+func (c *controller) Upload(ctx context.Context, id int64, chunk io.Reader, start, end int64) (*Import, error) {
+	exec, err := c.getExecution(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	imp := toImport(exec)
+	if imp.Status != StatusUploading {
+		return nil, errors.New(nil).WithCode(errors.PreconditionCode).
+			WithMessage("the archive of the import %d isn't being uploaded, the import is %s", id, imp.Status)
+	}
+	if start != imp.Uploaded {
+		return nil, errors.BadRequestError(nil).WithMessage("the chunk must start at %d", imp.Uploaded)
+	}
+	if end < start || end >= imp.Size {
+		return nil, errors.BadRequestError(nil).WithMessage("invalid range of the chunk: %d-%d", start, end)
+	}
+
+	record := &model.SystemArtifact{
+		Repository: strconv.FormatInt(id, 10),
+		Digest:     imp.Digest,
+		Size:       imp.Size,
+		Vendor:     Vendor,
+		Type:       ArtifactType,
+	}
+	location, err := c.sysArtifactMgr.CreateChunked(ctx, record, io.LimitReader(chunk, end-start+1), start, end, imp.location)
+	if err != nil {
+		return nil, err
+	}
+	imp.Uploaded = end + 1
+	imp.location = location
+	exec.ExtraAttrs[attrUploaded] = imp.Uploaded
+	exec.ExtraAttrs[attrLocation] = imp.location
+	if err = c.exeMgr.UpdateExtraAttrs(ctx, id, exec.ExtraAttrs); err != nil {
+		return nil, err
+	}
+	if imp.Uploaded < imp.Size {
+		return imp, nil
+	}
+
+	// the whole archive is uploaded, submit the job importing the images
+	_, err = c.taskMgr.Create(ctx, id, &task.Job{
+		Name: job.ImageImport,
+		Metadata: &job.Metadata{
+			JobKind: job.KindGeneric,
+		},
+		Parameters: map[string]interface{}{
+			ParamImportID:    id,
+			ParamProjectName: imp.ProjectName,
+			ParamRepository:  imp.Repository,
+			ParamDigest:      imp.Digest,
+		},
+	})
+	if err != nil {
+		if e := c.exeMgr.MarkError(ctx, id, err.Error()); e != nil {
+			log.Errorf("failed to mark the error status of the image import %d: %v", id, e)
+		}
+		return nil, err
+	}
+	imp.Status = job.RunningStatus.String()
+	return imp, nil
+}
+
+func (c *controller) Get(ctx context.Context, id int64) (*Import, error) {
+	exec, err := c.getExecution(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	imp := toImport(exec)
+	if err = c.populateProgress(ctx, imp); err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+func (c *controller) Count(ctx context.Context, projectID int64) (int64, error) {
+	return c.exeMgr.Count(ctx, &q.Query{
+		Keywords: map[string]interface{}{
+			"VendorType": job.ImageImport,
+			"VendorID":   projectID,
+		},
+	})
+}
+
+func (c *controller) List(ctx context.Context, projectID int64, query *q.Query) ([]*Import, error) {
+	query = q.MustClone(query)
+	query.Keywords["VendorType"] = job.ImageImport
+	query.Keywords["VendorID"] = projectID
+	query.Sorts = []*q.Sort{q.NewSort("ID", true)}
+	execs, err := c.exeMgr.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var imps []*Import
+	for _, exec := range execs {
+		imp := toImport(exec)
+		if err = c.populateProgress(ctx, imp); err != nil {
+			return nil, err
+		}
+		imps = append(imps, imp)
+	}
+	return imps, nil
+}
+
+func (c *controller) Cancel(ctx context.Context, id int64) error {
+	exec, err := c.getExecution(ctx, id)
+	if err != nil {
+		return err
+	}
+	if job.Status(exec.Status).Final() {
+		return errors.New(nil).WithCode(errors.PreconditionCode).
+			WithMessage("the import %d is already %s", id, exec.Status)
+	}
+	return c.exeMgr.Stop(ctx, id)
+}
+
+func (c *controller) getExecution(ctx context.Context, id int64) (*task.Execution, error) {
+	exec, err := c.exeMgr.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if exec.VendorType != job.ImageImport {
+		return nil, errors.NotFoundError(nil).WithMessage("image import %d not found", id)
+	}
+	if exec.ExtraAttrs == nil {
+		exec.ExtraAttrs = map[string]interface{}{}
+	}
+	return exec, nil
+}
+
+// populateProgress populates the progress checked in by the job of the import
+func (c *controller) populateProgress(ctx context.Context, imp *Import) error {
+	if imp.Status == StatusUploading {
+		return nil
+	}
+	tasks, err := c.taskMgr.List(ctx, &q.Query{
+		Keywords: map[string]interface{}{
+			"ExecutionID": imp.ID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if len(tasks) > 0 {
+		imp.Total = intAttr(tasks[0].ExtraAttrs, attrTotal)
+		imp.Imported = intAttr(tasks[0].ExtraAttrs, attrImported)
+		imp.Failed = intAttr(tasks[0].ExtraAttrs, attrFailed)
+	}
+	return nil
+}
+
+func toImport(exec *task.Execution) *Import {
+	imp := &Import{
+		ID:            exec.ID,
+		ProjectID:     exec.VendorID,
+		Status:        exec.Status,
+		StatusMessage: exec.StatusMessage,
+		Size:          int64Attr(exec.ExtraAttrs, attrSize),
+		Uploaded:      int64Attr(exec.ExtraAttrs, attrUploaded),
+		StartTime:     exec.StartTime,
+		EndTime:       exec.EndTime,
+	}
+	imp.ProjectName, _ = exec.ExtraAttrs[attrProjectName].(string)
+	imp.Repository, _ = exec.ExtraAttrs[attrRepository].(string)
+	imp.Digest, _ = exec.ExtraAttrs[attrDigest].(string)
+	imp.Operator, _ = exec.ExtraAttrs[attrOperator].(string)
+	imp.location, _ = exec.ExtraAttrs[attrLocation].(string)
+	// the execution keeps running without tasks until the whole archive is uploaded
+	if exec.Status == job.RunningStatus.String() && imp.Uploaded < imp.Size {
+		imp.Status = StatusUploading
+	}
+	return imp
+}
+
+func int64Attr(attrs map[string]interface{}, key string) int64 {
+	switch v := attrs[key].(type) {
+	case int:
+		return int64(v)
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	default:
+		return 0
+	}
+}
+
+func intAttr(attrs map[string]interface{}, key string) int {
+	return int(int64Attr(attrs, key))
+}
+
+func checkInProcessor(ctx context.Context, t *task.Task, sc *job.StatusChange) error {
+	if sc.CheckIn == "" {
+		return nil
+	}
+	progress := &Progress{}
+	if err := json.Unmarshal([]byte(sc.CheckIn), progress); err != nil {
+		log.Errorf("failed to resolve checkin of image import task %d: %v", t.ID, err)
+		return err
+	}
+	if t.ExtraAttrs == nil {
+		t.ExtraAttrs = map[string]interface{}{}
+	}
+	t.ExtraAttrs[attrTotal] = progress.Total
+	t.ExtraAttrs[attrImported] = progress.Imported
+	t.ExtraAttrs[attrFailed] = progress.Failed
+	return task.Mgr.UpdateExtraAttrs(ctx, t.ID, t.ExtraAttrs)
+}
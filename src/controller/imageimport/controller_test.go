@@ -0,0 +1,183 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageimport
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/systemartifact/model"
+	"github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/project"
+	systemartifacttesting "github.com/goharbor/harbor/src/testing/pkg/systemartifact"
+	tasktesting "github.com/goharbor/harbor/src/testing/pkg/task"
+)
+
+const testDigest = "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180"
+
+type controllerTestSuite struct {
+	suite.Suite
+	ctl            *controller
+	proMgr         *project.Manager
+	exeMgr         *tasktesting.ExecutionManager
+	taskMgr        *tasktesting.Manager
+	sysArtifactMgr *systemartifacttesting.Manager
+}
+
+func (c *controllerTestSuite) SetupTest() {
+	c.proMgr = &project.Manager{}
+	c.exeMgr = &tasktesting.ExecutionManager{}
+	c.taskMgr = &tasktesting.Manager{}
+	c.sysArtifactMgr = &systemartifacttesting.Manager{}
+	c.ctl = &controller{
+		proMgr:         c.proMgr,
+		exeMgr:         c.exeMgr,
+		taskMgr:        c.taskMgr,
+		sysArtifactMgr: c.sysArtifactMgr,
+	}
+}
+
+func (c *controllerTestSuite) uploadingExecution(uploaded int64, location string) *task.Execution {
+	return &task.Execution{
+		ID:         1,
+		VendorType: job.ImageImport,
+		VendorID:   2,
+		Status:     job.RunningStatus.String(),
+		ExtraAttrs: map[string]interface{}{
+			"project_name": "library",
+			"size":         float64(10),
+			"digest":       testDigest,
+			"uploaded":     float64(uploaded),
+			"location":     location,
+		},
+	}
+}
+
+func (c *controllerTestSuite) TestCreate() {
+	// invalid size
+	_, err := c.ctl.Create(context.TODO(), 2, 0, testDigest, "")
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// invalid digest
+	_, err = c.ctl.Create(context.TODO(), 2, 10, "invalid", "")
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// proxy cache project
+	c.proMgr.On("Get", mock.Anything, int64(2)).Return(&proModels.Project{ProjectID: 2, Name: "proxy", RegistryID: 1}, nil).Once()
+	_, err = c.ctl.Create(context.TODO(), 2, 10, testDigest, "")
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	c.proMgr.On("Get", mock.Anything, int64(2)).Return(&proModels.Project{ProjectID: 2, Name: "library"}, nil).Once()
+	c.exeMgr.On("Create", mock.Anything, job.ImageImport, int64(2), task.ExecutionTriggerManual, map[string]interface{}{
+		"project_name": "library",
+		"repository":   "hello-world",
+		"size":         int64(10),
+		"digest":       testDigest,
+		"uploaded":     0,
+	}).Return(int64(1), nil).Once()
+	id, err := c.ctl.Create(context.TODO(), 2, 10, testDigest, "hello-world")
+	c.Require().NoError(err)
+	c.Equal(int64(1), id)
+	c.exeMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestUpload() {
+	// the chunk doesn't start at the uploaded position
+	c.exeMgr.On("Get", mock.Anything, int64(1)).Return(c.uploadingExecution(5, "/location1"), nil).Once()
+	_, err := c.ctl.Upload(context.TODO(), 1, strings.NewReader("01234"), 0, 4)
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// the first chunk
+	c.exeMgr.On("Get", mock.Anything, int64(1)).Return(c.uploadingExecution(0, ""), nil).Once()
+	c.sysArtifactMgr.On("CreateChunked", mock.Anything, &model.SystemArtifact{
+		Repository: "1",
+		Digest:     testDigest,
+		Size:       10,
+		Vendor:     Vendor,
+		Type:       ArtifactType,
+	}, mock.Anything, int64(0), int64(4), "").Return("/location1", nil).Once()
+	c.exeMgr.On("UpdateExtraAttrs", mock.Anything, int64(1), mock.Anything).Return(nil).Once()
+	imp, err := c.ctl.Upload(context.TODO(), 1, strings.NewReader("01234"), 0, 4)
+	c.Require().NoError(err)
+	c.Equal(int64(5), imp.Uploaded)
+	c.Equal(StatusUploading, imp.Status)
+	c.taskMgr.AssertNotCalled(c.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+
+	// the last chunk submits the job
+	c.exeMgr.On("Get", mock.Anything, int64(1)).Return(c.uploadingExecution(5, "/location1"), nil).Once()
+	c.sysArtifactMgr.On("CreateChunked", mock.Anything, mock.Anything, mock.Anything, int64(5), int64(9), "/location1").Return("/location2", nil).Once()
+	c.exeMgr.On("UpdateExtraAttrs", mock.Anything, int64(1), mock.Anything).Return(nil).Once()
+	c.taskMgr.On("Create", mock.Anything, int64(1), mock.Anything).Return(int64(1), nil).Once()
+	imp, err = c.ctl.Upload(context.TODO(), 1, strings.NewReader("56789"), 5, 9)
+	c.Require().NoError(err)
+	c.Equal(int64(10), imp.Uploaded)
+	c.Equal(job.RunningStatus.String(), imp.Status)
+	c.sysArtifactMgr.AssertExpectations(c.T())
+	c.taskMgr.AssertExpectations(c.T())
+
+	// the archive is uploaded already
+	exec := c.uploadingExecution(10, "/location2")
+	c.exeMgr.On("Get", mock.Anything, int64(1)).Return(exec, nil).Once()
+	_, err = c.ctl.Upload(context.TODO(), 1, strings.NewReader("0"), 10, 10)
+	c.True(errors.IsErr(err, errors.PreconditionCode))
+}
+
+func (c *controllerTestSuite) TestGet() {
+	// not an image import
+	c.exeMgr.On("Get", mock.Anything, int64(1)).Return(&task.Execution{ID: 1, VendorType: job.RepositoryDeletion}, nil).Once()
+	_, err := c.ctl.Get(context.TODO(), 1)
+	c.True(errors.IsNotFoundErr(err))
+
+	exec := c.uploadingExecution(10, "")
+	c.exeMgr.On("Get", mock.Anything, int64(1)).Return(exec, nil).Once()
+	c.taskMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Task{{
+		ID: 1,
+		ExtraAttrs: map[string]interface{}{
+			"total":    float64(3),
+			"imported": float64(1),
+			"failed":   float64(1),
+		},
+	}}, nil).Once()
+	imp, err := c.ctl.Get(context.TODO(), 1)
+	c.Require().NoError(err)
+	c.Equal(int64(2), imp.ProjectID)
+	c.Equal("library", imp.ProjectName)
+	c.Equal(job.RunningStatus.String(), imp.Status)
+	c.Equal(3, imp.Total)
+	c.Equal(1, imp.Imported)
+	c.Equal(1, imp.Failed)
+}
+
+func (c *controllerTestSuite) TestCancel() {
+	c.exeMgr.On("Get", mock.Anything, int64(1)).Return(&task.Execution{ID: 1, VendorType: job.ImageImport, Status: job.SuccessStatus.String()}, nil).Once()
+	err := c.ctl.Cancel(context.TODO(), 1)
+	c.True(errors.IsErr(err, errors.PreconditionCode))
+
+	c.exeMgr.On("Get", mock.Anything, int64(1)).Return(c.uploadingExecution(5, ""), nil).Once()
+	c.exeMgr.On("Stop", mock.Anything, int64(1)).Return(nil).Once()
+	c.Require().NoError(c.ctl.Cancel(context.TODO(), 1))
+	c.exeMgr.AssertExpectations(c.T())
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &controllerTestSuite{})
+}
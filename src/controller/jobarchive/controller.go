@@ -0,0 +1,118 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobarchive
+
+import (
+	"context"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/jobarchive"
+	"github.com/goharbor/harbor/src/pkg/jobarchive/model"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
+)
+
+const (
+	// VendorTypeJobArchiveCleanup is the vendor type of the job archive cleanup schedule
+	VendorTypeJobArchiveCleanup = "JOB_ARCHIVE_CLEANUP"
+	// JobArchiveCleanupCallback is the name of the callback function of the job archive cleanup schedule
+	JobArchiveCleanupCallback = "JOB_ARCHIVE_CLEANUP"
+	cronTypeDaily             = "Daily"
+	cronSpec                  = "0 30 0 * * *"
+)
+
+var (
+	// Ctl is a global job archive controller instance
+	Ctl   = NewController()
+	sched = scheduler.Sched
+)
+
+func init() {
+	if err := scheduler.RegisterCallbackFunc(JobArchiveCleanupCallback, cleanupCallback); err != nil {
+		log.Fatalf("failed to register the callback for the job archive cleanup schedule, error %v", err)
+	}
+}
+
+func cleanupCallback(ctx context.Context, _ string) error {
+	_, err := Ctl.Cleanup(ctx)
+	return err
+}
+
+// Controller manages the archived jobs
+type Controller interface {
+	// Count returns the total count of the archived jobs according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List the archived jobs according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.JobArchive, error)
+	// Cleanup removes the archived jobs which exceed the retention days, returns the count of the removed jobs
+	Cleanup(ctx context.Context) (int64, error)
+}
+
+// NewController creates an instance of the default job archive controller
+func NewController() Controller {
+	return &controller{
+		mgr:           jobarchive.Mgr,
+		retentionDays: config.JobArchiveRetentionDays,
+	}
+}
+
+type controller struct {
+	mgr           jobarchive.Manager
+	retentionDays func(ctx context.Context) int64
+}
+
+func (c *controller) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return c.mgr.Count(ctx, query)
+}
+
+func (c *controller) List(ctx context.Context, query *q.Query) ([]*model.JobArchive, error) {
+	return c.mgr.List(ctx, query)
+}
+
+func (c *controller) Cleanup(ctx context.Context) (int64, error) {
+	days := c.retentionDays(ctx)
+	if days <= 0 {
+		log.Debug("the retention of the job archive isn't set, skip the cleanup")
+		return 0, nil
+	}
+	n, err := c.mgr.Purge(ctx, time.Now().AddDate(0, 0, -int(days)))
+	if err != nil {
+		log.Errorf("failed to cleanup the job archive: %v", err)
+		return 0, err
+	}
+	log.Infof("removed %d archived jobs older than %d days", n, days)
+	return n, nil
+}
+
+// ScheduleCleanupTask schedules the daily cleanup of the job archive if it isn't scheduled yet
+func ScheduleCleanupTask(ctx context.Context) {
+	schedules, err := sched.ListSchedules(ctx, q.New(q.KeyWords{"vendor_type": VendorTypeJobArchiveCleanup}))
+	if err != nil {
+		log.Errorf("failed to check whether the job archive cleanup is scheduled: %v", err)
+		return
+	}
+	if len(schedules) > 0 {
+		log.Debugf("the job archive cleanup is already scheduled with ID: %d", schedules[0].ID)
+		return
+	}
+	id, err := sched.Schedule(ctx, VendorTypeJobArchiveCleanup, 0, cronTypeDaily, cronSpec, JobArchiveCleanupCallback, nil, nil)
+	if err != nil {
+		log.Errorf("failed to schedule the job archive cleanup: %v", err)
+		return
+	}
+	log.Infof("scheduled the job archive cleanup with ID: %d", id)
+}
@@ -0,0 +1,64 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobarchive
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/testing/pkg/jobarchive"
+)
+
+type controllerTestSuite struct {
+	suite.Suite
+	ctl  *controller
+	mgr  *jobarchive.Manager
+	days int64
+}
+
+func (c *controllerTestSuite) SetupTest() {
+	c.mgr = &jobarchive.Manager{}
+	c.ctl = &controller{
+		mgr:           c.mgr,
+		retentionDays: func(ctx context.Context) int64 { return c.days },
+	}
+}
+
+func (c *controllerTestSuite) TestCleanup() {
+	// keep the archives forever
+	c.days = 0
+	n, err := c.ctl.Cleanup(context.TODO())
+	c.Require().Nil(err)
+	c.Equal(int64(0), n)
+	c.mgr.AssertNotCalled(c.T(), "Purge", mock.Anything, mock.Anything)
+
+	c.days = 30
+	c.mgr.On("Purge", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		expected := time.Now().AddDate(0, 0, -30)
+		return before.Sub(expected) < time.Minute && expected.Sub(before) < time.Minute
+	})).Return(int64(3), nil)
+	n, err = c.ctl.Cleanup(context.TODO())
+	c.Require().Nil(err)
+	c.Equal(int64(3), n)
+	c.mgr.AssertExpectations(c.T())
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &controllerTestSuite{})
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	jobSvc "github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/pkg/queuestatus"
 
@@ -69,6 +70,15 @@ type MonitorController interface {
 	PauseJobQueues(ctx context.Context, jobType string) error
 	// ResumeJobQueues resume the job queue by type
 	ResumeJobQueues(ctx context.Context, jobType string) error
+	// UpdateMaxConcurrency updates the max concurrency of the job type at runtime
+	UpdateMaxConcurrency(ctx context.Context, jobType string, maxConcurrency uint) error
+
+	// ListQueuedJobs lists the pending and running jobs of the job type which match the filter
+	ListQueuedJobs(ctx context.Context, jobType string, filter *jm.QueuedJobFilter) ([]*jm.QueuedJob, error)
+	// RequeueJob moves the stuck running job back to the head of the job queue
+	RequeueJob(ctx context.Context, jobType string, jobID string) error
+	// PurgeJob removes the poisoned job from the job queue and marks the task as error
+	PurgeJob(ctx context.Context, jobType string, jobID string) error
 }
 
 type monitorController struct {
@@ -260,21 +270,162 @@ func (w *monitorController) ListQueues(ctx context.Context) ([]*jm.Queue, error)
 	if err != nil {
 		return nil, err
 	}
+	redisClient, err := w.jobServiceRedisClient()
+	if err != nil {
+		return nil, err
+	}
 	result := make([]*jm.Queue, 0)
 	for _, queue := range qs {
 		if skippedUnusedJobType(queue.JobName) {
 			continue
 		}
+		maxConcurrency, err := redisClient.MaxConcurrency(ctx, queue.JobName)
+		if err != nil {
+			return nil, err
+		}
 		result = append(result, &jm.Queue{
-			JobType: queue.JobName,
-			Count:   queue.Count,
-			Latency: queue.Latency,
-			Paused:  statusMap[queue.JobName],
+			JobType:        queue.JobName,
+			Count:          queue.Count,
+			Latency:        queue.Latency,
+			Paused:         statusMap[queue.JobName],
+			MaxConcurrency: maxConcurrency,
 		})
 	}
 	return result, nil
 }
 
+func (w *monitorController) UpdateMaxConcurrency(ctx context.Context, jobType string, maxConcurrency uint) error {
+	redisClient, err := w.jobServiceRedisClient()
+	if err != nil {
+		return err
+	}
+	if err := checkJobType(ctx, redisClient, jobType); err != nil {
+		return err
+	}
+	return redisClient.SetMaxConcurrency(ctx, jobType, maxConcurrency)
+}
+
+func checkJobType(ctx context.Context, redisClient jm.RedisClient, jobType string) error {
+	jobTypes, err := redisClient.AllJobTypes(ctx)
+	if err != nil {
+		return err
+	}
+	for _, t := range jobTypes {
+		if t == jobType && !skippedUnusedJobType(t) {
+			return nil
+		}
+	}
+	return errors.NotFoundError(nil).WithMessage("job type %s not found", jobType)
+}
+
+func (w *monitorController) ListQueuedJobs(ctx context.Context, jobType string, filter *jm.QueuedJobFilter) ([]*jm.QueuedJob, error) {
+	redisClient, err := w.jobServiceRedisClient()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkJobType(ctx, redisClient, jobType); err != nil {
+		return nil, err
+	}
+	status := ""
+	if filter != nil {
+		status = filter.Status
+	}
+	jobs, err := redisClient.ListJobs(ctx, jobType, status)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	result := make([]*jm.QueuedJob, 0)
+	for _, j := range jobs {
+		if filter.Match(j, now) {
+			result = append(result, j)
+		}
+	}
+	return result, nil
+}
+
+func (w *monitorController) RequeueJob(ctx context.Context, jobType string, jobID string) error {
+	redisClient, err := w.jobServiceRedisClient()
+	if err != nil {
+		return err
+	}
+	job, err := w.queuedJob(ctx, redisClient, jobType, jobID)
+	if err != nil {
+		return err
+	}
+	if job.Status != jm.QueuedJobStatusRunning {
+		return errors.BadRequestError(nil).WithMessage("the job %s is %s, only the running job can be requeued", jobID, job.Status)
+	}
+	return redisClient.RequeueJob(ctx, job)
+}
+
+func (w *monitorController) PurgeJob(ctx context.Context, jobType string, jobID string) error {
+	redisClient, err := w.jobServiceRedisClient()
+	if err != nil {
+		return err
+	}
+	job, err := w.queuedJob(ctx, redisClient, jobType, jobID)
+	if err != nil {
+		return err
+	}
+	if err := redisClient.PurgeJob(ctx, job); err != nil {
+		return err
+	}
+	// the purged job never reports the status to the core, mark the task as error
+	tasks, err := w.taskManager.List(ctx, q.New(q.KeyWords{"job_id": jobID}))
+	if err != nil {
+		return err
+	}
+	if len(tasks) != 1 {
+		log.Infof("no task found for the purged job %s", jobID)
+		return nil
+	}
+	if err := w.taskManager.UpdateStatusInBatch(ctx, []string{jobID}, jobSvc.ErrorStatus.String(), batchUpdateSize); err != nil {
+		return err
+	}
+	_, _, err = w.executionDAO.RefreshStatus(ctx, tasks[0].ExecutionID)
+	return err
+}
+
+// queuedJob gets the job in the queue, the error is returned if the job is still being processed by the worker
+// as the job can be stopped directly
+func (w *monitorController) queuedJob(ctx context.Context, redisClient jm.RedisClient, jobType string, jobID string) (*jm.QueuedJob, error) {
+	if err := checkJobType(ctx, redisClient, jobType); err != nil {
+		return nil, err
+	}
+	jobs, err := redisClient.ListJobs(ctx, jobType, "")
+	if err != nil {
+		return nil, err
+	}
+	var job *jm.QueuedJob
+	for _, j := range jobs {
+		if j.ID == jobID {
+			job = j
+			break
+		}
+	}
+	if job == nil {
+		return nil, errors.NotFoundError(nil).WithMessage("job %s not found in the queue of %s", jobID, jobType)
+	}
+	if job.Status != jm.QueuedJobStatusRunning {
+		return job, nil
+	}
+	mClient, err := w.monitorClient()
+	if err != nil {
+		return nil, err
+	}
+	observations, err := mClient.WorkerObservations()
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range observations {
+		if o.IsBusy && o.JobID == jobID {
+			return nil, errors.PreconditionFailedError(nil).WithMessage("the job %s is being processed by the worker %s, stop it instead", jobID, o.WorkerID)
+		}
+	}
+	return job, nil
+}
+
 func skippedUnusedJobType(jobType string) bool {
 	for _, t := range skippedJobTypes {
 		if jobType == t {
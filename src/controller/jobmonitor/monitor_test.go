@@ -15,9 +15,11 @@
 package jobmonitor
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/pkg/queuestatus"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
 	queueStatusMock "github.com/goharbor/harbor/src/testing/pkg/queuestatus"
@@ -27,11 +29,22 @@ import (
 
 	"github.com/goharbor/harbor/src/pkg/jobmonitor"
 	"github.com/goharbor/harbor/src/pkg/task"
+	taskDao "github.com/goharbor/harbor/src/pkg/task/dao"
 	"github.com/goharbor/harbor/src/testing/mock"
 	monitorMock "github.com/goharbor/harbor/src/testing/pkg/jobmonitor"
 	taskMock "github.com/goharbor/harbor/src/testing/pkg/task"
 )
 
+type fakeExecutionDAO struct {
+	taskDao.ExecutionDAO
+	refreshed []int64
+}
+
+func (f *fakeExecutionDAO) RefreshStatus(ctx context.Context, id int64) (bool, string, error) {
+	f.refreshed = append(f.refreshed, id)
+	return true, "Error", nil
+}
+
 type JobServiceMonitorTestSuite struct {
 	suite.Suite
 	jmClient           jobmonitor.JobServiceMonitorClient
@@ -42,6 +55,7 @@ type JobServiceMonitorTestSuite struct {
 	queueStatusManager queuestatus.Manager
 	sch                scheduler.Scheduler
 	redisClient        jobmonitor.RedisClient
+	executionDAO       *fakeExecutionDAO
 }
 
 func (s *JobServiceMonitorTestSuite) SetupSuite() {
@@ -51,6 +65,7 @@ func (s *JobServiceMonitorTestSuite) SetupSuite() {
 	s.taskManager = &taskMock.Manager{}
 	s.redisClient = &monitorMock.RedisClient{}
 	s.queueStatusManager = &queueStatusMock.Manager{}
+	s.executionDAO = &fakeExecutionDAO{}
 	s.monitController = &monitorController{
 		poolManager:        s.poolManager,
 		workerManager:      s.workerManager,
@@ -62,6 +77,7 @@ func (s *JobServiceMonitorTestSuite) SetupSuite() {
 		jobServiceRedisClient: func() (jobmonitor.RedisClient, error) {
 			return s.redisClient, nil
 		},
+		executionDAO: s.executionDAO,
 	}
 }
 
@@ -108,11 +124,23 @@ func (s *JobServiceMonitorTestSuite) TestListQueue() {
 	mock.OnAnything(s.jmClient, "Queues").Return([]*work.Queue{
 		{JobName: "GARBAGE_COLLECTION", Count: 100, Latency: 10000}}, nil)
 	mock.OnAnything(s.queueStatusManager, "AllJobTypeStatus").Return(map[string]bool{"GARBAGE_COLLECTION": false}, nil).Once()
+	mock.OnAnything(s.redisClient, "MaxConcurrency").Return(uint(1), nil).Once()
 	queues, err := s.monitController.ListQueues(nil)
 	s.Assert().Nil(err)
 	s.Assert().Equal(1, len(queues))
 	s.Assert().Equal("GARBAGE_COLLECTION", queues[0].JobType)
 	s.Assert().False(queues[0].Paused)
+	s.Assert().Equal(uint(1), queues[0].MaxConcurrency)
+}
+
+func (s *JobServiceMonitorTestSuite) TestUpdateMaxConcurrency() {
+	mock.OnAnything(s.redisClient, "AllJobTypes").Return([]string{"GARBAGE_COLLECTION", "REPLICATION"}, nil)
+	mock.OnAnything(s.redisClient, "SetMaxConcurrency").Return(nil).Once()
+	err := s.monitController.UpdateMaxConcurrency(nil, "REPLICATION", 5)
+	s.Assert().Nil(err)
+
+	err = s.monitController.UpdateMaxConcurrency(nil, "UNKNOWN", 5)
+	s.Assert().True(errors.IsNotFoundErr(err))
 }
 
 func (s *JobServiceMonitorTestSuite) TestPauseJob() {
@@ -124,6 +152,72 @@ func (s *JobServiceMonitorTestSuite) TestPauseJob() {
 	s.Assert().Nil(err)
 }
 
+func (s *JobServiceMonitorTestSuite) TestListQueuedJobs() {
+	now := time.Now().Unix()
+	mock.OnAnything(s.redisClient, "AllJobTypes").Return([]string{"GARBAGE_COLLECTION", "REPLICATION"}, nil)
+	mock.OnAnything(s.redisClient, "ListJobs").Return([]*jobmonitor.QueuedJob{
+		{ID: "2", JobType: "REPLICATION", Status: jobmonitor.QueuedJobStatusRunning, PoolID: "pool1", EnqueuedAt: now - 3600},
+		{ID: "3", JobType: "REPLICATION", Status: jobmonitor.QueuedJobStatusRunning, PoolID: "pool2", EnqueuedAt: now - 3600},
+		{ID: "4", JobType: "REPLICATION", Status: jobmonitor.QueuedJobStatusRunning, PoolID: "pool1", EnqueuedAt: now},
+	}, nil).Once()
+	jobs, err := s.monitController.ListQueuedJobs(nil, "REPLICATION", &jobmonitor.QueuedJobFilter{
+		Status: jobmonitor.QueuedJobStatusRunning,
+		PoolID: "pool1",
+		MinAge: 600,
+	})
+	s.Require().Nil(err)
+	s.Require().Len(jobs, 1)
+	s.Equal("2", jobs[0].ID)
+
+	_, err = s.monitController.ListQueuedJobs(nil, "UNKNOWN", nil)
+	s.True(errors.IsNotFoundErr(err))
+}
+
+func (s *JobServiceMonitorTestSuite) TestRequeueJob() {
+	mock.OnAnything(s.redisClient, "AllJobTypes").Return([]string{"GARBAGE_COLLECTION", "REPLICATION"}, nil)
+	mock.OnAnything(s.jmClient, "WorkerObservations").Return([]*work.WorkerObservation{
+		{WorkerID: "abc", IsBusy: true, JobName: "test", JobID: "1"},
+	}, nil)
+	queued := []*jobmonitor.QueuedJob{
+		{ID: "1", JobType: "REPLICATION", Status: jobmonitor.QueuedJobStatusRunning, PoolID: "pool1"},
+		{ID: "2", JobType: "REPLICATION", Status: jobmonitor.QueuedJobStatusRunning, PoolID: "pool1"},
+		{ID: "3", JobType: "REPLICATION", Status: jobmonitor.QueuedJobStatusPending},
+	}
+
+	// stuck job
+	mock.OnAnything(s.redisClient, "ListJobs").Return(queued, nil).Once()
+	s.redisClient.(*monitorMock.RedisClient).On("RequeueJob", mock.Anything, queued[1]).Return(nil).Once()
+	s.Nil(s.monitController.RequeueJob(nil, "REPLICATION", "2"))
+
+	// the job is being processed by the worker
+	mock.OnAnything(s.redisClient, "ListJobs").Return(queued, nil).Once()
+	err := s.monitController.RequeueJob(nil, "REPLICATION", "1")
+	s.Equal(errors.PreconditionCode, errors.ErrCode(err))
+
+	// pending job
+	mock.OnAnything(s.redisClient, "ListJobs").Return(queued, nil).Once()
+	err = s.monitController.RequeueJob(nil, "REPLICATION", "3")
+	s.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// not found
+	mock.OnAnything(s.redisClient, "ListJobs").Return(queued, nil).Once()
+	err = s.monitController.RequeueJob(nil, "REPLICATION", "4")
+	s.True(errors.IsNotFoundErr(err))
+}
+
+func (s *JobServiceMonitorTestSuite) TestPurgeJob() {
+	mock.OnAnything(s.redisClient, "AllJobTypes").Return([]string{"GARBAGE_COLLECTION", "REPLICATION"}, nil)
+	queued := []*jobmonitor.QueuedJob{
+		{ID: "5", JobType: "REPLICATION", Status: jobmonitor.QueuedJobStatusPending, Fails: 3},
+	}
+	mock.OnAnything(s.redisClient, "ListJobs").Return(queued, nil).Once()
+	s.redisClient.(*monitorMock.RedisClient).On("PurgeJob", mock.Anything, queued[0]).Return(nil).Once()
+	mock.OnAnything(s.taskManager, "List").Return([]*task.Task{{ID: 1, ExecutionID: 10, JobID: "5"}}, nil).Once()
+	s.taskManager.(*taskMock.Manager).On("UpdateStatusInBatch", mock.Anything, []string{"5"}, "Error", batchUpdateSize).Return(nil).Once()
+	s.Nil(s.monitController.PurgeJob(nil, "REPLICATION", "5"))
+	s.Equal([]int64{10}, s.executionDAO.refreshed)
+}
+
 func TestJobServiceMonitorTestSuite(t *testing.T) {
 	suite.Run(t, &JobServiceMonitorTestSuite{})
 }
@@ -19,13 +19,19 @@ import (
 	"fmt"
 
 	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/rbac"
+	event "github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/controller/event/operator"
+	"github.com/goharbor/harbor/src/controller/statistic"
 	"github.com/goharbor/harbor/src/core/auth"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg"
 	"github.com/goharbor/harbor/src/pkg/member"
 	"github.com/goharbor/harbor/src/pkg/member/models"
+	"github.com/goharbor/harbor/src/pkg/notification"
 	"github.com/goharbor/harbor/src/pkg/project"
+	"github.com/goharbor/harbor/src/pkg/role"
 	"github.com/goharbor/harbor/src/pkg/user"
 	"github.com/goharbor/harbor/src/pkg/usergroup"
 )
@@ -72,18 +78,20 @@ type UserGroup struct {
 var ErrDuplicateProjectMember = errors.ConflictError(nil).WithMessage("The project member specified already exist")
 
 // ErrInvalidRole ...
-var ErrInvalidRole = errors.BadRequestError(nil).WithMessage("Failed to update project member, role is not in 1,2,3")
+var ErrInvalidRole = errors.BadRequestError(nil).WithMessage("Failed to update project member, role is neither a built-in role nor a custom role")
 
 type controller struct {
 	userManager  user.Manager
 	mgr          member.Manager
 	projectMgr   project.Manager
 	groupManager usergroup.Manager
+	roleMgr      role.Manager
+	statisticCtl statistic.Controller
 }
 
 // NewController ...
 func NewController() Controller {
-	return &controller{mgr: member.Mgr, projectMgr: pkg.ProjectMgr, userManager: user.New(), groupManager: usergroup.Mgr}
+	return &controller{mgr: pkg.MemberMgr, projectMgr: pkg.ProjectMgr, userManager: user.New(), groupManager: pkg.UserGroupMgr, roleMgr: role.Mgr, statisticCtl: statistic.Ctl}
 }
 
 func (c *controller) Count(ctx context.Context, projectNameOrID interface{}, query *q.Query) (int, error) {
@@ -102,7 +110,23 @@ func (c *controller) UpdateRole(ctx context.Context, projectNameOrID interface{}
 	if p == nil {
 		return errors.BadRequestError(nil).WithMessage("project is not found")
 	}
-	return c.mgr.UpdateRole(ctx, p.ProjectID, memberID, role)
+	if !c.isValidRole(ctx, role) {
+		return ErrInvalidRole
+	}
+	before, err := c.mgr.Get(ctx, p.ProjectID, memberID)
+	if err != nil {
+		return err
+	}
+	if err := c.mgr.UpdateRole(ctx, p.ProjectID, memberID, role); err != nil {
+		return err
+	}
+	c.statisticCtl.MarkDirty(p.ProjectID)
+	after, err := c.mgr.Get(ctx, p.ProjectID, memberID)
+	if err != nil {
+		return err
+	}
+	auditChange(ctx, rbac.ActionUpdate, before, after)
+	return nil
 }
 
 func (c *controller) Get(ctx context.Context, projectNameOrID interface{}, memberID int) (*models.Member, error) {
@@ -216,15 +240,25 @@ func (c *controller) Create(ctx context.Context, projectNameOrID interface{}, re
 		return 0, ErrDuplicateProjectMember
 	}
 
-	if !isValidRole(member.Role) {
+	if !c.isValidRole(ctx, member.Role) {
 		// Return invalid role error
 		return 0, ErrInvalidRole
 	}
-	return c.mgr.AddProjectMember(ctx, member)
+	id, err := c.mgr.AddProjectMember(ctx, member)
+	if err != nil {
+		return 0, err
+	}
+	c.statisticCtl.MarkDirty(p.ProjectID)
+	after, err := c.mgr.Get(ctx, p.ProjectID, id)
+	if err != nil {
+		return 0, err
+	}
+	auditChange(ctx, rbac.ActionCreate, nil, after)
+	return id, nil
 }
 
-func isValidRole(role int) bool {
-	switch role {
+func (c *controller) isValidRole(ctx context.Context, roleID int) bool {
+	switch roleID {
 	case common.RoleProjectAdmin,
 		common.RoleMaintainer,
 		common.RoleDeveloper,
@@ -232,7 +266,12 @@ func isValidRole(role int) bool {
 		common.RoleLimitedGuest:
 		return true
 	default:
-		return false
+		// the role may be a custom one defined by the system admin
+		r, err := c.roleMgr.Get(ctx, roleID)
+		if err != nil {
+			return false
+		}
+		return r.Custom
 	}
 }
 
@@ -256,5 +295,31 @@ func (c *controller) Delete(ctx context.Context, projectNameOrID interface{}, me
 	if err != nil {
 		return err
 	}
-	return c.mgr.Delete(ctx, p.ProjectID, memberID)
+	before, err := c.mgr.Get(ctx, p.ProjectID, memberID)
+	if err != nil {
+		return err
+	}
+	if err := c.mgr.Delete(ctx, p.ProjectID, memberID); err != nil {
+		return err
+	}
+	c.statisticCtl.MarkDirty(p.ProjectID)
+	auditChange(ctx, rbac.ActionDelete, before, nil)
+	return nil
+}
+
+// auditChange records the change of the project member with the snapshots before and after the change
+func auditChange(ctx context.Context, action rbac.Action, before, after *models.Member) {
+	m := after
+	if m == nil {
+		m = before
+	}
+	notification.AddEvent(ctx, &event.ResourceChangeEventMetadata{
+		ProjectID:    m.ProjectID,
+		ResourceType: event.ResourceTypeProjectMember,
+		Resource:     m.Entityname,
+		Operation:    action.String(),
+		Operator:     operator.FromContext(ctx),
+		Before:       before,
+		After:        after,
+	})
 }
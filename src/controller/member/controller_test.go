@@ -15,6 +15,7 @@
 package member
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -22,11 +23,13 @@ import (
 
 	comModels "github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/pkg/member"
+	memberModels "github.com/goharbor/harbor/src/pkg/member/models"
 	"github.com/goharbor/harbor/src/pkg/project"
 	"github.com/goharbor/harbor/src/pkg/project/models"
 	"github.com/goharbor/harbor/src/pkg/user"
 	"github.com/goharbor/harbor/src/pkg/usergroup"
 	modelGroup "github.com/goharbor/harbor/src/pkg/usergroup/model"
+	mockStatistic "github.com/goharbor/harbor/src/testing/controller/statistic"
 	"github.com/goharbor/harbor/src/testing/mock"
 	mockMember "github.com/goharbor/harbor/src/testing/pkg/member"
 	mockProject "github.com/goharbor/harbor/src/testing/pkg/project"
@@ -40,6 +43,7 @@ type MemberControllerTestSuite struct {
 	memberManager member.Manager
 	projectMgr    project.Manager
 	groupManager  usergroup.Manager
+	statisticCtl  *mockStatistic.Controller
 	controller    *controller
 }
 
@@ -48,11 +52,14 @@ func (suite *MemberControllerTestSuite) SetupSuite() {
 	suite.memberManager = &mockMember.Manager{}
 	suite.projectMgr = &mockProject.Manager{}
 	suite.groupManager = &mockUsergroup.Manager{}
+	suite.statisticCtl = &mockStatistic.Controller{}
+	mock.OnAnything(suite.statisticCtl, "MarkDirty").Return()
 	suite.controller = &controller{
 		userManager:  suite.userManager,
 		mgr:          suite.memberManager,
 		projectMgr:   suite.projectMgr,
 		groupManager: suite.groupManager,
+		statisticCtl: suite.statisticCtl,
 	}
 }
 
@@ -65,16 +72,18 @@ func (suite *MemberControllerTestSuite) TestAddProjectMemberWithUser() {
 		ProjectID: 1,
 	}, nil)
 	suite.userManager.(*mockUser.Manager).On("Get", mock.Anything, 1).Return(nil, fmt.Errorf("user not found"))
-	_, err := suite.controller.Create(nil, 1, Request{MemberUser: User{UserID: 1}})
+	_, err := suite.controller.Create(context.Background(), 1, Request{MemberUser: User{UserID: 1}})
 	suite.Error(err)
-	_, err = suite.controller.Create(nil, 1, Request{MemberUser: User{UserID: 1}})
+	_, err = suite.controller.Create(context.Background(), 1, Request{MemberUser: User{UserID: 1}})
 	suite.Error(err)
 	suite.userManager.(*mockUser.Manager).On("Get", mock.Anything, 2).Return(&comModels.User{UserID: 2, Username: "mike"}, nil)
 	suite.memberManager.(*mockMember.Manager).On("Add", mock.Anything, 1, 2).Return(nil)
 	mock.OnAnything(suite.memberManager, "List").Return(nil, nil)
 	mock.OnAnything(suite.memberManager, "AddProjectMember").Return(0, nil)
-	_, err = suite.controller.Create(nil, 1, Request{MemberUser: User{UserID: 2}, Role: 1})
+	mock.OnAnything(suite.memberManager, "Get").Return(&memberModels.Member{ProjectID: 1}, nil)
+	_, err = suite.controller.Create(context.Background(), 1, Request{MemberUser: User{UserID: 2}, Role: 1})
 	suite.NoError(err)
+	suite.statisticCtl.AssertCalled(suite.T(), "MarkDirty", int64(1))
 }
 
 func (suite *MemberControllerTestSuite) TestAddProjectMemberWithUserGroup() {
@@ -82,16 +91,17 @@ func (suite *MemberControllerTestSuite) TestAddProjectMemberWithUserGroup() {
 		ProjectID: 1,
 	}, nil)
 	suite.groupManager.(*mockUsergroup.Manager).On("Get", mock.Anything, 1).Return(nil, fmt.Errorf("user group not found"))
-	_, err := suite.controller.Create(nil, 1, Request{MemberGroup: UserGroup{ID: 1}})
+	_, err := suite.controller.Create(context.Background(), 1, Request{MemberGroup: UserGroup{ID: 1}})
 	suite.Error(err)
 	suite.groupManager.(*mockUsergroup.Manager).On("Get", mock.Anything, 1).Return(nil, fmt.Errorf("group not found"))
-	_, err = suite.controller.Create(nil, 1, Request{MemberGroup: UserGroup{ID: 1}})
+	_, err = suite.controller.Create(context.Background(), 1, Request{MemberGroup: UserGroup{ID: 1}})
 	suite.Error(err)
 	suite.groupManager.(*mockUsergroup.Manager).On("Get", mock.Anything, 2).Return(&modelGroup.UserGroup{ID: 2, GroupName: "group1"}, nil)
 	suite.memberManager.(*mockMember.Manager).On("Add", mock.Anything, 1, 2).Return(nil)
 	mock.OnAnything(suite.memberManager, "List").Return(nil, nil)
 	mock.OnAnything(suite.memberManager, "AddProjectMember").Return(0, nil)
-	_, err = suite.controller.Create(nil, 1, Request{MemberGroup: UserGroup{ID: 2}, Role: 1})
+	mock.OnAnything(suite.memberManager, "Get").Return(&memberModels.Member{ProjectID: 1}, nil)
+	_, err = suite.controller.Create(context.Background(), 1, Request{MemberGroup: UserGroup{ID: 2}, Role: 1})
 	suite.NoError(err)
 }
 
@@ -0,0 +1,345 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promotion
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/goharbor/harbor/src/common"
+	commonmodels "github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/quota"
+	"github.com/goharbor/harbor/src/controller/replication"
+	"github.com/goharbor/harbor/src/controller/repository"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/label"
+	"github.com/goharbor/harbor/src/pkg/promotion"
+	"github.com/goharbor/harbor/src/pkg/promotion/model"
+	regmodel "github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+// Ctl is a global variable for the default promotion controller implementation
+var Ctl = NewController()
+
+// the power of the roles to check whether the reviewer has the designated approver role or a higher one
+var rolePower = map[int]int{
+	common.RoleProjectAdmin: 50,
+	common.RoleMaintainer:   40,
+	common.RoleDeveloper:    30,
+	common.RoleGuest:        20,
+	common.RoleLimitedGuest: 10,
+}
+
+// Controller manages the promotions of the artifacts: the promotion is requested against the artifact
+// and the target, and the artifact is copied to the target project or replicated by the replication
+// policy only after the promotion is approved by the users with the designated approver role
+type Controller interface {
+	// Create the pending promotion of the artifact specified by the reference, which can be the tag or the digest
+	Create(ctx context.Context, promotion *model.Promotion, reference string) (int64, error)
+	// Get the promotion specified by the ID
+	Get(ctx context.Context, id int64) (*model.Promotion, error)
+	// Count returns the total count of the promotions according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List the promotions according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.Promotion, error)
+	// Approve the pending promotion and promote the artifact, the returned promotion is failed if the
+	// artifact cannot be promoted
+	Approve(ctx context.Context, id int64, reviewer *commonmodels.User, comment string) (*model.Promotion, error)
+	// Reject the pending promotion
+	Reject(ctx context.Context, id int64, reviewer *commonmodels.User, comment string) error
+}
+
+// NewController creates an instance of the default promotion controller
+func NewController() Controller {
+	return &controller{
+		mgr:      promotion.Mgr,
+		artCtl:   artifact.Ctl,
+		proCtl:   project.Ctl,
+		repoCtl:  repository.Ctl,
+		repCtl:   replication.Ctl,
+		quotaCtl: quota.Ctl,
+		labelMgr: label.Mgr,
+	}
+}
+
+type controller struct {
+	mgr      promotion.Manager
+	artCtl   artifact.Controller
+	proCtl   project.Controller
+	repoCtl  repository.Controller
+	repCtl   replication.Controller
+	quotaCtl quota.Controller
+	labelMgr label.Manager
+}
+
+func (c *controller) Create(ctx context.Context, promotion *model.Promotion, reference string) (int64, error) {
+	art, err := c.artCtl.GetByReference(ctx, promotion.RepositoryName, reference, nil)
+	if err != nil {
+		return 0, err
+	}
+	if art.ProjectID != promotion.ProjectID {
+		return 0, errors.BadRequestError(nil).WithMessage("the artifact %s isn't in the project %d", promotion.RepositoryName, promotion.ProjectID)
+	}
+	promotion.Digest = art.Digest
+
+	if (promotion.TargetProjectID > 0) == (promotion.ReplicationPolicyID > 0) {
+		return 0, errors.BadRequestError(nil).WithMessage("only one of the target project and the replication policy must be specified")
+	}
+	if promotion.TargetProjectID > 0 {
+		if err = c.validateTargetProject(ctx, promotion); err != nil {
+			return 0, err
+		}
+	} else if err = c.validateReplicationPolicy(ctx, promotion.ReplicationPolicyID); err != nil {
+		return 0, err
+	}
+	if promotion.LabelID > 0 {
+		if err = c.validateLabel(ctx, promotion); err != nil {
+			return 0, err
+		}
+	}
+	return c.mgr.Create(ctx, promotion)
+}
+
+func (c *controller) validateTargetProject(ctx context.Context, promotion *model.Promotion) error {
+	if promotion.TargetProjectID == promotion.ProjectID {
+		return errors.BadRequestError(nil).WithMessage("the artifact cannot be promoted to its own project")
+	}
+	target, err := c.proCtl.Get(ctx, promotion.TargetProjectID)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return errors.BadRequestError(nil).WithMessage("the target project %d not found", promotion.TargetProjectID)
+		}
+		return err
+	}
+	if target.IsProxy() {
+		return errors.BadRequestError(nil).WithMessage("the artifact cannot be promoted to the proxy cache project %s", target.Name)
+	}
+	return nil
+}
+
+func (c *controller) validateReplicationPolicy(ctx context.Context, id int64) error {
+	policy, err := c.repCtl.GetPolicy(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return errors.BadRequestError(nil).WithMessage("the replication policy %d not found", id)
+		}
+		return err
+	}
+	// the promoted artifact is pushed from the local Harbor to the remote registry
+	if !(policy.SrcRegistry == nil || policy.SrcRegistry.ID == 0) || policy.DestRegistry == nil || policy.DestRegistry.ID == 0 {
+		return errors.BadRequestError(nil).WithMessage("the replication policy %d doesn't replicate the artifacts of the local Harbor to the remote registry", id)
+	}
+	if !policy.Enabled {
+		return errors.BadRequestError(nil).WithMessage("the replication policy %d is disabled", id)
+	}
+	return nil
+}
+
+// validateLabel checks the label can be applied to the promoted artifact, which is the copy in the target project
+// or the artifact itself when it's replicated to the remote registry
+func (c *controller) validateLabel(ctx context.Context, promotion *model.Promotion) error {
+	l, err := c.labelMgr.Get(ctx, promotion.LabelID)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return errors.BadRequestError(nil).WithMessage("the label %d not found", promotion.LabelID)
+		}
+		return err
+	}
+	if l.Scope == common.LabelScopeProject && l.ProjectID != promotedProjectID(promotion) {
+		return errors.BadRequestError(nil).WithMessage("the label %d cannot be applied to the promoted artifact", promotion.LabelID)
+	}
+	return nil
+}
+
+func (c *controller) Get(ctx context.Context, id int64) (*model.Promotion, error) {
+	return c.mgr.Get(ctx, id)
+}
+
+func (c *controller) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return c.mgr.Count(ctx, query)
+}
+
+func (c *controller) List(ctx context.Context, query *q.Query) ([]*model.Promotion, error) {
+	return c.mgr.List(ctx, query)
+}
+
+func (c *controller) Approve(ctx context.Context, id int64, reviewer *commonmodels.User, comment string) (*model.Promotion, error) {
+	promotion, err := c.mgr.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.requireApprover(ctx, promotion, reviewer); err != nil {
+		return nil, err
+	}
+	// the status is checked when updating it, so the promotion is approved only once by the concurrent reviewers
+	if err = c.mgr.Approve(ctx, id, reviewer.Username, comment); err != nil {
+		return nil, err
+	}
+	promotion.Reviewer = reviewer.Username
+	promotion.ReviewComment = comment
+
+	if err = c.promote(ctx, promotion); err != nil {
+		log.G(ctx).Errorf("failed to promote the artifact %s@%s of the promotion %d: %v", promotion.RepositoryName, promotion.Digest, id, err)
+		promotion.Status = model.StatusFailed
+		promotion.StatusMessage = err.Error()
+	} else {
+		promotion.Status = model.StatusSucceed
+	}
+	if err = c.mgr.Update(ctx, promotion, "Status", "StatusMessage", "ExecutionID"); err != nil {
+		return nil, err
+	}
+	return c.mgr.Get(ctx, id)
+}
+
+func (c *controller) Reject(ctx context.Context, id int64, reviewer *commonmodels.User, comment string) error {
+	promotion, err := c.mgr.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = c.requireApprover(ctx, promotion, reviewer); err != nil {
+		return err
+	}
+	return c.mgr.Reject(ctx, id, reviewer.Username, comment)
+}
+
+// requireApprover checks the reviewer has the approver role designated by the project which the artifact is
+// promoted to, or a higher role. The requester cannot review its own promotion unless it's the system admin
+func (c *controller) requireApprover(ctx context.Context, promotion *model.Promotion, reviewer *commonmodels.User) error {
+	if reviewer.SysAdminFlag {
+		return nil
+	}
+	if reviewer.Username == promotion.Requester {
+		return errors.ForbiddenError(nil).WithMessage("the promotion cannot be reviewed by its requester")
+	}
+	projectID := promotedProjectID(promotion)
+	p, err := c.proCtl.Get(ctx, projectID, project.Metadata(true))
+	if err != nil {
+		return err
+	}
+	roles, err := c.proCtl.ListRoles(ctx, projectID, reviewer)
+	if err != nil {
+		return err
+	}
+	required := rolePower[p.PromotionApproverRole()]
+	for _, role := range roles {
+		if rolePower[role] >= required {
+			return nil
+		}
+	}
+	return errors.ForbiddenError(nil).WithMessage("the promotion can only be reviewed by the users with the approver role of the project %s", p.Name)
+}
+
+// promote copies the artifact to the target project or starts the replication of it, and applies the label
+func (c *controller) promote(ctx context.Context, promotion *model.Promotion) error {
+	if promotion.TargetProjectID > 0 {
+		return c.copy(ctx, promotion)
+	}
+	return c.replicate(ctx, promotion)
+}
+
+func (c *controller) copy(ctx context.Context, promotion *model.Promotion) error {
+	target, err := c.proCtl.Get(ctx, promotion.TargetProjectID)
+	if err != nil {
+		return err
+	}
+	_, name := utils.ParseRepository(promotion.RepositoryName)
+	dstRepo := fmt.Sprintf("%s/%s", target.Name, name)
+	if _, _, err = c.repoCtl.Ensure(ctx, dstRepo); err != nil {
+		return err
+	}
+	id, err := c.artCtl.Copy(ctx, promotion.RepositoryName, promotion.Digest, dstRepo)
+	if err != nil {
+		return err
+	}
+	// the approved promotion isn't blocked by the quota of the target project, but the usage is kept accurate
+	if err = c.quotaCtl.Refresh(ctx, quota.ProjectReference, strconv.FormatInt(target.ProjectID, 10), quota.IgnoreLimitation(true)); err != nil {
+		log.G(ctx).Warningf("failed to refresh the quota of the project %d: %v", target.ProjectID, err)
+	}
+	return c.addLabel(ctx, id, promotion.LabelID)
+}
+
+func (c *controller) replicate(ctx context.Context, promotion *model.Promotion) error {
+	art, err := c.artCtl.GetByReference(ctx, promotion.RepositoryName, promotion.Digest, &artifact.Option{WithTag: true})
+	if err != nil {
+		return err
+	}
+	// the label is applied before the replication starts, so the label filters of the policy match the artifact
+	if err = c.addLabel(ctx, art.ID, promotion.LabelID); err != nil {
+		return err
+	}
+	policy, err := c.repCtl.GetPolicy(ctx, promotion.ReplicationPolicyID)
+	if err != nil {
+		return err
+	}
+	p, err := c.proCtl.Get(ctx, promotion.ProjectID, project.Metadata(true))
+	if err != nil {
+		return err
+	}
+	labels, err := c.labelMgr.ListByArtifact(ctx, art.ID)
+	if err != nil {
+		return err
+	}
+	resource := &regmodel.Resource{
+		Type: regmodel.ResourceTypeArtifact,
+		Metadata: &regmodel.ResourceMetadata{
+			Repository: &regmodel.Repository{
+				Name: promotion.RepositoryName,
+				Metadata: map[string]interface{}{
+					"public": strconv.FormatBool(p.IsPublic()),
+				},
+			},
+			Artifacts: []*regmodel.Artifact{
+				{
+					Type:   art.Type,
+					Digest: art.Digest,
+				},
+			},
+		},
+	}
+	for _, t := range art.Tags {
+		resource.Metadata.Artifacts[0].Tags = append(resource.Metadata.Artifacts[0].Tags, t.Name)
+	}
+	for _, l := range labels {
+		resource.Metadata.Artifacts[0].Labels = append(resource.Metadata.Artifacts[0].Labels, l.Name)
+	}
+	promotion.ExecutionID, err = c.repCtl.Start(ctx, policy, resource, task.ExecutionTriggerManual)
+	return err
+}
+
+func (c *controller) addLabel(ctx context.Context, artifactID, labelID int64) error {
+	if labelID == 0 {
+		return nil
+	}
+	if err := c.artCtl.AddLabel(ctx, artifactID, labelID); err != nil && !errors.IsConflictErr(err) {
+		return err
+	}
+	return nil
+}
+
+// promotedProjectID returns the ID of the project which the artifact is promoted to, it's the project of the
+// artifact itself when the artifact is replicated to the remote registry
+func promotedProjectID(promotion *model.Promotion) int64 {
+	if promotion.TargetProjectID > 0 {
+		return promotion.TargetProjectID
+	}
+	return promotion.ProjectID
+}
@@ -0,0 +1,204 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promotion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common"
+	commonmodels "github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/controller/artifact"
+	repctlmodel "github.com/goharbor/harbor/src/controller/replication/model"
+	"github.com/goharbor/harbor/src/lib/errors"
+	pkgartifact "github.com/goharbor/harbor/src/pkg/artifact"
+	labelmodel "github.com/goharbor/harbor/src/pkg/label/model"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/promotion/model"
+	regmodel "github.com/goharbor/harbor/src/pkg/reg/model"
+	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	quotatesting "github.com/goharbor/harbor/src/testing/controller/quota"
+	replicationtesting "github.com/goharbor/harbor/src/testing/controller/replication"
+	repositorytesting "github.com/goharbor/harbor/src/testing/controller/repository"
+	"github.com/goharbor/harbor/src/testing/mock"
+	labeltesting "github.com/goharbor/harbor/src/testing/pkg/label"
+	promotiontesting "github.com/goharbor/harbor/src/testing/pkg/promotion"
+)
+
+type controllerTestSuite struct {
+	suite.Suite
+	ctl      *controller
+	mgr      *promotiontesting.Manager
+	artCtl   *artifacttesting.Controller
+	proCtl   *projecttesting.Controller
+	repoCtl  *repositorytesting.Controller
+	repCtl   *replicationtesting.Controller
+	quotaCtl *quotatesting.Controller
+	labelMgr *labeltesting.Manager
+}
+
+func (c *controllerTestSuite) SetupTest() {
+	c.mgr = &promotiontesting.Manager{}
+	c.artCtl = &artifacttesting.Controller{}
+	c.proCtl = &projecttesting.Controller{}
+	c.repoCtl = &repositorytesting.Controller{}
+	c.repCtl = &replicationtesting.Controller{}
+	c.quotaCtl = &quotatesting.Controller{}
+	c.labelMgr = &labeltesting.Manager{}
+	c.ctl = &controller{
+		mgr:      c.mgr,
+		artCtl:   c.artCtl,
+		proCtl:   c.proCtl,
+		repoCtl:  c.repoCtl,
+		repCtl:   c.repCtl,
+		quotaCtl: c.quotaCtl,
+		labelMgr: c.labelMgr,
+	}
+}
+
+func (c *controllerTestSuite) mockArtifact() {
+	mock.OnAnything(c.artCtl, "GetByReference").Return(&artifact.Artifact{
+		Artifact: pkgartifact.Artifact{ID: 1, ProjectID: 1, RepositoryName: "library/hello-world", Digest: "sha256:123"},
+	}, nil)
+}
+
+func (c *controllerTestSuite) TestCreate() {
+	c.mockArtifact()
+
+	// both the target project and the replication policy are specified
+	_, err := c.ctl.Create(context.Background(), &model.Promotion{ProjectID: 1, RepositoryName: "library/hello-world",
+		TargetProjectID: 2, ReplicationPolicyID: 1}, "latest")
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// promoted to its own project
+	_, err = c.ctl.Create(context.Background(), &model.Promotion{ProjectID: 1, RepositoryName: "library/hello-world",
+		TargetProjectID: 1}, "latest")
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// the replication policy pulls the artifacts from the remote registry
+	c.repCtl.On("GetPolicy", mock.Anything, int64(1)).Return(&repctlmodel.Policy{
+		ID:           1,
+		SrcRegistry:  &regmodel.Registry{ID: 1},
+		DestRegistry: &regmodel.Registry{ID: 0},
+		Enabled:      true,
+	}, nil)
+	_, err = c.ctl.Create(context.Background(), &model.Promotion{ProjectID: 1, RepositoryName: "library/hello-world",
+		ReplicationPolicyID: 1}, "latest")
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// the label of other projects
+	c.proCtl.On("Get", mock.Anything, int64(2)).Return(&proModels.Project{ProjectID: 2, Name: "prod"}, nil)
+	c.labelMgr.On("Get", mock.Anything, int64(1)).Return(&labelmodel.Label{ID: 1, Scope: common.LabelScopeProject, ProjectID: 1}, nil)
+	_, err = c.ctl.Create(context.Background(), &model.Promotion{ProjectID: 1, RepositoryName: "library/hello-world",
+		TargetProjectID: 2, LabelID: 1}, "latest")
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// pass
+	c.labelMgr.On("Get", mock.Anything, int64(2)).Return(&labelmodel.Label{ID: 2, Scope: common.LabelScopeGlobal}, nil)
+	c.mgr.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	promotion := &model.Promotion{ProjectID: 1, RepositoryName: "library/hello-world", TargetProjectID: 2, LabelID: 2}
+	id, err := c.ctl.Create(context.Background(), promotion, "latest")
+	c.Require().Nil(err)
+	c.Equal(int64(1), id)
+	c.Equal("sha256:123", promotion.Digest)
+}
+
+func (c *controllerTestSuite) TestApprove() {
+	c.mgr.On("Get", mock.Anything, int64(1)).Return(&model.Promotion{ID: 1, ProjectID: 1, RepositoryName: "library/hello-world",
+		Digest: "sha256:123", TargetProjectID: 2, LabelID: 2, Requester: "dev"}, nil)
+	c.proCtl.On("Get", mock.Anything, int64(2), mock.Anything).Return(&proModels.Project{ProjectID: 2, Name: "prod",
+		Metadata: map[string]string{proModels.ProMetaPromotionApproverRole: proModels.PromotionApproverMaintainer}}, nil)
+
+	// reviewed by the requester
+	_, err := c.ctl.Approve(context.Background(), 1, &commonmodels.User{Username: "dev"}, "")
+	c.True(errors.IsErr(err, errors.ForbiddenCode))
+
+	// the reviewer doesn't have the approver role
+	c.proCtl.On("ListRoles", mock.Anything, int64(2), mock.Anything).Return([]int{common.RoleDeveloper}, nil).Once()
+	_, err = c.ctl.Approve(context.Background(), 1, &commonmodels.User{Username: "tester"}, "")
+	c.True(errors.IsErr(err, errors.ForbiddenCode))
+
+	// pass
+	c.proCtl.On("ListRoles", mock.Anything, int64(2), mock.Anything).Return([]int{common.RoleGuest, common.RoleProjectAdmin}, nil)
+	c.mgr.On("Approve", mock.Anything, int64(1), "admin", "lgtm").Return(nil)
+	c.repoCtl.On("Ensure", mock.Anything, "prod/hello-world").Return(true, int64(2), nil)
+	c.artCtl.On("Copy", mock.Anything, "library/hello-world", "sha256:123", "prod/hello-world").Return(int64(2), nil)
+	mock.OnAnything(c.quotaCtl, "Refresh").Return(nil)
+	c.artCtl.On("AddLabel", mock.Anything, int64(2), int64(2)).Return(nil)
+	c.mgr.On("Update", mock.Anything, mock.Anything, "Status", "StatusMessage", "ExecutionID").Return(nil)
+	_, err = c.ctl.Approve(context.Background(), 1, &commonmodels.User{Username: "admin"}, "lgtm")
+	c.Require().Nil(err)
+	updated := c.mgr.Calls[len(c.mgr.Calls)-2].Arguments.Get(1).(*model.Promotion)
+	c.Equal(model.StatusSucceed, updated.Status)
+	c.artCtl.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestApproveByReplication() {
+	c.mgr.On("Get", mock.Anything, int64(1)).Return(&model.Promotion{ID: 1, ProjectID: 1, RepositoryName: "library/hello-world",
+		Digest: "sha256:123", ReplicationPolicyID: 1, Requester: "dev"}, nil)
+	c.mgr.On("Approve", mock.Anything, int64(1), "admin", "").Return(nil)
+	c.mockArtifact()
+	c.repCtl.On("GetPolicy", mock.Anything, int64(1)).Return(&repctlmodel.Policy{ID: 1}, nil)
+	c.proCtl.On("Get", mock.Anything, int64(1), mock.Anything).Return(&proModels.Project{ProjectID: 1, Name: "library"}, nil)
+	mock.OnAnything(c.labelMgr, "ListByArtifact").Return([]*labelmodel.Label{}, nil)
+	c.repCtl.On("Start", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(10), nil)
+	c.mgr.On("Update", mock.Anything, mock.Anything, "Status", "StatusMessage", "ExecutionID").Return(nil)
+
+	// the system admin can review any promotion
+	_, err := c.ctl.Approve(context.Background(), 1, &commonmodels.User{Username: "admin", SysAdminFlag: true}, "")
+	c.Require().Nil(err)
+	updated := c.mgr.Calls[len(c.mgr.Calls)-2].Arguments.Get(1).(*model.Promotion)
+	c.Equal(model.StatusSucceed, updated.Status)
+	c.Equal(int64(10), updated.ExecutionID)
+	resource := c.repCtl.Calls[len(c.repCtl.Calls)-1].Arguments.Get(2).(*regmodel.Resource)
+	c.Equal("library/hello-world", resource.Metadata.Repository.Name)
+	c.Equal("sha256:123", resource.Metadata.Artifacts[0].Digest)
+}
+
+func (c *controllerTestSuite) TestApproveFailed() {
+	c.mgr.On("Get", mock.Anything, int64(1)).Return(&model.Promotion{ID: 1, ProjectID: 1, RepositoryName: "library/hello-world",
+		Digest: "sha256:123", TargetProjectID: 2}, nil)
+	c.mgr.On("Approve", mock.Anything, int64(1), "admin", "").Return(nil)
+	c.proCtl.On("Get", mock.Anything, int64(2)).Return(&proModels.Project{ProjectID: 2, Name: "prod"}, nil)
+	c.repoCtl.On("Ensure", mock.Anything, "prod/hello-world").Return(false, int64(2), nil)
+	c.artCtl.On("Copy", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(0), errors.New("failed to copy"))
+	c.mgr.On("Update", mock.Anything, mock.Anything, "Status", "StatusMessage", "ExecutionID").Return(nil)
+
+	_, err := c.ctl.Approve(context.Background(), 1, &commonmodels.User{Username: "admin", SysAdminFlag: true}, "")
+	c.Require().Nil(err)
+	updated := c.mgr.Calls[len(c.mgr.Calls)-2].Arguments.Get(1).(*model.Promotion)
+	c.Equal(model.StatusFailed, updated.Status)
+	c.Equal("failed to copy", updated.StatusMessage)
+}
+
+func (c *controllerTestSuite) TestReject() {
+	c.mgr.On("Get", mock.Anything, int64(1)).Return(&model.Promotion{ID: 1, ProjectID: 1, TargetProjectID: 2, Requester: "dev"}, nil)
+	// only the project admins can approve the promotions by default
+	c.proCtl.On("Get", mock.Anything, int64(2), mock.Anything).Return(&proModels.Project{ProjectID: 2, Name: "prod"}, nil)
+	c.proCtl.On("ListRoles", mock.Anything, int64(2), mock.Anything).Return([]int{common.RoleMaintainer}, nil)
+	err := c.ctl.Reject(context.Background(), 1, &commonmodels.User{Username: "maintainer"}, "")
+	c.True(errors.IsErr(err, errors.ForbiddenCode))
+
+	c.mgr.On("Reject", mock.Anything, int64(1), "admin", "not ready").Return(nil)
+	c.Nil(c.ctl.Reject(context.Background(), 1, &commonmodels.User{Username: "admin", SysAdminFlag: true}, "not ready"))
+	c.mgr.AssertExpectations(c.T())
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &controllerTestSuite{})
+}
@@ -35,6 +35,8 @@ import (
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/goharbor/harbor/src/pkg/reg/util"
 )
 
 const (
@@ -44,6 +46,9 @@ const (
 	sleepIntervalSec    = 20
 	// keep manifest list in cache for one week
 	manifestListCacheInterval = 7 * 24 * 60 * 60 * time.Second
+	// the key prefixes of the tags checked with the remote registry recently and the references not found in it
+	freshTagKeyPrefix = "proxycache:fresh:"
+	notFoundKeyPrefix = "proxycache:notfound:"
 )
 
 var (
@@ -56,14 +61,14 @@ var (
 type Controller interface {
 	// UseLocalBlob check if the blob should use local copy
 	UseLocalBlob(ctx context.Context, art lib.ArtifactInfo) bool
-	// UseLocalManifest check manifest should use local copy
-	UseLocalManifest(ctx context.Context, art lib.ArtifactInfo, remote RemoteInterface) (bool, *ManifestList, error)
+	// UseLocalManifest check manifest should use local copy, p is the proxy project
+	UseLocalManifest(ctx context.Context, p *proModels.Project, art lib.ArtifactInfo, remote RemoteInterface) (bool, *ManifestList, error)
 	// ProxyBlob proxy the blob request to the remote server, p is the proxy project
 	// art is the ArtifactInfo which includes the digest of the blob
 	ProxyBlob(ctx context.Context, p *proModels.Project, art lib.ArtifactInfo) (int64, io.ReadCloser, error)
 	// ProxyManifest proxy the manifest request to the remote server, p is the proxy project,
 	// art is the ArtifactInfo which includes the tag or digest of the manifest
-	ProxyManifest(ctx context.Context, art lib.ArtifactInfo, remote RemoteInterface) (distribution.Manifest, error)
+	ProxyManifest(ctx context.Context, p *proModels.Project, art lib.ArtifactInfo, remote RemoteInterface) (distribution.Manifest, error)
 	// HeadManifest send manifest head request to the remote server
 	HeadManifest(ctx context.Context, art lib.ArtifactInfo, remote RemoteInterface) (bool, *distribution.Descriptor, error)
 	// EnsureTag ensure tag for digest
@@ -142,7 +147,7 @@ type ManifestList struct {
 // the return error should be nil when it is not found in local and need to delegate to remote registry
 // the return error should be NotFoundError when it is not found in remote registry
 // the error will be captured by framework and return 404 to client
-func (c *controller) UseLocalManifest(ctx context.Context, art lib.ArtifactInfo, remote RemoteInterface) (bool, *ManifestList, error) {
+func (c *controller) UseLocalManifest(ctx context.Context, p *proModels.Project, art lib.ArtifactInfo, remote RemoteInterface) (bool, *ManifestList, error) {
 	a, err := c.local.GetManifest(ctx, art)
 	if err != nil {
 		return false, nil, err
@@ -151,6 +156,15 @@ func (c *controller) UseLocalManifest(ctx context.Context, art lib.ArtifactInfo,
 	if a != nil && len(art.Digest) > 0 {
 		return true, nil, nil
 	}
+	// the tag is checked with the remote registry within the TTL and the local one is the same, use it directly
+	if a != nil && c.isFreshTag(ctx, art, a.Digest) {
+		log.Debugf("the tag %s:%s is checked with the remote registry recently, use the local one", art.Repository, art.Tag)
+		return true, nil, nil
+	}
+	// the reference isn't found in the remote registry within the negative TTL
+	if c.cache != nil && c.cache.Contains(ctx, notFoundKey(art)) {
+		return false, nil, errors.NotFoundError(fmt.Errorf("repo %v, reference %v not found in the remote registry", art.Repository, getReference(art)))
+	}
 
 	remoteRepo := getRemoteRepo(art)
 	exist, desc, err := remote.ManifestExist(remoteRepo, getReference(art)) // HEAD
@@ -158,11 +172,13 @@ func (c *controller) UseLocalManifest(ctx context.Context, art lib.ArtifactInfo,
 		return false, nil, err
 	}
 	if !exist || desc == nil {
+		c.cacheNotFound(ctx, p, art)
 		go func() {
 			c.local.DeleteManifest(remoteRepo, art.Tag)
 		}()
 		return false, nil, errors.NotFoundError(fmt.Errorf("repo %v, tag %v not found", art.Repository, art.Tag))
 	}
+	c.cacheFreshTag(ctx, p, art, string(desc.Digest))
 
 	var content []byte
 	var contentType string
@@ -188,6 +204,57 @@ func (c *controller) UseLocalManifest(ctx context.Context, art lib.ArtifactInfo,
 	return true, &ManifestList{content, string(desc.Digest), contentType}, nil
 }
 
+// isFreshTag checks whether the tag is checked with the remote registry within the TTL and it refers to the digest
+func (c *controller) isFreshTag(ctx context.Context, art lib.ArtifactInfo, digest string) bool {
+	if c.cache == nil || len(art.Tag) == 0 {
+		return false
+	}
+	var dig string
+	if err := c.cache.Fetch(ctx, freshTagKey(art), &dig); err != nil {
+		if !errors.Is(err, cache.ErrNotFound) {
+			log.Errorf("failed to get the fresh tag %s:%s from cache, error: %v", art.Repository, art.Tag, err)
+		}
+		return false
+	}
+	return dig == digest
+}
+
+// cacheFreshTag records the digest of the tag in the remote registry within the TTL of the project
+func (c *controller) cacheFreshTag(ctx context.Context, p *proModels.Project, art lib.ArtifactInfo, digest string) {
+	if c.cache == nil || p == nil || len(art.Tag) == 0 || len(digest) == 0 {
+		return
+	}
+	ttl := p.ProxyCacheTTL()
+	if ttl <= 0 {
+		return
+	}
+	if err := c.cache.Save(ctx, freshTagKey(art), digest, ttl); err != nil {
+		log.Errorf("failed to save the fresh tag %s:%s to cache, error: %v", art.Repository, art.Tag, err)
+	}
+}
+
+// cacheNotFound records the reference isn't found in the remote registry within the negative TTL of the project
+func (c *controller) cacheNotFound(ctx context.Context, p *proModels.Project, art lib.ArtifactInfo) {
+	if c.cache == nil || p == nil {
+		return
+	}
+	ttl := p.ProxyNegativeCacheTTL()
+	if ttl <= 0 {
+		return
+	}
+	if err := c.cache.Save(ctx, notFoundKey(art), true, ttl); err != nil {
+		log.Errorf("failed to save the not found reference %s:%s to cache, error: %v", art.Repository, getReference(art), err)
+	}
+}
+
+func freshTagKey(art lib.ArtifactInfo) string {
+	return freshTagKeyPrefix + art.Repository + ":" + art.Tag
+}
+
+func notFoundKey(art lib.ArtifactInfo) string {
+	return notFoundKeyPrefix + art.Repository + ":" + getReference(art)
+}
+
 func manifestListKey(repo string, art lib.ArtifactInfo) string {
 	// actual redis key format is cache:manifestlist:<repo name>:<tag> or cache:manifestlist:<repo name>:sha256:xxxx
 	return "manifestlist:" + repo + ":" + getReference(art)
@@ -197,19 +264,21 @@ func manifestListContentTypeKey(rep string, art lib.ArtifactInfo) string {
 	return manifestListKey(rep, art) + ":contenttype"
 }
 
-func (c *controller) ProxyManifest(ctx context.Context, art lib.ArtifactInfo, remote RemoteInterface) (distribution.Manifest, error) {
+func (c *controller) ProxyManifest(ctx context.Context, p *proModels.Project, art lib.ArtifactInfo, remote RemoteInterface) (distribution.Manifest, error) {
 	var man distribution.Manifest
 	remoteRepo := getRemoteRepo(art)
 	ref := getReference(art)
 	man, dig, err := remote.Manifest(remoteRepo, ref)
 	if err != nil {
 		if errors.IsNotFoundErr(err) {
+			c.cacheNotFound(ctx, p, art)
 			go func() {
 				c.local.DeleteManifest(remoteRepo, art.Tag)
 			}()
 		}
 		return man, err
 	}
+	c.cacheFreshTag(ctx, p, art, dig)
 	ct, _, err := man.Payload()
 	if err != nil {
 		return man, err
@@ -218,6 +287,9 @@ func (c *controller) ProxyManifest(ctx context.Context, art lib.ArtifactInfo, re
 	// Push manifest in background
 	go func(operator string) {
 		bCtx := orm.Copy(ctx)
+		if p.ProxyForeignLayerMode() == model.ForeignLayerModePullThrough {
+			c.cacheForeignLayers(bCtx, art.Repository, man)
+		}
 		a, err := c.local.GetManifest(bCtx, art)
 		if err != nil {
 			log.Errorf("failed to get manifest, error %v", err)
@@ -287,6 +359,30 @@ func (c *controller) putBlobToLocal(remoteRepo string, localRepo string, desc di
 	return err
 }
 
+// cacheForeignLayers pulls the foreign layers referenced by the manifest from their URLs and pushes them to local
+func (c *controller) cacheForeignLayers(ctx context.Context, localRepo string, man distribution.Manifest) {
+	for _, desc := range man.References() {
+		if !util.IsForeignLayer(desc.MediaType) {
+			continue
+		}
+		exist, err := c.local.BlobExist(ctx, lib.ArtifactInfo{Repository: localRepo, Digest: string(desc.Digest)})
+		if err == nil && exist {
+			continue
+		}
+		size, bReader, err := util.PullForeignLayer(desc)
+		if err != nil {
+			log.Errorf("failed to cache the foreign layer of %s, it's left to be pulled from its URLs by the clients: %v", localRepo, err)
+			continue
+		}
+		desc.Size = size
+		err = c.local.PushBlob(localRepo, desc, bReader)
+		bReader.Close()
+		if err != nil {
+			log.Errorf("failed to push the foreign layer %s to local repo %s: %v", desc.Digest, localRepo, err)
+		}
+	}
+}
+
 func (c *controller) waitAndPushManifest(ctx context.Context, remoteRepo string, man distribution.Manifest, art lib.ArtifactInfo, contType string, r RemoteInterface) {
 	h, ok := c.handlerRegistry[contType]
 	if !ok {
@@ -27,8 +27,10 @@ import (
 	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/controller/blob"
 	"github.com/goharbor/harbor/src/lib"
-	_ "github.com/goharbor/harbor/src/lib/cache"
+	"github.com/goharbor/harbor/src/lib/cache"
+	_ "github.com/goharbor/harbor/src/lib/cache/memory"
 	"github.com/goharbor/harbor/src/lib/errors"
+	pkgartifact "github.com/goharbor/harbor/src/pkg/artifact"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	testproxy "github.com/goharbor/harbor/src/testing/controller/proxy"
 )
@@ -105,7 +107,7 @@ func (p *proxyControllerTestSuite) TestUseLocalManifest_True() {
 	art := lib.ArtifactInfo{Repository: "library/hello-world", Digest: dig}
 	p.local.On("GetManifest", mock.Anything, mock.Anything).Return(&artifact.Artifact{}, nil)
 
-	result, _, err := p.ctr.UseLocalManifest(ctx, art, p.remote)
+	result, _, err := p.ctr.UseLocalManifest(ctx, p.proj, art, p.remote)
 	p.Assert().Nil(err)
 	p.Assert().True(result)
 }
@@ -117,7 +119,7 @@ func (p *proxyControllerTestSuite) TestUseLocalManifest_False() {
 	art := lib.ArtifactInfo{Repository: "library/hello-world", Digest: dig}
 	p.remote.On("ManifestExist", mock.Anything, mock.Anything).Return(true, desc, nil)
 	p.local.On("GetManifest", mock.Anything, mock.Anything).Return(nil, nil)
-	result, _, err := p.ctr.UseLocalManifest(ctx, art, p.remote)
+	result, _, err := p.ctr.UseLocalManifest(ctx, p.proj, art, p.remote)
 	p.Assert().Nil(err)
 	p.Assert().False(result)
 }
@@ -128,11 +130,57 @@ func (p *proxyControllerTestSuite) TestUseLocalManifestWithTag_False() {
 	desc := &distribution.Descriptor{}
 	p.local.On("GetManifest", mock.Anything, mock.Anything).Return(&artifact.Artifact{}, nil)
 	p.remote.On("ManifestExist", mock.Anything, mock.Anything).Return(false, desc, nil)
-	result, _, err := p.ctr.UseLocalManifest(ctx, art, p.remote)
+	result, _, err := p.ctr.UseLocalManifest(ctx, p.proj, art, p.remote)
 	p.Assert().True(errors.IsNotFoundErr(err))
 	p.Assert().False(result)
 }
 
+func (p *proxyControllerTestSuite) TestUseLocalManifestWithFreshTag() {
+	ctx := context.Background()
+	c, err := cache.New(cache.Memory)
+	p.Require().Nil(err)
+	p.ctr.(*controller).cache = c
+	p.proj.Metadata = map[string]string{proModels.ProMetaProxyCacheTTL: "60"}
+	dig := "sha256:1a9ec845ee94c202b2d5da74a24f0ed2058318bfa9879fa541efaecba272e86b"
+	art := lib.ArtifactInfo{Repository: "library/hello-world", Tag: "latest"}
+	desc := &distribution.Descriptor{Digest: digest.Digest(dig)}
+	p.local.On("GetManifest", mock.Anything, mock.Anything).Return(&artifact.Artifact{Artifact: pkgartifact.Artifact{Digest: dig}}, nil)
+	p.remote.On("ManifestExist", mock.Anything, mock.Anything).Return(true, desc, nil).Once()
+
+	// check the remote registry for the first time
+	result, _, err := p.ctr.UseLocalManifest(ctx, p.proj, art, p.remote)
+	p.Require().Nil(err)
+	p.True(result)
+
+	// use the local one directly within the TTL
+	result, _, err = p.ctr.UseLocalManifest(ctx, p.proj, art, p.remote)
+	p.Require().Nil(err)
+	p.True(result)
+	p.remote.AssertExpectations(p.T())
+}
+
+func (p *proxyControllerTestSuite) TestUseLocalManifestWithNegativeCache() {
+	ctx := context.Background()
+	c, err := cache.New(cache.Memory)
+	p.Require().Nil(err)
+	p.ctr.(*controller).cache = c
+	p.proj.Metadata = map[string]string{proModels.ProMetaProxyNegativeCacheTTL: "60"}
+	art := lib.ArtifactInfo{Repository: "library/hello-world", Tag: "notexist"}
+	p.local.On("GetManifest", mock.Anything, mock.Anything).Return(nil, nil)
+	p.remote.On("ManifestExist", mock.Anything, mock.Anything).Return(false, nil, nil).Once()
+
+	// check the remote registry for the first time
+	result, _, err := p.ctr.UseLocalManifest(ctx, p.proj, art, p.remote)
+	p.True(errors.IsNotFoundErr(err))
+	p.False(result)
+
+	// return not found directly within the negative TTL
+	result, _, err = p.ctr.UseLocalManifest(ctx, p.proj, art, p.remote)
+	p.True(errors.IsNotFoundErr(err))
+	p.False(result)
+	p.remote.AssertExpectations(p.T())
+}
+
 func (p *proxyControllerTestSuite) TestUseLocalBlob_True() {
 	ctx := context.Background()
 	dig := "sha256:1a9ec845ee94c202b2d5da74a24f0ed2058318bfa9879fa541efaecba272e86b"
@@ -29,6 +29,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/notifier/event"
 	"github.com/goharbor/harbor/src/pkg/proxy/secret"
+	"github.com/goharbor/harbor/src/pkg/reg/util"
 	"github.com/goharbor/harbor/src/pkg/registry"
 )
 
@@ -139,6 +140,12 @@ func (l *localHelper) CheckDependencies(ctx context.Context, repo string, man di
 	descriptors := man.References()
 	waitDesc := make([]distribution.Descriptor, 0)
 	for _, desc := range descriptors {
+		// the foreign layers are pulled from their URLs by the clients rather than through the proxy,
+		// and they're cached before pushing the manifest if the proxy cache project pulls them through
+		if util.IsForeignLayer(desc.MediaType) {
+			log.Debugf("skip checking the foreign layer: %v", desc.Digest)
+			continue
+		}
 		log.Debugf("checking the blob dependency: %v", desc.Digest)
 		art := lib.ArtifactInfo{Repository: repo, Digest: string(desc.Digest)}
 		exist, err := l.BlobExist(ctx, art)
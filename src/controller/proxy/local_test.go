@@ -135,6 +135,20 @@ func (lh *localHelperTestSuite) TestCheckDependencies_Suc() {
 	lh.Assert().Equal(len(ret), 0)
 }
 
+func (lh *localHelperTestSuite) TestCheckDependencies_ForeignLayer() {
+	ctx := context.Background()
+	manifest := &mockManifest{}
+	refs := []distribution2.Descriptor{
+		{MediaType: schema2.MediaTypeForeignLayer, Digest: "sha256:1a9ec845ee94c202b2d5da74a24f0ed2058318bfa9879fa541efaecba272e86b"},
+		{MediaType: schema2.MediaTypeLayer, Digest: "sha256:92c7f9c92844bbbb5d0a101b22f7c2a7949e40f8ea90c8b3bc396879d95e899a"},
+	}
+	manifest.On("References").Return(refs)
+	lh.registryClient.On("BlobExist", mock.Anything, mock.Anything).Return(false, nil)
+	ret := lh.local.CheckDependencies(ctx, "library/hello-world", manifest)
+	lh.Require().Len(ret, 1)
+	lh.Assert().Equal(refs[1].Digest, ret[0].Digest)
+}
+
 func (lh *localHelperTestSuite) TestManifestExist() {
 	ctx := context.Background()
 	dig := "sha256:1a9ec845ee94c202b2d5da74a24f0ed2058318bfa9879fa541efaecba272e86b"
@@ -0,0 +1,160 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulltoken
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/pulltoken"
+	"github.com/goharbor/harbor/src/pkg/pulltoken/model"
+)
+
+const (
+	// NamePrefix is the prefix of the username used to authenticate with the pull token
+	NamePrefix = "pulltoken$"
+	// DefaultTTL is the default time to live of the pull token in minutes
+	DefaultTTL = 30
+	// MaxTTL is the max time to live of the pull token in minutes
+	MaxTTL = 24 * 60
+)
+
+var (
+	// Ctl is a global variable for the default pull token controller implementation
+	Ctl = NewController()
+)
+
+// Controller to handle the requests related with the pull tokens
+type Controller interface {
+	// Create a pull token which expires after the TTL in minutes, returns the ID and the secret of the token
+	Create(ctx context.Context, t *model.PullToken, ttl int64) (int64, string, error)
+
+	// Get the pull token specified by ID
+	Get(ctx context.Context, id int64) (*model.PullToken, error)
+
+	// Count returns the total count of pull tokens according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+
+	// List the pull tokens according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.PullToken, error)
+
+	// Revoke the pull token, the revoked token cannot be used anymore even it isn't expired
+	Revoke(ctx context.Context, id int64) error
+
+	// Authenticate returns the pull token if the name and secret match a valid token
+	Authenticate(ctx context.Context, name, secret string) (*model.PullToken, error)
+
+	// GetByName returns the pull token specified by the name
+	GetByName(ctx context.Context, name string) (*model.PullToken, error)
+}
+
+// NewController ...
+func NewController() Controller {
+	return &controller{
+		mgr: pulltoken.Mgr,
+	}
+}
+
+type controller struct {
+	mgr pulltoken.Manager
+}
+
+func (c *controller) Create(ctx context.Context, t *model.PullToken, ttl int64) (int64, string, error) {
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+	if ttl < 0 || ttl > MaxTTL {
+		return 0, "", errors.BadRequestError(nil).WithMessage("the ttl must be between 1 and %d minutes", MaxTTL)
+	}
+	repos := t.RepositoryList()
+	if len(repos) == 0 {
+		return 0, "", errors.BadRequestError(nil).WithMessage("at least one repository is required")
+	}
+	for _, repo := range repos {
+		if len(repo) == 0 || strings.Contains(repo, ",") {
+			return 0, "", errors.BadRequestError(nil).WithMessage("invalid repository name %q", repo)
+		}
+	}
+	secret := utils.GenerateRandomStringWithLen(32)
+	t.Salt = utils.GenerateRandomString()
+	t.Secret = utils.Encrypt(secret, t.Salt, utils.SHA256)
+	t.ExpiresAt = time.Now().Add(time.Duration(ttl) * time.Minute)
+	id, err := c.mgr.Create(ctx, t)
+	if err != nil {
+		return 0, "", err
+	}
+	return id, secret, nil
+}
+
+func (c *controller) Get(ctx context.Context, id int64) (*model.PullToken, error) {
+	return c.mgr.Get(ctx, id)
+}
+
+func (c *controller) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return c.mgr.Count(ctx, query)
+}
+
+func (c *controller) List(ctx context.Context, query *q.Query) ([]*model.PullToken, error) {
+	return c.mgr.List(ctx, query)
+}
+
+func (c *controller) Revoke(ctx context.Context, id int64) error {
+	return c.mgr.Update(ctx, &model.PullToken{ID: id, Revoked: true}, "revoked")
+}
+
+func (c *controller) Authenticate(ctx context.Context, name, secret string) (*model.PullToken, error) {
+	t, err := c.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if utils.Encrypt(secret, t.Salt, utils.SHA256) != t.Secret {
+		return nil, errors.UnauthorizedError(nil).WithMessage("invalid secret of the pull token %s", name)
+	}
+	if !t.IsValid() {
+		return nil, errors.UnauthorizedError(nil).WithMessage("the pull token %s is revoked or expired", name)
+	}
+	return t, nil
+}
+
+func (c *controller) GetByName(ctx context.Context, name string) (*model.PullToken, error) {
+	id, ok := ParseName(name)
+	if !ok {
+		return nil, errors.NotFoundError(nil).WithMessage("pull token %s not found", name)
+	}
+	return c.mgr.Get(ctx, id)
+}
+
+// Name returns the username used to authenticate with the pull token
+func Name(id int64) string {
+	return fmt.Sprintf("%s%d", NamePrefix, id)
+}
+
+// ParseName returns the ID of the pull token from the username
+func ParseName(name string) (int64, bool) {
+	if !strings.HasPrefix(name, NamePrefix) {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(strings.TrimPrefix(name, NamePrefix), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
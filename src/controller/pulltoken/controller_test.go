@@ -0,0 +1,119 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulltoken
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/pulltoken/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	pulltokentesting "github.com/goharbor/harbor/src/testing/pkg/pulltoken"
+)
+
+type controllerTestSuite struct {
+	suite.Suite
+	ctl *controller
+	mgr *pulltokentesting.Manager
+}
+
+func (c *controllerTestSuite) SetupTest() {
+	c.mgr = &pulltokentesting.Manager{}
+	c.ctl = &controller{
+		mgr: c.mgr,
+	}
+}
+
+func (c *controllerTestSuite) TestCreate() {
+	// invalid ttl
+	_, _, err := c.ctl.Create(context.Background(), &model.PullToken{ProjectID: 1, Repositories: "nginx"}, MaxTTL+1)
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// no repository
+	_, _, err = c.ctl.Create(context.Background(), &model.PullToken{ProjectID: 1}, 10)
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// pass
+	c.mgr.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	t := &model.PullToken{ProjectID: 1, Repositories: "nginx,busybox"}
+	id, secret, err := c.ctl.Create(context.Background(), t, 0)
+	c.Require().Nil(err)
+	c.Equal(int64(1), id)
+	c.Len(secret, 32)
+	c.NotEqual(secret, t.Secret)
+	c.Equal(utils.Encrypt(secret, t.Salt, utils.SHA256), t.Secret)
+	c.WithinDuration(time.Now().Add(DefaultTTL*time.Minute), t.ExpiresAt, time.Minute)
+}
+
+func (c *controllerTestSuite) TestAuthenticate() {
+	salt := utils.GenerateRandomString()
+	c.mgr.On("Get", mock.Anything, int64(1)).Return(&model.PullToken{
+		ID:        1,
+		Secret:    utils.Encrypt("secret", salt, utils.SHA256),
+		Salt:      salt,
+		ExpiresAt: time.Now().Add(time.Minute),
+	}, nil)
+	c.mgr.On("Get", mock.Anything, int64(2)).Return(&model.PullToken{
+		ID:        2,
+		Secret:    utils.Encrypt("secret", salt, utils.SHA256),
+		Salt:      salt,
+		Revoked:   true,
+		ExpiresAt: time.Now().Add(time.Minute),
+	}, nil)
+
+	// invalid name
+	_, err := c.ctl.Authenticate(context.Background(), "robot$1", "secret")
+	c.NotNil(err)
+
+	// invalid secret
+	_, err = c.ctl.Authenticate(context.Background(), Name(1), "invalid")
+	c.True(errors.IsErr(err, errors.UnAuthorizedCode))
+
+	// revoked
+	_, err = c.ctl.Authenticate(context.Background(), Name(2), "secret")
+	c.True(errors.IsErr(err, errors.UnAuthorizedCode))
+
+	// pass
+	t, err := c.ctl.Authenticate(context.Background(), Name(1), "secret")
+	c.Require().Nil(err)
+	c.Equal(int64(1), t.ID)
+}
+
+func (c *controllerTestSuite) TestRevoke() {
+	c.mgr.On("Update", mock.Anything, &model.PullToken{ID: 1, Revoked: true}, "revoked").Return(nil)
+	c.Nil(c.ctl.Revoke(context.Background(), 1))
+	c.mgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestParseName() {
+	id, ok := ParseName(Name(10))
+	c.True(ok)
+	c.Equal(int64(10), id)
+
+	_, ok = ParseName("pulltoken$abc")
+	c.False(ok)
+
+	_, ok = ParseName("admin")
+	c.False(ok)
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &controllerTestSuite{})
+}
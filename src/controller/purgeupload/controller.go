@@ -0,0 +1,133 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purgeupload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+const (
+	// SchedulerCallback ...
+	SchedulerCallback = "PURGE_UPLOAD_CALLBACK"
+	// VendorType ...
+	VendorType = "PURGE_UPLOAD"
+
+	attrPurged         = "purged"
+	attrReclaimedBytes = "reclaimed_bytes"
+)
+
+// Ctrl a global upload purge controller instance
+var Ctrl = NewController()
+
+func init() {
+	if err := scheduler.RegisterCallbackFunc(SchedulerCallback, purgeUploadCallback); err != nil {
+		log.Fatalf("failed to register the upload purge job call back, %v", err)
+	}
+	if err := task.RegisterCheckInProcessor(job.PurgeUpload, purgeUploadCheckInProcessor); err != nil {
+		log.Fatalf("failed to register the checkin processor for the upload purge job, error %v", err)
+	}
+}
+
+func purgeUploadCallback(ctx context.Context, p string) error {
+	param := &JobPolicy{}
+	if err := json.Unmarshal([]byte(p), param); err != nil {
+		return fmt.Errorf("failed to unmashal the param: %v", err)
+	}
+	_, err := Ctrl.Start(ctx, *param, task.ExecutionTriggerSchedule)
+	return err
+}
+
+// purgeUploadCheckInProcessor records the result checked in by the job into the execution,
+// so the reclaimed space is reported in the history of the upload purge
+func purgeUploadCheckInProcessor(ctx context.Context, t *task.Task, sc *job.StatusChange) error {
+	if sc.CheckIn == "" {
+		return nil
+	}
+	result := &Result{}
+	if err := json.Unmarshal([]byte(sc.CheckIn), result); err != nil {
+		log.Errorf("failed to resolve checkin of upload purge task %d: %v", t.ID, err)
+		return err
+	}
+	exec, err := task.ExecMgr.Get(ctx, t.ExecutionID)
+	if err != nil {
+		return err
+	}
+	if exec.ExtraAttrs == nil {
+		exec.ExtraAttrs = map[string]interface{}{}
+	}
+	exec.ExtraAttrs[attrPurged] = result.Purged
+	exec.ExtraAttrs[attrReclaimedBytes] = result.ReclaimedBytes
+	return task.ExecMgr.UpdateExtraAttrs(ctx, exec.ID, exec.ExtraAttrs)
+}
+
+// Controller defines the interface with the upload purge job
+type Controller interface {
+	// Start kick off an upload purge
+	Start(ctx context.Context, policy JobPolicy, trigger string) (int64, error)
+	// Stop an upload purge job
+	Stop(ctx context.Context, id int64) error
+}
+
+type controller struct {
+	taskMgr task.Manager
+	exeMgr  task.ExecutionManager
+}
+
+func (c *controller) Stop(ctx context.Context, id int64) error {
+	return c.exeMgr.Stop(ctx, id)
+}
+
+func (c *controller) Start(ctx context.Context, policy JobPolicy, trigger string) (int64, error) {
+	olderThanHours := policy.OlderThanHours
+	if olderThanHours <= 0 {
+		olderThanHours = common.DefaultPurgeUploadOlderThanHours
+	}
+	para := map[string]interface{}{
+		common.PurgeUploadOlderThanHours: olderThanHours,
+		common.PurgeUploadDryRun:         policy.DryRun,
+	}
+
+	execID, err := c.exeMgr.Create(ctx, VendorType, -1, trigger, para)
+	if err != nil {
+		return -1, err
+	}
+	_, err = c.taskMgr.Create(ctx, execID, &task.Job{
+		Name: job.PurgeUpload,
+		Metadata: &job.Metadata{
+			JobKind: job.KindGeneric,
+		},
+		Parameters: para,
+	})
+	if err != nil {
+		return -1, err
+	}
+	return execID, nil
+}
+
+// NewController ...
+func NewController() Controller {
+	return &controller{
+		taskMgr: task.NewManager(),
+		exeMgr:  task.NewExecutionManager(),
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purgeupload
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/task"
+	testingTask "github.com/goharbor/harbor/src/testing/pkg/task"
+)
+
+type controllerTestSuite struct {
+	suite.Suite
+	taskMgr *testingTask.Manager
+	exeMgr  *testingTask.ExecutionManager
+	ctl     Controller
+}
+
+func (c *controllerTestSuite) SetupTest() {
+	c.taskMgr = &testingTask.Manager{}
+	c.exeMgr = &testingTask.ExecutionManager{}
+	c.ctl = &controller{
+		taskMgr: c.taskMgr,
+		exeMgr:  c.exeMgr,
+	}
+}
+
+func (c *controllerTestSuite) TestStart() {
+	para := map[string]interface{}{
+		common.PurgeUploadOlderThanHours: common.DefaultPurgeUploadOlderThanHours,
+		common.PurgeUploadDryRun:         true,
+	}
+	c.exeMgr.On("Create", mock.Anything, VendorType, int64(-1), task.ExecutionTriggerManual, para).Return(int64(1), nil)
+	c.taskMgr.On("Create", mock.Anything, int64(1), mock.MatchedBy(func(j *task.Job) bool {
+		return j.Name == job.PurgeUpload && j.Parameters[common.PurgeUploadOlderThanHours] == common.DefaultPurgeUploadOlderThanHours
+	})).Return(int64(1), nil)
+
+	id, err := c.ctl.Start(context.TODO(), JobPolicy{DryRun: true}, task.ExecutionTriggerManual)
+	c.Require().Nil(err)
+	c.Equal(int64(1), id)
+	c.exeMgr.AssertExpectations(c.T())
+	c.taskMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestStop() {
+	c.exeMgr.On("Stop", mock.Anything, int64(1)).Return(nil)
+	c.Nil(c.ctl.Stop(context.TODO(), 1))
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &controllerTestSuite{})
+}
@@ -0,0 +1,28 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purgeupload
+
+// JobPolicy defines the upload purge job policy
+type JobPolicy struct {
+	DryRun         bool                   `json:"dryrun"`
+	OlderThanHours int                    `json:"older_than_hours"`
+	ExtraAttrs     map[string]interface{} `json:"extra_attrs"`
+}
+
+// Result is the result of the upload purge checked in by the job
+type Result struct {
+	Purged         int   `json:"purged"`
+	ReclaimedBytes int64 `json:"reclaimed_bytes"`
+}
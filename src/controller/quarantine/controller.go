@@ -0,0 +1,241 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quarantine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/scan"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg"
+	"github.com/goharbor/harbor/src/pkg/accessory"
+	accmodel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	pkgart "github.com/goharbor/harbor/src/pkg/artifact"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/quarantine"
+	"github.com/goharbor/harbor/src/pkg/quarantine/model"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	"github.com/goharbor/harbor/src/pkg/signature/cosign"
+)
+
+// Ctl is a global variable for the default quarantine controller implementation
+var Ctl = NewController()
+
+// Controller quarantines the newly pushed artifacts of the projects enabling the quarantine, the quarantined
+// artifacts cannot be pulled except by the admins until they pass the scan and signature policies of the
+// project, and then they are released automatically
+type Controller interface {
+	// Quarantine the newly pushed artifact if the quarantine of its project is enabled and the artifact
+	// doesn't pass the scan and signature policies, the accessories are never quarantined
+	Quarantine(ctx context.Context, art *artifact.Artifact) error
+	// Evaluate checks the quarantined artifact against the policies again and releases it if they pass,
+	// returns whether the artifact is released
+	Evaluate(ctx context.Context, artifactID int64) (bool, error)
+	// EvaluateRepository evaluates all the quarantined artifacts of the repository
+	EvaluateRepository(ctx context.Context, repository string) error
+	// Release the artifact from the quarantine manually whether it passes the policies or not
+	Release(ctx context.Context, artifactID int64) error
+	// Get the quarantine of the artifact, the not found error is returned if the artifact isn't quarantined
+	Get(ctx context.Context, artifactID int64) (*model.Quarantine, error)
+}
+
+// NewController creates an instance of the default quarantine controller
+func NewController() Controller {
+	return &controller{
+		mgr:         quarantine.Mgr,
+		artCtl:      artifact.Ctl,
+		artMgr:      pkg.ArtifactMgr,
+		proCtl:      project.Ctl,
+		scanCtl:     scan.DefaultController,
+		scanChecker: scan.NewChecker,
+		accMgr:      accessory.Mgr,
+		verifier:    cosign.Verifier,
+	}
+}
+
+type controller struct {
+	mgr         quarantine.Manager
+	artCtl      artifact.Controller
+	artMgr      pkgart.Manager
+	proCtl      project.Controller
+	scanCtl     scan.Controller
+	scanChecker func() scan.Checker
+	accMgr      accessory.Manager
+	verifier    cosign.SignatureVerifier
+}
+
+func (c *controller) Quarantine(ctx context.Context, art *artifact.Artifact) error {
+	p, err := c.proCtl.Get(ctx, art.ProjectID, project.WithEffectCVEAllowlist())
+	if err != nil {
+		return err
+	}
+	if !p.QuarantineEnabled() {
+		return nil
+	}
+	// the accessories are checked along with their subject artifacts, quarantining them
+	// would block the clients verifying the signatures of the subject artifacts
+	n, err := c.accMgr.Count(ctx, q.New(q.KeyWords{"ArtifactID": art.ID}))
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	reason, err := c.check(ctx, p, art)
+	if err != nil {
+		return err
+	}
+	if len(reason) == 0 {
+		return nil
+	}
+	if _, err = c.mgr.Quarantine(ctx, &model.Quarantine{
+		ArtifactID:     art.ID,
+		ProjectID:      art.ProjectID,
+		RepositoryName: art.RepositoryName,
+		Digest:         art.Digest,
+		Reason:         reason,
+	}); err != nil && !errors.IsConflictErr(err) {
+		return err
+	}
+	log.G(ctx).Infof("the artifact %s@%s is quarantined: %s", art.RepositoryName, art.Digest, reason)
+	return nil
+}
+
+func (c *controller) Evaluate(ctx context.Context, artifactID int64) (bool, error) {
+	qt, err := c.mgr.Get(ctx, artifactID)
+	if err != nil {
+		return false, err
+	}
+	p, err := c.proCtl.Get(ctx, qt.ProjectID, project.WithEffectCVEAllowlist())
+	if err != nil {
+		return false, err
+	}
+	art, err := c.artCtl.Get(ctx, artifactID, &artifact.Option{WithAccessory: true})
+	if err != nil {
+		return false, err
+	}
+	reason := ""
+	// the project may disable the quarantine after the artifact is quarantined, release it directly then
+	if p.QuarantineEnabled() {
+		if reason, err = c.check(ctx, p, art); err != nil {
+			return false, err
+		}
+	}
+	if len(reason) > 0 {
+		return false, c.mgr.UpdateReason(ctx, artifactID, reason)
+	}
+	if err = c.Release(ctx, artifactID); err != nil {
+		return false, err
+	}
+	log.G(ctx).Infof("the artifact %s@%s passes the policies and is released from the quarantine", art.RepositoryName, art.Digest)
+	return true, nil
+}
+
+func (c *controller) EvaluateRepository(ctx context.Context, repository string) error {
+	quarantines, err := c.mgr.List(ctx, q.New(q.KeyWords{"RepositoryName": repository}))
+	if err != nil {
+		return err
+	}
+	var errs errors.Errors
+	for _, qt := range quarantines {
+		if _, err = c.Evaluate(ctx, qt.ArtifactID); err != nil && !errors.IsNotFoundErr(err) {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (c *controller) Release(ctx context.Context, artifactID int64) error {
+	if err := c.mgr.Release(ctx, artifactID); err != nil {
+		return err
+	}
+	// the children of the index are pulled along with it, so they are released together
+	references, err := c.artMgr.ListReferences(ctx, q.New(q.KeyWords{"ParentID": artifactID}))
+	if err != nil {
+		return err
+	}
+	for _, reference := range references {
+		if err = c.mgr.Release(ctx, reference.ChildID); err != nil && !errors.IsNotFoundErr(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *controller) Get(ctx context.Context, artifactID int64) (*model.Quarantine, error) {
+	return c.mgr.Get(ctx, artifactID)
+}
+
+// check returns the reason why the artifact doesn't pass the scan and signature policies of the project,
+// the empty reason means it passes them
+func (c *controller) check(ctx context.Context, p *proModels.Project, art *artifact.Artifact) (string, error) {
+	scannable, err := c.scanChecker().IsScannable(ctx, art)
+	if err != nil {
+		return "", err
+	}
+	if scannable {
+		vulnerable, err := c.scanCtl.GetVulnerable(ctx, art, p.CVEAllowlist.CVESet())
+		if err != nil {
+			if !errors.IsNotFoundErr(err) {
+				return "", err
+			}
+			return "the artifact isn't scanned", nil
+		}
+		if !vulnerable.IsScanSuccess() {
+			return fmt.Sprintf("the scanning of the artifact is %s", vulnerable.ScanStatus), nil
+		}
+		if p.VulPrevented() {
+			severity := vuln.ParseSeverityVersion3(p.Severity())
+			if vulnerable.Severity != nil && vulnerable.Severity.Code() >= severity.Code() {
+				return fmt.Sprintf("the artifact has %d vulnerabilities with the severity of %q or higher",
+					vulnerable.VulnerabilitiesCount, severity), nil
+			}
+		}
+	}
+
+	if p.ContentTrustCosignEnabled() {
+		var signatures []string
+		for _, acc := range art.Accessories {
+			if acc.GetData().Type == accmodel.TypeCosignSignature {
+				signatures = append(signatures, acc.GetData().Digest)
+			}
+		}
+		if len(signatures) == 0 {
+			return "the artifact isn't signed in Cosign", nil
+		}
+		if trustedKeys := p.CosignTrustedKeys(); len(trustedKeys) > 0 {
+			keys, err := cosign.ParsePublicKeys(trustedKeys)
+			if err != nil {
+				return fmt.Sprintf("the trusted keys of Cosign are invalid: %v", err), nil
+			}
+			for _, signature := range signatures {
+				if err = c.verifier.Verify(ctx, art.RepositoryName, art.Digest, signature, keys); err == nil {
+					return "", nil
+				}
+				log.G(ctx).Debugf("failed to verify the signature %s of %s@%s: %v", signature, art.RepositoryName, art.Digest, err)
+			}
+			return "the artifact isn't signed by the trusted keys in Cosign", nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,163 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quarantine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	scanctl "github.com/goharbor/harbor/src/controller/scan"
+	accmodel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/pkg/accessory/model/cosign"
+	pkgart "github.com/goharbor/harbor/src/pkg/artifact"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/quarantine/model"
+	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	scantesting "github.com/goharbor/harbor/src/testing/controller/scan"
+	"github.com/goharbor/harbor/src/testing/mock"
+	accessorytesting "github.com/goharbor/harbor/src/testing/pkg/accessory"
+	arttesting "github.com/goharbor/harbor/src/testing/pkg/artifact"
+	quarantinetesting "github.com/goharbor/harbor/src/testing/pkg/quarantine"
+	cosigntesting "github.com/goharbor/harbor/src/testing/pkg/signature/cosign"
+)
+
+type controllerTestSuite struct {
+	suite.Suite
+	ctl      *controller
+	mgr      *quarantinetesting.Manager
+	artCtl   *artifacttesting.Controller
+	artMgr   *arttesting.Manager
+	proCtl   *projecttesting.Controller
+	scanCtl  *scantesting.Controller
+	checker  *scantesting.Checker
+	accMgr   *accessorytesting.Manager
+	verifier *cosigntesting.SignatureVerifier
+	art      *artifact.Artifact
+}
+
+func (c *controllerTestSuite) SetupTest() {
+	c.mgr = &quarantinetesting.Manager{}
+	c.artCtl = &artifacttesting.Controller{}
+	c.artMgr = &arttesting.Manager{}
+	c.proCtl = &projecttesting.Controller{}
+	c.scanCtl = &scantesting.Controller{}
+	c.checker = &scantesting.Checker{}
+	c.accMgr = &accessorytesting.Manager{}
+	c.verifier = &cosigntesting.SignatureVerifier{}
+	c.ctl = &controller{
+		mgr:         c.mgr,
+		artCtl:      c.artCtl,
+		artMgr:      c.artMgr,
+		proCtl:      c.proCtl,
+		scanCtl:     c.scanCtl,
+		scanChecker: func() scanctl.Checker { return c.checker },
+		accMgr:      c.accMgr,
+		verifier:    c.verifier,
+	}
+	c.art = &artifact.Artifact{
+		Artifact: pkgart.Artifact{ID: 1, ProjectID: 1, RepositoryName: "library/hello-world", Digest: "sha256:123"},
+	}
+}
+
+func (c *controllerTestSuite) mockProject(metadata map[string]string) {
+	mock.OnAnything(c.proCtl, "Get").Return(&proModels.Project{ProjectID: 1, Name: "library", Metadata: metadata}, nil)
+}
+
+func (c *controllerTestSuite) TestQuarantineDisabled() {
+	c.mockProject(nil)
+	c.Require().Nil(c.ctl.Quarantine(context.Background(), c.art))
+	c.mgr.AssertNotCalled(c.T(), "Quarantine", mock.Anything, mock.Anything)
+}
+
+func (c *controllerTestSuite) TestQuarantineAccessory() {
+	c.mockProject(map[string]string{proModels.ProMetaQuarantine: "true"})
+	mock.OnAnything(c.accMgr, "Count").Return(int64(1), nil)
+	c.Require().Nil(c.ctl.Quarantine(context.Background(), c.art))
+	c.mgr.AssertNotCalled(c.T(), "Quarantine", mock.Anything, mock.Anything)
+}
+
+func (c *controllerTestSuite) TestQuarantine() {
+	c.mockProject(map[string]string{proModels.ProMetaQuarantine: "true"})
+	mock.OnAnything(c.accMgr, "Count").Return(int64(0), nil)
+	mock.OnAnything(c.checker, "IsScannable").Return(true, nil)
+	mock.OnAnything(c.scanCtl, "GetVulnerable").Return(&scanctl.Vulnerable{ScanStatus: "Running"}, nil)
+	mock.OnAnything(c.mgr, "Quarantine").Return(int64(1), nil)
+	c.Require().Nil(c.ctl.Quarantine(context.Background(), c.art))
+	qt := c.mgr.Calls[0].Arguments.Get(1).(*model.Quarantine)
+	c.Equal(int64(1), qt.ArtifactID)
+	c.Equal("the scanning of the artifact is Running", qt.Reason)
+}
+
+func (c *controllerTestSuite) TestQuarantinePassed() {
+	c.mockProject(map[string]string{proModels.ProMetaQuarantine: "true"})
+	mock.OnAnything(c.accMgr, "Count").Return(int64(0), nil)
+	mock.OnAnything(c.checker, "IsScannable").Return(false, nil)
+	c.Require().Nil(c.ctl.Quarantine(context.Background(), c.art))
+	c.mgr.AssertNotCalled(c.T(), "Quarantine", mock.Anything, mock.Anything)
+}
+
+func (c *controllerTestSuite) TestEvaluate() {
+	c.mockProject(map[string]string{
+		proModels.ProMetaQuarantine:               "true",
+		proModels.ProMetaEnableContentTrustCosign: "true",
+	})
+	c.mgr.On("Get", mock.Anything, int64(1)).Return(&model.Quarantine{ArtifactID: 1, ProjectID: 1}, nil)
+	mock.OnAnything(c.checker, "IsScannable").Return(false, nil)
+
+	// not signed
+	c.artCtl.On("Get", mock.Anything, int64(1), mock.Anything).Return(c.art, nil).Once()
+	c.mgr.On("UpdateReason", mock.Anything, int64(1), "the artifact isn't signed in Cosign").Return(nil).Once()
+	released, err := c.ctl.Evaluate(context.Background(), 1)
+	c.Require().Nil(err)
+	c.False(released)
+
+	// signed, the artifact and its children are released
+	signed := *c.art
+	signed.Accessories = []accmodel.Accessory{cosign.New(accmodel.AccessoryData{Type: accmodel.TypeCosignSignature, Digest: "sha256:456"})}
+	c.artCtl.On("Get", mock.Anything, int64(1), mock.Anything).Return(&signed, nil).Once()
+	c.mgr.On("Release", mock.Anything, int64(1)).Return(nil).Once()
+	mock.OnAnything(c.artMgr, "ListReferences").Return([]*pkgart.Reference{{ParentID: 1, ChildID: 2}}, nil)
+	c.mgr.On("Release", mock.Anything, int64(2)).Return(nil).Once()
+	released, err = c.ctl.Evaluate(context.Background(), 1)
+	c.Require().Nil(err)
+	c.True(released)
+	c.mgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestEvaluateTrustedKeys() {
+	c.mockProject(map[string]string{
+		proModels.ProMetaQuarantine:               "true",
+		proModels.ProMetaEnableContentTrustCosign: "true",
+		proModels.ProMetaCosignTrustedKeys:        "invalid",
+	})
+	c.mgr.On("Get", mock.Anything, int64(1)).Return(&model.Quarantine{ArtifactID: 1, ProjectID: 1}, nil)
+	mock.OnAnything(c.checker, "IsScannable").Return(false, nil)
+	signed := *c.art
+	signed.Accessories = []accmodel.Accessory{cosign.New(accmodel.AccessoryData{Type: accmodel.TypeCosignSignature, Digest: "sha256:456"})}
+	c.artCtl.On("Get", mock.Anything, int64(1), mock.Anything).Return(&signed, nil)
+	mock.OnAnything(c.mgr, "UpdateReason").Return(nil)
+	released, err := c.ctl.Evaluate(context.Background(), 1)
+	c.Require().Nil(err)
+	c.False(released)
+	c.verifier.AssertNotCalled(c.T(), "Verify", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestController(t *testing.T) {
+	suite.Run(t, &controllerTestSuite{})
+}
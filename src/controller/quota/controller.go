@@ -23,6 +23,7 @@ import (
 	_ "github.com/goharbor/harbor/src/controller/quota/driver"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/metric"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/lib/retry"
@@ -33,6 +34,8 @@ import (
 
 var (
 	defaultRetryTimeout = time.Minute * 5
+	// the page size to list the quotas when applying the default hard limits
+	applyDefaultsPageSize int64 = 100
 )
 
 var (
@@ -42,6 +45,11 @@ var (
 
 // Controller defines the operations related with quotas
 type Controller interface {
+	// ApplyDefaults updates the hard limits of the quotas of the reference objects which still equal the previous
+	// default ones to the current default ones per resource, and returns the count of the quotas updated.
+	// The quotas are only counted without being updated when dryRun is true
+	ApplyDefaults(ctx context.Context, reference string, previous types.ResourceList, dryRun bool) (int64, error)
+
 	// Count returns the total count of quotas according to the query.
 	Count(ctx context.Context, query *q.Query) (int64, error)
 
@@ -89,6 +97,63 @@ type controller struct {
 	quotaMgr quota.Manager
 }
 
+func (c *controller) ApplyDefaults(ctx context.Context, reference string, previous types.ResourceList, dryRun bool) (int64, error) {
+	d, err := Driver(ctx, reference)
+	if err != nil {
+		return 0, err
+	}
+	for resource := range previous {
+		if !types.IsValidResource(resource) {
+			return 0, errors.BadRequestError(nil).WithMessage("resource %s not support", resource)
+		}
+	}
+	defaults := d.HardLimits(ctx)
+
+	var updated int64
+	query := &q.Query{
+		Keywords:   q.KeyWords{"reference": reference},
+		PageNumber: 1,
+		PageSize:   applyDefaultsPageSize,
+		Sorts:      []*q.Sort{q.NewSort("id", false)},
+	}
+	for {
+		quotas, err := c.quotaMgr.List(ctx, query)
+		if err != nil {
+			return updated, err
+		}
+		for _, qt := range quotas {
+			hard, err := qt.GetHard()
+			if err != nil {
+				log.G(ctx).Warningf("failed to get the hard limits of the quota %d, error: %v", qt.ID, err)
+				continue
+			}
+			changed := false
+			for resource, value := range previous {
+				current, found := hard[resource]
+				if def, ok := defaults[resource]; ok && found && current == value && current != def {
+					hard[resource] = def
+					changed = true
+				}
+			}
+			if !changed {
+				continue
+			}
+			updated++
+			if dryRun {
+				continue
+			}
+			qt.SetHard(hard)
+			if err := c.Update(ctx, qt); err != nil {
+				return updated - 1, err
+			}
+		}
+		if int64(len(quotas)) < query.PageSize {
+			return updated, nil
+		}
+		query.PageNumber++
+	}
+}
+
 func (c *controller) Count(ctx context.Context, query *q.Query) (int64, error) {
 	return c.quotaMgr.Count(ctx, query)
 }
@@ -308,13 +373,44 @@ func reserveResources(resources types.ResourceList) func(hardLimits, used types.
 		newUsed := types.Add(used, resources)
 
 		if err := quota.IsSafe(hardLimits, used, newUsed, false); err != nil {
-			return nil, errors.DeniedError(err).WithMessage("Quota exceeded when processing the request of %v", err)
+			details := quotaExceededDetails(err)
+			for _, detail := range details {
+				metric.QuotaRejectedReqCnt.WithLabelValues(fmt.Sprint(detail.Data["resource"])).Inc()
+			}
+			return nil, errors.DeniedError(err).WithMessage("Quota exceeded when processing the request of %v", err).
+				WithDetails(details...)
 		}
 
 		return newUsed, nil
 	}
 }
 
+// quotaExceededDetails converts the resource overflow errors to the details of the error returned to the client
+func quotaExceededDetails(err error) []*errors.Detail {
+	errs, ok := err.(quota.Errors)
+	if !ok {
+		errs = quota.Errors{err}
+	}
+
+	var details []*errors.Detail
+	for _, e := range errs {
+		overflow, ok := e.(*quota.ResourceOverflow)
+		if !ok {
+			continue
+		}
+		details = append(details, &errors.Detail{
+			Reason: errors.ReasonQuotaExceeded,
+			Data: map[string]interface{}{
+				"resource":  overflow.Resource,
+				"hard":      overflow.HardLimit,
+				"used":      overflow.CurrentUsed,
+				"requested": overflow.NewUsed - overflow.CurrentUsed,
+			},
+		})
+	}
+	return details
+}
+
 func rollbackResources(resources types.ResourceList) func(hardLimits, used types.ResourceList) (types.ResourceList, error) {
 	return func(hardLimits, used types.ResourceList) (types.ResourceList, error) {
 		newUsed := types.Subtract(used, resources)
@@ -22,6 +22,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/pkg/quota"
 	"github.com/goharbor/harbor/src/pkg/quota/driver"
@@ -130,7 +131,16 @@ func (suite *ControllerTestSuite) TestRequestExceed() {
 	referenceID := uuid.New().String()
 	resources := types.ResourceList{types.ResourceStorage: 101}
 
-	suite.Error(suite.ctl.Request(ctx, suite.reference, referenceID, resources, func() error { return nil }))
+	err := suite.ctl.Request(ctx, suite.reference, referenceID, resources, func() error { return nil })
+	suite.Error(err)
+	suite.True(errors.IsErr(err, errors.DENIED))
+	details := errors.ErrDetails(err)
+	if suite.Len(details, 1) {
+		suite.Equal(errors.ReasonQuotaExceeded, details[0].Reason)
+		suite.Equal(types.ResourceStorage, details[0].Data["resource"])
+		suite.Equal(int64(100), details[0].Data["hard"])
+		suite.Equal(int64(101), details[0].Data["requested"])
+	}
 }
 
 func (suite *ControllerTestSuite) TestRequestFunctionFailed() {
@@ -143,6 +153,59 @@ func (suite *ControllerTestSuite) TestRequestFunctionFailed() {
 	suite.Error(suite.ctl.Request(ctx, suite.reference, referenceID, resources, func() error { return fmt.Errorf("error") }))
 }
 
+func (suite *ControllerTestSuite) TestApplyDefaults() {
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+	newQuota := func(id int64, hard types.ResourceList) *quota.Quota {
+		return &quota.Quota{ID: id, Reference: suite.reference, ReferenceID: fmt.Sprint(id), Hard: hard.String()}
+	}
+	quotas := func() []*quota.Quota {
+		return []*quota.Quota{
+			newQuota(1, types.ResourceList{types.ResourceStorage: 100, types.ResourceCount: types.UNLIMITED}),
+			newQuota(2, types.ResourceList{types.ResourceStorage: 200, types.ResourceCount: types.UNLIMITED}),
+			newQuota(3, types.ResourceList{types.ResourceStorage: 100, types.ResourceCount: 10}),
+			newQuota(4, types.ResourceList{types.ResourceStorage: 200, types.ResourceCount: 10}),
+		}
+	}
+	mock.OnAnything(suite.driver, "HardLimits").Return(types.ResourceList{types.ResourceStorage: 500, types.ResourceCount: 50})
+	previous := types.ResourceList{types.ResourceStorage: 100, types.ResourceCount: types.UNLIMITED}
+
+	{
+		// dry run
+		mock.OnAnything(suite.quotaMgr, "List").Return(quotas(), nil).Once()
+
+		updated, err := suite.ctl.ApplyDefaults(ctx, suite.reference, previous, true)
+		suite.Nil(err)
+		suite.Equal(int64(3), updated)
+		suite.quotaMgr.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything)
+	}
+
+	{
+		qs := quotas()
+		mock.OnAnything(suite.quotaMgr, "List").Return(qs, nil).Once()
+		for _, qt := range qs[:3] {
+			suite.quotaMgr.On("GetByRef", mock.Anything, suite.reference, qt.ReferenceID).Return(qt, nil).Once()
+		}
+		mock.OnAnything(suite.quotaMgr, "Update").Return(nil).Times(3)
+
+		updated, err := suite.ctl.ApplyDefaults(ctx, suite.reference, previous, false)
+		suite.Nil(err)
+		suite.Equal(int64(3), updated)
+		hard, _ := qs[0].GetHard()
+		suite.Equal(types.ResourceList{types.ResourceStorage: 500, types.ResourceCount: 50}, hard)
+		hard, _ = qs[1].GetHard()
+		suite.Equal(types.ResourceList{types.ResourceStorage: 200, types.ResourceCount: 50}, hard)
+		hard, _ = qs[2].GetHard()
+		suite.Equal(types.ResourceList{types.ResourceStorage: 500, types.ResourceCount: 10}, hard)
+		suite.quotaMgr.AssertExpectations(suite.T())
+	}
+
+	{
+		// invalid resource
+		_, err := suite.ctl.ApplyDefaults(ctx, suite.reference, types.ResourceList{"unknown": 1}, false)
+		suite.True(errors.IsErr(err, errors.BadRequestCode))
+	}
+}
+
 func TestControllerTestSuite(t *testing.T) {
 	suite.Run(t, &ControllerTestSuite{})
 }
@@ -22,9 +22,11 @@ import (
 	"github.com/graph-gophers/dataloader"
 
 	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/controller/blob"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/config/db"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	dr "github.com/goharbor/harbor/src/pkg/quota/driver"
@@ -39,7 +41,8 @@ type driver struct {
 	cfg    config.Manager
 	loader *dataloader.Loader
 
-	blobCtl blob.Controller
+	artifactCtl artifact.Controller
+	blobCtl     blob.Controller
 }
 
 func (d *driver) Enabled(ctx context.Context, key string) (bool, error) {
@@ -58,6 +61,7 @@ func (d *driver) HardLimits(ctx context.Context) types.ResourceList {
 
 	return types.ResourceList{
 		types.ResourceStorage: d.cfg.Get(ctx, common.StoragePerProject).GetInt64(),
+		types.ResourceCount:   d.cfg.Get(ctx, common.CountPerProject).GetInt64(),
 	}
 }
 
@@ -82,12 +86,14 @@ func (d *driver) Load(ctx context.Context, key string) (dr.RefObject, error) {
 }
 
 func (d *driver) Validate(hardLimits types.ResourceList) error {
+	// the value indicates whether the resource is required, the count is optional for the quotas created before it's supported
 	resources := map[types.ResourceName]bool{
 		types.ResourceStorage: true,
+		types.ResourceCount:   false,
 	}
 
 	for resource, value := range hardLimits {
-		if !resources[resource] {
+		if _, ok := resources[resource]; !ok {
 			return fmt.Errorf("resource %s not support", resource)
 		}
 
@@ -96,8 +102,8 @@ func (d *driver) Validate(hardLimits types.ResourceList) error {
 		}
 	}
 
-	for resource := range resources {
-		if _, found := hardLimits[resource]; !found {
+	for resource, required := range resources {
+		if _, found := hardLimits[resource]; required && !found {
 			return fmt.Errorf("resource %s not found", resource)
 		}
 	}
@@ -116,7 +122,13 @@ func (d *driver) CalculateUsage(ctx context.Context, key string) (types.Resource
 		return nil, err
 	}
 
-	return types.ResourceList{types.ResourceStorage: size}, nil
+	// all the artifacts including the children of the indexes and the accessories are counted
+	count, err := d.artifactCtl.Count(ctx, q.New(q.KeyWords{"ProjectID": projectID, "base": "*"}))
+	if err != nil {
+		return nil, err
+	}
+
+	return types.ResourceList{types.ResourceStorage: size, types.ResourceCount: count}, nil
 }
 
 func newDriver() dr.Driver {
@@ -125,8 +137,9 @@ func newDriver() dr.Driver {
 	loader := dataloader.NewBatchedLoader(getProjectsBatchFn, dataloader.WithClearCacheOnBatch())
 
 	return &driver{
-		cfg:     cfg,
-		loader:  loader,
-		blobCtl: blob.Ctl,
+		cfg:         cfg,
+		loader:      loader,
+		artifactCtl: artifact.Ctl,
+		blobCtl:     blob.Ctl,
 	}
 }
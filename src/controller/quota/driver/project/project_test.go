@@ -40,7 +40,8 @@ func (suite *DriverTestSuite) SetupTest() {
 	suite.blobCtl = &blobtesting.Controller{}
 
 	suite.d = &driver{
-		blobCtl: suite.blobCtl,
+		artifactCtl: suite.artifactCtl,
+		blobCtl:     suite.blobCtl,
 	}
 }
 
@@ -48,15 +49,25 @@ func (suite *DriverTestSuite) TestCalculateUsage() {
 
 	{
 		mock.OnAnything(suite.blobCtl, "CalculateTotalSizeByProject").Return(int64(1000), nil).Once()
+		mock.OnAnything(suite.artifactCtl, "Count").Return(int64(10), nil).Once()
 
 		resources, err := suite.d.CalculateUsage(context.TODO(), "1")
 		if suite.Nil(err) {
-			suite.Len(resources, 1)
+			suite.Len(resources, 2)
 			suite.Equal(resources[types.ResourceStorage], int64(1000))
+			suite.Equal(resources[types.ResourceCount], int64(10))
 		}
 	}
 }
 
+func (suite *DriverTestSuite) TestValidate() {
+	suite.Nil(suite.d.Validate(types.ResourceList{types.ResourceStorage: 100}))
+	suite.Nil(suite.d.Validate(types.ResourceList{types.ResourceStorage: types.UNLIMITED, types.ResourceCount: 10}))
+	suite.NotNil(suite.d.Validate(types.ResourceList{types.ResourceCount: 10}))
+	suite.NotNil(suite.d.Validate(types.ResourceList{types.ResourceStorage: 100, types.ResourceCount: 0}))
+	suite.NotNil(suite.d.Validate(types.ResourceList{types.ResourceStorage: 100, "unknown": 1}))
+}
+
 func TestDriverTestSuite(t *testing.T) {
 	suite.Run(t, &DriverTestSuite{})
 }
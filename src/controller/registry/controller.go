@@ -19,13 +19,18 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/goharbor/harbor/src/common/rbac"
+	event "github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/controller/event/operator"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg"
+	"github.com/goharbor/harbor/src/pkg/notification"
 	"github.com/goharbor/harbor/src/pkg/project"
 	"github.com/goharbor/harbor/src/pkg/reg"
+	adp "github.com/goharbor/harbor/src/pkg/reg/adapter"
 	"github.com/goharbor/harbor/src/pkg/reg/model"
 	"github.com/goharbor/harbor/src/pkg/replication"
 )
@@ -56,6 +61,14 @@ type Controller interface {
 	ListRegistryProviderTypes(ctx context.Context) (types []string, err error)
 	// ListRegistryProviderInfos returns all the registered registry provider information
 	ListRegistryProviderInfos(ctx context.Context) (infos map[string]*model.AdapterPattern, err error)
+	// ListRegistryProviderCapabilities returns the capabilities declared by the registered registry providers
+	ListRegistryProviderCapabilities(ctx context.Context) (capabilities []*model.RegistryInfo, err error)
+	// Peer exchanges the certificates with the Harbor registry and negotiates the capabilities of the peering
+	Peer(ctx context.Context, id int64, peering *model.Peering) (result *model.Peering, err error)
+	// Unpeer removes the peering with the Harbor registry
+	Unpeer(ctx context.Context, id int64) (err error)
+	// GetPeeringInfo returns the API version and capabilities that the local Harbor offers to its peers
+	GetPeeringInfo(ctx context.Context) (info *model.PeeringInfo, err error)
 	// StartRegularHealthCheck for all registries
 	StartRegularHealthCheck(ctx context.Context, closing, done chan struct{})
 }
@@ -79,7 +92,12 @@ func (c *controller) Create(ctx context.Context, registry *model.Registry) (int6
 	if err := c.validate(ctx, registry); err != nil {
 		return 0, err
 	}
-	return c.regMgr.Create(ctx, registry)
+	id, err := c.regMgr.Create(ctx, registry)
+	if err != nil {
+		return 0, err
+	}
+	c.auditChange(ctx, registry.Name, rbac.ActionCreate, nil, registry)
+	return id, nil
 }
 
 func (c *controller) validate(ctx context.Context, registry *model.Registry) error {
@@ -94,6 +112,11 @@ func (c *controller) validate(ctx context.Context, registry *model.Registry) err
 		return err
 	}
 	registry.URL = url
+	if registry.Storage != nil {
+		if err = registry.Storage.Validate(); err != nil {
+			return errors.New(err).WithCode(errors.BadRequestCode).WithMessage("invalid storage: %v", err)
+		}
+	}
 
 	healthy, err := c.IsHealthy(ctx, registry)
 	if err != nil {
@@ -122,7 +145,15 @@ func (c *controller) Update(ctx context.Context, registry *model.Registry, props
 	if err := c.validate(ctx, registry); err != nil {
 		return err
 	}
-	return c.regMgr.Update(ctx, registry, props...)
+	before, err := c.regMgr.Get(ctx, registry.ID)
+	if err != nil {
+		return err
+	}
+	if err = c.regMgr.Update(ctx, registry, props...); err != nil {
+		return err
+	}
+	c.auditChange(ctx, registry.Name, rbac.ActionUpdate, before, registry)
+	return nil
 }
 
 func (c *controller) Delete(ctx context.Context, id int64) error {
@@ -163,7 +194,27 @@ func (c *controller) Delete(ctx context.Context, id int64) error {
 		return errors.New(nil).WithCode(errors.PreconditionCode).WithMessage("the registry %d is referenced by proxy cache project, cannot delete it", id)
 	}
 
-	return c.regMgr.Delete(ctx, id)
+	before, err := c.regMgr.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = c.regMgr.Delete(ctx, id); err != nil {
+		return err
+	}
+	c.auditChange(ctx, before.Name, rbac.ActionDelete, before, nil)
+	return nil
+}
+
+// auditChange records the change of the registry with the snapshots before and after the change
+func (c *controller) auditChange(ctx context.Context, name string, action rbac.Action, before, after *model.Registry) {
+	notification.AddEvent(ctx, &event.ResourceChangeEventMetadata{
+		ResourceType: event.ResourceTypeRegistry,
+		Resource:     name,
+		Operation:    action.String(),
+		Operator:     operator.FromContext(ctx),
+		Before:       before,
+		After:        after,
+	})
 }
 
 func (c *controller) IsHealthy(ctx context.Context, registry *model.Registry) (bool, error) {
@@ -213,6 +264,81 @@ func (c *controller) ListRegistryProviderInfos(ctx context.Context) (map[string]
 	return c.regMgr.ListRegistryProviderInfos(ctx)
 }
 
+func (c *controller) ListRegistryProviderCapabilities(ctx context.Context) ([]*model.RegistryInfo, error) {
+	return c.regMgr.ListRegistryProviderCapabilities(ctx)
+}
+
+func (c *controller) Peer(ctx context.Context, id int64, peering *model.Peering) (*model.Peering, error) {
+	registry, err := c.regMgr.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if registry.Type != model.RegistryTypeHarbor {
+		return nil, errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("only the Harbor registry can be peered, the type of registry %d is %s", id, registry.Type)
+	}
+	if err = peering.Validate(); err != nil {
+		return nil, errors.New(err).WithCode(errors.BadRequestCode).WithMessage("invalid peering: %v", err)
+	}
+	return c.peer(ctx, registry, peering)
+}
+
+// peer negotiates the API version and capabilities with the registry through the mutual TLS channel
+func (c *controller) peer(ctx context.Context, registry *model.Registry, peering *model.Peering) (*model.Peering, error) {
+	before := *registry
+	// clear the capabilities negotiated before to make sure the API version is negotiated again
+	// through the mutual TLS channel
+	registry.Peering = &model.Peering{
+		CACertificate:     peering.CACertificate,
+		ClientCertificate: peering.ClientCertificate,
+		ClientKey:         peering.ClientKey,
+	}
+	adapter, err := c.regMgr.CreateAdapter(ctx, registry)
+	if err != nil {
+		return nil, errors.New(err).WithCode(errors.PreconditionCode).WithMessage("failed to connect to the peer: %v", err)
+	}
+	negotiator, ok := adapter.(adp.PeeringNegotiator)
+	if !ok {
+		return nil, errors.New(nil).WithCode(errors.PreconditionCode).WithMessage("the registry %d doesn't support peering", registry.ID)
+	}
+	info, err := negotiator.GetPeeringInfo()
+	if err != nil {
+		return nil, errors.New(err).WithCode(errors.PreconditionCode).WithMessage("failed to negotiate with the peer: %v", err)
+	}
+	registry.Peering.APIVersion = info.APIVersion
+	registry.Peering.Capabilities = model.NegotiateCapabilities(model.PeeringCapabilities, info.Capabilities)
+	registry.Peering.PeeredTime = time.Now()
+	if err = c.regMgr.Update(ctx, registry, "Peering"); err != nil {
+		return nil, err
+	}
+	c.auditChange(ctx, registry.Name, rbac.ActionUpdate, &before, registry)
+	return registry.Peering, nil
+}
+
+func (c *controller) Unpeer(ctx context.Context, id int64) error {
+	registry, err := c.regMgr.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if registry.Peering == nil {
+		return errors.NotFoundError(nil).WithMessage("the registry %d isn't peered", id)
+	}
+	before := *registry
+	registry.Peering = nil
+	if err = c.regMgr.Update(ctx, registry, "Peering"); err != nil {
+		return err
+	}
+	c.auditChange(ctx, registry.Name, rbac.ActionUpdate, &before, registry)
+	return nil
+}
+
+func (c *controller) GetPeeringInfo(ctx context.Context) (*model.PeeringInfo, error) {
+	return &model.PeeringInfo{
+		APIVersion:   model.PeeringAPIVersion,
+		Capabilities: model.PeeringCapabilities,
+	}, nil
+}
+
 func (c *controller) StartRegularHealthCheck(ctx context.Context, closing, done chan struct{}) {
 	// Wait some random time before starting health checking. If Harbor is deployed in HA mode
 	// with multiple instances, this will avoid instances check health in the same time.
@@ -15,10 +15,12 @@
 package registry
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/pkg/reg/model"
 	"github.com/goharbor/harbor/src/testing/mock"
 	testingproject "github.com/goharbor/harbor/src/testing/pkg/project"
@@ -116,6 +118,17 @@ func (r *registryTestSuite) TestValidate() {
 
 	r.SetupTest()
 
+	// invalid storage
+	registry = &model.Registry{
+		Name:    "endpoint01",
+		URL:     "http://example.com",
+		Storage: &model.Storage{Type: "gcs"},
+	}
+	err = r.ctl.validate(nil, registry)
+	r.NotNil(err)
+
+	r.SetupTest()
+
 	// URL with HTTPS scheme
 	registry = &model.Registry{
 		Name: "endpoint01",
@@ -167,13 +180,105 @@ func (r *registryTestSuite) TestDelete() {
 	// pass
 	mock.OnAnything(r.repMgr, "Count").Return(int64(0), nil)
 	mock.OnAnything(r.proMgr, "Count").Return(int64(0), nil)
+	mock.OnAnything(r.regMgr, "Get").Return(&model.Registry{ID: 1, Name: "registry"}, nil)
 	mock.OnAnything(r.regMgr, "Delete").Return(nil)
-	err = r.ctl.Delete(nil, 1)
+	err = r.ctl.Delete(context.Background(), 1)
 	r.Nil(err)
 	r.repMgr.AssertExpectations(r.T())
 	r.proMgr.AssertExpectations(r.T())
 }
 
+type peeringAdapter struct {
+	*testingadapter.Adapter
+	info *model.PeeringInfo
+}
+
+func (p *peeringAdapter) GetPeeringInfo() (*model.PeeringInfo, error) {
+	return p.info, nil
+}
+
+func (r *registryTestSuite) TestPeer() {
+	peering := &model.Peering{
+		CACertificate:     "ca",
+		ClientCertificate: "cert",
+		ClientKey:         "key",
+	}
+
+	// not Harbor
+	mock.OnAnything(r.regMgr, "Get").Return(&model.Registry{ID: 1, Type: model.RegistryTypeDockerHub}, nil)
+	_, err := r.ctl.Peer(context.Background(), 1, peering)
+	r.Require().NotNil(err)
+	r.True(errors.IsErr(err, errors.BadRequestCode))
+	r.regMgr.AssertExpectations(r.T())
+
+	r.SetupTest()
+
+	// invalid certificates
+	mock.OnAnything(r.regMgr, "Get").Return(&model.Registry{ID: 1, Type: model.RegistryTypeHarbor}, nil)
+	_, err = r.ctl.Peer(context.Background(), 1, peering)
+	r.Require().NotNil(err)
+	r.True(errors.IsErr(err, errors.BadRequestCode))
+	r.regMgr.AssertExpectations(r.T())
+
+	r.SetupTest()
+
+	// the adapter doesn't support peering
+	registry := &model.Registry{ID: 1, Type: model.RegistryTypeHarbor}
+	mock.OnAnything(r.regMgr, "CreateAdapter").Return(r.adapter, nil)
+	_, err = r.ctl.peer(context.Background(), registry, peering)
+	r.Require().NotNil(err)
+	r.True(errors.IsErr(err, errors.PreconditionCode))
+
+	r.SetupTest()
+
+	// pass, only the capabilities supported by both sides are kept
+	registry = &model.Registry{ID: 1, Name: "harbor", Type: model.RegistryTypeHarbor}
+	adapter := &peeringAdapter{
+		Adapter: r.adapter,
+		info: &model.PeeringInfo{
+			APIVersion:   "v2.0",
+			Capabilities: []string{model.PeeringCapabilityLabelSync, "unknown"},
+		},
+	}
+	mock.OnAnything(r.regMgr, "CreateAdapter").Return(adapter, nil)
+	mock.OnAnything(r.regMgr, "Update").Return(nil)
+	result, err := r.ctl.peer(context.Background(), registry, peering)
+	r.Require().Nil(err)
+	r.Equal("v2.0", result.APIVersion)
+	r.Equal([]string{model.PeeringCapabilityLabelSync}, result.Capabilities)
+	r.Equal("key", result.ClientKey)
+	r.False(result.PeeredTime.IsZero())
+	r.Same(result, registry.Peering)
+	r.regMgr.AssertExpectations(r.T())
+}
+
+func (r *registryTestSuite) TestUnpeer() {
+	// not peered
+	mock.OnAnything(r.regMgr, "Get").Return(&model.Registry{ID: 1}, nil)
+	err := r.ctl.Unpeer(context.Background(), 1)
+	r.Require().NotNil(err)
+	r.True(errors.IsNotFoundErr(err))
+	r.regMgr.AssertExpectations(r.T())
+
+	r.SetupTest()
+
+	// pass
+	registry := &model.Registry{ID: 1, Peering: &model.Peering{APIVersion: "v2.0"}}
+	mock.OnAnything(r.regMgr, "Get").Return(registry, nil)
+	mock.OnAnything(r.regMgr, "Update").Return(nil)
+	err = r.ctl.Unpeer(context.Background(), 1)
+	r.Require().Nil(err)
+	r.Nil(registry.Peering)
+	r.regMgr.AssertExpectations(r.T())
+}
+
+func (r *registryTestSuite) TestGetPeeringInfo() {
+	info, err := r.ctl.GetPeeringInfo(context.Background())
+	r.Require().Nil(err)
+	r.Equal(model.PeeringAPIVersion, info.APIVersion)
+	r.Equal(model.PeeringCapabilities, info.Capabilities)
+}
+
 func TestRegistryTestSuite(t *testing.T) {
 	suite.Run(t, &registryTestSuite{})
 }
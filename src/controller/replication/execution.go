@@ -57,10 +57,18 @@ type Controller interface {
 	UpdatePolicy(ctx context.Context, policy *replicationmodel.Policy, props ...string) (err error)
 	// DeletePolicy deletes the specific policy
 	DeletePolicy(ctx context.Context, id int64) (err error)
+	// ValidatePolicy checks the syntax of the policy and whether it is compatible with the
+	// capabilities and the credentials of the involved registries without saving it
+	ValidatePolicy(ctx context.Context, policy *replicationmodel.Policy) (findings []*replicationmodel.Finding, err error)
+	// PreviewRepositoryMappings previews the destination repositories that the source repositories are replicated to with the policy
+	PreviewRepositoryMappings(ctx context.Context, policy *replicationmodel.Policy, repositories []string) (mappings []*replicationmodel.RepositoryMapping, err error)
 	// Start the replication according to the policy
 	Start(ctx context.Context, policy *replicationmodel.Policy, resource *model.Resource, trigger string) (executionID int64, err error)
 	// Stop the replication specified by the execution ID
 	Stop(ctx context.Context, executionID int64) (err error)
+	// Retry re-runs the tasks of the execution in the scope with the resources snapshotted when the tasks were created
+	// rather than fetching the resources again, the tasks run in a new execution whose ID is returned
+	Retry(ctx context.Context, executionID int64, scope string) (id int64, err error)
 	// ExecutionCount returns the total count of executions according to the query
 	ExecutionCount(ctx context.Context, query *q.Query) (count int64, err error)
 	// ListExecutions lists the executions according to the query
@@ -166,6 +174,70 @@ func (c *controller) Stop(ctx context.Context, id int64) error {
 	return c.execMgr.Stop(ctx, id)
 }
 
+func (c *controller) Retry(ctx context.Context, executionID int64, scope string) (int64, error) {
+	query := q.New(q.KeyWords{"ExecutionID": executionID})
+	switch scope {
+	case RetryScopeFailed:
+		query.Keywords["Status"] = job.ErrorStatus.String()
+	case RetryScopeAll:
+	default:
+		return 0, errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("invalid retry scope %q, only %q and %q are supported", scope, RetryScopeFailed, RetryScopeAll)
+	}
+	execution, err := c.GetExecution(ctx, executionID)
+	if err != nil {
+		return 0, err
+	}
+	if !job.Status(execution.Status).Final() {
+		return 0, errors.New(nil).WithCode(errors.PreconditionCode).
+			WithMessage("the execution %d is still running", executionID)
+	}
+	policy, err := c.GetPolicy(ctx, execution.PolicyID)
+	if err != nil {
+		return 0, err
+	}
+	if !policy.Enabled {
+		return 0, errors.New(nil).WithCode(errors.PreconditionCode).
+			WithMessage("the policy %d is disabled", policy.ID)
+	}
+
+	tasks, err := c.taskMgr.List(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	var retryTasks []*task.Task
+	for _, tk := range tasks {
+		// the tasks created by the old versions contain no snapshot of the job parameters
+		if _, ok := tk.ExtraAttrs["job_parameters"].(map[string]interface{}); ok {
+			retryTasks = append(retryTasks, tk)
+		}
+	}
+	if len(retryTasks) == 0 {
+		return 0, errors.New(nil).WithCode(errors.PreconditionCode).
+			WithMessage("no task of the execution %d can be retried with the scope %q", executionID, scope)
+	}
+
+	id, err := c.execMgr.Create(ctx, job.Replication, policy.ID, task.ExecutionTriggerManual,
+		map[string]interface{}{"retried_execution_id": executionID})
+	if err != nil {
+		return 0, err
+	}
+	for _, tk := range retryTasks {
+		jb := &task.Job{
+			Name: job.Replication,
+			Metadata: &job.Metadata{
+				JobKind: job.KindGeneric,
+			},
+			Parameters: tk.ExtraAttrs["job_parameters"].(map[string]interface{}),
+		}
+		if _, err = c.taskMgr.Create(ctx, id, jb, tk.ExtraAttrs); err != nil {
+			c.markError(ctx, id, err)
+			return 0, err
+		}
+	}
+	return id, nil
+}
+
 func (c *controller) ExecutionCount(ctx context.Context, query *q.Query) (int64, error) {
 	return c.execMgr.Count(ctx, c.buildExecutionQuery(query))
 }
@@ -25,6 +25,10 @@ import (
 	repctlmodel "github.com/goharbor/harbor/src/controller/replication/model"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	replicationmodel "github.com/goharbor/harbor/src/pkg/replication/model"
 	"github.com/goharbor/harbor/src/pkg/task"
 	"github.com/goharbor/harbor/src/pkg/task/dao"
 	"github.com/goharbor/harbor/src/testing/lib/orm"
@@ -111,6 +115,73 @@ func (r *replicationTestSuite) TestStop() {
 	r.execMgr.AssertExpectations(r.T())
 }
 
+func (r *replicationTestSuite) TestRetry() {
+	// invalid scope
+	_, err := r.ctl.Retry(nil, 1, "invalid")
+	r.Require().NotNil(err)
+	r.True(errors.IsErr(err, errors.BadRequestCode))
+
+	// the execution is still running
+	r.execMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Execution{
+		{
+			ID:         1,
+			VendorType: job.Replication,
+			VendorID:   1,
+			Status:     job.RunningStatus.String(),
+		},
+	}, nil).Once()
+	_, err = r.ctl.Retry(nil, 1, RetryScopeFailed)
+	r.Require().NotNil(err)
+	r.True(errors.IsErr(err, errors.PreconditionCode))
+
+	// only the failed task with the snapshot is retried
+	r.execMgr.On("List", mock.Anything, mock.Anything).Return([]*task.Execution{
+		{
+			ID:         1,
+			VendorType: job.Replication,
+			VendorID:   1,
+			Status:     job.ErrorStatus.String(),
+		},
+	}, nil)
+	mock.OnAnything(r.repMgr, "Get").Return(&replicationmodel.Policy{
+		ID:            1,
+		SrcRegistryID: 1,
+		Enabled:       true,
+	}, nil)
+	mock.OnAnything(r.regMgr, "Get").Return(&model.Registry{}, nil)
+	parameters := map[string]interface{}{
+		"src_resource": "{}",
+		"dst_resource": "{}",
+	}
+	r.taskMgr.On("List", mock.Anything, mock.MatchedBy(func(query *q.Query) bool {
+		return query.Keywords["ExecutionID"] == int64(1) && query.Keywords["Status"] == job.ErrorStatus.String()
+	})).Return([]*task.Task{
+		{
+			ID: 1,
+			ExtraAttrs: map[string]interface{}{
+				"operation":      "copy",
+				"job_parameters": parameters,
+			},
+		},
+		{
+			ID: 2,
+			ExtraAttrs: map[string]interface{}{
+				"operation": "copy",
+			},
+		},
+	}, nil)
+	r.execMgr.On("Create", mock.Anything, job.Replication, int64(1), task.ExecutionTriggerManual,
+		map[string]interface{}{"retried_execution_id": int64(1)}).Return(int64(2), nil)
+	r.taskMgr.On("Create", mock.Anything, int64(2), mock.MatchedBy(func(jb *task.Job) bool {
+		return jb.Name == job.Replication && jb.Parameters["src_resource"] == "{}"
+	}), mock.Anything).Return(int64(3), nil).Once()
+	id, err := r.ctl.Retry(nil, 1, RetryScopeFailed)
+	r.Require().Nil(err)
+	r.Equal(int64(2), id)
+	r.execMgr.AssertExpectations(r.T())
+	r.taskMgr.AssertExpectations(r.T())
+}
+
 func (r *replicationTestSuite) TestExecutionCount() {
 	r.execMgr.On("Count", mock.Anything, mock.Anything).Return(int64(1), nil)
 	total, err := r.ctl.ExecutionCount(nil, nil)
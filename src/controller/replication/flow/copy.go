@@ -92,7 +92,7 @@ func (c *copyFlow) Run(ctx context.Context) error {
 		return err
 	}
 
-	return c.createTasks(ctx, srcResources, dstResources, c.policy.Speed, c.policy.CopyByChunk)
+	return c.createTasks(ctx, srcResources, dstResources, c.policy.Speed, c.policy.CopyByChunk, c.policy.CopyByStorage, c.policy.ForeignLayerMode)
 }
 
 func (c *copyFlow) isExecutionStopped(ctx context.Context) (bool, error) {
@@ -103,7 +103,7 @@ func (c *copyFlow) isExecutionStopped(ctx context.Context) (bool, error) {
 	return execution.Status == job.StoppedStatus.String(), nil
 }
 
-func (c *copyFlow) createTasks(ctx context.Context, srcResources, dstResources []*model.Resource, speed int32, copyByChunk bool) error {
+func (c *copyFlow) createTasks(ctx context.Context, srcResources, dstResources []*model.Resource, speed int32, copyByChunk, copyByStorage bool, foreignLayerMode string) error {
 	var taskCnt int
 	defer func() {
 		// if no task be created, mark execution done.
@@ -137,10 +137,13 @@ func (c *copyFlow) createTasks(ctx context.Context, srcResources, dstResources [
 				JobKind: job.KindGeneric,
 			},
 			Parameters: map[string]interface{}{
-				"src_resource":  string(src),
-				"dst_resource":  string(dest),
-				"speed":         speed,
-				"copy_by_chunk": copyByChunk,
+				"src_resource":       string(src),
+				"dst_resource":       string(dest),
+				"policy_id":          c.policy.ID,
+				"speed":              speed,
+				"copy_by_chunk":      copyByChunk,
+				"copy_by_storage":    copyByStorage,
+				"foreign_layer_mode": foreignLayerMode,
 			},
 		}
 
@@ -148,7 +151,9 @@ func (c *copyFlow) createTasks(ctx context.Context, srcResources, dstResources [
 			"operation":            "copy",
 			"resource_type":        string(srcResource.Type),
 			"source_resource":      getResourceName(srcResource),
-			"destination_resource": getResourceName(dstResource)}); err != nil {
+			"destination_resource": getResourceName(dstResource),
+			// the snapshot of the job parameters to re-run the task
+			"job_parameters": job.Parameters}); err != nil {
 			return err
 		}
 
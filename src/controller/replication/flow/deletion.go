@@ -93,7 +93,9 @@ func (d *deletionFlow) createTasks(ctx context.Context, srcResources, dstResourc
 			"operation":            operation,
 			"resource_type":        string(resource.Type),
 			"source_resource":      getResourceName(resource),
-			"destination_resource": getResourceName(dstResources[i])}); err != nil {
+			"destination_resource": getResourceName(dstResources[i]),
+			// the snapshot of the job parameters to re-run the task
+			"job_parameters": job.Parameters}); err != nil {
 			return err
 		}
 	}
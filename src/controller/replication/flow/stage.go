@@ -127,7 +127,7 @@ func assembleDestinationResources(resources []*model.Resource,
 	policy *repctlmodel.Policy, dstRepoComponentPathType string) ([]*model.Resource, error) {
 	var result []*model.Resource
 	for _, resource := range resources {
-		name, err := replaceNamespace(resource.Metadata.Repository.Name, policy.DestNamespace, policy.DestNamespaceReplaceCount, dstRepoComponentPathType)
+		name, err := DestinationRepository(policy, resource.Metadata.Repository.Name, dstRepoComponentPathType)
 		if err != nil {
 			return nil, err
 		}
@@ -193,6 +193,22 @@ func getResourceName(res *model.Resource) string {
 	return fmt.Sprintf("%s [%d item(s) in total]", meta.Repository.Name, n)
 }
 
+// DestinationRepository returns the name of the destination repository that the source repository is replicated to,
+// the namespace mapping of the policy takes precedence over the destination namespace
+func DestinationRepository(policy *repctlmodel.Policy, repository string, dstRepoComponentPathType string) (string, error) {
+	if policy.NamespaceMapping == nil {
+		return replaceNamespace(repository, policy.DestNamespace, policy.DestNamespaceReplaceCount, dstRepoComponentPathType)
+	}
+	name, err := policy.NamespaceMapping.Map(repository)
+	if err != nil {
+		return "", err
+	}
+	if err := checkPathComponents(name, dstRepoComponentPathType); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
 // repository:a/b/c/image namespace:n replaceCount: -1 -> n/image
 // repository:a/b/c/image namespace:n replaceCount: 0 -> n/a/b/c/image
 // repository:a/b/c/image namespace:n replaceCount: 1 -> n/b/c/image
@@ -221,20 +237,28 @@ func replaceNamespace(repository string, namespace string, replaceCount int8, ds
 
 	name := srcRepoPathComponents[srcLength-1] // the last part of the repository path components, we'll keep it as the same with the source
 	dstRepo := path.Join(dstRepoPrefix, name)
+	if err := checkPathComponents(dstRepo, dstRepoComponentPathType); err != nil {
+		return "", err
+	}
+	return dstRepo, nil
+}
+
+// check whether the count of the path components of the destination repository is supported by the destination registry
+func checkPathComponents(dstRepo string, dstRepoComponentPathType string) error {
 	dstRepoPathComponents := strings.Split(dstRepo, "/")
 	dstLength := len(dstRepoPathComponents)
 	switch dstRepoComponentPathType {
 	case model.RepositoryPathComponentTypeOnlyTwo:
 		if dstLength != 2 {
-			return "", errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("the destination repository %q contains %d path components %v, but the destination registry only supports 2",
+			return errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("the destination repository %q contains %d path components %v, but the destination registry only supports 2",
 				dstRepo, dstLength, dstRepoPathComponents)
 		}
 	case model.RepositoryPathComponentTypeAtLeastTwo:
 		if dstLength < 2 {
-			return "", errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("the destination repository %q contains only %d path components %v, but the destination registry requires at least 2",
+			return errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("the destination repository %q contains only %d path components %v, but the destination registry requires at least 2",
 				dstRepo, dstLength, dstRepoPathComponents)
 		}
 	}
 
-	return dstRepo, nil
+	return nil
 }
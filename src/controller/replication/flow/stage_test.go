@@ -202,6 +202,63 @@ func (s *stageTestSuite) TestReplaceNamespace() {
 	s.Equal("n/a", result)
 }
 
+func (s *stageTestSuite) TestDestinationRepository() {
+	// no namespace mapping, fall back to the destination namespace
+	policy := &repctlmodel.Policy{
+		DestNamespace:             "n",
+		DestNamespaceReplaceCount: 1,
+	}
+	result, err := DestinationRepository(policy, "a/b/c", "")
+	s.Require().Nil(err)
+	s.Equal("n/b/c", result)
+
+	// the first matched rule is applied
+	policy = &repctlmodel.Policy{
+		NamespaceMapping: &model.NamespaceMapping{
+			Rules: []*model.NamespaceMappingRule{
+				{
+					Source:      "library/nginx",
+					Destination: "web/nginx",
+				},
+				{
+					Source:      "library/*",
+					Destination: "mirror-hub/*",
+				},
+			},
+		},
+	}
+	result, err = DestinationRepository(policy, "library/nginx", "")
+	s.Require().Nil(err)
+	s.Equal("web/nginx", result)
+	result, err = DestinationRepository(policy, "library/base/redis", "")
+	s.Require().Nil(err)
+	s.Equal("mirror-hub/base/redis", result)
+	result, err = DestinationRepository(policy, "other/redis", "")
+	s.Require().Nil(err)
+	s.Equal("other/redis", result)
+
+	// the nested namespace is flattened for the registry supporting only 2 path components
+	_, err = DestinationRepository(policy, "library/base/redis", model.RepositoryPathComponentTypeOnlyTwo)
+	s.Require().NotNil(err)
+	policy.NamespaceMapping.Flatten = true
+	result, err = DestinationRepository(policy, "library/base/redis", model.RepositoryPathComponentTypeOnlyTwo)
+	s.Require().Nil(err)
+	s.Equal("mirror-hub-base/redis", result)
+
+	// the prefix is added at last
+	policy.NamespaceMapping.Prefix = "dr-"
+	result, err = DestinationRepository(policy, "a/b/c/image", "")
+	s.Require().Nil(err)
+	s.Equal("dr-a-b-c/image", result)
+
+	// the mapped name is invalid
+	policy.NamespaceMapping = &model.NamespaceMapping{
+		Prefix: "-",
+	}
+	_, err = DestinationRepository(policy, "library/nginx", "")
+	s.Require().NotNil(err)
+}
+
 func TestStage(t *testing.T) {
 	suite.Run(t, &stageTestSuite{})
 }
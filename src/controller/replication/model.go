@@ -20,6 +20,14 @@ import (
 	"github.com/goharbor/harbor/src/pkg/task/dao"
 )
 
+// the scopes of the tasks to retry
+const (
+	// RetryScopeFailed retries the failed tasks only
+	RetryScopeFailed = "failed"
+	// RetryScopeAll retries all the tasks
+	RetryScopeAll = "all"
+)
+
 // Execution model for replication
 type Execution struct {
 	ID            int64
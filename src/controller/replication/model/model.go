@@ -47,6 +47,11 @@ type Policy struct {
 	UpdateTime                time.Time       `json:"update_time"`
 	Speed                     int32           `json:"speed"`
 	CopyByChunk               bool            `json:"copy_by_chunk"`
+	// CopyByStorage copies the blobs by the server-side copy API when both registries declare the same storage provider
+	CopyByStorage    bool   `json:"copy_by_storage"`
+	ForeignLayerMode string `json:"foreign_layer_mode"`
+	// NamespaceMapping names the destination repositories, it cannot be used together with the DestNamespace
+	NamespaceMapping *model.NamespaceMapping `json:"namespace_mapping"`
 }
 
 // IsScheduledTrigger returns true when the policy is scheduled trigger and enabled
@@ -60,10 +65,55 @@ func (p *Policy) IsScheduledTrigger() bool {
 	return p.Trigger.Type == model.TriggerTypeScheduled
 }
 
+// the severities of the findings reported by the policy validation
+const (
+	FindingSeverityError   = "error"
+	FindingSeverityWarning = "warning"
+)
+
+// Finding is a problem found when validating the policy before it is saved or run
+type Finding struct {
+	// the field of the policy that the finding relates to, e.g. "filters[0]", "trigger", "dest_registry"
+	Field    string `json:"field"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// RepositoryMapping is the preview of the destination repository that the source repository is replicated to
+type RepositoryMapping struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	// the reason why the source repository cannot be replicated with the policy
+	Error string `json:"error,omitempty"`
+}
+
+// FieldError is the validation error of a specific field of the policy
+type FieldError struct {
+	Field string
+	Err   error
+}
+
 // Validate the policy
 func (p *Policy) Validate() error {
+	if errs := p.ValidateFields(); len(errs) > 0 {
+		return errs[0].Err
+	}
+	return nil
+}
+
+// ValidateFields validates all the fields of the policy and returns every error found
+// rather than stopping at the first one
+func (p *Policy) ValidateFields() []*FieldError {
+	var errs []*FieldError
+	invalid := func(field string, format string, args ...interface{}) {
+		errs = append(errs, &FieldError{
+			Field: field,
+			Err:   errors.New(nil).WithCode(errors.BadRequestCode).WithMessage(format, args...),
+		})
+	}
+
 	if len(p.Name) == 0 {
-		return errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("empty name")
+		invalid("name", "empty name")
 	}
 	var srcRegistryID, dstRegistryID int64
 	if p.SrcRegistry != nil {
@@ -76,44 +126,54 @@ func (p *Policy) Validate() error {
 	// one of the source registry and destination registry must be Harbor itself
 	if srcRegistryID != 0 && dstRegistryID != 0 ||
 		srcRegistryID == 0 && dstRegistryID == 0 {
-		return errors.New(nil).WithCode(errors.BadRequestCode).
-			WithMessage("either src_registry or dest_registry should be empty and the other one shouldn't be empty")
+		invalid("registry", "either src_registry or dest_registry should be empty and the other one shouldn't be empty")
 	}
 
 	// valid the filters
-	for _, f := range p.Filters {
+	for i, f := range p.Filters {
 		if err := f.Validate(); err != nil {
-			return err
+			errs = append(errs, &FieldError{Field: fmt.Sprintf("filters[%d]", i), Err: err})
 		}
 	}
 
 	// valid the destination namespace
 	if len(p.DestNamespace) > 0 {
 		if !lib.RepositoryNameRe.MatchString(p.DestNamespace) {
-			return errors.New(nil).WithCode(errors.BadRequestCode).
-				WithMessage("invalid destination namespace: %s", p.DestNamespace)
+			invalid("dest_namespace", "invalid destination namespace: %s", p.DestNamespace)
 		}
 	}
 
+	// valid the namespace mapping
+	if p.NamespaceMapping != nil {
+		if len(p.DestNamespace) > 0 {
+			invalid("namespace_mapping", "the namespace mapping cannot be used together with the destination namespace")
+		} else if err := p.NamespaceMapping.Validate(); err != nil {
+			errs = append(errs, &FieldError{Field: "namespace_mapping", Err: err})
+		}
+	}
+
+	// valid the mode to handle the foreign layers
+	switch p.ForeignLayerMode {
+	case "", model.ForeignLayerModeSkip, model.ForeignLayerModePullThrough, model.ForeignLayerModeRewrite:
+	default:
+		invalid("foreign_layer_mode", "invalid foreign layer mode: %s", p.ForeignLayerMode)
+	}
+
 	// valid trigger
 	if p.Trigger != nil {
 		switch p.Trigger.Type {
 		case model.TriggerTypeManual, model.TriggerTypeEventBased:
 		case model.TriggerTypeScheduled:
 			if p.Trigger.Settings == nil || len(p.Trigger.Settings.Cron) == 0 {
-				return errors.New(nil).WithCode(errors.BadRequestCode).
-					WithMessage("the cron string cannot be empty when the trigger type is %s", model.TriggerTypeScheduled)
-			}
-			if _, err := utils.CronParser().Parse(p.Trigger.Settings.Cron); err != nil {
-				return errors.New(nil).WithCode(errors.BadRequestCode).
-					WithMessage("invalid cron string for scheduled trigger: %s", p.Trigger.Settings.Cron)
+				invalid("trigger", "the cron string cannot be empty when the trigger type is %s", model.TriggerTypeScheduled)
+			} else if _, err := utils.CronParser().Parse(p.Trigger.Settings.Cron); err != nil {
+				invalid("trigger", "invalid cron string for scheduled trigger: %s", p.Trigger.Settings.Cron)
 			}
 		default:
-			return errors.New(nil).WithCode(errors.BadRequestCode).
-				WithMessage("invalid trigger type")
+			invalid("trigger", "invalid trigger type")
 		}
 	}
-	return nil
+	return errs
 }
 
 // From converts the pkg model into the Policy
@@ -134,6 +194,8 @@ func (p *Policy) From(policy *replicationmodel.Policy) error {
 	p.UpdateTime = policy.UpdateTime
 	p.Speed = policy.Speed
 	p.CopyByChunk = policy.CopyByChunk
+	p.CopyByStorage = policy.CopyByStorage
+	p.ForeignLayerMode = policy.ForeignLayerMode
 
 	if policy.SrcRegistryID > 0 {
 		p.SrcRegistry = &model.Registry{
@@ -160,6 +222,15 @@ func (p *Policy) From(policy *replicationmodel.Policy) error {
 	}
 	p.Trigger = trigger
 
+	// parse the namespace mapping
+	if len(policy.NamespaceMapping) > 0 {
+		mapping := &model.NamespaceMapping{}
+		if err := json.Unmarshal([]byte(policy.NamespaceMapping), mapping); err != nil {
+			return err
+		}
+		p.NamespaceMapping = mapping
+	}
+
 	return nil
 }
 
@@ -179,6 +250,8 @@ func (p *Policy) To() (*replicationmodel.Policy, error) {
 		UpdateTime:                p.UpdateTime,
 		Speed:                     p.Speed,
 		CopyByChunk:               p.CopyByChunk,
+		CopyByStorage:             p.CopyByStorage,
+		ForeignLayerMode:          p.ForeignLayerMode,
 	}
 	if p.SrcRegistry != nil {
 		policy.SrcRegistryID = p.SrcRegistry.ID
@@ -203,6 +276,14 @@ func (p *Policy) To() (*replicationmodel.Policy, error) {
 		policy.Filters = string(filters)
 	}
 
+	if p.NamespaceMapping != nil {
+		mapping, err := json.Marshal(p.NamespaceMapping)
+		if err != nil {
+			return nil, err
+		}
+		policy.NamespaceMapping = string(mapping)
+	}
+
 	return policy, nil
 }
 
@@ -199,6 +199,20 @@ func TestValidate(t *testing.T) {
 	err = policy.Validate()
 	assert.True(errors.IsErr(err, errors.BadRequestCode))
 
+	// invalid foreign layer mode
+	policy = &Policy{
+		Name: "policy01",
+		SrcRegistry: &model.Registry{
+			ID: 0,
+		},
+		DestRegistry: &model.Registry{
+			ID: 1,
+		},
+		ForeignLayerMode: "invalid_mode",
+	}
+	err = policy.Validate()
+	assert.True(errors.IsErr(err, errors.BadRequestCode))
+
 	// pass
 	policy = &Policy{
 		Name: "policy01",
@@ -224,7 +238,100 @@ func TestValidate(t *testing.T) {
 				Cron: "* * * * * *",
 			},
 		},
+		ForeignLayerMode: model.ForeignLayerModeRewrite,
 	}
 	err = policy.Validate()
 	assert.Nil(err)
 }
+
+func TestValidateFields(t *testing.T) {
+	assert := assert.New(t)
+
+	// all the errors are returned
+	policy := &Policy{
+		SrcRegistry: &model.Registry{
+			ID: 0,
+		},
+		DestRegistry: &model.Registry{
+			ID: 1,
+		},
+		Filters: []*model.Filter{
+			{
+				Type:  model.FilterTypeName,
+				Value: "library/**",
+			},
+			{
+				Type:  model.FilterTypeResource,
+				Value: "invalid_resource_type",
+			},
+		},
+		Trigger: &model.Trigger{
+			Type: model.TriggerTypeScheduled,
+		},
+	}
+	errs := policy.ValidateFields()
+	assert.Len(errs, 3)
+	assert.Equal("name", errs[0].Field)
+	assert.Equal("filters[1]", errs[1].Field)
+	assert.Equal("trigger", errs[2].Field)
+	for _, e := range errs {
+		assert.True(errors.IsErr(e.Err, errors.BadRequestCode))
+	}
+
+	// pass
+	policy.Name = "policy01"
+	policy.Filters[1].Value = "image"
+	policy.Trigger.Type = model.TriggerTypeManual
+	assert.Len(policy.ValidateFields(), 0)
+}
+
+func TestValidateNamespaceMapping(t *testing.T) {
+	assert := assert.New(t)
+
+	policy := &Policy{
+		Name: "policy01",
+		SrcRegistry: &model.Registry{
+			ID: 0,
+		},
+		DestRegistry: &model.Registry{
+			ID: 1,
+		},
+		NamespaceMapping: &model.NamespaceMapping{
+			Rules: []*model.NamespaceMappingRule{
+				{
+					Source:      "library/*",
+					Destination: "mirror-hub/*",
+				},
+				{
+					Source:      "*",
+					Destination: "mirror/*",
+				},
+			},
+			Flatten: true,
+			Prefix:  "dr-",
+		},
+	}
+	assert.Nil(policy.Validate())
+
+	// cannot be used together with the destination namespace
+	policy.DestNamespace = "n"
+	assert.True(errors.IsErr(policy.Validate(), errors.BadRequestCode))
+	policy.DestNamespace = ""
+
+	// the wildcard is missing in the destination
+	policy.NamespaceMapping.Rules[0].Destination = "mirror-hub"
+	assert.True(errors.IsErr(policy.Validate(), errors.BadRequestCode))
+
+	// the wildcard isn't the last path component
+	policy.NamespaceMapping.Rules[0].Source = "library/*/nginx"
+	policy.NamespaceMapping.Rules[0].Destination = "mirror-hub/*/nginx"
+	assert.True(errors.IsErr(policy.Validate(), errors.BadRequestCode))
+
+	// the policy is converted with the namespace mapping
+	policy.NamespaceMapping.Rules = policy.NamespaceMapping.Rules[1:]
+	p, err := policy.To()
+	assert.Nil(err)
+	converted := &Policy{}
+	assert.Nil(converted.From(p))
+	assert.Equal(policy.NamespaceMapping, converted.NamespaceMapping)
+}
@@ -18,10 +18,15 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/goharbor/harbor/src/common/rbac"
+	event "github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/controller/event/operator"
+	"github.com/goharbor/harbor/src/controller/replication/flow"
 	"github.com/goharbor/harbor/src/controller/replication/model"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/notification"
 	pkgmodel "github.com/goharbor/harbor/src/pkg/replication/model"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
 	"github.com/goharbor/harbor/src/pkg/task"
@@ -126,6 +131,7 @@ func (c *controller) CreatePolicy(ctx context.Context, policy *model.Policy) (in
 			return 0, err
 		}
 	}
+	auditPolicyChange(ctx, policy.Name, rbac.ActionCreate, nil, p)
 	return id, nil
 }
 
@@ -133,6 +139,10 @@ func (c *controller) UpdatePolicy(ctx context.Context, policy *model.Policy, pro
 	if err := c.validatePolicy(ctx, policy); err != nil {
 		return err
 	}
+	before, err := c.repMgr.Get(ctx, policy.ID)
+	if err != nil {
+		return err
+	}
 	// delete the schedule
 	if err := c.scheduler.UnScheduleByVendor(ctx, job.Replication, policy.ID); err != nil {
 		return err
@@ -153,6 +163,7 @@ func (c *controller) UpdatePolicy(ctx context.Context, policy *model.Policy, pro
 			return err
 		}
 	}
+	auditPolicyChange(ctx, policy.Name, rbac.ActionUpdate, before, p)
 	return nil
 }
 
@@ -173,7 +184,50 @@ func (c *controller) validatePolicy(ctx context.Context, policy *model.Policy) e
 	return nil
 }
 
+func (c *controller) PreviewRepositoryMappings(ctx context.Context, policy *model.Policy, repositories []string) ([]*model.RepositoryMapping, error) {
+	if policy.NamespaceMapping != nil {
+		if err := policy.NamespaceMapping.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	var destRegistryID int64
+	if policy.DestRegistry != nil {
+		destRegistryID = policy.DestRegistry.ID
+	}
+	registry, err := c.regMgr.Get(ctx, destRegistryID)
+	if err != nil {
+		return nil, err
+	}
+	adapter, err := c.regMgr.CreateAdapter(ctx, registry)
+	if err != nil {
+		return nil, err
+	}
+	info, err := adapter.Info()
+	if err != nil {
+		return nil, err
+	}
+
+	mappings := []*model.RepositoryMapping{}
+	for _, repository := range repositories {
+		mapping := &model.RepositoryMapping{
+			Source: repository,
+		}
+		name, err := flow.DestinationRepository(policy, repository, info.SupportedRepositoryPathComponentType)
+		if err != nil {
+			mapping.Error = err.Error()
+		} else {
+			mapping.Destination = name
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings, nil
+}
+
 func (c *controller) DeletePolicy(ctx context.Context, id int64) error {
+	before, err := c.repMgr.Get(ctx, id)
+	if err != nil {
+		return err
+	}
 	// delete the executions
 	if err := c.execMgr.DeleteByVendor(ctx, job.Replication, id); err != nil {
 		return err
@@ -183,5 +237,21 @@ func (c *controller) DeletePolicy(ctx context.Context, id int64) error {
 		return err
 	}
 	// delete the policy
-	return c.repMgr.Delete(ctx, id)
+	if err := c.repMgr.Delete(ctx, id); err != nil {
+		return err
+	}
+	auditPolicyChange(ctx, before.Name, rbac.ActionDelete, before, nil)
+	return nil
+}
+
+// auditPolicyChange records the change of the replication policy with the snapshots before and after the change
+func auditPolicyChange(ctx context.Context, name string, action rbac.Action, before, after *pkgmodel.Policy) {
+	notification.AddEvent(ctx, &event.ResourceChangeEventMetadata{
+		ResourceType: event.ResourceTypeReplicationPolicy,
+		Resource:     name,
+		Operation:    action.String(),
+		Operator:     operator.FromContext(ctx),
+		Before:       before,
+		After:        after,
+	})
 }
@@ -15,10 +15,13 @@
 package replication
 
 import (
+	"context"
+
 	repmodel "github.com/goharbor/harbor/src/controller/replication/model"
 	"github.com/goharbor/harbor/src/pkg/reg/model"
 	replicationmodel "github.com/goharbor/harbor/src/pkg/replication/model"
 	"github.com/goharbor/harbor/src/testing/mock"
+	testingadapter "github.com/goharbor/harbor/src/testing/pkg/reg/adapter"
 )
 
 func (r *replicationTestSuite) TestPolicyCount() {
@@ -68,7 +71,7 @@ func (r *replicationTestSuite) TestCreatePolicy() {
 		ID: 1,
 	}, nil)
 	mock.OnAnything(r.scheduler, "Schedule").Return(int64(1), nil)
-	id, err := r.ctl.CreatePolicy(nil, &repmodel.Policy{
+	id, err := r.ctl.CreatePolicy(context.Background(), &repmodel.Policy{
 		Name: "rule",
 		SrcRegistry: &model.Registry{
 			ID: 1,
@@ -94,8 +97,9 @@ func (r *replicationTestSuite) TestUpdatePolicy() {
 	}, nil)
 	mock.OnAnything(r.scheduler, "UnScheduleByVendor").Return(nil)
 	mock.OnAnything(r.scheduler, "Schedule").Return(int64(1), nil)
+	mock.OnAnything(r.repMgr, "Get").Return(&replicationmodel.Policy{ID: 1}, nil)
 	mock.OnAnything(r.repMgr, "Update").Return(nil)
-	err := r.ctl.UpdatePolicy(nil, &repmodel.Policy{
+	err := r.ctl.UpdatePolicy(context.Background(), &repmodel.Policy{
 		ID:   1,
 		Name: "rule",
 		SrcRegistry: &model.Registry{
@@ -118,10 +122,41 @@ func (r *replicationTestSuite) TestUpdatePolicy() {
 func (r *replicationTestSuite) TestDeletePolicy() {
 	mock.OnAnything(r.execMgr, "DeleteByVendor").Return(nil)
 	mock.OnAnything(r.scheduler, "UnScheduleByVendor").Return(nil)
+	mock.OnAnything(r.repMgr, "Get").Return(&replicationmodel.Policy{ID: 1}, nil)
 	mock.OnAnything(r.repMgr, "Delete").Return(nil)
-	err := r.ctl.DeletePolicy(nil, 1)
+	err := r.ctl.DeletePolicy(context.Background(), 1)
 	r.Require().Nil(err)
 	r.repMgr.AssertExpectations(r.T())
 	r.execMgr.AssertExpectations(r.T())
 	r.scheduler.AssertExpectations(r.T())
 }
+
+func (r *replicationTestSuite) TestPreviewRepositoryMappings() {
+	adapter := &testingadapter.Adapter{}
+	adapter.On("Info").Return(&model.RegistryInfo{
+		SupportedRepositoryPathComponentType: model.RepositoryPathComponentTypeOnlyTwo,
+	}, nil)
+	mock.OnAnything(r.regMgr, "Get").Return(&model.Registry{
+		ID: 1,
+	}, nil)
+	mock.OnAnything(r.regMgr, "CreateAdapter").Return(adapter, nil)
+	mappings, err := r.ctl.PreviewRepositoryMappings(nil, &repmodel.Policy{
+		DestRegistry: &model.Registry{ID: 1},
+		NamespaceMapping: &model.NamespaceMapping{
+			Rules: []*model.NamespaceMappingRule{
+				{
+					Source:      "library/*",
+					Destination: "mirror-hub/*",
+				},
+			},
+		},
+	}, []string{"library/nginx", "library/base/redis"})
+	r.Require().Nil(err)
+	r.Require().Len(mappings, 2)
+	r.Equal("mirror-hub/nginx", mappings[0].Destination)
+	r.Empty(mappings[0].Error)
+	r.Empty(mappings[1].Destination)
+	r.NotEmpty(mappings[1].Error)
+	r.regMgr.AssertExpectations(r.T())
+	adapter.AssertExpectations(r.T())
+}
@@ -0,0 +1,103 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/ocischema"
+	"github.com/docker/distribution/manifest/schema2"
+	godigest "github.com/opencontainers/go-digest"
+
+	"github.com/goharbor/harbor/src/pkg/reg/util"
+)
+
+// manifestDescriptor returns the descriptor of the manifest with the specified digest
+func manifestDescriptor(manifest distribution.Manifest, digest string) distribution.Descriptor {
+	desc := distribution.Descriptor{Digest: godigest.Digest(digest)}
+	if mediaType, payload, err := manifest.Payload(); err == nil {
+		desc.MediaType = mediaType
+		desc.Size = int64(len(payload))
+	}
+	return desc
+}
+
+// rewriteManifest rewrites the foreign layers of the image manifest as the distributable layers, or replaces
+// the manifests referenced by the index with the rewritten ones, and returns the manifest with its digest.
+// The manifest is returned as it is if nothing is rewritten
+func rewriteManifest(manifest distribution.Manifest, digest string, rewritten map[string]distribution.Descriptor) (distribution.Manifest, string, error) {
+	var (
+		m   distribution.Manifest
+		err error
+	)
+	switch mani := manifest.(type) {
+	case *schema2.DeserializedManifest:
+		layers, changed := rewriteLayers(mani.Layers)
+		if !changed {
+			return manifest, digest, nil
+		}
+		content := mani.Manifest
+		content.Layers = layers
+		m, err = schema2.FromStruct(content)
+	case *ocischema.DeserializedManifest:
+		layers, changed := rewriteLayers(mani.Layers)
+		if !changed {
+			return manifest, digest, nil
+		}
+		content := mani.Manifest
+		content.Layers = layers
+		m, err = ocischema.FromStruct(content)
+	case *manifestlist.DeserializedManifestList:
+		descriptors := make([]manifestlist.ManifestDescriptor, len(mani.Manifests))
+		changed := false
+		for i, desc := range mani.Manifests {
+			descriptors[i] = desc
+			if r, exist := rewritten[desc.Digest.String()]; exist && len(r.Digest) > 0 {
+				descriptors[i].Digest = r.Digest
+				descriptors[i].Size = r.Size
+				changed = true
+			}
+		}
+		if !changed {
+			return manifest, digest, nil
+		}
+		m, err = manifestlist.FromDescriptorsWithMediaType(descriptors, mani.MediaType)
+	default:
+		return manifest, digest, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	_, payload, err := m.Payload()
+	if err != nil {
+		return nil, "", err
+	}
+	return m, godigest.FromBytes(payload).String(), nil
+}
+
+// rewriteLayers rewrites the foreign layers as the distributable ones which are stored in the registry
+func rewriteLayers(layers []distribution.Descriptor) ([]distribution.Descriptor, bool) {
+	result := make([]distribution.Descriptor, len(layers))
+	changed := false
+	for i, layer := range layers {
+		result[i] = layer
+		if util.IsForeignLayer(layer.MediaType) {
+			result[i].MediaType = util.DistributableMediaType(layer.MediaType)
+			result[i].URLs = nil
+			changed = true
+		}
+	}
+	return result, changed
+}
@@ -0,0 +1,90 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/schema2"
+	godigest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteManifest(t *testing.T) {
+	foreign := distribution.Descriptor{
+		MediaType: schema2.MediaTypeForeignLayer,
+		Digest:    godigest.FromString("foreign"),
+		Size:      7,
+		URLs:      []string{"https://example.com/layer"},
+	}
+	layer := distribution.Descriptor{
+		MediaType: schema2.MediaTypeLayer,
+		Digest:    godigest.FromString("layer"),
+		Size:      5,
+	}
+	image, err := schema2.FromStruct(schema2.Manifest{
+		Versioned: schema2.SchemaVersion,
+		Config: distribution.Descriptor{
+			MediaType: schema2.MediaTypeImageConfig,
+			Digest:    godigest.FromString("config"),
+			Size:      6,
+		},
+		Layers: []distribution.Descriptor{foreign, layer},
+	})
+	require.Nil(t, err)
+	_, payload, err := image.Payload()
+	require.Nil(t, err)
+	digest := godigest.FromBytes(payload).String()
+
+	// the foreign layers are rewritten as the distributable ones
+	m, dig, err := rewriteManifest(image, digest, nil)
+	require.Nil(t, err)
+	assert.NotEqual(t, digest, dig)
+	rewritten := m.(*schema2.DeserializedManifest)
+	assert.Equal(t, schema2.MediaTypeLayer, rewritten.Layers[0].MediaType)
+	assert.Equal(t, foreign.Digest, rewritten.Layers[0].Digest)
+	assert.Empty(t, rewritten.Layers[0].URLs)
+	assert.Equal(t, layer, rewritten.Layers[1])
+
+	// nothing to rewrite
+	m2, dig2, err := rewriteManifest(m, dig, nil)
+	require.Nil(t, err)
+	assert.Equal(t, m, m2)
+	assert.Equal(t, dig, dig2)
+
+	// the index references the rewritten manifest
+	desc := manifestDescriptor(image, digest)
+	index, err := manifestlist.FromDescriptors([]manifestlist.ManifestDescriptor{{
+		Descriptor: desc,
+		Platform:   manifestlist.PlatformSpec{OS: "windows", Architecture: "amd64"},
+	}})
+	require.Nil(t, err)
+	_, payload, err = index.Payload()
+	require.Nil(t, err)
+	indexDigest := godigest.FromBytes(payload).String()
+	m3, dig3, err := rewriteManifest(index, indexDigest, map[string]distribution.Descriptor{
+		digest: manifestDescriptor(m, dig),
+	})
+	require.Nil(t, err)
+	assert.NotEqual(t, indexDigest, dig3)
+	list := m3.(*manifestlist.DeserializedManifestList)
+	assert.Equal(t, manifest.Versioned{SchemaVersion: 2, MediaType: manifestlist.MediaTypeManifestList}, list.Versioned)
+	assert.Equal(t, godigest.Digest(dig), list.Manifests[0].Digest)
+	assert.Equal(t, "windows", list.Manifests[0].Platform.OS)
+}
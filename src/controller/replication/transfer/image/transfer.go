@@ -15,7 +15,9 @@
 package image
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"strconv"
@@ -26,13 +28,19 @@ import (
 	"github.com/docker/distribution/manifest/manifestlist"
 	"github.com/docker/distribution/manifest/schema1"
 	"github.com/docker/distribution/manifest/schema2"
+	godigest "github.com/opencontainers/go-digest"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.opentelemetry.io/otel/attribute"
 
 	common_http "github.com/goharbor/harbor/src/common/http"
 	trans "github.com/goharbor/harbor/src/controller/replication/transfer"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/metric"
+	tracelib "github.com/goharbor/harbor/src/lib/trace"
 	"github.com/goharbor/harbor/src/pkg/reg/adapter"
 	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/goharbor/harbor/src/pkg/reg/storage"
+	"github.com/goharbor/harbor/src/pkg/reg/util"
 )
 
 var (
@@ -43,6 +51,8 @@ var (
 	defaultChunkSize = 10 * 1024 * 1024
 )
 
+const tracerName = "goharbor/harbor/src/controller/replication/transfer/image"
+
 func init() {
 	blobRetryCnt, _ = strconv.Atoi(os.Getenv("COPY_BLOB_RETRY_COUNT"))
 	if blobRetryCnt <= 0 {
@@ -84,6 +94,8 @@ type transfer struct {
 	isStopped trans.StopFunc
 	src       adapter.ArtifactRegistry
 	dst       adapter.ArtifactRegistry
+	// copier is only set when the blobs can be copied by the server-side copy API of the storage
+	copier storage.Copier
 }
 
 func (t *transfer) Transfer(src *model.Resource, dst *model.Resource, opts *trans.Options) error {
@@ -102,6 +114,10 @@ func (t *transfer) Transfer(src *model.Resource, dst *model.Resource, opts *tran
 		return t.delete(t.convert(dst))
 	}
 
+	if opts.CopyByStorage {
+		t.initializeCopier(src, dst)
+	}
+
 	// copy the repository from source registry to the destination
 	return t.copy(t.convert(src), t.convert(dst), dst.Override, opts)
 }
@@ -152,6 +168,22 @@ func (t *transfer) initialize(src *model.Resource, dst *model.Resource) error {
 	return nil
 }
 
+// initializeCopier creates the copier when both registries declare the storages in the same provider,
+// the blobs are streamed through the jobservice as usual if the copier cannot be created
+func (t *transfer) initializeCopier(src *model.Resource, dst *model.Resource) {
+	if src.Registry.Storage == nil || dst.Registry.Storage == nil {
+		t.logger.Warning("the storage of the source or destination registry isn't declared, copy the blobs through the jobservice")
+		return
+	}
+	copier, err := storage.NewCopier(src.Registry.Storage, dst.Registry.Storage)
+	if err != nil {
+		t.logger.Warningf("the blobs cannot be copied by the storage, copy them through the jobservice: %v", err)
+		return
+	}
+	t.copier = copier
+	t.logger.Infof("the blobs will be copied by the server-side copy API of the %s storage", dst.Registry.Storage.Type)
+}
+
 func createRegistry(reg *model.Registry) (adapter.ArtifactRegistry, error) {
 	factory, err := adapter.GetFactory(reg.Type)
 	if err != nil {
@@ -187,7 +219,7 @@ func (t *transfer) copy(src *repository, dst *repository, override bool, opts *t
 
 	var err error
 	for i := range src.tags {
-		if e := t.copyArtifact(srcRepo, src.tags[i], dstRepo, dst.tags[i], override, opts); e != nil {
+		if _, e := t.copyArtifact(opts.Context, srcRepo, src.tags[i], dstRepo, dst.tags[i], override, opts); e != nil {
 			if e == errStopped {
 				return nil
 			}
@@ -206,58 +238,119 @@ func (t *transfer) copy(src *repository, dst *repository, override bool, opts *t
 	return nil
 }
 
-func (t *transfer) copyArtifact(srcRepo, srcRef, dstRepo, dstRef string, override bool, opts *trans.Options) error {
+// copyArtifact copies the artifact and returns the descriptor of the manifest pushed to the destination registry,
+// which differs from the source one when the foreign layers are rewritten
+func (t *transfer) copyArtifact(ctx context.Context, srcRepo, srcRef, dstRepo, dstRef string, override bool, opts *trans.Options) (desc distribution.Descriptor, err error) {
+	ctx, span := tracelib.StartTrace(ctx, tracerName, "copy-artifact")
+	defer func() {
+		if err != nil && err != errStopped {
+			tracelib.RecordError(span, err, "copy artifact failed")
+		}
+		span.End()
+	}()
+	span.SetAttributes(
+		attribute.Key("srcRepository").String(srcRepo),
+		attribute.Key("srcReference").String(srcRef),
+		attribute.Key("dstRepository").String(dstRepo),
+		attribute.Key("dstReference").String(dstRef),
+	)
+
 	t.logger.Infof("copying %s:%s(source registry) to %s:%s(destination registry)...",
 		srcRepo, srcRef, dstRepo, dstRef)
 	// pull the manifest from the source registry
 	manifest, digest, err := t.pullManifest(srcRepo, srcRef)
 	if err != nil {
-		return err
+		return desc, err
 	}
 
-	// check the existence of the artifact on the destination registry
-	exist, digest2, err := t.exist(dstRepo, dstRef)
-	if err != nil {
-		return err
-	}
-	if exist {
-		// the same artifact already exists
-		if digest == digest2 {
-			t.logger.Infof("the artifact %s:%s already exists on the destination registry, skip",
-				dstRepo, dstRef)
-			return nil
-		}
-		// the same name artifact exists, but not allowed to override
-		if !override {
-			t.logger.Warningf("the same name artifact %s:%s exists on the destination registry, but the \"override\" is set to false, skip",
-				dstRepo, dstRef)
-			return nil
+	// the digest of the manifest is unknown until the foreign layers are rewritten,
+	// so the existence is checked after copying the contents in this case
+	rewrite := opts.ForeignLayerMode == model.ForeignLayerModeRewrite
+	if !rewrite {
+		skip, err := t.shouldSkip(dstRepo, dstRef, digest, override)
+		if err != nil || skip {
+			return manifestDescriptor(manifest, digest), err
 		}
-		// the same name artifact exists, but allowed to override
-		t.logger.Warningf("the same name artifact %s:%s exists on the destination registry and the \"override\" is set to true, continue...",
-			dstRepo, dstRef)
 	}
 
 	// copy contents between the source and destination registries
+	rewritten := map[string]distribution.Descriptor{}
 	for _, content := range manifest.References() {
-		if err = t.copyContent(content, srcRepo, dstRepo, opts); err != nil {
-			return err
+		copied, err := t.copyContent(ctx, content, srcRepo, dstRepo, opts)
+		if err != nil {
+			return desc, err
+		}
+		if copied.Digest != content.Digest {
+			rewritten[content.Digest.String()] = copied
+		}
+	}
+
+	if rewrite {
+		if manifest, digest, err = rewriteManifest(manifest, digest, rewritten); err != nil {
+			t.logger.Errorf("failed to rewrite the foreign layers of the artifact %s:%s: %v", srcRepo, srcRef, err)
+			return desc, err
+		}
+		// the artifact referenced by the digest is pushed with the digest after rewriting
+		if _, e := godigest.Parse(dstRef); e == nil && dstRef != digest {
+			t.logger.Infof("the digest of the artifact %s is changed to %s after rewriting the foreign layers", dstRef, digest)
+			dstRef = digest
+		}
+		skip, err := t.shouldSkip(dstRepo, dstRef, digest, override)
+		if err != nil || skip {
+			return manifestDescriptor(manifest, digest), err
 		}
 	}
 
 	// push the manifest to the destination registry
 	if err := t.pushManifest(manifest, dstRepo, dstRef); err != nil {
-		return err
+		return desc, err
 	}
 
 	t.logger.Infof("copy %s:%s(source registry) to %s:%s(destination registry) completed",
 		srcRepo, srcRef, dstRepo, dstRef)
-	return nil
+	return manifestDescriptor(manifest, digest), nil
+}
+
+// shouldSkip checks the existence of the artifact on the destination registry and
+// returns true if the same artifact exists or the existing one isn't allowed to be overridden
+func (t *transfer) shouldSkip(dstRepo, dstRef, digest string, override bool) (bool, error) {
+	exist, digest2, err := t.exist(dstRepo, dstRef)
+	if err != nil {
+		return false, err
+	}
+	if !exist {
+		return false, nil
+	}
+	// the same artifact already exists
+	if digest == digest2 {
+		t.logger.Infof("the artifact %s:%s already exists on the destination registry, skip",
+			dstRepo, dstRef)
+		return true, nil
+	}
+	// the same name artifact exists, but not allowed to override
+	if !override {
+		t.logger.Warningf("the same name artifact %s:%s exists on the destination registry, but the \"override\" is set to false, skip",
+			dstRepo, dstRef)
+		return true, nil
+	}
+	// the same name artifact exists, but allowed to override
+	t.logger.Warningf("the same name artifact %s:%s exists on the destination registry and the \"override\" is set to true, continue...",
+		dstRepo, dstRef)
+	return false, nil
 }
 
 // copy the content from source registry to destination according to its media type
-func (t *transfer) copyContent(content distribution.Descriptor, srcRepo, dstRepo string, opts *trans.Options) error {
+// it returns the descriptor of the content copied to the destination
+func (t *transfer) copyContent(ctx context.Context, content distribution.Descriptor, srcRepo, dstRepo string, opts *trans.Options) (distribution.Descriptor, error) {
 	digest := content.Digest.String()
+	if util.IsForeignLayer(content.MediaType) {
+		release, err := t.acquireSlot(ctx, digest, opts)
+		if err != nil {
+			return content, err
+		}
+		defer release()
+		return content, t.copyForeignLayer(content, srcRepo, dstRepo, opts)
+	}
 	switch content.MediaType {
 	// when the media type of pulled manifest is index,
 	// the contents it contains are a few manifests/indexes
@@ -265,24 +358,105 @@ func (t *transfer) copyContent(content distribution.Descriptor, srcRepo, dstRepo
 		v1.MediaTypeImageManifest, schema2.MediaTypeManifest,
 		schema1.MediaTypeSignedManifest, schema1.MediaTypeManifest:
 		// as using digest as the reference, so set the override to true directly
-		return t.copyArtifact(srcRepo, digest, dstRepo, digest, true, opts)
-	// handle foreign layer
-	case schema2.MediaTypeForeignLayer:
-		t.logger.Infof("the layer %s is a foreign layer, skip", digest)
-		return nil
+		return t.copyArtifact(ctx, srcRepo, digest, dstRepo, digest, true, opts)
 	// copy layer or artifact config
 	// the media type of the layer or config can be "application/octet-stream",
 	// schema1.MediaTypeManifestLayer, schema2.MediaTypeLayer, schema2.MediaTypeImageConfig
 	default:
+		release, err := t.acquireSlot(ctx, digest, opts)
+		if err != nil {
+			return content, err
+		}
+		defer release()
+		_, span := tracelib.StartTrace(ctx, tracerName, "copy-blob")
+		defer span.End()
+		span.SetAttributes(
+			attribute.Key("digest").String(digest),
+			attribute.Key("size").Int64(content.Size),
+			attribute.Key("copyByChunk").Bool(opts.CopyByChunk),
+		)
+		if t.copier != nil {
+			copied, err := t.copyBlobByStorage(ctx, srcRepo, dstRepo, digest, content.Size)
+			if err != nil {
+				if err != errStopped {
+					tracelib.RecordError(span, err, "copy blob failed")
+				}
+				return content, err
+			}
+			if copied {
+				return content, nil
+			}
+		}
 		if opts.CopyByChunk {
 			// copy by chunk
-			return t.copyChunkWithRetry(srcRepo, dstRepo, digest, content.Size, opts.Speed)
+			err = t.copyChunkWithRetry(srcRepo, dstRepo, digest, content.Size, opts.Speed)
+		} else {
+			// copy by blob
+			err = t.copyBlobWithRetry(srcRepo, dstRepo, digest, content.Size, opts.Speed)
+		}
+		if err != nil && err != errStopped {
+			tracelib.RecordError(span, err, "copy blob failed")
 		}
-		// copy by blob
-		return t.copyBlobWithRetry(srcRepo, dstRepo, digest, content.Size, opts.Speed)
+		return content, err
 	}
 }
 
+// acquireSlot waits for a slot of the scheduler shared by the blob transfers of all the replications
+func (t *transfer) acquireSlot(ctx context.Context, digest string, opts *trans.Options) (func(), error) {
+	release, err := trans.DefaultScheduler.Acquire(ctx, opts.PolicyID)
+	if err != nil {
+		t.logger.Errorf("failed to acquire the slot to copy the blob %s: %v", digest, err)
+		return nil, err
+	}
+	return release, nil
+}
+
+// copyForeignLayer copies the foreign layer according to the mode, the foreign layer is pulled
+// from its URLs, or from the source registry if it's stored there, and pushed to the destination
+func (t *transfer) copyForeignLayer(content distribution.Descriptor, srcRepo, dstRepo string, opts *trans.Options) error {
+	digest := content.Digest.String()
+	if opts.ForeignLayerMode != model.ForeignLayerModePullThrough && opts.ForeignLayerMode != model.ForeignLayerModeRewrite {
+		t.logger.Infof("the layer %s is a foreign layer, skip", digest)
+		return nil
+	}
+	mounted, err := t.tryMountBlob(srcRepo, dstRepo, digest)
+	if err != nil {
+		return err
+	}
+	if mounted {
+		return nil
+	}
+
+	t.logger.Infof("pulling the foreign layer %s from %s ...", digest, strings.Join(content.URLs, ","))
+	size, data, err := util.PullForeignLayer(content)
+	if err != nil {
+		t.logger.Warningf("%v, try to pull it from the source registry", err)
+		var e error
+		if size, data, e = t.src.PullBlob(srcRepo, digest); e != nil {
+			err = fmt.Errorf("failed to pull the foreign layer %s from neither its URLs nor the source registry, "+
+				"set the foreign layer mode of the policy to %q to replicate the artifact without it: %v; %v",
+				digest, model.ForeignLayerModeSkip, err, e)
+			t.logger.Error(err)
+			return err
+		}
+	}
+	if opts.Speed > 0 {
+		data = trans.NewReader(data, opts.Speed)
+	}
+	defer data.Close()
+	if size <= 0 {
+		size = content.Size
+	}
+
+	if err = t.dst.PushBlob(dstRepo, digest, size, data); err != nil {
+		t.logger.Errorf("failed to push the foreign layer %s, size %d: %v", digest, size, err)
+		return err
+	}
+	metric.ReplicationTransferredBytes.Add(float64(size))
+	t.logger.Infof("copy the foreign layer %s completed", digest)
+	return nil
+}
+
 func (t *transfer) copyBlobWithRetry(srcRepo, dstRepo, digest string, sizeFromDescriptor int64, speed int32) error {
 	var err error
 	for i, backoff := 1, 2*time.Second; i <= blobRetryCnt; i, backoff = i+1, backoff*2 {
@@ -358,6 +532,24 @@ func (t *transfer) tryMountBlob(srcRepo, dstRepo, digest string) (bool, error) {
 	return false, nil
 }
 
+// copyBlobByStorage copies the blob by the server-side copy API of the storage, it returns false
+// if the blob isn't copied and should be copied through the jobservice instead
+func (t *transfer) copyBlobByStorage(ctx context.Context, srcRepo, dstRepo, digest string, size int64) (bool, error) {
+	mounted, err := t.tryMountBlob(srcRepo, dstRepo, digest)
+	if err != nil {
+		return false, err
+	}
+	if mounted {
+		return true, nil
+	}
+	if err = t.copier.Copy(ctx, srcRepo, dstRepo, digest, size); err != nil {
+		t.logger.Warningf("failed to copy the blob %s by the storage, copy it through the jobservice: %v", digest, err)
+		return false, nil
+	}
+	t.logger.Infof("the blob %s copied by the storage", digest)
+	return true, nil
+}
+
 // copy the layer or artifact config from the source registry to destination
 // the size parameter is taken from manifests.
 func (t *transfer) copyBlob(srcRepo, dstRepo, digest string, sizeFromDescriptor int64, speed int32) error {
@@ -395,6 +587,7 @@ func (t *transfer) copyBlobByMonolithic(srcRepo, dstRepo, digest string, sizeFro
 		t.logger.Errorf("failed to pushing the blob %s, size %d: %v", digest, size, err)
 		return err
 	}
+	metric.ReplicationTransferredBytes.Add(float64(size))
 
 	return nil
 }
@@ -448,6 +641,7 @@ func (t *transfer) copyBlobByChunk(srcRepo, dstRepo, digest string, sizeFromDesc
 		}
 
 		data.Close()
+		metric.ReplicationTransferredBytes.Add(float64(*end - *start + 1))
 
 		t.logger.Infof("copy the blob chunk: %d-%d/%d completed", *start, *end, sizeFromDescriptor)
 		// if the end equals (blobSize-1), that means it is last chunk, return if this is the last chunk
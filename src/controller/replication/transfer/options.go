@@ -14,6 +14,8 @@
 
 package transfer
 
+import "context"
+
 type Option func(*Options)
 
 type Options struct {
@@ -21,10 +23,18 @@ type Options struct {
 	Speed int32
 	// CopyByChunk defines whether need to copy the artifact blob by chunk, copy by whole blob by default.
 	CopyByChunk bool
+	// CopyByStorage defines whether to copy the blobs by the server-side copy API of the storage when it's possible.
+	CopyByStorage bool
+	// ForeignLayerMode defines how to handle the foreign layers, skip them by default.
+	ForeignLayerMode string
+	// Context is the context to trace the transfer, background context by default.
+	Context context.Context
+	// PolicyID is the ID of the replication policy, the blob transfers share the slots of the scheduler by it.
+	PolicyID int64
 }
 
 func NewOptions(opts ...Option) *Options {
-	o := &Options{}
+	o := &Options{Context: context.Background()}
 	for _, opt := range opts {
 		opt(o)
 	}
@@ -43,3 +53,27 @@ func WithCopyByChunk(copyByChunk bool) Option {
 		o.CopyByChunk = copyByChunk
 	}
 }
+
+func WithCopyByStorage(copyByStorage bool) Option {
+	return func(o *Options) {
+		o.CopyByStorage = copyByStorage
+	}
+}
+
+func WithForeignLayerMode(mode string) Option {
+	return func(o *Options) {
+		o.ForeignLayerMode = mode
+	}
+}
+
+func WithPolicyID(policyID int64) Option {
+	return func(o *Options) {
+		o.PolicyID = policyID
+	}
+}
+
+func WithContext(ctx context.Context) Option {
+	return func(o *Options) {
+		o.Context = ctx
+	}
+}
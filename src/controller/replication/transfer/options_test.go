@@ -15,6 +15,7 @@
 package transfer
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -25,13 +26,24 @@ func TestNewOptions(t *testing.T) {
 	o := NewOptions()
 	assert.Equal(t, int32(0), o.Speed)
 	assert.Equal(t, false, o.CopyByChunk)
+	assert.Equal(t, context.Background(), o.Context)
 
 	// test with options
 	// with speed
 	withSpeed := WithSpeed(1024)
 	// with copy by chunk
 	withCopyByChunk := WithCopyByChunk(true)
-	o = NewOptions(withSpeed, withCopyByChunk)
+	// with copy by storage
+	withCopyByStorage := WithCopyByStorage(true)
+	// with context
+	ctx := context.WithValue(context.Background(), struct{}{}, "value")
+	withContext := WithContext(ctx)
+	// with policy ID
+	withPolicyID := WithPolicyID(1)
+	o = NewOptions(withSpeed, withCopyByChunk, withCopyByStorage, withContext, withPolicyID)
 	assert.Equal(t, int32(1024), o.Speed)
 	assert.Equal(t, true, o.CopyByChunk)
+	assert.Equal(t, true, o.CopyByStorage)
+	assert.Equal(t, ctx, o.Context)
+	assert.Equal(t, int64(1), o.PolicyID)
 }
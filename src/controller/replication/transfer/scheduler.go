@@ -0,0 +1,180 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transfer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/goharbor/harbor/src/lib/metric"
+)
+
+// DefaultScheduler is the scheduler shared by all the replication jobs running in the jobservice
+var DefaultScheduler = NewScheduler(0)
+
+// Scheduler caps the in-flight blob transfers of all the replication jobs and shares the slots fairly
+// between the policies: a released slot is handed to the policies with waiting transfers in turn, so the
+// policy queuing the most transfers cannot starve the others
+type Scheduler struct {
+	lock sync.Mutex
+	// limit is the max in-flight transfers, no limit when it's less than or equal to 0
+	limit int
+	inUse int
+	// the waiting transfers of each policy and the order in which the policies get the slots
+	queues map[int64][]chan struct{}
+	order  []int64
+}
+
+// NewScheduler returns an instance of the Scheduler with the limit of the in-flight transfers
+func NewScheduler(limit int) *Scheduler {
+	s := &Scheduler{
+		limit:  limit,
+		queues: map[int64][]chan struct{}{},
+	}
+	s.report()
+	return s
+}
+
+// SetLimit changes the limit of the in-flight transfers at runtime, the waiting transfers are started
+// directly if the limit is raised, and the in-flight ones are never interrupted if it's lowered
+func (s *Scheduler) SetLimit(limit int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.limit == limit {
+		return
+	}
+	s.limit = limit
+	s.dispatch()
+}
+
+// Acquire a slot for the transfer of the policy, it blocks until a slot is available or the context
+// is done. The returned function must be called to release the slot once the transfer completes
+func (s *Scheduler) Acquire(ctx context.Context, policyID int64) (func(), error) {
+	s.lock.Lock()
+	// queue the transfer if others are waiting even though a slot is available to keep the fairness
+	if len(s.order) == 0 && s.available() {
+		s.inUse++
+		s.report()
+		s.lock.Unlock()
+		return s.releaseFunc(), nil
+	}
+	ch := make(chan struct{})
+	if len(s.queues[policyID]) == 0 {
+		s.order = append(s.order, policyID)
+	}
+	s.queues[policyID] = append(s.queues[policyID], ch)
+	s.report()
+	s.lock.Unlock()
+
+	select {
+	case <-ch:
+		return s.releaseFunc(), nil
+	case <-ctx.Done():
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		select {
+		case <-ch:
+			// the slot is granted just before the context is done, give it back
+			s.release()
+		default:
+			s.remove(policyID, ch)
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// Stats returns the limit, the count of the in-flight and the waiting transfers
+func (s *Scheduler) Stats() (limit, inUse, waiting int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.limit, s.inUse, s.waiting()
+}
+
+func (s *Scheduler) releaseFunc() func() {
+	once := sync.Once{}
+	return func() {
+		once.Do(func() {
+			s.lock.Lock()
+			defer s.lock.Unlock()
+			s.release()
+		})
+	}
+}
+
+func (s *Scheduler) release() {
+	s.inUse--
+	s.dispatch()
+}
+
+func (s *Scheduler) available() bool {
+	return s.limit <= 0 || s.inUse < s.limit
+}
+
+// dispatch hands the available slots to the waiting transfers of the policies in turn
+func (s *Scheduler) dispatch() {
+	for len(s.order) > 0 && s.available() {
+		policyID := s.order[0]
+		s.order = s.order[1:]
+		queue := s.queues[policyID]
+		close(queue[0])
+		s.inUse++
+		if len(queue) > 1 {
+			s.queues[policyID] = queue[1:]
+			// move the policy to the end of the order
+			s.order = append(s.order, policyID)
+		} else {
+			delete(s.queues, policyID)
+		}
+	}
+	s.report()
+}
+
+// remove the waiting transfer whose context is done
+func (s *Scheduler) remove(policyID int64, ch chan struct{}) {
+	queue := s.queues[policyID]
+	for i := range queue {
+		if queue[i] == ch {
+			queue = append(queue[:i], queue[i+1:]...)
+			break
+		}
+	}
+	if len(queue) > 0 {
+		s.queues[policyID] = queue
+	} else {
+		delete(s.queues, policyID)
+		for i := range s.order {
+			if s.order[i] == policyID {
+				s.order = append(s.order[:i], s.order[i+1:]...)
+				break
+			}
+		}
+	}
+	s.report()
+}
+
+func (s *Scheduler) waiting() int {
+	count := 0
+	for _, queue := range s.queues {
+		count += len(queue)
+	}
+	return count
+}
+
+// report the usage of the slots to the metrics
+func (s *Scheduler) report() {
+	metric.ReplicationTransferSlots.WithLabelValues("limit").Set(float64(s.limit))
+	metric.ReplicationTransferSlots.WithLabelValues("in_use").Set(float64(s.inUse))
+	metric.ReplicationTransferSlots.WithLabelValues("waiting").Set(float64(s.waiting()))
+}
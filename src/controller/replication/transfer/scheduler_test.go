@@ -0,0 +1,160 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transfer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type schedulerTestSuite struct {
+	suite.Suite
+}
+
+func (s *schedulerTestSuite) TestNoLimit() {
+	scheduler := NewScheduler(0)
+	var releases []func()
+	for i := 0; i < 10; i++ {
+		release, err := scheduler.Acquire(context.Background(), 1)
+		s.Require().Nil(err)
+		releases = append(releases, release)
+	}
+	_, inUse, waiting := scheduler.Stats()
+	s.Equal(10, inUse)
+	s.Equal(0, waiting)
+
+	for _, release := range releases {
+		release()
+	}
+	_, inUse, _ = scheduler.Stats()
+	s.Equal(0, inUse)
+}
+
+func (s *schedulerTestSuite) TestLimit() {
+	scheduler := NewScheduler(1)
+	release, err := scheduler.Acquire(context.Background(), 1)
+	s.Require().Nil(err)
+
+	acquired := make(chan func())
+	go func() {
+		r, _ := scheduler.Acquire(context.Background(), 1)
+		acquired <- r
+	}()
+	s.waitFor(scheduler, 1)
+	select {
+	case <-acquired:
+		s.Fail("the slot shouldn't be acquired before it's released")
+	default:
+	}
+
+	// release twice only gives back one slot
+	release()
+	release()
+	r := <-acquired
+	limit, inUse, waiting := scheduler.Stats()
+	s.Equal(1, limit)
+	s.Equal(1, inUse)
+	s.Equal(0, waiting)
+	r()
+}
+
+func (s *schedulerTestSuite) TestFairness() {
+	scheduler := NewScheduler(1)
+	release, err := scheduler.Acquire(context.Background(), 1)
+	s.Require().Nil(err)
+
+	// policy 1 queues 3 transfers before policy 2 queues one
+	order := make(chan int64, 4)
+	acquire := func(policyID int64) {
+		r, err := scheduler.Acquire(context.Background(), policyID)
+		if err == nil {
+			order <- policyID
+			r()
+		}
+	}
+	for i := 0; i < 3; i++ {
+		go acquire(1)
+		s.waitFor(scheduler, i+1)
+	}
+	go acquire(2)
+	s.waitFor(scheduler, 4)
+
+	// the slots are handed to the policies in turn
+	release()
+	var got []int64
+	for i := 0; i < 4; i++ {
+		got = append(got, <-order)
+	}
+	s.Equal([]int64{1, 2, 1, 1}, got)
+}
+
+func (s *schedulerTestSuite) TestSetLimit() {
+	scheduler := NewScheduler(1)
+	release, err := scheduler.Acquire(context.Background(), 1)
+	s.Require().Nil(err)
+	defer release()
+
+	acquired := make(chan func())
+	go func() {
+		r, _ := scheduler.Acquire(context.Background(), 2)
+		acquired <- r
+	}()
+	s.waitFor(scheduler, 1)
+
+	// raise the limit starts the waiting transfer
+	scheduler.SetLimit(2)
+	r := <-acquired
+	defer r()
+	limit, inUse, waiting := scheduler.Stats()
+	s.Equal(2, limit)
+	s.Equal(2, inUse)
+	s.Equal(0, waiting)
+}
+
+func (s *schedulerTestSuite) TestContextDone() {
+	scheduler := NewScheduler(1)
+	release, err := scheduler.Acquire(context.Background(), 1)
+	s.Require().Nil(err)
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error)
+	go func() {
+		_, err := scheduler.Acquire(ctx, 2)
+		errs <- err
+	}()
+	s.waitFor(scheduler, 1)
+	cancel()
+	s.ErrorIs(<-errs, context.Canceled)
+
+	_, inUse, waiting := scheduler.Stats()
+	s.Equal(1, inUse)
+	s.Equal(0, waiting)
+}
+
+// waitFor waits until the count of the waiting transfers reaches the expected one
+func (s *schedulerTestSuite) waitFor(scheduler *Scheduler, expected int) {
+	s.Eventually(func() bool {
+		_, _, waiting := scheduler.Stats()
+		return waiting == expected
+	}, time.Second, time.Millisecond)
+}
+
+func TestSchedulerTestSuite(t *testing.T) {
+	suite.Run(t, &schedulerTestSuite{})
+}
@@ -0,0 +1,153 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goharbor/harbor/src/controller/replication/model"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	regmodel "github.com/goharbor/harbor/src/pkg/reg/model"
+)
+
+func (c *controller) ValidatePolicy(ctx context.Context, policy *model.Policy) ([]*model.Finding, error) {
+	findings := []*model.Finding{}
+	report := func(field, severity, format string, args ...interface{}) {
+		findings = append(findings, &model.Finding{
+			Field:    field,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	// the syntax of the policy
+	invalid := map[string]bool{}
+	for _, e := range policy.ValidateFields() {
+		invalid[e.Field] = true
+		report(e.Field, model.FindingSeverityError, "%s", e.Err.Error())
+	}
+	// the remote registry cannot be determined, skip the checks against it
+	if invalid["registry"] {
+		return findings, nil
+	}
+
+	// one and only one of the source and destination registries is the remote one
+	field, pull := "dest_registry", false
+	remoteID := policy.DestRegistry.ID
+	if policy.SrcRegistry != nil && policy.SrcRegistry.ID != 0 {
+		field, pull = "src_registry", true
+		remoteID = policy.SrcRegistry.ID
+	}
+	registry, err := c.regMgr.Get(ctx, remoteID)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			report(field, model.FindingSeverityError, "the registry %d doesn't exist", remoteID)
+			return findings, nil
+		}
+		return nil, err
+	}
+
+	// the credential of the remote registry
+	if registry.Credential == nil || len(registry.Credential.AccessKey) == 0 && len(registry.Credential.AccessSecret) == 0 {
+		if pull {
+			report(field, model.FindingSeverityWarning, "no credential is configured for the registry %s, only the public resources can be pulled from it", registry.Name)
+		} else {
+			report(field, model.FindingSeverityWarning, "no credential is configured for the registry %s, most registries reject the anonymous pushes", registry.Name)
+		}
+	}
+	adapter, err := c.regMgr.CreateAdapter(ctx, registry)
+	if err != nil {
+		report(field, model.FindingSeverityError, "failed to create the adapter for the registry %s: %v", registry.Name, err)
+		return findings, nil
+	}
+	status, err := adapter.HealthCheck()
+	if err != nil || status != regmodel.Healthy {
+		log.G(ctx).Debugf("the registry %s is unhealthy, status: %s, error: %v", registry.Name, status, err)
+		report(field, model.FindingSeverityError, "the registry %s is unreachable or rejects the configured credential, check the endpoint and the credential of the registry", registry.Name)
+	}
+
+	// the capabilities of the remote registry
+	info, err := adapter.Info()
+	if err != nil {
+		report(field, model.FindingSeverityError, "failed to get the capabilities of the registry %s: %v", registry.Name, err)
+		return findings, nil
+	}
+	for i, filter := range policy.Filters {
+		filterField := fmt.Sprintf("filters[%d]", i)
+		if invalid[filterField] {
+			continue
+		}
+		if filter.Type == regmodel.FilterTypeResource {
+			resourceType := filter.Value.(string)
+			if !supportsResourceType(info, resourceType) {
+				report(filterField, model.FindingSeverityError, "the registry %s doesn't support the resource type %s", registry.Name, resourceType)
+			}
+			continue
+		}
+		// the filters only take effect when fetching the resources from the remote registry
+		if pull && !supportsFilter(info, filter.Type) {
+			report(filterField, model.FindingSeverityWarning, "the registry %s doesn't support the %s filter, it may be ignored", registry.Name, filter.Type)
+		}
+	}
+	if pull && policy.Trigger != nil && !invalid["trigger"] && !contains(info.SupportedTriggers, policy.Trigger.Type) {
+		report("trigger", model.FindingSeverityError, "the registry %s doesn't support the %s trigger", registry.Name, policy.Trigger.Type)
+	}
+	if policy.CopyByChunk && !info.SupportedCopyByChunk {
+		report("copy_by_chunk", model.FindingSeverityError, "the registry %s doesn't support copying the blobs by chunk", registry.Name)
+	}
+	if policy.CopyByStorage {
+		local, err := c.regMgr.Get(ctx, 0)
+		if err != nil {
+			return nil, err
+		}
+		if !registry.Storage.SameProvider(local.Storage) {
+			report("copy_by_storage", model.FindingSeverityWarning, "the storages of the local registry and the registry %s aren't declared in the same provider, the blobs will be copied through the jobservice", registry.Name)
+		}
+	}
+	return findings, nil
+}
+
+// the image and the artifact are both fetched as artifacts during the replication
+func supportsResourceType(info *regmodel.RegistryInfo, resourceType string) bool {
+	for _, t := range info.SupportedResourceTypes {
+		if t == resourceType {
+			return true
+		}
+		if resourceType != regmodel.ResourceTypeChart && t != regmodel.ResourceTypeChart {
+			return true
+		}
+	}
+	return false
+}
+
+func supportsFilter(info *regmodel.RegistryInfo, filterType string) bool {
+	for _, style := range info.SupportedResourceFilters {
+		if style.Type == filterType {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"errors"
+
+	repctlmodel "github.com/goharbor/harbor/src/controller/replication/model"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	testingadapter "github.com/goharbor/harbor/src/testing/pkg/reg/adapter"
+)
+
+func (r *replicationTestSuite) TestValidatePolicy() {
+	// invalid syntax, the registries are not checked
+	findings, err := r.ctl.ValidatePolicy(nil, &repctlmodel.Policy{
+		SrcRegistry:  &model.Registry{ID: 0},
+		DestRegistry: &model.Registry{ID: 0},
+	})
+	r.Require().Nil(err)
+	r.Require().Len(findings, 2)
+	r.Equal("name", findings[0].Field)
+	r.Equal("registry", findings[1].Field)
+	r.Equal(repctlmodel.FindingSeverityError, findings[1].Severity)
+	r.regMgr.AssertExpectations(r.T())
+
+	// the destination registry is unreachable and doesn't support charts
+	adapter := &testingadapter.Adapter{}
+	adapter.On("HealthCheck").Return("", errors.New("unauthorized"))
+	adapter.On("Info").Return(&model.RegistryInfo{
+		SupportedResourceTypes: []string{model.ResourceTypeImage},
+	}, nil)
+	mock.OnAnything(r.regMgr, "Get").Return(&model.Registry{
+		ID:   1,
+		Name: "remote",
+		Credential: &model.Credential{
+			AccessKey:    "admin",
+			AccessSecret: "secret",
+		},
+	}, nil)
+	mock.OnAnything(r.regMgr, "CreateAdapter").Return(adapter, nil)
+	findings, err = r.ctl.ValidatePolicy(nil, &repctlmodel.Policy{
+		Name:         "policy",
+		SrcRegistry:  &model.Registry{ID: 0},
+		DestRegistry: &model.Registry{ID: 1},
+		Filters: []*model.Filter{
+			{
+				Type:  model.FilterTypeResource,
+				Value: model.ResourceTypeChart,
+			},
+			{
+				Type:  model.FilterTypeName,
+				Value: "library/**",
+			},
+		},
+		Trigger: &model.Trigger{
+			Type: model.TriggerTypeEventBased,
+		},
+		CopyByChunk: true,
+	})
+	r.Require().Nil(err)
+	r.Require().Len(findings, 3)
+	r.Equal("dest_registry", findings[0].Field)
+	r.Equal("filters[0]", findings[1].Field)
+	r.Equal("copy_by_chunk", findings[2].Field)
+	r.regMgr.AssertExpectations(r.T())
+	adapter.AssertExpectations(r.T())
+}
+
+func (r *replicationTestSuite) TestValidatePullPolicy() {
+	adapter := &testingadapter.Adapter{}
+	adapter.On("HealthCheck").Return(model.Healthy, nil)
+	adapter.On("Info").Return(&model.RegistryInfo{
+		SupportedResourceTypes: []string{model.ResourceTypeImage},
+		SupportedResourceFilters: []*model.FilterStyle{
+			{
+				Type: model.FilterTypeName,
+			},
+		},
+		SupportedTriggers: []string{model.TriggerTypeManual, model.TriggerTypeScheduled},
+	}, nil)
+	mock.OnAnything(r.regMgr, "Get").Return(&model.Registry{
+		ID:   1,
+		Name: "remote",
+	}, nil)
+	mock.OnAnything(r.regMgr, "CreateAdapter").Return(adapter, nil)
+	findings, err := r.ctl.ValidatePolicy(nil, &repctlmodel.Policy{
+		Name:         "policy",
+		SrcRegistry:  &model.Registry{ID: 1},
+		DestRegistry: &model.Registry{ID: 0},
+		Filters: []*model.Filter{
+			{
+				Type:  model.FilterTypeResource,
+				Value: model.ResourceTypeArtifact,
+			},
+			{
+				Type:  model.FilterTypeName,
+				Value: "library/**",
+			},
+			{
+				Type:  model.FilterTypeLabel,
+				Value: []interface{}{"prod"},
+			},
+		},
+		Trigger: &model.Trigger{
+			Type: model.TriggerTypeEventBased,
+		},
+		CopyByStorage: true,
+	})
+	r.Require().Nil(err)
+	r.Require().Len(findings, 4)
+	r.Equal("src_registry", findings[0].Field)
+	r.Equal(repctlmodel.FindingSeverityWarning, findings[0].Severity)
+	r.Equal("filters[2]", findings[1].Field)
+	r.Equal(repctlmodel.FindingSeverityWarning, findings[1].Severity)
+	r.Equal("trigger", findings[2].Field)
+	r.Equal(repctlmodel.FindingSeverityError, findings[2].Severity)
+	// the storages of the registries aren't declared
+	r.Equal("copy_by_storage", findings[3].Field)
+	r.Equal(repctlmodel.FindingSeverityWarning, findings[3].Severity)
+	r.regMgr.AssertExpectations(r.T())
+	adapter.AssertExpectations(r.T())
+}
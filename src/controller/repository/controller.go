@@ -16,6 +16,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/controller/artifact"
@@ -28,6 +29,7 @@ import (
 	"github.com/goharbor/harbor/src/pkg/project"
 	"github.com/goharbor/harbor/src/pkg/repository"
 	"github.com/goharbor/harbor/src/pkg/repository/model"
+	"github.com/goharbor/harbor/src/pkg/task"
 )
 
 var (
@@ -51,10 +53,24 @@ type Controller interface {
 	GetByName(ctx context.Context, name string) (repository *model.RepoRecord, err error)
 	// Delete the repository specified by ID
 	Delete(ctx context.Context, id int64) (err error)
+	// DeleteInBatches deletes the artifacts under the repository specified by ID and then the repository itself,
+	// the "progress" is called with the count of the deleted artifacts, the total count and the artifacts deleted
+	// since the last call every time "batchSize" artifacts are deleted and when all are deleted, the deletion
+	// aborts if it returns an error
+	DeleteInBatches(ctx context.Context, id int64, batchSize int, progress func(progress *DeletionProgress) error) (err error)
+	// DeleteAsync submits a job to delete the repository specified by ID in background and returns the ID of
+	// the deletion, the ongoing deletion is returned if the repository is being deleted
+	DeleteAsync(ctx context.Context, id int64) (deletionID int64, err error)
+	// GetDeletion returns the background deletion specified by ID
+	GetDeletion(ctx context.Context, id int64) (deletion *Deletion, err error)
 	// Update the repository. Specify the properties or all properties will be updated
 	Update(ctx context.Context, repository *model.RepoRecord, properties ...string) (err error)
 	// AddPullCount increase pull count for the specified repository
 	AddPullCount(ctx context.Context, id int64, count uint64) error
+	// UpdatePullTime updates the pull time of the specified repository if the provided one is later
+	UpdatePullTime(ctx context.Context, id int64, pullTime time.Time) error
+	// UpdatePushTime updates the push time of the specified repository
+	UpdatePushTime(ctx context.Context, id int64, pushTime time.Time) error
 }
 
 // NewController creates an instance of the default repository controller
@@ -64,6 +80,8 @@ func NewController() Controller {
 		repoMgr: pkg.RepositoryMgr,
 		artMgr:  pkg.ArtifactMgr,
 		artCtl:  artifact.Ctl,
+		exeMgr:  task.ExecMgr,
+		taskMgr: task.Mgr,
 	}
 }
 
@@ -72,6 +90,8 @@ type controller struct {
 	repoMgr repository.Manager
 	artMgr  art.Manager
 	artCtl  artifact.Controller
+	exeMgr  task.ExecutionManager
+	taskMgr task.Manager
 }
 
 func (c *controller) Ensure(ctx context.Context, name string) (bool, int64, error) {
@@ -142,14 +162,39 @@ func (c *controller) GetByName(ctx context.Context, name string) (*model.RepoRec
 }
 
 func (c *controller) Delete(ctx context.Context, id int64) error {
+	return c.DeleteInBatches(ctx, id, 0, nil)
+}
+
+func (c *controller) DeleteInBatches(ctx context.Context, id int64, batchSize int, progress func(progress *DeletionProgress) error) error {
+	var option *artifact.Option
+	if progress != nil {
+		// the tags are reported with the deleted artifacts
+		option = &artifact.Option{WithTag: true}
+	}
 	candidates, err := c.artCtl.List(ctx, &q.Query{
 		Keywords: map[string]interface{}{
 			"RepositoryID": id,
 		},
-	}, nil)
+	}, option)
 	if err != nil {
 		return err
 	}
+	total, deleted := len(candidates), 0
+	// the artifacts deleted since the last report, they are reported with the progress as the
+	// deletion events of them cannot be sent by the process running the deletion job
+	var pending []*DeletedArtifact
+	report := func() error {
+		if progress == nil {
+			return nil
+		}
+		p := &DeletionProgress{
+			Total:     total,
+			Deleted:   deleted,
+			Artifacts: pending,
+		}
+		pending = nil
+		return progress(p)
+	}
 	for len(candidates) > 0 {
 		artifacts := candidates
 		candidates = nil
@@ -171,11 +216,29 @@ func (c *controller) Delete(ctx context.Context, id int64) error {
 				continue
 			}
 			if err = c.artCtl.Delete(ctx, artifact.ID); err != nil {
+				// report the artifacts already deleted
+				if len(pending) > 0 {
+					if e := report(); e != nil {
+						log.Errorf("failed to report the progress of the repository deletion: %v", e)
+					}
+				}
 				return err
 			}
 			log.Debugf("the artifact %d is deleted", artifact.ID)
+			deleted++
+			if progress != nil {
+				pending = append(pending, toDeletedArtifact(artifact))
+				if batchSize > 0 && deleted%batchSize == 0 {
+					if err = report(); err != nil {
+						return err
+					}
+				}
+			}
 		}
 	}
+	if err = report(); err != nil {
+		return err
+	}
 	return c.repoMgr.Delete(ctx, id)
 }
 
@@ -186,3 +249,17 @@ func (c *controller) Update(ctx context.Context, repository *model.RepoRecord, p
 func (c *controller) AddPullCount(ctx context.Context, id int64, count uint64) error {
 	return c.repoMgr.AddPullCount(ctx, id, count)
 }
+
+func (c *controller) UpdatePullTime(ctx context.Context, id int64, pullTime time.Time) error {
+	return c.repoMgr.UpdatePullTime(ctx, id, pullTime)
+}
+
+func (c *controller) UpdatePushTime(ctx context.Context, id int64, pushTime time.Time) error {
+	// get the repository first to clean up the caches by both ID and name
+	repository, err := c.repoMgr.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	repository.PushTime = pushTime
+	return c.repoMgr.Update(ctx, repository, "PushTime")
+}
@@ -15,21 +15,34 @@
 package repository
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
 	"github.com/goharbor/harbor/src/controller/artifact"
+	event2 "github.com/goharbor/harbor/src/controller/event"
+	"github.com/goharbor/harbor/src/controller/tag"
+	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/orm"
+	art "github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notifier/event"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	"github.com/goharbor/harbor/src/pkg/repository/model"
+	tagmodel "github.com/goharbor/harbor/src/pkg/tag/model/tag"
+	"github.com/goharbor/harbor/src/pkg/task"
 	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
 	ormtesting "github.com/goharbor/harbor/src/testing/lib/orm"
 	"github.com/goharbor/harbor/src/testing/mock"
 	arttesting "github.com/goharbor/harbor/src/testing/pkg/artifact"
 	"github.com/goharbor/harbor/src/testing/pkg/project"
 	"github.com/goharbor/harbor/src/testing/pkg/repository"
+	tasktesting "github.com/goharbor/harbor/src/testing/pkg/task"
 )
 
 type controllerTestSuite struct {
@@ -39,6 +52,8 @@ type controllerTestSuite struct {
 	repoMgr *repository.Manager
 	argMgr  *arttesting.Manager
 	artCtl  *artifacttesting.Controller
+	exeMgr  *tasktesting.ExecutionManager
+	taskMgr *tasktesting.Manager
 }
 
 func (c *controllerTestSuite) SetupTest() {
@@ -46,11 +61,15 @@ func (c *controllerTestSuite) SetupTest() {
 	c.repoMgr = &repository.Manager{}
 	c.argMgr = &arttesting.Manager{}
 	c.artCtl = &artifacttesting.Controller{}
+	c.exeMgr = &tasktesting.ExecutionManager{}
+	c.taskMgr = &tasktesting.Manager{}
 	c.ctl = &controller{
 		proMgr:  c.proMgr,
 		repoMgr: c.repoMgr,
 		artMgr:  c.argMgr,
 		artCtl:  c.artCtl,
+		exeMgr:  c.exeMgr,
+		taskMgr: c.taskMgr,
 	}
 }
 
@@ -134,6 +153,179 @@ func (c *controllerTestSuite) TestDelete() {
 	c.Require().Nil(err)
 }
 
+func (c *controllerTestSuite) TestDeleteInBatches() {
+	var arts []*artifact.Artifact
+	for i := 1; i <= 5; i++ {
+		art := &artifact.Artifact{}
+		art.ID = int64(i)
+		art.ExtraAttrs = map[string]interface{}{"os": "linux"}
+		art.Tags = []*tag.Tag{{Tag: tagmodel.Tag{Name: fmt.Sprintf("v%d", i)}}}
+		arts = append(arts, art)
+	}
+	mock.OnAnything(c.argMgr, "ListReferences").Return(nil, nil)
+	mock.OnAnything(c.artCtl, "List").Return(arts, nil)
+	mock.OnAnything(c.artCtl, "Delete").Return(nil)
+	c.repoMgr.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	var progresses [][2]int
+	var deleted []*DeletedArtifact
+	err := c.ctl.DeleteInBatches(nil, 1, 2, func(progress *DeletionProgress) error {
+		progresses = append(progresses, [2]int{progress.Deleted, progress.Total})
+		deleted = append(deleted, progress.Artifacts...)
+		return nil
+	})
+	c.Require().Nil(err)
+	c.Equal([][2]int{{2, 5}, {4, 5}, {5, 5}}, progresses)
+	c.artCtl.AssertNumberOfCalls(c.T(), "Delete", 5)
+	// all the deleted artifacts are reported once with their tags
+	c.Require().Len(deleted, 5)
+	c.Equal(int64(1), deleted[0].Artifact.ID)
+	c.Equal([]string{"v1"}, deleted[0].Tags)
+	c.Nil(deleted[0].Artifact.ExtraAttrs)
+	c.Equal(int64(5), deleted[4].Artifact.ID)
+
+	// the artifacts deleted before the failure are reported
+	c.SetupTest()
+	mock.OnAnything(c.argMgr, "ListReferences").Return(nil, nil)
+	mock.OnAnything(c.artCtl, "List").Return(arts, nil)
+	c.artCtl.On("Delete", mock.Anything, int64(3)).Return(errors.New("failed"))
+	mock.OnAnything(c.artCtl, "Delete").Return(nil)
+	deleted = nil
+	err = c.ctl.DeleteInBatches(nil, 1, 10, func(progress *DeletionProgress) error {
+		deleted = append(deleted, progress.Artifacts...)
+		return nil
+	})
+	c.Require().NotNil(err)
+	c.Require().Len(deleted, 2)
+	c.repoMgr.AssertNotCalled(c.T(), "Delete", mock.Anything, mock.Anything)
+
+	// abort the deletion when the progress returns error
+	c.SetupTest()
+	mock.OnAnything(c.argMgr, "ListReferences").Return(nil, nil)
+	mock.OnAnything(c.artCtl, "List").Return(arts, nil)
+	mock.OnAnything(c.artCtl, "Delete").Return(nil)
+	err = c.ctl.DeleteInBatches(nil, 1, 2, func(progress *DeletionProgress) error {
+		return errors.New("stopped")
+	})
+	c.Require().NotNil(err)
+	c.artCtl.AssertNumberOfCalls(c.T(), "Delete", 2)
+	c.repoMgr.AssertNotCalled(c.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (c *controllerTestSuite) TestDeleteAsync() {
+	c.repoMgr.On("Get", mock.Anything, int64(1)).Return(&model.RepoRecord{
+		RepositoryID: 1,
+		ProjectID:    1,
+		Name:         "library/hello-world",
+	}, nil)
+	// the repository is being deleted
+	mock.OnAnything(c.exeMgr, "List").Return([]*task.Execution{{ID: 2, Status: job.RunningStatus.String()}}, nil).Once()
+	id, err := c.ctl.DeleteAsync(context.TODO(), 1)
+	c.Require().Nil(err)
+	c.Equal(int64(2), id)
+	c.exeMgr.AssertNotCalled(c.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// submit a new deletion
+	mock.OnAnything(c.exeMgr, "List").Return([]*task.Execution{{ID: 2, Status: job.ErrorStatus.String()}}, nil).Once()
+	mock.OnAnything(c.exeMgr, "Create").Return(int64(3), nil)
+	mock.OnAnything(c.taskMgr, "Create").Return(int64(1), nil)
+	id, err = c.ctl.DeleteAsync(context.TODO(), 1)
+	c.Require().Nil(err)
+	c.Equal(int64(3), id)
+	c.exeMgr.AssertExpectations(c.T())
+	c.taskMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestDeleteAsyncSubmitFailure() {
+	c.repoMgr.On("Get", mock.Anything, int64(1)).Return(&model.RepoRecord{
+		RepositoryID: 1,
+		ProjectID:    1,
+		Name:         "library/hello-world",
+	}, nil)
+	mock.OnAnything(c.exeMgr, "List").Return(nil, nil)
+	mock.OnAnything(c.exeMgr, "Create").Return(int64(3), nil)
+	mock.OnAnything(c.taskMgr, "Create").Return(int64(0), errors.New("failed to submit"))
+	c.exeMgr.On("MarkError", mock.Anything, int64(3), "failed to submit").Return(nil)
+	_, err := c.ctl.DeleteAsync(context.TODO(), 1)
+	c.Require().NotNil(err)
+	// the execution is marked as error to not block the following deletions
+	c.exeMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestDeletionCheckInProcessor() {
+	execMgr, taskMgr := task.ExecMgr, task.Mgr
+	defer func() {
+		task.ExecMgr, task.Mgr = execMgr, taskMgr
+	}()
+	task.ExecMgr, task.Mgr = c.exeMgr, c.taskMgr
+	c.taskMgr.On("UpdateExtraAttrs", mock.Anything, int64(1), mock.Anything).Return(nil)
+	c.exeMgr.On("Get", mock.Anything, int64(2)).Return(&task.Execution{
+		ID: 2,
+		ExtraAttrs: map[string]interface{}{
+			"operator": "admin",
+		},
+	}, nil)
+
+	progress := &DeletionProgress{
+		Total:   2,
+		Deleted: 2,
+		Artifacts: []*DeletedArtifact{
+			{Artifact: &art.Artifact{ID: 1, RepositoryName: "library/hello-world"}, Tags: []string{"latest"}},
+			{Artifact: &art.Artifact{ID: 2, RepositoryName: "library/hello-world"}},
+		},
+	}
+	data, err := json.Marshal(progress)
+	c.Require().Nil(err)
+	evc := notification.NewEventCtx()
+	ctx := notification.NewContext(context.TODO(), evc)
+	err = deletionCheckInProcessor(ctx, &task.Task{ID: 1, ExecutionID: 2}, &job.StatusChange{CheckIn: string(data)})
+	c.Require().Nil(err)
+
+	// the deletion events of the artifacts deleted by the job are added to be published by core
+	c.Require().Equal(2, evc.Events.Len())
+	e := &event.Event{}
+	c.Require().Nil(evc.Events.Front().Value.(event.Metadata).Resolve(e))
+	data2, ok := e.Data.(*event2.DeleteArtifactEvent)
+	c.Require().True(ok)
+	c.Equal(int64(1), data2.Artifact.ID)
+	c.Equal([]string{"latest"}, data2.Tags)
+	c.Equal("admin", data2.Operator)
+}
+
+func (c *controllerTestSuite) TestGetDeletion() {
+	// not a repository deletion
+	mock.OnAnything(c.exeMgr, "Get").Return(&task.Execution{ID: 1, VendorType: "GARBAGE_COLLECTION"}, nil).Once()
+	_, err := c.ctl.GetDeletion(nil, 1)
+	c.Require().NotNil(err)
+	c.True(errors.IsNotFoundErr(err))
+
+	mock.OnAnything(c.exeMgr, "Get").Return(&task.Execution{
+		ID:         2,
+		VendorType: job.RepositoryDeletion,
+		VendorID:   1,
+		Status:     job.RunningStatus.String(),
+		ExtraAttrs: map[string]interface{}{
+			"repository_name": "library/hello-world",
+			"project_id":      float64(1),
+		},
+	}, nil).Once()
+	mock.OnAnything(c.taskMgr, "List").Return([]*task.Task{{
+		ID: 1,
+		ExtraAttrs: map[string]interface{}{
+			"total":   float64(10),
+			"deleted": float64(4),
+		},
+	}}, nil)
+	deletion, err := c.ctl.GetDeletion(nil, 2)
+	c.Require().Nil(err)
+	c.Equal(int64(2), deletion.ID)
+	c.Equal(int64(1), deletion.RepositoryID)
+	c.Equal("library/hello-world", deletion.RepositoryName)
+	c.Equal(int64(1), deletion.ProjectID)
+	c.Equal(job.RunningStatus.String(), deletion.Status)
+	c.Equal(10, deletion.Total)
+	c.Equal(4, deletion.Deleted)
+}
+
 func (c *controllerTestSuite) TestUpdate() {
 	c.repoMgr.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	err := c.ctl.Update(nil, &model.RepoRecord{
@@ -149,6 +341,23 @@ func (c *controllerTestSuite) TestAddPullCount() {
 	c.Require().Nil(err)
 }
 
+func (c *controllerTestSuite) TestUpdatePullTime() {
+	c.repoMgr.On("UpdatePullTime", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	err := c.ctl.UpdatePullTime(nil, 1, time.Now())
+	c.Require().Nil(err)
+}
+
+func (c *controllerTestSuite) TestUpdatePushTime() {
+	pushTime := time.Now()
+	c.repoMgr.On("Get", mock.Anything, int64(1)).Return(&model.RepoRecord{RepositoryID: 1, Name: "library/hello-world"}, nil)
+	c.repoMgr.On("Update", mock.Anything, mock.Anything, "PushTime").Return(nil)
+	err := c.ctl.UpdatePushTime(nil, 1, pushTime)
+	c.Require().Nil(err)
+	repository := c.repoMgr.Calls[1].Arguments.Get(1).(*model.RepoRecord)
+	c.Equal("library/hello-world", repository.Name)
+	c.Equal(pushTime, repository.PushTime)
+}
+
 func TestControllerTestSuite(t *testing.T) {
 	suite.Run(t, &controllerTestSuite{})
 }
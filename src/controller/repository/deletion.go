@@ -0,0 +1,261 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	art "github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+const (
+	// DeletionBatchSize is the default count of the artifacts deleted in one batch by the deletion job
+	DeletionBatchSize = 100
+
+	deletionAttrRepositoryName = "repository_name"
+	deletionAttrProjectID      = "project_id"
+	deletionAttrOperator       = "operator"
+	deletionAttrTotal          = "total"
+	deletionAttrDeleted        = "deleted"
+
+	// DeletionParamRepositoryID is the job parameter of the ID of the repository to be deleted
+	DeletionParamRepositoryID = "repository_id"
+	// DeletionParamBatchSize is the job parameter of the count of the artifacts deleted in one batch
+	DeletionParamBatchSize = "batch_size"
+)
+
+func init() {
+	if err := task.RegisterCheckInProcessor(job.RepositoryDeletion, deletionCheckInProcessor); err != nil {
+		log.Fatalf("failed to register the checkin processor for the repository deletion job, error %v", err)
+	}
+	if err := task.RegisterTaskStatusChangePostFunc(job.RepositoryDeletion, deletionTaskStatusChange); err != nil {
+		log.Fatalf("failed to register the task status change post for the repository deletion job, error %v", err)
+	}
+}
+
+// DeletionProgress is the progress checked in by the repository deletion job
+type DeletionProgress struct {
+	Total   int `json:"total"`
+	Deleted int `json:"deleted"`
+	// Artifacts are the artifacts deleted since the last check in, the deletion events of them
+	// are fired by core when the check in is processed
+	Artifacts []*DeletedArtifact `json:"artifacts,omitempty"`
+}
+
+// DeletedArtifact is the artifact deleted by the repository deletion job
+type DeletedArtifact struct {
+	Artifact *art.Artifact `json:"artifact"`
+	Tags     []string      `json:"tags,omitempty"`
+}
+
+func toDeletedArtifact(artifact *artifact.Artifact) *DeletedArtifact {
+	a := artifact.Artifact
+	// the attributes not carried by the deletion event are dropped to keep the check in small
+	a.ExtraAttrs = nil
+	a.Annotations = nil
+	a.References = nil
+	deleted := &DeletedArtifact{Artifact: &a}
+	for _, tag := range artifact.Tags {
+		deleted.Tags = append(deleted.Tags, tag.Name)
+	}
+	return deleted
+}
+
+// Deletion is the background deletion of the repository
+type Deletion struct {
+	ID             int64
+	RepositoryID   int64
+	RepositoryName string
+	ProjectID      int64
+	Status         string
+	StatusMessage  string
+	Total          int
+	Deleted        int
+	StartTime      time.Time
+	EndTime        time.Time
+}
+
+// DeleteAsync ...
+func (c *controller) DeleteAsync(ctx context.Context, id int64) (int64, error) {
+	repository, err := c.repoMgr.Get(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	// return the deletion directly if the repository is being deleted
+	execs, err := c.exeMgr.List(ctx, &q.Query{
+		Keywords: map[string]interface{}{
+			"VendorType": job.RepositoryDeletion,
+			"VendorID":   id,
+		},
+		Sorts:    []*q.Sort{q.NewSort("ID", true)},
+		PageSize: 1,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(execs) > 0 && !job.Status(execs[0].Status).Final() {
+		return execs[0].ID, nil
+	}
+
+	attrs := map[string]interface{}{
+		deletionAttrRepositoryName: repository.Name,
+		deletionAttrProjectID:      repository.ProjectID,
+	}
+	if sc, ok := security.FromContext(ctx); ok {
+		attrs[deletionAttrOperator] = sc.GetUsername()
+	}
+	execID, err := c.exeMgr.Create(ctx, job.RepositoryDeletion, id, task.ExecutionTriggerManual, attrs)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = c.taskMgr.Create(ctx, execID, &task.Job{
+		Name: job.RepositoryDeletion,
+		Metadata: &job.Metadata{
+			JobKind: job.KindGeneric,
+		},
+		Parameters: map[string]interface{}{
+			DeletionParamRepositoryID: id,
+			DeletionParamBatchSize:    DeletionBatchSize,
+		},
+	}); err != nil {
+		// mark the execution as error, otherwise it blocks the following deletions of the repository
+		if e := c.exeMgr.MarkError(ctx, execID, err.Error()); e != nil {
+			log.Errorf("failed to mark the repository deletion %d as error: %v", execID, e)
+		}
+		return 0, err
+	}
+	return execID, nil
+}
+
+// GetDeletion ...
+func (c *controller) GetDeletion(ctx context.Context, id int64) (*Deletion, error) {
+	exec, err := c.exeMgr.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if exec.VendorType != job.RepositoryDeletion {
+		return nil, errors.NotFoundError(nil).WithMessage("repository deletion %d not found", id)
+	}
+	deletion := &Deletion{
+		ID:            exec.ID,
+		RepositoryID:  exec.VendorID,
+		Status:        exec.Status,
+		StatusMessage: exec.StatusMessage,
+		StartTime:     exec.StartTime,
+		EndTime:       exec.EndTime,
+	}
+	if name, ok := exec.ExtraAttrs[deletionAttrRepositoryName].(string); ok {
+		deletion.RepositoryName = name
+	}
+	if projectID, ok := exec.ExtraAttrs[deletionAttrProjectID].(float64); ok {
+		deletion.ProjectID = int64(projectID)
+	}
+
+	tasks, err := c.taskMgr.List(ctx, &q.Query{
+		Keywords: map[string]interface{}{
+			"ExecutionID": id,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) > 0 {
+		if total, ok := tasks[0].ExtraAttrs[deletionAttrTotal].(float64); ok {
+			deletion.Total = int(total)
+		}
+		if deleted, ok := tasks[0].ExtraAttrs[deletionAttrDeleted].(float64); ok {
+			deletion.Deleted = int(deleted)
+		}
+	}
+	return deletion, nil
+}
+
+func deletionCheckInProcessor(ctx context.Context, t *task.Task, sc *job.StatusChange) error {
+	if sc.CheckIn == "" {
+		return nil
+	}
+	progress := &DeletionProgress{}
+	if err := json.Unmarshal([]byte(sc.CheckIn), progress); err != nil {
+		log.Errorf("failed to resolve checkin of repository deletion task %d: %v", t.ID, err)
+		return err
+	}
+	if t.ExtraAttrs == nil {
+		t.ExtraAttrs = map[string]interface{}{}
+	}
+	t.ExtraAttrs[deletionAttrTotal] = progress.Total
+	t.ExtraAttrs[deletionAttrDeleted] = progress.Deleted
+	if err := task.Mgr.UpdateExtraAttrs(ctx, t.ID, t.ExtraAttrs); err != nil {
+		return err
+	}
+	if len(progress.Artifacts) == 0 {
+		return nil
+	}
+
+	// the artifact deletion events can only be handled by core, fire them for the artifacts deleted by the job
+	exec, err := task.ExecMgr.Get(ctx, t.ExecutionID)
+	if err != nil {
+		return err
+	}
+	operator, _ := exec.ExtraAttrs[deletionAttrOperator].(string)
+	for _, deleted := range progress.Artifacts {
+		if deleted.Artifact == nil {
+			continue
+		}
+		notification.AddEvent(ctx, &metadata.DeleteArtifactEventMetadata{
+			Ctx:      ctx,
+			Artifact: deleted.Artifact,
+			Tags:     deleted.Tags,
+			Operator: operator,
+		})
+	}
+	return nil
+}
+
+func deletionTaskStatusChange(ctx context.Context, taskID int64, status string) error {
+	// fire the repository deletion event when the deletion job completes successfully
+	if job.Status(status) != job.SuccessStatus {
+		return nil
+	}
+	t, err := task.Mgr.Get(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	exec, err := task.ExecMgr.Get(ctx, t.ExecutionID)
+	if err != nil {
+		return err
+	}
+	e := &metadata.DeleteRepositoryEventMetadata{
+		Ctx: ctx,
+	}
+	e.Repository, _ = exec.ExtraAttrs[deletionAttrRepositoryName].(string)
+	e.Operator, _ = exec.ExtraAttrs[deletionAttrOperator].(string)
+	if projectID, ok := exec.ExtraAttrs[deletionAttrProjectID].(float64); ok {
+		e.ProjectID = int64(projectID)
+	}
+	notification.AddEvent(ctx, e)
+	return nil
+}
@@ -17,12 +17,17 @@ package retention
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/goharbor/harbor/src/common/rbac"
+	event "github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/controller/event/operator"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/logger"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg"
+	"github.com/goharbor/harbor/src/pkg/notification"
 	"github.com/goharbor/harbor/src/pkg/project"
 	"github.com/goharbor/harbor/src/pkg/repository"
 	"github.com/goharbor/harbor/src/pkg/retention"
@@ -119,6 +124,8 @@ func (r *defaultController) CreateRetention(ctx context.Context, p *policy.Metad
 			}
 		}
 	}
+	p.ID = id
+	auditRetentionChange(ctx, id, rbac.ActionCreate, nil, p)
 
 	return id, nil
 }
@@ -179,6 +186,7 @@ func (r *defaultController) UpdateRetention(ctx context.Context, p *policy.Metad
 			return err
 		}
 	}
+	auditRetentionChange(ctx, p.ID, rbac.ActionUpdate, p0, p)
 
 	return nil
 }
@@ -200,7 +208,32 @@ func (r *defaultController) DeleteRetention(ctx context.Context, id int64) error
 	if err != nil {
 		return err
 	}
-	return r.manager.DeletePolicy(ctx, id)
+	if err = r.manager.DeletePolicy(ctx, id); err != nil {
+		return err
+	}
+	auditRetentionChange(ctx, id, rbac.ActionDelete, p, nil)
+	return nil
+}
+
+// auditRetentionChange records the change of the retention policy with the snapshots before and after the change
+func auditRetentionChange(ctx context.Context, id int64, action rbac.Action, before, after *policy.Metadata) {
+	p := after
+	if p == nil {
+		p = before
+	}
+	var projectID int64
+	if p != nil && p.Scope != nil && p.Scope.Level == policy.ScopeLevelProject {
+		projectID = p.Scope.Reference
+	}
+	notification.AddEvent(ctx, &event.ResourceChangeEventMetadata{
+		ProjectID:    projectID,
+		ResourceType: event.ResourceTypeRetentionPolicy,
+		Resource:     strconv.FormatInt(id, 10),
+		Operation:    action.String(),
+		Operator:     operator.FromContext(ctx),
+		Before:       before,
+		After:        after,
+	})
 }
 
 // deleteExecs delete executions
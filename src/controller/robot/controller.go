@@ -7,20 +7,26 @@ import (
 	"strconv"
 	"time"
 
+	rbac_common "github.com/goharbor/harbor/src/common/rbac"
 	rbac_project "github.com/goharbor/harbor/src/common/rbac/project"
 	"github.com/goharbor/harbor/src/common/utils"
+	event "github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/controller/event/operator"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/lib/retry"
 	"github.com/goharbor/harbor/src/pkg"
+	"github.com/goharbor/harbor/src/pkg/notification"
 	"github.com/goharbor/harbor/src/pkg/permission/types"
 	"github.com/goharbor/harbor/src/pkg/project"
 	"github.com/goharbor/harbor/src/pkg/rbac"
 	rbac_model "github.com/goharbor/harbor/src/pkg/rbac/model"
 	robot "github.com/goharbor/harbor/src/pkg/robot"
 	"github.com/goharbor/harbor/src/pkg/robot/model"
+	"github.com/goharbor/harbor/src/pkg/role"
+	role_model "github.com/goharbor/harbor/src/pkg/role/model"
 )
 
 var (
@@ -54,6 +60,7 @@ type controller struct {
 	robotMgr robot.Manager
 	proMgr   project.Manager
 	rbacMgr  rbac.Manager
+	roleMgr  role.Manager
 }
 
 // NewController ...
@@ -62,6 +69,7 @@ func NewController() Controller {
 		robotMgr: robot.Mgr,
 		proMgr:   pkg.ProjectMgr,
 		rbacMgr:  rbac.Mgr,
+		roleMgr:  role.Mgr,
 	}
 }
 
@@ -120,6 +128,8 @@ func (d *controller) Create(ctx context.Context, r *Robot) (int64, string, error
 		Duration:    r.Duration,
 		Salt:        salt,
 		Visible:     r.Visible,
+		IPAllowlist: r.IPAllowlist,
+		IPDenylist:  r.IPDenylist,
 	})
 	if err != nil {
 		return 0, "", err
@@ -128,17 +138,23 @@ func (d *controller) Create(ctx context.Context, r *Robot) (int64, string, error
 	if err := d.createPermission(ctx, r); err != nil {
 		return 0, "", err
 	}
+	auditChange(ctx, r.ProjectID, name, rbac_common.ActionCreate, nil, r)
 	return robotID, pwd, nil
 }
 
 // Delete ...
 func (d *controller) Delete(ctx context.Context, id int64) error {
+	before, err := d.Get(ctx, id, &Option{WithPermission: true})
+	if err != nil {
+		return err
+	}
 	if err := d.robotMgr.Delete(ctx, id); err != nil {
 		return err
 	}
 	if err := d.rbacMgr.DeletePermissionsByRole(ctx, ROBOTTYPE, id); err != nil {
 		return err
 	}
+	auditChange(ctx, before.ProjectID, before.Name, rbac_common.ActionDelete, before, nil)
 	return nil
 }
 
@@ -147,7 +163,11 @@ func (d *controller) Update(ctx context.Context, r *Robot, option *Option) error
 	if r == nil {
 		return errors.New("cannot update a nil robot").WithCode(errors.BadRequestCode)
 	}
-	if err := d.robotMgr.Update(ctx, &r.Robot, "secret", "description", "disabled", "duration", "expiresat"); err != nil {
+	before, err := d.Get(ctx, r.ID, &Option{WithPermission: true})
+	if err != nil {
+		return err
+	}
+	if err := d.robotMgr.Update(ctx, &r.Robot, "secret", "description", "disabled", "duration", "expiresat", "ip_allowlist", "ip_denylist"); err != nil {
 		return err
 	}
 	// update the permission
@@ -159,9 +179,24 @@ func (d *controller) Update(ctx context.Context, r *Robot, option *Option) error
 			return err
 		}
 	}
+	auditChange(ctx, r.ProjectID, r.Name, rbac_common.ActionUpdate, before, r)
 	return nil
 }
 
+// auditChange records the change of the robot account with the snapshots before and after the change,
+// the secret of the robot is redacted in the snapshots
+func auditChange(ctx context.Context, projectID int64, name string, action types.Action, before, after *Robot) {
+	notification.AddEvent(ctx, &event.ResourceChangeEventMetadata{
+		ProjectID:    projectID,
+		ResourceType: event.ResourceTypeRobot,
+		Resource:     name,
+		Operation:    action.String(),
+		Operator:     operator.FromContext(ctx),
+		Before:       before,
+		After:        after,
+	})
+}
+
 // List ...
 func (d *controller) List(ctx context.Context, query *q.Query, option *Option) ([]*Robot, error) {
 	robots, err := d.robotMgr.List(ctx, query)
@@ -192,6 +227,10 @@ func (d *controller) createPermission(ctx context.Context, r *Robot) error {
 		}
 		policy.Scope = scope
 
+		if err := d.expandRole(ctx, per); err != nil {
+			return err
+		}
+
 		for _, access := range per.Access {
 			policy.Resource = access.Resource.String()
 			policy.Action = access.Action.String()
@@ -215,6 +254,36 @@ func (d *controller) createPermission(ctx context.Context, r *Robot) error {
 	return nil
 }
 
+// expandRole appends the permissions of the custom role bound to the robot into its access list,
+// the access list is a snapshot of the role permissions when the robot is saved.
+func (d *controller) expandRole(ctx context.Context, p *Permission) error {
+	if p.RoleID == 0 {
+		return nil
+	}
+	if p.Kind != LEVELPROJECT {
+		return errors.BadRequestError(nil).WithMessage("only the project permission can be bound with a role")
+	}
+	r, err := d.roleMgr.Get(ctx, p.RoleID)
+	if err != nil {
+		return err
+	}
+	if !r.Custom {
+		return errors.BadRequestError(nil).WithMessage("only the custom role can be bound to the robot")
+	}
+	rolePermissions, err := d.rbacMgr.GetPermissionsByRole(ctx, role_model.RoleType, int64(r.ID))
+	if err != nil {
+		return err
+	}
+	for _, rp := range rolePermissions {
+		p.Access = append(p.Access, &types.Policy{
+			Resource: types.Resource(rp.Resource),
+			Action:   types.Action(rp.Action),
+			Effect:   types.Effect(rp.Effect),
+		})
+	}
+	return nil
+}
+
 func (d *controller) populate(ctx context.Context, r *model.Robot, option *Option) (*Robot, error) {
 	if r == nil {
 		return nil, nil
@@ -145,6 +145,13 @@ func (suite *ControllerTestSuite) TestDelete() {
 	c := controller{robotMgr: robotMgr, rbacMgr: rbacMgr, proMgr: projectMgr}
 	ctx := context.TODO()
 
+	conf := map[string]interface{}{
+		common.RobotPrefix: "robot$",
+	}
+	config.InitWithSettings(conf)
+
+	robotMgr.On("Get", mock.Anything, mock.Anything).Return(&model.Robot{ID: 1, Name: "test"}, nil)
+	rbacMgr.On("GetPermissionsByRole", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 	robotMgr.On("Delete", mock.Anything, mock.Anything).Return(nil)
 	rbacMgr.On("DeletePermissionsByRole", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
@@ -165,7 +172,9 @@ func (suite *ControllerTestSuite) TestUpdate() {
 	}
 	config.InitWithSettings(conf)
 
-	robotMgr.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	robotMgr.On("Get", mock.Anything, mock.Anything).Return(&model.Robot{ID: 1, Name: "testcreate"}, nil)
+	rbacMgr.On("GetPermissionsByRole", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	robotMgr.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	projectMgr.On("Get", mock.Anything, mock.Anything).Return(&proModels.Project{ProjectID: 1, Name: "library"}, nil)
 	rbacMgr.On("DeletePermissionsByRole", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
@@ -58,6 +58,7 @@ type Permission struct {
 	Kind      string          `json:"kind"`
 	Namespace string          `json:"namespace"`
 	Access    []*types.Policy `json:"access"`
+	RoleID    int             `json:"role_id,omitempty"`
 	Scope     string          `json:"-"`
 }
 
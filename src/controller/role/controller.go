@@ -0,0 +1,210 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package role
+
+import (
+	"context"
+
+	rbac_project "github.com/goharbor/harbor/src/common/rbac/project"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	"github.com/goharbor/harbor/src/pkg/rbac"
+	rbac_model "github.com/goharbor/harbor/src/pkg/rbac/model"
+	"github.com/goharbor/harbor/src/pkg/role"
+	"github.com/goharbor/harbor/src/pkg/role/model"
+)
+
+var (
+	// Ctl is a global variable for the default role controller implementation
+	Ctl = NewController()
+)
+
+// Controller to handle the requests related with project roles
+type Controller interface {
+	// Get the role specified by ID
+	Get(ctx context.Context, id int) (*Role, error)
+
+	// Count returns the total count of roles according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+
+	// List the roles according to the query
+	List(ctx context.Context, query *q.Query) ([]*Role, error)
+
+	// Create a custom role
+	Create(ctx context.Context, r *Role) (int, error)
+
+	// Update the name, description and permissions of the custom role
+	Update(ctx context.Context, r *Role) error
+
+	// Delete the custom role
+	Delete(ctx context.Context, id int) error
+}
+
+// NewController ...
+func NewController() Controller {
+	return &controller{
+		roleMgr: role.Mgr,
+		rbacMgr: rbac.Mgr,
+	}
+}
+
+type controller struct {
+	roleMgr role.Manager
+	rbacMgr rbac.Manager
+}
+
+func (c *controller) Get(ctx context.Context, id int) (*Role, error) {
+	r, err := c.roleMgr.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return c.populate(ctx, r)
+}
+
+func (c *controller) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return c.roleMgr.Count(ctx, query)
+}
+
+func (c *controller) List(ctx context.Context, query *q.Query) ([]*Role, error) {
+	roles, err := c.roleMgr.List(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var results []*Role
+	for _, r := range roles {
+		role, err := c.populate(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, role)
+	}
+	return results, nil
+}
+
+func (c *controller) Create(ctx context.Context, r *Role) (int, error) {
+	if err := r.Validate(); err != nil {
+		return 0, err
+	}
+	r.Custom = true
+	id, err := c.roleMgr.Create(ctx, &r.Role)
+	if err != nil {
+		return 0, err
+	}
+	r.ID = id
+	if err := c.createPermissions(ctx, r); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (c *controller) Update(ctx context.Context, r *Role) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	current, err := c.getCustomRole(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+	current.Name = r.Name
+	current.Description = r.Description
+	if err := c.roleMgr.Update(ctx, current, "name", "description"); err != nil {
+		return err
+	}
+	if err := c.deletePermissions(ctx, r.ID); err != nil {
+		return err
+	}
+	return c.createPermissions(ctx, r)
+}
+
+func (c *controller) Delete(ctx context.Context, id int) error {
+	if _, err := c.getCustomRole(ctx, id); err != nil {
+		return err
+	}
+	count, err := c.roleMgr.CountMembers(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.ConflictError(nil).WithMessage("the role %d is still assigned to %d project member(s)", id, count)
+	}
+	if err := c.deletePermissions(ctx, id); err != nil {
+		return err
+	}
+	return c.roleMgr.Delete(ctx, id)
+}
+
+// getCustomRole returns the role and makes sure it's a custom role as the built-in ones cannot be changed
+func (c *controller) getCustomRole(ctx context.Context, id int) (*model.Role, error) {
+	r, err := c.roleMgr.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !r.Custom {
+		return nil, errors.ForbiddenError(nil).WithMessage("the built-in role %s cannot be modified", r.Name)
+	}
+	return r, nil
+}
+
+func (c *controller) createPermissions(ctx context.Context, r *Role) error {
+	for _, p := range r.Permissions {
+		policyID, err := c.rbacMgr.CreateRbacPolicy(ctx, &rbac_model.PermissionPolicy{
+			Scope:    model.Scope,
+			Resource: p.Resource.String(),
+			Action:   p.Action.String(),
+			Effect:   p.Effect.String(),
+		})
+		if err != nil {
+			return err
+		}
+		if _, err = c.rbacMgr.CreatePermission(ctx, &rbac_model.RolePermission{
+			RoleType:           model.RoleType,
+			RoleID:             int64(r.ID),
+			PermissionPolicyID: policyID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *controller) deletePermissions(ctx context.Context, id int) error {
+	if err := c.rbacMgr.DeletePermissionsByRole(ctx, model.RoleType, int64(id)); err != nil && !errors.IsNotFoundErr(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *controller) populate(ctx context.Context, r *model.Role) (*Role, error) {
+	role := &Role{Role: *r}
+	if !r.Custom {
+		role.Permissions = rbac_project.GetPoliciesOfBuiltinRole(r.ID)
+		return role, nil
+	}
+	permissions, err := c.rbacMgr.GetPermissionsByRole(ctx, model.RoleType, int64(r.ID))
+	if err != nil {
+		log.Errorf("failed to get permissions of role %d: %v", r.ID, err)
+		return nil, err
+	}
+	for _, p := range permissions {
+		role.Permissions = append(role.Permissions, &types.Policy{
+			Resource: types.Resource(p.Resource),
+			Action:   types.Action(p.Action),
+			Effect:   types.Effect(p.Effect),
+		})
+	}
+	return role, nil
+}
@@ -0,0 +1,117 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package role
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	rbac_model "github.com/goharbor/harbor/src/pkg/rbac/model"
+	"github.com/goharbor/harbor/src/pkg/role/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	rbactesting "github.com/goharbor/harbor/src/testing/pkg/rbac"
+	roletesting "github.com/goharbor/harbor/src/testing/pkg/role"
+)
+
+type controllerTestSuite struct {
+	suite.Suite
+	ctl     *controller
+	roleMgr *roletesting.Manager
+	rbacMgr *rbactesting.Manager
+}
+
+func (c *controllerTestSuite) SetupTest() {
+	c.roleMgr = &roletesting.Manager{}
+	c.rbacMgr = &rbactesting.Manager{}
+	c.ctl = &controller{
+		roleMgr: c.roleMgr,
+		rbacMgr: c.rbacMgr,
+	}
+}
+
+func (c *controllerTestSuite) TestGetBuiltin() {
+	c.roleMgr.On("Get", mock.Anything, common.RoleDeveloper).Return(&model.Role{ID: common.RoleDeveloper, Name: "developer"}, nil)
+	r, err := c.ctl.Get(context.Background(), common.RoleDeveloper)
+	c.Require().Nil(err)
+	c.False(r.Custom)
+	c.NotEmpty(r.Permissions)
+	c.rbacMgr.AssertNotCalled(c.T(), "GetPermissionsByRole", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (c *controllerTestSuite) TestGetCustom() {
+	c.roleMgr.On("Get", mock.Anything, 10).Return(&model.Role{ID: 10, Name: "deployer", Custom: true}, nil)
+	c.rbacMgr.On("GetPermissionsByRole", mock.Anything, model.RoleType, int64(10)).Return([]*rbac_model.UniversalRolePermission{
+		{Resource: "repository", Action: "pull"},
+	}, nil)
+	r, err := c.ctl.Get(context.Background(), 10)
+	c.Require().Nil(err)
+	c.True(r.Custom)
+	c.Require().Len(r.Permissions, 1)
+	c.Equal(rbac.ResourceRepository, r.Permissions[0].Resource)
+	c.Equal(rbac.ActionPull, r.Permissions[0].Action)
+}
+
+func (c *controllerTestSuite) TestCreate() {
+	c.roleMgr.On("Create", mock.Anything, mock.Anything).Return(10, nil)
+	c.rbacMgr.On("CreateRbacPolicy", mock.Anything, mock.Anything).Return(int64(1), nil)
+	c.rbacMgr.On("CreatePermission", mock.Anything, mock.Anything).Return(int64(1), nil)
+	id, err := c.ctl.Create(context.Background(), &Role{
+		Role: model.Role{Name: "deployer"},
+		Permissions: []*types.Policy{
+			{Resource: rbac.ResourceRepository, Action: rbac.ActionPull},
+			{Resource: rbac.ResourceRepository, Action: rbac.ActionPush},
+		},
+	})
+	c.Require().Nil(err)
+	c.Equal(10, id)
+	c.rbacMgr.AssertNumberOfCalls(c.T(), "CreatePermission", 2)
+}
+
+func (c *controllerTestSuite) TestUpdateBuiltin() {
+	c.roleMgr.On("Get", mock.Anything, common.RoleGuest).Return(&model.Role{ID: common.RoleGuest, Name: "guest"}, nil)
+	err := c.ctl.Update(context.Background(), &Role{
+		Role:        model.Role{ID: common.RoleGuest, Name: "guest"},
+		Permissions: []*types.Policy{{Resource: rbac.ResourceRepository, Action: rbac.ActionPull}},
+	})
+	c.Require().NotNil(err)
+	c.True(errors.IsErr(err, errors.ForbiddenCode))
+}
+
+func (c *controllerTestSuite) TestDeleteInUse() {
+	c.roleMgr.On("Get", mock.Anything, 10).Return(&model.Role{ID: 10, Name: "deployer", Custom: true}, nil)
+	c.roleMgr.On("CountMembers", mock.Anything, 10).Return(int64(2), nil)
+	err := c.ctl.Delete(context.Background(), 10)
+	c.Require().NotNil(err)
+	c.True(errors.IsConflictErr(err))
+	c.roleMgr.AssertNotCalled(c.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (c *controllerTestSuite) TestDelete() {
+	c.roleMgr.On("Get", mock.Anything, 10).Return(&model.Role{ID: 10, Name: "deployer", Custom: true}, nil)
+	c.roleMgr.On("CountMembers", mock.Anything, 10).Return(int64(0), nil)
+	c.roleMgr.On("Delete", mock.Anything, 10).Return(nil)
+	c.rbacMgr.On("DeletePermissionsByRole", mock.Anything, model.RoleType, int64(10)).Return(nil)
+	c.Nil(c.ctl.Delete(context.Background(), 10))
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &controllerTestSuite{})
+}
@@ -0,0 +1,49 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package role
+
+import (
+	rbac_project "github.com/goharbor/harbor/src/common/rbac/project"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	"github.com/goharbor/harbor/src/pkg/role/model"
+)
+
+const maxNameLength = 255
+
+// Role ...
+type Role struct {
+	model.Role
+	Permissions []*types.Policy `json:"permissions"`
+}
+
+// Validate the name and the permissions of the custom role
+func (r *Role) Validate() error {
+	if len(r.Name) == 0 || len(r.Name) > maxNameLength {
+		return errors.BadRequestError(nil).WithMessage("the length of the role name must be between 1 and %d", maxNameLength)
+	}
+	if len(r.Permissions) == 0 {
+		return errors.BadRequestError(nil).WithMessage("the role must contain at least one permission")
+	}
+	for _, p := range r.Permissions {
+		if p.Effect != "" && p.Effect != types.EffectAllow && p.Effect != types.EffectDeny {
+			return errors.BadRequestError(nil).WithMessage("invalid effect %s", p.Effect)
+		}
+		if !rbac_project.IsPolicyOfProject(p) {
+			return errors.BadRequestError(nil).WithMessage("unsupported permission %s:%s", p.Resource, p.Action)
+		}
+	}
+	return nil
+}
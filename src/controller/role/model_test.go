@@ -0,0 +1,78 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package role
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	"github.com/goharbor/harbor/src/pkg/role/model"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		role    *Role
+		isValid bool
+	}{
+		{
+			name:    "empty name",
+			role:    &Role{Permissions: []*types.Policy{{Resource: rbac.ResourceRepository, Action: rbac.ActionPull}}},
+			isValid: false,
+		},
+		{
+			name:    "no permission",
+			role:    &Role{Role: model.Role{Name: "deployer"}},
+			isValid: false,
+		},
+		{
+			name: "unknown permission",
+			role: &Role{
+				Role:        model.Role{Name: "deployer"},
+				Permissions: []*types.Policy{{Resource: rbac.ResourceRepository, Action: "unknown"}},
+			},
+			isValid: false,
+		},
+		{
+			name: "invalid effect",
+			role: &Role{
+				Role:        model.Role{Name: "deployer"},
+				Permissions: []*types.Policy{{Resource: rbac.ResourceRepository, Action: rbac.ActionPull, Effect: "unknown"}},
+			},
+			isValid: false,
+		},
+		{
+			name: "developer but cannot delete",
+			role: &Role{
+				Role: model.Role{Name: "deployer"},
+				Permissions: []*types.Policy{
+					{Resource: rbac.ResourceRepository, Action: rbac.ActionPull},
+					{Resource: rbac.ResourceRepository, Action: rbac.ActionPush},
+					{Resource: rbac.ResourceArtifact, Action: rbac.ActionDelete, Effect: types.EffectDeny},
+				},
+			},
+			isValid: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.role.Validate()
+			assert.Equal(t, c.isValid, err == nil)
+		})
+	}
+}
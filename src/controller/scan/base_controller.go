@@ -88,6 +88,8 @@ type launchScanJobParam struct {
 	Artifact     *ar.Artifact
 	Tag          string
 	Reports      []*scan.Report
+
+	PriorityClass string
 }
 
 // basicController is default implementation of api.Controller interface
@@ -312,6 +314,7 @@ func (bc *basicController) Scan(ctx context.Context, artifact *ar.Artifact, opti
 	errs = errs[:0]
 	for _, launchScanJobParam := range launchScanJobParams {
 		launchScanJobParam.ExecutionID = opts.ExecutionID
+		launchScanJobParam.PriorityClass = opts.PriorityClass
 
 		if err := bc.launchScanJob(ctx, launchScanJobParam); err != nil {
 			log.G(ctx).Warningf("scan artifact %s@%s failed, error: %v", artifact.RepositoryName, artifact.Digest, err)
@@ -391,7 +394,8 @@ func (bc *basicController) startScanAll(ctx context.Context, executionID int64)
 		summary.TotalCount++
 
 		scan := func(ctx context.Context) error {
-			return bc.Scan(ctx, artifact, WithExecutionID(executionID))
+			// run the scan jobs of the scan all after the other jobs
+			return bc.Scan(ctx, artifact, WithExecutionID(executionID), WithPriorityClass(job.PriorityClassLow))
 		}
 
 		if err := orm.WithTransaction(scan)(orm.SetTransactionOpNameToContext(bc.makeCtx(), "tx-start-scanall")); err != nil {
@@ -956,7 +960,10 @@ func (bc *basicController) launchScanJob(ctx context.Context, param *launchScanJ
 	j := &task.Job{
 		Name: job.ImageScanJob,
 		Metadata: &job.Metadata{
-			JobKind: job.KindGeneric,
+			JobKind:       job.KindGeneric,
+			PriorityClass: param.PriorityClass,
+			// share the workers fairly among the projects
+			FairShareKey: fmt.Sprintf("project:%d", param.Artifact.ProjectID),
 		},
 		Parameters: params,
 	}
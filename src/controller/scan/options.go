@@ -18,6 +18,9 @@ package scan
 type Options struct {
 	ExecutionID int64  // The execution id to scan artifact
 	Tag         string // The tag of the artifact to scan
+
+	// The priority class of the scan jobs, e.g. the scan jobs launched by the scan all are in low priority
+	PriorityClass string
 }
 
 // Option represents an option item by func template.
@@ -44,3 +47,12 @@ func WithTag(tag string) Option {
 		return nil
 	}
 }
+
+// WithPriorityClass sets the priority class option.
+func WithPriorityClass(priorityClass string) Option {
+	return func(options *Options) error {
+		options.PriorityClass = priorityClass
+
+		return nil
+	}
+}
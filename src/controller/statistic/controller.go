@@ -0,0 +1,248 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistic
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg"
+	"github.com/goharbor/harbor/src/pkg/project"
+	"github.com/goharbor/harbor/src/pkg/project/statistic"
+	"github.com/goharbor/harbor/src/pkg/project/statistic/models"
+	"github.com/goharbor/harbor/src/pkg/scheduler"
+)
+
+const (
+	VendorTypeProjectStatisticReconcile = "PROJECT_STATISTIC_RECONCILE"
+	ProjectStatisticReconcileCallback   = "PROJECT_STATISTIC_RECONCILE"
+	cronTypeHourly                      = "Hourly"
+	cronSpec                            = "0 0 * * * *"
+
+	// defaultFlushInterval is the interval to refresh the statistics of the dirty projects
+	defaultFlushInterval = 10 * time.Second
+	// refreshBatchSize is the count of the projects refreshed by one statement
+	refreshBatchSize = 100
+)
+
+var (
+	// Ctl is the global project statistic controller instance
+	Ctl   = NewController()
+	sched = scheduler.Sched
+)
+
+func init() {
+	if err := scheduler.RegisterCallbackFunc(ProjectStatisticReconcileCallback, reconcileCallback); err != nil {
+		log.Fatalf("failed to register the callback for the project statistic reconcile schedule, error %v", err)
+	}
+}
+
+func reconcileCallback(ctx context.Context, _ string) error {
+	return Ctl.Reconcile(ctx)
+}
+
+// Controller provides the pre-computed statistics of the projects. The statistics are refreshed
+// asynchronously when the projects are changed and reconciled periodically, so they may lag
+// behind the actual state for a short while
+type Controller interface {
+	// Get the statistic of the project, it's calculated when missing
+	Get(ctx context.Context, projectID int64) (*models.ProjectStatistic, error)
+	// List the statistics of the projects keyed by the project ID, the projects not calculated yet are omitted
+	List(ctx context.Context, projectIDs ...int64) (map[int64]*models.ProjectStatistic, error)
+	// Total returns the totals of all projects, it's calculated when missing
+	Total(ctx context.Context) (*models.ProjectStatistic, error)
+	// MarkDirty marks the statistics of the projects to be refreshed asynchronously
+	MarkDirty(projectIDs ...int64)
+	// Reconcile refreshes the statistics of all projects and removes the ones of the deleted projects
+	Reconcile(ctx context.Context) error
+}
+
+// NewController creates an instance of the default project statistic controller
+func NewController() Controller {
+	return &controller{
+		mgr:           statistic.Mgr,
+		projectMgr:    pkg.ProjectMgr,
+		makeCtx:       orm.Context,
+		flushInterval: defaultFlushInterval,
+		dirty:         map[int64]struct{}{},
+	}
+}
+
+type controller struct {
+	mgr           statistic.Manager
+	projectMgr    project.Manager
+	makeCtx       func() context.Context
+	flushInterval time.Duration
+
+	once  sync.Once
+	lock  sync.Mutex
+	dirty map[int64]struct{}
+}
+
+func (c *controller) Get(ctx context.Context, projectID int64) (*models.ProjectStatistic, error) {
+	s, err := c.mgr.Get(ctx, projectID)
+	if err == nil || !errors.IsNotFoundErr(err) {
+		return s, err
+	}
+	if err := c.mgr.Refresh(ctx, projectID); err != nil {
+		return nil, err
+	}
+	return c.mgr.Get(ctx, projectID)
+}
+
+func (c *controller) List(ctx context.Context, projectIDs ...int64) (map[int64]*models.ProjectStatistic, error) {
+	statistics := map[int64]*models.ProjectStatistic{}
+	for i := 0; i < len(projectIDs); i += refreshBatchSize {
+		end := i + refreshBatchSize
+		if end > len(projectIDs) {
+			end = len(projectIDs)
+		}
+		list, err := c.mgr.List(ctx, projectIDs[i:end]...)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range list {
+			statistics[s.ProjectID] = s
+		}
+	}
+	return statistics, nil
+}
+
+func (c *controller) Total(ctx context.Context) (*models.ProjectStatistic, error) {
+	s, err := c.mgr.Get(ctx, models.TotalID)
+	if err == nil || !errors.IsNotFoundErr(err) {
+		return s, err
+	}
+	if err := c.mgr.RefreshTotal(ctx); err != nil {
+		return nil, err
+	}
+	return c.mgr.Get(ctx, models.TotalID)
+}
+
+func (c *controller) MarkDirty(projectIDs ...int64) {
+	c.once.Do(func() {
+		go c.loop()
+	})
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, id := range projectIDs {
+		if id > 0 {
+			c.dirty[id] = struct{}{}
+		}
+	}
+}
+
+func (c *controller) loop() {
+	ticker := time.NewTicker(c.flushInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		c.flush(c.makeCtx())
+	}
+}
+
+// flush refreshes the statistics of the dirty projects and the totals
+func (c *controller) flush(ctx context.Context) {
+	c.lock.Lock()
+	dirty := c.dirty
+	c.dirty = map[int64]struct{}{}
+	c.lock.Unlock()
+
+	if len(dirty) == 0 {
+		return
+	}
+	var ids []int64
+	for id := range dirty {
+		ids = append(ids, id)
+	}
+	if err := c.refresh(ctx, ids); err != nil {
+		// the statistics will be corrected by the reconciler
+		log.Errorf("failed to refresh the statistics of the projects %v: %v", ids, err)
+		return
+	}
+	if err := c.mgr.RefreshTotal(ctx); err != nil {
+		log.Errorf("failed to refresh the total statistic of the projects: %v", err)
+	}
+}
+
+func (c *controller) refresh(ctx context.Context, projectIDs []int64) error {
+	for i := 0; i < len(projectIDs); i += refreshBatchSize {
+		end := i + refreshBatchSize
+		if end > len(projectIDs) {
+			end = len(projectIDs)
+		}
+		if err := c.mgr.Refresh(ctx, projectIDs[i:end]...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *controller) Reconcile(ctx context.Context) error {
+	query := &q.Query{PageSize: refreshBatchSize}
+	for query.PageNumber = 1; ; query.PageNumber++ {
+		projects, err := c.projectMgr.List(ctx, query)
+		if err != nil {
+			return err
+		}
+		if len(projects) == 0 {
+			break
+		}
+		var ids []int64
+		for _, p := range projects {
+			ids = append(ids, p.ProjectID)
+		}
+		if err := c.mgr.Refresh(ctx, ids...); err != nil {
+			return err
+		}
+		if len(projects) < refreshBatchSize {
+			break
+		}
+	}
+
+	n, err := c.mgr.Purge(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.mgr.RefreshTotal(ctx); err != nil {
+		return err
+	}
+	log.Infof("reconciled the statistics of the projects, %d statistics of the deleted projects removed", n)
+	return nil
+}
+
+// ScheduleReconcile schedules the periodic reconciliation of the project statistics
+func ScheduleReconcile(ctx context.Context) {
+	schedules, err := sched.ListSchedules(ctx, q.New(q.KeyWords{"vendor_type": VendorTypeProjectStatisticReconcile}))
+	if err != nil {
+		log.Errorf("failed to check whether the project statistic reconcile is scheduled: %v", err)
+		return
+	}
+	if len(schedules) > 0 {
+		log.Debugf("the project statistic reconcile is already scheduled with ID: %d", schedules[0].ID)
+		return
+	}
+	id, err := sched.Schedule(ctx, VendorTypeProjectStatisticReconcile, 0, cronTypeHourly, cronSpec, ProjectStatisticReconcileCallback, nil, nil)
+	if err != nil {
+		log.Errorf("failed to schedule the project statistic reconcile: %v", err)
+		return
+	}
+	log.Infof("scheduled the project statistic reconcile with ID: %d", id)
+}
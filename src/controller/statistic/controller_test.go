@@ -0,0 +1,93 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/project/statistic/models"
+	"github.com/goharbor/harbor/src/testing/pkg/project"
+	"github.com/goharbor/harbor/src/testing/pkg/project/statistic"
+)
+
+type controllerTestSuite struct {
+	suite.Suite
+	ctl        *controller
+	mgr        *statistic.Manager
+	projectMgr *project.Manager
+}
+
+func (c *controllerTestSuite) SetupTest() {
+	c.mgr = &statistic.Manager{}
+	c.projectMgr = &project.Manager{}
+	c.ctl = &controller{
+		mgr:        c.mgr,
+		projectMgr: c.projectMgr,
+		makeCtx:    context.TODO,
+		dirty:      map[int64]struct{}{},
+	}
+}
+
+func (c *controllerTestSuite) TestGet() {
+	c.mgr.On("Get", mock.Anything, int64(1)).Return(nil, errors.NotFoundError(nil)).Once()
+	c.mgr.On("Refresh", mock.Anything, int64(1)).Return(nil)
+	c.mgr.On("Get", mock.Anything, int64(1)).Return(&models.ProjectStatistic{ProjectID: 1, RepoCount: 2}, nil)
+	s, err := c.ctl.Get(context.TODO(), 1)
+	c.Require().Nil(err)
+	c.Equal(int64(2), s.RepoCount)
+	c.mgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestList() {
+	c.mgr.On("List", mock.Anything, int64(1), int64(2)).Return([]*models.ProjectStatistic{{ProjectID: 1, RepoCount: 3}}, nil)
+	statistics, err := c.ctl.List(context.TODO(), 1, 2)
+	c.Require().Nil(err)
+	c.Len(statistics, 1)
+	c.Equal(int64(3), statistics[1].RepoCount)
+	c.Nil(statistics[2])
+}
+
+func (c *controllerTestSuite) TestFlush() {
+	// nothing to flush
+	c.ctl.flush(context.TODO())
+	c.mgr.AssertNotCalled(c.T(), "RefreshTotal", mock.Anything)
+
+	c.ctl.dirty[1] = struct{}{}
+	c.mgr.On("Refresh", mock.Anything, int64(1)).Return(nil)
+	c.mgr.On("RefreshTotal", mock.Anything).Return(nil)
+	c.ctl.flush(context.TODO())
+	c.Len(c.ctl.dirty, 0)
+	c.mgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestReconcile() {
+	c.projectMgr.On("List", mock.Anything, mock.Anything).Return([]*proModels.Project{{ProjectID: 1}, {ProjectID: 2}}, nil)
+	c.mgr.On("Refresh", mock.Anything, int64(1), int64(2)).Return(nil)
+	c.mgr.On("Purge", mock.Anything).Return(int64(1), nil)
+	c.mgr.On("RefreshTotal", mock.Anything).Return(nil)
+	c.Nil(c.ctl.Reconcile(context.TODO()))
+	c.projectMgr.AssertNumberOfCalls(c.T(), "List", 1)
+	c.mgr.AssertExpectations(c.T())
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &controllerTestSuite{})
+}
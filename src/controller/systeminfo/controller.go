@@ -28,6 +28,8 @@ import (
 	"github.com/goharbor/harbor/src/lib/config/models"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/migration"
+	migrationmodel "github.com/goharbor/harbor/src/pkg/migration/model"
 	"github.com/goharbor/harbor/src/pkg/systeminfo"
 	"github.com/goharbor/harbor/src/pkg/systeminfo/imagestorage"
 	"github.com/goharbor/harbor/src/pkg/version"
@@ -47,6 +49,8 @@ type Data struct {
 	SelfRegistration  bool
 	HarborVersion     string
 	AuthProxySettings *models.HTTPAuthProxy
+	BannerMessage     *models.BannerMessage
+	Maintenance       *models.MaintenanceSetting
 	Protected         *protectedData
 }
 
@@ -80,9 +84,17 @@ type Controller interface {
 
 	// GetCA returns a ReadCloser of Harbor's CA if it's configured and accessible from Harbor core
 	GetCA(ctx context.Context) (io.ReadCloser, error)
+
+	// GetMigrationStatus returns the schema version of the database and the pending migrations
+	GetMigrationStatus(ctx context.Context) (*migrationmodel.Status, error)
+
+	// MigrationPreflight checks whether the database is ready for the pending migrations before the upgrade
+	MigrationPreflight(ctx context.Context) (*migrationmodel.Preflight, error)
 }
 
-type controller struct{}
+type controller struct {
+	migrationMgr migration.Manager
+}
 
 func (c *controller) GetInfo(ctx context.Context, opt Options) (*Data, error) {
 	logger := log.GetLogger(ctx)
@@ -103,6 +115,16 @@ func (c *controller) GetInfo(ctx context.Context, opt Options) (*Data, error) {
 			logger.Warningf("Failed to get auth proxy setting, error: %v", err)
 		}
 	}
+	// the banner is returned only when it should be shown
+	if banner, err := config.BannerMessage(ctx); err != nil {
+		logger.Warningf("Failed to get banner message, error: %v", err)
+	} else if banner.Active(time.Now()) {
+		res.BannerMessage = banner
+	}
+	// the maintenance is returned once it's turned on to let the clients know the scheduled one in advance
+	if maintenance := config.Maintenance(ctx); maintenance.Enabled {
+		res.Maintenance = maintenance
+	}
 
 	if !opt.WithProtectedInfo {
 		return res, nil
@@ -154,5 +176,15 @@ func (c *controller) GetCA(ctx context.Context) (io.ReadCloser, error) {
 
 // NewController return an instance of controller
 func NewController() Controller {
-	return &controller{}
+	return &controller{
+		migrationMgr: migration.Mgr,
+	}
+}
+
+func (c *controller) GetMigrationStatus(ctx context.Context) (*migrationmodel.Status, error) {
+	return c.migrationMgr.Status(ctx)
+}
+
+func (c *controller) MigrationPreflight(ctx context.Context) (*migrationmodel.Preflight, error) {
+	return c.migrationMgr.Preflight(ctx)
 }
@@ -3,11 +3,13 @@ package systeminfo
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/config/models"
 	"github.com/goharbor/harbor/src/lib/errors"
 	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
 	"github.com/goharbor/harbor/src/pkg/version"
@@ -103,6 +105,35 @@ func (s *sysInfoCtlTestSuite) TestGetInfo() {
 	}
 }
 
+func (s *sysInfoCtlTestSuite) TestGetInfoWithBannerAndMaintenance() {
+	assert := s.Assert()
+	config.InitWithSettings(map[string]interface{}{
+		common.AUTHMode:             "db_auth",
+		common.ExtEndpoint:          "https://test.goharbor.io",
+		common.BannerMessage:        `{"message":"upgrade at 22:00","type":"warning","to_time":"2000-01-01T00:00:00Z"}`,
+		common.MaintenanceMode:      true,
+		common.MaintenanceStartTime: "2100-01-01T22:00:00Z",
+	})
+	res, err := s.ctl.GetInfo(context.Background(), Options{})
+	assert.Nil(err)
+	// the banner has expired
+	assert.Nil(res.BannerMessage)
+	assert.NotNil(res.Maintenance)
+	assert.False(res.Maintenance.Active(time.Now()))
+	assert.Equal(time.Date(2100, 1, 1, 22, 0, 0, 0, time.UTC), *res.Maintenance.StartTime)
+
+	config.InitWithSettings(map[string]interface{}{
+		common.AUTHMode:        "db_auth",
+		common.ExtEndpoint:     "https://test.goharbor.io",
+		common.BannerMessage:   `{"message":"upgrade at 22:00","type":"warning","closable":true}`,
+		common.MaintenanceMode: false,
+	})
+	res, err = s.ctl.GetInfo(context.Background(), Options{})
+	assert.Nil(err)
+	assert.Equal(&models.BannerMessage{Message: "upgrade at 22:00", Type: "warning", Closable: true}, res.BannerMessage)
+	assert.Nil(res.Maintenance)
+}
+
 func TestControllerSuite(t *testing.T) {
 	suite.Run(t, &sysInfoCtlTestSuite{})
 }
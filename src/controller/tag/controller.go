@@ -16,19 +16,24 @@ package tag
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/lib/selector"
 	"github.com/goharbor/harbor/src/pkg"
+	"github.com/goharbor/harbor/src/pkg/accessory"
+	acc_model "github.com/goharbor/harbor/src/pkg/accessory/model"
 	"github.com/goharbor/harbor/src/pkg/artifact"
 	"github.com/goharbor/harbor/src/pkg/immutable/match"
 	"github.com/goharbor/harbor/src/pkg/immutable/match/rule"
 	"github.com/goharbor/harbor/src/pkg/signature"
+	"github.com/goharbor/harbor/src/pkg/signature/cosign"
 	"github.com/goharbor/harbor/src/pkg/tag"
 	model_tag "github.com/goharbor/harbor/src/pkg/tag/model/tag"
 )
@@ -38,6 +43,15 @@ var (
 	Ctl = NewController()
 )
 
+const (
+	// the max count of the concurrent deletions in the bulk deletion
+	bulkDeleteConcurrency = 10
+	// the max count of the tags assembled concurrently when listing tags
+	assembleConcurrency = 10
+	// the timeout for assembling one tag, the properties that aren't populated in time are left empty
+	assembleTimeout = 10 * time.Second
+)
+
 // Controller manages the tags
 type Controller interface {
 	// Ensure
@@ -56,21 +70,37 @@ type Controller interface {
 	Delete(ctx context.Context, id int64) (err error)
 	// DeleteTags deletes all tags
 	DeleteTags(ctx context.Context, ids []int64) (err error)
+	// BulkDelete deletes the provided tags with limitation check concurrently and returns the result of each tag,
+	// the failure of one tag doesn't affect the others
+	BulkDelete(ctx context.Context, tags []*Tag) (results []*DeleteResult)
+	// ListNames lists the names of the tags under the repository which are greater than the "last" in
+	// byte order, the count of the returned names is limited by the "limit" if it is greater than 0
+	ListNames(ctx context.Context, repositoryID int64, last string, limit int) (names []string, err error)
 }
 
 // NewController creates an instance of the default repository controller
 func NewController() Controller {
 	return &controller{
-		tagMgr:       tag.Mgr,
-		artMgr:       pkg.ArtifactMgr,
-		immutableMtr: rule.NewRuleMatcher(),
+		tagMgr:         tag.Mgr,
+		artMgr:         pkg.ArtifactMgr,
+		accMgr:         accessory.Mgr,
+		immutableMtr:   rule.NewRuleMatcher(),
+		cosignVerifier: cosign.Verifier,
+		cloneCtx:       orm.Clone,
 	}
 }
 
 type controller struct {
 	tagMgr       tag.Manager
 	artMgr       artifact.Manager
+	accMgr       accessory.Manager
 	immutableMtr match.ImmutableTagMatcher
+	// inspects the signers of the cosign signatures
+	cosignVerifier cosign.SignatureVerifier
+	// cloneCtx returns a copy of the context with a new ormer
+	cloneCtx func(context.Context) context.Context
+	// protects the signature checker cached in the option when assembling tags concurrently
+	checkerLock sync.Mutex
 }
 
 // Ensure ...
@@ -129,16 +159,28 @@ func (c *controller) Count(ctx context.Context, query *q.Query) (total int64, er
 	return c.tagMgr.Count(ctx, query)
 }
 
+// ListNames ...
+func (c *controller) ListNames(ctx context.Context, repositoryID int64, last string, limit int) ([]string, error) {
+	return c.tagMgr.ListNames(ctx, repositoryID, last, limit)
+}
+
 // List ...
 func (c *controller) List(ctx context.Context, query *q.Query, option *Option) ([]*Tag, error) {
 	tgs, err := c.tagMgr.List(ctx, query)
 	if err != nil {
 		return nil, err
 	}
-	var tags []*Tag
-	for _, tg := range tgs {
-		tags = append(tags, c.assembleTag(ctx, tg, option))
+	tags := make([]*Tag, len(tgs))
+	// the queries in a transaction cannot run concurrently, and assembling without options is cheap
+	if option == nil || (!option.WithImmutableStatus && !option.WithSignature) || orm.InTransaction(ctx) {
+		for i, tg := range tgs {
+			tags[i] = c.assembleTag(ctx, tg, option)
+		}
+		return tags, nil
 	}
+	lib.RunConcurrently(ctx, assembleConcurrency, len(tgs), assembleTimeout, func(ctx context.Context, i int) {
+		tags[i] = c.assembleTag(ctx, tgs[i], option)
+	})
 	return tags, nil
 }
 
@@ -200,6 +242,31 @@ func (c *controller) DeleteTags(ctx context.Context, ids []int64) (err error) {
 	return nil
 }
 
+// BulkDelete ...
+func (c *controller) BulkDelete(ctx context.Context, tags []*Tag) []*DeleteResult {
+	results := make([]*DeleteResult, len(tags))
+	var wg sync.WaitGroup
+	// limit the count of the concurrent deletions
+	tokens := make(chan struct{}, bulkDeleteConcurrency)
+	for i, tag := range tags {
+		wg.Add(1)
+		tokens <- struct{}{}
+		go func(i int, tag *Tag) {
+			defer func() {
+				<-tokens
+				wg.Done()
+			}()
+			// simultaneous queries in transaction will fail, so clone a ctx with new ormer here
+			results[i] = &DeleteResult{
+				Tag:   tag,
+				Error: c.Delete(c.cloneCtx(ctx), tag.ID),
+			}
+		}(i, tag)
+	}
+	wg.Wait()
+	return results
+}
+
 // assemble several part into a single tag
 func (c *controller) assembleTag(ctx context.Context, tag *model_tag.Tag, option *Option) *Tag {
 	t := &Tag{
@@ -239,13 +306,48 @@ func (c *controller) populateTagSignature(ctx context.Context, tag *Tag, option
 	if err != nil {
 		return
 	}
+	// the cosign signatures are stored as the accessories of the artifact, the signers are read from the
+	// signatures themselves, so they are the same for the artifacts replicated from the other registries
+	accs, err := c.accMgr.List(ctx, q.New(q.KeyWords{"SubjectArtifactID": artifact.ID, "Type": acc_model.TypeCosignSignature}))
+	if err != nil {
+		log.Errorf("failed to list the cosign signatures of the artifact %d: %v", artifact.ID, err)
+	}
+	tag.CosignSigned = len(accs) > 0
+	for _, acc := range accs {
+		signers, err := c.cosignVerifier.Inspect(ctx, artifact.RepositoryName, acc.GetData().Digest)
+		if err != nil || len(signers) == 0 {
+			if err != nil {
+				log.Warningf("failed to inspect the cosign signature %s@%s: %v", artifact.RepositoryName, acc.GetData().Digest, err)
+			}
+			tag.Signatures = append(tag.Signatures, &Signature{Backend: signature.BackendCosign})
+			continue
+		}
+		for _, signer := range signers {
+			tag.Signatures = append(tag.Signatures, &Signature{
+				Backend:  signature.BackendCosign,
+				SignedBy: signer.Identity,
+				SignedAt: signer.SignedAt,
+			})
+		}
+	}
+
+	c.checkerLock.Lock()
 	if option.SignatureChecker == nil {
 		chk, err := signature.GetManager().GetCheckerByRepo(ctx, artifact.RepositoryName)
 		if err != nil {
+			c.checkerLock.Unlock()
 			log.Error(err)
 			return
 		}
 		option.SignatureChecker = chk
 	}
-	tag.Signed = option.SignatureChecker.IsTagSigned(tag.Name, artifact.Digest)
+	checker := option.SignatureChecker
+	c.checkerLock.Unlock()
+	tag.Signed = checker.IsTagSigned(tag.Name, artifact.Digest)
+	if tag.Signed {
+		tag.Signatures = append(tag.Signatures, &Signature{
+			Backend:  signature.BackendNotary,
+			SignedBy: checker.SignedBy(tag.Name),
+		})
+	}
 }
@@ -15,6 +15,8 @@
 package tag
 
 import (
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -25,13 +27,18 @@ import (
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	basemodel "github.com/goharbor/harbor/src/pkg/accessory/model/base"
 	pkg_artifact "github.com/goharbor/harbor/src/pkg/artifact"
 	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
+	"github.com/goharbor/harbor/src/pkg/signature/cosign"
 	"github.com/goharbor/harbor/src/pkg/tag/model/tag"
 	ormtesting "github.com/goharbor/harbor/src/testing/lib/orm"
+	"github.com/goharbor/harbor/src/testing/pkg/accessory"
 	"github.com/goharbor/harbor/src/testing/pkg/artifact"
 	"github.com/goharbor/harbor/src/testing/pkg/immutable"
 	"github.com/goharbor/harbor/src/testing/pkg/repository"
+	cosigntesting "github.com/goharbor/harbor/src/testing/pkg/signature/cosign"
 	tagtesting "github.com/goharbor/harbor/src/testing/pkg/tag"
 )
 
@@ -40,19 +47,27 @@ type controllerTestSuite struct {
 	ctl          *controller
 	repoMgr      *repository.Manager
 	artMgr       *artifact.Manager
+	accMgr       *accessory.Manager
 	tagMgr       *tagtesting.FakeManager
 	immutableMtr *immutable.FakeMatcher
+	verifier     *cosigntesting.SignatureVerifier
 }
 
 func (c *controllerTestSuite) SetupTest() {
 	c.repoMgr = &repository.Manager{}
 	c.artMgr = &artifact.Manager{}
+	c.accMgr = &accessory.Manager{}
+	c.accMgr.On("List", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 	c.tagMgr = &tagtesting.FakeManager{}
 	c.immutableMtr = &immutable.FakeMatcher{}
+	c.verifier = &cosigntesting.SignatureVerifier{}
 	c.ctl = &controller{
-		tagMgr:       c.tagMgr,
-		artMgr:       c.artMgr,
-		immutableMtr: c.immutableMtr,
+		tagMgr:         c.tagMgr,
+		artMgr:         c.artMgr,
+		accMgr:         c.accMgr,
+		immutableMtr:   c.immutableMtr,
+		cosignVerifier: c.verifier,
+		cloneCtx:       func(ctx context.Context) context.Context { return ctx },
 	}
 
 	var tagCtlTestConfig = map[string]interface{}{
@@ -136,6 +151,33 @@ func (c *controllerTestSuite) TestList() {
 	c.Equal("testlist", tags[0].Name)
 }
 
+func (c *controllerTestSuite) TestListWithImmutableStatus() {
+	var tags []*tag.Tag
+	for i := 0; i < 20; i++ {
+		tags = append(tags, &tag.Tag{
+			ID:           int64(i),
+			RepositoryID: 1,
+			ArtifactID:   1,
+			Name:         fmt.Sprintf("tag%d", i),
+		})
+	}
+	c.tagMgr.On("List").Return(tags, nil)
+	c.artMgr.On("Get", mock.Anything, mock.Anything).Return(&pkg_artifact.Artifact{
+		ID:             1,
+		ProjectID:      1,
+		RepositoryName: "library/hello-world",
+	}, nil)
+	c.immutableMtr.On("Match").Return(true, nil)
+	result, err := c.ctl.List(orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{}), nil, &Option{WithImmutableStatus: true})
+	c.Require().Nil(err)
+	c.Require().Len(result, 20)
+	// the order of the tags is kept
+	for i, t := range result {
+		c.Equal(int64(i), t.ID)
+		c.True(t.Immutable)
+	}
+}
+
 func (c *controllerTestSuite) TestGet() {
 	getTest := &tag.Tag{}
 	getTest.RepositoryID = 1
@@ -204,6 +246,44 @@ func (c *controllerTestSuite) TestDeleteTags() {
 	c.Require().Nil(err)
 }
 
+func (c *controllerTestSuite) TestBulkDelete() {
+	c.tagMgr.On("Get").Return(&tag.Tag{
+		RepositoryID: 1,
+		Name:         "test",
+	}, nil)
+	c.artMgr.On("Get", mock.Anything, mock.Anything).Return(&pkg_artifact.Artifact{
+		ID: 1,
+	}, nil)
+	c.immutableMtr.On("Match").Return(false, nil)
+	c.tagMgr.On("Delete").Return(nil)
+
+	var tags []*Tag
+	for i := 1; i <= 25; i++ {
+		tags = append(tags, &Tag{Tag: tag.Tag{ID: int64(i)}})
+	}
+	results := c.ctl.BulkDelete(context.TODO(), tags)
+	c.Require().Len(results, 25)
+	for i, result := range results {
+		c.Equal(tags[i], result.Tag)
+		c.Nil(result.Error)
+	}
+}
+
+func (c *controllerTestSuite) TestBulkDeleteImmutable() {
+	c.tagMgr.On("Get").Return(&tag.Tag{
+		RepositoryID: 1,
+		Name:         "test",
+	}, nil)
+	c.artMgr.On("Get", mock.Anything, mock.Anything).Return(&pkg_artifact.Artifact{
+		ID: 1,
+	}, nil)
+	c.immutableMtr.On("Match").Return(true, nil)
+
+	results := c.ctl.BulkDelete(context.TODO(), []*Tag{{Tag: tag.Tag{ID: 1}}})
+	c.Require().Len(results, 1)
+	c.True(errors.IsErr(results[0].Error, errors.PreconditionCode))
+}
+
 func (c *controllerTestSuite) TestAssembleTag() {
 	art := &pkg_artifact.Artifact{
 		ID:             1,
@@ -233,6 +313,50 @@ func (c *controllerTestSuite) TestAssembleTag() {
 	// TODO check signature
 }
 
+func (c *controllerTestSuite) TestAssembleTagWithCosignSignature() {
+	art := &pkg_artifact.Artifact{
+		ID:             1,
+		ProjectID:      1,
+		RepositoryID:   1,
+		RepositoryName: "library/hello-world",
+		Digest:         "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180",
+	}
+	tg := &tag.Tag{
+		ID:           1,
+		RepositoryID: 1,
+		ArtifactID:   1,
+		Name:         "latest",
+	}
+	c.accMgr = &accessory.Manager{}
+	c.ctl.accMgr = c.accMgr
+	c.artMgr.On("Get", mock.Anything, mock.Anything).Return(art, nil)
+	c.accMgr.On("List", mock.Anything, mock.Anything).Return([]model.Accessory{
+		&basemodel.Default{
+			Data: model.AccessoryData{
+				ID:            1,
+				ArtifactID:    2,
+				SubArtifactID: 1,
+				Digest:        "sha256:signature",
+				Type:          model.TypeCosignSignature,
+			},
+		},
+	}, nil)
+	signedAt := time.Unix(1672531200, 0).UTC()
+	c.verifier.On("Inspect", mock.Anything, "library/hello-world", "sha256:signature").Return([]*cosign.Signer{
+		{Identity: "signer@example.com", SignedAt: signedAt},
+	}, nil)
+	tag := c.ctl.assembleTag(nil, tg, &Option{WithSignature: true})
+	c.Require().NotNil(tag)
+	c.True(tag.CosignSigned)
+	c.False(tag.Signed)
+	c.Require().Len(tag.Signatures, 1)
+	c.Equal("cosign", tag.Signatures[0].Backend)
+	c.Equal("signer@example.com", tag.Signatures[0].SignedBy)
+	c.Equal(signedAt, tag.Signatures[0].SignedAt)
+	c.accMgr.AssertExpectations(c.T())
+	c.verifier.AssertExpectations(c.T())
+}
+
 func TestControllerTestSuite(t *testing.T) {
 	suite.Run(t, &controllerTestSuite{})
 }
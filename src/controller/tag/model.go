@@ -1,6 +1,8 @@
 package tag
 
 import (
+	"time"
+
 	"github.com/goharbor/harbor/src/pkg/signature"
 	"github.com/goharbor/harbor/src/pkg/tag/model/tag"
 )
@@ -10,6 +12,27 @@ type Tag struct {
 	tag.Tag
 	Immutable bool `json:"immutable"`
 	Signed    bool `json:"signed"`
+	// CosignSigned indicates whether the artifact of the tag has the cosign signatures
+	CosignSigned bool `json:"cosign_signed"`
+	// Signatures are the signatures of the tag in the content trust backends
+	Signatures []*Signature `json:"signatures"`
+}
+
+// Signature is the signature of the tag in one content trust backend
+type Signature struct {
+	// Backend is the content trust backend that the signature belongs to, e.g. "notary" or "cosign"
+	Backend string `json:"backend"`
+	// SignedBy is the signer of the signature, it's empty if the signer is unknown
+	SignedBy string `json:"signed_by"`
+	// SignedAt is the time when the artifact was signed, it's zero if the time isn't recorded in the signature
+	SignedAt time.Time `json:"signed_at"`
+}
+
+// DeleteResult is the result of deleting one tag in the bulk deletion
+type DeleteResult struct {
+	Tag *Tag
+	// Error is nil if the tag is deleted successfully
+	Error error
 }
 
 // Option is used to specify the properties returned when listing/getting tags
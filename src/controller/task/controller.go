@@ -36,6 +36,8 @@ type Controller interface {
 	List(ctx context.Context, query *q.Query) (tasks []*task.Task, err error)
 	// Get the log of the specified task.
 	GetLog(ctx context.Context, id int64) (log []byte, err error)
+	// Get the log of the specified task after the offset (bytes).
+	GetLogFrom(ctx context.Context, id int64, offset int64) (log []byte, err error)
 	// Count counts total.
 	Count(ctx context.Context, query *q.Query) (int64, error)
 }
@@ -76,3 +78,8 @@ func (c *controller) List(ctx context.Context, query *q.Query) (tasks []*task.Ta
 func (c *controller) GetLog(ctx context.Context, id int64) (log []byte, err error) {
 	return c.mgr.GetLog(ctx, id)
 }
+
+// Get the log of the specified task after the offset (bytes).
+func (c *controller) GetLogFrom(ctx context.Context, id int64, offset int64) (log []byte, err error) {
+	return c.mgr.GetLogFrom(ctx, id, offset)
+}
@@ -82,3 +82,11 @@ func (c *controllerTestSuite) TestGetLog() {
 	c.NoError(err)
 	c.Equal([]byte("logs"), l)
 }
+
+// TestGetLogFrom tests get log from the offset.
+func (c *controllerTestSuite) TestGetLogFrom() {
+	c.mgr.On("GetLogFrom", mock.Anything, int64(1), int64(2)).Return([]byte("gs"), nil)
+	l, err := c.ctl.GetLogFrom(nil, 1, 2)
+	c.NoError(err)
+	c.Equal([]byte("gs"), l)
+}
@@ -0,0 +1,290 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenkey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/docker/libtrust"
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/token"
+	v2 "github.com/goharbor/harbor/src/pkg/token/claims/v2"
+	"github.com/goharbor/harbor/src/pkg/tokenkey"
+	"github.com/goharbor/harbor/src/pkg/tokenkey/model"
+)
+
+const (
+	// SignMethod is the method signing the registry tokens
+	SignMethod = "RS256"
+	// the keys loaded from the database are cached for the interval, the other core instances pick up
+	// the rotated key within it, the tokens signed by the unknown key trigger the reloading immediately
+	cacheTTL = time.Minute
+	// the minimum interval of the forced reloading, avoids the bogus tokens hitting the database
+	minReloadInterval = 5 * time.Second
+)
+
+var (
+	// Ctl is a global variable for the default token key controller implementation
+	Ctl = NewController()
+	// the size of the generated keys, the same as the one generated by the prepare tool
+	keyBits = 4096
+)
+
+// Controller manages the keys signing the registry tokens, the keys are rotated without the downtime:
+// the new key signs the tokens immediately and the replaced key keeps verifying the issued tokens
+// during the overlap window. Before the first rotation, the key configured by the file is used
+type Controller interface {
+	// SigningOptions returns the options to sign the tokens with the active key
+	SigningOptions(ctx context.Context) (*token.Options, error)
+	// VerifyingOptions returns the options to verify the token signed by the key with the specified ID
+	VerifyingOptions(ctx context.Context, keyID string) (*token.Options, error)
+	// Rotate generates a new key to sign the tokens, the replaced key keeps verifying the tokens during the overlap
+	Rotate(ctx context.Context, overlap time.Duration) (*model.TokenKey, error)
+	// List the keys, the private keys aren't included
+	List(ctx context.Context) ([]*model.TokenKey, error)
+}
+
+// NewController creates an instance of the default token key controller
+func NewController() Controller {
+	return &controller{
+		mgr:       tokenkey.Mgr,
+		keyPath:   config.TokenPrivateKeyPath,
+		secretKey: config.SecretKey,
+	}
+}
+
+type key struct {
+	model *model.TokenKey
+	pem   []byte
+}
+
+type controller struct {
+	mgr       tokenkey.Manager
+	keyPath   func() string
+	secretKey func() (string, error)
+
+	lock     sync.RWMutex
+	keys     []*key
+	loadedAt time.Time
+}
+
+func (c *controller) SigningOptions(ctx context.Context) (*token.Options, error) {
+	keys, err := c.load(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		if k.model.Status == model.StatusActive {
+			return newOptions(k.pem), nil
+		}
+	}
+	return token.NewOptions(SignMethod, v2.Issuer, c.keyPath())
+}
+
+func (c *controller) VerifyingOptions(ctx context.Context, keyID string) (*token.Options, error) {
+	keys, err := c.load(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	k := find(keys, keyID)
+	if k == nil && len(keyID) > 0 {
+		// the key may be rotated by another instance
+		if keys, err = c.load(ctx, true); err != nil {
+			return nil, err
+		}
+		k = find(keys, keyID)
+	}
+	if k != nil {
+		if !k.model.IsValid() {
+			return nil, errors.UnauthorizedError(nil).WithMessage("the token key %s is expired", keyID)
+		}
+		return newOptions(k.pem), nil
+	}
+	// the key configured by the file is replaced once the keys are rotated
+	if len(keys) > 0 {
+		return nil, errors.UnauthorizedError(nil).WithMessage("unknown token key %s", keyID)
+	}
+	return token.NewOptions(SignMethod, v2.Issuer, c.keyPath())
+}
+
+func (c *controller) Rotate(ctx context.Context, overlap time.Duration) (*model.TokenKey, error) {
+	expiration, err := config.TokenExpiration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the tokens signed by the replaced key must be verified until they expire
+	minOverlap := time.Duration(expiration) * time.Minute
+	if overlap == 0 {
+		overlap = minOverlap
+	}
+	if overlap < minOverlap {
+		return nil, errors.BadRequestError(nil).WithMessage("the overlap should be no less than the token expiration %d minutes", expiration)
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, keyBits)
+	if err != nil {
+		return nil, err
+	}
+	newKey, err := c.encode(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	}))
+	if err != nil {
+		return nil, err
+	}
+	newKey.Status = model.StatusActive
+
+	now := time.Now()
+	expiresAt := now.Add(overlap)
+	err = orm.WithTransaction(func(ctx context.Context) error {
+		keys, err := c.mgr.List(ctx, q.New(q.KeyWords{}))
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			// the first rotation replaces the key configured by the file
+			data, err := os.ReadFile(c.keyPath())
+			if err != nil {
+				return err
+			}
+			fileKey, err := c.encode(data)
+			if err != nil {
+				return err
+			}
+			fileKey.Status = model.StatusRetiring
+			fileKey.ExpiresAt = expiresAt
+			if _, err = c.mgr.Create(ctx, fileKey); err != nil {
+				return err
+			}
+		}
+		for _, k := range keys {
+			switch {
+			case k.Status == model.StatusActive:
+				k.Status = model.StatusRetiring
+				k.ExpiresAt = expiresAt
+				if err = c.mgr.Update(ctx, k, "Status", "ExpiresAt"); err != nil {
+					return err
+				}
+			case k.ExpiresAt.Before(now):
+				if err = c.mgr.Delete(ctx, k.ID); err != nil {
+					return err
+				}
+			}
+		}
+		newKey.ID, err = c.mgr.Create(ctx, newKey)
+		return err
+	})(orm.SetTransactionOpNameToContext(ctx, "tx-rotate-token-key"))
+	if err != nil {
+		return nil, err
+	}
+
+	c.invalidate()
+	log.Infof("the token key is rotated to %s, the replaced key expires at %s", newKey.KeyID, expiresAt.Format(time.RFC3339))
+	return newKey, nil
+}
+
+func (c *controller) List(ctx context.Context) ([]*model.TokenKey, error) {
+	return c.mgr.List(ctx, q.New(q.KeyWords{}))
+}
+
+// encode the private key into the model with the encrypted private key
+func (c *controller) encode(data []byte) (*model.TokenKey, error) {
+	k, err := libtrust.UnmarshalPrivateKeyPEM(data)
+	if err != nil {
+		return nil, err
+	}
+	secretKey, err := c.secretKey()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := utils.ReversibleEncrypt(string(data), secretKey)
+	if err != nil {
+		return nil, err
+	}
+	return &model.TokenKey{
+		KeyID:      k.KeyID(),
+		PrivateKey: encrypted,
+	}, nil
+}
+
+// load the keys from the database, the cached keys are returned unless they are expired or the reloading is forced
+func (c *controller) load(ctx context.Context, force bool) ([]*key, error) {
+	c.lock.RLock()
+	keys, loadedAt := c.keys, c.loadedAt
+	c.lock.RUnlock()
+	if time.Since(loadedAt) < minReloadInterval || (!force && time.Since(loadedAt) < cacheTTL) {
+		return keys, nil
+	}
+
+	models, err := c.mgr.List(ctx, q.New(q.KeyWords{}))
+	if err != nil {
+		return nil, err
+	}
+	secretKey, err := c.secretKey()
+	if err != nil {
+		return nil, err
+	}
+	keys = make([]*key, 0, len(models))
+	for _, m := range models {
+		data, err := utils.ReversibleDecrypt(m.PrivateKey, secretKey)
+		if err != nil {
+			log.Errorf("failed to decrypt the token key %s: %v", m.KeyID, err)
+			continue
+		}
+		keys = append(keys, &key{model: m, pem: []byte(data)})
+	}
+
+	c.lock.Lock()
+	c.keys, c.loadedAt = keys, time.Now()
+	c.lock.Unlock()
+	return keys, nil
+}
+
+func (c *controller) invalidate() {
+	c.lock.Lock()
+	c.loadedAt = time.Time{}
+	c.lock.Unlock()
+}
+
+func find(keys []*key, keyID string) *key {
+	for _, k := range keys {
+		if k.model.KeyID == keyID {
+			return k
+		}
+	}
+	return nil
+}
+
+func newOptions(privateKey []byte) *token.Options {
+	return &token.Options{
+		SignMethod: jwt.GetSigningMethod(SignMethod),
+		Issuer:     v2.Issuer,
+		PrivateKey: privateKey,
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenkey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/libtrust"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
+	"github.com/goharbor/harbor/src/pkg/tokenkey/model"
+	ormtesting "github.com/goharbor/harbor/src/testing/lib/orm"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/tokenkey"
+)
+
+const secretKey = "0123456789abcdef"
+
+type controllerTestSuite struct {
+	suite.Suite
+	mgr       *tokenkey.Manager
+	ctl       *controller
+	ctx       context.Context
+	filePEM   []byte
+	fileKeyID string
+}
+
+func (c *controllerTestSuite) SetupSuite() {
+	keyBits = 2048
+	config.InitWithSettings(map[string]interface{}{common.TokenExpiration: 30})
+	c.ctx = orm.NewContext(nil, &ormtesting.FakeOrmer{})
+	c.filePEM = generateKey(c.T())
+	k, err := libtrust.UnmarshalPrivateKeyPEM(c.filePEM)
+	c.Require().Nil(err)
+	c.fileKeyID = k.KeyID()
+}
+
+func (c *controllerTestSuite) SetupTest() {
+	path := filepath.Join(c.T().TempDir(), "private_key.pem")
+	c.Require().Nil(os.WriteFile(path, c.filePEM, 0600))
+	c.mgr = &tokenkey.Manager{}
+	c.ctl = &controller{
+		mgr:       c.mgr,
+		keyPath:   func() string { return path },
+		secretKey: func() (string, error) { return secretKey, nil },
+	}
+}
+
+func generateKey(t *testing.T) []byte {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)})
+}
+
+func (c *controllerTestSuite) encrypted(data []byte) string {
+	s, err := utils.ReversibleEncrypt(string(data), secretKey)
+	c.Require().Nil(err)
+	return s
+}
+
+func (c *controllerTestSuite) TestOptionsFromFile() {
+	c.mgr.On("List", mock.Anything, mock.Anything).Return([]*model.TokenKey{}, nil)
+
+	opt, err := c.ctl.SigningOptions(c.ctx)
+	c.Require().Nil(err)
+	c.Equal(c.filePEM, opt.PrivateKey)
+
+	// the key configured by the file verifies the tokens before the rotation
+	opt, err = c.ctl.VerifyingOptions(c.ctx, c.fileKeyID)
+	c.Require().Nil(err)
+	c.Equal(c.filePEM, opt.PrivateKey)
+}
+
+func (c *controllerTestSuite) TestRotate() {
+	c.mgr.On("List", mock.Anything, mock.Anything).Return([]*model.TokenKey{}, nil).Once()
+	var created []*model.TokenKey
+	c.mgr.On("Create", mock.Anything, mock.Anything).Return(func(ctx context.Context, k *model.TokenKey) int64 {
+		created = append(created, k)
+		return int64(len(created))
+	}, nil)
+
+	key, err := c.ctl.Rotate(c.ctx, 0)
+	c.Require().Nil(err)
+	c.Require().Len(created, 2)
+	// the key configured by the file is kept for the overlap, which is the token expiration by default
+	c.Equal(c.fileKeyID, created[0].KeyID)
+	c.Equal(model.StatusRetiring, created[0].Status)
+	c.WithinDuration(time.Now().Add(30*time.Minute), created[0].ExpiresAt, time.Minute)
+	c.Equal(key, created[1])
+	c.Equal(model.StatusActive, key.Status)
+	c.NotEqual(c.fileKeyID, key.KeyID)
+
+	// the keys are reloaded after the rotation
+	c.mgr.On("List", mock.Anything, mock.Anything).Return([]*model.TokenKey{key, created[0]}, nil)
+	opt, err := c.ctl.SigningOptions(c.ctx)
+	c.Require().Nil(err)
+	k, err := libtrust.UnmarshalPrivateKeyPEM(opt.PrivateKey)
+	c.Require().Nil(err)
+	c.Equal(key.KeyID, k.KeyID())
+
+	opt, err = c.ctl.VerifyingOptions(c.ctx, c.fileKeyID)
+	c.Require().Nil(err)
+	c.Equal(c.filePEM, opt.PrivateKey)
+
+	_, err = c.ctl.VerifyingOptions(c.ctx, "unknown")
+	c.True(errors.IsErr(err, errors.UnAuthorizedCode))
+}
+
+func (c *controllerTestSuite) TestRotateActiveKey() {
+	active := &model.TokenKey{ID: 2, KeyID: "active", Status: model.StatusActive, PrivateKey: c.encrypted(generateKey(c.T()))}
+	expired := &model.TokenKey{ID: 1, KeyID: "expired", Status: model.StatusRetiring, ExpiresAt: time.Now().Add(-time.Hour), PrivateKey: c.encrypted(generateKey(c.T()))}
+	c.mgr.On("List", mock.Anything, mock.Anything).Return([]*model.TokenKey{active, expired}, nil)
+	c.mgr.On("Update", mock.Anything, active, "Status", "ExpiresAt").Return(nil)
+	c.mgr.On("Delete", mock.Anything, int64(1)).Return(nil)
+	c.mgr.On("Create", mock.Anything, mock.Anything).Return(int64(3), nil)
+
+	key, err := c.ctl.Rotate(c.ctx, 2*time.Hour)
+	c.Require().Nil(err)
+	c.Equal(int64(3), key.ID)
+	c.Equal(model.StatusRetiring, active.Status)
+	c.WithinDuration(time.Now().Add(2*time.Hour), active.ExpiresAt, time.Minute)
+	c.mgr.AssertExpectations(c.T())
+
+	// the keys are reloaded after the rotation
+	_, err = c.ctl.VerifyingOptions(c.ctx, "expired")
+	c.Require().NotNil(err)
+	c.Contains(err.Error(), "expired")
+}
+
+func (c *controllerTestSuite) TestRotateShortOverlap() {
+	_, err := c.ctl.Rotate(c.ctx, time.Minute)
+	c.True(errors.IsErr(err, errors.BadRequestCode))
+	c.mgr.AssertNotCalled(c.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &controllerTestSuite{})
+}
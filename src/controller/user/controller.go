@@ -21,12 +21,22 @@ import (
 	commonmodels "github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/common/security"
 	"github.com/goharbor/harbor/src/common/security/local"
+	"github.com/goharbor/harbor/src/common/utils"
+	event "github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/controller/event/operator"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg"
 	"github.com/goharbor/harbor/src/pkg/member"
+	memberModels "github.com/goharbor/harbor/src/pkg/member/models"
+	"github.com/goharbor/harbor/src/pkg/notification"
 	"github.com/goharbor/harbor/src/pkg/oidc"
+	"github.com/goharbor/harbor/src/pkg/project"
+	"github.com/goharbor/harbor/src/pkg/pulltoken"
+	pulltokenModel "github.com/goharbor/harbor/src/pkg/pulltoken/model"
 	"github.com/goharbor/harbor/src/pkg/user"
 	"github.com/goharbor/harbor/src/pkg/user/models"
 )
@@ -40,6 +50,8 @@ var (
 type Controller interface {
 	// SetSysAdmin ...
 	SetSysAdmin(ctx context.Context, id int, adminFlag bool) error
+	// SetAuditor grants or revokes the read-only system administrator role of the user
+	SetAuditor(ctx context.Context, id int, auditorFlag bool) error
 	// VerifyPassword ...
 	VerifyPassword(ctx context.Context, usernameOrEmail string, password string) (bool, error)
 	// UpdatePassword ...
@@ -65,6 +77,14 @@ type Controller interface {
 	SetCliSecret(ctx context.Context, id int, secret string) error
 	// UpdateOIDCMeta updates the OIDC metadata of a user, if the cols are not provided, by default the field of token and secret will be updated
 	UpdateOIDCMeta(ctx context.Context, ou *commonmodels.OIDCUser, cols ...string) error
+	// Deactivate disables the login of the user, revokes the credentials and the system roles of the user
+	// and transfers the projects owned by the user to the user specified by transferTo when it isn't 0,
+	// the records of the user are kept to retain the audit history. The bearer tokens issued to the user
+	// before the deactivation are rejected by core as the user is checked when they are used
+	Deactivate(ctx context.Context, id int, transferTo int) (*DeactivationReport, error)
+	// Reactivate enables the login of the deactivated user, the revoked credentials and system roles
+	// and the transferred projects aren't restored
+	Reactivate(ctx context.Context, id int) error
 	// OnboardOIDCUser inserts the record for basic user info and the oidc metadata
 	// if the onboard process is successful the input parm of user model will be populated with user id
 	OnboardOIDCUser(ctx context.Context, u *commonmodels.User) error
@@ -75,10 +95,29 @@ func NewController() Controller {
 	return &controller{
 		mgr:         user.New(),
 		oidcMetaMgr: oidc.NewMetaMgr(),
-		memberMgr:   member.Mgr,
+		memberMgr:   pkg.MemberMgr,
+		projectMgr:  pkg.ProjectMgr,
+		tokenMgr:    pulltoken.Mgr,
 	}
 }
 
+// DeactivationReport records what is changed when deactivating the user
+type DeactivationReport struct {
+	UserID   int
+	Username string
+	// LoginDisabled is false when the user has been deactivated before
+	LoginDisabled bool
+	// CliSecretRevoked is true when the CLI secret and the token of the OIDC user are revoked
+	CliSecretRevoked bool
+	// PullTokensRevoked is the IDs of the pull tokens created by the user and revoked
+	PullTokensRevoked []int64
+	SysAdminRevoked   bool
+	AuditorRevoked    bool
+	// TransferredProjects is the names of the projects transferred to the user specified by TransferredTo
+	TransferredProjects []string
+	TransferredTo       string
+}
+
 // Option  option for getting User info
 type Option struct {
 	WithOIDCInfo bool
@@ -88,6 +127,8 @@ type controller struct {
 	mgr         user.Manager
 	oidcMetaMgr oidc.MetaManager
 	memberMgr   member.Manager
+	projectMgr  project.Manager
+	tokenMgr    pulltoken.Manager
 }
 
 func (c *controller) UpdateOIDCMeta(ctx context.Context, ou *commonmodels.OIDCUser, cols ...string) error {
@@ -137,7 +178,19 @@ func (c *controller) SetCliSecret(ctx context.Context, id int, secret string) er
 }
 
 func (c *controller) Create(ctx context.Context, u *commonmodels.User) (int, error) {
-	return c.mgr.Create(ctx, u)
+	id, err := c.mgr.Create(ctx, u)
+	if err != nil {
+		return 0, err
+	}
+
+	// fire event
+	notification.AddEvent(ctx, &event.CreateUserEventMetadata{
+		UserID:   id,
+		Username: u.Username,
+		Operator: operator.FromContext(ctx),
+	})
+
+	return id, nil
 }
 
 func (c *controller) UpdateProfile(ctx context.Context, u *commonmodels.User, cols ...string) error {
@@ -208,3 +261,158 @@ func (c *controller) VerifyPassword(ctx context.Context, usernameOrEmail, passwo
 func (c *controller) SetSysAdmin(ctx context.Context, id int, adminFlag bool) error {
 	return c.mgr.SetSysAdminFlag(ctx, id, adminFlag)
 }
+
+func (c *controller) SetAuditor(ctx context.Context, id int, auditorFlag bool) error {
+	return c.mgr.SetAuditorFlag(ctx, id, auditorFlag)
+}
+
+func (c *controller) Deactivate(ctx context.Context, id int, transferTo int) (*DeactivationReport, error) {
+	u, err := c.mgr.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	var target *commonmodels.User
+	if transferTo != 0 {
+		if transferTo == id {
+			return nil, errors.BadRequestError(nil).WithMessage("can't transfer the projects to the user being deactivated")
+		}
+		target, err = c.mgr.Get(ctx, transferTo)
+		if err != nil {
+			if errors.IsNotFoundErr(err) {
+				return nil, errors.BadRequestError(nil).WithMessage("the user %d to transfer the projects to not found", transferTo)
+			}
+			return nil, err
+		}
+		if target.Deactivated {
+			return nil, errors.BadRequestError(nil).WithMessage("can't transfer the projects to the deactivated user %s", target.Username)
+		}
+	}
+
+	report := &DeactivationReport{
+		UserID:            u.UserID,
+		Username:          u.Username,
+		PullTokensRevoked: []int64{},
+	}
+	// all the steps are applied in one transaction to not leave the user partly deactivated
+	if err = orm.WithTransaction(func(ctx context.Context) error {
+		var err error
+		if !u.Deactivated {
+			if err = c.mgr.SetDeactivated(ctx, id, true); err != nil {
+				return err
+			}
+			report.LoginDisabled = true
+		}
+		if u.SysAdminFlag {
+			if err = c.mgr.SetSysAdminFlag(ctx, id, false); err != nil {
+				return err
+			}
+			report.SysAdminRevoked = true
+		}
+		if u.AuditorFlag {
+			if err = c.mgr.SetAuditorFlag(ctx, id, false); err != nil {
+				return err
+			}
+			report.AuditorRevoked = true
+		}
+		if report.CliSecretRevoked, err = c.revokeOIDCCredential(ctx, id); err != nil {
+			return err
+		}
+		if report.PullTokensRevoked, err = c.revokePullTokens(ctx, u.Username); err != nil {
+			return err
+		}
+		if target != nil {
+			if report.TransferredProjects, err = c.transferProjects(ctx, id, target.UserID); err != nil {
+				return err
+			}
+			report.TransferredTo = target.Username
+		}
+		return nil
+	})(orm.SetTransactionOpNameToContext(ctx, "tx-deactivate-user")); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (c *controller) Reactivate(ctx context.Context, id int) error {
+	return c.mgr.SetDeactivated(ctx, id, false)
+}
+
+// revokeOIDCCredential replaces the CLI secret of the OIDC user with a random one and drops the token,
+// it returns false when the user isn't onboarded from the OIDC provider
+func (c *controller) revokeOIDCCredential(ctx context.Context, id int) (bool, error) {
+	if lib.GetAuthMode(ctx) != common.OIDCAuth {
+		return false, nil
+	}
+	ou, err := c.oidcMetaMgr.GetByUserID(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := c.oidcMetaMgr.SetCliSecretByUserID(ctx, id, utils.GenerateRandomString()); err != nil {
+		return false, err
+	}
+	ou.Token = ""
+	if err := c.oidcMetaMgr.Update(ctx, ou, "token"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// revokePullTokens revokes the valid pull tokens created by the user
+func (c *controller) revokePullTokens(ctx context.Context, username string) ([]int64, error) {
+	tokens, err := c.tokenMgr.List(ctx, q.New(q.KeyWords{"creator": username, "revoked": false}))
+	if err != nil {
+		return nil, err
+	}
+	ids := []int64{}
+	for _, t := range tokens {
+		if !t.IsValid() {
+			continue
+		}
+		if err := c.tokenMgr.Update(ctx, &pulltokenModel.PullToken{ID: t.ID, Revoked: true}, "revoked"); err != nil {
+			return nil, err
+		}
+		ids = append(ids, t.ID)
+	}
+	return ids, nil
+}
+
+// transferProjects changes the owner of the projects owned by the user and makes sure the new owner is the project admin
+func (c *controller) transferProjects(ctx context.Context, id int, ownerID int) ([]string, error) {
+	projects, err := c.projectMgr.List(ctx, q.New(q.KeyWords{"owner_id": id}))
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, p := range projects {
+		if err := c.projectMgr.UpdateOwner(ctx, p.ProjectID, ownerID); err != nil {
+			return nil, err
+		}
+		members, err := c.memberMgr.List(ctx, memberModels.Member{
+			ProjectID:  p.ProjectID,
+			EntityID:   ownerID,
+			EntityType: common.UserMember,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(members) == 0 {
+			if _, err := c.memberMgr.AddProjectMember(ctx, memberModels.Member{
+				ProjectID:  p.ProjectID,
+				EntityID:   ownerID,
+				EntityType: common.UserMember,
+				Role:       common.RoleProjectAdmin,
+			}); err != nil {
+				return nil, err
+			}
+		} else if members[0].Role != common.RoleProjectAdmin {
+			if err := c.memberMgr.UpdateRole(ctx, p.ProjectID, members[0].ID, common.RoleProjectAdmin); err != nil {
+				return nil, err
+			}
+		}
+		names = append(names, p.Name)
+	}
+	return names, nil
+}
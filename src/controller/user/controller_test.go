@@ -0,0 +1,145 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common"
+	commonmodels "github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	memberModels "github.com/goharbor/harbor/src/pkg/member/models"
+	projectModels "github.com/goharbor/harbor/src/pkg/project/models"
+	pulltokenModel "github.com/goharbor/harbor/src/pkg/pulltoken/model"
+	ormtesting "github.com/goharbor/harbor/src/testing/lib/orm"
+	"github.com/goharbor/harbor/src/testing/mock"
+	testingmember "github.com/goharbor/harbor/src/testing/pkg/member"
+	testingoidc "github.com/goharbor/harbor/src/testing/pkg/oidc"
+	testingproject "github.com/goharbor/harbor/src/testing/pkg/project"
+	testingpulltoken "github.com/goharbor/harbor/src/testing/pkg/pulltoken"
+	testinguser "github.com/goharbor/harbor/src/testing/pkg/user"
+)
+
+type controllerTestSuite struct {
+	suite.Suite
+	ctl         *controller
+	mgr         *testinguser.Manager
+	oidcMetaMgr *testingoidc.MetaManager
+	memberMgr   *testingmember.Manager
+	projectMgr  *testingproject.Manager
+	tokenMgr    *testingpulltoken.Manager
+}
+
+func (c *controllerTestSuite) SetupTest() {
+	c.mgr = &testinguser.Manager{}
+	c.oidcMetaMgr = &testingoidc.MetaManager{}
+	c.memberMgr = &testingmember.Manager{}
+	c.projectMgr = &testingproject.Manager{}
+	c.tokenMgr = &testingpulltoken.Manager{}
+	c.ctl = &controller{
+		mgr:         c.mgr,
+		oidcMetaMgr: c.oidcMetaMgr,
+		memberMgr:   c.memberMgr,
+		projectMgr:  c.projectMgr,
+		tokenMgr:    c.tokenMgr,
+	}
+}
+
+func (c *controllerTestSuite) TestDeactivate() {
+	ctx := orm.NewContext(lib.WithAuthMode(context.TODO(), common.OIDCAuth), &ormtesting.FakeOrmer{})
+	c.mgr.On("Get", ctx, 2).Return(&commonmodels.User{UserID: 2, Username: "leaver", SysAdminFlag: true}, nil)
+	c.mgr.On("Get", ctx, 3).Return(&commonmodels.User{UserID: 3, Username: "successor"}, nil)
+	c.mgr.On("SetDeactivated", mock.Anything, 2, true).Return(nil).Once()
+	c.mgr.On("SetSysAdminFlag", mock.Anything, 2, false).Return(nil).Once()
+	c.oidcMetaMgr.On("GetByUserID", mock.Anything, 2).Return(&commonmodels.OIDCUser{ID: 1, UserID: 2, Token: "token"}, nil)
+	c.oidcMetaMgr.On("SetCliSecretByUserID", mock.Anything, 2, mock.Anything).Return(nil).Once()
+	c.oidcMetaMgr.On("Update", mock.Anything, mock.MatchedBy(func(ou *commonmodels.OIDCUser) bool { return ou.Token == "" }), "token").Return(nil).Once()
+	c.tokenMgr.On("List", mock.Anything, mock.Anything).Return([]*pulltokenModel.PullToken{
+		{ID: 1, ExpiresAt: time.Now().Add(time.Hour)},
+		{ID: 2, ExpiresAt: time.Now().Add(-time.Hour)},
+	}, nil)
+	c.tokenMgr.On("Update", mock.Anything, &pulltokenModel.PullToken{ID: 1, Revoked: true}, "revoked").Return(nil).Once()
+	c.projectMgr.On("List", mock.Anything, mock.Anything).Return([]*projectModels.Project{
+		{ProjectID: 1, Name: "library"},
+		{ProjectID: 2, Name: "team"},
+	}, nil)
+	c.projectMgr.On("UpdateOwner", mock.Anything, int64(1), 3).Return(nil).Once()
+	c.projectMgr.On("UpdateOwner", mock.Anything, int64(2), 3).Return(nil).Once()
+	c.memberMgr.On("List", mock.Anything, memberModels.Member{ProjectID: 1, EntityID: 3, EntityType: common.UserMember}, mock.Anything).Return([]*memberModels.Member{}, nil)
+	c.memberMgr.On("List", mock.Anything, memberModels.Member{ProjectID: 2, EntityID: 3, EntityType: common.UserMember}, mock.Anything).Return([]*memberModels.Member{{ID: 5, Role: common.RoleDeveloper}}, nil)
+	c.memberMgr.On("AddProjectMember", mock.Anything, memberModels.Member{ProjectID: 1, EntityID: 3, EntityType: common.UserMember, Role: common.RoleProjectAdmin}).Return(1, nil).Once()
+	c.memberMgr.On("UpdateRole", mock.Anything, int64(2), 5, common.RoleProjectAdmin).Return(nil).Once()
+
+	report, err := c.ctl.Deactivate(ctx, 2, 3)
+	c.Require().Nil(err)
+	c.Equal(&DeactivationReport{
+		UserID:              2,
+		Username:            "leaver",
+		LoginDisabled:       true,
+		CliSecretRevoked:    true,
+		PullTokensRevoked:   []int64{1},
+		SysAdminRevoked:     true,
+		TransferredProjects: []string{"library", "team"},
+		TransferredTo:       "successor",
+	}, report)
+	c.mgr.AssertExpectations(c.T())
+	c.oidcMetaMgr.AssertExpectations(c.T())
+	c.tokenMgr.AssertExpectations(c.T())
+	c.projectMgr.AssertExpectations(c.T())
+	c.memberMgr.AssertExpectations(c.T())
+}
+
+func (c *controllerTestSuite) TestDeactivateFailure() {
+	ctx := orm.NewContext(context.TODO(), &ormtesting.FakeOrmer{})
+	c.mgr.On("Get", ctx, 2).Return(&commonmodels.User{UserID: 2, Username: "leaver", SysAdminFlag: true}, nil)
+	c.mgr.On("SetDeactivated", mock.Anything, 2, true).Return(nil).Once()
+	c.mgr.On("SetSysAdminFlag", mock.Anything, 2, false).Return(errors.New("failed")).Once()
+
+	// the steps are rolled back and no report is returned
+	report, err := c.ctl.Deactivate(ctx, 2, 0)
+	c.Require().NotNil(err)
+	c.Nil(report)
+	c.tokenMgr.AssertNotCalled(c.T(), "List", mock.Anything, mock.Anything)
+}
+
+func (c *controllerTestSuite) TestDeactivateInvalidTransfer() {
+	ctx := context.TODO()
+	c.mgr.On("Get", ctx, 2).Return(&commonmodels.User{UserID: 2, Username: "leaver"}, nil)
+	c.mgr.On("Get", ctx, 3).Return(&commonmodels.User{UserID: 3, Username: "deactivated", Deactivated: true}, nil)
+	c.mgr.On("Get", ctx, 4).Return(nil, errors.NotFoundError(nil))
+
+	for _, transferTo := range []int{2, 3, 4} {
+		_, err := c.ctl.Deactivate(ctx, 2, transferTo)
+		c.True(errors.IsErr(err, errors.BadRequestCode))
+	}
+	c.mgr.AssertNotCalled(c.T(), "SetDeactivated", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (c *controllerTestSuite) TestReactivate() {
+	ctx := context.TODO()
+	c.mgr.On("SetDeactivated", ctx, 2, false).Return(nil).Once()
+	c.Require().Nil(c.ctl.Reactivate(ctx, 2))
+	c.mgr.AssertExpectations(c.T())
+}
+
+func TestControllerTestSuite(t *testing.T) {
+	suite.Run(t, &controllerTestSuite{})
+}
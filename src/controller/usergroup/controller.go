@@ -21,6 +21,7 @@ import (
 	"github.com/goharbor/harbor/src/core/auth"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg"
 	"github.com/goharbor/harbor/src/pkg/ldap"
 	"github.com/goharbor/harbor/src/pkg/usergroup"
 	"github.com/goharbor/harbor/src/pkg/usergroup/model"
@@ -49,6 +50,14 @@ type Controller interface {
 	List(ctx context.Context, q *q.Query) ([]*model.UserGroup, error)
 	// Count user group count
 	Count(ctx context.Context, q *q.Query) (int64, error)
+	// AddMember add the user into the user group
+	AddMember(ctx context.Context, groupID, userID int) error
+	// RemoveMember remove the user from the user group
+	RemoveMember(ctx context.Context, groupID, userID int) error
+	// ListMemberIDs list the IDs of the users in the user group
+	ListMemberIDs(ctx context.Context, groupID int) ([]int, error)
+	// ListGroupIDsByUser list the IDs of the user groups which the user is a member of
+	ListGroupIDsByUser(ctx context.Context, userID int) ([]int, error)
 }
 
 type controller struct {
@@ -56,7 +65,7 @@ type controller struct {
 }
 
 func newController() Controller {
-	return &controller{mgr: usergroup.Mgr}
+	return &controller{mgr: pkg.UserGroupMgr}
 }
 
 func (c *controller) List(ctx context.Context, query *q.Query) ([]*model.UserGroup, error) {
@@ -116,3 +125,19 @@ func (c *controller) Get(ctx context.Context, id int) (*model.UserGroup, error)
 func (c *controller) Count(ctx context.Context, query *q.Query) (int64, error) {
 	return c.mgr.Count(ctx, query)
 }
+
+func (c *controller) AddMember(ctx context.Context, groupID, userID int) error {
+	return c.mgr.AddMember(ctx, groupID, userID)
+}
+
+func (c *controller) RemoveMember(ctx context.Context, groupID, userID int) error {
+	return c.mgr.RemoveMember(ctx, groupID, userID)
+}
+
+func (c *controller) ListMemberIDs(ctx context.Context, groupID int) ([]int, error) {
+	return c.mgr.ListMemberIDs(ctx, groupID)
+}
+
+func (c *controller) ListGroupIDsByUser(ctx context.Context, userID int) ([]int, error) {
+	return c.mgr.ListGroupIDsByUser(ctx, userID)
+}
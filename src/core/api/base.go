@@ -33,6 +33,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/retention"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
 )
 
@@ -205,5 +206,7 @@ func initChartController() error {
 	}
 
 	chartController = chartCtl
+	// include the chart repositories in the tag retention
+	retention.RegisterChartLister(chartCtl)
 	return nil
 }
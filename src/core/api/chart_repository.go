@@ -23,7 +23,7 @@ import (
 	"github.com/goharbor/harbor/src/core/label"
 	"github.com/goharbor/harbor/src/lib/config"
 	hlog "github.com/goharbor/harbor/src/lib/log"
-	pkg_label "github.com/goharbor/harbor/src/pkg/label"
+	"github.com/goharbor/harbor/src/pkg"
 	n_event "github.com/goharbor/harbor/src/pkg/notifier/event"
 	"github.com/goharbor/harbor/src/pkg/reg/model"
 	"github.com/goharbor/harbor/src/server/middleware/orm"
@@ -90,11 +90,14 @@ func (cra *ChartRepositoryAPI) Prepare() {
 		if !cra.requireNamespace(cra.namespace) {
 			return
 		}
+		if !cra.requireChartRepositoryEnabled(cra.namespace) {
+			return
+		}
 	}
 
 	// Init label manager
 	cra.labelManager = &label.BaseManager{
-		LabelMgr: pkg_label.Mgr,
+		LabelMgr: pkg.LabelMgr,
 	}
 }
 
@@ -147,7 +150,7 @@ func (cra *ChartRepositoryAPI) GetIndex() {
 		return
 	}
 
-	projects, err := cra.ProjectCtl.List(cra.Context(), nil, project.Metadata(false))
+	projects, err := cra.ProjectCtl.List(cra.Context(), nil, project.Metadata(true))
 	if err != nil {
 		cra.SendInternalServerError(err)
 		return
@@ -155,6 +158,10 @@ func (cra *ChartRepositoryAPI) GetIndex() {
 
 	namespaces := []string{}
 	for _, r := range projects {
+		// the charts of the projects whose chart repository is disabled aren't served
+		if !r.ChartRepositoryEnabled() {
+			continue
+		}
 		namespaces = append(namespaces, r.Name)
 	}
 
@@ -445,6 +452,29 @@ func (cra *ChartRepositoryAPI) requireNamespace(namespace string) bool {
 	return true
 }
 
+// Check if the chart repository of the namespace is enabled
+// Return true if it is or the request deletes the charts, the charts can be still
+// deleted to release the storage after the chart repository is disabled
+// Return false if it is not
+func (cra *ChartRepositoryAPI) requireChartRepositoryEnabled(namespace string) bool {
+	if cra.Ctx.Request.Method == http.MethodDelete {
+		return true
+	}
+
+	pro, err := cra.ProjectCtl.Get(cra.Context(), namespace)
+	if err != nil {
+		cra.SendInternalServerError(fmt.Errorf("failed to get the namespace %s with error: %s", namespace, err.Error()))
+		return false
+	}
+
+	if !pro.ChartRepositoryEnabled() {
+		cra.SendForbiddenError(fmt.Errorf("the chart repository of the namespace %s is disabled", namespace))
+		return false
+	}
+
+	return true
+}
+
 // formFile is used to represent the uploaded files in the form
 type formFile struct {
 	// form field key contains the form file
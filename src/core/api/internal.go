@@ -16,6 +16,7 @@ package api
 
 import (
 	"context"
+	"strings"
 
 	o "github.com/beego/beego/v2/client/orm"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg"
 )
 
 // InternalAPI handles request of harbor admin...
@@ -108,3 +110,19 @@ func (ia *InternalAPI) SyncQuota() {
 		log.Info("success to sync quota(API).")
 	}()
 }
+
+// FlushCache flushes the cache of the resources specified by the "type" query parameter,
+// e.g. "type=project,label", the cache of all the resources is flushed if no type is specified
+func (ia *InternalAPI) FlushCache() {
+	var types []string
+	for _, t := range strings.Split(ia.GetString("type"), ",") {
+		if t = strings.TrimSpace(t); len(t) > 0 {
+			types = append(types, t)
+		}
+	}
+	if err := pkg.FlushCache(ia.Ctx.Request.Context(), types...); err != nil {
+		ia.SendError(err)
+		return
+	}
+	log.Infof("the cache is flushed by %s, resource types: %v", ia.SecurityCtx.GetUsername(), types)
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/core/label"
 	"github.com/goharbor/harbor/src/lib/log"
-	pkg_label "github.com/goharbor/harbor/src/pkg/label"
+	"github.com/goharbor/harbor/src/pkg"
 	"github.com/goharbor/harbor/src/pkg/label/model"
 )
 
@@ -23,7 +23,7 @@ func (lra *LabelResourceAPI) Prepare() {
 
 	// Create label manager
 	lra.labelManager = &label.BaseManager{
-		LabelMgr: pkg_label.Mgr,
+		LabelMgr: pkg.LabelMgr,
 	}
 }
 
@@ -161,8 +161,14 @@ func Login(ctx context.Context, m models.AuthModel) (*models.User, error) {
 		}
 		return nil, err
 	}
-	err = authenticator.PostAuthenticate(ctx, user)
-	return user, err
+	if err = authenticator.PostAuthenticate(ctx, user); err != nil {
+		return user, err
+	}
+	if user != nil && user.Deactivated {
+		log.Debugf("%s is deactivated, login failed", m.Principal)
+		return nil, NewErrAuth("the user is deactivated")
+	}
+	return user, nil
 }
 
 func getHelper(ctx context.Context) (AuthenticateHelper, error) {
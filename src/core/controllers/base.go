@@ -31,6 +31,7 @@ import (
 	"github.com/goharbor/harbor/src/core/auth"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
 )
@@ -78,7 +79,8 @@ func (cc *CommonController) Login() {
 		ep, err := config.ExtEndpoint()
 		if err != nil {
 			log.Errorf("Failed to get the external endpoint, error: %v", err)
-			cc.CustomAbort(http.StatusUnauthorized, "")
+			cc.SendError(errors.UnauthorizedError(nil).WithMessage("failed to redirect to the OIDC provider"))
+			return
 		}
 		url := strings.TrimSuffix(ep, "/") + common.OIDCLoginPath
 		log.Debugf("Redirect user %s to login page of OIDC provider", principal)
@@ -99,11 +101,13 @@ func (cc *CommonController) Login() {
 	})
 	if err != nil {
 		log.Errorf("Error occurred in UserLogin: %v", err)
-		cc.CustomAbort(http.StatusUnauthorized, "")
+		cc.SendError(errors.UnauthorizedError(nil).WithMessage("invalid username or password"))
+		return
 	}
 
 	if user == nil {
-		cc.CustomAbort(http.StatusUnauthorized, "")
+		cc.SendError(errors.UnauthorizedError(nil).WithMessage("invalid username or password"))
+		return
 	}
 	cc.PopulateUserSession(*user)
 }
@@ -112,7 +116,7 @@ func (cc *CommonController) Login() {
 func (cc *CommonController) LogOut() {
 	if err := cc.DestroySession(); err != nil {
 		log.Errorf("Error occurred in LogOut: %v", err)
-		cc.CustomAbort(http.StatusInternalServerError, "Internal error.")
+		cc.SendError(err)
 	}
 }
 
@@ -126,7 +130,8 @@ func (cc *CommonController) UserExists() {
 	securityCtx, ok := security.FromContext(ctx)
 	isAdmin := ok && securityCtx.IsSysAdmin()
 	if !flag && !isAdmin {
-		cc.CustomAbort(http.StatusPreconditionFailed, "self registration deactivated, only sysadmin can check user existence")
+		cc.SendError(errors.PreconditionFailedError(nil).WithMessage("self registration deactivated, only sysadmin can check user existence"))
+		return
 	}
 
 	target := cc.GetString("target")
@@ -143,12 +148,13 @@ func (cc *CommonController) UserExists() {
 	n, err := user.Ctl.Count(ctx, query)
 	if err != nil {
 		log.Errorf("Error occurred in UserExists: %v", err)
-		cc.CustomAbort(http.StatusInternalServerError, "Internal error.")
+		cc.SendError(err)
+		return
 	}
 	cc.Data["json"] = n > 0
 	if err := cc.ServeJSON(); err != nil {
 		log.Errorf("failed to serve json: %v", err)
-		cc.CustomAbort(http.StatusInternalServerError, "Internal error.")
+		cc.SendError(err)
 	}
 }
 
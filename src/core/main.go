@@ -26,14 +26,18 @@ import (
 	"syscall"
 	"time"
 
+	beegoorm "github.com/beego/beego/v2/client/orm"
 	"github.com/beego/beego/v2/server/web"
 
 	"github.com/goharbor/harbor/src/common/dao"
 	common_http "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/common/registryctl"
 	configCtl "github.com/goharbor/harbor/src/controller/config"
 	_ "github.com/goharbor/harbor/src/controller/event/handler"
 	"github.com/goharbor/harbor/src/controller/health"
+	"github.com/goharbor/harbor/src/controller/jobarchive"
 	"github.com/goharbor/harbor/src/controller/registry"
+	"github.com/goharbor/harbor/src/controller/statistic"
 	"github.com/goharbor/harbor/src/controller/systemartifact"
 	"github.com/goharbor/harbor/src/core/api"
 	_ "github.com/goharbor/harbor/src/core/auth/authproxy"
@@ -51,11 +55,15 @@ import (
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/metric"
 	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/profiling"
 	"github.com/goharbor/harbor/src/lib/retry"
 	tracelib "github.com/goharbor/harbor/src/lib/trace"
 	"github.com/goharbor/harbor/src/migration"
+	_ "github.com/goharbor/harbor/src/pkg/accessory/model/attestation"
 	_ "github.com/goharbor/harbor/src/pkg/accessory/model/base"
 	_ "github.com/goharbor/harbor/src/pkg/accessory/model/cosign"
+	_ "github.com/goharbor/harbor/src/pkg/accessory/model/sbom"
+	_ "github.com/goharbor/harbor/src/pkg/accessory/model/subject"
 	"github.com/goharbor/harbor/src/pkg/audit"
 	dbCfg "github.com/goharbor/harbor/src/pkg/config/db"
 	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
@@ -64,6 +72,7 @@ import (
 	"github.com/goharbor/harbor/src/pkg/oidc"
 	"github.com/goharbor/harbor/src/pkg/scan"
 	"github.com/goharbor/harbor/src/pkg/scan/dao/scanner"
+	"github.com/goharbor/harbor/src/pkg/usage"
 	pkguser "github.com/goharbor/harbor/src/pkg/user"
 	"github.com/goharbor/harbor/src/pkg/version"
 	"github.com/goharbor/harbor/src/server"
@@ -92,6 +101,27 @@ func updateInitPassword(ctx context.Context, userID int, password string) error
 	return nil
 }
 
+// validateConfigurations validates the dependencies and configurations at startup and exits on the fatal
+// misconfigurations, so they're reported with the fixes here rather than failing the requests later
+func validateConfigurations(ctx context.Context) {
+	fatal := false
+	for _, result := range health.Ctl.Validate(ctx) {
+		if len(result.Error) == 0 {
+			log.Infof("the validation of %s passed", result.Name)
+			continue
+		}
+		if result.Fatal {
+			fatal = true
+			log.Errorf("the validation of %s failed: %s, %s", result.Name, result.Error, result.Suggestion)
+			continue
+		}
+		log.Warningf("the validation of %s failed: %s, %s", result.Name, result.Error, result.Suggestion)
+	}
+	if fatal {
+		log.Fatal("failed to start core because of the misconfigurations above")
+	}
+}
+
 func gracefulShutdown(closing, done chan struct{}, shutdowns ...func()) {
 	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
@@ -153,6 +183,11 @@ func main() {
 		metric.RegisterCollectors()
 		go metric.ServeProm(metricCfg.Path, metricCfg.Port)
 	}
+	// the profiling must be enabled before initializing the database to profile the queries
+	if profilingCfg := config.Profiling(); profilingCfg.Enabled {
+		profiling.Enable(profilingCfg.Window, profilingCfg.TopN)
+		log.Infof("profiling the slowest API routes and database queries over %s", profilingCfg.Window)
+	}
 	ctx := context.Background()
 	config.InitTraceConfig(ctx)
 	shutdownTracerProvider := tracelib.InitGlobalTracer(ctx)
@@ -164,6 +199,13 @@ func main() {
 	if err := dao.InitDatabase(database); err != nil {
 		log.Fatalf("failed to initialize database: %v", err)
 	}
+	if metricCfg.Enabled {
+		if db, err := beegoorm.GetDB("default"); err == nil {
+			metric.RegisterDBStatsCollector(db)
+		} else {
+			log.Warningf("failed to get the database to collect its stats: %v", err)
+		}
+	}
 	if strings.EqualFold(*runMode, "migrate") {
 		// Used by Harbor helm preinstall, preupgrade hook container
 		if err = migration.Migrate(database); err != nil {
@@ -189,6 +231,7 @@ func main() {
 	if err := configCtl.Ctl.OverwriteConfig(ctx); err != nil {
 		log.Fatalf("failed to init config from CONFIG_OVERWRITE_JSON, error %v", err)
 	}
+	validateConfigurations(ctx)
 	password, err := config.InitialAdminPassword()
 	if err != nil {
 		log.Fatalf("failed to get admin's initial password: %v", err)
@@ -207,7 +250,7 @@ func main() {
 
 	closing := make(chan struct{})
 	done := make(chan struct{})
-	go gracefulShutdown(closing, done, shutdownTracerProvider)
+	go gracefulShutdown(closing, done, shutdownTracerProvider, audit.Writer.Close, audit.Forwarder.Close, usage.Mgr.Close)
 	// Start health checker for registries
 	go registry.Ctl.StartRegularHealthCheck(orm.Context(), closing, done)
 	// Init audit log
@@ -217,6 +260,9 @@ func main() {
 	log.Info("initializing notification...")
 	notification.Init()
 
+	// the registry controller manages the storage backend of the registry
+	registryctl.Init()
+
 	server.RegisterRoutes()
 
 	if common_http.InternalTLSEnabled() {
@@ -258,6 +304,8 @@ func main() {
 			return
 		}
 		systemartifact.ScheduleCleanupTask(ctx)
+		jobarchive.ScheduleCleanupTask(ctx)
+		statistic.ScheduleReconcile(ctx)
 	}()
 	web.RunWithMiddleWares("", middlewares.MiddleWares()...)
 }
@@ -17,18 +17,24 @@ package middlewares
 import (
 	"net/http"
 	"regexp"
+	"strings"
 
 	"github.com/beego/beego/v2/server/web"
 
 	"github.com/goharbor/harbor/src/pkg/distribution"
 	"github.com/goharbor/harbor/src/server/middleware"
+	"github.com/goharbor/harbor/src/server/middleware/apiversion"
 	"github.com/goharbor/harbor/src/server/middleware/artifactinfo"
+	"github.com/goharbor/harbor/src/server/middleware/cors"
 	"github.com/goharbor/harbor/src/server/middleware/csrf"
 	"github.com/goharbor/harbor/src/server/middleware/log"
+	"github.com/goharbor/harbor/src/server/middleware/maintenance"
 	"github.com/goharbor/harbor/src/server/middleware/mergeslash"
 	"github.com/goharbor/harbor/src/server/middleware/metric"
 	"github.com/goharbor/harbor/src/server/middleware/notification"
 	"github.com/goharbor/harbor/src/server/middleware/orm"
+	"github.com/goharbor/harbor/src/server/middleware/profiling"
+	"github.com/goharbor/harbor/src/server/middleware/ratelimit"
 	"github.com/goharbor/harbor/src/server/middleware/readonly"
 	"github.com/goharbor/harbor/src/server/middleware/requestid"
 	"github.com/goharbor/harbor/src/server/middleware/security"
@@ -36,6 +42,7 @@ import (
 	"github.com/goharbor/harbor/src/server/middleware/trace"
 	"github.com/goharbor/harbor/src/server/middleware/transaction"
 	"github.com/goharbor/harbor/src/server/middleware/url"
+	"github.com/goharbor/harbor/src/server/v2.0/route"
 )
 
 var (
@@ -74,8 +81,38 @@ var (
 		middleware.MethodAndPathSkipper(http.MethodPost, match("^/service/notifications/jobs/retention/task/"+numericRegexp.String())),
 		middleware.MethodAndPathSkipper(http.MethodPost, match("^/service/notifications/jobs/schedules/"+numericRegexp.String())),
 		middleware.MethodAndPathSkipper(http.MethodPost, match("^/service/notifications/jobs/webhook/"+numericRegexp.String())),
+		// the status of the jobs must be updated while the backups hold the read-only mode
+		middleware.MethodAndPathSkipper(http.MethodPost, match("^/service/notifications/tasks/"+numericRegexp.String())),
+		middleware.MethodAndPathSkipper(http.MethodPost, match("^/api/v2.0/system/backups")),
+		middleware.MethodAndPathSkipper(http.MethodPut, match("^/api/v2.0/system/backups/"+numericRegexp.String())),
 		pingSkipper,
 	}
+
+	// apiAliasSkippers skip the rewriting of the path for the APIs which aren't versioned
+	apiAliasSkippers = []middleware.Skipper{
+		middleware.MethodAndPathSkipper(http.MethodGet, match("^/api/version$")),
+		middleware.MethodAndPathSkipper(http.MethodGet, match("^/api/openapi.json$")),
+		func(r *http.Request) bool {
+			return strings.HasPrefix(r.URL.Path, "/api/internal/") || strings.HasPrefix(r.URL.Path, "/api/chartrepo/")
+		},
+	}
+
+	// corsSkippers skip the CORS policy for the requests other than the APIs
+	corsSkippers = []middleware.Skipper{
+		func(r *http.Request) bool {
+			return !strings.HasPrefix(r.URL.Path, "/api/")
+		},
+	}
+
+	// rateLimitSkippers skip the rate limit for the requests other than the APIs, and the ping and health APIs
+	// which are used by the probes
+	rateLimitSkippers = []middleware.Skipper{
+		func(r *http.Request) bool {
+			return !strings.HasPrefix(r.URL.Path, "/api/")
+		},
+		pingSkipper,
+		middleware.MethodAndPathSkipper(http.MethodGet, match("^/api/v2.0/health")),
+	}
 )
 
 // MiddleWares returns global middlewares
@@ -83,10 +120,13 @@ func MiddleWares() []web.MiddleWare {
 	return []web.MiddleWare{
 		url.Middleware(),
 		mergeslash.Middleware(),
+		apiversion.AliasMiddleware(route.APIVersion, apiAliasSkippers...),
 		trace.Middleware(),
 		metric.Middleware(),
+		profiling.Middleware(),
 		requestid.Middleware(),
 		log.Middleware(),
+		cors.Middleware(corsSkippers...), // the preflight requests are answered before the session, CSRF and auth
 		session.Middleware(),
 		csrf.Middleware(),
 		orm.Middleware(pingSkipper),
@@ -95,6 +135,8 @@ func MiddleWares() []web.MiddleWare {
 		artifactinfo.Middleware(),
 		security.Middleware(pingSkipper),
 		security.UnauthorizedMiddleware(),
+		ratelimit.Middleware(rateLimitSkippers...),
 		readonly.Middleware(readonlySkippers...),
+		maintenance.Middleware(readonlySkippers...), // the writes skipped by the readonly mode are allowed in the maintenance as well
 	}
 }
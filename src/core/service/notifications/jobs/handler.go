@@ -15,6 +15,7 @@
 package jobs
 
 import (
+	"context"
 	"encoding/json"
 	"time"
 
@@ -22,8 +23,10 @@ import (
 	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/core/service/notifications"
 	jjob "github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/notification"
 	"github.com/goharbor/harbor/src/pkg/notification/job/model"
 )
@@ -89,6 +92,14 @@ func (h *Handler) Prepare() {
 // HandleNotificationJob handles the hook of notification job
 func (h *Handler) HandleNotificationJob() {
 	log.Debugf("received notification job status update event: job-%d, status-%s", h.id, h.status)
+	// the check in data is the delivery attempt reported by the webhook or slack job
+	if len(h.checkIn) > 0 {
+		if err := h.recordAttempt(orm.Context()); err != nil {
+			log.Errorf("Failed to record the delivery attempt of notification job %d: %v", h.id, err)
+			h.SendInternalServerError(err)
+		}
+		return
+	}
 	if err := notification.JobMgr.Update(orm.Context(), &model.Job{
 		ID:         h.id,
 		Status:     h.status,
@@ -99,3 +110,41 @@ func (h *Handler) HandleNotificationJob() {
 		return
 	}
 }
+
+// recordAttempt records the delivery attempt, and parks the job in the dead letters when it fails for the max times
+func (h *Handler) recordAttempt(ctx context.Context) error {
+	attempt := &model.Attempt{}
+	if err := json.Unmarshal([]byte(h.checkIn), attempt); err != nil {
+		return err
+	}
+	attempt.JobID = h.id
+	if _, err := notification.JobMgr.CreateAttempt(ctx, attempt); err != nil {
+		return err
+	}
+	if attempt.Success || attempt.MaxFails <= 0 {
+		return nil
+	}
+
+	fails, err := notification.JobMgr.CountAttempts(ctx, q.New(q.KeyWords{"JobID": h.id, "Success": false}))
+	if err != nil {
+		return err
+	}
+	if fails < int64(attempt.MaxFails) {
+		return nil
+	}
+	j, err := notification.JobMgr.Get(ctx, h.id)
+	if err != nil {
+		return err
+	}
+	if _, err = notification.JobMgr.CreateDeadLetter(ctx, &model.DeadLetter{
+		JobID:      j.ID,
+		PolicyID:   j.PolicyID,
+		EventType:  j.EventType,
+		NotifyType: j.NotifyType,
+		Reason:     attempt.Error,
+	}); err != nil && !errors.IsConflictErr(err) {
+		return err
+	}
+	log.Warningf("the notification job %d failed %d times, parked in the dead letters", h.id, fails)
+	return nil
+}
@@ -28,24 +28,14 @@ import (
 	"github.com/goharbor/harbor/src/common/security"
 	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/tokenkey"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/networkpolicy"
 	tokenpkg "github.com/goharbor/harbor/src/pkg/token"
 	v2 "github.com/goharbor/harbor/src/pkg/token/claims/v2"
 )
 
-const (
-	signingMethod = "RS256"
-)
-
-var (
-	privateKey string
-)
-
-func init() {
-	privateKey = config.TokenPrivateKeyPath()
-}
-
 // GetResourceActions ...
 func GetResourceActions(scopes []string) []*token.ResourceActions {
 	log.Debugf("scopes: %+v", scopes)
@@ -109,9 +99,9 @@ func filterAccess(ctx context.Context, access []*token.ResourceActions,
 	return nil
 }
 
-// MakeToken makes a valid jwt token based on parms.
-func MakeToken(ctx context.Context, username, service string, access []*token.ResourceActions) (*models.Token, error) {
-	options, err := tokenpkg.NewOptions(signingMethod, v2.Issuer, privateKey)
+// MakeToken makes a valid jwt token based on parms, the token is only usable from the networks allowed by the policies.
+func MakeToken(ctx context.Context, username, service string, access []*token.ResourceActions, policies ...*networkpolicy.Policy) (*models.Token, error) {
+	options, err := tokenkey.Ctl.SigningOptions(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -131,7 +121,8 @@ func MakeToken(ctx context.Context, username, service string, access []*token.Re
 			IssuedAt:  jwt.NewNumericDate(now),
 			ID:        utils.GenerateRandomStringWithLen(16),
 		},
-		Access: access,
+		Access:          access,
+		NetworkPolicies: policies,
 	}
 	tok, err := tokenpkg.New(options, claims)
 	if err != nil {
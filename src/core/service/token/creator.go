@@ -27,10 +27,13 @@ import (
 	"github.com/goharbor/harbor/src/common/rbac"
 	rbac_project "github.com/goharbor/harbor/src/common/rbac/project"
 	"github.com/goharbor/harbor/src/common/security"
+	robotSec "github.com/goharbor/harbor/src/common/security/robot"
 	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/networkpolicy"
 )
 
 var creatorMap map[string]Creator
@@ -184,6 +187,24 @@ func (rep repositoryFilter) filter(ctx context.Context, ctl project.Controller,
 		return err
 	}
 
+	// the token granting the access to the project restricting the networks is only issued to and usable from them
+	if policy := project.NetworkPolicy(); !policy.IsEmpty() {
+		secCtx, ok := security.FromContext(ctx)
+		if !ok || !IsNetworkPolicyExempted(secCtx) {
+			if !policy.Allows(networkpolicy.FromContext(ctx)) {
+				log.Debugf("project %s isn't accessible from %v, set empty permission", projectName, networkpolicy.FromContext(ctx))
+				a.Actions = []string{}
+				return nil
+			}
+			collectNetworkPolicy(ctx, policy)
+		}
+	}
+
+	// populate the repository being accessed for the security contexts which are limited to some repositories
+	ctx = lib.WithArtifactInfo(ctx, lib.ArtifactInfo{
+		ProjectName: projectName,
+		Repository:  fmt.Sprintf("%s/%s", projectName, img.repo),
+	})
 	resource := rbac_project.NewNamespace(project.ProjectID).Resource(rbac.ResourceRepository)
 	scopeList := make([]string, 0)
 	for s := range resourceScopes(ctx, resource) {
@@ -242,11 +263,17 @@ func (g generalCreator) Create(r *http.Request) (*models.Token, error) {
 		}
 	}
 	access := GetResourceActions(scopes)
-	err = filterAccess(r.Context(), access, project.Ctl, g.filterMap)
+	filterCtx, collector := withPolicyCollector(networkpolicy.NewContext(r.Context(), networkpolicy.ClientIP(r)))
+	err = filterAccess(filterCtx, access, project.Ctl, g.filterMap)
 	if err != nil {
 		return nil, err
 	}
-	return MakeToken(r.Context(), ctx.GetUsername(), g.service, access)
+	policies := collector.policies
+	// the robot restricting the networks is checked when authenticating, bind its token to the networks as well
+	if robotCtx, ok := ctx.(*robotSec.SecurityContext); ok && !robotCtx.User().NetworkPolicy().IsEmpty() {
+		policies = append(policies, robotCtx.User().NetworkPolicy())
+	}
+	return MakeToken(r.Context(), ctx.GetUsername(), g.service, access, policies...)
 }
 
 func parseScopes(u *url.URL) []string {
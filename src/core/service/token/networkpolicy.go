@@ -0,0 +1,60 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"context"
+	"sync"
+
+	"github.com/goharbor/harbor/src/common/security"
+	robotSec "github.com/goharbor/harbor/src/common/security/robot"
+	"github.com/goharbor/harbor/src/pkg/networkpolicy"
+)
+
+// IsNetworkPolicyExempted returns whether the security context is exempted from the network policies of the projects,
+// they're the harbor components and the robots created by harbor internally, e.g. the ones for scanning
+func IsNetworkPolicyExempted(secCtx security.Context) bool {
+	if secCtx.IsSolutionUser() {
+		return true
+	}
+	if r, ok := secCtx.(*robotSec.SecurityContext); ok {
+		return !r.User().Visible
+	}
+	return false
+}
+
+// policyCollector collects the network policies the token is bound to while filtering the access
+type policyCollector struct {
+	sync.Mutex
+	policies []*networkpolicy.Policy
+}
+
+type policyCollectorKey struct{}
+
+func withPolicyCollector(ctx context.Context) (context.Context, *policyCollector) {
+	c := &policyCollector{}
+	return context.WithValue(ctx, policyCollectorKey{}, c), c
+}
+
+// collectNetworkPolicy binds the token created with the context to the network policy
+func collectNetworkPolicy(ctx context.Context, policy *networkpolicy.Policy) {
+	c, ok := ctx.Value(policyCollectorKey{}).(*policyCollector)
+	if !ok {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.policies = append(c.policies, policy)
+}
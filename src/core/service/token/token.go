@@ -17,10 +17,11 @@ package token
 import (
 	"fmt"
 	"html/template"
-	"net/http"
 
 	"github.com/beego/beego/v2/server/web"
 
+	"github.com/goharbor/harbor/src/lib/errors"
+	lib_http "github.com/goharbor/harbor/src/lib/http"
 	"github.com/goharbor/harbor/src/lib/log"
 )
 
@@ -40,19 +41,22 @@ func (h *Handler) Get() {
 	if !ok {
 		errMsg := fmt.Sprintf("Unable to handle service: %s", service)
 		log.Errorf(errMsg)
-		h.CustomAbort(http.StatusBadRequest, template.HTMLEscapeString(errMsg))
+		lib_http.SendError(h.Ctx.ResponseWriter, errors.BadRequestError(nil).WithMessage(template.HTMLEscapeString(errMsg)))
+		return
 	}
 	token, err := tokenCreator.Create(request)
 	if err != nil {
 		if _, ok := err.(*unauthorizedError); ok {
-			h.CustomAbort(http.StatusUnauthorized, "")
+			lib_http.SendError(h.Ctx.ResponseWriter, errors.UnauthorizedError(nil))
+			return
 		}
 		log.Errorf("Unexpected error when creating the token, error: %v", err)
-		h.CustomAbort(http.StatusInternalServerError, "")
+		lib_http.SendError(h.Ctx.ResponseWriter, err)
+		return
 	}
 	h.Data["json"] = token
 	if err := h.ServeJSON(); err != nil {
 		log.Errorf("failed to serve json on /service/token, %v", err)
-		h.CustomAbort(http.StatusInternalServerError, "")
+		lib_http.SendError(h.Ctx.ResponseWriter, err)
 	}
 }
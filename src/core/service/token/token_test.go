@@ -19,6 +19,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"path"
@@ -28,6 +29,7 @@ import (
 	"github.com/docker/distribution/registry/auth/token"
 	jwt "github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/common/rbac/project"
@@ -37,7 +39,9 @@ import (
 	"github.com/goharbor/harbor/src/lib/orm"
 	_ "github.com/goharbor/harbor/src/pkg/config/db"
 	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
+	"github.com/goharbor/harbor/src/pkg/networkpolicy"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
 )
 
 func TestMain(m *testing.M) {
@@ -130,7 +134,7 @@ type harborClaims struct {
 func TestMakeToken(t *testing.T) {
 	pk, crt := getKeyAndCertPath()
 	// overwrite the config values for testing.
-	privateKey = pk
+	t.Setenv("TOKEN_PRIVATE_KEY_PATH", pk)
 	ra := []*token.ResourceActions{{
 		Type:    "repository",
 		Name:    "10.117.4.142/notary-test/hello-world-2",
@@ -357,3 +361,32 @@ func TestResourceScopes(t *testing.T) {
 		assert.Equal(t, c.expect, resourceScopes(ctx, c.rc))
 	}
 }
+
+func TestRepositoryFilterNetworkPolicy(t *testing.T) {
+	ctl := &projecttesting.Controller{}
+	ctl.On("GetByName", mock.Anything, "library").Return(&proModels.Project{
+		ProjectID: 1,
+		Name:      "library",
+		Metadata:  map[string]string{proModels.ProMetaIPAllowlist: "10.0.0.0/8"},
+	}, nil)
+	secCtx := &fakeSecurityContext{
+		rcActions: map[rbac.Resource][]rbac.Action{
+			project.NewNamespace(1).Resource(rbac.ResourceRepository): {rbac.ActionPull},
+		},
+	}
+	filter := &repositoryFilter{parser: &basicParser{}}
+
+	// allowed network, the token is bound to the policy of the project
+	ctx, collector := withPolicyCollector(networkpolicy.NewContext(security.NewContext(context.TODO(), secCtx), net.ParseIP("10.0.0.1")))
+	a := &token.ResourceActions{Type: "repository", Name: "library/hello-world", Actions: []string{"pull"}}
+	assert.Nil(t, filter.filter(ctx, ctl, a))
+	assert.Equal(t, []string{"pull"}, a.Actions)
+	assert.Equal(t, []*networkpolicy.Policy{{Allowlist: "10.0.0.0/8"}}, collector.policies)
+
+	// other network
+	ctx, collector = withPolicyCollector(networkpolicy.NewContext(security.NewContext(context.TODO(), secCtx), net.ParseIP("1.2.3.4")))
+	a = &token.ResourceActions{Type: "repository", Name: "library/hello-world", Actions: []string{"pull"}}
+	assert.Nil(t, filter.filter(ctx, ctl, a))
+	assert.Empty(t, a.Actions)
+	assert.Empty(t, collector.policies)
+}
@@ -203,6 +203,17 @@ func (dh *DefaultHandler) HandleJobLogReq(w http.ResponseWriter, req *http.Reque
 		return
 	}
 
+	// Only return the log data after the offset (bytes), it's used to follow the log of the running job
+	var offset uint64
+	if v := req.URL.Query().Get("offset"); !utils.IsEmptyStr(v) {
+		o, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			dh.handleError(w, req, http.StatusBadRequest, errors.Errorf("invalid offset: %s", v))
+			return
+		}
+		offset = o
+	}
+
 	logData, err := dh.controller.GetJobLogData(jobID)
 	if err != nil {
 		code := http.StatusInternalServerError
@@ -217,6 +228,12 @@ func (dh *DefaultHandler) HandleJobLogReq(w http.ResponseWriter, req *http.Reque
 		return
 	}
 
+	if offset >= uint64(len(logData)) {
+		logData = []byte{}
+	} else {
+		logData = logData[offset:]
+	}
+
 	dh.log(req, http.StatusOK, "")
 
 	w.WriteHeader(http.StatusOK)
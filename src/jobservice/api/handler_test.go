@@ -239,6 +239,17 @@ func (suite *APIHandlerTestSuite) TestGetJobLog() {
 	resData, code := suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_job_ID/log"))
 	require.Equal(suite.T(), 200, code, "expected 200 ok but got %d", code)
 	assert.Equal(suite.T(), "hello log", string(resData))
+
+	resData, code = suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_job_ID/log?offset=6"))
+	require.Equal(suite.T(), 200, code, "expected 200 ok but got %d", code)
+	assert.Equal(suite.T(), "log", string(resData))
+
+	resData, code = suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_job_ID/log?offset=100"))
+	require.Equal(suite.T(), 200, code, "expected 200 ok but got %d", code)
+	assert.Empty(suite.T(), resData)
+
+	_, code = suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_job_ID/log?offset=a"))
+	assert.Equal(suite.T(), 400, code, "expected 400 bad request but got %d", code)
 }
 
 // TestGetPeriodicExecutionsWithoutQuery ...
@@ -62,6 +62,11 @@ func KeyPeriodicLock(namespace string) string {
 	return fmt.Sprintf("%s:%s", KeyPeriod(namespace), "lock")
 }
 
+// KeyPeriodicLeader returns the key of the leader lease under period
+func KeyPeriodicLeader(namespace string) string {
+	return fmt.Sprintf("%s:%s", KeyPeriod(namespace), "leader")
+}
+
 // KeyJobStats returns the key of job stats
 func KeyJobStats(namespace string, jobID string) string {
 	return fmt.Sprintf("%s%s:%s", KeyNamespacePrefix(namespace), "job_stats", jobID)
@@ -106,3 +111,13 @@ func KeyInProgressQueue(namespace string, jobType string, workerPoolID string) s
 func KeyWorkerPools(namespace string) string {
 	return KeyNamespacePrefix(namespace) + "worker_pools"
 }
+
+// KeyJobMaxConcurrency returns the key of the max concurrency for the specified job type.
+func KeyJobMaxConcurrency(namespace string, jobType string) string {
+	return fmt.Sprintf("%s:max_concurrency", KeyJobs(namespace, jobType))
+}
+
+// KeyMaxConcurrencyOverrides returns the key of the max concurrency of the job types changed at runtime.
+func KeyMaxConcurrencyOverrides(namespace string) string {
+	return KeyNamespacePrefix(namespace) + "max_concurrency_overrides"
+}
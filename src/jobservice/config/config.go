@@ -22,6 +22,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	yaml "gopkg.in/yaml.v2"
 
@@ -40,6 +41,8 @@ const (
 	jobServiceRedisNamespace             = "JOB_SERVICE_POOL_REDIS_NAMESPACE"
 	jobServiceRedisIdleConnTimeoutSecond = "JOB_SERVICE_POOL_REDIS_CONN_IDLE_TIMEOUT_SECOND"
 	jobServiceAuthSecret                 = "JOBSERVICE_SECRET"
+	jobServiceJobStoreType               = "JOB_SERVICE_JOB_STORE_TYPE"
+	jobServiceLeaderElectionType         = "JOB_SERVICE_LEADER_ELECTION_TYPE"
 	coreURL                              = "CORE_URL"
 
 	// JobServiceProtocolHTTPS points to the 'https' protocol
@@ -50,11 +53,36 @@ const (
 	// JobServicePoolBackendRedis represents redis backend
 	JobServicePoolBackendRedis = "redis"
 
+	// JobStoreTypeRedis keeps the job stats and periodic policies only in the redis
+	JobStoreTypeRedis = "redis"
+	// JobStoreTypePostgreSQL persists the job stats and periodic policies in the PostgreSQL database of Harbor
+	JobStoreTypePostgreSQL = "postgresql"
+
+	// LeaderElectionTypeRedis elects the leader by the lease key in the redis
+	LeaderElectionTypeRedis = "redis"
+	// LeaderElectionTypePostgreSQL elects the leader by the advisory lock of the PostgreSQL database of Harbor
+	LeaderElectionTypePostgreSQL = "postgresql"
+	// defaultLeaderLeaseSeconds is the default lease of the leader
+	defaultLeaderLeaseSeconds uint = 30
+
 	// secret of UI
 	uiAuthSecret = "CORE_SECRET"
 
 	// redis protocol schema
 	redisSchema = "redis://"
+
+	// RetryOnAny retries the failed job on any error
+	RetryOnAny = "any"
+	// ErrorClassNetwork is the class of the network errors, e.g. connection refused or reset
+	ErrorClassNetwork = "network"
+	// ErrorClassTimeout is the class of the timeout errors
+	ErrorClassTimeout = "timeout"
+	// ErrorClassServerError is the class of the 5xx errors returned by the remote servers, e.g. 502 Bad Gateway
+	ErrorClassServerError = "server_error"
+	// ErrorClassOther is the class of the errors not in the other classes
+	ErrorClassOther = "other"
+	// ErrorClassLimitExceeded is the class of the jobs cancelled for exceeding the resource limits, they're never retried
+	ErrorClassLimitExceeded = "limit_exceeded"
 )
 
 // DefaultConfig is the default configuration reference
@@ -82,6 +110,12 @@ type Configuration struct {
 
 	// Metric configurations
 	Metric *MetricConfig `yaml:"metric,omitempty"`
+
+	// Job store configurations
+	JobStoreConfig *JobStoreConfig `yaml:"job_store,omitempty"`
+
+	// Leader election configurations of the periodic scheduler
+	LeaderElectionConfig *LeaderElectionConfig `yaml:"leader_election,omitempty"`
 }
 
 // HTTPSConfig keeps additional configurations when using https protocol
@@ -106,6 +140,97 @@ type PoolConfig struct {
 	WorkerCount  uint             `yaml:"workers"`
 	Backend      string           `yaml:"backend"`
 	RedisPoolCfg *RedisPoolConfig `yaml:"redis_pool,omitempty"`
+	// Dedicated worker pools of the specified jobs, the other jobs are run by the default pool
+	JobPools []*JobPoolConfig `yaml:"job_pools,omitempty"`
+	// Max concurrency of the jobs across all the job service instances, key is the job name
+	MaxConcurrency map[string]uint `yaml:"max_concurrency,omitempty"`
+	// Fair share of the workers among the jobs of the different keys, e.g. the projects
+	FairShare *FairShareConfig `yaml:"fair_share,omitempty"`
+	// Retry policies of the failed jobs
+	Retry *RetryConfig `yaml:"retry,omitempty"`
+	// Resource limits of the running jobs
+	Limits *LimitsConfig `yaml:"limits,omitempty"`
+}
+
+// LimitsConfig keeps the resource limits of the running jobs.
+type LimitsConfig struct {
+	// The limit applied to all the jobs without their own limits
+	Default *JobLimit `yaml:"default,omitempty"`
+	// The limits of the specified jobs, key is the job name
+	Jobs map[string]*JobLimit `yaml:"jobs,omitempty"`
+}
+
+// JobLimit keeps the resource limits of the job, the job exceeding the limits is cancelled.
+type JobLimit struct {
+	// The max seconds the job can run, no timeout if it's 0
+	Timeout uint `yaml:"timeout"`
+	// The max memory in MB used by the job service process while the job is running, no guard if it's 0.
+	// The memory is shared by all the running jobs, so it's measured on the whole process
+	MaxMemoryMB uint `yaml:"max_memory_mb"`
+}
+
+// LimitOf returns the resource limit of the job, nil is returned if no limit is configured
+func (lc *LimitsConfig) LimitOf(jobName string) *JobLimit {
+	if lc == nil {
+		return nil
+	}
+	if l, ok := lc.Jobs[jobName]; ok && l != nil {
+		return l
+	}
+
+	return lc.Default
+}
+
+// RetryConfig keeps the retry policies of the failed jobs.
+type RetryConfig struct {
+	// The policy applied to all the jobs without their own policies
+	Default *RetryPolicy `yaml:"default,omitempty"`
+	// The policies of the specified jobs, key is the job name
+	Jobs map[string]*RetryPolicy `yaml:"jobs,omitempty"`
+}
+
+// RetryPolicy keeps the retry semantics of the failed job.
+type RetryPolicy struct {
+	// The max attempts including the first run, the max fails defined by the job is used if it's 0
+	MaxAttempts uint `yaml:"max_attempts"`
+	// The seconds to wait before the first retry
+	InitialBackoff uint `yaml:"initial_backoff"`
+	// The max seconds to wait before a retry
+	MaxBackoff uint `yaml:"max_backoff"`
+	// The backoff is multiplied by it for each retry
+	Multiplier float64 `yaml:"multiplier"`
+	// The classes of the errors to retry on, the job is retried on any error if it's empty
+	RetryOn []string `yaml:"retry_on,omitempty"`
+}
+
+// PolicyOf returns the retry policy of the job, nil is returned if no policy is configured
+func (rc *RetryConfig) PolicyOf(jobName string) *RetryPolicy {
+	if rc == nil {
+		return nil
+	}
+	if p, ok := rc.Jobs[jobName]; ok && p != nil {
+		return p
+	}
+
+	return rc.Default
+}
+
+// FairShareConfig keeps the configurations of the fair share of the workers.
+type FairShareConfig struct {
+	// The max ratio of the workers of a pool used by the jobs of one key when the jobs of the other keys are running,
+	// it's also the max ratio used by the low priority jobs. The fair share is disabled when it's 0
+	MaxShare float64 `yaml:"max_share"`
+	// The seconds to defer the job exceeding the share
+	DeferSeconds uint `yaml:"defer_seconds"`
+}
+
+// JobPoolConfig keeps the configurations of the dedicated worker pool.
+type JobPoolConfig struct {
+	Name string `yaml:"name"`
+	// Worker concurrency of the pool
+	WorkerCount uint `yaml:"workers"`
+	// Names of the jobs run by the pool
+	Jobs []string `yaml:"jobs"`
 }
 
 // MetricConfig used for configure metrics
@@ -115,6 +240,22 @@ type MetricConfig struct {
 	Port    int    `yaml:"port"`
 }
 
+// JobStoreConfig keeps the settings of the job store which persists the job stats and periodic policies,
+// the redis is always used for queueing no matter which store is used
+type JobStoreConfig struct {
+	// Type of the store: redis or postgresql
+	Type string `yaml:"type"`
+}
+
+// LeaderElectionConfig keeps the settings of the leader election among the job service replicas,
+// only the elected leader runs the periodic scheduler to avoid the duplicated scheduled executions
+type LeaderElectionConfig struct {
+	// Type of the election: redis or postgresql
+	Type string `yaml:"type"`
+	// LeaseSeconds is the lease of the leader, other replicas take over the leadership after the lease expires
+	LeaseSeconds uint `yaml:"lease_seconds,omitempty"`
+}
+
 // CustomizedSettings keeps the customized settings of logger
 type CustomizedSettings map[string]interface{}
 
@@ -173,6 +314,33 @@ func (c *Configuration) Load(yamlFilePath string, detectEnv bool) error {
 	return c.validate()
 }
 
+// JobStoreType returns the type of the job store, the redis store is used if not configured
+func JobStoreType() string {
+	if sc := DefaultConfig.JobStoreConfig; sc != nil && !utils.IsEmptyStr(sc.Type) {
+		return sc.Type
+	}
+
+	return JobStoreTypeRedis
+}
+
+// LeaderElectionType returns the type of the leader election, the redis is used if not configured
+func LeaderElectionType() string {
+	if lc := DefaultConfig.LeaderElectionConfig; lc != nil && !utils.IsEmptyStr(lc.Type) {
+		return lc.Type
+	}
+
+	return LeaderElectionTypeRedis
+}
+
+// LeaderLease returns the lease of the elected leader
+func LeaderLease() time.Duration {
+	if lc := DefaultConfig.LeaderElectionConfig; lc != nil && lc.LeaseSeconds > 0 {
+		return time.Duration(lc.LeaseSeconds) * time.Second
+	}
+
+	return time.Duration(defaultLeaderLeaseSeconds) * time.Second
+}
+
 // GetAuthSecret get the auth secret from the env
 func GetAuthSecret() string {
 	return utils.ReadEnv(jobServiceAuthSecret)
@@ -245,6 +413,20 @@ func (c *Configuration) loadEnvs() {
 		}
 	}
 
+	if storeType := utils.ReadEnv(jobServiceJobStoreType); !utils.IsEmptyStr(storeType) {
+		if c.JobStoreConfig == nil {
+			c.JobStoreConfig = &JobStoreConfig{}
+		}
+		c.JobStoreConfig.Type = storeType
+	}
+
+	if electionType := utils.ReadEnv(jobServiceLeaderElectionType); !utils.IsEmptyStr(electionType) {
+		if c.LeaderElectionConfig == nil {
+			c.LeaderElectionConfig = &LeaderElectionConfig{}
+		}
+		c.LeaderElectionConfig.Type = electionType
+	}
+
 	if c.PoolConfig != nil && c.PoolConfig.Backend == JobServicePoolBackendRedis {
 		redisURL := utils.ReadEnv(jobServiceRedisURL)
 		if !utils.IsEmptyStr(redisURL) {
@@ -333,6 +515,34 @@ func (c *Configuration) validate() error {
 		}
 	}
 
+	if err := c.PoolConfig.validateJobPools(); err != nil {
+		return err
+	}
+
+	if fs := c.PoolConfig.FairShare; fs != nil && (fs.MaxShare < 0 || fs.MaxShare > 1) {
+		return fmt.Errorf("max share of the fair share should be in the range of [0, 1], but current is %v", fs.MaxShare)
+	}
+
+	if err := c.PoolConfig.Retry.validate(); err != nil {
+		return err
+	}
+
+	if sc := c.JobStoreConfig; sc != nil && !utils.IsEmptyStr(sc.Type) &&
+		sc.Type != JobStoreTypeRedis && sc.Type != JobStoreTypePostgreSQL {
+		return fmt.Errorf("job store type should be %s or %s, but current setting is %s",
+			JobStoreTypeRedis,
+			JobStoreTypePostgreSQL,
+			sc.Type)
+	}
+
+	if lc := c.LeaderElectionConfig; lc != nil && !utils.IsEmptyStr(lc.Type) &&
+		lc.Type != LeaderElectionTypeRedis && lc.Type != LeaderElectionTypePostgreSQL {
+		return fmt.Errorf("leader election type should be %s or %s, but current setting is %s",
+			LeaderElectionTypeRedis,
+			LeaderElectionTypePostgreSQL,
+			lc.Type)
+	}
+
 	// Job service loggers
 	if len(c.LoggerConfigs) == 0 {
 		return errors.New("missing logger config of job service")
@@ -345,3 +555,68 @@ func (c *Configuration) validate() error {
 
 	return nil // valid
 }
+
+// Check if the dedicated worker pools are valid settings.
+func (pc *PoolConfig) validateJobPools() error {
+	names := make(map[string]struct{})
+	jobs := make(map[string]string)
+	for _, pool := range pc.JobPools {
+		if pool == nil {
+			continue
+		}
+		if utils.IsEmptyStr(pool.Name) {
+			return errors.New("name of the job pool is required")
+		}
+		if _, ok := names[pool.Name]; ok {
+			return fmt.Errorf("duplicated job pool %s", pool.Name)
+		}
+		names[pool.Name] = struct{}{}
+
+		if pool.WorkerCount == 0 {
+			return fmt.Errorf("worker count of the job pool %s should be a none zero integer", pool.Name)
+		}
+		if len(pool.Jobs) == 0 {
+			return fmt.Errorf("no job is configured for the job pool %s", pool.Name)
+		}
+		for _, j := range pool.Jobs {
+			if p, ok := jobs[j]; ok {
+				return fmt.Errorf("job %s is configured for both the job pools %s and %s", j, p, pool.Name)
+			}
+			jobs[j] = pool.Name
+		}
+	}
+
+	return nil
+}
+
+// Check if the retry policies are valid settings.
+func (rc *RetryConfig) validate() error {
+	if rc == nil {
+		return nil
+	}
+
+	policies := map[string]*RetryPolicy{"default": rc.Default}
+	for name, p := range rc.Jobs {
+		policies[name] = p
+	}
+	for name, p := range policies {
+		if p == nil {
+			continue
+		}
+		if p.Multiplier != 0 && p.Multiplier < 1 {
+			return fmt.Errorf("multiplier of the retry policy %s should not be less than 1, but current is %v", name, p.Multiplier)
+		}
+		if p.MaxBackoff > 0 && p.MaxBackoff < p.InitialBackoff {
+			return fmt.Errorf("max backoff of the retry policy %s should not be less than the initial backoff", name)
+		}
+		for _, class := range p.RetryOn {
+			switch class {
+			case RetryOnAny, ErrorClassNetwork, ErrorClassTimeout, ErrorClassServerError, ErrorClassOther:
+			default:
+				return fmt.Errorf("unknown error class %s in the retry policy %s", class, name)
+			}
+		}
+	}
+
+	return nil
+}
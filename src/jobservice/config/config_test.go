@@ -15,6 +15,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -79,6 +80,10 @@ func (suite *ConfigurationTestSuite) TestConfigLoadingWithEnv() {
 	assert.Equal(suite.T(), "js_secret", GetAuthSecret(), "expect auth secret 'js_secret' but got '%s'", GetAuthSecret())
 	assert.Equal(suite.T(), "core_secret", GetUIAuthSecret(), "expect auth secret 'core_secret' but got '%s'", GetUIAuthSecret())
 	assert.Equal(suite.T(), "core_url", GetCoreURL(), "expect core url 'core_url' but got '%s'", GetCoreURL())
+	require.NotNil(suite.T(), cfg.JobStoreConfig)
+	assert.Equal(suite.T(), JobStoreTypePostgreSQL, cfg.JobStoreConfig.Type)
+	require.NotNil(suite.T(), cfg.LeaderElectionConfig)
+	assert.Equal(suite.T(), LeaderElectionTypePostgreSQL, cfg.LeaderElectionConfig.Type)
 }
 
 // TestDefaultConfig ...
@@ -89,6 +94,24 @@ func (suite *ConfigurationTestSuite) TestDefaultConfig() {
 	redisURL := DefaultConfig.PoolConfig.RedisPoolCfg.RedisURL
 	assert.Equal(suite.T(), "redis://localhost:6379", redisURL, "expect redisURL '%s' but got '%s'", "redis://localhost:6379", redisURL)
 
+	require.Equal(suite.T(), 1, len(DefaultConfig.PoolConfig.JobPools), "expect 1 job pool configured")
+	assert.Equal(suite.T(), &JobPoolConfig{Name: "scan", WorkerCount: 5, Jobs: []string{"IMAGE_SCAN"}}, DefaultConfig.PoolConfig.JobPools[0])
+	assert.Equal(suite.T(), map[string]uint{"REPLICATION": 3}, DefaultConfig.PoolConfig.MaxConcurrency)
+	assert.Equal(suite.T(), &FairShareConfig{MaxShare: 0.5, DeferSeconds: 5}, DefaultConfig.PoolConfig.FairShare)
+	require.NotNil(suite.T(), DefaultConfig.PoolConfig.Retry)
+	assert.Equal(suite.T(), uint(3), DefaultConfig.PoolConfig.Retry.PolicyOf("IMAGE_SCAN").MaxAttempts)
+	replicationRetry := DefaultConfig.PoolConfig.Retry.PolicyOf("REPLICATION")
+	assert.Equal(suite.T(), uint(5), replicationRetry.MaxAttempts)
+	assert.Equal(suite.T(), []string{ErrorClassNetwork, ErrorClassTimeout, ErrorClassServerError}, replicationRetry.RetryOn)
+	require.NotNil(suite.T(), DefaultConfig.PoolConfig.Limits)
+	scanLimit := DefaultConfig.PoolConfig.Limits.LimitOf("IMAGE_SCAN")
+	assert.Equal(suite.T(), uint(3600), scanLimit.Timeout)
+	assert.Equal(suite.T(), uint(1024), scanLimit.MaxMemoryMB)
+	assert.Equal(suite.T(), uint(86400), DefaultConfig.PoolConfig.Limits.LimitOf("REPLICATION").Timeout)
+	assert.Equal(suite.T(), JobStoreTypeRedis, JobStoreType())
+	assert.Equal(suite.T(), LeaderElectionTypeRedis, LeaderElectionType())
+	assert.Equal(suite.T(), 30*time.Second, LeaderLease())
+
 	jLoggerCount := len(DefaultConfig.JobLoggerConfigs)
 	assert.Equal(suite.T(), 2, jLoggerCount, "expect 2 job loggers configured but got %d", jLoggerCount)
 
@@ -118,6 +141,114 @@ func (suite *ConfigurationTestSuite) TestDefaultConfig() {
 	)
 }
 
+// TestValidateJobPools ...
+func (suite *ConfigurationTestSuite) TestValidateJobPools() {
+	cases := []struct {
+		pools []*JobPoolConfig
+		valid bool
+	}{
+		{
+			pools: []*JobPoolConfig{
+				{Name: "scan", WorkerCount: 5, Jobs: []string{"IMAGE_SCAN"}},
+				{Name: "replication", WorkerCount: 5, Jobs: []string{"REPLICATION", "REPLICATION_SCHEDULER"}},
+			},
+			valid: true,
+		},
+		{
+			pools: []*JobPoolConfig{{WorkerCount: 5, Jobs: []string{"IMAGE_SCAN"}}},
+		},
+		{
+			pools: []*JobPoolConfig{{Name: "scan", Jobs: []string{"IMAGE_SCAN"}}},
+		},
+		{
+			pools: []*JobPoolConfig{{Name: "scan", WorkerCount: 5}},
+		},
+		{
+			pools: []*JobPoolConfig{
+				{Name: "scan", WorkerCount: 5, Jobs: []string{"IMAGE_SCAN"}},
+				{Name: "scan", WorkerCount: 5, Jobs: []string{"REPLICATION"}},
+			},
+		},
+		{
+			pools: []*JobPoolConfig{
+				{Name: "scan", WorkerCount: 5, Jobs: []string{"IMAGE_SCAN"}},
+				{Name: "other", WorkerCount: 5, Jobs: []string{"IMAGE_SCAN"}},
+			},
+		},
+	}
+	for _, c := range cases {
+		err := (&PoolConfig{JobPools: c.pools}).validateJobPools()
+		if c.valid {
+			assert.Nil(suite.T(), err)
+		} else {
+			assert.NotNil(suite.T(), err)
+		}
+	}
+}
+
+// TestValidateRetry ...
+func (suite *ConfigurationTestSuite) TestValidateRetry() {
+	cases := []struct {
+		retry *RetryConfig
+		valid bool
+	}{
+		{
+			retry: nil,
+			valid: true,
+		},
+		{
+			retry: &RetryConfig{
+				Default: &RetryPolicy{MaxAttempts: 3, InitialBackoff: 10, MaxBackoff: 60, Multiplier: 2},
+				Jobs:    map[string]*RetryPolicy{"REPLICATION": {RetryOn: []string{RetryOnAny}}},
+			},
+			valid: true,
+		},
+		{
+			retry: &RetryConfig{Default: &RetryPolicy{Multiplier: 0.5}},
+		},
+		{
+			retry: &RetryConfig{Default: &RetryPolicy{InitialBackoff: 10, MaxBackoff: 5}},
+		},
+		{
+			retry: &RetryConfig{Jobs: map[string]*RetryPolicy{"REPLICATION": {RetryOn: []string{"unknown"}}}},
+		},
+	}
+	for _, c := range cases {
+		err := c.retry.validate()
+		if c.valid {
+			assert.Nil(suite.T(), err)
+		} else {
+			assert.NotNil(suite.T(), err)
+		}
+	}
+}
+
+// TestValidateJobStore ...
+func (suite *ConfigurationTestSuite) TestValidateJobStore() {
+	cfg := &Configuration{}
+	err := cfg.Load("../config_test.yml", false)
+	require.Nil(suite.T(), err, "load config from yaml file, expect nil error but got error '%s'", err)
+
+	cfg.JobStoreConfig = &JobStoreConfig{Type: JobStoreTypePostgreSQL}
+	assert.Nil(suite.T(), cfg.validate())
+
+	cfg.JobStoreConfig = &JobStoreConfig{Type: "mongodb"}
+	assert.NotNil(suite.T(), cfg.validate())
+}
+
+// TestValidateLeaderElection ...
+func (suite *ConfigurationTestSuite) TestValidateLeaderElection() {
+	cfg := &Configuration{}
+	err := cfg.Load("../config_test.yml", false)
+	require.Nil(suite.T(), err, "load config from yaml file, expect nil error but got error '%s'", err)
+
+	cfg.LeaderElectionConfig = &LeaderElectionConfig{Type: LeaderElectionTypePostgreSQL, LeaseSeconds: 10}
+	assert.Nil(suite.T(), cfg.validate())
+
+	cfg.LeaderElectionConfig = &LeaderElectionConfig{Type: "etcd"}
+	assert.NotNil(suite.T(), cfg.validate())
+}
+
 func setENV(t *testing.T) {
 	t.Setenv("JOB_SERVICE_PROTOCOL", "https")
 	t.Setenv("JOB_SERVICE_PORT", "8989")
@@ -127,6 +258,8 @@ func setENV(t *testing.T) {
 	t.Setenv("JOB_SERVICE_POOL_WORKERS", "8")
 	t.Setenv("JOB_SERVICE_POOL_REDIS_URL", "redis://:password@8.8.8.8:6379/2")
 	t.Setenv("JOB_SERVICE_POOL_REDIS_NAMESPACE", "ut_namespace")
+	t.Setenv("JOB_SERVICE_JOB_STORE_TYPE", "postgresql")
+	t.Setenv("JOB_SERVICE_LEADER_ELECTION_TYPE", "postgresql")
 	t.Setenv("JOBSERVICE_SECRET", "js_secret")
 	t.Setenv("CORE_SECRET", "core_secret")
 	t.Setenv("CORE_URL", "core_url")
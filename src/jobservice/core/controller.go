@@ -62,6 +62,21 @@ func (bc *basicController) LaunchJob(req *job.Request) (res *job.Stats, err erro
 		return nil, errs.BadRequestError(err)
 	}
 
+	// Carry the trace context and ID of the launching request to the job except the periodic one,
+	// whose executions are not triggered by the request
+	metadata := req.Job.Metadata
+	if metadata.JobKind != job.KindPeriodic && (len(metadata.TraceContext) > 0 || len(metadata.RequestID) > 0) {
+		if req.Job.Parameters == nil {
+			req.Job.Parameters = make(job.Parameters)
+		}
+		if len(metadata.TraceContext) > 0 {
+			req.Job.Parameters[job.TraceContextKey] = metadata.TraceContext
+		}
+		if len(metadata.RequestID) > 0 {
+			req.Job.Parameters[job.RequestIDKey] = metadata.RequestID
+		}
+	}
+
 	// Enqueue job regarding of the kind
 	switch req.Job.Metadata.JobKind {
 	case job.KindScheduled:
@@ -91,9 +106,19 @@ func (bc *basicController) LaunchJob(req *job.Request) (res *job.Stats, err erro
 
 	// Save job stats
 	if err == nil {
+		res.Info.PriorityClass = req.Job.Metadata.PriorityClass
+		res.Info.FairShareKey = req.Job.Metadata.FairShareKey
 		if err := bc.manager.SaveJob(res); err != nil {
 			return nil, err
 		}
+
+		// Run the high priority job before the other enqueued jobs of the same type
+		if res.Info.JobKind == job.KindGeneric && res.Info.PriorityClass == job.PriorityClassHigh {
+			if er := bc.backendWorker.PrioritizeJob(res); er != nil {
+				// Just log it as the job has been enqueued
+				logger.Errorf("Failed to prioritize job %s:%s: %s", res.Info.JobName, res.Info.JobID, er)
+			}
+		}
 	}
 
 	return
@@ -191,6 +216,15 @@ func validJobReq(req *job.Request) error {
 			job.KindPeriodic)
 	}
 
+	if !job.IsValidPriorityClass(req.Job.Metadata.PriorityClass) {
+		return errors.Errorf(
+			"priority class '%s' is not supported, only support '%s','%s','%s'",
+			req.Job.Metadata.PriorityClass,
+			job.PriorityClassHigh,
+			job.PriorityClassNormal,
+			job.PriorityClassLow)
+	}
+
 	if req.Job.Metadata.JobKind == job.KindScheduled &&
 		req.Job.Metadata.ScheduleDelay == 0 {
 		return errors.Errorf("'schedule_delay' must be specified for %s job", job.KindScheduled)
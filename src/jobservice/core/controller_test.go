@@ -88,6 +88,57 @@ func (suite *ControllerTestSuite) TestLaunchGenericJob() {
 	assert.Equal(suite.T(), suite.jobID, res.Info.JobID, "mismatch job ID")
 }
 
+// TestLaunchHighPriorityJob ...
+func (suite *ControllerTestSuite) TestLaunchHighPriorityJob() {
+	req := createJobReq("Generic")
+	req.Job.Metadata.PriorityClass = job.PriorityClassHigh
+	req.Job.Metadata.FairShareKey = "project:1"
+
+	res := &job.Stats{
+		Info: &job.StatsInfo{
+			JobID:   utils.MakeIdentifier(),
+			JobKind: job.KindGeneric,
+		},
+	}
+	suite.worker.On("Enqueue", job.SampleJob, suite.params, true, req.Job.StatusHook).Return(res, nil)
+	suite.manager.On("SaveJob", res).Return(nil)
+	suite.worker.On("PrioritizeJob", res).Return(nil)
+
+	launched, err := suite.ctl.LaunchJob(req)
+	require.Nil(suite.T(), err, "launch high priority job: nil error expected but got %s", err)
+	assert.Equal(suite.T(), job.PriorityClassHigh, launched.Info.PriorityClass)
+	assert.Equal(suite.T(), "project:1", launched.Info.FairShareKey)
+	suite.worker.AssertCalled(suite.T(), "PrioritizeJob", res)
+
+	req.Job.Metadata.PriorityClass = "urgent"
+	_, err = suite.ctl.LaunchJob(req)
+	assert.NotNil(suite.T(), err, "launch job with invalid priority class: non nil error expected but got nil")
+}
+
+// TestLaunchTracedJob ...
+func (suite *ControllerTestSuite) TestLaunchTracedJob() {
+	carrier := map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+	req := createJobReq("Generic")
+	req.Job.Metadata.TraceContext = carrier
+	req.Job.Metadata.RequestID = "852803be-e5fe-499b-bbea-c9e5b5f43916"
+
+	params := job.Parameters{"name": "testing:v1", job.TraceContextKey: carrier, job.RequestIDKey: "852803be-e5fe-499b-bbea-c9e5b5f43916"}
+	suite.worker.On("Enqueue", job.SampleJob, params, true, req.Job.StatusHook).Return(suite.res, nil)
+
+	_, err := suite.ctl.LaunchJob(req)
+	require.Nil(suite.T(), err, "launch traced job: nil error expected but got %s", err)
+	suite.worker.AssertCalled(suite.T(), "Enqueue", job.SampleJob, params, true, req.Job.StatusHook)
+
+	// the trace context and request ID aren't carried by the periodic job
+	req = createJobReq("Periodic")
+	req.Job.Metadata.TraceContext = carrier
+	req.Job.Metadata.RequestID = "852803be-e5fe-499b-bbea-c9e5b5f43916"
+	suite.worker.On("PeriodicallyEnqueue", job.SampleJob, suite.params, "5 * * * * *", true, req.Job.StatusHook).Return(suite.res, nil)
+
+	_, err = suite.ctl.LaunchJob(req)
+	require.Nil(suite.T(), err, "launch traced periodic job: nil error expected but got %s", err)
+}
+
 // TestLaunchScheduledJob ...
 func (suite *ControllerTestSuite) TestLaunchScheduledJob() {
 	req := createJobReq("Scheduled")
@@ -243,6 +294,10 @@ func (suite *ControllerTestSuite) Enqueue(jobName string, params job.Parameters,
 	return suite.worker.Enqueue(jobName, params, isUnique, webHook)
 }
 
+func (suite *ControllerTestSuite) PrioritizeJob(stats *job.Stats) error {
+	return suite.worker.PrioritizeJob(stats)
+}
+
 func (suite *ControllerTestSuite) Schedule(jobName string, params job.Parameters, runAfterSeconds uint64, isUnique bool, webHook string) (*job.Stats, error) {
 	return suite.worker.Schedule(jobName, params, runAfterSeconds, isUnique, webHook)
 }
@@ -318,6 +373,10 @@ func (f *fakeWorker) Enqueue(jobName string, params job.Parameters, isUnique boo
 	return args.Get(0).(*job.Stats), nil
 }
 
+func (f *fakeWorker) PrioritizeJob(stats *job.Stats) error {
+	return f.Called(stats).Error(0)
+}
+
 func (f *fakeWorker) Schedule(jobName string, params job.Parameters, runAfterSeconds uint64, isUnique bool, webHook string) (*job.Stats, error) {
 	args := f.Called(jobName, params, runAfterSeconds, isUnique, webHook)
 	if args.Error(1) != nil {
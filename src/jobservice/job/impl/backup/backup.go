@@ -0,0 +1,149 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+
+	ctlbackup "github.com/goharbor/harbor/src/controller/backup"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/backup"
+	"github.com/goharbor/harbor/src/pkg/systemartifact"
+	"github.com/goharbor/harbor/src/pkg/systemartifact/model"
+)
+
+// the interval of checking the stop signal while holding the read-only mode
+var pollInterval = 3 * time.Second
+
+// Job creates the manifest of the metadata and the storage for the backup, then keeps running until
+// the backup is completed or the hold expires, the read-only mode is held as long as the job runs
+type Job struct {
+	backupMgr      backup.Manager
+	sysArtifactMgr systemartifact.Manager
+}
+
+// MaxFails is implementation of same method in Interface.
+func (j *Job) MaxFails() uint {
+	return 1
+}
+
+// MaxCurrency is implementation of same method in Interface.
+func (j *Job) MaxCurrency() uint {
+	return 1
+}
+
+// ShouldRetry ...
+func (j *Job) ShouldRetry() bool {
+	return false
+}
+
+// Validate is implementation of same method in Interface.
+func (j *Job) Validate(params job.Parameters) error {
+	if _, err := parseInt(params, ctlbackup.ParamBackupID); err != nil {
+		return err
+	}
+	_, err := parseInt(params, ctlbackup.ParamHoldUntil)
+	return err
+}
+
+// Run the backup logic here.
+func (j *Job) Run(ctx job.Context, params job.Parameters) error {
+	logger := ctx.GetLogger()
+	if j.backupMgr == nil {
+		j.backupMgr = backup.Mgr
+	}
+	if j.sysArtifactMgr == nil {
+		j.sysArtifactMgr = systemartifact.Mgr
+	}
+
+	id, err := parseInt(params, ctlbackup.ParamBackupID)
+	if err != nil {
+		return err
+	}
+	holdUntil, err := parseInt(params, ctlbackup.ParamHoldUntil)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := j.backupMgr.Snapshot(ctx.SystemContext(), true)
+	if err != nil {
+		logger.Errorf("failed to create the manifest of the backup %d: %v", id, err)
+		return err
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	dgt := digest.FromBytes(data)
+	_, err = j.sysArtifactMgr.Create(ctx.SystemContext(), &model.SystemArtifact{
+		Repository: strconv.FormatInt(id, 10),
+		Digest:     dgt.String(),
+		Size:       int64(len(data)),
+		Vendor:     ctlbackup.Vendor,
+		Type:       ctlbackup.ArtifactType,
+	}, bytes.NewReader(data))
+	if err != nil {
+		logger.Errorf("failed to store the manifest of the backup %d: %v", id, err)
+		return err
+	}
+	progress, err := json.Marshal(&ctlbackup.Progress{
+		Stage:  ctlbackup.StageReady,
+		Digest: dgt.String(),
+		Size:   int64(len(data)),
+	})
+	if err != nil {
+		return err
+	}
+	if err = ctx.Checkin(string(progress)); err != nil {
+		logger.Errorf("failed to check in the manifest of the backup %d: %v", id, err)
+		return err
+	}
+	logger.Infof("the manifest of the backup %d is created with %d blobs of %d bytes, the read-only mode is held until %s",
+		id, manifest.Storage.BlobCount, manifest.Storage.TotalSize, time.Unix(holdUntil, 0).Format(time.RFC3339))
+
+	// the backup is completed by stopping the job, otherwise the job exits once the hold expires
+	for time.Now().Unix() < holdUntil {
+		if opCmd, exit := ctx.OPCommand(); exit && opCmd.IsStop() {
+			logger.Infof("the backup %d is completed", id)
+			return nil
+		}
+		time.Sleep(pollInterval)
+	}
+	logger.Warningf("the hold of the backup %d expired before it was completed", id)
+	return nil
+}
+
+func parseInt(params job.Parameters, key string) (int64, error) {
+	value, exist := params[key]
+	if !exist {
+		return 0, errors.Errorf("missing the parameter %s", key)
+	}
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		return int64(v), nil
+	default:
+		return 0, errors.Errorf("invalid type of the parameter %s: %T", key, value)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/suite"
+
+	ctlbackup "github.com/goharbor/harbor/src/controller/backup"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	backupmodel "github.com/goharbor/harbor/src/pkg/backup/model"
+	"github.com/goharbor/harbor/src/pkg/systemartifact/model"
+	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
+	backuptesting "github.com/goharbor/harbor/src/testing/pkg/backup"
+	systemartifacttesting "github.com/goharbor/harbor/src/testing/pkg/systemartifact"
+)
+
+type backupTestSuite struct {
+	suite.Suite
+	backupMgr      *backuptesting.Manager
+	sysArtifactMgr *systemartifacttesting.Manager
+	job            *Job
+	record         *model.SystemArtifact
+	data           []byte
+}
+
+func (b *backupTestSuite) SetupTest() {
+	pollInterval = time.Millisecond
+	b.backupMgr = &backuptesting.Manager{}
+	b.sysArtifactMgr = &systemartifacttesting.Manager{}
+	b.job = &Job{backupMgr: b.backupMgr, sysArtifactMgr: b.sysArtifactMgr}
+
+	b.backupMgr.On("Snapshot", mock.Anything, true).Return(&backupmodel.Manifest{
+		SchemaVersion: 100,
+		Tables:        map[string]int64{"blob": 1},
+		Storage: &backupmodel.Storage{
+			BlobCount: 1,
+			TotalSize: 10,
+			Checksum:  "sha256:abc",
+			Blobs:     []*backupmodel.Blob{{Digest: "sha256:def", Size: 10}},
+		},
+	}, nil)
+	b.sysArtifactMgr.On("Create", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		b.record = args.Get(1).(*model.SystemArtifact)
+		b.data, _ = io.ReadAll(args.Get(2).(io.Reader))
+	}).Return(int64(1), nil)
+}
+
+func (b *backupTestSuite) TestValidate() {
+	b.NoError(b.job.Validate(job.Parameters{ctlbackup.ParamBackupID: float64(1), ctlbackup.ParamHoldUntil: float64(time.Now().Unix())}))
+	b.Error(b.job.Validate(job.Parameters{ctlbackup.ParamBackupID: float64(1)}))
+	b.Error(b.job.Validate(job.Parameters{ctlbackup.ParamBackupID: "1", ctlbackup.ParamHoldUntil: float64(0)}))
+}
+
+func (b *backupTestSuite) TestRunExpired() {
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.OPCommand(""), false)
+	ctx.On("Checkin", mock.Anything).Return(nil)
+
+	params := job.Parameters{
+		ctlbackup.ParamBackupID:  float64(1),
+		ctlbackup.ParamHoldUntil: float64(time.Now().Add(10 * time.Millisecond).Unix()),
+	}
+	b.Require().NoError(b.job.Run(ctx, params))
+
+	b.Require().NotNil(b.record)
+	b.Equal(ctlbackup.Vendor, b.record.Vendor)
+	b.Equal(ctlbackup.ArtifactType, b.record.Type)
+	b.Equal("1", b.record.Repository)
+	b.Equal(digest.FromBytes(b.data).String(), b.record.Digest)
+	manifest := &backupmodel.Manifest{}
+	b.Require().NoError(json.Unmarshal(b.data, manifest))
+	b.Equal(uint(100), manifest.SchemaVersion)
+	b.Len(manifest.Storage.Blobs, 1)
+
+	progress := &ctlbackup.Progress{}
+	ctx.AssertCalled(b.T(), "Checkin", mock.Anything)
+	for _, call := range ctx.Calls {
+		if call.Method == "Checkin" {
+			b.Require().NoError(json.Unmarshal([]byte(call.Arguments.String(0)), progress))
+		}
+	}
+	b.Equal(ctlbackup.StageReady, progress.Stage)
+	b.Equal(b.record.Digest, progress.Digest)
+	b.Equal(b.record.Size, progress.Size)
+}
+
+func (b *backupTestSuite) TestRunCompleted() {
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.StopCommand, true)
+	ctx.On("Checkin", mock.Anything).Return(nil)
+
+	params := job.Parameters{
+		ctlbackup.ParamBackupID:  float64(1),
+		ctlbackup.ParamHoldUntil: float64(time.Now().Add(time.Hour).Unix()),
+	}
+	done := make(chan error)
+	go func() {
+		done <- b.job.Run(ctx, params)
+	}()
+	select {
+	case err := <-done:
+		b.NoError(err)
+	case <-time.After(5 * time.Second):
+		b.Fail("the job doesn't exit after receiving the stop signal")
+	}
+}
+
+func TestBackupTestSuite(t *testing.T) {
+	suite.Run(t, &backupTestSuite{})
+}
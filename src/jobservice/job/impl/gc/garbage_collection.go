@@ -15,11 +15,13 @@
 package gc
 
 import (
+	"encoding/json"
 	"os"
 	"time"
 
 	"github.com/goharbor/harbor/src/common/registryctl"
 	"github.com/goharbor/harbor/src/controller/artifact"
+	gcCtl "github.com/goharbor/harbor/src/controller/gc"
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/logger"
@@ -65,6 +67,8 @@ type GarbageCollector struct {
 	// hold all of GC candidates(non-referenced blobs), it's captured by mark and consumed by sweep.
 	deleteSet       []*blobModels.Blob
 	timeWindowHours int64
+	// the statistics of the run, it's checked in when the job finishes
+	statistics gcCtl.Statistics
 }
 
 // MaxFails implements the interface in job/Interface
@@ -140,6 +144,8 @@ func (gc *GarbageCollector) parseParams(params job.Parameters) {
 		}
 	}
 
+	gc.statistics.DryRun = gc.dryRun
+
 	gc.logger.Infof("Garbage Collection parameters: [delete_untagged: %t, dry_run: %t, time_window: %d]",
 		gc.deleteUntagged, gc.dryRun, gc.timeWindowHours)
 }
@@ -149,6 +155,8 @@ func (gc *GarbageCollector) Run(ctx job.Context, params job.Parameters) error {
 	if err := gc.init(ctx, params); err != nil {
 		return err
 	}
+	// check in the statistics however the job finishes
+	defer gc.checkIn(ctx, time.Now())
 
 	gc.logger.Infof("start to run gc in job.")
 
@@ -228,6 +236,7 @@ func (gc *GarbageCollector) mark(ctx job.Context) error {
 			count, err := gc.blobMgr.UpdateBlobStatus(ctx.SystemContext(), blob)
 			if err != nil {
 				gc.logger.Warningf("failed to mark gc candidate, skip it.: %s, error: %v", blob.Digest, err)
+				gc.statistics.Errors++
 				continue
 			}
 			if count == 0 {
@@ -249,14 +258,15 @@ func (gc *GarbageCollector) mark(ctx job.Context) error {
 	}
 	gc.logger.Infof("%d blobs and %d manifests eligible for deletion", blobCt, mfCt)
 	gc.logger.Infof("The GC could free up %d MB space, the size is a rough estimation.", makeSize/1024/1024)
+	// nothing is deleted in the dry run, report the candidates instead
+	if gc.dryRun {
+		gc.statistics.DeletedBlobs, gc.statistics.DeletedManifests, gc.statistics.ReclaimedBytes = blobCt, mfCt, makeSize
+	}
 	return nil
 }
 
 func (gc *GarbageCollector) sweep(ctx job.Context) error {
 	gc.logger = ctx.GetLogger()
-	sweepSize := int64(0)
-	blobCnt := 0
-	mfCnt := 0
 	total := len(gc.deleteSet)
 	for i, blob := range gc.deleteSet {
 		if gc.shouldStop(ctx) {
@@ -268,6 +278,7 @@ func (gc *GarbageCollector) sweep(ctx job.Context) error {
 		count, err := gc.blobMgr.UpdateBlobStatus(ctx.SystemContext(), blob)
 		if err != nil {
 			gc.logger.Errorf("[%d/%d] failed to mark gc candidate deleting, skip: %s, %s", idx, total, blob.Digest, blob.Status)
+			gc.statistics.Errors++
 			continue
 		}
 		if count == 0 {
@@ -346,6 +357,7 @@ func (gc *GarbageCollector) sweep(ctx job.Context) error {
 
 		// skip deleting the blob if the manifest's tag/revision is not deleted
 		if skippedBlob {
+			gc.statistics.Errors++
 			continue
 		}
 
@@ -372,13 +384,14 @@ func (gc *GarbageCollector) sweep(ctx job.Context) error {
 					gc.logger.Errorf("[%d/%d] failed to call gc.markDeleteFailed() after gc.registryCtlClient.DeleteBlob() error out: %s, %v", idx, total, blob.Digest, err)
 					return err
 				}
+				gc.statistics.Errors++
 				// if the system is set to read-only mode, return directly
 				if err == readonly.Err {
 					return err
 				}
 				continue
 			}
-			sweepSize = sweepSize + blob.Size
+			gc.statistics.ReclaimedBytes += blob.Size
 		}
 
 		gc.logger.Infof("[%d/%d] delete blob record from database: %d, %s", idx, total, blob.ID, blob.Digest)
@@ -386,6 +399,7 @@ func (gc *GarbageCollector) sweep(ctx job.Context) error {
 			return gc.blobMgr.Delete(ctx.SystemContext(), blob.ID)
 		}); err != nil {
 			gc.logger.Errorf("[%d/%d] failed to delete blob from database: %s, %s, errMsg=%v", idx, total, blob.Digest, blob.Status, err)
+			gc.statistics.Errors++
 			if err := ignoreNotFound(func() error {
 				return gc.markDeleteFailed(ctx, blob)
 			}); err != nil {
@@ -395,16 +409,29 @@ func (gc *GarbageCollector) sweep(ctx job.Context) error {
 			return err
 		}
 		if blob.IsManifest() {
-			mfCnt++
+			gc.statistics.DeletedManifests++
 		} else {
-			blobCnt++
+			gc.statistics.DeletedBlobs++
 		}
 	}
-	gc.logger.Infof("%d blobs and %d manifests are actually deleted", blobCnt, mfCnt)
-	gc.logger.Infof("The GC job actual frees up %d MB space.", sweepSize/1024/1024)
+	gc.logger.Infof("%d blobs and %d manifests are actually deleted", gc.statistics.DeletedBlobs, gc.statistics.DeletedManifests)
+	gc.logger.Infof("The GC job actual frees up %d MB space.", gc.statistics.ReclaimedBytes/1024/1024)
 	return nil
 }
 
+// checkIn reports the statistics of the run, which are persisted in the gc execution by the core
+func (gc *GarbageCollector) checkIn(ctx job.Context, start time.Time) {
+	gc.statistics.Duration = int64(time.Since(start).Seconds())
+	data, err := json.Marshal(&gc.statistics)
+	if err != nil {
+		gc.logger.Warningf("failed to marshal the statistics of gc: %v", err)
+		return
+	}
+	if err = ctx.Checkin(string(data)); err != nil {
+		gc.logger.Warningf("failed to check in the statistics of gc: %v", err)
+	}
+}
+
 // cleanCache is to clean the registry cache for GC.
 // To do this is because the issue https://github.com/docker/distribution/issues/2094
 func (gc *GarbageCollector) cleanCache() error {
@@ -217,6 +217,7 @@ func (suite *gcTestSuite) TestRun() {
 	ctx.On("GetLogger").Return(logger)
 	ctx.On("OPCommand").Return(job.NilCommand, true)
 	mock.OnAnything(ctx, "Get").Return("core url", true)
+	ctx.On("Checkin", mock.Anything).Return(nil)
 
 	suite.artifactCtl.On("List").Return([]*artifact.Artifact{
 		{
@@ -281,6 +282,7 @@ func (suite *gcTestSuite) TestRun() {
 	}
 
 	suite.Nil(gc.Run(ctx, params))
+	ctx.AssertCalled(suite.T(), "Checkin", mock.Anything)
 }
 
 func (suite *gcTestSuite) TestMark() {
@@ -376,6 +378,8 @@ func (suite *gcTestSuite) TestSweep() {
 	}
 
 	suite.Nil(gc.sweep(ctx))
+	suite.Equal(1, gc.statistics.DeletedBlobs)
+	suite.Equal(0, gc.statistics.Errors)
 }
 
 func TestGCTestSuite(t *testing.T) {
@@ -0,0 +1,211 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageexport
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/goharbor/harbor/src/controller/imageexport"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/registry"
+	"github.com/goharbor/harbor/src/pkg/systemartifact"
+	"github.com/goharbor/harbor/src/pkg/systemartifact/model"
+)
+
+// Export is the job exporting the artifacts into the OCI image layout archive, the archive is kept as
+// the system artifact until the download URL expires
+type Export struct {
+	sysArtifactMgr systemartifact.Manager
+	regCli         registry.Client
+}
+
+// MaxFails is implementation of same method in Interface.
+func (e *Export) MaxFails() uint {
+	return 1
+}
+
+// MaxCurrency is implementation of same method in Interface.
+func (e *Export) MaxCurrency() uint {
+	return 0
+}
+
+// ShouldRetry ...
+func (e *Export) ShouldRetry() bool {
+	return false
+}
+
+// Validate is implementation of same method in Interface.
+func (e *Export) Validate(params job.Parameters) error {
+	if _, err := parseInt(params, imageexport.ParamExportID); err != nil {
+		return err
+	}
+	if _, err := parseInt(params, imageexport.ParamTTL); err != nil {
+		return err
+	}
+	artifacts, err := parseArtifacts(params)
+	if err != nil {
+		return err
+	}
+	if len(artifacts) == 0 {
+		return errors.Errorf("missing the parameter %s", imageexport.ParamArtifacts)
+	}
+	return nil
+}
+
+// Run the export logic here.
+func (e *Export) Run(ctx job.Context, params job.Parameters) error {
+	logger := ctx.GetLogger()
+	if e.sysArtifactMgr == nil {
+		e.sysArtifactMgr = systemartifact.Mgr
+	}
+	if e.regCli == nil {
+		e.regCli = registry.Cli
+	}
+
+	id, err := parseInt(params, imageexport.ParamExportID)
+	if err != nil {
+		return err
+	}
+	ttl, err := parseInt(params, imageexport.ParamTTL)
+	if err != nil {
+		return err
+	}
+	artifacts, err := parseArtifacts(params)
+	if err != nil {
+		return err
+	}
+	reg, _ := params[imageexport.ParamRegistry].(string)
+
+	dir, err := os.MkdirTemp("", "image-export-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	l := newLayout(filepath.Join(dir, "layout"), e.regCli)
+
+	progress := &imageexport.Progress{Total: len(artifacts)}
+	checkin(ctx, progress)
+	for _, art := range artifacts {
+		if opCmd, exit := ctx.OPCommand(); exit && opCmd.IsStop() {
+			logger.Info("received the stop signal, stop the image export job")
+			return nil
+		}
+		name := art.Repository + ":" + art.Tag
+		if len(reg) > 0 {
+			name = reg + "/" + name
+		}
+		if err = l.add(art.Repository, art.Tag, name); err != nil {
+			logger.Errorf("failed to export the artifact %s:%s: %v", art.Repository, art.Tag, err)
+			return err
+		}
+		progress.Exported++
+		checkin(ctx, progress)
+		logger.Infof("the artifact %s:%s is exported", art.Repository, art.Tag)
+	}
+	if err = l.close(); err != nil {
+		return err
+	}
+
+	file := filepath.Join(dir, "archive.tar")
+	dgt, size, err := l.archive(file)
+	if err != nil {
+		logger.Errorf("failed to archive the layout: %v", err)
+		return err
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	expiresAt := time.Now().Add(time.Duration(ttl) * time.Second).Unix()
+	attrs, err := json.Marshal(&imageexport.ExtraAttrs{ExpiresAt: expiresAt})
+	if err != nil {
+		return err
+	}
+	_, err = e.sysArtifactMgr.Create(ctx.SystemContext(), &model.SystemArtifact{
+		Repository: strconv.FormatInt(id, 10),
+		Digest:     dgt.String(),
+		Size:       size,
+		Vendor:     imageexport.Vendor,
+		Type:       imageexport.ArtifactType,
+		ExtraAttrs: string(attrs),
+	}, f)
+	if err != nil {
+		logger.Errorf("failed to store the archive of the image export %d: %v", id, err)
+		return err
+	}
+
+	// the digest, size and expiration of the archive must be checked in for the download
+	progress.Size, progress.Digest, progress.ExpiresAt = size, dgt.String(), expiresAt
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	if err = ctx.Checkin(string(data)); err != nil {
+		logger.Errorf("failed to check in the archive of the image export %d: %v", id, err)
+		return err
+	}
+	logger.Infof("%d artifacts are exported into the archive %s of %d bytes", progress.Exported, dgt, size)
+	return nil
+}
+
+func checkin(ctx job.Context, progress *imageexport.Progress) {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return
+	}
+	if err = ctx.Checkin(string(data)); err != nil {
+		ctx.GetLogger().Warningf("failed to check in the progress of the image export: %v", err)
+	}
+}
+
+func parseArtifacts(params job.Parameters) ([]*imageexport.Artifact, error) {
+	value, exist := params[imageexport.ParamArtifacts]
+	if !exist {
+		return nil, errors.Errorf("missing the parameter %s", imageexport.ParamArtifacts)
+	}
+	// the parameters are transferred as JSON
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var artifacts []*imageexport.Artifact
+	if err = json.Unmarshal(data, &artifacts); err != nil {
+		return nil, errors.Wrapf(err, "invalid parameter %s", imageexport.ParamArtifacts)
+	}
+	return artifacts, nil
+}
+
+func parseInt(params job.Parameters, key string) (int64, error) {
+	value, exist := params[key]
+	if !exist {
+		return 0, errors.Errorf("missing the parameter %s", key)
+	}
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		return int64(v), nil
+	default:
+		return 0, errors.Errorf("invalid type of the parameter %s: %T", key, value)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageexport
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/imageexport"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/systemartifact/model"
+	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
+	registrytesting "github.com/goharbor/harbor/src/testing/pkg/registry"
+	systemartifacttesting "github.com/goharbor/harbor/src/testing/pkg/systemartifact"
+)
+
+type exportTestSuite struct {
+	suite.Suite
+	sysArtifactMgr *systemartifacttesting.Manager
+	regCli         *registrytesting.Client
+	job            *Export
+	config         []byte
+	layer          []byte
+	manifest       distribution.Manifest
+}
+
+func (e *exportTestSuite) SetupTest() {
+	e.sysArtifactMgr = &systemartifacttesting.Manager{}
+	e.regCli = &registrytesting.Client{}
+	e.job = &Export{sysArtifactMgr: e.sysArtifactMgr, regCli: e.regCli}
+
+	e.config = []byte(`{"architecture":"amd64","os":"linux"}`)
+	e.layer = []byte("layer")
+	m, err := schema2.FromStruct(schema2.Manifest{
+		Versioned: manifest.Versioned{SchemaVersion: 2, MediaType: schema2.MediaTypeManifest},
+		Config: distribution.Descriptor{
+			MediaType: schema2.MediaTypeImageConfig,
+			Digest:    digest.FromBytes(e.config),
+			Size:      int64(len(e.config)),
+		},
+		Layers: []distribution.Descriptor{{
+			MediaType: schema2.MediaTypeLayer,
+			Digest:    digest.FromBytes(e.layer),
+			Size:      int64(len(e.layer)),
+		}},
+	})
+	e.Require().NoError(err)
+	e.manifest = m
+}
+
+func (e *exportTestSuite) params() job.Parameters {
+	return job.Parameters{
+		imageexport.ParamExportID: float64(1),
+		imageexport.ParamTTL:      float64(3600),
+		imageexport.ParamRegistry: "harbor.example.com",
+		imageexport.ParamArtifacts: []interface{}{
+			map[string]interface{}{"repository": "library/app", "tag": "1.0"},
+			map[string]interface{}{"repository": "library/app", "tag": "latest"},
+		},
+	}
+}
+
+func (e *exportTestSuite) TestValidate() {
+	e.NoError(e.job.Validate(e.params()))
+	e.Error(e.job.Validate(job.Parameters{imageexport.ParamExportID: 1, imageexport.ParamTTL: 3600}))
+	e.Error(e.job.Validate(job.Parameters{imageexport.ParamExportID: "1"}))
+}
+
+func (e *exportTestSuite) TestRun() {
+	e.regCli.On("PullManifest", "library/app", "1.0").Return(e.manifest, "", nil)
+	e.regCli.On("PullManifest", "library/app", "latest").Return(e.manifest, "", nil)
+	e.regCli.On("PullBlob", "library/app", digest.FromBytes(e.config).String()).Return(int64(len(e.config)), io.NopCloser(bytes.NewReader(e.config)), nil).Once()
+	e.regCli.On("PullBlob", "library/app", digest.FromBytes(e.layer).String()).Return(int64(len(e.layer)), io.NopCloser(bytes.NewReader(e.layer)), nil).Once()
+	var archive []byte
+	var record *model.SystemArtifact
+	e.sysArtifactMgr.On("Create", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		record = args.Get(1).(*model.SystemArtifact)
+		archive, _ = io.ReadAll(args.Get(2).(io.Reader))
+	}).Return(int64(1), nil)
+
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.OPCommand(""), false)
+	ctx.On("Checkin", mock.Anything).Return(nil)
+
+	e.Require().NoError(e.job.Run(ctx, e.params()))
+	e.regCli.AssertExpectations(e.T())
+
+	e.Require().NotNil(record)
+	e.Equal(imageexport.Vendor, record.Vendor)
+	e.Equal("1", record.Repository)
+	e.Equal(digest.FromBytes(archive).String(), record.Digest)
+	e.Equal(int64(len(archive)), record.Size)
+
+	progress := &imageexport.Progress{}
+	e.Require().NoError(json.Unmarshal([]byte(ctx.Calls[len(ctx.Calls)-1].Arguments.String(0)), progress))
+	e.Equal(2, progress.Exported)
+	e.Equal(record.Digest, progress.Digest)
+	e.NotZero(progress.ExpiresAt)
+
+	files := map[string][]byte{}
+	tr := tar.NewReader(bytes.NewReader(archive))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		e.Require().NoError(err)
+		files[hdr.Name], _ = io.ReadAll(tr)
+	}
+	e.Contains(files, v1.ImageLayoutFile)
+	e.Equal(e.layer, files["blobs/sha256/"+digest.FromBytes(e.layer).Encoded()])
+
+	index := &v1.Index{}
+	e.Require().NoError(json.Unmarshal(files["index.json"], index))
+	e.Require().Len(index.Manifests, 2)
+	e.Equal("harbor.example.com/library/app:1.0", index.Manifests[0].Annotations[annotationImageName])
+	e.Equal("latest", index.Manifests[1].Annotations[v1.AnnotationRefName])
+
+	var manifests []*dockerManifest
+	e.Require().NoError(json.Unmarshal(files["manifest.json"], &manifests))
+	e.Require().Len(manifests, 1)
+	e.Equal([]string{"harbor.example.com/library/app:1.0", "harbor.example.com/library/app:latest"}, manifests[0].RepoTags)
+}
+
+func (e *exportTestSuite) TestRunDigestMismatch() {
+	e.regCli.On("PullManifest", "library/app", "1.0").Return(e.manifest, "", nil)
+	e.regCli.On("PullBlob", "library/app", mock.Anything).Return(int64(7), io.NopCloser(bytes.NewReader([]byte("invalid"))), nil)
+
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.OPCommand(""), false)
+	ctx.On("Checkin", mock.Anything).Return(nil)
+
+	e.Error(e.job.Run(ctx, e.params()))
+	e.sysArtifactMgr.AssertNotCalled(e.T(), "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExportTestSuite(t *testing.T) {
+	suite.Run(t, &exportTestSuite{})
+}
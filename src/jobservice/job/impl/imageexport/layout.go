@@ -0,0 +1,279 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageexport
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/ocischema"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/registry"
+)
+
+// the annotation recognized by containerd and docker in the index.json of the OCI layout for the full image name
+const annotationImageName = "io.containerd.image.name"
+
+// dockerManifest is the entry of the "manifest.json" read by "docker load"
+type dockerManifest struct {
+	Config   string
+	RepoTags []string
+	Layers   []string
+}
+
+// layout writes the artifacts pulled from the registry into the OCI image layout in the local directory,
+// the "manifest.json" is written as well for the images to be loaded by the older docker versions
+type layout struct {
+	dir       string
+	regCli    registry.Client
+	index     *v1.Index
+	manifests []*dockerManifest
+	// the index of the entries of the "manifest.json" by the digests of the manifests
+	dockerIndex map[digest.Digest]int
+}
+
+func newLayout(dir string, regCli registry.Client) *layout {
+	return &layout{
+		dir:    dir,
+		regCli: regCli,
+		index: &v1.Index{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			Manifests: []v1.Descriptor{},
+		},
+		dockerIndex: map[digest.Digest]int{},
+	}
+}
+
+// add the artifact referenced by the repository and tag into the layout with the name
+func (l *layout) add(repository, tag, name string) error {
+	manifest, _, err := l.regCli.PullManifest(repository, tag)
+	if err != nil {
+		return err
+	}
+	mediaType, payload, err := manifest.Payload()
+	if err != nil {
+		return err
+	}
+	dgt := digest.FromBytes(payload)
+	if err = l.writeManifest(repository, manifest, payload, dgt); err != nil {
+		return err
+	}
+	l.index.Manifests = append(l.index.Manifests, v1.Descriptor{
+		MediaType: mediaType,
+		Digest:    dgt,
+		Size:      int64(len(payload)),
+		Annotations: map[string]string{
+			annotationImageName:  name,
+			v1.AnnotationRefName: tag,
+		},
+	})
+
+	// only the images rather than the indexes can be loaded from the "manifest.json"
+	var config distribution.Descriptor
+	var layers []distribution.Descriptor
+	switch m := manifest.(type) {
+	case *schema2.DeserializedManifest:
+		config, layers = m.Config, m.Layers
+	case *ocischema.DeserializedManifest:
+		config, layers = m.Config, m.Layers
+	default:
+		return nil
+	}
+	if i, ok := l.dockerIndex[dgt]; ok {
+		l.manifests[i].RepoTags = append(l.manifests[i].RepoTags, name)
+		return nil
+	}
+	dm := &dockerManifest{
+		Config:   blobPath(config.Digest),
+		RepoTags: []string{name},
+		Layers:   []string{},
+	}
+	for _, layer := range layers {
+		dm.Layers = append(dm.Layers, blobPath(layer.Digest))
+	}
+	l.dockerIndex[dgt] = len(l.manifests)
+	l.manifests = append(l.manifests, dm)
+	return nil
+}
+
+// writeManifest writes the manifest and the referenced manifests and blobs into the layout
+func (l *layout) writeManifest(repository string, manifest distribution.Manifest, payload []byte, dgt digest.Digest) error {
+	for _, ref := range manifest.References() {
+		if isManifest(ref.MediaType) {
+			child, _, err := l.regCli.PullManifest(repository, ref.Digest.String())
+			if err != nil {
+				return err
+			}
+			_, p, err := child.Payload()
+			if err != nil {
+				return err
+			}
+			if err = l.writeManifest(repository, child, p, ref.Digest); err != nil {
+				return err
+			}
+			continue
+		}
+		// the foreign layers are pulled from their URLs
+		if len(ref.URLs) > 0 {
+			continue
+		}
+		if err := l.writeBlob(repository, ref.Digest); err != nil {
+			return err
+		}
+	}
+	p := l.localPath(blobPath(dgt))
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(p, payload, 0600)
+}
+
+// writeBlob pulls the blob from the registry into the layout if it isn't written yet
+func (l *layout) writeBlob(repository string, dgt digest.Digest) error {
+	p := l.localPath(blobPath(dgt))
+	if _, err := os.Stat(p); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return err
+	}
+	_, blob, err := l.regCli.PullBlob(repository, dgt.String())
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+
+	tmp := p + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	verifier := dgt.Verifier()
+	_, err = io.Copy(io.MultiWriter(f, verifier), blob)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	if !verifier.Verified() {
+		return errors.Errorf("the digest of the blob %s pulled from %s mismatches", dgt, repository)
+	}
+	return os.Rename(tmp, p)
+}
+
+// close writes the index.json, manifest.json and oci-layout files of the layout
+func (l *layout) close() error {
+	files := map[string]interface{}{
+		"index.json":       l.index,
+		"manifest.json":    l.manifests,
+		v1.ImageLayoutFile: &v1.ImageLayout{Version: v1.ImageLayoutVersion},
+	}
+	for name, content := range files {
+		data, err := json.Marshal(content)
+		if err != nil {
+			return err
+		}
+		if err = os.WriteFile(l.localPath(name), data, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *layout) localPath(name string) string {
+	return filepath.Join(l.dir, filepath.FromSlash(name))
+}
+
+// archive the layout into the tar file, returns the digest and size of the tar file
+func (l *layout) archive(file string) (digest.Digest, int64, error) {
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	digester := digest.Canonical.Digester()
+	counter := &countWriter{}
+	tw := tar.NewWriter(io.MultiWriter(f, digester.Hash(), counter))
+	err = filepath.Walk(l.dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(l.dir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err = tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		src, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(tw, src)
+		return err
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	if err = tw.Close(); err != nil {
+		return "", 0, err
+	}
+	return digester.Digest(), counter.n, nil
+}
+
+type countWriter struct {
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
+
+func isManifest(mediaType string) bool {
+	switch mediaType {
+	case v1.MediaTypeImageManifest, v1.MediaTypeImageIndex, schema2.MediaTypeManifest, manifestlist.MediaTypeManifestList:
+		return true
+	default:
+		return false
+	}
+}
+
+// blobPath returns the path of the blob in the OCI layout
+func blobPath(dgt digest.Digest) string {
+	return path.Join("blobs", dgt.Algorithm().String(), dgt.Encoded())
+}
@@ -0,0 +1,340 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageimport
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+const (
+	// the annotation set by containerd and docker in the index.json of the OCI layout for the full image name
+	annotationImageName = "io.containerd.image.name"
+	// the max levels of the links followed when resolving the path in the archive
+	maxLinkLevels = 16
+)
+
+var tagRegexp = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+
+// image is the image found in the archive
+type image struct {
+	// the repository of the image without the registry part, empty if the image carries no name
+	repository string
+	// the tag of the image, empty if the image carries no tag
+	tag string
+	// the descriptor of the manifest when the archive is an OCI layout
+	manifest *v1.Descriptor
+	// the paths of the config and layers when the archive is created by "docker save"
+	config string
+	layers []string
+}
+
+// String returns the reference of the image
+func (i *image) String() string {
+	return i.repository + ":" + i.tag
+}
+
+// archive is the archive extracted into the local directory, the symbolic and hard links in the
+// archive are kept in the map rather than created in the file system to avoid escaping the directory
+type archive struct {
+	dir   string
+	links map[string]string
+}
+
+// extract the tar archive, which may be gzipped, into the directory
+func extract(reader io.Reader, dir string) (*archive, error) {
+	br := bufio.NewReader(reader)
+	var r io.Reader = br
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+		r = gr
+	}
+
+	a := &archive{
+		dir:   dir,
+		links: map[string]string{},
+	}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return a, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the archive")
+		}
+		name := cleanPath(hdr.Name)
+		if name == "" {
+			continue
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(a.localPath(name), 0700); err != nil {
+				return nil, err
+			}
+		case tar.TypeReg:
+			if err = a.writeFile(name, tr); err != nil {
+				return nil, err
+			}
+		case tar.TypeSymlink:
+			// the target of the symbolic link is relative to the directory of the link
+			if path.IsAbs(hdr.Linkname) {
+				a.links[name] = cleanPath(hdr.Linkname)
+			} else {
+				a.links[name] = cleanPath(path.Join(path.Dir(name), hdr.Linkname))
+			}
+		case tar.TypeLink:
+			// the target of the hard link is relative to the root of the archive
+			a.links[name] = cleanPath(hdr.Linkname)
+		}
+	}
+}
+
+func (a *archive) writeFile(name string, reader io.Reader) error {
+	p := a.localPath(name)
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, reader)
+	return err
+}
+
+// resolve the links in the path, the returned path is relative to the root of the archive
+func (a *archive) resolve(name string) (string, error) {
+	name = cleanPath(name)
+	for level := 0; level <= maxLinkLevels; level++ {
+		parts := strings.Split(name, "/")
+		resolved := true
+		for i := range parts {
+			if target, ok := a.links[strings.Join(parts[:i+1], "/")]; ok {
+				name = cleanPath(path.Join(target, strings.Join(parts[i+1:], "/")))
+				resolved = false
+				break
+			}
+		}
+		if resolved {
+			return name, nil
+		}
+	}
+	return "", errors.Errorf("too many levels of links in the path %s", name)
+}
+
+func (a *archive) localPath(name string) string {
+	return filepath.Join(a.dir, filepath.FromSlash(name))
+}
+
+// open the file in the archive, returns the size of the file as well
+func (a *archive) open(name string) (*os.File, int64, error) {
+	resolved, err := a.resolve(name)
+	if err != nil {
+		return nil, 0, err
+	}
+	f, err := os.Open(a.localPath(resolved))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, 0, errors.NotFoundError(nil).WithMessage("%s not found in the archive", name)
+		}
+		return nil, 0, err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	return f, stat.Size(), nil
+}
+
+func (a *archive) readFile(name string) ([]byte, error) {
+	f, _, err := a.open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+func (a *archive) exists(name string) bool {
+	f, _, err := a.open(name)
+	if err != nil {
+		return false
+	}
+	f.Close()
+	return true
+}
+
+// gzipped checks whether the file in the archive is gzipped
+func (a *archive) gzipped(name string) bool {
+	f, _, err := a.open(name)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	magic := make([]byte, 2)
+	if _, err = io.ReadFull(f, magic); err != nil {
+		return false
+	}
+	return magic[0] == 0x1f && magic[1] == 0x8b
+}
+
+// digest calculates the digest of the file in the archive
+func (a *archive) digest(name string) (digest.Digest, int64, error) {
+	f, size, err := a.open(name)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	dgt, err := digest.FromReader(f)
+	if err != nil {
+		return "", 0, err
+	}
+	return dgt, size, nil
+}
+
+// blobPath returns the path of the blob in the OCI layout
+func blobPath(dgt digest.Digest) string {
+	return path.Join("blobs", dgt.Algorithm().String(), dgt.Encoded())
+}
+
+// images returns the images in the archive, the "repository" is used for the images carrying only the tag
+func (a *archive) images(repository string) ([]*image, error) {
+	// "docker save" creates the "manifest.json" carrying the repositories and tags of the images,
+	// and the newer docker versions create the OCI layout in the same archive as well
+	if a.exists("manifest.json") {
+		return a.dockerImages(repository)
+	}
+	if a.exists("index.json") {
+		return a.ociImages(repository)
+	}
+	return nil, errors.BadRequestError(nil).WithMessage("neither manifest.json nor index.json is found in the archive")
+}
+
+func (a *archive) dockerImages(repository string) ([]*image, error) {
+	data, err := a.readFile("manifest.json")
+	if err != nil {
+		return nil, err
+	}
+	var manifests []struct {
+		Config   string
+		RepoTags []string
+		Layers   []string
+	}
+	if err = json.Unmarshal(data, &manifests); err != nil {
+		return nil, errors.Wrap(err, "invalid manifest.json in the archive")
+	}
+	var images []*image
+	for _, m := range manifests {
+		if len(m.RepoTags) == 0 {
+			images = append(images, &image{config: m.Config, layers: m.Layers})
+			continue
+		}
+		for _, ref := range m.RepoTags {
+			repo, tag, err := parseReference(ref, repository)
+			if err != nil {
+				return nil, err
+			}
+			images = append(images, &image{
+				repository: repo,
+				tag:        tag,
+				config:     m.Config,
+				layers:     m.Layers,
+			})
+		}
+	}
+	return images, nil
+}
+
+func (a *archive) ociImages(repository string) ([]*image, error) {
+	data, err := a.readFile("index.json")
+	if err != nil {
+		return nil, err
+	}
+	index := &v1.Index{}
+	if err = json.Unmarshal(data, index); err != nil {
+		return nil, errors.Wrap(err, "invalid index.json in the archive")
+	}
+	var images []*image
+	for i := range index.Manifests {
+		desc := index.Manifests[i]
+		ref := desc.Annotations[annotationImageName]
+		if ref == "" {
+			ref = desc.Annotations[v1.AnnotationRefName]
+		}
+		repo, tag, err := parseReference(ref, repository)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, &image{
+			repository: repo,
+			tag:        tag,
+			manifest:   &desc,
+		})
+	}
+	return images, nil
+}
+
+// parseReference parses the reference of the image in the archive into the repository without the registry
+// part and the tag. The reference is either a full one or only a tag, the "repository" is used for the latter
+func parseReference(ref, repository string) (string, string, error) {
+	if ref == "" {
+		return "", "", nil
+	}
+	if tagRegexp.MatchString(ref) {
+		return repository, ref, nil
+	}
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "invalid reference %s in the archive", ref)
+	}
+	repo := reference.Path(named)
+	if reference.Domain(named) == "docker.io" {
+		repo = strings.TrimPrefix(repo, "library/")
+	}
+	tag := "latest"
+	if tagged, ok := named.(reference.Tagged); ok {
+		tag = tagged.Tag()
+	} else if _, ok := named.(reference.Digested); ok {
+		tag = ""
+	}
+	return repo, tag, nil
+}
+
+// cleanPath cleans the path in the archive and makes it relative to the root of the archive,
+// which can never escape the root
+func cleanPath(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
@@ -0,0 +1,323 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/goharbor/harbor/src/common/http/modifier/auth"
+	"github.com/goharbor/harbor/src/controller/imageimport"
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/registry"
+	"github.com/goharbor/harbor/src/pkg/systemartifact"
+)
+
+// Import is the job importing the images from the archive uploaded in chunks. The archive is either created
+// by "docker save" or an OCI image layout, the images are pushed into the project through the core
+type Import struct {
+	sysArtifactMgr systemartifact.Manager
+	regCli         registry.Client
+}
+
+// MaxFails is implementation of same method in Interface.
+func (i *Import) MaxFails() uint {
+	return 1
+}
+
+// MaxCurrency is implementation of same method in Interface.
+func (i *Import) MaxCurrency() uint {
+	return 0
+}
+
+// ShouldRetry ...
+func (i *Import) ShouldRetry() bool {
+	return false
+}
+
+// Validate is implementation of same method in Interface.
+func (i *Import) Validate(params job.Parameters) error {
+	if _, err := parseInt(params, imageimport.ParamImportID); err != nil {
+		return err
+	}
+	for _, key := range []string{imageimport.ParamProjectName, imageimport.ParamDigest} {
+		if v, ok := params[key].(string); !ok || v == "" {
+			return errors.Errorf("missing the parameter %s", key)
+		}
+	}
+	return nil
+}
+
+// Run the import logic here.
+func (i *Import) Run(ctx job.Context, params job.Parameters) error {
+	logger := ctx.GetLogger()
+	if i.sysArtifactMgr == nil {
+		i.sysArtifactMgr = systemartifact.Mgr
+	}
+	if i.regCli == nil {
+		// the images are pushed through the internal endpoint of the core, so insecure transport is ok
+		i.regCli = registry.NewClientWithAuthorizer(config.GetCoreURL(), auth.NewSecretAuthorizer(config.GetAuthSecret()), true)
+	}
+
+	id, err := parseInt(params, imageimport.ParamImportID)
+	if err != nil {
+		return err
+	}
+	projectName, _ := params[imageimport.ParamProjectName].(string)
+	repository, _ := params[imageimport.ParamRepository].(string)
+	dgt, _ := params[imageimport.ParamDigest].(string)
+	archiveRepository := strconv.FormatInt(id, 10)
+
+	// the uploaded archive is deleted when the job completes whether the images are imported or not
+	defer func() {
+		if err := i.sysArtifactMgr.Delete(ctx.SystemContext(), imageimport.Vendor, archiveRepository, dgt); err != nil {
+			logger.Warningf("failed to delete the archive of the image import %d: %v", id, err)
+		}
+	}()
+
+	dir, err := os.MkdirTemp("", "image-import-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	logger.Infof("start to extract the archive %s of the image import %d", dgt, id)
+	reader, err := i.sysArtifactMgr.Read(ctx.SystemContext(), imageimport.Vendor, archiveRepository, dgt)
+	if err != nil {
+		logger.Errorf("failed to read the archive of the image import %d: %v", id, err)
+		return err
+	}
+	a, err := extract(reader, dir)
+	reader.Close()
+	if err != nil {
+		logger.Errorf("failed to extract the archive of the image import %d: %v", id, err)
+		return err
+	}
+	images, err := a.images(repository)
+	if err != nil {
+		logger.Errorf("failed to resolve the images in the archive of the image import %d: %v", id, err)
+		return err
+	}
+
+	var candidates []*image
+	for _, img := range images {
+		if img.repository == "" || img.tag == "" {
+			logger.Warningf("skip the image without the repository or the tag in the archive")
+			continue
+		}
+		candidates = append(candidates, img)
+	}
+	if len(candidates) == 0 {
+		return errors.New("no image with the repository and the tag is found in the archive")
+	}
+
+	progress := &imageimport.Progress{Total: len(candidates)}
+	checkin(ctx, progress)
+	for _, img := range candidates {
+		if opCmd, exit := ctx.OPCommand(); exit && opCmd.IsStop() {
+			logger.Info("received the stop signal, stop the image import job")
+			return nil
+		}
+		repo := fmt.Sprintf("%s/%s", projectName, img.repository)
+		if err = i.push(a, repo, img); err != nil {
+			logger.Errorf("failed to import the image %s into %s: %v", img, repo, err)
+			progress.Failed++
+		} else {
+			logger.Infof("the image %s is imported into %s:%s", img, repo, img.tag)
+			progress.Imported++
+		}
+		checkin(ctx, progress)
+	}
+
+	if progress.Failed > 0 {
+		return errors.Errorf("%d of %d images failed to be imported", progress.Failed, progress.Total)
+	}
+	logger.Infof("%d images are imported", progress.Imported)
+	return nil
+}
+
+func (i *Import) push(a *archive, repository string, img *image) error {
+	if img.manifest != nil {
+		return i.pushManifest(a, repository, *img.manifest, img.tag)
+	}
+	return i.pushDockerImage(a, repository, img)
+}
+
+// manifestContent contains the fields of the manifests and the indexes needed to push the referenced contents
+type manifestContent struct {
+	MediaType string          `json:"mediaType"`
+	Config    *v1.Descriptor  `json:"config"`
+	Layers    []v1.Descriptor `json:"layers"`
+	Manifests []v1.Descriptor `json:"manifests"`
+}
+
+// pushManifest pushes the manifest or the index of the OCI layout with the referenced contents,
+// the manifest is pushed by the tag if it isn't empty, otherwise by the digest
+func (i *Import) pushManifest(a *archive, repository string, desc v1.Descriptor, tag string) error {
+	payload, err := a.readFile(blobPath(desc.Digest))
+	if err != nil {
+		return err
+	}
+	content := &manifestContent{}
+	if err = json.Unmarshal(payload, content); err != nil {
+		return errors.Wrapf(err, "invalid manifest %s in the archive", desc.Digest)
+	}
+	mediaType := content.MediaType
+	if mediaType == "" {
+		mediaType = desc.MediaType
+	}
+
+	if len(content.Manifests) > 0 {
+		var present []v1.Descriptor
+		for _, child := range content.Manifests {
+			if a.exists(blobPath(child.Digest)) {
+				present = append(present, child)
+			}
+		}
+		if len(present) < len(content.Manifests) {
+			// only the manifest of the current platform is saved for the multi-platform image by "docker save"
+			if len(present) == 1 && tag != "" {
+				return i.pushManifest(a, repository, present[0], tag)
+			}
+			return errors.Errorf("%d of %d manifests of the index %s are missing in the archive",
+				len(content.Manifests)-len(present), len(content.Manifests), desc.Digest)
+		}
+		for _, child := range content.Manifests {
+			if err = i.pushManifest(a, repository, child, ""); err != nil {
+				return err
+			}
+		}
+	} else {
+		if content.Config != nil {
+			if err = i.pushBlob(a, repository, blobPath(content.Config.Digest), content.Config.Digest); err != nil {
+				return err
+			}
+		}
+		for _, layer := range content.Layers {
+			// the foreign layers are pulled from their URLs
+			if len(layer.URLs) > 0 && !a.exists(blobPath(layer.Digest)) {
+				continue
+			}
+			if err = i.pushBlob(a, repository, blobPath(layer.Digest), layer.Digest); err != nil {
+				return err
+			}
+		}
+	}
+
+	reference := desc.Digest.String()
+	if tag != "" {
+		reference = tag
+	}
+	_, err = i.regCli.PushManifest(repository, reference, mediaType, payload)
+	return err
+}
+
+// pushDockerImage pushes the image created by "docker save", an OCI manifest is built to reference
+// the config and the layers as "docker save" doesn't keep the manifest
+func (i *Import) pushDockerImage(a *archive, repository string, img *image) error {
+	configDigest, configSize, err := a.digest(img.config)
+	if err != nil {
+		return err
+	}
+	if err = i.pushBlob(a, repository, img.config, configDigest); err != nil {
+		return err
+	}
+	manifest := &v1.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: v1.MediaTypeImageManifest,
+		Config: v1.Descriptor{
+			MediaType: v1.MediaTypeImageConfig,
+			Digest:    configDigest,
+			Size:      configSize,
+		},
+	}
+	for _, layer := range img.layers {
+		dgt, size, err := a.digest(layer)
+		if err != nil {
+			return err
+		}
+		if err = i.pushBlob(a, repository, layer, dgt); err != nil {
+			return err
+		}
+		// the layers are uncompressed in the archives of "docker save" but may be gzipped in others
+		mediaType := v1.MediaTypeImageLayer
+		if a.gzipped(layer) {
+			mediaType = v1.MediaTypeImageLayerGzip
+		}
+		manifest.Layers = append(manifest.Layers, v1.Descriptor{
+			MediaType: mediaType,
+			Digest:    dgt,
+			Size:      size,
+		})
+	}
+	payload, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	_, err = i.regCli.PushManifest(repository, img.tag, v1.MediaTypeImageManifest, payload)
+	return err
+}
+
+// pushBlob pushes the file in the archive as the blob if it doesn't exist in the repository
+func (i *Import) pushBlob(a *archive, repository, name string, dgt digest.Digest) error {
+	exist, err := i.regCli.BlobExist(repository, dgt.String())
+	if err != nil {
+		return err
+	}
+	if exist {
+		return nil
+	}
+	f, size, err := a.open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return i.regCli.PushBlob(repository, dgt.String(), size, f)
+}
+
+func checkin(ctx job.Context, progress *imageimport.Progress) {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return
+	}
+	if err = ctx.Checkin(string(data)); err != nil {
+		ctx.GetLogger().Warningf("failed to check in the progress of the image import: %v", err)
+	}
+}
+
+func parseInt(params job.Parameters, key string) (int64, error) {
+	value, exist := params[key]
+	if !exist {
+		return 0, errors.Errorf("missing the parameter %s", key)
+	}
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		return int64(v), nil
+	default:
+		return 0, errors.Errorf("invalid type of the parameter %s: %T", key, value)
+	}
+}
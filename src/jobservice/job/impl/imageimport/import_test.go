@@ -0,0 +1,254 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageimport
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/imageimport"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
+	registrytesting "github.com/goharbor/harbor/src/testing/pkg/registry"
+	systemartifacttesting "github.com/goharbor/harbor/src/testing/pkg/systemartifact"
+)
+
+type tarEntry struct {
+	name     string
+	content  string
+	linkname string
+}
+
+func buildTar(entries []tarEntry, gzipped bool) []byte {
+	buf := &bytes.Buffer{}
+	var w io.Writer = buf
+	var gw *gzip.Writer
+	if gzipped {
+		gw = gzip.NewWriter(buf)
+		w = gw
+	}
+	tw := tar.NewWriter(w)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0644, Size: int64(len(e.content)), Typeflag: tar.TypeReg}
+		if len(e.linkname) > 0 {
+			hdr.Typeflag, hdr.Linkname, hdr.Size = tar.TypeSymlink, e.linkname, 0
+		}
+		_ = tw.WriteHeader(hdr)
+		_, _ = tw.Write([]byte(e.content))
+	}
+	_ = tw.Close()
+	if gw != nil {
+		_ = gw.Close()
+	}
+	return buf.Bytes()
+}
+
+func ociLayout() []tarEntry {
+	config, layer := `{"architecture":"amd64"}`, "layer"
+	manifest, _ := json.Marshal(&v1.Manifest{
+		MediaType: v1.MediaTypeImageManifest,
+		Config:    v1.Descriptor{MediaType: v1.MediaTypeImageConfig, Digest: digest.FromString(config), Size: int64(len(config))},
+		Layers:    []v1.Descriptor{{MediaType: v1.MediaTypeImageLayer, Digest: digest.FromString(layer), Size: int64(len(layer))}},
+	})
+	index, _ := json.Marshal(&v1.Index{
+		Manifests: []v1.Descriptor{
+			{
+				MediaType:   v1.MediaTypeImageManifest,
+				Digest:      digest.FromBytes(manifest),
+				Size:        int64(len(manifest)),
+				Annotations: map[string]string{annotationImageName: "docker.io/library/alpine:3.18"},
+			},
+			{
+				MediaType:   v1.MediaTypeImageManifest,
+				Digest:      digest.FromBytes(manifest),
+				Size:        int64(len(manifest)),
+				Annotations: map[string]string{v1.AnnotationRefName: "v1"},
+			},
+		},
+	})
+	return []tarEntry{
+		{name: "oci-layout", content: `{"imageLayoutVersion":"1.0.0"}`},
+		{name: "index.json", content: string(index)},
+		{name: blobPath(digest.FromBytes(manifest)), content: string(manifest)},
+		{name: blobPath(digest.FromString(config)), content: config},
+		{name: blobPath(digest.FromString(layer)), content: layer},
+	}
+}
+
+func dockerSave() []tarEntry {
+	return []tarEntry{
+		{name: "manifest.json", content: `[{"Config":"config.json","RepoTags":["quay.io/org/app:1.0","app:latest"],"Layers":["abc/layer.tar","def/layer.tar"]},{"Config":"config.json","Layers":[]}]`},
+		{name: "config.json", content: `{"architecture":"amd64"}`},
+		{name: "abc/layer.tar", content: "layer"},
+		// the duplicated layer is linked to the existing one
+		{name: "def/layer.tar", linkname: "../abc/layer.tar"},
+		// the links escaping the root are confined within the archive
+		{name: "evil", linkname: "../../../etc/passwd"},
+		{name: "../../escaped", content: "escaped"},
+	}
+}
+
+type importTestSuite struct {
+	suite.Suite
+	sysArtifactMgr *systemartifacttesting.Manager
+	regCli         *registrytesting.Client
+	job            *Import
+}
+
+func (i *importTestSuite) SetupTest() {
+	i.sysArtifactMgr = &systemartifacttesting.Manager{}
+	i.regCli = &registrytesting.Client{}
+	i.job = &Import{sysArtifactMgr: i.sysArtifactMgr, regCli: i.regCli}
+}
+
+func (i *importTestSuite) extract(entries []tarEntry, gzipped bool) *archive {
+	a, err := extract(bytes.NewReader(buildTar(entries, gzipped)), i.T().TempDir())
+	i.Require().NoError(err)
+	return a
+}
+
+func (i *importTestSuite) TestExtract() {
+	a := i.extract(dockerSave(), true)
+	data, err := a.readFile("def/layer.tar")
+	i.Require().NoError(err)
+	i.Equal("layer", string(data))
+	data, err = a.readFile("escaped")
+	i.Require().NoError(err)
+	i.Equal("escaped", string(data))
+	i.False(a.exists("evil"))
+	i.Equal("etc/passwd", a.links["evil"])
+}
+
+func (i *importTestSuite) TestDockerImages() {
+	a := i.extract(dockerSave(), false)
+	images, err := a.images("")
+	i.Require().NoError(err)
+	i.Require().Len(images, 3)
+	i.Equal("org/app", images[0].repository)
+	i.Equal("1.0", images[0].tag)
+	i.Equal("app", images[1].repository)
+	i.Equal("latest", images[1].tag)
+	i.Equal([]string{"abc/layer.tar", "def/layer.tar"}, images[1].layers)
+	// the image without the tags
+	i.Empty(images[2].repository)
+}
+
+func (i *importTestSuite) TestOCIImages() {
+	a := i.extract(ociLayout(), false)
+	images, err := a.images("hello-world")
+	i.Require().NoError(err)
+	i.Require().Len(images, 2)
+	i.Equal("alpine", images[0].repository)
+	i.Equal("3.18", images[0].tag)
+	// only the tag in the annotation
+	i.Equal("hello-world", images[1].repository)
+	i.Equal("v1", images[1].tag)
+	i.NotNil(images[1].manifest)
+}
+
+func (i *importTestSuite) TestParseReference() {
+	cases := []struct {
+		ref, repo, tag string
+	}{
+		{"alpine", "library", "alpine"},
+		{"docker.io/library/alpine:3.18", "alpine", "3.18"},
+		{"goharbor/harbor-core", "goharbor/harbor-core", "latest"},
+		{"registry.example.com:5000/team/app:v2", "team/app", "v2"},
+	}
+	for _, c := range cases {
+		repo, tag, err := parseReference(c.ref, "library")
+		i.Require().NoError(err)
+		i.Equal(c.repo, repo, c.ref)
+		i.Equal(c.tag, tag, c.ref)
+	}
+	_, _, err := parseReference("INVALID/Name", "")
+	i.Error(err)
+}
+
+func (i *importTestSuite) TestRun() {
+	archive := buildTar(dockerSave(), true)
+	i.sysArtifactMgr.On("Read", mock.Anything, imageimport.Vendor, "1", "sha256:abc").Return(io.NopCloser(bytes.NewReader(archive)), nil)
+	i.sysArtifactMgr.On("Delete", mock.Anything, imageimport.Vendor, "1", "sha256:abc").Return(nil).Once()
+	i.regCli.On("BlobExist", mock.Anything, mock.Anything).Return(false, nil)
+	i.regCli.On("PushBlob", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	i.regCli.On("PushManifest", "library/org/app", "1.0", v1.MediaTypeImageManifest, mock.Anything).Return("", nil).Once()
+	i.regCli.On("PushManifest", "library/app", "latest", v1.MediaTypeImageManifest, mock.Anything).Return("", nil).Once()
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.OPCommand(""), false)
+	ctx.On("Checkin", `{"total":2,"imported":0,"failed":0}`).Return(nil).Once()
+	ctx.On("Checkin", `{"total":2,"imported":1,"failed":0}`).Return(nil).Once()
+	ctx.On("Checkin", `{"total":2,"imported":2,"failed":0}`).Return(nil).Once()
+
+	err := i.job.Run(ctx, job.Parameters{
+		imageimport.ParamImportID:    float64(1),
+		imageimport.ParamProjectName: "library",
+		imageimport.ParamDigest:      "sha256:abc",
+	})
+	i.Require().NoError(err)
+	ctx.AssertExpectations(i.T())
+	i.regCli.AssertExpectations(i.T())
+	i.sysArtifactMgr.AssertExpectations(i.T())
+
+	// the manifest references the uncompressed layers
+	payload := i.regCli.Calls[len(i.regCli.Calls)-1].Arguments.Get(3).([]byte)
+	manifest := &v1.Manifest{}
+	i.Require().NoError(json.Unmarshal(payload, manifest))
+	i.Require().Len(manifest.Layers, 2)
+	i.Equal(digest.FromString("layer"), manifest.Layers[1].Digest)
+	i.Equal(v1.MediaTypeImageLayer, manifest.Layers[1].MediaType)
+}
+
+func (i *importTestSuite) TestRunOCILayout() {
+	archive := buildTar(ociLayout(), false)
+	i.sysArtifactMgr.On("Read", mock.Anything, imageimport.Vendor, "1", "sha256:abc").Return(io.NopCloser(bytes.NewReader(archive)), nil)
+	i.sysArtifactMgr.On("Delete", mock.Anything, imageimport.Vendor, "1", "sha256:abc").Return(nil).Once()
+	i.regCli.On("BlobExist", mock.Anything, mock.Anything).Return(true, nil)
+	i.regCli.On("PushManifest", "library/alpine", "3.18", v1.MediaTypeImageManifest, mock.Anything).Return("", nil).Once()
+	i.regCli.On("PushManifest", "library/hello-world", "v1", v1.MediaTypeImageManifest, mock.Anything).Return("", errors.New("failed")).Once()
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.OPCommand(""), false)
+	ctx.On("Checkin", mock.Anything).Return(nil)
+
+	err := i.job.Run(ctx, job.Parameters{
+		imageimport.ParamImportID:    float64(1),
+		imageimport.ParamProjectName: "library",
+		imageimport.ParamRepository:  "hello-world",
+		imageimport.ParamDigest:      "sha256:abc",
+	})
+	i.Require().Error(err)
+	i.regCli.AssertNotCalled(i.T(), "PushBlob", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	ctx.AssertCalled(i.T(), "Checkin", `{"total":2,"imported":1,"failed":1}`)
+	i.sysArtifactMgr.AssertExpectations(i.T())
+}
+
+func (i *importTestSuite) TestValidate() {
+	i.NoError(i.job.Validate(job.Parameters{"import_id": float64(1), "project_name": "library", "digest": "sha256:abc"}))
+	i.Error(i.job.Validate(job.Parameters{"import_id": float64(1), "project_name": "library"}))
+	i.Error(i.job.Validate(job.Parameters{"project_name": "library", "digest": "sha256:abc"}))
+}
+
+func TestImportTestSuite(t *testing.T) {
+	suite.Run(t, &importTestSuite{})
+}
@@ -0,0 +1,156 @@
+package notification
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/common/utils/email"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+// emailTimeout is the timeout in seconds to connect the SMTP server
+const emailTimeout = 60
+
+// EmailJob implements the job interface, which sends the notification email to the recipients
+// with the SMTP server configured in the system settings
+type EmailJob struct {
+	logger logger.Interface
+}
+
+// MaxFails returns that how many times this job can fail.
+func (ej *EmailJob) MaxFails() uint {
+	return (&WebhookJob{}).MaxFails()
+}
+
+// MaxCurrency is implementation of same method in Interface.
+func (ej *EmailJob) MaxCurrency() uint {
+	return 0
+}
+
+// ShouldRetry ...
+func (ej *EmailJob) ShouldRetry() bool {
+	return true
+}
+
+// Validate implements the interface in job/Interface
+func (ej *EmailJob) Validate(params job.Parameters) error {
+	if params == nil {
+		// Params are required
+		return errors.New("missing parameter of email job")
+	}
+	for _, key := range []string{"subject", "message"} {
+		value, ok := params[key]
+		if !ok {
+			return errors.Errorf("missing job parameter '%s'", key)
+		}
+		if _, ok = value.(string); !ok {
+			return errors.Errorf("malformed job parameter '%s', expecting string but got %s", key, reflect.TypeOf(value).String())
+		}
+	}
+	recipients, err := getRecipients(params)
+	if err != nil {
+		return err
+	}
+	if len(recipients) == 0 {
+		return errors.Errorf("empty job parameter 'recipients'")
+	}
+	return nil
+}
+
+// Run implements the interface in job/Interface
+func (ej *EmailJob) Run(ctx job.Context, params job.Parameters) error {
+	ej.logger = ctx.GetLogger()
+
+	if err := ej.execute(ctx, params); err != nil {
+		ej.logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// execute email job
+func (ej *EmailJob) execute(ctx job.Context, params map[string]interface{}) error {
+	subject := params["subject"].(string)
+	message := params["message"].(string)
+	recipients, err := getRecipients(params)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err = ej.send(ctx, recipients, subject, message)
+	a := &attempt{
+		Latency:  time.Since(start).Milliseconds(),
+		Success:  err == nil,
+		MaxFails: ej.MaxFails(),
+	}
+	if err != nil {
+		err = fmt.Errorf("email job(recipients: %v) failed: %v", recipients, err)
+		a.Error = err.Error()
+	}
+	checkIn(ctx, a)
+
+	return err
+}
+
+// send the email with the SMTP settings got from the job context
+func (ej *EmailJob) send(ctx job.Context, recipients []string, subject, message string) error {
+	host := getStringProp(ctx, common.EmailHost)
+	if len(host) == 0 {
+		return errors.New("the email server is not configured")
+	}
+	port := getStringProp(ctx, common.EmailPort)
+	if len(port) == 0 {
+		port = "25"
+	}
+	ssl, _ := strconv.ParseBool(getStringProp(ctx, common.EmailSSL))
+	insecure, _ := strconv.ParseBool(getStringProp(ctx, common.EmailInsecure))
+	return email.Send(net.JoinHostPort(host, port),
+		getStringProp(ctx, common.EmailIdentity),
+		getStringProp(ctx, common.EmailUsername),
+		getStringProp(ctx, common.EmailPassword),
+		emailTimeout, ssl, insecure,
+		getStringProp(ctx, common.EmailFrom),
+		recipients, subject, message)
+}
+
+// getStringProp returns the string value of the property in the job context, the config items are loaded into
+// the context with their own types
+func getStringProp(ctx job.Context, key string) string {
+	v, ok := ctx.Get(key)
+	if !ok || v == nil {
+		return ""
+	}
+	return utils.GetStrValueOfAnyType(v)
+}
+
+// getRecipients returns the recipients in the job parameters, which are decoded as []interface{} from JSON
+func getRecipients(params job.Parameters) ([]string, error) {
+	value, ok := params["recipients"]
+	if !ok {
+		return nil, errors.Errorf("missing job parameter 'recipients'")
+	}
+	var recipients []string
+	switch v := value.(type) {
+	case []string:
+		recipients = v
+	case []interface{}:
+		for _, r := range v {
+			s, ok := r.(string)
+			if !ok {
+				return nil, errors.Errorf("malformed job parameter 'recipients', expecting string but got %s", reflect.TypeOf(r).String())
+			}
+			recipients = append(recipients, s)
+		}
+	default:
+		return nil, errors.Errorf("malformed job parameter 'recipients', expecting array but got %s", reflect.TypeOf(value).String())
+	}
+	return recipients, nil
+}
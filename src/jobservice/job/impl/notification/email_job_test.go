@@ -0,0 +1,111 @@
+package notification
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/common"
+	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
+)
+
+// fakeSMTPServer accepts one connection without the extensions and records the mail data
+func fakeSMTPServer(t *testing.T) (string, string, <-chan string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	received := make(chan string, 1)
+	go func() {
+		defer l.Close()
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		fmt.Fprint(conn, "220 localhost ESMTP\r\n")
+		reader := bufio.NewReader(conn)
+		var rcpt []string
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"), strings.HasPrefix(cmd, "MAIL"):
+				fmt.Fprint(conn, "250 OK\r\n")
+			case strings.HasPrefix(cmd, "RCPT"):
+				rcpt = append(rcpt, strings.TrimSpace(line))
+				fmt.Fprint(conn, "250 OK\r\n")
+			case cmd == "DATA":
+				fmt.Fprint(conn, "354 go ahead\r\n")
+				var data strings.Builder
+				for {
+					l, err := reader.ReadString('\n')
+					if err != nil || l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				fmt.Fprint(conn, "250 OK\r\n")
+				received <- strings.Join(rcpt, "\n") + "\n" + data.String()
+			case cmd == "QUIT":
+				fmt.Fprint(conn, "221 bye\r\n")
+				return
+			default:
+				fmt.Fprint(conn, "502 unsupported\r\n")
+			}
+		}
+	}()
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+	return host, port, received
+}
+
+func TestEmailJobValidate(t *testing.T) {
+	ej := &EmailJob{}
+	assert.NotNil(t, ej.Validate(nil))
+	assert.NotNil(t, ej.Validate(map[string]interface{}{"subject": "subject", "message": "message"}))
+	assert.NotNil(t, ej.Validate(map[string]interface{}{"subject": "subject", "message": "message", "recipients": []interface{}{}}))
+	assert.NotNil(t, ej.Validate(map[string]interface{}{"subject": "subject", "message": "message", "recipients": []interface{}{1}}))
+	assert.Nil(t, ej.Validate(map[string]interface{}{"subject": "subject", "message": "message", "recipients": []interface{}{"dev@example.com"}}))
+}
+
+func TestEmailJobRun(t *testing.T) {
+	host, port, received := fakeSMTPServer(t)
+	ctx := &mockjobservice.MockJobContext{}
+	logger := &mockjobservice.MockJobLogger{}
+	ctx.On("GetLogger").Return(logger)
+	ctx.On("Checkin", mock.Anything).Return(nil)
+	ctx.On("Get", common.EmailHost).Return(host, true)
+	ctx.On("Get", common.EmailPort).Return(port, true)
+	ctx.On("Get", common.EmailFrom).Return("harbor@example.com", true)
+	ctx.On("Get", mock.Anything).Return(nil, false)
+
+	err := (&EmailJob{}).Run(ctx, map[string]interface{}{
+		"subject":    "[Harbor] Artifact pushed",
+		"message":    "<p>pushed</p>",
+		"recipients": []interface{}{"dev@example.com", "ops@example.com"},
+	})
+	require.Nil(t, err)
+	data := <-received
+	assert.Contains(t, data, "RCPT TO:<dev@example.com>")
+	assert.Contains(t, data, "RCPT TO:<ops@example.com>")
+	assert.Contains(t, data, "Subject: [Harbor] Artifact pushed")
+	assert.Contains(t, data, "<p>pushed</p>")
+
+	// the email server isn't configured
+	ctx = &mockjobservice.MockJobContext{}
+	ctx.On("GetLogger").Return(logger)
+	ctx.On("Checkin", mock.Anything).Return(nil)
+	ctx.On("Get", mock.Anything).Return(nil, false)
+	err = (&EmailJob{}).Run(ctx, map[string]interface{}{
+		"subject":    "subject",
+		"message":    "message",
+		"recipients": []interface{}{"dev@example.com"},
+	})
+	assert.NotNil(t, err)
+}
@@ -1,14 +1,21 @@
 package notification
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
+	"time"
 
 	commonhttp "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/jobservice/job"
 )
 
 const (
 	secure   = "secure"
 	insecure = "insecure"
+
+	// the max length of the response body recorded in the delivery attempt
+	maxResponseSnippet = 1024
 )
 
 var (
@@ -31,3 +38,45 @@ func init() {
 		Transport: commonhttp.GetHTTPTransport(commonhttp.WithInsecure(true)),
 	}
 }
+
+// attempt is the result of one delivery attempt which is checked in to the core
+type attempt struct {
+	Success    bool   `json:"success"`
+	StatusCode int    `json:"status_code"`
+	Latency    int64  `json:"latency"`
+	Response   string `json:"response,omitempty"`
+	Error      string `json:"error,omitempty"`
+	MaxFails   uint   `json:"max_fails"`
+}
+
+// send the request and record the status code, latency and the snippet of the response body
+func send(client *http.Client, req *http.Request) (*attempt, *http.Response, error) {
+	start := time.Now()
+	resp, err := client.Do(req)
+	a := &attempt{
+		Latency: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		a.Error = err.Error()
+		return a, nil, err
+	}
+	defer resp.Body.Close()
+	a.StatusCode = resp.StatusCode
+	a.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+	if body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSnippet)); err == nil {
+		a.Response = string(body)
+	}
+	return a, resp, nil
+}
+
+// checkIn reports the delivery attempt to the core, the failure doesn't affect the result of the job
+func checkIn(ctx job.Context, a *attempt) {
+	data, err := json.Marshal(a)
+	if err != nil {
+		ctx.GetLogger().Warningf("failed to marshal the delivery attempt: %v", err)
+		return
+	}
+	if err := ctx.Checkin(string(data)); err != nil {
+		ctx.GetLogger().Warningf("failed to check in the delivery attempt: %v", err)
+	}
+}
@@ -1,9 +1,13 @@
 package notification
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHttpHelper(t *testing.T) {
@@ -16,3 +20,29 @@ func TestHttpHelper(t *testing.T) {
 	_, ok := httpHelper.clients["notExists"]
 	assert.False(t, ok)
 }
+
+func TestSend(t *testing.T) {
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(strings.Repeat("a", maxResponseSnippet+10)))
+		}))
+	defer ts.Close()
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL, nil)
+	require.Nil(t, err)
+	a, resp, err := send(httpHelper.clients[secure], req)
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.False(t, a.Success)
+	assert.Equal(t, http.StatusBadGateway, a.StatusCode)
+	assert.Len(t, a.Response, maxResponseSnippet)
+
+	// unreachable target
+	req, err = http.NewRequest(http.MethodPost, "http://127.0.0.1:1", nil)
+	require.Nil(t, err)
+	a, _, err = send(httpHelper.clients[secure], req)
+	assert.NotNil(t, err)
+	assert.False(t, a.Success)
+	assert.NotEmpty(t, a.Error)
+}
@@ -0,0 +1,204 @@
+package notification
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+const (
+	natsDefaultPort = "4222"
+	natsTimeout     = 10 * time.Second
+)
+
+// NATSJob implements the job interface, which publishes notification to the subject of the NATS server
+// with the client protocol of NATS, the address is in the format of nats://[user:password@]host[:port],
+// the scheme "tls" is used to connect with TLS
+type NATSJob struct {
+	logger         logger.Interface
+	skipCertVerify bool
+}
+
+// natsConnect is the options sent by the CONNECT operation of the NATS protocol
+type natsConnect struct {
+	Verbose     bool   `json:"verbose"`
+	Pedantic    bool   `json:"pedantic"`
+	TLSRequired bool   `json:"tls_required"`
+	User        string `json:"user,omitempty"`
+	Pass        string `json:"pass,omitempty"`
+	AuthToken   string `json:"auth_token,omitempty"`
+	Name        string `json:"name"`
+	Lang        string `json:"lang"`
+	Version     string `json:"version"`
+}
+
+// natsInfo is the part of the INFO sent by the NATS server used by the job
+type natsInfo struct {
+	TLSRequired bool `json:"tls_required"`
+}
+
+// MaxFails returns that how many times this job can fail.
+func (nj *NATSJob) MaxFails() uint {
+	return (&WebhookJob{}).MaxFails()
+}
+
+// MaxCurrency is implementation of same method in Interface.
+func (nj *NATSJob) MaxCurrency() uint {
+	return 0
+}
+
+// ShouldRetry ...
+func (nj *NATSJob) ShouldRetry() bool {
+	return true
+}
+
+// Validate implements the interface in job/Interface
+func (nj *NATSJob) Validate(params job.Parameters) error {
+	if params == nil {
+		// Params are required
+		return errors.New("missing parameter of nats job")
+	}
+	for _, key := range []string{"payload", "address", "subject"} {
+		value, ok := params[key]
+		if !ok {
+			return errors.Errorf("missing job parameter '%s'", key)
+		}
+		if _, ok = value.(string); !ok {
+			return errors.Errorf("malformed job parameter '%s', expecting string but got %s", key, reflect.TypeOf(value).String())
+		}
+	}
+	return nil
+}
+
+// Run implements the interface in job/Interface
+func (nj *NATSJob) Run(ctx job.Context, params job.Parameters) error {
+	nj.logger = ctx.GetLogger()
+	if v, ok := params["skip_cert_verify"]; ok {
+		nj.skipCertVerify, _ = v.(bool)
+	}
+
+	if err := nj.execute(ctx, params); err != nil {
+		nj.logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// execute nats job
+func (nj *NATSJob) execute(ctx job.Context, params map[string]interface{}) error {
+	payload := params["payload"].(string)
+	address := params["address"].(string)
+	subject := params["subject"].(string)
+
+	start := time.Now()
+	reply, err := nj.publish(address, subject, []byte(payload))
+	a := &attempt{
+		Latency:  time.Since(start).Milliseconds(),
+		Success:  err == nil,
+		Response: reply,
+		MaxFails: nj.MaxFails(),
+	}
+	if err != nil {
+		err = fmt.Errorf("nats job(target: %s, subject: %s) failed: %v", address, subject, err)
+		a.Error = err.Error()
+	}
+	checkIn(ctx, a)
+
+	return err
+}
+
+// publish the payload to the subject and wait for the PONG of the server to make sure the message
+// is processed, returns the reply of the server
+func (nj *NATSJob) publish(address, subject string, payload []byte) (string, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", err
+	}
+	host := u.Host
+	if len(u.Port()) == 0 {
+		host = net.JoinHostPort(u.Hostname(), natsDefaultPort)
+	}
+
+	conn, err := net.DialTimeout("tcp", host, natsTimeout)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	if err = conn.SetDeadline(time.Now().Add(natsTimeout)); err != nil {
+		return "", err
+	}
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return line, errors.Errorf("unexpected reply from the nats server: %s", strings.TrimSpace(line))
+	}
+	info := &natsInfo{}
+	if err = json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), info); err != nil {
+		return line, errors.Errorf("invalid info from the nats server: %v", err)
+	}
+
+	// upgrade the connection to TLS when it is required by either side
+	useTLS := u.Scheme == "tls" || info.TLSRequired
+	if useTLS {
+		tlsConn := tls.Client(conn, &tls.Config{
+			ServerName:         u.Hostname(),
+			InsecureSkipVerify: nj.skipCertVerify, // #nosec G402
+		})
+		if err = tlsConn.Handshake(); err != nil {
+			return "", err
+		}
+		conn = tlsConn
+		reader = bufio.NewReader(conn)
+	}
+
+	opts := &natsConnect{
+		TLSRequired: useTLS,
+		Name:        "harbor",
+		Lang:        "go",
+		Version:     "1.0.0",
+	}
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			opts.User, opts.Pass = u.User.Username(), pass
+		} else {
+			opts.AuthToken = u.User.Username()
+		}
+	}
+	connect, err := json.Marshal(opts)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err = fmt.Fprintf(conn, "CONNECT %s\r\nPUB %s %d\r\n%s\r\nPING\r\n", connect, subject, len(payload), payload); err != nil {
+		return "", err
+	}
+
+	for {
+		line, err = reader.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		reply := strings.TrimSpace(line)
+		switch {
+		case reply == "PONG":
+			return reply, nil
+		case strings.HasPrefix(reply, "-ERR"):
+			return reply, errors.Errorf("error from the nats server: %s", reply)
+		}
+		// ignore the other operations, e.g. "+OK" and the INFO updates
+	}
+}
@@ -0,0 +1,91 @@
+package notification
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
+)
+
+// fakeNATSServer accepts one connection and records the published message
+func fakeNATSServer(t *testing.T, reply string) (string, <-chan string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	published := make(chan string, 1)
+	go func() {
+		defer l.Close()
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		fmt.Fprint(conn, "INFO {\"server_id\":\"test\",\"max_payload\":1048576}\r\n")
+		reader := bufio.NewReader(conn)
+		var connect, pub string
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch {
+			case strings.HasPrefix(line, "CONNECT "):
+				connect = strings.TrimSpace(line)
+			case strings.HasPrefix(line, "PUB "):
+				var subject string
+				var size int
+				fmt.Sscanf(line, "PUB %s %d", &subject, &size)
+				body := make([]byte, size+2)
+				if _, err := io.ReadFull(reader, body); err != nil {
+					return
+				}
+				pub = subject + " " + string(body[:size])
+			case strings.HasPrefix(line, "PING"):
+				fmt.Fprint(conn, reply)
+				published <- connect + "\n" + pub
+				return
+			}
+		}
+	}()
+	return "nats://token@" + l.Addr().String(), published
+}
+
+func TestNATSJobValidate(t *testing.T) {
+	nj := &NATSJob{}
+	assert.NotNil(t, nj.Validate(nil))
+	assert.NotNil(t, nj.Validate(map[string]interface{}{"payload": "{}", "address": "nats://127.0.0.1"}))
+	assert.Nil(t, nj.Validate(map[string]interface{}{"payload": "{}", "address": "nats://127.0.0.1", "subject": "harbor"}))
+}
+
+func TestNATSJobRun(t *testing.T) {
+	ctx := &mockjobservice.MockJobContext{}
+	logger := &mockjobservice.MockJobLogger{}
+	ctx.On("GetLogger").Return(logger)
+	ctx.On("Checkin", mock.Anything).Return(nil)
+
+	address, published := fakeNATSServer(t, "PONG\r\n")
+	err := (&NATSJob{}).Run(ctx, map[string]interface{}{
+		"payload": `{"key": "value"}`,
+		"address": address,
+		"subject": "harbor.events",
+	})
+	require.Nil(t, err)
+	msg := <-published
+	assert.Contains(t, msg, `"auth_token":"token"`)
+	assert.Contains(t, msg, `harbor.events {"key": "value"}`)
+
+	address, _ = fakeNATSServer(t, "-ERR 'Authorization Violation'\r\n")
+	err = (&NATSJob{}).Run(ctx, map[string]interface{}{
+		"payload": `{"key": "value"}`,
+		"address": address,
+		"subject": "harbor.events",
+	})
+	assert.NotNil(t, err)
+}
@@ -79,7 +79,7 @@ func (sj *SlackJob) Run(ctx job.Context, params job.Parameters) error {
 		return err
 	}
 
-	err := sj.execute(params)
+	err := sj.execute(ctx, params)
 	if err != nil {
 		sj.logger.Error(err)
 	}
@@ -105,7 +105,7 @@ func (sj *SlackJob) init(ctx job.Context, params map[string]interface{}) error {
 }
 
 // execute slack job
-func (sj *SlackJob) execute(params map[string]interface{}) error {
+func (sj *SlackJob) execute(ctx job.Context, params map[string]interface{}) error {
 	payload := params["payload"].(string)
 	address := params["address"].(string)
 
@@ -115,13 +115,13 @@ func (sj *SlackJob) execute(params map[string]interface{}) error {
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := sj.client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("slack job(target: %s) response code is %d", address, resp.StatusCode)
+	a, resp, err := send(sj.client, req)
+	if err == nil && !a.Success {
+		err = fmt.Errorf("slack job(target: %s) response code is %d", address, resp.StatusCode)
+		a.Error = err.Error()
 	}
-	return nil
+	a.MaxFails = sj.MaxFails()
+	checkIn(ctx, a)
+
+	return err
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/goharbor/harbor/src/jobservice/job"
 	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
 )
 
 func TestSlackJobMaxFails(t *testing.T) {
@@ -50,6 +51,7 @@ func TestSlackJobRun(t *testing.T) {
 	logger := &mockjobservice.MockJobLogger{}
 
 	ctx.On("GetLogger").Return(logger)
+	ctx.On("Checkin", mock.Anything).Return(nil)
 
 	rep := &SlackJob{}
 
@@ -2,15 +2,26 @@ package notification
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/logger"
 )
 
+const (
+	// SignatureHeader is the header of the HMAC-SHA256 signature of the payload
+	SignatureHeader = "X-Harbor-Signature"
+	// TimestampHeader is the header of the unix timestamp when the payload is signed
+	TimestampHeader = "X-Harbor-Timestamp"
+)
+
 // Max retry has the same meaning as max fails.
 const maxFails = "JOBSERVICE_WEBHOOK_JOB_MAX_RETRY"
 
@@ -94,16 +105,44 @@ func (wj *WebhookJob) execute(ctx job.Context, params map[string]interface{}) er
 	if v, ok := params["auth_header"]; ok && len(v.(string)) > 0 {
 		req.Header.Set("Authorization", v.(string))
 	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := wj.client.Do(req)
-	if err != nil {
-		return err
+	// the custom headers defined in the target of the notification policy
+	if v, ok := params["headers"]; ok && v != nil {
+		headers, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid headers of the webhook job: %v", v)
+		}
+		for key, value := range headers {
+			req.Header.Set(key, fmt.Sprintf("%v", value))
+		}
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("webhook job(target: %s) response code is %d", address, resp.StatusCode)
+	contentType := "application/json"
+	if v, ok := params["content_type"]; ok && len(v.(string)) > 0 {
+		contentType = v.(string)
+	}
+	req.Header.Set("Content-Type", contentType)
+	// sign the payload with the secret of the policy, the timestamp is signed as well to prevent replay
+	if v, ok := params["secret"]; ok && len(v.(string)) > 0 {
+		timestamp := time.Now().Unix()
+		req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(SignatureHeader, "sha256="+Sign(v.(string), timestamp, []byte(payload)))
 	}
 
-	return nil
+	a, resp, err := send(wj.client, req)
+	if err == nil && !a.Success {
+		err = fmt.Errorf("webhook job(target: %s) response code is %d", address, resp.StatusCode)
+		a.Error = err.Error()
+	}
+	a.MaxFails = wj.MaxFails()
+	checkIn(ctx, a)
+
+	return err
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of "<timestamp>.<payload>" with the secret,
+// the receivers can authenticate the events by calculating the same signature
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
 }
@@ -4,11 +4,13 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
 )
 
 func TestMaxFails(t *testing.T) {
@@ -43,6 +45,7 @@ func TestRun(t *testing.T) {
 	logger := &mockjobservice.MockJobLogger{}
 
 	ctx.On("GetLogger").Return(logger)
+	ctx.On("Checkin", mock.Anything).Return(nil)
 
 	rep := &WebhookJob{}
 
@@ -55,6 +58,7 @@ func TestRun(t *testing.T) {
 			assert.Equal(t, http.MethodPost, r.Method)
 			// test request header
 			assert.Equal(t, "auth_test", r.Header.Get("Authorization"))
+			assert.Equal(t, "harbor", r.Header.Get("X-Custom-Source"))
 			// test request body
 			assert.Equal(t, string(body), `{"key": "value"}`)
 		}))
@@ -64,6 +68,7 @@ func TestRun(t *testing.T) {
 		"payload":          `{"key": "value"}`,
 		"address":          ts.URL,
 		"auth_header":      "auth_test",
+		"headers":          map[string]interface{}{"X-Custom-Source": "harbor"},
 	}
 	// test correct webhook response
 	assert.Nil(t, rep.Run(ctx, params))
@@ -82,3 +87,29 @@ func TestRun(t *testing.T) {
 	// test incorrect webhook response
 	assert.NotNil(t, rep.Run(ctx, paramsWrong))
 }
+
+func TestSign(t *testing.T) {
+	ctx := &mockjobservice.MockJobContext{}
+	logger := &mockjobservice.MockJobLogger{}
+	ctx.On("GetLogger").Return(logger)
+	ctx.On("Checkin", mock.Anything).Return(nil)
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			timestamp, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+			assert.Nil(t, err)
+			assert.Equal(t, "sha256="+Sign("secret", timestamp, body), r.Header.Get(SignatureHeader))
+		}))
+	defer ts.Close()
+
+	rep := &WebhookJob{}
+	assert.Nil(t, rep.Run(ctx, map[string]interface{}{
+		"payload": `{"key": "value"}`,
+		"address": ts.URL,
+		"secret":  "secret",
+	}))
+
+	// the signature changes with the timestamp
+	assert.NotEqual(t, Sign("secret", 1, []byte("payload")), Sign("secret", 2, []byte("payload")))
+}
@@ -0,0 +1,116 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purgeupload
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/registryctl"
+	"github.com/goharbor/harbor/src/controller/purgeupload"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/registryctl/client"
+)
+
+// Job purges the stale uploads, e.g. the abandoned chunked pushes, from the storage via registryctl
+type Job struct {
+	olderThanHours    int
+	dryRun            bool
+	registryCtlClient client.Client
+}
+
+// MaxFails is implementation of same method in Interface.
+func (j *Job) MaxFails() uint {
+	return 1
+}
+
+// MaxCurrency is implementation of same method in Interface.
+func (j *Job) MaxCurrency() uint {
+	return 1
+}
+
+// ShouldRetry ...
+func (j *Job) ShouldRetry() bool {
+	return false
+}
+
+// Validate is implementation of same method in Interface.
+func (j *Job) Validate(params job.Parameters) error {
+	return nil
+}
+
+func (j *Job) parseParams(params job.Parameters) {
+	j.olderThanHours = common.DefaultPurgeUploadOlderThanHours
+	if hours, exist := params[common.PurgeUploadOlderThanHours]; exist {
+		if h, ok := hours.(int); ok && h > 0 {
+			j.olderThanHours = h
+		} else if h, ok := hours.(float64); ok && h > 0 {
+			j.olderThanHours = int(h)
+		}
+	}
+	if dryRun, exist := params[common.PurgeUploadDryRun]; exist {
+		if dryRun, ok := dryRun.(bool); ok {
+			j.dryRun = dryRun
+		}
+	}
+	// UT will use the mock client
+	if os.Getenv("UTTEST") != "true" {
+		registryctl.Init()
+		j.registryCtlClient = registryctl.RegistryCtlClient
+	}
+}
+
+// Run the upload purge logic here.
+func (j *Job) Run(ctx job.Context, params job.Parameters) error {
+	logger := ctx.GetLogger()
+	logger.Info("Upload purge job start")
+	logger.Infof("job parameters %+v", params)
+	if opCmd, exit := ctx.OPCommand(); exit && opCmd.IsStop() {
+		logger.Info("received the stop signal, stop the upload purge job")
+		return nil
+	}
+	j.parseParams(params)
+
+	result, err := j.registryCtlClient.PurgeUploads(j.olderThanHours, j.dryRun)
+	if err != nil {
+		logger.Errorf("failed to purge the uploads older than %d hours, error: %v", j.olderThanHours, err)
+		return err
+	}
+	for _, e := range result.Errors {
+		logger.Warningf("failed to purge the upload: %s", e)
+	}
+	prefix := ""
+	if j.dryRun {
+		prefix = "[DRYRUN]"
+	}
+	for _, p := range result.Purged {
+		logger.Infof("%spurged the upload %s", prefix, p)
+	}
+	logger.Infof("%sPurged %d uploads older than %d hours, %d bytes reclaimed",
+		prefix, len(result.Purged), j.olderThanHours, result.ReclaimedBytes)
+
+	data, err := json.Marshal(&purgeupload.Result{
+		Purged:         len(result.Purged),
+		ReclaimedBytes: result.ReclaimedBytes,
+	})
+	if err != nil {
+		return err
+	}
+	if err = ctx.Checkin(string(data)); err != nil {
+		logger.Warningf("failed to check in the result of the upload purge: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package purgeupload
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/registryctl/storage"
+	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/registryctl"
+)
+
+type purgeUploadTestSuite struct {
+	suite.Suite
+}
+
+func (p *purgeUploadTestSuite) SetupSuite() {
+	p.T().Setenv("UTTEST", "true")
+}
+
+func (p *purgeUploadTestSuite) TestParseParams() {
+	j := &Job{}
+	j.parseParams(job.Parameters{common.PurgeUploadOlderThanHours: float64(24), common.PurgeUploadDryRun: true})
+	p.Equal(24, j.olderThanHours)
+	p.True(j.dryRun)
+
+	j = &Job{}
+	j.parseParams(job.Parameters{})
+	p.Equal(common.DefaultPurgeUploadOlderThanHours, j.olderThanHours)
+	p.False(j.dryRun)
+}
+
+func (p *purgeUploadTestSuite) TestRun() {
+	client := &registryctl.Mockclient{}
+	client.On("PurgeUploads", 24, false).Return(&storage.PurgeUploadsResult{
+		Purged:         []string{"/docker/registry/v2/repositories/library/hello-world/_uploads/1"},
+		ReclaimedBytes: 1024,
+	}, nil)
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.NilCommand, false)
+	ctx.On("Checkin", `{"purged":1,"reclaimed_bytes":1024}`).Return(nil)
+
+	j := &Job{registryCtlClient: client}
+	p.Require().Nil(j.Run(ctx, job.Parameters{common.PurgeUploadOlderThanHours: 24}))
+	ctx.AssertCalled(p.T(), "Checkin", `{"purged":1,"reclaimed_bytes":1024}`)
+}
+
+func (p *purgeUploadTestSuite) TestRunFailure() {
+	client := &registryctl.Mockclient{}
+	client.On("PurgeUploads", mock.Anything, mock.Anything).Return(nil, errors.New("failed"))
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.NilCommand, false)
+
+	j := &Job{registryCtlClient: client}
+	p.Require().NotNil(j.Run(ctx, job.Parameters{}))
+	ctx.AssertNotCalled(p.T(), "Checkin", mock.Anything)
+}
+
+func TestPurgeUploadTestSuite(t *testing.T) {
+	suite.Run(t, &purgeUploadTestSuite{})
+}
@@ -15,18 +15,27 @@
 package replication
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/controller/replication/transfer"
 	// import chart transfer
 	_ "github.com/goharbor/harbor/src/controller/replication/transfer/chart"
 	// import image transfer
 	_ "github.com/goharbor/harbor/src/controller/replication/transfer/image"
 	"github.com/goharbor/harbor/src/jobservice/job"
+	tracelib "github.com/goharbor/harbor/src/lib/trace"
 	"github.com/goharbor/harbor/src/pkg/reg/model"
 )
 
+const tracerName = "goharbor/harbor/src/jobservice/job/impl/replication"
+
 // Replication implements the job interface
 type Replication struct{}
 
@@ -52,14 +61,42 @@ func (r *Replication) Validate(params job.Parameters) error {
 
 // Run gets the corresponding transfer according to the resource type
 // and calls its function to do the real work
-func (r *Replication) Run(ctx job.Context, params job.Parameters) error {
+func (r *Replication) Run(ctx job.Context, params job.Parameters) (err error) {
 	logger := ctx.GetLogger()
 
+	traceCtx := ctx.SystemContext()
+	if traceCtx == nil {
+		traceCtx = context.Background()
+	}
+	traceCtx, span := tracelib.StartTrace(traceCtx, tracerName, "replicate")
+	defer func() {
+		if err != nil {
+			tracelib.RecordError(span, err, "replication failed")
+		}
+		span.End()
+	}()
+
 	src, dst, opts, err := parseParams(params)
 	if err != nil {
 		logger.Errorf("failed to parse parameters: %v", err)
 		return err
 	}
+	span.SetAttributes(attribute.Key("resourceType").String(string(src.Type)))
+	if src.Registry != nil && dst.Registry != nil {
+		span.SetAttributes(
+			attribute.Key("srcRegistryType").String(string(src.Registry.Type)),
+			attribute.Key("dstRegistryType").String(string(dst.Registry.Type)),
+		)
+	}
+	if src.Metadata != nil && src.Metadata.Repository != nil {
+		span.SetAttributes(attribute.Key("repository").String(src.Metadata.Repository.Name))
+	}
+	// the spans of the transfer are the children of the replication span
+	opts.Context = traceCtx
+	// the limit of the scheduler is refreshed by each job as it can be updated at runtime
+	if limit, ok := getMaxConcurrentTransfers(ctx); ok {
+		transfer.DefaultScheduler.SetLimit(limit)
+	}
 
 	factory, err := transfer.GetFactory(src.Type)
 	if err != nil {
@@ -83,6 +120,20 @@ func (r *Replication) Run(ctx job.Context, params job.Parameters) error {
 	return trans.Transfer(src, dst, opts)
 }
 
+// getMaxConcurrentTransfers returns the max in-flight blob transfers configured, the config items are loaded
+// into the job context with their own types
+func getMaxConcurrentTransfers(ctx job.Context) (int, bool) {
+	v, ok := ctx.Get(common.ReplicationMaxConcurrentTransfers)
+	if !ok || v == nil {
+		return 0, false
+	}
+	limit, err := strconv.Atoi(utils.GetStrValueOfAnyType(v))
+	if err != nil {
+		return 0, false
+	}
+	return limit, true
+}
+
 func parseParams(params map[string]interface{}) (*model.Resource, *model.Resource, *transfer.Options, error) {
 	src := &model.Resource{}
 	if err := parseParam(params, "src_resource", src); err != nil {
@@ -114,6 +165,21 @@ func parseParams(params map[string]interface{}) (*model.Resource, *model.Resourc
 		}
 	}
 
+	var policyID int64
+	value, exist = params["policy_id"]
+	if exist {
+		switch id := value.(type) {
+		case int64:
+			policyID = id
+		case int:
+			policyID = int64(id)
+		case float64:
+			policyID = int64(id)
+		default:
+			return nil, nil, nil, fmt.Errorf("the value of policy_id isn't integer (%T)", value)
+		}
+	}
+
 	var copyByChunk bool
 	value, exist = params["copy_by_chunk"]
 	if exist {
@@ -122,9 +188,28 @@ func parseParams(params map[string]interface{}) (*model.Resource, *model.Resourc
 		}
 	}
 
+	var copyByStorage bool
+	value, exist = params["copy_by_storage"]
+	if exist {
+		if boolVal, ok := value.(bool); ok {
+			copyByStorage = boolVal
+		}
+	}
+
+	var foreignLayerMode string
+	value, exist = params["foreign_layer_mode"]
+	if exist {
+		if strVal, ok := value.(string); ok {
+			foreignLayerMode = strVal
+		}
+	}
+
 	opts := transfer.NewOptions(
+		transfer.WithPolicyID(policyID),
 		transfer.WithSpeed(speed),
 		transfer.WithCopyByChunk(copyByChunk),
+		transfer.WithCopyByStorage(copyByStorage),
+		transfer.WithForeignLayerMode(foreignLayerMode),
 	)
 	return src, dst, opts, nil
 }
@@ -53,6 +53,7 @@ func TestParseParams(t *testing.T) {
 	params := map[string]interface{}{
 		"src_resource":  `{"type":"chart"}`,
 		"dst_resource":  `{"type":"chart"}`,
+		"policy_id":     float64(1),
 		"speed":         1024,
 		"copy_by_chunk": true,
 	}
@@ -62,6 +63,7 @@ func TestParseParams(t *testing.T) {
 	assert.Equal(t, "chart", string(dst.Type))
 	assert.Equal(t, int32(1024), opts.Speed)
 	assert.True(t, opts.CopyByChunk)
+	assert.Equal(t, int64(1), opts.PolicyID)
 }
 
 func TestMaxFails(t *testing.T) {
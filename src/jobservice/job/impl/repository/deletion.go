@@ -0,0 +1,121 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"encoding/json"
+
+	"github.com/goharbor/harbor/src/controller/repository"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+var errDeletionStop = errors.New("stopped")
+
+// Deletion is the job deleting the repository in background, the artifacts under the repository
+// are deleted in batches and the progress is checked in after each batch
+type Deletion struct {
+	repoCtl repository.Controller
+}
+
+// MaxFails is implementation of same method in Interface.
+func (d *Deletion) MaxFails() uint {
+	return 1
+}
+
+// MaxCurrency is implementation of same method in Interface.
+func (d *Deletion) MaxCurrency() uint {
+	return 0
+}
+
+// ShouldRetry ...
+func (d *Deletion) ShouldRetry() bool {
+	return false
+}
+
+// Validate is implementation of same method in Interface.
+func (d *Deletion) Validate(params job.Parameters) error {
+	if _, err := parseInt(params, repository.DeletionParamRepositoryID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Run the deletion logic here.
+func (d *Deletion) Run(ctx job.Context, params job.Parameters) error {
+	logger := ctx.GetLogger()
+	if d.repoCtl == nil {
+		d.repoCtl = repository.Ctl
+	}
+
+	id, err := parseInt(params, repository.DeletionParamRepositoryID)
+	if err != nil {
+		return err
+	}
+	batchSize, err := parseInt(params, repository.DeletionParamBatchSize)
+	if err != nil {
+		batchSize = repository.DeletionBatchSize
+	}
+
+	logger.Infof("start to delete the repository %d in batches of %d artifacts", id, batchSize)
+	err = d.repoCtl.DeleteInBatches(ctx.SystemContext(), id, int(batchSize), func(progress *repository.DeletionProgress) error {
+		// check in before handling the stop signal, the deleted artifacts carried by the check in
+		// are needed by core to fire the deletion events
+		data, err := json.Marshal(progress)
+		if err != nil {
+			return err
+		}
+		if err = ctx.Checkin(string(data)); err != nil {
+			logger.Warningf("failed to check in the progress of the repository deletion: %v", err)
+		}
+		logger.Infof("%d/%d artifacts deleted", progress.Deleted, progress.Total)
+		if opCmd, exit := ctx.OPCommand(); exit && opCmd.IsStop() {
+			return errDeletionStop
+		}
+		return nil
+	})
+	if err != nil {
+		if err == errDeletionStop {
+			logger.Info("received the stop signal, stop the repository deletion job")
+			return nil
+		}
+		if errors.IsNotFoundErr(err) {
+			logger.Infof("the repository %d has already been deleted", id)
+			return nil
+		}
+		logger.Errorf("failed to delete the repository %d: %v", id, err)
+		return err
+	}
+
+	logger.Infof("the repository %d is deleted", id)
+	return nil
+}
+
+func parseInt(params job.Parameters, key string) (int64, error) {
+	value, exist := params[key]
+	if !exist {
+		return 0, errors.Errorf("missing the parameter %s", key)
+	}
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		return int64(v), nil
+	default:
+		return 0, errors.Errorf("invalid type of the parameter %s: %T", key, value)
+	}
+}
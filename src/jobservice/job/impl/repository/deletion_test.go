@@ -0,0 +1,98 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/repository"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	repotesting "github.com/goharbor/harbor/src/testing/controller/repository"
+	mockjobservice "github.com/goharbor/harbor/src/testing/jobservice"
+	"github.com/goharbor/harbor/src/testing/mock"
+)
+
+type deletionTestSuite struct {
+	suite.Suite
+	repoCtl *repotesting.Controller
+	job     *Deletion
+}
+
+func (d *deletionTestSuite) SetupTest() {
+	d.repoCtl = &repotesting.Controller{}
+	d.job = &Deletion{repoCtl: d.repoCtl}
+}
+
+func (d *deletionTestSuite) TestValidate() {
+	d.NoError(d.job.Validate(job.Parameters{"repository_id": float64(1)}))
+	d.Error(d.job.Validate(job.Parameters{}))
+	d.Error(d.job.Validate(job.Parameters{"repository_id": "1"}))
+}
+
+func (d *deletionTestSuite) TestRun() {
+	d.repoCtl.On("DeleteInBatches", mock.Anything, int64(1), 10, mock.Anything).Return(func(ctx context.Context, id int64, batchSize int, progress func(*repository.DeletionProgress) error) error {
+		if err := progress(&repository.DeletionProgress{Total: 15, Deleted: 10}); err != nil {
+			return err
+		}
+		return progress(&repository.DeletionProgress{Total: 15, Deleted: 15})
+	})
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.OPCommand(""), false)
+	ctx.On("Checkin", `{"total":15,"deleted":10}`).Return(nil)
+	ctx.On("Checkin", `{"total":15,"deleted":15}`).Return(nil)
+
+	err := d.job.Run(ctx, job.Parameters{"repository_id": float64(1), "batch_size": float64(10)})
+	d.Require().NoError(err)
+	ctx.AssertExpectations(d.T())
+}
+
+func (d *deletionTestSuite) TestRunStopped() {
+	d.repoCtl.On("DeleteInBatches", mock.Anything, int64(1), 100, mock.Anything).Return(func(ctx context.Context, id int64, batchSize int, progress func(*repository.DeletionProgress) error) error {
+		if err := progress(&repository.DeletionProgress{
+			Total:     200,
+			Deleted:   100,
+			Artifacts: []*repository.DeletedArtifact{{Artifact: &artifact.Artifact{ID: 1}}},
+		}); err != nil {
+			return err
+		}
+		return progress(&repository.DeletionProgress{Total: 200, Deleted: 200})
+	})
+	ctx := &mockjobservice.MockJobContext{}
+	ctx.On("OPCommand").Return(job.StopCommand, true)
+	ctx.On("Checkin", mock.Anything).Return(nil)
+
+	err := d.job.Run(ctx, job.Parameters{"repository_id": float64(1)})
+	d.Require().NoError(err)
+	// the deleted artifacts are checked in before stopping
+	ctx.AssertNumberOfCalls(d.T(), "Checkin", 1)
+	d.Contains(ctx.Calls[0].Arguments.String(0), `"artifacts":[{"artifact":{"id":1`)
+}
+
+func (d *deletionTestSuite) TestRunFailure() {
+	d.repoCtl.On("DeleteInBatches", mock.Anything, int64(1), 100, mock.Anything).Return(errors.New("failed"))
+	ctx := &mockjobservice.MockJobContext{}
+
+	err := d.job.Run(ctx, job.Parameters{"repository_id": float64(1)})
+	d.Require().Error(err)
+}
+
+func TestDeletionTestSuite(t *testing.T) {
+	suite.Run(t, &deletionTestSuite{})
+}
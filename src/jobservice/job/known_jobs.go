@@ -30,6 +30,10 @@ const (
 	WebhookJob = "WEBHOOK"
 	// SlackJob : the name of the slack job in job service
 	SlackJob = "SLACK"
+	// NATSJob : the name of the job publishing the notification to the NATS subject in job service
+	NATSJob = "NATS"
+	// EmailJob : the name of the job sending the notification email in job service
+	EmailJob = "EMAIL"
 	// Retention : the name of the retention job
 	Retention = "RETENTION"
 	// P2PPreheat : the name of the P2P preheat job
@@ -40,4 +44,14 @@ const (
 	SystemArtifactCleanup = "SYSTEM_ARTIFACT_CLEANUP"
 	// ScanDataExport : the name of the scan data export job
 	ScanDataExport = "SCAN_DATA_EXPORT"
+	// RepositoryDeletion : the name of the job deleting the repository in background
+	RepositoryDeletion = "REPOSITORY_DELETION"
+	// PurgeUpload : the name of the job purging the stale uploads from the storage
+	PurgeUpload = "PURGE_UPLOAD"
+	// ImageImport : the name of the job importing the images from the uploaded archive
+	ImageImport = "IMAGE_IMPORT"
+	// ImageExport : the name of the job exporting the artifacts into the OCI layout archive
+	ImageExport = "IMAGE_EXPORT"
+	// MetadataBackup : the name of the job creating the manifest of the metadata backup and holding the read-only mode
+	MetadataBackup = "METADATA_BACKUP"
 )
@@ -45,6 +45,14 @@ type Metadata struct {
 	ScheduleDelay uint64 `json:"schedule_delay,omitempty"`
 	Cron          string `json:"cron_spec,omitempty"`
 	IsUnique      bool   `json:"unique"`
+	// The priority class of the job, see PriorityClassHigh/PriorityClassNormal/PriorityClassLow
+	PriorityClass string `json:"priority_class,omitempty"`
+	// The jobs with the same key share the workers fairly with the jobs of the other keys, e.g. the project of the job
+	FairShareKey string `json:"fair_share_key,omitempty"`
+	// The trace context of the request launching the job, the spans of the job are the children of it
+	TraceContext map[string]string `json:"trace_context,omitempty"`
+	// The ID of the request launching the job, it's logged by the job for tracking
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // Stats keeps the result of job launching.
@@ -73,6 +81,19 @@ type StatsInfo struct {
 	Parameters    Parameters `json:"parameters,omitempty"`
 	Revision      int64      `json:"revision,omitempty"` // For differentiating the each retry of the same job
 	HookAck       *ACK       `json:"ack,omitempty"`
+	PriorityClass string     `json:"priority_class,omitempty"`
+	FairShareKey  string     `json:"fair_share_key,omitempty"`
+	Attempts      []*Attempt `json:"attempts,omitempty"` // The history of the attempts to run the job
+}
+
+// Attempt keeps the info of one attempt to run the job
+type Attempt struct {
+	Attempt    int64  `json:"attempt"`
+	Status     string `json:"status"`
+	StartTime  int64  `json:"start_time"`
+	EndTime    int64  `json:"end_time,omitempty"`
+	Error      string `json:"error,omitempty"`
+	ErrorClass string `json:"error_class,omitempty"`
 }
 
 // ACK is the acknowledge of hook event
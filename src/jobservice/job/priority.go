@@ -16,6 +16,15 @@ package job
 
 const (
 	defaultPriority uint = 1000
+
+	// PriorityClassHigh is the priority class of the job which is put at the head of the queue
+	// and isn't limited by the fair share of the workers
+	PriorityClassHigh = "high"
+	// PriorityClassNormal is the default priority class of the job
+	PriorityClassNormal = "normal"
+	// PriorityClassLow is the priority class of the job which can only use the fair share of the workers
+	// no matter the other jobs are running or not, e.g. the bulk operations triggered by the system
+	PriorityClassLow = "low"
 )
 
 // PrioritySampler define the job priority generation method
@@ -56,3 +65,13 @@ func (ps *defaultSampler) For(job string) uint {
 func Priority() PrioritySampler {
 	return &defaultSampler{}
 }
+
+// IsValidPriorityClass checks if the priority class is supported, empty means the normal class
+func IsValidPriorityClass(class string) bool {
+	switch class {
+	case "", PriorityClassHigh, PriorityClassNormal, PriorityClassLow:
+		return true
+	default:
+		return false
+	}
+}
@@ -51,3 +51,12 @@ func (suite *PrioritySamplerSuite) Test() {
 	p4 := suite.sampler.For(SlackJob)
 	suite.Equal((uint)(1), p4, "Job priority for %s", SlackJob)
 }
+
+// TestIsValidPriorityClass for the priority classes
+func (suite *PrioritySamplerSuite) TestIsValidPriorityClass() {
+	suite.True(IsValidPriorityClass(""))
+	suite.True(IsValidPriorityClass(PriorityClassHigh))
+	suite.True(IsValidPriorityClass(PriorityClassNormal))
+	suite.True(IsValidPriorityClass(PriorityClassLow))
+	suite.False(IsValidPriorityClass("urgent"))
+}
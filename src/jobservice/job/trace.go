@@ -0,0 +1,65 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+const (
+	// TraceContextKey is the reserved parameter key to carry the trace context of the request launching the job
+	TraceContextKey = "_trace_context_"
+	// RequestIDKey is the reserved parameter key to carry the ID of the request launching the job
+	RequestIDKey = "_request_id_"
+)
+
+// PopTraceContext removes the trace context from the job parameters and returns it.
+// The trace context may be decoded as a map of interface values after being queued.
+func PopTraceContext(params Parameters) map[string]string {
+	if params == nil {
+		return nil
+	}
+	v, ok := params[TraceContextKey]
+	if !ok {
+		return nil
+	}
+	delete(params, TraceContextKey)
+
+	carrier := map[string]string{}
+	switch c := v.(type) {
+	case map[string]string:
+		for k, val := range c {
+			carrier[k] = val
+		}
+	case map[string]interface{}:
+		for k, val := range c {
+			if s, ok := val.(string); ok {
+				carrier[k] = s
+			}
+		}
+	}
+	return carrier
+}
+
+// PopRequestID removes the request ID from the job parameters and returns it
+func PopRequestID(params Parameters) string {
+	if params == nil {
+		return ""
+	}
+	v, ok := params[RequestIDKey]
+	if !ok {
+		return ""
+	}
+	delete(params, RequestIDKey)
+
+	rid, _ := v.(string)
+	return rid
+}
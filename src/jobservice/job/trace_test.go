@@ -0,0 +1,51 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPopTraceContext(t *testing.T) {
+	assert.Nil(t, PopTraceContext(nil))
+
+	params := Parameters{"image": "library/hello-world"}
+	assert.Nil(t, PopTraceContext(params))
+	assert.Len(t, params, 1)
+
+	traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	params[TraceContextKey] = map[string]string{"traceparent": traceparent}
+	assert.Equal(t, map[string]string{"traceparent": traceparent}, PopTraceContext(params))
+	_, exist := params[TraceContextKey]
+	assert.False(t, exist)
+
+	// decoded from the queue
+	params[TraceContextKey] = map[string]interface{}{"traceparent": traceparent, "invalid": 1}
+	assert.Equal(t, map[string]string{"traceparent": traceparent}, PopTraceContext(params))
+	assert.Len(t, params, 1)
+}
+
+func TestPopRequestID(t *testing.T) {
+	assert.Empty(t, PopRequestID(nil))
+
+	params := Parameters{"image": "library/hello-world"}
+	assert.Empty(t, PopRequestID(params))
+
+	params[RequestIDKey] = "852803be-e5fe-499b-bbea-c9e5b5f43916"
+	assert.Equal(t, "852803be-e5fe-499b-bbea-c9e5b5f43916", PopRequestID(params))
+	assert.Len(t, params, 1)
+}
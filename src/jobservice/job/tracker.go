@@ -33,6 +33,8 @@ import (
 const (
 	// Check in data placeholder for saving data space
 	redundantCheckInData = "[REDUNDANT]"
+	// The max count of the attempts kept in the attempt history
+	maxAttemptHistory = 20
 )
 
 // Tracker is designed to track the life cycle of the job described by the stats
@@ -101,6 +103,14 @@ type Tracker interface {
 
 	// Fire status hook to report the current status
 	FireHook() error
+
+	// Record the attempt to run the job in the attempt history
+	//
+	// attempt *Attempt : the attempt, the one with the same number is replaced
+	//
+	// Returns:
+	//  error if record failed
+	RecordAttempt(attempt *Attempt) error
 }
 
 // basicTracker implements Tracker interface based on redis
@@ -331,6 +341,14 @@ func (bt *basicTracker) Save() (err error) {
 		args = append(args, "upstream_job_id", stats.Info.UpstreamJobID)
 	}
 
+	if !utils.IsEmptyStr(stats.Info.PriorityClass) {
+		args = append(args, "priority_class", stats.Info.PriorityClass)
+	}
+
+	if !utils.IsEmptyStr(stats.Info.FairShareKey) {
+		args = append(args, "fair_share_key", stats.Info.FairShareKey)
+	}
+
 	if len(stats.Info.Parameters) > 0 {
 		if bytes, err := json.Marshal(&stats.Info.Parameters); err == nil {
 			args = append(args, "parameters", string(bytes))
@@ -426,6 +444,60 @@ func (bt *basicTracker) Reset() error {
 	return nil
 }
 
+// RecordAttempt records the attempt to run the job in the attempt history
+func (bt *basicTracker) RecordAttempt(attempt *Attempt) error {
+	if attempt == nil {
+		return errors.New("nil attempt to record")
+	}
+
+	conn := bt.pool.Get()
+	defer func() {
+		closeConn(conn)
+	}()
+
+	key := rds.KeyJobStats(bt.namespace, bt.jobID)
+	attempts := make([]*Attempt, 0)
+	raw, err := redis.String(conn.Do("HGET", key, "attempts"))
+	if err != nil && !errors.Is(err, redis.ErrNil) {
+		return errors.Wrap(err, "record attempt")
+	}
+	if len(raw) > 0 {
+		if err := json.Unmarshal([]byte(raw), &attempts); err != nil {
+			logger.Error(errors.Wrap(err, "record attempt: tracker"))
+		}
+	}
+
+	replaced := false
+	for i, a := range attempts {
+		if a.Attempt == attempt.Attempt {
+			attempts[i] = attempt
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		attempts = append(attempts, attempt)
+	}
+	// Only keep the latest attempts
+	if len(attempts) > maxAttemptHistory {
+		attempts = attempts[len(attempts)-maxAttemptHistory:]
+	}
+
+	data, err := json.Marshal(attempts)
+	if err != nil {
+		return errors.Wrap(err, "record attempt")
+	}
+	if _, err := conn.Do("HSET", key, "attempts", string(data)); err != nil {
+		return errors.Wrap(err, "record attempt")
+	}
+
+	if bt.jobStats != nil {
+		bt.jobStats.Info.Attempts = attempts
+	}
+
+	return nil
+}
+
 // FireHook fires status hook event to report current status
 func (bt *basicTracker) FireHook() error {
 	return bt.fireHookEvent(
@@ -583,6 +655,17 @@ func (bt *basicTracker) retrieve() error {
 			res.Info.UpstreamJobID = value
 		case "numeric_policy_id":
 			res.Info.NumericPID = parseInt64(value)
+		case "priority_class":
+			res.Info.PriorityClass = value
+		case "fair_share_key":
+			res.Info.FairShareKey = value
+		case "attempts":
+			attempts := make([]*Attempt, 0)
+			if err := json.Unmarshal([]byte(value), &attempts); err == nil {
+				res.Info.Attempts = attempts
+			} else {
+				logger.Error(errors.Wrap(err, "retrieve: tracker"))
+			}
 		case "parameters":
 			params := make(Parameters)
 			if err := json.Unmarshal([]byte(value), &params); err == nil {
@@ -63,11 +63,13 @@ func (suite *TrackerTestSuite) TestTracker() {
 	jobID := utils.MakeIdentifier()
 	mockJobStats := &Stats{
 		Info: &StatsInfo{
-			JobID:    jobID,
-			Status:   SuccessStatus.String(),
-			JobKind:  KindGeneric,
-			JobName:  SampleJob,
-			IsUnique: false,
+			JobID:         jobID,
+			Status:        SuccessStatus.String(),
+			JobKind:       KindGeneric,
+			JobName:       SampleJob,
+			IsUnique:      false,
+			PriorityClass: PriorityClassLow,
+			FairShareKey:  "project:1",
 		},
 	}
 
@@ -105,6 +107,20 @@ func (suite *TrackerTestSuite) TestTracker() {
 		"http://hook.url",
 		tracker.Job().Info.WebHookURL,
 	)
+	assert.Equal(suite.T(), PriorityClassLow, tracker.Job().Info.PriorityClass)
+	assert.Equal(suite.T(), "project:1", tracker.Job().Info.FairShareKey)
+
+	err = tracker.RecordAttempt(&Attempt{Attempt: 1, Status: RunningStatus.String(), StartTime: 1})
+	assert.Nil(suite.T(), err, "record attempt: nil error expected but got %s", err)
+	err = tracker.RecordAttempt(&Attempt{Attempt: 1, Status: ErrorStatus.String(), StartTime: 1, EndTime: 2, Error: "bad gateway"})
+	assert.Nil(suite.T(), err, "record attempt again: nil error expected but got %s", err)
+	err = tracker.RecordAttempt(&Attempt{Attempt: 2, Status: SuccessStatus.String(), StartTime: 3, EndTime: 4})
+	assert.Nil(suite.T(), err, "record attempt 2: nil error expected but got %s", err)
+	err = tracker.Load()
+	assert.Nil(suite.T(), err, "load: nil error expected but got %s", err)
+	require.Len(suite.T(), tracker.Job().Info.Attempts, 2)
+	assert.Equal(suite.T(), "bad gateway", tracker.Job().Info.Attempts[0].Error)
+	assert.Equal(suite.T(), SuccessStatus.String(), tracker.Job().Info.Attempts[1].Status)
 
 	err = tracker.Run()
 	assert.Error(suite.T(), err, "run: non nil error expected but got nil")
@@ -28,6 +28,7 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/errs"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/jobservice/store"
 	"github.com/goharbor/harbor/src/lib/errors"
 )
 
@@ -36,6 +37,8 @@ const (
 	longLoopInterval = 5 * time.Minute
 	// shortInterval is initial interval and be as based to give random buffer to loopInterval
 	shortInterval = 10
+	// statsRetention is how long the stats of the jobs in the final status are kept in the job store
+	statsRetention = 7 * 24 * time.Hour
 )
 
 // Controller is designed to control the life cycle of the job
@@ -58,6 +61,7 @@ type basicController struct {
 	callback  job.HookCallback
 	wg        *sync.WaitGroup
 	retryList *list.SyncList
+	store     store.Store
 }
 
 // NewController is the constructor of basic controller
@@ -69,6 +73,7 @@ func NewController(ctx *env.Context, ns string, pool *redis.Pool, callback job.H
 		callback:  callback,
 		wg:        ctx.WG,
 		retryList: list.New(),
+		store:     store.Default(),
 	}
 }
 
@@ -92,7 +97,10 @@ func (bc *basicController) New(stats *job.Stats) (job.Tracker, error) {
 		return nil, errors.Errorf("error occurred when creating job tracker: %s", err)
 	}
 
-	bt := job.NewBasicTrackerWithStats(bc.context, stats, bc.namespace, bc.pool, bc.callback, bc.retryList)
+	bt := newStoredTracker(bc.context,
+		job.NewBasicTrackerWithStats(bc.context, stats, bc.namespace, bc.pool, bc.callback, bc.retryList),
+		bc.store,
+	)
 	if err := bt.Save(); err != nil {
 		return nil, err
 	}
@@ -104,10 +112,29 @@ func (bc *basicController) New(stats *job.Stats) (job.Tracker, error) {
 func (bc *basicController) Track(jobID string) (job.Tracker, error) {
 	bt := job.NewBasicTrackerWithID(bc.context, jobID, bc.namespace, bc.pool, bc.callback, bc.retryList)
 	if err := bt.Load(); err != nil {
-		return nil, err
+		if !errs.IsObjectNotFoundError(err) {
+			return nil, err
+		}
+
+		// The stats may be lost with the redis, restore them from the job store
+		stats, er := bc.store.GetStats(bc.context, jobID)
+		if er != nil {
+			if !errors.IsNotFoundErr(er) {
+				logger.Errorf("Failed to get the stats of job %s from the job store: %v", jobID, er)
+			}
+
+			return nil, err
+		}
+
+		bt = job.NewBasicTrackerWithStats(bc.context, stats, bc.namespace, bc.pool, bc.callback, bc.retryList)
+		if err := bt.Save(); err != nil {
+			return nil, errors.Wrap(err, "restore job stats")
+		}
+
+		logger.Infof("Restored the stats of job %s from the job store", jobID)
 	}
 
-	return bt, nil
+	return newStoredTracker(bc.context, bt, bc.store), nil
 }
 
 // loopForRestoreDeadStatus is a loop to restore the dead states of jobs
@@ -136,6 +163,8 @@ func (bc *basicController) loopForRestoreDeadStatus() {
 
 			// Retry the items in the list
 			bc.retryLoop()
+			// Clear the stats of the outdated jobs in the job store
+			bc.purgeStats()
 		case <-bc.context.Done():
 			return // terminated
 		}
@@ -171,6 +200,19 @@ func (bc *basicController) retryLoop() {
 	})
 }
 
+// purgeStats purges the stats of the jobs which are done for a while from the job store
+func (bc *basicController) purgeStats() {
+	n, err := bc.store.PurgeStats(bc.context, time.Now().Add(-statsRetention))
+	if err != nil {
+		logger.Errorf("Failed to purge the stats of the outdated jobs from the job store: %v", err)
+		return
+	}
+
+	if n > 0 {
+		logger.Debugf("Purged the stats of %d outdated jobs from the job store", n)
+	}
+}
+
 // retry status update action
 func retry(conn redis.Conn, ns string, change job.SimpleStatusChange) error {
 	// Debug
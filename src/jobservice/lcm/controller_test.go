@@ -28,9 +28,35 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/common/utils"
 	"github.com/goharbor/harbor/src/jobservice/env"
 	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/store"
 	"github.com/goharbor/harbor/src/jobservice/tests"
+	"github.com/goharbor/harbor/src/lib/errors"
 )
 
+// fakeStore keeps the job stats in memory
+type fakeStore struct {
+	store.Store
+
+	stats map[string]*job.Stats
+}
+
+func (f *fakeStore) SaveStats(ctx context.Context, stats *job.Stats) error {
+	info := *stats.Info
+	f.stats[info.JobID] = &job.Stats{Info: &info}
+	return nil
+}
+
+func (f *fakeStore) GetStats(ctx context.Context, jobID string) (*job.Stats, error) {
+	if s, ok := f.stats[jobID]; ok {
+		return s, nil
+	}
+	return nil, errors.NotFoundError(nil)
+}
+
+func (f *fakeStore) PurgeStats(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
 // LcmControllerTestSuite tests functions of life cycle controller
 type LcmControllerTestSuite struct {
 	suite.Suite
@@ -93,6 +119,49 @@ func (suite *LcmControllerTestSuite) TestController() {
 	assert.Equal(suite.T(), job.RunningStatus.String(), t.Job().Info.Status)
 }
 
+// TestJobStore tests persisting and restoring the job stats with the job store
+func (suite *LcmControllerTestSuite) TestJobStore() {
+	bc := suite.ctl.(*basicController)
+	fs := &fakeStore{stats: make(map[string]*job.Stats)}
+	bc.store = fs
+	defer func() {
+		bc.store = store.Default()
+	}()
+
+	// The stats are persisted after the status changes
+	jobID := utils.MakeIdentifier()
+	suite.newsStats(jobID, time.Now().Unix())
+	require.Contains(suite.T(), fs.stats, jobID)
+	assert.Equal(suite.T(), job.PendingStatus.String(), fs.stats[jobID].Info.Status)
+
+	t, err := suite.ctl.Track(jobID)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), t.Run())
+	assert.Equal(suite.T(), job.RunningStatus.String(), fs.stats[jobID].Info.Status)
+
+	// The stats lost with the redis are restored from the job store
+	lostID := utils.MakeIdentifier()
+	fs.stats[lostID] = &job.Stats{
+		Info: &job.StatsInfo{
+			JobID:    lostID,
+			JobKind:  job.KindGeneric,
+			JobName:  job.SampleJob,
+			Status:   job.RunningStatus.String(),
+			Revision: time.Now().Unix(),
+		},
+	}
+	t, err = suite.ctl.Track(lostID)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), job.RunningStatus.String(), t.Job().Info.Status)
+
+	rt := job.NewBasicTrackerWithID(context.Background(), lostID, suite.namespace, suite.pool, nil, nil)
+	require.NoError(suite.T(), rt.Load())
+	assert.Equal(suite.T(), job.SampleJob, rt.Job().Info.JobName)
+
+	_, err = suite.ctl.Track(utils.MakeIdentifier())
+	assert.Error(suite.T(), err)
+}
+
 // newsStats create job stats
 func (suite *LcmControllerTestSuite) newsStats(jobID string, revision int64) {
 	stats := &job.Stats{
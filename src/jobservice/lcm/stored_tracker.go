@@ -0,0 +1,111 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lcm
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/jobservice/store"
+)
+
+// storedTracker persists the stats of the job into the job store after the stats are changed in the redis.
+// Failing to persist the stats doesn't block the job flow, the error is only logged.
+type storedTracker struct {
+	job.Tracker
+
+	context context.Context
+	store   store.Store
+}
+
+func newStoredTracker(ctx context.Context, tracker job.Tracker, s store.Store) job.Tracker {
+	return &storedTracker{
+		Tracker: tracker,
+		context: ctx,
+		store:   s,
+	}
+}
+
+// Save the stats into both the redis and the job store
+func (st *storedTracker) Save() error {
+	return st.persist(st.Tracker.Save())
+}
+
+// UpdateStatusWithRetry updates the status and persists the stats with the target status
+func (st *storedTracker) UpdateStatusWithRetry(targetStatus job.Status) error {
+	if err := st.Tracker.UpdateStatusWithRetry(targetStatus); err != nil {
+		return err
+	}
+
+	if stats := st.Tracker.Job(); stats != nil && stats.Info != nil {
+		info := *stats.Info
+		info.Status = targetStatus.String()
+		st.save(&job.Stats{Info: &info})
+	}
+
+	return nil
+}
+
+// Run the job and persist the stats
+func (st *storedTracker) Run() error {
+	return st.persist(st.Tracker.Run())
+}
+
+// Stop the job and persist the stats
+func (st *storedTracker) Stop() error {
+	return st.persist(st.Tracker.Stop())
+}
+
+// Fail the job and persist the stats
+func (st *storedTracker) Fail() error {
+	return st.persist(st.Tracker.Fail())
+}
+
+// Succeed the job and persist the stats
+func (st *storedTracker) Succeed() error {
+	return st.persist(st.Tracker.Succeed())
+}
+
+// Reset the job and persist the stats
+func (st *storedTracker) Reset() error {
+	return st.persist(st.Tracker.Reset())
+}
+
+// RecordAttempt records the attempt and persist the stats
+func (st *storedTracker) RecordAttempt(attempt *job.Attempt) error {
+	return st.persist(st.Tracker.RecordAttempt(attempt))
+}
+
+// persist the current stats if the previous operation succeeded
+func (st *storedTracker) persist(err error) error {
+	if err != nil {
+		return err
+	}
+
+	st.save(st.Tracker.Job())
+
+	return nil
+}
+
+func (st *storedTracker) save(stats *job.Stats) {
+	if stats == nil || stats.Info == nil {
+		return
+	}
+
+	if err := st.store.SaveStats(st.context, stats); err != nil {
+		logger.Errorf("Failed to persist the stats of job %s into the job store: %v", stats.Info.JobID, err)
+	}
+}
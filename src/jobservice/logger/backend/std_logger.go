@@ -26,7 +26,8 @@ func NewStdOutputLogger(level string, output string, depth int) *StdOutputLogger
 	if output == StdErr {
 		logStream = os.Stderr
 	}
-	backendLogger := log.New(logStream, log.NewTextFormatter(), logLevel, depth)
+	// the format of the std output follows the other components, e.g. JSON for the log collectors
+	backendLogger := log.New(logStream, log.NewFormatter(os.Getenv("LOG_FORMAT")), logLevel, depth)
 
 	return &StdOutputLogger{
 		backendLogger: backendLogger,
@@ -30,6 +30,7 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/logger"
 	"github.com/goharbor/harbor/src/jobservice/period"
+	"github.com/goharbor/harbor/src/jobservice/store"
 	"github.com/goharbor/harbor/src/lib/errors"
 )
 
@@ -101,6 +102,8 @@ type basicManager struct {
 	pool *redis.Pool
 	// go work client
 	client *work.Client
+	// the durable store of the job stats
+	store store.Store
 }
 
 // NewManager news a basic manager
@@ -110,6 +113,7 @@ func NewManager(ctx context.Context, ns string, pool *redis.Pool) Manager {
 		namespace: ns,
 		pool:      pool,
 		client:    work.NewClient(ns, pool),
+		store:     store.Default(),
 	}
 }
 
@@ -297,6 +301,13 @@ func (bm *basicManager) GetJob(jobID string) (*job.Stats, error) {
 
 	t := job.NewBasicTrackerWithID(bm.ctx, jobID, bm.namespace, bm.pool, nil, nil)
 	if err := t.Load(); err != nil {
+		if errs.IsObjectNotFoundError(err) {
+			// The stats may be lost with the redis, get them from the job store
+			if stats, er := bm.store.GetStats(bm.ctx, jobID); er == nil {
+				return stats, nil
+			}
+		}
+
 		return nil, err
 	}
 
@@ -310,7 +321,11 @@ func (bm *basicManager) SaveJob(j *job.Stats) error {
 	}
 
 	t := job.NewBasicTrackerWithStats(bm.ctx, j, bm.namespace, bm.pool, nil, nil)
-	return t.Save()
+	if err := t.Save(); err != nil {
+		return err
+	}
+
+	return bm.store.SaveStats(bm.ctx, j)
 }
 
 // queryExecutions queries periodic executions by status
@@ -28,10 +28,14 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/lcm"
 	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/jobservice/store"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
 )
 
+// the interval to restore the periodic policies lost with the redis from the job store
+const restorePoliciesInterval = 5 * time.Minute
+
 // basicScheduler manages the periodic scheduling policies.
 type basicScheduler struct {
 	context   context.Context
@@ -40,6 +44,7 @@ type basicScheduler struct {
 	enqueuer  *enqueuer
 	client    *work.Client
 	ctl       lcm.Controller
+	store     store.Store
 }
 
 // NewScheduler is constructor of basicScheduler
@@ -51,17 +56,21 @@ func NewScheduler(ctx context.Context, namespace string, pool *redis.Pool, ctl l
 		enqueuer:  newEnqueuer(ctx, namespace, pool, ctl),
 		client:    work.NewClient(namespace, pool),
 		ctl:       ctl,
+		store:     store.Default(),
 	}
 }
 
 // Start the periodic scheduling process
 func (bs *basicScheduler) Start() {
+	// start enqueuer, the leader is elected before it returns
+	bs.enqueuer.start()
+
 	// Run once clean
 	// Try best to do
 	go bs.clearDirtyJobs()
 
-	// start enqueuer
-	bs.enqueuer.start()
+	// Keep the policies in the redis consistent with the job store
+	go bs.loopForRestorePolicies()
 }
 
 // Schedule is implementation of the same method in period.Interface
@@ -95,6 +104,16 @@ func (bs *basicScheduler) Schedule(p *Policy) (int64, error) {
 		return -1, err
 	}
 
+	// Persist the policy to restore it when the redis data is lost
+	if err := bs.store.SavePolicy(bs.context, &store.Policy{ID: p.ID, NumericID: pid, Data: rawJSON}); err != nil {
+		// Roll back to keep the redis consistent with the job store
+		if _, er := conn.Do("ZREM", rds.KeyPeriodicPolicy(bs.namespace), rawJSON); er != nil {
+			logger.Errorf("Roll back periodic job policy %s error: %s", p.ID, er)
+		}
+
+		return -1, errors.Wrap(err, "persist periodic job policy error")
+	}
+
 	return pid, nil
 }
 
@@ -157,6 +176,11 @@ func (bs *basicScheduler) UnSchedule(policyID string) error {
 		}
 	}
 
+	// Remove from the job store first to avoid the policy being restored
+	if err := bs.store.DeletePolicy(bs.context, policyID); err != nil {
+		return errors.Wrap(err, "unschedule periodic job error")
+	}
+
 	// REM from redis db
 	// Accurately remove the item with the specified score
 	removed, err := redis.Int64(conn.Do("ZREMRANGEBYSCORE", rds.KeyPeriodicPolicy(bs.namespace), numericID, numericID))
@@ -210,6 +234,11 @@ func (bs *basicScheduler) locatePolicy(policyID string, conn redis.Conn) (int64,
 // A scheduled job will be marked as dirty job only if the enqueued timestamp has expired a horizon.
 // This is a try best action
 func (bs *basicScheduler) clearDirtyJobs() {
+	// Leave it to the leader to avoid racing with the enqueuing
+	if !bs.enqueuer.elector.isLeader() {
+		return
+	}
+
 	conn := bs.pool.Get()
 	defer func() {
 		_ = conn.Close()
@@ -239,6 +268,68 @@ func (bs *basicScheduler) clearDirtyJobs() {
 	}
 }
 
+// loopForRestorePolicies restores the periodic policies from the job store periodically until the scheduler is stopped
+func (bs *basicScheduler) loopForRestorePolicies() {
+	tk := time.NewTicker(restorePoliciesInterval)
+	defer tk.Stop()
+
+	for {
+		bs.restorePolicies()
+
+		select {
+		case <-tk.C:
+		case <-bs.context.Done():
+			return
+		}
+	}
+}
+
+// restorePolicies adds the policies which exist in the job store but are missing in the redis back to the redis
+func (bs *basicScheduler) restorePolicies() {
+	if !bs.enqueuer.elector.isLeader() {
+		return
+	}
+
+	persisted, err := bs.store.ListPolicies(bs.context)
+	if err != nil {
+		logger.Errorf("Failed to list periodic job policies from the job store: %s", err)
+		return
+	}
+
+	if len(persisted) == 0 {
+		return
+	}
+
+	conn := bs.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	policies, err := Load(bs.namespace, conn)
+	if err != nil {
+		logger.Errorf("Failed to load periodic job policies: %s", err)
+		return
+	}
+
+	existing := make(map[string]bool, len(policies))
+	for _, p := range policies {
+		existing[p.ID] = true
+	}
+
+	for _, p := range persisted {
+		if existing[p.ID] {
+			continue
+		}
+
+		if _, err := conn.Do("ZADD", rds.KeyPeriodicPolicy(bs.namespace), p.NumericID, p.Data); err != nil {
+			logger.Errorf("Failed to restore periodic job policy %s: %s", p.ID, err)
+			continue
+		}
+
+		logger.Infof("Restored periodic job policy %s from the job store", p.ID)
+	}
+}
+
 // Get relevant executions for the periodic job
 func getPeriodicExecutions(conn redis.Conn, key string) ([]string, error) {
 	args := []interface{}{key, 0, "+inf"}
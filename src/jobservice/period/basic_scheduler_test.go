@@ -31,9 +31,35 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/env"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/lcm"
+	"github.com/goharbor/harbor/src/jobservice/store"
 	"github.com/goharbor/harbor/src/jobservice/tests"
 )
 
+// fakePolicyStore keeps the periodic policies in memory
+type fakePolicyStore struct {
+	store.Store
+
+	policies map[string]*store.Policy
+}
+
+func (f *fakePolicyStore) SavePolicy(ctx context.Context, policy *store.Policy) error {
+	f.policies[policy.ID] = policy
+	return nil
+}
+
+func (f *fakePolicyStore) DeletePolicy(ctx context.Context, policyID string) error {
+	delete(f.policies, policyID)
+	return nil
+}
+
+func (f *fakePolicyStore) ListPolicies(ctx context.Context) ([]*store.Policy, error) {
+	policies := make([]*store.Policy, 0)
+	for _, p := range f.policies {
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
 // BasicSchedulerTestSuite tests functions of basic scheduler
 type BasicSchedulerTestSuite struct {
 	suite.Suite
@@ -146,6 +172,46 @@ func (suite *BasicSchedulerTestSuite) TestUnSchedule() {
 	require.NoError(suite.T(), err, "unschedule: nil error expected but got %s", err)
 }
 
+// TestJobStore tests restoring the periodic policies lost with the redis from the job store
+func (suite *BasicSchedulerTestSuite) TestJobStore() {
+	bs := suite.scheduler.(*basicScheduler)
+	fs := &fakePolicyStore{policies: make(map[string]*store.Policy)}
+	bs.store = fs
+	defer func() {
+		bs.store = store.Default()
+	}()
+
+	p := &Policy{
+		ID:       "persisted_policy",
+		JobName:  job.SampleJob,
+		CronSpec: "0 10 10 5 * *",
+	}
+	pid, err := suite.scheduler.Schedule(p)
+	require.NoError(suite.T(), err, "schedule: nil error expected but got %s", err)
+	require.Contains(suite.T(), fs.policies, p.ID)
+	assert.Equal(suite.T(), pid, fs.policies[p.ID].NumericID)
+
+	conn := suite.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// Lose the policies in the redis
+	_, err = conn.Do("DEL", rds.KeyPeriodicPolicy(suite.namespace))
+	require.NoError(suite.T(), err)
+
+	bs.restorePolicies()
+	policies, err := Load(suite.namespace, conn)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), policies, 1)
+	assert.Equal(suite.T(), p.ID, policies[0].ID)
+	assert.Equal(suite.T(), pid, policies[0].NumericID)
+
+	err = suite.scheduler.UnSchedule(p.ID)
+	require.NoError(suite.T(), err, "unschedule: nil error expected but got %s", err)
+	assert.NotContains(suite.T(), fs.policies, p.ID)
+}
+
 // setupDirtyJobs adds dirty jobs for testing dirty jobs clear method in the Start()
 func (suite *BasicSchedulerTestSuite) setupDirtyJobs() {
 	// Add one fake job for next testing
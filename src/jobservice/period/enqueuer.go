@@ -47,6 +47,8 @@ type enqueuer struct {
 	ctl       lcm.Controller
 	// Diff with other nodes
 	nodeID string
+	// Only the leader does the enqueuing
+	elector leaderElector
 	// Track the error of enqueuing
 	lastEnqueueErr error
 }
@@ -64,11 +66,13 @@ func newEnqueuer(ctx context.Context, namespace string, pool *redis.Pool, ctl lc
 		pool:      pool,
 		ctl:       ctl,
 		nodeID:    nodeID.(string),
+		elector:   newLeaderElector(ctx, namespace, pool, nodeID.(string)),
 	}
 }
 
 // Blocking call
 func (e *enqueuer) start() {
+	e.elector.start()
 	go e.loop()
 	logger.Info("Scheduler: periodic enqueuer is started")
 }
@@ -250,6 +254,11 @@ func (e *enqueuer) createExecution(p *Policy, runAt int64) *job.Stats {
 }
 
 func (e *enqueuer) shouldEnqueue() bool {
+	// Only the leader enqueues the periodic executions
+	if !e.elector.isLeader() {
+		return false
+	}
+
 	conn := e.pool.Get()
 	defer func() {
 		_ = conn.Close()
@@ -0,0 +1,262 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package period
+
+import (
+	"context"
+	"database/sql"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	beegoorm "github.com/beego/beego/v2/client/orm"
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+)
+
+// renew the lease of the leader or campaign for the leadership every 1/3 of the lease
+const campaignsPerLease = 3
+
+var (
+	// campaignScript takes the leadership if no leader exists or renews the lease if the node is the leader
+	campaignScript = redis.NewScript(1, `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+  return 1
+end
+return 0
+`)
+	// resignScript gives up the leadership only if the node is the leader
+	resignScript = redis.NewScript(1, `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+)
+
+// leaderElector elects the leader among the job service replicas,
+// only the leader runs the periodic enqueuer to avoid the duplicated scheduled executions
+type leaderElector interface {
+	// start campaigning for the leadership until the context is done,
+	// the first round of the campaign is done before returning
+	start()
+	// isLeader checks whether the current node holds the leadership
+	isLeader() bool
+}
+
+// newLeaderElector creates the leader elector according to the configuration
+func newLeaderElector(ctx context.Context, namespace string, pool *redis.Pool, nodeID string) leaderElector {
+	base := &baseElector{
+		context: ctx,
+		nodeID:  nodeID,
+		lease:   config.LeaderLease(),
+	}
+
+	if config.LeaderElectionType() == config.LeaderElectionTypePostgreSQL {
+		base.campaigner = &postgreSQLCampaigner{lockID: advisoryLockID(namespace)}
+	} else {
+		base.campaigner = &redisCampaigner{
+			pool:   pool,
+			key:    rds.KeyPeriodicLeader(namespace),
+			nodeID: nodeID,
+		}
+	}
+
+	return base
+}
+
+// campaigner takes or renews the leadership in the backend
+type campaigner interface {
+	// campaign returns true if the node holds the leadership for the lease
+	campaign(ctx context.Context, lease time.Duration) (bool, error)
+	// resign gives up the leadership
+	resign(ctx context.Context) error
+}
+
+type baseElector struct {
+	context    context.Context
+	nodeID     string
+	lease      time.Duration
+	campaigner campaigner
+
+	lock sync.RWMutex
+	// the leadership is only trusted before the lease expires even if the backend can't be reached
+	leaseUntil time.Time
+}
+
+func (b *baseElector) start() {
+	b.campaign()
+
+	go func() {
+		tk := time.NewTicker(b.lease / campaignsPerLease)
+		defer tk.Stop()
+
+		for {
+			select {
+			case <-tk.C:
+				b.campaign()
+			case <-b.context.Done():
+				if b.isLeader() {
+					// Give up the leadership to let other replicas take over immediately
+					ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+					if err := b.campaigner.resign(ctx); err != nil {
+						logger.Errorf("Scheduler: node %s failed to resign the leadership: %s", b.nodeID, err)
+					}
+					cancel()
+				}
+				return
+			}
+		}
+	}()
+}
+
+func (b *baseElector) campaign() {
+	wasLeader := b.isLeader()
+	// Record the time before campaigning as the lease is counted from the request
+	now := time.Now()
+
+	elected, err := b.campaigner.campaign(b.context, b.lease)
+	if err != nil {
+		logger.Errorf("Scheduler: node %s failed to campaign for the leadership: %s", b.nodeID, err)
+	}
+
+	b.lock.Lock()
+	if elected {
+		b.leaseUntil = now.Add(b.lease)
+	} else if err == nil {
+		// Lost the leadership for sure
+		b.leaseUntil = time.Time{}
+	}
+	b.lock.Unlock()
+
+	switch isLeader := b.isLeader(); {
+	case isLeader && !wasLeader:
+		logger.Infof("Scheduler: node %s is elected as the leader of the periodic scheduler", b.nodeID)
+	case !isLeader && wasLeader:
+		logger.Infof("Scheduler: node %s lost the leadership of the periodic scheduler", b.nodeID)
+	}
+}
+
+func (b *baseElector) isLeader() bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return time.Now().Before(b.leaseUntil)
+}
+
+// redisCampaigner elects the leader by the lease key in the redis
+type redisCampaigner struct {
+	pool   *redis.Pool
+	key    string
+	nodeID string
+}
+
+func (r *redisCampaigner) campaign(_ context.Context, lease time.Duration) (bool, error) {
+	conn := r.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	res, err := redis.Int(campaignScript.Do(conn, r.key, r.nodeID, lease.Milliseconds()))
+	if err != nil {
+		return false, err
+	}
+
+	return res == 1, nil
+}
+
+func (r *redisCampaigner) resign(_ context.Context) error {
+	conn := r.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_, err := resignScript.Do(conn, r.key, r.nodeID)
+	return err
+}
+
+// postgreSQLCampaigner elects the leader by the session level advisory lock of the PostgreSQL database of Harbor,
+// the lock is released automatically by the database when the connection of the leader is broken
+type postgreSQLCampaigner struct {
+	lockID int64
+	// the dedicated connection which holds the advisory lock
+	conn *sql.Conn
+}
+
+func (p *postgreSQLCampaigner) campaign(ctx context.Context, lease time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, lease/campaignsPerLease)
+	defer cancel()
+
+	if p.conn != nil {
+		// Already the leader, make sure the connection holding the lock is still alive
+		if err := p.conn.PingContext(ctx); err != nil {
+			p.close()
+			return false, err
+		}
+		return true, nil
+	}
+
+	db, err := beegoorm.GetDB("default")
+	if err != nil {
+		return false, err
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", p.lockID).Scan(&locked); err != nil {
+		_ = conn.Close()
+		return false, err
+	}
+	if !locked {
+		_ = conn.Close()
+		return false, nil
+	}
+
+	p.conn = conn
+	return true, nil
+}
+
+func (p *postgreSQLCampaigner) resign(ctx context.Context) error {
+	if p.conn == nil {
+		return nil
+	}
+	defer p.close()
+
+	_, err := p.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", p.lockID)
+	return err
+}
+
+func (p *postgreSQLCampaigner) close() {
+	if p.conn != nil {
+		_ = p.conn.Close()
+		p.conn = nil
+	}
+}
+
+// advisoryLockID returns the ID of the advisory lock for the namespace
+func advisoryLockID(namespace string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(rds.KeyPeriodicLeader(namespace)))
+	return int64(h.Sum64())
+}
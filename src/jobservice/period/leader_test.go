@@ -0,0 +1,93 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package period
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeCampaigner struct {
+	elected  bool
+	err      error
+	resigned atomic.Bool
+}
+
+func (f *fakeCampaigner) campaign(_ context.Context, _ time.Duration) (bool, error) {
+	return f.elected, f.err
+}
+
+func (f *fakeCampaigner) resign(_ context.Context) error {
+	f.resigned.Store(true)
+	return nil
+}
+
+func TestLeaderElector(t *testing.T) {
+	c := &fakeCampaigner{}
+	e := &baseElector{
+		context:    context.TODO(),
+		nodeID:     "node1",
+		lease:      100 * time.Millisecond,
+		campaigner: c,
+	}
+
+	e.campaign()
+	assert.False(t, e.isLeader())
+
+	c.elected = true
+	e.campaign()
+	assert.True(t, e.isLeader())
+
+	// keep the leadership until the lease expires when the backend can't be reached
+	c.elected, c.err = false, errors.New("connection refused")
+	e.campaign()
+	assert.True(t, e.isLeader())
+	time.Sleep(150 * time.Millisecond)
+	assert.False(t, e.isLeader())
+
+	// lose the leadership immediately when another node is elected
+	c.elected, c.err = true, nil
+	e.campaign()
+	assert.True(t, e.isLeader())
+	c.elected = false
+	e.campaign()
+	assert.False(t, e.isLeader())
+}
+
+func TestLeaderElectorResign(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	c := &fakeCampaigner{elected: true}
+	e := &baseElector{
+		context:    ctx,
+		nodeID:     "node1",
+		lease:      time.Minute,
+		campaigner: c,
+	}
+
+	e.start()
+	assert.True(t, e.isLeader())
+	cancel()
+	assert.Eventually(t, func() bool { return c.resigned.Load() }, time.Second, 10*time.Millisecond)
+}
+
+func TestAdvisoryLockID(t *testing.T) {
+	assert.Equal(t, advisoryLockID("ns1"), advisoryLockID("ns1"))
+	assert.NotEqual(t, advisoryLockID("ns1"), advisoryLockID("ns2"))
+}
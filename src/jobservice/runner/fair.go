@@ -0,0 +1,132 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gocraft/work"
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
+	"github.com/goharbor/harbor/src/jobservice/job"
+)
+
+const defaultDeferSeconds uint = 10
+
+// FairScheduler shares the workers of a worker pool fairly among the jobs of the different fair share keys.
+// When the jobs of more than one key are running, the jobs of one key can't occupy more than the max share
+// of the workers, and the low priority jobs can't occupy more than the max share of the workers at any time.
+// The high priority jobs are never limited. The jobs exceeding the share are deferred and retried later.
+type FairScheduler struct {
+	namespace    string
+	pool         *redis.Pool
+	limit        int
+	deferSeconds uint
+
+	lock sync.Mutex
+	// key is the fair share key, value is the count of the running jobs
+	running    map[string]int
+	lowRunning int
+}
+
+// NewFairScheduler is constructor of FairScheduler, workers is the count of the workers of the worker pool
+func NewFairScheduler(namespace string, pool *redis.Pool, workers uint, maxShare float64, deferSeconds uint) *FairScheduler {
+	limit := int(float64(workers) * maxShare)
+	if limit < 1 {
+		limit = 1
+	}
+	if deferSeconds == 0 {
+		deferSeconds = defaultDeferSeconds
+	}
+
+	return &FairScheduler{
+		namespace:    namespace,
+		pool:         pool,
+		limit:        limit,
+		deferSeconds: deferSeconds,
+		running:      make(map[string]int),
+	}
+}
+
+// Admit checks if the job with the fair share key and the priority class can run now,
+// the job should be released by calling Release once it's done if it's admitted
+func (fs *FairScheduler) Admit(key, priorityClass string) bool {
+	if priorityClass == job.PriorityClassHigh {
+		return true
+	}
+
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	if priorityClass == job.PriorityClassLow && fs.lowRunning >= fs.limit {
+		return false
+	}
+	if len(key) > 0 && fs.running[key] >= fs.limit {
+		// the jobs of the other keys are running
+		if len(fs.running) > 1 {
+			return false
+		}
+	}
+
+	if priorityClass == job.PriorityClassLow {
+		fs.lowRunning++
+	}
+	if len(key) > 0 {
+		fs.running[key]++
+	}
+
+	return true
+}
+
+// Release the admitted job
+func (fs *FairScheduler) Release(key, priorityClass string) {
+	if priorityClass == job.PriorityClassHigh {
+		return
+	}
+
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	if priorityClass == job.PriorityClassLow && fs.lowRunning > 0 {
+		fs.lowRunning--
+	}
+	if len(key) > 0 {
+		if fs.running[key] <= 1 {
+			delete(fs.running, key)
+		} else {
+			fs.running[key]--
+		}
+	}
+}
+
+// Defer puts the job into the scheduled queue to retry later, the job is kept in the pending status
+func (fs *FairScheduler) Defer(j *work.Job) error {
+	rawJSON, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+
+	conn := fs.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	runAt := time.Now().Unix() + int64(fs.deferSeconds)
+	_, err = conn.Do("ZADD", rds.RedisKeyScheduled(fs.namespace), runAt, rawJSON)
+	return err
+}
@@ -0,0 +1,60 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+)
+
+func TestFairSchedulerAdmit(t *testing.T) {
+	fs := NewFairScheduler("{test}", nil, 4, 0.5, 0)
+	assert.Equal(t, 2, fs.limit)
+	assert.Equal(t, defaultDeferSeconds, fs.deferSeconds)
+
+	// the only running key can use all the workers
+	assert.True(t, fs.Admit("project:1", job.PriorityClassNormal))
+	assert.True(t, fs.Admit("project:1", job.PriorityClassNormal))
+	assert.True(t, fs.Admit("project:1", job.PriorityClassNormal))
+
+	// the key exceeding the share is deferred when the other keys are running
+	assert.True(t, fs.Admit("project:2", job.PriorityClassNormal))
+	assert.False(t, fs.Admit("project:1", job.PriorityClassNormal))
+	assert.True(t, fs.Admit("project:2", job.PriorityClassNormal))
+	// the high priority jobs are never deferred
+	assert.True(t, fs.Admit("project:1", job.PriorityClassHigh))
+
+	fs.Release("project:2", job.PriorityClassNormal)
+	fs.Release("project:2", job.PriorityClassNormal)
+	assert.NotContains(t, fs.running, "project:2")
+	assert.True(t, fs.Admit("project:1", job.PriorityClassNormal))
+}
+
+func TestFairSchedulerAdmitLow(t *testing.T) {
+	fs := NewFairScheduler("{test}", nil, 3, 0.2, 5)
+	assert.Equal(t, 1, fs.limit)
+	assert.Equal(t, uint(5), fs.deferSeconds)
+
+	assert.True(t, fs.Admit("", job.PriorityClassLow))
+	assert.False(t, fs.Admit("", job.PriorityClassLow))
+	assert.True(t, fs.Admit("", job.PriorityClassNormal))
+
+	fs.Release("", job.PriorityClassLow)
+	assert.Equal(t, 0, fs.lowRunning)
+	assert.True(t, fs.Admit("", job.PriorityClassLow))
+}
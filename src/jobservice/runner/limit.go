@@ -0,0 +1,175 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+const (
+	// LimitTimeout means the job exceeds the timeout
+	LimitTimeout = "timeout"
+	// LimitMemory means the memory exceeds the max memory while the job is running
+	LimitMemory = "memory"
+)
+
+var (
+	// the interval to check the memory used by the job service process
+	memoryCheckInterval = 5 * time.Second
+	// the time to wait for the cancelled job to exit before abandoning it
+	cancelGracePeriod = 30 * time.Second
+	// memoryUsage returns the bytes of the memory obtained from the OS and not released yet
+	memoryUsage = func() uint64 {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		return m.Sys - m.HeapReleased
+	}
+)
+
+// LimitExceededError is returned when the job is cancelled for exceeding the resource limit
+type LimitExceededError struct {
+	Limit   string
+	Message string
+}
+
+// Error implements the error interface
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("job cancelled as exceeding the %s limit: %s", e.Limit, e.Message)
+}
+
+// limitGuard watches the running job and cancels it when the resource limit is exceeded
+type limitGuard struct {
+	limit  *config.JobLimit
+	cancel context.CancelFunc
+
+	lock     sync.Mutex
+	exceeded *LimitExceededError
+}
+
+// limitedContext is the job context whose system context is cancelled and the stop command is
+// returned when the job exceeds the resource limit, so the job can exit as being stopped
+type limitedContext struct {
+	job.Context
+	ctx   context.Context
+	guard *limitGuard
+}
+
+// SystemContext returns the system context cancelled when the limit is exceeded
+func (lc *limitedContext) SystemContext() context.Context {
+	return lc.ctx
+}
+
+// OPCommand returns the stop command when the limit is exceeded
+func (lc *limitedContext) OPCommand() (job.OPCommand, bool) {
+	if lc.guard.limitExceeded() != nil {
+		return job.StopCommand, true
+	}
+	return lc.Context.OPCommand()
+}
+
+// runWithLimit runs the job and cancels it if it exceeds the limit. The job is abandoned
+// if it doesn't exit in the grace period after being cancelled, to release the worker
+func runWithLimit(j job.Interface, ctx job.Context, params job.Parameters, limit *config.JobLimit) error {
+	if limit == nil || (limit.Timeout == 0 && limit.MaxMemoryMB == 0) {
+		return j.Run(ctx, params)
+	}
+
+	sysCtx, cancel := context.WithCancel(ctx.SystemContext())
+	defer cancel()
+	guard := &limitGuard{limit: limit, cancel: cancel}
+	lctx := &limitedContext{Context: ctx, ctx: sysCtx, guard: guard}
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				buf := make([]byte, 1<<10)
+				size := runtime.Stack(buf, false)
+				done <- errors.Errorf("runtime error: %s; stack: %s", r, buf[0:size])
+			}
+		}()
+		done <- j.Run(lctx, params)
+	}()
+
+	err := guard.watch(done)
+	if exceeded := guard.limitExceeded(); exceeded != nil {
+		return exceeded
+	}
+	return err
+}
+
+// watch waits for the job to exit and cancels it when the limit is exceeded
+func (g *limitGuard) watch(done <-chan error) error {
+	var timeout <-chan time.Time
+	if g.limit.Timeout > 0 {
+		timer := time.NewTimer(time.Duration(g.limit.Timeout) * time.Second)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	var memCheck <-chan time.Time
+	if g.limit.MaxMemoryMB > 0 {
+		ticker := time.NewTicker(memoryCheckInterval)
+		defer ticker.Stop()
+		memCheck = ticker.C
+	}
+
+	for {
+		select {
+		case err := <-done:
+			return err
+		case <-timeout:
+			g.exceed(LimitTimeout, fmt.Sprintf("the job runs more than %d seconds", g.limit.Timeout))
+		case <-memCheck:
+			maxBytes := uint64(g.limit.MaxMemoryMB) << 20
+			if used := memoryUsage(); used > maxBytes {
+				g.exceed(LimitMemory, fmt.Sprintf("the memory used %d MB exceeds %d MB", used>>20, g.limit.MaxMemoryMB))
+			}
+		}
+
+		if g.limitExceeded() != nil {
+			select {
+			case err := <-done:
+				return err
+			case <-time.After(cancelGracePeriod):
+				logger.Errorf("The cancelled job doesn't exit in %s, abandon it", cancelGracePeriod)
+				return nil
+			}
+		}
+	}
+}
+
+func (g *limitGuard) exceed(limit, message string) {
+	g.lock.Lock()
+	g.exceeded = &LimitExceededError{Limit: limit, Message: message}
+	g.lock.Unlock()
+
+	g.cancel()
+}
+
+func (g *limitGuard) limitExceeded() *LimitExceededError {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	return g.exceeded
+}
@@ -0,0 +1,104 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+type fakeJobContext struct {
+	job.Context
+}
+
+func (f *fakeJobContext) SystemContext() context.Context {
+	return context.TODO()
+}
+
+func (f *fakeJobContext) OPCommand() (job.OPCommand, bool) {
+	return job.NilCommand, false
+}
+
+// fakeLimitedJob runs until it's done or stopped
+type fakeLimitedJob struct {
+	duration time.Duration
+	// ignore the cancellation
+	stubborn bool
+}
+
+func (f *fakeLimitedJob) MaxFails() uint                       { return 1 }
+func (f *fakeLimitedJob) MaxCurrency() uint                    { return 0 }
+func (f *fakeLimitedJob) ShouldRetry() bool                    { return true }
+func (f *fakeLimitedJob) Validate(params job.Parameters) error { return nil }
+func (f *fakeLimitedJob) Run(ctx job.Context, params job.Parameters) error {
+	if f.stubborn {
+		time.Sleep(f.duration)
+		return nil
+	}
+	select {
+	case <-time.After(f.duration):
+		return nil
+	case <-ctx.SystemContext().Done():
+		if cmd, ok := ctx.OPCommand(); ok && cmd == job.StopCommand {
+			return nil
+		}
+		return ctx.SystemContext().Err()
+	}
+}
+
+func TestRunWithLimit(t *testing.T) {
+	ctx := &fakeJobContext{}
+
+	// no limit
+	assert.Nil(t, runWithLimit(&fakeLimitedJob{duration: 10 * time.Millisecond}, ctx, nil, nil))
+	// in the limit
+	assert.Nil(t, runWithLimit(&fakeLimitedJob{duration: 10 * time.Millisecond}, ctx, nil, &config.JobLimit{Timeout: 10}))
+
+	// exceed the timeout
+	err := runWithLimit(&fakeLimitedJob{duration: time.Minute}, ctx, nil, &config.JobLimit{Timeout: 1})
+	var limitErr *LimitExceededError
+	assert.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, LimitTimeout, limitErr.Limit)
+	assert.Equal(t, config.ErrorClassLimitExceeded, ErrorClassOf(errors.Wrap(err, "run error")))
+}
+
+func TestRunWithLimitMemory(t *testing.T) {
+	memoryCheckInterval = 10 * time.Millisecond
+	cancelGracePeriod = 50 * time.Millisecond
+	memoryUsage = func() uint64 { return 2048 << 20 }
+	defer func() {
+		memoryCheckInterval = 5 * time.Second
+		cancelGracePeriod = 30 * time.Second
+	}()
+
+	ctx := &fakeJobContext{}
+	err := runWithLimit(&fakeLimitedJob{duration: time.Minute}, ctx, nil, &config.JobLimit{MaxMemoryMB: 1024})
+	var limitErr *LimitExceededError
+	assert.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, LimitMemory, limitErr.Limit)
+
+	// the job ignoring the cancellation is abandoned after the grace period
+	start := time.Now()
+	err = runWithLimit(&fakeLimitedJob{duration: time.Second, stubborn: true}, ctx, nil, &config.JobLimit{MaxMemoryMB: 1024})
+	assert.True(t, errors.As(err, &limitErr))
+	assert.Less(t, time.Since(start), time.Second)
+}
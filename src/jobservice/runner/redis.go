@@ -23,7 +23,9 @@ import (
 	"github.com/gocraft/work"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
 
+	"github.com/goharbor/harbor/src/jobservice/config"
 	"github.com/goharbor/harbor/src/jobservice/env"
 	"github.com/goharbor/harbor/src/jobservice/errs"
 	"github.com/goharbor/harbor/src/jobservice/job"
@@ -45,6 +47,12 @@ type RedisJob struct {
 	job     interface{}    // the real job implementation
 	context *env.Context   // context
 	ctl     lcm.Controller // life cycle controller
+	fair    *FairScheduler // fair scheduler of the worker pool, nil if the fair share is disabled
+
+	// retry policy of the failed job, nil if no policy is configured
+	retryPolicy *config.RetryPolicy
+	// resource limit of the running job, nil if no limit is configured
+	limit *config.JobLimit
 }
 
 // NewRedisJob is constructor of RedisJob
@@ -56,15 +64,37 @@ func NewRedisJob(job interface{}, ctx *env.Context, ctl lcm.Controller) *RedisJo
 	}
 }
 
+// WithFairScheduler sets the fair scheduler to share the workers fairly among the jobs
+func (rj *RedisJob) WithFairScheduler(fs *FairScheduler) *RedisJob {
+	rj.fair = fs
+	return rj
+}
+
+// WithRetryPolicy sets the policy to retry the failed job
+func (rj *RedisJob) WithRetryPolicy(policy *config.RetryPolicy) *RedisJob {
+	rj.retryPolicy = policy
+	return rj
+}
+
+// WithLimit sets the resource limit to cancel the running job exceeding it
+func (rj *RedisJob) WithLimit(limit *config.JobLimit) *RedisJob {
+	rj.limit = limit
+	return rj
+}
+
 // Run the job
 func (rj *RedisJob) Run(j *work.Job) (err error) {
-	_, span := tracelib.StartTrace(context.Background(), tracerName, "run-job")
+	// Continue the trace of the request launching the job if it's carried
+	traceCtx := tracelib.ExtractCarrier(context.Background(), job.PopTraceContext(j.Args))
+	_, span := tracelib.StartTrace(traceCtx, tracerName, "run-job")
 	defer span.End()
+	requestID := job.PopRequestID(j.Args)
 
 	var (
 		runningJob  job.Interface
 		execContext job.Context
 		tracker     job.Tracker
+		attempt     *job.Attempt
 	)
 	// Track the running job now
 	jID := j.ID
@@ -78,6 +108,9 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 		logger.Infof("Start to run periodical job execution: %s", eID)
 	}
 	span.SetAttributes(attribute.Key("jobID").String(jID), attribute.Key("jobName").String(j.Name))
+	if len(requestID) > 0 {
+		span.SetAttributes(attribute.Key("requestID").String(requestID))
+	}
 
 	// As the job stats may not be ready when job executing sometimes (corner case),
 	// the track call here may get NOT_FOUND error. For that case, let's do retry to recovery.
@@ -114,15 +147,37 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 		return
 	}
 
+	// Check the fair share before switching the status as the deferred job should be kept pending
+	if rj.fair != nil {
+		info := tracker.Job().Info
+		if s := job.Status(info.Status); s == job.PendingStatus || s == job.ScheduledStatus {
+			if !rj.fair.Admit(info.FairShareKey, info.PriorityClass) {
+				logger.Debugf("Job %s:%s exceeds the fair share of %s, defer it", j.Name, j.ID, info.FairShareKey)
+				span.AddEvent("job deferred by fair share")
+				return rj.fair.Defer(j)
+			}
+			defer rj.fair.Release(info.FairShareKey, info.PriorityClass)
+		}
+	}
+
 	// Defer to switch status
 	defer func() {
+		// Keep the result of the attempt in the attempt history
+		if attempt != nil {
+			rj.recordAttempt(tracker, attempt, err)
+		}
+
 		// Switch job status based on the returned error.
 		// The err happened here should not override the job run error, just log it.
 		if err != nil {
 			// log error
 			logger.Errorf("Job '%s:%s' exit with error: %s", j.Name, j.ID, err)
-			metric.JobserviceTotalTask.WithLabelValues(j.Name, "fail").Inc()
-			metric.JobservieTaskProcessTimeSummary.WithLabelValues(j.Name, "fail").Observe(time.Since(now).Seconds())
+			status := "fail"
+			if ErrorClassOf(err) == config.ErrorClassLimitExceeded {
+				status = "limit_exceeded"
+			}
+			metric.JobserviceTotalTask.WithLabelValues(j.Name, status).Inc()
+			metric.JobservieTaskProcessTimeSummary.WithLabelValues(j.Name, status).Observe(time.Since(now).Seconds())
 			tracelib.RecordError(span, err, "job failed with err")
 			if er := tracker.Fail(); er != nil {
 				logger.Errorf("Error occurred when marking the status of job %s:%s to failure: %s", j.Name, j.ID, er)
@@ -208,6 +263,12 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 	if execContext, err = rj.context.JobContext.Build(tracker); err != nil {
 		return
 	}
+	// The spans started by the job are the children of the job span
+	execContext = &tracedContext{Context: execContext, ctx: oteltrace.ContextWithSpan(execContext.SystemContext(), span)}
+	// Log the request launching the job to correlate the job log with the request
+	if len(requestID) > 0 {
+		execContext.GetLogger().Infof("Job %s:%s is launched by the request %s", j.Name, jID, requestID)
+	}
 
 	// Defer to close logger stream
 	defer func() {
@@ -227,15 +288,25 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 		tracelib.RecordError(span, err, "failed set status to run")
 		return
 	}
-	// Run the job
-	err = runningJob.Run(execContext, j.Args)
+	// The fails of the job is the count of the previous failed attempts
+	attempt = &job.Attempt{
+		Attempt:   j.Fails + 1,
+		Status:    job.RunningStatus.String(),
+		StartTime: time.Now().Unix(),
+	}
+	if er := tracker.RecordAttempt(attempt); er != nil {
+		// Just log it
+		logger.Errorf("Failed to record the attempt %d of job %s:%s: %s", attempt.Attempt, j.Name, j.ID, er)
+	}
+	// Run the job, it's cancelled if exceeding the resource limit
+	err = runWithLimit(runningJob, execContext, j.Args, rj.limit)
 	// Add error context
 	if err != nil {
 		err = errors.Wrap(err, "run error")
 	}
 
 	// Handle retry
-	rj.retry(runningJob, j)
+	rj.retry(runningJob, j, err)
 	// Handle periodic job execution
 	if _, yes := isPeriodicJobExecution(j); yes {
 		if er := tracker.PeriodicExecutionDone(); er != nil {
@@ -247,13 +318,49 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 	return
 }
 
-func (rj *RedisJob) retry(j job.Interface, wj *work.Job) {
+func (rj *RedisJob) retry(j job.Interface, wj *work.Job, err error) {
 	if !j.ShouldRetry() {
 		// Cancel retry immediately
 		// Make it big enough to avoid retrying
 		wj.Fails = 10000000000
 		return
 	}
+
+	// Only retry on the classes of the errors configured in the retry policy
+	if err != nil {
+		if class := ErrorClassOf(err); !shouldRetryOn(rj.retryPolicy, class) {
+			logger.Infof("Job %s:%s failed with the %s error which is not retried by the retry policy", wj.Name, wj.ID, class)
+			wj.Fails = 10000000000
+		}
+	}
+}
+
+func (rj *RedisJob) recordAttempt(tracker job.Tracker, attempt *job.Attempt, err error) {
+	attempt.EndTime = time.Now().Unix()
+	attempt.Status = job.SuccessStatus.String()
+	if err != nil {
+		attempt.Status = job.ErrorStatus.String()
+		attempt.Error = err.Error()
+		attempt.ErrorClass = ErrorClassOf(err)
+	} else if latest, er := tracker.Status(); er == nil && latest == job.StoppedStatus {
+		attempt.Status = job.StoppedStatus.String()
+	}
+
+	if er := tracker.RecordAttempt(attempt); er != nil {
+		// Just log it
+		logger.Errorf("Failed to record the attempt %d of job %s: %s", attempt.Attempt, tracker.Job().Info.JobID, er)
+	}
+}
+
+// tracedContext is the job context whose system context carries the span of the running job
+type tracedContext struct {
+	job.Context
+	ctx context.Context
+}
+
+// SystemContext returns the system context with the span of the running job
+func (tc *tracedContext) SystemContext() context.Context {
+	return tc.ctx
 }
 
 func isPeriodicJobExecution(j *work.Job) (string, bool) {
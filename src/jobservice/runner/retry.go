@@ -0,0 +1,128 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"regexp"
+
+	commonhttp "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+const (
+	defaultInitialBackoff uint = 10
+	defaultMaxBackoff     uint = 600
+	defaultMultiplier          = 2.0
+)
+
+var (
+	// the messages of the errors returned by the remote servers, most of the clients only keep the status in the message
+	serverErrorRegexp = regexp.MustCompile(`(?i)(internal server error|bad gateway|service unavailable|gateway time-?out)`)
+	timeoutRegexp     = regexp.MustCompile(`(?i)(i/o timeout|deadline exceeded|timed out|timeout exceeded)`)
+	networkRegexp     = regexp.MustCompile(`(?i)(connection refused|connection reset|broken pipe|no such host|unexpected EOF|network is unreachable)`)
+)
+
+// Backoff returns the seconds to wait before retrying the job which has failed the specified times.
+// The backoff grows exponentially from the initial backoff until the max backoff.
+func Backoff(policy *config.RetryPolicy, fails int64) int64 {
+	initial, max, multiplier := defaultInitialBackoff, defaultMaxBackoff, defaultMultiplier
+	if policy != nil {
+		if policy.InitialBackoff > 0 {
+			initial = policy.InitialBackoff
+		}
+		if policy.MaxBackoff > 0 {
+			max = policy.MaxBackoff
+		}
+		if policy.Multiplier >= 1 {
+			multiplier = policy.Multiplier
+		}
+	}
+	if fails < 1 {
+		fails = 1
+	}
+
+	backoff := float64(initial) * math.Pow(multiplier, float64(fails-1))
+	if backoff > float64(max) {
+		return int64(max)
+	}
+
+	return int64(backoff)
+}
+
+// ErrorClassOf returns the class of the error which is used to decide whether to retry the failed job
+func ErrorClassOf(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var limitErr *LimitExceededError
+	if errors.As(err, &limitErr) {
+		return config.ErrorClassLimitExceeded
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return config.ErrorClassTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return config.ErrorClassTimeout
+		}
+		return config.ErrorClassNetwork
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return config.ErrorClassNetwork
+	}
+	var httpErr *commonhttp.Error
+	if errors.As(err, &httpErr) && httpErr.Code >= http.StatusInternalServerError {
+		return config.ErrorClassServerError
+	}
+
+	// the errors are wrapped as messages in most cases, check the messages at last
+	msg := err.Error()
+	switch {
+	case timeoutRegexp.MatchString(msg):
+		return config.ErrorClassTimeout
+	case serverErrorRegexp.MatchString(msg):
+		return config.ErrorClassServerError
+	case networkRegexp.MatchString(msg):
+		return config.ErrorClassNetwork
+	default:
+		return config.ErrorClassOther
+	}
+}
+
+// shouldRetryOn checks whether the job failed with the error class should be retried according to the policy
+func shouldRetryOn(policy *config.RetryPolicy, class string) bool {
+	// the job exceeding the limit will exceed it again
+	if class == config.ErrorClassLimitExceeded {
+		return false
+	}
+	if policy == nil || len(policy.RetryOn) == 0 {
+		return true
+	}
+	for _, c := range policy.RetryOn {
+		if c == config.RetryOnAny || c == class {
+			return true
+		}
+	}
+
+	return false
+}
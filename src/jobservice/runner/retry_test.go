@@ -0,0 +1,74 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	commonhttp "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+func TestBackoff(t *testing.T) {
+	// default policy
+	assert.Equal(t, int64(10), Backoff(nil, 1))
+	assert.Equal(t, int64(20), Backoff(nil, 2))
+	assert.Equal(t, int64(600), Backoff(nil, 10))
+
+	policy := &config.RetryPolicy{InitialBackoff: 5, MaxBackoff: 100, Multiplier: 3}
+	assert.Equal(t, int64(5), Backoff(policy, 0))
+	assert.Equal(t, int64(5), Backoff(policy, 1))
+	assert.Equal(t, int64(15), Backoff(policy, 2))
+	assert.Equal(t, int64(45), Backoff(policy, 3))
+	assert.Equal(t, int64(100), Backoff(policy, 4))
+}
+
+func TestErrorClassOf(t *testing.T) {
+	cases := []struct {
+		err   error
+		class string
+	}{
+		{nil, ""},
+		{errors.Wrap(context.DeadlineExceeded, "run error"), config.ErrorClassTimeout},
+		{&net.OpError{Op: "dial", Err: fmt.Errorf("connection refused")}, config.ErrorClassNetwork},
+		{errors.Wrap(&commonhttp.Error{Code: 502, Message: "bad gateway"}, "run error"), config.ErrorClassServerError},
+		{&commonhttp.Error{Code: 404, Message: "not found"}, config.ErrorClassOther},
+		{errors.New("http status code: 502, body: <html>502 Bad Gateway</html>"), config.ErrorClassServerError},
+		{errors.New("dial tcp 10.0.0.1:443: i/o timeout"), config.ErrorClassTimeout},
+		{errors.New("read tcp: connection reset by peer"), config.ErrorClassNetwork},
+		{errors.New("unauthorized"), config.ErrorClassOther},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.class, ErrorClassOf(c.err), "error: %v", c.err)
+	}
+}
+
+func TestShouldRetryOn(t *testing.T) {
+	assert.True(t, shouldRetryOn(nil, config.ErrorClassOther))
+	assert.True(t, shouldRetryOn(&config.RetryPolicy{}, config.ErrorClassOther))
+	assert.True(t, shouldRetryOn(&config.RetryPolicy{RetryOn: []string{config.RetryOnAny}}, config.ErrorClassOther))
+
+	assert.False(t, shouldRetryOn(nil, config.ErrorClassLimitExceeded))
+
+	policy := &config.RetryPolicy{RetryOn: []string{config.ErrorClassNetwork, config.ErrorClassServerError}}
+	assert.True(t, shouldRetryOn(policy, config.ErrorClassServerError))
+	assert.False(t, shouldRetryOn(policy, config.ErrorClassOther))
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/gomodule/redigo/redis"
 
 	"github.com/goharbor/harbor/src/jobservice/api"
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
 	"github.com/goharbor/harbor/src/jobservice/common/utils"
 	"github.com/goharbor/harbor/src/jobservice/config"
 	"github.com/goharbor/harbor/src/jobservice/core"
@@ -34,11 +35,16 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/hook"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/job/impl"
+	"github.com/goharbor/harbor/src/jobservice/job/impl/backup"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/gc"
+	"github.com/goharbor/harbor/src/jobservice/job/impl/imageexport"
+	"github.com/goharbor/harbor/src/jobservice/job/impl/imageimport"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/legacy"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/notification"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/purge"
+	"github.com/goharbor/harbor/src/jobservice/job/impl/purgeupload"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/replication"
+	"github.com/goharbor/harbor/src/jobservice/job/impl/repository"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/sample"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/scandataexport"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/systemartifact"
@@ -47,6 +53,7 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/mgt"
 	"github.com/goharbor/harbor/src/jobservice/migration"
 	"github.com/goharbor/harbor/src/jobservice/period"
+	"github.com/goharbor/harbor/src/jobservice/store"
 	sync2 "github.com/goharbor/harbor/src/jobservice/sync"
 	"github.com/goharbor/harbor/src/jobservice/worker"
 	"github.com/goharbor/harbor/src/jobservice/worker/cworker"
@@ -75,6 +82,37 @@ var JobService = &Bootstrap{
 // workerPoolID
 var workerPoolID string
 
+// knownJobs are the jobs registered to the worker
+var knownJobs = map[string]interface{}{
+	// Only for debugging and testing purpose
+	job.SampleJob: (*sample.Job)(nil),
+	// Functional jobs
+	job.ImageScanJob:           (*scan.Job)(nil),
+	job.PurgeAudit:             (*purge.Job)(nil),
+	job.GarbageCollection:      (*gc.GarbageCollector)(nil),
+	job.Replication:            (*replication.Replication)(nil),
+	job.Retention:              (*retention.Job)(nil),
+	scheduler.JobNameScheduler: (*scheduler.PeriodicJob)(nil),
+	job.WebhookJob:             (*notification.WebhookJob)(nil),
+	job.SlackJob:               (*notification.SlackJob)(nil),
+	job.NATSJob:                (*notification.NATSJob)(nil),
+	job.EmailJob:               (*notification.EmailJob)(nil),
+	job.P2PPreheat:             (*preheat.Job)(nil),
+	job.ScanDataExport:         (*scandataexport.ScanDataExport)(nil),
+	job.RepositoryDeletion:     (*repository.Deletion)(nil),
+	job.PurgeUpload:            (*purgeupload.Job)(nil),
+	job.ImageImport:            (*imageimport.Import)(nil),
+	job.ImageExport:            (*imageexport.Export)(nil),
+	job.MetadataBackup:         (*backup.Job)(nil),
+	// In v2.2 we migrate the scheduled replication, garbage collection and scan all to
+	// the scheduler mechanism, the following three jobs are kept for the legacy jobs
+	// and they can be removed after several releases
+	"IMAGE_REPLICATE":         (*legacy.ReplicationScheduler)(nil),
+	"IMAGE_GC":                (*legacy.GarbageCollectionScheduler)(nil),
+	"IMAGE_SCAN_ALL":          (*legacy.ScanAllScheduler)(nil),
+	job.SystemArtifactCleanup: (*systemartifact.Cleanup)(nil),
+}
+
 // Bootstrap is coordinating process to help load and start the other components to serve.
 type Bootstrap struct {
 	jobContextInitializer job.ContextInitializer
@@ -112,6 +150,11 @@ func (bs *Bootstrap) LoadAndRun(ctx context.Context, cancel context.CancelFunc)
 	// Alliance to config
 	cfg := config.DefaultConfig
 
+	// Initialize the job store before creating the components persisting data into it
+	if err = store.Init(config.JobStoreType()); err != nil {
+		return errors.Errorf("initialize job store error: %s", err)
+	}
+
 	var (
 		backendWorker worker.Interface
 		manager       mgt.Manager
@@ -176,13 +219,16 @@ func (bs *Bootstrap) LoadAndRun(ctx context.Context, cancel context.CancelFunc)
 		backendWorker, err = bs.loadAndRunRedisWorkerPool(
 			rootContext,
 			namespace,
-			workerNum,
+			cfg.PoolConfig,
 			redisPool,
 			lcmCtl,
 		)
 		if err != nil {
 			return errors.Errorf("load and run worker error: %s", err)
 		}
+		if cfg.Metric != nil && cfg.Metric.Enabled {
+			metric.RegisterJobQueueCollector(queueDepths(namespace, redisPool))
+		}
 
 		// Run daemon process of life cycle controller
 		// Ignore returned error
@@ -278,6 +324,24 @@ func (bs *Bootstrap) createMetricServer(cfg *config.Configuration) {
 	}
 }
 
+// queueDepths returns a function counting the pending jobs in the queues of the known jobs
+func queueDepths(namespace string, redisPool *redis.Pool) func() (map[string]int64, error) {
+	return func() (map[string]int64, error) {
+		conn := redisPool.Get()
+		defer conn.Close()
+
+		depths := map[string]int64{}
+		for jobType := range knownJobs {
+			n, err := redis.Int64(conn.Do("LLEN", rds.KeyJobs(namespace, jobType)))
+			if err != nil {
+				return nil, err
+			}
+			depths[jobType] = n
+		}
+		return depths, nil
+	}
+}
+
 // Load and run the API server.
 func (bs *Bootstrap) createAPIServer(ctx context.Context, cfg *config.Configuration, ctl core.Interface) *api.Server {
 	// Initialized API server
@@ -301,37 +365,15 @@ func (bs *Bootstrap) createAPIServer(ctx context.Context, cfg *config.Configurat
 func (bs *Bootstrap) loadAndRunRedisWorkerPool(
 	ctx *env.Context,
 	ns string,
-	workers uint,
+	poolCfg *config.PoolConfig,
 	redisPool *redis.Pool,
 	lcmCtl lcm.Controller,
 ) (worker.Interface, error) {
-	redisWorker := cworker.NewWorker(ctx, ns, workers, redisPool, lcmCtl)
+	redisWorker := cworker.NewWorker(ctx, ns, poolCfg, redisPool, lcmCtl)
 	workerPoolID = redisWorker.GetPoolID()
 
 	// Register jobs here
-	if err := redisWorker.RegisterJobs(
-		map[string]interface{}{
-			// Only for debugging and testing purpose
-			job.SampleJob: (*sample.Job)(nil),
-			// Functional jobs
-			job.ImageScanJob:           (*scan.Job)(nil),
-			job.PurgeAudit:             (*purge.Job)(nil),
-			job.GarbageCollection:      (*gc.GarbageCollector)(nil),
-			job.Replication:            (*replication.Replication)(nil),
-			job.Retention:              (*retention.Job)(nil),
-			scheduler.JobNameScheduler: (*scheduler.PeriodicJob)(nil),
-			job.WebhookJob:             (*notification.WebhookJob)(nil),
-			job.SlackJob:               (*notification.SlackJob)(nil),
-			job.P2PPreheat:             (*preheat.Job)(nil),
-			job.ScanDataExport:         (*scandataexport.ScanDataExport)(nil),
-			// In v2.2 we migrate the scheduled replication, garbage collection and scan all to
-			// the scheduler mechanism, the following three jobs are kept for the legacy jobs
-			// and they can be removed after several releases
-			"IMAGE_REPLICATE":         (*legacy.ReplicationScheduler)(nil),
-			"IMAGE_GC":                (*legacy.GarbageCollectionScheduler)(nil),
-			"IMAGE_SCAN_ALL":          (*legacy.ScanAllScheduler)(nil),
-			job.SystemArtifactCleanup: (*systemartifact.Cleanup)(nil),
-		}); err != nil {
+	if err := redisWorker.RegisterJobs(knownJobs); err != nil {
 		// exit
 		return nil, err
 	}
@@ -0,0 +1,171 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+)
+
+func init() {
+	orm.RegisterModel(
+		new(jobStats),
+		new(jobPolicy),
+	)
+}
+
+type jobStats struct {
+	ID         int64     `orm:"pk;auto;column(id)"`
+	JobID      string    `orm:"column(job_id)"`
+	Status     string    `orm:"column(status)"`
+	Data       string    `orm:"column(data)"`
+	UpdateTime time.Time `orm:"column(update_time);auto_now"`
+}
+
+// TableName ...
+func (j *jobStats) TableName() string {
+	return "job_service_stats"
+}
+
+type jobPolicy struct {
+	ID         int64     `orm:"pk;auto;column(id)"`
+	PolicyID   string    `orm:"column(policy_id)"`
+	NumericID  int64     `orm:"column(numeric_id)"`
+	Data       string    `orm:"column(data)"`
+	UpdateTime time.Time `orm:"column(update_time);auto_now"`
+}
+
+// TableName ...
+func (j *jobPolicy) TableName() string {
+	return "job_service_policy"
+}
+
+// NewPostgreSQLStore creates the store which persists the data in the PostgreSQL database of Harbor
+func NewPostgreSQLStore() Store {
+	return &postgreSQLStore{}
+}
+
+type postgreSQLStore struct{}
+
+// withOrm attaches the ormer to the context if it doesn't carry one,
+// the callers are the job service components which aren't aware of the database
+func withOrm(ctx context.Context) context.Context {
+	if _, err := orm.FromContext(ctx); err == nil {
+		return ctx
+	}
+	return orm.Clone(ctx)
+}
+
+func (p *postgreSQLStore) SaveStats(ctx context.Context, stats *job.Stats) error {
+	if stats == nil || stats.Info == nil {
+		return errors.New("nil job stats to save")
+	}
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	o, err := orm.FromContext(withOrm(ctx))
+	if err != nil {
+		return err
+	}
+	_, err = o.InsertOrUpdate(&jobStats{
+		JobID:  stats.Info.JobID,
+		Status: stats.Info.Status,
+		Data:   string(data),
+	}, "job_id")
+	return err
+}
+
+func (p *postgreSQLStore) GetStats(ctx context.Context, jobID string) (*job.Stats, error) {
+	o, err := orm.FromContext(withOrm(ctx))
+	if err != nil {
+		return nil, err
+	}
+	s := &jobStats{JobID: jobID}
+	if err := o.Read(s, "JobID"); err != nil {
+		if e := orm.AsNotFoundError(err, "stats of job %s not found", jobID); e != nil {
+			err = e
+		}
+		return nil, err
+	}
+	stats := &job.Stats{}
+	if err := json.Unmarshal([]byte(s.Data), stats); err != nil {
+		return nil, errors.Wrapf(err, "malformed stats of job %s", jobID)
+	}
+	return stats, nil
+}
+
+func (p *postgreSQLStore) PurgeStats(ctx context.Context, before time.Time) (int64, error) {
+	o, err := orm.FromContext(withOrm(ctx))
+	if err != nil {
+		return 0, err
+	}
+	res, err := o.Raw("DELETE FROM job_service_stats WHERE status IN (?, ?, ?) AND update_time < ?",
+		job.StoppedStatus.String(), job.ErrorStatus.String(), job.SuccessStatus.String(), before).Exec()
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (p *postgreSQLStore) SavePolicy(ctx context.Context, policy *Policy) error {
+	if policy == nil {
+		return errors.New("nil policy to save")
+	}
+	o, err := orm.FromContext(withOrm(ctx))
+	if err != nil {
+		return err
+	}
+	_, err = o.InsertOrUpdate(&jobPolicy{
+		PolicyID:  policy.ID,
+		NumericID: policy.NumericID,
+		Data:      string(policy.Data),
+	}, "policy_id")
+	return err
+}
+
+func (p *postgreSQLStore) DeletePolicy(ctx context.Context, policyID string) error {
+	o, err := orm.FromContext(withOrm(ctx))
+	if err != nil {
+		return err
+	}
+	_, err = o.Raw("DELETE FROM job_service_policy WHERE policy_id = ?", policyID).Exec()
+	return err
+}
+
+func (p *postgreSQLStore) ListPolicies(ctx context.Context) ([]*Policy, error) {
+	o, err := orm.FromContext(withOrm(ctx))
+	if err != nil {
+		return nil, err
+	}
+	var records []*jobPolicy
+	if _, err := o.QueryTable(&jobPolicy{}).OrderBy("id").All(&records); err != nil {
+		return nil, err
+	}
+	policies := make([]*Policy, 0, len(records))
+	for _, r := range records {
+		policies = append(policies, &Policy{
+			ID:        r.PolicyID,
+			NumericID: r.NumericID,
+			Data:      []byte(r.Data),
+		})
+	}
+	return policies, nil
+}
@@ -0,0 +1,92 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	htesting "github.com/goharbor/harbor/src/testing"
+)
+
+type postgreSQLStoreTestSuite struct {
+	htesting.Suite
+	store Store
+}
+
+func (p *postgreSQLStoreTestSuite) SetupSuite() {
+	p.Suite.SetupSuite()
+	p.Suite.ClearTables = []string{"job_service_stats", "job_service_policy"}
+	p.store = NewPostgreSQLStore()
+}
+
+func (p *postgreSQLStoreTestSuite) TestStats() {
+	ctx := p.Context()
+	stats := &job.Stats{
+		Info: &job.StatsInfo{
+			JobID:   "job_id",
+			JobName: job.SampleJob,
+			JobKind: job.KindGeneric,
+			Status:  job.PendingStatus.String(),
+		},
+	}
+	p.Require().Nil(p.store.SaveStats(ctx, stats))
+
+	stats.Info.Status = job.SuccessStatus.String()
+	p.Require().Nil(p.store.SaveStats(ctx, stats))
+
+	s, err := p.store.GetStats(ctx, "job_id")
+	p.Require().Nil(err)
+	p.Equal(job.SampleJob, s.Info.JobName)
+	p.Equal(job.SuccessStatus.String(), s.Info.Status)
+
+	_, err = p.store.GetStats(ctx, "not_exist")
+	p.True(errors.IsNotFoundErr(err))
+
+	n, err := p.store.PurgeStats(ctx, time.Now().Add(-time.Hour))
+	p.Require().Nil(err)
+	p.Equal(int64(0), n)
+
+	n, err = p.store.PurgeStats(ctx, time.Now().Add(time.Hour))
+	p.Require().Nil(err)
+	p.Equal(int64(1), n)
+}
+
+func (p *postgreSQLStoreTestSuite) TestPolicies() {
+	ctx := p.Context()
+	p.Require().Nil(p.store.SavePolicy(ctx, &Policy{ID: "policy1", NumericID: 1, Data: []byte(`{"id":"policy1"}`)}))
+	p.Require().Nil(p.store.SavePolicy(ctx, &Policy{ID: "policy2", NumericID: 2, Data: []byte(`{"id":"policy2"}`)}))
+
+	policies, err := p.store.ListPolicies(ctx)
+	p.Require().Nil(err)
+	p.Require().Len(policies, 2)
+	p.Equal("policy1", policies[0].ID)
+	p.Equal(int64(1), policies[0].NumericID)
+	p.Equal(`{"id":"policy1"}`, string(policies[0].Data))
+
+	p.Require().Nil(p.store.DeletePolicy(ctx, "policy1"))
+	policies, err = p.store.ListPolicies(ctx)
+	p.Require().Nil(err)
+	p.Require().Len(policies, 1)
+	p.Equal("policy2", policies[0].ID)
+}
+
+func TestPostgreSQLStoreTestSuite(t *testing.T) {
+	suite.Run(t, &postgreSQLStoreTestSuite{})
+}
@@ -0,0 +1,98 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+// Policy is the periodic policy kept in the store
+type Policy struct {
+	ID        string
+	NumericID int64
+	// Data is the serialized policy
+	Data []byte
+}
+
+// Store persists the authoritative state of the jobs and the periodic policies.
+// The redis is still used for queueing, the data lost with the redis is restored from the store.
+type Store interface {
+	// SaveStats creates or updates the stats of the job
+	SaveStats(ctx context.Context, stats *job.Stats) error
+	// GetStats gets the stats of the job, the not found error is returned if the job doesn't exist
+	GetStats(ctx context.Context, jobID string) (*job.Stats, error)
+	// PurgeStats deletes the stats of the jobs in the final status which aren't updated since the specified time
+	PurgeStats(ctx context.Context, before time.Time) (int64, error)
+	// SavePolicy creates or updates the periodic policy
+	SavePolicy(ctx context.Context, policy *Policy) error
+	// DeletePolicy deletes the periodic policy
+	DeletePolicy(ctx context.Context, policyID string) error
+	// ListPolicies lists all the periodic policies
+	ListPolicies(ctx context.Context) ([]*Policy, error)
+}
+
+// defaultStore is the store used by the job service, it's the redis store until initialized
+var defaultStore Store = &redisStore{}
+
+// Init initializes the default store with the specified type
+func Init(storeType string) error {
+	switch storeType {
+	case config.JobStoreTypeRedis:
+		defaultStore = &redisStore{}
+	case config.JobStoreTypePostgreSQL:
+		defaultStore = NewPostgreSQLStore()
+	default:
+		return errors.Errorf("job store type %s is not supported", storeType)
+	}
+
+	return nil
+}
+
+// Default returns the store used by the job service
+func Default() Store {
+	return defaultStore
+}
+
+// redisStore is used when the redis is the only datastore, the stats and the policies are already kept in the redis
+type redisStore struct{}
+
+func (r *redisStore) SaveStats(ctx context.Context, stats *job.Stats) error {
+	return nil
+}
+
+func (r *redisStore) GetStats(ctx context.Context, jobID string) (*job.Stats, error) {
+	return nil, errors.NotFoundError(nil).WithMessage("stats of job %s not found", jobID)
+}
+
+func (r *redisStore) PurgeStats(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *redisStore) SavePolicy(ctx context.Context, policy *Policy) error {
+	return nil
+}
+
+func (r *redisStore) DeletePolicy(ctx context.Context, policyID string) error {
+	return nil
+}
+
+func (r *redisStore) ListPolicies(ctx context.Context) ([]*Policy, error) {
+	return nil, nil
+}
@@ -15,6 +15,7 @@
 package cworker
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"sync"
@@ -23,7 +24,9 @@ import (
 	"github.com/gocraft/work"
 	"github.com/gomodule/redigo/redis"
 
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
 	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/config"
 	"github.com/goharbor/harbor/src/jobservice/env"
 	"github.com/goharbor/harbor/src/jobservice/errs"
 	"github.com/goharbor/harbor/src/jobservice/job"
@@ -38,6 +41,14 @@ import (
 
 var (
 	workerPoolDeadTime = 10 * time.Second
+	// move the job to the tail of the list which is the head of the queue as the jobs are fetched by RPOPLPUSH
+	prioritizeJobScript = redis.NewScript(1, `
+if redis.call('lrem', KEYS[1], 1, ARGV[1]) > 0 then
+  redis.call('rpush', KEYS[1], ARGV[1])
+  return 1
+end
+return 0
+`)
 )
 
 const (
@@ -62,6 +73,22 @@ type basicWorker struct {
 	// key is name of known job
 	// value is the type of known job
 	knownJobs *sync.Map
+
+	// the dedicated worker pools, key is the name of the pool
+	jobPools map[string]*work.WorkerPool
+	// key is the name of the job run by the dedicated pool
+	// value is the name of the pool
+	poolOfJobs map[string]string
+	// key is the name of the job
+	// value is the max concurrency overriding the one defined by the job
+	maxConcurrency map[string]uint
+	// the fair schedulers of the worker pools, key is the name of the dedicated pool,
+	// empty key is for the default pool. It's empty if the fair share is disabled
+	fairSchedulers map[string]*runner.FairScheduler
+	// the retry policies of the failed jobs
+	retry *config.RetryConfig
+	// the resource limits of the running jobs
+	limits *config.LimitsConfig
 }
 
 // workerContext ...
@@ -80,10 +107,44 @@ func (rpc *workerContext) logJob(job *work.Job, next work.NextMiddlewareFunc) er
 }
 
 // NewWorker is constructor of worker
-func NewWorker(ctx *env.Context, namespace string, workerCount uint, redisPool *redis.Pool, ctl lcm.Controller) worker.Interface {
+func NewWorker(ctx *env.Context, namespace string, poolCfg *config.PoolConfig, redisPool *redis.Pool, ctl lcm.Controller) worker.Interface {
 	wc := defaultWorkerCount
-	if workerCount > 0 {
-		wc = workerCount
+	if poolCfg != nil && poolCfg.WorkerCount > 0 {
+		wc = poolCfg.WorkerCount
+	}
+
+	jobPools := make(map[string]*work.WorkerPool)
+	poolOfJobs := make(map[string]string)
+	maxConcurrency := make(map[string]uint)
+	fairSchedulers := make(map[string]*runner.FairScheduler)
+	var (
+		retry  *config.RetryConfig
+		limits *config.LimitsConfig
+	)
+	if poolCfg != nil {
+		retry = poolCfg.Retry
+		limits = poolCfg.Limits
+		for _, p := range poolCfg.JobPools {
+			if p == nil {
+				continue
+			}
+			jobPools[p.Name] = work.NewWorkerPool(workerContext{}, p.WorkerCount, namespace, redisPool)
+			for _, j := range p.Jobs {
+				poolOfJobs[j] = p.Name
+			}
+		}
+		for j, c := range poolCfg.MaxConcurrency {
+			maxConcurrency[j] = c
+		}
+		if fs := poolCfg.FairShare; fs != nil && fs.MaxShare > 0 {
+			fairSchedulers[""] = runner.NewFairScheduler(namespace, redisPool, wc, fs.MaxShare, fs.DeferSeconds)
+			for _, p := range poolCfg.JobPools {
+				if p == nil {
+					continue
+				}
+				fairSchedulers[p.Name] = runner.NewFairScheduler(namespace, redisPool, p.WorkerCount, fs.MaxShare, fs.DeferSeconds)
+			}
+		}
 	}
 
 	return &basicWorker{
@@ -103,6 +164,12 @@ func NewWorker(ctx *env.Context, namespace string, workerCount uint, redisPool *
 			lcmCtl:    ctl,
 			jobTypes:  make([]string, 0), // Append data later (at the start step)
 		},
+		jobPools:       jobPools,
+		poolOfJobs:     poolOfJobs,
+		maxConcurrency: maxConcurrency,
+		fairSchedulers: fairSchedulers,
+		retry:          retry,
+		limits:         limits,
 	}
 }
 
@@ -144,6 +211,9 @@ func (w *basicWorker) Start() error {
 
 		<-w.context.SystemContext.Done()
 		w.pool.Stop()
+		for _, p := range w.jobPools {
+			p.Stop()
+		}
 	}()
 
 	// Start the backend worker pool
@@ -151,8 +221,20 @@ func (w *basicWorker) Start() error {
 	w.pool.Middleware((*workerContext).logJob)
 	// Non blocking call
 	w.pool.Start()
+	// Start the dedicated worker pools
+	for name, p := range w.jobPools {
+		p.Middleware((*workerContext).logJob)
+		p.Start()
+		logger.Infof("Worker pool %s is started", name)
+	}
 	logger.Infof("Basic worker is started")
 
+	// The max concurrency of the jobs written by the pools at the start may be changed at runtime before,
+	// restore the changed ones
+	if err := w.restoreMaxConcurrency(); err != nil {
+		logger.Errorf("Failed to restore the max concurrency of the jobs: %s", err)
+	}
+
 	// Start the reaper
 	w.knownJobs.Range(func(k interface{}, v interface{}) bool {
 		w.reaper.jobTypes = append(w.reaper.jobTypes, k.(string))
@@ -216,6 +298,40 @@ func (w *basicWorker) Enqueue(jobName string, params job.Parameters, isUnique bo
 	return generateResult(j, job.KindGeneric, isUnique, params, webHook), nil
 }
 
+// PrioritizeJob moves the enqueued job to the head of its queue
+func (w *basicWorker) PrioritizeJob(stats *job.Stats) error {
+	if stats == nil || stats.Info == nil {
+		return errors.New("missing job stats")
+	}
+
+	// rebuild the raw JSON of the enqueued job which is the same as the one pushed by the enqueuer
+	rawJSON, err := json.Marshal(&work.Job{
+		Name:       stats.Info.JobName,
+		ID:         stats.Info.JobID,
+		EnqueuedAt: stats.Info.EnqueueTime,
+		Args:       stats.Info.Parameters,
+		Unique:     stats.Info.IsUnique,
+	})
+	if err != nil {
+		return err
+	}
+
+	conn := w.redisPool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	moved, err := redis.Int(prioritizeJobScript.Do(conn, rds.KeyJobs(w.namespace, stats.Info.JobName), rawJSON))
+	if err != nil {
+		return err
+	}
+	if moved == 0 {
+		logger.Debugf("Job %s:%s is not in the queue, skip prioritizing it", stats.Info.JobName, stats.Info.JobID)
+	}
+
+	return nil
+}
+
 // Schedule job
 func (w *basicWorker) Schedule(jobName string, params job.Parameters, runAfterSeconds uint64, isUnique bool, webHook string) (*job.Stats, error) {
 	var (
@@ -421,19 +537,37 @@ func (w *basicWorker) registerJob(name string, j interface{}) (err error) {
 		return
 	}
 
+	retryPolicy := w.retry.PolicyOf(name)
 	// Wrap job
-	redisJob := runner.NewRedisJob(j, w.context, w.ctl)
+	redisJob := runner.NewRedisJob(j, w.context, w.ctl).
+		WithFairScheduler(w.fairSchedulers[w.poolOfJobs[name]]).
+		WithRetryPolicy(retryPolicy).
+		WithLimit(w.limits.LimitOf(name))
 	// Get more info from j
 	theJ := runner.Wrap(j)
+	maxConcurrency := theJ.MaxCurrency()
+	if c, ok := w.maxConcurrency[name]; ok {
+		maxConcurrency = c
+	}
+	opts := work.JobOptions{
+		MaxFails:       theJ.MaxFails(),
+		MaxConcurrency: maxConcurrency,
+		Priority:       job.Priority().For(name),
+		SkipDead:       true,
+	}
+	// Override the max fails and the backoff with the retry policy
+	if retryPolicy != nil {
+		if retryPolicy.MaxAttempts > 0 {
+			opts.MaxFails = retryPolicy.MaxAttempts
+		}
+		opts.Backoff = func(wj *work.Job) int64 {
+			return runner.Backoff(retryPolicy, wj.Fails)
+		}
+	}
 	// Put into the pool
-	w.pool.JobWithOptions(
+	w.poolOf(name).JobWithOptions(
 		name,
-		work.JobOptions{
-			MaxFails:       theJ.MaxFails(),
-			MaxConcurrency: theJ.MaxCurrency(),
-			Priority:       job.Priority().For(name),
-			SkipDead:       true,
-		},
+		opts,
 		// Use generic handler to handle as we do not accept context with this way.
 		func(job *work.Job) error {
 			return redisJob.Run(job)
@@ -447,6 +581,41 @@ func (w *basicWorker) registerJob(name string, j interface{}) (err error) {
 	return nil
 }
 
+// poolOf returns the worker pool which runs the specified job
+func (w *basicWorker) poolOf(name string) *work.WorkerPool {
+	if poolName, ok := w.poolOfJobs[name]; ok {
+		if p, ok := w.jobPools[poolName]; ok {
+			return p
+		}
+	}
+
+	return w.pool
+}
+
+// restoreMaxConcurrency restores the max concurrency of the known jobs changed at runtime
+func (w *basicWorker) restoreMaxConcurrency() error {
+	conn := w.redisPool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	overrides, err := redis.StringMap(conn.Do("HGETALL", rds.KeyMaxConcurrencyOverrides(w.namespace)))
+	if err != nil {
+		return err
+	}
+	for name, c := range overrides {
+		if _, ok := w.knownJobs.Load(name); !ok {
+			continue
+		}
+		if _, err := conn.Do("SET", rds.KeyJobMaxConcurrency(w.namespace, name), c); err != nil {
+			return err
+		}
+		logger.Infof("Restore the max concurrency of job %s to %s", name, c)
+	}
+
+	return nil
+}
+
 // Ping the redis server
 func (w *basicWorker) ping() error {
 	conn := w.redisPool.Get()
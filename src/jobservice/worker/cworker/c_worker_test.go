@@ -28,6 +28,7 @@ import (
 
 	common_dao "github.com/goharbor/harbor/src/common/dao"
 	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/config"
 	"github.com/goharbor/harbor/src/jobservice/env"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/job/impl"
@@ -77,7 +78,7 @@ func (suite *CWorkerTestSuite) SetupSuite() {
 		func(hookURL string, change *job.StatusChange) error { return nil },
 	)
 
-	suite.cWorker = NewWorker(envCtx, suite.namespace, 5, suite.pool, suite.lcmCtl)
+	suite.cWorker = NewWorker(envCtx, suite.namespace, &config.PoolConfig{WorkerCount: 5}, suite.pool, suite.lcmCtl)
 	err := suite.cWorker.RegisterJobs(map[string]interface{}{
 		"fake_job":          (*fakeJob)(nil),
 		"fake_long_run_job": (*fakeLongRunJob)(nil),
@@ -116,6 +117,23 @@ func (suite *CWorkerTestSuite) TestRegisterJobs() {
 	assert.NoError(suite.T(), err, "validate parameters: nil error expected but got %s", err)
 }
 
+// TestPoolOf tests the worker pool selection of the jobs
+func TestPoolOf(t *testing.T) {
+	ctx := &env.Context{
+		SystemContext: context.WithValue(context.Background(), utils.NodeID, utils.GenerateNodeID()),
+	}
+	w := NewWorker(ctx, "{ut_namespace}", &config.PoolConfig{
+		WorkerCount: 5,
+		JobPools: []*config.JobPoolConfig{
+			{Name: "scan", WorkerCount: 2, Jobs: []string{job.ImageScanJob}},
+		},
+	}, &redis.Pool{}, nil).(*basicWorker)
+
+	assert.Equal(t, w.jobPools["scan"], w.poolOf(job.ImageScanJob))
+	assert.Equal(t, w.pool, w.poolOf(job.Replication))
+	assert.NotEqual(t, w.pool, w.poolOf(job.ImageScanJob))
+}
+
 // TestEnqueueJob tests enqueue job
 func (suite *CWorkerTestSuite) TestEnqueueJob() {
 	params := make(job.Parameters)
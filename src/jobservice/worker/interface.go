@@ -50,6 +50,14 @@ type Interface interface {
 	//  error      : if failed to enqueue
 	Enqueue(jobName string, params job.Parameters, isUnique bool, webHook string) (*job.Stats, error)
 
+	// Move the enqueued job to the head of its queue to run it before the other enqueued jobs
+	//
+	// stats *job.Stats : the stats of the enqueued job
+	//
+	// Returns:
+	//  error : if failed to prioritize
+	PrioritizeJob(stats *job.Stats) error
+
 	// Schedule job to run after the specified interval (seconds).
 	//
 	// jobName string         : the name of enqueuing job
@@ -49,6 +49,7 @@ const (
 	OIDCGroup      = "oidc"
 	DatabaseGroup  = "database"
 	QuotaGroup     = "quota"
+	EmailGroup     = "email"
 	// Put all config items do not belong a existing group into basic
 	BasicGroup = "basic"
 	TrivyGroup = "trivy"
@@ -73,6 +74,15 @@ var (
 		{Name: common.CoreLocalURL, Scope: SystemScope, Group: BasicGroup, EnvKey: "CORE_LOCAL_URL", DefaultValue: "http://127.0.0.1:8080", ItemType: &StringType{}, Editable: false},
 		{Name: common.DatabaseType, Scope: SystemScope, Group: BasicGroup, EnvKey: "DATABASE_TYPE", DefaultValue: "postgresql", ItemType: &StringType{}, Editable: false},
 
+		{Name: common.EmailFrom, Scope: UserScope, Group: EmailGroup, EnvKey: "EMAIL_FROM", DefaultValue: "", ItemType: &StringType{}, Editable: false, Description: `The sender of the notification emails, e.g. "harbor <harbor@example.com>"`},
+		{Name: common.EmailHost, Scope: UserScope, Group: EmailGroup, EnvKey: "EMAIL_HOST", DefaultValue: "", ItemType: &StringType{}, Editable: false, Description: `The host of the SMTP server used to send the notification emails`},
+		{Name: common.EmailIdentity, Scope: UserScope, Group: EmailGroup, EnvKey: "EMAIL_IDENTITY", DefaultValue: "", ItemType: &StringType{}, Editable: false, Description: `The identity used to authenticate with the SMTP server, usually empty`},
+		{Name: common.EmailInsecure, Scope: UserScope, Group: EmailGroup, EnvKey: "EMAIL_INSECURE", DefaultValue: "false", ItemType: &BoolType{}, Editable: false, Description: `Whether to skip verifying the certificate of the SMTP server`},
+		{Name: common.EmailPassword, Scope: UserScope, Group: EmailGroup, EnvKey: "EMAIL_PWD", DefaultValue: "", ItemType: &PasswordType{}, Editable: false, Description: `The password used to authenticate with the SMTP server`},
+		{Name: common.EmailPort, Scope: UserScope, Group: EmailGroup, EnvKey: "EMAIL_PORT", DefaultValue: "25", ItemType: &PortType{}, Editable: false, Description: `The port of the SMTP server`},
+		{Name: common.EmailSSL, Scope: UserScope, Group: EmailGroup, EnvKey: "EMAIL_SSL", DefaultValue: "false", ItemType: &BoolType{}, Editable: false, Description: `Whether to connect the SMTP server over SSL/TLS, STARTTLS is used when it's supported by the server otherwise`},
+		{Name: common.EmailUsername, Scope: UserScope, Group: EmailGroup, EnvKey: "EMAIL_USR", DefaultValue: "", ItemType: &StringType{}, Editable: false, Description: `The username used to authenticate with the SMTP server`},
+
 		{Name: common.ExtEndpoint, Scope: SystemScope, Group: BasicGroup, EnvKey: "EXT_ENDPOINT", DefaultValue: "https://host01.com", ItemType: &StringType{}, Editable: false},
 		{Name: common.JobServiceURL, Scope: SystemScope, Group: BasicGroup, EnvKey: "JOBSERVICE_URL", DefaultValue: "http://jobservice:8080", ItemType: &StringType{}, Editable: false},
 
@@ -106,8 +116,12 @@ var (
 		{Name: common.PostGreSQLMaxOpenConns, Scope: SystemScope, Group: DatabaseGroup, EnvKey: "POSTGRESQL_MAX_OPEN_CONNS", DefaultValue: "0", ItemType: &IntType{}, Editable: false},
 		{Name: common.PostGreSQLConnMaxLifetime, Scope: SystemScope, Group: DatabaseGroup, EnvKey: "POSTGRESQL_CONN_MAX_LIFETIME", DefaultValue: "5m", ItemType: &DurationType{}, Editable: false},
 		{Name: common.PostGreSQLConnMaxIdleTime, Scope: SystemScope, Group: DatabaseGroup, EnvKey: "POSTGRESQL_CONN_MAX_IDLE_TIME", DefaultValue: "0", ItemType: &DurationType{}, Editable: false},
+		{Name: common.PostGreSQLStatementTimeout, Scope: SystemScope, Group: DatabaseGroup, EnvKey: "POSTGRESQL_STATEMENT_TIMEOUT", DefaultValue: "0", ItemType: &DurationType{}, Editable: false},
+		{Name: common.PostGreSQLReplicaHost, Scope: SystemScope, Group: DatabaseGroup, EnvKey: "POSTGRESQL_REPLICA_HOST", DefaultValue: "", ItemType: &StringType{}, Editable: false},
+		{Name: common.PostGreSQLReplicaPort, Scope: SystemScope, Group: DatabaseGroup, EnvKey: "POSTGRESQL_REPLICA_PORT", DefaultValue: "0", ItemType: &PortType{}, Editable: false},
 
 		{Name: common.ProjectCreationRestriction, Scope: UserScope, Group: BasicGroup, EnvKey: "PROJECT_CREATION_RESTRICTION", DefaultValue: common.ProCrtRestrEveryone, ItemType: &ProjectCreationRestrictionType{}, Editable: false, Description: `Indicate who can create projects, it could be ''adminonly'' or ''everyone''.`},
+		{Name: common.ProjectCreationGroups, Scope: UserScope, Group: BasicGroup, EnvKey: "PROJECT_CREATION_GROUPS", DefaultValue: "", ItemType: &StringType{}, Editable: true, Description: `The semicolon separated names or LDAP DNs of the groups whose members can create projects when the project creation restriction is ''everyone'', empty means no restriction`},
 		{Name: common.ReadOnly, Scope: UserScope, Group: BasicGroup, EnvKey: "READ_ONLY", DefaultValue: "false", ItemType: &BoolType{}, Editable: false, Description: `The flag to indicate whether Harbor is in readonly mode.`},
 
 		{Name: common.RegistryStorageProviderName, Scope: SystemScope, Group: BasicGroup, EnvKey: "REGISTRY_STORAGE_PROVIDER_NAME", DefaultValue: "filesystem", ItemType: &StringType{}, Editable: false},
@@ -155,8 +169,13 @@ var (
 		{Name: common.MetricPort, Scope: SystemScope, Group: BasicGroup, EnvKey: "METRIC_PORT", DefaultValue: "9090", ItemType: &PortType{}, Editable: true},
 		{Name: common.MetricPath, Scope: SystemScope, Group: BasicGroup, EnvKey: "METRIC_PATH", DefaultValue: "/metrics", ItemType: &StringType{}, Editable: true},
 
+		{Name: common.ProfilingEnabled, Scope: SystemScope, Group: BasicGroup, EnvKey: "PROFILING_ENABLED", DefaultValue: "false", ItemType: &BoolType{}, Editable: false, Description: `Enable the collection of the slowest API routes and database queries and the pprof endpoints`},
+		{Name: common.ProfilingWindow, Scope: SystemScope, Group: BasicGroup, EnvKey: "PROFILING_WINDOW", DefaultValue: "15", ItemType: &IntType{}, Editable: false, Description: `The window in minutes over which the slowest API routes and database queries are collected`},
+		{Name: common.ProfilingTopN, Scope: SystemScope, Group: BasicGroup, EnvKey: "PROFILING_TOP_N", DefaultValue: "20", ItemType: &IntType{}, Editable: false, Description: `The count of the slowest API routes and database queries in the profiling report`},
+
 		{Name: common.QuotaPerProjectEnable, Scope: UserScope, Group: QuotaGroup, EnvKey: "QUOTA_PER_PROJECT_ENABLE", DefaultValue: "true", ItemType: &BoolType{}, Editable: true, Description: `Enable quota per project`},
 		{Name: common.StoragePerProject, Scope: UserScope, Group: QuotaGroup, EnvKey: "STORAGE_PER_PROJECT", DefaultValue: "-1", ItemType: &QuotaType{}, Editable: true, Description: `The storage quota per project`},
+		{Name: common.CountPerProject, Scope: UserScope, Group: QuotaGroup, EnvKey: "COUNT_PER_PROJECT", DefaultValue: "-1", ItemType: &QuotaType{}, Editable: true, Description: `The quota of the artifact count per project`},
 
 		{Name: common.TraceEnabled, Scope: SystemScope, Group: BasicGroup, EnvKey: "TRACE_ENABLED", DefaultValue: "false", ItemType: &BoolType{}, Editable: false, Description: `Enable trace`},
 		{Name: common.TraceServiceName, Scope: SystemScope, Group: BasicGroup, EnvKey: "TRACE_SERVICE_NAME", DefaultValue: "", ItemType: &StringType{}, Editable: false, Description: `The service name of the trace`},
@@ -184,8 +203,35 @@ var (
 		{Name: common.GDPRDeleteUser, Scope: SystemScope, Group: GDPRGroup, EnvKey: "GDPR_DELETE_USER", DefaultValue: "false", ItemType: &BoolType{}, Editable: false, Description: `The flag indicates if a user should be deleted compliant with GDPR.`},
 
 		{Name: common.AuditLogForwardEndpoint, Scope: UserScope, Group: BasicGroup, EnvKey: "AUDIT_LOG_FORWARD_ENDPOINT", DefaultValue: "", ItemType: &StringType{}, Editable: false, Description: `The endpoint to forward the audit log.`},
+		{Name: common.AuditLogForwardFormat, Scope: UserScope, Group: BasicGroup, EnvKey: "AUDIT_LOG_FORWARD_FORMAT", DefaultValue: "text", ItemType: &StringType{}, Editable: false, Description: `The format of the forwarded audit log, "text", "cef" or "json".`},
+		{Name: common.AuditLogForwardToken, Scope: UserScope, Group: BasicGroup, EnvKey: "AUDIT_LOG_FORWARD_TOKEN", DefaultValue: "", ItemType: &PasswordType{}, Editable: false, Description: `The bearer token to access the HTTPS audit log collector.`},
 		{Name: common.SkipAuditLogDatabase, Scope: UserScope, Group: BasicGroup, EnvKey: "SKIP_LOG_AUDIT_DATABASE", DefaultValue: "false", ItemType: &BoolType{}, Editable: false, Description: `The option to skip audit log in database`},
 
 		{Name: common.SessionTimeout, Scope: UserScope, Group: BasicGroup, EnvKey: "SESSION_TIMEOUT", DefaultValue: "60", ItemType: &Int64Type{}, Editable: true, Description: `The session timeout in minutes`},
+
+		{Name: common.SCIMToken, Scope: UserScope, Group: BasicGroup, ItemType: &PasswordType{}, Description: `The bearer token used by the identity provider to access the SCIM endpoint`},
+
+		{Name: common.JobArchiveRetentionDays, Scope: UserScope, Group: BasicGroup, EnvKey: "JOB_ARCHIVE_RETENTION_DAYS", DefaultValue: "90", ItemType: &Int64Type{}, Editable: true, Description: `The days to keep the archived jobs, 0 means keeping them forever`},
+
+		{Name: common.IfMatchRequired, Scope: UserScope, Group: BasicGroup, EnvKey: "IF_MATCH_REQUIRED", DefaultValue: "false", ItemType: &BoolType{}, Editable: true, Description: `The flag to indicate whether the "If-Match" header is required when updating the projects, labels, configurations and policies`},
+
+		{Name: common.APIRateLimitUser, Scope: UserScope, Group: BasicGroup, EnvKey: "API_RATE_LIMIT_USER", DefaultValue: "0", ItemType: &IntType{}, Editable: true, Description: `The max requests per second of the APIs for each user, 0 means no limit`},
+		{Name: common.APIRateLimitRobot, Scope: UserScope, Group: BasicGroup, EnvKey: "API_RATE_LIMIT_ROBOT", DefaultValue: "0", ItemType: &IntType{}, Editable: true, Description: `The max requests per second of the APIs for each robot account, 0 means no limit`},
+		{Name: common.APIRateLimitIP, Scope: UserScope, Group: BasicGroup, EnvKey: "API_RATE_LIMIT_IP", DefaultValue: "0", ItemType: &IntType{}, Editable: true, Description: `The max requests per second of the APIs for the anonymous requests from each IP, 0 means no limit`},
+		{Name: common.APIRateLimitBurst, Scope: UserScope, Group: BasicGroup, EnvKey: "API_RATE_LIMIT_BURST", DefaultValue: "0", ItemType: &IntType{}, Editable: true, Description: `The max burst of the requests allowed by the rate limits of the APIs, the rate is used as the burst if it's less than the rate`},
+
+		{Name: common.ReplicationMaxConcurrentTransfers, Scope: UserScope, Group: BasicGroup, EnvKey: "REPLICATION_MAX_CONCURRENT_TRANSFERS", DefaultValue: "0", ItemType: &IntType{}, Editable: true, Description: `The max in-flight blob transfers of all the replications in each jobservice, 0 means no limit`},
+
+		{Name: common.CORSAllowedOrigins, Scope: UserScope, Group: BasicGroup, EnvKey: "CORS_ALLOWED_ORIGINS", DefaultValue: "", ItemType: &StringType{}, Editable: true, Description: `The comma separated origins allowed to call the APIs cross-origin, e.g. "https://tools.example.com", "*" allows any origin, empty means CORS is disabled`},
+		{Name: common.CORSAllowedMethods, Scope: UserScope, Group: BasicGroup, EnvKey: "CORS_ALLOWED_METHODS", DefaultValue: "GET,HEAD,POST,PUT,PATCH,DELETE", ItemType: &StringType{}, Editable: true, Description: `The comma separated methods allowed in the cross-origin requests`},
+		{Name: common.CORSAllowedHeaders, Scope: UserScope, Group: BasicGroup, EnvKey: "CORS_ALLOWED_HEADERS", DefaultValue: "Authorization,Content-Type,If-Match,X-Harbor-CSRF-Token,X-Request-Id", ItemType: &StringType{}, Editable: true, Description: `The comma separated headers allowed in the cross-origin requests`},
+		{Name: common.CORSAllowCredentials, Scope: UserScope, Group: BasicGroup, EnvKey: "CORS_ALLOW_CREDENTIALS", DefaultValue: "false", ItemType: &BoolType{}, Editable: true, Description: `The flag to indicate whether the cross-origin requests can carry the credentials, e.g. the cookies and the authorization header`},
+
+		{Name: common.BannerMessage, Scope: UserScope, Group: BasicGroup, EnvKey: "BANNER_MESSAGE", DefaultValue: "", ItemType: &StringType{}, Editable: true, Description: `The announcement banner shown on the UI in JSON format, e.g. {"message":"...","type":"warning","closable":true,"from_time":"...","to_time":"..."}, empty means no banner`},
+		{Name: common.MaintenanceMode, Scope: UserScope, Group: BasicGroup, EnvKey: "MAINTENANCE_MODE", DefaultValue: "false", ItemType: &BoolType{}, Editable: true, Description: `The flag to indicate whether the maintenance mode is turned on, the writes except the ones of the system admins via the APIs are rejected with 503 in it while the pulls keep working`},
+		{Name: common.MaintenanceStartTime, Scope: UserScope, Group: BasicGroup, EnvKey: "MAINTENANCE_START_TIME", DefaultValue: "", ItemType: &StringType{}, Editable: true, Description: `The time in RFC3339 format when the maintenance mode takes effect, empty means immediately`},
+		{Name: common.MaintenanceEndTime, Scope: UserScope, Group: BasicGroup, EnvKey: "MAINTENANCE_END_TIME", DefaultValue: "", ItemType: &StringType{}, Editable: true, Description: `The estimated time in RFC3339 format when the maintenance finishes, it's used to calculate the "Retry-After" header`},
+
+		{Name: common.ConfigSyncRegistryID, Scope: UserScope, Group: BasicGroup, EnvKey: "CONFIG_SYNC_REGISTRY_ID", DefaultValue: "0", ItemType: &Int64Type{}, Editable: true, Description: `The ID of the registry endpoint of the primary Harbor instance the configurations, labels, project skeletons and policies are synced from, they're read-only locally when it's set, 0 means the config sync is disabled`},
 	}
 )
@@ -15,9 +15,23 @@
 package models
 
 import (
+	"time"
+
 	"github.com/beego/beego/v2/client/orm"
 )
 
+const (
+	// BannerTypeInfo is the type of the informational banner
+	BannerTypeInfo = "info"
+	// BannerTypeWarning is the type of the warning banner
+	BannerTypeWarning = "warning"
+	// BannerTypeDanger is the type of the danger banner
+	BannerTypeDanger = "danger"
+
+	// the default interval suggested to retry the rejected request when the end time of the maintenance is unknown
+	defaultMaintenanceRetryAfter = 5 * time.Minute
+)
+
 // HTTPAuthProxy wraps the settings for HTTP auth proxy
 type HTTPAuthProxy struct {
 	Endpoint            string   `json:"endpoint"`
@@ -49,6 +63,79 @@ type OIDCSetting struct {
 // QuotaSetting wraps the settings for Quota
 type QuotaSetting struct {
 	StoragePerProject int64 `json:"storage_per_project"`
+	CountPerProject   int64 `json:"count_per_project"`
+}
+
+// RateLimitSetting wraps the settings for the rate limits of the APIs, the limits are the max requests per second
+type RateLimitSetting struct {
+	User  int `json:"user"`
+	Robot int `json:"robot"`
+	IP    int `json:"ip"`
+	Burst int `json:"burst"`
+}
+
+// CORSSetting wraps the settings for the cross-origin requests of the APIs
+type CORSSetting struct {
+	AllowedOrigins   []string `json:"allowed_origins"`
+	AllowedMethods   []string `json:"allowed_methods"`
+	AllowedHeaders   []string `json:"allowed_headers"`
+	AllowCredentials bool     `json:"allow_credentials"`
+}
+
+// EmailSetting wraps the settings of the SMTP server used to send the notification emails
+type EmailSetting struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password,omitempty"`
+	From     string `json:"from"`
+	Identity string `json:"identity"`
+	SSL      bool   `json:"ssl"`
+	Insecure bool   `json:"insecure"`
+}
+
+// BannerMessage is the announcement banner shown on the UI
+type BannerMessage struct {
+	Message  string     `json:"message"`
+	Type     string     `json:"type"`
+	Closable bool       `json:"closable"`
+	FromTime *time.Time `json:"from_time,omitempty"`
+	ToTime   *time.Time `json:"to_time,omitempty"`
+}
+
+// Active returns whether the banner should be shown at the specified time
+func (b *BannerMessage) Active(now time.Time) bool {
+	if b == nil || len(b.Message) == 0 {
+		return false
+	}
+	if b.FromTime != nil && now.Before(*b.FromTime) {
+		return false
+	}
+	return b.ToTime == nil || now.Before(*b.ToTime)
+}
+
+// MaintenanceSetting wraps the settings for the maintenance mode
+type MaintenanceSetting struct {
+	Enabled   bool       `json:"enabled"`
+	StartTime *time.Time `json:"start_time,omitempty"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+}
+
+// Active returns whether the maintenance mode takes effect at the specified time. The end time is only an estimation,
+// the mode keeps taking effect until it's turned off to avoid the writes in an overrunning upgrade
+func (m *MaintenanceSetting) Active(now time.Time) bool {
+	if m == nil || !m.Enabled {
+		return false
+	}
+	return m.StartTime == nil || !now.Before(*m.StartTime)
+}
+
+// RetryAfter returns the interval suggested to retry the requests rejected at the specified time
+func (m *MaintenanceSetting) RetryAfter(now time.Time) time.Duration {
+	if m != nil && m.EndTime != nil && m.EndTime.After(now) {
+		return m.EndTime.Sub(now)
+	}
+	return defaultMaintenanceRetryAfter
 }
 
 func init() {
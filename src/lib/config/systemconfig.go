@@ -34,6 +34,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/models"
@@ -70,6 +71,22 @@ func RegistryURL() (string, error) {
 	return url, nil
 }
 
+// RegistryShards returns the URLs of the registries serving the projects assigned to them instead of the default
+// registry, keyed by the names of the shards. They're configured by the env "REGISTRY_SHARDS" in the format of
+// "name1=url1,name2=url2", all the shards share the credential of the default registry
+func RegistryShards() map[string]string {
+	shards := map[string]string{}
+	for _, item := range strings.Split(os.Getenv("REGISTRY_SHARDS"), ",") {
+		name, url, found := strings.Cut(strings.TrimSpace(item), "=")
+		name, url = strings.TrimSpace(name), strings.TrimSpace(url)
+		if !found || len(name) == 0 || len(url) == 0 {
+			continue
+		}
+		shards[name] = strings.TrimSuffix(url, "/")
+	}
+	return shards
+}
+
 // InternalJobServiceURL returns jobservice URL for internal communication between Harbor containers
 func InternalJobServiceURL() string {
 	return os.Getenv("JOBSERVICE_URL")
@@ -240,6 +257,15 @@ func Metric() *models.Metric {
 	}
 }
 
+// Profiling returns the settings of the collection of the slowest API routes and database queries
+func Profiling() *models.Profiling {
+	return &models.Profiling{
+		Enabled: DefaultMgr().Get(backgroundCtx, common.ProfilingEnabled).GetBool(),
+		Window:  time.Duration(DefaultMgr().Get(backgroundCtx, common.ProfilingWindow).GetInt()) * time.Minute,
+		TopN:    DefaultMgr().Get(backgroundCtx, common.ProfilingTopN).GetInt(),
+	}
+}
+
 // InitialAdminPassword returns the initial password for administrator
 func InitialAdminPassword() (string, error) {
 	return DefaultMgr().Get(backgroundCtx, common.AdminInitialPassword).GetString(), nil
@@ -274,16 +300,19 @@ func Database() (*models.Database, error) {
 	database := &models.Database{}
 	database.Type = DefaultMgr().Get(backgroundCtx, common.DatabaseType).GetString()
 	postgresql := &models.PostGreSQL{
-		Host:            DefaultMgr().Get(backgroundCtx, common.PostGreSQLHOST).GetString(),
-		Port:            DefaultMgr().Get(backgroundCtx, common.PostGreSQLPort).GetInt(),
-		Username:        DefaultMgr().Get(backgroundCtx, common.PostGreSQLUsername).GetString(),
-		Password:        DefaultMgr().Get(backgroundCtx, common.PostGreSQLPassword).GetPassword(),
-		Database:        DefaultMgr().Get(backgroundCtx, common.PostGreSQLDatabase).GetString(),
-		SSLMode:         DefaultMgr().Get(backgroundCtx, common.PostGreSQLSSLMode).GetString(),
-		MaxIdleConns:    DefaultMgr().Get(backgroundCtx, common.PostGreSQLMaxIdleConns).GetInt(),
-		MaxOpenConns:    DefaultMgr().Get(backgroundCtx, common.PostGreSQLMaxOpenConns).GetInt(),
-		ConnMaxLifetime: DefaultMgr().Get(backgroundCtx, common.PostGreSQLConnMaxLifetime).GetDuration(),
-		ConnMaxIdleTime: DefaultMgr().Get(backgroundCtx, common.PostGreSQLConnMaxIdleTime).GetDuration(),
+		Host:             DefaultMgr().Get(backgroundCtx, common.PostGreSQLHOST).GetString(),
+		Port:             DefaultMgr().Get(backgroundCtx, common.PostGreSQLPort).GetInt(),
+		Username:         DefaultMgr().Get(backgroundCtx, common.PostGreSQLUsername).GetString(),
+		Password:         DefaultMgr().Get(backgroundCtx, common.PostGreSQLPassword).GetPassword(),
+		Database:         DefaultMgr().Get(backgroundCtx, common.PostGreSQLDatabase).GetString(),
+		SSLMode:          DefaultMgr().Get(backgroundCtx, common.PostGreSQLSSLMode).GetString(),
+		MaxIdleConns:     DefaultMgr().Get(backgroundCtx, common.PostGreSQLMaxIdleConns).GetInt(),
+		MaxOpenConns:     DefaultMgr().Get(backgroundCtx, common.PostGreSQLMaxOpenConns).GetInt(),
+		ConnMaxLifetime:  DefaultMgr().Get(backgroundCtx, common.PostGreSQLConnMaxLifetime).GetDuration(),
+		ConnMaxIdleTime:  DefaultMgr().Get(backgroundCtx, common.PostGreSQLConnMaxIdleTime).GetDuration(),
+		StatementTimeout: DefaultMgr().Get(backgroundCtx, common.PostGreSQLStatementTimeout).GetDuration(),
+		ReplicaHost:      DefaultMgr().Get(backgroundCtx, common.PostGreSQLReplicaHost).GetString(),
+		ReplicaPort:      DefaultMgr().Get(backgroundCtx, common.PostGreSQLReplicaPort).GetInt(),
 	}
 	database.PostGreSQL = postgresql
 
@@ -19,6 +19,7 @@ import (
 	"path"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -312,3 +313,51 @@ func TestSplitAndTrim(t *testing.T) {
 		assert.Equal(t, c.expect, SplitAndTrim(c.s, c.sep))
 	}
 }
+
+func TestRegistryShards(t *testing.T) {
+	t.Setenv("REGISTRY_SHARDS", "")
+	assert.Empty(t, RegistryShards())
+
+	t.Setenv("REGISTRY_SHARDS", "large=http://registry-large:5000/, small = http://registry-small:5000,invalid,=http://registry:5000")
+	assert.Equal(t, map[string]string{
+		"large": "http://registry-large:5000",
+		"small": "http://registry-small:5000",
+	}, RegistryShards())
+}
+
+func TestParseBannerMessage(t *testing.T) {
+	banner, err := ParseBannerMessage("")
+	assert.Nil(t, err)
+	assert.Nil(t, banner)
+
+	banner, err = ParseBannerMessage(`{"message":"upgrade tonight","closable":true,"from_time":"2023-01-01T00:00:00Z","to_time":"2023-01-02T00:00:00Z"}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "upgrade tonight", banner.Message)
+	assert.Equal(t, models.BannerTypeInfo, banner.Type)
+	assert.True(t, banner.Closable)
+	assert.True(t, banner.Active(banner.FromTime.Add(time.Hour)))
+	assert.False(t, banner.Active(banner.ToTime.Add(time.Hour)))
+
+	for _, s := range []string{
+		`not json`,
+		`{"message":""}`,
+		`{"message":"a","type":"unknown"}`,
+		`{"message":"a","from_time":"2023-01-02T00:00:00Z","to_time":"2023-01-01T00:00:00Z"}`,
+	} {
+		_, err = ParseBannerMessage(s)
+		assert.NotNil(t, err, s)
+	}
+}
+
+func TestParseMaintenanceTime(t *testing.T) {
+	tm, err := ParseMaintenanceTime(" ")
+	assert.Nil(t, err)
+	assert.Nil(t, tm)
+
+	tm, err = ParseMaintenanceTime("2023-01-01T08:00:00+08:00")
+	assert.Nil(t, err)
+	assert.True(t, tm.Equal(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	_, err = ParseMaintenanceTime("2023-01-01 08:00")
+	assert.NotNil(t, err)
+}
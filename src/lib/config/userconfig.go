@@ -16,7 +16,9 @@ package config
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
+	"time"
 
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/models"
@@ -113,6 +115,12 @@ func OnlyAdminCreateProject(ctx context.Context) (bool, error) {
 	return DefaultMgr().Get(ctx, common.ProjectCreationRestriction).GetString() == common.ProCrtRestrAdmOnly, nil
 }
 
+// ProjectCreationGroups returns the names or LDAP DNs of the groups whose members can create projects,
+// it's empty when the creation isn't restricted to the groups
+func ProjectCreationGroups(ctx context.Context) []string {
+	return SplitAndTrim(DefaultMgr().Get(ctx, common.ProjectCreationGroups).GetString(), ";")
+}
+
 // UAASettings returns the UAASettings to access UAA service.
 func UAASettings(ctx context.Context) (*models.UAASettings, error) {
 	mgr := DefaultMgr()
@@ -206,6 +214,7 @@ func QuotaSetting(ctx context.Context) (*cfgModels.QuotaSetting, error) {
 	}
 	return &cfgModels.QuotaSetting{
 		StoragePerProject: DefaultMgr().Get(ctx, common.StoragePerProject).GetInt64(),
+		CountPerProject:   DefaultMgr().Get(ctx, common.CountPerProject).GetInt64(),
 	}, nil
 }
 
@@ -245,7 +254,133 @@ func AuditLogForwardEndpoint(ctx context.Context) string {
 	return DefaultMgr().Get(ctx, common.AuditLogForwardEndpoint).GetString()
 }
 
+// AuditLogForwardFormat returns the format of the forwarded audit log
+func AuditLogForwardFormat(ctx context.Context) string {
+	return DefaultMgr().Get(ctx, common.AuditLogForwardFormat).GetString()
+}
+
+// AuditLogForwardToken returns the bearer token to access the HTTPS audit log collector
+func AuditLogForwardToken(ctx context.Context) string {
+	return DefaultMgr().Get(ctx, common.AuditLogForwardToken).GetString()
+}
+
 // SkipAuditLogDatabase returns the audit log forward endpoint
 func SkipAuditLogDatabase(ctx context.Context) bool {
 	return DefaultMgr().Get(ctx, common.SkipAuditLogDatabase).GetBool()
 }
+
+// SCIMToken returns the bearer token of the SCIM endpoint, the SCIM endpoint is disabled when it's empty
+func SCIMToken(ctx context.Context) string {
+	return DefaultMgr().Get(ctx, common.SCIMToken).GetString()
+}
+
+// JobArchiveRetentionDays returns the days to keep the archived jobs, the archived jobs are kept forever when it's 0
+func JobArchiveRetentionDays(ctx context.Context) int64 {
+	return DefaultMgr().Get(ctx, common.JobArchiveRetentionDays).GetInt64()
+}
+
+// IfMatchRequired returns whether the "If-Match" header is required when updating the resources supporting the conditional requests
+func IfMatchRequired(ctx context.Context) bool {
+	return DefaultMgr().Get(ctx, common.IfMatchRequired).GetBool()
+}
+
+// ConfigSyncRegistryID returns the ID of the registry endpoint of the primary instance the configurations are synced from,
+// 0 means the config sync is disabled
+func ConfigSyncRegistryID(ctx context.Context) int64 {
+	return DefaultMgr().Get(ctx, common.ConfigSyncRegistryID).GetInt64()
+}
+
+// BannerMessage returns the announcement banner, nil is returned if there is no banner configured
+func BannerMessage(ctx context.Context) (*cfgModels.BannerMessage, error) {
+	return ParseBannerMessage(DefaultMgr().Get(ctx, common.BannerMessage).GetString())
+}
+
+// ParseBannerMessage parses and validates the banner in JSON format, nil is returned for the empty string
+func ParseBannerMessage(s string) (*cfgModels.BannerMessage, error) {
+	if len(strings.TrimSpace(s)) == 0 {
+		return nil, nil
+	}
+	banner := &cfgModels.BannerMessage{}
+	if err := json.Unmarshal([]byte(s), banner); err != nil {
+		return nil, errors.Wrap(err, "invalid banner message")
+	}
+	if len(strings.TrimSpace(banner.Message)) == 0 {
+		return nil, errors.New("the message of the banner is required")
+	}
+	switch banner.Type {
+	case "":
+		banner.Type = cfgModels.BannerTypeInfo
+	case cfgModels.BannerTypeInfo, cfgModels.BannerTypeWarning, cfgModels.BannerTypeDanger:
+	default:
+		return nil, errors.Errorf("invalid type of the banner %q, it must be %q, %q or %q",
+			banner.Type, cfgModels.BannerTypeInfo, cfgModels.BannerTypeWarning, cfgModels.BannerTypeDanger)
+	}
+	if banner.FromTime != nil && banner.ToTime != nil && !banner.ToTime.After(*banner.FromTime) {
+		return nil, errors.New("the to_time of the banner must be after the from_time")
+	}
+	return banner, nil
+}
+
+// Maintenance returns the setting of the maintenance mode, the invalid times are ignored
+func Maintenance(ctx context.Context) *cfgModels.MaintenanceSetting {
+	mgr := DefaultMgr()
+	setting := &cfgModels.MaintenanceSetting{
+		Enabled: mgr.Get(ctx, common.MaintenanceMode).GetBool(),
+	}
+	var err error
+	if setting.StartTime, err = ParseMaintenanceTime(mgr.Get(ctx, common.MaintenanceStartTime).GetString()); err != nil {
+		log.Warningf("failed to parse the start time of the maintenance: %v", err)
+	}
+	if setting.EndTime, err = ParseMaintenanceTime(mgr.Get(ctx, common.MaintenanceEndTime).GetString()); err != nil {
+		log.Warningf("failed to parse the end time of the maintenance: %v", err)
+	}
+	return setting
+}
+
+// ParseMaintenanceTime parses the time of the maintenance in RFC3339 format, nil is returned for the empty string
+func ParseMaintenanceTime(s string) (*time.Time, error) {
+	if len(strings.TrimSpace(s)) == 0 {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(s))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid time %q of the maintenance, it must be in RFC3339 format", s)
+	}
+	return &t, nil
+}
+
+// APIRateLimit returns the setting of the rate limits of the APIs
+func APIRateLimit(ctx context.Context) *cfgModels.RateLimitSetting {
+	return &cfgModels.RateLimitSetting{
+		User:  DefaultMgr().Get(ctx, common.APIRateLimitUser).GetInt(),
+		Robot: DefaultMgr().Get(ctx, common.APIRateLimitRobot).GetInt(),
+		IP:    DefaultMgr().Get(ctx, common.APIRateLimitIP).GetInt(),
+		Burst: DefaultMgr().Get(ctx, common.APIRateLimitBurst).GetInt(),
+	}
+}
+
+// CORS returns the setting of the cross-origin requests of the APIs
+func CORS(ctx context.Context) *cfgModels.CORSSetting {
+	mgr := DefaultMgr()
+	return &cfgModels.CORSSetting{
+		AllowedOrigins:   SplitAndTrim(mgr.Get(ctx, common.CORSAllowedOrigins).GetString(), ","),
+		AllowedMethods:   SplitAndTrim(mgr.Get(ctx, common.CORSAllowedMethods).GetString(), ","),
+		AllowedHeaders:   SplitAndTrim(mgr.Get(ctx, common.CORSAllowedHeaders).GetString(), ","),
+		AllowCredentials: mgr.Get(ctx, common.CORSAllowCredentials).GetBool(),
+	}
+}
+
+// Email returns the settings of the SMTP server used to send the notification emails
+func Email(ctx context.Context) *cfgModels.EmailSetting {
+	mgr := DefaultMgr()
+	return &cfgModels.EmailSetting{
+		Host:     mgr.Get(ctx, common.EmailHost).GetString(),
+		Port:     mgr.Get(ctx, common.EmailPort).GetInt(),
+		Username: mgr.Get(ctx, common.EmailUsername).GetString(),
+		Password: mgr.Get(ctx, common.EmailPassword).GetPassword(),
+		From:     mgr.Get(ctx, common.EmailFrom).GetString(),
+		Identity: mgr.Get(ctx, common.EmailIdentity).GetString(),
+		SSL:      mgr.Get(ctx, common.EmailSSL).GetBool(),
+		Insecure: mgr.Get(ctx, common.EmailInsecure).GetBool(),
+	}
+}
@@ -26,6 +26,7 @@ const (
 	contextKeyArtifactInfo contextKey = "artifactInfo"
 	contextKeyAuthMode     contextKey = "authMode"
 	contextKeyCarrySession contextKey = "carrySession"
+	contextKeyRequestID    contextKey = "requestID"
 )
 
 // ArtifactInfo wraps the artifact info extracted from the request to "/v2/"
@@ -112,3 +113,18 @@ func GetCarrySession(ctx context.Context) bool {
 	}
 	return carrySession
 }
+
+// WithRequestID returns a context with the request ID set
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return setToContext(ctx, contextKeyRequestID, requestID)
+}
+
+// GetRequestID gets the request ID from the context
+func GetRequestID(ctx context.Context) string {
+	requestID := ""
+	value := getFromContext(ctx, contextKeyRequestID)
+	if value != nil {
+		requestID, _ = value.(string)
+	}
+	return requestID
+}
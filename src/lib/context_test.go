@@ -40,3 +40,9 @@ func TestGetAPIVersion(t *testing.T) {
 	version = GetAPIVersion(ctx)
 	assert.Equal(t, "1.0", version)
 }
+
+func TestGetRequestID(t *testing.T) {
+	assert.Empty(t, GetRequestID(nil))
+	assert.Empty(t, GetRequestID(context.Background()))
+	assert.Equal(t, "1", GetRequestID(WithRequestID(context.Background(), "1")))
+}
@@ -15,13 +15,24 @@
 package encrypt
 
 import (
+	"fmt"
 	"os"
+	"strings"
 	"sync"
 
 	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/lib/log"
 )
 
+const (
+	// StoreLocal encrypts the secrets with AES and the key read from the key file
+	StoreLocal = "local"
+	// StoreVault encrypts the secrets with the transit secrets engine of HashiCorp Vault
+	StoreVault = "vault"
+	// StoreKMS encrypts the secrets with AWS KMS
+	StoreKMS = "kms"
+)
+
 var (
 	defaultKeyPath = "/etc/core/key"
 )
@@ -50,7 +61,8 @@ func NewAESEncryptor(keyProvider KeyProvider) Encryptor {
 var encryptInstance Encryptor
 var encryptOnce sync.Once
 
-// Instance ... Get instance of encryptor
+// Instance ... Get instance of encryptor, the secrets are encrypted by the secret store set by the env "SECRET_STORE"
+// and the ciphertext is always decrypted by the store which generated it
 func Instance() Encryptor {
 	encryptOnce.Do(func() {
 		kp := os.Getenv("KEY_PATH")
@@ -58,11 +70,106 @@ func Instance() Encryptor {
 			kp = defaultKeyPath
 		}
 		log.Infof("the path of key used by key provider: %s", kp)
-		encryptInstance = NewAESEncryptor(NewFileKeyProvider(kp))
+		encryptInstance = newStoreEncryptor(NewAESEncryptor(NewFileKeyProvider(kp)))
 	})
 	return encryptInstance
 }
 
+// CurrentStore returns the secret store used to encrypt the secrets
+func CurrentStore() string {
+	store := strings.ToLower(os.Getenv("SECRET_STORE"))
+	if len(store) == 0 {
+		return StoreLocal
+	}
+	return store
+}
+
+// StoreOf returns the secret store which generated the ciphertext,
+// an empty string is returned if the value isn't encrypted by any of them
+func StoreOf(ciphertext string) string {
+	switch {
+	case strings.HasPrefix(ciphertext, utils.EncryptHeaderV1):
+		return StoreLocal
+	case strings.HasPrefix(ciphertext, VaultHeader):
+		return StoreVault
+	case strings.HasPrefix(ciphertext, KMSHeader):
+		return StoreKMS
+	default:
+		return ""
+	}
+}
+
+// storeEncryptor encrypts the secrets with the configured secret store and decrypts the ciphertext with the store which
+// generated it, so the secrets encrypted before switching the store can still be read until they are migrated
+type storeEncryptor struct {
+	store string
+	local Encryptor
+	vault Encryptor
+	kms   Encryptor
+}
+
+func newStoreEncryptor(local Encryptor) Encryptor {
+	s := &storeEncryptor{
+		store: CurrentStore(),
+		local: local,
+	}
+	if addr := os.Getenv("VAULT_ADDR"); len(addr) > 0 {
+		s.vault = NewVaultEncryptor(addr, os.Getenv("VAULT_TOKEN"), os.Getenv("VAULT_NAMESPACE"),
+			os.Getenv("VAULT_TRANSIT_MOUNT"), os.Getenv("VAULT_TRANSIT_KEY"))
+	}
+	if keyID := os.Getenv("KMS_KEY_ID"); len(keyID) > 0 {
+		kms, err := NewKMSEncryptor(os.Getenv("KMS_REGION"), keyID)
+		if err != nil {
+			log.Errorf("failed to initialize the kms encryptor: %v", err)
+		} else {
+			s.kms = kms
+		}
+	}
+	log.Infof("the secret store used to encrypt the secrets: %s", s.store)
+	return s
+}
+
+func (s *storeEncryptor) get(store string) (Encryptor, error) {
+	var encryptor Encryptor
+	switch store {
+	case StoreLocal:
+		encryptor = s.local
+	case StoreVault:
+		encryptor = s.vault
+	case StoreKMS:
+		encryptor = s.kms
+	default:
+		return nil, fmt.Errorf("unsupported secret store: %s", store)
+	}
+	if encryptor == nil {
+		return nil, fmt.Errorf("the secret store %s isn't configured", store)
+	}
+	return encryptor, nil
+}
+
+// Encrypt ...
+func (s *storeEncryptor) Encrypt(plaintext string) (string, error) {
+	encryptor, err := s.get(s.store)
+	if err != nil {
+		return "", err
+	}
+	return encryptor.Encrypt(plaintext)
+}
+
+// Decrypt ...
+func (s *storeEncryptor) Decrypt(ciphertext string) (string, error) {
+	store := StoreOf(ciphertext)
+	if len(store) == 0 {
+		// the values encoded by base64 only are handled by the local store
+		store = StoreLocal
+	}
+	encryptor, err := s.get(store)
+	if err != nil {
+		return "", err
+	}
+	return encryptor.Decrypt(ciphertext)
+}
+
 // Encrypt ...
 func (a *AESEncryptor) Encrypt(plaintext string) (string, error) {
 	key, err := a.keyProvider.Get(a.keyParams)
@@ -0,0 +1,82 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encrypt
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+)
+
+// KMSHeader is the prefix of the ciphertext encrypted by AWS KMS
+const KMSHeader = "<enc-kms>"
+
+// KMSEncryptor encrypts and decrypts the strings with a key of AWS KMS, the credentials are
+// resolved by the default credential chain of the AWS SDK (environment variables, shared config, instance role, etc.)
+type KMSEncryptor struct {
+	keyID  string
+	client *kms.KMS
+}
+
+// NewKMSEncryptor returns an instance of KMSEncryptor, keyID is the ID, ARN or alias of the KMS key
+func NewKMSEncryptor(region, keyID string) (Encryptor, error) {
+	config := aws.NewConfig()
+	if len(region) > 0 {
+		config = config.WithRegion(region)
+	}
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, err
+	}
+	return &KMSEncryptor{
+		keyID:  keyID,
+		client: kms.New(sess),
+	}, nil
+}
+
+// Encrypt ...
+func (k *KMSEncryptor) Encrypt(plaintext string) (string, error) {
+	out, err := k.client.Encrypt(&kms.EncryptInput{
+		KeyId:     aws.String(k.keyID),
+		Plaintext: []byte(plaintext),
+	})
+	if err != nil {
+		return "", err
+	}
+	return KMSHeader + base64.StdEncoding.EncodeToString(out.CiphertextBlob), nil
+}
+
+// Decrypt ...
+func (k *KMSEncryptor) Decrypt(ciphertext string) (string, error) {
+	if !strings.HasPrefix(ciphertext, KMSHeader) {
+		return "", fmt.Errorf("the ciphertext isn't encrypted by kms")
+	}
+	blob, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(ciphertext, KMSHeader))
+	if err != nil {
+		return "", err
+	}
+	out, err := k.client.Decrypt(&kms.DecryptInput{
+		KeyId:          aws.String(k.keyID),
+		CiphertextBlob: blob,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(out.Plaintext), nil
+}
@@ -0,0 +1,128 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encrypt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// VaultHeader is the prefix of the ciphertext generated by the transit secrets engine of Vault
+	VaultHeader = "vault:"
+
+	defaultVaultTransitMount = "transit"
+)
+
+// VaultEncryptor encrypts and decrypts the strings with a key of the transit secrets engine of HashiCorp Vault,
+// the key never leaves Vault and the ciphertext returned by Vault is stored as it is
+type VaultEncryptor struct {
+	addr      string
+	token     string
+	namespace string
+	mount     string
+	key       string
+	client    *http.Client
+}
+
+// NewVaultEncryptor returns an instance of VaultEncryptor,
+// addr is the address of Vault, mount is the path where the transit engine is mounted and key is the name of the transit key
+func NewVaultEncryptor(addr, token, namespace, mount, key string) Encryptor {
+	if len(mount) == 0 {
+		mount = defaultVaultTransitMount
+	}
+	return &VaultEncryptor{
+		addr:      strings.TrimSuffix(addr, "/"),
+		token:     token,
+		namespace: namespace,
+		mount:     strings.Trim(mount, "/"),
+		key:       key,
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Encrypt ...
+func (v *VaultEncryptor) Encrypt(plaintext string) (string, error) {
+	data := map[string]string{}
+	if err := v.do("encrypt", map[string]string{
+		"plaintext": base64.StdEncoding.EncodeToString([]byte(plaintext)),
+	}, &data); err != nil {
+		return "", err
+	}
+	ciphertext, ok := data["ciphertext"]
+	if !ok {
+		return "", fmt.Errorf("no ciphertext returned by vault")
+	}
+	return ciphertext, nil
+}
+
+// Decrypt ...
+func (v *VaultEncryptor) Decrypt(ciphertext string) (string, error) {
+	if !strings.HasPrefix(ciphertext, VaultHeader) {
+		return "", fmt.Errorf("the ciphertext isn't encrypted by vault")
+	}
+	data := map[string]string{}
+	if err := v.do("decrypt", map[string]string{"ciphertext": ciphertext}, &data); err != nil {
+		return "", err
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(data["plaintext"])
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// do calls the "encrypt" or "decrypt" endpoint of the transit engine and unmarshals the data of the response into result
+func (v *VaultEncryptor) do(action string, body map[string]string, result interface{}) error {
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/v1/%s/%s/%s", v.addr, v.mount, action, v.key)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(buf))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", v.token)
+	if len(v.namespace) > 0 {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to %s with vault, status code: %d, response: %s", action, resp.StatusCode, string(data))
+	}
+	r := &struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err = json.Unmarshal(data, r); err != nil {
+		return err
+	}
+	return json.Unmarshal(r.Data, result)
+}
@@ -0,0 +1,119 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encrypt
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVault mocks the transit secrets engine, the ciphertext is the reversed base64 encoded plaintext
+func fakeVault(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("X-Vault-Token"))
+		body := map[string]string{}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		data := map[string]string{}
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/harbor":
+			data["ciphertext"] = VaultHeader + "v1:" + reverse(body["plaintext"])
+		case "/v1/transit/decrypt/harbor":
+			data["plaintext"] = reverse(strings.TrimPrefix(body["ciphertext"], VaultHeader+"v1:"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+}
+
+func reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+func TestVaultEncryptor(t *testing.T) {
+	server := fakeVault(t)
+	defer server.Close()
+
+	v := NewVaultEncryptor(server.URL, "token", "", "", "harbor")
+	encrypted, err := v.Encrypt("password")
+	require.Nil(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, VaultHeader))
+	decrypted, err := v.Decrypt(encrypted)
+	require.Nil(t, err)
+	assert.Equal(t, "password", decrypted)
+
+	_, err = v.Decrypt("<enc-v1>xxx")
+	assert.NotNil(t, err)
+
+	_, err = NewVaultEncryptor(server.URL, "token", "", "", "unknown").Encrypt("password")
+	assert.NotNil(t, err)
+}
+
+func TestStoreEncryptor(t *testing.T) {
+	server := fakeVault(t)
+	defer server.Close()
+
+	local := NewAESEncryptor(&PresetKeyProvider{Key: "9TXCcHgNAAp1aSHh"})
+	legacy, err := local.Encrypt("password")
+	require.Nil(t, err)
+
+	// the vault isn't configured
+	t.Setenv("SECRET_STORE", StoreVault)
+	_, err = newStoreEncryptor(local).Encrypt("password")
+	assert.NotNil(t, err)
+
+	t.Setenv("VAULT_ADDR", server.URL)
+	t.Setenv("VAULT_TOKEN", "token")
+	t.Setenv("VAULT_TRANSIT_KEY", "harbor")
+	s := newStoreEncryptor(local)
+	encrypted, err := s.Encrypt("password")
+	require.Nil(t, err)
+	assert.Equal(t, StoreVault, StoreOf(encrypted))
+	decrypted, err := s.Decrypt(encrypted)
+	require.Nil(t, err)
+	assert.Equal(t, "password", decrypted)
+	// the secrets encrypted by the local store are still readable
+	decrypted, err = s.Decrypt(legacy)
+	require.Nil(t, err)
+	assert.Equal(t, "password", decrypted)
+
+	// switch back to the local store
+	t.Setenv("SECRET_STORE", "")
+	s = newStoreEncryptor(local)
+	decrypted, err = s.Decrypt(encrypted)
+	require.Nil(t, err)
+	assert.Equal(t, "password", decrypted)
+	encrypted, err = s.Encrypt("password")
+	require.Nil(t, err)
+	assert.Equal(t, StoreLocal, StoreOf(encrypted))
+}
+
+func TestStoreOf(t *testing.T) {
+	assert.Equal(t, StoreLocal, StoreOf("<enc-v1>xxx"))
+	assert.Equal(t, StoreVault, StoreOf("vault:v1:xxx"))
+	assert.Equal(t, StoreKMS, StoreOf("<enc-kms>xxx"))
+	assert.Equal(t, "", StoreOf("Bearer xxx"))
+}
@@ -15,6 +15,12 @@ const (
 	MethodNotAllowedCode = "METHOD_NOT_ALLOWED"
 	// PreconditionCode ...
 	PreconditionCode = "PRECONDITION"
+	// PreconditionRequiredCode is the error code for the case that the conditional header is missing
+	PreconditionRequiredCode = "PRECONDITION_REQUIRED"
+	// TooManyRequestsCode is the error code for the case that the request exceeds the rate limit
+	TooManyRequestsCode = "TOO_MANY_REQUESTS"
+	// ServiceUnavailableCode is the error code for the case that the service is temporarily unavailable, e.g. in maintenance
+	ServiceUnavailableCode = "SERVICE_UNAVAILABLE"
 	// GeneralCode ...
 	GeneralCode = "UNKNOWN"
 	// ChallengesUnsupportedCode ...
@@ -33,6 +39,15 @@ const (
 	UNSUPPORTED = "UNSUPPORTED"
 )
 
+const (
+	// ReasonQuotaExceeded is the reason of the error for the case that the quota of project is exceeded
+	ReasonQuotaExceeded = "QUOTA_EXCEEDED"
+	// ReasonReadOnly is the reason of the error for the case that the system is in read only mode
+	ReasonReadOnly = "READ_ONLY"
+	// ReasonMaintenance is the reason of the error for the case that the system is in maintenance mode
+	ReasonMaintenance = "MAINTENANCE"
+)
+
 // NotFoundError is error for the case of object not found
 func NotFoundError(err error) *Error {
 	return New("resource not found").WithCode(NotFoundCode).WithCause(err)
@@ -31,10 +31,18 @@ var (
 
 // Error ...
 type Error struct {
-	Cause   error  `json:"-"`
-	Code    string `json:"code"`
-	Message string `json:"message"`
-	Stack   *stack `json:"-"`
+	Cause   error     `json:"-"`
+	Code    string    `json:"code"`
+	Message string    `json:"message"`
+	Details []*Detail `json:"details,omitempty"`
+	Stack   *stack    `json:"-"`
+}
+
+// Detail carries the machine-readable information of an error, e.g. the reason why a request is denied
+// and the related data, so that clients needn't parse the message to distinguish errors with the same code
+type Detail struct {
+	Reason string                 `json:"reason"`
+	Data   map[string]interface{} `json:"data,omitempty"`
 }
 
 // Error returns a human readable error, error.Error() will not contains the track information. Needs it? just call error.StackTrace()
@@ -55,11 +63,13 @@ func (e *Error) StackTrace() string {
 // MarshalJSON ...
 func (e *Error) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
-		Code    string `json:"code"`
-		Message string `json:"message"`
+		Code    string    `json:"code"`
+		Message string    `json:"message"`
+		Details []*Detail `json:"details,omitempty"`
 	}{
 		Code:    e.Code,
 		Message: e.Error(),
+		Details: ErrDetails(e),
 	})
 }
 
@@ -75,6 +85,12 @@ func (e *Error) WithCode(code string) *Error {
 	return e
 }
 
+// WithDetails appends the details to the error
+func (e *Error) WithDetails(details ...*Detail) *Error {
+	e.Details = append(e.Details, details...)
+	return e
+}
+
 // WithCause ...
 func (e *Error) WithCause(err error) *Error {
 	e.Cause = err
@@ -214,3 +230,17 @@ func ErrCode(err error) string {
 
 	return GeneralCode
 }
+
+// ErrDetails returns the details of all the errors in the err chain
+func ErrDetails(err error) []*Detail {
+	var details []*Detail
+	for err != nil {
+		var e *Error
+		if !As(err, &e) {
+			break
+		}
+		details = append(details, e.Details...)
+		err = e.Cause
+	}
+	return details
+}
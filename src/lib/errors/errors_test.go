@@ -280,6 +280,27 @@ func (suite *ErrorTestSuite) TestErrors() {
 	suite.Equal(`{"errors":[{"code":"UNKNOWN","message":"internal server error"}]}`, NewErrs(err).Error())
 }
 
+func (suite *ErrorTestSuite) TestWithDetails() {
+	err := DeniedError(nil).WithMessage("quota exceeded").WithDetails(&Detail{
+		Reason: ReasonQuotaExceeded,
+		Data:   map[string]interface{}{"resource": "storage"},
+	})
+	suite.Len(ErrDetails(err), 1)
+	suite.Equal(`{"errors":[{"code":"DENIED","message":"quota exceeded","details":[{"reason":"QUOTA_EXCEEDED","data":{"resource":"storage"}}]}]}`, NewErrs(err).Error())
+
+	// the details of the causes are included
+	err2 := Wrap(err, "failed to push").WithCode(DENIED)
+	suite.Equal(`{"code":"DENIED","message":"failed to push: quota exceeded","details":[{"reason":"QUOTA_EXCEEDED","data":{"resource":"storage"}}]}`, mustMarshal(err2))
+
+	suite.Nil(ErrDetails(errors.New("stdErr")))
+	suite.Nil(ErrDetails(nil))
+}
+
+func mustMarshal(err *Error) string {
+	out, _ := err.MarshalJSON()
+	return string(out)
+}
+
 func TestErrorTestSuite(t *testing.T) {
 	suite.Run(t, &ErrorTestSuite{})
 }
@@ -39,6 +39,9 @@ var (
 		errors.NotFoundCode:                    http.StatusNotFound,
 		errors.ConflictCode:                    http.StatusConflict,
 		errors.PreconditionCode:                http.StatusPreconditionFailed,
+		errors.PreconditionRequiredCode:        http.StatusPreconditionRequired,
+		errors.TooManyRequestsCode:             http.StatusTooManyRequests,
+		errors.ServiceUnavailableCode:          http.StatusServiceUnavailable,
 		errors.ViolateForeignKeyConstraintCode: http.StatusPreconditionFailed,
 		errors.PROJECTPOLICYVIOLATION:          http.StatusPreconditionFailed,
 		errors.GeneralCode:                     http.StatusInternalServerError,
@@ -51,8 +54,9 @@ var (
 func SendError(w http.ResponseWriter, err error) {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	statusCode, errPayload, stackTrace := apiError(err)
-	// the error detail is logged only, and will not be sent to the client to avoid leaking server information
-	if statusCode >= http.StatusInternalServerError {
+	// the error detail is logged only, and will not be sent to the client to avoid leaking server information,
+	// except the unavailable error which is raised deliberately, e.g. in maintenance
+	if statusCode >= http.StatusInternalServerError && statusCode != http.StatusServiceUnavailable {
 		log.Errorf("%s %s", errPayload, stackTrace)
 		err = errors.New(nil).WithCode(errors.GeneralCode).WithMessage("internal server error")
 		errPayload = errors.NewErrs(err).Error()
@@ -48,6 +48,20 @@ func TestSendError(t *testing.T) {
 	SendError(rw, err)
 	assert.Equal(t, http.StatusNotFound, rw.Code)
 	assert.Equal(t, `{"errors":[{"code":"NOT_FOUND","message":"object not found"}]}`+"\n", rw.Body.String())
+
+	// error with details
+	rw = httptest.NewRecorder()
+	err = errors.New(nil).WithCode(errors.DENIED).WithMessage("read only").WithDetails(&errors.Detail{Reason: errors.ReasonReadOnly})
+	SendError(rw, err)
+	assert.Equal(t, http.StatusForbidden, rw.Code)
+	assert.Equal(t, `{"errors":[{"code":"DENIED","message":"read only","details":[{"reason":"READ_ONLY"}]}]}`+"\n", rw.Body.String())
+
+	// service unavailable error isn't hidden
+	rw = httptest.NewRecorder()
+	err = errors.New(nil).WithCode(errors.ServiceUnavailableCode).WithMessage("in maintenance")
+	SendError(rw, err)
+	assert.Equal(t, http.StatusServiceUnavailable, rw.Code)
+	assert.Equal(t, `{"errors":[{"code":"SERVICE_UNAVAILABLE","message":"in maintenance"}]}`+"\n", rw.Body.String())
 }
 
 func TestAPIError(t *testing.T) {
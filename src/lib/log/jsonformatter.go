@@ -0,0 +1,88 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// FormatText formats the logs as plain text
+	FormatText = "text"
+	// FormatJSON formats the logs as JSON objects
+	FormatJSON = "json"
+)
+
+// JSONFormatter represents a kind of formatter that formats the logs as JSON objects, one object per line
+type JSONFormatter struct {
+	timeFormat string
+}
+
+// NewJSONFormatter returns a JSONFormatter, the format of time is time.RFC3339
+func NewJSONFormatter() *JSONFormatter {
+	return &JSONFormatter{
+		timeFormat: defaultTimeFormat,
+	}
+}
+
+// Format formats the logs as {"time":"...","level":"...","caller":"...","msg":"...",...fields}
+// the fields of the logger can't override the reserved keys
+func (j *JSONFormatter) Format(r *Record) ([]byte, error) {
+	b, err := json.Marshal(j.data(r, false))
+	if err != nil {
+		// fall back to the string of the fields as some of them can't be marshaled
+		if b, err = json.Marshal(j.data(r, true)); err != nil {
+			return nil, err
+		}
+	}
+	return append(b, '\n'), nil
+}
+
+func (j *JSONFormatter) data(r *Record, stringify bool) map[string]interface{} {
+	data := make(map[string]interface{}, len(r.Fields)+4)
+	for key, value := range r.Fields {
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		if stringify {
+			value = fmt.Sprint(value)
+		}
+		data[key] = value
+	}
+	data["time"] = r.Time.Format(j.timeFormat)
+	data["level"] = r.Lvl.string()
+	if len(r.Caller) != 0 {
+		data["caller"] = r.Caller
+	}
+	data["msg"] = r.Msg
+	return data
+}
+
+// SetTimeFormat sets time format of JSONFormatter if the parameter format is not null
+func (j *JSONFormatter) SetTimeFormat(format string) {
+	if len(format) != 0 {
+		j.timeFormat = format
+	}
+}
+
+// NewFormatter returns the formatter of the format, the text formatter is returned for the unknown format
+func NewFormatter(format string) Formatter {
+	if strings.EqualFold(format, FormatJSON) {
+		return NewJSONFormatter()
+	}
+	return NewTextFormatter()
+}
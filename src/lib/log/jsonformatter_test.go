@@ -0,0 +1,60 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONFormatterFormat(t *testing.T) {
+	now := time.Date(2022, 10, 1, 8, 0, 0, 0, time.UTC)
+	r := NewRecord(now, "message", "[pkg/scan/job.go:10][requestID=\"1\"]:", InfoLevel)
+	r.Caller = "pkg/scan/job.go:10"
+	r.Fields = map[string]interface{}{"requestID": "1", "error": errors.New("failed"), "msg": "overridden"}
+
+	b, err := NewJSONFormatter().Format(r)
+	require.Nil(t, err)
+	assert.Equal(t, byte('\n'), b[len(b)-1])
+
+	data := map[string]interface{}{}
+	require.Nil(t, json.Unmarshal(b, &data))
+	assert.Equal(t, "2022-10-01T08:00:00Z", data["time"])
+	assert.Equal(t, "INFO", data["level"])
+	assert.Equal(t, "pkg/scan/job.go:10", data["caller"])
+	assert.Equal(t, "message", data["msg"])
+	assert.Equal(t, "1", data["requestID"])
+	assert.Equal(t, "failed", data["error"])
+
+	// the field can't be marshaled
+	r.Fields = map[string]interface{}{"channel": make(chan int)}
+	b, err = NewJSONFormatter().Format(r)
+	require.Nil(t, err)
+	data = map[string]interface{}{}
+	require.Nil(t, json.Unmarshal(b, &data))
+	assert.NotEmpty(t, data["channel"])
+	assert.Equal(t, "message", data["msg"])
+}
+
+func TestNewFormatter(t *testing.T) {
+	assert.IsType(t, &JSONFormatter{}, NewFormatter("JSON"))
+	assert.IsType(t, &TextFormatter{}, NewFormatter("text"))
+	assert.IsType(t, &TextFormatter{}, NewFormatter(""))
+}
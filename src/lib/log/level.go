@@ -54,6 +54,21 @@ func (l Level) string() (lvl string) {
 	return
 }
 
+// String returns the string of the level
+func (l Level) String() string {
+	return l.string()
+}
+
+// MarshalText marshals the level as its lower case string
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(strings.ToLower(l.string())), nil
+}
+
+// ParseLevel parses the level from the string: debug, info, warning, error or fatal
+func ParseLevel(lvl string) (Level, error) {
+	return parseLevel(lvl)
+}
+
 func parseLevel(lvl string) (level Level, err error) {
 	switch strings.ToLower(lvl) {
 	case "debug":
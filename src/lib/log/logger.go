@@ -31,6 +31,9 @@ var logger = New(os.Stdout, NewTextFormatter(), WarningLevel, 3)
 const srcSeparator = "harbor" + string(os.PathSeparator) + "src"
 
 func init() {
+	logger.modular = true
+	logger.setFormatter(NewFormatter(os.Getenv("LOG_FORMAT")))
+
 	lvl := os.Getenv("LOG_LEVEL")
 	if len(lvl) == 0 {
 		logger.setLevel(InfoLevel)
@@ -56,6 +59,7 @@ type Logger struct {
 	lvl       Level
 	callDepth int
 	skipLine  bool
+	modular   bool // whether the module levels take effect
 	fields    map[string]interface{}
 	fieldsStr string
 	mu        *sync.Mutex // ptr here to share one sync.Mutex for clone method
@@ -102,6 +106,7 @@ func (l *Logger) clone() *Logger {
 		lvl:       l.lvl,
 		callDepth: l.callDepth,
 		skipLine:  l.skipLine,
+		modular:   l.modular,
 		fields:    l.fields,
 		fieldsStr: l.fieldsStr,
 		mu:        l.mu,
@@ -197,72 +202,72 @@ func (l *Logger) output(record *Record) (err error) {
 
 // Debug ...
 func (l *Logger) Debug(v ...interface{}) {
-	if l.lvl <= DebugLevel {
-		record := NewRecord(time.Now(), fmt.Sprint(v...), l.getLine(), DebugLevel)
+	if l.enabled(DebugLevel) {
+		record := l.newRecord(fmt.Sprint(v...), DebugLevel)
 		_ = l.output(record)
 	}
 }
 
 // Debugf ...
 func (l *Logger) Debugf(format string, v ...interface{}) {
-	if l.lvl <= DebugLevel {
-		record := NewRecord(time.Now(), fmt.Sprintf(format, v...), l.getLine(), DebugLevel)
+	if l.enabled(DebugLevel) {
+		record := l.newRecord(fmt.Sprintf(format, v...), DebugLevel)
 		_ = l.output(record)
 	}
 }
 
 // Info ...
 func (l *Logger) Info(v ...interface{}) {
-	if l.lvl <= InfoLevel {
-		record := NewRecord(time.Now(), fmt.Sprint(v...), l.getLine(), InfoLevel)
+	if l.enabled(InfoLevel) {
+		record := l.newRecord(fmt.Sprint(v...), InfoLevel)
 		_ = l.output(record)
 	}
 }
 
 // Infof ...
 func (l *Logger) Infof(format string, v ...interface{}) {
-	if l.lvl <= InfoLevel {
-		record := NewRecord(time.Now(), fmt.Sprintf(format, v...), l.getLine(), InfoLevel)
+	if l.enabled(InfoLevel) {
+		record := l.newRecord(fmt.Sprintf(format, v...), InfoLevel)
 		_ = l.output(record)
 	}
 }
 
 // Warning ...
 func (l *Logger) Warning(v ...interface{}) {
-	if l.lvl <= WarningLevel {
-		record := NewRecord(time.Now(), fmt.Sprint(v...), l.getLine(), WarningLevel)
+	if l.enabled(WarningLevel) {
+		record := l.newRecord(fmt.Sprint(v...), WarningLevel)
 		_ = l.output(record)
 	}
 }
 
 // Warningf ...
 func (l *Logger) Warningf(format string, v ...interface{}) {
-	if l.lvl <= WarningLevel {
-		record := NewRecord(time.Now(), fmt.Sprintf(format, v...), l.getLine(), WarningLevel)
+	if l.enabled(WarningLevel) {
+		record := l.newRecord(fmt.Sprintf(format, v...), WarningLevel)
 		_ = l.output(record)
 	}
 }
 
 // Error ...
 func (l *Logger) Error(v ...interface{}) {
-	if l.lvl <= ErrorLevel {
-		record := NewRecord(time.Now(), fmt.Sprint(v...), l.getLine(), ErrorLevel)
+	if l.enabled(ErrorLevel) {
+		record := l.newRecord(fmt.Sprint(v...), ErrorLevel)
 		_ = l.output(record)
 	}
 }
 
 // Errorf ...
 func (l *Logger) Errorf(format string, v ...interface{}) {
-	if l.lvl <= ErrorLevel {
-		record := NewRecord(time.Now(), fmt.Sprintf(format, v...), l.getLine(), ErrorLevel)
+	if l.enabled(ErrorLevel) {
+		record := l.newRecord(fmt.Sprintf(format, v...), ErrorLevel)
 		_ = l.output(record)
 	}
 }
 
 // Fatal ...
 func (l *Logger) Fatal(v ...interface{}) {
-	if l.lvl <= FatalLevel {
-		record := NewRecord(time.Now(), fmt.Sprint(v...), l.getLine(), FatalLevel)
+	if l.enabled(FatalLevel) {
+		record := l.newRecord(fmt.Sprint(v...), FatalLevel)
 		_ = l.output(record)
 	}
 	os.Exit(1)
@@ -270,8 +275,8 @@ func (l *Logger) Fatal(v ...interface{}) {
 
 // Fatalf ...
 func (l *Logger) Fatalf(format string, v ...interface{}) {
-	if l.lvl <= FatalLevel {
-		record := NewRecord(time.Now(), fmt.Sprintf(format, v...), l.getLine(), FatalLevel)
+	if l.enabled(FatalLevel) {
+		record := l.newRecord(fmt.Sprintf(format, v...), FatalLevel)
 		_ = l.output(record)
 	}
 	os.Exit(1)
@@ -282,19 +287,36 @@ func (l *Logger) GetLevel() Level {
 	return l.lvl
 }
 
-func (l *Logger) getLine() string {
-	var str string
+// enabled checks whether the log of the level is enabled, the level of the module
+// which the caller belongs to takes precedence over the level of the logger
+func (l *Logger) enabled(lvl Level) bool {
+	if l.modular && modules.configured() {
+		if mlvl, ok := modules.levelOf(callerFile(l.callDepth)); ok {
+			return mlvl <= lvl
+		}
+	}
+	return l.lvl <= lvl
+}
+
+func (l *Logger) newRecord(msg string, lvl Level) *Record {
+	var src string
 	if !l.skipLine {
-		str = line(l.callDepth)
+		src = fileLine(l.callDepth)
 	}
 
+	var str string
+	if src != "" {
+		str = "[" + src + "]"
+	}
 	str = str + l.fieldsStr
-
 	if str != "" {
 		str = str + ":"
 	}
 
-	return str
+	record := NewRecord(time.Now(), msg, str, lvl)
+	record.Caller = src
+	record.Fields = l.fields
+	return record
 }
 
 // Debug ...
@@ -352,8 +374,21 @@ func GetLevel() Level {
 	return logger.GetLevel()
 }
 
-func line(callDepth int) string {
-	_, file, line, ok := runtime.Caller(callDepth)
+// fileLine returns the "file:line" of the caller, the file is relative to the src directory
+func fileLine(callDepth int) string {
+	file, line := caller(callDepth)
+	return fmt.Sprintf("%s:%d", file, line)
+}
+
+// callerFile returns the file of the caller, the file is relative to the src directory
+func callerFile(callDepth int) string {
+	file, _ := caller(callDepth)
+	return file
+}
+
+// caller is called by fileLine or callerFile, the extra frame is skipped
+func caller(callDepth int) (string, int) {
+	_, file, line, ok := runtime.Caller(callDepth + 1)
 	if !ok {
 		file = "???"
 
@@ -363,5 +398,5 @@ func line(callDepth int) string {
 	if len(l) > 1 {
 		file = l[1]
 	}
-	return fmt.Sprintf("[%s:%d]", file, line)
+	return file, line
 }
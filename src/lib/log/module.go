@@ -0,0 +1,115 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// modules holds the log levels of the modules which override the level of the default logger
+var modules = &moduleLevels{levels: map[string]Level{}}
+
+// moduleLevels maps the modules, i.e. the directories relative to the src directory
+// such as "pkg/scan" or "controller/artifact", to their log levels
+type moduleLevels struct {
+	mu     sync.RWMutex
+	levels map[string]Level
+	count  int32 // count of the levels, to skip the lookup when no level is set
+}
+
+func (m *moduleLevels) configured() bool {
+	return atomic.LoadInt32(&m.count) > 0
+}
+
+// levelOf returns the level of the most specific module which the file belongs to
+func (m *moduleLevels) levelOf(file string) (Level, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var (
+		matched string
+		level   Level
+	)
+	for module, lvl := range m.levels {
+		if len(module) > len(matched) && strings.Contains(file, "/"+module+"/") {
+			matched, level = module, lvl
+		}
+	}
+	return level, len(matched) > 0
+}
+
+func (m *moduleLevels) set(module string, lvl Level) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.levels[module] = lvl
+	atomic.StoreInt32(&m.count, int32(len(m.levels)))
+}
+
+func (m *moduleLevels) reset(module string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.levels, module)
+	atomic.StoreInt32(&m.count, int32(len(m.levels)))
+}
+
+func (m *moduleLevels) list() map[string]Level {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	levels := make(map[string]Level, len(m.levels))
+	for module, lvl := range m.levels {
+		levels[module] = lvl
+	}
+	return levels
+}
+
+func normalizeModule(module string) (string, error) {
+	module = strings.Trim(strings.TrimSpace(module), "/")
+	if len(module) == 0 {
+		return "", errors.New("empty module")
+	}
+	return module, nil
+}
+
+// SetModuleLevel sets the log level of the module, the module is the directory relative to
+// the src directory, e.g. "pkg/scan", the level of the most specific module takes effect
+func SetModuleLevel(module string, lvl Level) error {
+	module, err := normalizeModule(module)
+	if err != nil {
+		return err
+	}
+	modules.set(module, lvl)
+	return nil
+}
+
+// ResetModuleLevel removes the log level of the module, the level of the default logger takes effect again
+func ResetModuleLevel(module string) error {
+	module, err := normalizeModule(module)
+	if err != nil {
+		return err
+	}
+	modules.reset(module)
+	return nil
+}
+
+// ModuleLevels returns the log levels of the modules
+func ModuleLevels() map[string]Level {
+	return modules.list()
+}
@@ -0,0 +1,68 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleLevels(t *testing.T) {
+	assert.NotNil(t, SetModuleLevel("", DebugLevel))
+	assert.Nil(t, SetModuleLevel("/lib/", DebugLevel))
+	assert.Nil(t, SetModuleLevel("lib/log", ErrorLevel))
+	defer func() {
+		_ = ResetModuleLevel("lib")
+		_ = ResetModuleLevel("lib/log")
+	}()
+	assert.Equal(t, map[string]Level{"lib": DebugLevel, "lib/log": ErrorLevel}, ModuleLevels())
+
+	// the most specific module takes effect
+	lvl, ok := modules.levelOf("/harbor/src/lib/log/logger.go")
+	assert.True(t, ok)
+	assert.Equal(t, ErrorLevel, lvl)
+	lvl, ok = modules.levelOf("/harbor/src/lib/orm/orm.go")
+	assert.True(t, ok)
+	assert.Equal(t, DebugLevel, lvl)
+	_, ok = modules.levelOf("/harbor/src/pkg/scan/job.go")
+	assert.False(t, ok)
+
+	buf := enter()
+	defer exit()
+
+	// the level of "lib/log" module is error
+	Warning(message)
+	assert.Empty(t, buf.String())
+	Error(message)
+	assert.Contains(t, buf.String(), "module_test.go")
+
+	// the level of "lib" module is debug
+	buf.Reset()
+	assert.Nil(t, ResetModuleLevel("lib/log"))
+	Debug(message)
+	assert.Contains(t, buf.String(), "module_test.go")
+
+	// the logger created by New isn't affected
+	buf.Reset()
+	New(buf, NewTextFormatter(), InfoLevel).Debug(message)
+	assert.Empty(t, buf.String())
+
+	// the level of the default logger takes effect again
+	buf.Reset()
+	assert.Nil(t, ResetModuleLevel("lib"))
+	Debug(message)
+	assert.Empty(t, buf.String())
+}
@@ -24,6 +24,9 @@ type Record struct {
 	Msg  string    // content of the log
 	Line string    // in which file and line that the log produced
 	Lvl  Level     // level of the log
+
+	Caller string                 // the file and line that the log produced without the fields
+	Fields map[string]interface{} // the fields of the logger
 }
 
 // NewRecord creates a record according to the arguments provided and returns it
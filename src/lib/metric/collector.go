@@ -12,6 +12,24 @@ func RegisterCollectors() {
 		TotalInFlightGauge,
 		TotalReqCnt,
 		TotalReqDurSummary,
+		RateLimitedReqCnt,
+		ReqDurHistogram,
+		ReqSizeHistogram,
+		RespSizeHistogram,
+		QuotaRejectedReqCnt,
+	}...)
+}
+
+// RegisterHTTPCollectors register the collectors of the requests only, for the components
+// which serve the requests but don't share the other metrics of core
+func RegisterHTTPCollectors() {
+	prometheus.MustRegister([]prometheus.Collector{
+		TotalInFlightGauge,
+		TotalReqCnt,
+		TotalReqDurSummary,
+		ReqDurHistogram,
+		ReqSizeHistogram,
+		RespSizeHistogram,
 	}...)
 }
 
@@ -47,4 +65,58 @@ var (
 			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
 		},
 		[]string{"method", "operation"})
+
+	// RateLimitedReqCnt used to collect the counter of the requests rejected by the rate limits
+	RateLimitedReqCnt = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: os.Getenv(NamespaceEnvKey),
+			Subsystem: os.Getenv(SubsystemEnvKey),
+			Name:      "http_rate_limited_request_total",
+			Help:      "The total number of requests rejected by the rate limits",
+		},
+		[]string{"principal"})
+
+	// ReqDurHistogram used to collect the distribution of the request durations, the histogram can be aggregated
+	// across the instances while the summary can't
+	ReqDurHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: os.Getenv(NamespaceEnvKey),
+			Subsystem: os.Getenv(SubsystemEnvKey),
+			Name:      "http_request_latency_seconds",
+			Help:      "The distribution of the time duration of the requests",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		},
+		[]string{"method", "operation"})
+
+	// ReqSizeHistogram used to collect the distribution of the request body sizes
+	ReqSizeHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: os.Getenv(NamespaceEnvKey),
+			Subsystem: os.Getenv(SubsystemEnvKey),
+			Name:      "http_request_size_bytes",
+			Help:      "The distribution of the body size of the requests",
+			Buckets:   prometheus.ExponentialBuckets(100, 10, 8),
+		},
+		[]string{"method", "operation"})
+
+	// RespSizeHistogram used to collect the distribution of the response body sizes
+	RespSizeHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: os.Getenv(NamespaceEnvKey),
+			Subsystem: os.Getenv(SubsystemEnvKey),
+			Name:      "http_response_size_bytes",
+			Help:      "The distribution of the body size of the responses",
+			Buckets:   prometheus.ExponentialBuckets(100, 10, 8),
+		},
+		[]string{"method", "operation"})
+
+	// QuotaRejectedReqCnt used to collect the counter of the requests rejected by the quotas
+	QuotaRejectedReqCnt = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: os.Getenv(NamespaceEnvKey),
+			Subsystem: os.Getenv(SubsystemEnvKey),
+			Name:      "quota_rejected_request_total",
+			Help:      "The total number of requests rejected by the quotas",
+		},
+		[]string{"resource"})
 )
@@ -0,0 +1,66 @@
+package metric
+
+import (
+	"database/sql"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RegisterDBStatsCollector register the collector of the connection pool stats of the database
+func RegisterDBStatsCollector(db *sql.DB) {
+	prometheus.MustRegister(newDBStatsCollector(db))
+}
+
+func newDBStatsCollector(db *sql.DB) *dbStatsCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(os.Getenv(NamespaceEnvKey), os.Getenv(SubsystemEnvKey), name), help, nil, nil)
+	}
+	return &dbStatsCollector{
+		maxOpen:           desc("db_max_open_connections", "The max number of open connections to the database"),
+		openConnections:   desc("db_open_connections", "The number of established connections to the database, both in use and idle"),
+		inUse:             desc("db_in_use_connections", "The number of connections to the database currently in use"),
+		idle:              desc("db_idle_connections", "The number of idle connections to the database"),
+		waitCount:         desc("db_wait_total", "The total number of connections waited for"),
+		waitDuration:      desc("db_wait_duration_seconds_total", "The total time blocked waiting for a new connection"),
+		maxIdleClosed:     desc("db_max_idle_closed_total", "The total number of connections closed due to the max idle connections"),
+		maxLifetimeClosed: desc("db_max_lifetime_closed_total", "The total number of connections closed due to the max lifetime"),
+		conn:              db,
+	}
+}
+
+// dbStatsCollector collects the stats of the connection pool when being scraped
+type dbStatsCollector struct {
+	conn              *sql.DB
+	maxOpen           *prometheus.Desc
+	openConnections   *prometheus.Desc
+	inUse             *prometheus.Desc
+	idle              *prometheus.Desc
+	waitCount         *prometheus.Desc
+	waitDuration      *prometheus.Desc
+	maxIdleClosed     *prometheus.Desc
+	maxLifetimeClosed *prometheus.Desc
+}
+
+func (d *dbStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- d.maxOpen
+	ch <- d.openConnections
+	ch <- d.inUse
+	ch <- d.idle
+	ch <- d.waitCount
+	ch <- d.waitDuration
+	ch <- d.maxIdleClosed
+	ch <- d.maxLifetimeClosed
+}
+
+func (d *dbStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := d.conn.Stats()
+	ch <- prometheus.MustNewConstMetric(d.maxOpen, prometheus.GaugeValue, float64(stats.MaxOpenConnections))
+	ch <- prometheus.MustNewConstMetric(d.openConnections, prometheus.GaugeValue, float64(stats.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(d.inUse, prometheus.GaugeValue, float64(stats.InUse))
+	ch <- prometheus.MustNewConstMetric(d.idle, prometheus.GaugeValue, float64(stats.Idle))
+	ch <- prometheus.MustNewConstMetric(d.waitCount, prometheus.CounterValue, float64(stats.WaitCount))
+	ch <- prometheus.MustNewConstMetric(d.waitDuration, prometheus.CounterValue, stats.WaitDuration.Seconds())
+	ch <- prometheus.MustNewConstMetric(d.maxIdleClosed, prometheus.CounterValue, float64(stats.MaxIdleClosed))
+	ch <- prometheus.MustNewConstMetric(d.maxLifetimeClosed, prometheus.CounterValue, float64(stats.MaxLifetimeClosed))
+}
@@ -12,9 +12,42 @@ func RegisterJobServiceCollectors() {
 		JobserviceInfo,
 		JobserviceTotalTask,
 		JobservieTaskProcessTimeSummary,
+		ScanDurationHistogram,
+		ReplicationTransferredBytes,
+		ReplicationTransferSlots,
 	}...)
 }
 
+// RegisterJobQueueCollector register the collector of the count of the pending jobs in the queues,
+// the depths function is called when being scraped and returns the count by the job type
+func RegisterJobQueueCollector(depths func() (map[string]int64, error)) {
+	prometheus.MustRegister(&jobQueueCollector{
+		depths: depths,
+		desc: prometheus.NewDesc(prometheus.BuildFQName(os.Getenv(NamespaceEnvKey), os.Getenv(SubsystemEnvKey), "queue_depth"),
+			"The number of the pending jobs in the queue", []string{"type"}, nil),
+	})
+}
+
+type jobQueueCollector struct {
+	depths func() (map[string]int64, error)
+	desc   *prometheus.Desc
+}
+
+func (j *jobQueueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- j.desc
+}
+
+func (j *jobQueueCollector) Collect(ch chan<- prometheus.Metric) {
+	depths, err := j.depths()
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(j.desc, err)
+		return
+	}
+	for jobType, depth := range depths {
+		ch <- prometheus.MustNewConstMetric(j.desc, prometheus.GaugeValue, float64(depth), jobType)
+	}
+}
+
 var (
 	// JobserviceInfo used for collect jobservice information
 	JobserviceInfo = prometheus.NewGaugeVec(
@@ -46,4 +79,32 @@ var (
 			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
 		},
 		[]string{"type", "status"})
+
+	// ScanDurationHistogram used for instrument the time duration from submitting the scan request to getting the reports
+	ScanDurationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: os.Getenv(NamespaceEnvKey),
+			Subsystem: os.Getenv(SubsystemEnvKey),
+			Name:      "scan_duration_seconds",
+			Help:      "The time duration of the scans",
+			Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600},
+		},
+		[]string{"scanner", "status"})
+	// ReplicationTransferredBytes used for collect the replication throughput
+	ReplicationTransferredBytes = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: os.Getenv(NamespaceEnvKey),
+			Subsystem: os.Getenv(SubsystemEnvKey),
+			Name:      "replication_transferred_bytes_total",
+			Help:      "The total size of the blobs transferred by the replications",
+		})
+	// ReplicationTransferSlots used for collect the usage of the slots shared by the blob transfers of the replications
+	ReplicationTransferSlots = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: os.Getenv(NamespaceEnvKey),
+			Subsystem: os.Getenv(SubsystemEnvKey),
+			Name:      "replication_transfer_slots",
+			Help:      "The limit, in-flight and waiting blob transfers of the replications, no limit when the limit is 0",
+		},
+		[]string{"state"})
 )
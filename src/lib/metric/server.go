@@ -1,8 +1,11 @@
 package metric
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"net/http"
+	"os"
+	"strings"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
@@ -14,12 +17,30 @@ const (
 	NamespaceEnvKey = "METRIC_NAMESPACE"
 	// SubsystemEnvKey is the metric subsystem key in environment
 	SubsystemEnvKey = "METRIC_SUBSYSTEM"
+	// TokenEnvKey is the env key of the bearer token to scrape the metrics, the metrics are open if it's empty
+	TokenEnvKey = "METRIC_TOKEN"
 )
 
 // ServeProm return a server to serve prometheus metrics
 func ServeProm(path string, port int) {
 	mux := http.NewServeMux()
-	mux.Handle(path, promhttp.Handler())
+	mux.Handle(path, tokenHandler(os.Getenv(TokenEnvKey), promhttp.Handler()))
 	log.Infof("Prometheus metric server running on port %v", port)
 	log.Errorf("Promethus metrcis server down with %s", http.ListenAndServe(fmt.Sprintf(":%v", port), mux))
 }
+
+// tokenHandler requires the requests to carry the token as the bearer token if the token isn't empty
+func tokenHandler(token string, next http.Handler) http.Handler {
+	if len(token) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
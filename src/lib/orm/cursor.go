@@ -0,0 +1,138 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+
+	"github.com/beego/beego/v2/client/orm"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+)
+
+// cursor is the decoded format of the cursor used by the keyset pagination,
+// it records the values of the sort keys of the last returned record
+type cursor struct {
+	Keys   []string          `json:"k"`
+	Values []json.RawMessage `json:"v"`
+}
+
+// SetNextCursor populates the next cursor of the query according to the records returned by the query.
+// The "records" must be the slice of the model pointers used to build the query setter, e.g. []*Model.
+// The next cursor is set to empty if there are no more records
+func SetNextCursor(query *q.Query, records interface{}) error {
+	if query == nil || query.Cursor == nil {
+		return nil
+	}
+	query.Cursor.Next = ""
+	v := reflect.ValueOf(records)
+	if v.Kind() != reflect.Slice {
+		return errors.Errorf("<orm.SetNextCursor> cannot use non-slice records %s", v.Type().String())
+	}
+	// the page isn't full, no more records
+	if v.Len() == 0 || int64(v.Len()) < query.PageSize {
+		return nil
+	}
+	last := reflect.Indirect(v.Index(v.Len() - 1))
+	if last.Kind() != reflect.Struct {
+		return errors.Errorf("<orm.SetNextCursor> cannot use non-struct record %s", last.Type().String())
+	}
+	next, err := encodeCursor(getSorts(query, parseModel(reflect.New(last.Type()).Interface())), last)
+	if err != nil {
+		return err
+	}
+	query.Cursor.Next = next
+	return nil
+}
+
+// encode the values of the sort keys of the record as the cursor
+func encodeCursor(sorts []*q.Sort, record reflect.Value) (string, error) {
+	c := &cursor{}
+	for _, sort := range sorts {
+		field := record.FieldByName(sort.Key)
+		if !field.IsValid() {
+			return "", errors.Errorf("the sort key %s not found in %s", sort.Key, record.Type().String())
+		}
+		value, err := json.Marshal(field.Interface())
+		if err != nil {
+			return "", err
+		}
+		c.Keys = append(c.Keys, sort.Key)
+		c.Values = append(c.Values, value)
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decode the cursor into the values of the sort keys, the type of each value is the same as the type of the
+// corresponding field of the model. An error is returned if the cursor doesn't match the sorts
+func decodeCursor(value string, sorts []*q.Sort, model reflect.Type) ([]interface{}, error) {
+	invalid := errors.BadRequestError(nil).WithMessage("invalid cursor %s", value)
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, invalid
+	}
+	c := &cursor{}
+	if err = json.Unmarshal(data, c); err != nil {
+		return nil, invalid
+	}
+	if len(c.Keys) != len(sorts) || len(c.Values) != len(sorts) {
+		return nil, invalid
+	}
+	var values []interface{}
+	for i, sort := range sorts {
+		if c.Keys[i] != sort.Key {
+			return nil, invalid
+		}
+		field, exist := model.FieldByName(sort.Key)
+		if !exist {
+			return nil, invalid
+		}
+		v := reflect.New(field.Type)
+		if err = json.Unmarshal(c.Values[i], v.Interface()); err != nil {
+			return nil, invalid
+		}
+		values = append(values, v.Elem().Interface())
+	}
+	return values, nil
+}
+
+// set the keyset condition to return the records after the position specified by the values of the sort keys.
+// e.g. for sorts "a desc, id asc", the condition is "a < ? or (a = ? and id > ?)"
+func setKeyset(qs orm.QuerySeter, sorts []*q.Sort, values []interface{}) orm.QuerySeter {
+	keyset := orm.NewCondition()
+	for i, sort := range sorts {
+		cond := orm.NewCondition()
+		for j := 0; j < i; j++ {
+			cond = cond.And(sorts[j].Key, values[j])
+		}
+		operator := "__gt"
+		if sort.DESC {
+			operator = "__lt"
+		}
+		cond = cond.And(sort.Key+operator, values[i])
+		keyset = keyset.OrCond(cond)
+	}
+	if existing := qs.GetCond(); existing != nil {
+		keyset = existing.AndCond(keyset)
+	}
+	return qs.SetCond(keyset)
+}
@@ -0,0 +1,128 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orm
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+)
+
+type baz struct {
+	ID           int64     `orm:"pk;auto;column(id)"`
+	Name         string    `orm:"column(name)"`
+	CreationTime time.Time `orm:"column(creation_time)" sort:"default:desc"`
+}
+
+func TestGetSorts(t *testing.T) {
+	meta := parseModel(&baz{})
+	assert.Equal(t, "ID", meta.PK)
+
+	// the sorts of the queries without the cursor are kept as they are
+	sorts := getSorts(&q.Query{Sorts: []*q.Sort{q.NewSort("name", false), q.NewSort("unknown", true)}}, meta)
+	require.Len(t, sorts, 1)
+	assert.Equal(t, "name", sorts[0].Key)
+	sorts = getSorts(&q.Query{}, meta)
+	require.Len(t, sorts, 1)
+	assert.Equal(t, "CreationTime", sorts[0].Key)
+
+	// default sorts with the primary key appended
+	sorts = getSorts(&q.Query{Cursor: q.NewCursor("")}, meta)
+	require.Len(t, sorts, 2)
+	assert.Equal(t, "CreationTime", sorts[0].Key)
+	assert.True(t, sorts[0].DESC)
+	assert.Equal(t, "ID", sorts[1].Key)
+	assert.True(t, sorts[1].DESC)
+
+	// column names are normalized to field names and unsortable keys are ignored
+	sorts = getSorts(&q.Query{Sorts: []*q.Sort{q.NewSort("name", false), q.NewSort("unknown", true)}, Cursor: q.NewCursor("")}, meta)
+	require.Len(t, sorts, 2)
+	assert.Equal(t, "Name", sorts[0].Key)
+	assert.False(t, sorts[0].DESC)
+	assert.Equal(t, "ID", sorts[1].Key)
+	assert.False(t, sorts[1].DESC)
+
+	// the primary key is already included
+	sorts = getSorts(&q.Query{Sorts: []*q.Sort{q.NewSort("id", true), q.NewSort("name", false)}, Cursor: q.NewCursor("")}, meta)
+	require.Len(t, sorts, 2)
+	assert.Equal(t, "ID", sorts[0].Key)
+	assert.Equal(t, "Name", sorts[1].Key)
+
+	// the keys without the field, e.g. the default sorts returned by "GetDefaultSorts", are kept
+	meta = &metadata{Keys: map[string]*key{}, DefaultSorts: []*q.Sort{q.NewSort("update_time", true)}, PK: "ID"}
+	sorts = getSorts(&q.Query{Cursor: q.NewCursor("")}, meta)
+	require.Len(t, sorts, 2)
+	assert.Equal(t, "update_time", sorts[0].Key)
+}
+
+func TestSetNextCursor(t *testing.T) {
+	now := time.Now().UTC()
+	records := []*baz{
+		{ID: 2, Name: "b", CreationTime: now},
+		{ID: 1, Name: "a", CreationTime: now},
+	}
+
+	// no cursor
+	query := &q.Query{PageSize: 2}
+	require.Nil(t, SetNextCursor(query, records))
+	assert.Nil(t, query.Cursor)
+
+	// the page isn't full
+	query = &q.Query{PageSize: 3, Cursor: q.NewCursor("")}
+	require.Nil(t, SetNextCursor(query, records))
+	assert.Empty(t, query.Cursor.Next)
+
+	// the page is full
+	query = &q.Query{PageSize: 2, Cursor: q.NewCursor("")}
+	require.Nil(t, SetNextCursor(query, records))
+	require.NotEmpty(t, query.Cursor.Next)
+
+	sorts := getSorts(query, parseModel(&baz{}))
+	values, err := decodeCursor(query.Cursor.Next, sorts, reflect.TypeOf(baz{}))
+	require.Nil(t, err)
+	require.Len(t, values, 2)
+	assert.True(t, now.Equal(values[0].(time.Time)))
+	assert.Equal(t, int64(1), values[1])
+
+	// non-slice records
+	assert.NotNil(t, SetNextCursor(query, records[0]))
+}
+
+func TestDecodeCursor(t *testing.T) {
+	meta := parseModel(&baz{})
+	sorts := getSorts(&q.Query{Sorts: []*q.Sort{q.NewSort("Name", false)}, Cursor: q.NewCursor("")}, meta)
+	value, err := encodeCursor(sorts, reflect.ValueOf(baz{ID: 3, Name: "c"}))
+	require.Nil(t, err)
+
+	values, err := decodeCursor(value, sorts, reflect.TypeOf(baz{}))
+	require.Nil(t, err)
+	assert.Equal(t, []interface{}{"c", int64(3)}, values)
+
+	// the cursor doesn't match the sorts
+	_, err = decodeCursor(value, getSorts(&q.Query{Cursor: q.NewCursor("")}, meta), reflect.TypeOf(baz{}))
+	require.NotNil(t, err)
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+
+	// malformed cursor
+	_, err = decodeCursor("!invalid", sorts, reflect.TypeOf(baz{}))
+	require.NotNil(t, err)
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+}
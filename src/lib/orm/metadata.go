@@ -33,6 +33,7 @@ var (
 
 type key struct {
 	Name       string
+	Field      string
 	Filterable bool
 	FilterFunc func(context.Context, orm.QuerySeter, string, interface{}) orm.QuerySeter
 	Sortable   bool
@@ -41,6 +42,8 @@ type key struct {
 type metadata struct {
 	Keys         map[string]*key
 	DefaultSorts []*q.Sort
+	// the field name of the primary key
+	PK string
 }
 
 func (m *metadata) Filterable(key string) (*key, bool) {
@@ -90,17 +93,22 @@ func parseModel(model interface{}) *metadata {
 
 		metadata.Keys[field.Name] = &key{
 			Name:       field.Name,
+			Field:      field.Name,
 			Filterable: filterable,
 			Sortable:   sortable,
 		}
 		metadata.Keys[column] = &key{
 			Name:       column,
+			Field:      field.Name,
 			Filterable: filterable,
 			Sortable:   sortable,
 		}
 		if defaultSort != nil {
 			metadata.DefaultSorts = []*q.Sort{defaultSort}
 		}
+		if parsePK(field) || (len(metadata.PK) == 0 && field.Name == "ID") {
+			metadata.PK = field.Name
+		}
 	}
 
 	// parse filter methods of the provided model
@@ -189,6 +197,20 @@ func parseSortable(field reflect.StructField) (*q.Sort, bool) {
 	return defaultSort, true
 }
 
+// parsePK parses whether the field is the primary key according to the field annotation
+//
+//	type Model struct {
+//		 Field1 int64 `orm:"pk;auto;column(id)"`
+//	}
+func parsePK(field reflect.StructField) bool {
+	for _, item := range strings.Split(field.Tag.Get("orm"), ";") {
+		if item == "pk" {
+			return true
+		}
+	}
+	return false
+}
+
 // parseColumn parses the column name according to the field annotation
 //
 //	type Model struct {
@@ -70,8 +70,24 @@ func init() {
 	}
 }
 
-// FromContext returns orm from context
+// FromContext returns orm from context, the orm of the read replica is returned instead when
+// the context is marked by "WithReplica", the replica is registered and no transaction is in progress
 func FromContext(ctx context.Context) (orm.QueryExecutor, error) {
+	o, err := primaryFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if readFromReplica(ctx) {
+		if _, ok := o.(orm.TxOrmer); !ok {
+			if replica := replicaOrmer(); replica != nil {
+				return replica, nil
+			}
+		}
+	}
+	return o, nil
+}
+
+func primaryFromContext(ctx context.Context) (orm.QueryExecutor, error) {
 	o, ok := ctx.Value(ormKey{}).(orm.QueryExecutor)
 	if !ok {
 		return nil, errors.New("cannot get the ORM from context")
@@ -79,6 +95,20 @@ func FromContext(ctx context.Context) (orm.QueryExecutor, error) {
 	return o, nil
 }
 
+// InTransaction returns whether the orm in the context is in a transaction. The queries in a transaction
+// share one database connection, so they must not be run concurrently
+func InTransaction(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	o, err := primaryFromContext(ctx)
+	if err != nil {
+		return false
+	}
+	_, ok := o.(orm.TxOrmer)
+	return ok
+}
+
 // NewContext returns new context with orm
 func NewContext(ctx context.Context, o orm.QueryExecutor) context.Context {
 	if ctx == nil {
@@ -130,7 +160,8 @@ func WithTransaction(f func(ctx context.Context) error) func(ctx context.Context
 	return func(ctx context.Context) error {
 		cx, span := tracelib.StartTrace(ctx, tracerName, GetTransactionOpNameFromContext(ctx))
 		defer span.End()
-		o, err := FromContext(ctx)
+		// the transaction always runs against the primary database
+		o, err := primaryFromContext(ctx)
 		if err != nil {
 			tracelib.RecordError(span, err, "get orm from ctx failed")
 			return err
@@ -145,7 +176,7 @@ func WithTransaction(f func(ctx context.Context) error) func(ctx context.Context
 			return errors.New("no orm found in the context")
 		}
 
-		if err := tx.Begin(); err != nil {
+		if err := tx.Begin(cx); err != nil {
 			tracelib.RecordError(span, err, "begin transaction failed")
 			log.Errorf("begin transaction failed: %v", err)
 			return err
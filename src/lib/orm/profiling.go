@@ -0,0 +1,75 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orm
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+
+	"github.com/goharbor/harbor/src/lib/profiling"
+)
+
+const maxProfiledQueryLength = 512
+
+var (
+	enableProfilingOnce sync.Once
+	// the placeholder lists of the "IN" clauses, e.g. "($1, $2, $3)"
+	placeholderListRegexp = regexp.MustCompile(`\(\s*\$\d+(\s*,\s*\$\d+)*\s*\)`)
+	whitespaceRegexp      = regexp.MustCompile(`\s+`)
+)
+
+// EnableProfiling records the durations of the queries of the ormers created after calling it into
+// the profiling collector, it only takes effect once
+func EnableProfiling() {
+	enableProfilingOnce.Do(func() {
+		// the durations of the queries are only measured by the debug logger of beego,
+		// discard the debug logs and get the durations from the log function
+		orm.DebugLog = orm.NewLog(io.Discard)
+		orm.LogFunc = profileQuery
+		orm.Debug = true
+	})
+}
+
+func profileQuery(query map[string]interface{}) {
+	cost, ok := query["cost_time"].(float64)
+	if !ok {
+		return
+	}
+	sql, ok := query["sql"].(string)
+	if !ok {
+		return
+	}
+	profiling.Record(profiling.KindQuery, normalizeQuery(sql), time.Duration(cost*float64(time.Millisecond)))
+}
+
+// normalizeQuery removes the arguments from the logged query and collapses the placeholder lists
+// to make the same queries with the different arguments profiled as one
+func normalizeQuery(sql string) string {
+	// the arguments are appended to the query in the format "query-`arg1`, `arg2`"
+	if i := strings.Index(sql, "-`"); i >= 0 {
+		sql = sql[:i]
+	}
+	sql = whitespaceRegexp.ReplaceAllString(strings.TrimSpace(sql), " ")
+	sql = placeholderListRegexp.ReplaceAllString(sql, "(...)")
+	if len(sql) > maxProfiledQueryLength {
+		sql = sql[:maxProfiledQueryLength] + "..."
+	}
+	return sql
+}
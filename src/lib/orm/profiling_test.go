@@ -0,0 +1,28 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	assert.Equal(t, `SELECT * FROM "artifact" WHERE "id" IN (...)`,
+		normalizeQuery("SELECT *\n\t\tFROM \"artifact\" WHERE \"id\" IN ($1, $2,$3)-`1`, `2`, `3`"))
+	assert.Equal(t, `UPDATE "repository" SET "pull_time" = $1 WHERE "repository_id" = $2`,
+		normalizeQuery("UPDATE \"repository\" SET \"pull_time\" = $1 WHERE \"repository_id\" = $2"))
+}
@@ -64,6 +64,10 @@ import (
 //			},
 //		 }
 //	}
+//
+// When the cursor is specified in the query, the primary key is appended to the sorts as the tiebreaker to make
+// the order stable. When the cursor value is specified, the keyset pagination is applied instead of the offset
+// and the "PageNumber" is ignored, call "SetNextCursor" with the returned records to populate the next cursor
 func QuerySetter(ctx context.Context, model interface{}, query *q.Query) (orm.QuerySeter, error) {
 	t := reflect.TypeOf(model)
 	if t.Kind() != reflect.Ptr {
@@ -83,12 +87,23 @@ func QuerySetter(ctx context.Context, model interface{}, query *q.Query) (orm.Qu
 	qs = setFilters(ctx, qs, query, metadata)
 
 	// sorting
-	qs = setSorts(qs, query, metadata)
+	sorts := getSorts(query, metadata)
+	qs = setSorts(qs, sorts)
+
+	// keyset pagination
+	keyset := query.Cursor != nil && len(query.Cursor.Value) > 0
+	if keyset {
+		values, err := decodeCursor(query.Cursor.Value, sorts, t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		qs = setKeyset(qs, sorts, values)
+	}
 
 	// pagination
 	if query.PageSize > 0 {
 		qs = qs.Limit(query.PageSize)
-		if query.PageNumber > 0 {
+		if query.PageNumber > 0 && !keyset {
 			qs = qs.Offset(query.PageSize * (query.PageNumber - 1))
 		}
 	}
@@ -121,6 +136,7 @@ func QuerySetterForCount(ctx context.Context, model interface{}, query *q.Query,
 	query.Sorts = nil
 	query.PageSize = 0
 	query.PageNumber = 0
+	query.Cursor = nil
 	return QuerySetter(ctx, model, query)
 }
 
@@ -181,29 +197,62 @@ func setFilters(ctx context.Context, qs orm.QuerySeter, query *q.Query, meta *me
 	return qs
 }
 
-// set sorts according to the query
-func setSorts(qs orm.QuerySeter, query *q.Query, meta *metadata) orm.QuerySeter {
-	var sortings []string
+// get the sorts according to the query. For the cursor pagination, the keys are normalized to the field names
+// which are used to encode the cursor, and the primary key is appended as the tiebreaker if it isn't included
+// to make the order stable, the sorts of the other queries are kept as they are
+func getSorts(query *q.Query, meta *metadata) []*q.Sort {
+	cursor := query.Cursor != nil
+	normalize := func(key string) string {
+		if k, exist := meta.Keys[key]; cursor && exist && len(k.Field) > 0 {
+			return k.Field
+		}
+		return key
+	}
+	var sorts []*q.Sort
 	for _, sort := range query.Sorts {
 		if !meta.Sortable(sort.Key) {
 			continue
 		}
+		sorts = append(sorts, &q.Sort{
+			Key:  normalize(sort.Key),
+			DESC: sort.DESC,
+		})
+	}
+	// if no sorts are specified, apply the default sort setting if exists
+	if len(sorts) == 0 {
+		for _, ds := range meta.DefaultSorts {
+			sorts = append(sorts, &q.Sort{
+				Key:  normalize(ds.Key),
+				DESC: ds.DESC,
+			})
+		}
+	}
+	if !cursor || len(meta.PK) == 0 {
+		return sorts
+	}
+	desc := false
+	for _, sort := range sorts {
+		if sort.Key == meta.PK {
+			return sorts
+		}
+		desc = sort.DESC
+	}
+	return append(sorts, &q.Sort{
+		Key:  meta.PK,
+		DESC: desc,
+	})
+}
+
+// set sorts to the query setter
+func setSorts(qs orm.QuerySeter, sorts []*q.Sort) orm.QuerySeter {
+	var sortings []string
+	for _, sort := range sorts {
 		sorting := sort.Key
 		if sort.DESC {
 			sorting = fmt.Sprintf("-%s", sorting)
 		}
 		sortings = append(sortings, sorting)
 	}
-	// if no sorts are specified, apply the default sort setting if exists
-	if len(sortings) == 0 {
-		for _, ds := range meta.DefaultSorts {
-			sorting := ds.Key
-			if ds.DESC {
-				sorting = fmt.Sprintf("-%s", sorting)
-			}
-			sortings = append(sortings, sorting)
-		}
-	}
 	if len(sortings) > 0 {
 		qs = qs.OrderBy(sortings...)
 	}
@@ -0,0 +1,60 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orm
+
+import (
+	"context"
+	"sync"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+// ReplicaAlias is the alias of the read replica database registered to the orm
+const ReplicaAlias = "replica"
+
+type replicaKey struct{}
+
+var (
+	replicaLock sync.Mutex
+	replica     orm.Ormer
+)
+
+// WithReplica returns a context which allows the queries to be served by the read replica database.
+// Only the read-only queries which tolerate the replication lag, e.g. listing, searching and reading logs,
+// should be marked. The queries run against the primary database if the replica isn't registered or
+// the context is inside a transaction
+func WithReplica(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, replicaKey{}, true)
+}
+
+func readFromReplica(ctx context.Context) bool {
+	read, _ := ctx.Value(replicaKey{}).(bool)
+	return read
+}
+
+// replicaOrmer returns the orm of the read replica, nil is returned if the replica isn't registered
+func replicaOrmer() orm.Ormer {
+	replicaLock.Lock()
+	defer replicaLock.Unlock()
+	if replica == nil {
+		if _, err := orm.GetDB(ReplicaAlias); err == nil {
+			replica = orm.NewOrmUsingDB(ReplicaAlias)
+		}
+	}
+	return replica
+}
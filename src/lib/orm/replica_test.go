@@ -0,0 +1,54 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/beego/beego/v2/client/orm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeOrmer struct {
+	orm.Ormer
+}
+
+type fakeTxOrmer struct {
+	orm.TxOrmer
+}
+
+func TestWithReplica(t *testing.T) {
+	assert.False(t, readFromReplica(context.TODO()))
+	assert.True(t, readFromReplica(WithReplica(context.TODO())))
+	assert.True(t, readFromReplica(WithReplica(nil)))
+}
+
+func TestFromContextWithReplicaNotRegistered(t *testing.T) {
+	// fall back to the primary when the replica isn't registered
+	ormer := &fakeOrmer{}
+	o, err := FromContext(WithReplica(NewContext(context.TODO(), ormer)))
+	require.Nil(t, err)
+	assert.Equal(t, ormer, o)
+
+	txOrmer := &fakeTxOrmer{}
+	o, err = FromContext(WithReplica(NewContext(context.TODO(), txOrmer)))
+	require.Nil(t, err)
+	assert.Equal(t, txOrmer, o)
+
+	_, err = FromContext(WithReplica(context.TODO()))
+	assert.NotNil(t, err)
+}
@@ -17,6 +17,7 @@ package test
 import (
 	"context"
 	"errors"
+	"os"
 	"sync"
 	"testing"
 
@@ -425,6 +426,41 @@ func (suite *OrmSuite) TestPaginationOnRawSQL() {
 	suite.Equal(int64(0), params[2])
 }
 
+func (suite *OrmSuite) TestReplica() {
+	// register the primary as the replica to verify the routing
+	replica := dao.NewPGSQL(os.Getenv("POSTGRESQL_HOST"), os.Getenv("POSTGRESQL_PORT"), os.Getenv("POSTGRESQL_USR"),
+		os.Getenv("POSTGRESQL_PWD"), os.Getenv("POSTGRESQL_DATABASE"), "", 1, 1, 0, 0, 0)
+	suite.Require().Nil(replica.Register(ReplicaAlias))
+
+	primary := orm.NewOrm()
+	ctx := NewContext(context.TODO(), primary)
+	o, err := FromContext(ctx)
+	suite.Nil(err)
+	suite.Equal(primary, o)
+
+	o, err = FromContext(WithReplica(ctx))
+	suite.Nil(err)
+	suite.NotEqual(primary, o)
+
+	id, err := addFoo(ctx, Foo{Name: "replica"})
+	suite.Require().Nil(err)
+	foo, err := readFoo(WithReplica(ctx), id)
+	suite.Nil(err)
+	suite.Equal("replica", foo.Name)
+
+	// the transaction always runs against the primary
+	t1 := WithTransaction(func(ctx context.Context) error {
+		o, err := FromContext(WithReplica(ctx))
+		suite.Nil(err)
+		_, ok := o.(orm.TxOrmer)
+		suite.True(ok)
+		return nil
+	})
+	suite.Nil(t1(WithReplica(ctx)))
+
+	suite.Nil(deleteFoo(ctx, id))
+}
+
 func TestRunOrmSuite(t *testing.T) {
 	suite.Run(t, new(OrmSuite))
 }
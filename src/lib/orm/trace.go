@@ -0,0 +1,62 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orm
+
+import (
+	"context"
+	"sync"
+
+	"github.com/beego/beego/v2/client/orm"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	tracelib "github.com/goharbor/harbor/src/lib/trace"
+)
+
+var enableTracingOnce sync.Once
+
+// EnableTracing traces the operations of the ormers created after calling it,
+// it only takes effect once
+func EnableTracing() {
+	enableTracingOnce.Do(func() {
+		orm.AddGlobalFilterChain(traceFilterChain)
+	})
+}
+
+// traceFilterChain starts a span for the operation of the ormer when the context is being traced,
+// the operations without a traced context are ignored to avoid the orphan spans
+func traceFilterChain(next orm.Filter) orm.Filter {
+	return func(ctx context.Context, inv *orm.Invocation) []interface{} {
+		if !oteltrace.SpanContextFromContext(ctx).IsValid() {
+			return next(ctx, inv)
+		}
+
+		ctx, span := tracelib.StartTrace(ctx, tracerName, "orm."+inv.Method, oteltrace.WithSpanKind(oteltrace.SpanKindClient))
+		defer span.End()
+		span.SetAttributes(semconv.DBSystemPostgreSQL, semconv.DBOperationKey.String(inv.Method))
+		if table := inv.GetTableName(); len(table) > 0 {
+			span.SetAttributes(semconv.DBSQLTableKey.String(table))
+		}
+
+		res := next(ctx, inv)
+		// the error is the last result of the ormer methods
+		if len(res) > 0 {
+			if err, ok := res[len(res)-1].(error); ok && err != nil && err != orm.ErrNoRows {
+				tracelib.RecordError(span, err, "orm operation failed")
+			}
+		}
+		return res
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/beego/beego/v2/client/orm"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type spanRecorder struct {
+	sync.Mutex
+	ended []tracesdk.ReadOnlySpan
+}
+
+func (r *spanRecorder) OnStart(context.Context, tracesdk.ReadWriteSpan) {}
+func (r *spanRecorder) OnEnd(s tracesdk.ReadOnlySpan) {
+	r.Lock()
+	defer r.Unlock()
+	r.ended = append(r.ended, s)
+}
+func (r *spanRecorder) Shutdown(context.Context) error   { return nil }
+func (r *spanRecorder) ForceFlush(context.Context) error { return nil }
+
+func TestTraceFilterChain(t *testing.T) {
+	recorder := &spanRecorder{}
+	provider := tracesdk.NewTracerProvider(tracesdk.WithSpanProcessor(recorder))
+	origin := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(origin)
+
+	filter := traceFilterChain(func(ctx context.Context, inv *orm.Invocation) []interface{} {
+		if inv.Method == "ReadWithCtx" {
+			return []interface{}{errors.New("failed")}
+		}
+		return []interface{}{int64(1), nil}
+	})
+
+	// not traced
+	res := filter(context.TODO(), &orm.Invocation{Method: "InsertWithCtx"})
+	assert.Equal(t, []interface{}{int64(1), nil}, res)
+	assert.Len(t, recorder.ended, 0)
+
+	ctx, span := provider.Tracer("test").Start(context.TODO(), "parent")
+	res = filter(ctx, &orm.Invocation{Method: "InsertWithCtx"})
+	assert.Equal(t, []interface{}{int64(1), nil}, res)
+	filter(ctx, &orm.Invocation{Method: "ReadWithCtx"})
+	span.End()
+
+	if assert.Len(t, recorder.ended, 3) {
+		assert.Equal(t, "orm.InsertWithCtx", recorder.ended[0].Name())
+		assert.Equal(t, span.SpanContext().SpanID(), recorder.ended[0].Parent().SpanID())
+		assert.Equal(t, codes.Unset, recorder.ended[0].Status().Code)
+		assert.Equal(t, "orm.ReadWithCtx", recorder.ended[1].Name())
+		assert.Equal(t, codes.Error, recorder.ended[1].Status().Code)
+	}
+}
@@ -63,11 +63,13 @@ func (o *ormerTx) rollbackToSavepoint() error {
 	return err
 }
 
-func (o *ormerTx) Begin() error {
+// Begin starts the transaction bound to the context, the transaction is rolled back
+// by the database/sql when the context is canceled or its deadline is exceeded
+func (o *ormerTx) Begin(ctx context.Context) error {
 	if o.TxOrmer != nil {
 		return o.createSavepoint()
 	}
-	txOrmer, err := o.Ormer.Begin()
+	txOrmer, err := o.Ormer.BeginWithCtx(ctx)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,222 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiling
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// the kinds of the profiled operations
+const (
+	KindRoute = "route"
+	KindQuery = "query"
+)
+
+// the window is split into the slots, the slots out of the window are dropped as a whole
+const defaultSlotCount = 15
+
+var (
+	// the global collector, nil means the profiling is disabled
+	collector *Collector
+	lock      sync.RWMutex
+)
+
+// Enable the global collection of the slowest operations over the window
+func Enable(window time.Duration, topN int) {
+	lock.Lock()
+	defer lock.Unlock()
+	collector = NewCollector(window, topN)
+}
+
+// Enabled returns whether the profiling is enabled
+func Enabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return collector != nil
+}
+
+// Record the duration of the operation into the global collector, it does nothing if the profiling is disabled
+func Record(kind, name string, duration time.Duration) {
+	lock.RLock()
+	c := collector
+	lock.RUnlock()
+	if c != nil {
+		c.Record(kind, name, duration)
+	}
+}
+
+// GetReport returns the report of the global collector, nil is returned if the profiling is disabled
+func GetReport() *Report {
+	lock.RLock()
+	c := collector
+	lock.RUnlock()
+	if c == nil {
+		return nil
+	}
+	return c.Report()
+}
+
+// Entry is the statistics of the durations of one operation
+type Entry struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+	// the durations in milliseconds
+	Max     float64 `json:"max_ms"`
+	Average float64 `json:"avg_ms"`
+	total   time.Duration
+	max     time.Duration
+}
+
+// Report contains the slowest operations over the window sorted by the max durations in descending order
+type Report struct {
+	Since   time.Time `json:"since"`
+	Window  string    `json:"window"`
+	Routes  []*Entry  `json:"routes"`
+	Queries []*Entry  `json:"queries"`
+}
+
+type slot struct {
+	start   time.Time
+	entries map[string]map[string]*Entry
+}
+
+// NewCollector returns a collector of the slowest operations over the window
+func NewCollector(window time.Duration, topN int) *Collector {
+	if window <= 0 {
+		window = 15 * time.Minute
+	}
+	if topN <= 0 {
+		topN = 20
+	}
+	return &Collector{
+		window:   window,
+		slotSize: window / defaultSlotCount,
+		topN:     topN,
+		now:      time.Now,
+	}
+}
+
+// Collector aggregates the durations of the operations per kind and name in the slots of the window
+type Collector struct {
+	window   time.Duration
+	slotSize time.Duration
+	topN     int
+	lock     sync.Mutex
+	slots    []*slot
+	now      func() time.Time
+}
+
+// Record the duration of the operation
+func (c *Collector) Record(kind, name string, duration time.Duration) {
+	now := c.now()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var current *slot
+	if n := len(c.slots); n > 0 && now.Sub(c.slots[n-1].start) < c.slotSize {
+		current = c.slots[n-1]
+	} else {
+		current = &slot{start: now, entries: map[string]map[string]*Entry{}}
+		c.slots = append(c.slots, current)
+		c.expire(now)
+	}
+	entries, ok := current.entries[kind]
+	if !ok {
+		entries = map[string]*Entry{}
+		current.entries[kind] = entries
+	}
+	entry, ok := entries[name]
+	if !ok {
+		entry = &Entry{Name: name}
+		entries[name] = entry
+	}
+	entry.Count++
+	entry.total += duration
+	if duration > entry.max {
+		entry.max = duration
+	}
+}
+
+// Report returns the slowest operations over the window
+func (c *Collector) Report() *Report {
+	now := c.now()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.expire(now)
+
+	merged := map[string]map[string]*Entry{}
+	for _, s := range c.slots {
+		for kind, entries := range s.entries {
+			if _, ok := merged[kind]; !ok {
+				merged[kind] = map[string]*Entry{}
+			}
+			for name, e := range entries {
+				m, ok := merged[kind][name]
+				if !ok {
+					m = &Entry{Name: name}
+					merged[kind][name] = m
+				}
+				m.Count += e.Count
+				m.total += e.total
+				if e.max > m.max {
+					m.max = e.max
+				}
+			}
+		}
+	}
+	return &Report{
+		Since:   now.Add(-c.window),
+		Window:  c.window.String(),
+		Routes:  c.top(merged[KindRoute]),
+		Queries: c.top(merged[KindQuery]),
+	}
+}
+
+// expire drops the slots out of the window
+func (c *Collector) expire(now time.Time) {
+	i := 0
+	for ; i < len(c.slots); i++ {
+		if now.Sub(c.slots[i].start) < c.window {
+			break
+		}
+	}
+	c.slots = c.slots[i:]
+}
+
+// top returns the top N entries sorted by the max durations
+func (c *Collector) top(entries map[string]*Entry) []*Entry {
+	result := make([]*Entry, 0, len(entries))
+	for _, e := range entries {
+		e.Max = milliseconds(e.max)
+		e.Average = milliseconds(e.total / time.Duration(e.Count))
+		result = append(result, e)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].max == result[j].max {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].max > result[j].max
+	})
+	if len(result) > c.topN {
+		result = result[:c.topN]
+	}
+	return result
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,77 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	now := time.Now()
+	c := NewCollector(15*time.Minute, 2)
+	c.now = func() time.Time { return now }
+
+	c.Record(KindRoute, "GET listProjects", 100*time.Millisecond)
+	c.Record(KindRoute, "GET listProjects", 300*time.Millisecond)
+	c.Record(KindRoute, "GET getProject", 50*time.Millisecond)
+	c.Record(KindRoute, "GET listArtifacts", 200*time.Millisecond)
+	c.Record(KindQuery, "SELECT 1", time.Millisecond)
+
+	report := c.Report()
+	require.Len(t, report.Routes, 2)
+	assert.Equal(t, "GET listProjects", report.Routes[0].Name)
+	assert.Equal(t, int64(2), report.Routes[0].Count)
+	assert.Equal(t, float64(300), report.Routes[0].Max)
+	assert.Equal(t, float64(200), report.Routes[0].Average)
+	assert.Equal(t, "GET listArtifacts", report.Routes[1].Name)
+	require.Len(t, report.Queries, 1)
+	assert.Equal(t, float64(1), report.Queries[0].Max)
+
+	// the operations recorded in the other slot are merged
+	now = now.Add(5 * time.Minute)
+	c.Record(KindRoute, "GET getProject", time.Second)
+	report = c.Report()
+	require.Len(t, report.Routes, 2)
+	assert.Equal(t, "GET getProject", report.Routes[0].Name)
+	assert.Equal(t, int64(2), report.Routes[0].Count)
+
+	// the operations out of the window are dropped
+	now = now.Add(12 * time.Minute)
+	report = c.Report()
+	require.Len(t, report.Routes, 1)
+	assert.Equal(t, "GET getProject", report.Routes[0].Name)
+	assert.Equal(t, int64(1), report.Routes[0].Count)
+	assert.Empty(t, report.Queries)
+}
+
+func TestGlobalCollector(t *testing.T) {
+	defer func() { collector = nil }()
+
+	assert.False(t, Enabled())
+	Record(KindRoute, "GET listProjects", time.Second)
+	assert.Nil(t, GetReport())
+
+	Enable(time.Minute, 10)
+	assert.True(t, Enabled())
+	Record(KindRoute, "GET listProjects", time.Second)
+	report := GetReport()
+	require.NotNil(t, report)
+	assert.Len(t, report.Routes, 1)
+	assert.Equal(t, "1m0s", report.Window)
+}
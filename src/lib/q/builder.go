@@ -29,7 +29,7 @@ import (
 // query string format: q=k=v,k=~v,k=[min~max],k={v1 v2 v3},k=(v1 v2 v3)
 // exact match: k=v
 // fuzzy match: k=~v
-// range: k=[min~max]
+// range: k=[min~max], either min or max can be omitted, e.g. k=[2024-01-01~]
 // or list: k={v1 v2 v3}
 // and list: k=(v1 v2 v3)
 // sort format: sort=k1,-k2
@@ -184,13 +184,23 @@ func parseList(value string, c rune) ([]interface{}, error) {
 	return vs, nil
 }
 
+// the supported layouts of the time value, e.g. "2020-04-09T02:36:00", "2020-04-09 02:36:00",
+// "2020-04-09T02:36:00+08:00" and "2020-04-09"
+var timeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+	"2006-01-02",
+}
+
 // try to parse value as time first, then integer, and last string
 func parseValue(value string) interface{} {
 	value = strings.TrimSpace(value)
 	// try to parse time
-	time, err := time.Parse("2006-01-02T15:04:05", value)
-	if err == nil {
-		return time
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
 	}
 	// try to parse integer
 	i, err := strconv.ParseInt(value, 10, 64)
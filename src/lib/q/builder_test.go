@@ -153,6 +153,13 @@ func TestParseValue(t *testing.T) {
 	_, ok := v.(time.Time)
 	require.True(t, ok)
 
+	// time with other layouts
+	for _, value := range []string{"2020-03-04 17:08:23", "2020-03-04T17:08:23+08:00", "2020-03-04"} {
+		v = parseValue(value)
+		_, ok = v.(time.Time)
+		require.True(t, ok, value)
+	}
+
 	// integer
 	value = "1"
 	v = parseValue(value)
@@ -266,3 +273,17 @@ func TestParseKeywords(t *testing.T) {
 	require.Nil(t, err)
 	assert.Equal(t, "tags=nil", keywords["q"].(string))
 }
+
+func TestBuild(t *testing.T) {
+	query, err := Build(`name=~nginx,labels=(1 2),push_time=[2024-01-01~]`, "-push_time", 1, 10)
+	require.Nil(t, err)
+	require.Len(t, query.Keywords, 3)
+	assert.Equal(t, "nginx", query.Keywords["name"].(*FuzzyMatchValue).Value)
+	assert.Equal(t, []interface{}{int64(1), int64(2)}, query.Keywords["labels"].(*AndList).Values)
+	r := query.Keywords["push_time"].(*Range)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), r.Min)
+	assert.Nil(t, r.Max)
+	require.Len(t, query.Sorts, 1)
+	assert.Equal(t, "push_time", query.Sorts[0].Key)
+	assert.True(t, query.Sorts[0].DESC)
+}
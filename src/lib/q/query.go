@@ -29,6 +29,8 @@ type Query struct {
 	PageSize int64
 	// Deprecate, use "Sorts" instead
 	Sorting string
+	// Cursor for the keyset pagination, the "PageNumber" is ignored when the cursor value is specified
+	Cursor *Cursor
 }
 
 // First make the query only fetch the first one record in the sorting order
@@ -57,6 +59,8 @@ func MustClone(query *Query) *Query {
 		q.PageNumber = query.PageNumber
 		q.PageSize = query.PageSize
 		q.Sorts = query.Sorts
+		// share the cursor with the original query to make the next cursor populated by the DAO visible to the caller
+		q.Cursor = query.Cursor
 		for k, v := range query.Keywords {
 			q.Keywords[k] = v
 		}
@@ -76,6 +80,15 @@ type Sort struct {
 	DESC bool
 }
 
+// Cursor specifies the position for the keyset pagination
+type Cursor struct {
+	// Value is the opaque cursor specified by the caller, the query returns the records after the position it points to
+	Value string
+	// Next is populated after the query is executed and points to the position of the last returned record,
+	// it's empty when there are no more records
+	Next string
+}
+
 // Range query
 type Range struct {
 	Min interface{}
@@ -105,6 +118,13 @@ func NewSort(key string, desc bool) *Sort {
 	}
 }
 
+// NewCursor creates a new cursor
+func NewCursor(value string) *Cursor {
+	return &Cursor{
+		Value: value,
+	}
+}
+
 // NewRange creates a new range
 func NewRange(min, max interface{}) *Range {
 	return &Range{
@@ -39,3 +39,12 @@ func TestMustClone(t *testing.T) {
 		})
 	}
 }
+
+func TestMustCloneSharesCursor(t *testing.T) {
+	query := &Query{Cursor: NewCursor("abc")}
+	cloned := MustClone(query)
+	cloned.Cursor.Next = "def"
+	if query.Cursor.Next != "def" {
+		t.Errorf("MustClone() should share the cursor with the original query")
+	}
+}
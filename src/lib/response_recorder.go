@@ -25,7 +25,9 @@ func NewResponseRecorder(w http.ResponseWriter) *ResponseRecorder {
 
 // ResponseRecorder is a wrapper for the http.ResponseWriter to record the response status code
 type ResponseRecorder struct {
-	StatusCode  int
+	StatusCode int
+	// Size is the number of the bytes written into the body
+	Size        int64
 	wroteHeader bool
 	http.ResponseWriter
 }
@@ -35,7 +37,9 @@ func (r *ResponseRecorder) Write(data []byte) (int, error) {
 	if !r.wroteHeader {
 		r.WriteHeader(http.StatusOK)
 	}
-	return r.ResponseWriter.Write(data)
+	n, err := r.ResponseWriter.Write(data)
+	r.Size += int64(n)
+	return n, err
 }
 
 // WriteHeader records the status code before writing the code to the underlying writer
@@ -47,6 +51,14 @@ func (r *ResponseRecorder) WriteHeader(statusCode int) {
 	}
 }
 
+// Flush sends the buffered data to the client if the underlying writer supports it,
+// it's required by the APIs streaming the response
+func (r *ResponseRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 // Success checks whether the status code is >= 200 & <= 399
 func (r *ResponseRecorder) Success() bool {
 	statusCode := r.StatusCode
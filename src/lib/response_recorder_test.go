@@ -47,6 +47,13 @@ func (r *responseRecorderTestSuite) TestWrite() {
 	r.Equal(http.StatusOK, r.recorder.StatusCode)
 }
 
+func (r *responseRecorderTestSuite) TestFlush() {
+	_, err := r.recorder.Write([]byte{'a'})
+	r.Require().Nil(err)
+	r.recorder.Flush()
+	r.True(r.recorder.ResponseWriter.(*httptest.ResponseRecorder).Flushed)
+}
+
 func (r *responseRecorderTestSuite) TestSuccess() {
 	r.recorder.WriteHeader(http.StatusInternalServerError)
 	r.False(r.recorder.Success())
@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
@@ -86,3 +87,27 @@ func NewHandler(h http.Handler, operation string) http.Handler {
 func StartTrace(ctx context.Context, tracerName string, spanName string, opts ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
 	return otel.Tracer(tracerName).Start(ctx, spanName, opts...)
 }
+
+// InjectCarrier returns the trace context of the given context as a map carrier,
+// which can be passed across the process boundary that doesn't carry HTTP headers, e.g. the job queue.
+// Nil is returned if there is no valid span in the context.
+func InjectCarrier(ctx context.Context) map[string]string {
+	if !oteltrace.SpanContextFromContext(ctx).IsValid() {
+		return nil
+	}
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// ExtractCarrier returns a copy of the given context with the trace context extracted from the carrier,
+// the spans started with the returned context are the children of the remote span
+func ExtractCarrier(ctx context.Context, carrier map[string]string) context.Context {
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
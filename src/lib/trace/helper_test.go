@@ -15,9 +15,14 @@
 package trace
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 func TestHarborSpanNameFormatter(t *testing.T) {
@@ -88,3 +93,33 @@ func TestHarborSpanNameFormatter(t *testing.T) {
 		})
 	}
 }
+
+func TestInjectExtractCarrier(t *testing.T) {
+	propagator := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(propagator)
+
+	// no span in the context
+	if carrier := InjectCarrier(context.Background()); carrier != nil {
+		t.Errorf("InjectCarrier() = %v, want nil", carrier)
+	}
+	if ctx := ExtractCarrier(context.Background(), nil); oteltrace.SpanContextFromContext(ctx).IsValid() {
+		t.Error("ExtractCarrier() with empty carrier returns a valid span context")
+	}
+
+	traceID, _ := oteltrace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := oteltrace.SpanIDFromHex("00f067aa0ba902b7")
+	sc := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: oteltrace.FlagsSampled,
+	})
+	carrier := InjectCarrier(oteltrace.ContextWithSpanContext(context.Background(), sc))
+	if len(carrier) == 0 {
+		t.Fatal("InjectCarrier() returns empty carrier")
+	}
+	extracted := oteltrace.SpanContextFromContext(ExtractCarrier(context.Background(), carrier))
+	if extracted.TraceID() != traceID || extracted.SpanID() != spanID || !extracted.IsRemote() {
+		t.Errorf("ExtractCarrier() = %v, want trace %s span %s", extracted, traceID, spanID)
+	}
+}
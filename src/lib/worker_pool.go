@@ -14,6 +14,12 @@
 
 package lib
 
+import (
+	"context"
+	"sync"
+	"time"
+)
+
 // NewWorkerPool creates a new worker pool with specified size
 func NewWorkerPool(size int32) *WorkerPool {
 	wp := &WorkerPool{}
@@ -35,3 +41,33 @@ func (w *WorkerPool) GetWorker() {
 func (w *WorkerPool) ReleaseWorker() {
 	<-w.queue
 }
+
+// RunConcurrently calls the fn for every index in [0, count) with at most "size" calls running at the
+// same time and returns after all of them finish. Each call gets a context which is cancelled after
+// the timeout if the timeout is larger than 0, so one slow item cannot hold the whole batch
+func RunConcurrently(ctx context.Context, size int32, count int, timeout time.Duration, fn func(ctx context.Context, i int)) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if size <= 0 {
+		size = 1
+	}
+	wp := NewWorkerPool(size)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < count; i++ {
+		wp.GetWorker()
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer wp.ReleaseWorker()
+			cx := ctx
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				cx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			fn(cx, i)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,57 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lib
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunConcurrently(t *testing.T) {
+	var running, maxRunning int32
+	results := make([]int, 10)
+	RunConcurrently(context.TODO(), 3, len(results), 0, func(ctx context.Context, i int) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		results[i] = i * 2
+		atomic.AddInt32(&running, -1)
+	})
+	for i, r := range results {
+		assert.Equal(t, i*2, r)
+	}
+	assert.LessOrEqual(t, maxRunning, int32(3))
+}
+
+func TestRunConcurrentlyWithTimeout(t *testing.T) {
+	errs := make([]error, 2)
+	RunConcurrently(context.TODO(), 2, len(errs), 50*time.Millisecond, func(ctx context.Context, i int) {
+		if i == 0 {
+			<-ctx.Done()
+		}
+		errs[i] = ctx.Err()
+	})
+	assert.Equal(t, context.DeadlineExceeded, errs[0])
+	assert.Nil(t, errs[1])
+}
@@ -56,7 +56,7 @@ func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 
 func (d *dao) List(ctx context.Context, query *q.Query) ([]*Accessory, error) {
@@ -65,7 +65,7 @@ func (d *dao) List(ctx context.Context, query *q.Query) ([]*Accessory, error) {
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&accs); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &accs); err != nil {
 		return nil, err
 	}
 	return accs, nil
@@ -79,7 +79,7 @@ func (d *dao) Get(ctx context.Context, id int64) (*Accessory, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := ormer.Read(acc); err != nil {
+	if err := ormer.ReadWithCtx(ctx, acc); err != nil {
 		if e := orm.AsNotFoundError(err, "accessory %d not found", id); e != nil {
 			err = e
 		}
@@ -93,7 +93,7 @@ func (d *dao) Create(ctx context.Context, acc *Accessory) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	id, err := ormer.Insert(acc)
+	id, err := ormer.InsertWithCtx(ctx, acc)
 	if err != nil {
 		if e := orm.AsConflictError(err, "accessory %s already exists under the artifact %d",
 			acc.Digest, acc.SubjectArtifactID); e != nil {
@@ -111,7 +111,7 @@ func (d *dao) Delete(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&Accessory{
+	n, err := ormer.DeleteWithCtx(ctx, &Accessory{
 		ID: id,
 	})
 	if err != nil {
@@ -128,5 +128,5 @@ func (d *dao) DeleteAccessories(ctx context.Context, query *q.Query) (int64, err
 	if err != nil {
 		return 0, err
 	}
-	return qs.Delete()
+	return qs.DeleteWithCtx(ctx)
 }
@@ -69,6 +69,13 @@ const (
 
 	// TypeNydusAccelerator ...
 	TypeNydusAccelerator = "accelerator.nydus"
+
+	// TypeSBOM ...
+	TypeSBOM = "sbom"
+	// TypeInTotoAttestation ...
+	TypeInTotoAttestation = "attestation.in-toto"
+	// TypeSubject is the generic type of the artifacts referring to their subject by the OCI subject field
+	TypeSubject = "subject.accessory"
 )
 
 // AccessoryData ...
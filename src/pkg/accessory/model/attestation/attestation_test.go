@@ -0,0 +1,71 @@
+package attestation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	htesting "github.com/goharbor/harbor/src/testing"
+)
+
+type AttestationTestSuite struct {
+	htesting.Suite
+	accessory model.Accessory
+	digest    string
+}
+
+func (suite *AttestationTestSuite) SetupSuite() {
+	suite.digest = suite.DigestString()
+	suite.accessory, _ = model.New(model.TypeInTotoAttestation,
+		model.AccessoryData{
+			ArtifactID:    1,
+			SubArtifactID: 2,
+			Size:          4321,
+			Digest:        suite.digest,
+		})
+}
+
+func (suite *AttestationTestSuite) TestGetID() {
+	suite.Equal(int64(0), suite.accessory.GetData().ID)
+}
+
+func (suite *AttestationTestSuite) TestGetArtID() {
+	suite.Equal(int64(1), suite.accessory.GetData().ArtifactID)
+}
+
+func (suite *AttestationTestSuite) TestSubGetArtID() {
+	suite.Equal(int64(2), suite.accessory.GetData().SubArtifactID)
+}
+
+func (suite *AttestationTestSuite) TestSubGetSize() {
+	suite.Equal(int64(4321), suite.accessory.GetData().Size)
+}
+
+func (suite *AttestationTestSuite) TestSubGetDigest() {
+	suite.Equal(suite.digest, suite.accessory.GetData().Digest)
+}
+
+func (suite *AttestationTestSuite) TestSubGetType() {
+	suite.Equal(model.TypeInTotoAttestation, suite.accessory.GetData().Type)
+}
+
+func (suite *AttestationTestSuite) TestSubGetRefType() {
+	suite.Equal(model.RefHard, suite.accessory.Kind())
+}
+
+func (suite *AttestationTestSuite) TestIsSoft() {
+	suite.False(suite.accessory.IsSoft())
+}
+
+func (suite *AttestationTestSuite) TestIsHard() {
+	suite.True(suite.accessory.IsHard())
+}
+
+func (suite *AttestationTestSuite) TestDisplay() {
+	suite.False(suite.accessory.Display())
+}
+
+func TestCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(AttestationTestSuite))
+}
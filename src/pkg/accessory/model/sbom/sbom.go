@@ -0,0 +1,46 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/pkg/accessory/model/base"
+)
+
+// SBOM sbom model
+type SBOM struct {
+	base.Default
+}
+
+// Kind gives the reference type of sbom.
+func (a *SBOM) Kind() string {
+	return model.RefHard
+}
+
+// IsHard ...
+func (a *SBOM) IsHard() bool {
+	return true
+}
+
+// New returns sbom
+func New(data model.AccessoryData) model.Accessory {
+	return &SBOM{base.Default{
+		Data: data,
+	}}
+}
+
+func init() {
+	model.Register(model.TypeSBOM, New)
+}
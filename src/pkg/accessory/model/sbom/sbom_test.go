@@ -0,0 +1,71 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	htesting "github.com/goharbor/harbor/src/testing"
+)
+
+type SBOMTestSuite struct {
+	htesting.Suite
+	accessory model.Accessory
+	digest    string
+}
+
+func (suite *SBOMTestSuite) SetupSuite() {
+	suite.digest = suite.DigestString()
+	suite.accessory, _ = model.New(model.TypeSBOM,
+		model.AccessoryData{
+			ArtifactID:    1,
+			SubArtifactID: 2,
+			Size:          4321,
+			Digest:        suite.digest,
+		})
+}
+
+func (suite *SBOMTestSuite) TestGetID() {
+	suite.Equal(int64(0), suite.accessory.GetData().ID)
+}
+
+func (suite *SBOMTestSuite) TestGetArtID() {
+	suite.Equal(int64(1), suite.accessory.GetData().ArtifactID)
+}
+
+func (suite *SBOMTestSuite) TestSubGetArtID() {
+	suite.Equal(int64(2), suite.accessory.GetData().SubArtifactID)
+}
+
+func (suite *SBOMTestSuite) TestSubGetSize() {
+	suite.Equal(int64(4321), suite.accessory.GetData().Size)
+}
+
+func (suite *SBOMTestSuite) TestSubGetDigest() {
+	suite.Equal(suite.digest, suite.accessory.GetData().Digest)
+}
+
+func (suite *SBOMTestSuite) TestSubGetType() {
+	suite.Equal(model.TypeSBOM, suite.accessory.GetData().Type)
+}
+
+func (suite *SBOMTestSuite) TestSubGetRefType() {
+	suite.Equal(model.RefHard, suite.accessory.Kind())
+}
+
+func (suite *SBOMTestSuite) TestIsSoft() {
+	suite.False(suite.accessory.IsSoft())
+}
+
+func (suite *SBOMTestSuite) TestIsHard() {
+	suite.True(suite.accessory.IsHard())
+}
+
+func (suite *SBOMTestSuite) TestDisplay() {
+	suite.False(suite.accessory.Display())
+}
+
+func TestCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(SBOMTestSuite))
+}
@@ -0,0 +1,152 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg/activity/model"
+)
+
+// the resource types of the audit logs recording the changes of the policies
+var policyResourceTypes = []string{"replication_policy", "retention_policy"}
+
+// DAO is the data access object for the activities, the activities are ordered by the time in descending order
+type DAO interface {
+	// List returns the total count and the activities of the page matched by the query
+	List(ctx context.Context, query *model.Query, pageNumber, pageSize int64) (int64, []*model.Activity, error)
+}
+
+// New returns an instance of the default DAO
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+// List ...
+func (d *dao) List(ctx context.Context, query *model.Query, pageNumber, pageSize int64) (int64, []*model.Activity, error) {
+	activities := []*model.Activity{}
+	if query.ProjectIDs != nil && len(query.ProjectIDs) == 0 {
+		return 0, activities, nil
+	}
+	union, params := buildActivitySQL(query)
+	if len(union) == 0 {
+		return 0, activities, nil
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	var total int64
+	if err = ormer.Raw(`SELECT COUNT(*) FROM (`+union+`) AS activity`, params...).QueryRow(&total); err != nil {
+		return 0, nil, err
+	}
+	if total == 0 {
+		return 0, activities, nil
+	}
+	sql := `SELECT * FROM (` + union + `) AS activity ORDER BY op_time DESC`
+	sql, params = paginate(sql, params, pageNumber, pageSize)
+	if _, err = ormer.Raw(sql, params...).QueryRows(&activities); err != nil {
+		return 0, nil, err
+	}
+	return total, activities, nil
+}
+
+// buildActivitySQL builds the union of the selections of the activities of the types specified in the query,
+// the pushes, the policy and the member changes come from the audit logs and the scans come from the executions
+func buildActivitySQL(query *model.Query) (string, []interface{}) {
+	var (
+		selections []string
+		params     []interface{}
+	)
+	auditLog := func(typ, condition string, args ...interface{}) {
+		// param "typ" is one of the constant types, no need to sanitize
+		sql := fmt.Sprintf(`SELECT '%s' AS type, project_id, resource, operation, username AS operator, '' AS status, op_time
+			FROM audit_log WHERE %s`, typ, condition)
+		if query.ProjectIDs != nil {
+			sql += ` AND project_id ` + inClause(query.ProjectIDs)
+		}
+		selections = append(selections, sql)
+		params = append(params, args...)
+	}
+	for _, typ := range model.Types {
+		if !includeType(query, typ) {
+			continue
+		}
+		switch typ {
+		case model.TypePush:
+			auditLog(typ, `resource_type = ? AND operation = ?`, "artifact", "create")
+		case model.TypePolicy:
+			var args []interface{}
+			for _, t := range policyResourceTypes {
+				args = append(args, t)
+			}
+			auditLog(typ, fmt.Sprintf(`resource_type IN (%s)`, orm.ParamPlaceholderForIn(len(args))), args...)
+		case model.TypeMember:
+			auditLog(typ, `resource_type = ?`, "project_member")
+		case model.TypeScan:
+			// the project ID, the repository and the digest of the scanned artifact are recorded in the extra attributes
+			projectID := `(extra_attrs->'artifact'->>'project_id')::bigint`
+			sql := `SELECT '` + typ + `' AS type, ` + projectID + ` AS project_id,
+				CONCAT(extra_attrs->'artifact'->>'repository_name', '@', extra_attrs->'artifact'->>'digest') AS resource,
+				'scan' AS operation, trigger AS operator, status, start_time AS op_time
+				FROM execution WHERE vendor_type = ?`
+			if query.ProjectIDs != nil {
+				sql += ` AND ` + projectID + ` ` + inClause(query.ProjectIDs)
+			}
+			selections = append(selections, sql)
+			params = append(params, job.ImageScanJob)
+		}
+	}
+	return strings.Join(selections, " UNION ALL "), params
+}
+
+func includeType(query *model.Query, typ string) bool {
+	if len(query.Types) == 0 {
+		return true
+	}
+	for _, t := range query.Types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// inClause concats the IDs into the IN clause directly to avoid the too many arguments issue
+func inClause(ids []int64) string {
+	idStrs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		idStrs = append(idStrs, strconv.FormatInt(id, 10))
+	}
+	return fmt.Sprintf(`IN (%s)`, strings.Join(idStrs, ","))
+}
+
+func paginate(sql string, params []interface{}, pageNumber, pageSize int64) (string, []interface{}) {
+	if pageSize > 0 {
+		if pageNumber <= 0 {
+			pageNumber = 1
+		}
+		sql += ` LIMIT ? OFFSET ?`
+		params = append(params, pageSize, (pageNumber-1)*pageSize)
+	}
+	return sql, params
+}
@@ -0,0 +1,41 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/pkg/activity/model"
+)
+
+func TestBuildActivitySQL(t *testing.T) {
+	sql, params := buildActivitySQL(&model.Query{})
+	assert.Equal(t, 3, strings.Count(sql, "UNION ALL"))
+	assert.NotContains(t, sql, "project_id IN")
+	assert.Equal(t, []interface{}{"artifact", "create", "IMAGE_SCAN", "replication_policy", "retention_policy", "project_member"}, params)
+
+	sql, params = buildActivitySQL(&model.Query{ProjectIDs: []int64{1, 2}, Types: []string{model.TypeScan, model.TypeMember}})
+	assert.Equal(t, 1, strings.Count(sql, "UNION ALL"))
+	assert.Contains(t, sql, `AND project_id IN (1,2)`)
+	assert.Contains(t, sql, `(extra_attrs->'artifact'->>'project_id')::bigint IN (1,2)`)
+	assert.Equal(t, []interface{}{"IMAGE_SCAN", "project_member"}, params)
+
+	sql, params = buildActivitySQL(&model.Query{Types: []string{"unknown"}})
+	assert.Empty(t, sql)
+	assert.Empty(t, params)
+}
@@ -0,0 +1,45 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activity
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/pkg/activity/dao"
+	"github.com/goharbor/harbor/src/pkg/activity/model"
+)
+
+// Mgr is the global activity manager instance
+var Mgr = New()
+
+// Manager lists the recent activities, e.g. the pushes, the scans, the policy and the member changes
+type Manager interface {
+	// List returns the total count and the activities of the page matched by the query,
+	// the latest activities come first
+	List(ctx context.Context, query *model.Query, pageNumber, pageSize int64) (int64, []*model.Activity, error)
+}
+
+// New returns a default implementation of Manager
+func New() Manager {
+	return &manager{dao: dao.New()}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+func (m *manager) List(ctx context.Context, query *model.Query, pageNumber, pageSize int64) (int64, []*model.Activity, error) {
+	return m.dao.List(ctx, query, pageNumber, pageSize)
+}
@@ -0,0 +1,54 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// the types of the activities
+const (
+	// TypePush is the pushing of the artifacts
+	TypePush = "push"
+	// TypeScan is the scanning of the artifacts
+	TypeScan = "scan"
+	// TypePolicy is the changing of the replication and retention policies
+	TypePolicy = "policy"
+	// TypeMember is the changing of the project members
+	TypeMember = "member"
+)
+
+// Types contains all the supported activity types
+var Types = []string{TypePush, TypeScan, TypePolicy, TypeMember}
+
+// Query is the query of the activities
+type Query struct {
+	// the IDs of the projects that the activities belong to, nil means all the projects
+	// while the empty slice means none
+	ProjectIDs []int64
+	// the types of the activities, all the types are included if it's empty
+	Types []string
+}
+
+// Activity summarizes a recent operation, it's resolved from the audit logs and the scan executions
+type Activity struct {
+	Type      string    `orm:"column(type)" json:"type"`
+	ProjectID int64     `orm:"column(project_id)" json:"project_id"`
+	Resource  string    `orm:"column(resource)" json:"resource"`
+	Operation string    `orm:"column(operation)" json:"operation"`
+	Operator  string    `orm:"column(operator)" json:"operator"`
+	Status    string    `orm:"column(status)" json:"status"`
+	OpTime    time.Time `orm:"column(op_time)" json:"op_time"`
+}
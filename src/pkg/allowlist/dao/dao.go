@@ -44,7 +44,7 @@ func (d *dao) Set(ctx context.Context, l models.CVEAllowlist) (int64, error) {
 	l.UpdateTime = now
 	itemsBytes, _ := json.Marshal(l.Items)
 	l.ItemsText = string(itemsBytes)
-	return ormer.InsertOrUpdate(&l, "project_id")
+	return ormer.InsertOrUpdateWithCtx(ctx, &l, "project_id")
 }
 
 func (d *dao) QueryByProjectID(ctx context.Context, pid int64) (*models.CVEAllowlist, error) {
@@ -55,7 +55,7 @@ func (d *dao) QueryByProjectID(ctx context.Context, pid int64) (*models.CVEAllow
 	qs := ormer.QueryTable(&models.CVEAllowlist{})
 	qs = qs.Filter("ProjectID", pid)
 	var r []models.CVEAllowlist
-	_, err = qs.All(&r)
+	_, err = qs.AllWithCtx(ctx, &r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get CVE allowlist for project %d, error: %v", pid, err)
 	}
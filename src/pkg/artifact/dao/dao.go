@@ -93,7 +93,7 @@ func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 func (d *dao) List(ctx context.Context, query *q.Query) ([]*Artifact, error) {
 	artifacts := []*Artifact{}
@@ -101,7 +101,7 @@ func (d *dao) List(ctx context.Context, query *q.Query) ([]*Artifact, error) {
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&artifacts); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &artifacts); err != nil {
 		return nil, err
 	}
 
@@ -115,7 +115,7 @@ func (d *dao) Get(ctx context.Context, id int64) (*Artifact, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err = ormer.Read(artifact); err != nil {
+	if err = ormer.ReadWithCtx(ctx, artifact); err != nil {
 		if e := orm.AsNotFoundError(err, "artifact %d not found", id); e != nil {
 			err = e
 		}
@@ -135,7 +135,7 @@ func (d *dao) GetByDigest(ctx context.Context, repository, digest string) (*Arti
 		return nil, err
 	}
 	artifacts := []*Artifact{}
-	if _, err = qs.All(&artifacts); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &artifacts); err != nil {
 		return nil, err
 	}
 	if len(artifacts) == 0 {
@@ -150,7 +150,7 @@ func (d *dao) Create(ctx context.Context, artifact *Artifact) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	id, err := ormer.Insert(artifact)
+	id, err := ormer.InsertWithCtx(ctx, artifact)
 	if err != nil {
 		if e := orm.AsConflictError(err, "artifact %s already exists under the repository %d",
 			artifact.Digest, artifact.RepositoryID); e != nil {
@@ -164,7 +164,7 @@ func (d *dao) Delete(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&Artifact{
+	n, err := ormer.DeleteWithCtx(ctx, &Artifact{
 		ID: id,
 	})
 	if err != nil {
@@ -186,7 +186,7 @@ func (d *dao) Update(ctx context.Context, artifact *Artifact, props ...string) e
 		return err
 	}
 
-	n, err := ormer.Update(artifact, props...)
+	n, err := ormer.UpdateWithCtx(ctx, artifact, props...)
 	if err != nil {
 		return err
 	}
@@ -221,7 +221,7 @@ func (d *dao) CreateReference(ctx context.Context, reference *ArtifactReference)
 	if err != nil {
 		return 0, err
 	}
-	id, err := ormer.Insert(reference)
+	id, err := ormer.InsertWithCtx(ctx, reference)
 	if err != nil {
 		if e := orm.AsConflictError(err, "reference already exists, parent artifact ID: %d, child artifact ID: %d",
 			reference.ParentID, reference.ChildID); e != nil {
@@ -239,7 +239,7 @@ func (d *dao) ListReferences(ctx context.Context, query *q.Query) ([]*ArtifactRe
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&references); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &references); err != nil {
 		return nil, err
 	}
 	return references, nil
@@ -250,7 +250,7 @@ func (d *dao) DeleteReference(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&ArtifactReference{ID: id})
+	n, err := ormer.DeleteWithCtx(ctx, &ArtifactReference{ID: id})
 	if err != nil {
 		return err
 	}
@@ -274,7 +274,7 @@ func (d *dao) DeleteReferences(ctx context.Context, parentID int64) error {
 	if err != nil {
 		return err
 	}
-	_, err = qs.Delete()
+	_, err = qs.DeleteWithCtx(ctx)
 	return err
 }
 
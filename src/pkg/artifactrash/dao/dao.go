@@ -36,7 +36,7 @@ func (d *dao) Create(ctx context.Context, artifactrsh *model.ArtifactTrash) (id
 		return 0, err
 	}
 	artifactrsh.CreationTime = time.Now()
-	id, err = ormer.Insert(artifactrsh)
+	id, err = ormer.InsertWithCtx(ctx, artifactrsh)
 	if err != nil {
 		if e := orm.AsConflictError(err, "artifact trash %s already exists under the repository %s",
 			artifactrsh.Digest, artifactrsh.RepositoryName); e != nil {
@@ -52,7 +52,7 @@ func (d *dao) Delete(ctx context.Context, id int64) (err error) {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&model.ArtifactTrash{
+	n, err := ormer.DeleteWithCtx(ctx, &model.ArtifactTrash{
 		ID: id,
 	})
 	if err != nil {
@@ -32,6 +32,8 @@ import (
 type DAO interface {
 	// Create the audit log
 	Create(ctx context.Context, access *model.AuditLog) (id int64, err error)
+	// CreateBatch creates the audit logs in batch
+	CreateBatch(ctx context.Context, accesses []*model.AuditLog) (err error)
 	// Count returns the total count of audit logs according to the query
 	Count(ctx context.Context, query *q.Query) (total int64, err error)
 	// List audit logs according to the query
@@ -127,21 +129,28 @@ func permitOps(includeOperations []string) []string {
 
 // Count ...
 func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
+	// counting the audit logs tolerates the replication lag
+	ctx = orm.WithReplica(ctx)
 	qs, err := orm.QuerySetterForCount(ctx, &model.AuditLog{}, query)
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 
 // List ...
 func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.AuditLog, error) {
+	// listing the audit logs tolerates the replication lag
+	ctx = orm.WithReplica(ctx)
 	audit := []*model.AuditLog{}
 	qs, err := orm.QuerySetter(ctx, &model.AuditLog{}, query)
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&audit); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &audit); err != nil {
+		return nil, err
+	}
+	if err = orm.SetNextCursor(query, audit); err != nil {
 		return nil, err
 	}
 	return audit, nil
@@ -156,7 +165,7 @@ func (d *dao) Get(ctx context.Context, id int64) (*model.AuditLog, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := ormer.Read(audit); err != nil {
+	if err := ormer.ReadWithCtx(ctx, audit); err != nil {
 		if e := orm.AsNotFoundError(err, "audit %d not found", id); e != nil {
 			err = e
 		}
@@ -176,20 +185,38 @@ func (d *dao) Create(ctx context.Context, audit *model.AuditLog) (int64, error)
 	if len(audit.Username) > 255 {
 		audit.Username = audit.Username[:252] + "..."
 	}
-	id, err := ormer.Insert(audit)
+	id, err := ormer.InsertWithCtx(ctx, audit)
 	if err != nil {
 		return 0, err
 	}
 	return id, err
 }
 
+// CreateBatch ...
+func (d *dao) CreateBatch(ctx context.Context, audits []*model.AuditLog) error {
+	if len(audits) == 0 {
+		return nil
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	for _, audit := range audits {
+		if len(audit.Username) > 255 {
+			audit.Username = audit.Username[:252] + "..."
+		}
+	}
+	_, err = ormer.InsertMultiWithCtx(ctx, len(audits), audits)
+	return err
+}
+
 // Delete ...
 func (d *dao) Delete(ctx context.Context, id int64) error {
 	ormer, err := orm.FromContext(ctx)
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&model.AuditLog{
+	n, err := ormer.DeleteWithCtx(ctx, &model.AuditLog{
 		ID: id,
 	})
 	if err != nil {
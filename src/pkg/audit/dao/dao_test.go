@@ -89,6 +89,27 @@ func (d *daoTestSuite) TestList() {
 	d.Equal("admin", audits[0].Username)
 }
 
+func (d *daoTestSuite) TestListByName() {
+	// exact match
+	audits, err := d.dao.List(d.ctx, q.New(q.KeyWords{"name": "library/test-audit"}))
+	d.Require().Nil(err)
+	d.Len(audits, 1)
+
+	// fuzzy match
+	audits, err = d.dao.List(d.ctx, q.New(q.KeyWords{"name": &q.FuzzyMatchValue{Value: "test-aud"}}))
+	d.Require().Nil(err)
+	d.Len(audits, 1)
+
+	// or list
+	audits, err = d.dao.List(d.ctx, q.New(q.KeyWords{"name": &q.OrList{Values: []interface{}{"library/test-audit", "library/other"}}}))
+	d.Require().Nil(err)
+	d.Len(audits, 1)
+
+	audits, err = d.dao.List(d.ctx, q.New(q.KeyWords{"name": "library/other"}))
+	d.Require().Nil(err)
+	d.Len(audits, 0)
+}
+
 func (d *daoTestSuite) TestGet() {
 	// get the non-exist tag
 	_, err := d.dao.Get(d.ctx, 10000)
@@ -157,6 +178,34 @@ func (d *daoTestSuite) TestCreate() {
 	d.Require().Nil(err)
 }
 
+func (d *daoTestSuite) TestCreateBatch() {
+	err := d.dao.CreateBatch(d.ctx, nil)
+	d.Require().Nil(err)
+
+	err = d.dao.CreateBatch(d.ctx, []*model.AuditLog{
+		{
+			Operation:    "Create",
+			ResourceType: "tag",
+			Resource:     "library/batch",
+			Username:     "admin",
+		},
+		{
+			Operation:    "Delete",
+			ResourceType: "tag",
+			Resource:     "library/batch",
+			Username:     "admin",
+		},
+	})
+	d.Require().Nil(err)
+	total, err := d.dao.Count(d.ctx, &q.Query{
+		Keywords: map[string]interface{}{
+			"Resource": "library/batch",
+		},
+	})
+	d.Require().Nil(err)
+	d.Equal(int64(2), total)
+}
+
 func (d *daoTestSuite) TestDelete() {
 	err := d.dao.Delete(d.ctx, 10000)
 	d.Require().NotNil(err)
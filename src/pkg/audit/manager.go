@@ -70,17 +70,30 @@ func (m *manager) Get(ctx context.Context, id int64) (*model.AuditLog, error) {
 
 // Create ...
 func (m *manager) Create(ctx context.Context, audit *model.AuditLog) (int64, error) {
-	if len(config.AuditLogForwardEndpoint(ctx)) > 0 {
-		LogMgr.DefaultLogger(ctx).WithField("operator", audit.Username).
-			WithField("time", audit.OpTime).WithField("resourceType", audit.ResourceType).
-			Infof("action:%s, resource:%s", audit.Operation, audit.Resource)
-	}
+	forward(ctx, audit)
 	if config.SkipAuditLogDatabase(ctx) {
 		return 0, nil
 	}
 	return m.dao.Create(ctx, audit)
 }
 
+// forward the audit log to the forward endpoint if it is configured
+func forward(ctx context.Context, audit *model.AuditLog) {
+	endpoint := config.AuditLogForwardEndpoint(ctx)
+	if len(endpoint) == 0 {
+		return
+	}
+	format := config.AuditLogForwardFormat(ctx)
+	// keep the legacy plain text syslog forwarding
+	if (len(format) == 0 || format == ForwardFormatText) && !IsHTTPEndpoint(endpoint) {
+		LogMgr.DefaultLogger(ctx).WithField("operator", audit.Username).
+			WithField("time", audit.OpTime).WithField("resourceType", audit.ResourceType).
+			Infof("action:%s, resource:%s", audit.Operation, audit.Resource)
+		return
+	}
+	Forwarder.Forward(ctx, endpoint, format, config.AuditLogForwardToken(ctx), audit)
+}
+
 // Purge ...
 func (m *manager) Purge(ctx context.Context, retentionHour int, includeOperations []string, dryRun bool) (int64, error) {
 	return m.dao.Purge(ctx, retentionHour, includeOperations, dryRun)
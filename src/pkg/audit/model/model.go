@@ -1,9 +1,13 @@
 package model
 
 import (
+	"context"
 	"time"
 
 	beego_orm "github.com/beego/beego/v2/client/orm"
+
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
 )
 
 func init() {
@@ -19,6 +23,27 @@ type AuditLog struct {
 	Resource     string    `orm:"column(resource)" json:"resource"`
 	Username     string    `orm:"column(username)"  json:"username"`
 	OpTime       time.Time `orm:"column(op_time)" json:"op_time" sort:"default:desc"`
+	// the JSON snapshots of the changed resource before and after the operation, the secrets are redacted
+	BeforeSnapshot string `orm:"column(before_snapshot)" json:"before_snapshot,omitempty"`
+	AfterSnapshot  string `orm:"column(after_snapshot)" json:"after_snapshot,omitempty"`
+}
+
+// FilterByName filters the audit logs by the name of the resource, so the logs can be queried with the "name"
+// key as the other resources, e.g. q=name=~nginx
+func (a *AuditLog) FilterByName(ctx context.Context, qs orm.QuerySeter, key string, value interface{}) orm.QuerySeter {
+	switch v := value.(type) {
+	case string:
+		return qs.Filter("resource", v)
+	case *q.FuzzyMatchValue:
+		return qs.Filter("resource__icontains", orm.Escape(v.Value))
+	case *q.OrList:
+		if len(v.Values) == 0 {
+			return qs.Filter("resource__in", nil)
+		}
+		return qs.Filter("resource__in", v.Values...)
+	default:
+		return qs
+	}
 }
 
 // TableName for audit log
@@ -0,0 +1,317 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/syslog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	commonhttp "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/retry"
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+	"github.com/goharbor/harbor/src/pkg/version"
+)
+
+// the formats of the forwarded audit logs
+const (
+	ForwardFormatText = "text"
+	ForwardFormatCEF  = "cef"
+	ForwardFormatJSON = "json"
+)
+
+const (
+	// the total time to retry sending one audit log before dropping it
+	defaultForwardRetryTimeout = 30 * time.Second
+	defaultForwardHTTPTimeout  = 10 * time.Second
+)
+
+// Forwarder is the global SIEM forwarder instance
+var Forwarder = NewSIEMForwarder(defaultBufferSize, defaultForwardRetryTimeout)
+
+// ValidateForwardFormat checks whether the format of the forwarded audit log is supported
+func ValidateForwardFormat(format string) error {
+	switch format {
+	case "", ForwardFormatText, ForwardFormatCEF, ForwardFormatJSON:
+		return nil
+	}
+	return errors.BadRequestError(nil).WithMessage("unsupported audit log forward format %s, the supported ones are text, cef and json", format)
+}
+
+// IsHTTPEndpoint returns whether the audit logs are forwarded to an HTTPS collector rather than a syslog endpoint
+func IsHTTPEndpoint(endpoint string) bool {
+	return strings.HasPrefix(endpoint, "https://") || strings.HasPrefix(endpoint, "http://")
+}
+
+// Format the audit log as the forwarded message in the specified format
+func Format(audit *model.AuditLog, format string) ([]byte, error) {
+	switch format {
+	case ForwardFormatJSON:
+		return json.Marshal(audit)
+	case ForwardFormatCEF:
+		return []byte(formatCEF(audit)), nil
+	default:
+		return []byte(fmt.Sprintf("operator:%s, time:%s, resourceType:%s, action:%s, resource:%s",
+			audit.Username, audit.OpTime.Format(time.RFC3339), audit.ResourceType, audit.Operation, audit.Resource)), nil
+	}
+}
+
+// formatCEF formats the audit log in the ArcSight Common Event Format:
+// CEF:Version|Device Vendor|Device Product|Device Version|Signature ID|Name|Severity|Extension
+func formatCEF(audit *model.AuditLog) string {
+	severity := 3
+	if audit.Operation == "delete" {
+		severity = 6
+	}
+	extensions := []string{
+		"rt=" + cefExtension(fmt.Sprintf("%d", audit.OpTime.UnixMilli())),
+		"suser=" + cefExtension(audit.Username),
+		"act=" + cefExtension(audit.Operation),
+		"cs1Label=resourceType",
+		"cs1=" + cefExtension(audit.ResourceType),
+		"cs2Label=resource",
+		"cs2=" + cefExtension(audit.Resource),
+		"cn1Label=projectID",
+		fmt.Sprintf("cn1=%d", audit.ProjectID),
+	}
+	return fmt.Sprintf("CEF:0|Harbor|Harbor|%s|%s|%s|%d|%s",
+		cefHeader(version.ReleaseVersion),
+		cefHeader(audit.ResourceType+":"+audit.Operation),
+		cefHeader(audit.ResourceType+" "+audit.Operation),
+		severity,
+		strings.Join(extensions, " "))
+}
+
+func cefHeader(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ").Replace(s)
+}
+
+func cefExtension(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`).Replace(s)
+}
+
+// sink sends the formatted audit logs to the SIEM endpoint
+type sink interface {
+	Send(format string, data []byte) error
+	Close() error
+}
+
+// newSink creates the sink according to the scheme of the endpoint
+func newSink(endpoint, token string) (sink, error) {
+	if IsHTTPEndpoint(endpoint) {
+		if _, err := url.Parse(endpoint); err != nil {
+			return nil, err
+		}
+		return &httpSink{
+			endpoint: endpoint,
+			token:    token,
+			client: &http.Client{
+				Transport: commonhttp.GetHTTPTransport(),
+				Timeout:   defaultForwardHTTPTimeout,
+			},
+		}, nil
+	}
+	w, err := syslog.Dial("tcp", endpoint, syslog.LOG_INFO, "audit")
+	if err != nil {
+		return nil, err
+	}
+	return &syslogSink{writer: w}, nil
+}
+
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+// Send the audit log as a syslog message, the writer reconnects to the endpoint if the connection is broken
+func (s *syslogSink) Send(_ string, data []byte) error {
+	return s.writer.Info(string(data))
+}
+
+func (s *syslogSink) Close() error {
+	return s.writer.Close()
+}
+
+type httpSink struct {
+	endpoint string
+	token    string
+	client   *http.Client
+}
+
+// Send posts the audit log to the HTTPS collector
+func (h *httpSink) Send(format string, data []byte) error {
+	req, err := http.NewRequest(http.MethodPost, h.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	contentType := "text/plain"
+	if format == ForwardFormatJSON {
+		contentType = "application/json"
+	}
+	req.Header.Set("Content-Type", contentType)
+	if len(h.token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+h.token)
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code %d from the audit log collector: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+func (h *httpSink) Close() error {
+	h.client.CloseIdleConnections()
+	return nil
+}
+
+type forwardItem struct {
+	endpoint string
+	format   string
+	token    string
+	audit    *model.AuditLog
+}
+
+// NewSIEMForwarder returns a SIEM forwarder. The background sending is started by the first forwarding
+func NewSIEMForwarder(bufferSize int, retryTimeout time.Duration) *SIEMForwarder {
+	return &SIEMForwarder{
+		buffer:       make(chan *forwardItem, bufferSize),
+		retryTimeout: retryTimeout,
+		closing:      make(chan struct{}),
+		done:         make(chan struct{}),
+		sinks:        map[string]sink{},
+		newSink:      newSink,
+	}
+}
+
+// SIEMForwarder forwards the audit logs in CEF or JSON to the syslog endpoint or the HTTPS collector in
+// the background, the audit logs are buffered and the sending is retried when the endpoint is unavailable
+type SIEMForwarder struct {
+	buffer       chan *forwardItem
+	retryTimeout time.Duration
+	startOnce    sync.Once
+	closeOnce    sync.Once
+	running      bool
+	closing      chan struct{}
+	done         chan struct{}
+	dropped      int64
+	// the sinks are only accessed by the background goroutine
+	sinks   map[string]sink
+	newSink func(endpoint, token string) (sink, error)
+}
+
+// Forward puts the audit log into the buffer. The audit log is dropped when the buffer is full or the forwarder is closed
+func (f *SIEMForwarder) Forward(_ context.Context, endpoint, format, token string, audit *model.AuditLog) {
+	select {
+	case <-f.closing:
+		return
+	default:
+	}
+	f.startOnce.Do(func() {
+		f.running = true
+		go f.run()
+	})
+	select {
+	case f.buffer <- &forwardItem{endpoint: endpoint, format: format, token: token, audit: audit}:
+	default:
+		n := atomic.AddInt64(&f.dropped, 1)
+		if n%100 == 1 {
+			log.Warningf("the audit log forward buffer is full, %d audit logs dropped in total", n)
+		}
+	}
+}
+
+// Close stops the background sending after sending the buffered audit logs
+func (f *SIEMForwarder) Close() {
+	f.closeOnce.Do(func() {
+		close(f.closing)
+	})
+	f.startOnce.Do(func() {})
+	if f.running {
+		<-f.done
+	}
+}
+
+func (f *SIEMForwarder) run() {
+	defer close(f.done)
+	defer f.closeSinks()
+	for {
+		select {
+		case item := <-f.buffer:
+			f.send(item)
+		case <-f.closing:
+			for {
+				select {
+				case item := <-f.buffer:
+					f.send(item)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (f *SIEMForwarder) send(item *forwardItem) {
+	data, err := Format(item.audit, item.format)
+	if err != nil {
+		log.Errorf("failed to format the audit log %d: %v", item.audit.ID, err)
+		return
+	}
+	key := item.endpoint + "|" + item.token
+	err = retry.Retry(func() error {
+		s, ok := f.sinks[key]
+		if !ok {
+			// the endpoint is changed, close the sinks of the previous ones
+			f.closeSinks()
+			created, e := f.newSink(item.endpoint, item.token)
+			if e != nil {
+				return e
+			}
+			s = created
+			f.sinks[key] = s
+		}
+		if e := s.Send(item.format, data); e != nil {
+			// recreate the sink in the next retry
+			f.closeSinks()
+			return e
+		}
+		return nil
+	}, retry.Timeout(f.retryTimeout), retry.MaxInterval(5*time.Second))
+	if err != nil {
+		log.Errorf("failed to forward the audit log to %s, dropped: %v", item.endpoint, err)
+	}
+}
+
+func (f *SIEMForwarder) closeSinks() {
+	for key, s := range f.sinks {
+		_ = s.Close()
+		delete(f.sinks, key)
+	}
+}
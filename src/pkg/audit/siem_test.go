@@ -0,0 +1,111 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+)
+
+func TestFormat(t *testing.T) {
+	audit := &model.AuditLog{
+		ID:           1,
+		ProjectID:    2,
+		Operation:    "delete",
+		ResourceType: "artifact",
+		Resource:     "library/hello=world|1",
+		Username:     "admin",
+		OpTime:       time.Unix(1, 0).UTC(),
+	}
+
+	data, err := Format(audit, ForwardFormatCEF)
+	require.Nil(t, err)
+	assert.Equal(t, `CEF:0|Harbor|Harbor||artifact:delete|artifact delete|6|rt=1000 suser=admin act=delete cs1Label=resourceType cs1=artifact cs2Label=resource cs2=library/hello\=world|1 cn1Label=projectID cn1=2`, string(data))
+
+	data, err = Format(audit, ForwardFormatJSON)
+	require.Nil(t, err)
+	m := map[string]interface{}{}
+	require.Nil(t, json.Unmarshal(data, &m))
+	assert.Equal(t, "library/hello=world|1", m["resource"])
+	assert.Equal(t, "admin", m["username"])
+
+	data, err = Format(audit, ForwardFormatText)
+	require.Nil(t, err)
+	assert.Equal(t, "operator:admin, time:1970-01-01T00:00:01Z, resourceType:artifact, action:delete, resource:library/hello=world|1", string(data))
+}
+
+func TestValidateForwardFormat(t *testing.T) {
+	assert.Nil(t, ValidateForwardFormat(""))
+	assert.Nil(t, ValidateForwardFormat(ForwardFormatCEF))
+	assert.Nil(t, ValidateForwardFormat(ForwardFormatJSON))
+	assert.NotNil(t, ValidateForwardFormat("xml"))
+}
+
+func TestIsHTTPEndpoint(t *testing.T) {
+	assert.True(t, IsHTTPEndpoint("https://siem.example.com/collector"))
+	assert.True(t, IsHTTPEndpoint("http://siem.example.com/collector"))
+	assert.False(t, IsHTTPEndpoint("siem.example.com:514"))
+}
+
+func TestForwardToHTTPCollector(t *testing.T) {
+	var (
+		lock     sync.Mutex
+		requests int
+		received []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		requests++
+		// fail the first request to verify the retry
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, string(body))
+	}))
+	defer server.Close()
+
+	forwarder := NewSIEMForwarder(10, 10*time.Second)
+	forwarder.Forward(context.TODO(), server.URL, ForwardFormatJSON, "token", &model.AuditLog{ID: 1, Operation: "create"})
+	forwarder.Forward(context.TODO(), server.URL, ForwardFormatJSON, "token", &model.AuditLog{ID: 2, Operation: "delete"})
+	// the buffered audit logs are sent before closing
+	forwarder.Close()
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, 3, requests)
+	require.Len(t, received, 2)
+	assert.Contains(t, received[0], `"id":1`)
+	assert.Contains(t, received[1], `"id":2`)
+
+	// dropped after closed
+	forwarder.Forward(context.TODO(), server.URL, ForwardFormatJSON, "token", &model.AuditLog{ID: 3})
+	assert.Len(t, forwarder.buffer, 0)
+}
@@ -0,0 +1,153 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg/audit/dao"
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+)
+
+const (
+	// the max count of the audit logs waiting to be written, the ones beyond it are dropped
+	defaultBufferSize = 10000
+	// the max count of the audit logs written in one insertion
+	defaultBatchSize = 100
+	// the interval to flush the buffered audit logs even if the batch isn't full
+	defaultFlushInterval = time.Second
+)
+
+// Writer is the global buffered audit log writer instance
+var Writer = NewBufferedWriter(dao.New(), defaultBufferSize, defaultBatchSize, defaultFlushInterval)
+
+// NewBufferedWriter returns a buffered audit log writer. The background flushing is started by the first write
+func NewBufferedWriter(dao dao.DAO, bufferSize, batchSize int, interval time.Duration) *BufferedWriter {
+	return &BufferedWriter{
+		dao:       dao,
+		buffer:    make(chan *model.AuditLog, bufferSize),
+		batchSize: batchSize,
+		interval:  interval,
+		closing:   make(chan struct{}),
+		done:      make(chan struct{}),
+		ctx:       orm.Context,
+	}
+}
+
+// BufferedWriter writes the audit logs into the database in batch in the background, so
+// recording an audit log doesn't add a synchronous insertion to the request path
+type BufferedWriter struct {
+	dao       dao.DAO
+	buffer    chan *model.AuditLog
+	batchSize int
+	interval  time.Duration
+	startOnce sync.Once
+	closeOnce sync.Once
+	running   bool
+	closing   chan struct{}
+	done      chan struct{}
+	dropped   int64
+	// ctx returns the context with a new ormer to write the audit logs
+	ctx func() context.Context
+}
+
+// Write the audit log into the buffer. The audit log is dropped when the buffer is full
+// to protect the memory, and is written directly after the writer is closed
+func (w *BufferedWriter) Write(ctx context.Context, audit *model.AuditLog) {
+	forward(ctx, audit)
+	if config.SkipAuditLogDatabase(ctx) {
+		return
+	}
+	select {
+	case <-w.closing:
+		if _, err := w.dao.Create(w.ctx(), audit); err != nil {
+			log.Errorf("failed to create the audit log: %v", err)
+		}
+		return
+	default:
+	}
+	w.startOnce.Do(func() {
+		w.running = true
+		go w.run()
+	})
+	select {
+	case w.buffer <- audit:
+	default:
+		n := atomic.AddInt64(&w.dropped, 1)
+		if n%100 == 1 {
+			log.Warningf("the audit log buffer is full, %d audit logs dropped in total", n)
+		}
+	}
+}
+
+// Close stops the background flushing after writing all the buffered audit logs into the database
+func (w *BufferedWriter) Close() {
+	w.closeOnce.Do(func() {
+		close(w.closing)
+	})
+	// the writer mustn't be started after closed
+	w.startOnce.Do(func() {})
+	if w.running {
+		<-w.done
+	}
+}
+
+func (w *BufferedWriter) run() {
+	defer close(w.done)
+	ctx := w.ctx()
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	batch := make([]*model.AuditLog, 0, w.batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := w.dao.CreateBatch(ctx, batch); err != nil {
+			log.Errorf("failed to create %d audit logs: %v", len(batch), err)
+		}
+		batch = make([]*model.AuditLog, 0, w.batchSize)
+	}
+	for {
+		select {
+		case audit := <-w.buffer:
+			batch = append(batch, audit)
+			if len(batch) >= w.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-w.closing:
+			for {
+				select {
+				case audit := <-w.buffer:
+					batch = append(batch, audit)
+					if len(batch) >= w.batchSize {
+						flush()
+					}
+				default:
+					flush()
+					log.Info("the buffered audit logs are flushed")
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+	mockDAO "github.com/goharbor/harbor/src/testing/pkg/audit/dao"
+)
+
+type writerTestSuite struct {
+	suite.Suite
+	dao     *mockDAO.DAO
+	lock    sync.Mutex
+	written []*model.AuditLog
+}
+
+func (w *writerTestSuite) SetupTest() {
+	w.dao = &mockDAO.DAO{}
+	w.written = nil
+	w.dao.On("CreateBatch", mock.Anything, mock.Anything).Return(func(ctx context.Context, audits []*model.AuditLog) error {
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		w.written = append(w.written, audits...)
+		return nil
+	})
+}
+
+func (w *writerTestSuite) newWriter(bufferSize, batchSize int, interval time.Duration) *BufferedWriter {
+	writer := NewBufferedWriter(w.dao, bufferSize, batchSize, interval)
+	writer.ctx = context.TODO
+	return writer
+}
+
+func (w *writerTestSuite) count() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return len(w.written)
+}
+
+func (w *writerTestSuite) TestFlushByBatchSize() {
+	writer := w.newWriter(10, 2, time.Hour)
+	defer writer.Close()
+	writer.Write(context.TODO(), &model.AuditLog{Resource: "library/hello-world"})
+	writer.Write(context.TODO(), &model.AuditLog{Resource: "library/hello-world"})
+	w.Eventually(func() bool { return w.count() == 2 }, time.Second, 10*time.Millisecond)
+}
+
+func (w *writerTestSuite) TestFlushByInterval() {
+	writer := w.newWriter(10, 5, 10*time.Millisecond)
+	defer writer.Close()
+	writer.Write(context.TODO(), &model.AuditLog{Resource: "library/hello-world"})
+	w.Eventually(func() bool { return w.count() == 1 }, time.Second, 10*time.Millisecond)
+}
+
+func (w *writerTestSuite) TestFlushOnClose() {
+	writer := w.newWriter(10, 5, time.Hour)
+	for i := 0; i < 3; i++ {
+		writer.Write(context.TODO(), &model.AuditLog{Resource: "library/hello-world"})
+	}
+	writer.Close()
+	w.Equal(3, w.count())
+
+	// written directly after closed
+	w.dao.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	writer.Write(context.TODO(), &model.AuditLog{Resource: "library/hello-world"})
+	w.dao.AssertCalled(w.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (w *writerTestSuite) TestOverflow() {
+	// the writer isn't started, so the buffer is never drained
+	writer := w.newWriter(1, 5, time.Hour)
+	writer.startOnce.Do(func() {})
+	writer.Write(context.TODO(), &model.AuditLog{Resource: "library/hello-world"})
+	writer.Write(context.TODO(), &model.AuditLog{Resource: "library/hello-world"})
+	w.Equal(1, len(writer.buffer))
+	w.Equal(int64(1), writer.dropped)
+}
+
+func TestWriterTestSuite(t *testing.T) {
+	suite.Run(t, &writerTestSuite{})
+}
@@ -0,0 +1,78 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg/backup/model"
+)
+
+// DAO is the data access object for the state of the metadata captured by the backups
+type DAO interface {
+	// Count returns the exact row count of the table
+	Count(ctx context.Context, table string) (int64, error)
+	// ListBlobs lists the blobs whose digests are greater than the cursor ordered by the digests
+	ListBlobs(ctx context.Context, cursor string, limit int) ([]*model.Blob, error)
+	// WALPosition returns the current write-ahead log position of the database
+	WALPosition(ctx context.Context) (string, error)
+}
+
+// New returns an instance of the default DAO
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+func (d *dao) Count(ctx context.Context, table string) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	// the table names are the constants rather than the user inputs
+	if err = ormer.Raw(fmt.Sprintf("SELECT COUNT(*) FROM %s", table)).QueryRow(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (d *dao) ListBlobs(ctx context.Context, cursor string, limit int) ([]*model.Blob, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var blobs []*model.Blob
+	sql := `SELECT digest, size FROM blob WHERE digest > ? ORDER BY digest LIMIT ?`
+	if _, err = ormer.Raw(sql, cursor, limit).QueryRows(&blobs); err != nil {
+		return nil, err
+	}
+	return blobs, nil
+}
+
+func (d *dao) WALPosition(ctx context.Context) (string, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	var position string
+	if err = ormer.Raw("SELECT pg_current_wal_lsn()::text").QueryRow(&position); err != nil {
+		return "", err
+	}
+	return position, nil
+}
@@ -0,0 +1,123 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/backup/dao"
+	"github.com/goharbor/harbor/src/pkg/backup/model"
+	migrationdao "github.com/goharbor/harbor/src/pkg/migration/dao"
+	"github.com/goharbor/harbor/src/pkg/version"
+)
+
+// the page size of listing the blobs
+const blobPageSize = 1000
+
+// Tables are the tables whose row counts are recorded in the manifest
+var Tables = []string{
+	"harbor_user",
+	"project",
+	"project_member",
+	"repository",
+	"artifact",
+	"artifact_reference",
+	"tag",
+	"blob",
+	"artifact_blob",
+	"project_blob",
+	"label",
+	"label_reference",
+	"quota",
+	"quota_usage",
+	"registry",
+	"replication_policy",
+	"robot",
+	"properties",
+}
+
+// Mgr is the global backup manager instance
+var Mgr = New()
+
+// Manager captures the state of the metadata and the storage for the backups
+type Manager interface {
+	// Snapshot captures the state of the metadata and the storage, the blobs are listed only when withBlobs is true
+	// while the count, total size and checksum of them are always calculated
+	Snapshot(ctx context.Context, withBlobs bool) (*model.Manifest, error)
+}
+
+// New returns a default implementation of Manager
+func New() Manager {
+	return &manager{
+		dao:          dao.New(),
+		migrationDAO: migrationdao.New(),
+	}
+}
+
+type manager struct {
+	dao          dao.DAO
+	migrationDAO migrationdao.DAO
+}
+
+func (m *manager) Snapshot(ctx context.Context, withBlobs bool) (*model.Manifest, error) {
+	schemaVersion, _, err := m.migrationDAO.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &model.Manifest{
+		HarborVersion: version.ReleaseVersion,
+		SchemaVersion: schemaVersion,
+		CreatedAt:     time.Now().UTC(),
+		Tables:        map[string]int64{},
+		Storage:       &model.Storage{},
+	}
+	// the WAL position isn't available on the standby or for the users without the privilege
+	if manifest.WALPosition, err = m.dao.WALPosition(ctx); err != nil {
+		log.Warningf("failed to get the WAL position of the database: %v", err)
+	}
+	for _, table := range Tables {
+		if manifest.Tables[table], err = m.dao.Count(ctx, table); err != nil {
+			return nil, err
+		}
+	}
+
+	hash := sha256.New()
+	cursor := ""
+	for {
+		blobs, err := m.dao.ListBlobs(ctx, cursor, blobPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, blob := range blobs {
+			manifest.Storage.BlobCount++
+			manifest.Storage.TotalSize += blob.Size
+			fmt.Fprintf(hash, "%s %d\n", blob.Digest, blob.Size)
+		}
+		if withBlobs {
+			manifest.Storage.Blobs = append(manifest.Storage.Blobs, blobs...)
+		}
+		if len(blobs) < blobPageSize {
+			break
+		}
+		cursor = blobs[len(blobs)-1].Digest
+	}
+	manifest.Storage.Checksum = "sha256:" + hex.EncodeToString(hash.Sum(nil))
+	return manifest, nil
+}
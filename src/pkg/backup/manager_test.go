@@ -0,0 +1,77 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/backup/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/backup/dao"
+	migrationdao "github.com/goharbor/harbor/src/testing/pkg/migration/dao"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	dao          *dao.DAO
+	migrationDAO *migrationdao.DAO
+	mgr          *manager
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.dao = &dao.DAO{}
+	m.migrationDAO = &migrationdao.DAO{}
+	m.mgr = &manager{dao: m.dao, migrationDAO: m.migrationDAO}
+}
+
+func (m *managerTestSuite) TestSnapshot() {
+	m.migrationDAO.On("Version", mock.Anything).Return(uint(120), false, nil)
+	m.dao.On("WALPosition", mock.Anything).Return("", errors.New("recovery is in progress"))
+	m.dao.On("Count", mock.Anything, mock.Anything).Return(int64(2), nil)
+	page := make([]*model.Blob, blobPageSize)
+	hash := sha256.New()
+	for i := range page {
+		page[i] = &model.Blob{Digest: fmt.Sprintf("sha256:%04d", i), Size: 1}
+		fmt.Fprintf(hash, "%s %d\n", page[i].Digest, page[i].Size)
+	}
+	fmt.Fprintf(hash, "%s %d\n", "sha256:9999", 10)
+	m.dao.On("ListBlobs", mock.Anything, "", blobPageSize).Return(page, nil)
+	m.dao.On("ListBlobs", mock.Anything, page[blobPageSize-1].Digest, blobPageSize).Return([]*model.Blob{{Digest: "sha256:9999", Size: 10}}, nil)
+
+	manifest, err := m.mgr.Snapshot(context.TODO(), false)
+	m.Require().NoError(err)
+	m.Equal(uint(120), manifest.SchemaVersion)
+	m.Empty(manifest.WALPosition)
+	m.Len(manifest.Tables, len(Tables))
+	m.Equal(int64(blobPageSize+1), manifest.Storage.BlobCount)
+	m.Equal(int64(blobPageSize+10), manifest.Storage.TotalSize)
+	m.Equal("sha256:"+hex.EncodeToString(hash.Sum(nil)), manifest.Storage.Checksum)
+	m.Empty(manifest.Storage.Blobs)
+
+	manifest, err = m.mgr.Snapshot(context.TODO(), true)
+	m.Require().NoError(err)
+	m.Len(manifest.Storage.Blobs, blobPageSize+1)
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
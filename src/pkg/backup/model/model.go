@@ -0,0 +1,48 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// Manifest records the state of the metadata and the storage at the consistency point of a backup,
+// it's used to validate the restore target
+type Manifest struct {
+	HarborVersion string `json:"harbor_version"`
+	SchemaVersion uint   `json:"schema_version"`
+	// WALPosition is the write-ahead log position of the database when the manifest is created,
+	// the database dump must be taken at or after it
+	WALPosition string    `json:"wal_position,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	// Tables are the row counts of the tables
+	Tables  map[string]int64 `json:"tables"`
+	Storage *Storage         `json:"storage"`
+}
+
+// Storage is the state of the blobs which must be in the storage
+type Storage struct {
+	BlobCount int64 `json:"blob_count"`
+	TotalSize int64 `json:"total_size"`
+	// Checksum is the sha256 checksum of the digests and sizes of the blobs sorted by the digests
+	Checksum string  `json:"checksum"`
+	Blobs    []*Blob `json:"blobs,omitempty"`
+}
+
+// Blob is a blob which must be in the storage
+type Blob struct {
+	Digest string `json:"digest"`
+	Size   int64  `json:"size"`
+}
@@ -104,7 +104,7 @@ func (d *dao) CreateArtifactAndBlob(ctx context.Context, artifactDigest, blobDig
 		CreationTime: time.Now(),
 	}
 
-	return o.InsertOrUpdate(md, "digest_af, digest_blob")
+	return o.InsertOrUpdateWithCtx(ctx, md, "digest_af, digest_blob")
 }
 
 func (d *dao) GetArtifactAndBlob(ctx context.Context, artifactDigest, blobDigest string) (*models.ArtifactAndBlob, error) {
@@ -118,7 +118,7 @@ func (d *dao) GetArtifactAndBlob(ctx context.Context, artifactDigest, blobDigest
 		DigestBlob: blobDigest,
 	}
 
-	if err := o.Read(md, "digest_af", "digest_blob"); err != nil {
+	if err := o.ReadWithCtx(ctx, md, "digest_af", "digest_blob"); err != nil {
 		return nil, orm.WrapNotFoundError(err, "not found by artifact digest %s and blob digest %s", artifactDigest, blobDigest)
 	}
 
@@ -131,7 +131,7 @@ func (d *dao) DeleteArtifactAndBlobByArtifact(ctx context.Context, artifactDiges
 		return err
 	}
 
-	_, err = qs.Delete()
+	_, err = qs.DeleteWithCtx(ctx)
 	return err
 }
 
@@ -142,7 +142,7 @@ func (d *dao) GetAssociatedBlobDigestsForArtifact(ctx context.Context, artifact
 	}
 
 	mds := []*models.ArtifactAndBlob{}
-	if _, err = qs.All(&mds); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &mds); err != nil {
 		return nil, err
 	}
 
@@ -164,7 +164,7 @@ func (d *dao) CreateBlob(ctx context.Context, blob *models.Blob) (int64, error)
 	// the default status is none
 	blob.Status = models.StatusNone
 
-	return o.InsertOrUpdate(blob, "digest")
+	return o.InsertOrUpdateWithCtx(ctx, blob, "digest")
 }
 
 func (d *dao) GetBlobByDigest(ctx context.Context, digest string) (*models.Blob, error) {
@@ -174,7 +174,7 @@ func (d *dao) GetBlobByDigest(ctx context.Context, digest string) (*models.Blob,
 	}
 
 	blob := &models.Blob{Digest: digest}
-	if err = o.Read(blob, "digest"); err != nil {
+	if err = o.ReadWithCtx(ctx, blob, "digest"); err != nil {
 		return nil, orm.WrapNotFoundError(err, "blob %s not found", digest)
 	}
 
@@ -220,7 +220,7 @@ func (d *dao) UpdateBlob(ctx context.Context, blob *models.Blob) error {
 		return err
 	}
 	blob.UpdateTime = time.Now()
-	_, err = o.Update(blob, "size", "content_type", "update_time")
+	_, err = o.UpdateWithCtx(ctx, blob, "size", "content_type", "update_time")
 	return err
 }
 
@@ -230,7 +230,7 @@ func (d *dao) ListBlobs(ctx context.Context, query *q.Query) ([]*models.Blob, er
 		return nil, err
 	}
 	blobs := []*models.Blob{}
-	if _, err = qs.All(&blobs); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &blobs); err != nil {
 		return nil, err
 	}
 	return blobs, nil
@@ -336,7 +336,7 @@ func (d *dao) CreateProjectBlob(ctx context.Context, projectID, blobID int64) (i
 	}
 
 	// ignore conflict error on (blob_id, project_id)
-	return o.InsertOrUpdate(md, "blob_id, project_id")
+	return o.InsertOrUpdateWithCtx(ctx, md, "blob_id, project_id")
 }
 
 func (d *dao) ExistProjectBlob(ctx context.Context, projectID int64, blobDigest string) (bool, error) {
@@ -369,7 +369,7 @@ func (d *dao) DeleteProjectBlob(ctx context.Context, projectID int64, blobIDs ..
 		return err
 	}
 
-	_, err = qs.Delete()
+	_, err = qs.DeleteWithCtx(ctx)
 	return err
 }
 
@@ -378,7 +378,7 @@ func (d *dao) DeleteBlob(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&models.Blob{
+	n, err := ormer.DeleteWithCtx(ctx, &models.Blob{
 		ID: id,
 	})
 	if err != nil {
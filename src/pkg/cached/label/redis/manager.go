@@ -0,0 +1,220 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/lib/retry"
+	"github.com/goharbor/harbor/src/pkg/cached"
+	"github.com/goharbor/harbor/src/pkg/label"
+	"github.com/goharbor/harbor/src/pkg/label/model"
+)
+
+var _ CachedManager = &Manager{}
+
+// CachedManager is the interface combines raw resource Manager and cached Manager for better extension.
+type CachedManager interface {
+	// Manager is the raw resource Manager.
+	label.Manager
+	// Manager is the common interface for resource cache.
+	cached.Manager
+}
+
+// Manager is the cached manager implemented by redis.
+type Manager struct {
+	*cached.BaseManager
+	// delegator delegates the raw crud to DAO.
+	delegator label.Manager
+	// keyBuilder builds cache object key.
+	keyBuilder *cached.ObjectKey
+	// lifetime is the cache life time.
+	lifetime time.Duration
+}
+
+// NewManager returns the redis cache manager.
+func NewManager(m label.Manager) *Manager {
+	return &Manager{
+		BaseManager: cached.NewBaseManager(cached.ResourceTypeLabel),
+		delegator:   m,
+		keyBuilder:  cached.NewObjectKey(cached.ResourceTypeLabel),
+		lifetime:    time.Duration(config.CacheExpireHours()) * time.Hour,
+	}
+}
+
+func (m *Manager) Create(ctx context.Context, label *model.Label) (int64, error) {
+	return m.delegator.Create(ctx, label)
+}
+
+func (m *Manager) Get(ctx context.Context, id int64) (*model.Label, error) {
+	key, err := m.keyBuilder.Format("id", id)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &model.Label{}
+	if err = m.CacheClient(ctx).Fetch(ctx, key, l); err == nil {
+		return l, nil
+	}
+
+	log.Debugf("get label %d from cache error: %v, will query from database.", id, err)
+
+	l, err = m.delegator.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = m.CacheClient(ctx).Save(ctx, key, l, m.lifetime); err != nil {
+		// log error if save to cache failed
+		log.Debugf("save label %s to cache error: %v", l.Name, err)
+	}
+
+	return l, nil
+}
+
+func (m *Manager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return m.delegator.Count(ctx, query)
+}
+
+func (m *Manager) Update(ctx context.Context, label *model.Label) error {
+	if err := m.delegator.Update(ctx, label); err != nil {
+		return err
+	}
+	// clean cache
+	m.cleanUp(ctx, label.ID)
+	return nil
+}
+
+func (m *Manager) Delete(ctx context.Context, id int64) error {
+	if err := m.delegator.Delete(ctx, id); err != nil {
+		return err
+	}
+	// clean cache
+	m.cleanUp(ctx, id)
+	return nil
+}
+
+func (m *Manager) List(ctx context.Context, query *q.Query) ([]*model.Label, error) {
+	return m.delegator.List(ctx, query)
+}
+
+func (m *Manager) ListByArtifact(ctx context.Context, artifactID int64) ([]*model.Label, error) {
+	key, err := m.keyBuilder.Format("artifactID", artifactID)
+	if err != nil {
+		return nil, err
+	}
+
+	var labels []*model.Label
+	if err = m.CacheClient(ctx).Fetch(ctx, key, &labels); err == nil {
+		return labels, nil
+	}
+
+	log.Debugf("get labels of artifact %d from cache error: %v, will query from database.", artifactID, err)
+
+	labels, err = m.delegator.ListByArtifact(ctx, artifactID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = m.CacheClient(ctx).Save(ctx, key, &labels, m.lifetime); err != nil {
+		// log error if save to cache failed
+		log.Debugf("save labels of artifact %d to cache error: %v", artifactID, err)
+	}
+
+	return labels, nil
+}
+
+func (m *Manager) AddTo(ctx context.Context, labelID int64, artifactID int64) error {
+	if err := m.delegator.AddTo(ctx, labelID, artifactID); err != nil {
+		return err
+	}
+	// clean cache
+	m.cleanUpArtifact(ctx, artifactID)
+	return nil
+}
+
+func (m *Manager) RemoveFrom(ctx context.Context, labelID int64, artifactID int64) error {
+	if err := m.delegator.RemoveFrom(ctx, labelID, artifactID); err != nil {
+		return err
+	}
+	// clean cache
+	m.cleanUpArtifact(ctx, artifactID)
+	return nil
+}
+
+func (m *Manager) RemoveAllFrom(ctx context.Context, artifactID int64) error {
+	if err := m.delegator.RemoveAllFrom(ctx, artifactID); err != nil {
+		return err
+	}
+	// clean cache
+	m.cleanUpArtifact(ctx, artifactID)
+	return nil
+}
+
+func (m *Manager) RemoveFromAllArtifacts(ctx context.Context, labelID int64) error {
+	if err := m.delegator.RemoveFromAllArtifacts(ctx, labelID); err != nil {
+		return err
+	}
+	// clean cache
+	m.cleanUp(ctx, labelID)
+	return nil
+}
+
+// cleanUp cleans up the label and the labels of all artifacts in cache,
+// as the label may be added to any artifact.
+func (m *Manager) cleanUp(ctx context.Context, labelID int64) {
+	idIdx, err := m.keyBuilder.Format("id", labelID)
+	if err != nil {
+		log.Errorf("format label id key error: %v", err)
+	} else {
+		// retry to avoid dirty data
+		if err = retry.Retry(func() error { return m.CacheClient(ctx).Delete(ctx, idIdx) }); err != nil {
+			log.Errorf("delete label cache key %s error: %v", idIdx, err)
+		}
+	}
+
+	prefix, err := m.keyBuilder.Format("artifactID", "")
+	if err != nil {
+		log.Errorf("format label artifact key error: %v", err)
+		return
+	}
+	keys, err := m.CacheClient(ctx).Keys(ctx, prefix)
+	if err != nil {
+		log.Errorf("list label cache keys with prefix %s error: %v", prefix, err)
+		return
+	}
+	for _, key := range keys {
+		if err = retry.Retry(func() error { return m.CacheClient(ctx).Delete(ctx, key) }); err != nil {
+			log.Errorf("delete label cache key %s error: %v", key, err)
+		}
+	}
+}
+
+// cleanUpArtifact cleans up the labels of the artifact in cache.
+func (m *Manager) cleanUpArtifact(ctx context.Context, artifactID int64) {
+	key, err := m.keyBuilder.Format("artifactID", artifactID)
+	if err != nil {
+		log.Errorf("format label artifact key error: %v", err)
+		return
+	}
+	// retry to avoid dirty data
+	if err = retry.Retry(func() error { return m.CacheClient(ctx).Delete(ctx, key) }); err != nil {
+		log.Errorf("delete label cache key %s error: %v", key, err)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/cache"
+	"github.com/goharbor/harbor/src/pkg/label/model"
+	testcache "github.com/goharbor/harbor/src/testing/lib/cache"
+	"github.com/goharbor/harbor/src/testing/mock"
+	testLabel "github.com/goharbor/harbor/src/testing/pkg/label"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	cachedManager CachedManager
+	labelMgr      *testLabel.Manager
+	cache         *testcache.Cache
+	ctx           context.Context
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.labelMgr = &testLabel.Manager{}
+	m.cache = &testcache.Cache{}
+	m.cachedManager = NewManager(m.labelMgr)
+	m.cachedManager.(*Manager).WithCacheClient(m.cache)
+	m.ctx = context.TODO()
+}
+
+func (m *managerTestSuite) TestGet() {
+	// get from cache directly
+	m.cache.On("Fetch", mock.Anything, "label:id:1", mock.Anything).Return(nil).Once()
+	_, err := m.cachedManager.Get(m.ctx, 1)
+	m.NoError(err, "should get from cache")
+	m.labelMgr.AssertNotCalled(m.T(), "Get", mock.Anything, mock.Anything)
+
+	// not found in cache, read from dao
+	m.cache.On("Fetch", mock.Anything, "label:id:1", mock.Anything).Return(cache.ErrNotFound).Once()
+	m.cache.On("Save", mock.Anything, "label:id:1", mock.Anything, mock.Anything).Return(nil).Once()
+	m.labelMgr.On("Get", mock.Anything, int64(1)).Return(&model.Label{ID: 1}, nil).Once()
+	l, err := m.cachedManager.Get(m.ctx, 1)
+	m.NoError(err, "should get from labelMgr")
+	m.Equal(int64(1), l.ID)
+}
+
+func (m *managerTestSuite) TestListByArtifact() {
+	// get from cache directly
+	m.cache.On("Fetch", mock.Anything, "label:artifactID:1", mock.Anything).Return(nil).Once()
+	_, err := m.cachedManager.ListByArtifact(m.ctx, 1)
+	m.NoError(err, "should get from cache")
+	m.labelMgr.AssertNotCalled(m.T(), "ListByArtifact", mock.Anything, mock.Anything)
+
+	// not found in cache, read from dao
+	m.cache.On("Fetch", mock.Anything, "label:artifactID:1", mock.Anything).Return(cache.ErrNotFound).Once()
+	m.cache.On("Save", mock.Anything, "label:artifactID:1", mock.Anything, mock.Anything).Return(nil).Once()
+	m.labelMgr.On("ListByArtifact", mock.Anything, int64(1)).Return([]*model.Label{{ID: 1}}, nil).Once()
+	labels, err := m.cachedManager.ListByArtifact(m.ctx, 1)
+	m.NoError(err, "should get from labelMgr")
+	m.Len(labels, 1)
+}
+
+func (m *managerTestSuite) TestAddToAndRemoveFrom() {
+	m.labelMgr.On("AddTo", mock.Anything, int64(1), int64(2)).Return(nil).Once()
+	m.labelMgr.On("RemoveFrom", mock.Anything, int64(1), int64(2)).Return(nil).Once()
+	m.labelMgr.On("RemoveAllFrom", mock.Anything, int64(2)).Return(nil).Once()
+	m.cache.On("Delete", mock.Anything, "label:artifactID:2").Return(nil).Times(3)
+	m.NoError(m.cachedManager.AddTo(m.ctx, 1, 2))
+	m.NoError(m.cachedManager.RemoveFrom(m.ctx, 1, 2))
+	m.NoError(m.cachedManager.RemoveAllFrom(m.ctx, 2))
+	m.cache.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestUpdate() {
+	m.labelMgr.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+	m.cache.On("Delete", mock.Anything, "label:id:1").Return(nil).Once()
+	m.cache.On("Keys", mock.Anything, "label:artifactID:").Return([]string{"label:artifactID:2"}, nil).Once()
+	m.cache.On("Delete", mock.Anything, "label:artifactID:2").Return(nil).Once()
+	m.NoError(m.cachedManager.Update(m.ctx, &model.Label{ID: 1}))
+	m.cache.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestResourceType() {
+	t := m.cachedManager.ResourceType(m.ctx)
+	m.Equal("label", t)
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
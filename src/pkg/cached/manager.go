@@ -34,6 +34,12 @@ const (
 	ResourceTypeRepository = "repository"
 	// ResourceTypeManifest defines manifest type.
 	ResourceTypeManifest = "manifest"
+	// ResourceTypeProjectMember defines the type of the roles of the project members.
+	ResourceTypeProjectMember = "project_member"
+	// ResourceTypeUserGroup defines the type of the user group membership.
+	ResourceTypeUserGroup = "user_group"
+	// ResourceTypeLabel defines label type.
+	ResourceTypeLabel = "label"
 )
 
 // Manager is the interface for resource cache manager.
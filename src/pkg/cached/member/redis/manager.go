@@ -0,0 +1,136 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/lib/retry"
+	"github.com/goharbor/harbor/src/pkg/cached"
+	"github.com/goharbor/harbor/src/pkg/member"
+	"github.com/goharbor/harbor/src/pkg/member/models"
+)
+
+var _ CachedManager = &Manager{}
+
+// CachedManager is the interface combines raw resource Manager and cached Manager for better extension.
+type CachedManager interface {
+	// Manager is the raw resource Manager.
+	member.Manager
+	// Manager is the common interface for resource cache.
+	cached.Manager
+}
+
+// Manager is the cached manager implemented by redis.
+// The roles of the project members are cached by the cached project manager, this manager
+// cleans them up when the project members are changed.
+type Manager struct {
+	*cached.BaseManager
+	// delegator delegates the raw crud to DAO.
+	delegator member.Manager
+	// keyBuilder builds cache object key.
+	keyBuilder *cached.ObjectKey
+}
+
+// NewManager returns the redis cache manager.
+func NewManager(m member.Manager) *Manager {
+	return &Manager{
+		BaseManager: cached.NewBaseManager(cached.ResourceTypeProjectMember),
+		delegator:   m,
+		keyBuilder:  cached.NewObjectKey(cached.ResourceTypeProjectMember),
+	}
+}
+
+func (m *Manager) AddProjectMember(ctx context.Context, pm models.Member) (int, error) {
+	id, err := m.delegator.AddProjectMember(ctx, pm)
+	if err != nil {
+		return 0, err
+	}
+	// clean cache
+	m.cleanUp(ctx, pm.ProjectID)
+	return id, nil
+}
+
+func (m *Manager) Delete(ctx context.Context, projectID int64, memberID int) error {
+	if err := m.delegator.Delete(ctx, projectID, memberID); err != nil {
+		return err
+	}
+	// clean cache
+	m.cleanUp(ctx, projectID)
+	return nil
+}
+
+func (m *Manager) Get(ctx context.Context, projectID int64, memberID int) (*models.Member, error) {
+	return m.delegator.Get(ctx, projectID, memberID)
+}
+
+func (m *Manager) List(ctx context.Context, queryMember models.Member, query *q.Query) ([]*models.Member, error) {
+	return m.delegator.List(ctx, queryMember, query)
+}
+
+func (m *Manager) UpdateRole(ctx context.Context, projectID int64, pmID int, role int) error {
+	if err := m.delegator.UpdateRole(ctx, projectID, pmID, role); err != nil {
+		return err
+	}
+	// clean cache
+	m.cleanUp(ctx, projectID)
+	return nil
+}
+
+func (m *Manager) SearchMemberByName(ctx context.Context, projectID int64, entityName string) ([]*models.Member, error) {
+	return m.delegator.SearchMemberByName(ctx, projectID, entityName)
+}
+
+func (m *Manager) DeleteMemberByUserID(ctx context.Context, uid int) error {
+	if err := m.delegator.DeleteMemberByUserID(ctx, uid); err != nil {
+		return err
+	}
+	// the user may be the member of any project, flush the roles of all projects
+	if err := m.FlushAll(ctx); err != nil {
+		log.Errorf("flush project member cache error: %v", err)
+	}
+	return nil
+}
+
+func (m *Manager) GetTotalOfProjectMembers(ctx context.Context, projectID int64, query *q.Query, roles ...int) (int, error) {
+	return m.delegator.GetTotalOfProjectMembers(ctx, projectID, query, roles...)
+}
+
+func (m *Manager) ListRoles(ctx context.Context, user *models.User, projectID int64) ([]int, error) {
+	return m.delegator.ListRoles(ctx, user, projectID)
+}
+
+// cleanUp cleans up the roles of the members of the project in cache.
+func (m *Manager) cleanUp(ctx context.Context, projectID int64) {
+	prefix, err := m.keyBuilder.Format("projectID", projectID)
+	if err != nil {
+		log.Errorf("format project member key error: %v", err)
+		return
+	}
+	// lookup all keys with projectID prefix
+	keys, err := m.CacheClient(ctx).Keys(ctx, prefix)
+	if err != nil {
+		log.Errorf("list project member cache keys with prefix %s error: %v", prefix, err)
+		return
+	}
+	for _, key := range keys {
+		// retry to avoid dirty data
+		if err = retry.Retry(func() error { return m.CacheClient(ctx).Delete(ctx, key) }); err != nil {
+			log.Errorf("delete project member cache key %s error: %v", key, err)
+		}
+	}
+}
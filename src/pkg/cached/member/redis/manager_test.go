@@ -0,0 +1,96 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/pkg/member/models"
+	testcache "github.com/goharbor/harbor/src/testing/lib/cache"
+	"github.com/goharbor/harbor/src/testing/mock"
+	testMember "github.com/goharbor/harbor/src/testing/pkg/member"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	cachedManager CachedManager
+	memberMgr     *testMember.Manager
+	cache         *testcache.Cache
+	ctx           context.Context
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.memberMgr = &testMember.Manager{}
+	m.cache = &testcache.Cache{}
+	m.cachedManager = NewManager(m.memberMgr)
+	m.cachedManager.(*Manager).WithCacheClient(m.cache)
+	m.ctx = context.TODO()
+}
+
+func (m *managerTestSuite) TestAddProjectMember() {
+	m.memberMgr.On("AddProjectMember", mock.Anything, mock.Anything).Return(1, nil).Once()
+	m.cache.On("Keys", mock.Anything, "project_member:projectID:1").Return([]string{"project_member:projectID:1:userID:1:groupIDs:"}, nil).Once()
+	m.cache.On("Delete", mock.Anything, "project_member:projectID:1:userID:1:groupIDs:").Return(nil).Once()
+	id, err := m.cachedManager.AddProjectMember(m.ctx, models.Member{ProjectID: 1})
+	m.NoError(err)
+	m.Equal(1, id)
+	m.cache.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestUpdateRole() {
+	m.memberMgr.On("UpdateRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	m.cache.On("Keys", mock.Anything, "project_member:projectID:1").Return([]string{}, nil).Once()
+	err := m.cachedManager.UpdateRole(m.ctx, 1, 1, 2)
+	m.NoError(err)
+	m.cache.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestDelete() {
+	// delete from memberMgr error
+	m.memberMgr.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("delete failed")).Once()
+	err := m.cachedManager.Delete(m.ctx, 1, 1)
+	m.Error(err)
+	m.cache.AssertNotCalled(m.T(), "Keys", mock.Anything, mock.Anything)
+
+	// delete from memberMgr success
+	m.memberMgr.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	m.cache.On("Keys", mock.Anything, "project_member:projectID:1").Return([]string{"project_member:projectID:1:userID:1:groupIDs:"}, nil).Once()
+	m.cache.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+	err = m.cachedManager.Delete(m.ctx, 1, 1)
+	m.NoError(err)
+	m.cache.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestDeleteMemberByUserID() {
+	m.memberMgr.On("DeleteMemberByUserID", mock.Anything, mock.Anything).Return(nil).Once()
+	m.cache.On("Keys", mock.Anything, "project_member").Return([]string{"1", "2"}, nil).Once()
+	m.cache.On("Delete", mock.Anything, mock.Anything).Return(nil).Twice()
+	err := m.cachedManager.DeleteMemberByUserID(m.ctx, 1)
+	m.NoError(err)
+	m.cache.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestResourceType() {
+	t := m.cachedManager.ResourceType(m.ctx)
+	m.Equal("project_member", t)
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
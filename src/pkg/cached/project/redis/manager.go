@@ -16,6 +16,9 @@ package redis
 
 import (
 	"context"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/goharbor/harbor/src/common/utils"
@@ -45,6 +48,9 @@ type Manager struct {
 	delegator project.Manager
 	// keyBuilder builds cache object key.
 	keyBuilder *cached.ObjectKey
+	// roleKeyBuilder builds the cache object key of the roles of project members,
+	// the keys are cleaned up by the cached member manager when the members are changed.
+	roleKeyBuilder *cached.ObjectKey
 	// lifetime is the cache life time.
 	lifetime time.Duration
 }
@@ -52,10 +58,11 @@ type Manager struct {
 // NewManager returns the redis cache manager.
 func NewManager(m project.Manager) *Manager {
 	return &Manager{
-		BaseManager: cached.NewBaseManager(cached.ResourceTypeProject),
-		delegator:   m,
-		keyBuilder:  cached.NewObjectKey(cached.ResourceTypeProject),
-		lifetime:    time.Duration(config.CacheExpireHours()) * time.Hour,
+		BaseManager:    cached.NewBaseManager(cached.ResourceTypeProject),
+		delegator:      m,
+		keyBuilder:     cached.NewObjectKey(cached.ResourceTypeProject),
+		roleKeyBuilder: cached.NewObjectKey(cached.ResourceTypeProjectMember),
+		lifetime:       time.Duration(config.CacheExpireHours()) * time.Hour,
 	}
 }
 
@@ -72,7 +79,36 @@ func (m *Manager) List(ctx context.Context, query *q.Query) ([]*models.Project,
 }
 
 func (m *Manager) ListRoles(ctx context.Context, projectID int64, userID int, groupIDs ...int) ([]int, error) {
-	return m.delegator.ListRoles(ctx, projectID, userID, groupIDs...)
+	ids := make([]int, len(groupIDs))
+	copy(ids, groupIDs)
+	sort.Ints(ids)
+	var groups []string
+	for _, id := range ids {
+		groups = append(groups, strconv.Itoa(id))
+	}
+	key, err := m.roleKeyBuilder.Format("projectID", projectID, "userID", userID, "groupIDs", strings.Join(groups, ","))
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []int
+	if err = m.CacheClient(ctx).Fetch(ctx, key, &roles); err == nil {
+		return roles, nil
+	}
+
+	log.Debugf("get roles of user %d in project %d from cache error: %v, will query from database.", userID, projectID, err)
+
+	roles, err = m.delegator.ListRoles(ctx, projectID, userID, groupIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = m.CacheClient(ctx).Save(ctx, key, &roles, m.lifetime); err != nil {
+		// log error if save to cache failed
+		log.Debugf("save roles of user %d in project %d to cache error: %v", userID, projectID, err)
+	}
+
+	return roles, nil
 }
 
 func (m *Manager) Delete(ctx context.Context, id int64) error {
@@ -90,6 +126,20 @@ func (m *Manager) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+func (m *Manager) UpdateOwner(ctx context.Context, id int64, ownerID int) error {
+	p, err := m.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := m.delegator.UpdateOwner(ctx, id, ownerID); err != nil {
+		return err
+	}
+	// clean cache
+	m.cleanUp(ctx, p)
+	return nil
+}
+
 func (m *Manager) Get(ctx context.Context, idOrName interface{}) (*models.Project, error) {
 	var (
 		key string
@@ -157,4 +207,21 @@ func (m *Manager) cleanUp(ctx context.Context, p *models.Project) {
 			log.Errorf("delete project cache key %s error: %v", nameIdx, err)
 		}
 	}
+
+	// clean the roles of the members
+	rolePrefix, err := m.roleKeyBuilder.Format("projectID", p.ProjectID)
+	if err != nil {
+		log.Errorf("format project member key error: %v", err)
+		return
+	}
+	keys, err := m.CacheClient(ctx).Keys(ctx, rolePrefix)
+	if err != nil {
+		log.Errorf("list project member cache keys with prefix %s error: %v", rolePrefix, err)
+		return
+	}
+	for _, key := range keys {
+		if err = retry.Retry(func() error { return m.CacheClient(ctx).Delete(ctx, key) }); err != nil {
+			log.Errorf("delete project member cache key %s error: %v", key, err)
+		}
+	}
 }
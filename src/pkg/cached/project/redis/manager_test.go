@@ -67,6 +67,15 @@ func (m *managerTestSuite) TestList() {
 }
 
 func (m *managerTestSuite) TestListRoles() {
+	// get from cache directly
+	m.cache.On("Fetch", mock.Anything, "project_member:projectID:1:userID:1:groupIDs:2,3", mock.Anything).Return(nil).Once()
+	_, err := m.cachedManager.ListRoles(m.ctx, 1, 1, 3, 2)
+	m.NoError(err, "should get from cache")
+	m.projectMgr.AssertNotCalled(m.T(), "ListRoles", mock.Anything, mock.Anything, mock.Anything)
+
+	// not found in cache, read from dao
+	m.cache.On("Fetch", mock.Anything, mock.Anything, mock.Anything).Return(cache.ErrNotFound).Once()
+	m.cache.On("Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	m.projectMgr.On("ListRoles", mock.Anything, mock.Anything, mock.Anything).Return([]int{1}, nil)
 	rs, err := m.cachedManager.ListRoles(m.ctx, 1, 1)
 	m.NoError(err)
@@ -101,7 +110,8 @@ func (m *managerTestSuite) TestDelete() {
 	// delete from projectMgr success
 	m.projectMgr.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
 	m.cache.On("Fetch", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
-	m.cache.On("Delete", mock.Anything, mock.Anything).Return(nil).Twice()
+	m.cache.On("Keys", mock.Anything, mock.Anything).Return([]string{"project_member:projectID:100:userID:1:groupIDs:"}, nil).Once()
+	m.cache.On("Delete", mock.Anything, mock.Anything).Return(nil).Times(3)
 	err = m.cachedManager.Delete(m.ctx, 100)
 	m.NoError(err, "delete should success")
 	m.cache.AssertCalled(m.T(), "Delete", mock.Anything, mock.Anything)
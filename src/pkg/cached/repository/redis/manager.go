@@ -162,6 +162,20 @@ func (m *Manager) AddPullCount(ctx context.Context, id int64, count uint64) erro
 	return nil
 }
 
+func (m *Manager) UpdatePullTime(ctx context.Context, id int64, pullTime time.Time) error {
+	repo, err := m.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	// pass on update operation
+	if err = m.delegator.UpdatePullTime(ctx, id, pullTime); err != nil {
+		return err
+	}
+	// refresh cache
+	m.refreshCache(ctx, repo)
+	return nil
+}
+
 // cleanUp cleans up data in cache.
 func (m *Manager) cleanUp(ctx context.Context, repo *model.RepoRecord) {
 	// clean index by id
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
@@ -127,6 +128,23 @@ func (m *managerTestSuite) TestAddPullCount() {
 	m.cache.AssertCalled(m.T(), "Delete", mock.Anything, mock.Anything)
 }
 
+func (m *managerTestSuite) TestUpdatePullTime() {
+	// update pull time from repoMgr error
+	errUpdate := errors.New("update pull time failed")
+	m.repoMgr.On("UpdatePullTime", mock.Anything, mock.Anything, mock.Anything).Return(errUpdate).Once()
+	m.cache.On("Fetch", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	err := m.cachedManager.UpdatePullTime(m.ctx, 100, time.Now())
+	m.ErrorIs(err, errUpdate, "update pull time should error")
+	m.cache.AssertNotCalled(m.T(), "Delete", mock.Anything, mock.Anything)
+
+	// update pull time from repoMgr success
+	m.repoMgr.On("UpdatePullTime", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	m.cache.On("Delete", mock.Anything, mock.Anything).Return(nil).Twice()
+	err = m.cachedManager.UpdatePullTime(m.ctx, 100, time.Now())
+	m.NoError(err, "update pull time should success")
+	m.cache.AssertCalled(m.T(), "Delete", mock.Anything, mock.Anything)
+}
+
 func (m *managerTestSuite) TestCount() {
 	m.repoMgr.On("Count", mock.Anything, mock.Anything).Return(int64(1), nil)
 	c, err := m.cachedManager.Count(m.ctx, nil)
@@ -0,0 +1,173 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/cache"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/lib/retry"
+	"github.com/goharbor/harbor/src/pkg/cached"
+	"github.com/goharbor/harbor/src/pkg/usergroup"
+	"github.com/goharbor/harbor/src/pkg/usergroup/model"
+)
+
+var _ CachedManager = &Manager{}
+
+// CachedManager is the interface combines raw resource Manager and cached Manager for better extension.
+type CachedManager interface {
+	// Manager is the raw resource Manager.
+	usergroup.Manager
+	// Manager is the common interface for resource cache.
+	cached.Manager
+}
+
+// Manager is the cached manager implemented by redis, it caches the IDs of the user groups which the users are members of.
+type Manager struct {
+	*cached.BaseManager
+	// delegator delegates the raw crud to DAO.
+	delegator usergroup.Manager
+	// keyBuilder builds cache object key.
+	keyBuilder *cached.ObjectKey
+	// memberCache is used to clean up the roles of project members when the user group is deleted.
+	memberCache *cached.BaseManager
+	// lifetime is the cache life time.
+	lifetime time.Duration
+}
+
+// NewManager returns the redis cache manager.
+func NewManager(m usergroup.Manager) *Manager {
+	return &Manager{
+		BaseManager: cached.NewBaseManager(cached.ResourceTypeUserGroup),
+		delegator:   m,
+		keyBuilder:  cached.NewObjectKey(cached.ResourceTypeUserGroup),
+		memberCache: cached.NewBaseManager(cached.ResourceTypeProjectMember),
+		lifetime:    time.Duration(config.CacheExpireHours()) * time.Hour,
+	}
+}
+
+// WithCacheClient overrides the default cache client.
+func (m *Manager) WithCacheClient(cc cache.Cache) *Manager {
+	m.BaseManager.WithCacheClient(cc)
+	m.memberCache.WithCacheClient(cc)
+	return m
+}
+
+func (m *Manager) Create(ctx context.Context, userGroup model.UserGroup) (int, error) {
+	return m.delegator.Create(ctx, userGroup)
+}
+
+func (m *Manager) List(ctx context.Context, query *q.Query) ([]*model.UserGroup, error) {
+	return m.delegator.List(ctx, query)
+}
+
+func (m *Manager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return m.delegator.Count(ctx, query)
+}
+
+func (m *Manager) Get(ctx context.Context, id int) (*model.UserGroup, error) {
+	return m.delegator.Get(ctx, id)
+}
+
+func (m *Manager) Populate(ctx context.Context, userGroups []model.UserGroup) ([]int, error) {
+	return m.delegator.Populate(ctx, userGroups)
+}
+
+func (m *Manager) Delete(ctx context.Context, id int) error {
+	if err := m.delegator.Delete(ctx, id); err != nil {
+		return err
+	}
+	// the members of the user group and the project members of it are removed, flush them all
+	if err := m.FlushAll(ctx); err != nil {
+		log.Errorf("flush user group cache error: %v", err)
+	}
+	if err := m.memberCache.FlushAll(ctx); err != nil {
+		log.Errorf("flush project member cache error: %v", err)
+	}
+	return nil
+}
+
+func (m *Manager) UpdateName(ctx context.Context, id int, groupName string) error {
+	return m.delegator.UpdateName(ctx, id, groupName)
+}
+
+func (m *Manager) Onboard(ctx context.Context, g *model.UserGroup) error {
+	return m.delegator.Onboard(ctx, g)
+}
+
+func (m *Manager) AddMember(ctx context.Context, groupID, userID int) error {
+	if err := m.delegator.AddMember(ctx, groupID, userID); err != nil {
+		return err
+	}
+	// clean cache
+	m.cleanUp(ctx, userID)
+	return nil
+}
+
+func (m *Manager) RemoveMember(ctx context.Context, groupID, userID int) error {
+	if err := m.delegator.RemoveMember(ctx, groupID, userID); err != nil {
+		return err
+	}
+	// clean cache
+	m.cleanUp(ctx, userID)
+	return nil
+}
+
+func (m *Manager) ListMemberIDs(ctx context.Context, groupID int) ([]int, error) {
+	return m.delegator.ListMemberIDs(ctx, groupID)
+}
+
+func (m *Manager) ListGroupIDsByUser(ctx context.Context, userID int) ([]int, error) {
+	key, err := m.keyBuilder.Format("userID", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	if err = m.CacheClient(ctx).Fetch(ctx, key, &ids); err == nil {
+		return ids, nil
+	}
+
+	log.Debugf("get user groups of user %d from cache error: %v, will query from database.", userID, err)
+
+	ids, err = m.delegator.ListGroupIDsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = m.CacheClient(ctx).Save(ctx, key, &ids, m.lifetime); err != nil {
+		// log error if save to cache failed
+		log.Debugf("save user groups of user %d to cache error: %v", userID, err)
+	}
+
+	return ids, nil
+}
+
+// cleanUp cleans up the user groups of the user in cache.
+func (m *Manager) cleanUp(ctx context.Context, userID int) {
+	key, err := m.keyBuilder.Format("userID", userID)
+	if err != nil {
+		log.Errorf("format user group key error: %v", err)
+		return
+	}
+	// retry to avoid dirty data
+	if err = retry.Retry(func() error { return m.CacheClient(ctx).Delete(ctx, key) }); err != nil {
+		log.Errorf("delete user group cache key %s error: %v", key, err)
+	}
+}
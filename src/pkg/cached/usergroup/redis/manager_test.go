@@ -0,0 +1,87 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/cache"
+	testcache "github.com/goharbor/harbor/src/testing/lib/cache"
+	"github.com/goharbor/harbor/src/testing/mock"
+	testUserGroup "github.com/goharbor/harbor/src/testing/pkg/usergroup"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	cachedManager CachedManager
+	userGroupMgr  *testUserGroup.Manager
+	cache         *testcache.Cache
+	ctx           context.Context
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.userGroupMgr = &testUserGroup.Manager{}
+	m.cache = &testcache.Cache{}
+	m.cachedManager = NewManager(m.userGroupMgr).WithCacheClient(m.cache)
+	m.ctx = context.TODO()
+}
+
+func (m *managerTestSuite) TestListGroupIDsByUser() {
+	// get from cache directly
+	m.cache.On("Fetch", mock.Anything, "user_group:userID:1", mock.Anything).Return(nil).Once()
+	_, err := m.cachedManager.ListGroupIDsByUser(m.ctx, 1)
+	m.NoError(err, "should get from cache")
+	m.userGroupMgr.AssertNotCalled(m.T(), "ListGroupIDsByUser", mock.Anything, mock.Anything)
+
+	// not found in cache, read from dao
+	m.cache.On("Fetch", mock.Anything, "user_group:userID:1", mock.Anything).Return(cache.ErrNotFound).Once()
+	m.cache.On("Save", mock.Anything, "user_group:userID:1", mock.Anything, mock.Anything).Return(nil).Once()
+	m.userGroupMgr.On("ListGroupIDsByUser", mock.Anything, 1).Return([]int{1, 2}, nil).Once()
+	ids, err := m.cachedManager.ListGroupIDsByUser(m.ctx, 1)
+	m.NoError(err, "should get from userGroupMgr")
+	m.Equal([]int{1, 2}, ids)
+}
+
+func (m *managerTestSuite) TestAddAndRemoveMember() {
+	m.userGroupMgr.On("AddMember", mock.Anything, 1, 2).Return(nil).Once()
+	m.userGroupMgr.On("RemoveMember", mock.Anything, 1, 2).Return(nil).Once()
+	m.cache.On("Delete", mock.Anything, "user_group:userID:2").Return(nil).Twice()
+	m.NoError(m.cachedManager.AddMember(m.ctx, 1, 2))
+	m.NoError(m.cachedManager.RemoveMember(m.ctx, 1, 2))
+	m.cache.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestDelete() {
+	m.userGroupMgr.On("Delete", mock.Anything, 1).Return(nil).Once()
+	// both the user groups and the roles of project members are flushed
+	m.cache.On("Keys", mock.Anything, "user_group").Return([]string{"user_group:userID:2"}, nil).Once()
+	m.cache.On("Keys", mock.Anything, "project_member").Return([]string{"project_member:projectID:1:userID:2:groupIDs:1"}, nil).Once()
+	m.cache.On("Delete", mock.Anything, "user_group:userID:2").Return(nil).Once()
+	m.cache.On("Delete", mock.Anything, "project_member:projectID:1:userID:2:groupIDs:1").Return(nil).Once()
+	m.NoError(m.cachedManager.Delete(m.ctx, 1))
+	m.cache.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestResourceType() {
+	t := m.cachedManager.ResourceType(m.ctx)
+	m.Equal("user_group", t)
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
@@ -0,0 +1,322 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package client provides the typed clients of the Harbor v2.0 APIs for the Go automations
+//
+//	c, err := client.New("https://harbor.example.com", client.WithBasicAuth("admin", "password"))
+//	if err != nil {
+//		return err
+//	}
+//	it := c.Projects().List(&client.ListOptions{Query: "name=~library"})
+//	for it.Next(ctx) {
+//		fmt.Println(it.Value().Name)
+//	}
+//	if err = it.Err(); err != nil {
+//		return err
+//	}
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+)
+
+const (
+	// BasePath is the base path of the v2.0 APIs
+	BasePath = "/api/v2.0"
+
+	defaultMaxRetries = 3
+	defaultBackoff    = 500 * time.Millisecond
+	maxBackoff        = 10 * time.Second
+)
+
+// Option customizes the client
+type Option func(c *Client)
+
+// WithBasicAuth authenticates the requests with the username and password, robot accounts are supported as well
+func WithBasicAuth(username, password string) Option {
+	return func(c *Client) {
+		c.username = username
+		c.password = password
+	}
+}
+
+// WithHTTPClient specifies the underlying HTTP client, e.g. to trust the self-signed certificate of Harbor
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		c.client = client
+	}
+}
+
+// WithRetry specifies the max retries and the initial backoff of the retries. The backoff is doubled after
+// each retry. Only the idempotent requests are retried when they fail with the network errors, 429 or 5xx
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// Client is the client of the Harbor v2.0 APIs, it's safe for the concurrent use
+type Client struct {
+	endpoint   *url.URL
+	client     *http.Client
+	username   string
+	password   string
+	maxRetries int
+	backoff    time.Duration
+}
+
+// New creates the client of the Harbor instance specified by the endpoint, e.g. "https://harbor.example.com"
+func New(endpoint string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %s: %v", endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid endpoint %s: the scheme must be http or https", endpoint)
+	}
+	c := &Client{
+		endpoint:   u,
+		client:     http.DefaultClient,
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Projects returns the client of projects
+func (c *Client) Projects() *ProjectClient {
+	return &ProjectClient{client: c}
+}
+
+// Labels returns the client of labels
+func (c *Client) Labels() *LabelClient {
+	return &LabelClient{client: c}
+}
+
+// Repositories returns the client of repositories
+func (c *Client) Repositories() *RepositoryClient {
+	return &RepositoryClient{client: c}
+}
+
+// Replication returns the client of replication policies and executions
+func (c *Client) Replication() *ReplicationClient {
+	return &ReplicationClient{client: c}
+}
+
+// Scans returns the client of artifact scans
+func (c *Client) Scans() *ScanClient {
+	return &ScanClient{client: c}
+}
+
+// Webhooks returns the client of webhook policies
+func (c *Client) Webhooks() *WebhookClient {
+	return &WebhookClient{client: c}
+}
+
+// Error is returned when the API responds with the non-2xx status code
+type Error struct {
+	StatusCode int
+	Errors     []*models.Error
+}
+
+// Error ...
+func (e *Error) Error() string {
+	var msgs []string
+	for _, err := range e.Errors {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", err.Code, err.Message))
+	}
+	if len(msgs) == 0 {
+		return fmt.Sprintf("http error: code %d", e.StatusCode)
+	}
+	return fmt.Sprintf("http error: code %d, %s", e.StatusCode, strings.Join(msgs, "; "))
+}
+
+// IsNotFoundErr returns whether the error is caused by the not found resource
+func IsNotFoundErr(err error) bool {
+	e, ok := err.(*Error)
+	return ok && e.StatusCode == http.StatusNotFound
+}
+
+// IsConflictErr returns whether the error is caused by the conflict, e.g. the resource already exists
+func IsConflictErr(err error) bool {
+	e, ok := err.(*Error)
+	return ok && e.StatusCode == http.StatusConflict
+}
+
+// IsQuotaExceededErr returns whether the error is caused by exceeding the quota of the project
+func IsQuotaExceededErr(err error) bool {
+	e, ok := err.(*Error)
+	if !ok {
+		return false
+	}
+	for _, er := range e.Errors {
+		for _, detail := range er.Details {
+			if detail != nil && detail.Reason == "QUOTA_EXCEEDED" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// request describes the request sent to the API
+type request struct {
+	method string
+	// the path under the base path, the parts must be escaped
+	path   string
+	query  url.Values
+	header http.Header
+	body   interface{}
+}
+
+// do sends the request and decodes the response body into "out" if it isn't nil.
+// The response is returned with the body closed to read the headers
+func (c *Client) do(ctx context.Context, req *request, out interface{}) (*http.Response, error) {
+	var body []byte
+	if req.body != nil {
+		data, err := json.Marshal(req.body)
+		if err != nil {
+			return nil, err
+		}
+		body = data
+	}
+
+	u := c.endpoint.String() + BasePath + req.path
+	if len(req.query) > 0 {
+		u += "?" + req.query.Encode()
+	}
+
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, req, u, body)
+		if attempt >= c.maxRetries || !retryable(ctx, req.method, resp, err) {
+			if err != nil {
+				return nil, err
+			}
+			return resp, decode(resp, out)
+		}
+
+		wait := backoff
+		if resp != nil {
+			if seconds, e := strconv.Atoi(resp.Header.Get("Retry-After")); e == nil && seconds > 0 {
+				wait = time.Duration(seconds) * time.Second
+			}
+			drain(resp)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, req *request, u string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	r, err := http.NewRequestWithContext(ctx, req.method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	for k, vs := range req.header {
+		for _, v := range vs {
+			r.Header.Add(k, v)
+		}
+	}
+	r.Header.Set("Accept", "application/json")
+	if body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	if len(c.username) > 0 {
+		r.SetBasicAuth(c.username, c.password)
+	}
+	return c.client.Do(r)
+}
+
+// only the idempotent requests are retried as the non-idempotent ones may have been handled by the server
+func retryable(ctx context.Context, method string, resp *http.Response, err error) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+	if err != nil {
+		// the cancellation of the context isn't retried
+		return ctx.Err() == nil
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+func decode(resp *http.Response, out interface{}) error {
+	defer drain(resp)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		e := &Error{StatusCode: resp.StatusCode}
+		errs := &models.Errors{}
+		// the body may not be the error envelope, e.g. it's returned by the proxy in front of Harbor
+		if err := json.NewDecoder(resp.Body).Decode(errs); err == nil {
+			e.Errors = errs.Errors
+		}
+		return e
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func drain(resp *http.Response) {
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+// parse the ID of the created resource from the "Location" header, e.g. "/api/v2.0/projects/1"
+func idFromLocation(resp *http.Response) (int64, error) {
+	location := resp.Header.Get("Location")
+	id, err := strconv.ParseInt(path.Base(location), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse the ID from the location %q: %v", location, err)
+	}
+	return id, nil
+}
+
+// escape the repository name which may contain slashes, the API requires it to be escaped twice
+func escapeRepository(name string) string {
+	return url.PathEscape(url.PathEscape(name))
+}
+
+// the projects are specified by names in the path rather than IDs
+func projectNameHeader() http.Header {
+	return http.Header{"X-Is-Resource-Name": []string{"true"}}
+}
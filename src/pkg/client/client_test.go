@@ -0,0 +1,182 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type clientTestSuite struct {
+	suite.Suite
+	server  *httptest.Server
+	handler http.HandlerFunc
+	client  *Client
+}
+
+func (c *clientTestSuite) SetupTest() {
+	c.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.handler(w, r)
+	}))
+	client, err := New(c.server.URL, WithBasicAuth("admin", "Harbor12345"), WithRetry(2, time.Millisecond))
+	c.Require().Nil(err)
+	c.client = client
+}
+
+func (c *clientTestSuite) TearDownTest() {
+	c.server.Close()
+}
+
+func (c *clientTestSuite) TestNew() {
+	_, err := New("ftp://harbor.example.com")
+	c.NotNil(err)
+
+	client, err := New("https://harbor.example.com/")
+	c.Require().Nil(err)
+	c.Equal("https://harbor.example.com", client.endpoint.String())
+}
+
+func (c *clientTestSuite) TestRetry() {
+	// the idempotent request is retried
+	count := 0
+	c.handler = func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if count < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"name":"library"}`))
+	}
+	project, err := c.client.Projects().Get(context.Background(), "library")
+	c.Require().Nil(err)
+	c.Equal("library", project.Name)
+	c.Equal(3, count)
+
+	// exceed the max retries
+	count = -10
+	_, err = c.client.Projects().Get(context.Background(), "library")
+	c.NotNil(err)
+	c.Equal(-7, count)
+
+	// the non-idempotent request isn't retried
+	count = 0
+	_, err = c.client.Labels().Create(context.Background(), nil)
+	c.NotNil(err)
+	c.Equal(1, count)
+}
+
+func (c *clientTestSuite) TestError() {
+	c.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":[{"code":"NOT_FOUND","message":"project library not found"}]}`))
+	}
+	_, err := c.client.Projects().Get(context.Background(), "library")
+	c.Require().NotNil(err)
+	c.True(IsNotFoundErr(err))
+	c.False(IsConflictErr(err))
+	c.Equal("http error: code 404, NOT_FOUND: project library not found", err.Error())
+
+	// the body isn't the error envelope
+	c.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`conflict`))
+	}
+	_, err = c.client.Projects().Create(context.Background(), nil)
+	c.Require().NotNil(err)
+	c.True(IsConflictErr(err))
+	c.False(IsQuotaExceededErr(err))
+	c.Equal("http error: code 409", err.Error())
+
+	// quota exceeded
+	c.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"errors":[{"code":"DENIED","message":"quota exceeded","details":[{"reason":"QUOTA_EXCEEDED","data":{"resource":"storage"}}]}]}`))
+	}
+	_, err = c.client.Projects().Create(context.Background(), nil)
+	c.Require().NotNil(err)
+	c.True(IsQuotaExceededErr(err))
+}
+
+func (c *clientTestSuite) TestRequest() {
+	c.handler = func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		c.True(ok)
+		c.Equal("admin", username)
+		c.Equal("Harbor12345", password)
+		c.Equal(http.MethodPost, r.Method)
+		c.Equal("/api/v2.0/projects", r.URL.Path)
+		c.Equal("application/json", r.Header.Get("Content-Type"))
+		w.Header().Set("Location", "/api/v2.0/projects/10")
+		w.WriteHeader(http.StatusCreated)
+	}
+	id, err := c.client.Projects().Create(context.Background(), nil)
+	c.Require().Nil(err)
+	c.Equal(int64(10), id)
+
+	// the project name and the repository name with slashes
+	c.handler = func(w http.ResponseWriter, r *http.Request) {
+		c.Equal("/api/v2.0/projects/library/repositories/a%252Fb/artifacts/latest/scan", r.URL.EscapedPath())
+		w.WriteHeader(http.StatusAccepted)
+	}
+	c.Nil(c.client.Scans().Start(context.Background(), "library", "library/a/b", "latest"))
+
+	c.handler = func(w http.ResponseWriter, r *http.Request) {
+		c.Equal("true", r.Header.Get("X-Is-Resource-Name"))
+		c.Equal("/api/v2.0/projects/library/webhook/policies/1", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}
+	c.Nil(c.client.Webhooks().DeletePolicy(context.Background(), "library", 1))
+}
+
+func (c *clientTestSuite) TestIterator() {
+	c.handler = func(w http.ResponseWriter, r *http.Request) {
+		c.Equal("/api/v2.0/labels", r.URL.Path)
+		c.Equal("p", r.URL.Query().Get("scope"))
+		c.Equal("1", r.URL.Query().Get("project_id"))
+		switch r.URL.Query().Get("page") {
+		case "":
+			c.Equal("name", r.URL.Query().Get("sort"))
+			w.Header().Set("Link", `</api/v2.0/labels?page=2&page_size=2&project_id=1&scope=p&sort=name>; rel="next"`)
+			w.Write([]byte(`[{"id":1},{"id":2}]`))
+		case "2":
+			w.Header().Set("Link", `</api/v2.0/labels?page=1&page_size=2&project_id=1&scope=p&sort=name>; rel="prev"`)
+			w.Write([]byte(`[{"id":3}]`))
+		}
+	}
+	it := c.client.Labels().List(1, &ListOptions{Sort: "name", PageSize: 2})
+	var ids []int64
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Value().ID)
+	}
+	c.Require().Nil(it.Err())
+	c.Equal([]int64{1, 2, 3}, ids)
+
+	// error
+	c.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}
+	labels, err := c.client.Labels().List(0, nil).All(context.Background())
+	c.NotNil(err)
+	c.Empty(labels)
+}
+
+func TestClientTestSuite(t *testing.T) {
+	suite.Run(t, &clientTestSuite{})
+}
@@ -0,0 +1,134 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib"
+)
+
+// ListOptions specifies the options of the list requests
+type ListOptions struct {
+	// Query is the query string, e.g. "name=~library", refer to the "q" parameter of the APIs for the syntax
+	Query string
+	// Sort is the sort fields, e.g. "name,-creation_time"
+	Sort string
+	// PageSize is the size of the pages fetched by the iterator, the server side default is used if it's 0
+	PageSize int64
+}
+
+func (l *ListOptions) values() url.Values {
+	values := url.Values{}
+	if l == nil {
+		return values
+	}
+	if len(l.Query) > 0 {
+		values.Set("q", l.Query)
+	}
+	if len(l.Sort) > 0 {
+		values.Set("sort", l.Sort)
+	}
+	if l.PageSize > 0 {
+		values.Set("page_size", strconv.FormatInt(l.PageSize, 10))
+	}
+	return values
+}
+
+// Iterator iterates the resources page by page by following the "next" links returned by the APIs
+type Iterator[T any] struct {
+	client *Client
+	// the request of the next page, it's nil when all pages are fetched
+	req   *request
+	page  []T
+	index int
+	value T
+	err   error
+}
+
+func newIterator[T any](client *Client, req *request) *Iterator[T] {
+	req.method = http.MethodGet
+	return &Iterator[T]{
+		client: client,
+		req:    req,
+	}
+}
+
+// Next advances the iterator to the next resource, it returns false when all the resources are iterated
+// or an error occurs, call Err to check the error
+func (i *Iterator[T]) Next(ctx context.Context) bool {
+	for i.index >= len(i.page) {
+		if i.req == nil || i.err != nil {
+			return false
+		}
+		i.fetch(ctx)
+	}
+	i.value = i.page[i.index]
+	i.index++
+	return true
+}
+
+// Value returns the current resource
+func (i *Iterator[T]) Value() T {
+	return i.value
+}
+
+// Err returns the error occurred during the iteration
+func (i *Iterator[T]) Err() error {
+	return i.err
+}
+
+// All returns all the remaining resources
+func (i *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for i.Next(ctx) {
+		all = append(all, i.Value())
+	}
+	return all, i.Err()
+}
+
+func (i *Iterator[T]) fetch(ctx context.Context) {
+	var page []T
+	resp, err := i.client.do(ctx, i.req, &page)
+	if err != nil {
+		i.err = err
+		return
+	}
+	i.page, i.index = page, 0
+
+	req := i.req
+	i.req = nil
+	for _, link := range lib.ParseLinks(resp.Header.Get("Link")) {
+		if link.Rel != "next" {
+			continue
+		}
+		u, err := url.Parse(link.URL)
+		if err != nil {
+			i.err = err
+			return
+		}
+		i.req = &request{
+			method: http.MethodGet,
+			path:   strings.TrimPrefix(u.EscapedPath(), BasePath),
+			query:  u.Query(),
+			header: req.header,
+		}
+		break
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+)
+
+const (
+	// LabelScopeGlobal is the scope of the global labels
+	LabelScopeGlobal = "g"
+	// LabelScopeProject is the scope of the project labels
+	LabelScopeProject = "p"
+)
+
+// LabelClient is the client of labels
+type LabelClient struct {
+	client *Client
+}
+
+// List returns the iterator of the global labels if the projectID is 0, otherwise the labels of the project
+func (l *LabelClient) List(projectID int64, opts *ListOptions) *Iterator[*models.Label] {
+	query := opts.values()
+	if projectID > 0 {
+		query.Set("scope", LabelScopeProject)
+		query.Set("project_id", strconv.FormatInt(projectID, 10))
+	} else {
+		query.Set("scope", LabelScopeGlobal)
+	}
+	return newIterator[*models.Label](l.client, &request{path: "/labels", query: query})
+}
+
+// Get returns the label specified by the ID
+func (l *LabelClient) Get(ctx context.Context, id int64) (*models.Label, error) {
+	label := &models.Label{}
+	if _, err := l.client.do(ctx, &request{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/labels/%d", id),
+	}, label); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+// Create creates the label and returns its ID
+func (l *LabelClient) Create(ctx context.Context, label *models.Label) (int64, error) {
+	resp, err := l.client.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/labels",
+		body:   label,
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+	return idFromLocation(resp)
+}
+
+// Update updates the label specified by the ID
+func (l *LabelClient) Update(ctx context.Context, id int64, label *models.Label) error {
+	_, err := l.client.do(ctx, &request{
+		method: http.MethodPut,
+		path:   fmt.Sprintf("/labels/%d", id),
+		body:   label,
+	}, nil)
+	return err
+}
+
+// Delete deletes the label specified by the ID
+func (l *LabelClient) Delete(ctx context.Context, id int64) error {
+	_, err := l.client.do(ctx, &request{
+		method: http.MethodDelete,
+		path:   fmt.Sprintf("/labels/%d", id),
+	}, nil)
+	return err
+}
@@ -0,0 +1,80 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+)
+
+// ProjectClient is the client of projects
+type ProjectClient struct {
+	client *Client
+}
+
+// List returns the iterator of projects
+func (p *ProjectClient) List(opts *ListOptions) *Iterator[*models.Project] {
+	return newIterator[*models.Project](p.client, &request{path: "/projects", query: opts.values()})
+}
+
+// Get returns the project specified by the name
+func (p *ProjectClient) Get(ctx context.Context, name string) (*models.Project, error) {
+	project := &models.Project{}
+	if _, err := p.client.do(ctx, &request{
+		method: http.MethodGet,
+		path:   "/projects/" + url.PathEscape(name),
+		header: projectNameHeader(),
+	}, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// Create creates the project and returns its ID
+func (p *ProjectClient) Create(ctx context.Context, project *models.ProjectReq) (int64, error) {
+	resp, err := p.client.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/projects",
+		body:   project,
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+	return idFromLocation(resp)
+}
+
+// Update updates the project specified by the name
+func (p *ProjectClient) Update(ctx context.Context, name string, project *models.ProjectReq) error {
+	_, err := p.client.do(ctx, &request{
+		method: http.MethodPut,
+		path:   "/projects/" + url.PathEscape(name),
+		header: projectNameHeader(),
+		body:   project,
+	}, nil)
+	return err
+}
+
+// Delete deletes the project specified by the name
+func (p *ProjectClient) Delete(ctx context.Context, name string) error {
+	_, err := p.client.do(ctx, &request{
+		method: http.MethodDelete,
+		path:   "/projects/" + url.PathEscape(name),
+		header: projectNameHeader(),
+	}, nil)
+	return err
+}
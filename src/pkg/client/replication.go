@@ -0,0 +1,119 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+)
+
+// ReplicationClient is the client of replication policies and executions
+type ReplicationClient struct {
+	client *Client
+}
+
+// ListPolicies returns the iterator of replication policies
+func (r *ReplicationClient) ListPolicies(opts *ListOptions) *Iterator[*models.ReplicationPolicy] {
+	return newIterator[*models.ReplicationPolicy](r.client, &request{path: "/replication/policies", query: opts.values()})
+}
+
+// GetPolicy returns the replication policy specified by the ID
+func (r *ReplicationClient) GetPolicy(ctx context.Context, id int64) (*models.ReplicationPolicy, error) {
+	policy := &models.ReplicationPolicy{}
+	if _, err := r.client.do(ctx, &request{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/replication/policies/%d", id),
+	}, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// CreatePolicy creates the replication policy and returns its ID
+func (r *ReplicationClient) CreatePolicy(ctx context.Context, policy *models.ReplicationPolicy) (int64, error) {
+	resp, err := r.client.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/replication/policies",
+		body:   policy,
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+	return idFromLocation(resp)
+}
+
+// UpdatePolicy updates the replication policy specified by the ID
+func (r *ReplicationClient) UpdatePolicy(ctx context.Context, id int64, policy *models.ReplicationPolicy) error {
+	_, err := r.client.do(ctx, &request{
+		method: http.MethodPut,
+		path:   fmt.Sprintf("/replication/policies/%d", id),
+		body:   policy,
+	}, nil)
+	return err
+}
+
+// DeletePolicy deletes the replication policy specified by the ID
+func (r *ReplicationClient) DeletePolicy(ctx context.Context, id int64) error {
+	_, err := r.client.do(ctx, &request{
+		method: http.MethodDelete,
+		path:   fmt.Sprintf("/replication/policies/%d", id),
+	}, nil)
+	return err
+}
+
+// StartExecution starts the replication of the policy manually and returns the ID of the execution
+func (r *ReplicationClient) StartExecution(ctx context.Context, policyID int64) (int64, error) {
+	resp, err := r.client.do(ctx, &request{
+		method: http.MethodPost,
+		path:   "/replication/executions",
+		body:   &models.StartReplicationExecution{PolicyID: policyID},
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+	return idFromLocation(resp)
+}
+
+// ListExecutions returns the iterator of the executions of the replication policy
+func (r *ReplicationClient) ListExecutions(policyID int64, opts *ListOptions) *Iterator[*models.ReplicationExecution] {
+	query := opts.values()
+	query.Set("policy_id", strconv.FormatInt(policyID, 10))
+	return newIterator[*models.ReplicationExecution](r.client, &request{path: "/replication/executions", query: query})
+}
+
+// GetExecution returns the replication execution specified by the ID
+func (r *ReplicationClient) GetExecution(ctx context.Context, id int64) (*models.ReplicationExecution, error) {
+	execution := &models.ReplicationExecution{}
+	if _, err := r.client.do(ctx, &request{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/replication/executions/%d", id),
+	}, execution); err != nil {
+		return nil, err
+	}
+	return execution, nil
+}
+
+// StopExecution stops the replication execution specified by the ID
+func (r *ReplicationClient) StopExecution(ctx context.Context, id int64) error {
+	_, err := r.client.do(ctx, &request{
+		method: http.MethodPut,
+		path:   fmt.Sprintf("/replication/executions/%d", id),
+	}, nil)
+	return err
+}
@@ -0,0 +1,66 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+)
+
+// RepositoryClient is the client of repositories
+type RepositoryClient struct {
+	client *Client
+}
+
+// List returns the iterator of the repositories under the project, the repositories of all projects
+// are iterated if the project name is empty
+func (r *RepositoryClient) List(projectName string, opts *ListOptions) *Iterator[*models.Repository] {
+	path := "/repositories"
+	if len(projectName) > 0 {
+		path = "/projects/" + url.PathEscape(projectName) + path
+	}
+	return newIterator[*models.Repository](r.client, &request{path: path, query: opts.values()})
+}
+
+// Get returns the repository, the name can be either with or without the project name prefix,
+// e.g. both "library/hello-world" and "hello-world" are accepted for the project "library"
+func (r *RepositoryClient) Get(ctx context.Context, projectName, repositoryName string) (*models.Repository, error) {
+	repository := &models.Repository{}
+	if _, err := r.client.do(ctx, &request{
+		method: http.MethodGet,
+		path:   repositoryPath(projectName, repositoryName),
+	}, repository); err != nil {
+		return nil, err
+	}
+	return repository, nil
+}
+
+// Delete deletes the repository and all the artifacts under it
+func (r *RepositoryClient) Delete(ctx context.Context, projectName, repositoryName string) error {
+	_, err := r.client.do(ctx, &request{
+		method: http.MethodDelete,
+		path:   repositoryPath(projectName, repositoryName),
+	}, nil)
+	return err
+}
+
+func repositoryPath(projectName, repositoryName string) string {
+	repositoryName = strings.TrimPrefix(repositoryName, projectName+"/")
+	return "/projects/" + url.PathEscape(projectName) + "/repositories/" + escapeRepository(repositoryName)
+}
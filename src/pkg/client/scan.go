@@ -0,0 +1,64 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+)
+
+// ScanClient is the client of artifact scans
+type ScanClient struct {
+	client *Client
+}
+
+// Start triggers the scan of the artifact, the reference can be either the digest or the tag
+func (s *ScanClient) Start(ctx context.Context, projectName, repositoryName, reference string) error {
+	_, err := s.client.do(ctx, &request{
+		method: http.MethodPost,
+		path:   artifactPath(projectName, repositoryName, reference) + "/scan",
+	}, nil)
+	return err
+}
+
+// Stop stops the running scan of the artifact
+func (s *ScanClient) Stop(ctx context.Context, projectName, repositoryName, reference string) error {
+	_, err := s.client.do(ctx, &request{
+		method: http.MethodPost,
+		path:   artifactPath(projectName, repositoryName, reference) + "/scan/stop",
+	}, nil)
+	return err
+}
+
+// Overview returns the scan overview of the artifact keyed by the MIME type of the reports,
+// the overview is empty if the artifact hasn't been scanned
+func (s *ScanClient) Overview(ctx context.Context, projectName, repositoryName, reference string) (models.ScanOverview, error) {
+	artifact := &models.Artifact{}
+	if _, err := s.client.do(ctx, &request{
+		method: http.MethodGet,
+		path:   artifactPath(projectName, repositoryName, reference),
+		query:  url.Values{"with_scan_overview": []string{"true"}},
+	}, artifact); err != nil {
+		return nil, err
+	}
+	return artifact.ScanOverview, nil
+}
+
+func artifactPath(projectName, repositoryName, reference string) string {
+	return repositoryPath(projectName, repositoryName) + "/artifacts/" + url.PathEscape(reference)
+}
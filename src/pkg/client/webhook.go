@@ -0,0 +1,90 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+)
+
+// WebhookClient is the client of webhook policies
+type WebhookClient struct {
+	client *Client
+}
+
+// ListPolicies returns the iterator of the webhook policies of the project
+func (w *WebhookClient) ListPolicies(projectName string, opts *ListOptions) *Iterator[*models.WebhookPolicy] {
+	return newIterator[*models.WebhookPolicy](w.client, &request{
+		path:   webhookPoliciesPath(projectName),
+		query:  opts.values(),
+		header: projectNameHeader(),
+	})
+}
+
+// GetPolicy returns the webhook policy of the project specified by the ID
+func (w *WebhookClient) GetPolicy(ctx context.Context, projectName string, id int64) (*models.WebhookPolicy, error) {
+	policy := &models.WebhookPolicy{}
+	if _, err := w.client.do(ctx, &request{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("%s/%d", webhookPoliciesPath(projectName), id),
+		header: projectNameHeader(),
+	}, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// CreatePolicy creates the webhook policy of the project and returns its ID
+func (w *WebhookClient) CreatePolicy(ctx context.Context, projectName string, policy *models.WebhookPolicy) (int64, error) {
+	resp, err := w.client.do(ctx, &request{
+		method: http.MethodPost,
+		path:   webhookPoliciesPath(projectName),
+		header: projectNameHeader(),
+		body:   policy,
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+	return idFromLocation(resp)
+}
+
+// UpdatePolicy updates the webhook policy of the project specified by the ID
+func (w *WebhookClient) UpdatePolicy(ctx context.Context, projectName string, id int64, policy *models.WebhookPolicy) error {
+	_, err := w.client.do(ctx, &request{
+		method: http.MethodPut,
+		path:   fmt.Sprintf("%s/%d", webhookPoliciesPath(projectName), id),
+		header: projectNameHeader(),
+		body:   policy,
+	}, nil)
+	return err
+}
+
+// DeletePolicy deletes the webhook policy of the project specified by the ID
+func (w *WebhookClient) DeletePolicy(ctx context.Context, projectName string, id int64) error {
+	_, err := w.client.do(ctx, &request{
+		method: http.MethodDelete,
+		path:   fmt.Sprintf("%s/%d", webhookPoliciesPath(projectName), id),
+		header: projectNameHeader(),
+	}, nil)
+	return err
+}
+
+func webhookPoliciesPath(projectName string) string {
+	return "/projects/" + url.PathEscape(projectName) + "/webhook/policies"
+}
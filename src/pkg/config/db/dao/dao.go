@@ -71,13 +71,13 @@ func (d *dao) SaveConfigEntries(ctx context.Context, entries []models.ConfigEntr
 		tempEntry := models.ConfigEntry{}
 		tempEntry.Key = entry.Key
 		tempEntry.Value = entry.Value
-		created, _, err := o.ReadOrCreate(&tempEntry, "k")
+		created, _, err := o.ReadOrCreateWithCtx(ctx, &tempEntry, "k")
 		if err != nil && !orm.IsDuplicateKeyError(err) {
 			return errors.Wrap(err, "failed to create configuration entry")
 		}
 		if !created {
 			entry.ID = tempEntry.ID
-			_, err := o.Update(&entry, "v")
+			_, err := o.UpdateWithCtx(ctx, &entry, "v")
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,76 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/config/history/dao"
+	"github.com/goharbor/harbor/src/pkg/config/history/model"
+)
+
+var (
+	// Mgr is a global variable for the default configuration history manager implementation
+	Mgr = NewManager()
+)
+
+// Manager manages the histories of the configuration changes
+type Manager interface {
+	// Create ...
+	Create(ctx context.Context, h *model.History) (int64, error)
+
+	// Get the history specified by the version
+	Get(ctx context.Context, version int64) (*model.History, error)
+
+	// Count returns the total count of histories according to the query
+	Count(ctx context.Context, query *q.Query) (total int64, err error)
+
+	// List ...
+	List(ctx context.Context, query *q.Query) ([]*model.History, error)
+}
+
+var _ Manager = &manager{}
+
+type manager struct {
+	dao dao.DAO
+}
+
+// NewManager return a new instance of the configuration history manager
+func NewManager() Manager {
+	return &manager{
+		dao: dao.New(),
+	}
+}
+
+// Create ...
+func (m *manager) Create(ctx context.Context, h *model.History) (int64, error) {
+	return m.dao.Create(ctx, h)
+}
+
+// Get ...
+func (m *manager) Get(ctx context.Context, version int64) (*model.History, error) {
+	return m.dao.Get(ctx, version)
+}
+
+// Count ...
+func (m *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.Count(ctx, query)
+}
+
+// List ...
+func (m *manager) List(ctx context.Context, query *q.Query) ([]*model.History, error) {
+	return m.dao.List(ctx, query)
+}
@@ -0,0 +1,63 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+func init() {
+	orm.RegisterModel(&History{})
+}
+
+// History records the configurations changed by an update, the ID is used as the version
+type History struct {
+	ID       int64  `orm:"pk;auto;column(id)" json:"version" sort:"default:desc"`
+	Operator string `orm:"column(operator)" json:"operator"`
+	// Before is the values of the changed configurations before the update in JSON format
+	Before string `orm:"column(before)" json:"before"`
+	// After is the values of the changed configurations after the update in JSON format
+	After        string    `orm:"column(after)" json:"after"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+}
+
+// TableName ...
+func (h *History) TableName() string {
+	return "config_history"
+}
+
+// BeforeValues returns the values of the changed configurations before the update
+func (h *History) BeforeValues() (map[string]interface{}, error) {
+	return unmarshal(h.Before)
+}
+
+// AfterValues returns the values of the changed configurations after the update
+func (h *History) AfterValues() (map[string]interface{}, error) {
+	return unmarshal(h.After)
+}
+
+func unmarshal(str string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if len(str) == 0 {
+		return values, nil
+	}
+	if err := json.Unmarshal([]byte(str), &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
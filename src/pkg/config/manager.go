@@ -159,16 +159,19 @@ func (c *CfgManager) GetDatabaseCfg() *models.Database {
 	return &models.Database{
 		Type: c.Get(ctx, common.DatabaseType).GetString(),
 		PostGreSQL: &models.PostGreSQL{
-			Host:            c.Get(ctx, common.PostGreSQLHOST).GetString(),
-			Port:            c.Get(ctx, common.PostGreSQLPort).GetInt(),
-			Username:        c.Get(ctx, common.PostGreSQLUsername).GetString(),
-			Password:        c.Get(ctx, common.PostGreSQLPassword).GetString(),
-			Database:        c.Get(ctx, common.PostGreSQLDatabase).GetString(),
-			SSLMode:         c.Get(ctx, common.PostGreSQLSSLMode).GetString(),
-			MaxIdleConns:    c.Get(ctx, common.PostGreSQLMaxIdleConns).GetInt(),
-			MaxOpenConns:    c.Get(ctx, common.PostGreSQLMaxOpenConns).GetInt(),
-			ConnMaxLifetime: c.Get(ctx, common.PostGreSQLConnMaxLifetime).GetDuration(),
-			ConnMaxIdleTime: c.Get(ctx, common.PostGreSQLConnMaxIdleTime).GetDuration(),
+			Host:             c.Get(ctx, common.PostGreSQLHOST).GetString(),
+			Port:             c.Get(ctx, common.PostGreSQLPort).GetInt(),
+			Username:         c.Get(ctx, common.PostGreSQLUsername).GetString(),
+			Password:         c.Get(ctx, common.PostGreSQLPassword).GetString(),
+			Database:         c.Get(ctx, common.PostGreSQLDatabase).GetString(),
+			SSLMode:          c.Get(ctx, common.PostGreSQLSSLMode).GetString(),
+			MaxIdleConns:     c.Get(ctx, common.PostGreSQLMaxIdleConns).GetInt(),
+			MaxOpenConns:     c.Get(ctx, common.PostGreSQLMaxOpenConns).GetInt(),
+			ConnMaxLifetime:  c.Get(ctx, common.PostGreSQLConnMaxLifetime).GetDuration(),
+			ConnMaxIdleTime:  c.Get(ctx, common.PostGreSQLConnMaxIdleTime).GetDuration(),
+			StatementTimeout: c.Get(ctx, common.PostGreSQLStatementTimeout).GetDuration(),
+			ReplicaHost:      c.Get(ctx, common.PostGreSQLReplicaHost).GetString(),
+			ReplicaPort:      c.Get(ctx, common.PostGreSQLReplicaPort).GetInt(),
 		},
 	}
 }
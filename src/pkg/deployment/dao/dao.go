@@ -0,0 +1,112 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/deployment/model"
+)
+
+// DAO defines the interface to access the deployment marks
+type DAO interface {
+	// Upsert creates the mark or renews the expire time of the existing one of the same
+	// repository, digest and deployment, returns the ID of the mark
+	Upsert(ctx context.Context, mark *model.Mark) (int64, error)
+	// Count returns the total count of the marks according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List the marks according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.Mark, error)
+	// Delete the mark of the repository, digest and deployment
+	Delete(ctx context.Context, repository, digest, deployment string) error
+	// DeleteExpired deletes the marks expired before the time and returns the deleted count
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// New returns an instance of the default DAO
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+func (d *dao) Upsert(ctx context.Context, mark *model.Mark) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	sql := `INSERT INTO deployment_mark (project_id, repository_name, digest, deployment, created_by, creation_time, update_time, expire_time)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (repository_name, digest, deployment)
+		DO UPDATE SET update_time = EXCLUDED.update_time, expire_time = EXCLUDED.expire_time
+		RETURNING id`
+	var id int64
+	if err = ormer.Raw(sql, mark.ProjectID, mark.RepositoryName, mark.Digest, mark.Deployment,
+		mark.CreatedBy, mark.CreationTime, mark.UpdateTime, mark.ExpireTime).QueryRow(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.Mark{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.CountWithCtx(ctx)
+}
+
+func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.Mark, error) {
+	marks := []*model.Mark{}
+	qs, err := orm.QuerySetter(ctx, &model.Mark{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.AllWithCtx(ctx, &marks); err != nil {
+		return nil, err
+	}
+	return marks, nil
+}
+
+func (d *dao) Delete(ctx context.Context, repository, digest, deployment string) error {
+	qs, err := orm.QuerySetter(ctx, &model.Mark{}, q.New(q.KeyWords{
+		"RepositoryName": repository,
+		"Digest":         digest,
+		"Deployment":     deployment,
+	}))
+	if err != nil {
+		return err
+	}
+	n, err := qs.DeleteWithCtx(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("the mark of the deployment %s on %s@%s not found", deployment, repository, digest)
+	}
+	return nil
+}
+
+func (d *dao) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	qs, err := orm.QuerySetter(ctx, &model.Mark{}, nil)
+	if err != nil {
+		return 0, err
+	}
+	return qs.Filter("expire_time__lte", before).DeleteWithCtx(ctx)
+}
@@ -0,0 +1,113 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"context"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/deployment/dao"
+	"github.com/goharbor/harbor/src/pkg/deployment/model"
+)
+
+const (
+	// DefaultTTL is the TTL of the mark when it isn't specified
+	DefaultTTL = time.Hour
+	// MaxTTL is the max TTL of the mark, the CD systems should renew the marks by the heartbeats
+	// rather than mark the artifacts in use forever
+	MaxTTL = 30 * 24 * time.Hour
+)
+
+// Mgr is the global deployment mark manager instance
+var Mgr = New()
+
+// Manager manages the marks of the artifacts in use by the deployments of the external CD systems
+type Manager interface {
+	// Mark the artifact in use by the deployment for the TTL, marking it again renews the expire time
+	Mark(ctx context.Context, mark *model.Mark, ttl time.Duration) (*model.Mark, error)
+	// Unmark removes the mark of the deployment from the artifact
+	Unmark(ctx context.Context, repository, digest, deployment string) error
+	// ListActive lists the marks that aren't expired according to the query
+	ListActive(ctx context.Context, query *q.Query) ([]*model.Mark, error)
+	// CountActive returns the count of the marks that aren't expired according to the query
+	CountActive(ctx context.Context, query *q.Query) (int64, error)
+	// InUse returns whether the artifact is marked by any deployment that isn't expired
+	InUse(ctx context.Context, repository, digest string) (bool, error)
+}
+
+// New returns a default implementation of Manager
+func New() Manager {
+	return &manager{dao: dao.New()}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+func (m *manager) Mark(ctx context.Context, mark *model.Mark, ttl time.Duration) (*model.Mark, error) {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	if ttl > MaxTTL {
+		return nil, errors.BadRequestError(nil).WithMessage("the TTL of the mark mustn't exceed %d seconds", int64(MaxTTL.Seconds()))
+	}
+	now := time.Now()
+	// clean up the expired marks as they are useless, the failure doesn't block the marking
+	if _, err := m.dao.DeleteExpired(ctx, now); err != nil {
+		log.Warningf("failed to delete the expired deployment marks: %v", err)
+	}
+	mark.CreationTime = now
+	mark.UpdateTime = now
+	mark.ExpireTime = now.Add(ttl)
+	id, err := m.dao.Upsert(ctx, mark)
+	if err != nil {
+		return nil, err
+	}
+	mark.ID = id
+	return mark, nil
+}
+
+func (m *manager) Unmark(ctx context.Context, repository, digest, deployment string) error {
+	return m.dao.Delete(ctx, repository, digest, deployment)
+}
+
+func (m *manager) ListActive(ctx context.Context, query *q.Query) ([]*model.Mark, error) {
+	return m.dao.List(ctx, activeQuery(query))
+}
+
+func (m *manager) CountActive(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.Count(ctx, activeQuery(query))
+}
+
+func (m *manager) InUse(ctx context.Context, repository, digest string) (bool, error) {
+	n, err := m.CountActive(ctx, q.New(q.KeyWords{
+		"RepositoryName": repository,
+		"Digest":         digest,
+	}))
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// activeQuery returns a copy of the query that filters out the expired marks
+func activeQuery(query *q.Query) *q.Query {
+	query = q.MustClone(query)
+	query.Keywords["Active"] = true
+	return query
+}
@@ -0,0 +1,86 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/deployment/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/deployment/dao"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	dao *dao.DAO
+	mgr *manager
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.dao = &dao.DAO{}
+	m.mgr = &manager{dao: m.dao}
+}
+
+func (m *managerTestSuite) TestMark() {
+	mock.OnAnything(m.dao, "DeleteExpired").Return(int64(1), nil)
+	mock.OnAnything(m.dao, "Upsert").Return(int64(1), nil)
+
+	mark, err := m.mgr.Mark(context.Background(), &model.Mark{
+		RepositoryName: "library/hello-world",
+		Digest:         "sha256:123",
+		Deployment:     "prod/web",
+	}, 0)
+	m.Require().Nil(err)
+	m.Equal(int64(1), mark.ID)
+	m.True(mark.IsActive())
+	m.WithinDuration(mark.UpdateTime.Add(DefaultTTL), mark.ExpireTime, time.Second)
+
+	// the TTL exceeds the max one
+	_, err = m.mgr.Mark(context.Background(), &model.Mark{}, MaxTTL+time.Second)
+	m.True(errors.IsErr(err, errors.BadRequestCode))
+	m.dao.AssertNumberOfCalls(m.T(), "Upsert", 1)
+}
+
+func (m *managerTestSuite) TestInUse() {
+	mock.OnAnything(m.dao, "Count").Return(int64(1), nil)
+	inUse, err := m.mgr.InUse(context.Background(), "library/hello-world", "sha256:123")
+	m.Require().Nil(err)
+	m.True(inUse)
+	query := m.dao.Calls[0].Arguments.Get(1).(*q.Query)
+	m.Equal(true, query.Keywords["Active"])
+	m.Equal("library/hello-world", query.Keywords["RepositoryName"])
+	m.Equal("sha256:123", query.Keywords["Digest"])
+}
+
+func (m *managerTestSuite) TestListActive() {
+	mock.OnAnything(m.dao, "List").Return([]*model.Mark{{ID: 1}}, nil)
+	query := q.New(q.KeyWords{"RepositoryName": "library/hello-world"})
+	marks, err := m.mgr.ListActive(context.Background(), query)
+	m.Require().Nil(err)
+	m.Len(marks, 1)
+	// the query of the caller isn't changed
+	_, exist := query.Keywords["Active"]
+	m.False(exist)
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
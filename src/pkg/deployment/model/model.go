@@ -0,0 +1,62 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+func init() {
+	orm.RegisterModel(&Mark{})
+}
+
+// Mark records that the artifact is in use by the deployment of an external CD system, the artifacts
+// with the active marks cannot be deleted by the users, the tag retention or the garbage collection.
+// The mark expires unless the CD system renews it before the expire time as the heartbeat
+type Mark struct {
+	ID             int64  `orm:"pk;auto;column(id)" json:"id"`
+	ProjectID      int64  `orm:"column(project_id)" json:"project_id"`
+	RepositoryName string `orm:"column(repository_name)" json:"repository_name"`
+	Digest         string `orm:"column(digest)" json:"digest"`
+	// the identifier of the deployment in the CD system, e.g. "prod/cluster-a/web"
+	Deployment   string    `orm:"column(deployment)" json:"deployment"`
+	CreatedBy    string    `orm:"column(created_by)" json:"created_by"`
+	CreationTime time.Time `orm:"column(creation_time)" json:"creation_time"`
+	// the time of the latest heartbeat
+	UpdateTime time.Time `orm:"column(update_time)" json:"update_time" sort:"default:desc"`
+	ExpireTime time.Time `orm:"column(expire_time)" json:"expire_time"`
+}
+
+// TableName ...
+func (m *Mark) TableName() string {
+	return "deployment_mark"
+}
+
+// FilterByActive filters out the expired marks when the value is true
+func (m *Mark) FilterByActive(ctx context.Context, qs orm.QuerySeter, key string, value interface{}) orm.QuerySeter {
+	active, ok := value.(bool)
+	if !ok || !active {
+		return qs
+	}
+	return qs.Filter("expire_time__gt", time.Now())
+}
+
+// IsActive returns whether the mark isn't expired
+func (m *Mark) IsActive() bool {
+	return time.Now().Before(m.ExpireTime)
+}
@@ -15,16 +15,26 @@
 package pkg
 
 import (
+	"context"
+
 	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/pkg/cached"
 	cachedArtifact "github.com/goharbor/harbor/src/pkg/cached/artifact/redis"
+	cachedLabel "github.com/goharbor/harbor/src/pkg/cached/label/redis"
 	cachedManifest "github.com/goharbor/harbor/src/pkg/cached/manifest/redis"
+	cachedMember "github.com/goharbor/harbor/src/pkg/cached/member/redis"
 	cachedProject "github.com/goharbor/harbor/src/pkg/cached/project/redis"
 	cachedProjectMeta "github.com/goharbor/harbor/src/pkg/cached/project_metadata/redis"
 	cachedRepo "github.com/goharbor/harbor/src/pkg/cached/repository/redis"
+	cachedUserGroup "github.com/goharbor/harbor/src/pkg/cached/usergroup/redis"
+	"github.com/goharbor/harbor/src/pkg/label"
+	"github.com/goharbor/harbor/src/pkg/member"
 	"github.com/goharbor/harbor/src/pkg/project"
 	"github.com/goharbor/harbor/src/pkg/project/metadata"
 	"github.com/goharbor/harbor/src/pkg/repository"
+	"github.com/goharbor/harbor/src/pkg/usergroup"
 )
 
 // Define global resource manager.
@@ -39,6 +49,12 @@ var (
 	RepositoryMgr repository.Manager
 	// ManifestMgr is the manager for manifest.
 	ManifestMgr cachedManifest.CachedManager
+	// MemberMgr is the manager for project member.
+	MemberMgr member.Manager
+	// UserGroupMgr is the manager for user group.
+	UserGroupMgr usergroup.Manager
+	// LabelMgr is the manager for label.
+	LabelMgr label.Manager
 )
 
 // init initialize mananger for resources
@@ -49,6 +65,9 @@ func init() {
 	initProjectMetaMgr(cacheEnabled)
 	initRepositoryMgr(cacheEnabled)
 	initManifestManager(cacheEnabled)
+	initMemberMgr(cacheEnabled)
+	initUserGroupMgr(cacheEnabled)
+	initLabelMgr(cacheEnabled)
 }
 
 func initArtifactMgr(cacheEnabled bool) {
@@ -92,3 +111,60 @@ func initRepositoryMgr(cacheEnabled bool) {
 func initManifestManager(cacheEnabled bool) {
 	ManifestMgr = cachedManifest.NewManager()
 }
+
+func initMemberMgr(cacheEnabled bool) {
+	if cacheEnabled {
+		MemberMgr = cachedMember.NewManager(member.Mgr)
+	} else {
+		MemberMgr = member.Mgr
+	}
+}
+
+func initUserGroupMgr(cacheEnabled bool) {
+	if cacheEnabled {
+		UserGroupMgr = cachedUserGroup.NewManager(usergroup.Mgr)
+	} else {
+		UserGroupMgr = usergroup.Mgr
+	}
+}
+
+func initLabelMgr(cacheEnabled bool) {
+	if cacheEnabled {
+		LabelMgr = cachedLabel.NewManager(label.Mgr)
+	} else {
+		LabelMgr = label.Mgr
+	}
+}
+
+// FlushCache flushes the cache of the resources specified by the types,
+// the cache of all the resources is flushed if no type is specified.
+func FlushCache(ctx context.Context, resourceTypes ...string) error {
+	managers := map[string]cached.Manager{}
+	for _, mgr := range []interface{}{ArtifactMgr, ProjectMgr, ProjectMetaMgr, RepositoryMgr, ManifestMgr, MemberMgr, UserGroupMgr, LabelMgr} {
+		if cm, ok := mgr.(cached.Manager); ok {
+			managers[cm.ResourceType(ctx)] = cm
+		}
+	}
+
+	if len(resourceTypes) == 0 {
+		for resourceType := range managers {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+	}
+	for _, resourceType := range resourceTypes {
+		if _, exist := managers[resourceType]; !exist {
+			return errors.BadRequestError(nil).WithMessage("the cache of resource type %s isn't supported", resourceType)
+		}
+	}
+
+	var errs errors.Errors
+	for _, resourceType := range resourceTypes {
+		if err := managers[resourceType].FlushAll(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if errs.Len() > 0 {
+		return errs
+	}
+	return nil
+}
@@ -34,7 +34,7 @@ func (i *iDao) CreateImmutableRule(ctx context.Context, ir *model.ImmutableRule)
 		return 0, err
 	}
 	ir.Disabled = false
-	id, err := ormer.Insert(ir)
+	id, err := ormer.InsertWithCtx(ctx, ir)
 	if err != nil {
 		if e := orm.AsConflictError(err, "immutable rule already exists"); e != nil {
 			err = e
@@ -50,7 +50,7 @@ func (i *iDao) UpdateImmutableRule(ctx context.Context, projectID int64, ir *mod
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Update(ir, "TagFilter")
+	n, err := ormer.UpdateWithCtx(ctx, ir, "TagFilter")
 	if err != nil {
 		return err
 	}
@@ -67,7 +67,7 @@ func (i *iDao) ToggleImmutableRule(ctx context.Context, id int64, status bool) e
 		return err
 	}
 	ir := &model.ImmutableRule{ID: id, Disabled: status}
-	n, err := ormer.Update(ir, "Disabled")
+	n, err := ormer.UpdateWithCtx(ctx, ir, "Disabled")
 	if err != nil {
 		return err
 	}
@@ -84,7 +84,7 @@ func (i *iDao) GetImmutableRule(ctx context.Context, id int64) (*model.Immutable
 		return nil, err
 	}
 	ir := &model.ImmutableRule{ID: id}
-	if err = ormer.Read(ir); err != nil {
+	if err = ormer.ReadWithCtx(ctx, ir); err != nil {
 		if e := orm.AsNotFoundError(err, "immutable rule %d not found", id); e != nil {
 			err = e
 		}
@@ -100,7 +100,7 @@ func (i *iDao) ListImmutableRules(ctx context.Context, query *q.Query) ([]*model
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&rules); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &rules); err != nil {
 		return nil, err
 	}
 	return rules, nil
@@ -112,7 +112,7 @@ func (i *iDao) Count(ctx context.Context, query *q.Query) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 
 // DeleteImmutableRule delete the immutable rule
@@ -123,7 +123,7 @@ func (i *iDao) DeleteImmutableRule(ctx context.Context, id int64) error {
 	}
 	ir := &model.ImmutableRule{ID: id}
 
-	n, err := ormer.Delete(ir)
+	n, err := ormer.DeleteWithCtx(ctx, ir)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,107 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/jobarchive/model"
+)
+
+// DAO is the data access object for the job archive
+type DAO interface {
+	// Create the job archive, the existing archive of the same task is overwritten
+	Create(ctx context.Context, archive *model.JobArchive) (id int64, err error)
+	// Count returns the total count of job archives according to the query
+	Count(ctx context.Context, query *q.Query) (total int64, err error)
+	// List job archives according to the query
+	List(ctx context.Context, query *q.Query) (archives []*model.JobArchive, err error)
+	// Get the job archive specified by ID
+	Get(ctx context.Context, id int64) (archive *model.JobArchive, err error)
+	// Purge the job archives which end before the specified time
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// New returns an instance of the default DAO
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+// Create ...
+func (d *dao) Create(ctx context.Context, archive *model.JobArchive) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return ormer.InsertOrUpdateWithCtx(ctx, archive, "task_id")
+}
+
+// Count ...
+func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.JobArchive{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.CountWithCtx(ctx)
+}
+
+// List ...
+func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.JobArchive, error) {
+	archives := []*model.JobArchive{}
+	qs, err := orm.QuerySetter(ctx, &model.JobArchive{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.AllWithCtx(ctx, &archives); err != nil {
+		return nil, err
+	}
+	return archives, nil
+}
+
+// Get ...
+func (d *dao) Get(ctx context.Context, id int64) (*model.JobArchive, error) {
+	archive := &model.JobArchive{
+		ID: id,
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ormer.ReadWithCtx(ctx, archive); err != nil {
+		if e := orm.AsNotFoundError(err, "job archive %d not found", id); e != nil {
+			err = e
+		}
+		return nil, err
+	}
+	return archive, nil
+}
+
+// Purge ...
+func (d *dao) Purge(ctx context.Context, before time.Time) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	r, err := ormer.Raw("DELETE FROM job_archive WHERE end_time < ?", before).Exec()
+	if err != nil {
+		return 0, err
+	}
+	return r.RowsAffected()
+}
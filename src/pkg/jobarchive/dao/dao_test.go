@@ -0,0 +1,133 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	beegoorm "github.com/beego/beego/v2/client/orm"
+	"github.com/stretchr/testify/suite"
+
+	common_dao "github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/jobarchive/model"
+)
+
+type daoTestSuite struct {
+	suite.Suite
+	dao       DAO
+	archiveID int64
+	ctx       context.Context
+}
+
+func (d *daoTestSuite) SetupSuite() {
+	d.dao = New()
+	common_dao.PrepareTestForPostgresSQL()
+	d.ctx = orm.NewContext(nil, beegoorm.NewOrm())
+	id, err := d.dao.Create(d.ctx, &model.JobArchive{
+		TaskID:      1,
+		ExecutionID: 1,
+		JobID:       "job01",
+		JobName:     "GARBAGE_COLLECTION",
+		VendorType:  "GARBAGE_COLLECTION",
+		Status:      "Success",
+		StartTime:   time.Now().AddDate(0, 0, -10),
+		EndTime:     time.Now().AddDate(0, 0, -10),
+	})
+	d.Require().Nil(err)
+	d.archiveID = id
+	_, err = d.dao.Create(d.ctx, &model.JobArchive{
+		TaskID:      2,
+		ExecutionID: 2,
+		JobID:       "job02",
+		JobName:     "IMAGE_SCAN",
+		VendorType:  "IMAGE_SCAN",
+		ProjectID:   1,
+		Status:      "Error",
+		StartTime:   time.Now().Add(-time.Minute),
+		EndTime:     time.Now(),
+	})
+	d.Require().Nil(err)
+}
+
+func (d *daoTestSuite) TearDownSuite() {
+	ormer, err := orm.FromContext(d.ctx)
+	d.Require().Nil(err)
+	_, err = ormer.Raw("delete from job_archive").Exec()
+	d.Require().Nil(err)
+}
+
+func (d *daoTestSuite) TestCreate() {
+	// the archive of the same task is overwritten
+	_, err := d.dao.Create(d.ctx, &model.JobArchive{
+		TaskID:      2,
+		ExecutionID: 2,
+		JobID:       "job02",
+		JobName:     "IMAGE_SCAN",
+		VendorType:  "IMAGE_SCAN",
+		ProjectID:   1,
+		Status:      "Error",
+		StartTime:   time.Now().Add(-time.Minute),
+		EndTime:     time.Now(),
+	})
+	d.Require().Nil(err)
+	total, err := d.dao.Count(d.ctx, q.New(q.KeyWords{"TaskID": 2}))
+	d.Require().Nil(err)
+	d.Equal(int64(1), total)
+}
+
+func (d *daoTestSuite) TestList() {
+	archives, err := d.dao.List(d.ctx, q.New(q.KeyWords{"ProjectID": 1, "VendorType": "IMAGE_SCAN"}))
+	d.Require().Nil(err)
+	d.Require().Len(archives, 1)
+	d.Equal("job02", archives[0].JobID)
+
+	archives, err = d.dao.List(d.ctx, q.New(q.KeyWords{"EndTime": &q.Range{Max: time.Now().AddDate(0, 0, -1)}}))
+	d.Require().Nil(err)
+	d.Require().Len(archives, 1)
+	d.Equal("job01", archives[0].JobID)
+}
+
+func (d *daoTestSuite) TestGet() {
+	_, err := d.dao.Get(d.ctx, 10000)
+	d.Require().NotNil(err)
+	d.True(errors.IsErr(err, errors.NotFoundCode))
+
+	archive, err := d.dao.Get(d.ctx, d.archiveID)
+	d.Require().Nil(err)
+	d.Equal("GARBAGE_COLLECTION", archive.JobName)
+}
+
+func (d *daoTestSuite) TestPurge() {
+	_, err := d.dao.Create(d.ctx, &model.JobArchive{
+		TaskID:      3,
+		ExecutionID: 3,
+		VendorType:  "REPLICATION",
+		Status:      "Stopped",
+		EndTime:     time.Now().AddDate(-1, 0, 0),
+	})
+	d.Require().Nil(err)
+	n, err := d.dao.Purge(d.ctx, time.Now().AddDate(0, -1, 0))
+	d.Require().Nil(err)
+	d.Equal(int64(1), n)
+}
+
+func TestDaoTestSuite(t *testing.T) {
+	suite.Run(t, &daoTestSuite{})
+}
@@ -0,0 +1,131 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobarchive
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/jobarchive/dao"
+	"github.com/goharbor/harbor/src/pkg/jobarchive/model"
+)
+
+const redactedValue = "******"
+
+// the parameters whose names match the pattern are redacted before archiving
+var sensitiveParamRegexp = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|auth|private_key|access_key)`)
+
+// Mgr is the global job archive manager instance
+var Mgr = New()
+
+// Manager is used for job archive management
+type Manager interface {
+	// Create the job archive, the existing archive of the same task is overwritten
+	Create(ctx context.Context, archive *model.JobArchive) (id int64, err error)
+	// Count returns the total count of job archives according to the query
+	Count(ctx context.Context, query *q.Query) (total int64, err error)
+	// List job archives according to the query
+	List(ctx context.Context, query *q.Query) (archives []*model.JobArchive, err error)
+	// Get the job archive specified by ID
+	Get(ctx context.Context, id int64) (archive *model.JobArchive, err error)
+	// Purge the job archives which end before the specified time
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// New returns a default implementation of Manager
+func New() Manager {
+	return &manager{
+		dao: dao.New(),
+	}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+// Create ...
+func (m *manager) Create(ctx context.Context, archive *model.JobArchive) (int64, error) {
+	if archive.Duration == 0 && !archive.StartTime.IsZero() && archive.EndTime.After(archive.StartTime) {
+		archive.Duration = int64(archive.EndTime.Sub(archive.StartTime).Seconds())
+	}
+	return m.dao.Create(ctx, archive)
+}
+
+// Count ...
+func (m *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.Count(ctx, query)
+}
+
+// List ...
+func (m *manager) List(ctx context.Context, query *q.Query) ([]*model.JobArchive, error) {
+	return m.dao.List(ctx, query)
+}
+
+// Get ...
+func (m *manager) Get(ctx context.Context, id int64) (*model.JobArchive, error) {
+	return m.dao.Get(ctx, id)
+}
+
+// Purge ...
+func (m *manager) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return m.dao.Purge(ctx, before)
+}
+
+// EncodeParameters encodes the job parameters as the json string with the sensitive values redacted
+func EncodeParameters(params map[string]interface{}) string {
+	if len(params) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(redact(params))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			if _, isStr := val.(string); isStr && sensitiveParamRegexp.MatchString(key) {
+				m[key] = redactedValue
+				continue
+			}
+			m[key] = redact(val)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, val := range v {
+			s[i] = redact(val)
+		}
+		return s
+	case string:
+		// the complex parameters are passed as json strings in most of the jobs
+		var obj map[string]interface{}
+		if len(v) > 0 && v[0] == '{' && json.Unmarshal([]byte(v), &obj) == nil {
+			if data, err := json.Marshal(redact(obj)); err == nil {
+				return string(data)
+			}
+		}
+		return v
+	default:
+		return v
+	}
+}
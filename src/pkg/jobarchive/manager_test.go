@@ -0,0 +1,92 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobarchive
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/pkg/jobarchive/model"
+	mockDAO "github.com/goharbor/harbor/src/testing/pkg/jobarchive/dao"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	mgr *manager
+	dao *mockDAO.DAO
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.dao = &mockDAO.DAO{}
+	m.mgr = &manager{
+		dao: m.dao,
+	}
+}
+
+func (m *managerTestSuite) TestCreate() {
+	m.dao.On("Create", mock.Anything, mock.Anything).Return(int64(1), nil)
+	now := time.Now()
+	archive := &model.JobArchive{
+		TaskID:    1,
+		StartTime: now.Add(-90 * time.Second),
+		EndTime:   now,
+	}
+	id, err := m.mgr.Create(nil, archive)
+	m.Require().Nil(err)
+	m.Equal(int64(1), id)
+	m.Equal(int64(90), archive.Duration)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestPurge() {
+	m.dao.On("Purge", mock.Anything, mock.Anything).Return(int64(2), nil)
+	n, err := m.mgr.Purge(nil, time.Now())
+	m.Require().Nil(err)
+	m.Equal(int64(2), n)
+}
+
+func (m *managerTestSuite) TestEncodeParameters() {
+	m.Equal("", EncodeParameters(nil))
+
+	params := map[string]interface{}{
+		"project_id": 1,
+		"password":   "pwd",
+		"delete":     true,
+		"registration": map[string]interface{}{
+			"url":          "http://scanner",
+			"access_token": "token",
+		},
+		"payload": `{"auth_header":"Bearer xxx","name":"test"}`,
+	}
+	encoded := EncodeParameters(params)
+	result := map[string]interface{}{}
+	m.Require().Nil(json.Unmarshal([]byte(encoded), &result))
+	m.Equal(float64(1), result["project_id"])
+	m.Equal(redactedValue, result["password"])
+	m.Equal(true, result["delete"])
+	m.Equal(redactedValue, result["registration"].(map[string]interface{})["access_token"])
+	m.Equal("http://scanner", result["registration"].(map[string]interface{})["url"])
+	m.JSONEq(`{"auth_header":"******","name":"test"}`, result["payload"].(string))
+	// the original parameters are not changed
+	m.Equal("pwd", params["password"])
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
@@ -0,0 +1,50 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+func init() {
+	orm.RegisterModel(&JobArchive{})
+}
+
+// JobArchive is the archived record of the job in the final status
+type JobArchive struct {
+	ID            int64     `orm:"pk;auto;column(id)" json:"id"`
+	TaskID        int64     `orm:"column(task_id)" json:"task_id"`
+	ExecutionID   int64     `orm:"column(execution_id)" json:"execution_id"`
+	JobID         string    `orm:"column(job_id)" json:"job_id"`
+	JobName       string    `orm:"column(job_name)" json:"job_name"`
+	VendorType    string    `orm:"column(vendor_type)" json:"vendor_type"`
+	VendorID      int64     `orm:"column(vendor_id)" json:"vendor_id"`
+	ProjectID     int64     `orm:"column(project_id)" json:"project_id"`
+	Status        string    `orm:"column(status)" json:"status"`
+	StatusMessage string    `orm:"column(status_message)" json:"status_message"`
+	Trigger       string    `orm:"column(trigger)" json:"trigger"`
+	Parameters    string    `orm:"column(parameters)" json:"parameters"` // json string
+	StartTime     time.Time `orm:"column(start_time)" json:"start_time"`
+	EndTime       time.Time `orm:"column(end_time)" json:"end_time" sort:"default:desc"`
+	Duration      int64     `orm:"column(duration)" json:"duration"` // in seconds
+	CreationTime  time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+}
+
+// TableName for job archive
+func (j *JobArchive) TableName() string {
+	return "job_archive"
+}
@@ -32,7 +32,7 @@ func (d *dao) Create(ctx context.Context, jobLog *models.JobLog) (int64, error)
 	if err != nil {
 		return 0, err
 	}
-	count, err := ormer.InsertOrUpdate(jobLog, "job_uuid")
+	count, err := ormer.InsertOrUpdateWithCtx(ctx, jobLog, "job_uuid")
 	if err != nil {
 		return 0, err
 	}
@@ -42,12 +42,13 @@ func (d *dao) Create(ctx context.Context, jobLog *models.JobLog) (int64, error)
 
 // Get ...
 func (d *dao) Get(ctx context.Context, uuid string) (jobLog *models.JobLog, err error) {
-	ormer, err := orm.FromContext(ctx)
+	// reading the job log tolerates the replication lag
+	ormer, err := orm.FromContext(orm.WithReplica(ctx))
 	if err != nil {
 		return nil, err
 	}
 	jl := models.JobLog{UUID: uuid}
-	err = ormer.Read(&jl, "UUID")
+	err = ormer.ReadWithCtx(ctx, &jl, "UUID")
 	if e := orm.AsNotFoundError(err, "no job log founded"); e != nil {
 		log.Warningf("no job log founded. Query condition, uuid: %s, err: %v", uuid, e)
 		return nil, err
@@ -42,4 +42,48 @@ type Queue struct {
 	Count   int64
 	Latency int64
 	Paused  bool
+	// MaxConcurrency is the max concurrency of the job type, 0 means unlimited
+	MaxConcurrency uint
+}
+
+const (
+	// QueuedJobStatusPending the job is waiting in the queue
+	QueuedJobStatusPending = "pending"
+	// QueuedJobStatusRunning the job is fetched by the worker pool
+	QueuedJobStatusRunning = "running"
+)
+
+// QueuedJob is the job in the waiting queue or in the in-progress queue of the worker pool
+type QueuedJob struct {
+	ID         string
+	JobType    string
+	Status     string
+	PoolID     string
+	EnqueuedAt int64
+	Fails      int64
+	LastErr    string
+	// raw is the original content of the job in the queue, it's used to remove the job from the queue
+	raw string
+}
+
+// QueuedJobFilter filters the queued jobs
+type QueuedJobFilter struct {
+	Status string
+	PoolID string
+	// MinAge filters the jobs which are enqueued at least the seconds ago, it helps to find the stuck jobs
+	MinAge int64
+}
+
+// Match checks whether the job matches the filter
+func (f *QueuedJobFilter) Match(j *QueuedJob, now int64) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.Status) > 0 && f.Status != j.Status {
+		return false
+	}
+	if len(f.PoolID) > 0 && f.PoolID != j.PoolID {
+		return false
+	}
+	return f.MinAge <= 0 || now-j.EnqueuedAt >= f.MinAge
 }
@@ -20,10 +20,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/gocraft/work"
 	"github.com/gomodule/redigo/redis"
 
 	"github.com/goharbor/harbor/src/common/job"
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
 	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	libRedis "github.com/goharbor/harbor/src/lib/redis"
 )
@@ -41,8 +44,37 @@ type RedisClient interface {
 	UnpauseJob(ctx context.Context, jobName string) error
 	// StopPendingJobs stop the pending jobs of the specified type, and remove the jobs from the waiting queue
 	StopPendingJobs(ctx context.Context, jobType string) (jobIDs []string, err error)
+	// MaxConcurrency returns the max concurrency of the specified type job across all the job service instances, 0 means unlimited
+	MaxConcurrency(ctx context.Context, jobName string) (uint, error)
+	// SetMaxConcurrency changes the max concurrency of the specified type job, the change is kept after the job service restarts
+	SetMaxConcurrency(ctx context.Context, jobName string, maxConcurrency uint) error
+	// ListJobs lists the jobs of the specified type in the waiting queue and in the in-progress queues of the worker pools,
+	// only the jobs in the specified status are listed if the status isn't empty
+	ListJobs(ctx context.Context, jobType string, status string) ([]*QueuedJob, error)
+	// RequeueJob moves the running job from the in-progress queue back to the head of the waiting queue
+	RequeueJob(ctx context.Context, job *QueuedJob) error
+	// PurgeJob removes the job from the waiting queue or the in-progress queue
+	PurgeJob(ctx context.Context, job *QueuedJob) error
 }
 
+// KEYS[1] = the in-progress queue, KEYS[2] = the job queue, KEYS[3] = the lock, KEYS[4] = the lock info
+// ARGV[1] = the job, ARGV[2] = the worker pool ID, ARGV[3] = whether to push the job back to the job queue
+// The lock held by the job is released in the same way as the dead pool reaper of the worker pool
+var removeRunningJobScript = redis.NewScript(4, `
+if redis.call('lrem', KEYS[1], 1, ARGV[1]) == 0 then
+  return 0
+end
+if ARGV[3] == '1' then
+  redis.call('rpush', KEYS[2], ARGV[1])
+end
+if tonumber(redis.call('get', KEYS[3]) or '0') > 0 then
+  redis.call('decr', KEYS[3])
+end
+if tonumber(redis.call('hget', KEYS[4], ARGV[2]) or '0') > 0 then
+  redis.call('hincrby', KEYS[4], ARGV[2], -1)
+end
+return 1`)
+
 type redisClientImpl struct {
 	redisPool *redis.Pool
 	namespace string
@@ -126,7 +158,140 @@ func (r *redisClientImpl) UnpauseJob(ctx context.Context, jobName string) error
 	return err
 }
 
+func (r *redisClientImpl) MaxConcurrency(ctx context.Context, jobName string) (uint, error) {
+	conn := r.redisPool.Get()
+	defer conn.Close()
+	c, err := redis.Uint64(conn.Do("GET", rds.KeyJobMaxConcurrency(fmt.Sprintf("{%s}", r.namespace), jobName)))
+	if err == redis.ErrNil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return uint(c), nil
+}
+
+func (r *redisClientImpl) SetMaxConcurrency(ctx context.Context, jobName string, maxConcurrency uint) error {
+	log.Infof("set max concurrency of job %s to %d", jobName, maxConcurrency)
+	namespace := fmt.Sprintf("{%s}", r.namespace)
+	conn := r.redisPool.Get()
+	defer conn.Close()
+	// the key of the max concurrency is read by the workers before fetching the job, so the change takes effect immediately,
+	// keep the change in the overrides as the key is rewritten by the worker pools when the job service restarts
+	if err := conn.Send("MULTI"); err != nil {
+		return err
+	}
+	if err := conn.Send("SET", rds.KeyJobMaxConcurrency(namespace, jobName), maxConcurrency); err != nil {
+		return err
+	}
+	if err := conn.Send("HSET", rds.KeyMaxConcurrencyOverrides(namespace), jobName, maxConcurrency); err != nil {
+		return err
+	}
+	_, err := conn.Do("EXEC")
+	return err
+}
+
 // JobServiceRedisClient function to create redis client for job service
+func (r *redisClientImpl) ListJobs(ctx context.Context, jobType string, status string) ([]*QueuedJob, error) {
+	namespace := fmt.Sprintf("{%s}", r.namespace)
+	conn := r.redisPool.Get()
+	defer conn.Close()
+	jobs := make([]*QueuedJob, 0)
+	if len(status) == 0 || status == QueuedJobStatusPending {
+		items, err := redis.Strings(conn.Do("LRANGE", rds.KeyJobs(namespace, jobType), 0, -1))
+		if err != nil {
+			return nil, err
+		}
+		// the jobs are fetched from the tail of the queue, list them in the order to be executed
+		for i := len(items) - 1; i >= 0; i-- {
+			if j := parseQueuedJob(items[i], jobType, ""); j != nil {
+				jobs = append(jobs, j)
+			}
+		}
+	}
+	if len(status) == 0 || status == QueuedJobStatusRunning {
+		poolIDs, err := redis.Strings(conn.Do("SMEMBERS", rds.KeyWorkerPools(namespace)))
+		if err != nil {
+			return nil, err
+		}
+		for _, poolID := range poolIDs {
+			items, err := redis.Strings(conn.Do("LRANGE", rds.KeyInProgressQueue(namespace, jobType, poolID), 0, -1))
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range items {
+				if j := parseQueuedJob(item, jobType, poolID); j != nil {
+					jobs = append(jobs, j)
+				}
+			}
+		}
+	}
+	return jobs, nil
+}
+
+func parseQueuedJob(raw string, jobType string, poolID string) *QueuedJob {
+	j := &work.Job{}
+	if err := json.Unmarshal([]byte(raw), j); err != nil {
+		log.Errorf("failed to parse the job info %v, %v", raw, err)
+		return nil
+	}
+	status := QueuedJobStatusPending
+	if len(poolID) > 0 {
+		status = QueuedJobStatusRunning
+	}
+	return &QueuedJob{
+		ID:         j.ID,
+		JobType:    jobType,
+		Status:     status,
+		PoolID:     poolID,
+		EnqueuedAt: j.EnqueuedAt,
+		Fails:      j.Fails,
+		LastErr:    j.LastErr,
+		raw:        raw,
+	}
+}
+
+func (r *redisClientImpl) RequeueJob(ctx context.Context, job *QueuedJob) error {
+	log.Infof("requeue the job %s of type %s from the worker pool %s", job.ID, job.JobType, job.PoolID)
+	return r.removeRunningJob(job, true)
+}
+
+func (r *redisClientImpl) PurgeJob(ctx context.Context, job *QueuedJob) error {
+	log.Infof("purge the %s job %s of type %s", job.Status, job.ID, job.JobType)
+	if job.Status == QueuedJobStatusRunning {
+		return r.removeRunningJob(job, false)
+	}
+	conn := r.redisPool.Get()
+	defer conn.Close()
+	n, err := redis.Int(conn.Do("LREM", rds.KeyJobs(fmt.Sprintf("{%s}", r.namespace), job.JobType), 1, job.raw))
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("the job %s isn't in the queue", job.ID)
+	}
+	return nil
+}
+
+func (r *redisClientImpl) removeRunningJob(job *QueuedJob, requeue bool) error {
+	namespace := fmt.Sprintf("{%s}", r.namespace)
+	conn := r.redisPool.Get()
+	defer conn.Close()
+	n, err := redis.Int(removeRunningJobScript.Do(conn,
+		rds.KeyInProgressQueue(namespace, job.JobType, job.PoolID),
+		rds.KeyJobs(namespace, job.JobType),
+		rds.KeyJobLock(namespace, job.JobType),
+		rds.KeyJobLockInfo(namespace, job.JobType),
+		job.raw, job.PoolID, requeue))
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("the job %s isn't in the in-progress queue of the worker pool %s", job.ID, job.PoolID)
+	}
+	return nil
+}
+
 func JobServiceRedisClient() (RedisClient, error) {
 	cfg, err := job.GlobalClient.GetJobServiceConfig()
 	if err != nil {
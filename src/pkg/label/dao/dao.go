@@ -64,7 +64,7 @@ func (d *defaultDAO) Get(ctx context.Context, id int64) (*model.Label, error) {
 	label := &model.Label{
 		ID: id,
 	}
-	if err = ormer.Read(label); err != nil {
+	if err = ormer.ReadWithCtx(ctx, label); err != nil {
 		if e := orm.AsNotFoundError(err, "label %d not found", id); e != nil {
 			err = e
 		}
@@ -78,7 +78,7 @@ func (d *defaultDAO) Create(ctx context.Context, label *model.Label) (int64, err
 	if err != nil {
 		return 0, err
 	}
-	id, err := ormer.Insert(label)
+	id, err := ormer.InsertWithCtx(ctx, label)
 	if err != nil {
 		if e := orm.AsConflictError(err, "label %s already exists", label.Name); e != nil {
 			err = e
@@ -92,7 +92,7 @@ func (d *defaultDAO) Count(ctx context.Context, query *q.Query) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 
 func (d *defaultDAO) Update(ctx context.Context, label *model.Label) error {
@@ -101,7 +101,7 @@ func (d *defaultDAO) Update(ctx context.Context, label *model.Label) error {
 		return err
 	}
 	label.UpdateTime = time.Now()
-	n, err := ormer.Update(label)
+	n, err := ormer.UpdateWithCtx(ctx, label)
 	if n == 0 {
 		if e := orm.AsConflictError(err, "label %s already exists", label.Name); e != nil {
 			err = e
@@ -116,7 +116,7 @@ func (d *defaultDAO) Delete(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&model.Label{
+	n, err := ormer.DeleteWithCtx(ctx, &model.Label{
 		ID: id,
 	})
 	if err != nil {
@@ -134,7 +134,7 @@ func (d *defaultDAO) List(ctx context.Context, query *q.Query) ([]*model.Label,
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&robots); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &robots); err != nil {
 		return nil, err
 	}
 	return robots, nil
@@ -159,7 +159,7 @@ func (d *defaultDAO) CreateReference(ctx context.Context, ref *model.Reference)
 	if err != nil {
 		return 0, err
 	}
-	id, err := ormer.Insert(ref)
+	id, err := ormer.InsertWithCtx(ctx, ref)
 	if err != nil {
 		if e := orm.AsConflictError(err, "label %d is already added to the artifact %d",
 			ref.LabelID, ref.ArtifactID); e != nil {
@@ -177,7 +177,7 @@ func (d *defaultDAO) DeleteReference(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&model.Reference{
+	n, err := ormer.DeleteWithCtx(ctx, &model.Reference{
 		ID: id,
 	})
 	if err != nil {
@@ -194,5 +194,5 @@ func (d *defaultDAO) DeleteReferences(ctx context.Context, query *q.Query) (int6
 	if err != nil {
 		return 0, err
 	}
-	return qs.Delete()
+	return qs.DeleteWithCtx(ctx)
 }
@@ -24,7 +24,6 @@ import (
 	testDao "github.com/goharbor/harbor/src/common/dao"
 	_ "github.com/goharbor/harbor/src/common/dao"
 	"github.com/goharbor/harbor/src/lib/orm"
-	"github.com/goharbor/harbor/src/pkg"
 	"github.com/goharbor/harbor/src/pkg/member/models"
 	"github.com/goharbor/harbor/src/pkg/project"
 	"github.com/goharbor/harbor/src/pkg/user"
@@ -70,7 +69,7 @@ func (s *DaoTestSuite) SetupSuite() {
 		"delete from project_member where id > 1",
 	}
 	testDao.PrepareTestData(clearSqls, initSqls)
-	s.projectMgr = pkg.ProjectMgr
+	s.projectMgr = project.New()
 	s.userMgr = user.Mgr
 	ctx := s.Context()
 	proj, err := s.projectMgr.Get(ctx, "member_test_01")
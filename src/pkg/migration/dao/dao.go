@@ -0,0 +1,118 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+
+	"github.com/golang-migrate/migrate/v4/database"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+)
+
+// the table in which the golang-migrate records the schema version
+const migrationsTable = "schema_migrations"
+
+// DAO is the data access object for the status of the schema migrations
+type DAO interface {
+	// Version returns the schema version and whether it's dirty, the version is 0 if no migration is applied
+	Version(ctx context.Context) (uint, bool, error)
+	// EstimateRows returns the estimated row counts of the existing tables from the statistics of the database,
+	// the tables which don't exist are absent in the result
+	EstimateRows(ctx context.Context, tables ...string) (map[string]int64, error)
+	// Locked returns whether the lock of the schema migration is held by any session
+	Locked(ctx context.Context) (bool, error)
+}
+
+// New returns an instance of the default DAO
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+func (d *dao) Version(ctx context.Context) (uint, bool, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	var version int64
+	var dirty bool
+	if err = ormer.Raw("SELECT version, dirty FROM "+migrationsTable+" LIMIT 1").QueryRow(&version, &dirty); err != nil {
+		if errors.Is(err, orm.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	if version < 0 {
+		return 0, dirty, nil
+	}
+	return uint(version), dirty, nil
+}
+
+func (d *dao) EstimateRows(ctx context.Context, tables ...string) (map[string]int64, error) {
+	rows := map[string]int64{}
+	if len(tables) == 0 {
+		return rows, nil
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the reltuples is updated by the vacuum and analyze, it's accurate enough for the estimation without scanning the tables
+	sql := `SELECT relname, GREATEST(reltuples, 0)::bigint AS rows FROM pg_class
+		WHERE relkind = 'r' AND relnamespace = current_schema()::regnamespace
+		AND relname IN (` + orm.ParamPlaceholderForIn(len(tables)) + `)`
+	params := make([]interface{}, 0, len(tables))
+	for _, table := range tables {
+		params = append(params, table)
+	}
+	var results []*struct {
+		Relname string `orm:"column(relname)"`
+		Rows    int64  `orm:"column(rows)"`
+	}
+	if _, err = ormer.Raw(sql, params...).QueryRows(&results); err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		rows[result.Relname] = result.Rows
+	}
+	return rows, nil
+}
+
+func (d *dao) Locked(ctx context.Context) (bool, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	var databaseName, schemaName string
+	if err = ormer.Raw("SELECT current_database(), current_schema()").QueryRow(&databaseName, &schemaName); err != nil {
+		return false, err
+	}
+	// the same lock ID is used by the golang-migrate to serialize the migrations
+	lockID, err := database.GenerateAdvisoryLockId(databaseName, schemaName, migrationsTable)
+	if err != nil {
+		return false, err
+	}
+	// the advisory lock on a bigint key is recorded with the high 32 bits as the classid and the low 32 bits as the objid
+	var count int64
+	sql := `SELECT COUNT(*) FROM pg_locks WHERE locktype = 'advisory' AND granted
+		AND classid = 0 AND objid = ?::bigint::oid AND objsubid = 1`
+	if err = ormer.Raw(sql, lockID).QueryRow(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,231 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4/source"
+
+	commondao "github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/pkg/migration/dao"
+	"github.com/goharbor/harbor/src/pkg/migration/model"
+)
+
+const (
+	// MinSchemaVersion is the minimum schema version which can be upgraded directly, the schemas prior to
+	// v1.10 must be upgraded to v1.10 first
+	MinSchemaVersion = 15
+	// the migrations touching the tables with more rows than it are considered long-running
+	longRunningRows = 1000000
+)
+
+// the statements in the migration scripts which scan or rewrite the tables
+var tablePattern = regexp.MustCompile(`(?i)\b(?:ALTER\s+TABLE(?:\s+IF\s+EXISTS)?(?:\s+ONLY)?|UPDATE(?:\s+ONLY)?|DELETE\s+FROM|INSERT\s+INTO|` +
+	`CREATE\s+(?:UNIQUE\s+)?INDEX(?:\s+CONCURRENTLY)?(?:\s+IF\s+NOT\s+EXISTS)?(?:\s+\w+)?\s+ON(?:\s+ONLY)?)\s+"?(\w+)"?`)
+
+// Mgr is the global migration manager instance
+var Mgr = New()
+
+// Manager reports the status of the database schema migrations
+type Manager interface {
+	// Status returns the schema version in the database and the pending migrations
+	// shipped with the running core
+	Status(ctx context.Context) (*model.Status, error)
+	// Preflight checks whether the database is ready for the pending migrations
+	Preflight(ctx context.Context) (*model.Preflight, error)
+}
+
+// New returns a default implementation of Manager
+func New() Manager {
+	return &manager{
+		dao:  dao.New(),
+		path: commondao.MigrationPath(),
+	}
+}
+
+type manager struct {
+	dao dao.DAO
+	// the directory of the migration scripts
+	path string
+}
+
+func (m *manager) Status(ctx context.Context) (*model.Status, error) {
+	version, dirty, err := m.dao.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status := &model.Status{
+		CurrentVersion: version,
+		Dirty:          dirty,
+		LatestVersion:  version,
+		Pending:        []*model.Migration{},
+	}
+	scripts, err := m.scripts()
+	if err != nil {
+		return nil, err
+	}
+	// the tables touched by each pending migration
+	touched := map[uint][]string{}
+	var tables []string
+	for _, script := range scripts {
+		if script.Version > status.LatestVersion {
+			status.LatestVersion = script.Version
+		}
+		if script.Version <= version {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(m.path, script.Raw))
+		if err != nil {
+			return nil, err
+		}
+		touched[script.Version] = parseTables(string(content))
+		tables = append(tables, touched[script.Version]...)
+		status.Pending = append(status.Pending, &model.Migration{
+			Version: script.Version,
+			Name:    script.Identifier,
+			Tables:  []*model.Table{},
+		})
+	}
+
+	rows, err := m.dao.EstimateRows(ctx, unique(tables)...)
+	if err != nil {
+		return nil, err
+	}
+	for _, migration := range status.Pending {
+		for _, table := range touched[migration.Version] {
+			// the words matched by the pattern but not the existing tables are dropped
+			n, exist := rows[table]
+			if !exist {
+				continue
+			}
+			migration.Tables = append(migration.Tables, &model.Table{Name: table, EstimatedRows: n})
+			if n >= longRunningRows {
+				migration.LongRunning = true
+			}
+		}
+	}
+	return status, nil
+}
+
+func (m *manager) Preflight(ctx context.Context) (*model.Preflight, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	locked, err := m.dao.Locked(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var longRunning []string
+	for _, migration := range status.Pending {
+		if migration.LongRunning {
+			longRunning = append(longRunning, fmt.Sprintf("%d_%s", migration.Version, migration.Name))
+		}
+	}
+	checks := []*model.Check{
+		newCheck("schema_version", status.CurrentVersion == 0 || status.CurrentVersion >= MinSchemaVersion, true,
+			fmt.Sprintf("the schema version %d can be upgraded", status.CurrentVersion),
+			fmt.Sprintf("the schema version %d is prior to v1.10, please upgrade to v1.10 first", status.CurrentVersion)),
+		newCheck("schema_clean", !status.Dirty, true,
+			"the schema is clean",
+			fmt.Sprintf("the migration to version %d failed in the middle, the schema must be fixed manually", status.CurrentVersion)),
+		newCheck("migration_lock", !locked, true,
+			"no migration is in progress",
+			"another migration is in progress"),
+		// the long-running migrations don't block the upgrade but the downtime should be planned for them
+		newCheck("long_running_migrations", len(longRunning) == 0, false,
+			"no long-running migration is pending",
+			fmt.Sprintf("the migrations %s touch the tables with more than %d rows and may take a long time",
+				strings.Join(longRunning, ", "), longRunningRows)),
+	}
+
+	preflight := &model.Preflight{
+		Ready:  true,
+		Checks: checks,
+		Status: status,
+	}
+	for _, check := range checks {
+		if check.Blocking && !check.Passed {
+			preflight.Ready = false
+		}
+	}
+	return preflight, nil
+}
+
+func newCheck(name string, passed, blocking bool, passedMsg, failedMsg string) *model.Check {
+	check := &model.Check{
+		Name:     name,
+		Passed:   passed,
+		Blocking: blocking,
+		Message:  passedMsg,
+	}
+	if !passed {
+		check.Message = failedMsg
+	}
+	return check
+}
+
+// scripts returns the up migration scripts sorted by the version
+func (m *manager) scripts() ([]*source.Migration, error) {
+	entries, err := os.ReadDir(m.path)
+	if err != nil {
+		return nil, err
+	}
+	var scripts []*source.Migration
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		script, err := source.DefaultParse(entry.Name())
+		if err != nil || script.Direction != source.Up {
+			continue
+		}
+		scripts = append(scripts, script)
+	}
+	sort.Slice(scripts, func(i, j int) bool {
+		return scripts[i].Version < scripts[j].Version
+	})
+	return scripts, nil
+}
+
+// parseTables returns the tables scanned or rewritten by the statements in the migration script
+func parseTables(content string) []string {
+	var tables []string
+	for _, match := range tablePattern.FindAllStringSubmatch(content, -1) {
+		tables = append(tables, strings.ToLower(match[1]))
+	}
+	return unique(tables)
+}
+
+func unique(values []string) []string {
+	var result []string
+	seen := map[string]struct{}{}
+	for _, v := range values {
+		if _, exist := seen[v]; exist {
+			continue
+		}
+		seen[v] = struct{}{}
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,124 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/pkg/migration/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/migration/dao"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	dao *dao.DAO
+	mgr *manager
+}
+
+func (m *managerTestSuite) SetupTest() {
+	dir := m.T().TempDir()
+	scripts := map[string]string{
+		"0015_1.10.0_schema.up.sql": `CREATE TABLE artifact (id SERIAL PRIMARY KEY);`,
+		"0100_2.7.0_schema.up.sql": `ALTER TABLE artifact ADD COLUMN IF NOT EXISTS size bigint;
+CREATE INDEX IF NOT EXISTS idx_artifact_size ON artifact (size);`,
+		"0110_2.8.0_schema.up.sql": `UPDATE blob SET status='none' WHERE status IS NULL;
+CREATE TABLE IF NOT EXISTS label_ref (id SERIAL PRIMARY KEY, blob_id int REFERENCES blob(id) ON UPDATE CASCADE);`,
+		"0110_2.8.0_schema.down.sql": `DROP TABLE label_ref;`,
+		"README.md":                  `the migration scripts`,
+	}
+	for name, content := range scripts {
+		m.Require().NoError(os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+	m.dao = &dao.DAO{}
+	m.mgr = &manager{dao: m.dao, path: dir}
+}
+
+func (m *managerTestSuite) TestStatus() {
+	m.dao.On("Version", mock.Anything).Return(uint(90), false, nil)
+	m.dao.On("EstimateRows", mock.Anything, "artifact", "blob", "cascade").Return(map[string]int64{
+		"artifact": 2000000,
+		"blob":     10,
+	}, nil)
+
+	status, err := m.mgr.Status(context.TODO())
+	m.Require().NoError(err)
+	m.Equal(uint(90), status.CurrentVersion)
+	m.Equal(uint(110), status.LatestVersion)
+	m.Require().Len(status.Pending, 2)
+	m.Equal(&model.Migration{
+		Version:     100,
+		Name:        "2.7.0_schema",
+		Tables:      []*model.Table{{Name: "artifact", EstimatedRows: 2000000}},
+		LongRunning: true,
+	}, status.Pending[0])
+	m.Equal(&model.Migration{
+		Version: 110,
+		Name:    "2.8.0_schema",
+		Tables:  []*model.Table{{Name: "blob", EstimatedRows: 10}},
+	}, status.Pending[1])
+}
+
+func (m *managerTestSuite) TestStatusUpToDate() {
+	m.dao.On("Version", mock.Anything).Return(uint(110), false, nil)
+	m.dao.On("EstimateRows", mock.Anything).Return(map[string]int64{}, nil)
+
+	status, err := m.mgr.Status(context.TODO())
+	m.Require().NoError(err)
+	m.Equal(uint(110), status.LatestVersion)
+	m.Empty(status.Pending)
+}
+
+func (m *managerTestSuite) TestPreflight() {
+	m.dao.On("Version", mock.Anything).Return(uint(90), false, nil)
+	m.dao.On("EstimateRows", mock.Anything, "artifact", "blob", "cascade").Return(map[string]int64{"artifact": 2000000}, nil)
+	m.dao.On("Locked", mock.Anything).Return(false, nil)
+
+	preflight, err := m.mgr.Preflight(context.TODO())
+	m.Require().NoError(err)
+	// the long-running migrations don't block the upgrade
+	m.True(preflight.Ready)
+	m.Require().Len(preflight.Checks, 4)
+	m.Equal("long_running_migrations", preflight.Checks[3].Name)
+	m.False(preflight.Checks[3].Passed)
+	m.Contains(preflight.Checks[3].Message, "100_2.7.0_schema")
+}
+
+func (m *managerTestSuite) TestPreflightNotReady() {
+	m.dao.On("Version", mock.Anything).Return(uint(100), true, nil)
+	m.dao.On("EstimateRows", mock.Anything, "blob", "cascade").Return(map[string]int64{}, nil)
+	m.dao.On("Locked", mock.Anything).Return(true, nil)
+
+	preflight, err := m.mgr.Preflight(context.TODO())
+	m.Require().NoError(err)
+	m.False(preflight.Ready)
+	for _, check := range preflight.Checks {
+		switch check.Name {
+		case "schema_clean", "migration_lock":
+			m.False(check.Passed)
+		default:
+			m.True(check.Passed)
+		}
+	}
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
@@ -0,0 +1,60 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// Status is the status of the database schema migrations
+type Status struct {
+	// CurrentVersion is the version of the schema in the database, it's 0 before the first migration
+	CurrentVersion uint `json:"current_version"`
+	// Dirty is true when the last migration failed in the middle and the schema must be fixed manually
+	Dirty bool `json:"dirty"`
+	// LatestVersion is the version of the latest migration script shipped with the running core
+	LatestVersion uint `json:"latest_version"`
+	// Pending are the migrations to be applied in the next upgrade
+	Pending []*Migration `json:"pending"`
+}
+
+// Migration is a pending migration script
+type Migration struct {
+	Version uint   `json:"version"`
+	Name    string `json:"name"`
+	// Tables are the existing tables touched by the migration with their estimated row counts
+	Tables []*Table `json:"tables"`
+	// LongRunning is true when the migration touches the large tables and may take a long time
+	LongRunning bool `json:"long_running"`
+}
+
+// Table is a table touched by the migration
+type Table struct {
+	Name          string `json:"name"`
+	EstimatedRows int64  `json:"estimated_rows"`
+}
+
+// Check is a pre-flight check done before the upgrade
+type Check struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	// Blocking is true when the upgrade is supposed to fail if the check doesn't pass
+	Blocking bool   `json:"blocking"`
+	Message  string `json:"message"`
+}
+
+// Preflight is the result of the pre-flight checks
+type Preflight struct {
+	// Ready is true when all the blocking checks pass
+	Ready  bool     `json:"ready"`
+	Checks []*Check `json:"checks"`
+	Status *Status  `json:"status"`
+}
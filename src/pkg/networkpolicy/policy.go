@@ -0,0 +1,155 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Policy restricts the networks the credentials are usable from. The allowlist and denylist are the comma
+// separated CIDRs or IPs, the denylist takes precedence and all the networks are allowed if the allowlist is empty
+type Policy struct {
+	Allowlist string `json:"allowlist,omitempty"`
+	Denylist  string `json:"denylist,omitempty"`
+}
+
+// IsEmpty returns whether the policy restricts nothing
+func (p *Policy) IsEmpty() bool {
+	return p == nil || (len(strings.TrimSpace(p.Allowlist)) == 0 && len(strings.TrimSpace(p.Denylist)) == 0)
+}
+
+// Validate checks the entries of the allowlist and denylist are valid CIDRs or IPs
+func (p *Policy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if _, err := ParseCIDRs(p.Allowlist); err != nil {
+		return err
+	}
+	_, err := ParseCIDRs(p.Denylist)
+	return err
+}
+
+// Allows returns whether the credentials are usable from the IP, the IP isn't allowed when it's
+// unknown or the policy is invalid unless the policy is empty
+func (p *Policy) Allows(ip net.IP) bool {
+	if p.IsEmpty() {
+		return true
+	}
+	if ip == nil {
+		return false
+	}
+	allowlist, err := ParseCIDRs(p.Allowlist)
+	if err != nil {
+		return false
+	}
+	denylist, err := ParseCIDRs(p.Denylist)
+	if err != nil {
+		return false
+	}
+	if contains(denylist, ip) {
+		return false
+	}
+	return len(allowlist) == 0 || contains(allowlist, ip)
+}
+
+func contains(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCIDRs parses the comma separated CIDRs, the IPs are treated as the networks containing only themselves
+func ParseCIDRs(s string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) == 0 {
+			continue
+		}
+		if !strings.Contains(item, "/") {
+			ip := net.ParseIP(item)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP: %s", item)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR: %s", item)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// ClientIPHeader returns the name of the header carrying the true client IP, because it varies based on
+// the foreground proxy/lb settings, it's configurable by env
+func ClientIPHeader() string {
+	name := os.Getenv("TRUE_CLIENT_IP_HEADER")
+	if len(name) == 0 {
+		name = "x-forwarded-for"
+	}
+	return name
+}
+
+// ClientIP returns the IP of the client sending the request. The last address in the true client IP header
+// is used as it's appended by the closest proxy while the ones before it can be forged by the client, the
+// remote address is used when the header is absent
+func ClientIP(r *http.Request) net.IP {
+	if r == nil {
+		return nil
+	}
+	if header := r.Header.Get(ClientIPHeader()); len(header) > 0 {
+		addresses := strings.Split(header, ",")
+		return parseIP(addresses[len(addresses)-1])
+	}
+	return parseIP(r.RemoteAddr)
+}
+
+// parseIP parses the IP in the address which may contain the port
+func parseIP(address string) net.IP {
+	address = strings.TrimSpace(address)
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+	return net.ParseIP(strings.Trim(address, "[]"))
+}
+
+type clientIPKey struct{}
+
+// NewContext returns the context carrying the IP of the client
+func NewContext(ctx context.Context, ip net.IP) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// FromContext returns the IP of the client carried by the context
+func FromContext(ctx context.Context) net.IP {
+	ip, _ := ctx.Value(clientIPKey{}).(net.IP)
+	return ip
+}
@@ -0,0 +1,73 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, (*Policy)(nil).Validate())
+	assert.Nil(t, (&Policy{Allowlist: "10.0.0.0/8, 192.168.1.1", Denylist: "2001:db8::/32"}).Validate())
+	assert.NotNil(t, (&Policy{Allowlist: "10.0.0.0/33"}).Validate())
+	assert.NotNil(t, (&Policy{Denylist: "example.com"}).Validate())
+}
+
+func TestAllows(t *testing.T) {
+	cases := []struct {
+		policy  *Policy
+		ip      string
+		allowed bool
+	}{
+		{nil, "", true},
+		{&Policy{}, "1.2.3.4", true},
+		{&Policy{Allowlist: "10.0.0.0/8"}, "", false},
+		{&Policy{Allowlist: "10.0.0.0/8"}, "10.1.2.3", true},
+		{&Policy{Allowlist: "10.0.0.0/8"}, "1.2.3.4", false},
+		{&Policy{Allowlist: "10.0.0.0/8", Denylist: "10.1.0.0/16"}, "10.1.2.3", false},
+		{&Policy{Denylist: "1.2.3.4"}, "1.2.3.4", false},
+		{&Policy{Denylist: "1.2.3.4"}, "1.2.3.5", true},
+		{&Policy{Allowlist: "2001:db8::/32"}, "2001:db8::1", true},
+		{&Policy{Allowlist: "invalid"}, "1.2.3.4", false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.allowed, c.policy.Allows(net.ParseIP(c.ip)), "%+v %s", c.policy, c.ip)
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	req := httptest.NewRequest("GET", "/v2/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	assert.Equal(t, "10.0.0.1", ClientIP(req).String())
+
+	req.Header.Set("X-Forwarded-For", "1.1.1.1, 2.2.2.2")
+	assert.Equal(t, "2.2.2.2", ClientIP(req).String())
+
+	t.Setenv("TRUE_CLIENT_IP_HEADER", "X-Real-IP")
+	req.Header.Set("X-Real-IP", "[2001:db8::1]:443")
+	assert.Equal(t, "2001:db8::1", ClientIP(req).String())
+
+	assert.Nil(t, ClientIP(nil))
+}
+
+func TestContext(t *testing.T) {
+	assert.Nil(t, FromContext(context.Background()))
+	assert.Equal(t, net.ParseIP("10.0.0.1"), FromContext(NewContext(context.Background(), net.ParseIP("10.0.0.1"))))
+}
@@ -10,29 +10,37 @@ import (
 	"github.com/goharbor/harbor/src/common/job/models"
 	cModels "github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/core/utils"
+	jjob "github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/notification/job"
 	job_model "github.com/goharbor/harbor/src/pkg/notification/job/model"
+	"github.com/goharbor/harbor/src/pkg/notification/policy"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
 	"github.com/goharbor/harbor/src/pkg/notifier/model"
 )
 
 // Manager send hook
 type Manager interface {
 	StartHook(context.Context, *model.HookEvent, *models.JobData) error
+	// Redeliver submits the notification job specified by ID again as a new job
+	Redeliver(ctx context.Context, jobID int64) error
 }
 
 // DefaultManager ...
 type DefaultManager struct {
-	jobMgr job.Manager
-	client cJob.Client
+	jobMgr    job.Manager
+	policyMgr policy.Manager
+	client    cJob.Client
 }
 
 // NewHookManager ...
 func NewHookManager() *DefaultManager {
 	return &DefaultManager{
-		jobMgr: job.NewManager(),
-		client: utils.GetJobServiceClient(),
+		jobMgr:    job.NewManager(),
+		policyMgr: policy.Mgr,
+		client:    utils.GetJobServiceClient(),
 	}
 }
 
@@ -42,16 +50,28 @@ func (hm *DefaultManager) StartHook(ctx context.Context, event *model.HookEvent,
 	if err != nil {
 		return err
 	}
+	// the secret isn't recorded with the job, it's got from the policy again when redelivering
+	parameters := map[string]interface{}{}
+	for k, v := range data.Parameters {
+		if k != "secret" {
+			parameters[k] = v
+		}
+	}
+	params, err := json.Marshal(parameters)
+	if err != nil {
+		return err
+	}
 
 	t := time.Now()
 	id, err := hm.jobMgr.Create(ctx, &job_model.Job{
-		PolicyID:     event.PolicyID,
-		EventType:    event.EventType,
-		NotifyType:   event.Target.Type,
-		Status:       cModels.JobPending,
-		CreationTime: t,
-		UpdateTime:   t,
-		JobDetail:    string(payload),
+		PolicyID:      event.PolicyID,
+		EventType:     event.EventType,
+		NotifyType:    event.Target.Type,
+		Status:        cModels.JobPending,
+		CreationTime:  t,
+		UpdateTime:    t,
+		JobDetail:     string(payload),
+		JobParameters: string(params),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create the job record for notification based on policy %d: %v", event.PolicyID, err)
@@ -84,3 +104,55 @@ func (hm *DefaultManager) StartHook(ctx context.Context, event *model.HookEvent,
 	}
 	return nil
 }
+
+// Redeliver submits the notification job again with the same payload and parameters
+func (hm *DefaultManager) Redeliver(ctx context.Context, jobID int64) error {
+	j, err := hm.jobMgr.Get(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	// the jobs created before the parameters are recorded cannot be redelivered
+	if len(j.JobParameters) == 0 {
+		return errors.BadRequestError(nil).WithMessage("the notification job %d cannot be redelivered", jobID)
+	}
+	params := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(j.JobParameters), &params); err != nil {
+		return err
+	}
+	payload := &model.Payload{}
+	if err := json.Unmarshal([]byte(j.JobDetail), payload); err != nil {
+		return err
+	}
+
+	name := jjob.WebhookJob
+	switch j.NotifyType {
+	case model.NotifyTypeSlack:
+		name = jjob.SlackJob
+	case model.NotifyTypeNATS:
+		name = jjob.NATSJob
+	case model.NotifyTypeEmail:
+		name = jjob.EmailJob
+	case model.NotifyTypeKafka:
+		// the records are sent to the Kafka REST proxy by the webhook job without signing
+	default:
+		ply, err := hm.policyMgr.Get(ctx, j.PolicyID)
+		if err != nil {
+			return err
+		}
+		if len(ply.Secret) > 0 {
+			params["secret"] = ply.Secret
+		}
+	}
+	return hm.StartHook(ctx, &model.HookEvent{
+		PolicyID:  j.PolicyID,
+		EventType: j.EventType,
+		Target:    &policy_model.EventTarget{Type: j.NotifyType},
+		Payload:   payload,
+	}, &models.JobData{
+		Name:       name,
+		Parameters: params,
+		Metadata: &models.JobMetadata{
+			JobKind: jjob.KindGeneric,
+		},
+	})
+}
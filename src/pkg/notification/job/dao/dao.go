@@ -37,6 +37,27 @@ type DAO interface {
 
 	// DeleteByPolicyID
 	DeleteByPolicyID(ctx context.Context, policyID int64) error
+
+	// CreateAttempt records the delivery attempt of the job
+	CreateAttempt(ctx context.Context, attempt *model.Attempt) (int64, error)
+
+	// CountAttempts ...
+	CountAttempts(ctx context.Context, query *q.Query) (total int64, err error)
+
+	// ListAttempts ...
+	ListAttempts(ctx context.Context, query *q.Query) ([]*model.Attempt, error)
+
+	// CreateDeadLetter parks the permanently failing job
+	CreateDeadLetter(ctx context.Context, letter *model.DeadLetter) (int64, error)
+
+	// CountDeadLetters ...
+	CountDeadLetters(ctx context.Context, query *q.Query) (total int64, err error)
+
+	// ListDeadLetters ...
+	ListDeadLetters(ctx context.Context, query *q.Query) ([]*model.DeadLetter, error)
+
+	// DeleteDeadLetter ...
+	DeleteDeadLetter(ctx context.Context, id int64) error
 }
 
 // New creates a default implementation for Dao
@@ -60,7 +81,7 @@ func (d *dao) Update(ctx context.Context, job *model.Job, props ...string) error
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Update(job, props...)
+	n, err := ormer.UpdateWithCtx(ctx, job, props...)
 	if n == 0 {
 		return errors.NotFoundError(nil).WithMessage("notification %d not found", job.ID)
 	}
@@ -82,7 +103,7 @@ func (d *dao) Create(ctx context.Context, job *model.Job) (int64, error) {
 	if len(job.Status) == 0 {
 		job.Status = models.JobPending
 	}
-	return ormer.Insert(job)
+	return ormer.InsertWithCtx(ctx, job)
 }
 
 // Get ...
@@ -94,7 +115,7 @@ func (d *dao) Get(ctx context.Context, id int64) (*model.Job, error) {
 	j := &model.Job{
 		ID: id,
 	}
-	if err := ormer.Read(j); err != nil {
+	if err := ormer.ReadWithCtx(ctx, j); err != nil {
 		if e := orm.AsNotFoundError(err, "notificationJob %d not found", id); e != nil {
 			err = e
 		}
@@ -109,7 +130,7 @@ func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 
 // List ...
@@ -120,7 +141,7 @@ func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.Job, error) {
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&jobs); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &jobs); err != nil {
 		return nil, err
 	}
 	return jobs, nil
@@ -153,7 +174,7 @@ func (d *dao) Delete(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&model.Job{
+	n, err := ormer.DeleteWithCtx(ctx, &model.Job{
 		ID: id,
 	})
 	if err != nil {
@@ -175,7 +196,7 @@ func (d *dao) DeleteByPolicyID(ctx context.Context, policyID int64) error {
 	if err != nil {
 		return err
 	}
-	n, err := qs.Delete()
+	n, err := qs.DeleteWithCtx(ctx)
 	if err != nil {
 		return err
 	}
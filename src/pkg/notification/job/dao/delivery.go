@@ -0,0 +1,103 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/notification/job/model"
+)
+
+// CreateAttempt ...
+func (d *dao) CreateAttempt(ctx context.Context, attempt *model.Attempt) (int64, error) {
+	if attempt == nil {
+		return 0, errors.New("nil attempt")
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return ormer.InsertWithCtx(ctx, attempt)
+}
+
+// CountAttempts ...
+func (d *dao) CountAttempts(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.Attempt{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.CountWithCtx(ctx)
+}
+
+// ListAttempts ...
+func (d *dao) ListAttempts(ctx context.Context, query *q.Query) ([]*model.Attempt, error) {
+	attempts := []*model.Attempt{}
+	qs, err := orm.QuerySetter(ctx, &model.Attempt{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.AllWithCtx(ctx, &attempts); err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+// CreateDeadLetter ...
+func (d *dao) CreateDeadLetter(ctx context.Context, letter *model.DeadLetter) (int64, error) {
+	if letter == nil {
+		return 0, errors.New("nil dead letter")
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	id, err := ormer.InsertWithCtx(ctx, letter)
+	if err != nil {
+		if e := orm.AsConflictError(err, "the notification job %d is already in the dead letters", letter.JobID); e != nil {
+			err = e
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+// CountDeadLetters ...
+func (d *dao) CountDeadLetters(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.DeadLetter{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.CountWithCtx(ctx)
+}
+
+// ListDeadLetters ...
+func (d *dao) ListDeadLetters(ctx context.Context, query *q.Query) ([]*model.DeadLetter, error) {
+	letters := []*model.DeadLetter{}
+	qs, err := orm.QuerySetter(ctx, &model.DeadLetter{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.AllWithCtx(ctx, &letters); err != nil {
+		return nil, err
+	}
+	return letters, nil
+}
+
+// DeleteDeadLetter ...
+func (d *dao) DeleteDeadLetter(ctx context.Context, id int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.DeleteWithCtx(ctx, &model.DeadLetter{
+		ID: id,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("dead letter %d not found", id)
+	}
+	return nil
+}
@@ -29,6 +29,30 @@ type Manager interface {
 
 	// Count ...
 	Count(ctx context.Context, query *q.Query) (total int64, err error)
+
+	// Get the notification job specified by ID
+	Get(ctx context.Context, id int64) (*model.Job, error)
+
+	// CreateAttempt records the delivery attempt of the notification job
+	CreateAttempt(ctx context.Context, attempt *model.Attempt) (int64, error)
+
+	// CountAttempts ...
+	CountAttempts(ctx context.Context, query *q.Query) (total int64, err error)
+
+	// ListAttempts lists the delivery attempts of the notification jobs
+	ListAttempts(ctx context.Context, query *q.Query) ([]*model.Attempt, error)
+
+	// CreateDeadLetter parks the notification job which still fails after all the attempts
+	CreateDeadLetter(ctx context.Context, letter *model.DeadLetter) (int64, error)
+
+	// CountDeadLetters ...
+	CountDeadLetters(ctx context.Context, query *q.Query) (total int64, err error)
+
+	// ListDeadLetters ...
+	ListDeadLetters(ctx context.Context, query *q.Query) ([]*model.DeadLetter, error)
+
+	// DeleteDeadLetter ...
+	DeleteDeadLetter(ctx context.Context, id int64) error
 }
 
 var _ Manager = &manager{}
@@ -68,3 +92,43 @@ func (d *manager) Update(ctx context.Context, job *model.Job, props ...string) e
 func (d *manager) ListJobsGroupByEventType(ctx context.Context, policyID int64) ([]*model.Job, error) {
 	return d.dao.GetLastTriggerJobsGroupByEventType(ctx, policyID)
 }
+
+// Get ...
+func (d *manager) Get(ctx context.Context, id int64) (*model.Job, error) {
+	return d.dao.Get(ctx, id)
+}
+
+// CreateAttempt ...
+func (d *manager) CreateAttempt(ctx context.Context, attempt *model.Attempt) (int64, error) {
+	return d.dao.CreateAttempt(ctx, attempt)
+}
+
+// CountAttempts ...
+func (d *manager) CountAttempts(ctx context.Context, query *q.Query) (int64, error) {
+	return d.dao.CountAttempts(ctx, query)
+}
+
+// ListAttempts ...
+func (d *manager) ListAttempts(ctx context.Context, query *q.Query) ([]*model.Attempt, error) {
+	return d.dao.ListAttempts(ctx, query)
+}
+
+// CreateDeadLetter ...
+func (d *manager) CreateDeadLetter(ctx context.Context, letter *model.DeadLetter) (int64, error) {
+	return d.dao.CreateDeadLetter(ctx, letter)
+}
+
+// CountDeadLetters ...
+func (d *manager) CountDeadLetters(ctx context.Context, query *q.Query) (int64, error) {
+	return d.dao.CountDeadLetters(ctx, query)
+}
+
+// ListDeadLetters ...
+func (d *manager) ListDeadLetters(ctx context.Context, query *q.Query) ([]*model.DeadLetter, error) {
+	return d.dao.ListDeadLetters(ctx, query)
+}
+
+// DeleteDeadLetter ...
+func (d *manager) DeleteDeadLetter(ctx context.Context, id int64) error {
+	return d.dao.DeleteDeadLetter(ctx, id)
+}
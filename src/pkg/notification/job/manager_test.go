@@ -46,6 +46,42 @@ func (m *managerTestSuite) TestCount() {
 	m.dao.AssertExpectations(m.T())
 }
 
+func (m *managerTestSuite) TestCreateAttempt() {
+	m.dao.On("CreateAttempt", mock.Anything, mock.Anything).Return(int64(1), nil)
+	id, err := m.mgr.CreateAttempt(context.Background(), &model.Attempt{JobID: 1})
+	m.Nil(err)
+	m.Equal(int64(1), id)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestListAttempts() {
+	m.dao.On("ListAttempts", mock.Anything, mock.Anything).Return([]*model.Attempt{
+		{
+			ID:         1,
+			JobID:      1,
+			StatusCode: 500,
+		},
+	}, nil)
+	attempts, err := m.mgr.ListAttempts(context.Background(), nil)
+	m.Nil(err)
+	m.Equal(1, len(attempts))
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestDeadLetters() {
+	m.dao.On("CreateDeadLetter", mock.Anything, mock.Anything).Return(int64(1), nil)
+	m.dao.On("CountDeadLetters", mock.Anything, mock.Anything).Return(int64(1), nil)
+	m.dao.On("DeleteDeadLetter", mock.Anything, int64(1)).Return(nil)
+	id, err := m.mgr.CreateDeadLetter(context.Background(), &model.DeadLetter{JobID: 1})
+	m.Nil(err)
+	m.Equal(int64(1), id)
+	n, err := m.mgr.CountDeadLetters(context.Background(), nil)
+	m.Nil(err)
+	m.Equal(int64(1), n)
+	m.Nil(m.mgr.DeleteDeadLetter(context.Background(), 1))
+	m.dao.AssertExpectations(m.T())
+}
+
 func (m *managerTestSuite) TestList() {
 	m.dao.On("List", mock.Anything, mock.Anything).Return([]*model.Job{
 		{
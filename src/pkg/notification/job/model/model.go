@@ -7,23 +7,61 @@ import (
 )
 
 func init() {
-	orm.RegisterModel(&Job{})
+	orm.RegisterModel(&Job{}, &Attempt{}, &DeadLetter{})
 }
 
 // Job is the model for a notification job
 type Job struct {
+	ID         int64  `orm:"pk;auto;column(id)" json:"id"`
+	PolicyID   int64  `orm:"column(policy_id)" json:"policy_id"`
+	EventType  string `orm:"column(event_type)" json:"event_type"`
+	NotifyType string `orm:"column(notify_type)" json:"notify_type"`
+	Status     string `orm:"column(status)" json:"status"`
+	JobDetail  string `orm:"column(job_detail)" json:"job_detail"`
+	// JobParameters are the parameters of the job submitted to the jobservice, which are used to redeliver the job
+	JobParameters string    `orm:"column(job_parameters)" json:"-"`
+	UUID          string    `orm:"column(job_uuid)" json:"-"`
+	CreationTime  time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+	UpdateTime    time.Time `orm:"column(update_time);auto_now" json:"update_time" sort:"default:desc"`
+}
+
+// TableName set table name for ORM.
+func (j *Job) TableName() string {
+	return "notification_job"
+}
+
+// Attempt is the result of one delivery attempt of the notification job
+type Attempt struct {
+	ID         int64 `orm:"pk;auto;column(id)" json:"id"`
+	JobID      int64 `orm:"column(job_id)" json:"job_id"`
+	Success    bool  `orm:"column(success)" json:"success"`
+	StatusCode int   `orm:"column(status_code)" json:"status_code"`
+	// Latency of the delivery in milliseconds
+	Latency  int64  `orm:"column(latency)" json:"latency"`
+	Response string `orm:"column(response)" json:"response"`
+	Error    string `orm:"column(error)" json:"error"`
+	// MaxFails is how many times the job can fail, it's reported by the job and isn't stored
+	MaxFails     int       `orm:"-" json:"max_fails"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time" sort:"default:desc"`
+}
+
+// TableName set table name for ORM.
+func (a *Attempt) TableName() string {
+	return "notification_job_attempt"
+}
+
+// DeadLetter is the notification job which still fails after all the attempts
+type DeadLetter struct {
 	ID           int64     `orm:"pk;auto;column(id)" json:"id"`
+	JobID        int64     `orm:"column(job_id)" json:"job_id"`
 	PolicyID     int64     `orm:"column(policy_id)" json:"policy_id"`
 	EventType    string    `orm:"column(event_type)" json:"event_type"`
 	NotifyType   string    `orm:"column(notify_type)" json:"notify_type"`
-	Status       string    `orm:"column(status)" json:"status"`
-	JobDetail    string    `orm:"column(job_detail)" json:"job_detail"`
-	UUID         string    `orm:"column(job_uuid)" json:"-"`
-	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
-	UpdateTime   time.Time `orm:"column(update_time);auto_now" json:"update_time" sort:"default:desc"`
+	Reason       string    `orm:"column(reason)" json:"reason"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time" sort:"default:desc"`
 }
 
 // TableName set table name for ORM.
-func (j *Job) TableName() string {
-	return "notification_job"
+func (d *DeadLetter) TableName() string {
+	return "notification_dead_letter"
 }
@@ -26,8 +26,15 @@ var (
 	// SupportedEventTypes is a map to store supported event type, eg. pushImage, pullImage etc
 	SupportedEventTypes map[string]struct{}
 
+	// SupportedSystemEventTypes is a map to store the event types supported by the system level policies,
+	// which include the project event types and the system event types, eg. createUser, createProject etc
+	SupportedSystemEventTypes map[string]struct{}
+
 	// SupportedNotifyTypes is a map to store notification type, eg. HTTP, Email etc
 	SupportedNotifyTypes map[string]struct{}
+
+	// SupportedPayloadFormats is a map to store the payload formats of the http notification, eg. Slack, Teams etc
+	SupportedPayloadFormats map[string]struct{}
 )
 
 // Init ...
@@ -46,7 +53,9 @@ func Init() {
 
 func initSupportedNotifyType() {
 	SupportedEventTypes = make(map[string]struct{}, 0)
+	SupportedSystemEventTypes = make(map[string]struct{}, 0)
 	SupportedNotifyTypes = make(map[string]struct{}, 0)
+	SupportedPayloadFormats = make(map[string]struct{}, 0)
 
 	eventTypes := []string{
 		event.TopicPushArtifact,
@@ -65,12 +74,30 @@ func initSupportedNotifyType() {
 	}
 	for _, eventType := range eventTypes {
 		SupportedEventTypes[eventType] = struct{}{}
+		SupportedSystemEventTypes[eventType] = struct{}{}
 	}
 
-	notifyTypes := []string{notifier_model.NotifyTypeHTTP, notifier_model.NotifyTypeSlack}
+	systemEventTypes := []string{
+		event.TopicCreateUser,
+		event.TopicCreateProject,
+		event.TopicGarbageCollection,
+		event.TopicBackup,
+	}
+	for _, eventType := range systemEventTypes {
+		SupportedSystemEventTypes[eventType] = struct{}{}
+	}
+
+	notifyTypes := []string{notifier_model.NotifyTypeHTTP, notifier_model.NotifyTypeSlack,
+		notifier_model.NotifyTypeKafka, notifier_model.NotifyTypeNATS, notifier_model.NotifyTypeEmail}
 	for _, notifyType := range notifyTypes {
 		SupportedNotifyTypes[notifyType] = struct{}{}
 	}
+
+	payloadFormats := []string{notifier_model.PayloadFormatGenericJSON, notifier_model.PayloadFormatSlack,
+		notifier_model.PayloadFormatTeams, notifier_model.PayloadFormatCloudEvents}
+	for _, payloadFormat := range payloadFormats {
+		SupportedPayloadFormats[payloadFormat] = struct{}{}
+	}
 }
 
 type eventKey struct{}
@@ -47,7 +47,7 @@ func (d *dao) Get(ctx context.Context, id int64) (*model.Policy, error) {
 	j := &model.Policy{
 		ID: id,
 	}
-	if err := ormer.Read(j); err != nil {
+	if err := ormer.ReadWithCtx(ctx, j); err != nil {
 		if e := orm.AsNotFoundError(err, "notificationPolicy %d not found", id); e != nil {
 			err = e
 		}
@@ -65,7 +65,7 @@ func (d *dao) Create(ctx context.Context, policy *model.Policy) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	id, err := ormer.Insert(policy)
+	id, err := ormer.InsertWithCtx(ctx, policy)
 	if err != nil {
 		if e := orm.AsConflictError(err, "notification policy named %s already exists", policy.Name); e != nil {
 			err = e
@@ -87,7 +87,7 @@ func (d *dao) Update(ctx context.Context, policy *model.Policy) error {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Update(policy)
+	n, err := ormer.UpdateWithCtx(ctx, policy)
 	if n == 0 {
 		if e := orm.AsConflictError(err, "notification policy named %s already exists", policy.Name); e != nil {
 			err = e
@@ -106,7 +106,7 @@ func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 
 // List ...
@@ -117,7 +117,7 @@ func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.Policy, error)
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&policies); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &policies); err != nil {
 		return nil, err
 	}
 	return policies, nil
@@ -129,7 +129,7 @@ func (d *dao) Delete(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&model.Policy{
+	n, err := ormer.DeleteWithCtx(ctx, &model.Policy{
 		ID: id,
 	})
 	if err != nil {
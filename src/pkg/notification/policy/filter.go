@@ -0,0 +1,125 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"regexp"
+
+	"github.com/bmatcuk/doublestar"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+)
+
+// FilterSubject contains the attributes of the event which are evaluated by the filters of the policy,
+// the empty attribute means the event doesn't carry it
+type FilterSubject struct {
+	Repository string
+	// Tags of the artifact, nil if the event isn't about an artifact
+	Tags []string
+	// Labels returns the IDs of the labels attached to the artifact, it's only called when the labels are filtered
+	Labels   func() ([]int64, error)
+	Severity string
+}
+
+// ValidateFilters checks whether the filters are valid
+func ValidateFilters(filters *model.EventFilters) error {
+	if filters == nil {
+		return nil
+	}
+	for _, pattern := range filters.Repositories {
+		// the pattern is only parsed as far as the name is matched, so match the pattern itself to parse it wholly
+		if _, err := doublestar.Match(pattern, pattern); err != nil {
+			return errors.BadRequestError(nil).WithMessage("invalid repository pattern %s: %v", pattern, err)
+		}
+	}
+	if len(filters.TagPattern) > 0 {
+		if _, err := regexp.Compile(filters.TagPattern); err != nil {
+			return errors.BadRequestError(nil).WithMessage("invalid tag pattern %s: %v", filters.TagPattern, err)
+		}
+	}
+	if len(filters.MinSeverity) > 0 {
+		switch vuln.Severity(filters.MinSeverity) {
+		case vuln.None, vuln.Unknown, vuln.Negligible, vuln.Low, vuln.Medium, vuln.High, vuln.Critical:
+		default:
+			return errors.BadRequestError(nil).WithMessage("invalid min severity %s", filters.MinSeverity)
+		}
+	}
+	return nil
+}
+
+// Match returns true if the subject matches all the filters
+func Match(filters *model.EventFilters, subject *FilterSubject) (bool, error) {
+	if filters == nil {
+		return true, nil
+	}
+
+	if len(filters.Repositories) > 0 && len(subject.Repository) > 0 {
+		matched := false
+		for _, pattern := range filters.Repositories {
+			ok, err := doublestar.Match(pattern, subject.Repository)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+
+	if len(filters.TagPattern) > 0 && subject.Tags != nil {
+		re, err := regexp.Compile(filters.TagPattern)
+		if err != nil {
+			return false, err
+		}
+		matched := false
+		for _, tag := range subject.Tags {
+			if re.MatchString(tag) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+
+	if len(filters.MinSeverity) > 0 && len(subject.Severity) > 0 &&
+		vuln.Severity(subject.Severity).Code() < vuln.Severity(filters.MinSeverity).Code() {
+		return false, nil
+	}
+
+	if len(filters.LabelIDs) > 0 && subject.Labels != nil {
+		labels, err := subject.Labels()
+		if err != nil {
+			return false, err
+		}
+		for _, id := range labels {
+			for _, expected := range filters.LabelIDs {
+				if id == expected {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}
+
+	return true, nil
+}
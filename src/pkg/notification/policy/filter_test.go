@@ -0,0 +1,64 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/pkg/notification/policy/model"
+)
+
+func TestValidateFilters(t *testing.T) {
+	assert.Nil(t, ValidateFilters(nil))
+	assert.Nil(t, ValidateFilters(&model.EventFilters{
+		Repositories: []string{"library/**"},
+		TagPattern:   `^v\d+`,
+		MinSeverity:  "High",
+	}))
+	assert.NotNil(t, ValidateFilters(&model.EventFilters{Repositories: []string{"library/[a"}}))
+	assert.NotNil(t, ValidateFilters(&model.EventFilters{TagPattern: "(v"}))
+	assert.NotNil(t, ValidateFilters(&model.EventFilters{MinSeverity: "Severe"}))
+}
+
+func TestMatch(t *testing.T) {
+	labels := func() ([]int64, error) { return []int64{1, 2}, nil }
+	cases := []struct {
+		name    string
+		filters *model.EventFilters
+		subject *FilterSubject
+		matched bool
+	}{
+		{"no filters", nil, &FilterSubject{Repository: "library/nginx"}, true},
+		{"repository matched", &model.EventFilters{Repositories: []string{"dev/*", "library/**"}}, &FilterSubject{Repository: "library/base/nginx"}, true},
+		{"repository not matched", &model.EventFilters{Repositories: []string{"dev/*"}}, &FilterSubject{Repository: "library/nginx"}, false},
+		{"repository skipped", &model.EventFilters{Repositories: []string{"dev/*"}}, &FilterSubject{}, true},
+		{"tag matched", &model.EventFilters{TagPattern: `^v\d+`}, &FilterSubject{Tags: []string{"latest", "v1.0"}}, true},
+		{"tag not matched", &model.EventFilters{TagPattern: `^v\d+`}, &FilterSubject{Tags: []string{}}, false},
+		{"severity matched", &model.EventFilters{MinSeverity: "High"}, &FilterSubject{Severity: "Critical"}, true},
+		{"severity not matched", &model.EventFilters{MinSeverity: "High"}, &FilterSubject{Severity: "Low"}, false},
+		{"label matched", &model.EventFilters{LabelIDs: []int64{2, 3}}, &FilterSubject{Labels: labels}, true},
+		{"label not matched", &model.EventFilters{LabelIDs: []int64{3}}, &FilterSubject{Labels: labels}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			matched, err := Match(c.filters, c.subject)
+			require.Nil(t, err)
+			assert.Equal(t, c.matched, matched)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/pkg/notification/policy/model"
+)
+
+// the expired records are cleaned up when the count of the records exceeds the value
+const maxThrottleRecords = 10000
+
+var (
+	// PullEventLimiter is a global limiter of the pull artifact events
+	PullEventLimiter = NewPullEventLimiter()
+)
+
+// Limiter decides whether the pull artifact event of the repository is sent to the policy according to
+// the pull event options of the policy, the throttling state is kept in the memory of each instance
+type Limiter interface {
+	// Allow returns true if the pull artifact event of the repository can be sent to the policy
+	Allow(policy *model.Policy, repository string) bool
+}
+
+// NewPullEventLimiter returns an instance of the pull event limiter
+func NewPullEventLimiter() Limiter {
+	return &limiter{
+		suppressUntil: map[string]time.Time{},
+		now:           time.Now,
+		random:        rand.Float64, // #nosec G404
+	}
+}
+
+type limiter struct {
+	lock sync.Mutex
+	// the time until which the following events of the policy and repository are suppressed
+	suppressUntil map[string]time.Time
+	now           func() time.Time
+	random        func() float64
+}
+
+func (l *limiter) Allow(policy *model.Policy, repository string) bool {
+	options := policy.PullEventOptions
+	if options == nil {
+		return true
+	}
+	if options.SampleRate > 0 && options.SampleRate < 1 && l.random() >= options.SampleRate {
+		return false
+	}
+	if options.ThrottleInterval <= 0 {
+		return true
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	key := fmt.Sprintf("%d:%s", policy.ID, repository)
+	if until, exist := l.suppressUntil[key]; exist && now.Before(until) {
+		return false
+	}
+	if len(l.suppressUntil) >= maxThrottleRecords {
+		for k, until := range l.suppressUntil {
+			if !now.Before(until) {
+				delete(l.suppressUntil, k)
+			}
+		}
+	}
+	l.suppressUntil[key] = now.Add(time.Duration(options.ThrottleInterval) * time.Second)
+	return true
+}
@@ -0,0 +1,49 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/pkg/notification/policy/model"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	now := time.Now()
+	l := &limiter{
+		suppressUntil: map[string]time.Time{},
+		now:           func() time.Time { return now },
+		random:        func() float64 { return 0.3 },
+	}
+
+	// no options
+	assert.True(t, l.Allow(&model.Policy{ID: 1}, "library/nginx"))
+	assert.True(t, l.Allow(&model.Policy{ID: 1}, "library/nginx"))
+
+	// throttling per repository
+	throttled := &model.Policy{ID: 2, PullEventOptions: &model.PullEventOptions{ThrottleInterval: 60}}
+	assert.True(t, l.Allow(throttled, "library/nginx"))
+	assert.False(t, l.Allow(throttled, "library/nginx"))
+	assert.True(t, l.Allow(throttled, "library/redis"))
+	now = now.Add(61 * time.Second)
+	assert.True(t, l.Allow(throttled, "library/nginx"))
+
+	// sampling
+	assert.True(t, l.Allow(&model.Policy{ID: 3, PullEventOptions: &model.PullEventOptions{SampleRate: 0.5}}, "library/nginx"))
+	assert.False(t, l.Allow(&model.Policy{ID: 3, PullEventOptions: &model.PullEventOptions{SampleRate: 0.2}}, "library/nginx"))
+}
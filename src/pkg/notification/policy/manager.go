@@ -3,12 +3,19 @@ package policy
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	commonhttp "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/common/utils/email"
+	"github.com/goharbor/harbor/src/lib/config"
+	libencrypt "github.com/goharbor/harbor/src/lib/encrypt"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/notification/policy/dao"
 	"github.com/goharbor/harbor/src/pkg/notification/policy/model"
@@ -38,7 +45,7 @@ type Manager interface {
 	Delete(ctx context.Context, policyID int64) error
 	// Test the specified policy
 	Test(policy *model.Policy) error
-	// GetRelatedPolices get event type related policies in project
+	// GetRelatedPolices get event type related policies in project, including the system level policies
 	GetRelatedPolices(ctx context.Context, projectID int64, eventType string) ([]*model.Policy, error)
 }
 
@@ -61,11 +68,11 @@ func (m *manager) Create(ctx context.Context, policy *model.Policy) (int64, erro
 	policy.CreationTime = t
 	policy.UpdateTime = t
 
-	err := policy.ConvertToDBModel()
+	p, err := toDBModel(policy)
 	if err != nil {
 		return 0, err
 	}
-	return m.dao.Create(ctx, policy)
+	return m.dao.Create(ctx, p)
 }
 
 // List the notification policies, returns the policy list and error
@@ -77,8 +84,7 @@ func (m *manager) List(ctx context.Context, query *q.Query) ([]*model.Policy, er
 	}
 
 	for _, policy := range persisPolicies {
-		err := policy.ConvertFromDBModel()
-		if err != nil {
+		if err := fromDBModel(policy); err != nil {
 			return nil, err
 		}
 		policies = append(policies, policy)
@@ -101,10 +107,10 @@ func (m *manager) Get(ctx context.Context, id int64) (*model.Policy, error) {
 	if policy == nil {
 		return nil, nil
 	}
-	if err := policy.ConvertFromDBModel(); err != nil {
+	if err := fromDBModel(policy); err != nil {
 		return nil, err
 	}
-	return policy, err
+	return policy, nil
 }
 
 // GetByNameAndProjectID notification policy by the name and projectID
@@ -118,20 +124,20 @@ func (m *manager) GetByNameAndProjectID(ctx context.Context, name string, projec
 		return nil, errors.New(nil).WithCode(errors.NotFoundCode).WithMessage("no notification policy found")
 	}
 	policy := policies[0]
-	if err := policy.ConvertFromDBModel(); err != nil {
+	if err := fromDBModel(policy); err != nil {
 		return nil, err
 	}
-	return policy, err
+	return policy, nil
 }
 
 // Update the specified notification policy
 func (m *manager) Update(ctx context.Context, policy *model.Policy) error {
 	policy.UpdateTime = time.Now()
-	err := policy.ConvertToDBModel()
+	p, err := toDBModel(policy)
 	if err != nil {
 		return err
 	}
-	return m.dao.Update(ctx, policy)
+	return m.dao.Update(ctx, p)
 }
 
 // Delete the specified notification policy
@@ -143,8 +149,12 @@ func (m *manager) Delete(ctx context.Context, policyID int64) error {
 func (m *manager) Test(policy *model.Policy) error {
 	for _, target := range policy.Targets {
 		switch target.Type {
-		case notifier_model.NotifyTypeHTTP, notifier_model.NotifyTypeSlack:
+		case notifier_model.NotifyTypeHTTP, notifier_model.NotifyTypeSlack, notifier_model.NotifyTypeKafka:
 			return m.policyHTTPTest(target.Address, target.SkipCertVerify)
+		case notifier_model.NotifyTypeNATS:
+			return m.policyNATSTest(target.Address)
+		case notifier_model.NotifyTypeEmail:
+			return m.policyEmailTest()
 		default:
 			return fmt.Errorf("invalid policy target type: %s", target.Type)
 		}
@@ -173,9 +183,50 @@ func (m *manager) policyHTTPTest(address string, skipCertVerify bool) error {
 	return nil
 }
 
-// GetRelatedPolices get policies including event type in project
+// policyNATSTest only checks whether the NATS server is reachable
+func (m *manager) policyNATSTest(address string) error {
+	u, err := url.Parse(address)
+	if err != nil {
+		return err
+	}
+	host := u.Host
+	if len(u.Port()) == 0 {
+		host = net.JoinHostPort(u.Hostname(), "4222")
+	}
+	conn, err := net.DialTimeout("tcp", host, 10*time.Second)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	log.Debugf("policy test success with address %s", address)
+
+	return nil
+}
+
+// policyEmailTest checks whether the SMTP server in the system settings is reachable and
+// accepts the credentials, the recipients are not verified
+func (m *manager) policyEmailTest() error {
+	setting := config.Email(orm.Context())
+	if len(setting.Host) == 0 {
+		return errors.New("the SMTP server for the notification emails isn't configured")
+	}
+	addr := net.JoinHostPort(setting.Host, strconv.Itoa(setting.Port))
+	if err := email.Ping(addr, setting.Identity, setting.Username, setting.Password, 10, setting.SSL, setting.Insecure); err != nil {
+		return err
+	}
+	log.Debugf("policy test success with SMTP server %s", addr)
+
+	return nil
+}
+
+// GetRelatedPolices get policies including event type in project, the system level policies are included as well
+// as they receive the events of all projects
 func (m *manager) GetRelatedPolices(ctx context.Context, projectID int64, eventType string) ([]*model.Policy, error) {
-	policies, err := m.List(ctx, q.New(q.KeyWords{"project_id": projectID}))
+	var projectIDs interface{} = model.SystemLevelProjectID
+	if projectID != model.SystemLevelProjectID {
+		projectIDs = &q.OrList{Values: []interface{}{projectID, model.SystemLevelProjectID}}
+	}
+	policies, err := m.List(ctx, q.New(q.KeyWords{"project_id": projectIDs}))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notification policies with projectID %d: %v", projectID, err)
 	}
@@ -196,3 +247,62 @@ func (m *manager) GetRelatedPolices(ctx context.Context, projectID int64, eventT
 
 	return result, nil
 }
+
+// toDBModel returns a copy of the policy to be stored, the secret and the auth headers of the targets are encrypted
+func toDBModel(policy *model.Policy) (*model.Policy, error) {
+	p := *policy
+	var err error
+	if p.Secret, err = encrypt(policy.Secret); err != nil {
+		return nil, err
+	}
+	if len(policy.Targets) > 0 {
+		p.Targets = make([]model.EventTarget, 0, len(policy.Targets))
+		for _, target := range policy.Targets {
+			if target.AuthHeader, err = encrypt(target.AuthHeader); err != nil {
+				return nil, err
+			}
+			p.Targets = append(p.Targets, target)
+		}
+	}
+	if err = p.ConvertToDBModel(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// fromDBModel converts the policy read from the database and decrypts the secret and the auth headers of the targets
+func fromDBModel(policy *model.Policy) error {
+	if err := policy.ConvertFromDBModel(); err != nil {
+		return err
+	}
+	var err error
+	if policy.Secret, err = decrypt(policy.Secret); err != nil {
+		return err
+	}
+	for i := range policy.Targets {
+		// the auth headers stored before they were encrypted are kept as they are
+		if len(libencrypt.StoreOf(policy.Targets[i].AuthHeader)) == 0 {
+			continue
+		}
+		if policy.Targets[i].AuthHeader, err = decrypt(policy.Targets[i].AuthHeader); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encrypt the secret of the policy before storing it
+func encrypt(secret string) (string, error) {
+	if len(secret) == 0 {
+		return secret, nil
+	}
+	return libencrypt.Instance().Encrypt(secret)
+}
+
+// decrypt the secret of the policy read from the database
+func decrypt(secret string) (string, error) {
+	if len(secret) == 0 {
+		return secret, nil
+	}
+	return libencrypt.Instance().Decrypt(secret)
+}
@@ -2,11 +2,15 @@ package policy
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/notification/policy/model"
 	"github.com/goharbor/harbor/src/testing/mock"
 	"github.com/goharbor/harbor/src/testing/pkg/notification/policy/dao"
@@ -18,6 +22,12 @@ type managerTestSuite struct {
 	dao *dao.DAO
 }
 
+func (m *managerTestSuite) SetupSuite() {
+	keyPath := filepath.Join(m.T().TempDir(), "key")
+	m.Require().Nil(os.WriteFile(keyPath, []byte("9TXCcHgNAAp1aSHh"), 0600))
+	m.T().Setenv("KEY_PATH", keyPath)
+}
+
 func (m *managerTestSuite) SetupTest() {
 	m.dao = &dao.DAO{}
 	m.mgr = &manager{
@@ -32,6 +42,31 @@ func (m *managerTestSuite) TestCreate() {
 	m.dao.AssertExpectations(m.T())
 }
 
+func (m *managerTestSuite) TestCreateWithAuthHeader() {
+	var stored *model.Policy
+	m.dao.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*model.Policy)
+	}).Return(int64(1), nil)
+	policy := &model.Policy{
+		Secret:  "secret",
+		Targets: []model.EventTarget{{Type: "http", Address: "http://127.0.0.1", AuthHeader: "Bearer token"}},
+	}
+	_, err := m.mgr.Create(context.Background(), policy)
+	m.Require().Nil(err)
+	m.Equal("Bearer token", policy.Targets[0].AuthHeader)
+	m.NotEqual("secret", stored.Secret)
+	m.False(strings.Contains(stored.TargetsDB, "Bearer token"))
+
+	m.Require().Nil(fromDBModel(stored))
+	m.Equal("secret", stored.Secret)
+	m.Equal("Bearer token", stored.Targets[0].AuthHeader)
+
+	// the auth headers stored in plain text are kept as they are
+	legacy := &model.Policy{TargetsDB: `[{"type":"http","address":"http://127.0.0.1","auth_header":"Bearer legacy"}]`}
+	m.Require().Nil(fromDBModel(legacy))
+	m.Equal("Bearer legacy", legacy.Targets[0].AuthHeader)
+}
+
 func (m *managerTestSuite) TestDelete() {
 	m.dao.On("Delete", mock.Anything, mock.Anything).Return(nil)
 	err := m.mgr.Delete(context.Background(), 1)
@@ -113,6 +148,24 @@ func (m *managerTestSuite) TestGetRelatedPolices() {
 	m.dao.AssertExpectations(m.T())
 }
 
+func (m *managerTestSuite) TestGetRelatedPolicesIncludingSystemLevel() {
+	m.dao.On("List", mock.Anything, mock.Anything).Return([]*model.Policy{
+		{
+			ID:           1,
+			Name:         "system",
+			ProjectID:    model.SystemLevelProjectID,
+			Enabled:      true,
+			EventTypesDB: "[\"QUOTA_EXCEED\"]",
+		},
+	}, nil)
+	rpers, err := m.mgr.GetRelatedPolices(context.Background(), 1, "QUOTA_EXCEED")
+	m.Nil(err)
+	m.Equal(1, len(rpers))
+	query := m.dao.Calls[0].Arguments.Get(1).(*q.Query)
+	m.Equal(&q.OrList{Values: []interface{}{int64(1), model.SystemLevelProjectID}}, query.Keywords["project_id"])
+	m.dao.AssertExpectations(m.T())
+}
+
 func TestManager(t *testing.T) {
 	suite.Run(t, &managerTestSuite{})
 }
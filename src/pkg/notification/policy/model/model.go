@@ -7,6 +7,9 @@ import (
 	"github.com/beego/beego/v2/client/orm"
 )
 
+// SystemLevelProjectID is the project ID of the system level policies which receive the events of all projects
+const SystemLevelProjectID int64 = 0
+
 func init() {
 	orm.RegisterModel(&Policy{})
 }
@@ -25,6 +28,35 @@ type Policy struct {
 	CreationTime time.Time     `orm:"column(creation_time);auto_now_add" json:"creation_time" sort:"default:desc"`
 	UpdateTime   time.Time     `orm:"column(update_time);auto_now_add" json:"update_time"`
 	Enabled      bool          `orm:"column(enabled)" json:"enabled"`
+	// Secret is used to sign the payloads sent to the http targets
+	Secret string `orm:"column(secret)" json:"secret,omitempty"`
+	// PullEventOptions throttles or samples the pull artifact events sent to the policy
+	PullEventOptionsDB string            `orm:"column(pull_event_options)" json:"-"`
+	PullEventOptions   *PullEventOptions `orm:"-" json:"pull_event_options,omitempty"`
+	// Filters are evaluated before sending the events to the policy
+	FiltersDB string        `orm:"column(filters)" json:"-"`
+	Filters   *EventFilters `orm:"-" json:"filters,omitempty"`
+}
+
+// EventFilters filters the events sent to the policy, the filter of the attribute which the event
+// doesn't carry is skipped, e.g. the min severity is only evaluated for the scan events
+type EventFilters struct {
+	// Repositories are the doublestar patterns of the repository names, e.g. "library/**"
+	Repositories []string `json:"repositories,omitempty"`
+	// TagPattern is the regular expression which at least one of the tags should match
+	TagPattern string `json:"tag_pattern,omitempty"`
+	// LabelIDs are the IDs of the labels which at least one of them should be attached to the artifact
+	LabelIDs []int64 `json:"label_ids,omitempty"`
+	// MinSeverity is the minimal severity of the vulnerabilities of the scanned artifact
+	MinSeverity string `json:"min_severity,omitempty"`
+}
+
+// PullEventOptions limits the pull artifact events sent to the policy to avoid the event storms
+type PullEventOptions struct {
+	// ThrottleInterval is the interval in seconds, at most one pull event of each repository is sent in the interval
+	ThrottleInterval int64 `json:"throttle_interval"`
+	// SampleRate is the ratio of the pull events sent, in the range of (0, 1], all events are sent when it's 0
+	SampleRate float64 `json:"sample_rate"`
 }
 
 // TableName set table name for ORM.
@@ -48,6 +80,20 @@ func (w *Policy) ConvertToDBModel() error {
 		}
 		w.EventTypesDB = string(eventTypes)
 	}
+	if w.PullEventOptions != nil {
+		options, err := json.Marshal(w.PullEventOptions)
+		if err != nil {
+			return err
+		}
+		w.PullEventOptionsDB = string(options)
+	}
+	if w.Filters != nil {
+		filters, err := json.Marshal(w.Filters)
+		if err != nil {
+			return err
+		}
+		w.FiltersDB = string(filters)
+	}
 
 	return nil
 }
@@ -72,13 +118,35 @@ func (w *Policy) ConvertFromDBModel() error {
 	}
 	w.EventTypes = types
 
+	if len(w.PullEventOptionsDB) != 0 {
+		options := &PullEventOptions{}
+		if err := json.Unmarshal([]byte(w.PullEventOptionsDB), options); err != nil {
+			return err
+		}
+		w.PullEventOptions = options
+	}
+
+	if len(w.FiltersDB) != 0 {
+		filters := &EventFilters{}
+		if err := json.Unmarshal([]byte(w.FiltersDB), filters); err != nil {
+			return err
+		}
+		w.Filters = filters
+	}
+
 	return nil
 }
 
 // EventTarget defines the structure of target a notification send to
 type EventTarget struct {
-	Type           string `json:"type"`
-	Address        string `json:"address"`
-	AuthHeader     string `json:"auth_header,omitempty"`
-	SkipCertVerify bool   `json:"skip_cert_verify"`
+	Type          string            `json:"type"`
+	Address       string            `json:"address"`
+	AuthHeader    string            `json:"auth_header,omitempty"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	PayloadFormat string            `json:"payload_format,omitempty"`
+	// Topic is the Kafka topic or the NATS subject the event is published to
+	Topic string `json:"topic,omitempty"`
+	// Recipients are the email addresses the notification is sent to by the email target
+	Recipients     []string `json:"recipients,omitempty"`
+	SkipCertVerify bool     `json:"skip_cert_verify"`
 }
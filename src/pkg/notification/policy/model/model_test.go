@@ -44,6 +44,34 @@ func TestPolicy_ConvertFromDBModel(t *testing.T) {
 				EventTypes: []string{"pushImage", "pullImage", "deleteImage"},
 			},
 		},
+		{
+			name: "ConvertFromDBModel with pull event options",
+			policy: &Policy{
+				EventTypesDB:       "[\"PULL_ARTIFACT\"]",
+				PullEventOptionsDB: "{\"throttle_interval\":60,\"sample_rate\":0.5}",
+			},
+			want: &Policy{
+				Targets:          []EventTarget{},
+				EventTypes:       []string{"PULL_ARTIFACT"},
+				PullEventOptions: &PullEventOptions{ThrottleInterval: 60, SampleRate: 0.5},
+			},
+		},
+		{
+			name: "ConvertFromDBModel with filters",
+			policy: &Policy{
+				EventTypesDB: "[\"PUSH_ARTIFACT\"]",
+				FiltersDB:    "{\"repositories\":[\"library/**\"],\"tag_pattern\":\"^v\\\\d+\",\"label_ids\":[1]}",
+			},
+			want: &Policy{
+				Targets:    []EventTarget{},
+				EventTypes: []string{"PUSH_ARTIFACT"},
+				Filters: &EventFilters{
+					Repositories: []string{"library/**"},
+					TagPattern:   "^v\\d+",
+					LabelIDs:     []int64{1},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -56,6 +84,8 @@ func TestPolicy_ConvertFromDBModel(t *testing.T) {
 			require.Nil(t, err)
 			assert.Equal(t, tt.want.Targets, tt.policy.Targets)
 			assert.Equal(t, tt.want.EventTypes, tt.policy.EventTypes)
+			assert.Equal(t, tt.want.PullEventOptions, tt.policy.PullEventOptions)
+			assert.Equal(t, tt.want.Filters, tt.policy.Filters)
 		})
 	}
 }
@@ -84,6 +114,45 @@ func TestPolicy_ConvertToDBModel(t *testing.T) {
 				EventTypesDB: "[\"pushImage\",\"pullImage\",\"deleteImage\"]",
 			},
 		},
+		{
+			name: "ConvertToDBModel with headers",
+			policy: &Policy{
+				Targets: []EventTarget{
+					{
+						Type:    "http",
+						Address: "http://127.0.0.1",
+						Headers: map[string]string{"X-Source": "harbor"},
+					},
+				},
+				EventTypes: []string{"PUSH_ARTIFACT"},
+			},
+			want: &Policy{
+				TargetsDB:    "[{\"type\":\"http\",\"address\":\"http://127.0.0.1\",\"headers\":{\"X-Source\":\"harbor\"},\"skip_cert_verify\":false}]",
+				EventTypesDB: "[\"PUSH_ARTIFACT\"]",
+			},
+		},
+		{
+			name: "ConvertToDBModel with pull event options",
+			policy: &Policy{
+				EventTypes:       []string{"PULL_ARTIFACT"},
+				PullEventOptions: &PullEventOptions{ThrottleInterval: 60, SampleRate: 0.5},
+			},
+			want: &Policy{
+				EventTypesDB:       "[\"PULL_ARTIFACT\"]",
+				PullEventOptionsDB: "{\"throttle_interval\":60,\"sample_rate\":0.5}",
+			},
+		},
+		{
+			name: "ConvertToDBModel with filters",
+			policy: &Policy{
+				EventTypes: []string{"SCANNING_COMPLETED"},
+				Filters:    &EventFilters{MinSeverity: "High"},
+			},
+			want: &Policy{
+				EventTypesDB: "[\"SCANNING_COMPLETED\"]",
+				FiltersDB:    "{\"min_severity\":\"High\"}",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -96,6 +165,8 @@ func TestPolicy_ConvertToDBModel(t *testing.T) {
 			require.Nil(t, err)
 			assert.Equal(t, tt.want.TargetsDB, tt.policy.TargetsDB)
 			assert.Equal(t, tt.want.EventTypesDB, tt.policy.EventTypesDB)
+			assert.Equal(t, tt.want.PullEventOptionsDB, tt.policy.PullEventOptionsDB)
+			assert.Equal(t, tt.want.FiltersDB, tt.policy.FiltersDB)
 		})
 	}
 }
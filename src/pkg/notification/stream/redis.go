@@ -0,0 +1,138 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+const (
+	channel = "harbor:event_stream"
+	// the interval to refresh the count of the core instances subscribing the channel
+	refreshInterval = 5 * time.Second
+)
+
+// pubSubClient is implemented by the redis client of the redis cache
+type pubSubClient interface {
+	Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd
+	Subscribe(ctx context.Context, channels ...string) *redis.PubSub
+	PubSubNumSub(ctx context.Context, channels ...string) *redis.StringIntMapCmd
+}
+
+// redisBroker relays the events through the redis channel, so that the subscribers connected to any core
+// instance receive the events published by all the instances. The instance only subscribes the channel
+// when it has local subscribers, which makes the count of the channel subscribers indicate whether it's active
+type redisBroker struct {
+	client pubSubClient
+	local  *localBroker
+
+	lock   sync.Mutex
+	cancel context.CancelFunc
+
+	active    atomic.Bool
+	refreshed atomic.Int64
+}
+
+func newRedisBroker(client pubSubClient) *redisBroker {
+	r := &redisBroker{
+		client: client,
+		local:  newLocalBroker(),
+	}
+	r.local.onActive = r.relay
+	r.local.onInactive = r.stopRelay
+	return r
+}
+
+func (r *redisBroker) Active(ctx context.Context) bool {
+	if r.local.count() > 0 {
+		return true
+	}
+	if time.Since(time.Unix(0, r.refreshed.Load())) < refreshInterval {
+		return r.active.Load()
+	}
+	counts, err := r.client.PubSubNumSub(ctx, channel).Result()
+	if err != nil {
+		log.Errorf("failed to get the count of the subscribers of the event stream: %v", err)
+		return false
+	}
+	r.active.Store(counts[channel] > 0)
+	r.refreshed.Store(time.Now().UnixNano())
+	return r.active.Load()
+}
+
+func (r *redisBroker) Publish(ctx context.Context, event *Event) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Errorf("failed to marshal the %s event of the event stream: %v", event.Payload.Type, err)
+		return
+	}
+	if err = r.client.Publish(ctx, channel, data).Err(); err != nil {
+		log.Errorf("failed to publish the %s event to the event stream: %v", event.Payload.Type, err)
+	}
+}
+
+func (r *redisBroker) Subscribe(ctx context.Context) <-chan *Event {
+	return r.local.Subscribe(ctx)
+}
+
+// relay subscribes the redis channel and delivers the events to the local subscribers
+func (r *redisBroker) relay() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	pubSub := r.client.Subscribe(ctx, channel)
+	go func() {
+		defer pubSub.Close()
+		messages := pubSub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				event := &Event{}
+				if err := json.Unmarshal([]byte(msg.Payload), event); err != nil || event.Payload == nil {
+					log.Errorf("failed to unmarshal the event of the event stream: %v", err)
+					continue
+				}
+				r.local.Publish(ctx, event)
+			}
+		}
+	}()
+}
+
+func (r *redisBroker) stopRelay() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.cancel != nil {
+		r.cancel()
+		r.cancel = nil
+	}
+}
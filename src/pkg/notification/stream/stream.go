@@ -0,0 +1,134 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"sync"
+
+	"github.com/goharbor/harbor/src/lib/cache"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+)
+
+// the size of the buffer of each subscriber, the events are dropped for the slow subscribers whose buffer is full
+const bufferSize = 64
+
+// Event is the event delivered to the subscribers of the event stream, it carries the same payload as the webhook
+type Event struct {
+	// the ID of the project which the event belongs to, 0 for the system level events
+	ProjectID int64          `json:"project_id"`
+	Payload   *model.Payload `json:"payload"`
+}
+
+// Broker delivers the events to the subscribers of the event stream
+type Broker interface {
+	// Active returns whether there is any subscriber, the publishers can skip constructing the events if not
+	Active(ctx context.Context) bool
+	// Publish the event to all the subscribers
+	Publish(ctx context.Context, event *Event)
+	// Subscribe the events, the returned channel is closed when the context is done
+	Subscribe(ctx context.Context) <-chan *Event
+}
+
+var (
+	defaultBroker Broker
+	once          sync.Once
+)
+
+// Default returns the default broker, the events are delivered across the core instances through the
+// redis if the cache is backed by the redis, otherwise they are only delivered in the current instance
+func Default() Broker {
+	once.Do(func() {
+		if client, ok := cache.Default().(pubSubClient); ok {
+			defaultBroker = newRedisBroker(client)
+			return
+		}
+		defaultBroker = newLocalBroker()
+	})
+	return defaultBroker
+}
+
+// Publish is a shortcut to publish the event with the payload to the default broker if it's active
+func Publish(ctx context.Context, projectID int64, payload *model.Payload) {
+	if payload == nil {
+		return
+	}
+	broker := Default()
+	if broker.Active(ctx) {
+		broker.Publish(ctx, &Event{ProjectID: projectID, Payload: payload})
+	}
+}
+
+// Active is a shortcut to check whether the default broker is active
+func Active(ctx context.Context) bool {
+	return Default().Active(ctx)
+}
+
+type localBroker struct {
+	lock        sync.RWMutex
+	subscribers map[chan *Event]struct{}
+	// called when the count of subscribers changes between 0 and 1
+	onActive   func()
+	onInactive func()
+}
+
+func newLocalBroker() *localBroker {
+	return &localBroker{subscribers: map[chan *Event]struct{}{}}
+}
+
+func (l *localBroker) Active(_ context.Context) bool {
+	return l.count() > 0
+}
+
+func (l *localBroker) count() int {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return len(l.subscribers)
+}
+
+func (l *localBroker) Publish(_ context.Context, event *Event) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	for ch := range l.subscribers {
+		select {
+		case ch <- event:
+		default:
+			log.Debugf("the buffer of the event stream subscriber is full, drop the %s event", event.Payload.Type)
+		}
+	}
+}
+
+func (l *localBroker) Subscribe(ctx context.Context) <-chan *Event {
+	ch := make(chan *Event, bufferSize)
+	l.lock.Lock()
+	l.subscribers[ch] = struct{}{}
+	if len(l.subscribers) == 1 && l.onActive != nil {
+		l.onActive()
+	}
+	l.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		l.lock.Lock()
+		delete(l.subscribers, ch)
+		if len(l.subscribers) == 0 && l.onInactive != nil {
+			l.onInactive()
+		}
+		l.lock.Unlock()
+		close(ch)
+	}()
+	return ch
+}
@@ -0,0 +1,96 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+)
+
+type localBrokerTestSuite struct {
+	suite.Suite
+	broker *localBroker
+}
+
+func (l *localBrokerTestSuite) SetupTest() {
+	l.broker = newLocalBroker()
+}
+
+func (l *localBrokerTestSuite) TestPublishAndSubscribe() {
+	ctx := context.Background()
+	l.False(l.broker.Active(ctx))
+
+	subCtx, cancel := context.WithCancel(ctx)
+	events := l.broker.Subscribe(subCtx)
+	l.True(l.broker.Active(ctx))
+
+	l.broker.Publish(ctx, &Event{ProjectID: 1, Payload: &model.Payload{Type: "PUSH_ARTIFACT"}})
+	evt := <-events
+	l.Equal(int64(1), evt.ProjectID)
+	l.Equal("PUSH_ARTIFACT", evt.Payload.Type)
+
+	cancel()
+	// the channel is closed after the context is done
+	for range events {
+	}
+	l.False(l.broker.Active(ctx))
+}
+
+func (l *localBrokerTestSuite) TestSlowSubscriber() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := l.broker.Subscribe(ctx)
+
+	// the events are dropped when the buffer is full rather than blocking the publisher
+	for i := 0; i < bufferSize+10; i++ {
+		l.broker.Publish(ctx, &Event{Payload: &model.Payload{Type: "PULL_ARTIFACT"}})
+	}
+	l.Len(events, bufferSize)
+}
+
+func (l *localBrokerTestSuite) TestActiveCallbacks() {
+	var active, inactive int
+	l.broker.onActive = func() { active++ }
+	done := make(chan struct{})
+	l.broker.onInactive = func() {
+		inactive++
+		close(done)
+	}
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	events1 := l.broker.Subscribe(ctx1)
+	events2 := l.broker.Subscribe(ctx2)
+	l.Equal(1, active)
+
+	cancel1()
+	for range events1 {
+	}
+	l.Equal(0, inactive)
+	cancel2()
+	for range events2 {
+	}
+	<-done
+	l.Equal(1, active)
+	l.Equal(1, inactive)
+}
+
+func TestLocalBrokerTestSuite(t *testing.T) {
+	suite.Run(t, &localBrokerTestSuite{})
+}
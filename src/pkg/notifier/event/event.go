@@ -42,6 +42,7 @@ type HookMetaData struct {
 	EventType string
 	Target    *policy_model.EventTarget
 	Payload   *model.Payload
+	Secret    string
 }
 
 // Resolve hook metadata into hook event
@@ -51,6 +52,7 @@ func (h *HookMetaData) Resolve(evt *Event) error {
 		EventType: h.EventType,
 		Target:    h.Target,
 		Payload:   h.Payload,
+		Secret:    h.Secret,
 	}
 
 	evt.Topic = h.Target.Type
@@ -0,0 +1,84 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+)
+
+const (
+	// CloudEventsContentType is the content type of the CloudEvents in the structured content mode
+	CloudEventsContentType = "application/cloudevents+json"
+
+	cloudEventsSpecVersion = "1.0"
+	cloudEventsTypePrefix  = "io.goharbor."
+	// the source is used when the external endpoint of Harbor cannot be got
+	defaultCloudEventsSource = "/harbor"
+)
+
+// cloudEvent is the event defined in the CloudEvents specification 1.0 in the JSON format
+type cloudEvent struct {
+	SpecVersion     string           `json:"specversion"`
+	ID              string           `json:"id"`
+	Source          string           `json:"source"`
+	Type            string           `json:"type"`
+	Subject         string           `json:"subject,omitempty"`
+	Time            string           `json:"time"`
+	DataContentType string           `json:"datacontenttype"`
+	Data            *model.EventData `json:"data,omitempty"`
+	// the extension attribute, the name must consist of lower-case letters or digits
+	Operator string `json:"operator,omitempty"`
+}
+
+// convertToCloudEvent converts the payload to the CloudEvents 1.0 event, e.g. the type of the
+// "PUSH_ARTIFACT" event is "io.goharbor.push_artifact" and the subject is the repository name
+func convertToCloudEvent(payload *model.Payload) (string, error) {
+	ce := &cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              uuid.New().String(),
+		Source:          cloudEventsSource(),
+		Type:            cloudEventsTypePrefix + strings.ToLower(payload.Type),
+		Time:            time.Unix(payload.OccurAt, 0).UTC().Format(time.RFC3339),
+		DataContentType: "application/json",
+		Data:            payload.EventData,
+		Operator:        payload.Operator,
+	}
+	if payload.EventData != nil && payload.EventData.Repository != nil {
+		ce.Subject = payload.EventData.Repository.RepoFullName
+	}
+	data, err := json.Marshal(ce)
+	if err != nil {
+		return "", fmt.Errorf("marshal cloud event failed: %v", err)
+	}
+	return string(data), nil
+}
+
+func cloudEventsSource() string {
+	endpoint, err := config.ExtEndpoint()
+	if err != nil || len(endpoint) == 0 {
+		log.Debugf("failed to get the external endpoint, use the default source of cloud events: %v", err)
+		return defaultCloudEventsSource
+	}
+	return strings.TrimSuffix(endpoint, "/")
+}
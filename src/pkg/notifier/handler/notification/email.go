@@ -0,0 +1,155 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"strings"
+	textTemplate "text/template"
+	"time"
+
+	"github.com/goharbor/harbor/src/controller/event"
+	eventModel "github.com/goharbor/harbor/src/controller/event/model"
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+)
+
+// emailTemplate defines the subject and the summary of the notification email of an event type
+type emailTemplate struct {
+	subject *textTemplate.Template
+	summary *template.Template
+}
+
+func newEmailTemplate(subject, summary string) *emailTemplate {
+	return &emailTemplate{
+		subject: textTemplate.Must(textTemplate.New("subject").Parse(subject)),
+		summary: template.Must(template.New("summary").Parse(summary)),
+	}
+}
+
+var (
+	// emailTemplates defines the templates of the notification emails per event type
+	emailTemplates = map[string]*emailTemplate{
+		event.TopicPushArtifact: newEmailTemplate(`Artifact pushed to {{.Repository}}`,
+			`{{.Operator}} pushed {{len .Resources}} artifact(s) to the repository {{.Repository}}.`),
+		event.TopicPullArtifact: newEmailTemplate(`Artifact pulled from {{.Repository}}`,
+			`{{.Operator}} pulled {{len .Resources}} artifact(s) from the repository {{.Repository}}.`),
+		event.TopicDeleteArtifact: newEmailTemplate(`Artifact deleted from {{.Repository}}`,
+			`{{.Operator}} deleted {{len .Resources}} artifact(s) from the repository {{.Repository}}.`),
+		event.TopicUploadChart: newEmailTemplate(`Chart uploaded to {{.Repository}}`,
+			`{{.Operator}} uploaded the chart {{.Repository}}.`),
+		event.TopicDownloadChart: newEmailTemplate(`Chart downloaded from {{.Repository}}`,
+			`{{.Operator}} downloaded the chart {{.Repository}}.`),
+		event.TopicDeleteChart: newEmailTemplate(`Chart deleted from {{.Repository}}`,
+			`{{.Operator}} deleted the chart {{.Repository}}.`),
+		event.TopicQuotaExceed: newEmailTemplate(`Quota exceeded when pushing to {{.Repository}}`,
+			`The quota of the project is exceeded when pushing to the repository {{.Repository}}. {{index .Custom "Details"}}`),
+		event.TopicQuotaWarning: newEmailTemplate(`Quota warning for {{.Repository}}`,
+			`The usage of the project quota reached the warning threshold when pushing to the repository {{.Repository}}. {{index .Custom "Details"}}`),
+		event.TopicScanningFailed: newEmailTemplate(`Scanning failed in {{.Repository}}`,
+			`The scanning of the artifact in the repository {{.Repository}} failed.`),
+		event.TopicScanningStopped: newEmailTemplate(`Scanning stopped in {{.Repository}}`,
+			`The scanning of the artifact in the repository {{.Repository}} was stopped.`),
+		event.TopicScanningCompleted: newEmailTemplate(`Scanning completed in {{.Repository}}`,
+			`The scanning of the artifact in the repository {{.Repository}} completed.`),
+		event.TopicReplication: newEmailTemplate(`Replication {{with .Replication}}{{.JobStatus}}{{end}}`,
+			`{{with .Replication}}The replication triggered by {{.TriggerType}} finished with the status {{.JobStatus}}.{{end}}`),
+		event.TopicTagRetention: newEmailTemplate(`Tag retention {{with .Retention}}{{.Status}} in the project {{.ProjectName}}{{end}}`,
+			`{{with .Retention}}The tag retention of the project {{.ProjectName}} finished with the status {{.Status}}, {{.Retained}} of {{.Total}} artifact(s) retained.{{end}}`),
+		event.TopicCreateUser: newEmailTemplate(`User {{index .Custom "username"}} created`,
+			`The user {{index .Custom "username"}} was created{{if .Operator}} by {{.Operator}}{{end}}.`),
+		event.TopicCreateProject: newEmailTemplate(`Project {{index .Custom "project_name"}} created`,
+			`The project {{index .Custom "project_name"}} was created{{if .Operator}} by {{.Operator}}{{end}}.`),
+		event.TopicGarbageCollection: newEmailTemplate(`Garbage collection {{index .Custom "status"}}`,
+			`The garbage collection {{index .Custom "execution_id"}} finished with the status {{index .Custom "status"}}.`),
+		event.TopicBackup: newEmailTemplate(`Backup {{index .Custom "backup_id"}} {{index .Custom "stage"}}`,
+			`The backup {{index .Custom "backup_id"}} moved to the stage {{index .Custom "stage"}}.`),
+	}
+
+	defaultEmailTemplate = newEmailTemplate(`{{.Type}} event`, `The {{.Type}} event occurred.`)
+
+	// emailBodyTemplate is the layout of the notification emails, which contains the summary and the details of the event
+	emailBodyTemplate = template.Must(template.New("body").Parse(`<html>
+<body>
+<p>{{.Summary}}</p>
+<table>
+<tr><td>Event type:</td><td>{{.Type}}</td></tr>
+<tr><td>Occurred at:</td><td>{{.OccurAt}}</td></tr>
+{{- if .Operator}}
+<tr><td>Operator:</td><td>{{.Operator}}</td></tr>
+{{- end}}
+{{- if .Repository}}
+<tr><td>Repository:</td><td>{{.Repository}}</td></tr>
+{{- end}}
+</table>
+{{- if .Resources}}
+<p>Artifacts:</p>
+<ul>
+{{- range .Resources}}
+<li>{{if .Tag}}{{.Tag}} {{end}}{{.Digest}}{{if .ResourceURL}} ({{.ResourceURL}}){{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+<p>Event data:</p>
+<pre>{{.EventData}}</pre>
+</body>
+</html>`))
+
+	subjectReplacer = strings.NewReplacer("\r", " ", "\n", " ")
+)
+
+// emailData is the data passed to the email templates
+type emailData struct {
+	Type        string
+	Operator    string
+	OccurAt     string
+	Repository  string
+	Resources   []*model.Resource
+	Custom      map[string]string
+	Replication *eventModel.Replication
+	Retention   *eventModel.Retention
+	EventData   string
+	Summary     template.HTML
+}
+
+// renderEmail renders the subject and the HTML body of the notification email with the templates of the event type
+func renderEmail(payload *model.Payload) (string, string, error) {
+	data := &emailData{
+		Type:     payload.Type,
+		Operator: payload.Operator,
+		OccurAt:  time.Unix(payload.OccurAt, 0).UTC().Format(time.RFC1123),
+	}
+	if payload.EventData != nil {
+		if payload.EventData.Repository != nil {
+			data.Repository = payload.EventData.Repository.RepoFullName
+		}
+		data.Resources = payload.EventData.Resources
+		data.Custom = payload.EventData.Custom
+		data.Replication = payload.EventData.Replication
+		data.Retention = payload.EventData.Retention
+		eventData, err := json.MarshalIndent(payload.EventData, "", "  ")
+		if err != nil {
+			return "", "", err
+		}
+		data.EventData = string(eventData)
+	}
+
+	tmpl, ok := emailTemplates[payload.Type]
+	if !ok {
+		tmpl = defaultEmailTemplate
+	}
+	subject := &bytes.Buffer{}
+	if err := tmpl.subject.Execute(subject, data); err != nil {
+		return "", "", err
+	}
+	summary := &bytes.Buffer{}
+	if err := tmpl.summary.Execute(summary, data); err != nil {
+		return "", "", err
+	}
+	// the summary is escaped by the html template already
+	data.Summary = template.HTML(summary.String()) // #nosec G203
+	body := &bytes.Buffer{}
+	if err := emailBodyTemplate.Execute(body, data); err != nil {
+		return "", "", err
+	}
+	return "[Harbor] " + subjectReplacer.Replace(subject.String()), body.String(), nil
+}
@@ -0,0 +1,108 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/common/job/models"
+	"github.com/goharbor/harbor/src/controller/event"
+	eventModel "github.com/goharbor/harbor/src/controller/event/model"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+)
+
+type recordedHookManager struct {
+	fakedHookManager
+	job *models.JobData
+}
+
+func (r *recordedHookManager) StartHook(ctx context.Context, event *model.HookEvent, job *models.JobData) error {
+	r.job = job
+	return nil
+}
+
+func TestEmailHandler_Handle(t *testing.T) {
+	hookMgr := notification.HookManager
+	defer func() {
+		notification.HookManager = hookMgr
+	}()
+	recorder := &recordedHookManager{}
+	notification.HookManager = recorder
+
+	handler := &EmailHandler{}
+	assert.NotNil(t, handler.Handle(context.TODO(), nil))
+	assert.NotNil(t, handler.Handle(context.TODO(), &model.EventData{}))
+
+	err := handler.Handle(context.TODO(), &model.HookEvent{
+		PolicyID:  1,
+		EventType: event.TopicPushArtifact,
+		Target: &policy_model.EventTarget{
+			Type:       "email",
+			Recipients: []string{"dev@example.com"},
+		},
+		Payload: &model.Payload{
+			OccurAt:  time.Now().Unix(),
+			Type:     event.TopicPushArtifact,
+			Operator: "admin",
+			EventData: &model.EventData{
+				Resources:  []*model.Resource{{Tag: "v1.0", Digest: "sha256:abc"}},
+				Repository: &model.Repository{Name: "debian", RepoFullName: "library/debian"},
+			},
+		},
+	})
+	require.Nil(t, err)
+	assert.Equal(t, "EMAIL", recorder.job.Name)
+	assert.Equal(t, "[Harbor] Artifact pushed to library/debian", recorder.job.Parameters["subject"])
+	assert.Equal(t, []string{"dev@example.com"}, recorder.job.Parameters["recipients"])
+	assert.Contains(t, recorder.job.Parameters["message"], "admin pushed 1 artifact(s) to the repository library/debian.")
+	assert.Contains(t, recorder.job.Parameters["message"], "<li>v1.0 sha256:abc</li>")
+}
+
+func TestRenderEmail(t *testing.T) {
+	// every event type is rendered without the optional event data
+	for eventType := range emailTemplates {
+		subject, body, err := renderEmail(&model.Payload{Type: eventType, OccurAt: time.Now().Unix()})
+		require.Nil(t, err, eventType)
+		assert.NotEmpty(t, subject, eventType)
+		assert.Contains(t, body, eventType)
+	}
+
+	subject, body, err := renderEmail(&model.Payload{
+		Type: event.TopicTagRetention,
+		EventData: &model.EventData{
+			Retention: &eventModel.Retention{ProjectName: "library", Status: "SUCCESS", Total: 3, Retained: 2},
+		},
+	})
+	require.Nil(t, err)
+	assert.Equal(t, "[Harbor] Tag retention SUCCESS in the project library", subject)
+	assert.Contains(t, body, "2 of 3 artifact(s) retained")
+
+	// the values are escaped in the body and the line breaks are removed from the subject
+	subject, body, err = renderEmail(&model.Payload{
+		Type:      event.TopicCreateUser,
+		Operator:  "admin",
+		EventData: &model.EventData{Custom: map[string]string{"username": "<b>user</b>\r\nBcc: x@example.com"}},
+	})
+	require.Nil(t, err)
+	assert.Equal(t, "[Harbor] User <b>user</b>  Bcc: x@example.com created", subject)
+	assert.NotContains(t, body, "<b>user</b>")
+
+	subject, _, err = renderEmail(&model.Payload{Type: "UNKNOWN"})
+	require.Nil(t, err)
+	assert.Equal(t, "[Harbor] UNKNOWN event", subject)
+}
+
+func TestEmailHandler_IsStateful(t *testing.T) {
+	handler := &EmailHandler{}
+	assert.False(t, handler.IsStateful())
+}
+
+func TestEmailHandler_Name(t *testing.T) {
+	handler := &EmailHandler{}
+	assert.Equal(t, "Email", handler.Name())
+}
@@ -47,18 +47,56 @@ func (h *HTTPHandler) process(ctx context.Context, event *model.HookEvent) error
 	}
 	j.Name = job.WebhookJob
 
-	payload, err := json.Marshal(event.Payload)
+	payload, err := h.format(event)
 	if err != nil {
-		return fmt.Errorf("marshal from payload %v failed: %v", event.Payload, err)
+		return err
 	}
 
 	j.Parameters = map[string]interface{}{
-		"payload": string(payload),
+		"payload": payload,
 		"address": event.Target.Address,
 		// Users can define a auth header in http statement in notification(webhook) policy.
 		// So it will be sent in header in http request.
 		"auth_header":      event.Target.AuthHeader,
+		"headers":          event.Target.Headers,
 		"skip_cert_verify": event.Target.SkipCertVerify,
 	}
+	if event.Target.PayloadFormat == model.PayloadFormatCloudEvents {
+		j.Parameters["content_type"] = CloudEventsContentType
+	}
+	// the payload is signed with the secret of the policy when sending
+	if len(event.Secret) > 0 {
+		j.Parameters["secret"] = event.Secret
+	}
 	return notification.HookManager.StartHook(ctx, event, j)
 }
+
+// format the payload according to the payload format of the target, so the chat tools can render it natively
+func (h *HTTPHandler) format(event *model.HookEvent) (string, error) {
+	switch event.Target.PayloadFormat {
+	case model.PayloadFormatSlack:
+		payload, err := (&SlackHandler{}).convert(event.Payload)
+		if err != nil {
+			return "", fmt.Errorf("convert payload to slack body failed: %v", err)
+		}
+		return payload, nil
+	case model.PayloadFormatTeams:
+		payload, err := convertToTeams(event.Payload)
+		if err != nil {
+			return "", fmt.Errorf("convert payload to teams body failed: %v", err)
+		}
+		return payload, nil
+	case model.PayloadFormatCloudEvents:
+		payload, err := convertToCloudEvent(event.Payload)
+		if err != nil {
+			return "", fmt.Errorf("convert payload to cloud event failed: %v", err)
+		}
+		return payload, nil
+	default:
+		payload, err := json.Marshal(event.Payload)
+		if err != nil {
+			return "", fmt.Errorf("marshal from payload %v failed: %v", event.Payload, err)
+		}
+		return string(payload), nil
+	}
+}
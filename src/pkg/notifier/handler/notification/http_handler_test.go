@@ -2,13 +2,17 @@ package notification
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/job/models"
+	"github.com/goharbor/harbor/src/lib/config"
+	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
 	"github.com/goharbor/harbor/src/pkg/notification"
 	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
 	"github.com/goharbor/harbor/src/pkg/notifier/event"
@@ -22,6 +26,10 @@ func (f *fakedHookManager) StartHook(ctx context.Context, event *model.HookEvent
 	return nil
 }
 
+func (f *fakedHookManager) Redeliver(ctx context.Context, jobID int64) error {
+	return nil
+}
+
 func TestHTTPHandler_Handle(t *testing.T) {
 	hookMgr := notification.HookManager
 	defer func() {
@@ -101,3 +109,76 @@ func TestHTTPHandler_Name(t *testing.T) {
 	handler := &HTTPHandler{}
 	assert.Equal(t, "HTTP", handler.Name())
 }
+
+func TestHTTPHandler_Format(t *testing.T) {
+	handler := &HTTPHandler{}
+	payload := &model.Payload{
+		Type:     "PUSH_ARTIFACT",
+		OccurAt:  time.Now().Unix(),
+		Operator: "admin",
+		EventData: &model.EventData{
+			Repository: &model.Repository{Name: "nginx", Namespace: "library"},
+		},
+	}
+
+	// generic json by default
+	data, err := handler.format(&model.HookEvent{Target: &policy_model.EventTarget{}, Payload: payload})
+	require.Nil(t, err)
+	p := &model.Payload{}
+	require.Nil(t, json.Unmarshal([]byte(data), p))
+	assert.Equal(t, "PUSH_ARTIFACT", p.Type)
+
+	// slack
+	data, err = handler.format(&model.HookEvent{Target: &policy_model.EventTarget{PayloadFormat: model.PayloadFormatSlack}, Payload: payload})
+	require.Nil(t, err)
+	assert.Contains(t, data, `"blocks"`)
+
+	// teams
+	data, err = handler.format(&model.HookEvent{Target: &policy_model.EventTarget{PayloadFormat: model.PayloadFormatTeams}, Payload: payload})
+	require.Nil(t, err)
+	card := &teamsMessageCard{}
+	require.Nil(t, json.Unmarshal([]byte(data), card))
+	assert.Equal(t, "MessageCard", card.Type)
+	require.Len(t, card.Sections, 1)
+	assert.Equal(t, "PUSH_ARTIFACT", card.Sections[0].Facts[0].Value)
+	assert.Contains(t, card.Sections[0].Text, "nginx")
+
+	// cloudevents
+	config.InitWithSettings(map[string]interface{}{common.ExtEndpoint: "https://harbor.test"})
+	payload.EventData.Repository.RepoFullName = "library/nginx"
+	data, err = handler.format(&model.HookEvent{Target: &policy_model.EventTarget{PayloadFormat: model.PayloadFormatCloudEvents}, Payload: payload})
+	require.Nil(t, err)
+	ce := &cloudEvent{}
+	require.Nil(t, json.Unmarshal([]byte(data), ce))
+	assert.Equal(t, "1.0", ce.SpecVersion)
+	assert.NotEmpty(t, ce.ID)
+	assert.Equal(t, "https://harbor.test", ce.Source)
+	assert.Equal(t, "io.goharbor.push_artifact", ce.Type)
+	assert.Equal(t, "library/nginx", ce.Subject)
+	assert.Equal(t, "admin", ce.Operator)
+	require.NotNil(t, ce.Data)
+	assert.Equal(t, "nginx", ce.Data.Repository.Name)
+}
+
+func TestKafkaHandler_Convert(t *testing.T) {
+	handler := &KafkaHandler{}
+	payload := &model.Payload{
+		Type:     "PUSH_ARTIFACT",
+		OccurAt:  time.Now().Unix(),
+		Operator: "admin",
+		EventData: &model.EventData{
+			Repository: &model.Repository{Name: "nginx", Namespace: "library", RepoFullName: "library/nginx"},
+		},
+	}
+	data, err := handler.convert(&model.HookEvent{Target: &policy_model.EventTarget{Topic: "harbor"}, Payload: payload})
+	require.Nil(t, err)
+	records := &kafkaRecords{}
+	require.Nil(t, json.Unmarshal([]byte(data), records))
+	require.Len(t, records.Records, 1)
+	assert.Equal(t, "library/nginx", records.Records[0].Key)
+	p := &model.Payload{}
+	require.Nil(t, json.Unmarshal(records.Records[0].Value, p))
+	assert.Equal(t, "PUSH_ARTIFACT", p.Type)
+
+	assert.Equal(t, "http://proxy:8082/topics/harbor", kafkaTopicURL("http://proxy:8082/", "harbor"))
+}
@@ -0,0 +1,117 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/goharbor/harbor/src/common/job/models"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+)
+
+// KafkaContentType is the content type of the JSON records accepted by the Kafka REST proxy
+const KafkaContentType = "application/vnd.kafka.json.v2+json"
+
+// kafkaRecords is the request body to produce records to the topic via the Kafka REST proxy
+type kafkaRecords struct {
+	Records []*kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string          `json:"key,omitempty"`
+	Value json.RawMessage `json:"value"`
+}
+
+// KafkaHandler preprocess event data and publish it to the Kafka topic via the REST proxy
+type KafkaHandler struct {
+}
+
+// Name ...
+func (k *KafkaHandler) Name() string {
+	return "Kafka"
+}
+
+// Handle handles event to Kafka
+func (k *KafkaHandler) Handle(ctx context.Context, value interface{}) error {
+	if value == nil {
+		return errors.New("KafkaHandler cannot handle nil value")
+	}
+
+	event, ok := value.(*model.HookEvent)
+	if !ok || event == nil {
+		return errors.New("invalid notification kafka event")
+	}
+	return k.process(ctx, event)
+}
+
+// IsStateful ...
+func (k *KafkaHandler) IsStateful() bool {
+	return false
+}
+
+func (k *KafkaHandler) process(ctx context.Context, event *model.HookEvent) error {
+	j := &models.JobData{
+		Metadata: &models.JobMetadata{
+			JobKind: job.KindGeneric,
+		},
+	}
+	// the records are produced by the REST proxy, so the webhook job is reused to send them
+	j.Name = job.WebhookJob
+
+	payload, err := k.convert(event)
+	if err != nil {
+		return err
+	}
+
+	j.Parameters = map[string]interface{}{
+		"payload":          payload,
+		"address":          kafkaTopicURL(event.Target.Address, event.Target.Topic),
+		"auth_header":      event.Target.AuthHeader,
+		"headers":          event.Target.Headers,
+		"content_type":     KafkaContentType,
+		"skip_cert_verify": event.Target.SkipCertVerify,
+	}
+	return notification.HookManager.StartHook(ctx, event, j)
+}
+
+// convert the event to the records, the repository name is used as the key to keep the events of
+// the same repository in order
+func (k *KafkaHandler) convert(event *model.HookEvent) (string, error) {
+	value, err := (&HTTPHandler{}).format(event)
+	if err != nil {
+		return "", err
+	}
+	record := &kafkaRecord{Value: json.RawMessage(value)}
+	if event.Payload.EventData != nil && event.Payload.EventData.Repository != nil {
+		record.Key = event.Payload.EventData.Repository.RepoFullName
+	}
+	data, err := json.Marshal(&kafkaRecords{Records: []*kafkaRecord{record}})
+	if err != nil {
+		return "", fmt.Errorf("marshal kafka records failed: %v", err)
+	}
+	return string(data), nil
+}
+
+// kafkaTopicURL returns the URL of the topic in the Kafka REST proxy
+func kafkaTopicURL(address, topic string) string {
+	return strings.TrimSuffix(address, "/") + "/topics/" + url.PathEscape(topic)
+}
@@ -0,0 +1,74 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+
+	"github.com/goharbor/harbor/src/common/job/models"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+)
+
+// NATSHandler preprocess event data and publish it to the NATS subject
+type NATSHandler struct {
+}
+
+// Name ...
+func (n *NATSHandler) Name() string {
+	return "NATS"
+}
+
+// Handle handles event to NATS
+func (n *NATSHandler) Handle(ctx context.Context, value interface{}) error {
+	if value == nil {
+		return errors.New("NATSHandler cannot handle nil value")
+	}
+
+	event, ok := value.(*model.HookEvent)
+	if !ok || event == nil {
+		return errors.New("invalid notification nats event")
+	}
+	return n.process(ctx, event)
+}
+
+// IsStateful ...
+func (n *NATSHandler) IsStateful() bool {
+	return false
+}
+
+func (n *NATSHandler) process(ctx context.Context, event *model.HookEvent) error {
+	j := &models.JobData{
+		Metadata: &models.JobMetadata{
+			JobKind: job.KindGeneric,
+		},
+	}
+	j.Name = job.NATSJob
+
+	payload, err := (&HTTPHandler{}).format(event)
+	if err != nil {
+		return err
+	}
+
+	j.Parameters = map[string]interface{}{
+		"payload":          payload,
+		"address":          event.Target.Address,
+		"subject":          event.Target.Topic,
+		"skip_cert_verify": event.Target.SkipCertVerify,
+	}
+	return notification.HookManager.StartHook(ctx, event, j)
+}
@@ -0,0 +1,57 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+)
+
+// teamsMessageCard is the legacy actionable message card accepted by the incoming webhooks of Microsoft Teams
+type teamsMessageCard struct {
+	Type     string          `json:"@type"`
+	Context  string          `json:"@context"`
+	Summary  string          `json:"summary"`
+	Title    string          `json:"title"`
+	Sections []*teamsSection `json:"sections"`
+}
+
+type teamsSection struct {
+	Facts []*teamsFact `json:"facts,omitempty"`
+	Text  string       `json:"text,omitempty"`
+}
+
+type teamsFact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// convertToTeams converts the payload to the message card of Microsoft Teams
+func convertToTeams(payload *model.Payload) (string, error) {
+	eventData, err := json.MarshalIndent(payload.EventData, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal from eventData %v failed: %v", payload.EventData, err)
+	}
+	card := &teamsMessageCard{
+		Type:    "MessageCard",
+		Context: "http://schema.org/extensions",
+		Summary: fmt.Sprintf("Harbor webhook events: %s", payload.Type),
+		Title:   "Harbor webhook events",
+		Sections: []*teamsSection{
+			{
+				Facts: []*teamsFact{
+					{Name: "event_type", Value: payload.Type},
+					{Name: "occur_at", Value: time.Unix(payload.OccurAt, 0).UTC().Format(time.RFC3339)},
+					{Name: "operator", Value: payload.Operator},
+				},
+				Text: "<pre>" + string(eventData) + "</pre>",
+			},
+		},
+	}
+	data, err := json.Marshal(card)
+	if err != nil {
+		return "", fmt.Errorf("marshal teams message card failed: %v", err)
+	}
+	return string(data), nil
+}
@@ -4,4 +4,16 @@ package model
 const (
 	NotifyTypeHTTP  = "http"
 	NotifyTypeSlack = "slack"
+	NotifyTypeKafka = "kafka"
+	NotifyTypeNATS  = "nats"
+	NotifyTypeEmail = "email"
+
+	// PayloadFormatGenericJSON is the default payload format which sends the event as JSON
+	PayloadFormatGenericJSON = "generic_json"
+	// PayloadFormatSlack formats the payload as the Slack message
+	PayloadFormatSlack = "slack"
+	// PayloadFormatTeams formats the payload as the Microsoft Teams message card
+	PayloadFormatTeams = "teams"
+	// PayloadFormatCloudEvents formats the payload as the CloudEvents 1.0 event in structured content mode
+	PayloadFormatCloudEvents = "cloudevents"
 )
@@ -11,6 +11,8 @@ type HookEvent struct {
 	EventType string
 	Target    *policy_model.EventTarget
 	Payload   *Payload
+	// Secret of the policy to sign the payload
+	Secret string
 }
 
 // Payload of notification event
@@ -6,6 +6,10 @@ const (
 	WebhookTopic = "http"
 	// SlackTopic is topic for sending slack payload
 	SlackTopic = "slack"
+	// KafkaTopic is topic for publishing payload to the Kafka topic
+	KafkaTopic = "kafka"
+	// NATSTopic is topic for publishing payload to the NATS subject
+	NATSTopic = "nats"
 	// EmailTopic is topic for sending email payload
 	EmailTopic = "email"
 )
@@ -12,6 +12,9 @@ func init() {
 	handlersMap := map[string][]notifier.NotificationHandler{
 		model.WebhookTopic: {&notification.HTTPHandler{}},
 		model.SlackTopic:   {&notification.SlackHandler{}},
+		model.KafkaTopic:   {&notification.KafkaHandler{}},
+		model.NATSTopic:    {&notification.NATSHandler{}},
+		model.EmailTopic:   {&notification.EmailHandler{}},
 	}
 
 	for t, handlers := range handlersMap {
@@ -75,7 +75,7 @@ func (md *metaDAO) Update(ctx context.Context, oidcUser *models.OIDCUser, props
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Update(oidcUser, props...)
+	n, err := ormer.UpdateWithCtx(ctx, oidcUser, props...)
 	if err != nil {
 		return err
 	}
@@ -92,7 +92,7 @@ func (md *metaDAO) List(ctx context.Context, query *q.Query) ([]*models.OIDCUser
 	}
 
 	var res []*models.OIDCUser
-	if _, err := qs.All(&res); err != nil {
+	if _, err := qs.AllWithCtx(ctx, &res); err != nil {
 		return nil, err
 	}
 
@@ -104,7 +104,7 @@ func (md *metaDAO) Create(ctx context.Context, oidcUser *models.OIDCUser) (int,
 	if err != nil {
 		return 0, err
 	}
-	id, err := ormer.Insert(oidcUser)
+	id, err := ormer.InsertWithCtx(ctx, oidcUser)
 	if e := orm.AsConflictError(err, "The OIDC info for user %d exists, subissuer: %s", oidcUser.UserID, oidcUser.SubIss); e != nil {
 		err = e
 	}
@@ -43,7 +43,7 @@ func (d *dao) Create(ctx context.Context, instance *provider.Instance) (id int64
 		return
 	}
 
-	id, err = o.Insert(instance)
+	id, err = o.InsertWithCtx(ctx, instance)
 	if err != nil {
 		if e := orm.AsConflictError(err, "instance %s already exists", instance.Name); e != nil {
 			err = e
@@ -61,7 +61,7 @@ func (d *dao) Get(ctx context.Context, id int64) (*provider.Instance, error) {
 	}
 
 	di := provider.Instance{ID: id}
-	if err = o.Read(&di, "ID"); err != nil {
+	if err = o.ReadWithCtx(ctx, &di, "ID"); err != nil {
 		if e := orm.AsNotFoundError(err, "instance %d not found", id); e != nil {
 			err = e
 		}
@@ -79,7 +79,7 @@ func (d *dao) GetByName(ctx context.Context, name string) (instance *provider.In
 	}
 
 	instance = &provider.Instance{Name: name}
-	if err = o.Read(instance, "Name"); err != nil {
+	if err = o.ReadWithCtx(ctx, instance, "Name"); err != nil {
 		if e := orm.AsNotFoundError(err, "instance %s not found", name); e != nil {
 			err = e
 		}
@@ -104,7 +104,7 @@ func (d *dao) Update(ctx context.Context, instance *provider.Instance, props ...
 			}
 		}
 
-		_, err = o.Update(instance, props...)
+		_, err = o.UpdateWithCtx(ctx, instance, props...)
 		return
 	}
 	return orm.WithTransaction(trans)(orm.SetTransactionOpNameToContext(ctx, "tx-prehead-update"))
@@ -117,7 +117,7 @@ func (d *dao) Delete(ctx context.Context, id int64) error {
 		return err
 	}
 
-	_, err = o.Delete(&provider.Instance{ID: id})
+	_, err = o.DeleteWithCtx(ctx, &provider.Instance{ID: id})
 	return err
 }
 
@@ -127,7 +127,7 @@ func (d *dao) Count(ctx context.Context, query *q.Query) (total int64, err error
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 
 // List lists instances by query params.
@@ -137,7 +137,7 @@ func (d *dao) List(ctx context.Context, query *q.Query) (ins []*provider.Instanc
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&ins); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &ins); err != nil {
 		return nil, err
 	}
 	return ins, nil
@@ -57,7 +57,7 @@ func (d *dao) Count(ctx context.Context, query *q.Query) (total int64, err error
 		return 0, err
 	}
 
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 
 // Create a policy schema.
@@ -67,7 +67,7 @@ func (d *dao) Create(ctx context.Context, schema *policy.Schema) (id int64, err
 		return
 	}
 
-	id, err = ormer.Insert(schema)
+	id, err = ormer.InsertWithCtx(ctx, schema)
 	if err != nil {
 		if e := orm.AsConflictError(err, "policy %s already exists", schema.Name); e != nil {
 			err = e
@@ -85,7 +85,7 @@ func (d *dao) Update(ctx context.Context, schema *policy.Schema, props ...string
 		return err
 	}
 
-	id, err := ormer.Update(schema, props...)
+	id, err := ormer.UpdateWithCtx(ctx, schema, props...)
 	if err != nil {
 		return err
 	}
@@ -105,7 +105,7 @@ func (d *dao) Get(ctx context.Context, id int64) (schema *policy.Schema, err err
 	}
 
 	schema = &policy.Schema{ID: id}
-	if err = ormer.Read(schema); err != nil {
+	if err = ormer.ReadWithCtx(ctx, schema); err != nil {
 		if e := orm.AsNotFoundError(err, "policy %d not found", id); e != nil {
 			err = e
 		}
@@ -123,7 +123,7 @@ func (d *dao) GetByName(ctx context.Context, projectID int64, name string) (sche
 	}
 
 	schema = &policy.Schema{Name: name, ProjectID: projectID}
-	if err = ormer.Read(schema, "Name", "ProjectID"); err != nil {
+	if err = ormer.ReadWithCtx(ctx, schema, "Name", "ProjectID"); err != nil {
 		if e := orm.AsNotFoundError(err, "policy %s not found", name); e != nil {
 			err = e
 		}
@@ -140,7 +140,7 @@ func (d *dao) Delete(ctx context.Context, id int64) (err error) {
 		return
 	}
 
-	n, err := ormer.Delete(&policy.Schema{
+	n, err := ormer.DeleteWithCtx(ctx, &policy.Schema{
 		ID: id,
 	})
 	if err != nil {
@@ -161,7 +161,7 @@ func (d *dao) List(ctx context.Context, query *q.Query) (schemas []*policy.Schem
 	if err != nil {
 		return
 	}
-	if _, err = qs.All(&schemas); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &schemas); err != nil {
 		return
 	}
 	return schemas, nil
@@ -0,0 +1,78 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditor
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/rbac/project"
+	"github.com/goharbor/harbor/src/common/rbac/system"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/permission/evaluator"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+)
+
+var _ evaluator.Evaluator = &Evaluator{}
+
+// the resources the auditor can read and list, they are relative to the namespace of the resource,
+// e.g. "repository" for "/project/1/repository" and "." for the project itself
+var readableResources = map[string]map[types.Resource]bool{
+	system.NamespaceKind: {
+		rbac.ResourceAuditLog:      true,
+		rbac.ResourceConfiguration: true,
+		rbac.ResourceProject:       true,
+		rbac.ResourceScanAll:       true,
+	},
+	project.NamespaceKind: {
+		types.Resource("."):           true,
+		rbac.ResourceMetadata:         true,
+		rbac.ResourceLog:              true,
+		rbac.ResourceRepository:       true,
+		rbac.ResourceArtifact:         true,
+		rbac.ResourceTag:              true,
+		rbac.ResourceAccessory:        true,
+		rbac.ResourceArtifactAddition: true,
+		rbac.ResourceScan:             true,
+	},
+}
+
+// Evaluator the permission evaluator for the auditor which is the read-only system administrator
+type Evaluator struct {
+	username string
+}
+
+// HasPermission returns true only for the read and list actions on the audit logs, configurations, projects,
+// repositories and scan results, the other resources, e.g. the users and robots, aren't readable by the auditor
+func (e *Evaluator) HasPermission(ctx context.Context, resource types.Resource, action types.Action) bool {
+	log.Debugf("auditor %s require %s action for resource %s", e.username, action, resource)
+	if action != rbac.ActionRead && action != rbac.ActionList {
+		return false
+	}
+	ns, ok := types.NamespaceFromResource(resource)
+	if !ok {
+		return false
+	}
+	relative, err := resource.RelativeTo(ns.Resource())
+	if err != nil {
+		return false
+	}
+	return readableResources[ns.Kind()][relative]
+}
+
+// New returns evaluator.Evaluator for the auditor
+func New(username string) *Evaluator {
+	return &Evaluator{username: username}
+}
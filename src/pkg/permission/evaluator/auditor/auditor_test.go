@@ -0,0 +1,53 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/rbac/project"
+	"github.com/goharbor/harbor/src/common/rbac/system"
+)
+
+func TestHasPermission(t *testing.T) {
+	e := New("auditor")
+	ctx := context.TODO()
+	auditLog := system.NewNamespace().Resource(rbac.ResourceAuditLog)
+	projects := system.NewNamespace().Resource(rbac.ResourceProject)
+	sysConfig := system.NewNamespace().Resource(rbac.ResourceConfiguration)
+	users := system.NewNamespace().Resource(rbac.ResourceUser)
+	proj := project.NewNamespace(1).Resource(rbac.ResourceSelf)
+	repo := project.NewNamespace(1).Resource(rbac.ResourceRepository)
+	scan := project.NewNamespace(1).Resource(rbac.ResourceScan)
+	robot := project.NewNamespace(1).Resource(rbac.ResourceRobot)
+
+	assert.True(t, e.HasPermission(ctx, auditLog, rbac.ActionList))
+	assert.True(t, e.HasPermission(ctx, projects, rbac.ActionList))
+	assert.True(t, e.HasPermission(ctx, proj, rbac.ActionRead))
+	assert.True(t, e.HasPermission(ctx, repo, rbac.ActionList))
+	assert.True(t, e.HasPermission(ctx, scan, rbac.ActionRead))
+	assert.True(t, e.HasPermission(ctx, sysConfig, rbac.ActionRead))
+	assert.False(t, e.HasPermission(ctx, users, rbac.ActionRead))
+	assert.False(t, e.HasPermission(ctx, users, rbac.ActionList))
+	assert.False(t, e.HasPermission(ctx, robot, rbac.ActionList))
+	assert.False(t, e.HasPermission(ctx, sysConfig, rbac.ActionUpdate))
+	assert.False(t, e.HasPermission(ctx, repo, rbac.ActionPull))
+	assert.False(t, e.HasPermission(ctx, repo, rbac.ActionPush))
+	assert.False(t, e.HasPermission(ctx, repo, rbac.ActionDelete))
+}
@@ -0,0 +1,93 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/pin/model"
+)
+
+// DAO defines the interface to access the pins of the artifacts
+type DAO interface {
+	// Create the pin and returns the ID, the conflict error is returned if the digest is pinned already
+	Create(ctx context.Context, pin *model.Pin) (int64, error)
+	// Count returns the total count of the pins according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List the pins according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.Pin, error)
+	// Delete the pin of the digest in the repository
+	Delete(ctx context.Context, repository, digest string) error
+}
+
+// New returns an instance of the default DAO
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+func (d *dao) Create(ctx context.Context, pin *model.Pin) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	id, err := ormer.Insert(pin)
+	if err != nil {
+		return 0, orm.WrapConflictError(err, "the artifact %s@%s is pinned already", pin.RepositoryName, pin.Digest)
+	}
+	return id, nil
+}
+
+func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.Pin{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.CountWithCtx(ctx)
+}
+
+func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.Pin, error) {
+	pins := []*model.Pin{}
+	qs, err := orm.QuerySetter(ctx, &model.Pin{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.AllWithCtx(ctx, &pins); err != nil {
+		return nil, err
+	}
+	return pins, nil
+}
+
+func (d *dao) Delete(ctx context.Context, repository, digest string) error {
+	qs, err := orm.QuerySetter(ctx, &model.Pin{}, q.New(q.KeyWords{
+		"RepositoryName": repository,
+		"Digest":         digest,
+	}))
+	if err != nil {
+		return err
+	}
+	n, err := qs.DeleteWithCtx(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("the pin of %s@%s not found", repository, digest)
+	}
+	return nil
+}
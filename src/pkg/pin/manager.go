@@ -0,0 +1,93 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pin
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/pin/dao"
+	"github.com/goharbor/harbor/src/pkg/pin/model"
+)
+
+// Mgr is the global pin manager instance
+var Mgr = New()
+
+// Manager manages the pins protecting the digests from the deletions
+type Manager interface {
+	// Pin the digest of the repository and returns the ID of the pin
+	Pin(ctx context.Context, pin *model.Pin) (int64, error)
+	// Unpin removes the pin of the digest of the repository
+	Unpin(ctx context.Context, repository, digest string) error
+	// Get the pin of the digest of the repository
+	Get(ctx context.Context, repository, digest string) (*model.Pin, error)
+	// Count returns the total count of the pins according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List the pins according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.Pin, error)
+	// IsPinned returns whether the digest of the repository is pinned
+	IsPinned(ctx context.Context, repository, digest string) (bool, error)
+}
+
+// New returns a default implementation of Manager
+func New() Manager {
+	return &manager{dao: dao.New()}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+func (m *manager) Pin(ctx context.Context, pin *model.Pin) (int64, error) {
+	return m.dao.Create(ctx, pin)
+}
+
+func (m *manager) Unpin(ctx context.Context, repository, digest string) error {
+	return m.dao.Delete(ctx, repository, digest)
+}
+
+func (m *manager) Get(ctx context.Context, repository, digest string) (*model.Pin, error) {
+	pins, err := m.dao.List(ctx, q.New(q.KeyWords{
+		"RepositoryName": repository,
+		"Digest":         digest,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	if len(pins) == 0 {
+		return nil, errors.NotFoundError(nil).WithMessage("the artifact %s@%s isn't pinned", repository, digest)
+	}
+	return pins[0], nil
+}
+
+func (m *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.Count(ctx, query)
+}
+
+func (m *manager) List(ctx context.Context, query *q.Query) ([]*model.Pin, error) {
+	return m.dao.List(ctx, query)
+}
+
+func (m *manager) IsPinned(ctx context.Context, repository, digest string) (bool, error) {
+	n, err := m.dao.Count(ctx, q.New(q.KeyWords{
+		"RepositoryName": repository,
+		"Digest":         digest,
+	}))
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,71 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/pin/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/pin/dao"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	dao *dao.DAO
+	mgr *manager
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.dao = &dao.DAO{}
+	m.mgr = &manager{dao: m.dao}
+}
+
+func (m *managerTestSuite) TestGet() {
+	mock.OnAnything(m.dao, "List").Return([]*model.Pin{{ID: 1}}, nil).Once()
+	pin, err := m.mgr.Get(context.Background(), "library/hello-world", "sha256:123")
+	m.Require().Nil(err)
+	m.Equal(int64(1), pin.ID)
+
+	// not pinned
+	mock.OnAnything(m.dao, "List").Return([]*model.Pin{}, nil).Once()
+	_, err = m.mgr.Get(context.Background(), "library/hello-world", "sha256:456")
+	m.True(errors.IsNotFoundErr(err))
+}
+
+func (m *managerTestSuite) TestIsPinned() {
+	mock.OnAnything(m.dao, "Count").Return(int64(1), nil)
+	pinned, err := m.mgr.IsPinned(context.Background(), "library/hello-world", "sha256:123")
+	m.Require().Nil(err)
+	m.True(pinned)
+	query := m.dao.Calls[0].Arguments.Get(1).(*q.Query)
+	m.Equal("library/hello-world", query.Keywords["RepositoryName"])
+	m.Equal("sha256:123", query.Keywords["Digest"])
+}
+
+func (m *managerTestSuite) TestUnpin() {
+	m.dao.On("Delete", mock.Anything, "library/hello-world", "sha256:123").Return(nil)
+	m.Nil(m.mgr.Unpin(context.Background(), "library/hello-world", "sha256:123"))
+	m.dao.AssertExpectations(m.T())
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
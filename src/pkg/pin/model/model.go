@@ -0,0 +1,43 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+func init() {
+	orm.RegisterModel(&Pin{})
+}
+
+// Pin protects the digest of the repository from the deletions by the users, the tag retention and
+// the garbage collection until it is unpinned, it is a stronger guarantee than the tag immutability
+// as the digest is protected no matter which tags are attached to it
+type Pin struct {
+	ID             int64     `orm:"pk;auto;column(id)" json:"id"`
+	ProjectID      int64     `orm:"column(project_id)" json:"project_id"`
+	RepositoryName string    `orm:"column(repository_name)" json:"repository_name"`
+	Digest         string    `orm:"column(digest)" json:"digest"`
+	Reason         string    `orm:"column(reason)" json:"reason"`
+	PinnedBy       string    `orm:"column(pinned_by)" json:"pinned_by"`
+	CreationTime   time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time" sort:"default:desc"`
+}
+
+// TableName ...
+func (p *Pin) TableName() string {
+	return "artifact_pin"
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/project/models"
@@ -40,6 +41,8 @@ type DAO interface {
 	GetByName(ctx context.Context, name string) (*models.Project, error)
 	// List list projects
 	List(ctx context.Context, query *q.Query) ([]*models.Project, error)
+	// UpdateOwner updates the owner of the project
+	UpdateOwner(ctx context.Context, id int64, ownerID int) error
 	// Lists the roles of user for the specific project
 	ListRoles(ctx context.Context, projectID int64, userID int, groupIDs ...int) ([]int, error)
 }
@@ -65,7 +68,7 @@ func (d *dao) Create(ctx context.Context, project *models.Project) (int64, error
 		project.CreationTime = now
 		project.UpdateTime = now
 
-		projectID, err = o.Insert(project)
+		projectID, err = o.InsertWithCtx(ctx, project)
 		if err != nil {
 			return orm.WrapConflictError(err, "The project named %s already exists", project.Name)
 		}
@@ -79,7 +82,7 @@ func (d *dao) Create(ctx context.Context, project *models.Project) (int64, error
 			UpdateTime:   now,
 		}
 
-		if _, err := o.Insert(member); err != nil {
+		if _, err := o.InsertWithCtx(ctx, member); err != nil {
 			return err
 		}
 
@@ -102,7 +105,7 @@ func (d *dao) Count(ctx context.Context, query *q.Query) (total int64, err error
 		return 0, err
 	}
 
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 
 // Delete delete the project instance by id
@@ -120,7 +123,7 @@ func (d *dao) Delete(ctx context.Context, id int64) error {
 		return err
 	}
 
-	_, err = o.Update(project, "deleted", "name")
+	_, err = o.UpdateWithCtx(ctx, project, "deleted", "name")
 	return err
 }
 
@@ -132,7 +135,7 @@ func (d *dao) Get(ctx context.Context, id int64) (*models.Project, error) {
 	}
 
 	project := &models.Project{ProjectID: id, Deleted: false}
-	if err = o.Read(project, "project_id", "deleted"); err != nil {
+	if err = o.ReadWithCtx(ctx, project, "project_id", "deleted"); err != nil {
 		return nil, orm.WrapNotFoundError(err, "project %d not found", id)
 	}
 	return project, nil
@@ -146,12 +149,29 @@ func (d *dao) GetByName(ctx context.Context, name string) (*models.Project, erro
 	}
 
 	project := &models.Project{Name: name, Deleted: false}
-	if err := o.Read(project, "name", "deleted"); err != nil {
+	if err := o.ReadWithCtx(ctx, project, "name", "deleted"); err != nil {
 		return nil, orm.WrapNotFoundError(err, "project %s not found", name)
 	}
 	return project, nil
 }
 
+// UpdateOwner updates the owner of the project
+func (d *dao) UpdateOwner(ctx context.Context, id int64, ownerID int) error {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	n, err := o.UpdateWithCtx(ctx, &models.Project{ProjectID: id, OwnerID: ownerID}, "owner_id")
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("project %d not found", id)
+	}
+	return nil
+}
+
 func (d *dao) List(ctx context.Context, query *q.Query) ([]*models.Project, error) {
 	query = q.MustClone(query)
 	query.Keywords["deleted"] = false
@@ -162,7 +182,7 @@ func (d *dao) List(ctx context.Context, query *q.Query) ([]*models.Project, erro
 	}
 
 	projects := []*models.Project{}
-	if _, err := qs.All(&projects); err != nil {
+	if _, err := qs.AllWithCtx(ctx, &projects); err != nil {
 		return nil, err
 	}
 
@@ -25,6 +25,9 @@ import (
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
+	artifactdao "github.com/goharbor/harbor/src/pkg/artifact/dao"
+	labeldao "github.com/goharbor/harbor/src/pkg/label/dao"
+	labelmodel "github.com/goharbor/harbor/src/pkg/label/model"
 	"github.com/goharbor/harbor/src/pkg/project/models"
 	htesting "github.com/goharbor/harbor/src/testing"
 )
@@ -284,6 +287,31 @@ func (suite *DaoTestSuite) TestListByOwner() {
 	}
 }
 
+func (suite *DaoTestSuite) TestUpdateOwner() {
+	suite.WithUser(func(userID int64, username string) {
+		project := &models.Project{
+			Name:    "project-update-owner",
+			OwnerID: 1,
+		}
+		projectID, err := suite.dao.Create(orm.Context(), project)
+		suite.Nil(err)
+
+		defer suite.dao.Delete(orm.Context(), projectID)
+
+		suite.Nil(suite.dao.UpdateOwner(orm.Context(), projectID, int(userID)))
+
+		p, err := suite.dao.Get(orm.Context(), projectID)
+		suite.Nil(err)
+		suite.Equal(int(userID), p.OwnerID)
+	}, "new-owner")
+
+	{
+		// not found
+		err := suite.dao.UpdateOwner(orm.Context(), 10000, 1)
+		suite.True(errors.IsNotFoundErr(err))
+	}
+}
+
 func (suite *DaoTestSuite) TestListByMember() {
 	{
 		// project admin
@@ -341,6 +369,48 @@ func (suite *DaoTestSuite) TestListByMember() {
 	}
 }
 
+func (suite *DaoTestSuite) TestListByLabels() {
+	ctx := orm.Context()
+	afDao := artifactdao.New()
+	afID, err := afDao.Create(ctx, &artifactdao.Artifact{
+		Type:              "IMAGE",
+		MediaType:         "application/vnd.oci.image.config.v1+json",
+		ManifestMediaType: "application/vnd.oci.image.manifest.v1+json",
+		ProjectID:         1,
+		RepositoryID:      1,
+		RepositoryName:    "library/hello-world",
+		Digest:            "TestListByLabels",
+	})
+	suite.Require().Nil(err)
+	defer afDao.Delete(ctx, afID)
+
+	labelDao := labeldao.New()
+	labelID, err := labelDao.Create(ctx, &labelmodel.Label{Name: "TestListByLabels", Scope: "g", Level: "user"})
+	suite.Require().Nil(err)
+	defer labelDao.Delete(ctx, labelID)
+	otherLabelID, err := labelDao.Create(ctx, &labelmodel.Label{Name: "TestListByLabelsOther", Scope: "g", Level: "user"})
+	suite.Require().Nil(err)
+	defer labelDao.Delete(ctx, otherLabelID)
+	refID, err := labelDao.CreateReference(ctx, &labelmodel.Reference{LabelID: labelID, ArtifactID: afID})
+	suite.Require().Nil(err)
+	defer labelDao.DeleteReference(ctx, refID)
+
+	{
+		// default library project
+		projects, err := suite.dao.List(ctx, q.New(q.KeyWords{"labels": &q.AndList{Values: []interface{}{labelID}}}))
+		suite.Nil(err)
+		suite.Require().Len(projects, 1)
+		suite.Equal(int64(1), projects[0].ProjectID)
+	}
+
+	{
+		// the project must contain the artifacts attached with all the labels
+		projects, err := suite.dao.List(ctx, q.New(q.KeyWords{"labels": &q.AndList{Values: []interface{}{labelID, otherLabelID}}}))
+		suite.Nil(err)
+		suite.Len(projects, 0)
+	}
+}
+
 func (suite *DaoTestSuite) TestListRoles() {
 	{
 		// only projectAdmin
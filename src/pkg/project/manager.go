@@ -43,6 +43,9 @@ type Manager interface {
 	// List projects according to the query
 	List(ctx context.Context, query *q.Query) ([]*models.Project, error)
 
+	// UpdateOwner updates the owner of the project
+	UpdateOwner(ctx context.Context, id int64, ownerID int) error
+
 	// ListRoles returns the roles of user for the specific project
 	ListRoles(ctx context.Context, projectID int64, userID int, groupIDs ...int) ([]int, error)
 }
@@ -115,6 +118,11 @@ func (m *manager) List(ctx context.Context, query *q.Query) ([]*models.Project,
 	return m.dao.List(ctx, query)
 }
 
+// UpdateOwner updates the owner of the project
+func (m *manager) UpdateOwner(ctx context.Context, id int64, ownerID int) error {
+	return m.dao.UpdateOwner(ctx, id, ownerID)
+}
+
 // Lists the roles of user for the specific project
 func (m *manager) ListRoles(ctx context.Context, projectID int64, userID int, groupIDs ...int) ([]int, error) {
 	return m.dao.ListRoles(ctx, projectID, userID, groupIDs...)
@@ -61,7 +61,7 @@ func (d *dao) Create(ctx context.Context, projectID int64, name, value string) (
 		UpdateTime:   now,
 	}
 
-	id, err := o.Insert(md)
+	id, err := o.InsertWithCtx(ctx, md)
 	if err != nil {
 		if e := orm.AsConflictError(err, "metadata %s already exists for project %d", name, projectID); e != nil {
 			err = e
@@ -78,7 +78,7 @@ func (d *dao) Delete(ctx context.Context, query *q.Query) error {
 		return err
 	}
 
-	_, err = qs.Delete()
+	_, err = qs.DeleteWithCtx(ctx)
 	return err
 }
 
@@ -91,7 +91,7 @@ func (d *dao) Update(ctx context.Context, projectID int64, name, value string) e
 
 	qs = qs.Filter("project_id", projectID).Filter("name", name)
 
-	_, err = qs.Update(orm.Params{"value": value})
+	_, err = qs.UpdateWithCtx(ctx, orm.Params{"value": value})
 	return err
 }
 
@@ -103,7 +103,7 @@ func (d *dao) List(ctx context.Context, query *q.Query) ([]*models.ProjectMetada
 	}
 
 	mds := []*models.ProjectMetadata{}
-	if _, err := qs.All(&mds); err != nil {
+	if _, err := qs.AllWithCtx(ctx, &mds); err != nil {
 		return nil, err
 	}
 
@@ -19,8 +19,36 @@ const (
 	ProMetaPublic                   = "public"
 	ProMetaEnableContentTrust       = "enable_content_trust"
 	ProMetaEnableContentTrustCosign = "enable_content_trust_cosign"
-	ProMetaPreventVul               = "prevent_vul" // prevent vulnerable images from being pulled
+	ProMetaCosignTrustedKeys        = "cosign_trusted_keys"  // the PEM encoded public keys to verify the cosign signatures
+	ProMetaContentTrustPolicy       = "content_trust_policy" // how the signatures of the enabled content trust backends are required
+	ProMetaPreventVul               = "prevent_vul"          // prevent vulnerable images from being pulled
 	ProMetaSeverity                 = "severity"
 	ProMetaAutoScan                 = "auto_scan"
 	ProMetaReuseSysCVEAllowlist     = "reuse_sys_cve_allowlist"
+	ProMetaProxyCacheTTL            = "proxy_cache_ttl"          // the seconds to serve the cached tags without checking the upstream
+	ProMetaProxyNegativeCacheTTL    = "proxy_negative_cache_ttl" // the seconds to remember the references not found in the upstream
+	ProMetaEnableChartRepository    = "enable_chart_repository"  // whether the chart repository of the project is enabled
+	ProMetaProxyForeignLayerMode    = "proxy_foreign_layer_mode" // how the proxy cache project handles the foreign layers
+	ProMetaRegistryShard            = "registry_shard"           // the name of the registry shard serving the project
+	ProMetaIPAllowlist              = "ip_allowlist"             // the comma separated CIDRs the registry of the project is accessible from
+	ProMetaIPDenylist               = "ip_denylist"              // the comma separated CIDRs the registry of the project isn't accessible from
+	ProMetaListedInCatalog          = "listed_in_catalog"        // whether the public project is listed in the anonymous catalog
+	ProMetaPromotionApproverRole    = "promotion_approver_role"  // the minimum role to approve the promotions to the project
+	ProMetaQuarantine               = "quarantine"               // whether the new artifacts are quarantined until the scan and signature policies pass
+	ProMetaSyncedFromPrimary        = "synced_from_primary"      // whether the project skeleton is synced from the primary instance and read-only locally
+)
+
+// the policies to require the signatures of the enabled content trust backends
+const (
+	// ContentTrustPolicyAll requires the signatures of all the enabled backends
+	ContentTrustPolicyAll = "all"
+	// ContentTrustPolicyAny requires the signature of any one of the enabled backends
+	ContentTrustPolicyAny = "any"
+)
+
+// the roles which can be designated to approve the promotions
+const (
+	PromotionApproverProjectAdmin = "projectAdmin"
+	PromotionApproverMaintainer   = "maintainer"
+	PromotionApproverDeveloper    = "developer"
 )
@@ -21,8 +21,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
 	allowlist "github.com/goharbor/harbor/src/pkg/allowlist/models"
+	"github.com/goharbor/harbor/src/pkg/networkpolicy"
+	regModels "github.com/goharbor/harbor/src/pkg/reg/model"
 )
 
 const (
@@ -112,6 +116,98 @@ func (p *Project) ContentTrustCosignEnabled() bool {
 	return isTrue(enabled)
 }
 
+// CosignTrustedKeys returns the PEM encoded public keys to verify the cosign signatures
+func (p *Project) CosignTrustedKeys() string {
+	keys, _ := p.GetMetadata(ProMetaCosignTrustedKeys)
+	return keys
+}
+
+// ContentTrustPolicy returns the policy to require the signatures of the enabled content trust backends,
+// the signatures of all the enabled backends are required by default
+func (p *Project) ContentTrustPolicy() string {
+	policy, _ := p.GetMetadata(ProMetaContentTrustPolicy)
+	if policy == ContentTrustPolicyAny {
+		return ContentTrustPolicyAny
+	}
+	return ContentTrustPolicyAll
+}
+
+// ChartRepositoryEnabled returns whether the chart repository of the project is enabled,
+// it's enabled unless it's disabled explicitly
+func (p *Project) ChartRepositoryEnabled() bool {
+	enabled, exist := p.GetMetadata(ProMetaEnableChartRepository)
+	if !exist {
+		return true
+	}
+	return isTrue(enabled)
+}
+
+// ProxyCacheTTL returns the duration to serve the cached tags of the proxy cache project without checking
+// the upstream registry, 0 means the upstream is checked for every pull
+func (p *Project) ProxyCacheTTL() time.Duration {
+	return p.durationMetadata(ProMetaProxyCacheTTL)
+}
+
+// ProxyNegativeCacheTTL returns the duration to remember the references not found in the upstream registry
+// of the proxy cache project, 0 means the upstream is checked for every pull
+func (p *Project) ProxyNegativeCacheTTL() time.Duration {
+	return p.durationMetadata(ProMetaProxyNegativeCacheTTL)
+}
+
+// ProxyForeignLayerMode returns how the proxy cache project handles the foreign layers,
+// they're skipped and pulled from their URLs by the clients by default
+func (p *Project) ProxyForeignLayerMode() string {
+	mode, _ := p.GetMetadata(ProMetaProxyForeignLayerMode)
+	if mode == regModels.ForeignLayerModePullThrough {
+		return regModels.ForeignLayerModePullThrough
+	}
+	return regModels.ForeignLayerModeSkip
+}
+
+// PromotionApproverRole returns the ID of the minimum role to approve the promotions to the project,
+// only the project admins can approve them by default
+func (p *Project) PromotionApproverRole() int {
+	role, _ := p.GetMetadata(ProMetaPromotionApproverRole)
+	switch role {
+	case PromotionApproverMaintainer:
+		return common.RoleMaintainer
+	case PromotionApproverDeveloper:
+		return common.RoleDeveloper
+	default:
+		return common.RoleProjectAdmin
+	}
+}
+
+// QuarantineEnabled returns whether the newly pushed artifacts of the project are quarantined
+// until the scan and signature policies of the project pass
+func (p *Project) QuarantineEnabled() bool {
+	enabled, exist := p.GetMetadata(ProMetaQuarantine)
+	if !exist {
+		return false
+	}
+	return isTrue(enabled)
+}
+
+// NetworkPolicy returns the policy restricting the networks the registry of the project is accessible from
+func (p *Project) NetworkPolicy() *networkpolicy.Policy {
+	allowed, _ := p.GetMetadata(ProMetaIPAllowlist)
+	denied, _ := p.GetMetadata(ProMetaIPDenylist)
+	return &networkpolicy.Policy{Allowlist: allowed, Denylist: denied}
+}
+
+// durationMetadata returns the metadata specified by the key in seconds as the duration
+func (p *Project) durationMetadata(key string) time.Duration {
+	value, exist := p.GetMetadata(key)
+	if !exist {
+		return 0
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
 // VulPrevented ...
 func (p *Project) VulPrevented() bool {
 	prevent, exist := p.GetMetadata(ProMetaPreventVul)
@@ -148,6 +244,18 @@ func (p *Project) AutoScan() bool {
 	return isTrue(auto)
 }
 
+// ListedInCatalog returns whether the project is listed in the public catalog, only the public projects can be listed
+func (p *Project) ListedInCatalog() bool {
+	if !p.IsPublic() {
+		return false
+	}
+	listed, exist := p.GetMetadata(ProMetaListedInCatalog)
+	if !exist {
+		return false
+	}
+	return isTrue(listed)
+}
+
 // FilterByPublic returns orm.QuerySeter with public filter
 func (p *Project) FilterByPublic(ctx context.Context, qs orm.QuerySeter, key string, value interface{}) orm.QuerySeter {
 	subQuery := `SELECT project_id FROM project_metadata WHERE name = 'public' AND value = '%s'`
@@ -159,6 +267,25 @@ func (p *Project) FilterByPublic(ctx context.Context, qs orm.QuerySeter, key str
 	return qs.FilterRaw("project_id", fmt.Sprintf("IN (%s)", subQuery))
 }
 
+// FilterByListedInCatalog returns orm.QuerySeter with the filter of the projects listed in the public catalog,
+// the public filter should be specified together to select only the public projects
+func (p *Project) FilterByListedInCatalog(ctx context.Context, qs orm.QuerySeter, key string, value interface{}) orm.QuerySeter {
+	subQuery := `SELECT project_id FROM project_metadata WHERE name = 'listed_in_catalog' AND value = 'true'`
+	if isTrue(value) {
+		return qs.FilterRaw("project_id", fmt.Sprintf("IN (%s)", subQuery))
+	}
+	return qs.FilterRaw("project_id", fmt.Sprintf("NOT IN (%s)", subQuery))
+}
+
+// FilterBySyncedFromPrimary returns orm.QuerySeter with the filter of the projects synced from the primary instance
+func (p *Project) FilterBySyncedFromPrimary(ctx context.Context, qs orm.QuerySeter, key string, value interface{}) orm.QuerySeter {
+	subQuery := `SELECT project_id FROM project_metadata WHERE name = 'synced_from_primary' AND value = 'true'`
+	if isTrue(value) {
+		return qs.FilterRaw("project_id", fmt.Sprintf("IN (%s)", subQuery))
+	}
+	return qs.FilterRaw("project_id", fmt.Sprintf("NOT IN (%s)", subQuery))
+}
+
 // FilterByOwner returns orm.QuerySeter with owner filter
 func (p *Project) FilterByOwner(ctx context.Context, qs orm.QuerySeter, key string, value interface{}) orm.QuerySeter {
 	username, ok := value.(string)
@@ -197,6 +324,28 @@ func (p *Project) FilterByMember(ctx context.Context, qs orm.QuerySeter, key str
 	return qs.FilterRaw("project_id", fmt.Sprintf("IN (%s)", subQuery))
 }
 
+// FilterByLabels filters the projects which contain the artifacts attached with all the specified labels,
+// the value is the label ID list with the intersection relationship, e.g. q=labels=(1 2)
+func (p *Project) FilterByLabels(ctx context.Context, qs orm.QuerySeter, key string, value interface{}) orm.QuerySeter {
+	al, ok := value.(*q.AndList)
+	if !ok || len(al.Values) == 0 {
+		return qs
+	}
+	var collections []string
+	for _, v := range al.Values {
+		labelID, ok := v.(int64)
+		if !ok {
+			// make sure no project will be selected with the invalid label ID
+			return qs.FilterRaw("project_id", "IN (-1)")
+		}
+		// param "labelID" is integer, no need to sanitize
+		collections = append(collections, fmt.Sprintf(`SELECT a.project_id FROM artifact AS a
+				JOIN label_reference AS lr ON a.id = lr.artifact_id
+				WHERE lr.label_id = %d`, labelID))
+	}
+	return qs.FilterRaw("project_id", fmt.Sprintf("IN (%s)", strings.Join(collections, " INTERSECT ")))
+}
+
 // FilterByNames returns orm.QuerySeter with name filter
 func (p *Project) FilterByNames(ctx context.Context, qs orm.QuerySeter, key string, value interface{}) orm.QuerySeter {
 	query, ok := value.(*NamesQuery)
@@ -0,0 +1,82 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/project/readme/model"
+)
+
+// DAO defines the interface to access the READMEs of the projects
+type DAO interface {
+	// Get the README of the project, the not found error is returned if the project has no README
+	Get(ctx context.Context, projectID int64) (*model.Readme, error)
+	// Set creates or updates the README of the project
+	Set(ctx context.Context, readme *model.Readme) error
+	// Delete the README of the project
+	Delete(ctx context.Context, projectID int64) error
+}
+
+// New returns an instance of the default DAO
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+func (d *dao) Get(ctx context.Context, projectID int64) (*model.Readme, error) {
+	readmes := []*model.Readme{}
+	qs, err := orm.QuerySetter(ctx, &model.Readme{}, q.New(q.KeyWords{"ProjectID": projectID}))
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.AllWithCtx(ctx, &readmes); err != nil {
+		return nil, err
+	}
+	if len(readmes) == 0 {
+		return nil, errors.NotFoundError(nil).WithMessage("the README of the project %d not found", projectID)
+	}
+	return readmes[0], nil
+}
+
+func (d *dao) Set(ctx context.Context, readme *model.Readme) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	readme.UpdateTime = time.Now()
+	_, err = ormer.InsertOrUpdateWithCtx(ctx, readme, "project_id")
+	return err
+}
+
+func (d *dao) Delete(ctx context.Context, projectID int64) error {
+	qs, err := orm.QuerySetter(ctx, &model.Readme{}, q.New(q.KeyWords{"ProjectID": projectID}))
+	if err != nil {
+		return err
+	}
+	n, err := qs.DeleteWithCtx(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("the README of the project %d not found", projectID)
+	}
+	return nil
+}
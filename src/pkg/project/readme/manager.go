@@ -0,0 +1,64 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package readme
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/project/readme/dao"
+	"github.com/goharbor/harbor/src/pkg/project/readme/model"
+)
+
+// MaxContentSize is the max size in bytes of the content of the README
+const MaxContentSize = 256 * 1024
+
+// Mgr is the global README manager instance
+var Mgr = New()
+
+// Manager manages the markdown landing pages of the projects
+type Manager interface {
+	// Get the README of the project
+	Get(ctx context.Context, projectID int64) (*model.Readme, error)
+	// Set sanitizes the content and creates or updates the README of the project
+	Set(ctx context.Context, readme *model.Readme) error
+	// Delete the README of the project
+	Delete(ctx context.Context, projectID int64) error
+}
+
+// New returns a default implementation of Manager
+func New() Manager {
+	return &manager{dao: dao.New()}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+func (m *manager) Get(ctx context.Context, projectID int64) (*model.Readme, error) {
+	return m.dao.Get(ctx, projectID)
+}
+
+func (m *manager) Set(ctx context.Context, readme *model.Readme) error {
+	if len(readme.Content) > MaxContentSize {
+		return errors.BadRequestError(nil).WithMessage("the size of the README exceeds the limit %d bytes", MaxContentSize)
+	}
+	readme.Content = Sanitize(readme.Content)
+	return m.dao.Set(ctx, readme)
+}
+
+func (m *manager) Delete(ctx context.Context, projectID int64) error {
+	return m.dao.Delete(ctx, projectID)
+}
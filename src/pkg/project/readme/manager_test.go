@@ -0,0 +1,56 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package readme
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/project/readme/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/project/readme/dao"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	dao *dao.DAO
+	mgr *manager
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.dao = &dao.DAO{}
+	m.mgr = &manager{dao: m.dao}
+}
+
+func (m *managerTestSuite) TestSet() {
+	mock.OnAnything(m.dao, "Set").Return(nil).Once()
+	readme := &model.Readme{ProjectID: 1, Content: "# Hello<script>alert(1)</script>"}
+	m.Require().Nil(m.mgr.Set(context.Background(), readme))
+	m.Equal("# Hello", readme.Content)
+	m.dao.AssertExpectations(m.T())
+
+	// exceed the size limit
+	err := m.mgr.Set(context.Background(), &model.Readme{ProjectID: 1, Content: strings.Repeat("a", MaxContentSize+1)})
+	m.True(errors.IsErr(err, errors.BadRequestCode))
+	m.dao.AssertNumberOfCalls(m.T(), "Set", 1)
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
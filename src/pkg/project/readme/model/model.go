@@ -0,0 +1,39 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+func init() {
+	orm.RegisterModel(&Readme{})
+}
+
+// Readme is the markdown landing page of the project, every project has one README at most
+type Readme struct {
+	ID         int64     `orm:"pk;auto;column(id)" json:"id"`
+	ProjectID  int64     `orm:"column(project_id)" json:"project_id"`
+	Content    string    `orm:"column(content)" json:"content"`
+	UpdatedBy  string    `orm:"column(updated_by)" json:"updated_by"`
+	UpdateTime time.Time `orm:"column(update_time)" json:"update_time"`
+}
+
+// TableName ...
+func (r *Readme) TableName() string {
+	return "project_readme"
+}
@@ -0,0 +1,279 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package readme
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"strings"
+
+	xhtml "golang.org/x/net/html"
+)
+
+var (
+	fenceRegexp = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	// the destinations of the inline links and the link reference definitions
+	linkRegexp     = regexp.MustCompile(`(\]\(\s*<?)((?:[^\s()<>]|\([^\s()]*\))+)`)
+	linkRefRegexp  = regexp.MustCompile(`(?m)^( {0,3}\[[^\]]+\]:[ \t]*<?)([^\s>]+)`)
+	autolinkRegexp = regexp.MustCompile("<(?i:https?|ftp|mailto):[^\\s<>]*>|<[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9.-]+>")
+	escapeRegexp   = regexp.MustCompile("\\\\([!-/:-@\\[-`{-~])")
+
+	// the tags and the attributes which are kept in the markdown text
+	allowedTags = map[string]struct{}{
+		"a": {}, "abbr": {}, "b": {}, "blockquote": {}, "br": {}, "code": {}, "dd": {}, "del": {}, "details": {},
+		"div": {}, "dl": {}, "dt": {}, "em": {}, "h1": {}, "h2": {}, "h3": {}, "h4": {}, "h5": {}, "h6": {},
+		"hr": {}, "i": {}, "img": {}, "ins": {}, "kbd": {}, "li": {}, "ol": {}, "p": {}, "pre": {}, "q": {},
+		"s": {}, "samp": {}, "span": {}, "strike": {}, "strong": {}, "sub": {}, "summary": {}, "sup": {},
+		"table": {}, "tbody": {}, "td": {}, "tfoot": {}, "th": {}, "thead": {}, "tr": {}, "tt": {}, "u": {},
+		"ul": {}, "var": {},
+	}
+	allowedAttrs = map[string]struct{}{
+		"align": {}, "alt": {}, "colspan": {}, "height": {}, "href": {}, "open": {}, "rowspan": {}, "src": {},
+		"start": {}, "title": {}, "width": {},
+	}
+	urlAttrs = map[string]struct{}{"href": {}, "src": {}}
+	// the tags which are removed everywhere, the content of them is removed in the markdown text as well
+	dangerousTags = map[string]struct{}{
+		"applet": {}, "base": {}, "embed": {}, "frame": {}, "frameset": {}, "iframe": {}, "link": {}, "math": {},
+		"meta": {}, "noscript": {}, "object": {}, "script": {}, "style": {}, "svg": {}, "template": {},
+	}
+	voidTags      = map[string]struct{}{"base": {}, "embed": {}, "frame": {}, "link": {}, "meta": {}}
+	safeSchemes   = map[string]struct{}{"http": {}, "https": {}, "mailto": {}, "ftp": {}}
+	unsafeSchemes = []string{"javascript:", "vbscript:", "livescript:", "data:"}
+)
+
+// Sanitize removes the unsafe HTML and links from the markdown content. Only the allowlisted tags and
+// attributes are kept in the markdown text, while the code blocks and the code spans keep the tags as
+// they are unless the tags are dangerous, as the code may be rendered as the raw HTML inside the HTML blocks.
+func Sanitize(content string) string {
+	var result, text, code strings.Builder
+	fence := ""
+	for _, line := range strings.SplitAfter(content, "\n") {
+		if len(fence) == 0 {
+			m := fenceRegexp.FindStringSubmatch(line)
+			// the info string of the backtick fence cannot contain backticks
+			if m == nil || (m[1][0] == '`' && strings.Contains(line[len(m[0]):], "`")) {
+				text.WriteString(line)
+				continue
+			}
+			result.WriteString(sanitizeText(text.String()))
+			text.Reset()
+			fence = m[1]
+			code.WriteString(line)
+			continue
+		}
+		code.WriteString(line)
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" && len(line)-len(strings.TrimLeft(line, " ")) < 4 {
+			result.WriteString(sanitizeHTML(code.String(), false))
+			code.Reset()
+			fence = ""
+		}
+	}
+	// the fence which isn't closed lasts to the end of the content
+	result.WriteString(sanitizeHTML(code.String(), false))
+	result.WriteString(sanitizeText(text.String()))
+	return result.String()
+}
+
+// sanitizeText sanitizes the markdown text outside the code blocks, the code spans and the autolinks
+// are sanitized leniently
+func sanitizeText(s string) string {
+	var result strings.Builder
+	for len(s) > 0 {
+		start, end := nextVerbatim(s)
+		if start < 0 {
+			result.WriteString(sanitizeMarkdown(s))
+			break
+		}
+		result.WriteString(sanitizeMarkdown(s[:start]))
+		result.WriteString(sanitizeHTML(s[start:end], false))
+		s = s[end:]
+	}
+	return result.String()
+}
+
+// nextVerbatim returns the position of the first code span or autolink in the text, -1 is returned if there is none
+func nextVerbatim(s string) (int, int) {
+	linkStart, linkEnd := -1, -1
+	if loc := autolinkRegexp.FindStringIndex(s); loc != nil {
+		linkStart, linkEnd = loc[0], loc[1]
+	}
+	for i := 0; i < len(s); {
+		if linkStart >= 0 && i >= linkStart {
+			return linkStart, linkEnd
+		}
+		if s[i] != '`' {
+			i++
+			continue
+		}
+		n := len(s[i:]) - len(strings.TrimLeft(s[i:], "`"))
+		// the code span closes with a backtick string of the same length
+		for j := i + n; j < len(s); {
+			if s[j] != '`' {
+				j++
+				continue
+			}
+			m := len(s[j:]) - len(strings.TrimLeft(s[j:], "`"))
+			if m == n {
+				// the autolink inside the code span is a part of the code
+				return i, j + m
+			}
+			j += m
+		}
+		i += n
+	}
+	return linkStart, linkEnd
+}
+
+// sanitizeMarkdown sanitizes the HTML in the markdown text strictly and replaces the unsafe link destinations
+func sanitizeMarkdown(s string) string {
+	s = sanitizeHTML(s, true)
+	replace := func(re *regexp.Regexp) {
+		s = re.ReplaceAllStringFunc(s, func(match string) string {
+			sub := re.FindStringSubmatch(match)
+			dest := html.UnescapeString(escapeRegexp.ReplaceAllString(sub[2], "$1"))
+			if safeURL(dest) {
+				return match
+			}
+			return sub[1] + "#"
+		})
+	}
+	replace(linkRegexp)
+	replace(linkRefRegexp)
+	return s
+}
+
+// sanitizeHTML removes the unsafe tags, attributes and comments from the HTML. In the strict mode only
+// the allowlisted tags and attributes are kept, otherwise only the dangerous ones are removed. The tags
+// which aren't closed at the end are removed so that they cannot be combined with the following content.
+func sanitizeHTML(s string, strict bool) string {
+	var buf bytes.Buffer
+	z := xhtml.NewTokenizer(strings.NewReader(s))
+	skip := 0
+	for {
+		tt := z.Next()
+		if tt == xhtml.ErrorToken {
+			return buf.String()
+		}
+		raw := z.Raw()
+		switch tt {
+		case xhtml.TextToken:
+			if skip == 0 {
+				buf.Write(raw)
+			}
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken, xhtml.EndTagToken:
+			token := z.Token()
+			if !keepTag(&token, tt, strict, &skip) {
+				if tt != xhtml.EndTagToken {
+					// inspect the content of the raw text elements, e.g. script, as the tag is removed
+					z.NextIsNotRawText()
+				}
+				continue
+			}
+			if sanitizeAttrs(&token, strict) {
+				buf.Write(raw)
+			} else {
+				buf.WriteString(token.String())
+			}
+		case xhtml.CommentToken, xhtml.DoctypeToken:
+			if !strict && skip == 0 {
+				buf.Write(raw)
+			}
+		}
+	}
+}
+
+// keepTag returns whether the tag is kept, the skip counts the dangerous elements whose content is removed
+func keepTag(token *xhtml.Token, tt xhtml.TokenType, strict bool, skip *int) bool {
+	if _, ok := dangerousTags[token.Data]; ok {
+		if strict {
+			if _, void := voidTags[token.Data]; tt != xhtml.EndTagToken && !void {
+				*skip++
+			} else if tt == xhtml.EndTagToken && *skip > 0 {
+				*skip--
+			}
+		}
+		return false
+	}
+	if *skip > 0 {
+		return false
+	}
+	if strict {
+		if _, ok := allowedTags[token.Data]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// sanitizeAttrs removes the unsafe attributes of the tag, returns whether all the attributes are kept
+func sanitizeAttrs(token *xhtml.Token, strict bool) bool {
+	attrs := make([]xhtml.Attribute, 0, len(token.Attr))
+	for _, attr := range token.Attr {
+		key := attr.Key
+		if len(attr.Namespace) > 0 {
+			key = attr.Namespace + ":" + attr.Key
+		}
+		if strict {
+			if _, ok := allowedAttrs[key]; !ok {
+				continue
+			}
+			if _, ok := urlAttrs[key]; ok && !safeURL(attr.Val) {
+				continue
+			}
+		} else if strings.HasPrefix(key, "on") || key == "srcdoc" || unsafeURL(attr.Val) {
+			continue
+		}
+		attrs = append(attrs, attr)
+	}
+	kept := len(attrs) == len(token.Attr)
+	token.Attr = attrs
+	return kept
+}
+
+// normalizeURL removes the characters ignored by the browsers from the URL
+func normalizeURL(u string) string {
+	u = strings.TrimLeftFunc(u, func(r rune) bool { return r <= ' ' })
+	return strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return -1
+		}
+		return r
+	}, u)
+}
+
+// safeURL returns whether the URL is relative or in the allowlisted schemes
+func safeURL(u string) bool {
+	u = normalizeURL(u)
+	i := strings.IndexAny(u, ":/?#")
+	if i < 0 || u[i] != ':' {
+		return true
+	}
+	_, ok := safeSchemes[strings.ToLower(u[:i])]
+	return ok
+}
+
+// unsafeURL returns whether the value is a URL in the schemes which can run scripts
+func unsafeURL(u string) bool {
+	u = strings.ToLower(normalizeURL(u))
+	for _, scheme := range unsafeSchemes {
+		if strings.HasPrefix(u, scheme) {
+			return true
+		}
+	}
+	return false
+}
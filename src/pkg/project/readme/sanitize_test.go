@@ -0,0 +1,90 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package readme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitize(t *testing.T) {
+	cases := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "plain markdown",
+			content:  "# Hello\n\nPull the image with `docker pull <registry>/library/hello-world`.\n",
+			expected: "# Hello\n\nPull the image with `docker pull <registry>/library/hello-world`.\n",
+		},
+		{
+			name:     "allowed tags",
+			content:  "<details open><summary>Usage</summary><img src=\"https://example.com/a.png\" width=\"100\"></details>",
+			expected: "<details open><summary>Usage</summary><img src=\"https://example.com/a.png\" width=\"100\"></details>",
+		},
+		{
+			name:     "script",
+			content:  "before<script>alert(1)</script>after",
+			expected: "beforeafter",
+		},
+		{
+			name:     "event handler",
+			content:  "<img src=\"a.png\" onerror=\"alert(1)\">",
+			expected: "<img src=\"a.png\">",
+		},
+		{
+			name:     "unsafe url",
+			content:  "<a href=\"java&#x09;script:alert(1)\">x</a>",
+			expected: "<a>x</a>",
+		},
+		{
+			name:     "raw text element",
+			content:  "<xmp><img src=x onerror=alert(1)></xmp>",
+			expected: "<img src=\"x\">",
+		},
+		{
+			name:     "markdown links",
+			content:  "[a](https://example.com) [b](javascript:alert(1)) [c](java\\script&colon;alert(1))\n[d]: vbscript:msgbox\n",
+			expected: "[a](https://example.com) [b](#) [c](#)\n[d]: #\n",
+		},
+		{
+			name:     "autolink",
+			content:  "see <https://example.com/docs> and <dev@example.com>",
+			expected: "see <https://example.com/docs> and <dev@example.com>",
+		},
+		{
+			name:     "code block",
+			content:  "```\n<none> <b onclick=\"alert(1)\">\n```\n<none>",
+			expected: "```\n<none> <b>\n```\n",
+		},
+		{
+			name:     "code block in html block",
+			content:  "<div>\n```\n<script>alert(1)</script>\n```\n</div>",
+			expected: "<div>\n```\nalert(1)\n```\n</div>",
+		},
+		{
+			name:     "unclosed tag",
+			content:  "<img src=x `y` onerror=alert(1)>",
+			expected: "`y` onerror=alert(1)>",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, Sanitize(c.content))
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/distribution/manifest/schema2"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/project/statistic/models"
+)
+
+// DAO is the data access object interface for project statistic
+type DAO interface {
+	// Get returns the statistic of the project
+	Get(ctx context.Context, projectID int64) (*models.ProjectStatistic, error)
+	// List returns the statistics filtered by the query
+	List(ctx context.Context, query *q.Query) ([]*models.ProjectStatistic, error)
+	// Refresh recalculates the statistics of the specified projects, the statistics of the deleted projects are removed
+	Refresh(ctx context.Context, projectIDs ...int64) error
+	// RefreshTotal recalculates the statistic holding the totals of all projects
+	RefreshTotal(ctx context.Context) error
+	// Purge removes the statistics of the projects which don't exist anymore
+	Purge(ctx context.Context) (int64, error)
+}
+
+// New returns an instance of the default DAO
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+// Get returns the statistic of the project
+func (d *dao) Get(ctx context.Context, projectID int64) (*models.ProjectStatistic, error) {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statistic := &models.ProjectStatistic{ProjectID: projectID}
+	if err := o.ReadWithCtx(ctx, statistic); err != nil {
+		if e := orm.AsNotFoundError(err, "statistic of project %d not found", projectID); e != nil {
+			err = e
+		}
+		return nil, err
+	}
+	return statistic, nil
+}
+
+// List returns the statistics filtered by the query
+func (d *dao) List(ctx context.Context, query *q.Query) ([]*models.ProjectStatistic, error) {
+	statistics := []*models.ProjectStatistic{}
+	qs, err := orm.QuerySetter(ctx, &models.ProjectStatistic{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.AllWithCtx(ctx, &statistics); err != nil {
+		return nil, err
+	}
+	return statistics, nil
+}
+
+// Refresh recalculates the statistics of the specified projects, the statistics of the deleted projects are removed
+func (d *dao) Refresh(ctx context.Context, projectIDs ...int64) error {
+	if len(projectIDs) == 0 {
+		return nil
+	}
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]interface{}, len(projectIDs))
+	for i, id := range projectIDs {
+		ids[i] = id
+	}
+	placeholder := orm.ParamPlaceholderForIn(len(ids))
+
+	sql := fmt.Sprintf(`INSERT INTO project_statistic (project_id, repo_count, tag_count, storage,
+		project_admin_count, maintainer_count, developer_count, guest_count, limited_guest_count, update_time)
+	SELECT p.project_id,
+		(SELECT COUNT(*) FROM repository r WHERE r.project_id = p.project_id),
+		(SELECT COUNT(*) FROM tag t JOIN repository r ON t.repository_id = r.repository_id WHERE r.project_id = p.project_id),
+		(SELECT COALESCE(SUM(b.size), 0) FROM blob b JOIN project_blob pb ON b.id = pb.blob_id
+			WHERE pb.project_id = p.project_id AND b.content_type != ?),
+		m.project_admin_count, m.maintainer_count, m.developer_count, m.guest_count, m.limited_guest_count,
+		CURRENT_TIMESTAMP
+	FROM project p, LATERAL (SELECT
+		COUNT(*) FILTER (WHERE role = ?) AS project_admin_count,
+		COUNT(*) FILTER (WHERE role = ?) AS maintainer_count,
+		COUNT(*) FILTER (WHERE role = ?) AS developer_count,
+		COUNT(*) FILTER (WHERE role = ?) AS guest_count,
+		COUNT(*) FILTER (WHERE role = ?) AS limited_guest_count
+		FROM project_member WHERE project_id = p.project_id) m
+	WHERE p.deleted = false AND p.project_id IN (%s)
+	ON CONFLICT (project_id) DO UPDATE SET
+		repo_count = EXCLUDED.repo_count,
+		tag_count = EXCLUDED.tag_count,
+		storage = EXCLUDED.storage,
+		project_admin_count = EXCLUDED.project_admin_count,
+		maintainer_count = EXCLUDED.maintainer_count,
+		developer_count = EXCLUDED.developer_count,
+		guest_count = EXCLUDED.guest_count,
+		limited_guest_count = EXCLUDED.limited_guest_count,
+		update_time = EXCLUDED.update_time`, placeholder)
+	params := []interface{}{schema2.MediaTypeForeignLayer, common.RoleProjectAdmin, common.RoleMaintainer,
+		common.RoleDeveloper, common.RoleGuest, common.RoleLimitedGuest}
+	if _, err := o.Raw(sql, append(params, ids...)...).Exec(); err != nil {
+		return err
+	}
+
+	sql = fmt.Sprintf(`DELETE FROM project_statistic WHERE project_id IN (%s)
+		AND project_id NOT IN (SELECT project_id FROM project WHERE deleted = false)`, placeholder)
+	_, err = o.Raw(sql, ids...).Exec()
+	return err
+}
+
+// RefreshTotal recalculates the statistic holding the totals of all projects,
+// the storage is the size of the blobs rather than the sum of the projects as the blobs are shared
+func (d *dao) RefreshTotal(ctx context.Context) error {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	sql := `INSERT INTO project_statistic (project_id, repo_count, tag_count, storage,
+		project_admin_count, maintainer_count, developer_count, guest_count, limited_guest_count, update_time)
+	SELECT ?, COALESCE(SUM(repo_count), 0), COALESCE(SUM(tag_count), 0),
+		(SELECT COALESCE(SUM(size), 0) FROM blob WHERE content_type != ?),
+		COALESCE(SUM(project_admin_count), 0), COALESCE(SUM(maintainer_count), 0), COALESCE(SUM(developer_count), 0),
+		COALESCE(SUM(guest_count), 0), COALESCE(SUM(limited_guest_count), 0), CURRENT_TIMESTAMP
+	FROM project_statistic WHERE project_id != ?
+	ON CONFLICT (project_id) DO UPDATE SET
+		repo_count = EXCLUDED.repo_count,
+		tag_count = EXCLUDED.tag_count,
+		storage = EXCLUDED.storage,
+		project_admin_count = EXCLUDED.project_admin_count,
+		maintainer_count = EXCLUDED.maintainer_count,
+		developer_count = EXCLUDED.developer_count,
+		guest_count = EXCLUDED.guest_count,
+		limited_guest_count = EXCLUDED.limited_guest_count,
+		update_time = EXCLUDED.update_time`
+	_, err = o.Raw(sql, models.TotalID, schema2.MediaTypeForeignLayer, models.TotalID).Exec()
+	return err
+}
+
+// Purge removes the statistics of the projects which don't exist anymore
+func (d *dao) Purge(ctx context.Context) (int64, error) {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	sql := `DELETE FROM project_statistic WHERE project_id != ?
+		AND project_id NOT IN (SELECT project_id FROM project WHERE deleted = false)`
+	result, err := o.Raw(sql, models.TotalID).Exec()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,96 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"testing"
+
+	beegoorm "github.com/beego/beego/v2/client/orm"
+	"github.com/stretchr/testify/suite"
+
+	common_dao "github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/project/statistic/models"
+)
+
+type daoTestSuite struct {
+	suite.Suite
+	dao DAO
+	ctx context.Context
+}
+
+func (d *daoTestSuite) SetupSuite() {
+	d.dao = New()
+	common_dao.PrepareTestForPostgresSQL()
+	d.ctx = orm.NewContext(nil, beegoorm.NewOrm())
+}
+
+func (d *daoTestSuite) TestRefresh() {
+	// the library project with the admin as the project admin
+	d.Require().Nil(d.dao.Refresh(d.ctx, 1))
+	statistic, err := d.dao.Get(d.ctx, 1)
+	d.Require().Nil(err)
+	d.Equal(int64(1), statistic.ProjectID)
+	d.GreaterOrEqual(statistic.ProjectAdminCount, int64(1))
+
+	// refreshing the project which doesn't exist removes its statistic
+	ormer, err := orm.FromContext(d.ctx)
+	d.Require().Nil(err)
+	_, err = ormer.Raw("insert into project_statistic (project_id, repo_count) values (10000, 1)").Exec()
+	d.Require().Nil(err)
+	d.Require().Nil(d.dao.Refresh(d.ctx, 10000))
+	_, err = d.dao.Get(d.ctx, 10000)
+	d.True(errors.IsNotFoundErr(err))
+
+	statistics, err := d.dao.List(d.ctx, q.New(q.KeyWords{"ProjectID": q.NewOrList([]interface{}{int64(1), int64(10000)})}))
+	d.Require().Nil(err)
+	d.Require().Len(statistics, 1)
+	d.Equal(int64(1), statistics[0].ProjectID)
+}
+
+func (d *daoTestSuite) TestRefreshTotal() {
+	d.Require().Nil(d.dao.Refresh(d.ctx, 1))
+	d.Require().Nil(d.dao.RefreshTotal(d.ctx))
+	project, err := d.dao.Get(d.ctx, 1)
+	d.Require().Nil(err)
+	total, err := d.dao.Get(d.ctx, models.TotalID)
+	d.Require().Nil(err)
+	d.GreaterOrEqual(total.RepoCount, project.RepoCount)
+	d.GreaterOrEqual(total.ProjectAdminCount, project.ProjectAdminCount)
+}
+
+func (d *daoTestSuite) TestPurge() {
+	ormer, err := orm.FromContext(d.ctx)
+	d.Require().Nil(err)
+	_, err = ormer.Raw("insert into project_statistic (project_id, repo_count) values (10001, 1)").Exec()
+	d.Require().Nil(err)
+	d.Require().Nil(d.dao.RefreshTotal(d.ctx))
+
+	n, err := d.dao.Purge(d.ctx)
+	d.Require().Nil(err)
+	d.GreaterOrEqual(n, int64(1))
+	_, err = d.dao.Get(d.ctx, 10001)
+	d.True(errors.IsNotFoundErr(err))
+	// the totals are kept
+	_, err = d.dao.Get(d.ctx, models.TotalID)
+	d.Nil(err)
+}
+
+func TestDaoTestSuite(t *testing.T) {
+	suite.Run(t, &daoTestSuite{})
+}
@@ -0,0 +1,78 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistic
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/project/statistic/dao"
+	"github.com/goharbor/harbor/src/pkg/project/statistic/models"
+)
+
+// Mgr is the global project statistic manager instance
+var Mgr = New()
+
+// Manager is used for project statistic management
+type Manager interface {
+	// Get the statistic of the project, use "models.TotalID" to get the totals of all projects
+	Get(ctx context.Context, projectID int64) (*models.ProjectStatistic, error)
+	// List the statistics of the specified projects, the projects without statistic are omitted
+	List(ctx context.Context, projectIDs ...int64) ([]*models.ProjectStatistic, error)
+	// Refresh recalculates the statistics of the specified projects
+	Refresh(ctx context.Context, projectIDs ...int64) error
+	// RefreshTotal recalculates the totals of all projects
+	RefreshTotal(ctx context.Context) error
+	// Purge removes the statistics of the projects which don't exist anymore
+	Purge(ctx context.Context) (int64, error)
+}
+
+// New returns a default implementation of Manager
+func New() Manager {
+	return &manager{
+		dao: dao.New(),
+	}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+func (m *manager) Get(ctx context.Context, projectID int64) (*models.ProjectStatistic, error) {
+	return m.dao.Get(ctx, projectID)
+}
+
+func (m *manager) List(ctx context.Context, projectIDs ...int64) ([]*models.ProjectStatistic, error) {
+	if len(projectIDs) == 0 {
+		return []*models.ProjectStatistic{}, nil
+	}
+	ids := make([]interface{}, len(projectIDs))
+	for i, id := range projectIDs {
+		ids[i] = id
+	}
+	return m.dao.List(ctx, q.New(q.KeyWords{"ProjectID": q.NewOrList(ids)}))
+}
+
+func (m *manager) Refresh(ctx context.Context, projectIDs ...int64) error {
+	return m.dao.Refresh(ctx, projectIDs...)
+}
+
+func (m *manager) RefreshTotal(ctx context.Context) error {
+	return m.dao.RefreshTotal(ctx)
+}
+
+func (m *manager) Purge(ctx context.Context) (int64, error) {
+	return m.dao.Purge(ctx)
+}
@@ -0,0 +1,66 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/project/statistic/models"
+	mockDAO "github.com/goharbor/harbor/src/testing/pkg/project/statistic/dao"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	mgr *manager
+	dao *mockDAO.DAO
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.dao = &mockDAO.DAO{}
+	m.mgr = &manager{
+		dao: m.dao,
+	}
+}
+
+func (m *managerTestSuite) TestList() {
+	statistics, err := m.mgr.List(context.TODO())
+	m.Require().Nil(err)
+	m.Len(statistics, 0)
+	m.dao.AssertNotCalled(m.T(), "List", mock.Anything, mock.Anything)
+
+	m.dao.On("List", mock.Anything, mock.MatchedBy(func(query *q.Query) bool {
+		ol, ok := query.Keywords["ProjectID"].(*q.OrList)
+		return ok && len(ol.Values) == 2 && ol.Values[0] == int64(1) && ol.Values[1] == int64(2)
+	})).Return([]*models.ProjectStatistic{{ProjectID: 1}, {ProjectID: 2}}, nil)
+	statistics, err = m.mgr.List(context.TODO(), 1, 2)
+	m.Require().Nil(err)
+	m.Len(statistics, 2)
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestRefresh() {
+	m.dao.On("Refresh", mock.Anything, int64(1), int64(2)).Return(nil)
+	m.Nil(m.mgr.Refresh(context.TODO(), 1, 2))
+	m.dao.AssertExpectations(m.T())
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
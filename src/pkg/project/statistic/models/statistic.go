@@ -0,0 +1,47 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+// TotalID is the ID of the statistic which holds the totals of all projects
+const TotalID int64 = 0
+
+func init() {
+	orm.RegisterModel(new(ProjectStatistic))
+}
+
+// ProjectStatistic holds the pre-computed counters of a project
+type ProjectStatistic struct {
+	ProjectID         int64     `orm:"pk;column(project_id)" json:"project_id"`
+	RepoCount         int64     `orm:"column(repo_count)" json:"repo_count"`
+	TagCount          int64     `orm:"column(tag_count)" json:"tag_count"`
+	Storage           int64     `orm:"column(storage)" json:"storage"`
+	ProjectAdminCount int64     `orm:"column(project_admin_count)" json:"project_admin_count"`
+	MaintainerCount   int64     `orm:"column(maintainer_count)" json:"maintainer_count"`
+	DeveloperCount    int64     `orm:"column(developer_count)" json:"developer_count"`
+	GuestCount        int64     `orm:"column(guest_count)" json:"guest_count"`
+	LimitedGuestCount int64     `orm:"column(limited_guest_count)" json:"limited_guest_count"`
+	UpdateTime        time.Time `orm:"column(update_time)" json:"update_time"`
+}
+
+// TableName ...
+func (p *ProjectStatistic) TableName() string {
+	return "project_statistic"
+}
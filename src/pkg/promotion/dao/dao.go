@@ -0,0 +1,138 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"time"
+
+	beegoorm "github.com/beego/beego/v2/client/orm"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/promotion/model"
+)
+
+// DAO defines the interface to access the promotions
+type DAO interface {
+	// Create the promotion and returns the ID
+	Create(ctx context.Context, promotion *model.Promotion) (int64, error)
+	// Get the promotion specified by the ID
+	Get(ctx context.Context, id int64) (*model.Promotion, error)
+	// Update the specified properties of the promotion, all the properties are updated if none is specified
+	Update(ctx context.Context, promotion *model.Promotion, props ...string) error
+	// Review updates the status of the pending promotion with the reviewer and the comment, the conflict
+	// error is returned if the promotion is reviewed already
+	Review(ctx context.Context, id int64, status, reviewer, comment string) error
+	// Count returns the total count of the promotions according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List the promotions according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.Promotion, error)
+}
+
+// New returns an instance of the default DAO
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+func (d *dao) Create(ctx context.Context, promotion *model.Promotion) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return ormer.Insert(promotion)
+}
+
+func (d *dao) Get(ctx context.Context, id int64) (*model.Promotion, error) {
+	promotion := &model.Promotion{
+		ID: id,
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = ormer.ReadWithCtx(ctx, promotion); err != nil {
+		if e := orm.AsNotFoundError(err, "promotion %d not found", id); e != nil {
+			err = e
+		}
+		return nil, err
+	}
+	return promotion, nil
+}
+
+func (d *dao) Update(ctx context.Context, promotion *model.Promotion, props ...string) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.UpdateWithCtx(ctx, promotion, props...)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("promotion %d not found", promotion.ID)
+	}
+	return nil
+}
+
+func (d *dao) Review(ctx context.Context, id int64, status, reviewer, comment string) error {
+	qs, err := orm.QuerySetter(ctx, &model.Promotion{}, q.New(q.KeyWords{
+		"ID":     id,
+		"Status": model.StatusPending,
+	}))
+	if err != nil {
+		return err
+	}
+	n, err := qs.UpdateWithCtx(ctx, beegoorm.Params{
+		"status":         status,
+		"reviewer":       reviewer,
+		"review_comment": comment,
+		"update_time":    time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	// distinguish the reviewed promotion from the nonexistent one
+	if _, err = d.Get(ctx, id); err != nil {
+		return err
+	}
+	return errors.ConflictError(nil).WithMessage("promotion %d is reviewed already", id)
+}
+
+func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.Promotion{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.CountWithCtx(ctx)
+}
+
+func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.Promotion, error) {
+	promotions := []*model.Promotion{}
+	qs, err := orm.QuerySetter(ctx, &model.Promotion{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.AllWithCtx(ctx, &promotions); err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
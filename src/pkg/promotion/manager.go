@@ -0,0 +1,82 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promotion
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/promotion/dao"
+	"github.com/goharbor/harbor/src/pkg/promotion/model"
+)
+
+// Mgr is the global promotion manager instance
+var Mgr = New()
+
+// Manager manages the promotions of the artifacts
+type Manager interface {
+	// Create the pending promotion and returns the ID
+	Create(ctx context.Context, promotion *model.Promotion) (int64, error)
+	// Get the promotion specified by the ID
+	Get(ctx context.Context, id int64) (*model.Promotion, error)
+	// Approve the pending promotion, the conflict error is returned if it's reviewed already
+	Approve(ctx context.Context, id int64, reviewer, comment string) error
+	// Reject the pending promotion, the conflict error is returned if it's reviewed already
+	Reject(ctx context.Context, id int64, reviewer, comment string) error
+	// Update the specified properties of the promotion
+	Update(ctx context.Context, promotion *model.Promotion, props ...string) error
+	// Count returns the total count of the promotions according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List the promotions according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.Promotion, error)
+}
+
+// New returns a default implementation of Manager
+func New() Manager {
+	return &manager{dao: dao.New()}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+func (m *manager) Create(ctx context.Context, promotion *model.Promotion) (int64, error) {
+	promotion.Status = model.StatusPending
+	return m.dao.Create(ctx, promotion)
+}
+
+func (m *manager) Get(ctx context.Context, id int64) (*model.Promotion, error) {
+	return m.dao.Get(ctx, id)
+}
+
+func (m *manager) Approve(ctx context.Context, id int64, reviewer, comment string) error {
+	return m.dao.Review(ctx, id, model.StatusApproved, reviewer, comment)
+}
+
+func (m *manager) Reject(ctx context.Context, id int64, reviewer, comment string) error {
+	return m.dao.Review(ctx, id, model.StatusRejected, reviewer, comment)
+}
+
+func (m *manager) Update(ctx context.Context, promotion *model.Promotion, props ...string) error {
+	return m.dao.Update(ctx, promotion, props...)
+}
+
+func (m *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.Count(ctx, query)
+}
+
+func (m *manager) List(ctx context.Context, query *q.Query) ([]*model.Promotion, error) {
+	return m.dao.List(ctx, query)
+}
@@ -0,0 +1,63 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promotion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/pkg/promotion/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/promotion/dao"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	dao *dao.DAO
+	mgr *manager
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.dao = &dao.DAO{}
+	m.mgr = &manager{dao: m.dao}
+}
+
+func (m *managerTestSuite) TestCreate() {
+	mock.OnAnything(m.dao, "Create").Return(int64(1), nil)
+	promotion := &model.Promotion{Status: model.StatusSucceed}
+	id, err := m.mgr.Create(context.Background(), promotion)
+	m.Require().Nil(err)
+	m.Equal(int64(1), id)
+	// the created promotion is always pending
+	m.Equal(model.StatusPending, promotion.Status)
+}
+
+func (m *managerTestSuite) TestApprove() {
+	m.dao.On("Review", mock.Anything, int64(1), model.StatusApproved, "admin", "lgtm").Return(nil)
+	m.Nil(m.mgr.Approve(context.Background(), 1, "admin", "lgtm"))
+	m.dao.AssertExpectations(m.T())
+}
+
+func (m *managerTestSuite) TestReject() {
+	m.dao.On("Review", mock.Anything, int64(1), model.StatusRejected, "admin", "").Return(nil)
+	m.Nil(m.mgr.Reject(context.Background(), 1, "admin", ""))
+	m.dao.AssertExpectations(m.T())
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
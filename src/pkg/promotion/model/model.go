@@ -0,0 +1,68 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+// the status of the promotions
+const (
+	StatusPending  = "Pending"
+	StatusRejected = "Rejected"
+	// the promotion is approved and the artifact is being promoted
+	StatusApproved = "Approved"
+	// the promotion is approved and the copy or the replication of the artifact is triggered
+	StatusSucceed = "Succeed"
+	// the promotion is approved but the copy or the replication of the artifact cannot be triggered
+	StatusFailed = "Failed"
+)
+
+func init() {
+	orm.RegisterModel(&Promotion{})
+}
+
+// Promotion requests to promote the artifact to the target project or to the remote registry by the
+// replication policy. The artifact is promoted only after the promotion is approved by the users with
+// the approver role designated by the project, and the label is applied to the promoted artifact
+type Promotion struct {
+	ID             int64  `orm:"pk;auto;column(id)" json:"id"`
+	ProjectID      int64  `orm:"column(project_id)" json:"project_id"`
+	RepositoryName string `orm:"column(repository_name)" json:"repository_name"`
+	Digest         string `orm:"column(digest)" json:"digest"`
+	// TargetProjectID is the project the artifact is copied to, only one of it and the replication policy is set
+	TargetProjectID int64 `orm:"column(target_project_id)" json:"target_project_id"`
+	// ReplicationPolicyID is the policy replicating the artifact to the remote registry
+	ReplicationPolicyID int64 `orm:"column(replication_policy_id)" json:"replication_policy_id"`
+	// LabelID is the label applied to the promoted artifact, no label is applied if it's 0
+	LabelID       int64  `orm:"column(label_id)" json:"label_id"`
+	Status        string `orm:"column(status)" json:"status"`
+	StatusMessage string `orm:"column(status_message)" json:"status_message"`
+	Requester     string `orm:"column(requester)" json:"requester"`
+	Comment       string `orm:"column(comment)" json:"comment"`
+	Reviewer      string `orm:"column(reviewer)" json:"reviewer"`
+	ReviewComment string `orm:"column(review_comment)" json:"review_comment"`
+	// ExecutionID is the replication execution triggered by the approval
+	ExecutionID  int64     `orm:"column(execution_id)" json:"execution_id"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time" sort:"default:desc"`
+	UpdateTime   time.Time `orm:"column(update_time);auto_now" json:"update_time"`
+}
+
+// TableName ...
+func (p *Promotion) TableName() string {
+	return "promotion"
+}
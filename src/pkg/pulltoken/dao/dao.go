@@ -0,0 +1,106 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/pulltoken/model"
+)
+
+// DAO defines the interface to access the pull token data model
+type DAO interface {
+	// Create ...
+	Create(ctx context.Context, t *model.PullToken) (int64, error)
+
+	// Update ...
+	Update(ctx context.Context, t *model.PullToken, props ...string) error
+
+	// Get ...
+	Get(ctx context.Context, id int64) (*model.PullToken, error)
+
+	// Count returns the total count of pull tokens according to the query
+	Count(ctx context.Context, query *q.Query) (total int64, err error)
+
+	// List ...
+	List(ctx context.Context, query *q.Query) ([]*model.PullToken, error)
+}
+
+// New creates a default implementation for Dao
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+func (d *dao) Create(ctx context.Context, t *model.PullToken) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return ormer.InsertWithCtx(ctx, t)
+}
+
+func (d *dao) Update(ctx context.Context, t *model.PullToken, props ...string) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.UpdateWithCtx(ctx, t, props...)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("pull token %d not found", t.ID)
+	}
+	return nil
+}
+
+func (d *dao) Get(ctx context.Context, id int64) (*model.PullToken, error) {
+	t := &model.PullToken{
+		ID: id,
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ormer.ReadWithCtx(ctx, t); err != nil {
+		return nil, orm.WrapNotFoundError(err, "pull token %d not found", id)
+	}
+	return t, nil
+}
+
+func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.PullToken{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.CountWithCtx(ctx)
+}
+
+func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.PullToken, error) {
+	tokens := []*model.PullToken{}
+	qs, err := orm.QuerySetter(ctx, &model.PullToken{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.AllWithCtx(ctx, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
@@ -0,0 +1,58 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+func init() {
+	orm.RegisterModel(&PullToken{})
+}
+
+// PullToken is a short-lived credential which can only pull the specified repositories of a project
+type PullToken struct {
+	ID           int64     `orm:"pk;auto;column(id)" json:"id"`
+	ProjectID    int64     `orm:"column(project_id)" json:"project_id"`
+	Repositories string    `orm:"column(repositories)" json:"repositories"`
+	Description  string    `orm:"column(description)" json:"description"`
+	Secret       string    `orm:"column(secret)" json:"-"`
+	Salt         string    `orm:"column(salt)" json:"-"`
+	Creator      string    `orm:"column(creator)" json:"creator"`
+	Revoked      bool      `orm:"column(revoked)" json:"revoked"`
+	ExpiresAt    time.Time `orm:"column(expires_at)" json:"expires_at"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time" sort:"default:desc"`
+}
+
+// TableName ...
+func (p *PullToken) TableName() string {
+	return "pull_token"
+}
+
+// RepositoryList returns the names of the repositories which the token can pull
+func (p *PullToken) RepositoryList() []string {
+	if len(p.Repositories) == 0 {
+		return nil
+	}
+	return strings.Split(p.Repositories, ",")
+}
+
+// IsValid returns whether the token is neither revoked nor expired
+func (p *PullToken) IsValid() bool {
+	return !p.Revoked && time.Now().Before(p.ExpiresAt)
+}
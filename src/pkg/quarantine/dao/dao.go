@@ -0,0 +1,107 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/quarantine/model"
+)
+
+// DAO defines the interface to access the quarantined artifacts
+type DAO interface {
+	// Create the quarantine and returns the ID, the conflict error is returned if the artifact is quarantined already
+	Create(ctx context.Context, quarantine *model.Quarantine) (int64, error)
+	// Update the specified properties of the quarantine
+	Update(ctx context.Context, quarantine *model.Quarantine, props ...string) error
+	// Count returns the total count of the quarantines according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List the quarantines according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.Quarantine, error)
+	// Delete the quarantine of the artifact
+	Delete(ctx context.Context, artifactID int64) error
+}
+
+// New returns an instance of the default DAO
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+func (d *dao) Create(ctx context.Context, quarantine *model.Quarantine) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	id, err := ormer.Insert(quarantine)
+	if err != nil {
+		return 0, orm.WrapConflictError(err, "the artifact %s@%s is quarantined already", quarantine.RepositoryName, quarantine.Digest)
+	}
+	return id, nil
+}
+
+func (d *dao) Update(ctx context.Context, quarantine *model.Quarantine, props ...string) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.Update(quarantine, props...)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("quarantine %d not found", quarantine.ID)
+	}
+	return nil
+}
+
+func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.Quarantine{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.CountWithCtx(ctx)
+}
+
+func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.Quarantine, error) {
+	quarantines := []*model.Quarantine{}
+	qs, err := orm.QuerySetter(ctx, &model.Quarantine{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.AllWithCtx(ctx, &quarantines); err != nil {
+		return nil, err
+	}
+	return quarantines, nil
+}
+
+func (d *dao) Delete(ctx context.Context, artifactID int64) error {
+	qs, err := orm.QuerySetter(ctx, &model.Quarantine{}, q.New(q.KeyWords{"ArtifactID": artifactID}))
+	if err != nil {
+		return err
+	}
+	n, err := qs.DeleteWithCtx(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("the artifact %d isn't quarantined", artifactID)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quarantine
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/quarantine/dao"
+	"github.com/goharbor/harbor/src/pkg/quarantine/model"
+)
+
+// Mgr is the global quarantine manager instance
+var Mgr = New()
+
+// Manager manages the quarantines of the newly pushed artifacts
+type Manager interface {
+	// Quarantine the artifact and returns the ID of the quarantine
+	Quarantine(ctx context.Context, quarantine *model.Quarantine) (int64, error)
+	// Release the artifact from the quarantine
+	Release(ctx context.Context, artifactID int64) error
+	// Get the quarantine of the artifact
+	Get(ctx context.Context, artifactID int64) (*model.Quarantine, error)
+	// UpdateReason updates the reason why the artifact is still quarantined
+	UpdateReason(ctx context.Context, artifactID int64, reason string) error
+	// Count returns the total count of the quarantines according to the query
+	Count(ctx context.Context, query *q.Query) (int64, error)
+	// List the quarantines according to the query
+	List(ctx context.Context, query *q.Query) ([]*model.Quarantine, error)
+	// IsQuarantined returns whether the artifact is quarantined
+	IsQuarantined(ctx context.Context, artifactID int64) (bool, error)
+}
+
+// New returns a default implementation of Manager
+func New() Manager {
+	return &manager{dao: dao.New()}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+func (m *manager) Quarantine(ctx context.Context, quarantine *model.Quarantine) (int64, error) {
+	return m.dao.Create(ctx, quarantine)
+}
+
+func (m *manager) Release(ctx context.Context, artifactID int64) error {
+	return m.dao.Delete(ctx, artifactID)
+}
+
+func (m *manager) Get(ctx context.Context, artifactID int64) (*model.Quarantine, error) {
+	quarantines, err := m.dao.List(ctx, q.New(q.KeyWords{"ArtifactID": artifactID}))
+	if err != nil {
+		return nil, err
+	}
+	if len(quarantines) == 0 {
+		return nil, errors.NotFoundError(nil).WithMessage("the artifact %d isn't quarantined", artifactID)
+	}
+	return quarantines[0], nil
+}
+
+func (m *manager) UpdateReason(ctx context.Context, artifactID int64, reason string) error {
+	quarantine, err := m.Get(ctx, artifactID)
+	if err != nil {
+		return err
+	}
+	if quarantine.Reason == reason {
+		return nil
+	}
+	quarantine.Reason = reason
+	return m.dao.Update(ctx, quarantine, "Reason", "UpdateTime")
+}
+
+func (m *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
+	return m.dao.Count(ctx, query)
+}
+
+func (m *manager) List(ctx context.Context, query *q.Query) ([]*model.Quarantine, error) {
+	return m.dao.List(ctx, query)
+}
+
+func (m *manager) IsQuarantined(ctx context.Context, artifactID int64) (bool, error) {
+	n, err := m.dao.Count(ctx, q.New(q.KeyWords{"ArtifactID": artifactID}))
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,83 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quarantine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/quarantine/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/quarantine/dao"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	dao *dao.DAO
+	mgr *manager
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.dao = &dao.DAO{}
+	m.mgr = &manager{dao: m.dao}
+}
+
+func (m *managerTestSuite) TestGet() {
+	mock.OnAnything(m.dao, "List").Return([]*model.Quarantine{{ID: 1}}, nil).Once()
+	quarantine, err := m.mgr.Get(context.Background(), 1)
+	m.Require().Nil(err)
+	m.Equal(int64(1), quarantine.ID)
+
+	// not quarantined
+	mock.OnAnything(m.dao, "List").Return([]*model.Quarantine{}, nil).Once()
+	_, err = m.mgr.Get(context.Background(), 2)
+	m.True(errors.IsNotFoundErr(err))
+}
+
+func (m *managerTestSuite) TestUpdateReason() {
+	mock.OnAnything(m.dao, "List").Return([]*model.Quarantine{{ID: 1, Reason: "not scanned"}}, nil).Once()
+	m.dao.On("Update", mock.Anything, mock.Anything, "Reason", "UpdateTime").Return(nil).Once()
+	m.Require().Nil(m.mgr.UpdateReason(context.Background(), 1, "not signed"))
+	quarantine := m.dao.Calls[1].Arguments.Get(1).(*model.Quarantine)
+	m.Equal("not signed", quarantine.Reason)
+
+	// the reason isn't changed
+	mock.OnAnything(m.dao, "List").Return([]*model.Quarantine{{ID: 1, Reason: "not signed"}}, nil).Once()
+	m.Require().Nil(m.mgr.UpdateReason(context.Background(), 1, "not signed"))
+	m.dao.AssertNumberOfCalls(m.T(), "Update", 1)
+}
+
+func (m *managerTestSuite) TestIsQuarantined() {
+	mock.OnAnything(m.dao, "Count").Return(int64(1), nil)
+	quarantined, err := m.mgr.IsQuarantined(context.Background(), 1)
+	m.Require().Nil(err)
+	m.True(quarantined)
+	query := m.dao.Calls[0].Arguments.Get(1).(*q.Query)
+	m.Equal(int64(1), query.Keywords["ArtifactID"])
+}
+
+func (m *managerTestSuite) TestRelease() {
+	m.dao.On("Delete", mock.Anything, int64(1)).Return(nil)
+	m.Nil(m.mgr.Release(context.Background(), 1))
+	m.dao.AssertExpectations(m.T())
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
@@ -0,0 +1,43 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+func init() {
+	orm.RegisterModel(&Quarantine{})
+}
+
+// Quarantine holds the newly pushed artifact which isn't pullable except by the admins until the
+// scan and signature policies of the project pass, the reason records the latest failed check
+type Quarantine struct {
+	ID             int64     `orm:"pk;auto;column(id)" json:"id"`
+	ArtifactID     int64     `orm:"column(artifact_id)" json:"artifact_id"`
+	ProjectID      int64     `orm:"column(project_id)" json:"project_id"`
+	RepositoryName string    `orm:"column(repository_name)" json:"repository_name"`
+	Digest         string    `orm:"column(digest)" json:"digest"`
+	Reason         string    `orm:"column(reason)" json:"reason"`
+	CreationTime   time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time" sort:"default:desc"`
+	UpdateTime     time.Time `orm:"column(update_time);auto_now" json:"update_time"`
+}
+
+// TableName ...
+func (q *Quarantine) TableName() string {
+	return "artifact_quarantine"
+}
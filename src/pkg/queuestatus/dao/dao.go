@@ -59,7 +59,7 @@ func (d *dao) Query(ctx context.Context, query *q.Query) ([]*model.JobQueueStatu
 		return nil, err
 	}
 	var queueStatusList []*model.JobQueueStatus
-	if _, err := qs.All(&queueStatusList); err != nil {
+	if _, err := qs.AllWithCtx(ctx, &queueStatusList); err != nil {
 		return nil, err
 	}
 	return queueStatusList, nil
@@ -86,5 +86,5 @@ func (d *dao) InsertOrUpdate(ctx context.Context, queue *model.JobQueueStatus) (
 	if err != nil {
 		return 0, err
 	}
-	return o.InsertOrUpdate(queue, "job_type")
+	return o.InsertOrUpdateWithCtx(ctx, queue, "job_type")
 }
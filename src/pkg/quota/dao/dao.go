@@ -91,7 +91,7 @@ func (d *dao) Create(ctx context.Context, reference, referenceID string, hardLim
 		UpdateTime:   now,
 	}
 
-	id, err := o.Insert(quota)
+	id, err := o.InsertWithCtx(ctx, quota)
 	if err != nil {
 		return 0, err
 	}
@@ -105,7 +105,7 @@ func (d *dao) Create(ctx context.Context, reference, referenceID string, hardLim
 		UpdateTime:   now,
 	}
 
-	_, err = o.Insert(usage)
+	_, err = o.InsertWithCtx(ctx, usage)
 	if err != nil {
 		return 0, err
 	}
@@ -120,12 +120,12 @@ func (d *dao) Delete(ctx context.Context, id int64) error {
 	}
 
 	quota := &Quota{ID: id}
-	if _, err := o.Delete(quota, "id"); err != nil {
+	if _, err := o.DeleteWithCtx(ctx, quota, "id"); err != nil {
 		return err
 	}
 
 	usage := &QuotaUsage{ID: id}
-	if _, err := o.Delete(usage, "id"); err != nil {
+	if _, err := o.DeleteWithCtx(ctx, usage, "id"); err != nil {
 		return err
 	}
 
@@ -139,12 +139,12 @@ func (d *dao) Get(ctx context.Context, id int64) (*models.Quota, error) {
 	}
 
 	quota := &Quota{ID: id}
-	if err := o.Read(quota); err != nil {
+	if err := o.ReadWithCtx(ctx, quota); err != nil {
 		return nil, orm.WrapNotFoundError(err, "quota %d not found", id)
 	}
 
 	usage := &QuotaUsage{ID: id}
-	if err := o.Read(usage); err != nil {
+	if err := o.ReadWithCtx(ctx, usage); err != nil {
 		return nil, orm.WrapNotFoundError(err, "quota usage %d not found", id)
 	}
 
@@ -158,12 +158,12 @@ func (d *dao) GetByRef(ctx context.Context, reference, referenceID string) (*mod
 	}
 
 	quota := &Quota{Reference: reference, ReferenceID: referenceID}
-	if err := o.Read(quota, "reference", "reference_id"); err != nil {
+	if err := o.ReadWithCtx(ctx, quota, "reference", "reference_id"); err != nil {
 		return nil, orm.WrapNotFoundError(err, "quota not found for (%s, %s)", reference, referenceID)
 	}
 
 	usage := &QuotaUsage{Reference: reference, ReferenceID: referenceID}
-	if err := o.Read(usage, "reference", "reference_id"); err != nil {
+	if err := o.ReadWithCtx(ctx, usage, "reference", "reference_id"); err != nil {
 		return nil, orm.WrapNotFoundError(err, "quota usage not found for (%s, %s)", reference, referenceID)
 	}
 
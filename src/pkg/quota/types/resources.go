@@ -27,6 +27,8 @@ const (
 
 	// ResourceStorage storage size, in bytes
 	ResourceStorage ResourceName = "storage"
+	// ResourceCount count of the artifacts
+	ResourceCount ResourceName = "count"
 )
 
 // ResourceName is the name identifying various resources in a ResourceList.
@@ -144,7 +146,7 @@ func IsNegative(a ResourceList) []ResourceName {
 // IsValidResource returns true when resource was supported
 func IsValidResource(resource ResourceName) bool {
 	switch resource {
-	case ResourceStorage:
+	case ResourceStorage, ResourceCount:
 		return true
 	default:
 		return false
@@ -45,7 +45,7 @@ func (d *dao) CreatePermission(ctx context.Context, rp *model.RolePermission) (i
 		return 0, err
 	}
 	rp.CreationTime = time.Now()
-	return ormer.InsertOrUpdate(rp, "role_type, role_id, permission_policy_id")
+	return ormer.InsertOrUpdateWithCtx(ctx, rp, "role_type, role_id, permission_policy_id")
 }
 
 func (d *dao) DeletePermission(ctx context.Context, id int64) (err error) {
@@ -53,7 +53,7 @@ func (d *dao) DeletePermission(ctx context.Context, id int64) (err error) {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&model.RolePermission{
+	n, err := ormer.DeleteWithCtx(ctx, &model.RolePermission{
 		ID: id,
 	})
 	if err != nil {
@@ -71,7 +71,7 @@ func (d *dao) ListPermissions(ctx context.Context, query *q.Query) ([]*model.Rol
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&rps); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &rps); err != nil {
 		return nil, err
 	}
 	return rps, nil
@@ -87,7 +87,7 @@ func (d *dao) DeletePermissionsByRole(ctx context.Context, roleType string, role
 	if err != nil {
 		return err
 	}
-	n, err := qs.Delete()
+	n, err := qs.DeleteWithCtx(ctx)
 	if err != nil {
 		return err
 	}
@@ -103,7 +103,7 @@ func (d *dao) CreateRbacPolicy(ctx context.Context, pp *model.PermissionPolicy)
 		return 0, err
 	}
 	pp.CreationTime = time.Now()
-	return ormer.InsertOrUpdate(pp, "scope, resource, action, effect")
+	return ormer.InsertOrUpdateWithCtx(ctx, pp, "scope, resource, action, effect")
 }
 
 func (d *dao) DeleteRbacPolicy(ctx context.Context, id int64) (err error) {
@@ -111,7 +111,7 @@ func (d *dao) DeleteRbacPolicy(ctx context.Context, id int64) (err error) {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&model.PermissionPolicy{
+	n, err := ormer.DeleteWithCtx(ctx, &model.PermissionPolicy{
 		ID: id,
 	})
 	if err != nil {
@@ -129,7 +129,7 @@ func (d *dao) ListRbacPolicies(ctx context.Context, query *q.Query) ([]*model.Pe
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&pps); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &pps); err != nil {
 		return nil, err
 	}
 	return pps, nil
@@ -40,6 +40,18 @@ type Factory interface {
 	AdapterPattern() *model.AdapterPattern
 }
 
+// CapabilityDeclarer is implemented by the factories declaring the capabilities of their adapters statically,
+// so that the capabilities can be discovered without connecting to the registries
+type CapabilityDeclarer interface {
+	Capabilities() *model.RegistryInfo
+}
+
+// PeeringNegotiator is implemented by the adapters of the registries which can be peered with the local Harbor
+type PeeringNegotiator interface {
+	// GetPeeringInfo returns the API version and capabilities that the registry offers to its peers
+	GetPeeringInfo() (*model.PeeringInfo, error)
+}
+
 // Adapter interface defines the capabilities of registry
 type Adapter interface {
 	// Info return the information of this adapter
@@ -118,3 +130,22 @@ func ListRegisteredAdapterTypes() []string {
 func ListRegisteredAdapterInfos() map[string]*model.AdapterPattern {
 	return adapterInfoMap
 }
+
+// ListRegisteredAdapterCapabilities lists the capabilities declared by the registered factories in the order of
+// the adapter types, the factories not implementing the CapabilityDeclarer interface are skipped
+func ListRegisteredAdapterCapabilities() []*model.RegistryInfo {
+	var capabilities []*model.RegistryInfo
+	for _, t := range registryKeys {
+		declarer, ok := registry[t].(CapabilityDeclarer)
+		if !ok {
+			continue
+		}
+		capability := declarer.Capabilities()
+		if capability == nil {
+			continue
+		}
+		capability.Type = t
+		capabilities = append(capabilities, capability)
+	}
+	return capabilities
+}
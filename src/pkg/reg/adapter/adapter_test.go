@@ -34,6 +34,16 @@ func (fakedFactory) AdapterPattern() *model.AdapterPattern {
 	return nil
 }
 
+type fakedDeclarerFactory struct {
+	fakedFactory
+}
+
+func (fakedDeclarerFactory) Capabilities() *model.RegistryInfo {
+	return &model.RegistryInfo{
+		SupportedResourceTypes: []string{model.ResourceTypeImage},
+	}
+}
+
 func TestRegisterFactory(t *testing.T) {
 	// empty type
 	assert.NotNil(t, RegisterFactory("", nil))
@@ -84,3 +94,17 @@ func TestListRegisteredAdapterTypesOrder(t *testing.T) {
 	require.Equal(t, "b", types[1])
 	require.Equal(t, "c", types[2])
 }
+
+func TestListRegisteredAdapterCapabilities(t *testing.T) {
+	registry = map[string]Factory{}
+	registryKeys = []string{}
+	require.Nil(t, RegisterFactory("b", new(fakedDeclarerFactory)))
+	require.Nil(t, RegisterFactory("c", new(fakedFactory)))
+	require.Nil(t, RegisterFactory("a", new(fakedDeclarerFactory)))
+
+	capabilities := ListRegisteredAdapterCapabilities()
+	require.Len(t, capabilities, 2)
+	assert.Equal(t, "a", capabilities[0].Type)
+	assert.Equal(t, "b", capabilities[1].Type)
+	assert.Equal(t, []string{model.ResourceTypeImage}, capabilities[0].SupportedResourceTypes)
+}
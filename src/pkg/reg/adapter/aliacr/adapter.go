@@ -81,6 +81,10 @@ func (f *factory) AdapterPattern() *model.AdapterPattern {
 	return getAdapterInfo()
 }
 
+func (f *factory) Capabilities() *model.RegistryInfo {
+	return getRegistryInfo()
+}
+
 var (
 	_ adp.Adapter          = (*adapter)(nil)
 	_ adp.ArtifactRegistry = (*adapter)(nil)
@@ -98,7 +102,11 @@ var _ adp.Adapter = &adapter{}
 
 // Info ...
 func (a *adapter) Info() (info *model.RegistryInfo, err error) {
-	info = &model.RegistryInfo{
+	return getRegistryInfo(), nil
+}
+
+func getRegistryInfo() *model.RegistryInfo {
+	return &model.RegistryInfo{
 		Type: model.RegistryTypeAliAcr,
 		SupportedResourceTypes: []string{
 			model.ResourceTypeImage,
@@ -118,7 +126,6 @@ func (a *adapter) Info() (info *model.RegistryInfo, err error) {
 			model.TriggerTypeScheduled,
 		},
 	}
-	return
 }
 
 func getAdapterInfo() *model.AdapterPattern {
@@ -83,6 +83,10 @@ func (f *factory) AdapterPattern() *model.AdapterPattern {
 	return getAdapterInfo()
 }
 
+func (f *factory) Capabilities() *model.RegistryInfo {
+	return getRegistryInfo()
+}
+
 var (
 	_ adp.Adapter          = (*adapter)(nil)
 	_ adp.ArtifactRegistry = (*adapter)(nil)
@@ -95,6 +99,10 @@ type adapter struct {
 }
 
 func (*adapter) Info() (info *model.RegistryInfo, err error) {
+	return getRegistryInfo(), nil
+}
+
+func getRegistryInfo() *model.RegistryInfo {
 	return &model.RegistryInfo{
 		Type: model.RegistryTypeAwsEcr,
 		SupportedResourceTypes: []string{
@@ -114,7 +122,7 @@ func (*adapter) Info() (info *model.RegistryInfo, err error) {
 			model.TriggerTypeManual,
 			model.TriggerTypeScheduled,
 		},
-	}, nil
+	}
 }
 
 func getAdapterInfo() *model.AdapterPattern {
@@ -48,6 +48,10 @@ func (f *factory) AdapterPattern() *model.AdapterPattern {
 	return nil
 }
 
+func (f *factory) Capabilities() *model.RegistryInfo {
+	return getRegistryInfo()
+}
+
 type adapter struct {
 	*native.Adapter
 }
@@ -59,6 +63,10 @@ var (
 
 // Info returns information of the registry
 func (a *adapter) Info() (*model.RegistryInfo, error) {
+	return getRegistryInfo(), nil
+}
+
+func getRegistryInfo() *model.RegistryInfo {
 	return &model.RegistryInfo{
 		Type: model.RegistryTypeAzureAcr,
 		SupportedResourceTypes: []string{
@@ -78,5 +86,5 @@ func (a *adapter) Info() (*model.RegistryInfo, error) {
 			model.TriggerTypeManual,
 			model.TriggerTypeScheduled,
 		},
-	}, nil
+	}
 }
@@ -56,6 +56,10 @@ func (f *factory) AdapterPattern() *model.AdapterPattern {
 	return getAdapterInfo()
 }
 
+func (f *factory) Capabilities() *model.RegistryInfo {
+	return getRegistryInfo()
+}
+
 var (
 	_ adp.Adapter          = (*adapter)(nil)
 	_ adp.ArtifactRegistry = (*adapter)(nil)
@@ -72,6 +76,10 @@ var _ adp.Adapter = (*adapter)(nil)
 
 // Info returns information of the registry
 func (a *adapter) Info() (*model.RegistryInfo, error) {
+	return getRegistryInfo(), nil
+}
+
+func getRegistryInfo() *model.RegistryInfo {
 	return &model.RegistryInfo{
 		Type: model.RegistryTypeDockerHub,
 		SupportedResourceTypes: []string{
@@ -92,7 +100,7 @@ func (a *adapter) Info() (*model.RegistryInfo, error) {
 			model.TriggerTypeScheduled,
 		},
 		SupportedRepositoryPathComponentType: model.RepositoryPathComponentTypeOnlyTwo,
-	}, nil
+	}
 }
 
 func getAdapterInfo() *model.AdapterPattern {
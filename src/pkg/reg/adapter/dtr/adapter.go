@@ -35,6 +35,10 @@ func (f *factory) AdapterPattern() *model.AdapterPattern {
 	return nil
 }
 
+func (f *factory) Capabilities() *model.RegistryInfo {
+	return getRegistryInfo()
+}
+
 var (
 	_ adp.Adapter = (*adapter)(nil)
 )
@@ -59,6 +63,10 @@ func newAdapter(registry *model.Registry) *adapter {
 
 // Info returns information of the registry
 func (a *adapter) Info() (*model.RegistryInfo, error) {
+	return getRegistryInfo(), nil
+}
+
+func getRegistryInfo() *model.RegistryInfo {
 	return &model.RegistryInfo{
 		Type: model.RegistryTypeAzureAcr,
 		SupportedResourceTypes: []string{
@@ -78,7 +86,7 @@ func (a *adapter) Info() (*model.RegistryInfo, error) {
 			model.TriggerTypeManual,
 			model.TriggerTypeScheduled,
 		},
-	}, nil
+	}
 }
 
 // FetchArtifacts ...
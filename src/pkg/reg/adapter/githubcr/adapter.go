@@ -41,6 +41,10 @@ func (f *factory) AdapterPattern() *model.AdapterPattern {
 	return getAdapterPattern()
 }
 
+func (f *factory) Capabilities() *model.RegistryInfo {
+	return getRegistryInfo()
+}
+
 func getAdapterPattern() *model.AdapterPattern {
 	return &model.AdapterPattern{
 		EndpointPattern: &model.EndpointPattern{
@@ -93,7 +97,11 @@ func newAdapter(registry *model.Registry) *adapter {
 
 // Info ...
 func (a *adapter) Info() (info *model.RegistryInfo, err error) {
-	info = &model.RegistryInfo{
+	return getRegistryInfo(), nil
+}
+
+func getRegistryInfo() *model.RegistryInfo {
+	return &model.RegistryInfo{
 		Type: model.RegistryTypeGithubCR,
 		SupportedResourceTypes: []string{
 			model.ResourceTypeImage,
@@ -113,7 +121,6 @@ func (a *adapter) Info() (info *model.RegistryInfo, err error) {
 			model.TriggerTypeScheduled,
 		},
 	}
-	return
 }
 
 func (a *adapter) FetchArtifacts(filters []*model.Filter) (resources []*model.Resource, err error) {
@@ -31,6 +31,10 @@ func (f *factory) AdapterPattern() *model.AdapterPattern {
 	return nil
 }
 
+func (f *factory) Capabilities() *model.RegistryInfo {
+	return getRegistryInfo()
+}
+
 var (
 	_ adp.Adapter          = (*adapter)(nil)
 	_ adp.ArtifactRegistry = (*adapter)(nil)
@@ -59,6 +63,10 @@ func newAdapter(registry *model.Registry) (*adapter, error) {
 }
 
 func (a *adapter) Info() (info *model.RegistryInfo, err error) {
+	return getRegistryInfo(), nil
+}
+
+func getRegistryInfo() *model.RegistryInfo {
 	return &model.RegistryInfo{
 		Type: model.RegistryTypeGitLab,
 		SupportedResourceTypes: []string{
@@ -78,7 +86,7 @@ func (a *adapter) Info() (info *model.RegistryInfo, err error) {
 			model.TriggerTypeManual,
 			model.TriggerTypeScheduled,
 		},
-	}, nil
+	}
 }
 
 // FetchArtifacts fetches images
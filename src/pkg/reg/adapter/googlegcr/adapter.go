@@ -57,6 +57,10 @@ func (f *factory) AdapterPattern() *model.AdapterPattern {
 	return getAdapterInfo()
 }
 
+func (f *factory) Capabilities() *model.RegistryInfo {
+	return getRegistryInfo()
+}
+
 var (
 	_ adp.Adapter          = (*adapter)(nil)
 	_ adp.ArtifactRegistry = (*adapter)(nil)
@@ -70,6 +74,10 @@ type adapter struct {
 var _ adp.Adapter = adapter{}
 
 func (adapter) Info() (info *model.RegistryInfo, err error) {
+	return getRegistryInfo(), nil
+}
+
+func getRegistryInfo() *model.RegistryInfo {
 	return &model.RegistryInfo{
 		Type: model.RegistryTypeGoogleGcr,
 		SupportedResourceTypes: []string{
@@ -89,7 +97,7 @@ func (adapter) Info() (info *model.RegistryInfo, err error) {
 			model.TriggerTypeManual,
 			model.TriggerTypeScheduled,
 		},
-	}, nil
+	}
 }
 
 func getAdapterInfo() *model.AdapterPattern {
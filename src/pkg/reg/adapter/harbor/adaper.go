@@ -52,3 +52,36 @@ func (f *factory) Create(r *model.Registry) (adp.Adapter, error) {
 func (f *factory) AdapterPattern() *model.AdapterPattern {
 	return nil
 }
+
+// Capabilities declares the capabilities of the Harbor 2.x instances, the chart is supported only when
+// the chart repository is enabled in the remote Harbor and the artifact isn't supported by Harbor 1.x
+func (f *factory) Capabilities() *model.RegistryInfo {
+	return &model.RegistryInfo{
+		Type: model.RegistryTypeHarbor,
+		SupportedResourceTypes: []string{
+			model.ResourceTypeImage,
+			model.ResourceTypeChart,
+			model.ResourceTypeArtifact,
+		},
+		SupportedResourceFilters: []*model.FilterStyle{
+			{
+				Type:  model.FilterTypeName,
+				Style: model.FilterStyleTypeText,
+			},
+			{
+				Type:  model.FilterTypeTag,
+				Style: model.FilterStyleTypeText,
+			},
+			{
+				Type:  model.FilterTypeLabel,
+				Style: model.FilterStyleTypeList,
+			},
+		},
+		SupportedTriggers: []string{
+			model.TriggerTypeManual,
+			model.TriggerTypeScheduled,
+		},
+		SupportedRepositoryPathComponentType: model.RepositoryPathComponentTypeAtLeastTwo,
+		SupportedCopyByChunk:                 true,
+	}
+}
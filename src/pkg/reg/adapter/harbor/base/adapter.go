@@ -56,6 +56,10 @@ func New(registry *model.Registry) (*Adapter, error) {
 		}, nil
 	}
 
+	if registry.Peering != nil {
+		return newPeered(registry)
+	}
+
 	var authorizers []modifier.Modifier
 	if registry.Credential != nil {
 		authorizers = append(authorizers, basic.NewAuthorizer(
@@ -78,6 +82,41 @@ func New(registry *model.Registry) (*Adapter, error) {
 	}, nil
 }
 
+// newPeered creates the base adapter talking with the peered Harbor over the mutual TLS channel,
+// the API version negotiated when peering is used directly if any
+func newPeered(registry *model.Registry) (*Adapter, error) {
+	tlsConfig, err := registry.Peering.TLSConfig(registry.Insecure)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build the TLS configuration for the peer %s", registry.Name)
+	}
+	transport := common_http.NewTransport(common_http.WithTLSClientConfig(tlsConfig))
+	var authorizers []modifier.Modifier
+	if registry.Credential != nil {
+		authorizers = append(authorizers, basic.NewAuthorizer(
+			registry.Credential.AccessKey,
+			registry.Credential.AccessSecret))
+	}
+	httpClient := common_http.NewClient(&http.Client{
+		Transport: transport,
+	}, authorizers...)
+	var client *Client
+	if len(registry.Peering.APIVersion) > 0 {
+		client = NewClientWithAPIVersion(registry.URL, registry.Peering.APIVersion, httpClient)
+	} else {
+		client, err = NewClient(registry.URL, httpClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Adapter{
+		Adapter:    native.NewAdapterWithTransport(registry, transport),
+		Registry:   registry,
+		Client:     client,
+		url:        registry.URL,
+		httpClient: httpClient,
+	}, nil
+}
+
 // Adapter is the base adapter for Harbor
 type Adapter struct {
 	*native.Adapter
@@ -94,6 +133,11 @@ func (a *Adapter) GetAPIVersion() string {
 	return a.Client.APIVersion
 }
 
+// GetPeeringInfo returns the API version and capabilities that the remote Harbor offers to its peers
+func (a *Adapter) GetPeeringInfo() (*model.PeeringInfo, error) {
+	return a.Client.GetPeeringInfo()
+}
+
 // Info provides the information of the Harbor registry instance
 func (a *Adapter) Info() (*model.RegistryInfo, error) {
 	info := &model.RegistryInfo{
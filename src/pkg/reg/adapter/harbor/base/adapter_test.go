@@ -15,9 +15,19 @@
 package base
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -324,3 +334,98 @@ func TestListProjects(t *testing.T) {
 	require.Equal(t, "p1", projects[0].Name)
 	require.Equal(t, "p2", projects[1].Name)
 }
+
+// generateClientCertificate generates a self-signed client certificate and returns the PEM encoded certificate and key
+func generateClientCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "peer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.Nil(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
+
+func TestNewPeered(t *testing.T) {
+	clientCert, clientKey := generateClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM([]byte(clientCert)))
+
+	versionRequested := false
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/version":
+			versionRequested = true
+			w.Write([]byte(`{"version":"v2.0"}`))
+		case "/api/v2.0/registries/peering/capabilities":
+			w.Write([]byte(`{"api_version":"v2.0","capabilities":["label_sync"]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+	ca := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	// invalid certificates
+	_, err := New(&model.Registry{
+		URL: server.URL,
+		Peering: &model.Peering{
+			CACertificate:     "invalid",
+			ClientCertificate: clientCert,
+			ClientKey:         clientKey,
+		},
+	})
+	require.NotNil(t, err)
+
+	// the API version isn't negotiated yet
+	adapter, err := New(&model.Registry{
+		URL: server.URL,
+		Peering: &model.Peering{
+			CACertificate:     ca,
+			ClientCertificate: clientCert,
+			ClientKey:         clientKey,
+		},
+	})
+	require.Nil(t, err)
+	assert.True(t, versionRequested)
+	assert.Equal(t, "v2.0", adapter.GetAPIVersion())
+	info, err := adapter.GetPeeringInfo()
+	require.Nil(t, err)
+	assert.Equal(t, "v2.0", info.APIVersion)
+	assert.Equal(t, []string{model.PeeringCapabilityLabelSync}, info.Capabilities)
+
+	// the API version negotiated when peering is used directly
+	versionRequested = false
+	adapter, err = New(&model.Registry{
+		URL: server.URL,
+		Peering: &model.Peering{
+			CACertificate:     ca,
+			ClientCertificate: clientCert,
+			ClientKey:         clientKey,
+			APIVersion:        "v2.0",
+		},
+	})
+	require.Nil(t, err)
+	assert.False(t, versionRequested)
+	assert.Equal(t, "v2.0", adapter.GetAPIVersion())
+
+	// the server rejects the connections without the client certificate
+	_, err = New(&model.Registry{
+		URL: server.URL,
+	})
+	require.NotNil(t, err)
+}
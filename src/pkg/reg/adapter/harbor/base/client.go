@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	common_http "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/pkg/reg/model"
 )
 
 // NewClient returns an instance of the base client
@@ -37,6 +38,16 @@ func NewClient(url string, c *common_http.Client) (*Client, error) {
 	return client, nil
 }
 
+// NewClientWithAPIVersion returns an instance of the base client for the known API version
+// without negotiating it with the remote Harbor, e.g. the version is negotiated when peering
+func NewClientWithAPIVersion(url, version string, c *common_http.Client) *Client {
+	return &Client{
+		URL:        strings.TrimSuffix(url, "/"),
+		APIVersion: version,
+		C:          c,
+	}
+}
+
 // Client is the base client that provides common methods for all versions of Harbor clients
 type Client struct {
 	URL        string
@@ -60,6 +71,15 @@ func (c *Client) GetAPIVersion() (string, error) {
 	return "", err
 }
 
+// GetPeeringInfo returns the API version and capabilities that the remote Harbor offers to its peers
+func (c *Client) GetPeeringInfo() (*model.PeeringInfo, error) {
+	info := &model.PeeringInfo{}
+	if err := c.C.Get(c.BasePath()+"/registries/peering/capabilities", info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
 // ChartRegistryEnabled returns whether the chart registry is enabled for the Harbor instance
 func (c *Client) ChartRegistryEnabled() (bool, error) {
 	sys := &struct {
@@ -53,6 +53,10 @@ func (f *factory) AdapterPattern() *model.AdapterPattern {
 	}
 }
 
+func (f *factory) Capabilities() *model.RegistryInfo {
+	return getRegistryInfo()
+}
+
 var (
 	_ adp.Adapter       = (*adapter)(nil)
 	_ adp.ChartRegistry = (*adapter)(nil)
@@ -71,6 +75,10 @@ func newAdapter(registry *model.Registry) (*adapter, error) {
 }
 
 func (a *adapter) Info() (*model.RegistryInfo, error) {
+	return getRegistryInfo(), nil
+}
+
+func getRegistryInfo() *model.RegistryInfo {
 	return &model.RegistryInfo{
 		Type: model.RegistryTypeHelmHub,
 		SupportedResourceTypes: []string{
@@ -90,7 +98,7 @@ func (a *adapter) Info() (*model.RegistryInfo, error) {
 			model.TriggerTypeManual,
 			model.TriggerTypeScheduled,
 		},
-	}, nil
+	}
 }
 
 func (a *adapter) PrepareForPush(resources []*model.Resource) error {
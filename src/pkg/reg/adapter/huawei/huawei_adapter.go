@@ -53,6 +53,10 @@ func (f *factory) AdapterPattern() *model.AdapterPattern {
 	return nil
 }
 
+func (f *factory) Capabilities() *model.RegistryInfo {
+	return getRegistryInfo()
+}
+
 var (
 	_ adp.Adapter          = (*adapter)(nil)
 	_ adp.ArtifactRegistry = (*adapter)(nil)
@@ -71,7 +75,11 @@ type adapter struct {
 
 // Info gets info about Huawei SWR
 func (a *adapter) Info() (*model.RegistryInfo, error) {
-	registryInfo := model.RegistryInfo{
+	return getRegistryInfo(), nil
+}
+
+func getRegistryInfo() *model.RegistryInfo {
+	return &model.RegistryInfo{
 		Type:                   model.RegistryTypeHuawei,
 		Description:            "Adapter for SWR -- The image registry of Huawei Cloud",
 		SupportedResourceTypes: []string{model.ResourceTypeImage},
@@ -90,7 +98,6 @@ func (a *adapter) Info() (*model.RegistryInfo, error) {
 			model.TriggerTypeScheduled,
 		},
 	}
-	return &registryInfo, nil
 }
 
 // ListNamespaces lists namespaces from Huawei SWR with the provided query conditions.
@@ -55,6 +55,10 @@ func (f *factory) AdapterPattern() *model.AdapterPattern {
 	return nil
 }
 
+func (f *factory) Capabilities() *model.RegistryInfo {
+	return getRegistryInfo()
+}
+
 var (
 	_ adp.Adapter          = (*adapter)(nil)
 	_ adp.ArtifactRegistry = (*adapter)(nil)
@@ -71,7 +75,11 @@ var _ adp.Adapter = (*adapter)(nil)
 
 // Info gets info about jfrog artifactory adapter
 func (a *adapter) Info() (info *model.RegistryInfo, err error) {
-	info = &model.RegistryInfo{
+	return getRegistryInfo(), nil
+}
+
+func getRegistryInfo() *model.RegistryInfo {
+	return &model.RegistryInfo{
 		Type: model.RegistryTypeJfrogArtifactory,
 		SupportedResourceTypes: []string{
 			model.ResourceTypeImage,
@@ -91,7 +99,6 @@ func (a *adapter) Info() (info *model.RegistryInfo, err error) {
 			model.TriggerTypeScheduled,
 		},
 	}
-	return
 }
 
 func newAdapter(registry *model.Registry) (adp.Adapter, error) {
@@ -16,6 +16,7 @@ package native
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/lib"
@@ -26,6 +27,7 @@ import (
 	"github.com/goharbor/harbor/src/pkg/reg/model"
 	"github.com/goharbor/harbor/src/pkg/reg/util"
 	"github.com/goharbor/harbor/src/pkg/registry"
+	"github.com/goharbor/harbor/src/pkg/registry/auth"
 )
 
 func init() {
@@ -50,6 +52,10 @@ func (f *factory) AdapterPattern() *model.AdapterPattern {
 	return nil
 }
 
+func (f *factory) Capabilities() *model.RegistryInfo {
+	return getRegistryInfo()
+}
+
 var (
 	_ adp.Adapter          = (*Adapter)(nil)
 	_ adp.ArtifactRegistry = (*Adapter)(nil)
@@ -76,6 +82,19 @@ func NewAdapter(reg *model.Registry) *Adapter {
 	return adapter
 }
 
+// NewAdapterWithTransport returns an instance of the Adapter which talks with the registry by the provided transport
+func NewAdapterWithTransport(reg *model.Registry, transport http.RoundTripper) *Adapter {
+	username, password := "", ""
+	if reg.Credential != nil {
+		username = reg.Credential.AccessKey
+		password = reg.Credential.AccessSecret
+	}
+	return &Adapter{
+		registry: reg,
+		Client:   registry.NewClientWithTransport(reg.URL, auth.NewAuthorizerWithTransport(username, password, transport), transport),
+	}
+}
+
 // NewAdapterWithAuthorizer returns an instance of the Adapter with provided authorizer
 func NewAdapterWithAuthorizer(reg *model.Registry, authorizer lib.Authorizer) *Adapter {
 	return &Adapter{
@@ -86,6 +105,10 @@ func NewAdapterWithAuthorizer(reg *model.Registry, authorizer lib.Authorizer) *A
 
 // Info returns the basic information about the adapter
 func (a *Adapter) Info() (info *model.RegistryInfo, err error) {
+	return getRegistryInfo(), nil
+}
+
+func getRegistryInfo() *model.RegistryInfo {
 	return &model.RegistryInfo{
 		Type: model.RegistryTypeDockerRegistry,
 		SupportedResourceTypes: []string{
@@ -105,7 +128,7 @@ func (a *Adapter) Info() (info *model.RegistryInfo, err error) {
 			model.TriggerTypeManual,
 			model.TriggerTypeScheduled,
 		},
-	}, nil
+	}
 }
 
 // PrepareForPush does nothing
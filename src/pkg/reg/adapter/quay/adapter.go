@@ -114,8 +114,16 @@ func (f *factory) AdapterPattern() *model.AdapterPattern {
 	return info
 }
 
+func (f *factory) Capabilities() *model.RegistryInfo {
+	return getRegistryInfo()
+}
+
 // Info returns information of the registry
 func (a *adapter) Info() (*model.RegistryInfo, error) {
+	return getRegistryInfo(), nil
+}
+
+func getRegistryInfo() *model.RegistryInfo {
 	return &model.RegistryInfo{
 		Type: model.RegistryTypeQuay,
 		SupportedResourceTypes: []string{
@@ -135,7 +143,7 @@ func (a *adapter) Info() (*model.RegistryInfo, error) {
 			model.TriggerTypeManual,
 			model.TriggerTypeScheduled,
 		},
-	}, nil
+	}
 }
 
 // PrepareForPush does the prepare work that needed for pushing/uploading the resource
@@ -58,6 +58,10 @@ func (f *factory) AdapterPattern() *model.AdapterPattern {
 	return getAdapterInfo()
 }
 
+func (f *factory) Capabilities() *model.RegistryInfo {
+	return getRegistryInfo()
+}
+
 func getAdapterInfo() *model.AdapterPattern {
 	return &model.AdapterPattern{}
 }
@@ -166,7 +170,11 @@ func newAdapter(registry *model.Registry) (a *adapter, err error) {
 }
 
 func (a *adapter) Info() (info *model.RegistryInfo, err error) {
-	info = &model.RegistryInfo{
+	return getRegistryInfo(), nil
+}
+
+func getRegistryInfo() *model.RegistryInfo {
+	return &model.RegistryInfo{
 		Type: model.RegistryTypeTencentTcr,
 		SupportedResourceTypes: []string{
 			model.ResourceTypeImage,
@@ -187,7 +195,6 @@ func (a *adapter) Info() (info *model.RegistryInfo, err error) {
 			model.TriggerTypeScheduled,
 		},
 	}
-	return
 }
 
 func (a *adapter) PrepareForPush(resources []*model.Resource) (err error) {
@@ -48,7 +48,7 @@ func (d *dao) Create(ctx context.Context, registry *Registry) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	id, err := ormer.Insert(registry)
+	id, err := ormer.InsertWithCtx(ctx, registry)
 	if e := orm.AsConflictError(err, "registry %s already exists", registry.Name); e != nil {
 		err = e
 	}
@@ -60,7 +60,7 @@ func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 
 func (d *dao) List(ctx context.Context, query *q.Query) ([]*Registry, error) {
@@ -69,7 +69,7 @@ func (d *dao) List(ctx context.Context, query *q.Query) ([]*Registry, error) {
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&registries); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &registries); err != nil {
 		return nil, err
 	}
 	return registries, nil
@@ -83,7 +83,7 @@ func (d *dao) Get(ctx context.Context, id int64) (*Registry, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := ormer.Read(registry); err != nil {
+	if err := ormer.ReadWithCtx(ctx, registry); err != nil {
 		if e := orm.AsNotFoundError(err, "registry %d not found", id); e != nil {
 			err = e
 		}
@@ -98,7 +98,7 @@ func (d *dao) Update(ctx context.Context, registry *Registry, props ...string) e
 		return err
 	}
 	registry.UpdateTime = time.Now()
-	n, err := ormer.Update(registry, props...)
+	n, err := ormer.UpdateWithCtx(ctx, registry, props...)
 	if err != nil {
 		if e := orm.AsConflictError(err, "registry %s already exists", registry.Name); e != nil {
 			err = e
@@ -116,7 +116,7 @@ func (d *dao) Delete(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&Registry{
+	n, err := ormer.DeleteWithCtx(ctx, &Registry{
 		ID: id,
 	})
 	if err != nil {
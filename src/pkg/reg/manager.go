@@ -16,10 +16,12 @@ package reg
 
 import (
 	"context"
+	"encoding/json"
+	"os"
 
 	commonthttp "github.com/goharbor/harbor/src/common/http"
-	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/lib/config"
+	libencrypt "github.com/goharbor/harbor/src/lib/encrypt"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/reg/adapter"
 
@@ -84,6 +86,8 @@ type Manager interface {
 	ListRegistryProviderTypes(ctx context.Context) (types []string, err error)
 	// ListRegistryProviderInfos returns all the registered registry provider information
 	ListRegistryProviderInfos(ctx context.Context) (infos map[string]*model.AdapterPattern, err error)
+	// ListRegistryProviderCapabilities returns the capabilities declared by the registered registry providers
+	ListRegistryProviderCapabilities(ctx context.Context) (capabilities []*model.RegistryInfo, err error)
 }
 
 // NewManager creates an instance of registry manager
@@ -164,6 +168,10 @@ func (m *manager) ListRegistryProviderInfos(ctx context.Context) (infos map[stri
 	return adapter.ListRegisteredAdapterInfos(), nil
 }
 
+func (m *manager) ListRegistryProviderCapabilities(ctx context.Context) ([]*model.RegistryInfo, error) {
+	return adapter.ListRegisteredAdapterCapabilities(), nil
+}
+
 // getLocalRegistry returns the info of the local Harbor registry
 func getLocalRegistry() *model.Registry {
 	return &model.Registry{
@@ -178,6 +186,24 @@ func getLocalRegistry() *model.Registry {
 			AccessSecret: config.JobserviceSecret(),
 		},
 		Insecure: !commonthttp.InternalTLSEnabled(),
+		Storage:  getLocalRegistryStorage(),
+	}
+}
+
+// getLocalRegistryStorage returns the S3 storage of the local Harbor registry from the environment variables,
+// which are named as the ones overriding the storage configuration of the registry
+func getLocalRegistryStorage() *model.Storage {
+	if os.Getenv("REGISTRY_STORAGE_PROVIDER_NAME") != model.StorageTypeS3 || len(os.Getenv("REGISTRY_STORAGE_S3_BUCKET")) == 0 {
+		return nil
+	}
+	return &model.Storage{
+		Type:           model.StorageTypeS3,
+		Region:         os.Getenv("REGISTRY_STORAGE_S3_REGION"),
+		RegionEndpoint: os.Getenv("REGISTRY_STORAGE_S3_REGIONENDPOINT"),
+		Bucket:         os.Getenv("REGISTRY_STORAGE_S3_BUCKET"),
+		RootDirectory:  os.Getenv("REGISTRY_STORAGE_S3_ROOTDIRECTORY"),
+		AccessKey:      os.Getenv("REGISTRY_STORAGE_S3_ACCESSKEY"),
+		SecretKey:      os.Getenv("REGISTRY_STORAGE_S3_SECRETKEY"),
 	}
 }
 
@@ -186,11 +212,7 @@ func decrypt(secret string) (string, error) {
 	if len(secret) == 0 {
 		return "", nil
 	}
-	secretKey, err := config.SecretKey()
-	if err != nil {
-		return "", nil
-	}
-	decrypted, err := utils.ReversibleDecrypt(secret, secretKey)
+	decrypted, err := libencrypt.Instance().Decrypt(secret)
 	if err != nil {
 		return "", err
 	}
@@ -203,11 +225,7 @@ func encrypt(secret string) (string, error) {
 	if len(secret) == 0 {
 		return secret, nil
 	}
-	secretKey, err := config.SecretKey()
-	if err != nil {
-		return "", nil
-	}
-	encrypted, err := utils.ReversibleEncrypt(secret, secretKey)
+	encrypted, err := libencrypt.Instance().Encrypt(secret)
 	if err != nil {
 		return "", err
 	}
@@ -247,6 +265,32 @@ func fromDaoModel(registry *dao.Registry) (*model.Registry, error) {
 		}
 	}
 
+	if len(registry.Peering) != 0 {
+		peering := &model.Peering{}
+		if err := json.Unmarshal([]byte(registry.Peering), peering); err != nil {
+			return nil, err
+		}
+		decrypted, err := decrypt(peering.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+		peering.ClientKey = decrypted
+		r.Peering = peering
+	}
+
+	if len(registry.Storage) != 0 {
+		storage := &model.Storage{}
+		if err := json.Unmarshal([]byte(registry.Storage), storage); err != nil {
+			return nil, err
+		}
+		decrypted, err := decrypt(storage.SecretKey)
+		if err != nil {
+			return nil, err
+		}
+		storage.SecretKey = decrypted
+		r.Storage = storage
+	}
+
 	return r, nil
 }
 
@@ -280,5 +324,34 @@ func toDaoModel(registry *model.Registry) (*dao.Registry, error) {
 		m.AccessSecret = encrypted
 	}
 
+	// the client key of the peering is encrypted as the access secret
+	if registry.Peering != nil {
+		peering := *registry.Peering
+		encrypted, err := encrypt(peering.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+		peering.ClientKey = encrypted
+		data, err := json.Marshal(&peering)
+		if err != nil {
+			return nil, err
+		}
+		m.Peering = string(data)
+	}
+
+	if registry.Storage != nil {
+		storage := *registry.Storage
+		encrypted, err := encrypt(storage.SecretKey)
+		if err != nil {
+			return nil, err
+		}
+		storage.SecretKey = encrypted
+		data, err := json.Marshal(&storage)
+		if err != nil {
+			return nil, err
+		}
+		m.Storage = string(data)
+	}
+
 	return m, nil
 }
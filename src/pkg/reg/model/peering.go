@@ -0,0 +1,113 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"time"
+)
+
+const (
+	// PeeringCapabilityArtifactExistenceCheck indicates the peer can check the existence of artifacts in batch
+	PeeringCapabilityArtifactExistenceCheck = "artifact_existence_check"
+	// PeeringCapabilityLabelSync indicates the peer accepts the labels of the replicated artifacts
+	PeeringCapabilityLabelSync = "label_sync"
+	// PeeringCapabilityQuotaPreCheck indicates the peer can check the project quota before the blobs are pushed
+	PeeringCapabilityQuotaPreCheck = "quota_precheck"
+
+	// PeeringAPIVersion is the API version that the peers talk with each other
+	PeeringAPIVersion = "v2.0"
+)
+
+// PeeringCapabilities lists all the capabilities that the local Harbor offers to its peers
+var PeeringCapabilities = []string{
+	PeeringCapabilityArtifactExistenceCheck,
+	PeeringCapabilityLabelSync,
+	PeeringCapabilityQuotaPreCheck,
+}
+
+// PeeringInfo is the information that a Harbor instance advertises to its peers
+type PeeringInfo struct {
+	APIVersion   string   `json:"api_version"`
+	Capabilities []string `json:"capabilities"`
+}
+
+// Peering keeps the mutual TLS materials and the negotiated capabilities of a peered Harbor registry
+type Peering struct {
+	// CACertificate is the PEM encoded CA that signs the certificate of the peer
+	CACertificate string `json:"ca_certificate"`
+	// ClientCertificate and ClientKey are the PEM encoded key pair presented to the peer
+	ClientCertificate string    `json:"client_certificate"`
+	ClientKey         string    `json:"client_key"`
+	APIVersion        string    `json:"api_version"`
+	Capabilities      []string  `json:"capabilities"`
+	PeeredTime        time.Time `json:"peered_time"`
+}
+
+// Validate the certificates of the peering
+func (p *Peering) Validate() error {
+	if len(p.CACertificate) == 0 {
+		return errors.New("the CA certificate is required")
+	}
+	if len(p.ClientCertificate) == 0 || len(p.ClientKey) == 0 {
+		return errors.New("the client certificate and key are required")
+	}
+	_, err := p.TLSConfig(false)
+	return err
+}
+
+// Supports checks whether the capability is negotiated with the peer
+func (p *Peering) Supports(capability string) bool {
+	for _, c := range p.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// TLSConfig builds the mutual TLS configuration used to talk with the peer
+func (p *Peering) TLSConfig(insecure bool) (*tls.Config, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(p.CACertificate)) {
+		return nil, errors.New("no valid certificate found in the CA certificate")
+	}
+	cert, err := tls.X509KeyPair([]byte(p.ClientCertificate), []byte(p.ClientKey))
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		RootCAs:            pool,
+		Certificates:       []tls.Certificate{cert},
+		InsecureSkipVerify: insecure,
+		MinVersion:         tls.VersionTLS12,
+	}, nil
+}
+
+// NegotiateCapabilities returns the capabilities supported by both the local Harbor and the peer
+func NegotiateCapabilities(local, remote []string) []string {
+	var capabilities []string
+	for _, l := range local {
+		for _, r := range remote {
+			if l == r {
+				capabilities = append(capabilities, l)
+				break
+			}
+		}
+	}
+	return capabilities
+}
@@ -14,7 +14,12 @@
 
 package model
 
-import "github.com/goharbor/harbor/src/lib/errors"
+import (
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
 
 // const definition
 const (
@@ -97,3 +102,105 @@ type Trigger struct {
 type TriggerSettings struct {
 	Cron string `json:"cron"`
 }
+
+// NamespaceMapping holds the rules to name the destination repositories according to the source ones,
+// the first matched rule, the flattening and the prefix are applied in order
+type NamespaceMapping struct {
+	Rules []*NamespaceMappingRule `json:"rules,omitempty"`
+	// Flatten joins the nested namespace components with "-" to keep at most two path components,
+	// e.g. "a/b/c/image" -> "a-b-c/image"
+	Flatten bool `json:"flatten,omitempty"`
+	// Prefix is prepended to the destination repository, e.g. "mirror-" makes "library/nginx" "mirror-library/nginx"
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// NamespaceMappingRule maps the source repository to the destination one. The trailing "*" of the source
+// matches the rest of the repository path which replaces the trailing "*" of the destination,
+// e.g. "library/*" -> "mirror-hub/*" maps "library/base/nginx" to "mirror-hub/base/nginx".
+// The source without "*" only matches the repository with the same name
+type NamespaceMappingRule struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+}
+
+// Validate the namespace mapping
+func (n *NamespaceMapping) Validate() error {
+	for _, rule := range n.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	if len(n.Prefix) > 0 && !lib.RepositoryNameRe.MatchString(n.Prefix+"a") {
+		return errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("invalid namespace mapping prefix: %s", n.Prefix)
+	}
+	return nil
+}
+
+// Map the source repository to the destination one
+func (n *NamespaceMapping) Map(repository string) (string, error) {
+	name := repository
+	for _, rule := range n.Rules {
+		if mapped, ok := rule.match(repository); ok {
+			name = mapped
+			break
+		}
+	}
+	if n.Flatten {
+		components := strings.Split(name, "/")
+		if l := len(components); l > 2 {
+			name = strings.Join(components[:l-1], "-") + "/" + components[l-1]
+		}
+	}
+	name = n.Prefix + name
+	if !lib.RepositoryNameRe.MatchString(name) {
+		return "", errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("the repository %q is mapped to the invalid repository name %q", repository, name)
+	}
+	return name, nil
+}
+
+// Validate the namespace mapping rule
+func (r *NamespaceMappingRule) Validate() error {
+	sourceWildcard, err := validateMappingPattern(r.Source)
+	if err != nil {
+		return err
+	}
+	destWildcard, err := validateMappingPattern(r.Destination)
+	if err != nil {
+		return err
+	}
+	if sourceWildcard != destWildcard {
+		return errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("the source %q and the destination %q of the namespace mapping rule must both or neither end with \"*\"", r.Source, r.Destination)
+	}
+	return nil
+}
+
+func (r *NamespaceMappingRule) match(repository string) (string, bool) {
+	if !strings.HasSuffix(r.Source, "*") {
+		return r.Destination, repository == r.Source
+	}
+	prefix := strings.TrimSuffix(r.Source, "*")
+	if len(repository) <= len(prefix) || !strings.HasPrefix(repository, prefix) {
+		return "", false
+	}
+	return strings.TrimSuffix(r.Destination, "*") + repository[len(prefix):], true
+}
+
+// the pattern is a repository name optionally ending with "/*", or "*" only
+func validateMappingPattern(pattern string) (bool, error) {
+	wildcard := pattern == "*" || strings.HasSuffix(pattern, "/*")
+	name := pattern
+	if wildcard {
+		name = strings.TrimSuffix(strings.TrimSuffix(pattern, "*"), "/")
+	}
+	if wildcard && len(name) == 0 {
+		return true, nil
+	}
+	if !lib.RepositoryNameRe.MatchString(name) {
+		return false, errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("invalid namespace mapping pattern %q, it must be a repository name optionally ending with \"/*\"", pattern)
+	}
+	return wildcard, nil
+}
@@ -100,8 +100,12 @@ type Registry struct {
 	Credential      *Credential `json:"credential"`
 	Insecure        bool        `json:"insecure"`
 	Status          string      `json:"status"`
-	CreationTime    time.Time   `json:"creation_time"`
-	UpdateTime      time.Time   `json:"update_time"`
+	// Peering is only set when the registry is a Harbor instance peered with the local one
+	Peering *Peering `json:"peering,omitempty"`
+	// Storage is only set when the cloud storage of the registry is declared for the server-side copy of the blobs
+	Storage      *Storage  `json:"storage,omitempty"`
+	CreationTime time.Time `json:"creation_time"`
+	UpdateTime   time.Time `json:"update_time"`
 }
 
 // FilterStyle ...
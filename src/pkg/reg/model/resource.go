@@ -26,6 +26,17 @@ const (
 	ResourceTypeChart    = "chart"
 )
 
+// the modes to handle the foreign layers which are referenced by their URLs and not stored in the registries
+const (
+	// ForeignLayerModeSkip keeps the foreign layers referenced by their URLs without copying them
+	ForeignLayerModeSkip = "skip"
+	// ForeignLayerModePullThrough pulls the foreign layers from their URLs and stores them in the destination
+	ForeignLayerModePullThrough = "pull_through"
+	// ForeignLayerModeRewrite stores the foreign layers in the destination and rewrites them as
+	// the distributable layers in the manifests, which changes the digests of the manifests
+	ForeignLayerModeRewrite = "rewrite"
+)
+
 // Resource represents the general replicating content
 type Resource struct {
 	Type         string                 `json:"type"`
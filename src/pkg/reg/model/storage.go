@@ -0,0 +1,59 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+)
+
+// StorageTypeS3 indicates the blobs are kept in the AWS S3 or the S3 compatible storage
+const StorageTypeS3 = "s3"
+
+// Storage describes the cloud storage that the registry keeps the blobs in. When both the source and destination
+// registries of the replication keep the blobs in the same storage provider, the blobs are copied by the server-side
+// copy API of the storage rather than being streamed through the jobservice
+type Storage struct {
+	Type string `json:"type"`
+	// Region and RegionEndpoint locate the storage service, the endpoint is only needed by the S3 compatible storages
+	Region         string `json:"region"`
+	RegionEndpoint string `json:"region_endpoint"`
+	Bucket         string `json:"bucket"`
+	// RootDirectory is the prefix under which the registry keeps its files, the same as the "rootdirectory" of the registry
+	RootDirectory string `json:"root_directory"`
+	AccessKey     string `json:"access_key"`
+	SecretKey     string `json:"secret_key"`
+}
+
+// Validate the storage
+func (s *Storage) Validate() error {
+	if s.Type != StorageTypeS3 {
+		return errors.New("unsupported storage type, only \"s3\" is supported")
+	}
+	if len(s.Bucket) == 0 {
+		return errors.New("the bucket is required")
+	}
+	if len(s.Region) == 0 {
+		return errors.New("the region is required")
+	}
+	return nil
+}
+
+// SameProvider checks whether the blobs can be copied between the storages by the server-side copy API
+func (s *Storage) SameProvider(other *Storage) bool {
+	if s == nil || other == nil {
+		return false
+	}
+	return s.Type == other.Type && s.Region == other.Region && s.RegionEndpoint == other.RegionEndpoint
+}
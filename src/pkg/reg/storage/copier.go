@@ -0,0 +1,73 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+)
+
+// Copier copies the blobs between the storages of the registries by the server-side copy API of the
+// storage provider, the blob data never passes through the copier
+type Copier interface {
+	// Copy the blob specified by the digest into the storage of the destination registry and link it
+	// to the destination repository, so that the blob is visible in the destination repository directly
+	Copy(ctx context.Context, srcRepository, dstRepository, digest string, size int64) (err error)
+}
+
+// NewCopier returns the copier for the source and destination storages, error is returned if the storages
+// aren't in the same storage provider
+func NewCopier(src, dst *model.Storage) (Copier, error) {
+	if !src.SameProvider(dst) {
+		return nil, fmt.Errorf("the source and destination storages aren't in the same storage provider")
+	}
+	if err := dst.Validate(); err != nil {
+		return nil, err
+	}
+	if err := src.Validate(); err != nil {
+		return nil, err
+	}
+	return newS3Copier(src, dst)
+}
+
+// blobDataPath returns the path of the blob data under the root directory, the layout is the same as the
+// one of the registry storage driver
+func blobDataPath(rootDirectory, dgst string) (string, error) {
+	d, err := digest.Parse(dgst)
+	if err != nil {
+		return "", err
+	}
+	hex := d.Hex()
+	return key(rootDirectory, "docker/registry/v2/blobs", d.Algorithm().String(), hex[:2], hex, "data"), nil
+}
+
+// layerLinkPath returns the path of the file linking the blob to the repository under the root directory
+func layerLinkPath(rootDirectory, repository, dgst string) (string, error) {
+	d, err := digest.Parse(dgst)
+	if err != nil {
+		return "", err
+	}
+	return key(rootDirectory, "docker/registry/v2/repositories", repository, "_layers", d.Algorithm().String(), d.Hex(), "link"), nil
+}
+
+func key(rootDirectory string, elem ...string) string {
+	return strings.TrimPrefix(path.Join(append([]string{"/", rootDirectory}, elem...)...), "/")
+}
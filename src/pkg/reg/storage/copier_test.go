@@ -0,0 +1,131 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+)
+
+const testDigest = "sha256:4e8b3c1fa55f1a87d1dcae35a5e9c1b8a7b4c9d8b5f5b4e2a0a3c3b6d5a9e1f2"
+
+func TestPaths(t *testing.T) {
+	p, err := blobDataPath("", testDigest)
+	require.Nil(t, err)
+	assert.Equal(t, "docker/registry/v2/blobs/sha256/4e/4e8b3c1fa55f1a87d1dcae35a5e9c1b8a7b4c9d8b5f5b4e2a0a3c3b6d5a9e1f2/data", p)
+
+	p, err = blobDataPath("/harbor/", testDigest)
+	require.Nil(t, err)
+	assert.Equal(t, "harbor/docker/registry/v2/blobs/sha256/4e/4e8b3c1fa55f1a87d1dcae35a5e9c1b8a7b4c9d8b5f5b4e2a0a3c3b6d5a9e1f2/data", p)
+
+	p, err = layerLinkPath("harbor", "library/hello-world", testDigest)
+	require.Nil(t, err)
+	assert.Equal(t, "harbor/docker/registry/v2/repositories/library/hello-world/_layers/sha256/4e8b3c1fa55f1a87d1dcae35a5e9c1b8a7b4c9d8b5f5b4e2a0a3c3b6d5a9e1f2/link", p)
+
+	_, err = blobDataPath("", "invalid")
+	assert.NotNil(t, err)
+}
+
+func TestNewCopier(t *testing.T) {
+	// different providers
+	_, err := NewCopier(&model.Storage{Type: model.StorageTypeS3, Region: "us-east-1", Bucket: "src"},
+		&model.Storage{Type: model.StorageTypeS3, Region: "us-west-1", Bucket: "dst"})
+	assert.NotNil(t, err)
+
+	// invalid storage
+	_, err = NewCopier(&model.Storage{Type: model.StorageTypeS3, Region: "us-east-1"},
+		&model.Storage{Type: model.StorageTypeS3, Region: "us-east-1", Bucket: "dst"})
+	assert.NotNil(t, err)
+
+	// nil storage
+	_, err = NewCopier(nil, &model.Storage{Type: model.StorageTypeS3, Region: "us-east-1", Bucket: "dst"})
+	assert.NotNil(t, err)
+
+	// pass
+	copier, err := NewCopier(&model.Storage{Type: model.StorageTypeS3, Region: "us-east-1", Bucket: "src"},
+		&model.Storage{Type: model.StorageTypeS3, Region: "us-east-1", Bucket: "dst"})
+	require.Nil(t, err)
+	assert.NotNil(t, copier)
+}
+
+func TestS3Copy(t *testing.T) {
+	type request struct {
+		path       string
+		copySource string
+		body       string
+	}
+	var requests []*request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, &request{
+			path:       r.URL.Path,
+			copySource: r.Header.Get("X-Amz-Copy-Source"),
+			body:       string(body),
+		})
+		if len(r.Header.Get("X-Amz-Copy-Source")) > 0 {
+			w.Write([]byte(`<CopyObjectResult><ETag>"etag"</ETag></CopyObjectResult>`))
+		}
+	}))
+	defer server.Close()
+
+	src := &model.Storage{
+		Type:           model.StorageTypeS3,
+		Region:         "us-east-1",
+		RegionEndpoint: server.URL,
+		Bucket:         "src",
+		RootDirectory:  "/harbor",
+	}
+	dst := &model.Storage{
+		Type:           model.StorageTypeS3,
+		Region:         "us-east-1",
+		RegionEndpoint: server.URL,
+		Bucket:         "dst",
+		AccessKey:      "access",
+		SecretKey:      "secret",
+	}
+	copier, err := NewCopier(src, dst)
+	require.Nil(t, err)
+
+	// exceed the limit of the server-side copy
+	err = copier.Copy(context.Background(), "library/hello-world", "mirror/hello-world", testDigest, maxCopyObjectSize+1)
+	require.NotNil(t, err)
+	assert.Len(t, requests, 0)
+
+	// the blob is copied and linked to the destination repository
+	err = copier.Copy(context.Background(), "library/hello-world", "mirror/hello-world", testDigest, 1024)
+	require.Nil(t, err)
+	require.Len(t, requests, 2)
+	assert.Equal(t, "/dst/docker/registry/v2/blobs/sha256/4e/4e8b3c1fa55f1a87d1dcae35a5e9c1b8a7b4c9d8b5f5b4e2a0a3c3b6d5a9e1f2/data", requests[0].path)
+	assert.Equal(t, "src/harbor/docker/registry/v2/blobs/sha256/4e/4e8b3c1fa55f1a87d1dcae35a5e9c1b8a7b4c9d8b5f5b4e2a0a3c3b6d5a9e1f2/data", requests[0].copySource)
+	assert.Equal(t, "/dst/docker/registry/v2/repositories/mirror/hello-world/_layers/sha256/4e8b3c1fa55f1a87d1dcae35a5e9c1b8a7b4c9d8b5f5b4e2a0a3c3b6d5a9e1f2/link", requests[1].path)
+	assert.Equal(t, testDigest, requests[1].body)
+
+	// the blob is in the shared storage already, only the link is created
+	requests = nil
+	copier, err = NewCopier(dst, dst)
+	require.Nil(t, err)
+	err = copier.Copy(context.Background(), "library/hello-world", "mirror/hello-world", testDigest, 1024)
+	require.Nil(t, err)
+	require.Len(t, requests, 1)
+	assert.Empty(t, requests[0].copySource)
+}
@@ -0,0 +1,108 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/goharbor/harbor/src/pkg/reg/model"
+)
+
+// maxCopyObjectSize is the max size of the object that can be copied by one CopyObject request
+const maxCopyObjectSize = 5 * 1024 * 1024 * 1024
+
+// s3Copier copies the blobs by the CopyObject API, the requests are signed by the credential of the
+// destination storage which needs to be granted the permission to read the source bucket
+type s3Copier struct {
+	src    *model.Storage
+	dst    *model.Storage
+	client *s3.S3
+}
+
+func newS3Copier(src, dst *model.Storage) (*s3Copier, error) {
+	config := aws.NewConfig().WithRegion(dst.Region)
+	if len(dst.RegionEndpoint) > 0 {
+		// the S3 compatible storages are usually addressed by path style
+		config = config.WithEndpoint(dst.RegionEndpoint).WithS3ForcePathStyle(true)
+	}
+	if len(dst.AccessKey) > 0 {
+		config = config.WithCredentials(credentials.NewStaticCredentials(dst.AccessKey, dst.SecretKey, ""))
+	}
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, err
+	}
+	return &s3Copier{
+		src:    src,
+		dst:    dst,
+		client: s3.New(sess),
+	}, nil
+}
+
+func (s *s3Copier) Copy(ctx context.Context, srcRepository, dstRepository, digest string, size int64) error {
+	if size > maxCopyObjectSize {
+		return fmt.Errorf("the size %d of the blob %s exceeds the limit of the server-side copy", size, digest)
+	}
+	srcKey, err := blobDataPath(s.src.RootDirectory, digest)
+	if err != nil {
+		return err
+	}
+	dstKey, err := blobDataPath(s.dst.RootDirectory, digest)
+	if err != nil {
+		return err
+	}
+	// the blob may be in the destination storage already, e.g. when the source and destination registries
+	// share the same bucket and root directory
+	if s.src.Bucket != s.dst.Bucket || srcKey != dstKey {
+		if _, err = s.client.CopyObjectWithContext(ctx, &s3.CopyObjectInput{
+			Bucket:     aws.String(s.dst.Bucket),
+			Key:        aws.String(dstKey),
+			CopySource: aws.String(copySource(s.src.Bucket, srcKey)),
+		}); err != nil {
+			return fmt.Errorf("failed to copy the blob %s from %s to %s: %v", digest, srcKey, dstKey, err)
+		}
+	}
+
+	linkKey, err := layerLinkPath(s.dst.RootDirectory, dstRepository, digest)
+	if err != nil {
+		return err
+	}
+	if _, err = s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.dst.Bucket),
+		Key:         aws.String(linkKey),
+		Body:        strings.NewReader(digest),
+		ContentType: aws.String("application/octet-stream"),
+	}); err != nil {
+		return fmt.Errorf("failed to link the blob %s to the repository %s: %v", digest, dstRepository, err)
+	}
+	return nil
+}
+
+// copySource returns the URL encoded source of the CopyObject request
+func copySource(bucket, key string) string {
+	segments := strings.Split(bucket+"/"+key, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	return strings.Join(segments, "/")
+}
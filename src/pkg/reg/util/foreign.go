@@ -0,0 +1,86 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/schema2"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+// the distributable media types of the foreign layers
+var distributableMediaTypes = map[string]string{
+	schema2.MediaTypeForeignLayer:              schema2.MediaTypeLayer,
+	v1.MediaTypeImageLayerNonDistributable:     v1.MediaTypeImageLayer,
+	v1.MediaTypeImageLayerNonDistributableGzip: v1.MediaTypeImageLayerGzip,
+	v1.MediaTypeImageLayerNonDistributableZstd: v1.MediaTypeImageLayerZstd,
+}
+
+// IsForeignLayer returns whether the media type is the one of the foreign layers which
+// are referenced by their URLs rather than stored in the registries, e.g. the base layers of the Windows images
+func IsForeignLayer(mediaType string) bool {
+	_, exist := distributableMediaTypes[mediaType]
+	return exist
+}
+
+// DistributableMediaType returns the media type of the distributable layer that the foreign layer can be rewritten as,
+// the media type is returned as it is if it isn't the one of the foreign layers
+func DistributableMediaType(mediaType string) string {
+	if mt, exist := distributableMediaTypes[mediaType]; exist {
+		return mt
+	}
+	return mediaType
+}
+
+// PullForeignLayer pulls the foreign layer from the URLs of its descriptor one by one until succeeded
+func PullForeignLayer(desc distribution.Descriptor) (int64, io.ReadCloser, error) {
+	if len(desc.URLs) == 0 {
+		return 0, nil, errors.Errorf("the foreign layer %s has no URLs to pull it from", desc.Digest)
+	}
+	client := &http.Client{
+		Transport: GetHTTPTransport(false),
+	}
+	var errs []string
+	for _, url := range desc.URLs {
+		resp, err := client.Get(url)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			errs = append(errs, fmt.Sprintf("%s: unexpected status code %d", url, resp.StatusCode))
+			continue
+		}
+		size := resp.ContentLength
+		if size < 0 {
+			size = desc.Size
+		}
+		if desc.Size > 0 && size != desc.Size {
+			resp.Body.Close()
+			errs = append(errs, fmt.Sprintf("%s: the size %d mismatches the size %d in the descriptor", url, size, desc.Size))
+			continue
+		}
+		return size, resp.Body, nil
+	}
+	return 0, nil, errors.Errorf("failed to pull the foreign layer %s from its URLs: %s", desc.Digest, strings.Join(errs, "; "))
+}
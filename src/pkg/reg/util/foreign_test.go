@@ -0,0 +1,85 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsForeignLayer(t *testing.T) {
+	assert.True(t, IsForeignLayer(schema2.MediaTypeForeignLayer))
+	assert.True(t, IsForeignLayer(v1.MediaTypeImageLayerNonDistributableGzip))
+	assert.False(t, IsForeignLayer(schema2.MediaTypeLayer))
+	assert.False(t, IsForeignLayer(v1.MediaTypeImageLayerGzip))
+}
+
+func TestDistributableMediaType(t *testing.T) {
+	assert.Equal(t, schema2.MediaTypeLayer, DistributableMediaType(schema2.MediaTypeForeignLayer))
+	assert.Equal(t, v1.MediaTypeImageLayerGzip, DistributableMediaType(v1.MediaTypeImageLayerNonDistributableGzip))
+	assert.Equal(t, schema2.MediaTypeImageConfig, DistributableMediaType(schema2.MediaTypeImageConfig))
+}
+
+func TestPullForeignLayer(t *testing.T) {
+	content := []byte("foreign layer")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/layer" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	desc := distribution.Descriptor{
+		MediaType: schema2.MediaTypeForeignLayer,
+		Digest:    digest.FromBytes(content),
+		Size:      int64(len(content)),
+	}
+
+	// no URLs
+	_, _, err := PullForeignLayer(desc)
+	assert.NotNil(t, err)
+
+	// fall back to the next URL
+	desc.URLs = []string{server.URL + "/notfound", server.URL + "/layer"}
+	size, reader, err := PullForeignLayer(desc)
+	require.Nil(t, err)
+	defer reader.Close()
+	assert.Equal(t, int64(len(content)), size)
+	data, err := io.ReadAll(reader)
+	require.Nil(t, err)
+	assert.Equal(t, content, data)
+
+	// size mismatch
+	desc.URLs = []string{server.URL + "/layer"}
+	desc.Size = 1
+	_, _, err = PullForeignLayer(desc)
+	assert.NotNil(t, err)
+
+	// all the URLs fail
+	desc.URLs = []string{server.URL + "/notfound"}
+	_, _, err = PullForeignLayer(desc)
+	assert.NotNil(t, err)
+}
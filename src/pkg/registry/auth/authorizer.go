@@ -33,11 +33,16 @@ import (
 
 // NewAuthorizer creates an authorizer that can handle different auth schemes
 func NewAuthorizer(username, password string, insecure bool) lib.Authorizer {
+	return NewAuthorizerWithTransport(username, password, commonhttp.GetHTTPTransport(commonhttp.WithInsecure(insecure)))
+}
+
+// NewAuthorizerWithTransport creates an authorizer that talks with the registry and token service by the provided transport
+func NewAuthorizerWithTransport(username, password string, transport http.RoundTripper) lib.Authorizer {
 	return &authorizer{
 		username: username,
 		password: password,
 		client: &http.Client{
-			Transport: commonhttp.GetHTTPTransport(commonhttp.WithInsecure(insecure)),
+			Transport: transport,
 		},
 	}
 }
@@ -45,11 +45,21 @@ import (
 )
 
 var (
-	// Cli is the global registry client instance, it targets to the backend docker registry
+	// Cli is the global registry client instance, it targets to the backend docker registry, or
+	// the registry shards serving the projects when the shards are configured
 	Cli = func() Client {
 		url, _ := config.RegistryURL()
 		username, password := config.RegistryCredential()
-		return NewClient(url, username, password, false, readonly.NewInterceptor())
+		cli := NewClient(url, username, password, false, readonly.NewInterceptor())
+		shards := config.RegistryShards()
+		if len(shards) == 0 {
+			return cli
+		}
+		clients := map[string]Client{}
+		for name, url := range shards {
+			clients[name] = NewClient(url, username, password, false, readonly.NewInterceptor())
+		}
+		return NewShardedClient(cli, clients)
 	}()
 
 	accepts = []string{
@@ -135,12 +145,17 @@ func NewClient(url, username, password string, insecure bool, interceptors ...in
 
 // NewClientWithAuthorizer creates a registry client with the provided authorizer
 func NewClientWithAuthorizer(url string, authorizer lib.Authorizer, insecure bool, interceptors ...interceptor.Interceptor) Client {
+	return NewClientWithTransport(url, authorizer, commonhttp.GetHTTPTransport(commonhttp.WithInsecure(insecure)), interceptors...)
+}
+
+// NewClientWithTransport creates a registry client with the provided authorizer and transport
+func NewClientWithTransport(url string, authorizer lib.Authorizer, transport http.RoundTripper, interceptors ...interceptor.Interceptor) Client {
 	return &client{
 		url:          url,
 		authorizer:   authorizer,
 		interceptors: interceptors,
 		client: &http.Client{
-			Transport: commonhttp.GetHTTPTransport(commonhttp.WithInsecure(insecure)),
+			Transport: transport,
 			Timeout:   registryHTTPClientTimeout,
 		},
 	}
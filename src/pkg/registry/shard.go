@@ -0,0 +1,316 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/distribution/manifest/schema2"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg"
+	"github.com/goharbor/harbor/src/pkg/project/models"
+)
+
+// ShardOf returns the name of the registry shard serving the repository, it's empty when the project
+// of the repository isn't assigned to any shard and is served by the default registry
+func ShardOf(ctx context.Context, repository string) (string, error) {
+	projectName, _ := utils.ParseRepository(repository)
+	p, err := pkg.ProjectMgr.Get(ctx, projectName)
+	if err != nil {
+		// let the default registry handle the repositories of the nonexistent projects
+		if errors.IsNotFoundErr(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	metas, err := pkg.ProjectMetaMgr.Get(ctx, p.ProjectID, models.ProMetaRegistryShard)
+	if err != nil {
+		return "", err
+	}
+	return metas[models.ProMetaRegistryShard], nil
+}
+
+// NewShardedClient returns the client which sends the requests to the registry shards serving the projects
+// of the repositories. The "shards" are keyed by the names of the shards and the "def" serves the projects
+// which aren't assigned to any shard
+func NewShardedClient(def Client, shards map[string]Client) Client {
+	return &shardedClient{
+		def:    def,
+		shards: shards,
+		resolve: func(repository string) (string, error) {
+			return ShardOf(orm.Context(), repository)
+		},
+	}
+}
+
+type shardedClient struct {
+	def     Client
+	shards  map[string]Client
+	resolve func(repository string) (string, error)
+}
+
+// client returns the client of the registry serving the repository
+func (s *shardedClient) client(repository string) (Client, error) {
+	shard, err := s.resolve(repository)
+	if err != nil {
+		return nil, err
+	}
+	if len(shard) == 0 {
+		return s.def, nil
+	}
+	cli, exist := s.shards[shard]
+	if !exist {
+		return nil, errors.New(nil).WithCode(errors.GeneralCode).
+			WithMessage("the registry shard %s serving the repository %s isn't configured", shard, repository)
+	}
+	return cli, nil
+}
+
+func (s *shardedClient) Ping() error {
+	if err := s.def.Ping(); err != nil {
+		return err
+	}
+	for _, cli := range s.shards {
+		if err := cli.Ping(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *shardedClient) Catalog() ([]string, error) {
+	repositories, err := s.def.Catalog()
+	if err != nil {
+		return nil, err
+	}
+	for _, cli := range s.shards {
+		repos, err := cli.Catalog()
+		if err != nil {
+			return nil, err
+		}
+		repositories = append(repositories, repos...)
+	}
+	sort.Strings(repositories)
+	return repositories, nil
+}
+
+func (s *shardedClient) ListTags(repository string) ([]string, error) {
+	cli, err := s.client(repository)
+	if err != nil {
+		return nil, err
+	}
+	return cli.ListTags(repository)
+}
+
+func (s *shardedClient) ManifestExist(repository, reference string) (bool, *distribution.Descriptor, error) {
+	cli, err := s.client(repository)
+	if err != nil {
+		return false, nil, err
+	}
+	return cli.ManifestExist(repository, reference)
+}
+
+func (s *shardedClient) PullManifest(repository, reference string, acceptedMediaTypes ...string) (distribution.Manifest, string, error) {
+	cli, err := s.client(repository)
+	if err != nil {
+		return nil, "", err
+	}
+	return cli.PullManifest(repository, reference, acceptedMediaTypes...)
+}
+
+func (s *shardedClient) PushManifest(repository, reference, mediaType string, payload []byte) (string, error) {
+	cli, err := s.client(repository)
+	if err != nil {
+		return "", err
+	}
+	return cli.PushManifest(repository, reference, mediaType, payload)
+}
+
+func (s *shardedClient) DeleteManifest(repository, reference string) error {
+	cli, err := s.client(repository)
+	if err != nil {
+		return err
+	}
+	return cli.DeleteManifest(repository, reference)
+}
+
+func (s *shardedClient) BlobExist(repository, digest string) (bool, error) {
+	cli, err := s.client(repository)
+	if err != nil {
+		return false, err
+	}
+	return cli.BlobExist(repository, digest)
+}
+
+func (s *shardedClient) PullBlob(repository, digest string) (int64, io.ReadCloser, error) {
+	cli, err := s.client(repository)
+	if err != nil {
+		return 0, nil, err
+	}
+	return cli.PullBlob(repository, digest)
+}
+
+func (s *shardedClient) PullBlobChunk(repository, digest string, blobSize, start, end int64) (int64, io.ReadCloser, error) {
+	cli, err := s.client(repository)
+	if err != nil {
+		return 0, nil, err
+	}
+	return cli.PullBlobChunk(repository, digest, blobSize, start, end)
+}
+
+func (s *shardedClient) PushBlob(repository, digest string, size int64, blob io.Reader) error {
+	cli, err := s.client(repository)
+	if err != nil {
+		return err
+	}
+	return cli.PushBlob(repository, digest, size, blob)
+}
+
+func (s *shardedClient) PushBlobChunk(repository, digest string, blobSize int64, chunk io.Reader, start, end int64, location string) (string, int64, error) {
+	cli, err := s.client(repository)
+	if err != nil {
+		return "", 0, err
+	}
+	return cli.PushBlobChunk(repository, digest, blobSize, chunk, start, end, location)
+}
+
+// MountBlob mounts the blob when both the repositories are served by the same registry, otherwise
+// the blob is pulled from the source registry and pushed into the destination one
+func (s *shardedClient) MountBlob(srcRepository, digest, dstRepository string) error {
+	src, dst, err := s.clients(srcRepository, dstRepository)
+	if err != nil {
+		return err
+	}
+	if src == dst {
+		return src.MountBlob(srcRepository, digest, dstRepository)
+	}
+	return transferBlob(src, dst, srcRepository, digest, dstRepository)
+}
+
+func (s *shardedClient) DeleteBlob(repository, digest string) error {
+	cli, err := s.client(repository)
+	if err != nil {
+		return err
+	}
+	return cli.DeleteBlob(repository, digest)
+}
+
+// Copy copies the artifact inside the registry when both the repositories are served by the same registry,
+// otherwise the manifests and blobs are transferred from the source registry into the destination one
+func (s *shardedClient) Copy(srcRepository, srcReference, dstRepository, dstReference string, override bool) error {
+	src, dst, err := s.clients(srcRepository, dstRepository)
+	if err != nil {
+		return err
+	}
+	if src == dst {
+		return src.Copy(srcRepository, srcReference, dstRepository, dstReference, override)
+	}
+	return transfer(src, dst, srcRepository, srcReference, dstRepository, dstReference, override)
+}
+
+// Do sends the request to the default registry as the URL of the request is built by the caller
+func (s *shardedClient) Do(req *http.Request) (*http.Response, error) {
+	return s.def.Do(req)
+}
+
+func (s *shardedClient) clients(srcRepository, dstRepository string) (Client, Client, error) {
+	src, err := s.client(srcRepository)
+	if err != nil {
+		return nil, nil, err
+	}
+	dst, err := s.client(dstRepository)
+	if err != nil {
+		return nil, nil, err
+	}
+	return src, dst, nil
+}
+
+// transfer copies the artifact between the repositories served by the different registries
+func transfer(src, dst Client, srcRepo, srcRef, dstRepo, dstRef string, override bool) error {
+	manifest, srcDgt, err := src.PullManifest(srcRepo, srcRef)
+	if err != nil {
+		return err
+	}
+
+	exist, desc, err := dst.ManifestExist(dstRepo, dstRef)
+	if err != nil {
+		return err
+	}
+	if exist {
+		// the same artifact already exists
+		if desc != nil && srcDgt == string(desc.Digest) {
+			return nil
+		}
+		if !override {
+			return errors.New(nil).WithCode(errors.PreconditionCode).
+				WithMessage("the same name but different digest artifact exists, but the override is set to false")
+		}
+	}
+
+	for _, descriptor := range manifest.References() {
+		digest := descriptor.Digest.String()
+		switch descriptor.MediaType {
+		// skip foreign layer
+		case schema2.MediaTypeForeignLayer:
+			continue
+		// manifest or index
+		case v1.MediaTypeImageIndex, manifestlist.MediaTypeManifestList,
+			v1.MediaTypeImageManifest, schema2.MediaTypeManifest,
+			schema1.MediaTypeSignedManifest, schema1.MediaTypeManifest:
+			if err = transfer(src, dst, srcRepo, digest, dstRepo, digest, false); err != nil {
+				return err
+			}
+		// common layer
+		default:
+			exist, err := dst.BlobExist(dstRepo, digest)
+			if err != nil {
+				return err
+			}
+			if exist {
+				continue
+			}
+			if err = transferBlob(src, dst, srcRepo, digest, dstRepo); err != nil {
+				return err
+			}
+		}
+	}
+
+	mediaType, payload, err := manifest.Payload()
+	if err != nil {
+		return err
+	}
+	_, err = dst.PushManifest(dstRepo, dstRef, mediaType, payload)
+	return err
+}
+
+// transferBlob pulls the blob from the source registry and pushes it into the destination one
+func transferBlob(src, dst Client, srcRepo, digest, dstRepo string) error {
+	size, blob, err := src.PullBlob(srcRepo, digest)
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+	return dst.PushBlob(dstRepo, digest, size, blob)
+}
@@ -0,0 +1,110 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	testingregistry "github.com/goharbor/harbor/src/testing/pkg/registry"
+)
+
+type shardedClientTestSuite struct {
+	suite.Suite
+	def    *testingregistry.Client
+	large  *testingregistry.Client
+	client *shardedClient
+}
+
+func (s *shardedClientTestSuite) SetupTest() {
+	s.def = &testingregistry.Client{}
+	s.large = &testingregistry.Client{}
+	s.client = &shardedClient{
+		def:    s.def,
+		shards: map[string]Client{"large": s.large},
+		resolve: func(repository string) (string, error) {
+			switch {
+			case strings.HasPrefix(repository, "tenant/"):
+				return "large", nil
+			case strings.HasPrefix(repository, "removed/"):
+				return "removed", nil
+			case strings.HasPrefix(repository, "broken/"):
+				return "", errors.New("failed to get project")
+			}
+			return "", nil
+		},
+	}
+}
+
+func (s *shardedClientTestSuite) TestRouting() {
+	s.def.On("ListTags", "library/hello-world").Return([]string{"latest"}, nil)
+	s.large.On("ListTags", "tenant/hello-world").Return([]string{"v1"}, nil)
+
+	tags, err := s.client.ListTags("library/hello-world")
+	s.Require().Nil(err)
+	s.Equal([]string{"latest"}, tags)
+
+	tags, err = s.client.ListTags("tenant/hello-world")
+	s.Require().Nil(err)
+	s.Equal([]string{"v1"}, tags)
+
+	_, err = s.client.ListTags("removed/hello-world")
+	s.NotNil(err)
+
+	_, err = s.client.ListTags("broken/hello-world")
+	s.NotNil(err)
+
+	s.def.AssertExpectations(s.T())
+	s.large.AssertExpectations(s.T())
+}
+
+func (s *shardedClientTestSuite) TestCatalog() {
+	s.def.On("Catalog").Return([]string{"library/hello-world"}, nil)
+	s.large.On("Catalog").Return([]string{"tenant/app"}, nil)
+
+	repositories, err := s.client.Catalog()
+	s.Require().Nil(err)
+	s.Equal([]string{"library/hello-world", "tenant/app"}, repositories)
+}
+
+func (s *shardedClientTestSuite) TestMountBlob() {
+	// inside the same registry
+	s.large.On("MountBlob", "tenant/a", "sha256:1", "tenant/b").Return(nil)
+	s.Nil(s.client.MountBlob("tenant/a", "sha256:1", "tenant/b"))
+
+	// across the registries
+	s.def.On("PullBlob", "library/a", "sha256:2").Return(int64(3), io.NopCloser(strings.NewReader("abc")), nil)
+	s.large.On("PushBlob", "tenant/b", "sha256:2", int64(3), mock.Anything).Return(nil)
+	s.Nil(s.client.MountBlob("library/a", "sha256:2", "tenant/b"))
+
+	s.def.AssertNotCalled(s.T(), "MountBlob", mock.Anything, mock.Anything, mock.Anything)
+	s.def.AssertExpectations(s.T())
+	s.large.AssertExpectations(s.T())
+}
+
+func (s *shardedClientTestSuite) TestCopy() {
+	s.def.On("Copy", "library/a", "latest", "library/b", "latest", false).Return(nil)
+	s.Nil(s.client.Copy("library/a", "latest", "library/b", "latest", false))
+	s.def.AssertExpectations(s.T())
+}
+
+func TestShardedClientTestSuite(t *testing.T) {
+	suite.Run(t, &shardedClientTestSuite{})
+}
@@ -51,7 +51,7 @@ func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 
 func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.Policy, error) {
@@ -60,7 +60,7 @@ func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.Policy, error)
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&policies); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &policies); err != nil {
 		return nil, err
 	}
 	return policies, nil
@@ -74,7 +74,7 @@ func (d *dao) Get(ctx context.Context, id int64) (*model.Policy, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := ormer.Read(policy); err != nil {
+	if err := ormer.ReadWithCtx(ctx, policy); err != nil {
 		if e := orm.AsNotFoundError(err, "replication policy %d not found", id); e != nil {
 			err = e
 		}
@@ -88,7 +88,7 @@ func (d *dao) Create(ctx context.Context, policy *model.Policy) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	id, err := ormer.Insert(policy)
+	id, err := ormer.InsertWithCtx(ctx, policy)
 	if e := orm.AsConflictError(err, "replication policy %s already exists", policy.Name); e != nil {
 		err = e
 	}
@@ -100,7 +100,7 @@ func (d *dao) Update(ctx context.Context, policy *model.Policy, props ...string)
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Update(policy, props...)
+	n, err := ormer.UpdateWithCtx(ctx, policy, props...)
 	if err != nil {
 		if e := orm.AsConflictError(err, "replication policy %s already exists", policy.Name); e != nil {
 			err = e
@@ -119,7 +119,7 @@ func (d *dao) Delete(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&model.Policy{
+	n, err := ormer.DeleteWithCtx(ctx, &model.Policy{
 		ID: id,
 	})
 	if err != nil {
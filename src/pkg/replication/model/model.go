@@ -43,6 +43,9 @@ type Policy struct {
 	UpdateTime                time.Time `orm:"column(update_time);auto_now"`
 	Speed                     int32     `orm:"column(speed_kb)"`
 	CopyByChunk               bool      `orm:"column(copy_by_chunk)"`
+	CopyByStorage             bool      `orm:"column(copy_by_storage)"`
+	ForeignLayerMode          string    `orm:"column(foreign_layer_mode)"`
+	NamespaceMapping          string    `orm:"column(namespace_mapping)"`
 }
 
 // TableName set table name for ORM
@@ -42,6 +42,8 @@ type DAO interface {
 	Update(ctx context.Context, repository *model.RepoRecord, props ...string) (err error)
 	// AddPullCount increase pull count for the specified repository
 	AddPullCount(ctx context.Context, id int64, count uint64) error
+	// UpdatePullTime updates the pull time of the specified repository if the provided one is later
+	UpdatePullTime(ctx context.Context, id int64, pullTime time.Time) error
 	// NonEmptyRepos returns the repositories without any artifact or all the artifacts are untagged.
 	NonEmptyRepos(ctx context.Context) ([]*model.RepoRecord, error)
 }
@@ -58,7 +60,7 @@ func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.RepoRecord, error) {
 	repositories := []*model.RepoRecord{}
@@ -66,7 +68,10 @@ func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.RepoRecord, er
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&repositories); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &repositories); err != nil {
+		return nil, err
+	}
+	if err = orm.SetNextCursor(query, repositories); err != nil {
 		return nil, err
 	}
 	return repositories, nil
@@ -80,7 +85,7 @@ func (d *dao) Get(ctx context.Context, id int64) (*model.RepoRecord, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := ormer.Read(repository); err != nil {
+	if err := ormer.ReadWithCtx(ctx, repository); err != nil {
 		if e := orm.AsNotFoundError(err, "repository %d not found", id); e != nil {
 			err = e
 		}
@@ -94,7 +99,7 @@ func (d *dao) Create(ctx context.Context, repository *model.RepoRecord) (int64,
 	if err != nil {
 		return 0, err
 	}
-	id, err := ormer.Insert(repository)
+	id, err := ormer.InsertWithCtx(ctx, repository)
 	if e := orm.AsConflictError(err, "repository %s already exists", repository.Name); e != nil {
 		err = e
 	}
@@ -106,7 +111,7 @@ func (d *dao) Delete(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&model.RepoRecord{
+	n, err := ormer.DeleteWithCtx(ctx, &model.RepoRecord{
 		RepositoryID: id,
 	})
 	if err != nil {
@@ -123,7 +128,7 @@ func (d *dao) Update(ctx context.Context, repository *model.RepoRecord, props ..
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Update(repository, props...)
+	n, err := ormer.UpdateWithCtx(ctx, repository, props...)
 	if err != nil {
 		return err
 	}
@@ -152,6 +157,17 @@ func (d *dao) AddPullCount(ctx context.Context, id int64, count uint64) error {
 	return nil
 }
 
+func (d *dao) UpdatePullTime(ctx context.Context, id int64, pullTime time.Time) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	// the pull times are flushed asynchronously, make sure the pull time never goes backwards
+	_, err = ormer.Raw(`UPDATE repository SET pull_time = ? WHERE repository_id = ? AND (pull_time IS NULL OR pull_time < ?)`,
+		pullTime, id, pullTime).Exec()
+	return err
+}
+
 func (d *dao) NonEmptyRepos(ctx context.Context) ([]*model.RepoRecord, error) {
 	var repos []*model.RepoRecord
 	ormer, err := orm.FromContext(ctx)
@@ -29,6 +29,8 @@ import (
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
 	af_dao "github.com/goharbor/harbor/src/pkg/artifact/dao"
+	label_dao "github.com/goharbor/harbor/src/pkg/label/dao"
+	label_model "github.com/goharbor/harbor/src/pkg/label/model"
 	"github.com/goharbor/harbor/src/pkg/repository/model"
 	tag_dao "github.com/goharbor/harbor/src/pkg/tag/dao"
 	"github.com/goharbor/harbor/src/pkg/tag/model/tag"
@@ -171,6 +173,23 @@ func (d *daoTestSuite) TestUpdate() {
 	d.Equal(errors.NotFoundCode, e.Code)
 }
 
+func (d *daoTestSuite) TestUpdateLinksAndMetadata() {
+	repository := &model.RepoRecord{
+		RepositoryID: d.id,
+	}
+	d.Require().Nil(repository.SetLinks([]*model.Link{{Name: "Source code", URL: "https://github.com/goharbor/harbor"}}))
+	d.Require().Nil(repository.SetMetadata(map[string]string{"team": "core"}))
+	err := d.dao.Update(d.ctx, repository, "Links", "Metadata")
+	d.Require().Nil(err)
+
+	repository, err = d.dao.Get(d.ctx, d.id)
+	d.Require().Nil(err)
+	links := repository.GetLinks()
+	d.Require().Len(links, 1)
+	d.Equal("https://github.com/goharbor/harbor", links[0].URL)
+	d.Equal("core", repository.GetMetadata()["team"])
+}
+
 func (d *daoTestSuite) TestAddPullCount() {
 	repository := &model.RepoRecord{
 		Name:        "test/pullcount",
@@ -192,6 +211,31 @@ func (d *daoTestSuite) TestAddPullCount() {
 	d.dao.Delete(d.ctx, id)
 }
 
+func (d *daoTestSuite) TestUpdatePullTime() {
+	repository := &model.RepoRecord{
+		Name:        "test/pulltime",
+		ProjectID:   10,
+		Description: "test pull time",
+	}
+	id, err := d.dao.Create(d.ctx, repository)
+	d.Require().Nil(err)
+	defer d.dao.Delete(d.ctx, id)
+
+	pullTime := time.Now().Truncate(time.Second)
+	err = d.dao.UpdatePullTime(d.ctx, id, pullTime)
+	d.Require().Nil(err)
+	repository, err = d.dao.Get(d.ctx, id)
+	d.Require().Nil(err)
+	d.True(pullTime.Equal(repository.PullTime))
+
+	// the earlier pull time is ignored
+	err = d.dao.UpdatePullTime(d.ctx, id, pullTime.Add(-time.Hour))
+	d.Require().Nil(err)
+	repository, err = d.dao.Get(d.ctx, id)
+	d.Require().Nil(err)
+	d.True(pullTime.Equal(repository.PullTime))
+}
+
 func (d *daoTestSuite) TestNonEmptyRepos() {
 	repository := &model.RepoRecord{
 		Name:        "TestNonEmptyRepos",
@@ -258,6 +302,88 @@ func (d *daoTestSuite) TestNonEmptyRepos() {
 
 }
 
+func (d *daoTestSuite) TestListByLabels() {
+	art := &af_dao.Artifact{
+		Type:              "IMAGE",
+		MediaType:         v1.MediaTypeImageConfig,
+		ManifestMediaType: v1.MediaTypeImageManifest,
+		ProjectID:         1,
+		RepositoryID:      d.id,
+		RepositoryName:    repository,
+		Digest:            "TestListByLabels",
+		PushTime:          time.Now(),
+		PullTime:          time.Now(),
+	}
+	afID, err := d.afDao.Create(d.ctx, art)
+	d.Require().Nil(err)
+	defer d.afDao.Delete(d.ctx, afID)
+
+	labelDao := label_dao.New()
+	labelID, err := labelDao.Create(d.ctx, &label_model.Label{Name: "TestListByLabels", Scope: "g", Level: "user"})
+	d.Require().Nil(err)
+	defer labelDao.Delete(d.ctx, labelID)
+	otherLabelID, err := labelDao.Create(d.ctx, &label_model.Label{Name: "TestListByLabelsOther", Scope: "g", Level: "user"})
+	d.Require().Nil(err)
+	defer labelDao.Delete(d.ctx, otherLabelID)
+	refID, err := labelDao.CreateReference(d.ctx, &label_model.Reference{LabelID: labelID, ArtifactID: afID})
+	d.Require().Nil(err)
+	defer labelDao.DeleteReference(d.ctx, refID)
+
+	repositories, err := d.dao.List(d.ctx, q.New(q.KeyWords{"labels": &q.AndList{Values: []interface{}{labelID}}}))
+	d.Require().Nil(err)
+	d.Require().Len(repositories, 1)
+	d.Equal(d.id, repositories[0].RepositoryID)
+
+	// the repository must contain the artifacts attached with all the labels
+	repositories, err = d.dao.List(d.ctx, q.New(q.KeyWords{"labels": &q.AndList{Values: []interface{}{labelID, otherLabelID}}}))
+	d.Require().Nil(err)
+	d.Len(repositories, 0)
+
+	// invalid label ID
+	repositories, err = d.dao.List(d.ctx, q.New(q.KeyWords{"labels": &q.AndList{Values: []interface{}{"invalid"}}}))
+	d.Require().Nil(err)
+	d.Len(repositories, 0)
+}
+
+func (d *daoTestSuite) TestListByArtifactType() {
+	art := &af_dao.Artifact{
+		Type:              "OPENPOLICYAGENT",
+		MediaType:         "application/vnd.cncf.openpolicyagent.config.v1+json",
+		ManifestMediaType: v1.MediaTypeImageManifest,
+		ArtifactType:      "application/vnd.cncf.openpolicyagent.config.v1+json",
+		ProjectID:         1,
+		RepositoryID:      d.id,
+		RepositoryName:    repository,
+		Digest:            "TestListByArtifactType",
+		PushTime:          time.Now(),
+		PullTime:          time.Now(),
+	}
+	afID, err := d.afDao.Create(d.ctx, art)
+	d.Require().Nil(err)
+	defer d.afDao.Delete(d.ctx, afID)
+
+	// query by type
+	repositories, err := d.dao.List(d.ctx, q.New(q.KeyWords{"type": &q.OrList{Values: []interface{}{"IMAGE", "OPENPOLICYAGENT"}}}))
+	d.Require().Nil(err)
+	found := false
+	for _, repository := range repositories {
+		if repository.RepositoryID == d.id {
+			found = true
+		}
+	}
+	d.True(found)
+
+	// query by artifact type
+	repositories, err = d.dao.List(d.ctx, q.New(q.KeyWords{"artifact_type": "application/vnd.cncf.openpolicyagent.config.v1+json"}))
+	d.Require().Nil(err)
+	d.Require().Len(repositories, 1)
+	d.Equal(d.id, repositories[0].RepositoryID)
+
+	total, err := d.dao.Count(d.ctx, q.New(q.KeyWords{"artifact_type": "application/vnd.example.unknown"}))
+	d.Require().Nil(err)
+	d.Equal(int64(0), total)
+}
+
 func TestDaoTestSuite(t *testing.T) {
 	suite.Run(t, &daoTestSuite{})
 }
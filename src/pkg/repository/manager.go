@@ -16,6 +16,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/q"
@@ -41,6 +42,8 @@ type Manager interface {
 	Update(ctx context.Context, repository *model.RepoRecord, props ...string) (err error)
 	// AddPullCount increase pull count for the specified repository
 	AddPullCount(ctx context.Context, id int64, count uint64) error
+	// UpdatePullTime updates the pull time of the specified repository if the provided one is later
+	UpdatePullTime(ctx context.Context, id int64, pullTime time.Time) error
 	// NonEmptyRepos returns the repositories without any artifact or all the artifacts are untagged.
 	NonEmptyRepos(ctx context.Context) ([]*model.RepoRecord, error)
 }
@@ -103,6 +106,10 @@ func (m *manager) AddPullCount(ctx context.Context, id int64, count uint64) erro
 	return m.dao.AddPullCount(ctx, id, count)
 }
 
+func (m *manager) UpdatePullTime(ctx context.Context, id int64, pullTime time.Time) error {
+	return m.dao.UpdatePullTime(ctx, id, pullTime)
+}
+
 func (m *manager) NonEmptyRepos(ctx context.Context) ([]*model.RepoRecord, error) {
 	return m.dao.NonEmptyRepos(ctx)
 }
@@ -17,6 +17,7 @@ package repository
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -115,6 +116,13 @@ func (m *managerTestSuite) TestAddPullCount() {
 	m.dao.AssertExpectations(m.T())
 }
 
+func (m *managerTestSuite) TestUpdatePullTime() {
+	m.dao.On("UpdatePullTime", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	err := m.mgr.UpdatePullTime(context.Background(), 1, time.Now())
+	m.Require().Nil(err)
+	m.dao.AssertExpectations(m.T())
+}
+
 func (m *managerTestSuite) TestNonEmptyRepos() {
 	repository := &model.RepoRecord{
 		RepositoryID: 1,
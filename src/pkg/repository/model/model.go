@@ -16,9 +16,12 @@ package model
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
 )
@@ -39,6 +42,72 @@ type RepoRecord struct {
 	StarCount    int64     `orm:"column(star_count)" json:"star_count"`
 	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
 	UpdateTime   time.Time `orm:"column(update_time);auto_now" json:"update_time"`
+	PullTime     time.Time `orm:"column(pull_time)" json:"pull_time"`
+	PushTime     time.Time `orm:"column(push_time)" json:"push_time"`
+	// the JSON encoded links of the repository
+	Links string `orm:"column(links)" json:"links"`
+	// the JSON encoded custom key/values of the repository
+	Metadata string `orm:"column(metadata)" json:"metadata"`
+}
+
+// Link is the link of the repository, e.g. the source code and the documentation
+type Link struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// GetLinks returns the decoded links of the repository
+func (r *RepoRecord) GetLinks() []*Link {
+	links := []*Link{}
+	if len(r.Links) == 0 {
+		return links
+	}
+	if err := json.Unmarshal([]byte(r.Links), &links); err != nil {
+		log.Errorf("failed to decode the links of the repository %s: %v", r.Name, err)
+		return []*Link{}
+	}
+	return links
+}
+
+// SetLinks encodes the links into the repository
+func (r *RepoRecord) SetLinks(links []*Link) error {
+	if len(links) == 0 {
+		r.Links = ""
+		return nil
+	}
+	data, err := json.Marshal(links)
+	if err != nil {
+		return err
+	}
+	r.Links = string(data)
+	return nil
+}
+
+// GetMetadata returns the decoded custom key/values of the repository
+func (r *RepoRecord) GetMetadata() map[string]string {
+	metadata := map[string]string{}
+	if len(r.Metadata) == 0 {
+		return metadata
+	}
+	if err := json.Unmarshal([]byte(r.Metadata), &metadata); err != nil {
+		log.Errorf("failed to decode the metadata of the repository %s: %v", r.Name, err)
+		return map[string]string{}
+	}
+	return metadata
+}
+
+// SetMetadata encodes the custom key/values into the repository
+func (r *RepoRecord) SetMetadata(metadata map[string]string) error {
+	if len(metadata) == 0 {
+		r.Metadata = ""
+		return nil
+	}
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	r.Metadata = string(data)
+	return nil
 }
 
 // FilterByBlobDigest filters the repositories by the blob digest
@@ -56,6 +125,71 @@ func (r *RepoRecord) FilterByBlobDigest(ctx context.Context, qs orm.QuerySeter,
 	return qs.FilterRaw("repository_id", fmt.Sprintf("in (%s)", sql))
 }
 
+// FilterByLabels filters the repositories which contain the artifacts attached with all the specified labels,
+// the value is the label ID list with the intersection relationship, e.g. q=labels=(1 2)
+func (r *RepoRecord) FilterByLabels(ctx context.Context, qs orm.QuerySeter, key string, value interface{}) orm.QuerySeter {
+	al, ok := value.(*q.AndList)
+	if !ok || len(al.Values) == 0 {
+		return qs
+	}
+	var collections []string
+	for _, v := range al.Values {
+		labelID, ok := v.(int64)
+		if !ok {
+			// make sure no repository will be selected with the invalid label ID
+			return qs.FilterRaw("repository_id", "IN (-1)")
+		}
+		// param "labelID" is integer, no need to sanitize
+		collections = append(collections, fmt.Sprintf(`SELECT a.repository_id FROM artifact AS a
+				JOIN label_reference AS lr ON a.id = lr.artifact_id
+				WHERE lr.label_id = %d`, labelID))
+	}
+	return qs.FilterRaw("repository_id", fmt.Sprintf("IN (%s)", strings.Join(collections, " INTERSECT ")))
+}
+
+// FilterByType filters the repositories which contain the artifacts of the specified types, e.g. q=type=WASM
+// or q=type={IMAGE CHART}
+func (r *RepoRecord) FilterByType(ctx context.Context, qs orm.QuerySeter, key string, value interface{}) orm.QuerySeter {
+	return filterByArtifactColumn(qs, "type", value)
+}
+
+// FilterByArtifactType filters the repositories which contain the artifacts of the specified artifact types,
+// e.g. q=artifact_type=application/vnd.cncf.openpolicyagent.config.v1+json
+func (r *RepoRecord) FilterByArtifactType(ctx context.Context, qs orm.QuerySeter, key string, value interface{}) orm.QuerySeter {
+	return filterByArtifactColumn(qs, "artifact_type", value)
+}
+
+// filterByArtifactColumn selects the repositories containing the artifacts whose column equals to one of the values,
+// the value can be a string or an OR list of strings
+func filterByArtifactColumn(qs orm.QuerySeter, column string, value interface{}) orm.QuerySeter {
+	var values []string
+	switch v := value.(type) {
+	case string:
+		values = append(values, v)
+	case *q.OrList:
+		for _, item := range v.Values {
+			s, ok := item.(string)
+			if !ok {
+				// make sure no repository will be selected with the invalid value
+				return qs.FilterRaw("repository_id", "IN (-1)")
+			}
+			values = append(values, s)
+		}
+	}
+	var literals []string
+	for _, v := range values {
+		if len(v) > 0 {
+			literals = append(literals, orm.QuoteLiteral(v))
+		}
+	}
+	if len(literals) == 0 {
+		return qs
+	}
+	// param "column" is a constant, only the values need to be sanitized
+	return qs.FilterRaw("repository_id", fmt.Sprintf(`IN (SELECT DISTINCT(a.repository_id) FROM artifact AS a
+				WHERE a.%s IN (%s))`, column, strings.Join(literals, ",")))
+}
+
 // TableName is required by beego orm to map RepoRecord to table repository
 func (r *RepoRecord) TableName() string {
 	return "repository"
@@ -0,0 +1,49 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinks(t *testing.T) {
+	r := &RepoRecord{}
+	assert.Empty(t, r.GetLinks())
+
+	assert.Nil(t, r.SetLinks([]*Link{{Name: "Source code", URL: "https://github.com/goharbor/harbor"}}))
+	links := r.GetLinks()
+	assert.Len(t, links, 1)
+	assert.Equal(t, "Source code", links[0].Name)
+
+	assert.Nil(t, r.SetLinks(nil))
+	assert.Empty(t, r.Links)
+
+	// invalid JSON
+	r.Links = "invalid"
+	assert.Empty(t, r.GetLinks())
+}
+
+func TestMetadata(t *testing.T) {
+	r := &RepoRecord{}
+	assert.Empty(t, r.GetMetadata())
+
+	assert.Nil(t, r.SetMetadata(map[string]string{"team": "core"}))
+	assert.Equal(t, map[string]string{"team": "core"}, r.GetMetadata())
+
+	assert.Nil(t, r.SetMetadata(map[string]string{}))
+	assert.Empty(t, r.Metadata)
+}
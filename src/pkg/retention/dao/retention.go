@@ -13,7 +13,7 @@ func CreatePolicy(ctx context.Context, p *models.RetentionPolicy) (int64, error)
 	if err != nil {
 		return 0, err
 	}
-	return o.Insert(p)
+	return o.InsertWithCtx(ctx, p)
 }
 
 // UpdatePolicy Update Policy
@@ -22,7 +22,7 @@ func UpdatePolicy(ctx context.Context, p *models.RetentionPolicy, cols ...string
 	if err != nil {
 		return err
 	}
-	_, err = o.Update(p, cols...)
+	_, err = o.UpdateWithCtx(ctx, p, cols...)
 	return err
 }
 
@@ -35,7 +35,7 @@ func DeletePolicy(ctx context.Context, id int64) error {
 	p := &models.RetentionPolicy{
 		ID: id,
 	}
-	_, err = o.Delete(p)
+	_, err = o.DeleteWithCtx(ctx, p)
 	return err
 }
 
@@ -48,7 +48,7 @@ func GetPolicy(ctx context.Context, id int64) (*models.RetentionPolicy, error) {
 	p := &models.RetentionPolicy{
 		ID: id,
 	}
-	if err := o.Read(p); err != nil {
+	if err := o.ReadWithCtx(ctx, p); err != nil {
 		return nil, err
 	}
 	return p, nil
@@ -145,30 +145,33 @@ func (bc *basicClient) GetCandidates(repository *selector.Repository) ([]*select
 			}
 			candidates = append(candidates, candidate)
 		}
-	/*
-		case art.Chart:
-			charts, err := bc.coreClient.ListAllCharts(repository.Namespace, repository.Name)
-			if err != nil {
-				return nil, err
+	case selector.Chart:
+		charts, err := bc.coreClient.ListAllCharts(repository.Namespace, repository.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, chart := range charts {
+			if chart.Digest == "" {
+				return nil, fmt.Errorf("lack digest of candidate for %s/%s:%s", repository.Namespace, repository.Name, chart.Version)
 			}
-			for _, chart := range charts {
-				labels := make([]string, 0)
-				for _, label := range chart.Labels {
-					labels = append(labels, label.Name)
-				}
-				candidate := &art.Candidate{
-					Kind:         art.Chart,
-					Namespace:    repository.Namespace,
-					Repository:   repository.Name,
-					Tag:          chart.Name,
-					Labels:       labels,
-					CreationTime: chart.Created.Unix(),
-					PushedTime:   ,
-					PulledTime:   ,
-				}
-				candidates = append(candidates, candidate)
+			labels := make([]string, 0)
+			for _, label := range chart.Labels {
+				labels = append(labels, label.Name)
+			}
+			// the chart versions are immutable and the chart repository doesn't record the pulls
+			candidate := &selector.Candidate{
+				Kind:         selector.Chart,
+				NamespaceID:  repository.NamespaceID,
+				Namespace:    repository.Namespace,
+				Repository:   repository.Name,
+				Tags:         []string{chart.Version},
+				Digest:       chart.Digest,
+				Labels:       labels,
+				CreationTime: chart.Created.Unix(),
+				PushedTime:   chart.Created.Unix(),
 			}
-	*/
+			candidates = append(candidates, candidate)
+		}
 	default:
 		return nil, fmt.Errorf("unsupported repository kind: %s", repository.Kind)
 	}
@@ -183,10 +186,8 @@ func (bc *basicClient) DeleteRepository(repo *selector.Repository) error {
 	switch repo.Kind {
 	case selector.Image:
 		return bc.coreClient.DeleteArtifactRepository(repo.Namespace, repo.Name)
-	/*
-		case art.Chart:
-			return bc.coreClient.DeleteChartRepository(repo.Namespace, repo.Name)
-	*/
+	case selector.Chart:
+		return bc.coreClient.DeleteChartRepository(repo.Namespace, repo.Name)
 	default:
 		return fmt.Errorf("unsupported repository kind: %s", repo.Kind)
 	}
@@ -200,10 +201,11 @@ func (bc *basicClient) Delete(candidate *selector.Candidate) error {
 	switch candidate.Kind {
 	case selector.Image:
 		return bc.coreClient.DeleteArtifact(candidate.Namespace, candidate.Repository, candidate.Digest)
-	/*
-		case art.Chart:
-			return bc.coreClient.DeleteChart(candidate.Namespace, candidate.Repository, candidate.Tag)
-	*/
+	case selector.Chart:
+		if len(candidate.Tags) == 0 {
+			return fmt.Errorf("lack version of chart candidate %s/%s", candidate.Namespace, candidate.Repository)
+		}
+		return bc.coreClient.DeleteChart(candidate.Namespace, candidate.Repository, candidate.Tags[0])
 	default:
 		return fmt.Errorf("unsupported candidate kind: %s", candidate.Kind)
 	}
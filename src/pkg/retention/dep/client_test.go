@@ -53,11 +53,13 @@ func (f *fakeCoreClient) ListAllArtifacts(project, repository string) ([]*models
 
 func (f *fakeCoreClient) ListAllCharts(project, repository string) ([]*chartserver.ChartVersion, error) {
 	metadata := &chart.Metadata{
-		Name: "1.0",
+		Name:    "harbor",
+		Version: "1.0",
 	}
 	chart := &chartserver.ChartVersion{}
 	chart.ChartVersion = repo.ChartVersion{
 		Metadata: metadata,
+		Digest:   "sha256:123456",
 	}
 	return []*chartserver.ChartVersion{chart}, nil
 }
@@ -102,18 +104,18 @@ func (c *clientTestSuite) TestGetCandidates() {
 	assert.Equal(c.T(), "hello-world", candidates[0].Repository)
 	assert.Equal(c.T(), "latest", candidates[0].Tags[0])
 
-	/*
-		// chart repository
-		repository.Kind = art.Chart
-		repository.Namespace = "goharbor"
-		repository.Name = "harbor"
-		candidates, err = client.GetCandidates(repository)
-		require.Nil(c.T(), err)
-		assert.Equal(c.T(), 1, len(candidates))
-		assert.Equal(c.T(), art.Chart, candidates[0].Kind)
-		assert.Equal(c.T(), "goharbor", candidates[0].Namespace)
-		assert.Equal(c.T(), "1.0", candidates[0].Tag)
-	*/
+	// chart repository
+	repository.Kind = selector.Chart
+	repository.Namespace = "goharbor"
+	repository.Name = "harbor"
+	candidates, err = client.GetCandidates(repository)
+	require.Nil(c.T(), err)
+	assert.Equal(c.T(), 1, len(candidates))
+	assert.Equal(c.T(), selector.Chart, candidates[0].Kind)
+	assert.Equal(c.T(), "goharbor", candidates[0].Namespace)
+	assert.Equal(c.T(), "harbor", candidates[0].Repository)
+	assert.Equal(c.T(), "1.0", candidates[0].Tags[0])
+	assert.Equal(c.T(), "sha256:123456", candidates[0].Digest)
 }
 
 func (c *clientTestSuite) TestDelete() {
@@ -131,12 +133,15 @@ func (c *clientTestSuite) TestDelete() {
 	err = client.Delete(candidate)
 	require.Nil(c.T(), err)
 
-	/*
-		// chart
-		candidate.Kind = art.Chart
-		err = client.Delete(candidate)
-		require.Nil(c.T(), err)
-	*/
+	// chart without version
+	candidate.Kind = selector.Chart
+	err = client.Delete(candidate)
+	require.NotNil(c.T(), err)
+
+	// chart
+	candidate.Tags = []string{"1.0"}
+	err = client.Delete(candidate)
+	require.Nil(c.T(), err)
 
 	// unsupported type
 	candidate.Kind = "unsupported"
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/goharbor/harbor/src/chartserver"
 	cjob "github.com/goharbor/harbor/src/common/job"
 	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/jobservice/job"
@@ -42,6 +43,20 @@ const (
 	ParamDryRun = "dryRun"
 )
 
+// ChartLister lists the charts under the project
+type ChartLister interface {
+	ListCharts(namespace string) ([]*chartserver.ChartInfo, error)
+}
+
+// chartLister is nil if Harbor isn't deployed with the chart repository service
+var chartLister ChartLister
+
+// RegisterChartLister registers the lister to list the charts of the projects, the chart repositories
+// are included in the retention only after the lister is registered
+func RegisterChartLister(lister ChartLister) {
+	chartLister = lister
+}
+
 // Launcher provides function to launch the async jobs to run retentions based on the provided policy.
 type Launcher interface {
 	// Launch async jobs for the retention policy
@@ -280,12 +295,6 @@ func getProjects(ctx context.Context, projectMgr project.Manager) ([]*selector.C
 
 func getRepositories(ctx context.Context, projectMgr project.Manager, repositoryMgr repository.Manager, projectID int64) ([]*selector.Candidate, error) {
 	var candidates []*selector.Candidate
-	/*
-		pro, err := projectMgr.Get(projectID)
-		if err != nil {
-			return nil, err
-		}
-	*/
 	// get image repositories
 	imageRepositories, err := repositoryMgr.List(ctx, &pq.Query{
 		Keywords: map[string]interface{}{
@@ -301,7 +310,31 @@ func getRepositories(ctx context.Context, projectMgr project.Manager, repository
 			NamespaceID: projectID,
 			Namespace:   namespace,
 			Repository:  repo,
-			Kind:        "image",
+			Kind:        selector.Image,
+		})
+	}
+
+	// get chart repositories
+	if chartLister == nil {
+		return candidates, nil
+	}
+	pro, err := projectMgr.Get(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if !pro.ChartRepositoryEnabled() {
+		return candidates, nil
+	}
+	charts, err := chartLister.ListCharts(pro.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, chart := range charts {
+		candidates = append(candidates, &selector.Candidate{
+			NamespaceID: projectID,
+			Namespace:   pro.Name,
+			Repository:  chart.Name,
+			Kind:        selector.Chart,
 		})
 	}
 
@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/goharbor/harbor/src/chartserver"
 	"github.com/goharbor/harbor/src/common/job"
 	"github.com/goharbor/harbor/src/lib/orm"
 	_ "github.com/goharbor/harbor/src/lib/selector/selectors/doublestar"
@@ -166,6 +167,44 @@ func (l *launchTestSuite) TestGetRepositories() {
 	assert.Equal(l.T(), "image", repositories[0].Kind)
 }
 
+type fakeChartLister struct{}
+
+func (f *fakeChartLister) ListCharts(namespace string) ([]*chartserver.ChartInfo, error) {
+	return []*chartserver.ChartInfo{
+		{
+			Name: "harbor",
+		},
+	}, nil
+}
+
+func (l *launchTestSuite) TestGetChartRepositories() {
+	RegisterChartLister(&fakeChartLister{})
+	defer RegisterChartLister(nil)
+
+	repositoryMgr := &repository.Manager{}
+	repositoryMgr.On("List", mock.Anything, mock.Anything).Return([]*model.RepoRecord{}, nil)
+	projectMgr := &projecttesting.Manager{}
+	pro := &proModels.Project{
+		ProjectID: 1,
+		Name:      "library",
+		Metadata:  map[string]string{},
+	}
+	projectMgr.On("Get", mock.Anything, int64(1)).Return(pro, nil)
+	ctx := orm.Context()
+	repositories, err := getRepositories(ctx, projectMgr, repositoryMgr, 1)
+	require.Nil(l.T(), err)
+	require.Equal(l.T(), 1, len(repositories))
+	assert.Equal(l.T(), "library", repositories[0].Namespace)
+	assert.Equal(l.T(), "harbor", repositories[0].Repository)
+	assert.Equal(l.T(), "chart", repositories[0].Kind)
+
+	// the chart repository of the project is disabled
+	pro.Metadata[proModels.ProMetaEnableChartRepository] = "false"
+	repositories, err = getRepositories(ctx, projectMgr, repositoryMgr, 1)
+	require.Nil(l.T(), err)
+	assert.Equal(l.T(), 0, len(repositories))
+}
+
 func (l *launchTestSuite) TestLaunch() {
 	l.execMgr.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
 	l.taskMgr.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(1), nil)
@@ -94,6 +94,10 @@ func (ra *retainAction) Perform(ctx context.Context, candidates []*selector.Cand
 }
 
 func isImmutable(ctx context.Context, c *selector.Candidate) bool {
+	// the tag immutability rules are applied to the artifacts only
+	if c.Kind == selector.Chart {
+		return false
+	}
 	projectID := c.NamespaceID
 	repo := c.Repository
 	_, repoName := utils.ParseRepository(repo)
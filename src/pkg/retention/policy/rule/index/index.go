@@ -27,6 +27,7 @@ import (
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/latestk"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/latestpl"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/latestps"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/latestsv"
 )
 
 // index for keeping the mapping between template ID and evaluator
@@ -95,6 +96,19 @@ func init() {
 	}, latestpl.New, latestpl.Valid)
 
 	// Register latest active
+	Register(&Metadata{
+		TemplateID: latestsv.TemplateID,
+		Action:     action.Retain,
+		Parameters: []*IndexedParam{
+			{
+				Name:     latestsv.ParameterK,
+				Type:     "int",
+				Unit:     "count",
+				Required: true,
+			},
+		},
+	}, latestsv.New, latestsv.Valid)
+
 	Register(&Metadata{
 		TemplateID: latestk.TemplateID,
 		Action:     action.Retain,
@@ -0,0 +1,129 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package latestsv
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/Masterminds/semver"
+
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/selector"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/action"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
+)
+
+const (
+	// TemplateID of latest semantic version k rule
+	TemplateID = "latestSemverK"
+	// ParameterK ...
+	ParameterK = TemplateID
+	// DefaultK defines the default K
+	DefaultK = 10
+)
+
+// evaluator for evaluating the latest k semantic versions, e.g. the chart versions
+type evaluator struct {
+	// latest k
+	k int
+}
+
+// candidate with the highest semantic version among its tags
+type versioned struct {
+	candidate *selector.Candidate
+	version   *semver.Version
+}
+
+// Process the candidates based on the rule definition, the candidates without any tag
+// in the semantic version format aren't retained by this rule
+func (e *evaluator) Process(artifacts []*selector.Candidate) ([]*selector.Candidate, error) {
+	var candidates []*versioned
+	for _, art := range artifacts {
+		var latest *semver.Version
+		for _, tag := range art.Tags {
+			v, err := semver.NewVersion(tag)
+			if err != nil {
+				continue
+			}
+			if latest == nil || v.GreaterThan(latest) {
+				latest = v
+			}
+		}
+		if latest != nil {
+			candidates = append(candidates, &versioned{candidate: art, version: latest})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].version.GreaterThan(candidates[j].version)
+	})
+
+	i := e.k
+	if i > len(candidates) {
+		i = len(candidates)
+	}
+
+	result := make([]*selector.Candidate, 0, i)
+	for _, c := range candidates[:i] {
+		result = append(result, c.candidate)
+	}
+	return result, nil
+}
+
+// Specify what action is performed to the candidates processed by this evaluator
+func (e *evaluator) Action() string {
+	return action.Retain
+}
+
+// New a Evaluator
+func New(params rule.Parameters) rule.Evaluator {
+	if params != nil {
+		if p, ok := params[ParameterK]; ok {
+			if v, ok := utils.ParseJSONInt(p); ok && v >= 0 {
+				return &evaluator{
+					k: int(v),
+				}
+			}
+		}
+	}
+
+	log.Warningf("default parameter %d used for rule %s", DefaultK, TemplateID)
+
+	return &evaluator{
+		k: DefaultK,
+	}
+}
+
+// Valid ...
+func Valid(params rule.Parameters) error {
+	if params != nil {
+		if p, ok := params[ParameterK]; ok {
+			if v, ok := utils.ParseJSONInt(p); ok {
+				if v < 0 {
+					return fmt.Errorf("%s is less than zero", ParameterK)
+				}
+				if v >= math.MaxInt16 {
+					return fmt.Errorf("%s is too large", ParameterK)
+				}
+			} else {
+				return fmt.Errorf("%s type error", ParameterK)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package latestsv
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/selector"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
+)
+
+type EvaluatorTestSuite struct {
+	suite.Suite
+}
+
+func (e *EvaluatorTestSuite) TestNew() {
+	tests := []struct {
+		Name      string
+		args      rule.Parameters
+		expectedK int
+	}{
+		{Name: "Valid", args: map[string]rule.Parameter{ParameterK: float64(5)}, expectedK: 5},
+		{Name: "Default If Negative", args: map[string]rule.Parameter{ParameterK: float64(-1)}, expectedK: DefaultK},
+		{Name: "Default If Not Set", args: map[string]rule.Parameter{}, expectedK: DefaultK},
+		{Name: "Default If Wrong Type", args: map[string]rule.Parameter{ParameterK: "foo"}, expectedK: DefaultK},
+	}
+
+	for _, tt := range tests {
+		e.T().Run(tt.Name, func(t *testing.T) {
+			e := New(tt.args).(*evaluator)
+
+			require.Equal(t, tt.expectedK, e.k)
+		})
+	}
+}
+
+func (e *EvaluatorTestSuite) TestProcess() {
+	data := []*selector.Candidate{
+		{Tags: []string{"1.0.0"}},
+		{Tags: []string{"1.2.0-rc.1"}},
+		{Tags: []string{"1.2.0"}},
+		{Tags: []string{"latest", "1.10.0"}},
+		{Tags: []string{"0.9.1"}},
+		{Tags: []string{"latest"}},
+		{Tags: []string{}},
+	}
+	rand.Shuffle(len(data), func(i, j int) {
+		data[i], data[j] = data[j], data[i]
+	})
+
+	tests := []struct {
+		k        float64
+		expected []string
+	}{
+		{k: 0, expected: []string{}},
+		{k: 1, expected: []string{"1.10.0"}},
+		{k: 3, expected: []string{"1.10.0", "1.2.0", "1.2.0-rc.1"}},
+		{k: 10, expected: []string{"1.10.0", "1.2.0", "1.2.0-rc.1", "1.0.0", "0.9.1"}},
+	}
+
+	for _, tt := range tests {
+		e.T().Run(fmt.Sprintf("%v", tt.k), func(t *testing.T) {
+			e := New(map[string]rule.Parameter{ParameterK: tt.k})
+
+			result, err := e.Process(data)
+
+			require.NoError(t, err)
+			versions := []string{}
+			for _, r := range result {
+				versions = append(versions, r.Tags[len(r.Tags)-1])
+			}
+			require.Equal(t, tt.expected, versions)
+		})
+	}
+}
+
+func (e *EvaluatorTestSuite) TestValid() {
+	tests := []struct {
+		Name      string
+		args      rule.Parameters
+		expectedK error
+	}{
+		{Name: "Valid", args: map[string]rule.Parameter{ParameterK: 5}, expectedK: nil},
+		{Name: "Negative", args: map[string]rule.Parameter{ParameterK: -1}, expectedK: errors.New("latestSemverK is less than zero")},
+		{Name: "Big", args: map[string]rule.Parameter{ParameterK: 40000}, expectedK: errors.New("latestSemverK is too large")},
+	}
+
+	for _, tt := range tests {
+		e.T().Run(tt.Name, func(t *testing.T) {
+			err := Valid(tt.args)
+
+			require.Equal(t, tt.expectedK, err)
+		})
+	}
+}
+
+func TestEvaluator(t *testing.T) {
+	suite.Run(t, &EvaluatorTestSuite{})
+}
@@ -47,7 +47,7 @@ func (d *dao) Create(ctx context.Context, r *model.Robot) (int64, error) {
 		return 0, err
 	}
 	r.CreationTime = time.Now()
-	id, err := ormer.Insert(r)
+	id, err := ormer.InsertWithCtx(ctx, r)
 	if err != nil {
 		return 0, orm.WrapConflictError(err, "robot account %d:%s already exists", r.ProjectID, r.Name)
 	}
@@ -59,7 +59,7 @@ func (d *dao) Update(ctx context.Context, r *model.Robot, props ...string) error
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Update(r, props...)
+	n, err := ormer.UpdateWithCtx(ctx, r, props...)
 	if err != nil {
 		return err
 	}
@@ -77,7 +77,7 @@ func (d *dao) Get(ctx context.Context, id int64) (*model.Robot, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := ormer.Read(r); err != nil {
+	if err := ormer.ReadWithCtx(ctx, r); err != nil {
 		return nil, orm.WrapNotFoundError(err, "robot %d not found", id)
 	}
 	return r, nil
@@ -88,7 +88,7 @@ func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 
 func (d *dao) Delete(ctx context.Context, id int64) error {
@@ -96,7 +96,7 @@ func (d *dao) Delete(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&model.Robot{
+	n, err := ormer.DeleteWithCtx(ctx, &model.Robot{
 		ID: id,
 	})
 	if err != nil {
@@ -115,7 +115,7 @@ func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.Robot, error)
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&robots); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &robots); err != nil {
 		return nil, err
 	}
 	return robots, nil
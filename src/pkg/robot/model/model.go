@@ -7,6 +7,7 @@ import (
 	"github.com/beego/beego/v2/client/orm"
 
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/networkpolicy"
 )
 
 func init() {
@@ -25,6 +26,8 @@ type Robot struct {
 	ExpiresAt    int64     `orm:"column(expiresat)" json:"expires_at"`
 	Disabled     bool      `orm:"column(disabled)" json:"disabled"`
 	Visible      bool      `orm:"column(visible)" json:"-"`
+	IPAllowlist  string    `orm:"column(ip_allowlist)" json:"ip_allowlist"`
+	IPDenylist   string    `orm:"column(ip_denylist)" json:"ip_denylist"`
 	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
 	UpdateTime   time.Time `orm:"column(update_time);auto_now" json:"update_time"`
 }
@@ -34,6 +37,11 @@ func (r *Robot) TableName() string {
 	return "robot"
 }
 
+// NetworkPolicy returns the policy restricting the networks the robot is usable from
+func (r *Robot) NetworkPolicy() *networkpolicy.Policy {
+	return &networkpolicy.Policy{Allowlist: r.IPAllowlist, Denylist: r.IPDenylist}
+}
+
 // FromJSON parses robot from json data
 func (r *Robot) FromJSON(jsonData string) error {
 	if len(jsonData) == 0 {
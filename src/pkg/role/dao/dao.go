@@ -0,0 +1,152 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/role/model"
+)
+
+// DAO defines the interface to access the role data model
+type DAO interface {
+	// Create ...
+	Create(ctx context.Context, r *model.Role) (int, error)
+
+	// Update ...
+	Update(ctx context.Context, r *model.Role, props ...string) error
+
+	// Get ...
+	Get(ctx context.Context, id int) (*model.Role, error)
+
+	// Count returns the total count of roles according to the query
+	Count(ctx context.Context, query *q.Query) (total int64, err error)
+
+	// List ...
+	List(ctx context.Context, query *q.Query) ([]*model.Role, error)
+
+	// Delete ...
+	Delete(ctx context.Context, id int) error
+
+	// CountMembers returns the total count of project members bound to the role
+	CountMembers(ctx context.Context, id int) (int64, error)
+}
+
+// New creates a default implementation for Dao
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+func (d *dao) Create(ctx context.Context, r *model.Role) (int, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	r.CreationTime = time.Now()
+	r.UpdateTime = r.CreationTime
+	id, err := ormer.InsertWithCtx(ctx, r)
+	if err != nil {
+		return 0, orm.WrapConflictError(err, "role %s already exists", r.Name)
+	}
+	return int(id), nil
+}
+
+func (d *dao) Update(ctx context.Context, r *model.Role, props ...string) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	r.UpdateTime = time.Now()
+	if len(props) > 0 {
+		props = append(props, "update_time")
+	}
+	n, err := ormer.UpdateWithCtx(ctx, r, props...)
+	if err != nil {
+		return orm.WrapConflictError(err, "role %s already exists", r.Name)
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("role %d not found", r.ID)
+	}
+	return nil
+}
+
+func (d *dao) Get(ctx context.Context, id int) (*model.Role, error) {
+	r := &model.Role{
+		ID: id,
+	}
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ormer.ReadWithCtx(ctx, r); err != nil {
+		return nil, orm.WrapNotFoundError(err, "role %d not found", id)
+	}
+	return r, nil
+}
+
+func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
+	qs, err := orm.QuerySetterForCount(ctx, &model.Role{}, query)
+	if err != nil {
+		return 0, err
+	}
+	return qs.CountWithCtx(ctx)
+}
+
+func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.Role, error) {
+	roles := []*model.Role{}
+	qs, err := orm.QuerySetter(ctx, &model.Role{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.AllWithCtx(ctx, &roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (d *dao) Delete(ctx context.Context, id int) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.DeleteWithCtx(ctx, &model.Role{
+		ID: id,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("role %d not found", id)
+	}
+	return nil
+}
+
+func (d *dao) CountMembers(ctx context.Context, id int) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := ormer.Raw("SELECT COUNT(1) FROM project_member WHERE role = ?", id).QueryRow(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,49 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+const (
+	// RoleType is the role type used to store the permissions of the custom roles in table role_permission
+	RoleType = "projectrole"
+	// Scope of the permission policies of the custom roles, the roles can be bound in any project
+	Scope = "/project"
+)
+
+func init() {
+	orm.RegisterModel(&Role{})
+}
+
+// Role holds the details of a project role, both the built-in and the custom ones
+type Role struct {
+	ID           int       `orm:"pk;auto;column(role_id)" json:"role_id"`
+	Mask         int       `orm:"column(role_mask)" json:"-"`
+	Code         string    `orm:"column(role_code)" json:"-"`
+	Name         string    `orm:"column(name)" json:"name" sort:"default"`
+	Description  string    `orm:"column(description)" json:"description"`
+	Custom       bool      `orm:"column(custom)" json:"custom"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+	UpdateTime   time.Time `orm:"column(update_time);auto_now" json:"update_time"`
+}
+
+// TableName ...
+func (r *Role) TableName() string {
+	return "role"
+}
@@ -52,7 +52,7 @@ func (d *dao) Create(ctx context.Context, r *Report) (int64, error) {
 		return 0, orm.WrapConflictError(err, "a previous scan report found for artifact %s", r.Digest)
 	}
 
-	return o.Insert(r)
+	return o.InsertWithCtx(ctx, r)
 }
 
 func (d *dao) DeleteMany(ctx context.Context, query q.Query) (int64, error) {
@@ -65,7 +65,7 @@ func (d *dao) DeleteMany(ctx context.Context, query q.Query) (int64, error) {
 		return 0, err
 	}
 
-	return qs.Delete()
+	return qs.DeleteWithCtx(ctx)
 }
 
 func (d *dao) List(ctx context.Context, query *q.Query) ([]*Report, error) {
@@ -75,7 +75,7 @@ func (d *dao) List(ctx context.Context, query *q.Query) ([]*Report, error) {
 	}
 
 	reports := []*Report{}
-	if _, err = qs.All(&reports); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &reports); err != nil {
 		return nil, err
 	}
 
@@ -74,7 +74,7 @@ func (v *vulnerabilityRecordDao) Delete(ctx context.Context, vr *VulnerabilityRe
 	if err != nil {
 		return err
 	}
-	_, err = o.Delete(vr, "CVEID", "RegistrationUUID", "Package", "PackageVersion")
+	_, err = o.DeleteWithCtx(ctx, vr, "CVEID", "RegistrationUUID", "Package", "PackageVersion")
 
 	return err
 }
@@ -84,7 +84,7 @@ func (v *vulnerabilityRecordDao) Update(ctx context.Context, vr *VulnerabilityRe
 	if err != nil {
 		return err
 	}
-	_, err = o.Update(vr, cols...)
+	_, err = o.UpdateWithCtx(ctx, vr, cols...)
 
 	return err
 }
@@ -103,7 +103,7 @@ func (v *vulnerabilityRecordDao) List(ctx context.Context, query *q.Query) ([]*V
 	}
 
 	l := make([]*VulnerabilityRecord, 0)
-	_, err = qs.All(&l)
+	_, err = qs.AllWithCtx(ctx, &l)
 
 	return l, err
 }
@@ -136,7 +136,7 @@ func (v *vulnerabilityRecordDao) InsertForReport(ctx context.Context, reportUUID
 			return err
 		}
 
-		_, err = o.InsertMulti(100, records)
+		_, err = o.InsertMultiWithCtx(ctx, 100, records)
 		return err
 	}
 
@@ -159,7 +159,7 @@ func (v *vulnerabilityRecordDao) DeleteForReport(ctx context.Context, reportUUID
 	if err != nil {
 		return 0, err
 	}
-	delCount, err := o.Delete(&ReportVulnerabilityRecord{Report: reportUUID}, "report_uuid")
+	delCount, err := o.DeleteWithCtx(ctx, &ReportVulnerabilityRecord{Report: reportUUID}, "report_uuid")
 	return delCount, err
 }
 
@@ -203,7 +203,7 @@ func (v *vulnerabilityRecordDao) DeleteForScanner(ctx context.Context, registrat
 	}
 	vulnRec := new(VulnerabilityRecord)
 	vulnRec.RegistrationUUID = registrationUUID
-	return o.Delete(vulnRec, "registration_uuid")
+	return o.DeleteWithCtx(ctx, vulnRec, "registration_uuid")
 }
 
 // DeleteForDigests deletes the report vulnerability record mappings for the provided
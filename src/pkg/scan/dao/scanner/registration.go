@@ -32,7 +32,7 @@ func GetTotalOfRegistrations(ctx context.Context, query *q.Query) (int64, error)
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 
 // AddRegistration adds a new registration
@@ -42,7 +42,7 @@ func AddRegistration(ctx context.Context, r *Registration) (int64, error) {
 		return 0, err
 	}
 
-	id, err := o.Insert(r)
+	id, err := o.InsertWithCtx(ctx, r)
 	if err != nil {
 		return 0, orm.WrapConflictError(err, "registration name or url already exists")
 	}
@@ -78,7 +78,7 @@ func UpdateRegistration(ctx context.Context, r *Registration, cols ...string) er
 		return err
 	}
 
-	count, err := o.Update(r, cols...)
+	count, err := o.UpdateWithCtx(ctx, r, cols...)
 	if err != nil {
 		return err
 	}
@@ -130,7 +130,7 @@ func ListRegistrations(ctx context.Context, query *q.Query) ([]*Registration, er
 	}
 
 	l := make([]*Registration, 0)
-	_, err = qs.All(&l)
+	_, err = qs.AllWithCtx(ctx, &l)
 
 	return l, err
 }
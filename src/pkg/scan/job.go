@@ -33,6 +33,7 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/logger"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/metric"
 	"github.com/goharbor/harbor/src/pkg/robot/model"
 	"github.com/goharbor/harbor/src/pkg/scan/dao/scanner"
 	"github.com/goharbor/harbor/src/pkg/scan/postprocessors"
@@ -203,6 +204,7 @@ func (j *Job) Run(ctx job.Context, params job.Parameters) error {
 	}
 
 	req.Registry.Authorization = authorization
+	start := time.Now()
 	resp, err := client.SubmitScan(req)
 	if err != nil {
 		return logAndWrapError(myLogger, err, "scan job: submit scan request")
@@ -289,6 +291,12 @@ func (j *Job) Run(ctx job.Context, params job.Parameters) error {
 		}
 	}
 
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	metric.ScanDurationHistogram.WithLabelValues(r.Name, status).Observe(time.Since(start).Seconds())
+
 	// Log error to the job log
 	if err != nil {
 		myLogger.Error(err)
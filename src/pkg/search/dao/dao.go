@@ -0,0 +1,161 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/orm"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	repoModel "github.com/goharbor/harbor/src/pkg/repository/model"
+	"github.com/goharbor/harbor/src/pkg/search/model"
+)
+
+// DAO is the data access object for the search, the results are ordered by the relevance: the exact
+// matches come first, then the prefix matches, the others are the last
+type DAO interface {
+	// SearchProjects returns the total count and the projects of the page matched by the query
+	SearchProjects(ctx context.Context, query *model.Query, pageNumber, pageSize int64) (int64, []*proModels.Project, error)
+	// SearchRepositories returns the total count and the repositories of the page matched by the query
+	SearchRepositories(ctx context.Context, query *model.Query, pageNumber, pageSize int64) (int64, []*repoModel.RepoRecord, error)
+}
+
+// New returns an instance of the default DAO
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+// SearchProjects ...
+func (d *dao) SearchProjects(ctx context.Context, query *model.Query, pageNumber, pageSize int64) (int64, []*proModels.Project, error) {
+	projects := []*proModels.Project{}
+	if query.ProjectIDs != nil && len(query.ProjectIDs) == 0 {
+		return 0, projects, nil
+	}
+	where, params := buildProjectConditions(query)
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	var total int64
+	if err = ormer.Raw(`SELECT COUNT(*) FROM project AS p`+where, params...).QueryRow(&total); err != nil {
+		return 0, nil, err
+	}
+	if total == 0 {
+		return 0, projects, nil
+	}
+	sql := `SELECT p.* FROM project AS p` + where + `
+		ORDER BY CASE WHEN LOWER(p.name) = LOWER(?) THEN 0 WHEN p.name ILIKE ? THEN 1 ELSE 2 END, p.name`
+	params = append(params, query.Keyword, orm.Escape(query.Keyword)+"%")
+	sql, params = paginate(sql, params, pageNumber, pageSize)
+	if _, err = ormer.Raw(sql, params...).QueryRows(&projects); err != nil {
+		return 0, nil, err
+	}
+	return total, projects, nil
+}
+
+// SearchRepositories ...
+func (d *dao) SearchRepositories(ctx context.Context, query *model.Query, pageNumber, pageSize int64) (int64, []*repoModel.RepoRecord, error) {
+	repositories := []*repoModel.RepoRecord{}
+	if query.ProjectIDs != nil && len(query.ProjectIDs) == 0 {
+		return 0, repositories, nil
+	}
+	where, params := buildRepositoryConditions(query)
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	var total int64
+	if err = ormer.Raw(`SELECT COUNT(*) FROM repository AS r`+where, params...).QueryRow(&total); err != nil {
+		return 0, nil, err
+	}
+	if total == 0 {
+		return 0, repositories, nil
+	}
+	// the keyword matches either the full name or the last component of the repository name
+	keyword := strings.ToLower(orm.Escape(query.Keyword))
+	sql := `SELECT r.* FROM repository AS r` + where + `
+		ORDER BY CASE WHEN LOWER(r.name) = ? OR LOWER(r.name) LIKE ? THEN 0 WHEN LOWER(r.name) LIKE ? OR LOWER(r.name) LIKE ? THEN 1 ELSE 2 END,
+		r.pull_count DESC, r.name`
+	params = append(params, strings.ToLower(query.Keyword), "%/"+keyword, keyword+"%", "%/"+keyword+"%")
+	sql, params = paginate(sql, params, pageNumber, pageSize)
+	if _, err = ormer.Raw(sql, params...).QueryRows(&repositories); err != nil {
+		return 0, nil, err
+	}
+	return total, repositories, nil
+}
+
+// buildProjectConditions builds the WHERE clause selecting the projects matched by the query,
+// the trigram index on the name is used by the ILIKE when it's available
+func buildProjectConditions(query *model.Query) (string, []interface{}) {
+	sql := ` WHERE p.deleted = false AND p.name ILIKE ?`
+	params := []interface{}{"%" + orm.Escape(query.Keyword) + "%"}
+	if query.ProjectIDs != nil {
+		sql += ` AND p.project_id ` + inClause(query.ProjectIDs)
+	}
+	return sql, params
+}
+
+// buildRepositoryConditions builds the WHERE clause selecting the repositories matched by the query
+func buildRepositoryConditions(query *model.Query) (string, []interface{}) {
+	sql := ` WHERE r.name ILIKE ?`
+	params := []interface{}{"%" + orm.Escape(query.Keyword) + "%"}
+	if query.ProjectIDs != nil {
+		sql += ` AND r.project_id ` + inClause(query.ProjectIDs)
+	}
+	if query.LabelID > 0 {
+		sql += ` AND EXISTS (SELECT 1 FROM artifact AS a JOIN label_reference AS lr ON lr.artifact_id = a.id
+			WHERE a.repository_id = r.repository_id AND lr.label_id = ?)`
+		params = append(params, query.LabelID)
+	}
+	if len(query.ScanStatus) > 0 {
+		// the status of the latest scan execution of the artifact
+		latest := `SELECT e.status FROM execution AS e WHERE e.vendor_type = ? AND e.extra_attrs->'artifact'->>'digest' = a.digest
+			ORDER BY e.start_time DESC LIMIT 1`
+		if query.ScanStatus == model.ScanStatusNotScanned {
+			sql += fmt.Sprintf(` AND EXISTS (SELECT 1 FROM artifact AS a WHERE a.repository_id = r.repository_id AND NOT EXISTS (%s))`, latest)
+			params = append(params, job.ImageScanJob)
+		} else {
+			sql += fmt.Sprintf(` AND EXISTS (SELECT 1 FROM artifact AS a WHERE a.repository_id = r.repository_id AND (%s) = ?)`, latest)
+			params = append(params, job.ImageScanJob, query.ScanStatus)
+		}
+	}
+	return sql, params
+}
+
+// inClause concats the IDs into the IN clause directly to avoid the too many arguments issue
+func inClause(ids []int64) string {
+	idStrs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		idStrs = append(idStrs, strconv.FormatInt(id, 10))
+	}
+	return fmt.Sprintf(`IN (%s)`, strings.Join(idStrs, ","))
+}
+
+func paginate(sql string, params []interface{}, pageNumber, pageSize int64) (string, []interface{}) {
+	if pageSize > 0 {
+		if pageNumber <= 0 {
+			pageNumber = 1
+		}
+		sql += ` LIMIT ? OFFSET ?`
+		params = append(params, pageSize, (pageNumber-1)*pageSize)
+	}
+	return sql, params
+}
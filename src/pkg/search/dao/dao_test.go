@@ -0,0 +1,45 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/pkg/search/model"
+)
+
+func TestBuildProjectConditions(t *testing.T) {
+	sql, params := buildProjectConditions(&model.Query{Keyword: "lib_"})
+	assert.Equal(t, ` WHERE p.deleted = false AND p.name ILIKE ?`, sql)
+	assert.Equal(t, []interface{}{`%lib\_%`}, params)
+
+	sql, _ = buildProjectConditions(&model.Query{Keyword: "lib", ProjectIDs: []int64{1, 2}})
+	assert.Contains(t, sql, `AND p.project_id IN (1,2)`)
+}
+
+func TestBuildRepositoryConditions(t *testing.T) {
+	sql, params := buildRepositoryConditions(&model.Query{Keyword: "hello", ProjectIDs: []int64{1}, LabelID: 3, ScanStatus: "Error"})
+	assert.Contains(t, sql, `AND r.project_id IN (1)`)
+	assert.Contains(t, sql, `lr.label_id = ?`)
+	assert.Contains(t, sql, `LIMIT 1) = ?)`)
+	assert.Equal(t, []interface{}{"%hello%", int64(3), "IMAGE_SCAN", "Error"}, params)
+
+	sql, params = buildRepositoryConditions(&model.Query{Keyword: "hello", ScanStatus: model.ScanStatusNotScanned})
+	assert.NotContains(t, sql, `r.project_id`)
+	assert.Contains(t, sql, `NOT EXISTS`)
+	assert.Equal(t, []interface{}{"%hello%", "IMAGE_SCAN"}, params)
+}
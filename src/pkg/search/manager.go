@@ -0,0 +1,52 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"context"
+
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	repoModel "github.com/goharbor/harbor/src/pkg/repository/model"
+	"github.com/goharbor/harbor/src/pkg/search/dao"
+	"github.com/goharbor/harbor/src/pkg/search/model"
+)
+
+// Mgr is the global search manager instance
+var Mgr = New()
+
+// Manager searches the projects and the repositories by the names, the results are ordered by the relevance
+type Manager interface {
+	// SearchProjects returns the total count and the projects of the page matched by the query
+	SearchProjects(ctx context.Context, query *model.Query, pageNumber, pageSize int64) (int64, []*proModels.Project, error)
+	// SearchRepositories returns the total count and the repositories of the page matched by the query
+	SearchRepositories(ctx context.Context, query *model.Query, pageNumber, pageSize int64) (int64, []*repoModel.RepoRecord, error)
+}
+
+// New returns a default implementation of Manager
+func New() Manager {
+	return &manager{dao: dao.New()}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+func (m *manager) SearchProjects(ctx context.Context, query *model.Query, pageNumber, pageSize int64) (int64, []*proModels.Project, error) {
+	return m.dao.SearchProjects(ctx, query, pageNumber, pageSize)
+}
+
+func (m *manager) SearchRepositories(ctx context.Context, query *model.Query, pageNumber, pageSize int64) (int64, []*repoModel.RepoRecord, error) {
+	return m.dao.SearchRepositories(ctx, query, pageNumber, pageSize)
+}
@@ -0,0 +1,31 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// ScanStatusNotScanned selects the artifacts which have never been scanned
+const ScanStatusNotScanned = "NotScanned"
+
+// Query is the criteria of the search
+type Query struct {
+	// the keyword matched with the names
+	Keyword string
+	// the IDs of the projects accessible by the current user, nil means all the projects are accessible
+	ProjectIDs []int64
+	// only select the repositories containing the artifacts attached with the label
+	LabelID int64
+	// only select the repositories containing the artifacts whose latest scan is in the status,
+	// e.g. "Success" or "NotScanned"
+	ScanStatus string
+}
@@ -0,0 +1,246 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/registry"
+)
+
+const (
+	// MediaTypeSimpleSigning is the media type of the layer containing the payload signed by cosign
+	MediaTypeSimpleSigning = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// AnnotationSignature is the annotation of the layer containing the base64 encoded signature of the payload
+	AnnotationSignature = "dev.cosignproject.cosign/signature"
+	// AnnotationCertificate is the annotation of the layer containing the PEM encoded certificate of the keyless signature
+	AnnotationCertificate = "dev.sigstore.cosign/certificate"
+	// AnnotationBundle is the annotation of the layer containing the transparency log entry of the signature
+	AnnotationBundle = "dev.sigstore.cosign/bundle"
+
+	// the payload is a small JSON document, limit the size to avoid reading the unexpected large blob
+	maxPayloadSize = 1 << 20
+)
+
+// Verifier is the global cosign signature verifier instance
+var Verifier = NewVerifier()
+
+// SignatureVerifier verifies the cosign signatures with the trusted public keys
+type SignatureVerifier interface {
+	// Verify checks whether the signature artifact specified by the "signatureDigest" under the repository contains
+	// one signature that is signed by one of the keys for the subject artifact specified by the "subjectDigest"
+	Verify(ctx context.Context, repository, subjectDigest, signatureDigest string, keys []crypto.PublicKey) error
+	// Inspect returns the signers recorded in the signatures contained by the signature artifact specified by
+	// the "signatureDigest" under the repository
+	Inspect(ctx context.Context, repository, signatureDigest string) ([]*Signer, error)
+}
+
+// Signer is the signer and the signing time recorded in the cosign signature itself, so they keep the same
+// wherever the signature is pushed or replicated to
+type Signer struct {
+	// Identity is the email or URI of the certificate of the keyless signature, it's empty for the signature signed by the key
+	Identity string
+	// SignedAt is the integrated time of the transparency log entry, it's zero if the signature isn't uploaded to the transparency log
+	SignedAt time.Time
+}
+
+// NewVerifier creates an instance of the default signature verifier
+func NewVerifier() SignatureVerifier {
+	return &verifier{
+		regCli: registry.Cli,
+	}
+}
+
+type verifier struct {
+	regCli registry.Client
+}
+
+func (v *verifier) Verify(ctx context.Context, repository, subjectDigest, signatureDigest string, keys []crypto.PublicKey) error {
+	manifest, _, err := v.regCli.PullManifest(repository, signatureDigest)
+	if err != nil {
+		return err
+	}
+	for _, layer := range manifest.References() {
+		if layer.MediaType != MediaTypeSimpleSigning {
+			continue
+		}
+		signature, ok := layer.Annotations[AnnotationSignature]
+		if !ok {
+			continue
+		}
+		payload, err := v.pullPayload(repository, layer.Digest.String())
+		if err != nil {
+			return err
+		}
+		if err = VerifyPayload(payload, signature, subjectDigest, keys); err != nil {
+			log.G(ctx).Debugf("the signature %s@%s isn't verified: %v", repository, layer.Digest, err)
+			continue
+		}
+		return nil
+	}
+	return errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).
+		WithMessage("no signature of %s@%s is signed by the trusted keys", repository, subjectDigest)
+}
+
+func (v *verifier) Inspect(ctx context.Context, repository, signatureDigest string) ([]*Signer, error) {
+	manifest, _, err := v.regCli.PullManifest(repository, signatureDigest)
+	if err != nil {
+		return nil, err
+	}
+	var signers []*Signer
+	for _, layer := range manifest.References() {
+		if layer.MediaType != MediaTypeSimpleSigning {
+			continue
+		}
+		if _, ok := layer.Annotations[AnnotationSignature]; !ok {
+			continue
+		}
+		signers = append(signers, ParseSigner(layer.Annotations))
+	}
+	return signers, nil
+}
+
+func (v *verifier) pullPayload(repository, digest string) ([]byte, error) {
+	_, blob, err := v.regCli.PullBlob(repository, digest)
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Close()
+	return io.ReadAll(io.LimitReader(blob, maxPayloadSize))
+}
+
+// bundle is the transparency log entry of the signature, only the properties needed are defined
+type bundle struct {
+	Payload struct {
+		IntegratedTime int64 `json:"integratedTime"`
+	} `json:"Payload"`
+}
+
+// ParseSigner parses the signer identity from the certificate and the signing time from the transparency log
+// entry in the annotations of the signature layer, the properties that aren't recorded are left empty
+func ParseSigner(annotations map[string]string) *Signer {
+	signer := &Signer{}
+	if data, ok := annotations[AnnotationCertificate]; ok {
+		if block, _ := pem.Decode([]byte(data)); block != nil {
+			if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+				switch {
+				case len(cert.EmailAddresses) > 0:
+					signer.Identity = cert.EmailAddresses[0]
+				case len(cert.URIs) > 0:
+					signer.Identity = cert.URIs[0].String()
+				default:
+					signer.Identity = cert.Subject.CommonName
+				}
+			}
+		}
+	}
+	if data, ok := annotations[AnnotationBundle]; ok {
+		b := &bundle{}
+		if err := json.Unmarshal([]byte(data), b); err == nil && b.Payload.IntegratedTime > 0 {
+			signer.SignedAt = time.Unix(b.Payload.IntegratedTime, 0).UTC()
+		}
+	}
+	return signer
+}
+
+// simpleSigning is the payload signed by cosign, only the properties needed by the verification are defined
+type simpleSigning struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// VerifyPayload checks the base64 encoded signature of the payload is signed by one of the keys
+// and the payload refers to the subject artifact
+func VerifyPayload(payload []byte, signature, subjectDigest string, keys []crypto.PublicKey) error {
+	ss := &simpleSigning{}
+	if err := json.Unmarshal(payload, ss); err != nil {
+		return errors.Wrap(err, "invalid signature payload")
+	}
+	if ss.Critical.Image.DockerManifestDigest != subjectDigest {
+		return errors.Errorf("the signature payload refers to %s rather than %s", ss.Critical.Image.DockerManifestDigest, subjectDigest)
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	for _, key := range keys {
+		if verifySignature(key, payload, sig) {
+			return nil
+		}
+	}
+	return errors.New("the signature isn't signed by any of the trusted keys")
+}
+
+func verifySignature(key crypto.PublicKey, payload, sig []byte) bool {
+	digest := sha256.Sum256(payload)
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, digest[:], sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, payload, sig)
+	}
+	return false
+}
+
+// ParsePublicKeys parses the PEM encoded ECDSA, RSA or Ed25519 public keys, e.g. the "cosign.pub" generated by
+// "cosign generate-key-pair", multiple keys can be concatenated
+func ParsePublicKeys(data string) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	rest := []byte(strings.TrimSpace(data))
+	for len(rest) > 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New("invalid PEM encoded public key")
+		}
+		if block.Type != "PUBLIC KEY" {
+			return nil, errors.Errorf("unsupported PEM block type %s, only PUBLIC KEY is supported", block.Type)
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid public key")
+		}
+		switch key.(type) {
+		case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		default:
+			return nil, errors.Errorf("unsupported public key type %T", key)
+		}
+		keys = append(keys, key)
+		rest = []byte(strings.TrimSpace(string(rest)))
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no public key found")
+	}
+	return keys, nil
+}
@@ -0,0 +1,224 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cosign
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/pkg/distribution"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/registry"
+)
+
+const subjectDigest = "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180"
+
+type verifierTestSuite struct {
+	suite.Suite
+	key       *ecdsa.PrivateKey
+	publicKey string
+	payload   []byte
+	signature string
+	regCli    *registry.Client
+	verifier  *verifier
+}
+
+func (v *verifierTestSuite) SetupTest() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	v.Require().Nil(err)
+	v.key = key
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	v.Require().Nil(err)
+	v.publicKey = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	v.payload = []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"harbor.example.com/library/hello-world"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, subjectDigest))
+	v.signature = v.sign(key, v.payload)
+
+	v.regCli = &registry.Client{}
+	v.verifier = &verifier{regCli: v.regCli}
+}
+
+func (v *verifierTestSuite) sign(key *ecdsa.PrivateKey, payload []byte) string {
+	d := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, d[:])
+	v.Require().Nil(err)
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+func (v *verifierTestSuite) TestParsePublicKeys() {
+	keys, err := ParsePublicKeys(v.publicKey)
+	v.Require().Nil(err)
+	v.Len(keys, 1)
+
+	// multiple keys
+	keys, err = ParsePublicKeys(v.publicKey + "\n" + v.publicKey)
+	v.Require().Nil(err)
+	v.Len(keys, 2)
+
+	// empty
+	_, err = ParsePublicKeys("  ")
+	v.NotNil(err)
+
+	// invalid PEM
+	_, err = ParsePublicKeys("invalid")
+	v.NotNil(err)
+
+	// private key
+	der, err := x509.MarshalECPrivateKey(v.key)
+	v.Require().Nil(err)
+	_, err = ParsePublicKeys(string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})))
+	v.NotNil(err)
+}
+
+func (v *verifierTestSuite) TestVerifyPayload() {
+	keys, err := ParsePublicKeys(v.publicKey)
+	v.Require().Nil(err)
+
+	v.Nil(VerifyPayload(v.payload, v.signature, subjectDigest, keys))
+
+	// the payload refers to another artifact
+	v.NotNil(VerifyPayload(v.payload, v.signature, "sha256:0000000000000000000000000000000000000000000000000000000000000000", keys))
+
+	// signed by another key
+	another, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	v.Require().Nil(err)
+	v.NotNil(VerifyPayload(v.payload, v.sign(another, v.payload), subjectDigest, keys))
+
+	// invalid signature
+	v.NotNil(VerifyPayload(v.payload, "invalid", subjectDigest, keys))
+}
+
+func (v *verifierTestSuite) TestVerify() {
+	keys, err := ParsePublicKeys(v.publicKey)
+	v.Require().Nil(err)
+
+	manifest, _, err := distribution.UnmarshalManifest("application/vnd.oci.image.manifest.v1+json", []byte(fmt.Sprintf(`{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "config": {
+    "mediaType": "application/vnd.oci.image.config.v1+json",
+    "size": 233,
+    "digest": "sha256:d4e6059ece7bea95266fd7766353130d4bf3dc21048b8a9783c98b8412618c38"
+  },
+  "layers": [
+    {
+      "mediaType": "%s",
+      "size": %d,
+      "digest": "%s",
+      "annotations": {
+        "%s": "%s"
+      }
+    }
+  ]
+}`, MediaTypeSimpleSigning, len(v.payload), digest.FromBytes(v.payload), AnnotationSignature, v.signature)))
+	v.Require().Nil(err)
+
+	mock.OnAnything(v.regCli, "PullManifest").Return(manifest, "", nil)
+	mock.OnAnything(v.regCli, "PullBlob").Return(int64(len(v.payload)), io.NopCloser(strings.NewReader(string(v.payload))), nil)
+	v.Nil(v.verifier.Verify(nil, "library/hello-world", subjectDigest, "sha256:signature", keys))
+
+	another, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	v.Require().Nil(err)
+	der, err := x509.MarshalPKIXPublicKey(&another.PublicKey)
+	v.Require().Nil(err)
+	untrusted, err := ParsePublicKeys(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+	v.Require().Nil(err)
+	v.regCli.ExpectedCalls = nil
+	mock.OnAnything(v.regCli, "PullManifest").Return(manifest, "", nil)
+	mock.OnAnything(v.regCli, "PullBlob").Return(int64(len(v.payload)), io.NopCloser(strings.NewReader(string(v.payload))), nil)
+	v.NotNil(v.verifier.Verify(nil, "library/hello-world", subjectDigest, "sha256:signature", untrusted))
+}
+
+func (v *verifierTestSuite) TestParseSigner() {
+	template := &x509.Certificate{
+		SerialNumber:   big.NewInt(1),
+		NotBefore:      time.Now(),
+		NotAfter:       time.Now().Add(10 * time.Minute),
+		EmailAddresses: []string{"signer@example.com"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &v.key.PublicKey, v.key)
+	v.Require().Nil(err)
+	cert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	signer := ParseSigner(map[string]string{
+		AnnotationSignature:   v.signature,
+		AnnotationCertificate: cert,
+		AnnotationBundle:      `{"SignedEntryTimestamp":"MEUCIQ==","Payload":{"body":"e30=","integratedTime":1672531200,"logIndex":1,"logID":"id"}}`,
+	})
+	v.Equal("signer@example.com", signer.Identity)
+	v.Equal(time.Unix(1672531200, 0).UTC(), signer.SignedAt)
+
+	// signed by the key without uploading to the transparency log
+	signer = ParseSigner(map[string]string{AnnotationSignature: v.signature})
+	v.Empty(signer.Identity)
+	v.True(signer.SignedAt.IsZero())
+
+	// invalid certificate and bundle
+	signer = ParseSigner(map[string]string{
+		AnnotationSignature:   v.signature,
+		AnnotationCertificate: "invalid",
+		AnnotationBundle:      "invalid",
+	})
+	v.Empty(signer.Identity)
+	v.True(signer.SignedAt.IsZero())
+}
+
+func (v *verifierTestSuite) TestInspect() {
+	manifest, _, err := distribution.UnmarshalManifest("application/vnd.oci.image.manifest.v1+json", []byte(fmt.Sprintf(`{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "config": {
+    "mediaType": "application/vnd.oci.image.config.v1+json",
+    "size": 233,
+    "digest": "sha256:d4e6059ece7bea95266fd7766353130d4bf3dc21048b8a9783c98b8412618c38"
+  },
+  "layers": [
+    {
+      "mediaType": "%s",
+      "size": %d,
+      "digest": "%s",
+      "annotations": {
+        "%s": "%s",
+        "%s": "{\"Payload\":{\"integratedTime\":1672531200}}"
+      }
+    }
+  ]
+}`, MediaTypeSimpleSigning, len(v.payload), digest.FromBytes(v.payload), AnnotationSignature, v.signature, AnnotationBundle)))
+	v.Require().Nil(err)
+
+	mock.OnAnything(v.regCli, "PullManifest").Return(manifest, "", nil)
+	signers, err := v.verifier.Inspect(nil, "library/hello-world", "sha256:signature")
+	v.Require().Nil(err)
+	v.Require().Len(signers, 1)
+	v.Equal(time.Unix(1672531200, 0).UTC(), signers[0].SignedAt)
+}
+
+func TestVerifierTestSuite(t *testing.T) {
+	suite.Run(t, &verifierTestSuite{})
+}
@@ -24,9 +24,17 @@ import (
 	"github.com/goharbor/harbor/src/pkg/signature/notary/model"
 )
 
+// the content trust backends that the signatures belong to
+const (
+	BackendNotary = "notary"
+	BackendCosign = "cosign"
+)
+
 // Checker checks the signature status of artifact
 type Checker struct {
 	signatures map[string]string
+	// the roles that signed the tags
+	roles map[string]string
 }
 
 // IsTagSigned checks if the tag of the artifact is signed, it also checks the signed artifact has the same digest as parm.
@@ -38,6 +46,11 @@ func (sc Checker) IsTagSigned(tag, digest string) bool {
 	return digest == d
 }
 
+// SignedBy returns the role that signed the tag, it's empty if the tag isn't signed
+func (sc Checker) SignedBy(tag string) string {
+	return sc.roles[tag]
+}
+
 // IsArtifactSigned checks if the artifact with given digest is signed.
 func (sc Checker) IsArtifactSigned(digest string) bool {
 	for _, v := range sc.signatures {
@@ -63,6 +76,7 @@ func (m *mgr) GetCheckerByRepo(ctx context.Context, repo string) (*Checker, erro
 		return &Checker{}, nil
 	}
 	s := make(map[string]string)
+	roles := make(map[string]string)
 	targets, err := m.getTargetsByRepo(ctx, repo)
 	if err != nil {
 		return nil, err
@@ -72,9 +86,10 @@ func (m *mgr) GetCheckerByRepo(ctx context.Context, repo string) (*Checker, erro
 			log.Warningf("Failed to get signed digest for tag %s, error: %v, skip", t.Tag, err)
 		} else {
 			s[t.Tag] = d
+			roles[t.Tag] = t.Role
 		}
 	}
-	return &Checker{s}, nil
+	return &Checker{signatures: s, roles: roles}, nil
 }
 
 func (m *mgr) getTargetsByRepo(ctx context.Context, repo string) ([]model.Target, error) {
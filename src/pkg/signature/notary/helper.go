@@ -106,6 +106,7 @@ func GetTargets(ctx context.Context, notaryEndpoint string, username string, fqR
 		res = append(res, model2.Target{
 			Tag:    t.Name,
 			Hashes: t.Hashes,
+			Role:   t.Role.String(),
 		})
 	}
 	return res, nil
@@ -21,5 +21,7 @@ import "github.com/theupdateframework/notary/tuf/data"
 type Target struct {
 	Tag    string      `json:"tag"`
 	Hashes data.Hashes `json:"hashes"`
+	// Role is the role that signed the target, e.g. "targets" or the delegation role "targets/releases"
+	Role string `json:"role"`
 	// TODO: update fields as needed.
 }
@@ -46,7 +46,7 @@ func (*systemArtifactDAO) Create(ctx context.Context, systemArtifact *model.Syst
 	if err != nil {
 		return 0, err
 	}
-	id, err := ormer.Insert(systemArtifact)
+	id, err := ormer.InsertWithCtx(ctx, systemArtifact)
 	if err != nil {
 		if e := orm.AsConflictError(err, "system artifact with repository name %s and digest %s already exists",
 			systemArtifact.Repository, systemArtifact.Digest); e != nil {
@@ -66,7 +66,7 @@ func (*systemArtifactDAO) Get(ctx context.Context, vendor, repository, digest st
 
 	sa := model.SystemArtifact{Repository: repository, Digest: digest, Vendor: vendor}
 
-	err = ormer.Read(&sa, "vendor", "repository", "digest")
+	err = ormer.ReadWithCtx(ctx, &sa, "vendor", "repository", "digest")
 
 	if err != nil {
 		if e := orm.AsNotFoundError(err, "system artifact with repository name %s and digest %s not found",
@@ -92,7 +92,7 @@ func (*systemArtifactDAO) Delete(ctx context.Context, vendor, repository, digest
 		Vendor:     vendor,
 	}
 
-	_, err = ormer.Delete(&sa, "vendor", "repository", "digest")
+	_, err = ormer.DeleteWithCtx(ctx, &sa, "vendor", "repository", "digest")
 
 	return err
 }
@@ -105,7 +105,7 @@ func (*systemArtifactDAO) List(ctx context.Context, query *q.Query) ([]*model.Sy
 	}
 	var systemArtifactRecords []*model.SystemArtifact
 
-	_, err = qs.All(&systemArtifactRecords)
+	_, err = qs.AllWithCtx(ctx, &systemArtifactRecords)
 
 	if err != nil {
 		return nil, err
@@ -39,6 +39,12 @@ type Manager interface {
 	// Invoking this API would result in a repository being created with the specified name and digest within the registry.
 	Create(ctx context.Context, artifactRecord *model.SystemArtifact, reader io.Reader) (int64, error)
 
+	// CreateChunked pushes the chunk [start, end] of the data artifact of the system artifact described by artifact record.
+	// The location is the one returned by pushing the previous chunk and is ignored for the first chunk.
+	// The tracking record is created once the last chunk is pushed.
+	// Returns the location to push the next chunk or any errors encountered in the data artifact upload process.
+	CreateChunked(ctx context.Context, artifactRecord *model.SystemArtifact, chunk io.Reader, start, end int64, location string) (string, error)
+
 	// Read a system artifact described by repository name and digest.
 	// The reader is responsible for closing the IO stream after the read completes.
 	Read(ctx context.Context, vendor string, repository string, digest string) (io.ReadCloser, error)
@@ -118,6 +124,28 @@ func (mgr *systemArtifactManager) Create(ctx context.Context, artifactRecord *mo
 	return artifactID, createError
 }
 
+func (mgr *systemArtifactManager) CreateChunked(ctx context.Context, artifactRecord *model.SystemArtifact, chunk io.Reader, start, end int64, location string) (string, error) {
+	repoName := mgr.getRepositoryName(artifactRecord.Vendor, artifactRecord.Repository)
+	location, _, err := mgr.regCli.PushBlobChunk(repoName, artifactRecord.Digest, artifactRecord.Size, chunk, start, end, location)
+	if err != nil {
+		log.Errorf("Error pushing the chunk of system artifact %s/%s/%s: %v", artifactRecord.Vendor, artifactRecord.Repository, artifactRecord.Digest, err)
+		return "", err
+	}
+	// not the last chunk
+	if end < artifactRecord.Size-1 {
+		return location, nil
+	}
+	// create time defaults to current time if unset
+	if artifactRecord.CreateTime.IsZero() {
+		artifactRecord.CreateTime = time.Now()
+	}
+	if _, err = mgr.dao.Create(ctx, artifactRecord); err != nil {
+		log.Errorf("Error creating system artifact record for %s/%s/%s: %v", artifactRecord.Vendor, artifactRecord.Repository, artifactRecord.Digest, err)
+		return "", err
+	}
+	return location, nil
+}
+
 func (mgr *systemArtifactManager) Read(ctx context.Context, vendor string, repository string, digest string) (io.ReadCloser, error) {
 	sa, err := mgr.dao.Get(ctx, vendor, repository, digest)
 	if err != nil {
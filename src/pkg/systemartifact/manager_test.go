@@ -126,6 +126,43 @@ func (suite *ManagerTestSuite) TestCreateArtifactRecordFailure() {
 	suite.regCli.AssertNotCalled(suite.T(), "PushBlob")
 }
 
+func (suite *ManagerTestSuite) TestCreateChunked() {
+	sa := model.SystemArtifact{
+		Repository: "test_repo",
+		Digest:     "test_digest",
+		Size:       int64(10),
+		Vendor:     "test_vendor",
+		Type:       "test_type",
+	}
+	suite.regCli.On("PushBlobChunk", "sys_harb0r/test_vendor/test_repo", "test_digest", int64(10), mock.Anything, int64(0), int64(4), "").Return("/location1", int64(4), nil).Once()
+	location, err := suite.mgr.CreateChunked(context.TODO(), &sa, strings.NewReader("01234"), 0, 4, "")
+	suite.Require().NoError(err)
+	suite.Equal("/location1", location)
+	suite.dao.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+
+	suite.regCli.On("PushBlobChunk", "sys_harb0r/test_vendor/test_repo", "test_digest", int64(10), mock.Anything, int64(5), int64(9), "/location1").Return("/location2", int64(9), nil).Once()
+	suite.dao.On("Create", mock.Anything, &sa).Return(int64(1), nil).Once()
+	location, err = suite.mgr.CreateChunked(context.TODO(), &sa, strings.NewReader("56789"), 5, 9, "/location1")
+	suite.Require().NoError(err)
+	suite.Equal("/location2", location)
+	suite.False(sa.CreateTime.IsZero(), "Create time expected to be set")
+	suite.dao.AssertExpectations(suite.T())
+}
+
+func (suite *ManagerTestSuite) TestCreateChunkedPushBlobChunkFails() {
+	sa := model.SystemArtifact{
+		Repository: "test_repo",
+		Digest:     "test_digest",
+		Size:       int64(10),
+		Vendor:     "test_vendor",
+		Type:       "test_type",
+	}
+	suite.regCli.On("PushBlobChunk", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", int64(9), errors.New("error")).Once()
+	_, err := suite.mgr.CreateChunked(context.TODO(), &sa, strings.NewReader("0123456789"), 0, 9, "")
+	suite.Error(err)
+	suite.dao.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
 func (suite *ManagerTestSuite) TestRead() {
 	sa := model.SystemArtifact{
 		Repository: "test_repo",
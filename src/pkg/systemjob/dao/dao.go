@@ -0,0 +1,216 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg/systemjob/model"
+)
+
+// the status of the webhook job is recorded in the legacy format, e.g. "finished",
+// convert it to the status of the execution
+const webhookStatusExpression = `CASE status
+	WHEN 'pending' THEN 'Pending'
+	WHEN 'scheduled' THEN 'Scheduled'
+	WHEN 'running' THEN 'Running'
+	WHEN 'retrying' THEN 'Running'
+	WHEN 'stopped' THEN 'Stopped'
+	WHEN 'canceled' THEN 'Stopped'
+	WHEN 'error' THEN 'Error'
+	WHEN 'finished' THEN 'Success'
+	ELSE status END`
+
+// DAO is the data access object for the system jobs
+type DAO interface {
+	// Count the jobs selected by the filter
+	Count(ctx context.Context, filter *model.Filter) (int64, error)
+	// List the jobs selected by the filter sorted by the start time in descending order
+	List(ctx context.Context, filter *model.Filter, pageNumber, pageSize int64) ([]*model.Job, error)
+	// Stats returns the statistics of the jobs selected by the filter per job type
+	Stats(ctx context.Context, filter *model.Filter) ([]*model.Stats, error)
+}
+
+// New returns an instance of the default DAO
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+// Count ...
+func (d *dao) Count(ctx context.Context, filter *model.Filter) (int64, error) {
+	jobs, params := buildJobsSQL(filter)
+	if len(jobs) == 0 {
+		return 0, nil
+	}
+	ormer, err := orm.FromContext(orm.WithReplica(ctx))
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err = ormer.Raw(fmt.Sprintf(`SELECT COUNT(*) FROM (%s) AS jobs`, jobs), params...).QueryRow(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// List ...
+func (d *dao) List(ctx context.Context, filter *model.Filter, pageNumber, pageSize int64) ([]*model.Job, error) {
+	jobs, params := buildJobsSQL(filter)
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	ormer, err := orm.FromContext(orm.WithReplica(ctx))
+	if err != nil {
+		return nil, err
+	}
+	sql := fmt.Sprintf(`SELECT * FROM (%s) AS jobs ORDER BY start_time DESC, id DESC`, jobs)
+	if pageSize > 0 {
+		if pageNumber <= 0 {
+			pageNumber = 1
+		}
+		sql += ` LIMIT ? OFFSET ?`
+		params = append(params, pageSize, (pageNumber-1)*pageSize)
+	}
+	result := []*model.Job{}
+	if _, err = ormer.Raw(sql, params...).QueryRows(&result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Stats ...
+func (d *dao) Stats(ctx context.Context, filter *model.Filter) ([]*model.Stats, error) {
+	jobs, params := buildJobsSQL(filter)
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	ormer, err := orm.FromContext(orm.WithReplica(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	counts := []*struct {
+		Type   string `orm:"column(type)"`
+		Status string `orm:"column(status)"`
+		Count  int64  `orm:"column(count)"`
+	}{}
+	sql := fmt.Sprintf(`SELECT type, status, COUNT(*) AS count FROM (%s) AS jobs GROUP BY type, status`, jobs)
+	if _, err = ormer.Raw(sql, params...).QueryRows(&counts); err != nil {
+		return nil, err
+	}
+	durations := []*struct {
+		Type string  `orm:"column(type)"`
+		P50  float64 `orm:"column(p50)"`
+		P90  float64 `orm:"column(p90)"`
+		P99  float64 `orm:"column(p99)"`
+	}{}
+	sql = fmt.Sprintf(`SELECT type,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM end_time - start_time)) AS p50,
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM end_time - start_time)) AS p90,
+			percentile_cont(0.99) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM end_time - start_time)) AS p99
+		FROM (%s) AS jobs
+		WHERE end_time IS NOT NULL AND end_time >= start_time
+		GROUP BY type`, jobs)
+	if _, err = ormer.Raw(sql, params...).QueryRows(&durations); err != nil {
+		return nil, err
+	}
+
+	stats := map[string]*model.Stats{}
+	for _, c := range counts {
+		s, ok := stats[c.Type]
+		if !ok {
+			s = &model.Stats{Type: c.Type, Statuses: map[string]int64{}}
+			stats[c.Type] = s
+		}
+		s.Total += c.Count
+		s.Statuses[c.Status] += c.Count
+	}
+	for _, du := range durations {
+		if s, ok := stats[du.Type]; ok {
+			s.DurationP50, s.DurationP90, s.DurationP99 = du.P50, du.P90, du.P99
+		}
+	}
+	var result []*model.Stats
+	for _, s := range stats {
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Type < result[j].Type
+	})
+	return result, nil
+}
+
+// buildJobsSQL builds the SQL selecting the executions and the webhook jobs as the unified jobs,
+// an empty SQL is returned if no job type is selected
+func buildJobsSQL(filter *model.Filter) (string, []interface{}) {
+	if filter == nil {
+		return "", nil
+	}
+	var (
+		selects []string
+		params  []interface{}
+	)
+	// keep the order of the job types to make the SQL stable
+	var types []string
+	for typ := range filter.VendorTypes {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	for _, typ := range types {
+		vendorTypes := filter.VendorTypes[typ]
+		if len(vendorTypes) == 0 {
+			continue
+		}
+		sql := fmt.Sprintf(`SELECT id, %s AS type, vendor_type, vendor_id, status, trigger, start_time, end_time
+			FROM execution WHERE vendor_type IN (%s)`, orm.QuoteLiteral(typ), orm.ParamPlaceholderForIn(len(vendorTypes)))
+		for _, vendorType := range vendorTypes {
+			params = append(params, vendorType)
+		}
+		if !filter.Since.IsZero() {
+			sql += ` AND start_time >= ?`
+			params = append(params, filter.Since)
+		}
+		selects = append(selects, sql)
+	}
+	if filter.Webhook {
+		sql := fmt.Sprintf(`SELECT id, %s AS type, notify_type AS vendor_type, policy_id AS vendor_id, %s AS status,
+				'EVENT' AS trigger, creation_time AS start_time,
+				CASE WHEN status IN ('finished', 'error', 'stopped', 'canceled') THEN update_time END AS end_time
+			FROM notification_job`, orm.QuoteLiteral(model.TypeWebhook), webhookStatusExpression)
+		if !filter.Since.IsZero() {
+			sql += ` WHERE creation_time >= ?`
+			params = append(params, filter.Since)
+		}
+		selects = append(selects, sql)
+	}
+	if len(selects) == 0 {
+		return "", nil
+	}
+
+	sql := strings.Join(selects, " UNION ALL ")
+	if len(filter.Statuses) > 0 {
+		sql = fmt.Sprintf(`SELECT * FROM (%s) AS unified WHERE status IN (%s)`, sql, orm.ParamPlaceholderForIn(len(filter.Statuses)))
+		for _, status := range filter.Statuses {
+			params = append(params, status)
+		}
+	}
+	return sql, params
+}
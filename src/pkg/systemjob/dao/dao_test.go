@@ -0,0 +1,51 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/pkg/systemjob/model"
+)
+
+func TestBuildJobsSQL(t *testing.T) {
+	// no job type selected
+	sql, params := buildJobsSQL(nil)
+	assert.Empty(t, sql)
+	assert.Empty(t, params)
+	sql, _ = buildJobsSQL(&model.Filter{Statuses: []string{"Error"}})
+	assert.Empty(t, sql)
+
+	since := time.Now()
+	sql, params = buildJobsSQL(&model.Filter{
+		VendorTypes: map[string][]string{
+			model.TypeScan: {"IMAGE_SCAN", "SCAN_ALL"},
+			model.TypeGC:   {"GARBAGE_COLLECTION"},
+		},
+		Webhook:  true,
+		Statuses: []string{"Error"},
+		Since:    since,
+	})
+	assert.Contains(t, sql, "'gc' AS type")
+	assert.Contains(t, sql, "'scan' AS type")
+	assert.Contains(t, sql, "'webhook' AS type")
+	assert.Contains(t, sql, "FROM notification_job WHERE creation_time >= ?")
+	assert.Contains(t, sql, "WHERE status IN (?)")
+	// the job types are sorted
+	assert.Equal(t, []interface{}{"GARBAGE_COLLECTION", since, "IMAGE_SCAN", "SCAN_ALL", since, since, "Error"}, params)
+}
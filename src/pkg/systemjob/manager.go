@@ -0,0 +1,61 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systemjob
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/pkg/systemjob/dao"
+	"github.com/goharbor/harbor/src/pkg/systemjob/model"
+)
+
+// Mgr is the global system job manager instance
+var Mgr = New()
+
+// Manager provides the system-wide view of the replication, scan, GC, retention and webhook jobs
+type Manager interface {
+	// Count the jobs selected by the filter
+	Count(ctx context.Context, filter *model.Filter) (int64, error)
+	// List the jobs selected by the filter sorted by the start time in descending order
+	List(ctx context.Context, filter *model.Filter, pageNumber, pageSize int64) ([]*model.Job, error)
+	// Stats returns the statistics of the jobs selected by the filter per job type
+	Stats(ctx context.Context, filter *model.Filter) ([]*model.Stats, error)
+}
+
+// New returns a default implementation of Manager
+func New() Manager {
+	return &manager{
+		dao: dao.New(),
+	}
+}
+
+type manager struct {
+	dao dao.DAO
+}
+
+// Count ...
+func (m *manager) Count(ctx context.Context, filter *model.Filter) (int64, error) {
+	return m.dao.Count(ctx, filter)
+}
+
+// List ...
+func (m *manager) List(ctx context.Context, filter *model.Filter, pageNumber, pageSize int64) ([]*model.Job, error) {
+	return m.dao.List(ctx, filter, pageNumber, pageSize)
+}
+
+// Stats ...
+func (m *manager) Stats(ctx context.Context, filter *model.Filter) ([]*model.Stats, error) {
+	return m.dao.Stats(ctx, filter)
+}
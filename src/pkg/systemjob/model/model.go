@@ -0,0 +1,67 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+// the types of the system jobs
+const (
+	TypeReplication = "replication"
+	TypeScan        = "scan"
+	TypeGC          = "gc"
+	TypeRetention   = "retention"
+	TypeWebhook     = "webhook"
+)
+
+// Job is the execution of the replication, scan, GC and retention or the webhook job
+type Job struct {
+	ID int64 `orm:"column(id)" json:"id"`
+	// one of the job types
+	Type string `orm:"column(type)" json:"type"`
+	// the vendor type of the execution or the notify type of the webhook job
+	VendorType string `orm:"column(vendor_type)" json:"vendor_type"`
+	// the policy ID of the job if any
+	VendorID  int64     `orm:"column(vendor_id)" json:"vendor_id"`
+	Status    string    `orm:"column(status)" json:"status"`
+	Trigger   string    `orm:"column(trigger)" json:"trigger"`
+	StartTime time.Time `orm:"column(start_time)" json:"start_time"`
+	// the end time is zero if the job isn't in the final status
+	EndTime time.Time `orm:"column(end_time)" json:"end_time"`
+}
+
+// Filter selects the jobs, the zero values are ignored
+type Filter struct {
+	// the execution vendor types of the job types, e.g. "scan": ["IMAGE_SCAN", "SCAN_ALL"]
+	VendorTypes map[string][]string
+	// include the webhook jobs or not
+	Webhook bool
+	// the statuses of the jobs, e.g. "Running", "Error"
+	Statuses []string
+	// the jobs started since the time
+	Since time.Time
+}
+
+// Stats is the statistics of the jobs of the type
+type Stats struct {
+	Type     string           `json:"type"`
+	Total    int64            `json:"total"`
+	Statuses map[string]int64 `json:"statuses"`
+	// the percentiles of the durations of the finished jobs in seconds
+	DurationP50 float64 `json:"duration_p50"`
+	DurationP90 float64 `json:"duration_p90"`
+	DurationP99 float64 `json:"duration_p99"`
+}
@@ -45,6 +45,9 @@ type DAO interface {
 	Delete(ctx context.Context, id int64) (err error)
 	// DeleteOfArtifact deletes all tags attached to the artifact
 	DeleteOfArtifact(ctx context.Context, artifactID int64) (err error)
+	// ListNames lists the names of the tags under the repository which are greater than the "last" in
+	// byte order, the count of the returned names is limited by the "limit" if it is greater than 0
+	ListNames(ctx context.Context, repositoryID int64, last string, limit int) (names []string, err error)
 }
 
 // New returns an instance of the default DAO
@@ -59,7 +62,7 @@ func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 func (d *dao) List(ctx context.Context, query *q.Query) ([]*tag.Tag, error) {
 	tags := []*tag.Tag{}
@@ -67,7 +70,10 @@ func (d *dao) List(ctx context.Context, query *q.Query) ([]*tag.Tag, error) {
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&tags); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &tags); err != nil {
+		return nil, err
+	}
+	if err = orm.SetNextCursor(query, tags); err != nil {
 		return nil, err
 	}
 	return tags, nil
@@ -80,7 +86,7 @@ func (d *dao) Get(ctx context.Context, id int64) (*tag.Tag, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := ormer.Read(tag); err != nil {
+	if err := ormer.ReadWithCtx(ctx, tag); err != nil {
 		if e := orm.AsNotFoundError(err, "tag %d not found", id); e != nil {
 			err = e
 		}
@@ -93,7 +99,7 @@ func (d *dao) Create(ctx context.Context, tag *tag.Tag) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	id, err := ormer.Insert(tag)
+	id, err := ormer.InsertWithCtx(ctx, tag)
 	if err != nil {
 		if e := orm.AsConflictError(err, "tag %s already exists under the repository %d",
 			tag.Name, tag.RepositoryID); e != nil {
@@ -110,7 +116,7 @@ func (d *dao) Update(ctx context.Context, tag *tag.Tag, props ...string) error {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Update(tag, props...)
+	n, err := ormer.UpdateWithCtx(ctx, tag, props...)
 	if err != nil {
 		if e := orm.AsForeignKeyError(err, "the tag %d tries to attach to a non existing artifact %d",
 			tag.ID, tag.ArtifactID); e != nil {
@@ -128,7 +134,7 @@ func (d *dao) Delete(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&tag.Tag{
+	n, err := ormer.DeleteWithCtx(ctx, &tag.Tag{
 		ID: id,
 	})
 	if err != nil {
@@ -149,6 +155,31 @@ func (d *dao) DeleteOfArtifact(ctx context.Context, artifactID int64) error {
 	if err != nil {
 		return err
 	}
-	_, err = qs.Delete()
+	_, err = qs.DeleteWithCtx(ctx)
 	return err
 }
+
+func (d *dao) ListNames(ctx context.Context, repositoryID int64, last string, limit int) ([]string, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// compare and order the names with the "C" collation to keep consistent with the byte order
+	// required by the distribution spec, and it's served by the index "idx_tag_repository_id_name"
+	sql := `select name from tag where repository_id = ?`
+	params := []interface{}{repositoryID}
+	if last != "" {
+		sql += ` and name COLLATE "C" > ?`
+		params = append(params, last)
+	}
+	sql += ` order by name COLLATE "C"`
+	if limit > 0 {
+		sql += ` limit ?`
+		params = append(params, limit)
+	}
+	names := []string{}
+	if _, err = ormer.Raw(sql, params...).QueryRows(&names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
@@ -270,6 +270,44 @@ func (d *daoTestSuite) TestDeleteOfArtifact() {
 	d.Require().Len(tags, 0)
 }
 
+func (d *daoTestSuite) TestListNames() {
+	artifactID, err := d.artDAO.Create(d.ctx, &artdao.Artifact{
+		Type:              "IMAGE",
+		MediaType:         "application/vnd.oci.image.config.v1+json",
+		ManifestMediaType: "application/vnd.oci.image.manifest.v1+json",
+		ProjectID:         1,
+		RepositoryID:      1000,
+		Digest:            "sha256:digest03",
+	})
+	d.Require().Nil(err)
+	defer d.artDAO.Delete(d.ctx, artifactID)
+	defer d.dao.DeleteOfArtifact(d.ctx, artifactID)
+
+	for _, name := range []string{"v2", "V1", "v1"} {
+		_, err = d.dao.Create(d.ctx, &tag.Tag{
+			RepositoryID: 1000,
+			ArtifactID:   artifactID,
+			Name:         name,
+		})
+		d.Require().Nil(err)
+	}
+
+	// all names in byte order
+	names, err := d.dao.ListNames(d.ctx, 1000, "", 0)
+	d.Require().Nil(err)
+	d.Equal([]string{"V1", "latest", "v1", "v2"}, names)
+
+	// with last and limit
+	names, err = d.dao.ListNames(d.ctx, 1000, "latest", 1)
+	d.Require().Nil(err)
+	d.Equal([]string{"v1"}, names)
+
+	// no names after the last
+	names, err = d.dao.ListNames(d.ctx, 1000, "v2", 2)
+	d.Require().Nil(err)
+	d.Len(names, 0)
+}
+
 func TestDaoTestSuite(t *testing.T) {
 	suite.Run(t, &daoTestSuite{})
 }
@@ -43,6 +43,9 @@ type Manager interface {
 	Delete(ctx context.Context, id int64) (err error)
 	// DeleteOfArtifact deletes all tags attached to the artifact
 	DeleteOfArtifact(ctx context.Context, artifactID int64) (err error)
+	// ListNames lists the names of the tags under the repository which are greater than the "last" in
+	// byte order, the count of the returned names is limited by the "limit" if it is greater than 0
+	ListNames(ctx context.Context, repositoryID int64, last string, limit int) (names []string, err error)
 }
 
 // NewManager creates an instance of the default tag manager
@@ -83,3 +86,7 @@ func (m *manager) Delete(ctx context.Context, id int64) error {
 func (m *manager) DeleteOfArtifact(ctx context.Context, artifactID int64) error {
 	return m.dao.DeleteOfArtifact(ctx, artifactID)
 }
+
+func (m *manager) ListNames(ctx context.Context, repositoryID int64, last string, limit int) ([]string, error) {
+	return m.dao.ListNames(ctx, repositoryID, last, limit)
+}
@@ -58,6 +58,10 @@ func (f *fakeDao) DeleteOfArtifact(ctx context.Context, artifactID int64) error
 	args := f.Called()
 	return args.Error(0)
 }
+func (f *fakeDao) ListNames(ctx context.Context, repositoryID int64, last string, limit int) ([]string, error) {
+	args := f.Called()
+	return args.Get(0).([]string), args.Error(1)
+}
 
 type managerTestSuite struct {
 	suite.Suite
@@ -129,6 +133,14 @@ func (m *managerTestSuite) TestDeleteOfArtifact() {
 	m.Require().Nil(err)
 }
 
+func (m *managerTestSuite) TestListNames() {
+	m.dao.On("ListNames", mock.Anything).Return([]string{"v2"}, nil)
+	names, err := m.mgr.ListNames(nil, 1, "v1", 2)
+	m.Require().Nil(err)
+	m.Equal([]string{"v2"}, names)
+	m.dao.AssertExpectations(m.T())
+}
+
 func TestManager(t *testing.T) {
 	suite.Run(t, &managerTestSuite{})
 }
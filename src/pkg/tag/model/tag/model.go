@@ -15,9 +15,17 @@
 package tag
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/beego/beego/v2/client/orm"
+
+	liborm "github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
 )
 
 // Tag model in database
@@ -30,6 +38,80 @@ type Tag struct {
 	PullTime     time.Time `orm:"column(pull_time)" json:"pull_time"`
 }
 
+// FilterByLabels filters the tags whose artifacts are attached with all the specified labels,
+// the value is the label ID list with the intersection relationship, e.g. q=labels=(1 2)
+func (t *Tag) FilterByLabels(ctx context.Context, qs orm.QuerySeter, key string, value interface{}) orm.QuerySeter {
+	al, ok := value.(*q.AndList)
+	if !ok || len(al.Values) == 0 {
+		return qs
+	}
+	var collections []string
+	for _, v := range al.Values {
+		labelID, ok := v.(int64)
+		if !ok {
+			// make sure no tag will be selected with the invalid label ID
+			return qs.FilterRaw("artifact_id", "IN (-1)")
+		}
+		// param "labelID" is integer, no need to sanitize
+		collections = append(collections, fmt.Sprintf(`SELECT artifact_id FROM label_reference WHERE label_id = %d`, labelID))
+	}
+	return qs.FilterRaw("artifact_id", fmt.Sprintf("IN (%s)", strings.Join(collections, " INTERSECT ")))
+}
+
+// FilterBySigned filters the tags by whether their artifacts are signed by cosign, e.g. q=signed=true
+func (t *Tag) FilterBySigned(ctx context.Context, qs orm.QuerySeter, key string, value interface{}) orm.QuerySeter {
+	signed, ok := value.(bool)
+	if !ok {
+		return qs
+	}
+	sql := fmt.Sprintf(`IN (SELECT subject_artifact_id FROM artifact_accessory WHERE type = %s)`,
+		liborm.QuoteLiteral(model.TypeCosignSignature))
+	if !signed {
+		sql = "NOT " + sql
+	}
+	return qs.FilterRaw("artifact_id", sql)
+}
+
+// FilterByMaxSeverity filters the tags whose artifacts are scanned successfully and contain no vulnerability
+// more severe than the specified one, e.g. q=max_severity=High
+func (t *Tag) FilterByMaxSeverity(ctx context.Context, qs orm.QuerySeter, key string, value interface{}) orm.QuerySeter {
+	s, ok := value.(string)
+	if !ok || len(s) == 0 {
+		return qs
+	}
+	maxSev, ok := ParseSeverity(s)
+	if !ok {
+		// make sure no tag will be selected with the invalid severity
+		return qs.FilterRaw("artifact_id", "IN (-1)")
+	}
+	// list the allowed severities rather than the disallowed ones to exclude the unrecognized severities as well
+	var allowed []string
+	for _, sev := range severities {
+		if sev.Code() <= maxSev.Code() {
+			allowed = append(allowed, liborm.QuoteLiteral(sev.String()))
+		}
+	}
+	return qs.FilterRaw("artifact_id", fmt.Sprintf(`IN (SELECT a.id FROM artifact AS a
+				JOIN scan_report AS sr ON sr.digest = a.digest
+				WHERE sr.status = 'Success' AND NOT EXISTS (
+					SELECT 1 FROM report_vulnerability_record AS rvr
+					JOIN vulnerability_record AS vr ON vr.id = rvr.vuln_record_id
+					WHERE rvr.report_uuid = sr.uuid AND vr.severity NOT IN (%s)))`, strings.Join(allowed, ",")))
+}
+
+var severities = []vuln.Severity{vuln.None, vuln.Unknown, vuln.Negligible, vuln.Low, vuln.Medium, vuln.High, vuln.Critical}
+
+// ParseSeverity returns the severity matching the string case-insensitively, the second return value
+// is false if the string isn't a recognized severity
+func ParseSeverity(s string) (vuln.Severity, bool) {
+	for _, sev := range severities {
+		if strings.EqualFold(sev.String(), s) {
+			return sev, true
+		}
+	}
+	return "", false
+}
+
 // GetDefaultSorts specifies the default sorts
 func (t *Tag) GetDefaultSorts() []*q.Sort {
 	return []*q.Sort{
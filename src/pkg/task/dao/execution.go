@@ -74,7 +74,7 @@ func (e *executionDAO) Count(ctx context.Context, query *q.Query) (int64, error)
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 
 func (e *executionDAO) List(ctx context.Context, query *q.Query) ([]*Execution, error) {
@@ -83,7 +83,10 @@ func (e *executionDAO) List(ctx context.Context, query *q.Query) ([]*Execution,
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&executions); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &executions); err != nil {
+		return nil, err
+	}
+	if err = orm.SetNextCursor(query, executions); err != nil {
 		return nil, err
 	}
 	return executions, nil
@@ -97,7 +100,7 @@ func (e *executionDAO) Get(ctx context.Context, id int64) (*Execution, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := ormer.Read(execution); err != nil {
+	if err := ormer.ReadWithCtx(ctx, execution); err != nil {
 		if e := orm.AsNotFoundError(err, "execution %d not found", id); e != nil {
 			err = e
 		}
@@ -111,7 +114,7 @@ func (e *executionDAO) Create(ctx context.Context, execution *Execution) (int64,
 	if err != nil {
 		return 0, err
 	}
-	return ormer.Insert(execution)
+	return ormer.InsertWithCtx(ctx, execution)
 }
 
 func (e *executionDAO) Update(ctx context.Context, execution *Execution, props ...string) error {
@@ -119,7 +122,7 @@ func (e *executionDAO) Update(ctx context.Context, execution *Execution, props .
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Update(execution, props...)
+	n, err := ormer.UpdateWithCtx(ctx, execution, props...)
 	if err != nil {
 		return err
 	}
@@ -134,7 +137,7 @@ func (e *executionDAO) Delete(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&Execution{
+	n, err := ormer.DeleteWithCtx(ctx, &Execution{
 		ID: id,
 	})
 	if err != nil {
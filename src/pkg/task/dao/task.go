@@ -73,7 +73,7 @@ func (t *taskDAO) Count(ctx context.Context, query *q.Query) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 
 func (t *taskDAO) List(ctx context.Context, query *q.Query) ([]*Task, error) {
@@ -82,7 +82,7 @@ func (t *taskDAO) List(ctx context.Context, query *q.Query) ([]*Task, error) {
 	if err != nil {
 		return nil, err
 	}
-	if _, err = qs.All(&tasks); err != nil {
+	if _, err = qs.AllWithCtx(ctx, &tasks); err != nil {
 		return nil, err
 	}
 	return tasks, nil
@@ -96,7 +96,7 @@ func (t *taskDAO) Get(ctx context.Context, id int64) (*Task, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := ormer.Read(task); err != nil {
+	if err := ormer.ReadWithCtx(ctx, task); err != nil {
 		if e := orm.AsNotFoundError(err, "task %d not found", id); e != nil {
 			err = e
 		}
@@ -110,7 +110,7 @@ func (t *taskDAO) Create(ctx context.Context, task *Task) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	id, err := ormer.Insert(task)
+	id, err := ormer.InsertWithCtx(ctx, task)
 	if err != nil {
 		if e := orm.AsForeignKeyError(err,
 			"the task tries to reference a non existing execution %d", task.ExecutionID); e != nil {
@@ -126,7 +126,7 @@ func (t *taskDAO) Update(ctx context.Context, task *Task, props ...string) error
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Update(task, props...)
+	n, err := ormer.UpdateWithCtx(ctx, task, props...)
 	if err != nil {
 		return err
 	}
@@ -175,7 +175,7 @@ func (t *taskDAO) Delete(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Delete(&Task{
+	n, err := ormer.DeleteWithCtx(ctx, &Task{
 		ID: id,
 	})
 	if err != nil {
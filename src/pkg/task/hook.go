@@ -17,11 +17,15 @@ package task
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/jobarchive"
+	"github.com/goharbor/harbor/src/pkg/jobarchive/model"
 	"github.com/goharbor/harbor/src/pkg/task/dao"
 )
 
@@ -35,6 +39,7 @@ func NewHookHandler() *HookHandler {
 	return &HookHandler{
 		taskDAO:      dao.NewTaskDAO(),
 		executionDAO: dao.NewExecutionDAO(),
+		archiveMgr:   jobarchive.Mgr,
 	}
 }
 
@@ -42,6 +47,7 @@ func NewHookHandler() *HookHandler {
 type HookHandler struct {
 	taskDAO      dao.TaskDAO
 	executionDAO dao.ExecutionDAO
+	archiveMgr   jobarchive.Manager
 }
 
 // Handle the job status changing webhook
@@ -93,6 +99,12 @@ func (h *HookHandler) Handle(ctx context.Context, sc *job.StatusChange) error {
 			logger.Errorf("failed to run the task status change post function for task %d: %v", task.ID, err)
 		}
 	}
+	// archive the job in the final status, the archive is kept after the task is swept
+	if job.Status(sc.Status).Final() {
+		if err = h.archive(ctx, task.ID, execution, sc); err != nil {
+			logger.Errorf("failed to archive the job of task %d: %v", task.ID, err)
+		}
+	}
 
 	// update execution status
 	statusChanged, currentStatus, err := h.executionDAO.RefreshStatus(ctx, task.ExecutionID)
@@ -107,3 +119,57 @@ func (h *HookHandler) Handle(ctx context.Context, sc *job.StatusChange) error {
 	}
 	return nil
 }
+
+func (h *HookHandler) archive(ctx context.Context, taskID int64, execution *dao.Execution, sc *job.StatusChange) error {
+	// read the task again to get the updated status and time
+	task, err := h.taskDAO.Get(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	// the status change may be ignored when it's out of date
+	if !job.Status(task.Status).Final() {
+		return nil
+	}
+	archive := &model.JobArchive{
+		TaskID:        task.ID,
+		ExecutionID:   task.ExecutionID,
+		JobID:         task.JobID,
+		VendorType:    execution.VendorType,
+		VendorID:      execution.VendorID,
+		Status:        task.Status,
+		StatusMessage: task.StatusMessage,
+		Trigger:       execution.Trigger,
+		StartTime:     task.StartTime,
+		EndTime:       task.EndTime,
+	}
+	if sc.Metadata != nil {
+		archive.JobName = sc.Metadata.JobName
+		archive.ProjectID = projectIDOf(sc.Metadata)
+		archive.Parameters = jobarchive.EncodeParameters(sc.Metadata.Parameters)
+	}
+	_, err = h.archiveMgr.Create(ctx, archive)
+	return err
+}
+
+// projectIDOf returns the ID of the project which the job belongs to, it's resolved from
+// the fair share key "project:<id>" or the "project_id" parameter of the job
+func projectIDOf(info *job.StatsInfo) int64 {
+	if strings.HasPrefix(info.FairShareKey, "project:") {
+		if id, err := strconv.ParseInt(strings.TrimPrefix(info.FairShareKey, "project:"), 10, 64); err == nil {
+			return id
+		}
+	}
+	switch v := info.Parameters["project_id"].(type) {
+	case float64:
+		return int64(v)
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case string:
+		if id, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return id
+		}
+	}
+	return 0
+}
@@ -23,7 +23,9 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/jobarchive/model"
 	"github.com/goharbor/harbor/src/pkg/task/dao"
+	"github.com/goharbor/harbor/src/testing/pkg/jobarchive"
 )
 
 type hookHandlerTestSuite struct {
@@ -31,14 +33,17 @@ type hookHandlerTestSuite struct {
 	handler *HookHandler
 	execDAO *mockExecutionDAO
 	taskDAO *mockTaskDAO
+	archMgr *jobarchive.Manager
 }
 
 func (h *hookHandlerTestSuite) SetupTest() {
 	h.execDAO = &mockExecutionDAO{}
 	h.taskDAO = &mockTaskDAO{}
+	h.archMgr = &jobarchive.Manager{}
 	h.handler = &HookHandler{
 		taskDAO:      h.taskDAO,
 		executionDAO: h.execDAO,
+		archiveMgr:   h.archMgr,
 	}
 }
 
@@ -81,16 +86,33 @@ func (h *hookHandlerTestSuite) TestHandle() {
 		VendorType: "test",
 	}, nil)
 	h.execDAO.On("RefreshStatus", mock.Anything, mock.Anything).Return(true, job.RunningStatus.String(), nil)
+	h.taskDAO.On("Get", mock.Anything, int64(1)).Return(&dao.Task{
+		ID:          1,
+		ExecutionID: 1,
+		Status:      job.SuccessStatus.String(),
+	}, nil)
+	h.archMgr.On("Create", mock.Anything, mock.MatchedBy(func(a *model.JobArchive) bool {
+		return a.TaskID == 1 && a.VendorType == "test" && a.ProjectID == 2 && a.Status == job.SuccessStatus.String()
+	})).Return(int64(1), nil)
 	sc = &job.StatusChange{
 		Status: job.SuccessStatus.String(),
 		Metadata: &job.StatsInfo{
-			Revision: time.Now().Unix(),
+			Revision:     time.Now().Unix(),
+			FairShareKey: "project:2",
 		},
 	}
 	err = h.handler.Handle(nil, sc)
 	h.Require().Nil(err)
 	h.taskDAO.AssertExpectations(h.T())
 	h.execDAO.AssertExpectations(h.T())
+	h.archMgr.AssertExpectations(h.T())
+}
+
+func (h *hookHandlerTestSuite) TestProjectIDOf() {
+	h.Equal(int64(1), projectIDOf(&job.StatsInfo{FairShareKey: "project:1"}))
+	h.Equal(int64(2), projectIDOf(&job.StatsInfo{Parameters: job.Parameters{"project_id": float64(2)}}))
+	h.Equal(int64(3), projectIDOf(&job.StatsInfo{Parameters: job.Parameters{"project_id": "3"}}))
+	h.Equal(int64(0), projectIDOf(&job.StatsInfo{FairShareKey: "robot:1"}))
 }
 
 func TestHookHandlerTestSuite(t *testing.T) {
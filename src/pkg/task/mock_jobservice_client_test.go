@@ -60,6 +60,29 @@ func (_m *mockJobserviceClient) GetJobLog(uuid string) ([]byte, error) {
 	return r0, r1
 }
 
+// GetJobLogFrom provides a mock function with given fields: uuid, offset
+func (_m *mockJobserviceClient) GetJobLogFrom(uuid string, offset int64) ([]byte, error) {
+	ret := _m.Called(uuid, offset)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(string, int64) []byte); ok {
+		r0 = rf(uuid, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(uuid, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetJobServiceConfig provides a mock function with given fields:
 func (_m *mockJobserviceClient) GetJobServiceConfig() (*job.Config, error) {
 	ret := _m.Called()
@@ -23,9 +23,11 @@ import (
 	cjob "github.com/goharbor/harbor/src/common/job"
 	"github.com/goharbor/harbor/src/common/job/models"
 	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
+	tracelib "github.com/goharbor/harbor/src/lib/trace"
 	"github.com/goharbor/harbor/src/pkg/task/dao"
 )
 
@@ -53,6 +55,8 @@ type Manager interface {
 	UpdateExtraAttrs(ctx context.Context, id int64, extraAttrs map[string]interface{}) (err error)
 	// Get the log of the specified task
 	GetLog(ctx context.Context, id int64) (log []byte, err error)
+	// Get the log of the specified task after the offset (bytes)
+	GetLogFrom(ctx context.Context, id int64, offset int64) (log []byte, err error)
 	// Count counts total of tasks according to the query.
 	// Query the "ExtraAttrs" by setting 'query.Keywords["ExtraAttrs.key"]="value"'
 	Count(ctx context.Context, query *q.Query) (int64, error)
@@ -168,6 +172,10 @@ func (m *manager) submitJob(ctx context.Context, id int64, jb *Job) (string, err
 			ScheduleDelay: jb.Metadata.ScheduleDelay,
 			Cron:          jb.Metadata.Cron,
 			IsUnique:      jb.Metadata.IsUnique,
+			PriorityClass: jb.Metadata.PriorityClass,
+			FairShareKey:  jb.Metadata.FairShareKey,
+			TraceContext:  tracelib.InjectCarrier(ctx),
+			RequestID:     lib.GetRequestID(ctx),
 		}
 	}
 
@@ -252,6 +260,14 @@ func (m *manager) GetLog(ctx context.Context, id int64) ([]byte, error) {
 	return m.jsClient.GetJobLog(task.JobID)
 }
 
+func (m *manager) GetLogFrom(ctx context.Context, id int64, offset int64) ([]byte, error) {
+	task, err := m.dao.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return m.jsClient.GetJobLogFrom(task.JobID, offset)
+}
+
 func (m *manager) UpdateStatusInBatch(ctx context.Context, jobIDs []string, status string, batchSize int) error {
 	return m.dao.UpdateStatusInBatch(ctx, jobIDs, status, batchSize)
 }
@@ -127,6 +127,19 @@ func (t *taskManagerTestSuite) TestGet() {
 	t.dao.AssertExpectations(t.T())
 }
 
+func (t *taskManagerTestSuite) TestGetLogFrom() {
+	t.dao.On("Get", mock.Anything, mock.Anything).Return(&dao.Task{
+		ID:    1,
+		JobID: "1",
+	}, nil)
+	t.jsClient.On("GetJobLogFrom", "1", int64(10)).Return([]byte("log"), nil)
+	log, err := t.mgr.GetLogFrom(nil, 1, 10)
+	t.Require().Nil(err)
+	t.Equal("log", string(log))
+	t.dao.AssertExpectations(t.T())
+	t.jsClient.AssertExpectations(t.T())
+}
+
 func (t *taskManagerTestSuite) TestUpdateExtraAttrs() {
 	t.dao.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	err := t.mgr.UpdateExtraAttrs(nil, 1, map[string]interface{}{})
@@ -3,9 +3,12 @@ package v2
 import (
 	"crypto/subtle"
 	"fmt"
+	"net"
 
 	"github.com/docker/distribution/registry/auth/token"
 	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/goharbor/harbor/src/pkg/networkpolicy"
 )
 
 func init() {
@@ -21,6 +24,18 @@ const (
 type Claims struct {
 	jwt.RegisteredClaims
 	Access []*token.ResourceActions `json:"access"`
+	// NetworkPolicies restrict the networks the token is usable from, all of them must allow the client
+	NetworkPolicies []*networkpolicy.Policy `json:"network_policies,omitempty"`
+}
+
+// AllowsClient returns whether the token is usable from the IP of the client
+func (c *Claims) AllowsClient(ip net.IP) bool {
+	for _, policy := range c.NetworkPolicies {
+		if !policy.Allows(ip) {
+			return false
+		}
+	}
+	return true
 }
 
 // Valid checks if the issuer is harbor
@@ -1,11 +1,14 @@
 package v2
 
 import (
+	"net"
 	"testing"
 
 	"github.com/docker/distribution/registry/auth/token"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/pkg/networkpolicy"
 )
 
 func TestValid(t *testing.T) {
@@ -41,3 +44,16 @@ func TestValid(t *testing.T) {
 		}
 	}
 }
+
+func TestAllowsClient(t *testing.T) {
+	claims := &Claims{}
+	assert.True(t, claims.AllowsClient(net.ParseIP("1.2.3.4")))
+
+	claims.NetworkPolicies = []*networkpolicy.Policy{
+		{Allowlist: "10.0.0.0/8"},
+		{Denylist: "10.1.0.0/16"},
+	}
+	assert.True(t, claims.AllowsClient(net.ParseIP("10.2.0.1")))
+	assert.False(t, claims.AllowsClient(net.ParseIP("10.1.0.1")))
+	assert.False(t, claims.AllowsClient(net.ParseIP("1.2.3.4")))
+}
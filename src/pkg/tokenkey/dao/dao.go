@@ -0,0 +1,100 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/tokenkey/model"
+)
+
+// DAO defines the interface to access the token key data model
+type DAO interface {
+	// Create ...
+	Create(ctx context.Context, k *model.TokenKey) (int64, error)
+
+	// Update ...
+	Update(ctx context.Context, k *model.TokenKey, props ...string) error
+
+	// Delete ...
+	Delete(ctx context.Context, id int64) error
+
+	// List ...
+	List(ctx context.Context, query *q.Query) ([]*model.TokenKey, error)
+}
+
+// New creates a default implementation for Dao
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+func (d *dao) Create(ctx context.Context, k *model.TokenKey) (int64, error) {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	id, err := ormer.InsertWithCtx(ctx, k)
+	if err != nil {
+		return 0, orm.WrapConflictError(err, "token key %s already exists", k.KeyID)
+	}
+	return id, nil
+}
+
+func (d *dao) Update(ctx context.Context, k *model.TokenKey, props ...string) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.UpdateWithCtx(ctx, k, props...)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("token key %d not found", k.ID)
+	}
+	return nil
+}
+
+func (d *dao) Delete(ctx context.Context, id int64) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	n, err := ormer.DeleteWithCtx(ctx, &model.TokenKey{ID: id})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.NotFoundError(nil).WithMessage("token key %d not found", id)
+	}
+	return nil
+}
+
+func (d *dao) List(ctx context.Context, query *q.Query) ([]*model.TokenKey, error) {
+	keys := []*model.TokenKey{}
+	qs, err := orm.QuerySetter(ctx, &model.TokenKey{}, query)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = qs.AllWithCtx(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
@@ -0,0 +1,76 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokenkey
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/tokenkey/dao"
+	"github.com/goharbor/harbor/src/pkg/tokenkey/model"
+)
+
+var (
+	// Mgr is a global variable for the default token key manager implementation
+	Mgr = NewManager()
+)
+
+// Manager manages the keys signing the registry tokens
+type Manager interface {
+	// Create ...
+	Create(ctx context.Context, k *model.TokenKey) (int64, error)
+
+	// Update ...
+	Update(ctx context.Context, k *model.TokenKey, props ...string) error
+
+	// Delete ...
+	Delete(ctx context.Context, id int64) error
+
+	// List ...
+	List(ctx context.Context, query *q.Query) ([]*model.TokenKey, error)
+}
+
+var _ Manager = &manager{}
+
+type manager struct {
+	dao dao.DAO
+}
+
+// NewManager return a new instance of the token key manager
+func NewManager() Manager {
+	return &manager{
+		dao: dao.New(),
+	}
+}
+
+// Create ...
+func (m *manager) Create(ctx context.Context, k *model.TokenKey) (int64, error) {
+	return m.dao.Create(ctx, k)
+}
+
+// Update ...
+func (m *manager) Update(ctx context.Context, k *model.TokenKey, props ...string) error {
+	return m.dao.Update(ctx, k, props...)
+}
+
+// Delete ...
+func (m *manager) Delete(ctx context.Context, id int64) error {
+	return m.dao.Delete(ctx, id)
+}
+
+// List ...
+func (m *manager) List(ctx context.Context, query *q.Query) ([]*model.TokenKey, error) {
+	return m.dao.List(ctx, query)
+}
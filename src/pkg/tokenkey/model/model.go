@@ -0,0 +1,52 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+func init() {
+	orm.RegisterModel(&TokenKey{})
+}
+
+const (
+	// StatusActive is the status of the key which signs the new tokens
+	StatusActive = "active"
+	// StatusRetiring is the status of the replaced key which still verifies the tokens until it expires
+	StatusRetiring = "retiring"
+)
+
+// TokenKey is the key pair signing the registry tokens, the private key is encrypted
+type TokenKey struct {
+	ID           int64     `orm:"pk;auto;column(id)" json:"id"`
+	KeyID        string    `orm:"column(key_id)" json:"key_id"`
+	PrivateKey   string    `orm:"column(private_key)" json:"-"`
+	Status       string    `orm:"column(status)" json:"status"`
+	ExpiresAt    time.Time `orm:"column(expires_at);null" json:"expires_at"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time" sort:"default:desc"`
+}
+
+// TableName ...
+func (t *TokenKey) TableName() string {
+	return "token_key"
+}
+
+// IsValid returns whether the key can verify the tokens, the retiring key is valid until it expires
+func (t *TokenKey) IsValid() bool {
+	return t.Status == StatusActive || time.Now().Before(t.ExpiresAt)
+}
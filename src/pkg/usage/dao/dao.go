@@ -0,0 +1,121 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg/usage/model"
+)
+
+// the expressions of the keys the usages can be grouped by
+var groupByExpressions = map[string]string{
+	model.GroupByDay:        "to_char(day, 'YYYY-MM-DD')",
+	model.GroupByRepository: "repository_name",
+	model.GroupByTag:        "tag",
+	model.GroupByPrincipal:  "principal",
+}
+
+// DAO is the data access object for the pull and push usages
+type DAO interface {
+	// Increase the pull and push counts of the daily rollup, the rollup is created if it doesn't exist
+	Increase(ctx context.Context, usage *model.Usage) error
+	// Summarize the usages selected by the filter grouped by the field, the summaries are sorted by the key
+	// for the days and by the pull count in descending order for the others, limit <= 0 means no limitation
+	Summarize(ctx context.Context, groupBy string, filter *model.Filter, limit int) ([]*model.Summary, error)
+}
+
+// New returns an instance of the default DAO
+func New() DAO {
+	return &dao{}
+}
+
+type dao struct{}
+
+// Increase ...
+func (d *dao) Increase(ctx context.Context, usage *model.Usage) error {
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	sql := `INSERT INTO artifact_usage (day, project_id, repository_name, tag, principal, pull_count, push_count)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (day, repository_name, tag, principal)
+		DO UPDATE SET pull_count = artifact_usage.pull_count + EXCLUDED.pull_count,
+			push_count = artifact_usage.push_count + EXCLUDED.push_count`
+	_, err = ormer.Raw(sql, usage.Day.Format("2006-01-02"), usage.ProjectID, usage.RepositoryName,
+		usage.Tag, usage.Principal, usage.PullCount, usage.PushCount).Exec()
+	return err
+}
+
+// Summarize ...
+func (d *dao) Summarize(ctx context.Context, groupBy string, filter *model.Filter, limit int) ([]*model.Summary, error) {
+	expr, ok := groupByExpressions[groupBy]
+	if !ok {
+		return nil, errors.BadRequestError(nil).WithMessage("unsupported group by field %s", groupBy)
+	}
+	// summarizing the usages tolerates the replication lag
+	ctx = orm.WithReplica(ctx)
+	ormer, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		conditions []string
+		params     []interface{}
+	)
+	if filter != nil {
+		if filter.ProjectID > 0 {
+			conditions = append(conditions, "project_id = ?")
+			params = append(params, filter.ProjectID)
+		}
+		if len(filter.RepositoryName) > 0 {
+			conditions = append(conditions, "repository_name = ?")
+			params = append(params, filter.RepositoryName)
+		}
+		if !filter.From.IsZero() {
+			conditions = append(conditions, "day >= ?")
+			params = append(params, filter.From.Format("2006-01-02"))
+		}
+		if !filter.To.IsZero() {
+			conditions = append(conditions, "day <= ?")
+			params = append(params, filter.To.Format("2006-01-02"))
+		}
+	}
+	sql := fmt.Sprintf("SELECT %s AS key, SUM(pull_count) AS pull_count, SUM(push_count) AS push_count FROM artifact_usage", expr)
+	if len(conditions) > 0 {
+		sql += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	sql += " GROUP BY key"
+	if groupBy == model.GroupByDay {
+		sql += " ORDER BY key"
+	} else {
+		sql += " ORDER BY pull_count DESC, key"
+	}
+	if limit > 0 {
+		sql += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	summaries := []*model.Summary{}
+	if _, err = ormer.Raw(sql, params...).QueryRows(&summaries); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
@@ -0,0 +1,225 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg/usage/dao"
+	"github.com/goharbor/harbor/src/pkg/usage/model"
+)
+
+const (
+	// the interval to flush the usages aggregated in memory into the database
+	defaultFlushInterval = 10 * time.Second
+	// the max count of the tags and principals in the statistics and reports
+	defaultTopN = 20
+)
+
+// Mgr is the global usage manager instance
+var Mgr = New()
+
+// Manager manages the pull and push usages of the repositories
+type Manager interface {
+	// Record the pull and push counts of the usage, the usages are aggregated in memory
+	// and flushed into the daily rollups in the background
+	Record(ctx context.Context, usage *model.Usage)
+	// Close stops the background flushing after flushing the aggregated usages
+	Close()
+	// GetRepositoryStats returns the usage statistics of the repository between the days
+	GetRepositoryStats(ctx context.Context, repositoryName string, from, to time.Time) (*model.RepositoryStats, error)
+	// GetProjectReport returns the usage report of the project between the days
+	GetProjectReport(ctx context.Context, projectID int64, from, to time.Time) (*model.ProjectReport, error)
+}
+
+// New returns a default implementation of Manager
+func New() Manager {
+	return &manager{
+		dao:      dao.New(),
+		interval: defaultFlushInterval,
+		pending:  map[key]*model.Usage{},
+		closing:  make(chan struct{}),
+		done:     make(chan struct{}),
+		ctx:      orm.Context,
+	}
+}
+
+type key struct {
+	day        string
+	repository string
+	tag        string
+	principal  string
+}
+
+type manager struct {
+	dao       dao.DAO
+	interval  time.Duration
+	lock      sync.Mutex
+	pending   map[key]*model.Usage
+	startOnce sync.Once
+	closeOnce sync.Once
+	running   bool
+	closing   chan struct{}
+	done      chan struct{}
+	// ctx returns the context with a new ormer to flush the usages
+	ctx func() context.Context
+}
+
+// Record ...
+func (m *manager) Record(_ context.Context, usage *model.Usage) {
+	day := usage.Day.UTC().Truncate(24 * time.Hour)
+	k := key{
+		day:        day.Format("2006-01-02"),
+		repository: usage.RepositoryName,
+		tag:        usage.Tag,
+		principal:  usage.Principal,
+	}
+	m.lock.Lock()
+	if u, ok := m.pending[k]; ok {
+		u.PullCount += usage.PullCount
+		u.PushCount += usage.PushCount
+	} else {
+		u := *usage
+		u.Day = day
+		m.pending[k] = &u
+	}
+	m.lock.Unlock()
+
+	select {
+	case <-m.closing:
+		// flush directly after closed
+		m.flush()
+		return
+	default:
+	}
+	m.startOnce.Do(func() {
+		m.running = true
+		go m.run()
+	})
+}
+
+// Close ...
+func (m *manager) Close() {
+	m.closeOnce.Do(func() {
+		close(m.closing)
+	})
+	m.startOnce.Do(func() {})
+	if m.running {
+		<-m.done
+	}
+}
+
+func (m *manager) run() {
+	defer close(m.done)
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.flush()
+		case <-m.closing:
+			m.flush()
+			log.Info("the aggregated usages are flushed")
+			return
+		}
+	}
+}
+
+func (m *manager) flush() {
+	m.lock.Lock()
+	pending := m.pending
+	m.pending = map[key]*model.Usage{}
+	m.lock.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+	ctx := m.ctx()
+	for _, u := range pending {
+		if err := m.dao.Increase(ctx, u); err != nil {
+			log.Errorf("failed to increase the usage of %s:%s by %s on %s: %v",
+				u.RepositoryName, u.Tag, u.Principal, u.Day.Format("2006-01-02"), err)
+		}
+	}
+}
+
+// GetRepositoryStats ...
+func (m *manager) GetRepositoryStats(ctx context.Context, repositoryName string, from, to time.Time) (*model.RepositoryStats, error) {
+	filter := &model.Filter{
+		RepositoryName: repositoryName,
+		From:           from,
+		To:             to,
+	}
+	daily, err := m.dao.Summarize(ctx, model.GroupByDay, filter, 0)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := m.dao.Summarize(ctx, model.GroupByTag, filter, defaultTopN)
+	if err != nil {
+		return nil, err
+	}
+	principals, err := m.dao.Summarize(ctx, model.GroupByPrincipal, filter, defaultTopN)
+	if err != nil {
+		return nil, err
+	}
+	stats := &model.RepositoryStats{
+		RepositoryName: repositoryName,
+		From:           from.Format("2006-01-02"),
+		To:             to.Format("2006-01-02"),
+		Daily:          daily,
+		Tags:           tags,
+		Principals:     principals,
+	}
+	stats.PullCount, stats.PushCount = total(daily)
+	return stats, nil
+}
+
+// GetProjectReport ...
+func (m *manager) GetProjectReport(ctx context.Context, projectID int64, from, to time.Time) (*model.ProjectReport, error) {
+	filter := &model.Filter{
+		ProjectID: projectID,
+		From:      from,
+		To:        to,
+	}
+	repositories, err := m.dao.Summarize(ctx, model.GroupByRepository, filter, 0)
+	if err != nil {
+		return nil, err
+	}
+	principals, err := m.dao.Summarize(ctx, model.GroupByPrincipal, filter, defaultTopN)
+	if err != nil {
+		return nil, err
+	}
+	report := &model.ProjectReport{
+		ProjectID:    projectID,
+		From:         from.Format("2006-01-02"),
+		To:           to.Format("2006-01-02"),
+		Repositories: repositories,
+		Principals:   principals,
+	}
+	report.PullCount, report.PushCount = total(repositories)
+	return report, nil
+}
+
+func total(summaries []*model.Summary) (pull, push int64) {
+	for _, s := range summaries {
+		pull += s.PullCount
+		push += s.PushCount
+	}
+	return pull, push
+}
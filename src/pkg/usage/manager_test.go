@@ -0,0 +1,119 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/pkg/usage/model"
+	"github.com/goharbor/harbor/src/testing/mock"
+	"github.com/goharbor/harbor/src/testing/pkg/usage/dao"
+)
+
+type managerTestSuite struct {
+	suite.Suite
+	dao *dao.DAO
+	mgr *manager
+}
+
+func (m *managerTestSuite) SetupTest() {
+	m.dao = &dao.DAO{}
+	m.mgr = &manager{
+		dao:      m.dao,
+		interval: time.Hour,
+		pending:  map[key]*model.Usage{},
+		closing:  make(chan struct{}),
+		done:     make(chan struct{}),
+		ctx:      context.Background,
+	}
+}
+
+func (m *managerTestSuite) TestRecord() {
+	var increased []*model.Usage
+	mock.OnAnything(m.dao, "Increase").Run(func(args mock.Arguments) {
+		increased = append(increased, args.Get(1).(*model.Usage))
+	}).Return(nil)
+
+	now := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+	m.mgr.Record(ctx, &model.Usage{Day: now, RepositoryName: "library/hello-world", Tag: "latest", Principal: "admin", PullCount: 1})
+	m.mgr.Record(ctx, &model.Usage{Day: now.Add(time.Hour), RepositoryName: "library/hello-world", Tag: "latest", Principal: "admin", PullCount: 1})
+	m.mgr.Record(ctx, &model.Usage{Day: now, RepositoryName: "library/hello-world", Tag: "latest", Principal: "admin", PushCount: 1})
+	m.mgr.Record(ctx, &model.Usage{Day: now, RepositoryName: "library/hello-world", Tag: "latest", Principal: "robot$ci", PullCount: 1})
+	// flushed when closing
+	m.mgr.Close()
+
+	m.Require().Len(increased, 2)
+	for _, u := range increased {
+		m.Equal(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), u.Day)
+		if u.Principal == "admin" {
+			m.Equal(int64(2), u.PullCount)
+			m.Equal(int64(1), u.PushCount)
+		} else {
+			m.Equal(int64(1), u.PullCount)
+			m.Equal(int64(0), u.PushCount)
+		}
+	}
+}
+
+func (m *managerTestSuite) TestGetRepositoryStats() {
+	from := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)
+	m.dao.On("Summarize", mock.Anything, model.GroupByDay, mock.Anything, 0).Return([]*model.Summary{
+		{Key: "2023-06-01", PullCount: 3, PushCount: 1},
+		{Key: "2023-06-02", PullCount: 2},
+	}, nil)
+	m.dao.On("Summarize", mock.Anything, model.GroupByTag, mock.Anything, defaultTopN).Return([]*model.Summary{
+		{Key: "latest", PullCount: 5, PushCount: 1},
+	}, nil)
+	m.dao.On("Summarize", mock.Anything, model.GroupByPrincipal, mock.Anything, defaultTopN).Return([]*model.Summary{
+		{Key: "admin", PullCount: 5, PushCount: 1},
+	}, nil)
+
+	stats, err := m.mgr.GetRepositoryStats(context.Background(), "library/hello-world", from, to)
+	m.Require().Nil(err)
+	m.Equal("2023-06-01", stats.From)
+	m.Equal("2023-06-02", stats.To)
+	m.Equal(int64(5), stats.PullCount)
+	m.Equal(int64(1), stats.PushCount)
+	m.Len(stats.Daily, 2)
+	m.Len(stats.Tags, 1)
+	m.Len(stats.Principals, 1)
+	filter := m.dao.Calls[0].Arguments.Get(2).(*model.Filter)
+	m.Equal("library/hello-world", filter.RepositoryName)
+}
+
+func (m *managerTestSuite) TestGetProjectReport() {
+	m.dao.On("Summarize", mock.Anything, model.GroupByRepository, mock.Anything, 0).Return([]*model.Summary{
+		{Key: "library/hello-world", PullCount: 3, PushCount: 1},
+		{Key: "library/busybox", PullCount: 2, PushCount: 2},
+	}, nil)
+	m.dao.On("Summarize", mock.Anything, model.GroupByPrincipal, mock.Anything, defaultTopN).Return([]*model.Summary{}, nil)
+
+	report, err := m.mgr.GetProjectReport(context.Background(), 1, time.Now(), time.Now())
+	m.Require().Nil(err)
+	m.Equal(int64(1), report.ProjectID)
+	m.Equal(int64(5), report.PullCount)
+	m.Equal(int64(3), report.PushCount)
+	m.Len(report.Repositories, 2)
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, &managerTestSuite{})
+}
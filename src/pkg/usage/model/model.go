@@ -0,0 +1,90 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/beego/beego/v2/client/orm"
+)
+
+func init() {
+	orm.RegisterModel(&Usage{})
+}
+
+// the fields the usages can be summarized by
+const (
+	GroupByDay        = "day"
+	GroupByRepository = "repository_name"
+	GroupByTag        = "tag"
+	GroupByPrincipal  = "principal"
+)
+
+// Usage is the daily rollup of the pull and push counts of the repository, tag and principal
+type Usage struct {
+	ID             int64     `orm:"pk;auto;column(id)" json:"id"`
+	Day            time.Time `orm:"column(day);type(date)" json:"day"`
+	ProjectID      int64     `orm:"column(project_id)" json:"project_id"`
+	RepositoryName string    `orm:"column(repository_name)" json:"repository_name"`
+	// the tag is empty when the artifact is pulled or pushed by digest
+	Tag       string `orm:"column(tag)" json:"tag"`
+	Principal string `orm:"column(principal)" json:"principal"`
+	PullCount int64  `orm:"column(pull_count)" json:"pull_count"`
+	PushCount int64  `orm:"column(push_count)" json:"push_count"`
+}
+
+// TableName for usage
+func (u *Usage) TableName() string {
+	return "artifact_usage"
+}
+
+// Filter selects the usages to summarize, the zero values are ignored
+type Filter struct {
+	ProjectID      int64
+	RepositoryName string
+	// the days are inclusive
+	From time.Time
+	To   time.Time
+}
+
+// Summary is the total pull and push counts of the usages grouped by the key
+type Summary struct {
+	Key       string `orm:"column(key)" json:"key"`
+	PullCount int64  `orm:"column(pull_count)" json:"pull_count"`
+	PushCount int64  `orm:"column(push_count)" json:"push_count"`
+}
+
+// RepositoryStats is the usage statistics of the repository
+type RepositoryStats struct {
+	RepositoryName string     `json:"repository_name"`
+	From           string     `json:"from"`
+	To             string     `json:"to"`
+	PullCount      int64      `json:"pull_count"`
+	PushCount      int64      `json:"push_count"`
+	Daily          []*Summary `json:"daily"`
+	Tags           []*Summary `json:"tags"`
+	Principals     []*Summary `json:"principals"`
+}
+
+// ProjectReport is the usage report of the project
+type ProjectReport struct {
+	ProjectID    int64      `json:"project_id"`
+	From         string     `json:"from"`
+	To           string     `json:"to"`
+	PullCount    int64      `json:"pull_count"`
+	PushCount    int64      `json:"push_count"`
+	Repositories []*Summary `json:"repositories"`
+	Principals   []*Summary `json:"principals"`
+}
@@ -55,7 +55,7 @@ func (d *dao) Delete(ctx context.Context, userID int) error {
 	if err != nil {
 		return err
 	}
-	_, err = ormer.Delete(&User{UserID: userID})
+	_, err = ormer.DeleteWithCtx(ctx, &User{UserID: userID})
 	return err
 }
 
@@ -66,7 +66,7 @@ func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
 }
 
 func (d *dao) Create(ctx context.Context, user *commonmodels.User) (int, error) {
@@ -77,7 +77,7 @@ func (d *dao) Create(ctx context.Context, user *commonmodels.User) (int, error)
 	if err != nil {
 		return 0, err
 	}
-	id, err := ormer.Insert(toDBUser(user))
+	id, err := ormer.InsertWithCtx(ctx, toDBUser(user))
 	if err != nil {
 		return 0, orm.WrapConflictError(err, "user %s or email %s already exists", user.Username, user.Email)
 	}
@@ -89,7 +89,7 @@ func (d *dao) Update(ctx context.Context, user *commonmodels.User, props ...stri
 	if err != nil {
 		return err
 	}
-	n, err := ormer.Update(toDBUser(user), props...)
+	n, err := ormer.UpdateWithCtx(ctx, toDBUser(user), props...)
 	if err != nil {
 		return err
 	}
@@ -110,7 +110,10 @@ func (d *dao) List(ctx context.Context, query *q.Query) ([]*commonmodels.User, e
 	}
 
 	var users []*User
-	if _, err := qs.All(&users); err != nil {
+	if _, err := qs.AllWithCtx(ctx, &users); err != nil {
+		return nil, err
+	}
+	if err := orm.SetNextCursor(query, users); err != nil {
 		return nil, err
 	}
 
@@ -38,6 +38,8 @@ type User struct {
 	Comment         string         `orm:"column(comment)" json:"comment"`
 	Deleted         bool           `orm:"column(deleted)" json:"deleted"`
 	SysAdminFlag    bool           `orm:"column(sysadmin_flag)" json:"sysadmin_flag"`
+	AuditorFlag     bool           `orm:"column(auditor_flag)" json:"auditor_flag"`
+	Deactivated     bool           `orm:"column(deactivated)" json:"deactivated"`
 	ResetUUID       string         `orm:"column(reset_uuid)" json:"reset_uuid"`
 	Salt            string         `orm:"column(salt)" json:"-"`
 	CreationTime    time.Time      `orm:"column(creation_time);auto_now_add" json:"creation_time"`
@@ -65,6 +67,8 @@ func toDBUser(u *commonmodels.User) *User {
 	user.Comment = u.Comment
 	user.Deleted = u.Deleted
 	user.SysAdminFlag = u.SysAdminFlag
+	user.AuditorFlag = u.AuditorFlag
+	user.Deactivated = u.Deactivated
 	user.ResetUUID = u.ResetUUID
 	user.Salt = u.Salt
 	user.CreationTime = u.CreationTime
@@ -85,6 +89,8 @@ func toCommonUser(u *User) *commonmodels.User {
 	user.Comment = u.Comment
 	user.Deleted = u.Deleted
 	user.SysAdminFlag = u.SysAdminFlag
+	user.AuditorFlag = u.AuditorFlag
+	user.Deactivated = u.Deactivated
 	user.ResetUUID = u.ResetUUID
 	user.Salt = u.Salt
 	user.CreationTime = u.CreationTime
@@ -52,6 +52,10 @@ type Manager interface {
 	DeleteGDPR(ctx context.Context, id int) error
 	// SetSysAdminFlag sets the system admin flag of the user in local DB
 	SetSysAdminFlag(ctx context.Context, id int, admin bool) error
+	// SetAuditorFlag sets the auditor flag of the user in local DB
+	SetAuditorFlag(ctx context.Context, id int, auditor bool) error
+	// SetDeactivated sets the deactivated flag of the user in local DB
+	SetDeactivated(ctx context.Context, id int, deactivated bool) error
 	// UpdateProfile updates the user's profile
 	UpdateProfile(ctx context.Context, user *commonmodels.User, col ...string) error
 	// UpdatePassword updates user's password
@@ -79,6 +83,7 @@ func (m *manager) Onboard(ctx context.Context, user *commonmodels.User) error {
 	if err == nil {
 		user.Email = u.Email
 		user.SysAdminFlag = u.SysAdminFlag
+		user.Deactivated = u.Deactivated
 		user.Realname = u.Realname
 		user.UserID = u.UserID
 		return nil
@@ -163,6 +168,22 @@ func (m *manager) SetSysAdminFlag(ctx context.Context, id int, admin bool) error
 	return m.dao.Update(ctx, u, "sysadmin_flag")
 }
 
+func (m *manager) SetAuditorFlag(ctx context.Context, id int, auditor bool) error {
+	u := &commonmodels.User{
+		UserID:      id,
+		AuditorFlag: auditor,
+	}
+	return m.dao.Update(ctx, u, "auditor_flag")
+}
+
+func (m *manager) SetDeactivated(ctx context.Context, id int, deactivated bool) error {
+	u := &commonmodels.User{
+		UserID:      id,
+		Deactivated: deactivated,
+	}
+	return m.dao.Update(ctx, u, "deactivated")
+}
+
 func (m *manager) Create(ctx context.Context, user *commonmodels.User) (int, error) {
 	injectPasswd(user, user.Password)
 	return m.dao.Create(ctx, user)
@@ -49,6 +49,14 @@ type DAO interface {
 	UpdateName(ctx context.Context, id int, groupName string) error
 	// ReadOrCreate create a user group or read existing one from db
 	ReadOrCreate(ctx context.Context, g *model.UserGroup, keyAttribute string, combinedKeyAttributes ...string) (bool, int64, error)
+	// AddMember adds the user into the user group, it does nothing if the user is already a member of the group
+	AddMember(ctx context.Context, groupID, userID int) error
+	// RemoveMember removes the user from the user group
+	RemoveMember(ctx context.Context, groupID, userID int) error
+	// ListMemberIDs lists the IDs of the users who are the members of the user group
+	ListMemberIDs(ctx context.Context, groupID int) ([]int, error)
+	// ListGroupIDsByUser lists the IDs of the user groups which the user is a member of
+	ListGroupIDsByUser(ctx context.Context, userID int) ([]int, error)
 }
 
 type dao struct {
@@ -96,7 +104,7 @@ func (d *dao) Query(ctx context.Context, query *q.Query) ([]*model.UserGroup, er
 		return nil, err
 	}
 	var usergroups []*model.UserGroup
-	if _, err := qs.All(&usergroups); err != nil {
+	if _, err := qs.AllWithCtx(ctx, &usergroups); err != nil {
 		return nil, err
 	}
 	return usergroups, nil
@@ -121,7 +129,7 @@ func (d *dao) Delete(ctx context.Context, id int) error {
 	if err != nil {
 		return err
 	}
-	_, err = o.Delete(&userGroup)
+	_, err = o.DeleteWithCtx(ctx, &userGroup)
 	if err == nil {
 		// Delete all related project members
 		sql := `delete from project_member where entity_id = ? and entity_type='g'`
@@ -151,7 +159,7 @@ func (d *dao) ReadOrCreate(ctx context.Context, g *model.UserGroup, keyAttribute
 	if err != nil {
 		return false, 0, err
 	}
-	return o.ReadOrCreate(g, keyAttribute, combinedKeyAttributes...)
+	return o.ReadOrCreateWithCtx(ctx, g, keyAttribute, combinedKeyAttributes...)
 }
 
 func (d *dao) onBoardCommonUserGroup(ctx context.Context, g *model.UserGroup, keyAttribute string, combinedKeyAttributes ...string) error {
@@ -183,5 +191,57 @@ func (d *dao) Count(ctx context.Context, query *q.Query) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return qs.Count()
+	return qs.CountWithCtx(ctx)
+}
+
+// AddMember ...
+func (d *dao) AddMember(ctx context.Context, groupID, userID int) error {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	sql := `insert into user_group_member (group_id, user_id, creation_time) values (?, ?, ?) on conflict (group_id, user_id) do nothing`
+	_, err = o.Raw(sql, groupID, userID, time.Now()).Exec()
+	return err
+}
+
+// RemoveMember ...
+func (d *dao) RemoveMember(ctx context.Context, groupID, userID int) error {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	sql := `delete from user_group_member where group_id = ? and user_id = ?`
+	_, err = o.Raw(sql, groupID, userID).Exec()
+	return err
+}
+
+// ListMemberIDs ...
+func (d *dao) ListMemberIDs(ctx context.Context, groupID int) ([]int, error) {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := []int{}
+	// the deleted users are excluded
+	sql := `select m.user_id from user_group_member m join harbor_user u on m.user_id = u.user_id
+		where m.group_id = ? and u.deleted = false order by m.user_id`
+	if _, err = o.Raw(sql, groupID).QueryRows(&ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ListGroupIDsByUser ...
+func (d *dao) ListGroupIDsByUser(ctx context.Context, userID int) ([]int, error) {
+	o, err := orm.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := []int{}
+	sql := `select group_id from user_group_member where user_id = ? order by group_id`
+	if _, err = o.Raw(sql, userID).QueryRows(&ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
 }
@@ -51,6 +51,14 @@ type Manager interface {
 	UpdateName(ctx context.Context, id int, groupName string) error
 	// Onboard sync the user group from external auth server to Harbor
 	Onboard(ctx context.Context, g *model.UserGroup) error
+	// AddMember adds the user into the user group
+	AddMember(ctx context.Context, groupID, userID int) error
+	// RemoveMember removes the user from the user group
+	RemoveMember(ctx context.Context, groupID, userID int) error
+	// ListMemberIDs lists the IDs of the users who are the members of the user group
+	ListMemberIDs(ctx context.Context, groupID int) ([]int, error)
+	// ListGroupIDsByUser lists the IDs of the user groups which the user is a member of
+	ListGroupIDsByUser(ctx context.Context, userID int) ([]int, error)
 }
 
 type manager struct {
@@ -159,3 +167,19 @@ func (m *manager) onBoardCommonUserGroup(ctx context.Context, g *model.UserGroup
 func (m *manager) Count(ctx context.Context, query *q.Query) (int64, error) {
 	return m.dao.Count(ctx, query)
 }
+
+func (m *manager) AddMember(ctx context.Context, groupID, userID int) error {
+	return m.dao.AddMember(ctx, groupID, userID)
+}
+
+func (m *manager) RemoveMember(ctx context.Context, groupID, userID int) error {
+	return m.dao.RemoveMember(ctx, groupID, userID)
+}
+
+func (m *manager) ListMemberIDs(ctx context.Context, groupID int) ([]int, error) {
+	return m.dao.ListMemberIDs(ctx, groupID)
+}
+
+func (m *manager) ListGroupIDsByUser(ctx context.Context, userID int) ([]int, error) {
+	return m.dao.ListGroupIDsByUser(ctx, userID)
+}
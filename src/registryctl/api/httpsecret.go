@@ -0,0 +1,76 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/registryctl/storage"
+)
+
+// HTTPSecretRequest is the request to update the HTTP secret of the registry
+type HTTPSecretRequest struct {
+	Secret string `json:"secret"`
+}
+
+// NewHTTPSecretHandler returns the handler to update the HTTP secret of the registry and reload the registry
+func NewHTTPSecretHandler(registryConfig, reloadCommand string) http.Handler {
+	return &httpSecretHandler{
+		registryConfig: registryConfig,
+		reloadCommand:  reloadCommand,
+	}
+}
+
+type httpSecretHandler struct {
+	registryConfig string
+	reloadCommand  string
+}
+
+// ServeHTTP ...
+func (h *httpSecretHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		h.update(w, r)
+	default:
+		HandleNotMethodAllowed(w)
+	}
+}
+
+func (h *httpSecretHandler) update(w http.ResponseWriter, r *http.Request) {
+	req := &HTTPSecretRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		HandleBadRequest(w, err)
+		return
+	}
+
+	configLock.Lock()
+	defer configLock.Unlock()
+	if err := storage.SaveHTTPSecret(h.registryConfig, req.Secret); err != nil {
+		HandleError(w, err)
+		return
+	}
+	log.Info("the HTTP secret of the registry is updated")
+
+	reloaded, err := storage.Reload(r.Context(), h.reloadCommand)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	if err = WriteJSON(w, &storage.UpdateResult{Reloaded: reloaded}); err != nil {
+		log.Errorf("Failed to write response: %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/registryctl/storage"
+)
+
+// configLock serializes the updates of the configuration file of the registry
+var configLock sync.Mutex
+
+// NewStorageHandler returns the handler to view, validate and update the storage configuration of the registry
+func NewStorageHandler(registryConfig, reloadCommand string, driver *storage.Driver) http.Handler {
+	return &storageHandler{
+		registryConfig: registryConfig,
+		reloadCommand:  reloadCommand,
+		driver:         driver,
+	}
+}
+
+type storageHandler struct {
+	registryConfig string
+	reloadCommand  string
+	driver         *storage.Driver
+}
+
+// ServeHTTP ...
+func (s *storageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.get(w, r)
+	case http.MethodPost:
+		s.validate(w, r)
+	case http.MethodPut:
+		s.update(w, r)
+	default:
+		HandleNotMethodAllowed(w)
+	}
+}
+
+func (s *storageHandler) get(w http.ResponseWriter, r *http.Request) {
+	cfg, err := storage.Load(s.registryConfig)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	if err = WriteJSON(w, cfg.Redact()); err != nil {
+		log.Errorf("Failed to write response: %v", err)
+	}
+}
+
+func (s *storageHandler) validate(w http.ResponseWriter, r *http.Request) {
+	cfg, err := s.decode(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	if _, err = storage.Validate(r.Context(), cfg); err != nil {
+		HandleError(w, err)
+		return
+	}
+}
+
+func (s *storageHandler) update(w http.ResponseWriter, r *http.Request) {
+	configLock.Lock()
+	defer configLock.Unlock()
+
+	cfg, err := s.decode(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	// the configuration is applied only when the storage passes the validation
+	driver, err := storage.Validate(r.Context(), cfg)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	if err = storage.Save(s.registryConfig, cfg); err != nil {
+		HandleInternalServerError(w, err)
+		return
+	}
+	s.driver.Swap(driver)
+	log.Infof("the storage of the registry is updated to %s", cfg.Type)
+
+	reloaded, err := storage.Reload(r.Context(), s.reloadCommand)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	if err = WriteJSON(w, &storage.UpdateResult{Reloaded: reloaded}); err != nil {
+		log.Errorf("Failed to write response: %v", err)
+	}
+}
+
+// decode the configuration in the request and restore the redacted parameters from the current configuration
+func (s *storageHandler) decode(r *http.Request) (*storage.Config, error) {
+	cfg := &storage.Config{}
+	if err := json.NewDecoder(r.Body).Decode(cfg); err != nil {
+		return nil, errors.BadRequestError(err).WithMessage("invalid storage configuration: %v", err)
+	}
+	if cfg.Parameters == nil {
+		cfg.Parameters = map[string]interface{}{}
+	}
+	current, err := storage.Load(s.registryConfig)
+	if err != nil && !errors.IsNotFoundErr(err) {
+		return nil, err
+	}
+	if err = cfg.Restore(current); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
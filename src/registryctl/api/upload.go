@@ -0,0 +1,77 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/registryctl/storage"
+)
+
+// PurgeUploadsRequest is the request to purge the stale uploads
+type PurgeUploadsRequest struct {
+	// OlderThanHours specifies the uploads started more than the hours ago are purged
+	OlderThanHours int  `json:"older_than_hours"`
+	DryRun         bool `json:"dry_run"`
+}
+
+// NewUploadHandler returns the handler to purge the stale uploads from the storage of the registry
+func NewUploadHandler(driver *storage.Driver) http.Handler {
+	return &uploadHandler{
+		driver: driver,
+	}
+}
+
+type uploadHandler struct {
+	driver *storage.Driver
+}
+
+// ServeHTTP ...
+func (u *uploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		u.purge(w, r)
+	default:
+		HandleNotMethodAllowed(w)
+	}
+}
+
+func (u *uploadHandler) purge(w http.ResponseWriter, r *http.Request) {
+	req := &PurgeUploadsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		HandleBadRequest(w, err)
+		return
+	}
+	// the uploads in progress must not be purged
+	if req.OlderThanHours < 1 {
+		HandleError(w, errors.BadRequestError(nil).WithMessage("the older_than_hours should be at least 1"))
+		return
+	}
+	olderThan := time.Now().Add(-time.Duration(req.OlderThanHours) * time.Hour)
+	result, err := storage.PurgeUploads(r.Context(), u.driver, olderThan, req.DryRun)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	log.Infof("purged %d uploads older than %d hours, reclaimed %d bytes, dry run: %v",
+		len(result.Purged), req.OlderThanHours, result.ReclaimedBytes, req.DryRun)
+	if err = WriteJSON(w, result); err != nil {
+		log.Errorf("Failed to write response: %v", err)
+	}
+}
@@ -15,6 +15,8 @@
 package client
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -25,6 +27,7 @@ import (
 	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/pkg/registry/interceptor"
+	"github.com/goharbor/harbor/src/registryctl/storage"
 )
 
 // const definition
@@ -40,6 +43,16 @@ type Client interface {
 	DeleteBlob(reference string) (err error)
 	// DeleteManifest deletes the specified manifest. The "reference" can be "tag" or "digest"
 	DeleteManifest(repository, reference string) (err error)
+	// GetStorage returns the storage configuration of registry with the sensitive parameters redacted
+	GetStorage() (cfg *storage.Config, err error)
+	// ValidateStorage checks the registry can write, read and delete with the storage configuration
+	ValidateStorage(cfg *storage.Config) (err error)
+	// UpdateStorage validates and applies the storage configuration and reloads the registry
+	UpdateStorage(cfg *storage.Config) (result *storage.UpdateResult, err error)
+	// PurgeUploads purges the uploads started more than the specified hours ago, which are left by the abandoned pushes
+	PurgeUploads(olderThanHours int, dryRun bool) (result *storage.PurgeUploadsResult, err error)
+	// UpdateHTTPSecret updates the HTTP secret which signs the upload states and reloads the registry
+	UpdateHTTPSecret(secret string) (result *storage.UpdateResult, err error)
 }
 
 type client struct {
@@ -107,6 +120,117 @@ func (c *client) DeleteManifest(repository, reference string) (err error) {
 	return nil
 }
 
+// GetStorage ...
+func (c *client) GetStorage() (*storage.Config, error) {
+	req, err := http.NewRequest(http.MethodGet, buildStorageURL(c.baseURL), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	cfg := &storage.Config{}
+	if err = json.NewDecoder(resp.Body).Decode(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// ValidateStorage ...
+func (c *client) ValidateStorage(cfg *storage.Config) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, buildStorageURL(c.baseURL)+"/validate", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
+
+// UpdateStorage ...
+func (c *client) UpdateStorage(cfg *storage.Config) (*storage.UpdateResult, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPut, buildStorageURL(c.baseURL), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	result := &storage.UpdateResult{}
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PurgeUploads ...
+func (c *client) PurgeUploads(olderThanHours int, dryRun bool) (*storage.PurgeUploadsResult, error) {
+	data, err := json.Marshal(map[string]interface{}{
+		"older_than_hours": olderThanHours,
+		"dry_run":          dryRun,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, buildUploadPurgeURL(c.baseURL), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	result := &storage.PurgeUploadsResult{}
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateHTTPSecret ...
+func (c *client) UpdateHTTPSecret(secret string) (*storage.UpdateResult, error) {
+	data, err := json.Marshal(map[string]string{
+		"secret": secret,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPut, buildHTTPSecretURL(c.baseURL), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	result := &storage.UpdateResult{}
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *client) do(req *http.Request) (*http.Response, error) {
 	for _, interceptor := range c.interceptors {
 		if err := interceptor.Intercept(req); err != nil {
@@ -127,6 +251,8 @@ func (c *client) do(req *http.Request) (*http.Response, error) {
 		message := fmt.Sprintf("http status code: %d, body: %s", resp.StatusCode, string(body))
 		code := errors.GeneralCode
 		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			code = errors.BadRequestCode
 		case http.StatusUnauthorized:
 			code = errors.UnAuthorizedCode
 		case http.StatusForbidden:
@@ -147,3 +273,15 @@ func buildManifestURL(endpoint, repository, reference string) string {
 func buildBlobURL(endpoint, reference string) string {
 	return fmt.Sprintf("%s/api/registry/blob/%s", endpoint, reference)
 }
+
+func buildStorageURL(endpoint string) string {
+	return fmt.Sprintf("%s/api/registry/storage", endpoint)
+}
+
+func buildUploadPurgeURL(endpoint string) string {
+	return fmt.Sprintf("%s/api/registry/uploads/purge", endpoint)
+}
+
+func buildHTTPSecretURL(endpoint string) string {
+	return fmt.Sprintf("%s/api/registry/httpsecret", endpoint)
+}
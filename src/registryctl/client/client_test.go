@@ -74,6 +74,41 @@ func (c *clientTestSuite) TestDeleteBlob() {
 	c.Require().Nil(err)
 }
 
+func (c *clientTestSuite) TestPurgeUploads() {
+	server := test.NewServer(
+		&test.RequestHandlerMapping{
+			Method:  "POST",
+			Pattern: "/api/registry/uploads/purge",
+			Handler: test.Handler(&test.Response{
+				StatusCode: http.StatusOK,
+				Body:       []byte(`{"purged":["/docker/registry/v2/repositories/library/hello-world/_uploads/1"],"reclaimed_bytes":1024}`),
+			}),
+		})
+	defer server.Close()
+
+	result, err := NewClient(server.URL, &Config{}).PurgeUploads(168, true)
+	c.Require().Nil(err)
+	c.Len(result.Purged, 1)
+	c.Equal(int64(1024), result.ReclaimedBytes)
+}
+
+func (c *clientTestSuite) TestUpdateHTTPSecret() {
+	server := test.NewServer(
+		&test.RequestHandlerMapping{
+			Method:  "PUT",
+			Pattern: "/api/registry/httpsecret",
+			Handler: test.Handler(&test.Response{
+				StatusCode: http.StatusOK,
+				Body:       []byte(`{"reloaded":true}`),
+			}),
+		})
+	defer server.Close()
+
+	result, err := NewClient(server.URL, &Config{}).UpdateHTTPSecret("0123456789abcdef")
+	c.Require().Nil(err)
+	c.True(result.Reloaded)
+}
+
 func TestClientTestSuite(t *testing.T) {
 	suite.Run(t, &clientTestSuite{})
 }
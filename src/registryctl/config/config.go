@@ -19,11 +19,11 @@ import (
 	"os"
 
 	"github.com/docker/distribution/configuration"
-	storagedriver "github.com/docker/distribution/registry/storage/driver"
 	"github.com/docker/distribution/registry/storage/driver/factory"
 	yaml "gopkg.in/yaml.v2"
 
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/registryctl/storage"
 )
 
 // DefaultConfig ...
@@ -38,8 +38,26 @@ type Configuration struct {
 		Cert string `yaml:"cert"`
 		Key  string `yaml:"key"`
 	} `yaml:"https_config,omitempty"`
-	RegistryConfig string                      `yaml:"registry_config"`
-	StorageDriver  storagedriver.StorageDriver `yaml:"-"`
+	RegistryConfig string `yaml:"registry_config"`
+	// the command to reload the registry after the storage configuration is updated, e.g. restarting the
+	// registry service, the registry must be restarted manually if it isn't specified
+	RegistryReloadCommand string `yaml:"registry_reload_command"`
+	// the storage driver is swapped when the storage configuration is updated
+	StorageDriver *storage.Driver `yaml:"-"`
+	// Metric configurations
+	Metric *MetricConfig `yaml:"metric,omitempty"`
+}
+
+// MetricConfig used for configure metrics
+type MetricConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Path    string `yaml:"path"`
+	Port    int    `yaml:"port"`
+}
+
+// MetricEnabled returns whether the metrics are enabled
+func (c *Configuration) MetricEnabled() bool {
+	return c.Metric != nil && c.Metric.Enabled
 }
 
 // Load the configuration options from the specified yaml file.
@@ -82,7 +100,7 @@ func (c *Configuration) setStorageDriver() error {
 	if err != nil {
 		return err
 	}
-	c.StorageDriver = storageDriver
+	c.StorageDriver = storage.NewDriver(storageDriver)
 	return nil
 }
 
@@ -135,4 +153,9 @@ func (c *Configuration) loadEnvs() {
 	if len(registryConf) != 0 {
 		c.RegistryConfig = registryConf
 	}
+
+	reloadCommand := os.Getenv("REGISTRY_RELOAD_COMMAND")
+	if len(reloadCommand) != 0 {
+		c.RegistryReloadCommand = reloadCommand
+	}
 }
@@ -50,6 +50,9 @@ func TestConfigLoadingWithYml(t *testing.T) {
 	assert.Equal(t, "ERROR", cfg.LogLevel)
 	assert.Equal(t, "../reg_conf_test.yml", cfg.RegistryConfig)
 	assert.True(t, cfg.StorageDriver.Name() == "filesystem")
+	assert.True(t, cfg.MetricEnabled())
+	assert.Equal(t, "/metrics", cfg.Metric.Path)
+	assert.Equal(t, 9090, cfg.Metric.Port)
 }
 
 func TestGetLogLevel(t *testing.T) {
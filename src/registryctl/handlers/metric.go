@@ -0,0 +1,50 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/metric"
+)
+
+// instrumentHandler collects the metrics of the requests, the operation is the path template of the matched route
+func instrumentHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		metric.TotalInFlightGauge.Inc()
+		defer metric.TotalInFlightGauge.Dec()
+		op := "unknown"
+		if route := mux.CurrentRoute(r); route != nil {
+			if tpl, err := route.GetPathTemplate(); err == nil {
+				op = tpl
+			}
+		}
+		now, rc := time.Now(), lib.NewResponseRecorder(w)
+		next.ServeHTTP(rc, r)
+		duration := time.Since(now).Seconds()
+		metric.TotalReqDurSummary.WithLabelValues(r.Method, op).Observe(duration)
+		metric.ReqDurHistogram.WithLabelValues(r.Method, op).Observe(duration)
+		metric.TotalReqCnt.WithLabelValues(r.Method, strconv.Itoa(rc.StatusCode), op).Inc()
+		if r.ContentLength > 0 {
+			metric.ReqSizeHistogram.WithLabelValues(r.Method, op).Observe(float64(r.ContentLength))
+		}
+		metric.RespSizeHistogram.WithLabelValues(r.Method, op).Observe(float64(rc.Size))
+	})
+}
@@ -29,9 +29,18 @@ func newRouter(conf config.Configuration) http.Handler {
 	// create the root rooter
 	rootRouter := mux.NewRouter()
 	rootRouter.StrictSlash(true)
+	if conf.MetricEnabled() {
+		rootRouter.Use(instrumentHandler)
+	}
 	rootRouter.HandleFunc("/api/health", api.Health).Methods("GET")
 
 	rootRouter.Path("/api/registry/blob/{reference}").Methods(http.MethodDelete).Handler(blob.NewHandler(conf.StorageDriver))
 	rootRouter.Path("/api/registry/{name:.*}/manifests/{reference}").Methods(http.MethodDelete).Handler(manifest.NewHandler(conf.StorageDriver))
+
+	storageHandler := api.NewStorageHandler(conf.RegistryConfig, conf.RegistryReloadCommand, conf.StorageDriver)
+	rootRouter.Path("/api/registry/storage").Methods(http.MethodGet, http.MethodPut).Handler(storageHandler)
+	rootRouter.Path("/api/registry/storage/validate").Methods(http.MethodPost).Handler(storageHandler)
+	rootRouter.Path("/api/registry/httpsecret").Methods(http.MethodPut).Handler(api.NewHTTPSecretHandler(conf.RegistryConfig, conf.RegistryReloadCommand))
+	rootRouter.Path("/api/registry/uploads/purge").Methods(http.MethodPost).Handler(api.NewUploadHandler(conf.StorageDriver))
 	return rootRouter
 }
@@ -37,6 +37,7 @@ import (
 	common_http "github.com/goharbor/harbor/src/common/http"
 	cfgLib "github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/metric"
 	tracelib "github.com/goharbor/harbor/src/lib/trace"
 	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
 	"github.com/goharbor/harbor/src/registryctl/config"
@@ -101,6 +102,11 @@ func main() {
 
 	cfgLib.InitTraceConfig(context.Background())
 
+	if config.DefaultConfig.MetricEnabled() {
+		metric.RegisterHTTPCollectors()
+		go metric.ServeProm(config.DefaultConfig.Metric.Path, config.DefaultConfig.Metric.Port)
+	}
+
 	regCtl := &RegistryCtl{
 		ServerConf: *config.DefaultConfig,
 		Handler:    handlers.NewHandlerChain(*config.DefaultConfig),
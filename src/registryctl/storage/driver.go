@@ -0,0 +1,105 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	storagedriver "github.com/docker/distribution/registry/storage/driver"
+)
+
+var _ storagedriver.StorageDriver = &Driver{}
+
+// Driver is the storage driver which can be swapped when the storage configuration is updated
+// without restarting the registry controller
+type Driver struct {
+	lock   sync.RWMutex
+	driver storagedriver.StorageDriver
+}
+
+// NewDriver wraps the storage driver
+func NewDriver(driver storagedriver.StorageDriver) *Driver {
+	return &Driver{driver: driver}
+}
+
+// Swap replaces the underlying storage driver
+func (d *Driver) Swap(driver storagedriver.StorageDriver) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.driver = driver
+}
+
+func (d *Driver) get() storagedriver.StorageDriver {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.driver
+}
+
+// Name ...
+func (d *Driver) Name() string {
+	return d.get().Name()
+}
+
+// GetContent ...
+func (d *Driver) GetContent(ctx context.Context, path string) ([]byte, error) {
+	return d.get().GetContent(ctx, path)
+}
+
+// PutContent ...
+func (d *Driver) PutContent(ctx context.Context, path string, content []byte) error {
+	return d.get().PutContent(ctx, path, content)
+}
+
+// Reader ...
+func (d *Driver) Reader(ctx context.Context, path string, offset int64) (io.ReadCloser, error) {
+	return d.get().Reader(ctx, path, offset)
+}
+
+// Writer ...
+func (d *Driver) Writer(ctx context.Context, path string, append bool) (storagedriver.FileWriter, error) {
+	return d.get().Writer(ctx, path, append)
+}
+
+// Stat ...
+func (d *Driver) Stat(ctx context.Context, path string) (storagedriver.FileInfo, error) {
+	return d.get().Stat(ctx, path)
+}
+
+// List ...
+func (d *Driver) List(ctx context.Context, path string) ([]string, error) {
+	return d.get().List(ctx, path)
+}
+
+// Move ...
+func (d *Driver) Move(ctx context.Context, sourcePath string, destPath string) error {
+	return d.get().Move(ctx, sourcePath, destPath)
+}
+
+// Delete ...
+func (d *Driver) Delete(ctx context.Context, path string) error {
+	return d.get().Delete(ctx, path)
+}
+
+// URLFor ...
+func (d *Driver) URLFor(ctx context.Context, path string, options map[string]interface{}) (string, error) {
+	return d.get().URLFor(ctx, path, options)
+}
+
+// Walk ...
+func (d *Driver) Walk(ctx context.Context, path string, f storagedriver.WalkFn) error {
+	return d.get().Walk(ctx, path, f)
+}
@@ -0,0 +1,55 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+// MinHTTPSecretLength is the minimum length of the HTTP secret of the registry
+const MinHTTPSecretLength = 16
+
+// SaveHTTPSecret writes the HTTP secret, which signs the upload states, into the configuration file of the
+// registry, the other settings of the http section are kept
+func SaveHTTPSecret(path, secret string) error {
+	if len(secret) < MinHTTPSecretLength {
+		return errors.BadRequestError(nil).WithMessage("the HTTP secret should contain at least %d characters", MinHTTPSecretLength)
+	}
+	return updateConfig(path, func(doc yaml.MapSlice) yaml.MapSlice {
+		for i, item := range doc {
+			if fmt.Sprint(item.Key) != "http" {
+				continue
+			}
+			section, _ := item.Value.(yaml.MapSlice)
+			found := false
+			for j, it := range section {
+				if fmt.Sprint(it.Key) == "secret" {
+					section[j].Value = secret
+					found = true
+				}
+			}
+			if !found {
+				section = append(section, yaml.MapItem{Key: "secret", Value: secret})
+			}
+			doc[i].Value = section
+			return doc
+		}
+		return append(doc, yaml.MapItem{Key: "http", Value: yaml.MapSlice{{Key: "secret", Value: secret}}})
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+func TestSaveHTTPSecret(t *testing.T) {
+	path := writeConfig(t)
+	err := SaveHTTPSecret(path, "short")
+	require.NotNil(t, err)
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+
+	require.Nil(t, SaveHTTPSecret(path, "0123456789abcdef0123"))
+	data, err := os.ReadFile(path)
+	require.Nil(t, err)
+	doc := map[string]interface{}{}
+	require.Nil(t, yaml.Unmarshal(data, &doc))
+	http, ok := doc["http"].(map[interface{}]interface{})
+	require.True(t, ok)
+	assert.Equal(t, ":5000", http["addr"])
+	assert.Equal(t, "0123456789abcdef0123", http["secret"])
+	// the storage section is kept
+	cfg, err := Load(path)
+	require.Nil(t, err)
+	assert.Equal(t, "s3", cfg.Type)
+
+	// rotate again
+	require.Nil(t, SaveHTTPSecret(path, "fedcba9876543210fedc"))
+	data, err = os.ReadFile(path)
+	require.Nil(t, err)
+	doc = map[string]interface{}{}
+	require.Nil(t, yaml.Unmarshal(data, &doc))
+	assert.Equal(t, "fedcba9876543210fedc", doc["http"].(map[interface{}]interface{})["secret"])
+}
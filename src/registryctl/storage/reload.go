@@ -0,0 +1,44 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"os/exec"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+// the timeout of the reload command
+const reloadTimeout = 2 * time.Minute
+
+// Reload runs the command to reload the registry, e.g. restarting the registry service by the process
+// supervisor or the container runtime, it returns false without error when no command is configured
+func Reload(ctx context.Context, command string) (bool, error) {
+	if command == "" {
+		return false, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, reloadTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "/bin/sh", "-c", command).CombinedOutput()
+	if err != nil {
+		log.Errorf("failed to reload the registry, output: %s, error: %v", string(out), err)
+		return false, errors.UnknownError(err).WithMessage("failed to reload the registry: %v", err)
+	}
+	log.Infof("the registry is reloaded, output: %s", string(out))
+	return true, nil
+}
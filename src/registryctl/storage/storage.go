@@ -0,0 +1,285 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	storagedriver "github.com/docker/distribution/registry/storage/driver"
+	"github.com/docker/distribution/registry/storage/driver/factory"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+const (
+	// Redacted replaces the values of the sensitive parameters in the responses, sending it back
+	// when updating the configuration keeps the current value of the parameter
+	Redacted = "******"
+	// the directory that the validation writes the probe files into
+	validationDir = "/harbor_storage_validation"
+)
+
+var (
+	// the keys of the storage section which aren't the storage driver
+	reservedKeys = map[string]bool{
+		"maintenance": true,
+		"cache":       true,
+		"delete":      true,
+		"redirect":    true,
+	}
+	// the parameters whose names contain these words are sensitive
+	sensitiveWords = []string{"secret", "password", "accesskey", "accountkey", "privatekey", "keyfile", "credentials", "token"}
+)
+
+// Config is the storage backend of the registry, e.g. filesystem, s3, gcs or azure with its parameters
+type Config struct {
+	Type       string                 `json:"type"`
+	Parameters map[string]interface{} `json:"parameters"`
+}
+
+// UpdateResult is the result of updating the storage configuration
+type UpdateResult struct {
+	// Reloaded indicates whether the registry is reloaded with the new configuration, the registry
+	// must be restarted manually to pick up the configuration when it is false
+	Reloaded bool `json:"reloaded"`
+}
+
+// Redact returns a copy of the configuration with the sensitive parameters masked
+func (c *Config) Redact() *Config {
+	cfg := &Config{Type: c.Type, Parameters: map[string]interface{}{}}
+	for k, v := range c.Parameters {
+		if isSensitive(k) {
+			v = Redacted
+		}
+		cfg.Parameters[k] = v
+	}
+	return cfg
+}
+
+// Restore fills the masked parameters with the values of the current configuration of the same type
+func (c *Config) Restore(current *Config) error {
+	for k, v := range c.Parameters {
+		if v != Redacted {
+			continue
+		}
+		if current == nil || current.Type != c.Type {
+			return errors.BadRequestError(nil).WithMessage("the value of the parameter %s is required", k)
+		}
+		cv, exist := current.Parameters[k]
+		if !exist {
+			return errors.BadRequestError(nil).WithMessage("the value of the parameter %s is required", k)
+		}
+		c.Parameters[k] = cv
+	}
+	return nil
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, word := range sensitiveWords {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Load reads the storage configuration from the configuration file of the registry
+func Load(path string) (*Config, error) {
+	storage, err := readStorageSection(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range storage {
+		key := fmt.Sprint(item.Key)
+		if reservedKeys[key] {
+			continue
+		}
+		cfg := &Config{Type: key, Parameters: map[string]interface{}{}}
+		// the driver without parameters, e.g. "storage: inmemory"
+		if params, ok := normalize(item.Value).(map[string]interface{}); ok {
+			cfg.Parameters = params
+		}
+		return cfg, nil
+	}
+	return nil, errors.NotFoundError(nil).WithMessage("no storage driver configured in %s", path)
+}
+
+// Save writes the storage configuration into the configuration file of the registry, the other settings of
+// the file and the maintenance, cache, delete and redirect settings of the storage section are kept
+func Save(path string, cfg *Config) error {
+	return updateConfig(path, func(doc yaml.MapSlice) yaml.MapSlice {
+		storage := yaml.MapSlice{{Key: cfg.Type, Value: cfg.Parameters}}
+		found := false
+		for i, item := range doc {
+			if fmt.Sprint(item.Key) != "storage" {
+				continue
+			}
+			if section, ok := item.Value.(yaml.MapSlice); ok {
+				for _, it := range section {
+					if reservedKeys[fmt.Sprint(it.Key)] {
+						storage = append(storage, it)
+					}
+				}
+			}
+			doc[i].Value = storage
+			found = true
+		}
+		if !found {
+			doc = append(doc, yaml.MapItem{Key: "storage", Value: storage})
+		}
+		return doc
+	})
+}
+
+// updateConfig applies the update to the configuration file of the registry, the file is written
+// into a temporary file first and renamed to avoid the registry reading the half written file
+func updateConfig(path string, update func(doc yaml.MapSlice) yaml.MapSlice) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	doc := yaml.MapSlice{}
+	if err = yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("error parsing registry configuration %s: %v", path, err)
+	}
+
+	out, err := yaml.Marshal(update(doc))
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(out); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), info.Mode()); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Validate creates the storage driver with the configuration and checks that the driver can write, read
+// and delete the probe file, the created driver is returned when the validation passes
+func Validate(ctx context.Context, cfg *Config) (storagedriver.StorageDriver, error) {
+	if cfg == nil || cfg.Type == "" {
+		return nil, errors.BadRequestError(nil).WithMessage("the type of the storage is required")
+	}
+	if reservedKeys[cfg.Type] {
+		return nil, errors.BadRequestError(nil).WithMessage("invalid storage type %s", cfg.Type)
+	}
+	params, _ := normalize(cfg.Parameters).(map[string]interface{})
+	driver, err := factory.Create(cfg.Type, params)
+	if err != nil {
+		return nil, errors.BadRequestError(err).WithMessage("failed to create the storage driver %s: %v", cfg.Type, err)
+	}
+
+	path := fmt.Sprintf("%s/%s", validationDir, utils.GenerateRandomString())
+	content := []byte(utils.GenerateRandomString())
+	if err = driver.PutContent(ctx, path, content); err != nil {
+		return nil, errors.BadRequestError(err).WithMessage("failed to write the storage: %v", err)
+	}
+	got, err := driver.GetContent(ctx, path)
+	if err != nil {
+		return nil, errors.BadRequestError(err).WithMessage("failed to read the storage: %v", err)
+	}
+	if !bytes.Equal(content, got) {
+		return nil, errors.BadRequestError(nil).WithMessage("the content read from the storage doesn't match the written one")
+	}
+	if err = driver.Delete(ctx, path); err != nil {
+		return nil, errors.BadRequestError(err).WithMessage("failed to delete from the storage: %v", err)
+	}
+	return driver, nil
+}
+
+func readStorageSection(path string) (yaml.MapSlice, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	doc := yaml.MapSlice{}
+	if err = yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("error parsing registry configuration %s: %v", path, err)
+	}
+	for _, item := range doc {
+		if fmt.Sprint(item.Key) != "storage" {
+			continue
+		}
+		switch v := item.Value.(type) {
+		case yaml.MapSlice:
+			return v, nil
+		case string:
+			return yaml.MapSlice{{Key: v, Value: nil}}, nil
+		}
+	}
+	return nil, errors.NotFoundError(nil).WithMessage("no storage configured in %s", path)
+}
+
+// normalize converts the YAML maps into the JSON compatible ones and the integral float numbers
+// decoded from JSON into integers, as the storage drivers don't accept the float numbers for the sizes
+func normalize(v interface{}) interface{} {
+	switch val := v.(type) {
+	case yaml.MapSlice:
+		m := map[string]interface{}{}
+		for _, item := range val {
+			m[fmt.Sprint(item.Key)] = normalize(item.Value)
+		}
+		return m
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for k, vv := range val {
+			m[fmt.Sprint(k)] = normalize(vv)
+		}
+		return m
+	case map[string]interface{}:
+		m := map[string]interface{}{}
+		for k, vv := range val {
+			m[k] = normalize(vv)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(val))
+		for i, vv := range val {
+			l[i] = normalize(vv)
+		}
+		return l
+	case float64:
+		if val == math.Trunc(val) && math.Abs(val) < math.MaxInt64 {
+			return int(val)
+		}
+		return val
+	default:
+		return v
+	}
+}
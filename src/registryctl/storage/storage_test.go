@@ -0,0 +1,136 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/docker/distribution/registry/storage/driver/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+const registryConfig = `version: 0.1
+storage:
+  cache:
+    layerinfo: redis
+  s3:
+    accesskey: AKIA
+    secretkey: secret
+    region: us-east-1
+    bucket: harbor
+    chunksize: 5242880
+  delete:
+    enabled: true
+http:
+  addr: :5000
+`
+
+func writeConfig(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.Nil(t, os.WriteFile(path, []byte(registryConfig), 0600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	cfg, err := Load(writeConfig(t))
+	require.Nil(t, err)
+	assert.Equal(t, "s3", cfg.Type)
+	assert.Equal(t, "harbor", cfg.Parameters["bucket"])
+	assert.Equal(t, 5242880, cfg.Parameters["chunksize"])
+
+	redacted := cfg.Redact()
+	assert.Equal(t, Redacted, redacted.Parameters["accesskey"])
+	assert.Equal(t, Redacted, redacted.Parameters["secretkey"])
+	assert.Equal(t, "us-east-1", redacted.Parameters["region"])
+	// the original one isn't changed
+	assert.Equal(t, "secret", cfg.Parameters["secretkey"])
+}
+
+func TestRestore(t *testing.T) {
+	current := &Config{Type: "s3", Parameters: map[string]interface{}{"secretkey": "secret"}}
+
+	cfg := &Config{Type: "s3", Parameters: map[string]interface{}{"secretkey": Redacted, "bucket": "new"}}
+	require.Nil(t, cfg.Restore(current))
+	assert.Equal(t, "secret", cfg.Parameters["secretkey"])
+
+	// the type is changed, the sensitive parameters must be provided
+	cfg = &Config{Type: "gcs", Parameters: map[string]interface{}{"keyfile": Redacted}}
+	err := cfg.Restore(current)
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+}
+
+func TestSave(t *testing.T) {
+	path := writeConfig(t)
+	require.Nil(t, Save(path, &Config{Type: "filesystem", Parameters: map[string]interface{}{"rootdirectory": "/storage"}}))
+
+	cfg, err := Load(path)
+	require.Nil(t, err)
+	assert.Equal(t, "filesystem", cfg.Type)
+	assert.Equal(t, "/storage", cfg.Parameters["rootdirectory"])
+
+	// the other settings are kept
+	data, err := os.ReadFile(path)
+	require.Nil(t, err)
+	doc := map[string]interface{}{}
+	require.Nil(t, yaml.Unmarshal(data, &doc))
+	assert.NotNil(t, doc["http"])
+	storage := doc["storage"].(map[interface{}]interface{})
+	assert.NotNil(t, storage["cache"])
+	assert.NotNil(t, storage["delete"])
+	assert.Nil(t, storage["s3"])
+}
+
+func TestValidate(t *testing.T) {
+	driver, err := Validate(context.Background(), &Config{Type: "inmemory"})
+	require.Nil(t, err)
+	assert.Equal(t, "inmemory", driver.Name())
+
+	_, err = Validate(context.Background(), &Config{Type: "unknown"})
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+
+	_, err = Validate(context.Background(), &Config{Type: "cache"})
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+}
+
+func TestNormalize(t *testing.T) {
+	v := normalize(map[string]interface{}{
+		"chunksize": float64(5242880),
+		"ratio":     0.5,
+		"nested":    map[interface{}]interface{}{"key": "value"},
+	}).(map[string]interface{})
+	assert.Equal(t, 5242880, v["chunksize"])
+	assert.Equal(t, 0.5, v["ratio"])
+	assert.Equal(t, map[string]interface{}{"key": "value"}, v["nested"])
+}
+
+func TestReload(t *testing.T) {
+	reloaded, err := Reload(context.Background(), "")
+	require.Nil(t, err)
+	assert.False(t, reloaded)
+
+	reloaded, err = Reload(context.Background(), "true")
+	require.Nil(t, err)
+	assert.True(t, reloaded)
+
+	_, err = Reload(context.Background(), "false")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,86 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/docker/distribution/registry/storage"
+	storagedriver "github.com/docker/distribution/registry/storage/driver"
+)
+
+// the root directory of the repositories which contain the "_uploads" directories
+const repositoriesRoot = "/docker/registry/v2/repositories"
+
+// PurgeUploadsResult is the result of purging the stale uploads
+type PurgeUploadsResult struct {
+	// Purged is the directories of the purged uploads
+	Purged []string `json:"purged"`
+	// ReclaimedBytes is the size of the purged uploads, which is reclaimed unless in the dry run mode
+	ReclaimedBytes int64 `json:"reclaimed_bytes"`
+	// Errors is the errors encountered when purging
+	Errors []string `json:"errors,omitempty"`
+}
+
+// PurgeUploads purges the uploads started before the "olderThan" from the "_uploads" directories of the repositories,
+// which are left by the abandoned chunked pushes. Nothing is deleted in the dry run mode
+func PurgeUploads(ctx context.Context, driver storagedriver.StorageDriver, olderThan time.Time, dryRun bool) (*PurgeUploadsResult, error) {
+	result := &PurgeUploadsResult{
+		Purged: []string{},
+	}
+	if _, err := driver.Stat(ctx, repositoriesRoot); err != nil {
+		// nothing is pushed yet
+		if _, ok := err.(storagedriver.PathNotFoundError); ok {
+			return result, nil
+		}
+		return nil, err
+	}
+
+	// only collect the stale uploads here, they're deleted after calculating their sizes
+	dirs, errs := storage.PurgeUploads(ctx, driver, olderThan, false)
+	for _, err := range errs {
+		result.Errors = append(result.Errors, err.Error())
+	}
+	for _, dir := range dirs {
+		size, err := sizeOf(ctx, driver, dir)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", dir, err))
+			continue
+		}
+		if !dryRun {
+			if err = driver.Delete(ctx, dir); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", dir, err))
+				continue
+			}
+		}
+		result.Purged = append(result.Purged, dir)
+		result.ReclaimedBytes += size
+	}
+	return result, nil
+}
+
+// sizeOf returns the total size of the files under the directory
+func sizeOf(ctx context.Context, driver storagedriver.StorageDriver, dir string) (int64, error) {
+	var size int64
+	err := driver.Walk(ctx, dir, func(fileInfo storagedriver.FileInfo) error {
+		if !fileInfo.IsDir() {
+			size += fileInfo.Size()
+		}
+		return nil
+	})
+	return size, err
+}
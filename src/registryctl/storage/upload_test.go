@@ -0,0 +1,70 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/docker/distribution/registry/storage/driver/inmemory"
+	"github.com/docker/distribution/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeUploads(t *testing.T) {
+	ctx := context.Background()
+	driver := inmemory.New()
+
+	// nothing is pushed
+	result, err := PurgeUploads(ctx, driver, time.Now(), false)
+	require.Nil(t, err)
+	assert.Empty(t, result.Purged)
+
+	upload := func(startedAt time.Time, size int) string {
+		dir := path.Join(repositoriesRoot, "library/hello-world/_uploads", uuid.Generate().String())
+		require.Nil(t, driver.PutContent(ctx, path.Join(dir, "startedat"), []byte(startedAt.Format(time.RFC3339))))
+		require.Nil(t, driver.PutContent(ctx, path.Join(dir, "data"), make([]byte, size)))
+		return dir
+	}
+	stale := upload(time.Now().Add(-48*time.Hour), 1024)
+	upload(time.Now(), 2048)
+	startedAtSize := int64(len(time.Now().Format(time.RFC3339)))
+
+	// dry run
+	result, err = PurgeUploads(ctx, driver, time.Now().Add(-24*time.Hour), true)
+	require.Nil(t, err)
+	assert.Equal(t, []string{stale}, result.Purged)
+	assert.Equal(t, 1024+startedAtSize, result.ReclaimedBytes)
+	_, err = driver.Stat(ctx, stale)
+	assert.Nil(t, err)
+
+	// purge
+	result, err = PurgeUploads(ctx, driver, time.Now().Add(-24*time.Hour), false)
+	require.Nil(t, err)
+	assert.Equal(t, []string{stale}, result.Purged)
+	assert.Equal(t, 1024+startedAtSize, result.ReclaimedBytes)
+	assert.Empty(t, result.Errors)
+	_, err = driver.Stat(ctx, stale)
+	assert.NotNil(t, err)
+
+	// nothing stale left
+	result, err = PurgeUploads(ctx, driver, time.Now().Add(-24*time.Hour), false)
+	require.Nil(t, err)
+	assert.Empty(t, result.Purged)
+	assert.Equal(t, int64(0), result.ReclaimedBytes)
+}
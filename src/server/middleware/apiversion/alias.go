@@ -0,0 +1,57 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiversion
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/goharbor/harbor/src/server/middleware"
+)
+
+// matches the version segment of the API path, e.g. "/api/v2.0/", "/api/v3"
+var versionRegexp = regexp.MustCompile(`^/api/v[0-9]+(\.[0-9]+)?(/|$)`)
+
+// AliasMiddleware returns a middleware that rewrites the path of the request sent to the aliases of the API version,
+// so that the following middlewares and handlers only need to handle the canonical path:
+//  1. the major version, e.g. "/api/v2/projects" is rewritten to "/api/v2.0/projects"
+//  2. the path without version, e.g. "/api/projects" is rewritten to "/api/v2.0/projects"
+//
+// The requests sent to other versions are left untouched.
+func AliasMiddleware(version string, skippers ...middleware.Skipper) func(http.Handler) http.Handler {
+	prefix := "/api/" + version
+	major := "/api/" + strings.SplitN(version, ".", 2)[0]
+	return middleware.New(func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		r.URL.Path = resolve(r.URL.Path, prefix, major)
+		if r.URL.RawPath != "" {
+			r.URL.RawPath = resolve(r.URL.RawPath, prefix, major)
+		}
+		next.ServeHTTP(w, r)
+	}, skippers...)
+}
+
+func resolve(path, prefix, major string) string {
+	if !strings.HasPrefix(path, "/api/") {
+		return path
+	}
+	if path == major || strings.HasPrefix(path, major+"/") {
+		return prefix + strings.TrimPrefix(path, major)
+	}
+	if versionRegexp.MatchString(path) {
+		return path
+	}
+	return prefix + strings.TrimPrefix(path, "/api")
+}
@@ -0,0 +1,69 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiversion
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAliasMiddleware(t *testing.T) {
+	cases := []struct {
+		path     string
+		rawPath  string
+		expected string
+		raw      string
+	}{
+		{path: "/api/v2.0/projects", expected: "/api/v2.0/projects"},
+		{path: "/api/v2/projects", expected: "/api/v2.0/projects"},
+		{path: "/api/v2", expected: "/api/v2.0"},
+		{path: "/api/projects", expected: "/api/v2.0/projects"},
+		{path: "/api/v3/projects", expected: "/api/v3/projects"},
+		{path: "/api/v2.1/projects", expected: "/api/v2.1/projects"},
+		{path: "/api/v20/projects", expected: "/api/v20/projects"},
+		{path: "/v2/library/hello-world/manifests/latest", expected: "/v2/library/hello-world/manifests/latest"},
+		{path: "/c/login", expected: "/c/login"},
+		{
+			path:     "/api/v2/projects/library/repositories/a/b/artifacts",
+			rawPath:  "/api/v2/projects/library/repositories/a%252Fb/artifacts",
+			expected: "/api/v2.0/projects/library/repositories/a/b/artifacts",
+			raw:      "/api/v2.0/projects/library/repositories/a%252Fb/artifacts",
+		},
+	}
+
+	var path, rawPath string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		rawPath = r.URL.RawPath
+	})
+	handler := AliasMiddleware("v2.0")(next)
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost", nil)
+		req.URL.Path = c.path
+		req.URL.RawPath = c.rawPath
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		assert.Equal(t, c.expected, path, c.path)
+		assert.Equal(t, c.raw, rawPath, c.path)
+	}
+
+	// skipped
+	handler = AliasMiddleware("v2.0", func(r *http.Request) bool { return r.URL.Path == "/api/version" })(next)
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/api/version", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "/api/version", path)
+}
@@ -0,0 +1,168 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conditional
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	lib_http "github.com/goharbor/harbor/src/lib/http"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/server/middleware"
+)
+
+const (
+	headerETag        = "ETag"
+	headerIfMatch     = "If-Match"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+var (
+	// ifMatchRequired returns whether the "If-Match" header is required for the update requests
+	ifMatchRequired = func(r *http.Request) bool {
+		return config.IfMatchRequired(r.Context())
+	}
+)
+
+// ReadMiddleware sets the "ETag" header computed from the response body for the read requests,
+// and responds 304 without body if the ETag matches the "If-None-Match" header of the request
+func ReadMiddleware() func(http.Handler) http.Handler {
+	return middleware.New(func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		if r.Method != http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		res := lib.NewResponseBuffer(w)
+		next.ServeHTTP(res, r)
+		if res.StatusCode() != http.StatusOK {
+			if _, err := res.Flush(); err != nil {
+				log.G(r.Context()).Errorf("failed to flush the response: %v", err)
+			}
+			return
+		}
+
+		etag := ETag(res.Buffer())
+		if match(r.Header.Get(headerIfNoneMatch), etag) {
+			w.Header().Set(headerETag, etag)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		res.Header().Set(headerETag, etag)
+		if _, err := res.Flush(); err != nil {
+			log.G(r.Context()).Errorf("failed to flush the response: %v", err)
+		}
+	})
+}
+
+// UpdateMiddleware checks the "If-Match" header of the update requests against the ETag of the current
+// representation of the resource, and rejects the request with 412 if they don't match to prevent lost updates.
+// The current representation is read by sending the GET request with the same URL to the "reader" which should
+// have the "ReadMiddleware" applied for the GET operation. The request without the "If-Match" header is rejected
+// with 428 when the "If-Match" header is configured as required, otherwise it's passed through
+func UpdateMiddleware(reader http.Handler) func(http.Handler) http.Handler {
+	return middleware.New(func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		if r.Method != http.MethodPut {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ifMatch := r.Header.Get(headerIfMatch)
+		if len(ifMatch) == 0 {
+			if ifMatchRequired(r) {
+				lib_http.SendError(w, errors.New(nil).WithCode(errors.PreconditionRequiredCode).
+					WithMessage(`the "If-Match" header is required`))
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		res, err := readCurrent(reader, w, r)
+		if err != nil {
+			lib_http.SendError(w, err)
+			return
+		}
+		// failed to read the current representation, respond the error directly
+		if res.StatusCode() != http.StatusOK {
+			if _, err := res.Flush(); err != nil {
+				log.G(r.Context()).Errorf("failed to flush the response: %v", err)
+			}
+			return
+		}
+		etag := res.Header().Get(headerETag)
+		if len(etag) == 0 {
+			etag = ETag(res.Buffer())
+		}
+		if !match(ifMatch, etag) {
+			lib_http.SendError(w, errors.PreconditionFailedError(nil).
+				WithMessage("the resource has been modified, please get the latest version and try again"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ETag returns the strong entity tag of the provided content
+func ETag(content []byte) string {
+	sum := sha256.Sum256(content)
+	return fmt.Sprintf(`"%s"`, hex.EncodeToString(sum[:]))
+}
+
+// read the current representation of the resource by sending the GET request to the reader,
+// the response is buffered and can be flushed into the provided response writer
+func readCurrent(reader http.Handler, w http.ResponseWriter, r *http.Request) (*lib.ResponseBuffer, error) {
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, r.URL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.RequestURI = r.RequestURI
+	req.RemoteAddr = r.RemoteAddr
+	for k, vs := range r.Header {
+		switch http.CanonicalHeaderKey(k) {
+		case headerIfMatch, headerIfNoneMatch, "Content-Length", "Content-Type":
+			continue
+		}
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+
+	res := lib.NewResponseBuffer(w)
+	reader.ServeHTTP(res, req)
+	return res, nil
+}
+
+// match checks whether the ETag matches the value of "If-Match" or "If-None-Match" header,
+// the value is either "*" or a comma separated list of the entity tags
+func match(header, etag string) bool {
+	if len(header) == 0 {
+		return false
+	}
+	for _, value := range strings.Split(header, ",") {
+		value = strings.TrimSpace(value)
+		if value == "*" || strings.TrimPrefix(value, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
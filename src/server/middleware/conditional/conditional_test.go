@@ -0,0 +1,152 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conditional
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type conditionalTestSuite struct {
+	suite.Suite
+	content string
+	reader  http.Handler
+	updated bool
+	update  http.Handler
+}
+
+func (c *conditionalTestSuite) SetupTest() {
+	c.content = `{"name":"library"}`
+	c.reader = ReadMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2.0/projects/1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(c.content))
+	}))
+	c.updated = false
+	c.update = UpdateMiddleware(c.reader)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.updated = true
+	}))
+	ifMatchRequired = func(r *http.Request) bool { return false }
+}
+
+func (c *conditionalTestSuite) TestRead() {
+	// no "If-None-Match"
+	req := httptest.NewRequest(http.MethodGet, "/api/v2.0/projects/1", nil)
+	rr := httptest.NewRecorder()
+	c.reader.ServeHTTP(rr, req)
+	c.Equal(http.StatusOK, rr.Code)
+	c.Equal(c.content, rr.Body.String())
+	etag := rr.Header().Get("ETag")
+	c.Equal(ETag([]byte(c.content)), etag)
+
+	// "If-None-Match" matches
+	req = httptest.NewRequest(http.MethodGet, "/api/v2.0/projects/1", nil)
+	req.Header.Set("If-None-Match", `"other", `+etag)
+	rr = httptest.NewRecorder()
+	c.reader.ServeHTTP(rr, req)
+	c.Equal(http.StatusNotModified, rr.Code)
+	c.Empty(rr.Body.String())
+	c.Equal(etag, rr.Header().Get("ETag"))
+
+	// "If-None-Match" doesn't match
+	req = httptest.NewRequest(http.MethodGet, "/api/v2.0/projects/1", nil)
+	req.Header.Set("If-None-Match", `"other"`)
+	rr = httptest.NewRecorder()
+	c.reader.ServeHTTP(rr, req)
+	c.Equal(http.StatusOK, rr.Code)
+	c.Equal(c.content, rr.Body.String())
+
+	// the response isn't successful
+	req = httptest.NewRequest(http.MethodGet, "/api/v2.0/projects/2", nil)
+	rr = httptest.NewRecorder()
+	c.reader.ServeHTTP(rr, req)
+	c.Equal(http.StatusNotFound, rr.Code)
+	c.Empty(rr.Header().Get("ETag"))
+}
+
+func (c *conditionalTestSuite) TestUpdate() {
+	etag := ETag([]byte(c.content))
+
+	// "If-Match" matches
+	req := httptest.NewRequest(http.MethodPut, "/api/v2.0/projects/1", nil)
+	req.Header.Set("If-Match", etag)
+	rr := httptest.NewRecorder()
+	c.update.ServeHTTP(rr, req)
+	c.Equal(http.StatusOK, rr.Code)
+	c.True(c.updated)
+
+	// "If-Match" doesn't match as the resource is modified
+	c.updated = false
+	c.content = `{"name":"library2"}`
+	req = httptest.NewRequest(http.MethodPut, "/api/v2.0/projects/1", nil)
+	req.Header.Set("If-Match", etag)
+	rr = httptest.NewRecorder()
+	c.update.ServeHTTP(rr, req)
+	c.Equal(http.StatusPreconditionFailed, rr.Code)
+	c.False(c.updated)
+
+	// the resource not found
+	req = httptest.NewRequest(http.MethodPut, "/api/v2.0/projects/2", nil)
+	req.Header.Set("If-Match", etag)
+	rr = httptest.NewRecorder()
+	c.update.ServeHTTP(rr, req)
+	c.Equal(http.StatusNotFound, rr.Code)
+	c.False(c.updated)
+
+	// no "If-Match" and it isn't required
+	req = httptest.NewRequest(http.MethodPut, "/api/v2.0/projects/1", nil)
+	rr = httptest.NewRecorder()
+	c.update.ServeHTTP(rr, req)
+	c.Equal(http.StatusOK, rr.Code)
+	c.True(c.updated)
+
+	// no "If-Match" and it is required
+	c.updated = false
+	ifMatchRequired = func(r *http.Request) bool { return true }
+	req = httptest.NewRequest(http.MethodPut, "/api/v2.0/projects/1", nil)
+	rr = httptest.NewRecorder()
+	c.update.ServeHTTP(rr, req)
+	c.Equal(http.StatusPreconditionRequired, rr.Code)
+	c.False(c.updated)
+}
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		header string
+		etag   string
+		match  bool
+	}{
+		{"", `"a"`, false},
+		{"*", `"a"`, true},
+		{`"a"`, `"a"`, true},
+		{`W/"a"`, `"a"`, true},
+		{`"b", "a"`, `"a"`, true},
+		{`"b"`, `"a"`, false},
+	}
+	for _, c := range cases {
+		if got := match(c.header, c.etag); got != c.match {
+			t.Errorf("match(%s, %s) = %v, want %v", c.header, c.etag, got, c.match)
+		}
+	}
+}
+
+func TestConditionalTestSuite(t *testing.T) {
+	suite.Run(t, &conditionalTestSuite{})
+}
@@ -0,0 +1,108 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contenttrust
+
+import (
+	"net/http"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/server/middleware"
+	"github.com/goharbor/harbor/src/server/middleware/util"
+)
+
+// backend is the content trust backend whose signatures can be required by the project
+type backend struct {
+	name string
+	// enabled returns whether the content trust of the backend is enabled for the project
+	enabled func(pro *proModels.Project) bool
+	// verify returns the policy violation error if the artifact isn't signed in the backend
+	verify func(r *http.Request, pro *proModels.Project, af lib.ArtifactInfo, art *artifact.Artifact) error
+}
+
+// Middleware handle docker pull content trust check of all the backends enabled for the project,
+// the signatures of all the enabled backends or any one of them are required according to the
+// content trust policy of the project
+func Middleware() func(http.Handler) http.Handler {
+	return enforce(notaryBackend, cosignBackend)
+}
+
+func enforce(backends ...*backend) func(http.Handler) http.Handler {
+	return middleware.BeforeRequest(func(r *http.Request) error {
+		ctx := r.Context()
+
+		logger := log.G(ctx)
+
+		none := lib.ArtifactInfo{}
+		af := lib.GetArtifactInfo(ctx)
+		if af == none {
+			return errors.New("artifactinfo middleware required before this middleware").WithCode(errors.NotFoundCode)
+		}
+
+		pro, err := project.Ctl.GetByName(ctx, af.ProjectName)
+		if err != nil {
+			return err
+		}
+		var enabled []*backend
+		for _, b := range backends {
+			if b.enabled(pro) {
+				enabled = append(enabled, b)
+			}
+		}
+		if len(enabled) == 0 {
+			return nil
+		}
+
+		art, err := artifact.Ctl.GetByReference(ctx, af.Repository, af.Reference, &artifact.Option{
+			WithAccessory: true,
+		})
+		if err != nil {
+			return err
+		}
+		if len(af.Digest) == 0 {
+			af.Digest = art.Digest
+		}
+		ok, err := util.SkipPolicyChecking(r, pro.ProjectID, art.ID)
+		if err != nil {
+			return err
+		}
+		if ok {
+			logger.Debugf("artifact %s@%s is pulling by the scanner/cosign, skip the checking", af.Repository, af.Digest)
+			return nil
+		}
+
+		policy := pro.ContentTrustPolicy()
+		var violation error
+		for _, b := range enabled {
+			err := b.verify(r, pro, af, art)
+			if err == nil {
+				if policy == proModels.ContentTrustPolicyAny {
+					return nil
+				}
+				continue
+			}
+			if policy == proModels.ContentTrustPolicyAll {
+				return err
+			}
+			logger.Debugf("artifact %s@%s isn't signed in %s: %v", af.Repository, af.Digest, b.name, err)
+			violation = err
+		}
+		return violation
+	})
+}
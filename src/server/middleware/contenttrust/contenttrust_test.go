@@ -0,0 +1,172 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contenttrust
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/artifact/processor/image"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/pkg/accessory"
+	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	basemodel "github.com/goharbor/harbor/src/pkg/accessory/model/base"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	"github.com/goharbor/harbor/src/testing/mock"
+	accessorytesting "github.com/goharbor/harbor/src/testing/pkg/accessory"
+)
+
+type ContentTrustMiddlewareTestSuite struct {
+	suite.Suite
+
+	originalArtifactController artifact.Controller
+	artifactController         *artifacttesting.Controller
+
+	originalProjectController project.Controller
+	projectController         *projecttesting.Controller
+
+	originalAccessMgr accessory.Manager
+	accessMgr         *accessorytesting.Manager
+
+	isArtifactSigned func(req *http.Request, art lib.ArtifactInfo) (bool, error)
+
+	artifact *artifact.Artifact
+	project  *proModels.Project
+	next     http.Handler
+}
+
+func (suite *ContentTrustMiddlewareTestSuite) SetupTest() {
+	suite.originalArtifactController = artifact.Ctl
+	suite.artifactController = &artifacttesting.Controller{}
+	artifact.Ctl = suite.artifactController
+
+	suite.originalProjectController = project.Ctl
+	suite.projectController = &projecttesting.Controller{}
+	project.Ctl = suite.projectController
+
+	suite.originalAccessMgr = accessory.Mgr
+	suite.accessMgr = &accessorytesting.Manager{}
+	accessory.Mgr = suite.accessMgr
+
+	suite.isArtifactSigned = isArtifactSigned
+	isArtifactSigned = func(req *http.Request, art lib.ArtifactInfo) (bool, error) {
+		return false, nil
+	}
+
+	// signed in cosign but not in notary
+	suite.artifact = &artifact.Artifact{}
+	suite.artifact.Type = image.ArtifactTypeImage
+	suite.artifact.ProjectID = 1
+	suite.artifact.RepositoryName = "library/photon"
+	suite.artifact.Digest = "digest"
+	suite.artifact.Accessories = []accessorymodel.Accessory{
+		&basemodel.Default{
+			Data: accessorymodel.AccessoryData{
+				ID:            1,
+				ArtifactID:    2,
+				SubArtifactID: 1,
+				Digest:        "sha256:signature",
+				Type:          accessorymodel.TypeCosignSignature,
+			},
+		},
+	}
+
+	suite.project = &proModels.Project{
+		ProjectID: suite.artifact.ProjectID,
+		Name:      "library",
+		Metadata: map[string]string{
+			proModels.ProMetaEnableContentTrust:       "true",
+			proModels.ProMetaEnableContentTrustCosign: "true",
+		},
+	}
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
+	mock.OnAnything(suite.projectController, "GetByName").Return(suite.project, nil)
+	mock.OnAnything(suite.accessMgr, "List").Return([]accessorymodel.Accessory{}, nil)
+
+	suite.next = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (suite *ContentTrustMiddlewareTestSuite) TearDownTest() {
+	artifact.Ctl = suite.originalArtifactController
+	project.Ctl = suite.originalProjectController
+	accessory.Mgr = suite.originalAccessMgr
+	isArtifactSigned = suite.isArtifactSigned
+}
+
+func (suite *ContentTrustMiddlewareTestSuite) makeRequest() *http.Request {
+	req := httptest.NewRequest("GET", "/v1/library/photon/manifests/2.0", nil)
+	info := lib.ArtifactInfo{
+		Repository: "library/photon",
+		Reference:  "2.0",
+		Tag:        "2.0",
+		Digest:     "",
+	}
+	return req.WithContext(lib.WithArtifactInfo(req.Context(), info))
+}
+
+// the signatures of all the enabled backends are required by default
+func (suite *ContentTrustMiddlewareTestSuite) TestPolicyAll() {
+	rr := httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, suite.makeRequest())
+	suite.Equal(http.StatusPreconditionFailed, rr.Code)
+
+	suite.project.Metadata[proModels.ProMetaContentTrustPolicy] = proModels.ContentTrustPolicyAll
+	rr = httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, suite.makeRequest())
+	suite.Equal(http.StatusPreconditionFailed, rr.Code)
+
+	isArtifactSigned = func(req *http.Request, art lib.ArtifactInfo) (bool, error) {
+		return true, nil
+	}
+	rr = httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, suite.makeRequest())
+	suite.Equal(http.StatusOK, rr.Code)
+}
+
+func (suite *ContentTrustMiddlewareTestSuite) TestPolicyAny() {
+	suite.project.Metadata[proModels.ProMetaContentTrustPolicy] = proModels.ContentTrustPolicyAny
+	rr := httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, suite.makeRequest())
+	suite.Equal(http.StatusOK, rr.Code)
+
+	// signed in neither of the backends
+	suite.artifact.Accessories = nil
+	rr = httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, suite.makeRequest())
+	suite.Equal(http.StatusPreconditionFailed, rr.Code)
+}
+
+func (suite *ContentTrustMiddlewareTestSuite) TestNoBackendEnabled() {
+	suite.project.Metadata = map[string]string{
+		proModels.ProMetaContentTrustPolicy: proModels.ContentTrustPolicyAny,
+	}
+	rr := httptest.NewRecorder()
+	Middleware()(suite.next).ServeHTTP(rr, suite.makeRequest())
+	suite.Equal(http.StatusOK, rr.Code)
+	suite.artifactController.AssertNotCalled(suite.T(), "GetByReference", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestContentTrustMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, &ContentTrustMiddlewareTestSuite{})
+}
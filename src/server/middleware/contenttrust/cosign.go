@@ -4,68 +4,61 @@ import (
 	"net/http"
 
 	"github.com/goharbor/harbor/src/controller/artifact"
-	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/accessory/model"
-	"github.com/goharbor/harbor/src/server/middleware"
-	"github.com/goharbor/harbor/src/server/middleware/util"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/signature/cosign"
 )
 
-// Cosign handle docker pull content trust check
-func Cosign() func(http.Handler) http.Handler {
-	return middleware.BeforeRequest(func(r *http.Request) error {
+var cosignBackend = &backend{
+	name: "Cosign",
+	enabled: func(pro *proModels.Project) bool {
+		return pro.ContentTrustCosignEnabled()
+	},
+	verify: func(r *http.Request, pro *proModels.Project, af lib.ArtifactInfo, art *artifact.Artifact) error {
 		ctx := r.Context()
 
 		logger := log.G(ctx)
 
-		none := lib.ArtifactInfo{}
-		af := lib.GetArtifactInfo(ctx)
-		if af == none {
-			return errors.New("artifactinfo middleware required before this middleware").WithCode(errors.NotFoundCode)
-		}
-		pro, err := project.Ctl.GetByName(ctx, af.ProjectName)
-		if err != nil {
-			return err
+		// If cosign policy enabled, it has to at least have one cosign signature.
+		if len(art.Accessories) == 0 {
+			pkgE := errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage("The image is not signed in Cosign.")
+			return pkgE
 		}
 
-		// If cosign policy enabled, it has to at least have one cosign signature.
-		if pro.ContentTrustCosignEnabled() {
-			art, err := artifact.Ctl.GetByReference(ctx, af.Repository, af.Reference, &artifact.Option{
-				WithAccessory: true,
-			})
-			if err != nil {
-				return err
+		var signatures []string
+		for _, acc := range art.Accessories {
+			if acc.GetData().Type == model.TypeCosignSignature {
+				signatures = append(signatures, acc.GetData().Digest)
 			}
+		}
+		if len(signatures) == 0 {
+			pkgE := errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage("The image is not signed in Cosign.")
+			return pkgE
+		}
 
-			ok, err := util.SkipPolicyChecking(r, pro.ProjectID, art.ID)
+		// If the trusted keys are configured, one of the signatures has to be signed by them.
+		if trustedKeys := pro.CosignTrustedKeys(); len(trustedKeys) > 0 {
+			keys, err := cosign.ParsePublicKeys(trustedKeys)
 			if err != nil {
-				return err
-			}
-			if ok {
-				logger.Debugf("artifact %s@%s is pulling by the scanner/cosign, skip the checking", af.Repository, af.Digest)
-				return nil
+				return errors.New(err).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage("The trusted keys of Cosign are invalid: %v", err)
 			}
-
-			if len(art.Accessories) == 0 {
-				pkgE := errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage("The image is not signed in Cosign.")
-				return pkgE
-			}
-
-			var hasCosignSignature bool
-			for _, acc := range art.Accessories {
-				if acc.GetData().Type == model.TypeCosignSignature {
-					hasCosignSignature = true
-					break
+			for _, signature := range signatures {
+				if err = cosign.Verifier.Verify(ctx, af.Repository, art.Digest, signature, keys); err == nil {
+					return nil
 				}
+				logger.Debugf("failed to verify the signature %s of %s@%s: %v", signature, af.Repository, art.Digest, err)
 			}
-			if !hasCosignSignature {
-				pkgE := errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage("The image is not signed in Cosign.")
-				return pkgE
-			}
+			pkgE := errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage("The image is not signed by the trusted keys in Cosign.")
+			return pkgE
 		}
-
 		return nil
-	})
+	},
+}
+
+// Cosign handle docker pull content trust check of Cosign only
+func Cosign() func(http.Handler) http.Handler {
+	return enforce(cosignBackend)
 }
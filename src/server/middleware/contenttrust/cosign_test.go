@@ -31,11 +31,13 @@ import (
 	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
 	basemodel "github.com/goharbor/harbor/src/pkg/accessory/model/base"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/signature/cosign"
 	securitytesting "github.com/goharbor/harbor/src/testing/common/security"
 	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
 	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
 	"github.com/goharbor/harbor/src/testing/mock"
 	accessorytesting "github.com/goharbor/harbor/src/testing/pkg/accessory"
+	cosigntesting "github.com/goharbor/harbor/src/testing/pkg/signature/cosign"
 )
 
 type CosignMiddlewareTestSuite struct {
@@ -50,6 +52,9 @@ type CosignMiddlewareTestSuite struct {
 	originalAccessMgr accessory.Manager
 	accessMgr         *accessorytesting.Manager
 
+	originalVerifier cosign.SignatureVerifier
+	verifier         *cosigntesting.SignatureVerifier
+
 	artifact *artifact.Artifact
 	project  *proModels.Project
 
@@ -69,6 +74,10 @@ func (suite *CosignMiddlewareTestSuite) SetupTest() {
 	suite.accessMgr = &accessorytesting.Manager{}
 	accessory.Mgr = suite.accessMgr
 
+	suite.originalVerifier = cosign.Verifier
+	suite.verifier = &cosigntesting.SignatureVerifier{}
+	cosign.Verifier = suite.verifier
+
 	suite.artifact = &artifact.Artifact{}
 	suite.artifact.Type = image.ArtifactTypeImage
 	suite.artifact.ProjectID = 1
@@ -93,6 +102,7 @@ func (suite *CosignMiddlewareTestSuite) TearDownTest() {
 	artifact.Ctl = suite.originalArtifactController
 	project.Ctl = suite.originalProjectController
 	accessory.Mgr = suite.originalAccessMgr
+	cosign.Verifier = suite.originalVerifier
 }
 
 func (suite *CosignMiddlewareTestSuite) makeRequest(setHeader ...bool) *http.Request {
@@ -244,6 +254,43 @@ func (suite *CosignMiddlewareTestSuite) TestSignaturePulling() {
 	suite.Equal(rr.Code, http.StatusOK)
 }
 
+// pull a signed image when the trusted keys are configured
+func (suite *CosignMiddlewareTestSuite) TestTrustedKeysPulling() {
+	suite.artifact.Accessories = []accessorymodel.Accessory{
+		&basemodel.Default{
+			Data: accessorymodel.AccessoryData{
+				ID:            1,
+				ArtifactID:    2,
+				SubArtifactID: 1,
+				Digest:        "sha256:signature",
+				Type:          accessorymodel.TypeCosignSignature,
+			},
+		},
+	}
+	suite.project.Metadata[proModels.ProMetaCosignTrustedKeys] = trustedKey
+	mock.OnAnything(suite.artifactController, "GetByReference").Return(suite.artifact, nil)
+	mock.OnAnything(suite.projectController, "GetByName").Return(suite.project, nil)
+	mock.OnAnything(suite.accessMgr, "List").Return([]accessorymodel.Accessory{}, nil)
+
+	// signed by the trusted keys
+	suite.verifier.On("Verify", mock.Anything, "library/photon", "digest", "sha256:signature", mock.Anything).Return(nil).Once()
+	rr := httptest.NewRecorder()
+	Cosign()(suite.next).ServeHTTP(rr, suite.makeRequest())
+	suite.Equal(http.StatusOK, rr.Code)
+
+	// not signed by the trusted keys
+	suite.verifier.On("Verify", mock.Anything, "library/photon", "digest", "sha256:signature", mock.Anything).Return(fmt.Errorf("untrusted")).Once()
+	rr = httptest.NewRecorder()
+	Cosign()(suite.next).ServeHTTP(rr, suite.makeRequest())
+	suite.Equal(http.StatusPreconditionFailed, rr.Code)
+	suite.verifier.AssertExpectations(suite.T())
+}
+
+const trustedKey = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE+vTGZolEX+EWRjhqPTLoLCxvr7UV
+RHhsieXL+TF4XsTDyxCYVFoUFrjliySBejPfEOsT0ojIT+5kTGdZBqM2pw==
+-----END PUBLIC KEY-----`
+
 func TestCosignMiddlewareTestSuite(t *testing.T) {
 	suite.Run(t, &CosignMiddlewareTestSuite{})
 }
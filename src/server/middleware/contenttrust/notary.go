@@ -4,13 +4,10 @@ import (
 	"net/http"
 
 	"github.com/goharbor/harbor/src/controller/artifact"
-	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
-	"github.com/goharbor/harbor/src/lib/log"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
 	"github.com/goharbor/harbor/src/pkg/signature"
-	"github.com/goharbor/harbor/src/server/middleware"
-	"github.com/goharbor/harbor/src/server/middleware/util"
 )
 
 var (
@@ -28,49 +25,25 @@ var (
 	}
 )
 
-// Notary handle docker pull content trust check
-func Notary() func(http.Handler) http.Handler {
-	return middleware.BeforeRequest(func(r *http.Request) error {
-		ctx := r.Context()
-
-		logger := log.G(ctx)
-
-		none := lib.ArtifactInfo{}
-		af := lib.GetArtifactInfo(ctx)
-		if af == none {
-			return errors.New("artifactinfo middleware required before this middleware").WithCode(errors.NotFoundCode)
-		}
-
-		pro, err := project.Ctl.GetByName(ctx, af.ProjectName)
+var notaryBackend = &backend{
+	name: "Notary",
+	enabled: func(pro *proModels.Project) bool {
+		return pro.ContentTrustEnabled()
+	},
+	verify: func(r *http.Request, _ *proModels.Project, af lib.ArtifactInfo, _ *artifact.Artifact) error {
+		match, err := isArtifactSigned(r, af)
 		if err != nil {
 			return err
 		}
-		if pro.ContentTrustEnabled() {
-			art, err := artifact.Ctl.GetByReference(ctx, af.Repository, af.Reference, nil)
-			if err != nil {
-				return err
-			}
-			if len(af.Digest) == 0 {
-				af.Digest = art.Digest
-			}
-			ok, err := util.SkipPolicyChecking(r, pro.ProjectID, art.ID)
-			if err != nil {
-				return err
-			}
-			if ok {
-				logger.Debugf("artifact %s@%s is pulling by the scanner/cosign, skip the checking", af.Repository, af.Digest)
-				return nil
-			}
-
-			match, err := isArtifactSigned(r, af)
-			if err != nil {
-				return err
-			}
-			if !match {
-				pkgE := errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage("The image is not signed in Notary.")
-				return pkgE
-			}
+		if !match {
+			pkgE := errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage("The image is not signed in Notary.")
+			return pkgE
 		}
 		return nil
-	})
+	},
+}
+
+// Notary handle docker pull content trust check of Notary only
+func Notary() func(http.Handler) http.Handler {
+	return enforce(notaryBackend)
 }
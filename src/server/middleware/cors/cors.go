@@ -0,0 +1,108 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cors
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib/config"
+	cfgModels "github.com/goharbor/harbor/src/lib/config/models"
+	"github.com/goharbor/harbor/src/server/middleware"
+)
+
+const (
+	headerOrigin           = "Origin"
+	headerVary             = "Vary"
+	headerRequestMethod    = "Access-Control-Request-Method"
+	headerAllowOrigin      = "Access-Control-Allow-Origin"
+	headerAllowMethods     = "Access-Control-Allow-Methods"
+	headerAllowHeaders     = "Access-Control-Allow-Headers"
+	headerAllowCredentials = "Access-Control-Allow-Credentials"
+	headerExposeHeaders    = "Access-Control-Expose-Headers"
+	headerMaxAge           = "Access-Control-Max-Age"
+	anyOrigin              = "*"
+	preflightMaxAge        = "600"
+	exposedHeaders         = "X-Total-Count, Link, Location, X-Request-Id, ETag"
+	preflightVary          = "Origin, Access-Control-Request-Method, Access-Control-Request-Headers"
+)
+
+// the function to get the setting of CORS, it's overridden in the tests
+var settingFunc = func(ctx context.Context) *cfgModels.CORSSetting {
+	return config.CORS(ctx)
+}
+
+// Middleware applies the CORS policy configured by the admin to the requests of the APIs. The preflight requests
+// from the allowed origins are answered directly, and the actual requests from them get the CORS headers so that
+// the browser-based tools can call the APIs without a reverse proxy. The requests from other origins are passed
+// through untouched and are rejected by the browser as before
+func Middleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler {
+	return middleware.New(func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		origin := r.Header.Get(headerOrigin)
+		if len(origin) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		setting := settingFunc(r.Context())
+		if !originAllowed(setting, origin) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h := w.Header()
+		h.Add(headerVary, headerOrigin)
+		h.Set(headerAllowOrigin, origin)
+		// the credentials are never allowed for the origins matched by the wildcard, otherwise any site could
+		// read the responses with the session of the logged-in user
+		if setting.AllowCredentials && !matchedByWildcard(setting, origin) {
+			h.Set(headerAllowCredentials, "true")
+		}
+
+		// preflight request
+		if r.Method == http.MethodOptions && len(r.Header.Get(headerRequestMethod)) > 0 {
+			h.Set(headerVary, preflightVary)
+			h.Set(headerAllowMethods, strings.Join(setting.AllowedMethods, ", "))
+			if len(setting.AllowedHeaders) > 0 {
+				h.Set(headerAllowHeaders, strings.Join(setting.AllowedHeaders, ", "))
+			}
+			h.Set(headerMaxAge, preflightMaxAge)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		h.Set(headerExposeHeaders, exposedHeaders)
+		next.ServeHTTP(w, r)
+	}, skippers...)
+}
+
+func originAllowed(setting *cfgModels.CORSSetting, origin string) bool {
+	for _, allowed := range setting.AllowedOrigins {
+		if allowed == anyOrigin || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchedByWildcard returns whether the origin is allowed only because of the wildcard origin
+func matchedByWildcard(setting *cfgModels.CORSSetting, origin string) bool {
+	for _, allowed := range setting.AllowedOrigins {
+		if strings.EqualFold(allowed, origin) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cors
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	cfgModels "github.com/goharbor/harbor/src/lib/config/models"
+)
+
+type corsTestSuite struct {
+	suite.Suite
+	setting *cfgModels.CORSSetting
+	handler http.Handler
+}
+
+func (c *corsTestSuite) SetupTest() {
+	c.setting = &cfgModels.CORSSetting{
+		AllowedOrigins: []string{"https://tools.example.com"},
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+	}
+	settingFunc = func(ctx context.Context) *cfgModels.CORSSetting { return c.setting }
+	c.handler = Middleware()(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func (c *corsTestSuite) request(method, origin string, preflight bool) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/v2.0/projects", nil)
+	if len(origin) > 0 {
+		req.Header.Set("Origin", origin)
+	}
+	if preflight {
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	}
+	rr := httptest.NewRecorder()
+	c.handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func (c *corsTestSuite) TestNoOrigin() {
+	rr := c.request(http.MethodGet, "", false)
+	c.Equal(http.StatusOK, rr.Code)
+	c.Empty(rr.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func (c *corsTestSuite) TestOriginNotAllowed() {
+	rr := c.request(http.MethodGet, "https://evil.example.com", false)
+	c.Equal(http.StatusOK, rr.Code)
+	c.Empty(rr.Header().Get("Access-Control-Allow-Origin"))
+
+	// the preflight request is passed through
+	rr = c.request(http.MethodOptions, "https://evil.example.com", true)
+	c.Equal(http.StatusOK, rr.Code)
+	c.Empty(rr.Header().Get("Access-Control-Allow-Methods"))
+
+	// CORS is disabled
+	c.setting.AllowedOrigins = nil
+	rr = c.request(http.MethodGet, "https://tools.example.com", false)
+	c.Empty(rr.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func (c *corsTestSuite) TestActualRequest() {
+	rr := c.request(http.MethodGet, "https://tools.example.com", false)
+	c.Equal(http.StatusOK, rr.Code)
+	c.Equal("https://tools.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+	c.Equal("Origin", rr.Header().Get("Vary"))
+	c.Contains(rr.Header().Get("Access-Control-Expose-Headers"), "X-Total-Count")
+	c.Empty(rr.Header().Get("Access-Control-Allow-Credentials"))
+
+	c.setting.AllowCredentials = true
+	rr = c.request(http.MethodGet, "https://tools.example.com", false)
+	c.Equal("true", rr.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func (c *corsTestSuite) TestAnyOriginWithoutCredentials() {
+	c.setting.AllowedOrigins = []string{"*"}
+	c.setting.AllowCredentials = true
+	rr := c.request(http.MethodGet, "https://evil.example.com", false)
+	c.Equal("https://evil.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+	c.Empty(rr.Header().Get("Access-Control-Allow-Credentials"))
+
+	// the credentials are still allowed for the origins listed explicitly
+	c.setting.AllowedOrigins = []string{"*", "https://tools.example.com"}
+	rr = c.request(http.MethodGet, "https://tools.example.com", false)
+	c.Equal("true", rr.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func (c *corsTestSuite) TestPreflight() {
+	rr := c.request(http.MethodOptions, "https://tools.example.com", true)
+	c.Equal(http.StatusNoContent, rr.Code)
+	c.Equal("https://tools.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+	c.Equal("GET, POST", rr.Header().Get("Access-Control-Allow-Methods"))
+	c.Equal("Authorization, Content-Type", rr.Header().Get("Access-Control-Allow-Headers"))
+	c.Equal("600", rr.Header().Get("Access-Control-Max-Age"))
+}
+
+func (c *corsTestSuite) TestAnyOrigin() {
+	c.setting.AllowedOrigins = []string{"*"}
+	rr := c.request(http.MethodGet, "https://other.example.com", false)
+	// the origin is echoed rather than the wildcard
+	c.Equal("https://other.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORSTestSuite(t *testing.T) {
+	suite.Run(t, &corsTestSuite{})
+}
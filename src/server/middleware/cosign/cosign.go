@@ -26,9 +26,18 @@ var (
 	subArtDigestSubexp = "digest"
 	// repositorySubexp is the name for sub regex that maps to repository name in the url
 	repositorySubexp = "repository"
-	cosignRe         = regexp.MustCompile(fmt.Sprintf(`^/v2/(?P<%s>%s)/manifests/%s-(?P<%s>%s).sig$`, repositorySubexp, reference.NameRegexp.String(), digest.SHA256, subArtDigestSubexp, reference.IdentifierRegexp))
+	// suffixSubexp is the name for sub regex that maps to the suffix of the cosign tag
+	suffixSubexp = "suffix"
+	cosignRe     = regexp.MustCompile(fmt.Sprintf(`^/v2/(?P<%s>%s)/manifests/%s-(?P<%s>%s)\.(?P<%s>sig|att|sbom)$`, repositorySubexp, reference.NameRegexp.String(), digest.SHA256, subArtDigestSubexp, reference.IdentifierRegexp, suffixSubexp))
 	// the media type of consign signature layer
 	mediaTypeCosignLayer = "application/vnd.dev.cosign.simplesigning.v1+json"
+
+	// the accessory types of the cosign tag suffixes, the signature is checked against its layer media type
+	suffixTypes = map[string]string{
+		"sig":  model.TypeCosignSignature,
+		"att":  model.TypeInTotoAttestation,
+		"sbom": model.TypeSBOM,
+	}
 )
 
 // SignatureMiddleware middleware to record the linkeage of artifact and its accessory
@@ -71,10 +80,11 @@ func SignatureMiddleware() func(http.Handler) http.Handler {
 		}
 
 		// Needs tag to match the cosign tag pattern.
-		_, subjectArtDigest, ok := matchCosignSignaturePattern(r.URL.Path)
+		_, subjectArtDigest, suffix, ok := matchCosignSignaturePattern(r.URL.Path)
 		if !ok {
 			return nil
 		}
+		accType := suffixTypes[suffix]
 
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
@@ -88,15 +98,16 @@ func SignatureMiddleware() func(http.Handler) http.Handler {
 			return err
 		}
 
-		var hasSignature bool
+		// the attestations and the SBOMs attached by cosign are recorded as is
+		hasAccessory := accType != model.TypeCosignSignature
 		for _, descriptor := range manifest.References() {
 			if descriptor.MediaType == mediaTypeCosignLayer {
-				hasSignature = true
+				hasAccessory = true
 				break
 			}
 		}
 
-		if hasSignature {
+		if hasAccessory {
 			subjectArt, err := artifact.Ctl.GetByReference(ctx, info.Repository, fmt.Sprintf("%s:%s", digest.SHA256, subjectArtDigest), nil)
 			if err != nil {
 				logger.Errorf("failed to get subject artifact: %s, error: %v", subjectArtDigest, err)
@@ -104,7 +115,7 @@ func SignatureMiddleware() func(http.Handler) http.Handler {
 			}
 			art, err := artifact.Ctl.GetByReference(ctx, info.Repository, desc.Digest.String(), nil)
 			if err != nil {
-				logger.Errorf("failed to get cosign signature artifact: %s, error: %v", desc.Digest.String(), err)
+				logger.Errorf("failed to get cosign %s artifact: %s, error: %v", suffix, desc.Digest.String(), err)
 				return err
 			}
 
@@ -114,12 +125,12 @@ func SignatureMiddleware() func(http.Handler) http.Handler {
 					SubArtifactID: subjectArt.ID,
 					Size:          desc.Size,
 					Digest:        desc.Digest.String(),
-					Type:          model.TypeCosignSignature,
+					Type:          accType,
 				})
 				return err
 			})(orm.SetTransactionOpNameToContext(ctx, "tx-create-cosign-accessory")); err != nil {
 				if !errors.IsConflictErr(err) {
-					logger.Errorf("failed to create cosign signature artifact: %s, error: %v", desc.Digest.String(), err)
+					logger.Errorf("failed to create cosign %s accessory: %s, error: %v", suffix, desc.Digest.String(), err)
 					return err
 				}
 			}
@@ -129,12 +140,12 @@ func SignatureMiddleware() func(http.Handler) http.Handler {
 	})
 }
 
-// matchCosignSignaturePattern checks whether the provided path matches the blob upload URL pattern,
-// if does, returns the repository as well
-func matchCosignSignaturePattern(path string) (repository, digest string, match bool) {
+// matchCosignSignaturePattern checks whether the provided path matches the cosign signature, attestation or SBOM
+// manifest URL pattern, if does, returns the repository, the subject digest and the tag suffix as well
+func matchCosignSignaturePattern(path string) (repository, digest, suffix string, match bool) {
 	strs := cosignRe.FindStringSubmatch(path)
-	if len(strs) < 3 {
-		return "", "", false
+	if len(strs) < 4 {
+		return "", "", "", false
 	}
-	return strs[1], strs[2], true
+	return strs[1], strs[2], strs[3], true
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/goharbor/harbor/src/pkg/accessory"
 	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
 	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	_ "github.com/goharbor/harbor/src/pkg/accessory/model/attestation"
 	_ "github.com/goharbor/harbor/src/pkg/accessory/model/base"
 	_ "github.com/goharbor/harbor/src/pkg/accessory/model/cosign"
 	"github.com/goharbor/harbor/src/pkg/artifact"
@@ -184,29 +185,71 @@ func (suite *MiddlewareTestSuite) TestCosignSignatureDup() {
 	})
 }
 
+func (suite *MiddlewareTestSuite) TestCosignAttestation() {
+	suite.WithProject(func(projectID int64, projectName string) {
+		name := fmt.Sprintf("%s/hello-world", projectName)
+		subArtDigest := suite.DigestString()
+		ref := fmt.Sprintf("%s.att", strings.ReplaceAll(subArtDigest, "sha256:", "sha256-"))
+		_, descriptor, req := suite.prepare(name, ref)
+
+		_, repoId, err := repository.Ctl.Ensure(suite.Context(), name)
+		suite.Nil(err)
+		subjectArtID := suite.addArt(projectID, repoId, name, subArtDigest)
+		artID := suite.addArt(projectID, repoId, name, descriptor.Digest.String())
+
+		res := httptest.NewRecorder()
+		next := suite.NextHandler(http.StatusCreated, map[string]string{"Docker-Content-Digest": descriptor.Digest.String()})
+		SignatureMiddleware()(next).ServeHTTP(res, req)
+		suite.Equal(http.StatusCreated, res.Code)
+
+		accs, err := accessory.Mgr.List(suite.Context(), &q.Query{
+			Keywords: map[string]interface{}{
+				"SubjectArtifactID": subjectArtID,
+			},
+		})
+		suite.Nil(err)
+		suite.Equal(1, len(accs))
+		suite.Equal(artID, accs[0].GetData().ArtifactID)
+		suite.True(accs[0].IsHard())
+		suite.Equal(model.TypeInTotoAttestation, accs[0].GetData().Type)
+	})
+}
+
 func (suite *MiddlewareTestSuite) TestMatchManifestURLPattern() {
-	_, _, ok := matchCosignSignaturePattern("/v2/library/hello-world/manifests/.Invalid")
+	_, _, _, ok := matchCosignSignaturePattern("/v2/library/hello-world/manifests/.Invalid")
+	suite.False(ok)
+
+	_, _, _, ok = matchCosignSignaturePattern("/v2/")
 	suite.False(ok)
 
-	_, _, ok = matchCosignSignaturePattern("/v2/")
+	_, _, _, ok = matchCosignSignaturePattern("/v2/library/hello-world/manifests//")
 	suite.False(ok)
 
-	_, _, ok = matchCosignSignaturePattern("/v2/library/hello-world/manifests//")
+	_, _, _, ok = matchCosignSignaturePattern("/v2/library/hello-world/manifests/###")
 	suite.False(ok)
 
-	_, _, ok = matchCosignSignaturePattern("/v2/library/hello-world/manifests/###")
+	repository, _, _, ok := matchCosignSignaturePattern("/v2/library/hello-world/manifests/latest")
 	suite.False(ok)
 
-	repository, _, ok := matchCosignSignaturePattern("/v2/library/hello-world/manifests/latest")
+	_, _, _, ok = matchCosignSignaturePattern("/v2/library/hello-world/manifests/sha256:e5785cb0c62cebbed4965129bae371f0589cadd6d84798fb58c2c5f9e237efd9")
 	suite.False(ok)
 
-	_, _, ok = matchCosignSignaturePattern("/v2/library/hello-world/manifests/sha256:e5785cb0c62cebbed4965129bae371f0589cadd6d84798fb58c2c5f9e237efd9")
+	_, _, _, ok = matchCosignSignaturePattern("/v2/library/hello-world/manifests/sha256-e5785cb0c62cebbed4965129bae371f0589cadd6d84798fb58c2c5f9e237efd9.unknown")
 	suite.False(ok)
 
-	repository, reference, ok := matchCosignSignaturePattern("/v2/library/hello-world/manifests/sha256-e5785cb0c62cebbed4965129bae371f0589cadd6d84798fb58c2c5f9e237efd9.sig")
+	repository, reference, suffix, ok := matchCosignSignaturePattern("/v2/library/hello-world/manifests/sha256-e5785cb0c62cebbed4965129bae371f0589cadd6d84798fb58c2c5f9e237efd9.sig")
 	suite.True(ok)
 	suite.Equal("library/hello-world", repository)
 	suite.Equal("e5785cb0c62cebbed4965129bae371f0589cadd6d84798fb58c2c5f9e237efd9", reference)
+	suite.Equal("sig", suffix)
+
+	_, _, suffix, ok = matchCosignSignaturePattern("/v2/library/hello-world/manifests/sha256-e5785cb0c62cebbed4965129bae371f0589cadd6d84798fb58c2c5f9e237efd9.att")
+	suite.True(ok)
+	suite.Equal("att", suffix)
+
+	_, _, suffix, ok = matchCosignSignaturePattern("/v2/library/hello-world/manifests/sha256-e5785cb0c62cebbed4965129bae371f0589cadd6d84798fb58c2c5f9e237efd9.sbom")
+	suite.True(ok)
+	suite.Equal("sbom", suffix)
 }
 
 func TestMiddlewareTestSuite(t *testing.T) {
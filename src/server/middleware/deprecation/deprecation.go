@@ -0,0 +1,60 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deprecation
+
+import (
+	"net/http"
+	"time"
+
+	openapi "github.com/go-openapi/runtime/middleware"
+
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/server/middleware"
+)
+
+const (
+	// the extension of the operation in the swagger, its value is the date when the operation will be removed, e.g. "2027-06-30"
+	sunsetExtension = "x-sunset"
+	dateLayout      = "2006-01-02"
+)
+
+// Middleware returns a middleware which sets the "Deprecation" header in the responses of the operations
+// marked as deprecated in the swagger, and the "Sunset" header if the removal date is scheduled.
+// It must be used after the routing of the swagger API handler, e.g. as the inner middleware.
+func Middleware() func(http.Handler) http.Handler {
+	return middleware.New(func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		route := openapi.MatchedRouteFrom(r)
+		if route != nil && route.Operation != nil && route.Operation.Deprecated {
+			w.Header().Set("Deprecation", "true")
+			if sunset := sunsetOf(route); !sunset.IsZero() {
+				w.Header().Set("Sunset", sunset.Format(http.TimeFormat))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func sunsetOf(route *openapi.MatchedRoute) time.Time {
+	value, ok := route.Operation.Extensions.GetString(sunsetExtension)
+	if !ok {
+		return time.Time{}
+	}
+	sunset, err := time.Parse(dateLayout, value)
+	if err != nil {
+		log.Warningf("invalid %s %q of the operation %s: %v", sunsetExtension, value, route.Operation.ID, err)
+		return time.Time{}
+	}
+	return sunset
+}
@@ -0,0 +1,67 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deprecation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/runtime"
+	openapi "github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/runtime/middleware/untyped"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const spec = `{
+  "swagger": "2.0",
+  "info": {"title": "test", "version": "1.0"},
+  "basePath": "/api",
+  "paths": {
+    "/current": {"get": {"operationId": "current", "responses": {"200": {"description": "OK"}}}},
+    "/deprecated": {"get": {"operationId": "deprecated", "deprecated": true, "responses": {"200": {"description": "OK"}}}},
+    "/sunset": {"get": {"operationId": "sunset", "deprecated": true, "x-sunset": "2027-06-30", "responses": {"200": {"description": "OK"}}}}
+  }
+}`
+
+func TestMiddleware(t *testing.T) {
+	doc, err := loads.Analyzed([]byte(spec), "")
+	require.Nil(t, err)
+	api := untyped.NewAPI(doc)
+	for _, path := range []string{"/current", "/deprecated", "/sunset"} {
+		api.RegisterOperation(http.MethodGet, path, runtime.OperationHandlerFunc(func(interface{}) (interface{}, error) {
+			return nil, nil
+		}))
+	}
+	handler := openapi.ServeWithBuilder(doc, api, Middleware())
+
+	cases := []struct {
+		path        string
+		deprecation string
+		sunset      string
+	}{
+		{path: "/api/current"},
+		{path: "/api/deprecated", deprecation: "true"},
+		{path: "/api/sunset", deprecation: "true", sunset: "Wed, 30 Jun 2027 00:00:00 GMT"},
+	}
+	for _, c := range cases {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, c.path, nil))
+		assert.Equal(t, c.deprecation, rec.Header().Get("Deprecation"), c.path)
+		assert.Equal(t, c.sunset, rec.Header().Get("Sunset"), c.path)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/cache"
+	"github.com/goharbor/harbor/src/lib/errors"
+	lib_http "github.com/goharbor/harbor/src/lib/http"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/server/middleware"
+)
+
+const (
+	// HeaderIdempotencyKey is the header carrying the idempotency key specified by the client
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed is set to "true" in the response replayed for the duplicated request
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	keyPrefix      = "idempotency:"
+	maxKeyLength   = 255
+	pendingTimeout = 5 * time.Minute
+)
+
+var (
+	// the window during which the response is replayed for the duplicated requests
+	window = 24 * time.Hour
+	// the cache used to store the responses, the middleware is disabled if it returns nil
+	cacheFunc = cache.Default
+	// the response headers replayed for the duplicated requests
+	replayedHeaders = []string{"Content-Type", "Location"}
+)
+
+// record is the stored state of the request with the idempotency key
+type record struct {
+	// Fingerprint is the digest of the request, the key cannot be reused by a different request
+	Fingerprint string `json:"fingerprint"`
+	// Completed is false when the original request is still in progress
+	Completed  bool                `json:"completed"`
+	StatusCode int                 `json:"status_code"`
+	Header     map[string][]string `json:"header"`
+	Body       []byte              `json:"body"`
+}
+
+// Middleware replays the original response for the duplicated POST requests which carry the same "Idempotency-Key"
+// header within the window, so the clients can retry the creation safely. The key is scoped to the user and the
+// request path, and the responses with 5xx status code aren't stored to allow the retry
+func Middleware() func(http.Handler) http.Handler {
+	return middleware.New(func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		idempotencyKey := r.Header.Get(HeaderIdempotencyKey)
+		c := cacheFunc()
+		if r.Method != http.MethodPost || len(idempotencyKey) == 0 || c == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(idempotencyKey) > maxKeyLength {
+			lib_http.SendError(w, errors.BadRequestError(nil).
+				WithMessage("the length of the %s header cannot exceed %d", HeaderIdempotencyKey, maxKeyLength))
+			return
+		}
+
+		ctx := r.Context()
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			lib_http.SendError(w, err)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		key := cacheKey(r, idempotencyKey)
+		fingerprint := digest(r.Method, r.URL.RequestURI(), string(body))
+		rec := &record{}
+		if err = c.Fetch(ctx, key, rec); err == nil {
+			switch {
+			case rec.Fingerprint != fingerprint:
+				lib_http.SendError(w, errors.BadRequestError(nil).
+					WithMessage("the %s has been used by a different request", HeaderIdempotencyKey))
+			case !rec.Completed:
+				lib_http.SendError(w, errors.ConflictError(nil).
+					WithMessage("the request with the same %s is in progress", HeaderIdempotencyKey))
+			default:
+				replay(w, rec)
+			}
+			return
+		}
+		if !errors.Is(err, cache.ErrNotFound) {
+			log.G(ctx).Errorf("failed to fetch the idempotency record %s, skip the idempotency check: %v", key, err)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// mark the request as in progress
+		if err = c.Save(ctx, key, &record{Fingerprint: fingerprint}, pendingTimeout); err != nil {
+			log.G(ctx).Errorf("failed to save the idempotency record %s, skip the idempotency check: %v", key, err)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		res := lib.NewResponseBuffer(w)
+		next.ServeHTTP(res, r)
+		if _, err = res.Flush(); err != nil {
+			log.G(ctx).Errorf("failed to flush the response: %v", err)
+		}
+
+		// the server side errors aren't stored to allow the retry
+		if res.StatusCode() >= http.StatusInternalServerError {
+			if err = c.Delete(ctx, key); err != nil {
+				log.G(ctx).Errorf("failed to delete the idempotency record %s: %v", key, err)
+			}
+			return
+		}
+		rec = &record{
+			Fingerprint: fingerprint,
+			Completed:   true,
+			StatusCode:  res.StatusCode(),
+			Header:      map[string][]string{},
+			Body:        res.Buffer(),
+		}
+		for _, h := range replayedHeaders {
+			if values := res.Header().Values(h); len(values) > 0 {
+				rec.Header[h] = values
+			}
+		}
+		if err = c.Save(ctx, key, rec, window); err != nil {
+			log.G(ctx).Errorf("failed to save the idempotency record %s: %v", key, err)
+		}
+	})
+}
+
+// write the stored response into the response writer
+func replay(w http.ResponseWriter, rec *record) {
+	for k, vs := range rec.Header {
+		for _, v := range vs {
+			w.Header().Add(k, v)
+		}
+	}
+	w.Header().Set(HeaderIdempotentReplayed, "true")
+	w.WriteHeader(rec.StatusCode)
+	if _, err := w.Write(rec.Body); err != nil {
+		log.Errorf("failed to write the replayed response: %v", err)
+	}
+}
+
+// the key is scoped to the user and the request path
+func cacheKey(r *http.Request, idempotencyKey string) string {
+	username := ""
+	if sc, ok := security.FromContext(r.Context()); ok && sc.IsAuthenticated() {
+		username = sc.GetUsername()
+	}
+	return fmt.Sprintf("%s%s", keyPrefix, digest(username, r.URL.Path, idempotencyKey))
+}
+
+func digest(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		// the length prefix avoids the ambiguity of the concatenation
+		fmt.Fprintf(h, "%d:%s;", len(part), part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
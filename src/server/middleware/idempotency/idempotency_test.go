@@ -0,0 +1,107 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/cache"
+	_ "github.com/goharbor/harbor/src/lib/cache/memory"
+)
+
+type idempotencyTestSuite struct {
+	suite.Suite
+	created int
+	status  int
+	handler http.Handler
+}
+
+func (i *idempotencyTestSuite) SetupTest() {
+	c, err := cache.New("memory")
+	i.Require().Nil(err)
+	cacheFunc = func() cache.Cache { return c }
+
+	i.created = 0
+	i.status = http.StatusCreated
+	i.handler = Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i.created++
+		w.Header().Set("Location", "/api/v2.0/projects/1")
+		w.WriteHeader(i.status)
+	}))
+}
+
+func (i *idempotencyTestSuite) post(key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v2.0/projects", strings.NewReader(body))
+	if len(key) > 0 {
+		req.Header.Set(HeaderIdempotencyKey, key)
+	}
+	rr := httptest.NewRecorder()
+	i.handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func (i *idempotencyTestSuite) TestNoKey() {
+	i.Equal(http.StatusCreated, i.post("", `{"project_name":"library"}`).Code)
+	i.Equal(http.StatusCreated, i.post("", `{"project_name":"library"}`).Code)
+	i.Equal(2, i.created)
+}
+
+func (i *idempotencyTestSuite) TestReplay() {
+	rr := i.post("key", `{"project_name":"library"}`)
+	i.Equal(http.StatusCreated, rr.Code)
+	i.Empty(rr.Header().Get(HeaderIdempotentReplayed))
+
+	// the duplicated request gets the original response
+	rr = i.post("key", `{"project_name":"library"}`)
+	i.Equal(http.StatusCreated, rr.Code)
+	i.Equal("/api/v2.0/projects/1", rr.Header().Get("Location"))
+	i.Equal("true", rr.Header().Get(HeaderIdempotentReplayed))
+	i.Equal(1, i.created)
+
+	// the key reused by a different request
+	rr = i.post("key", `{"project_name":"library2"}`)
+	i.Equal(http.StatusBadRequest, rr.Code)
+	i.Equal(1, i.created)
+
+	// another key
+	i.Equal(http.StatusCreated, i.post("key2", `{"project_name":"library"}`).Code)
+	i.Equal(2, i.created)
+}
+
+func (i *idempotencyTestSuite) TestServerError() {
+	i.status = http.StatusInternalServerError
+	i.Equal(http.StatusInternalServerError, i.post("key", `{"project_name":"library"}`).Code)
+
+	// the server side error isn't stored, the retry is handled
+	i.status = http.StatusCreated
+	rr := i.post("key", `{"project_name":"library"}`)
+	i.Equal(http.StatusCreated, rr.Code)
+	i.Empty(rr.Header().Get(HeaderIdempotentReplayed))
+	i.Equal(2, i.created)
+}
+
+func (i *idempotencyTestSuite) TestInvalidKey() {
+	i.Equal(http.StatusBadRequest, i.post(strings.Repeat("a", maxKeyLength+1), "").Code)
+	i.Equal(0, i.created)
+}
+
+func TestIdempotencyTestSuite(t *testing.T) {
+	suite.Run(t, &idempotencyTestSuite{})
+}
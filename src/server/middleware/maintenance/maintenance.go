@@ -0,0 +1,75 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/lib/config"
+	cfgModels "github.com/goharbor/harbor/src/lib/config/models"
+	"github.com/goharbor/harbor/src/lib/errors"
+	lib_http "github.com/goharbor/harbor/src/lib/http"
+	"github.com/goharbor/harbor/src/server/middleware"
+)
+
+var (
+	// the function to get the setting of the maintenance mode, it's overridden in the tests
+	settingFunc = func(ctx context.Context) *cfgModels.MaintenanceSetting {
+		return config.Maintenance(ctx)
+	}
+
+	safeMethods = map[string]bool{
+		http.MethodGet:     true,
+		http.MethodHead:    true,
+		http.MethodOptions: true,
+	}
+)
+
+// Middleware rejects the writes with 503 and the "Retry-After" header when the maintenance mode takes effect, the
+// reads including the pulls keep working. The writes of the system admins via the APIs are allowed so that they can
+// operate the system, e.g. trigger the GC, during the maintenance. It must be placed after the security middleware
+func Middleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler {
+	skippers = append(skippers, func(r *http.Request) bool {
+		return safeMethods[r.Method]
+	})
+	return middleware.New(func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		now := time.Now()
+		setting := settingFunc(r.Context())
+		if !setting.Active(now) || isSysAdminAPI(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		seconds := int(math.Ceil(setting.RetryAfter(now).Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+		lib_http.SendError(w, errors.New(nil).WithCode(errors.ServiceUnavailableCode).
+			WithMessage("The system is in maintenance. Any modification is prohibited, retry after %d seconds.", seconds).
+			WithDetails(&errors.Detail{Reason: errors.ReasonMaintenance}))
+	}, skippers...)
+}
+
+func isSysAdminAPI(r *http.Request) bool {
+	if !strings.HasPrefix(r.URL.Path, "/api/") {
+		return false
+	}
+	sc, ok := security.FromContext(r.Context())
+	return ok && sc.IsSysAdmin()
+}
@@ -0,0 +1,97 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/common/security/local"
+	cfgModels "github.com/goharbor/harbor/src/lib/config/models"
+)
+
+type maintenanceTestSuite struct {
+	suite.Suite
+	setting *cfgModels.MaintenanceSetting
+	handler http.Handler
+}
+
+func (m *maintenanceTestSuite) SetupTest() {
+	m.setting = &cfgModels.MaintenanceSetting{Enabled: true}
+	settingFunc = func(ctx context.Context) *cfgModels.MaintenanceSetting { return m.setting }
+	m.handler = Middleware()(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func (m *maintenanceTestSuite) request(method, path string, sc security.Context) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if sc != nil {
+		req = req.WithContext(security.NewContext(req.Context(), sc))
+	}
+	rr := httptest.NewRecorder()
+	m.handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func (m *maintenanceTestSuite) TestDisabled() {
+	m.setting.Enabled = false
+	m.Equal(http.StatusOK, m.request(http.MethodPost, "/v2/library/hello-world/blobs/uploads/", nil).Code)
+}
+
+func (m *maintenanceTestSuite) TestNotStarted() {
+	start := time.Now().Add(time.Hour)
+	m.setting.StartTime = &start
+	m.Equal(http.StatusOK, m.request(http.MethodPost, "/v2/library/hello-world/blobs/uploads/", nil).Code)
+}
+
+func (m *maintenanceTestSuite) TestReadsAllowed() {
+	m.Equal(http.StatusOK, m.request(http.MethodGet, "/v2/library/hello-world/manifests/latest", nil).Code)
+	m.Equal(http.StatusOK, m.request(http.MethodHead, "/v2/library/hello-world/manifests/latest", nil).Code)
+	m.Equal(http.StatusOK, m.request(http.MethodGet, "/api/v2.0/projects", nil).Code)
+}
+
+func (m *maintenanceTestSuite) TestWritesRejected() {
+	end := time.Now().Add(10 * time.Minute)
+	m.setting.EndTime = &end
+	rr := m.request(http.MethodPost, "/v2/library/hello-world/blobs/uploads/", nil)
+	m.Equal(http.StatusServiceUnavailable, rr.Code)
+	m.Equal("600", rr.Header().Get("Retry-After"))
+	m.Contains(rr.Body.String(), "MAINTENANCE")
+
+	// the end time has passed
+	end = time.Now().Add(-time.Minute)
+	rr = m.request(http.MethodDelete, "/api/v2.0/projects/1", local.NewSecurityContext(&models.User{Username: "user"}))
+	m.Equal(http.StatusServiceUnavailable, rr.Code)
+	m.Equal("300", rr.Header().Get("Retry-After"))
+}
+
+func (m *maintenanceTestSuite) TestSysAdmin() {
+	admin := local.NewSecurityContext(&models.User{Username: "admin", SysAdminFlag: true})
+	m.Equal(http.StatusOK, m.request(http.MethodPost, "/api/v2.0/system/gc/schedule", admin).Code)
+	// the pushes of the system admins are rejected as well
+	m.Equal(http.StatusServiceUnavailable, m.request(http.MethodPost, "/v2/library/hello-world/blobs/uploads/", admin).Code)
+}
+
+func TestMaintenanceTestSuite(t *testing.T) {
+	suite.Run(t, &maintenanceTestSuite{})
+}
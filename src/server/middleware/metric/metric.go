@@ -32,12 +32,21 @@ const (
 
 // SetMetricOpID used to set operation ID for metrics
 func SetMetricOpID(ctx context.Context, value string) {
-	if config.Metric().Enabled {
-		v := ctx.Value(contextOpIDKey{}).(*string)
+	if v, ok := ctx.Value(contextOpIDKey{}).(*string); ok {
 		*v = value
 	}
 }
 
+// NewOpIDContext returns the context carrying the operation ID set by SetMetricOpID, the
+// operation ID carried by the parent context is reused if any
+func NewOpIDContext(ctx context.Context) (context.Context, *string) {
+	if v, ok := ctx.Value(contextOpIDKey{}).(*string); ok {
+		return ctx, v
+	}
+	op := ""
+	return context.WithValue(ctx, contextOpIDKey{}, &op), &op
+}
+
 func isChartMuseumURL(url string) bool {
 	return strings.HasPrefix(url, "/chartrepo/") || strings.HasPrefix(url, "/api/chartrepo/")
 }
@@ -46,9 +55,10 @@ func instrumentHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		metric.TotalInFlightGauge.Inc()
 		defer metric.TotalInFlightGauge.Dec()
-		now, rc, op := time.Now(), lib.NewResponseRecorder(w), ""
-		ctx := context.WithValue(r.Context(), contextOpIDKey{}, &op)
+		now, rc := time.Now(), lib.NewResponseRecorder(w)
+		ctx, opID := NewOpIDContext(r.Context())
 		next.ServeHTTP(rc, r.WithContext(ctx))
+		op := *opID
 		if len(op) == 0 {
 			if isChartMuseumURL(r.URL.Path) {
 				op = "chartmuseum"
@@ -57,8 +67,14 @@ func instrumentHandler(next http.Handler) http.Handler {
 				op = "unknown"
 			}
 		}
-		metric.TotalReqDurSummary.WithLabelValues(r.Method, op).Observe(time.Since(now).Seconds())
+		duration := time.Since(now).Seconds()
+		metric.TotalReqDurSummary.WithLabelValues(r.Method, op).Observe(duration)
+		metric.ReqDurHistogram.WithLabelValues(r.Method, op).Observe(duration)
 		metric.TotalReqCnt.WithLabelValues(r.Method, strconv.Itoa(rc.StatusCode), op).Inc()
+		if r.ContentLength > 0 {
+			metric.ReqSizeHistogram.WithLabelValues(r.Method, op).Observe(float64(r.ContentLength))
+		}
+		metric.RespSizeHistogram.WithLabelValues(r.Method, op).Observe(float64(rc.Size))
 	})
 }
 
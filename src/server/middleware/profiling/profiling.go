@@ -0,0 +1,60 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiling
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/lib/profiling"
+	"github.com/goharbor/harbor/src/server/middleware/metric"
+)
+
+// the count of the path segments kept in the route name when the request has no operation ID
+const maxRouteSegments = 3
+
+func profileHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		now := time.Now()
+		ctx, op := metric.NewOpIDContext(r.Context())
+		next.ServeHTTP(w, r.WithContext(ctx))
+		profiling.Record(profiling.KindRoute, routeName(r, *op), time.Since(now))
+	})
+}
+
+// routeName returns the method with the operation ID of the API, or with the leading segments
+// of the path for the legacy URLs to avoid the unbounded names
+func routeName(r *http.Request, op string) string {
+	if len(op) > 0 {
+		return r.Method + " " + op
+	}
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	path := "/" + strings.Join(segments, "/")
+	if len(segments) > maxRouteSegments {
+		path = "/" + strings.Join(segments[:maxRouteSegments], "/") + "/*"
+	}
+	return r.Method + " " + path
+}
+
+// Middleware returns a middleware recording the durations of the API routes into the profiling collector
+func Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !profiling.Enabled() {
+			return next
+		}
+		return profileHandler(next)
+	}
+}
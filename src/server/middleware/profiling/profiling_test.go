@@ -0,0 +1,32 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiling
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteName(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v2.0/projects/library/repositories", nil)
+	assert.Equal(t, "GET listRepositories", routeName(req, "listRepositories"))
+	assert.Equal(t, "GET /api/v2.0/projects/*", routeName(req, ""))
+
+	req = httptest.NewRequest(http.MethodPost, "/c/login", nil)
+	assert.Equal(t, "POST /c/login", routeName(req, ""))
+}
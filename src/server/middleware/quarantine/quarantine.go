@@ -0,0 +1,195 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quarantine
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/rbac"
+	rbac_project "github.com/goharbor/harbor/src/common/rbac/project"
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	lib_http "github.com/goharbor/harbor/src/lib/http"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/distribution"
+	"github.com/goharbor/harbor/src/server/middleware"
+	"github.com/goharbor/harbor/src/server/middleware/util"
+)
+
+// Middleware blocks pulling the quarantined artifact in GET /v2/<name>/manifests/<reference> API,
+// only the system admins and the project admins can pull it before it's released
+func Middleware() func(http.Handler) http.Handler {
+	return middleware.BeforeRequest(func(r *http.Request) error {
+		ctx := r.Context()
+
+		logger := log.G(ctx).WithFields(log.Fields{"middleware": "quarantine"})
+
+		none := lib.ArtifactInfo{}
+		info := lib.GetArtifactInfo(ctx)
+		if info == none {
+			return errors.New("artifactinfo middleware required before this middleware").WithCode(errors.NotFoundCode)
+		}
+
+		proj, err := projectController.Get(ctx, info.ProjectName)
+		if err != nil {
+			logger.Errorf("get the project %s failed, error: %v", info.ProjectName, err)
+			return err
+		}
+		if !proj.QuarantineEnabled() {
+			return nil
+		}
+
+		art, err := artifactController.GetByReference(ctx, info.Repository, info.Reference, nil)
+		if err != nil {
+			if !errors.IsNotFoundErr(err) {
+				logger.Errorf("get artifact failed, error %v", err)
+			}
+			return err
+		}
+		qt, err := quarantineController.Get(ctx, art.ID)
+		if err != nil {
+			if errors.IsNotFoundErr(err) {
+				return nil
+			}
+			logger.Errorf("get the quarantine of the artifact %s@%s failed, error: %v", art.RepositoryName, art.Digest, err)
+			return err
+		}
+
+		skip, err := util.SkipPolicyChecking(r, proj.ProjectID, art.ID)
+		if err != nil {
+			return err
+		}
+		if skip {
+			logger.Debugf("artifact %s@%s is pulling by the scanner/cosign, skip the checking", art.RepositoryName, art.Digest)
+			return nil
+		}
+
+		admin, err := isAdmin(ctx, proj.ProjectID)
+		if err != nil {
+			return err
+		}
+		if admin {
+			logger.Debugf("the quarantined artifact %s@%s is pulling by the admin", art.RepositoryName, art.Digest)
+			return nil
+		}
+
+		return errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).
+			WithMessage("current image is quarantined until it passes the scan and signature policies of the project: %s. "+
+				"To continue with pull, please contact your project administrator for help.", qt.Reason)
+	})
+}
+
+// PutManifestMiddleware quarantines the newly pushed artifact after PUT /v2/<name>/manifests/<reference> success,
+// it must be placed before the middlewares recording the accessories, so they are recorded when it's quarantined
+func PutManifestMiddleware() func(http.Handler) http.Handler {
+	return middleware.New(func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		ctx := r.Context()
+
+		logger := log.G(ctx).WithFields(log.Fields{"middleware": "quarantine"})
+
+		info := lib.GetArtifactInfo(ctx)
+		proj, err := projectController.GetByName(ctx, info.ProjectName)
+		if err != nil {
+			lib_http.SendError(w, err)
+			return
+		}
+		if !proj.QuarantineEnabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		lib.NopCloseRequest(r) // make the r.Body re-readable
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			lib_http.SendError(w, err)
+			return
+		}
+		_, descriptor, err := distribution.UnmarshalManifest(r.Header.Get("Content-Type"), body)
+		if err != nil {
+			logger.Errorf("unmarshal manifest failed, error: %v", err)
+			lib_http.SendError(w, errors.Wrapf(err, "unmarshal manifest failed").WithCode(errors.MANIFESTINVALID))
+			return
+		}
+
+		// only the artifacts which don't exist before are quarantined, pushing the existing ones with the new tags doesn't
+		_, err = artifactController.GetByReference(ctx, info.Repository, descriptor.Digest.String(), nil)
+		if err == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !errors.IsNotFoundErr(err) {
+			lib_http.SendError(w, err)
+			return
+		}
+
+		middleware.AfterResponse(func(w http.ResponseWriter, r *http.Request, statusCode int) error {
+			if statusCode != http.StatusCreated {
+				return nil
+			}
+			art, err := artifactController.GetByReference(ctx, info.Repository, descriptor.Digest.String(), nil)
+			if err != nil {
+				logger.Errorf("get artifact %s@%s failed, error: %v", info.Repository, descriptor.Digest.String(), err)
+				return err
+			}
+			if err = quarantineController.Quarantine(ctx, art); err != nil {
+				logger.Errorf("quarantine artifact %s@%s failed, error: %v", art.RepositoryName, art.Digest, err)
+				return err
+			}
+			return nil
+		})(next).ServeHTTP(w, r)
+	})
+}
+
+// isAdmin returns whether the puller is the system admin or the admin of the project, as the registry token
+// only carries the requested actions, the roles of the user are resolved by the username of the token
+func isAdmin(ctx context.Context, projectID int64) (bool, error) {
+	secCtx, ok := security.FromContext(ctx)
+	if !ok || !secCtx.IsAuthenticated() {
+		return false, nil
+	}
+	if secCtx.IsSysAdmin() {
+		return true, nil
+	}
+	if secCtx.Name() != "v2token" {
+		resource := rbac_project.NewNamespace(projectID).Resource(rbac.ResourceArtifactQuarantine)
+		return secCtx.Can(ctx, rbac.ActionDelete, resource), nil
+	}
+
+	u, err := userController.GetByName(ctx, secCtx.GetUsername())
+	if err != nil {
+		// the robot accounts aren't the users
+		if errors.IsNotFoundErr(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if u.SysAdminFlag {
+		return true, nil
+	}
+	roles, err := projectController.ListRoles(ctx, projectID, u)
+	if err != nil {
+		return false, err
+	}
+	for _, role := range roles {
+		if role == common.RoleProjectAdmin {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,206 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quarantine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common"
+	commonmodels "github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/quarantine"
+	"github.com/goharbor/harbor/src/controller/user"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/accessory"
+	accessorymodel "github.com/goharbor/harbor/src/pkg/accessory/model"
+	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/quarantine/model"
+	securitytesting "github.com/goharbor/harbor/src/testing/common/security"
+	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	quarantinetesting "github.com/goharbor/harbor/src/testing/controller/quarantine"
+	usertesting "github.com/goharbor/harbor/src/testing/controller/user"
+	"github.com/goharbor/harbor/src/testing/mock"
+	accessorytesting "github.com/goharbor/harbor/src/testing/pkg/accessory"
+)
+
+type middlewareTestSuite struct {
+	suite.Suite
+
+	originalArtifactController artifact.Controller
+	artifactController         *artifacttesting.Controller
+
+	originalProjectController project.Controller
+	projectController         *projecttesting.Controller
+
+	originalQuarantineController quarantine.Controller
+	quarantineController         *quarantinetesting.Controller
+
+	originalUserController user.Controller
+	userController         *usertesting.Controller
+
+	originalAccessMgr accessory.Manager
+	accessMgr         *accessorytesting.Manager
+
+	artifact *artifact.Artifact
+	project  *proModels.Project
+
+	next http.Handler
+}
+
+func (m *middlewareTestSuite) SetupTest() {
+	m.originalArtifactController = artifactController
+	m.artifactController = &artifacttesting.Controller{}
+	artifactController = m.artifactController
+
+	m.originalProjectController = projectController
+	m.projectController = &projecttesting.Controller{}
+	projectController = m.projectController
+
+	m.originalQuarantineController = quarantineController
+	m.quarantineController = &quarantinetesting.Controller{}
+	quarantineController = m.quarantineController
+
+	m.originalUserController = userController
+	m.userController = &usertesting.Controller{}
+	userController = m.userController
+
+	m.originalAccessMgr = accessory.Mgr
+	m.accessMgr = &accessorytesting.Manager{}
+	accessory.Mgr = m.accessMgr
+
+	m.artifact = &artifact.Artifact{}
+	m.artifact.ID = 1
+	m.artifact.ProjectID = 1
+	m.artifact.RepositoryName = "library/photon"
+	m.artifact.Digest = "digest"
+
+	m.project = &proModels.Project{
+		ProjectID: 1,
+		Name:      "library",
+		Metadata:  map[string]string{proModels.ProMetaQuarantine: "true"},
+	}
+
+	m.next = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (m *middlewareTestSuite) TearDownTest() {
+	artifactController = m.originalArtifactController
+	projectController = m.originalProjectController
+	quarantineController = m.originalQuarantineController
+	userController = m.originalUserController
+	accessory.Mgr = m.originalAccessMgr
+}
+
+func (m *middlewareTestSuite) makeRequest(secCtx security.Context) *http.Request {
+	req := httptest.NewRequest("GET", "/v2/library/photon/manifests/2.0", nil)
+	info := lib.ArtifactInfo{
+		ProjectName: "library",
+		Repository:  "library/photon",
+		Reference:   "2.0",
+		Tag:         "2.0",
+	}
+	ctx := lib.WithArtifactInfo(req.Context(), info)
+	if secCtx != nil {
+		ctx = security.NewContext(ctx, secCtx)
+	}
+	return req.WithContext(ctx)
+}
+
+func (m *middlewareTestSuite) mockQuarantined() {
+	mock.OnAnything(m.projectController, "Get").Return(m.project, nil)
+	mock.OnAnything(m.artifactController, "GetByReference").Return(m.artifact, nil)
+	mock.OnAnything(m.accessMgr, "List").Return([]accessorymodel.Accessory{}, nil)
+	m.quarantineController.On("Get", mock.Anything, int64(1)).Return(&model.Quarantine{ArtifactID: 1, Reason: "the artifact isn't scanned"}, nil)
+}
+
+func (m *middlewareTestSuite) TestQuarantineDisabled() {
+	m.project.Metadata[proModels.ProMetaQuarantine] = "false"
+	mock.OnAnything(m.projectController, "Get").Return(m.project, nil)
+
+	rr := httptest.NewRecorder()
+	Middleware()(m.next).ServeHTTP(rr, m.makeRequest(nil))
+	m.Equal(http.StatusOK, rr.Code)
+}
+
+func (m *middlewareTestSuite) TestNotQuarantined() {
+	mock.OnAnything(m.projectController, "Get").Return(m.project, nil)
+	mock.OnAnything(m.artifactController, "GetByReference").Return(m.artifact, nil)
+	mock.OnAnything(m.quarantineController, "Get").Return(nil, errors.NotFoundError(nil))
+
+	rr := httptest.NewRecorder()
+	Middleware()(m.next).ServeHTTP(rr, m.makeRequest(nil))
+	m.Equal(http.StatusOK, rr.Code)
+}
+
+func (m *middlewareTestSuite) TestQuarantined() {
+	m.mockQuarantined()
+	secCtx := &securitytesting.Context{}
+	mock.OnAnything(secCtx, "Name").Return("v2token")
+	mock.OnAnything(secCtx, "IsAuthenticated").Return(true)
+	mock.OnAnything(secCtx, "IsSysAdmin").Return(false)
+	mock.OnAnything(secCtx, "GetUsername").Return("developer")
+	mock.OnAnything(secCtx, "Can").Return(false)
+	m.userController.On("GetByName", mock.Anything, "developer").Return(&commonmodels.User{UserID: 2}, nil)
+	mock.OnAnything(m.projectController, "ListRoles").Return([]int{common.RoleDeveloper}, nil)
+
+	rr := httptest.NewRecorder()
+	Middleware()(m.next).ServeHTTP(rr, m.makeRequest(secCtx))
+	m.Equal(http.StatusPreconditionFailed, rr.Code)
+}
+
+func (m *middlewareTestSuite) TestProjectAdminPulling() {
+	m.mockQuarantined()
+	secCtx := &securitytesting.Context{}
+	mock.OnAnything(secCtx, "Name").Return("v2token")
+	mock.OnAnything(secCtx, "IsAuthenticated").Return(true)
+	mock.OnAnything(secCtx, "IsSysAdmin").Return(false)
+	mock.OnAnything(secCtx, "GetUsername").Return("admin")
+	mock.OnAnything(secCtx, "Can").Return(false)
+	m.userController.On("GetByName", mock.Anything, "admin").Return(&commonmodels.User{UserID: 2}, nil)
+	mock.OnAnything(m.projectController, "ListRoles").Return([]int{common.RoleProjectAdmin}, nil)
+
+	rr := httptest.NewRecorder()
+	Middleware()(m.next).ServeHTTP(rr, m.makeRequest(secCtx))
+	m.Equal(http.StatusOK, rr.Code)
+}
+
+func (m *middlewareTestSuite) TestRobotPulling() {
+	m.mockQuarantined()
+	secCtx := &securitytesting.Context{}
+	mock.OnAnything(secCtx, "Name").Return("v2token")
+	mock.OnAnything(secCtx, "IsAuthenticated").Return(true)
+	mock.OnAnything(secCtx, "IsSysAdmin").Return(false)
+	mock.OnAnything(secCtx, "GetUsername").Return("robot$library+ci")
+	mock.OnAnything(secCtx, "Can").Return(false)
+	mock.OnAnything(m.userController, "GetByName").Return(nil, errors.NotFoundError(nil))
+
+	rr := httptest.NewRecorder()
+	Middleware()(m.next).ServeHTTP(rr, m.makeRequest(secCtx))
+	m.Equal(http.StatusPreconditionFailed, rr.Code)
+}
+
+func TestMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, &middlewareTestSuite{})
+}
@@ -132,7 +132,7 @@ func copyArtifactResources(r *http.Request, _, referenceID string) (types.Resour
 		}
 	}
 
-	return types.ResourceList{types.ResourceStorage: size}, nil
+	return types.ResourceList{types.ResourceStorage: size, types.ResourceCount: int64(len(artifactDigests))}, nil
 }
 
 func copyArtifactResourcesEvent(level int) func(*http.Request, string, string, string) event.Metadata {
@@ -19,6 +19,7 @@ import (
 	"strconv"
 
 	"github.com/goharbor/harbor/src/controller/blob"
+	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/blob/models"
@@ -53,6 +54,19 @@ func putManifestResources(r *http.Request, reference, referenceID string) (types
 	}
 
 	if exist {
+		// the manifest is in the project already, only the count is requested when it's pushed to another repository
+		repository := lib.GetArtifactInfo(r.Context()).Repository
+		if len(repository) == 0 {
+			return nil, nil
+		}
+		_, err := artifactController.GetByReference(r.Context(), repository, descriptor.Digest.String(), nil)
+		if errors.IsNotFoundErr(err) {
+			return types.ResourceList{types.ResourceCount: 1}, nil
+		}
+		if err != nil {
+			logger.Errorf("get artifact %s@%s failed, error: %v", repository, descriptor.Digest.String(), err)
+			return nil, err
+		}
 		return nil, nil
 	}
 
@@ -78,5 +92,5 @@ func putManifestResources(r *http.Request, reference, referenceID string) (types
 		}
 	}
 
-	return types.ResourceList{types.ResourceStorage: size}, nil
+	return types.ResourceList{types.ResourceStorage: size, types.ResourceCount: 1}, nil
 }
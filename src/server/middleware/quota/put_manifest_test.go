@@ -25,6 +25,7 @@ import (
 	"github.com/docker/distribution/manifest/schema2"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/pkg/blob/models"
 	"github.com/goharbor/harbor/src/pkg/distribution"
@@ -84,13 +85,35 @@ func (suite *PutManifestMiddlewareTestSuite) TestMiddleware() {
 		suite.Equal(http.StatusOK, rr.Code)
 	}
 
+	{
+		// manifest associated with project but pushed to another repository
+		mock.OnAnything(suite.blobController, "Exist").Return(true, nil).Once()
+		mock.OnAnything(suite.artifactController, "GetByReference").Return(nil, errors.NotFoundError(nil)).Once()
+		mock.OnAnything(suite.quotaController, "Request").Return(nil).Once().Run(func(args mock.Arguments) {
+			resources := args.Get(3).(types.ResourceList)
+			suite.Equal(types.ResourceList{types.ResourceCount: 1}, resources)
+
+			f := args.Get(4).(func() error)
+			f()
+		})
+		mock.OnAnything(suite.quotaController, "GetByRef").Return(&quota.Quota{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPut, "/v2/library/photon/manifests/2.0", nil)
+		req = req.WithContext(lib.WithArtifactInfo(req.Context(), lib.ArtifactInfo{Repository: "library/photon"}))
+		rr := httptest.NewRecorder()
+
+		PutManifestMiddleware()(next).ServeHTTP(rr, req)
+		suite.Equal(http.StatusOK, rr.Code)
+	}
+
 	{
 		// manifest not associated with project and blobs are already associated with project
 		mock.OnAnything(suite.blobController, "Exist").Return(false, nil).Once()
 		mock.OnAnything(suite.blobController, "FindMissingAssociationsForProject").Return(nil, nil).Once()
 		mock.OnAnything(suite.quotaController, "Request").Return(nil).Once().Run(func(args mock.Arguments) {
 			resources := args.Get(3).(types.ResourceList)
-			suite.Len(resources, 1)
+			suite.Len(resources, 2)
+			suite.Equal(int64(1), resources[types.ResourceCount])
 			suite.Equal(resources[types.ResourceStorage], int64(100))
 
 			f := args.Get(4).(func() error)
@@ -116,7 +139,8 @@ func (suite *PutManifestMiddlewareTestSuite) TestMiddleware() {
 		mock.OnAnything(suite.blobController, "FindMissingAssociationsForProject").Return(missing, nil).Once()
 		mock.OnAnything(suite.quotaController, "Request").Return(nil).Once().Run(func(args mock.Arguments) {
 			resources := args.Get(3).(types.ResourceList)
-			suite.Len(resources, 1)
+			suite.Len(resources, 2)
+			suite.Equal(int64(1), resources[types.ResourceCount])
 			suite.Equal(resources[types.ResourceStorage], int64(100+10))
 
 			f := args.Get(4).(func() error)
@@ -142,7 +166,8 @@ func (suite *PutManifestMiddlewareTestSuite) TestMiddleware() {
 		mock.OnAnything(suite.blobController, "FindMissingAssociationsForProject").Return(missing, nil).Once()
 		mock.OnAnything(suite.quotaController, "Request").Return(nil).Once().Run(func(args mock.Arguments) {
 			resources := args.Get(3).(types.ResourceList)
-			suite.Len(resources, 1)
+			suite.Len(resources, 2)
+			suite.Equal(int64(1), resources[types.ResourceCount])
 			suite.Equal(resources[types.ResourceStorage], int64(100+20))
 
 			f := args.Get(4).(func() error)
@@ -168,7 +193,8 @@ func (suite *PutManifestMiddlewareTestSuite) TestMiddleware() {
 		mock.OnAnything(suite.blobController, "FindMissingAssociationsForProject").Return(missing, nil).Once()
 		mock.OnAnything(suite.quotaController, "Request").Return(nil).Once().Run(func(args mock.Arguments) {
 			resources := args.Get(3).(types.ResourceList)
-			suite.Len(resources, 1)
+			suite.Len(resources, 2)
+			suite.Equal(int64(1), resources[types.ResourceCount])
 			suite.Equal(resources[types.ResourceStorage], int64(100))
 
 			f := args.Get(4).(func() error)
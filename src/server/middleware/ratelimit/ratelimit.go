@@ -0,0 +1,147 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/lib/config"
+	cfgModels "github.com/goharbor/harbor/src/lib/config/models"
+	"github.com/goharbor/harbor/src/lib/errors"
+	lib_http "github.com/goharbor/harbor/src/lib/http"
+	"github.com/goharbor/harbor/src/lib/metric"
+	"github.com/goharbor/harbor/src/pkg/networkpolicy"
+	"github.com/goharbor/harbor/src/server/middleware"
+)
+
+const (
+	principalUser  = "user"
+	principalRobot = "robot"
+	principalIP    = "ip"
+
+	// the limiters which aren't used in this duration are removed
+	idleTimeout = 10 * time.Minute
+)
+
+var (
+	limiters = &registry{limiters: map[string]*limiter{}}
+	// the function to get the setting of the rate limits, it's overridden in the tests
+	settingFunc = func(ctx context.Context) *cfgModels.RateLimitSetting {
+		return config.APIRateLimit(ctx)
+	}
+)
+
+// Middleware limits the rate of the requests per principal, the authenticated requests are limited by the user
+// or the robot account and the anonymous ones are limited by the client IP. The request exceeding the limit is
+// rejected with 429 and the "Retry-After" header. The requests of the solution users, e.g. the job service,
+// aren't limited. It must be placed after the security middleware to get the principal of the request
+func Middleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler {
+	return middleware.New(func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		setting := settingFunc(r.Context())
+		principal, key, limit := identify(r, setting)
+		if limit <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		burst := setting.Burst
+		if burst < limit {
+			burst = limit
+		}
+		reservation := limiters.get(key, limit, burst, time.Now()).Reserve()
+		if delay := reservation.Delay(); delay > 0 {
+			reservation.Cancel()
+			metric.RateLimitedReqCnt.WithLabelValues(principal).Inc()
+			seconds := int(math.Ceil(delay.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+			lib_http.SendError(w, errors.New(nil).WithCode(errors.TooManyRequestsCode).
+				WithMessage("the rate limit of %d requests per second is exceeded, retry after %d seconds", limit, seconds))
+			return
+		}
+		next.ServeHTTP(w, r)
+	}, skippers...)
+}
+
+// identify returns the type and the key of the principal of the request, and the rate limit applied to it
+func identify(r *http.Request, setting *cfgModels.RateLimitSetting) (string, string, int) {
+	if sc, ok := security.FromContext(r.Context()); ok && sc.IsAuthenticated() {
+		if sc.IsSolutionUser() {
+			return "", "", 0
+		}
+		if sc.Name() == "robot" {
+			return principalRobot, principalRobot + ":" + sc.GetUsername(), setting.Robot
+		}
+		return principalUser, principalUser + ":" + sc.GetUsername(), setting.User
+	}
+	return principalIP, principalIP + ":" + clientIP(r), setting.IP
+}
+
+// clientIP returns the IP of the client, the addresses in the true client IP header set by the client are
+// ignored, otherwise the limit could be bypassed by forging the header
+func clientIP(r *http.Request) string {
+	if ip := networkpolicy.ClientIP(r); ip != nil {
+		return ip.String()
+	}
+	return r.RemoteAddr
+}
+
+type limiter struct {
+	*rate.Limiter
+	limit    int
+	burst    int
+	lastSeen time.Time
+}
+
+// registry holds the limiters of the principals in memory, so the limits take effect per core instance
+type registry struct {
+	sync.Mutex
+	limiters  map[string]*limiter
+	lastSweep time.Time
+}
+
+func (r *registry) get(key string, limit, burst int, now time.Time) *rate.Limiter {
+	r.Lock()
+	defer r.Unlock()
+
+	if now.Sub(r.lastSweep) > idleTimeout {
+		for k, l := range r.limiters {
+			if now.Sub(l.lastSeen) > idleTimeout {
+				delete(r.limiters, k)
+			}
+		}
+		r.lastSweep = now
+	}
+
+	l, ok := r.limiters[key]
+	// recreate the limiter when the setting is changed
+	if !ok || l.limit != limit || l.burst != burst {
+		l = &limiter{
+			Limiter: rate.NewLimiter(rate.Limit(limit), burst),
+			limit:   limit,
+			burst:   burst,
+		}
+		r.limiters[key] = l
+	}
+	l.lastSeen = now
+	return l.Limiter
+}
@@ -0,0 +1,125 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/common/security/local"
+	robotSec "github.com/goharbor/harbor/src/common/security/robot"
+	"github.com/goharbor/harbor/src/controller/robot"
+	cfgModels "github.com/goharbor/harbor/src/lib/config/models"
+	robotModel "github.com/goharbor/harbor/src/pkg/robot/model"
+)
+
+type rateLimitTestSuite struct {
+	suite.Suite
+	setting *cfgModels.RateLimitSetting
+	handler http.Handler
+}
+
+func (r *rateLimitTestSuite) SetupTest() {
+	limiters = &registry{limiters: map[string]*limiter{}}
+	r.setting = &cfgModels.RateLimitSetting{User: 1, Robot: 2, IP: 1}
+	settingFunc = func(ctx context.Context) *cfgModels.RateLimitSetting { return r.setting }
+	r.handler = Middleware()(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func (r *rateLimitTestSuite) request(sc security.Context, ip string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v2.0/projects", nil)
+	req.RemoteAddr = ip + ":12345"
+	if sc != nil {
+		req = req.WithContext(security.NewContext(req.Context(), sc))
+	}
+	rr := httptest.NewRecorder()
+	r.handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func (r *rateLimitTestSuite) TestUser() {
+	admin := local.NewSecurityContext(&models.User{Username: "admin"})
+	r.Equal(http.StatusOK, r.request(admin, "10.0.0.1").Code)
+	// the limit is per user rather than per IP
+	rr := r.request(admin, "10.0.0.2")
+	r.Equal(http.StatusTooManyRequests, rr.Code)
+	r.Equal("1", rr.Header().Get("Retry-After"))
+
+	// another user isn't affected
+	r.Equal(http.StatusOK, r.request(local.NewSecurityContext(&models.User{Username: "user"}), "10.0.0.1").Code)
+}
+
+func (r *rateLimitTestSuite) TestRobot() {
+	sc := robotSec.NewSecurityContext(&robot.Robot{Robot: robotModel.Robot{Name: "robot$ci"}})
+	r.Equal(http.StatusOK, r.request(sc, "10.0.0.1").Code)
+	r.Equal(http.StatusOK, r.request(sc, "10.0.0.1").Code)
+	r.Equal(http.StatusTooManyRequests, r.request(sc, "10.0.0.1").Code)
+}
+
+func (r *rateLimitTestSuite) TestIP() {
+	r.Equal(http.StatusOK, r.request(nil, "10.0.0.1").Code)
+	r.Equal(http.StatusTooManyRequests, r.request(nil, "10.0.0.1").Code)
+	r.Equal(http.StatusOK, r.request(nil, "10.0.0.2").Code)
+
+	// the burst
+	r.setting.Burst = 3
+	for i := 0; i < 3; i++ {
+		r.Equal(http.StatusOK, r.request(nil, "10.0.0.3").Code)
+	}
+	r.Equal(http.StatusTooManyRequests, r.request(nil, "10.0.0.3").Code)
+}
+
+func (r *rateLimitTestSuite) TestForgedForwardedFor() {
+	send := func(forwarded string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v2.0/projects", nil)
+		req.RemoteAddr = "10.0.0.10:12345"
+		req.Header.Set("X-Forwarded-For", forwarded)
+		rr := httptest.NewRecorder()
+		r.handler.ServeHTTP(rr, req)
+		return rr.Code
+	}
+	// the client rotates the first address while the proxy appends the real one
+	r.Equal(http.StatusOK, send("1.1.1.1, 192.168.0.1"))
+	r.Equal(http.StatusTooManyRequests, send("2.2.2.2, 192.168.0.1"))
+	r.Equal(http.StatusOK, send("1.1.1.1, 192.168.0.2"))
+}
+
+func (r *rateLimitTestSuite) TestNoLimit() {
+	r.setting.IP = 0
+	for i := 0; i < 5; i++ {
+		r.Equal(http.StatusOK, r.request(nil, "10.0.0.1").Code)
+	}
+}
+
+func (r *rateLimitTestSuite) TestSweep() {
+	now := time.Now()
+	limiters.get("ip:10.0.0.1", 1, 1, now)
+	limiters.get("ip:10.0.0.2", 1, 1, now.Add(idleTimeout+time.Second))
+	r.Len(limiters.limiters, 1)
+	r.Contains(limiters.limiters, "ip:10.0.0.2")
+}
+
+func TestRateLimitTestSuite(t *testing.T) {
+	suite.Run(t, &rateLimitTestSuite{})
+}
@@ -100,7 +100,8 @@ func preCheck(ctx context.Context) (art lib.ArtifactInfo, p *proModels.Project,
 		return none, nil, nil, errors.New("artifactinfo is not found").WithCode(errors.NotFoundCode)
 	}
 	ctl = proxy.ControllerInstance()
-	p, err = project.Ctl.GetByName(ctx, art.ProjectName, project.Metadata(false))
+	// the metadata contains the TTLs of the proxy cache
+	p, err = project.Ctl.GetByName(ctx, art.ProjectName)
 	return
 }
 
@@ -171,7 +172,7 @@ func handleManifest(w http.ResponseWriter, r *http.Request, next http.Handler) e
 	if err != nil {
 		return err
 	}
-	useLocal, man, err := proxyCtl.UseLocalManifest(ctx, art, remote)
+	useLocal, man, err := proxyCtl.UseLocalManifest(ctx, p, art, remote)
 
 	if err != nil {
 		return err
@@ -212,7 +213,7 @@ func handleManifest(w http.ResponseWriter, r *http.Request, next http.Handler) e
 }
 
 func proxyManifestGet(ctx context.Context, w http.ResponseWriter, ctl proxy.Controller, p *proModels.Project, art lib.ArtifactInfo, remote proxy.RemoteInterface) error {
-	man, err := ctl.ProxyManifest(ctx, art, remote)
+	man, err := ctl.ProxyManifest(ctx, p, art, remote)
 	if err != nil {
 		return err
 	}
@@ -21,6 +21,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	oteltrace "go.opentelemetry.io/otel/trace"
 
+	"github.com/goharbor/harbor/src/lib"
 	tracelib "github.com/goharbor/harbor/src/lib/trace"
 	"github.com/goharbor/harbor/src/server/middleware"
 )
@@ -28,7 +29,8 @@ import (
 // HeaderXRequestID X-Request-ID header
 const HeaderXRequestID = "X-Request-ID"
 
-// Middleware middleware which add X-Request-ID header in the http request when not exist
+// Middleware middleware which add X-Request-ID header in the http request when not exist,
+// the request ID is also set into the context of the request
 func Middleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler {
 	return middleware.New(func(w http.ResponseWriter, r *http.Request, next http.Handler) {
 		rid := r.Header.Get(HeaderXRequestID)
@@ -40,6 +42,6 @@ func Middleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler
 		if tracelib.Enabled() {
 			oteltrace.SpanFromContext(r.Context()).SetAttributes(attribute.Key(HeaderXRequestID).String(rid))
 		}
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(lib.WithRequestID(r.Context(), rid)))
 	}, skippers...)
 }
@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/lib"
 )
 
 func TestRequestID(t *testing.T) {
@@ -44,4 +46,12 @@ func TestRequestID(t *testing.T) {
 	rec3 := httptest.NewRecorder()
 	Middleware()(next).ServeHTTP(rec3, req3)
 	assert.Equal("852803be-e5fe-499b-bbea-c9e5b5f43916", rec3.Header().Get(HeaderXRequestID))
+
+	var rid string
+	req4 := httptest.NewRequest(http.MethodGet, "/req4", nil)
+	req4.Header.Add(HeaderXRequestID, "852803be-e5fe-499b-bbea-c9e5b5f43916")
+	Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rid = lib.GetRequestID(r.Context())
+	})).ServeHTTP(httptest.NewRecorder(), req4)
+	assert.Equal("852803be-e5fe-499b-bbea-c9e5b5f43916", rid)
 }
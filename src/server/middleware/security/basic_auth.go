@@ -16,33 +16,23 @@ package security
 
 import (
 	"net/http"
-	"os"
 
 	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/common/security"
 	"github.com/goharbor/harbor/src/common/security/local"
 	"github.com/goharbor/harbor/src/core/auth"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/networkpolicy"
 )
 
 type basicAuth struct{}
 
-func trueClientIPHeaderName() string {
-	// because the true client IP header varies based on the foreground proxy/lb settings,
-	// make it configurable by env
-	name := os.Getenv("TRUE_CLIENT_IP_HEADER")
-	if len(name) == 0 {
-		name = "x-forwarded-for"
-	}
-	return name
-}
-
 // GetClientIP get client ip from request
 func GetClientIP(r *http.Request) string {
 	if r == nil {
 		return ""
 	}
-	ip := r.Header.Get(trueClientIPHeaderName())
+	ip := r.Header.Get(networkpolicy.ClientIPHeader())
 	if len(ip) > 0 {
 		return ip
 	}
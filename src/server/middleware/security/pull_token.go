@@ -0,0 +1,42 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/common/security/pulltoken"
+	pulltoken_ctl "github.com/goharbor/harbor/src/controller/pulltoken"
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+type pullToken struct{}
+
+func (p *pullToken) Generate(req *http.Request) security.Context {
+	log := log.G(req.Context())
+	name, secret, ok := req.BasicAuth()
+	if !ok || !strings.HasPrefix(name, pulltoken_ctl.NamePrefix) {
+		return nil
+	}
+	t, err := pulltoken_ctl.Ctl.Authenticate(req.Context(), name, secret)
+	if err != nil {
+		log.Errorf("failed to authenticate pull token %s: %v", name, err)
+		return nil
+	}
+	log.Debugf("a pull token security context generated for request %s %s", req.Method, req.URL.Path)
+	return pulltoken.NewSecurityContext(t)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/networkpolicy"
 )
 
 type robot struct{}
@@ -68,6 +69,11 @@ func (r *robot) Generate(req *http.Request) security.Context {
 		return nil
 	}
 
+	if !robot.NetworkPolicy().Allows(networkpolicy.ClientIP(req)) {
+		log.Errorf("the robot account %s isn't usable from %v", name, networkpolicy.ClientIP(req))
+		return nil
+	}
+
 	log.Infof("a robot security context generated for request %s %s", req.Method, req.URL.Path)
 	return robotCtx.NewSecurityContext(robot)
 }
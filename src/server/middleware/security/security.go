@@ -15,9 +15,12 @@
 package security
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/common/security/local"
+	"github.com/goharbor/harbor/src/controller/usergroup"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/log"
@@ -32,6 +35,7 @@ var (
 		&idToken{},
 		&authProxy{},
 		&robot{},
+		&pullToken{},
 		&basicAuth{},
 		&session{},
 		&proxyCacheSecret{},
@@ -55,6 +59,12 @@ func Middleware(skippers ...middleware.Skipper) func(http.Handler) http.Handler
 		}
 		for _, generator := range generators {
 			if ctx := generator.Generate(r); ctx != nil {
+				// the deactivated users are treated as the anonymous ones whatever the credential they carry
+				if deactivated(ctx) {
+					log.Debugf("the user %s is deactivated", ctx.GetUsername())
+					break
+				}
+				populateProvisionedGroups(r.Context(), ctx)
 				r = r.WithContext(security.NewContext(r.Context(), ctx))
 				break
 			}
@@ -75,3 +85,40 @@ func UnauthorizedMiddleware(skippers ...middleware.Skipper) func(http.Handler) h
 		next.ServeHTTP(w, r)
 	}, skippers...)
 }
+
+// populateProvisionedGroups appends the groups which the user is added into via SCIM to the user of the local security context
+func populateProvisionedGroups(ctx context.Context, sc security.Context) {
+	lsc, ok := sc.(*local.SecurityContext)
+	if !ok || lsc.User() == nil || lsc.User().UserID == 0 || len(config.SCIMToken(ctx)) == 0 {
+		return
+	}
+	user := lsc.User()
+	groupIDs, err := usergroup.Ctl.ListGroupIDsByUser(ctx, user.UserID)
+	if err != nil {
+		log.G(ctx).Warningf("failed to list the provisioned groups of user %s: %v", user.Username, err)
+		return
+	}
+	// copy the group IDs to avoid changing the ones shared with the session
+	ids := append([]int{}, user.GroupIDs...)
+	for _, id := range groupIDs {
+		if !containsInt(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	user.GroupIDs = ids
+}
+
+// deactivated returns whether the user of the local security context is deactivated
+func deactivated(sc security.Context) bool {
+	lsc, ok := sc.(*local.SecurityContext)
+	return ok && lsc.User() != nil && lsc.User().Deactivated
+}
+
+func containsInt(ints []int, i int) bool {
+	for _, v := range ints {
+		if v == i {
+			return true
+		}
+	}
+	return false
+}
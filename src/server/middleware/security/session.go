@@ -44,6 +44,15 @@ func (s *session) Generate(req *http.Request) security.Context {
 		log.Warning("can not convert the user in session to user model")
 		return nil
 	}
+	// the user is stored in the session when login, reload the flag to logout the user deactivated after that
+	if user.UserID != 0 {
+		u, err := uctl.Get(req.Context(), user.UserID, nil)
+		if err != nil {
+			log.Errorf("failed to get the user %d of the session: %v", user.UserID, err)
+			return nil
+		}
+		user.Deactivated = u.Deactivated
+	}
 	log.Debugf("a session security context generated for request %s %s", req.Method, req.URL.Path)
 	return local.NewSecurityContext(&user)
 }
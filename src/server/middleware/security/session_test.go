@@ -26,6 +26,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/security/local"
+	testingUser "github.com/goharbor/harbor/src/testing/controller/user"
+	"github.com/goharbor/harbor/src/testing/mock"
 )
 
 func TestSession(t *testing.T) {
@@ -56,7 +59,20 @@ func TestSession(t *testing.T) {
 	err = store.Set(req.Context(), "user", user)
 	require.Nil(t, err)
 
+	testCtl := &testingUser.Controller{}
+	testCtl.On("Get", mock.Anything, 1, mock.Anything).Return(&models.User{UserID: 1, Username: "admin"}, nil).Once()
+	origin := uctl
+	uctl = testCtl
+	defer func() { uctl = origin }()
+
 	session := &session{}
 	ctx := session.Generate(req)
 	assert.NotNil(t, ctx)
+	assert.False(t, ctx.(*local.SecurityContext).User().Deactivated)
+
+	// the user is deactivated after login
+	testCtl.On("Get", mock.Anything, 1, mock.Anything).Return(&models.User{UserID: 1, Username: "admin", Deactivated: true}, nil).Once()
+	ctx = session.Generate(req)
+	assert.NotNil(t, ctx)
+	assert.True(t, deactivated(ctx))
 }
@@ -6,11 +6,16 @@ import (
 	"strings"
 
 	registry_token "github.com/docker/distribution/registry/auth/token"
+	"github.com/golang-jwt/jwt/v4"
 
 	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/common/security/pulltoken"
 	"github.com/goharbor/harbor/src/common/security/v2token"
+	pulltoken_ctl "github.com/goharbor/harbor/src/controller/pulltoken"
+	"github.com/goharbor/harbor/src/controller/tokenkey"
 	svc_token "github.com/goharbor/harbor/src/core/service/token"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/networkpolicy"
 	"github.com/goharbor/harbor/src/pkg/token"
 	v2 "github.com/goharbor/harbor/src/pkg/token/claims/v2"
 )
@@ -42,13 +47,14 @@ func (vt *v2Token) Generate(req *http.Request) security.Context {
 		return nil
 	}
 
-	defaultOpt := token.DefaultTokenOptions()
-	if defaultOpt == nil {
-		logger.Warningf("failed to get default options")
+	// the token is verified by the key it is signed with, the replaced keys are still valid during the rotation
+	opt, err := tokenkey.Ctl.VerifyingOptions(req.Context(), keyID(tokenStr))
+	if err != nil {
+		logger.Warningf("failed to get the key to verify the bearer token: %v", err)
 		return nil
 	}
 	cl := &v2TokenClaims{}
-	t, err := token.Parse(defaultOpt, tokenStr, cl)
+	t, err := token.Parse(opt, tokenStr, cl)
 	if err != nil {
 		logger.Warningf("failed to decode bearer token: %v", err)
 		return nil
@@ -62,5 +68,35 @@ func (vt *v2Token) Generate(req *http.Request) security.Context {
 		logger.Warningf("invalid token claims.")
 		return nil
 	}
+	// the token bound to the networks of the projects and robot isn't usable from the others even if it's leaked
+	if !claims.AllowsClient(networkpolicy.ClientIP(req)) {
+		logger.Warningf("the bearer token of %s isn't usable from %v", claims.Subject, networkpolicy.ClientIP(req))
+		return nil
+	}
+	// the bearer tokens issued for the pull tokens are checked against the pull tokens to
+	// reject the revoked ones and limit the access to the specified repositories
+	if strings.HasPrefix(claims.Subject, pulltoken_ctl.NamePrefix) {
+		pt, err := pulltoken_ctl.Ctl.GetByName(req.Context(), claims.Subject)
+		if err != nil || !pt.IsValid() {
+			logger.Warningf("the pull token %s is revoked or expired", claims.Subject)
+			return nil
+		}
+		return pulltoken.NewSecurityContext(pt)
+	}
+	// the bearer tokens issued before the user is deactivated are still valid until they expire, reject them here
+	if u, err := uctl.GetByName(req.Context(), claims.Subject); err == nil && u.Deactivated {
+		logger.Warningf("the user %s of the bearer token is deactivated", claims.Subject)
+		return nil
+	}
 	return v2token.New(req.Context(), claims.Subject, claims.Access)
 }
+
+// keyID returns the ID of the key signing the token in the "kid" header, the token isn't verified
+func keyID(tokenStr string) string {
+	t, _, err := jwt.NewParser().ParseUnverified(tokenStr, &jwt.RegisteredClaims{})
+	if err != nil {
+		return ""
+	}
+	kid, _ := t.Header["kid"].(string)
+	return kid
+}
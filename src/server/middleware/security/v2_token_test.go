@@ -9,9 +9,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/core/service/token"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg/networkpolicy"
+	testingUser "github.com/goharbor/harbor/src/testing/controller/user"
+	"github.com/goharbor/harbor/src/testing/mock"
 )
 
 func TestGenerate(t *testing.T) {
@@ -33,4 +37,23 @@ func TestGenerate(t *testing.T) {
 	require.Nil(t, err2)
 	req4.Header.Set("Authorization", fmt.Sprintf("Bearer %s", mt2.Token))
 	assert.NotNil(t, vt.Generate(req4))
+
+	// the token bound to the networks
+	mt3, err3 := token.MakeToken(ctx, "admin", token.Registry, []*registry_token.ResourceActions{},
+		&networkpolicy.Policy{Allowlist: "10.0.0.0/8"})
+	require.Nil(t, err3)
+	req5 := req3.Clone(req3.Context())
+	req5.Header.Set("Authorization", fmt.Sprintf("Bearer %s", mt3.Token))
+	req5.RemoteAddr = "10.0.0.1:1234"
+	assert.NotNil(t, vt.Generate(req5))
+	req5.RemoteAddr = "1.2.3.4:1234"
+	assert.Nil(t, vt.Generate(req5))
+
+	// the token issued before the user is deactivated
+	testCtl := &testingUser.Controller{}
+	testCtl.On("GetByName", mock.Anything, "admin").Return(&models.User{UserID: 1, Username: "admin", Deactivated: true}, nil)
+	origin := uctl
+	uctl = testCtl
+	defer func() { uctl = origin }()
+	assert.Nil(t, vt.Generate(req4))
 }
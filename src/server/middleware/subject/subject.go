@@ -0,0 +1,163 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subject
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/orm"
+	"github.com/goharbor/harbor/src/pkg/accessory"
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	"github.com/goharbor/harbor/src/server/middleware"
+)
+
+var (
+	// the artifact type of the cosign signatures pushed with the subject field
+	mediaTypeCosignArtifactSignature = "application/vnd.dev.cosign.artifact.sig.v1+json"
+	// the media type of the in-toto statements
+	mediaTypeInToto = "application/vnd.in-toto+json"
+	// the media type of the DSSE envelopes wrapping the in-toto statements
+	mediaTypeDSSEEnvelope = "application/vnd.dsse.envelope.v1+json"
+)
+
+// the fields of the OCI 1.1 manifest used to link the artifact to its subject
+type subjectManifest struct {
+	ArtifactType string              `json:"artifactType,omitempty"`
+	Config       ocispec.Descriptor  `json:"config"`
+	Subject      *ocispec.Descriptor `json:"subject,omitempty"`
+}
+
+// Middleware middleware to record the linkage of the artifact and its subject declared by the subject field of the manifest
+/* PUT /v2/library/hello-world/manifests/sha256:f54a58bc1aac5ea1a25d796ae155dc228b3f0e11d046ae276b39c4bf2f13d8c4
+{
+	"schemaVersion":2,
+	"mediaType":"application/vnd.oci.image.manifest.v1+json",
+	"artifactType":"application/spdx+json",
+	"config":{
+		"mediaType":"application/vnd.oci.empty.v1+json",
+		"size":2,
+		"digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"
+	},
+	"layers":[
+		{
+			"mediaType":"application/spdx+json",
+			"size":1024,
+			"digest":"sha256:d49bf6d7db9dac935b99d4c2c846b0d280f550aae62012f888d5a6e3ca59a589"
+		}
+	],
+	"subject":{
+		"mediaType":"application/vnd.oci.image.manifest.v1+json",
+		"size":1024,
+		"digest":"sha256:1b26826f602946860c279fce658f31050cff2c596583af237d971f4629b57792"
+	}
+}
+*/
+func Middleware() func(http.Handler) http.Handler {
+	return middleware.AfterResponse(func(w http.ResponseWriter, r *http.Request, statusCode int) error {
+		if statusCode != http.StatusCreated {
+			return nil
+		}
+
+		ctx := r.Context()
+		logger := log.G(ctx).WithFields(log.Fields{"middleware": "subject"})
+
+		none := lib.ArtifactInfo{}
+		info := lib.GetArtifactInfo(ctx)
+		if info == none {
+			return errors.New("artifactinfo middleware required before this middleware").WithCode(errors.NotFoundCode)
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return err
+		}
+
+		mf := &subjectManifest{}
+		if err := json.Unmarshal(body, mf); err != nil {
+			logger.Errorf("unmarshal manifest failed, error: %v", err)
+			return err
+		}
+		if mf.Subject == nil || mf.Subject.Digest == "" {
+			return nil
+		}
+
+		// the subject may be pushed after its accessories, they are left as the individual artifacts in this case
+		subjectArt, err := artifact.Ctl.GetByReference(ctx, info.Repository, mf.Subject.Digest.String(), nil)
+		if err != nil {
+			if errors.IsNotFoundErr(err) {
+				logger.Debugf("the subject artifact %s of %s not found, skip", mf.Subject.Digest.String(), info.Repository)
+				return nil
+			}
+			logger.Errorf("failed to get subject artifact: %s, error: %v", mf.Subject.Digest.String(), err)
+			return err
+		}
+
+		dgt := digest.FromBytes(body).String()
+		art, err := artifact.Ctl.GetByReference(ctx, info.Repository, dgt, nil)
+		if err != nil {
+			logger.Errorf("failed to get accessory artifact: %s, error: %v", dgt, err)
+			return err
+		}
+
+		accType := accessoryType(mf)
+		if err := orm.WithTransaction(func(ctx context.Context) error {
+			_, err := accessory.Mgr.Create(ctx, model.AccessoryData{
+				ArtifactID:    art.ID,
+				SubArtifactID: subjectArt.ID,
+				Size:          art.Size,
+				Digest:        art.Digest,
+				Type:          accType,
+			})
+			return err
+		})(orm.SetTransactionOpNameToContext(ctx, "tx-create-subject-accessory")); err != nil {
+			if !errors.IsConflictErr(err) {
+				logger.Errorf("failed to create %s accessory: %s, error: %v", accType, art.Digest, err)
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// accessoryType resolves the accessory type from the artifact type, or the config media type when the artifact type is absent
+func accessoryType(mf *subjectManifest) string {
+	typ := mf.ArtifactType
+	if typ == "" {
+		typ = mf.Config.MediaType
+	}
+	typ = strings.ToLower(typ)
+	switch {
+	case typ == mediaTypeCosignArtifactSignature:
+		return model.TypeCosignSignature
+	case typ == mediaTypeInToto, typ == mediaTypeDSSEEnvelope, strings.Contains(typ, "in-toto"):
+		return model.TypeInTotoAttestation
+	case strings.Contains(typ, "spdx"), strings.Contains(typ, "cyclonedx"), strings.Contains(typ, "sbom"):
+		return model.TypeSBOM
+	default:
+		return model.TypeSubject
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subject
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/repository"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg"
+	"github.com/goharbor/harbor/src/pkg/accessory"
+	"github.com/goharbor/harbor/src/pkg/accessory/model"
+	_ "github.com/goharbor/harbor/src/pkg/accessory/model/attestation"
+	_ "github.com/goharbor/harbor/src/pkg/accessory/model/base"
+	_ "github.com/goharbor/harbor/src/pkg/accessory/model/cosign"
+	_ "github.com/goharbor/harbor/src/pkg/accessory/model/sbom"
+	_ "github.com/goharbor/harbor/src/pkg/accessory/model/subject"
+	"github.com/goharbor/harbor/src/pkg/artifact"
+	htesting "github.com/goharbor/harbor/src/testing"
+)
+
+type MiddlewareTestSuite struct {
+	htesting.Suite
+}
+
+func (suite *MiddlewareTestSuite) SetupTest() {
+	suite.Suite.SetupSuite()
+}
+
+func (suite *MiddlewareTestSuite) prepare(name, subjectDigest string) (string, *http.Request) {
+	body := fmt.Sprintf(`
+	{
+		"schemaVersion":2,
+		"mediaType":"application/vnd.oci.image.manifest.v1+json",
+		"artifactType":"application/spdx+json",
+		"config":{
+			"mediaType":"application/vnd.oci.empty.v1+json",
+			"size":2,
+			"digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"
+		},
+		"layers":[
+			{
+				"mediaType":"application/spdx+json",
+				"size":1024,
+				"digest":"sha256:d49bf6d7db9dac935b99d4c2c846b0d280f550aae62012f888d5a6e3ca59a589"
+			}
+		],
+		"subject":{
+			"mediaType":"application/vnd.oci.image.manifest.v1+json",
+			"size":1024,
+			"digest":"%s"
+		}
+	}`, subjectDigest)
+
+	dgt := digest.FromString(body).String()
+	req := suite.NewRequest(http.MethodPut, fmt.Sprintf("/v2/%s/manifests/%s", name, dgt), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+	info := lib.ArtifactInfo{
+		Repository: name,
+		Reference:  dgt,
+		Digest:     dgt,
+	}
+	return dgt, req.WithContext(lib.WithArtifactInfo(req.Context(), info))
+}
+
+func (suite *MiddlewareTestSuite) addArt(pid, repositoryID int64, repositoryName, dgt string) int64 {
+	af := &artifact.Artifact{
+		Type:           "Docker-Image",
+		ProjectID:      pid,
+		RepositoryID:   repositoryID,
+		RepositoryName: repositoryName,
+		Digest:         dgt,
+		Size:           1024,
+		PushTime:       time.Now(),
+		PullTime:       time.Now(),
+	}
+	afid, err := pkg.ArtifactMgr.Create(suite.Context(), af)
+	suite.Nil(err, fmt.Sprintf("Add artifact failed for %d", repositoryID))
+	return afid
+}
+
+func (suite *MiddlewareTestSuite) TestSubject() {
+	suite.WithProject(func(projectID int64, projectName string) {
+		name := fmt.Sprintf("%s/hello-world", projectName)
+		subArtDigest := suite.DigestString()
+		dgt, req := suite.prepare(name, subArtDigest)
+
+		_, repoID, err := repository.Ctl.Ensure(suite.Context(), name)
+		suite.Nil(err)
+		subjectArtID := suite.addArt(projectID, repoID, name, subArtDigest)
+		artID := suite.addArt(projectID, repoID, name, dgt)
+
+		res := httptest.NewRecorder()
+		next := suite.NextHandler(http.StatusCreated, map[string]string{"Docker-Content-Digest": dgt})
+		Middleware()(next).ServeHTTP(res, req)
+		suite.Equal(http.StatusCreated, res.Code)
+
+		accs, err := accessory.Mgr.List(suite.Context(), q.New(q.KeyWords{"SubjectArtifactID": subjectArtID}))
+		suite.Nil(err)
+		suite.Require().Equal(1, len(accs))
+		suite.Equal(artID, accs[0].GetData().ArtifactID)
+		suite.True(accs[0].IsHard())
+		suite.Equal(model.TypeSBOM, accs[0].GetData().Type)
+	})
+}
+
+func (suite *MiddlewareTestSuite) TestSubjectNotFound() {
+	suite.WithProject(func(projectID int64, projectName string) {
+		name := fmt.Sprintf("%s/hello-world", projectName)
+		dgt, req := suite.prepare(name, suite.DigestString())
+
+		_, repoID, err := repository.Ctl.Ensure(suite.Context(), name)
+		suite.Nil(err)
+		artID := suite.addArt(projectID, repoID, name, dgt)
+
+		res := httptest.NewRecorder()
+		next := suite.NextHandler(http.StatusCreated, map[string]string{"Docker-Content-Digest": dgt})
+		Middleware()(next).ServeHTTP(res, req)
+		suite.Equal(http.StatusCreated, res.Code)
+
+		count, err := accessory.Mgr.Count(suite.Context(), q.New(q.KeyWords{"ArtifactID": artID}))
+		suite.Nil(err)
+		suite.Equal(int64(0), count)
+	})
+}
+
+func TestAccessoryType(t *testing.T) {
+	cases := []struct {
+		artifactType string
+		configType   string
+		expected     string
+	}{
+		{"application/spdx+json", "", model.TypeSBOM},
+		{"application/vnd.cyclonedx+json", "", model.TypeSBOM},
+		{"application/vnd.in-toto+json", "", model.TypeInTotoAttestation},
+		{"", "application/vnd.dsse.envelope.v1+json", model.TypeInTotoAttestation},
+		{"application/vnd.dev.cosign.artifact.sig.v1+json", "", model.TypeCosignSignature},
+		{"application/vnd.example.thing", "", model.TypeSubject},
+	}
+	for _, c := range cases {
+		mf := &subjectManifest{ArtifactType: c.artifactType}
+		mf.Config.MediaType = c.configType
+		if typ := accessoryType(mf); typ != c.expected {
+			t.Errorf("expected %s for %q/%q, but got %s", c.expected, c.artifactType, c.configType, typ)
+		}
+	}
+}
+
+func TestMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, &MiddlewareTestSuite{})
+}
@@ -39,6 +39,10 @@ func (m *mockOrmer) Begin() (o.TxOrmer, error) {
 	return &m.tx, m.beginErr
 }
 
+func (m *mockOrmer) BeginWithCtx(ctx context.Context) (o.TxOrmer, error) {
+	return m.Begin()
+}
+
 func (m *mockOrmer) Reset() {
 	m.tx.Reset()
 	m.beginErr = nil
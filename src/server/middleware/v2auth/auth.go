@@ -33,6 +33,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/errors"
 	lib_http "github.com/goharbor/harbor/src/lib/http"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/networkpolicy"
 )
 
 const (
@@ -67,11 +68,17 @@ func (rc *reqChecker) check(req *http.Request) (string, error) {
 			return getChallenge(req, al), fmt.Errorf("authorize header needed to send HEAD to repository")
 		} else if a.target == repository {
 			pn := strings.Split(a.name, "/")[0]
-			pid, err := rc.projectID(req.Context(), pn)
+			p, err := rc.ctl.Get(req.Context(), pn)
 			if err != nil {
 				return "", err
 			}
-			resource := rbac_project.NewNamespace(pid).Resource(rbac.ResourceRepository)
+			// the bearer tokens are bound to the networks of the projects when they're issued,
+			// the other credentials are checked against the network policy of the project here
+			if policy := p.NetworkPolicy(); !policy.IsEmpty() && !isBearer(req) &&
+				!token.IsNetworkPolicyExempted(securityCtx) && !policy.Allows(networkpolicy.ClientIP(req)) {
+				return "", errors.ForbiddenError(nil).WithMessage("the project %s isn't accessible from %v", pn, networkpolicy.ClientIP(req))
+			}
+			resource := rbac_project.NewNamespace(p.ProjectID).Resource(rbac.ResourceRepository)
 			if !securityCtx.Can(req.Context(), a.action, resource) {
 				return getChallenge(req, al), fmt.Errorf("unauthorized to access repository: %s, action: %s", a.name, a.action)
 			}
@@ -80,13 +87,8 @@ func (rc *reqChecker) check(req *http.Request) (string, error) {
 	return "", nil
 }
 
-func (rc *reqChecker) projectID(ctx context.Context, name string) (int64, error) {
-	p, err := rc.ctl.Get(ctx, name)
-	if err != nil {
-		return 0, err
-	}
-
-	return p.ProjectID, nil
+func isBearer(req *http.Request) bool {
+	return strings.HasPrefix(strings.ToLower(req.Header.Get(authHeader)), "bearer ")
 }
 
 func getChallenge(req *http.Request, accessList []access) string {
@@ -161,6 +163,10 @@ func Middleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 			if challenge, err := checker.check(req); err != nil {
+				if errors.IsErr(err, errors.ForbiddenCode) {
+					lib_http.SendError(rw, err)
+					return
+				}
 				// the header is needed for "docker manifest" commands: https://github.com/docker/cli/issues/989
 				rw.Header().Set("Docker-Distribution-Api-Version", "registry/2.0")
 				rw.Header().Set("Www-Authenticate", challenge)
@@ -52,10 +52,15 @@ func TestMain(m *testing.M) {
 		if id == 0 {
 			return nil, fmt.Errorf("%s not found", name)
 		}
-		return &proModels.Project{
+		p := &proModels.Project{
 			ProjectID: int64(id),
 			Name:      name,
-		}, nil
+		}
+		// the project accessible from the restricted networks
+		if id == 9 {
+			p.Metadata = map[string]string{proModels.ProMetaIPAllowlist: "10.0.0.0/8"}
+		}
+		return p, nil
 	}
 	mock.OnAnything(ctl, "Get").Return(
 		func(ctx context.Context,
@@ -221,6 +226,45 @@ func TestMiddleware(t *testing.T) {
 	}
 }
 
+func TestMiddlewareNetworkPolicy(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+	})
+
+	sc := &securitytesting.Context{}
+	sc.On("IsAuthenticated").Return(true)
+	sc.On("IsSolutionUser").Return(false)
+	mock.OnAnything(sc, "Can").Return(true)
+	ctx := lib.WithArtifactInfo(security.NewContext(context.Background(), sc), lib.ArtifactInfo{
+		Repository:  "project_9/hello-world",
+		Reference:   "v1",
+		ProjectName: "project_9",
+	})
+
+	cases := []struct {
+		remoteAddr string
+		bearer     bool
+		status     int
+	}{
+		{"10.0.0.1:1234", false, http.StatusOK},
+		{"1.2.3.4:1234", false, http.StatusForbidden},
+		// the bearer token is checked against the networks bound to it when parsing it
+		{"1.2.3.4:1234", true, http.StatusOK},
+	}
+	for _, c := range cases {
+		req, _ := http.NewRequest(http.MethodGet, "/v2/project_9/hello-world/manifest/v1", nil)
+		req.RemoteAddr = c.remoteAddr
+		if c.bearer {
+			req.Header.Set("Authorization", "Bearer xxx")
+		} else {
+			req.SetBasicAuth("u", "p")
+		}
+		rec := httptest.NewRecorder()
+		Middleware()(next).ServeHTTP(rec, req.WithContext(ctx))
+		assert.Equal(t, c.status, rec.Result().StatusCode, c.remoteAddr)
+	}
+}
+
 func TestGetChallenge(t *testing.T) {
 	req1, _ := http.NewRequest(http.MethodGet, "https://registry.test/v2/", nil)
 	req1x := req1.Clone(req1.Context())
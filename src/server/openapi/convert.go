@@ -0,0 +1,423 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Version is the version of the OpenAPI specification which the converted document follows
+const Version = "3.0.3"
+
+var (
+	methods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+	// the keywords which are moved from the swagger 2.0 parameters into the schemas of the OpenAPI 3.0 parameters
+	schemaKeywords = []string{"type", "format", "items", "default", "enum", "minimum", "maximum", "exclusiveMinimum",
+		"exclusiveMaximum", "minLength", "maxLength", "pattern", "minItems", "maxItems", "uniqueItems", "multipleOf", "x-nullable"}
+)
+
+// Convert converts the swagger 2.0 document into the OpenAPI 3.0 document
+func Convert(swagger map[string]interface{}) (map[string]interface{}, error) {
+	if v, _ := swagger["swagger"].(string); v != "2.0" {
+		return nil, fmt.Errorf("unsupported swagger version %q", v)
+	}
+
+	c := &converter{
+		consumes:   strs(swagger["consumes"]),
+		produces:   strs(swagger["produces"]),
+		parameters: toMap(swagger["parameters"]),
+	}
+	if len(c.consumes) == 0 {
+		c.consumes = []string{"application/json"}
+	}
+	if len(c.produces) == 0 {
+		c.produces = []string{"application/json"}
+	}
+
+	components := map[string]interface{}{}
+	schemas := map[string]interface{}{}
+	for name, schema := range toMap(swagger["definitions"]) {
+		schemas[name] = c.schema(schema)
+	}
+	components["schemas"] = schemas
+
+	parameters, requestBodies := map[string]interface{}{}, map[string]interface{}{}
+	for name, param := range c.parameters {
+		p := toMap(param)
+		if p["in"] == "body" {
+			requestBodies[name] = c.requestBody(p, c.consumes)
+			continue
+		}
+		parameters[name] = c.parameter(p)
+	}
+	components["parameters"] = parameters
+	components["requestBodies"] = requestBodies
+
+	responses := map[string]interface{}{}
+	for name, resp := range toMap(swagger["responses"]) {
+		responses[name] = c.response(toMap(resp), c.produces)
+	}
+	components["responses"] = responses
+
+	securitySchemes := map[string]interface{}{}
+	for name, scheme := range toMap(swagger["securityDefinitions"]) {
+		securitySchemes[name] = securityScheme(toMap(scheme))
+	}
+	components["securitySchemes"] = securitySchemes
+
+	paths := map[string]interface{}{}
+	for path, item := range toMap(swagger["paths"]) {
+		paths[path] = c.pathItem(toMap(item))
+	}
+
+	doc := map[string]interface{}{
+		"openapi":    Version,
+		"info":       swagger["info"],
+		"paths":      paths,
+		"components": components,
+	}
+	basePath, _ := swagger["basePath"].(string)
+	if len(basePath) == 0 {
+		basePath = "/"
+	}
+	doc["servers"] = []interface{}{map[string]interface{}{"url": basePath}}
+	for _, key := range []string{"security", "tags", "externalDocs"} {
+		if v, ok := swagger[key]; ok {
+			doc[key] = v
+		}
+	}
+	copyExtensions(swagger, doc)
+	return doc, nil
+}
+
+type converter struct {
+	consumes   []string
+	produces   []string
+	parameters map[string]interface{}
+}
+
+func (c *converter) pathItem(item map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	copyExtensions(item, result)
+	if params, ok := item["parameters"].([]interface{}); ok {
+		var ps []interface{}
+		for _, param := range params {
+			ps = append(ps, c.parameter(toMap(param)))
+		}
+		result["parameters"] = ps
+	}
+	for _, method := range methods {
+		if op, ok := item[method]; ok {
+			result[method] = c.operation(toMap(op))
+		}
+	}
+	return result
+}
+
+func (c *converter) operation(op map[string]interface{}) map[string]interface{} {
+	consumes, produces := c.consumes, c.produces
+	if v, ok := op["consumes"]; ok {
+		consumes = strs(v)
+	}
+	if v, ok := op["produces"]; ok {
+		produces = strs(v)
+	}
+
+	result := map[string]interface{}{}
+	for k, v := range op {
+		switch k {
+		case "parameters", "responses", "consumes", "produces", "schemes":
+		default:
+			result[k] = v
+		}
+	}
+
+	var (
+		params   []interface{}
+		formData []map[string]interface{}
+	)
+	list, _ := op["parameters"].([]interface{})
+	for _, param := range list {
+		p := toMap(param)
+		if ref, ok := p["$ref"].(string); ok {
+			name := strings.TrimPrefix(ref, "#/parameters/")
+			p = toMap(c.parameters[name])
+			if p["in"] != "body" {
+				params = append(params, map[string]interface{}{"$ref": "#/components/parameters/" + name})
+				continue
+			}
+			// the request body defined globally uses the global consumes, so it's inlined if they are overridden
+			if _, ok := op["consumes"]; !ok {
+				result["requestBody"] = map[string]interface{}{"$ref": "#/components/requestBodies/" + name}
+				continue
+			}
+		}
+		switch p["in"] {
+		case "body":
+			result["requestBody"] = c.requestBody(p, consumes)
+		case "formData":
+			formData = append(formData, p)
+		default:
+			params = append(params, c.parameter(p))
+		}
+	}
+	if len(params) > 0 {
+		result["parameters"] = params
+	}
+	if len(formData) > 0 {
+		result["requestBody"] = c.formBody(formData, consumes)
+	}
+
+	responses := map[string]interface{}{}
+	for code, resp := range toMap(op["responses"]) {
+		responses[code] = c.response(toMap(resp), produces)
+	}
+	result["responses"] = responses
+	return result
+}
+
+func (c *converter) parameter(param map[string]interface{}) map[string]interface{} {
+	if ref, ok := param["$ref"].(string); ok {
+		return map[string]interface{}{"$ref": strings.Replace(ref, "#/parameters/", "#/components/parameters/", 1)}
+	}
+
+	result, schema := map[string]interface{}{}, map[string]interface{}{}
+	for _, k := range schemaKeywords {
+		if v, ok := param[k]; ok {
+			schema[k] = v
+		}
+	}
+	for k, v := range param {
+		switch k {
+		case "name", "in", "description", "required", "deprecated", "allowEmptyValue":
+			result[k] = v
+		default:
+			if strings.HasPrefix(k, "x-") && k != "x-nullable" {
+				result[k] = v
+			}
+		}
+	}
+	result["schema"] = c.schema(schema)
+
+	if schema["type"] == "array" {
+		style, explode := "form", false
+		if param["in"] == "path" || param["in"] == "header" {
+			style = "simple"
+		}
+		switch param["collectionFormat"] {
+		case "multi":
+			explode = true
+		case "ssv":
+			style = "spaceDelimited"
+		case "pipes":
+			style = "pipeDelimited"
+		}
+		result["style"] = style
+		result["explode"] = explode
+	}
+	return result
+}
+
+func (c *converter) requestBody(param map[string]interface{}, consumes []string) map[string]interface{} {
+	result := map[string]interface{}{
+		"content": content(c.schema(param["schema"]), consumes),
+	}
+	for _, k := range []string{"description", "required"} {
+		if v, ok := param[k]; ok {
+			result[k] = v
+		}
+	}
+	copyExtensions(param, result)
+	return result
+}
+
+func (c *converter) formBody(params []map[string]interface{}, consumes []string) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []interface{}
+	for _, param := range params {
+		name, _ := param["name"].(string)
+		schema := map[string]interface{}{}
+		for _, k := range append(schemaKeywords, "description") {
+			if v, ok := param[k]; ok {
+				schema[k] = v
+			}
+		}
+		properties[name] = c.schema(schema)
+		if r, _ := param["required"].(bool); r {
+			required = append(required, name)
+		}
+	}
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	var mediaTypes []string
+	for _, mediaType := range consumes {
+		if mediaType == "multipart/form-data" || mediaType == "application/x-www-form-urlencoded" {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{"multipart/form-data"}
+	}
+	return map[string]interface{}{"content": content(schema, mediaTypes)}
+}
+
+func (c *converter) response(resp map[string]interface{}, produces []string) map[string]interface{} {
+	if ref, ok := resp["$ref"].(string); ok {
+		return map[string]interface{}{"$ref": strings.Replace(ref, "#/responses/", "#/components/responses/", 1)}
+	}
+
+	description, _ := resp["description"].(string)
+	result := map[string]interface{}{"description": description}
+	if schema, ok := resp["schema"]; ok {
+		result["content"] = content(c.schema(schema), produces)
+	}
+	if headers := toMap(resp["headers"]); len(headers) > 0 {
+		hs := map[string]interface{}{}
+		for name, header := range headers {
+			h := map[string]interface{}{}
+			schema := map[string]interface{}{}
+			for k, v := range toMap(header) {
+				if k == "description" {
+					h[k] = v
+					continue
+				}
+				schema[k] = v
+			}
+			h["schema"] = c.schema(schema)
+			hs[name] = h
+		}
+		result["headers"] = hs
+	}
+	copyExtensions(resp, result)
+	return result
+}
+
+// schema converts the swagger 2.0 schema into the OpenAPI 3.0 schema recursively
+func (c *converter) schema(s interface{}) interface{} {
+	m, ok := s.(map[string]interface{})
+	if !ok {
+		return s
+	}
+
+	result := map[string]interface{}{}
+	for k, v := range m {
+		switch k {
+		case "$ref":
+			ref, _ := v.(string)
+			result[k] = strings.Replace(ref, "#/definitions/", "#/components/schemas/", 1)
+		case "x-nullable":
+			result["nullable"] = v
+		case "discriminator":
+			result[k] = map[string]interface{}{"propertyName": v}
+		case "items", "additionalProperties", "not":
+			result[k] = c.schema(v)
+		case "properties":
+			properties := map[string]interface{}{}
+			for name, property := range toMap(v) {
+				properties[name] = c.schema(property)
+			}
+			result[k] = properties
+		case "allOf", "anyOf", "oneOf":
+			items, _ := v.([]interface{})
+			var list []interface{}
+			for _, item := range items {
+				list = append(list, c.schema(item))
+			}
+			result[k] = list
+		default:
+			result[k] = v
+		}
+	}
+	if result["type"] == "file" {
+		result["type"] = "string"
+		result["format"] = "binary"
+	}
+	return result
+}
+
+func securityScheme(scheme map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	switch scheme["type"] {
+	case "basic":
+		result["type"] = "http"
+		result["scheme"] = "basic"
+	case "oauth2":
+		flow := map[string]interface{}{"scopes": scheme["scopes"]}
+		if flow["scopes"] == nil {
+			flow["scopes"] = map[string]interface{}{}
+		}
+		for _, k := range []string{"authorizationUrl", "tokenUrl"} {
+			if v, ok := scheme[k]; ok {
+				flow[k] = v
+			}
+		}
+		name, _ := scheme["flow"].(string)
+		switch name {
+		case "application":
+			name = "clientCredentials"
+		case "accessCode":
+			name = "authorizationCode"
+		}
+		result["type"] = "oauth2"
+		result["flows"] = map[string]interface{}{name: flow}
+	default:
+		for _, k := range []string{"type", "name", "in"} {
+			if v, ok := scheme[k]; ok {
+				result[k] = v
+			}
+		}
+	}
+	if v, ok := scheme["description"]; ok {
+		result["description"] = v
+	}
+	copyExtensions(scheme, result)
+	return result
+}
+
+func content(schema interface{}, mediaTypes []string) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, mediaType := range mediaTypes {
+		result[mediaType] = map[string]interface{}{"schema": schema}
+	}
+	return result
+}
+
+func copyExtensions(from, to map[string]interface{}) {
+	for k, v := range from {
+		if strings.HasPrefix(k, "x-") {
+			to[k] = v
+		}
+	}
+}
+
+func toMap(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	return m
+}
+
+func strs(v interface{}) []string {
+	var result []string
+	list, _ := v.([]interface{})
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
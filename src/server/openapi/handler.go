@@ -0,0 +1,78 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	lib_http "github.com/goharbor/harbor/src/lib/http"
+	"github.com/goharbor/harbor/src/pkg/version"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+)
+
+const sessionScheme = "session"
+
+var (
+	once     sync.Once
+	document []byte
+	buildErr error
+)
+
+// Handler serves the OpenAPI 3.0 document of the v2.0 APIs. The document is converted from the swagger 2.0
+// document which the APIs are generated and validated with, so it's always in sync with the running server
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() {
+			document, buildErr = build(restapi.SwaggerJSON)
+		})
+		if buildErr != nil {
+			lib_http.SendError(w, buildErr)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(document)
+	})
+}
+
+func build(data []byte) ([]byte, error) {
+	swagger := map[string]interface{}{}
+	if err := json.Unmarshal(data, &swagger); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the swagger document")
+	}
+	doc, err := Convert(swagger)
+	if err != nil {
+		return nil, err
+	}
+
+	// the session of the portal isn't declared in the swagger document as it's handled outside of the APIs
+	components := doc["components"].(map[string]interface{})
+	components["securitySchemes"].(map[string]interface{})[sessionScheme] = map[string]interface{}{
+		"type":        "apiKey",
+		"in":          "cookie",
+		"name":        config.SessionCookieName,
+		"description": "The session cookie of the portal, the modifying requests must carry the CSRF token in the \"X-Harbor-CSRF-Token\" header as well",
+	}
+	if security, ok := doc["security"].([]interface{}); ok {
+		doc["security"] = append(security, map[string]interface{}{sessionScheme: []interface{}{}})
+	}
+	if info, ok := doc["info"].(map[string]interface{}); ok && len(version.ReleaseVersion) > 0 {
+		info["x-release-version"] = version.ReleaseVersion
+	}
+	return json.Marshal(doc)
+}
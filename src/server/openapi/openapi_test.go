@@ -0,0 +1,173 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const swagger = `{
+  "swagger": "2.0",
+  "info": {"title": "Harbor API", "version": "2.0"},
+  "basePath": "/api/v2.0",
+  "produces": ["application/json"],
+  "consumes": ["application/json"],
+  "securityDefinitions": {"basic": {"type": "basic"}},
+  "security": [{"basic": []}, {}],
+  "paths": {
+    "/projects": {
+      "get": {
+        "operationId": "listProjects",
+        "parameters": [
+          {"$ref": "#/parameters/requestId"},
+          {"name": "names", "in": "query", "type": "array", "items": {"type": "string"}}
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "headers": {"X-Total-Count": {"description": "The total count", "type": "integer"}},
+            "schema": {"type": "array", "items": {"$ref": "#/definitions/Project"}}
+          },
+          "500": {"$ref": "#/responses/500"}
+        }
+      },
+      "post": {
+        "operationId": "createProject",
+        "parameters": [
+          {"name": "project", "in": "body", "required": true, "schema": {"$ref": "#/definitions/Project"}}
+        ],
+        "responses": {"201": {"description": "Created"}}
+      }
+    },
+    "/exports/{id}": {
+      "get": {
+        "operationId": "download",
+        "produces": ["text/csv"],
+        "parameters": [{"name": "id", "in": "path", "required": true, "type": "integer", "format": "int64"}],
+        "responses": {"200": {"description": "Success", "schema": {"type": "file"}}}
+      }
+    }
+  },
+  "parameters": {
+    "requestId": {"name": "X-Request-Id", "in": "header", "type": "string", "minLength": 1}
+  },
+  "responses": {
+    "500": {"description": "Internal server error", "schema": {"$ref": "#/definitions/Errors"}}
+  },
+  "definitions": {
+    "Project": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"},
+        "registry_id": {"type": "integer", "x-nullable": true}
+      }
+    },
+    "Errors": {"type": "object", "properties": {"errors": {"type": "array", "items": {"type": "object"}}}}
+  }
+}`
+
+func get(m interface{}, path ...string) interface{} {
+	for _, p := range path {
+		m = m.(map[string]interface{})[p]
+	}
+	return m
+}
+
+func TestConvert(t *testing.T) {
+	data := map[string]interface{}{}
+	require.Nil(t, json.Unmarshal([]byte(swagger), &data))
+	doc, err := Convert(data)
+	require.Nil(t, err)
+
+	assert.Equal(t, Version, doc["openapi"])
+	assert.Equal(t, "/api/v2.0", get(doc["servers"].([]interface{})[0], "url"))
+	assert.Equal(t, map[string]interface{}{"type": "http", "scheme": "basic"}, get(doc, "components", "securitySchemes", "basic"))
+
+	// parameters
+	assert.Equal(t, map[string]interface{}{"type": "string", "minLength": float64(1)}, get(doc, "components", "parameters", "requestId", "schema"))
+	params := get(doc, "paths", "/projects", "get", "parameters").([]interface{})
+	require.Len(t, params, 2)
+	assert.Equal(t, "#/components/parameters/requestId", get(params[0], "$ref"))
+	assert.Equal(t, "form", get(params[1], "style"))
+	assert.Equal(t, false, get(params[1], "explode"))
+
+	// responses
+	assert.Equal(t, "#/components/schemas/Project",
+		get(doc, "paths", "/projects", "get", "responses", "200", "content", "application/json", "schema", "items", "$ref"))
+	assert.Equal(t, map[string]interface{}{"type": "integer"},
+		get(doc, "paths", "/projects", "get", "responses", "200", "headers", "X-Total-Count", "schema"))
+	assert.Equal(t, "#/components/responses/500", get(doc, "paths", "/projects", "get", "responses", "500", "$ref"))
+	assert.Equal(t, "#/components/schemas/Errors",
+		get(doc, "components", "responses", "500", "content", "application/json", "schema", "$ref"))
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "binary"},
+		get(doc, "paths", "/exports/{id}", "get", "responses", "200", "content", "text/csv", "schema"))
+
+	// request body
+	body := get(doc, "paths", "/projects", "post")
+	assert.Nil(t, get(body, "parameters"))
+	assert.Equal(t, true, get(body, "requestBody", "required"))
+	assert.Equal(t, "#/components/schemas/Project", get(body, "requestBody", "content", "application/json", "schema", "$ref"))
+
+	// schemas
+	assert.Equal(t, true, get(doc, "components", "schemas", "Project", "properties", "registry_id", "nullable"))
+
+	// unsupported version
+	_, err = Convert(map[string]interface{}{"swagger": "1.2"})
+	assert.NotNil(t, err)
+}
+
+// all the references in the document converted from the swagger document of the APIs must be resolvable
+func TestHandler(t *testing.T) {
+	rr := httptest.NewRecorder()
+	Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	doc := map[string]interface{}{}
+	require.Nil(t, json.Unmarshal(rr.Body.Bytes(), &doc))
+	assert.Equal(t, Version, doc["openapi"])
+	assert.NotNil(t, get(doc, "components", "securitySchemes", sessionScheme))
+
+	var refs []string
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch val := v.(type) {
+		case map[string]interface{}:
+			for k, item := range val {
+				if ref, ok := item.(string); ok && k == "$ref" {
+					refs = append(refs, ref)
+				}
+				walk(item)
+			}
+		case []interface{}:
+			for _, item := range val {
+				walk(item)
+			}
+		}
+	}
+	walk(doc)
+	require.NotEmpty(t, refs)
+	for _, ref := range refs {
+		require.True(t, strings.HasPrefix(ref, "#/components/"), ref)
+		path := strings.Split(strings.TrimPrefix(ref, "#/"), "/")
+		assert.NotNil(t, get(doc, path...), ref)
+	}
+}
@@ -15,6 +15,7 @@
 package registry
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httputil"
@@ -22,12 +23,33 @@ import (
 
 	commonhttp "github.com/goharbor/harbor/src/common/http"
 	"github.com/goharbor/harbor/src/lib/config"
+	lib_http "github.com/goharbor/harbor/src/lib/http"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/distribution"
+	"github.com/goharbor/harbor/src/pkg/registry"
 )
 
 var proxy = newProxy()
 
 func newProxy() http.Handler {
 	regURL, _ := config.RegistryURL()
+	def := newReverseProxy(regURL)
+	shards := config.RegistryShards()
+	if len(shards) == 0 {
+		return def
+	}
+	p := &shardedProxy{
+		def:     def,
+		shards:  map[string]http.Handler{},
+		shardOf: registry.ShardOf,
+	}
+	for name, shardURL := range shards {
+		p.shards[name] = newReverseProxy(shardURL)
+	}
+	return p
+}
+
+func newReverseProxy(regURL string) http.Handler {
 	url, err := url.Parse(regURL)
 	if err != nil {
 		panic(fmt.Sprintf("failed to parse the URL of registry: %v", err))
@@ -50,3 +72,53 @@ func basicAuthDirector(d func(*http.Request)) func(*http.Request) {
 		}
 	}
 }
+
+// shardedProxy proxies the requests to the registry shards serving the projects of the repositories
+type shardedProxy struct {
+	def     http.Handler
+	shards  map[string]http.Handler
+	shardOf func(ctx context.Context, repository string) (string, error)
+}
+
+func (s *shardedProxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	repository := distribution.ParseName(req.URL.Path)
+	// the requests without repository, e.g. "/v2/", are served by the default registry
+	if len(repository) == 0 {
+		s.def.ServeHTTP(w, req)
+		return
+	}
+	handler, shard, err := s.handler(req.Context(), repository)
+	if err != nil {
+		lib_http.SendError(w, err)
+		return
+	}
+	// the blob can only be mounted from the repository served by the same registry, drop the mount
+	// parameters to start a regular upload session when it isn't, this is allowed by the distribution spec
+	query := req.URL.Query()
+	if from := query.Get("from"); req.Method == http.MethodPost && len(query.Get("mount")) > 0 && len(from) > 0 {
+		_, fromShard, err := s.handler(req.Context(), from)
+		if err != nil || fromShard != shard {
+			log.Debugf("the repository %s isn't served by the same registry as %s, skip mounting the blob", from, repository)
+			query.Del("mount")
+			query.Del("from")
+			req.URL.RawQuery = query.Encode()
+		}
+	}
+	handler.ServeHTTP(w, req)
+}
+
+// handler returns the proxy of the registry serving the repository and the name of the shard
+func (s *shardedProxy) handler(ctx context.Context, repository string) (http.Handler, string, error) {
+	shard, err := s.shardOf(ctx, repository)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(shard) == 0 {
+		return s.def, "", nil
+	}
+	handler, exist := s.shards[shard]
+	if !exist {
+		return nil, "", fmt.Errorf("the registry shard %s serving the repository %s isn't configured", shard, repository)
+	}
+	return handler, shard, nil
+}
@@ -0,0 +1,70 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardedProxy(t *testing.T) {
+	var served, query string
+	backend := func(name string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			served, query = name, r.URL.RawQuery
+		})
+	}
+	p := &shardedProxy{
+		def:    backend("default"),
+		shards: map[string]http.Handler{"large": backend("large")},
+		shardOf: func(ctx context.Context, repository string) (string, error) {
+			switch {
+			case strings.HasPrefix(repository, "tenant/"):
+				return "large", nil
+			case strings.HasPrefix(repository, "removed/"):
+				return "removed", nil
+			}
+			return "", nil
+		},
+	}
+
+	cases := []struct {
+		method string
+		path   string
+		served string
+		query  string
+		status int
+	}{
+		{http.MethodGet, "/v2/", "default", "", http.StatusOK},
+		{http.MethodGet, "/v2/library/hello-world/blobs/sha256:1", "default", "", http.StatusOK},
+		{http.MethodGet, "/v2/tenant/hello-world/blobs/sha256:1", "large", "", http.StatusOK},
+		{http.MethodPost, "/v2/tenant/b/blobs/uploads/?from=tenant%2Fa&mount=sha256%3A1", "large", "from=tenant%2Fa&mount=sha256%3A1", http.StatusOK},
+		{http.MethodPost, "/v2/tenant/b/blobs/uploads/?from=library%2Fa&mount=sha256%3A1", "large", "", http.StatusOK},
+		{http.MethodGet, "/v2/removed/hello-world/tags/list", "", "", http.StatusInternalServerError},
+	}
+	for _, c := range cases {
+		served, query = "", ""
+		w := httptest.NewRecorder()
+		p.ServeHTTP(w, httptest.NewRequest(c.method, c.path, nil))
+		assert.Equal(t, c.status, w.Code, c.path)
+		assert.Equal(t, c.served, served, c.path)
+		assert.Equal(t, c.query, query, c.path)
+	}
+}
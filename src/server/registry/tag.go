@@ -20,7 +20,6 @@ import (
 	"github.com/goharbor/harbor/src/controller/repository"
 	"github.com/goharbor/harbor/src/controller/tag"
 	lib_http "github.com/goharbor/harbor/src/lib/http"
-	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/server/registry/util"
 	"github.com/goharbor/harbor/src/server/router"
 )
@@ -50,7 +49,7 @@ type tagHandler struct {
 //	   ]
 //	}
 func (t *tagHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	n, _, err := util.ParseNAndLastParameters(req)
+	n, last, err := util.ParseNAndLastParameters(req)
 	if err != nil {
 		lib_http.SendError(w, err)
 		return
@@ -68,19 +67,17 @@ func (t *tagHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	tags, err := t.tagCtl.List(req.Context(), &q.Query{
-		Keywords: map[string]interface{}{
-			"RepositoryID": repository.RepositoryID,
-		}}, nil)
+	// only load the tags of the requested page from the database, one more tag is loaded
+	// to determine whether there is next page
+	limit := 0
+	if n != nil {
+		limit = *n + 1
+	}
+	tagNames, err := t.tagCtl.ListNames(req.Context(), repository.RepositoryID, last, limit)
 	if err != nil {
 		lib_http.SendError(w, err)
 		return
 	}
 
-	tagNames := make([]string, len(tags))
-	for i := range tags {
-		tagNames[i] = tags[i].Name
-	}
-
 	util.SendListTagsResponse(w, req, tagNames)
 }
@@ -25,7 +25,6 @@ import (
 	"github.com/goharbor/harbor/src/controller/repository"
 	"github.com/goharbor/harbor/src/controller/tag"
 	"github.com/goharbor/harbor/src/pkg/repository/model"
-	model_tag "github.com/goharbor/harbor/src/pkg/tag/model/tag"
 	repotesting "github.com/goharbor/harbor/src/testing/controller/repository"
 	tagtesting "github.com/goharbor/harbor/src/testing/controller/tag"
 	"github.com/goharbor/harbor/src/testing/mock"
@@ -67,20 +66,7 @@ func (c *tagTestSuite) TestListTag() {
 		RepositoryID: 1,
 		Name:         "library/hello-world",
 	}, nil)
-	c.tagCtl.On("List").Return([]*tag.Tag{
-		{
-			Tag: model_tag.Tag{
-				RepositoryID: 1,
-				Name:         "v1",
-			},
-		},
-		{
-			Tag: model_tag.Tag{
-				RepositoryID: 1,
-				Name:         "v2",
-			},
-		},
-	}, nil)
+	c.tagCtl.On("ListNames").Return([]string{"v1", "v2"}, nil)
 	w = httptest.NewRecorder()
 	newTagHandler().ServeHTTP(w, req)
 	c.Equal(http.StatusOK, w.Code)
@@ -102,20 +88,7 @@ func (c *tagTestSuite) TestListTagPagination1() {
 		RepositoryID: 1,
 		Name:         "hello-world",
 	}, nil)
-	c.tagCtl.On("List").Return([]*tag.Tag{
-		{
-			Tag: model_tag.Tag{
-				RepositoryID: 1,
-				Name:         "v1",
-			},
-		},
-		{
-			Tag: model_tag.Tag{
-				RepositoryID: 1,
-				Name:         "v2",
-			},
-		},
-	}, nil)
+	c.tagCtl.On("ListNames").Return([]string{"v1", "v2"}, nil)
 	w = httptest.NewRecorder()
 	newTagHandler().ServeHTTP(w, req)
 	c.Equal(http.StatusOK, w.Code)
@@ -138,20 +111,7 @@ func (c *tagTestSuite) TestListTagPagination2() {
 		RepositoryID: 1,
 		Name:         "hello-world",
 	}, nil)
-	c.tagCtl.On("List").Return([]*tag.Tag{
-		{
-			Tag: model_tag.Tag{
-				RepositoryID: 1,
-				Name:         "v1",
-			},
-		},
-		{
-			Tag: model_tag.Tag{
-				RepositoryID: 1,
-				Name:         "v2",
-			},
-		},
-	}, nil)
+	c.tagCtl.On("ListNames").Return([]string{"v1", "v2"}, nil)
 	w = httptest.NewRecorder()
 	newTagHandler().ServeHTTP(w, req)
 	c.Equal(http.StatusOK, w.Code)
@@ -174,20 +134,7 @@ func (c *tagTestSuite) TestListTagPagination3() {
 		RepositoryID: 1,
 		Name:         "hello-world",
 	}, nil)
-	c.tagCtl.On("List").Return([]*tag.Tag{
-		{
-			Tag: model_tag.Tag{
-				RepositoryID: 1,
-				Name:         "v1",
-			},
-		},
-		{
-			Tag: model_tag.Tag{
-				RepositoryID: 1,
-				Name:         "v2",
-			},
-		},
-	}, nil)
+	c.tagCtl.On("ListNames").Return([]string{"v2"}, nil)
 	w = httptest.NewRecorder()
 	newTagHandler().ServeHTTP(w, req)
 	c.Equal(http.StatusOK, w.Code)
@@ -26,6 +26,7 @@ import (
 	"github.com/goharbor/harbor/src/core/service/token"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/server/handler"
+	"github.com/goharbor/harbor/src/server/openapi"
 	"github.com/goharbor/harbor/src/server/router"
 )
 
@@ -36,6 +37,8 @@ func ignoreNotification(w http.ResponseWriter, r *http.Request) {
 func registerRoutes() {
 	// API version
 	router.NewRoute().Method(http.MethodGet).Path("/api/version").HandlerFunc(GetAPIVersion)
+	// OpenAPI 3.0 document of the APIs
+	router.NewRoute().Method(http.MethodGet).Path("/api/openapi.json").Handler(openapi.Handler())
 
 	// Controller API:
 	web.Router("/c/login", &controllers.CommonController{}, "post:Login")
@@ -48,6 +51,7 @@ func registerRoutes() {
 
 	web.Router("/api/internal/renameadmin", &api.InternalAPI{}, "post:RenameAdmin")
 	web.Router("/api/internal/syncquota", &api.InternalAPI{}, "post:SyncQuota")
+	web.Router("/api/internal/cache/flush", &api.InternalAPI{}, "post:FlushCache")
 
 	web.Router("/service/notifications/jobs/webhook/:id([0-9]+)", &jobs.Handler{}, "post:HandleNotificationJob")
 	router.NewRoute().Method(http.MethodPost).Path("/service/notifications/jobs/adminjob/:id([0-9]+)").Handler(handler.NewJobStatusHandler())         // legacy job status hook endpoint for adminjob
@@ -0,0 +1,69 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+)
+
+// only the "eq" operator which is used by the identity providers to look up the existing resources is supported
+var filterRegexp = regexp.MustCompile(`^\s*([A-Za-z][\w.]*)\s+(?i:eq)\s+("(?:[^"\\]|\\.)*"|\S+)\s*$`)
+
+// filter is the parsed "attribute eq value" expression
+type filter struct {
+	Attribute string
+	Value     string
+}
+
+// parseFilter parses the filter expression, e.g. userName eq "alice"
+func parseFilter(expr string) (*filter, error) {
+	if len(strings.TrimSpace(expr)) == 0 {
+		return nil, nil
+	}
+	matches := filterRegexp.FindStringSubmatch(expr)
+	if len(matches) != 3 {
+		return nil, errors.BadRequestError(nil).WithMessage("unsupported filter %s, only the \"eq\" operator is supported", expr)
+	}
+	value := matches[2]
+	if strings.HasPrefix(value, `"`) {
+		v, err := strconv.Unquote(value)
+		if err != nil {
+			return nil, errors.BadRequestError(nil).WithMessage("invalid filter value %s", value)
+		}
+		value = v
+	}
+	return &filter{Attribute: matches[1], Value: value}, nil
+}
+
+// parseValuePath parses the value path of the PATCH operation, e.g. members[value eq "1"],
+// returns the attribute name and the filter in the brackets
+func parseValuePath(path string) (string, *filter, error) {
+	i := strings.Index(path, "[")
+	if i < 0 {
+		return path, nil, nil
+	}
+	if !strings.HasSuffix(path, "]") {
+		return "", nil, errors.BadRequestError(nil).WithMessage("invalid path %s", path)
+	}
+	f, err := parseFilter(path[i+1 : len(path)-1])
+	if err != nil {
+		return "", nil, err
+	}
+	return path[:i], f, nil
+}
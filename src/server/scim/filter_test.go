@@ -0,0 +1,66 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	f, err := parseFilter("")
+	require.Nil(t, err)
+	assert.Nil(t, f)
+
+	f, err = parseFilter(`userName eq "alice"`)
+	require.Nil(t, err)
+	assert.Equal(t, "userName", f.Attribute)
+	assert.Equal(t, "alice", f.Value)
+
+	f, err = parseFilter(`displayName EQ "dev \"team\""`)
+	require.Nil(t, err)
+	assert.Equal(t, "displayName", f.Attribute)
+	assert.Equal(t, `dev "team"`, f.Value)
+
+	f, err = parseFilter(`emails.value eq alice@example.com`)
+	require.Nil(t, err)
+	assert.Equal(t, "emails.value", f.Attribute)
+	assert.Equal(t, "alice@example.com", f.Value)
+
+	_, err = parseFilter(`userName sw "a"`)
+	assert.NotNil(t, err)
+
+	_, err = parseFilter(`userName eq "a" and active eq true`)
+	assert.NotNil(t, err)
+}
+
+func TestParseValuePath(t *testing.T) {
+	attr, f, err := parseValuePath("members")
+	require.Nil(t, err)
+	assert.Equal(t, "members", attr)
+	assert.Nil(t, f)
+
+	attr, f, err = parseValuePath(`members[value eq "2"]`)
+	require.Nil(t, err)
+	assert.Equal(t, "members", attr)
+	require.NotNil(t, f)
+	assert.Equal(t, "value", f.Attribute)
+	assert.Equal(t, "2", f.Value)
+
+	_, _, err = parseValuePath(`members[value eq "2"`)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,416 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/controller/user"
+	"github.com/goharbor/harbor/src/controller/usergroup"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	ugModel "github.com/goharbor/harbor/src/pkg/usergroup/model"
+	"github.com/goharbor/harbor/src/server/router"
+)
+
+func newGroupHandler() *groupHandler {
+	return &groupHandler{
+		userCtl:  user.Ctl,
+		groupCtl: usergroup.Ctl,
+	}
+}
+
+type groupHandler struct {
+	userCtl  user.Controller
+	groupCtl usergroup.Controller
+}
+
+func (g *groupHandler) list(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	groupType, err := groupType(ctx)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	f, err := parseFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	keywords := map[string]interface{}{"GroupType": groupType}
+	if f != nil {
+		switch strings.ToLower(f.Attribute) {
+		case "displayname":
+			keywords["GroupName"] = f.Value
+		case "id":
+			keywords["ID"] = f.Value
+		default:
+			sendError(w, errors.BadRequestError(nil).WithMessage("unsupported filter attribute %s", f.Attribute))
+			return
+		}
+	}
+	query, err := buildQuery(r, keywords)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	total, err := g.groupCtl.Count(ctx, query)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	// some identity providers exclude the members when querying the groups to reduce the payload
+	withMembers := !strings.Contains(r.URL.Query().Get("excludedAttributes"), "members")
+	var resources []interface{}
+	if total > 0 && query.PageSize > 0 {
+		groups, err := g.groupCtl.List(ctx, query)
+		if err != nil {
+			sendError(w, err)
+			return
+		}
+		for _, group := range groups {
+			res, err := g.toGroup(ctx, group, withMembers)
+			if err != nil {
+				sendError(w, err)
+				return
+			}
+			resources = append(resources, res)
+		}
+	}
+	sendResponse(w, http.StatusOK, listResponse(query, total, resources))
+}
+
+func (g *groupHandler) get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	group, err := g.getGroup(ctx, router.Param(ctx, ":id"))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	res, err := g.toGroup(ctx, group, true)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	sendResponse(w, http.StatusOK, res)
+}
+
+func (g *groupHandler) create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	groupType, err := groupType(ctx)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	grp := &Group{}
+	if err := decode(r, grp); err != nil {
+		sendError(w, err)
+		return
+	}
+	if len(grp.DisplayName) == 0 || len(grp.DisplayName) > 255 {
+		sendError(w, errors.BadRequestError(nil).WithMessage("the displayName must be between 1 and 255 characters"))
+		return
+	}
+	id, err := g.groupCtl.Create(ctx, ugModel.UserGroup{GroupName: grp.DisplayName, GroupType: groupType})
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	if err := g.addMembers(ctx, id, grp.Members); err != nil {
+		sendError(w, err)
+		return
+	}
+	group, err := g.groupCtl.Get(ctx, id)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	res, err := g.toGroup(ctx, group, true)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	w.Header().Set("Location", res.Meta.Location)
+	sendResponse(w, http.StatusCreated, res)
+}
+
+func (g *groupHandler) replace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	group, err := g.getGroup(ctx, router.Param(ctx, ":id"))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	grp := &Group{}
+	if err := decode(r, grp); err != nil {
+		sendError(w, err)
+		return
+	}
+	if err := g.rename(ctx, group, grp.DisplayName); err != nil {
+		sendError(w, err)
+		return
+	}
+	if err := g.replaceMembers(ctx, group.ID, grp.Members); err != nil {
+		sendError(w, err)
+		return
+	}
+	res, err := g.toGroup(ctx, group, true)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	sendResponse(w, http.StatusOK, res)
+}
+
+func (g *groupHandler) patch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	group, err := g.getGroup(ctx, router.Param(ctx, ":id"))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	req := &PatchRequest{}
+	if err := decode(r, req); err != nil {
+		sendError(w, err)
+		return
+	}
+	for _, op := range req.Operations {
+		if err := g.applyOperation(ctx, group, op); err != nil {
+			sendError(w, err)
+			return
+		}
+	}
+	res, err := g.toGroup(ctx, group, true)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	sendResponse(w, http.StatusOK, res)
+}
+
+func (g *groupHandler) delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	group, err := g.getGroup(ctx, router.Param(ctx, ":id"))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	if err := g.groupCtl.Delete(ctx, group.ID); err != nil {
+		sendError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (g *groupHandler) applyOperation(ctx context.Context, group *ugModel.UserGroup, op *PatchOperation) error {
+	attr, f, err := parseValuePath(op.Path)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(op.Op) {
+	case "add", "replace":
+		attrs := map[string]json.RawMessage{}
+		if len(attr) > 0 {
+			attrs[attr] = op.Value
+		} else if err := json.Unmarshal(op.Value, &attrs); err != nil {
+			return errors.BadRequestError(nil).WithMessage("invalid value of the operation: %v", err)
+		}
+		for path, value := range attrs {
+			switch strings.ToLower(path) {
+			case "displayname":
+				var name string
+				if err := json.Unmarshal(value, &name); err != nil {
+					return errors.BadRequestError(nil).WithMessage("invalid value of %s: %v", path, err)
+				}
+				if err := g.rename(ctx, group, name); err != nil {
+					return err
+				}
+			case "members":
+				members := []*MultiValued{}
+				if err := json.Unmarshal(value, &members); err != nil {
+					return errors.BadRequestError(nil).WithMessage("invalid value of %s: %v", path, err)
+				}
+				if strings.EqualFold(op.Op, "replace") {
+					err = g.replaceMembers(ctx, group.ID, members)
+				} else {
+					err = g.addMembers(ctx, group.ID, members)
+				}
+				if err != nil {
+					return err
+				}
+			}
+			// the other attributes, e.g. externalId, are not stored in Harbor and ignored
+		}
+		return nil
+	case "remove":
+		if !strings.EqualFold(attr, "members") {
+			return errors.BadRequestError(nil).WithMessage("unsupported path %s of the remove operation", op.Path)
+		}
+		members := []*MultiValued{}
+		if f != nil {
+			if !strings.EqualFold(f.Attribute, "value") {
+				return errors.BadRequestError(nil).WithMessage("unsupported path %s of the remove operation", op.Path)
+			}
+			members = append(members, &MultiValued{Value: f.Value})
+		} else if len(op.Value) > 0 {
+			if err := json.Unmarshal(op.Value, &members); err != nil {
+				return errors.BadRequestError(nil).WithMessage("invalid value of the operation: %v", err)
+			}
+		} else {
+			// remove all the members
+			return g.replaceMembers(ctx, group.ID, nil)
+		}
+		for _, m := range members {
+			userID, err := parseID(m.Value)
+			if err != nil {
+				return errors.BadRequestError(nil).WithMessage("invalid member %s", m.Value)
+			}
+			if err := g.groupCtl.RemoveMember(ctx, group.ID, userID); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return errors.BadRequestError(nil).WithMessage("unsupported operation %s", op.Op)
+	}
+}
+
+func (g *groupHandler) rename(ctx context.Context, group *ugModel.UserGroup, name string) error {
+	if len(name) == 0 || name == group.GroupName {
+		return nil
+	}
+	if len(name) > 255 {
+		return errors.BadRequestError(nil).WithMessage("the displayName must be between 1 and 255 characters")
+	}
+	if err := g.groupCtl.Update(ctx, group.ID, name); err != nil {
+		return err
+	}
+	group.GroupName = name
+	return nil
+}
+
+func (g *groupHandler) addMembers(ctx context.Context, groupID int, members []*MultiValued) error {
+	for _, m := range members {
+		userID, err := g.memberID(ctx, m)
+		if err != nil {
+			return err
+		}
+		if err := g.groupCtl.AddMember(ctx, groupID, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *groupHandler) replaceMembers(ctx context.Context, groupID int, members []*MultiValued) error {
+	current, err := g.groupCtl.ListMemberIDs(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	expected := map[int]bool{}
+	for _, m := range members {
+		userID, err := g.memberID(ctx, m)
+		if err != nil {
+			return err
+		}
+		expected[userID] = true
+	}
+	for _, userID := range current {
+		if expected[userID] {
+			delete(expected, userID)
+			continue
+		}
+		if err := g.groupCtl.RemoveMember(ctx, groupID, userID); err != nil {
+			return err
+		}
+	}
+	for userID := range expected {
+		if err := g.groupCtl.AddMember(ctx, groupID, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// memberID returns the ID of the user referenced by the member and makes sure the user exists
+func (g *groupHandler) memberID(ctx context.Context, m *MultiValued) (int, error) {
+	userID, err := parseID(m.Value)
+	if err != nil {
+		return 0, errors.BadRequestError(nil).WithMessage("invalid member %s", m.Value)
+	}
+	if _, err := g.userCtl.Get(ctx, userID, nil); err != nil {
+		if errors.IsNotFoundErr(err) {
+			return 0, errors.BadRequestError(nil).WithMessage("the member %s not found", m.Value)
+		}
+		return 0, err
+	}
+	return userID, nil
+}
+
+func (g *groupHandler) getGroup(ctx context.Context, id string) (*ugModel.UserGroup, error) {
+	groupType, err := groupType(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groupID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	group, err := g.groupCtl.Get(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	// only the groups of the current auth mode can be managed
+	if group == nil || group.GroupType != groupType {
+		return nil, errors.NotFoundError(nil).WithMessage("group %s not found", id)
+	}
+	return group, nil
+}
+
+func (g *groupHandler) toGroup(ctx context.Context, group *ugModel.UserGroup, withMembers bool) (*Group, error) {
+	var members []*models.User
+	if withMembers {
+		ids, err := g.groupCtl.ListMemberIDs(ctx, group.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			u, err := g.userCtl.Get(ctx, id, nil)
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, u)
+		}
+	}
+	return toGroup(group, members, location("Groups", group.ID)), nil
+}
+
+// groupType returns the type of the provisioned groups according to the auth mode, the groups can only be
+// provisioned in the auth modes in which the groups are managed by the identity provider
+func groupType(ctx context.Context) (int, error) {
+	switch mode := lib.GetAuthMode(ctx); mode {
+	case common.OIDCAuth:
+		return common.OIDCGroupType, nil
+	case common.HTTPAuth:
+		return common.HTTPGroupType, nil
+	default:
+		return 0, errors.BadRequestError(nil).WithMessage("provisioning groups is not supported in the auth mode %s", mode)
+	}
+}
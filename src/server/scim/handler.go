@@ -0,0 +1,184 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/server/middleware"
+)
+
+const (
+	contentType = "application/scim+json"
+	// the default and max count of resources returned in one page
+	maxCount = 100
+)
+
+var statusMap = map[string]int{
+	errors.BadRequestCode:   http.StatusBadRequest,
+	errors.UnAuthorizedCode: http.StatusUnauthorized,
+	errors.ForbiddenCode:    http.StatusForbidden,
+	errors.NotFoundCode:     http.StatusNotFound,
+	errors.ConflictCode:     http.StatusConflict,
+}
+
+// authMiddleware authenticates the requests from the identity provider by the bearer token,
+// the SCIM endpoint is disabled when no token is configured
+func authMiddleware() func(http.Handler) http.Handler {
+	return middleware.New(func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		token := config.SCIMToken(r.Context())
+		if len(token) == 0 {
+			sendError(w, errors.NotFoundError(nil).WithMessage("the SCIM endpoint is not enabled"))
+			return
+		}
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Bearer ") ||
+			subtle.ConstantTimeCompare([]byte(strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))), []byte(token)) != 1 {
+			sendError(w, errors.UnauthorizedError(nil).WithMessage("invalid bearer token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func sendResponse(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	if payload == nil {
+		return
+	}
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		log.Errorf("failed to encode the SCIM response: %v", err)
+	}
+}
+
+// sendError sends the error in the format defined in RFC 7644 section 3.12
+func sendError(w http.ResponseWriter, err error) {
+	status, ok := statusMap[errors.ErrCode(err)]
+	detail := err.Error()
+	if !ok {
+		log.Errorf("failed to handle the SCIM request: %v", err)
+		status = http.StatusInternalServerError
+		detail = "internal server error"
+	}
+	e := &Error{
+		Schemas: []string{schemaError},
+		Status:  strconv.Itoa(status),
+		Detail:  detail,
+	}
+	if status == http.StatusConflict {
+		e.ScimType = "uniqueness"
+	}
+	sendResponse(w, status, e)
+}
+
+func decode(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return errors.BadRequestError(err).WithMessage("invalid request body: %v", err)
+	}
+	return nil
+}
+
+// buildQuery builds the query according to the 1-based "startIndex" and the "count" parameters
+func buildQuery(r *http.Request, keywords map[string]interface{}) (*q.Query, error) {
+	values := r.URL.Query()
+	startIndex, count := int64(1), int64(maxCount)
+	var err error
+	if v := values.Get("startIndex"); len(v) > 0 {
+		if startIndex, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return nil, errors.BadRequestError(nil).WithMessage("invalid startIndex %s", v)
+		}
+		if startIndex < 1 {
+			startIndex = 1
+		}
+	}
+	if v := values.Get("count"); len(v) > 0 {
+		if count, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return nil, errors.BadRequestError(nil).WithMessage("invalid count %s", v)
+		}
+		if count < 0 {
+			count = 0
+		}
+		if count > maxCount {
+			count = maxCount
+		}
+	}
+	query := q.New(keywords)
+	query.PageSize = count
+	if count > 0 {
+		query.PageNumber = (startIndex-1)/count + 1
+	}
+	return query, nil
+}
+
+func listResponse(query *q.Query, total int64, resources []interface{}) *ListResponse {
+	if resources == nil {
+		resources = []interface{}{}
+	}
+	startIndex := int64(1)
+	if query.PageSize > 0 {
+		startIndex = (query.PageNumber-1)*query.PageSize + 1
+	}
+	return &ListResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+func location(resource string, id int) string {
+	return fmt.Sprintf("%s/api/scim/v2/%s/%d", extEndpoint(), resource, id)
+}
+
+func extEndpoint() string {
+	ep, err := config.ExtEndpoint()
+	if err != nil {
+		log.Warningf("failed to get the external endpoint: %v", err)
+		return ""
+	}
+	return strings.TrimSuffix(ep, "/")
+}
+
+// serviceProviderConfig returns the capabilities of the SCIM endpoint
+func serviceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	sendResponse(w, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{schemaServiceProviderConfig},
+		"patch":          map[string]bool{"supported": true},
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": maxCount},
+		"changePassword": map[string]bool{"supported": false},
+		"sort":           map[string]bool{"supported": false},
+		"etag":           map[string]bool{"supported": false},
+		"authenticationSchemes": []map[string]interface{}{
+			{
+				"type":        "oauthbearertoken",
+				"name":        "OAuth Bearer Token",
+				"description": "Authentication scheme using the SCIM token configured in Harbor",
+				"primary":     true,
+			},
+		},
+	})
+}
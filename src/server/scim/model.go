@@ -0,0 +1,219 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/lib/errors"
+	ugModel "github.com/goharbor/harbor/src/pkg/usergroup/model"
+)
+
+// the schemas defined in RFC 7643 and RFC 7644
+const (
+	schemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	schemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	schemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	resourceTypeUser  = "User"
+	resourceTypeGroup = "Group"
+)
+
+// Meta is the common attribute "meta" of the SCIM resources
+type Meta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+// Name is the attribute "name" of the SCIM user
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// MultiValued is the element of the multi-valued attributes, e.g. "emails", "members", etc.
+type MultiValued struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// User is the SCIM user resource
+type User struct {
+	Schemas     []string       `json:"schemas"`
+	ID          string         `json:"id,omitempty"`
+	ExternalID  string         `json:"externalId,omitempty"`
+	UserName    string         `json:"userName"`
+	Name        *Name          `json:"name,omitempty"`
+	DisplayName string         `json:"displayName,omitempty"`
+	Emails      []*MultiValued `json:"emails,omitempty"`
+	Active      *bool          `json:"active,omitempty"`
+	Groups      []*MultiValued `json:"groups,omitempty"`
+	Meta        *Meta          `json:"meta,omitempty"`
+}
+
+// email returns the primary email of the user, the first one is returned if no primary email specified
+func (u *User) email() string {
+	for _, e := range u.Emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// realname returns the name of the user which is displayed in Harbor
+func (u *User) realname() string {
+	if len(u.DisplayName) > 0 {
+		return u.DisplayName
+	}
+	if u.Name == nil {
+		return ""
+	}
+	if len(u.Name.Formatted) > 0 {
+		return u.Name.Formatted
+	}
+	return strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+}
+
+// active returns false only when the user is deactivated explicitly
+func (u *User) active() bool {
+	return u.Active == nil || *u.Active
+}
+
+// Group is the SCIM group resource
+type Group struct {
+	Schemas     []string       `json:"schemas"`
+	ID          string         `json:"id,omitempty"`
+	ExternalID  string         `json:"externalId,omitempty"`
+	DisplayName string         `json:"displayName"`
+	Members     []*MultiValued `json:"members,omitempty"`
+	Meta        *Meta          `json:"meta,omitempty"`
+}
+
+// ListResponse is the response of the query requests
+type ListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int64         `json:"totalResults"`
+	StartIndex   int64         `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// PatchRequest is the body of the PATCH requests
+type PatchRequest struct {
+	Schemas    []string          `json:"schemas"`
+	Operations []*PatchOperation `json:"Operations"`
+}
+
+// PatchOperation is one of the operations in the PATCH request
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Error is the SCIM error response
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+func toUser(u *models.User, externalID string, groups []*ugModel.UserGroup, location string) *User {
+	active := !u.Deactivated
+	user := &User{
+		Schemas:     []string{schemaUser},
+		ID:          strconv.Itoa(u.UserID),
+		ExternalID:  externalID,
+		UserName:    u.Username,
+		DisplayName: u.Realname,
+		Active:      &active,
+		Meta: &Meta{
+			ResourceType: resourceTypeUser,
+			Created:      &u.CreationTime,
+			LastModified: &u.UpdateTime,
+			Location:     location,
+		},
+	}
+	if len(u.Realname) > 0 {
+		user.Name = &Name{Formatted: u.Realname}
+	}
+	if len(u.Email) > 0 {
+		user.Emails = []*MultiValued{{Value: u.Email, Primary: true}}
+	}
+	for _, g := range groups {
+		user.Groups = append(user.Groups, &MultiValued{Value: strconv.Itoa(g.ID), Display: g.GroupName})
+	}
+	return user
+}
+
+func toGroup(g *ugModel.UserGroup, members []*models.User, location string) *Group {
+	group := &Group{
+		Schemas:     []string{schemaGroup},
+		ID:          strconv.Itoa(g.ID),
+		DisplayName: g.GroupName,
+		Meta: &Meta{
+			ResourceType: resourceTypeGroup,
+			Location:     location,
+		},
+	}
+	for _, m := range members {
+		group.Members = append(group.Members, &MultiValued{Value: strconv.Itoa(m.UserID), Display: m.Username})
+	}
+	return group
+}
+
+// parseID parses the ID of the SCIM resource, the IDs are the IDs of the Harbor users or groups
+func parseID(id string) (int, error) {
+	i, err := strconv.Atoi(id)
+	if err != nil || i <= 0 {
+		return 0, errors.NotFoundError(nil).WithMessage("resource %s not found", id)
+	}
+	return i, nil
+}
+
+// parseBool parses the boolean value which may be sent as a string by some identity providers, e.g. "False"
+func parseBool(raw json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(raw, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return false, errors.BadRequestError(nil).WithMessage("invalid boolean value %s", string(raw))
+	}
+	b, err := strconv.ParseBool(strings.ToLower(s))
+	if err != nil {
+		return false, errors.BadRequestError(nil).WithMessage("invalid boolean value %s", s)
+	}
+	return b, nil
+}
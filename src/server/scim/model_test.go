@@ -0,0 +1,95 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/common/models"
+	ugModel "github.com/goharbor/harbor/src/pkg/usergroup/model"
+)
+
+func TestUserAttributes(t *testing.T) {
+	u := &User{}
+	assert.Equal(t, "", u.email())
+	assert.Equal(t, "", u.realname())
+	assert.True(t, u.active())
+
+	inactive := false
+	u = &User{
+		Name:   &Name{GivenName: "Alice", FamilyName: "Smith"},
+		Emails: []*MultiValued{{Value: "a@example.com"}, {Value: "alice@example.com", Primary: true}},
+		Active: &inactive,
+	}
+	assert.Equal(t, "alice@example.com", u.email())
+	assert.Equal(t, "Alice Smith", u.realname())
+	assert.False(t, u.active())
+
+	u.DisplayName = "alice"
+	assert.Equal(t, "alice", u.realname())
+}
+
+func TestToUser(t *testing.T) {
+	u := toUser(&models.User{UserID: 3, Username: "alice", Email: "alice@example.com", Realname: "Alice"},
+		"sub", []*ugModel.UserGroup{{ID: 1, GroupName: "dev"}}, "/api/scim/v2/Users/3")
+	assert.Equal(t, "3", u.ID)
+	assert.Equal(t, "sub", u.ExternalID)
+	assert.Equal(t, "alice", u.UserName)
+	assert.True(t, *u.Active)
+	require.Len(t, u.Emails, 1)
+	assert.Equal(t, "alice@example.com", u.Emails[0].Value)
+	require.Len(t, u.Groups, 1)
+	assert.Equal(t, "1", u.Groups[0].Value)
+	assert.Equal(t, "dev", u.Groups[0].Display)
+	assert.Equal(t, resourceTypeUser, u.Meta.ResourceType)
+
+	// the deactivated user is inactive
+	u = toUser(&models.User{UserID: 3, Username: "alice", Deactivated: true}, "", nil, "")
+	assert.False(t, *u.Active)
+}
+
+func TestToGroup(t *testing.T) {
+	g := toGroup(&ugModel.UserGroup{ID: 1, GroupName: "dev"}, []*models.User{{UserID: 3, Username: "alice"}}, "")
+	assert.Equal(t, "1", g.ID)
+	assert.Equal(t, "dev", g.DisplayName)
+	require.Len(t, g.Members, 1)
+	assert.Equal(t, "3", g.Members[0].Value)
+	assert.Equal(t, "alice", g.Members[0].Display)
+}
+
+func TestParseBool(t *testing.T) {
+	for raw, expected := range map[string]bool{`true`: true, `false`: false, `"False"`: false, `"True"`: true} {
+		b, err := parseBool(json.RawMessage(raw))
+		require.Nil(t, err)
+		assert.Equal(t, expected, b)
+	}
+	_, err := parseBool(json.RawMessage(`"no"`))
+	assert.NotNil(t, err)
+}
+
+func TestParseID(t *testing.T) {
+	id, err := parseID("12")
+	require.Nil(t, err)
+	assert.Equal(t, 12, id)
+
+	_, err = parseID("abc")
+	assert.NotNil(t, err)
+	_, err = parseID("0")
+	assert.NotNil(t, err)
+}
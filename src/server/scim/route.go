@@ -0,0 +1,46 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"net/http"
+
+	"github.com/goharbor/harbor/src/server/router"
+)
+
+// RegisterRoutes for the SCIM 2.0 APIs which are used by the identity providers to provision the users and groups
+func RegisterRoutes() {
+	root := router.NewRoute().
+		Path("/api/scim/v2").
+		Middleware(authMiddleware())
+
+	root.NewRoute().Method(http.MethodGet).Path("/ServiceProviderConfig").HandlerFunc(serviceProviderConfig)
+
+	users := newUserHandler()
+	root.NewRoute().Method(http.MethodGet).Path("/Users").HandlerFunc(users.list)
+	root.NewRoute().Method(http.MethodPost).Path("/Users").HandlerFunc(users.create)
+	root.NewRoute().Method(http.MethodGet).Path("/Users/:id").HandlerFunc(users.get)
+	root.NewRoute().Method(http.MethodPut).Path("/Users/:id").HandlerFunc(users.replace)
+	root.NewRoute().Method(http.MethodPatch).Path("/Users/:id").HandlerFunc(users.patch)
+	root.NewRoute().Method(http.MethodDelete).Path("/Users/:id").HandlerFunc(users.delete)
+
+	groups := newGroupHandler()
+	root.NewRoute().Method(http.MethodGet).Path("/Groups").HandlerFunc(groups.list)
+	root.NewRoute().Method(http.MethodPost).Path("/Groups").HandlerFunc(groups.create)
+	root.NewRoute().Method(http.MethodGet).Path("/Groups/:id").HandlerFunc(groups.get)
+	root.NewRoute().Method(http.MethodPut).Path("/Groups/:id").HandlerFunc(groups.replace)
+	root.NewRoute().Method(http.MethodPatch).Path("/Groups/:id").HandlerFunc(groups.patch)
+	root.NewRoute().Method(http.MethodDelete).Path("/Groups/:id").HandlerFunc(groups.delete)
+}
@@ -0,0 +1,397 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/mail"
+	"strings"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/controller/user"
+	"github.com/goharbor/harbor/src/controller/usergroup"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	ugModel "github.com/goharbor/harbor/src/pkg/usergroup/model"
+	"github.com/goharbor/harbor/src/server/router"
+)
+
+const provisionedComment = "Provisioned via SCIM"
+
+func newUserHandler() *userHandler {
+	return &userHandler{
+		userCtl:  user.Ctl,
+		groupCtl: usergroup.Ctl,
+	}
+}
+
+type userHandler struct {
+	userCtl  user.Controller
+	groupCtl usergroup.Controller
+}
+
+func (u *userHandler) list(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	f, err := parseFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	keywords := map[string]interface{}{}
+	if f != nil {
+		switch strings.ToLower(f.Attribute) {
+		case "username":
+			keywords["username"] = f.Value
+		case "id":
+			keywords["user_id"] = f.Value
+		case "externalid":
+			// the external ID is the subject of the OIDC user and only available in OIDC auth mode
+			id, err := u.userIDBySubject(ctx, f.Value)
+			if err != nil {
+				sendError(w, err)
+				return
+			}
+			keywords["user_id"] = id
+		case "emails", "emails.value":
+			keywords["email"] = f.Value
+		default:
+			sendError(w, errors.BadRequestError(nil).WithMessage("unsupported filter attribute %s", f.Attribute))
+			return
+		}
+	}
+	query, err := buildQuery(r, keywords)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	total, err := u.userCtl.Count(ctx, query)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	var resources []interface{}
+	if total > 0 && query.PageSize > 0 {
+		users, err := u.userCtl.List(ctx, query)
+		if err != nil {
+			sendError(w, err)
+			return
+		}
+		for _, usr := range users {
+			res, err := u.toUser(ctx, usr.UserID)
+			if err != nil {
+				sendError(w, err)
+				return
+			}
+			resources = append(resources, res)
+		}
+	}
+	sendResponse(w, http.StatusOK, listResponse(query, total, resources))
+}
+
+func (u *userHandler) get(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(router.Param(r.Context(), ":id"))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	res, err := u.toUser(r.Context(), id)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	sendResponse(w, http.StatusOK, res)
+}
+
+func (u *userHandler) create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	usr := &User{}
+	if err := decode(r, usr); err != nil {
+		sendError(w, err)
+		return
+	}
+	if !usr.active() {
+		sendError(w, errors.BadRequestError(nil).WithMessage("creating the inactive user is not supported"))
+		return
+	}
+	m := &models.User{
+		Username: usr.UserName,
+		Realname: usr.realname(),
+		Email:    usr.email(),
+		Comment:  provisionedComment,
+	}
+	if err := validateUser(m); err != nil {
+		sendError(w, err)
+		return
+	}
+	if len(m.Realname) == 0 {
+		m.Realname = m.Username
+	}
+
+	var err error
+	if lib.GetAuthMode(ctx) == common.OIDCAuth {
+		// bind the user with the subject so that the user can be found when logging in via the OIDC provider
+		err = u.onboardOIDCUser(ctx, m, usr.ExternalID)
+	} else {
+		// the password is useless as the users are authenticated by the identity provider
+		m.Password = utils.GenerateRandomStringWithLen(32)
+		m.UserID, err = u.userCtl.Create(ctx, m)
+	}
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	res, err := u.toUser(ctx, m.UserID)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	w.Header().Set("Location", res.Meta.Location)
+	sendResponse(w, http.StatusCreated, res)
+}
+
+func (u *userHandler) replace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseID(router.Param(ctx, ":id"))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	usr := &User{}
+	if err := decode(r, usr); err != nil {
+		sendError(w, err)
+		return
+	}
+	current, err := u.userCtl.Get(ctx, id, nil)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	if len(usr.UserName) > 0 && usr.UserName != current.Username {
+		sendError(w, errors.BadRequestError(nil).WithMessage("the userName is immutable"))
+		return
+	}
+	u.update(ctx, w, current, usr.realname(), usr.email(), usr.active())
+}
+
+func (u *userHandler) patch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseID(router.Param(ctx, ":id"))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	req := &PatchRequest{}
+	if err := decode(r, req); err != nil {
+		sendError(w, err)
+		return
+	}
+	current, err := u.userCtl.Get(ctx, id, nil)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	realname, email, active := current.Realname, current.Email, !current.Deactivated
+	for _, op := range req.Operations {
+		if !strings.EqualFold(op.Op, "add") && !strings.EqualFold(op.Op, "replace") {
+			sendError(w, errors.BadRequestError(nil).WithMessage("unsupported operation %s", op.Op))
+			return
+		}
+		attrs := map[string]json.RawMessage{}
+		if len(op.Path) > 0 {
+			attrs[op.Path] = op.Value
+		} else if err := json.Unmarshal(op.Value, &attrs); err != nil {
+			sendError(w, errors.BadRequestError(nil).WithMessage("invalid value of the operation: %v", err))
+			return
+		}
+		for path, value := range attrs {
+			var err error
+			switch p := strings.ToLower(path); {
+			case p == "active":
+				active, err = parseBool(value)
+			case p == "displayname" || p == "name.formatted":
+				err = json.Unmarshal(value, &realname)
+			case strings.HasPrefix(p, "emails"):
+				email, err = parseEmail(value)
+			}
+			// the other attributes, e.g. externalId, are not stored in Harbor and ignored
+			if err != nil {
+				sendError(w, errors.BadRequestError(nil).WithMessage("invalid value of %s: %v", path, err))
+				return
+			}
+		}
+	}
+	u.update(ctx, w, current, realname, email, active)
+}
+
+// update the profile of the user, the user is deactivated rather than deleted when "active" is false
+// to keep the audit history, and reactivated when "active" is true again
+func (u *userHandler) update(ctx context.Context, w http.ResponseWriter, current *models.User, realname, email string, active bool) {
+	if len(realname) > 0 {
+		current.Realname = realname
+	}
+	current.Email = email
+	if err := validateUser(current); err != nil {
+		sendError(w, err)
+		return
+	}
+	if err := u.userCtl.UpdateProfile(ctx, current, "Realname", "Email"); err != nil {
+		sendError(w, err)
+		return
+	}
+	if !active && !current.Deactivated {
+		if _, err := u.userCtl.Deactivate(ctx, current.UserID, 0); err != nil {
+			sendError(w, err)
+			return
+		}
+	} else if active && current.Deactivated {
+		if err := u.userCtl.Reactivate(ctx, current.UserID); err != nil {
+			sendError(w, err)
+			return
+		}
+	}
+	res, err := u.toUser(ctx, current.UserID)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	sendResponse(w, http.StatusOK, res)
+}
+
+func (u *userHandler) delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseID(router.Param(ctx, ":id"))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	if _, err := u.userCtl.Get(ctx, id, nil); err != nil {
+		sendError(w, err)
+		return
+	}
+	if err := u.userCtl.Delete(ctx, id); err != nil {
+		sendError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (u *userHandler) onboardOIDCUser(ctx context.Context, m *models.User, subject string) error {
+	if len(subject) == 0 {
+		return errors.BadRequestError(nil).WithMessage("the externalId which is the subject of the OIDC user is required")
+	}
+	setting, err := config.OIDCSetting(ctx)
+	if err != nil {
+		return err
+	}
+	key, err := config.SecretKey()
+	if err != nil {
+		return err
+	}
+	secret, err := utils.ReversibleEncrypt(utils.GenerateRandomString(), key)
+	if err != nil {
+		return err
+	}
+	m.OIDCUserMeta = &models.OIDCUser{
+		SubIss: subject + setting.Endpoint,
+		Secret: secret,
+	}
+	return u.userCtl.OnboardOIDCUser(ctx, m)
+}
+
+func (u *userHandler) userIDBySubject(ctx context.Context, subject string) (int, error) {
+	if lib.GetAuthMode(ctx) != common.OIDCAuth {
+		return 0, errors.BadRequestError(nil).WithMessage("filtering by externalId is only supported in OIDC auth mode")
+	}
+	setting, err := config.OIDCSetting(ctx)
+	if err != nil {
+		return 0, err
+	}
+	usr, err := u.userCtl.GetBySubIss(ctx, subject, setting.Endpoint)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			// no user matches the filter
+			return 0, nil
+		}
+		return 0, err
+	}
+	return usr.UserID, nil
+}
+
+func (u *userHandler) toUser(ctx context.Context, id int) (*User, error) {
+	oidcMode := lib.GetAuthMode(ctx) == common.OIDCAuth
+	usr, err := u.userCtl.Get(ctx, id, &user.Option{WithOIDCInfo: oidcMode})
+	if err != nil {
+		return nil, err
+	}
+	externalID := ""
+	if oidcMode && usr.OIDCUserMeta != nil {
+		setting, err := config.OIDCSetting(ctx)
+		if err != nil {
+			return nil, err
+		}
+		externalID = strings.TrimSuffix(usr.OIDCUserMeta.SubIss, setting.Endpoint)
+	}
+	groupIDs, err := u.groupCtl.ListGroupIDsByUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	var groups []*ugModel.UserGroup
+	for _, gid := range groupIDs {
+		g, err := u.groupCtl.Get(ctx, gid)
+		if err != nil {
+			return nil, err
+		}
+		if g != nil {
+			groups = append(groups, g)
+		}
+	}
+	return toUser(usr, externalID, groups, location("Users", id)), nil
+}
+
+func validateUser(u *models.User) error {
+	if utils.IsIllegalLength(u.Username, 1, 255) {
+		return errors.BadRequestError(nil).WithMessage("the userName must be between 1 and 255 characters")
+	}
+	if utils.IsContainIllegalChar(u.Username, []string{",", "~", "#", "$", "%"}) {
+		return errors.BadRequestError(nil).WithMessage("the userName contains illegal characters")
+	}
+	if len(u.Email) > 0 {
+		if _, err := mail.ParseAddress(u.Email); err != nil {
+			return errors.BadRequestError(nil).WithMessage("invalid email %s", u.Email)
+		}
+	}
+	if utils.IsIllegalLength(u.Realname, 0, 255) {
+		return errors.BadRequestError(nil).WithMessage("the displayName must be less than 256 characters")
+	}
+	return nil
+}
+
+// parseEmail parses the email from the value of the path "emails" or "emails[type eq "work"].value"
+func parseEmail(value json.RawMessage) (string, error) {
+	var email string
+	if err := json.Unmarshal(value, &email); err == nil {
+		return email, nil
+	}
+	emails := []*MultiValued{}
+	if err := json.Unmarshal(value, &emails); err != nil {
+		return "", err
+	}
+	return (&User{Emails: emails}).email(), nil
+}
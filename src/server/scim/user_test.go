@@ -0,0 +1,111 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/controller/user"
+	"github.com/goharbor/harbor/src/controller/usergroup"
+	"github.com/goharbor/harbor/src/lib/config"
+	_ "github.com/goharbor/harbor/src/pkg/config/inmemory"
+	testinguser "github.com/goharbor/harbor/src/testing/controller/user"
+	"github.com/goharbor/harbor/src/testing/mock"
+)
+
+// groupController returns no provisioned groups of the users
+type groupController struct {
+	usergroup.Controller
+}
+
+func (g *groupController) ListGroupIDsByUser(ctx context.Context, userID int) ([]int, error) {
+	return nil, nil
+}
+
+type userHandlerTestSuite struct {
+	suite.Suite
+	userCtl *testinguser.Controller
+	handler *userHandler
+}
+
+func (u *userHandlerTestSuite) SetupSuite() {
+	config.InitWithSettings(map[string]interface{}{
+		common.ExtEndpoint: "https://harbor.test",
+	})
+}
+
+func (u *userHandlerTestSuite) SetupTest() {
+	u.userCtl = &testinguser.Controller{}
+	u.handler = &userHandler{
+		userCtl:  u.userCtl,
+		groupCtl: &groupController{},
+	}
+	u.userCtl.On("UpdateProfile", mock.Anything, mock.Anything, "Realname", "Email").Return(nil)
+}
+
+func (u *userHandlerTestSuite) update(current *models.User, active bool) (*httptest.ResponseRecorder, *User) {
+	rec := httptest.NewRecorder()
+	u.handler.update(context.TODO(), rec, current, "Alice", "alice@example.com", active)
+	res := &User{}
+	if rec.Code == http.StatusOK {
+		u.Require().Nil(json.NewDecoder(rec.Body).Decode(res))
+	}
+	return rec, res
+}
+
+func (u *userHandlerTestSuite) TestDeactivate() {
+	u.userCtl.On("Deactivate", mock.Anything, 3, 0).Return(&user.DeactivationReport{UserID: 3}, nil).Once()
+	u.userCtl.On("Get", mock.Anything, 3, mock.Anything).Return(&models.User{UserID: 3, Username: "alice", Deactivated: true}, nil)
+
+	rec, res := u.update(&models.User{UserID: 3, Username: "alice"}, false)
+	u.Require().Equal(http.StatusOK, rec.Code)
+	u.False(*res.Active)
+	u.Equal("https://harbor.test/api/scim/v2/Users/3", res.Meta.Location)
+	// the user is deactivated rather than deleted
+	u.userCtl.AssertExpectations(u.T())
+	u.userCtl.AssertNotCalled(u.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (u *userHandlerTestSuite) TestReactivate() {
+	u.userCtl.On("Reactivate", mock.Anything, 3).Return(nil).Once()
+	u.userCtl.On("Get", mock.Anything, 3, mock.Anything).Return(&models.User{UserID: 3, Username: "alice"}, nil)
+
+	rec, res := u.update(&models.User{UserID: 3, Username: "alice", Deactivated: true}, true)
+	u.Require().Equal(http.StatusOK, rec.Code)
+	u.True(*res.Active)
+	u.userCtl.AssertExpectations(u.T())
+}
+
+func (u *userHandlerTestSuite) TestUpdateWithoutStateChange() {
+	u.userCtl.On("Get", mock.Anything, 3, mock.Anything).Return(&models.User{UserID: 3, Username: "alice"}, nil)
+
+	rec, res := u.update(&models.User{UserID: 3, Username: "alice"}, true)
+	u.Require().Equal(http.StatusOK, rec.Code)
+	u.True(*res.Active)
+	u.userCtl.AssertNotCalled(u.T(), "Deactivate", mock.Anything, mock.Anything, mock.Anything)
+	u.userCtl.AssertNotCalled(u.T(), "Reactivate", mock.Anything, mock.Anything)
+}
+
+func TestUserHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &userHandlerTestSuite{})
+}
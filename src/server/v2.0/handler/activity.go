@@ -0,0 +1,62 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	activityModel "github.com/goharbor/harbor/src/pkg/activity/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+)
+
+// parseActivityTypes parses the comma separated types of the activities, all the types are included if it's empty
+func parseActivityTypes(s string) ([]string, error) {
+	var types []string
+	for _, typ := range config.SplitAndTrim(s, ",") {
+		if !isActivityType(typ) {
+			return nil, errors.BadRequestError(nil).WithMessage("invalid activity type %q, only %v are supported",
+				typ, activityModel.Types)
+		}
+		types = append(types, typ)
+	}
+	return types, nil
+}
+
+func isActivityType(typ string) bool {
+	for _, t := range activityModel.Types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+func toActivitiesSwagger(activities []*activityModel.Activity) []*models.Activity {
+	var results []*models.Activity
+	for _, a := range activities {
+		results = append(results, &models.Activity{
+			Type:      a.Type,
+			ProjectID: a.ProjectID,
+			Resource:  a.Resource,
+			Operation: a.Operation,
+			Operator:  a.Operator,
+			Status:    a.Status,
+			OpTime:    strfmt.DateTime(a.OpTime),
+		})
+	}
+	return results
+}
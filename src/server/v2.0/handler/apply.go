@@ -0,0 +1,754 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/apply"
+)
+
+const (
+	apiBasePath   = "/api/v2.0"
+	applyPageSize = 100
+
+	applyKindProject           = "project"
+	applyKindLabel             = "label"
+	applyKindRegistry          = "registry"
+	applyKindReplicationPolicy = "replication_policy"
+	applyKindWebhookPolicy     = "webhook_policy"
+
+	applyActionCreate = "create"
+	applyActionUpdate = "update"
+	applyActionDelete = "delete"
+)
+
+func newApplyAPI() *applyAPI {
+	return &applyAPI{}
+}
+
+type applyAPI struct {
+	BaseAPI
+	// the handler of the v2.0 APIs, the declarative configuration is applied by sending the internal requests
+	// to it, so the same validations, permission checks and side effects as the imperative APIs take effect
+	handler http.Handler
+}
+
+func (a *applyAPI) ApplyConfiguration(ctx context.Context, params operation.ApplyConfigurationParams) middleware.Responder {
+	if err := a.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceConfiguration); err != nil {
+		return a.SendError(ctx, err)
+	}
+	if err := validateApplyDocument(params.Document); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	ap := &applier{
+		ctx:       ctx,
+		handler:   a.handler,
+		requestID: lib.StringValue(params.XRequestID),
+		dryRun:    lib.BoolValue(params.DryRun),
+		prune:     lib.BoolValue(params.Prune),
+		projects:  map[string]int64{},
+		changes:   []*models.ApplyChange{},
+	}
+	if err := ap.apply(params.Document); err != nil {
+		return a.SendError(ctx, err)
+	}
+	return operation.NewApplyConfigurationOK().WithPayload(&models.ApplyResult{
+		DryRun:  ap.dryRun,
+		Changes: ap.changes,
+	})
+}
+
+// the resources are matched by names, so the names must be specified and unique
+func validateApplyDocument(doc *models.ApplyDocument) error {
+	names := map[string]bool{}
+	check := func(kind, name string) error {
+		if len(name) == 0 {
+			return errors.BadRequestError(nil).WithMessage("the name of %s is required", kind)
+		}
+		if names[kind+":"+name] {
+			return errors.BadRequestError(nil).WithMessage("the %s %s is declared more than once", kind, name)
+		}
+		names[kind+":"+name] = true
+		return nil
+	}
+	for _, p := range doc.Projects {
+		if err := check(applyKindProject, p.Name); err != nil {
+			return err
+		}
+	}
+	for _, l := range doc.Labels {
+		if err := check(applyKindLabel, projectScopedName(l.Project, l.Name)); err != nil {
+			return err
+		}
+	}
+	for _, r := range doc.Registries {
+		if err := check(applyKindRegistry, r.Name); err != nil {
+			return err
+		}
+	}
+	for _, p := range doc.ReplicationPolicies {
+		if err := check(applyKindReplicationPolicy, p.Name); err != nil {
+			return err
+		}
+	}
+	for _, p := range doc.WebhookPolicies {
+		if len(p.Project) == 0 {
+			return errors.BadRequestError(nil).WithMessage("the project of the webhook policy %s is required", p.Name)
+		}
+		if err := check(applyKindWebhookPolicy, projectScopedName(p.Project, p.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applier reconciles the resources with the declarative document
+type applier struct {
+	ctx       context.Context
+	handler   http.Handler
+	requestID string
+	dryRun    bool
+	prune     bool
+	// the IDs of the declared projects, the ID is 0 if the project doesn't exist in the dry run mode
+	projects map[string]int64
+	// the names of the registries keyed by IDs
+	registries map[int64]string
+	// the deletions are done after all the creations and updates in the reversed order of the kinds,
+	// so the resources are deleted after the resources referencing them
+	deletions [][]func() error
+	changes   []*models.ApplyChange
+}
+
+func (a *applier) apply(doc *models.ApplyDocument) error {
+	for _, f := range []func(*models.ApplyDocument) error{
+		a.applyProjects,
+		a.applyRegistries,
+		a.applyLabels,
+		a.applyReplicationPolicies,
+		a.applyWebhookPolicies,
+	} {
+		if err := f(doc); err != nil {
+			return err
+		}
+	}
+	for i := len(a.deletions) - 1; i >= 0; i-- {
+		for _, deletion := range a.deletions[i] {
+			if err := deletion(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (a *applier) applyProjects(doc *models.ApplyDocument) error {
+	for _, declared := range doc.Projects {
+		current := &models.Project{}
+		err := a.call(http.MethodGet, "/projects/"+url.PathEscape(declared.Name), nil, nil, current)
+		if errors.IsNotFoundErr(err) {
+			a.projects[declared.Name] = 0
+			if err = a.change(applyKindProject, declared.Name, applyActionCreate, nil, func() error {
+				header, err := a.callWithHeader(http.MethodPost, "/projects", nil, &models.ProjectReq{
+					ProjectName:  declared.Name,
+					Metadata:     declared.Metadata,
+					StorageLimit: declared.StorageLimit,
+				}, nil)
+				if err != nil {
+					return err
+				}
+				a.projects[declared.Name], err = idFromLocation(header)
+				return err
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		a.projects[declared.Name] = int64(current.ProjectID)
+
+		var fields []string
+		desired, actual := map[string]interface{}{}, map[string]interface{}{}
+		if err = lib.JSONCopy(&desired, declared.Metadata); err != nil {
+			return err
+		}
+		if err = lib.JSONCopy(&actual, current.Metadata); err != nil {
+			return err
+		}
+		for k, v := range desired {
+			if !applyEqual(v, actual[k]) {
+				fields = append(fields, "metadata."+k)
+			}
+		}
+		sort.Strings(fields)
+		if err = a.change(applyKindProject, declared.Name, applyActionUpdate, fields, func() error {
+			return a.call(http.MethodPut, "/projects/"+url.PathEscape(declared.Name), nil,
+				&models.ProjectReq{Metadata: declared.Metadata}, nil)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *applier) applyRegistries(doc *models.ApplyDocument) error {
+	var currents []*models.Registry
+	if err := a.listAll("/registries", nil, &currents); err != nil {
+		return err
+	}
+	a.registries = map[int64]string{}
+	existing := map[string]*models.Registry{}
+	for _, current := range currents {
+		a.registries[current.ID] = current.Name
+		existing[current.Name] = current
+	}
+
+	declaredNames := map[string]bool{}
+	for _, declared := range doc.Registries {
+		declared := declared
+		declaredNames[declared.Name] = true
+		current, ok := existing[declared.Name]
+		if !ok {
+			if err := a.change(applyKindRegistry, declared.Name, applyActionCreate, nil, func() error {
+				header, err := a.callWithHeader(http.MethodPost, "/registries", nil, &models.Registry{
+					Name:        declared.Name,
+					Type:        declared.Type,
+					URL:         declared.URL,
+					Description: declared.Description,
+					Insecure:    declared.Insecure,
+					Credential:  declared.Credential,
+				}, nil)
+				if err != nil {
+					return err
+				}
+				id, err := idFromLocation(header)
+				if err != nil {
+					return err
+				}
+				a.registries[id] = declared.Name
+				return nil
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if len(declared.Type) > 0 && declared.Type != current.Type {
+			return errors.BadRequestError(nil).WithMessage("the type of the registry %s cannot be changed", declared.Name)
+		}
+		credential, currentCredential := declared.Credential, current.Credential
+		if credential == nil {
+			credential = &models.RegistryCredential{}
+		}
+		if currentCredential == nil {
+			currentCredential = &models.RegistryCredential{}
+		}
+		fields := diffFields(
+			"url", declared.URL, current.URL,
+			"description", declared.Description, current.Description,
+			"insecure", declared.Insecure, current.Insecure,
+			"credential.type", credential.Type, currentCredential.Type,
+			"credential.access_key", credential.AccessKey, currentCredential.AccessKey,
+		)
+		if err := a.change(applyKindRegistry, declared.Name, applyActionUpdate, fields, func() error {
+			update := &models.RegistryUpdate{
+				URL:         &declared.URL,
+				Description: &declared.Description,
+				Insecure:    &declared.Insecure,
+			}
+			if declared.Credential != nil {
+				update.CredentialType = &declared.Credential.Type
+				update.AccessKey = &declared.Credential.AccessKey
+				update.AccessSecret = &declared.Credential.AccessSecret
+			}
+			return a.call(http.MethodPut, fmt.Sprintf("/registries/%d", current.ID), nil, update, nil)
+		}); err != nil {
+			return err
+		}
+	}
+
+	var deletions []func() error
+	for _, current := range currents {
+		if !declaredNames[current.Name] {
+			deletions = append(deletions, a.deletion(applyKindRegistry, current.Name, fmt.Sprintf("/registries/%d", current.ID)))
+		}
+	}
+	a.deletions = append(a.deletions, deletions)
+	return nil
+}
+
+func (a *applier) applyLabels(doc *models.ApplyDocument) error {
+	// the global labels and the labels of the declared projects
+	scopes := []string{""}
+	for _, p := range doc.Projects {
+		scopes = append(scopes, p.Name)
+	}
+	declaredLabels := map[string][]*models.ApplyLabel{}
+	for _, l := range doc.Labels {
+		if _, ok := declaredLabels[l.Project]; !ok && len(l.Project) > 0 {
+			scopes = append(scopes, l.Project)
+		}
+		declaredLabels[l.Project] = append(declaredLabels[l.Project], l)
+	}
+
+	var deletions []func() error
+	visited := map[string]bool{}
+	for _, project := range scopes {
+		if visited[project] {
+			continue
+		}
+		visited[project] = true
+
+		query := url.Values{"scope": []string{"g"}}
+		var projectID int64
+		if len(project) > 0 {
+			id, err := a.projectID(project)
+			if err != nil {
+				return err
+			}
+			projectID = id
+			query = url.Values{"scope": []string{"p"}, "project_id": []string{strconv.FormatInt(id, 10)}}
+		}
+		var currents []*models.Label
+		// the project doesn't exist in the dry run mode
+		if len(project) == 0 || projectID > 0 {
+			if err := a.listAll("/labels", query, &currents); err != nil {
+				return err
+			}
+		}
+		existing := map[string]*models.Label{}
+		for _, current := range currents {
+			existing[current.Name] = current
+		}
+
+		declaredNames := map[string]bool{}
+		for _, declared := range declaredLabels[project] {
+			declared := declared
+			declaredNames[declared.Name] = true
+			name := projectScopedName(project, declared.Name)
+			current, ok := existing[declared.Name]
+			if !ok {
+				if err := a.change(applyKindLabel, name, applyActionCreate, nil, func() error {
+					label := &models.Label{
+						Name:        declared.Name,
+						Description: declared.Description,
+						Color:       declared.Color,
+						Scope:       "g",
+					}
+					if len(project) > 0 {
+						label.Scope = "p"
+						label.ProjectID = a.projects[project]
+					}
+					return a.call(http.MethodPost, "/labels", nil, label, nil)
+				}); err != nil {
+					return err
+				}
+				continue
+			}
+
+			fields := diffFields(
+				"description", declared.Description, current.Description,
+				"color", declared.Color, current.Color,
+			)
+			if err := a.change(applyKindLabel, name, applyActionUpdate, fields, func() error {
+				current.Description = declared.Description
+				current.Color = declared.Color
+				return a.call(http.MethodPut, fmt.Sprintf("/labels/%d", current.ID), nil, current, nil)
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, current := range currents {
+			if !declaredNames[current.Name] {
+				deletions = append(deletions, a.deletion(applyKindLabel, projectScopedName(project, current.Name),
+					fmt.Sprintf("/labels/%d", current.ID)))
+			}
+		}
+	}
+	a.deletions = append(a.deletions, deletions)
+	return nil
+}
+
+func (a *applier) applyReplicationPolicies(doc *models.ApplyDocument) error {
+	var currents []*models.ReplicationPolicy
+	if err := a.listAll("/replication/policies", nil, &currents); err != nil {
+		return err
+	}
+	existing := map[string]*models.ReplicationPolicy{}
+	for _, current := range currents {
+		existing[current.Name] = current
+	}
+
+	declaredNames := map[string]bool{}
+	for _, declared := range doc.ReplicationPolicies {
+		declared := declared
+		declaredNames[declared.Name] = true
+		for _, registry := range []string{declared.SrcRegistry, declared.DestRegistry} {
+			if _, err := a.registryID(registry); err != nil {
+				return err
+			}
+		}
+		policy := func() *models.ReplicationPolicy {
+			srcID, _ := a.registryID(declared.SrcRegistry)
+			destID, _ := a.registryID(declared.DestRegistry)
+			return &models.ReplicationPolicy{
+				Name:                      declared.Name,
+				Description:               declared.Description,
+				SrcRegistry:               &models.Registry{ID: srcID},
+				DestRegistry:              &models.Registry{ID: destID},
+				DestNamespace:             declared.DestNamespace,
+				DestNamespaceReplaceCount: declared.DestNamespaceReplaceCount,
+				Filters:                   declared.Filters,
+				Trigger:                   declared.Trigger,
+				Enabled:                   declared.Enabled,
+				Override:                  declared.Override,
+				ReplicateDeletion:         declared.ReplicateDeletion,
+				Speed:                     declared.Speed,
+				CopyByChunk:               declared.CopyByChunk,
+				ForeignLayerMode:          declared.ForeignLayerMode,
+			}
+		}
+
+		current, ok := existing[declared.Name]
+		if !ok {
+			if err := a.change(applyKindReplicationPolicy, declared.Name, applyActionCreate, nil, func() error {
+				return a.call(http.MethodPost, "/replication/policies", nil, policy(), nil)
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		fields := diffFields(
+			"description", declared.Description, current.Description,
+			"src_registry", declared.SrcRegistry, a.registryName(current.SrcRegistry),
+			"dest_registry", declared.DestRegistry, a.registryName(current.DestRegistry),
+			"dest_namespace", declared.DestNamespace, current.DestNamespace,
+			"dest_namespace_replace_count", declared.DestNamespaceReplaceCount, current.DestNamespaceReplaceCount,
+			"filters", declared.Filters, current.Filters,
+			"trigger", declared.Trigger, current.Trigger,
+			"enabled", declared.Enabled, current.Enabled,
+			"override", declared.Override, current.Override,
+			"replicate_deletion", declared.ReplicateDeletion, current.ReplicateDeletion,
+			"speed", declared.Speed, current.Speed,
+			"copy_by_chunk", declared.CopyByChunk, current.CopyByChunk,
+			"foreign_layer_mode", declared.ForeignLayerMode, current.ForeignLayerMode,
+		)
+		if err := a.change(applyKindReplicationPolicy, declared.Name, applyActionUpdate, fields, func() error {
+			return a.call(http.MethodPut, fmt.Sprintf("/replication/policies/%d", current.ID), nil, policy(), nil)
+		}); err != nil {
+			return err
+		}
+	}
+
+	var deletions []func() error
+	for _, current := range currents {
+		if !declaredNames[current.Name] {
+			deletions = append(deletions, a.deletion(applyKindReplicationPolicy, current.Name,
+				fmt.Sprintf("/replication/policies/%d", current.ID)))
+		}
+	}
+	a.deletions = append(a.deletions, deletions)
+	return nil
+}
+
+func (a *applier) applyWebhookPolicies(doc *models.ApplyDocument) error {
+	var projects []string
+	for _, p := range doc.Projects {
+		projects = append(projects, p.Name)
+	}
+	declaredPolicies := map[string][]*models.ApplyWebhookPolicy{}
+	for _, p := range doc.WebhookPolicies {
+		if _, ok := declaredPolicies[p.Project]; !ok {
+			projects = append(projects, p.Project)
+		}
+		declaredPolicies[p.Project] = append(declaredPolicies[p.Project], p)
+	}
+
+	var deletions []func() error
+	visited := map[string]bool{}
+	for _, project := range projects {
+		if visited[project] {
+			continue
+		}
+		visited[project] = true
+
+		projectID, err := a.projectID(project)
+		if err != nil {
+			return err
+		}
+		policiesPath := "/projects/" + url.PathEscape(project) + "/webhook/policies"
+		var currents []*models.WebhookPolicy
+		// the project doesn't exist in the dry run mode
+		if projectID > 0 {
+			if err := a.listAll(policiesPath, nil, &currents); err != nil {
+				return err
+			}
+		}
+		existing := map[string]*models.WebhookPolicy{}
+		for _, current := range currents {
+			existing[current.Name] = current
+		}
+
+		declaredNames := map[string]bool{}
+		for _, declared := range declaredPolicies[project] {
+			declared := declared
+			declaredNames[declared.Name] = true
+			name := projectScopedName(project, declared.Name)
+			policy := &models.WebhookPolicy{
+				Name:        declared.Name,
+				Description: declared.Description,
+				EventTypes:  declared.EventTypes,
+				Targets:     declared.Targets,
+				Enabled:     declared.Enabled,
+			}
+			current, ok := existing[declared.Name]
+			if !ok {
+				if err := a.change(applyKindWebhookPolicy, name, applyActionCreate, nil, func() error {
+					return a.call(http.MethodPost, policiesPath, nil, policy, nil)
+				}); err != nil {
+					return err
+				}
+				continue
+			}
+
+			eventTypes, currentEventTypes := append([]string{}, declared.EventTypes...), append([]string{}, current.EventTypes...)
+			sort.Strings(eventTypes)
+			sort.Strings(currentEventTypes)
+			fields := diffFields(
+				"description", declared.Description, current.Description,
+				"event_types", eventTypes, currentEventTypes,
+				"targets", declared.Targets, current.Targets,
+				"enabled", declared.Enabled, current.Enabled,
+			)
+			if err := a.change(applyKindWebhookPolicy, name, applyActionUpdate, fields, func() error {
+				return a.call(http.MethodPut, fmt.Sprintf("%s/%d", policiesPath, current.ID), nil, policy, nil)
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, current := range currents {
+			if !declaredNames[current.Name] {
+				deletions = append(deletions, a.deletion(applyKindWebhookPolicy, projectScopedName(project, current.Name),
+					fmt.Sprintf("%s/%d", policiesPath, current.ID)))
+			}
+		}
+	}
+	a.deletions = append(a.deletions, deletions)
+	return nil
+}
+
+// change records the change and applies it if it isn't in the dry run mode, the update without changed fields is ignored
+func (a *applier) change(kind, name, action string, fields []string, apply func() error) error {
+	if action == applyActionUpdate && len(fields) == 0 {
+		return nil
+	}
+	a.changes = append(a.changes, &models.ApplyChange{
+		Kind:   kind,
+		Name:   name,
+		Action: action,
+		Fields: fields,
+	})
+	if a.dryRun {
+		return nil
+	}
+	if err := apply(); err != nil {
+		return errors.New(nil).WithCode(errors.ErrCode(err)).
+			WithMessage("failed to %s the %s %s: %v", action, kind, name, err)
+	}
+	return nil
+}
+
+// deletion returns the function deleting the resource if the prune is enabled
+func (a *applier) deletion(kind, name, path string) func() error {
+	return func() error {
+		if !a.prune {
+			return nil
+		}
+		return a.change(kind, name, applyActionDelete, nil, func() error {
+			return a.call(http.MethodDelete, path, nil, nil, nil)
+		})
+	}
+}
+
+// projectID returns the ID of the project, it's 0 if the project is declared but doesn't exist in the dry run mode
+func (a *applier) projectID(name string) (int64, error) {
+	if id, ok := a.projects[name]; ok {
+		return id, nil
+	}
+	project := &models.Project{}
+	if err := a.call(http.MethodGet, "/projects/"+url.PathEscape(name), nil, nil, project); err != nil {
+		return 0, err
+	}
+	a.projects[name] = int64(project.ProjectID)
+	return a.projects[name], nil
+}
+
+// registryID returns the ID of the registry, it's 0 for the local Harbor or the declared registry which doesn't
+// exist in the dry run mode
+func (a *applier) registryID(name string) (int64, error) {
+	if len(name) == 0 {
+		return 0, nil
+	}
+	for id, n := range a.registries {
+		if n == name {
+			return id, nil
+		}
+	}
+	if a.dryRun {
+		return 0, nil
+	}
+	return 0, errors.NotFoundError(nil).WithMessage("registry %s not found", name)
+}
+
+func (a *applier) registryName(registry *models.Registry) string {
+	if registry == nil || registry.ID == 0 {
+		return ""
+	}
+	return a.registries[registry.ID]
+}
+
+// listAll lists all the resources page by page, the "out" must be a pointer to a slice
+func (a *applier) listAll(path string, query url.Values, out interface{}) error {
+	all := reflect.ValueOf(out).Elem()
+	for page := 1; ; page++ {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Set("page", strconv.Itoa(page))
+		q.Set("page_size", strconv.Itoa(applyPageSize))
+		items := reflect.New(all.Type())
+		if err := a.call(http.MethodGet, path, q, nil, items.Interface()); err != nil {
+			return err
+		}
+		all.Set(reflect.AppendSlice(all, items.Elem()))
+		if items.Elem().Len() < applyPageSize {
+			return nil
+		}
+	}
+}
+
+func (a *applier) call(method, path string, query url.Values, body, out interface{}) error {
+	_, err := a.callWithHeader(method, path, query, body, out)
+	return err
+}
+
+// callWithHeader sends the internal request to the API handler and returns the header of the response
+func (a *applier) callWithHeader(method, path string, query url.Values, body, out interface{}) (http.Header, error) {
+	header := http.Header{}
+	// the projects are always referenced by names
+	header.Set("X-Is-Resource-Name", "true")
+	// skip the conditional check as the resource is updated on purpose
+	if method == http.MethodPut {
+		header.Set("If-Match", "*")
+	}
+	if len(a.requestID) > 0 {
+		header.Set("X-Request-Id", a.requestID)
+	}
+	return sendInternalRequest(a.ctx, a.handler, method, path, query, header, body, out)
+}
+
+// parse the ID of the created resource from the "Location" header, e.g. "/api/v2.0/projects/1"
+func idFromLocation(header http.Header) (int64, error) {
+	location := header.Get("Location")
+	id, err := strconv.ParseInt(path.Base(location), 10, 64)
+	if err != nil {
+		return 0, errors.UnknownError(err).WithMessage("failed to parse the ID from the location %s", location)
+	}
+	return id, nil
+}
+
+func projectScopedName(project, name string) string {
+	if len(project) == 0 {
+		return name
+	}
+	return project + "/" + name
+}
+
+// diffFields returns the names of the changed fields, the arguments are the triples of the field name,
+// the declared value and the current value
+func diffFields(triples ...interface{}) []string {
+	var fields []string
+	for i := 0; i+2 < len(triples); i += 3 {
+		if !applyEqual(triples[i+1], triples[i+2]) {
+			fields = append(fields, triples[i].(string))
+		}
+	}
+	return fields
+}
+
+// applyEqual compares the values by their JSON representations, the empty values such as null, "", [] and {}
+// are treated as the same as they are omitted by the APIs
+func applyEqual(a, b interface{}) bool {
+	normalize := func(v interface{}) interface{} {
+		var result interface{}
+		data, err := json.Marshal(v)
+		if err != nil || json.Unmarshal(data, &result) != nil {
+			return v
+		}
+		return dropEmpty(result)
+	}
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+func dropEmpty(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			if item = dropEmpty(item); item == nil {
+				delete(val, k)
+				continue
+			}
+			val[k] = item
+		}
+		if len(val) == 0 {
+			return nil
+		}
+		return val
+	case []interface{}:
+		if len(val) == 0 {
+			return nil
+		}
+		for i, item := range val {
+			val[i] = dropEmpty(item)
+		}
+		return val
+	case string:
+		if len(val) == 0 {
+			return nil
+		}
+	}
+	return v
+}
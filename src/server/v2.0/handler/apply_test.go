@@ -0,0 +1,205 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+)
+
+type applyTestSuite struct {
+	suite.Suite
+	calls   []string
+	bodies  map[string]interface{}
+	applier *applier
+}
+
+func (a *applyTestSuite) SetupTest() {
+	a.calls = nil
+	a.bodies = map[string]interface{}{}
+	a.applier = &applier{
+		ctx:      context.Background(),
+		projects: map[string]int64{},
+		changes:  []*models.ApplyChange{},
+		handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			call := r.Method + " " + r.URL.Path
+			a.calls = append(a.calls, call)
+			if r.Body != nil {
+				data, _ := io.ReadAll(r.Body)
+				if len(data) > 0 {
+					var body interface{}
+					a.Require().Nil(json.Unmarshal(data, &body))
+					a.bodies[call] = body
+				}
+			}
+
+			write := func(v interface{}) {
+				a.Require().Nil(json.NewEncoder(w).Encode(v))
+			}
+			switch call {
+			case "GET /api/v2.0/projects/library":
+				write(&models.Project{ProjectID: 1, Name: "library", Metadata: &models.ProjectMetadata{Public: "false"}})
+			case "GET /api/v2.0/projects/new":
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"errors":[{"code":"NOT_FOUND","message":"project new not found"}]}`))
+			case "POST /api/v2.0/projects":
+				w.Header().Set("Location", "/api/v2.0/projects/2")
+				w.WriteHeader(http.StatusCreated)
+			case "GET /api/v2.0/registries":
+				write([]*models.Registry{
+					{ID: 1, Name: "hub", Type: "docker-hub", URL: "https://hub.docker.com"},
+					{ID: 2, Name: "obsolete", Type: "harbor", URL: "https://harbor.example.com"},
+				})
+			case "GET /api/v2.0/labels":
+				if r.URL.Query().Get("scope") == "g" {
+					write([]*models.Label{{ID: 1, Name: "release", Color: "#000000", Scope: "g"}})
+					return
+				}
+				write([]*models.Label{})
+			case "GET /api/v2.0/replication/policies":
+				write([]*models.ReplicationPolicy{{
+					ID:           1,
+					Name:         "sync",
+					SrcRegistry:  &models.Registry{ID: 1, Name: "hub"},
+					DestRegistry: &models.Registry{ID: 0, Name: "Local"},
+					Enabled:      true,
+				}})
+			case "GET /api/v2.0/projects/library/webhook/policies":
+				write([]*models.WebhookPolicy{})
+			case "POST /api/v2.0/labels", "POST /api/v2.0/projects/new/webhook/policies":
+				w.WriteHeader(http.StatusCreated)
+			}
+		}),
+	}
+}
+
+func (a *applyTestSuite) document() *models.ApplyDocument {
+	return &models.ApplyDocument{
+		Projects: []*models.ApplyProject{
+			{Name: "library", Metadata: &models.ProjectMetadata{Public: "true"}},
+			{Name: "new"},
+		},
+		Labels: []*models.ApplyLabel{
+			{Name: "release", Color: "#000000"},
+			{Name: "dev", Project: "new"},
+		},
+		Registries: []*models.ApplyRegistry{
+			{Name: "hub", Type: "docker-hub", URL: "https://hub.docker.com"},
+		},
+		ReplicationPolicies: []*models.ApplyReplicationPolicy{
+			{Name: "sync", SrcRegistry: "hub", Enabled: false},
+		},
+		WebhookPolicies: []*models.ApplyWebhookPolicy{
+			{Project: "new", Name: "notify", EventTypes: []string{"PUSH_ARTIFACT"}},
+		},
+	}
+}
+
+func (a *applyTestSuite) TestDryRun() {
+	a.applier.dryRun = true
+	a.applier.prune = true
+	a.Require().Nil(a.applier.apply(a.document()))
+
+	for _, call := range a.calls {
+		a.Contains(call, "GET ")
+	}
+	a.Equal([]*models.ApplyChange{
+		{Kind: applyKindProject, Name: "library", Action: applyActionUpdate, Fields: []string{"metadata.public"}},
+		{Kind: applyKindProject, Name: "new", Action: applyActionCreate},
+		{Kind: applyKindLabel, Name: "new/dev", Action: applyActionCreate},
+		{Kind: applyKindReplicationPolicy, Name: "sync", Action: applyActionUpdate, Fields: []string{"enabled"}},
+		{Kind: applyKindWebhookPolicy, Name: "new/notify", Action: applyActionCreate},
+		{Kind: applyKindRegistry, Name: "obsolete", Action: applyActionDelete},
+	}, a.applier.changes)
+}
+
+func (a *applyTestSuite) TestApply() {
+	a.Require().Nil(a.applier.apply(a.document()))
+
+	a.Contains(a.calls, "PUT /api/v2.0/projects/library")
+	a.Contains(a.calls, "POST /api/v2.0/projects")
+	a.Contains(a.calls, "PUT /api/v2.0/replication/policies/1")
+	a.Contains(a.calls, "POST /api/v2.0/projects/new/webhook/policies")
+	// not pruned
+	a.NotContains(a.calls, "DELETE /api/v2.0/registries/2")
+
+	// the label is created in the new project
+	label := a.bodies["POST /api/v2.0/labels"].(map[string]interface{})
+	a.Equal("dev", label["name"])
+	a.Equal("p", label["scope"])
+	a.Equal(float64(2), label["project_id"])
+	// the source registry is referenced by ID
+	policy := a.bodies["PUT /api/v2.0/replication/policies/1"].(map[string]interface{})
+	a.Equal(float64(1), policy["src_registry"].(map[string]interface{})["id"])
+	a.Len(a.applier.changes, 5)
+}
+
+func (a *applyTestSuite) TestPrune() {
+	a.applier.prune = true
+	a.Require().Nil(a.applier.apply(a.document()))
+	a.Equal("DELETE /api/v2.0/registries/2", a.calls[len(a.calls)-1])
+}
+
+func (a *applyTestSuite) TestUnknownRegistry() {
+	doc := a.document()
+	doc.ReplicationPolicies[0].DestRegistry = "unknown"
+	a.NotNil(a.applier.apply(doc))
+}
+
+func (a *applyTestSuite) TestValidate() {
+	a.Nil(validateApplyDocument(a.document()))
+
+	doc := a.document()
+	doc.Labels = append(doc.Labels, &models.ApplyLabel{Name: "dev", Project: "new"})
+	a.NotNil(validateApplyDocument(doc))
+
+	doc = a.document()
+	doc.WebhookPolicies[0].Project = ""
+	a.NotNil(validateApplyDocument(doc))
+
+	doc = a.document()
+	doc.Registries[0].Name = ""
+	a.NotNil(validateApplyDocument(doc))
+}
+
+func TestApplyTestSuite(t *testing.T) {
+	suite.Run(t, &applyTestSuite{})
+}
+
+func TestApplyEqual(t *testing.T) {
+	cases := []struct {
+		a, b  interface{}
+		equal bool
+	}{
+		{"", nil, true},
+		{[]string{}, nil, true},
+		{[]*models.ReplicationFilter{{Type: "name", Value: "library/**"}}, []*models.ReplicationFilter{{Type: "name", Value: "library/**"}}, true},
+		{&models.ReplicationTrigger{Type: "manual"}, &models.ReplicationTrigger{Type: "manual", TriggerSettings: &models.ReplicationTriggerSettings{}}, true},
+		{&models.ReplicationTrigger{Type: "manual"}, &models.ReplicationTrigger{Type: "scheduled"}, false},
+		{true, false, false},
+	}
+	for _, c := range cases {
+		if applyEqual(c.a, c.b) != c.equal {
+			t.Errorf("applyEqual(%v, %v) != %v", c.a, c.b, c.equal)
+		}
+	}
+}
@@ -19,13 +19,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/bmatcuk/doublestar"
 	"github.com/docker/distribution/reference"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
 	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/rbac"
@@ -33,6 +38,7 @@ import (
 	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/controller/event/metadata"
 	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/quarantine"
 	"github.com/goharbor/harbor/src/controller/repository"
 	"github.com/goharbor/harbor/src/controller/scan"
 	"github.com/goharbor/harbor/src/controller/tag"
@@ -40,9 +46,12 @@ import (
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg"
 	"github.com/goharbor/harbor/src/pkg/accessory"
 	"github.com/goharbor/harbor/src/pkg/label"
 	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/pin"
+	pinmodel "github.com/goharbor/harbor/src/pkg/pin/model"
 	"github.com/goharbor/harbor/src/pkg/scan/report"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/assembler"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
@@ -50,15 +59,24 @@ import (
 	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/artifact"
 )
 
+const (
+	// the max count of tags can be deleted in one bulk deletion request
+	maxBulkDeleteTags = 500
+	// the max length of the reason of pinning the artifact
+	maxPinReasonLength = 1024
+)
+
 func newArtifactAPI() *artifactAPI {
 	return &artifactAPI{
-		accMgr:   accessory.Mgr,
-		artCtl:   artifact.Ctl,
-		proCtl:   project.Ctl,
-		repoCtl:  repository.Ctl,
-		scanCtl:  scan.DefaultController,
-		tagCtl:   tag.Ctl,
-		labelMgr: label.Mgr,
+		accMgr:        accessory.Mgr,
+		artCtl:        artifact.Ctl,
+		proCtl:        project.Ctl,
+		repoCtl:       repository.Ctl,
+		scanCtl:       scan.DefaultController,
+		tagCtl:        tag.Ctl,
+		labelMgr:      pkg.LabelMgr,
+		pinMgr:        pin.Mgr,
+		quarantineCtl: quarantine.Ctl,
 	}
 }
 
@@ -71,6 +89,11 @@ type artifactAPI struct {
 	scanCtl  scan.Controller
 	tagCtl   tag.Controller
 	labelMgr label.Manager
+	pinMgr   pin.Manager
+	// quarantineCtl manages the quarantines of the newly pushed artifacts
+	quarantineCtl quarantine.Controller
+	// handler is the v2.0 API handler that the copy requests of the tags and batches are dispatched to
+	handler http.Handler
 }
 
 func (a *artifactAPI) Prepare(ctx context.Context, operation string, params interface{}) middleware.Responder {
@@ -93,9 +116,17 @@ func (a *artifactAPI) ListArtifacts(ctx context.Context, params operation.ListAr
 	}
 	query.Keywords["RepositoryName"] = fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName)
 
-	// set option
+	fields, err := newFieldSelector(params.Fields, models.Artifact{})
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	// set option, skip populating the properties that aren't selected
 	option := option(params.WithTag, params.WithImmutableStatus,
 		params.WithLabel, params.WithSignature, params.WithAccessory)
+	option.WithTag = option.WithTag && fields.Selected("tags")
+	option.WithLabel = option.WithLabel && fields.Selected("labels")
+	option.WithAccessory = option.WithAccessory && fields.Selected("accessories")
 
 	// get the total count of artifacts
 	total, err := a.artCtl.Count(ctx, query)
@@ -108,19 +139,24 @@ func (a *artifactAPI) ListArtifacts(ctx context.Context, params operation.ListAr
 		return a.SendError(ctx, err)
 	}
 
-	assembler := assembler.NewVulAssembler(lib.BoolValue(params.WithScanOverview), parseScanReportMimeTypes(params.XAcceptVulnerabilities))
-	var artifacts []*models.Artifact
+	withScanOverview := lib.BoolValue(params.WithScanOverview) && fields.Selected("scan_overview")
+	assembler := assembler.NewVulAssembler(withScanOverview, parseScanReportMimeTypes(params.XAcceptVulnerabilities))
+	var mArts []*model.Artifact
 	for _, art := range arts {
 		artifact := &model.Artifact{}
 		artifact.Artifact = *art
-		_ = assembler.WithArtifacts(artifact).Assemble(ctx)
+		mArts = append(mArts, artifact)
+	}
+	_ = assembler.WithArtifacts(mArts...).Assemble(ctx)
+	var artifacts []*models.Artifact
+	for _, artifact := range mArts {
 		artifacts = append(artifacts, artifact.ToSwagger())
 	}
 
-	return operation.NewListArtifactsOK().
+	return fields.Shape(operation.NewListArtifactsOK().
 		WithXTotalCount(total).
 		WithLink(a.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
-		WithPayload(artifacts)
+		WithPayload(artifacts))
 }
 
 func (a *artifactAPI) GetArtifact(ctx context.Context, params operation.GetArtifactParams) middleware.Responder {
@@ -198,14 +234,76 @@ func (a *artifactAPI) CopyArtifact(ctx context.Context, params operation.CopyArt
 		return a.SendError(ctx, err)
 	}
 
-	_, err = a.artCtl.Copy(ctx, srcRepo, ref, dstRepo)
+	dstRef := ref
+	if params.Tag != nil {
+		_, err = a.artCtl.CopyWithTag(ctx, srcRepo, ref, dstRepo, *params.Tag)
+		dstRef = *params.Tag
+	} else {
+		_, err = a.artCtl.Copy(ctx, srcRepo, ref, dstRepo)
+	}
 	if err != nil {
 		return a.SendError(ctx, err)
 	}
-	location := strings.TrimSuffix(params.HTTPRequest.URL.Path, "/") + "/" + ref
+	location := strings.TrimSuffix(params.HTTPRequest.URL.Path, "/") + "/" + dstRef
 	return operation.NewCopyArtifactCreated().WithLocation(location)
 }
 
+func (a *artifactAPI) CopyTag(ctx context.Context, params operation.CopyTagParams) middleware.Responder {
+	from := fmt.Sprintf("%s/%s:%s", params.ProjectName, params.RepositoryName, params.TagName)
+	if params.Target.Tag == "" {
+		params.Target.Tag = params.TagName
+	}
+	location, err := a.copy(ctx, from, params.Target, lib.StringValue(params.XRequestID))
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	return operation.NewCopyTagCreated().WithLocation(location)
+}
+
+func (a *artifactAPI) CopyArtifacts(ctx context.Context, params operation.CopyArtifactsParams) middleware.Responder {
+	if err := a.RequireAuthenticated(ctx); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	// the items are copied one by one and the whole batch is rolled back by the transaction once any of them fails
+	var results []*models.CopyArtifactsResult
+	for i, item := range params.Request.Items {
+		from := lib.StringValue(item.From)
+		location, err := a.copy(ctx, from, item.To, lib.StringValue(params.XRequestID))
+		if err != nil {
+			return a.SendError(ctx, errors.New(nil).WithCode(errors.ErrCode(err)).
+				WithMessage("failed to copy the item %d from %s: %v", i, from, err))
+		}
+		results = append(results, &models.CopyArtifactsResult{
+			From:     from,
+			Location: location,
+		})
+	}
+	return operation.NewCopyArtifactsOK().WithPayload(results)
+}
+
+// copy dispatches the request of copying the artifact to the "CopyArtifact" API, so the permissions of both the source
+// and the destination, the quota and the blobs are handled in the same way. The location of the copied artifact is returned
+func (a *artifactAPI) copy(ctx context.Context, from string, to *models.CopyTarget, requestID string) (string, error) {
+	// the repository name is escaped twice as it may contain slashes, see the "Prepare" method
+	path := fmt.Sprintf("/projects/%s/repositories/%s/artifacts", url.PathEscape(lib.StringValue(to.ProjectName)),
+		url.PathEscape(url.PathEscape(lib.StringValue(to.RepositoryName))))
+	query := url.Values{}
+	query.Set("from", from)
+	if to.Tag != "" {
+		query.Set("tag", to.Tag)
+	}
+	header := http.Header{}
+	if len(requestID) > 0 {
+		header.Set("X-Request-Id", requestID)
+	}
+	resHeader, err := sendInternalRequest(ctx, a.handler, http.MethodPost, path, query, header, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	return resHeader.Get("Location"), nil
+}
+
 // parse "repository:tag" or "repository@digest" into repository and reference parts
 func parse(s string) (string, string, error) {
 	matches := reference.ReferenceRegexp.FindStringSubmatch(s)
@@ -312,12 +410,113 @@ func (a *artifactAPI) DeleteTag(ctx context.Context, params operation.DeleteTagP
 	return operation.NewDeleteTagOK()
 }
 
+func (a *artifactAPI) DeleteTags(ctx context.Context, params operation.DeleteTagsParams) middleware.Responder {
+	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionDelete, rbac.ResourceTag); err != nil {
+		return a.SendError(ctx, err)
+	}
+	req := params.Request
+	if req == nil || (len(req.Names) == 0 && len(req.Selector) == 0) {
+		return a.SendError(ctx, errors.BadRequestError(nil).WithMessage("either names or selector must be specified"))
+	}
+	if len(req.Names) > 0 && len(req.Selector) > 0 {
+		return a.SendError(ctx, errors.BadRequestError(nil).WithMessage("only one of names and selector can be specified"))
+	}
+	if len(req.Names) > maxBulkDeleteTags {
+		return a.SendError(ctx, errors.BadRequestError(nil).WithMessage("at most %d tags can be deleted in one request", maxBulkDeleteTags))
+	}
+
+	repo, err := a.repoCtl.GetByName(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName))
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	query := q.New(q.KeyWords{"RepositoryID": repo.RepositoryID})
+	if len(req.Names) > 0 {
+		names := &q.OrList{}
+		for _, name := range req.Names {
+			names.Values = append(names.Values, name)
+		}
+		query.Keywords["Name"] = names
+	}
+	tags, err := a.tagCtl.List(ctx, query, nil)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	var selected []*tag.Tag
+	found := map[string]bool{}
+	for _, t := range tags {
+		if len(req.Selector) > 0 {
+			match, err := doublestar.Match(req.Selector, t.Name)
+			if err != nil {
+				return a.SendError(ctx, errors.BadRequestError(err).WithMessage("invalid selector %s", req.Selector))
+			}
+			if !match {
+				continue
+			}
+		}
+		selected = append(selected, t)
+		found[t.Name] = true
+	}
+	if len(selected) > maxBulkDeleteTags {
+		return a.SendError(ctx, errors.BadRequestError(nil).WithMessage(
+			"%d tags are selected, at most %d tags can be deleted in one request", len(selected), maxBulkDeleteTags))
+	}
+
+	payload := []*models.TagDeletionResult{}
+	for _, name := range req.Names {
+		if found[name] {
+			continue
+		}
+		// mark it as found to avoid duplicated results
+		found[name] = true
+		payload = append(payload, &models.TagDeletionResult{
+			Name:  name,
+			Error: fmt.Sprintf("tag %s not found", name),
+		})
+	}
+
+	artifacts := map[int64]*artifact.Artifact{}
+	for _, result := range a.tagCtl.BulkDelete(ctx, selected) {
+		r := &models.TagDeletionResult{
+			Name:    result.Tag.Name,
+			Success: result.Error == nil,
+		}
+		payload = append(payload, r)
+		if result.Error != nil {
+			r.Error = result.Error.Error()
+			continue
+		}
+
+		// fire event
+		art, exist := artifacts[result.Tag.ArtifactID]
+		if !exist {
+			art, err = a.artCtl.Get(ctx, result.Tag.ArtifactID, nil)
+			if err != nil {
+				log.G(ctx).Errorf("failed to get the artifact %d attached by the deleted tag %s: %v", result.Tag.ArtifactID, result.Tag.Name, err)
+				continue
+			}
+			artifacts[result.Tag.ArtifactID] = art
+		}
+		notification.AddEvent(ctx, &metadata.DeleteTagEventMetadata{
+			Ctx:              ctx,
+			Tag:              result.Tag.Name,
+			AttachedArtifact: &art.Artifact,
+		})
+	}
+
+	return operation.NewDeleteTagsOK().WithPayload(payload)
+}
+
 func (a *artifactAPI) ListTags(ctx context.Context, params operation.ListTagsParams) middleware.Responder {
 	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionList, rbac.ResourceTag); err != nil {
 		return a.SendError(ctx, err)
 	}
+	fields, err := newFieldSelector(params.Fields, models.Tag{})
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
 	// set query
-	query, err := a.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	query, err := a.BuildCursorQuery(ctx, params.Q, params.Sort, params.Cursor, params.Page, params.PageSize)
 	if err != nil {
 		return a.SendError(ctx, err)
 	}
@@ -337,10 +536,10 @@ func (a *artifactAPI) ListTags(ctx context.Context, params operation.ListTagsPar
 	// set option
 	option := &tag.Option{}
 	if params.WithSignature != nil {
-		option.WithSignature = *params.WithSignature
+		option.WithSignature = *params.WithSignature && fields.Selected("signed")
 	}
 	if params.WithImmutableStatus != nil {
-		option.WithImmutableStatus = *params.WithImmutableStatus
+		option.WithImmutableStatus = *params.WithImmutableStatus && fields.Selected("immutable")
 	}
 	// list tags according to the query and option
 	tags, err := a.tagCtl.List(ctx, query, option)
@@ -352,10 +551,176 @@ func (a *artifactAPI) ListTags(ctx context.Context, params operation.ListTagsPar
 	for _, tag := range tags {
 		ts = append(ts, model.NewTag(tag).ToSwagger())
 	}
-	return operation.NewListTagsOK().
+	return fields.Shape(operation.NewListTagsOK().
 		WithXTotalCount(total).
-		WithLink(a.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
-		WithPayload(ts)
+		WithLink(a.CursorLinks(ctx, params.HTTPRequest.URL, total, query).String()).
+		WithPayload(ts))
+}
+
+func (a *artifactAPI) ListRepositoryTags(ctx context.Context, params operation.ListRepositoryTagsParams) middleware.Responder {
+	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionList, rbac.ResourceTag); err != nil {
+		return a.SendError(ctx, err)
+	}
+	fields, err := newFieldSelector(params.Fields, models.Tag{})
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	query, err := a.BuildCursorQuery(ctx, params.Q, params.Sort, params.Cursor, params.Page, params.PageSize)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	if err = setTagFilters(query, params); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	repo, err := a.repoCtl.GetByName(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName))
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	query.Keywords["RepositoryID"] = repo.RepositoryID
+
+	total, err := a.tagCtl.Count(ctx, query)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	option := &tag.Option{}
+	if params.WithSignature != nil {
+		option.WithSignature = *params.WithSignature && fields.Selected("signed")
+	}
+	if params.WithImmutableStatus != nil {
+		option.WithImmutableStatus = *params.WithImmutableStatus && fields.Selected("immutable")
+	}
+	tags, err := a.tagCtl.List(ctx, query, option)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	var ts []*models.Tag
+	for _, tag := range tags {
+		ts = append(ts, model.NewTag(tag).ToSwagger())
+	}
+	return fields.Shape(operation.NewListRepositoryTagsOK().
+		WithXTotalCount(total).
+		WithLink(a.CursorLinks(ctx, params.HTTPRequest.URL, total, query).String()).
+		WithPayload(ts))
+}
+
+// setTagFilters converts the filter parameters of listing the repository tags into the keywords of the query,
+// they take precedence over the same keywords specified in the "q"
+func setTagFilters(query *q.Query, params operation.ListRepositoryTagsParams) error {
+	if params.Labels != nil {
+		labels := &q.AndList{}
+		for _, s := range strings.Split(*params.Labels, ",") {
+			s = strings.TrimSpace(s)
+			if len(s) == 0 {
+				continue
+			}
+			id, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return errors.BadRequestError(nil).WithMessage("invalid label ID %s", s)
+			}
+			labels.Values = append(labels.Values, id)
+		}
+		if len(labels.Values) > 0 {
+			query.Keywords["labels"] = labels
+		}
+	}
+	if params.Signed != nil {
+		query.Keywords["signed"] = *params.Signed
+	}
+	if params.MaxSeverity != nil {
+		query.Keywords["max_severity"] = *params.MaxSeverity
+	}
+	if params.PushedAfter != nil || params.PushedBefore != nil {
+		r := &q.Range{}
+		if params.PushedAfter != nil {
+			t, err := time.Parse(time.RFC3339, *params.PushedAfter)
+			if err != nil {
+				return errors.BadRequestError(nil).WithMessage("invalid pushed_after %s, must be in RFC3339 format", *params.PushedAfter)
+			}
+			r.Min = t
+		}
+		if params.PushedBefore != nil {
+			t, err := time.Parse(time.RFC3339, *params.PushedBefore)
+			if err != nil {
+				return errors.BadRequestError(nil).WithMessage("invalid pushed_before %s, must be in RFC3339 format", *params.PushedBefore)
+			}
+			r.Max = t
+		}
+		delete(query.Keywords, "push_time")
+		query.Keywords["PushTime"] = r
+	}
+	return nil
+}
+
+func (a *artifactAPI) GetTag(ctx context.Context, params operation.GetTagParams) middleware.Responder {
+	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionRead, rbac.ResourceArtifact); err != nil {
+		return a.SendError(ctx, err)
+	}
+	option := &artifact.Option{
+		WithTag:   true,
+		TagOption: &tag.Option{WithImmutableStatus: lib.BoolValue(params.WithImmutableStatus)},
+		WithLabel: lib.BoolValue(params.WithLabel),
+	}
+	art, err := a.artCtl.GetByReference(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName), params.TagName, option)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	detail := &models.TagDetail{
+		Children: []*models.TagPlatform{},
+	}
+	for _, t := range art.Tags {
+		if t.Name == params.TagName {
+			detail.Tag = model.NewTag(t).ToSwagger()
+			break
+		}
+	}
+	// the reference is a digest rather than a tag
+	if detail.Tag == nil {
+		return a.SendError(ctx, errors.NotFoundError(nil).WithMessage("tag %s not found", params.TagName))
+	}
+
+	// expand the manifest list or index into the per-platform children
+	arts := []*model.Artifact{{Artifact: *art}}
+	var platforms []*v1.Platform
+	for _, reference := range art.References {
+		child, err := a.artCtl.Get(ctx, reference.ChildID, &artifact.Option{WithLabel: lib.BoolValue(params.WithLabel)})
+		if err != nil {
+			// the child may be deleted concurrently
+			if errors.IsNotFoundErr(err) {
+				continue
+			}
+			return a.SendError(ctx, err)
+		}
+		arts = append(arts, &model.Artifact{Artifact: *child})
+		platforms = append(platforms, reference.Platform)
+	}
+	if err = assembler.NewVulAssembler(lib.BoolValue(params.WithScanOverview), parseScanReportMimeTypes(params.XAcceptVulnerabilities)).
+		WithArtifacts(arts...).Assemble(ctx); err != nil {
+		log.Warningf("failed to assemble vulnerabilities with the artifacts of the tag %s, error: %v", params.TagName, err)
+	}
+
+	detail.Artifact = arts[0].ToSwagger()
+	for i, child := range arts[1:] {
+		c := child.ToSwagger()
+		p := &models.TagPlatform{
+			ArtifactID:   c.ID,
+			Digest:       c.Digest,
+			MediaType:    c.ManifestMediaType,
+			Size:         c.Size,
+			Labels:       c.Labels,
+			ScanOverview: c.ScanOverview,
+		}
+		if platform := platforms[i]; platform != nil {
+			p.Os = platform.OS
+			p.Architecture = platform.Architecture
+			p.Variant = platform.Variant
+			p.OsVersion = platform.OSVersion
+		}
+		detail.Children = append(detail.Children, p)
+	}
+	return operation.NewGetTagOK().WithPayload(detail)
 }
 
 func (a *artifactAPI) ListAccessories(ctx context.Context, params operation.ListAccessoriesParams) middleware.Responder {
@@ -492,6 +857,109 @@ func (a *artifactAPI) RemoveLabel(ctx context.Context, params operation.RemoveLa
 	return operation.NewRemoveLabelOK()
 }
 
+func (a *artifactAPI) GetArtifactPin(ctx context.Context, params operation.GetArtifactPinParams) middleware.Responder {
+	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionList, rbac.ResourceArtifactPin); err != nil {
+		return a.SendError(ctx, err)
+	}
+	art, err := a.artCtl.GetByReference(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName), params.Reference, nil)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	p, err := a.pinMgr.Get(ctx, art.RepositoryName, art.Digest)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	return operation.NewGetArtifactPinOK().WithPayload(&models.ArtifactPin{
+		ID:             p.ID,
+		RepositoryName: p.RepositoryName,
+		Digest:         p.Digest,
+		Reason:         p.Reason,
+		PinnedBy:       p.PinnedBy,
+		CreationTime:   strfmt.DateTime(p.CreationTime),
+	})
+}
+
+func (a *artifactAPI) PinArtifact(ctx context.Context, params operation.PinArtifactParams) middleware.Responder {
+	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionCreate, rbac.ResourceArtifactPin); err != nil {
+		return a.SendError(ctx, err)
+	}
+	var reason string
+	if params.Pin != nil {
+		reason = params.Pin.Reason
+	}
+	if len(reason) > maxPinReasonLength {
+		return a.SendError(ctx, errors.BadRequestError(nil).WithMessage("the length of the reason mustn't exceed %d", maxPinReasonLength))
+	}
+	art, err := a.artCtl.GetByReference(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName), params.Reference, nil)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	var pinnedBy string
+	if secCtx, err := a.GetSecurityContext(ctx); err == nil {
+		pinnedBy = secCtx.GetUsername()
+	}
+	if _, err = a.pinMgr.Pin(ctx, &pinmodel.Pin{
+		ProjectID:      art.ProjectID,
+		RepositoryName: art.RepositoryName,
+		Digest:         art.Digest,
+		Reason:         reason,
+		PinnedBy:       pinnedBy,
+	}); err != nil {
+		return a.SendError(ctx, err)
+	}
+	return operation.NewPinArtifactCreated().WithLocation(params.HTTPRequest.URL.Path)
+}
+
+func (a *artifactAPI) UnpinArtifact(ctx context.Context, params operation.UnpinArtifactParams) middleware.Responder {
+	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionDelete, rbac.ResourceArtifactPin); err != nil {
+		return a.SendError(ctx, err)
+	}
+	art, err := a.artCtl.GetByReference(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName), params.Reference, nil)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	if err = a.pinMgr.Unpin(ctx, art.RepositoryName, art.Digest); err != nil {
+		return a.SendError(ctx, err)
+	}
+	return operation.NewUnpinArtifactOK()
+}
+
+func (a *artifactAPI) GetArtifactQuarantine(ctx context.Context, params operation.GetArtifactQuarantineParams) middleware.Responder {
+	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionRead, rbac.ResourceArtifactQuarantine); err != nil {
+		return a.SendError(ctx, err)
+	}
+	art, err := a.artCtl.GetByReference(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName), params.Reference, nil)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	qt, err := a.quarantineCtl.Get(ctx, art.ID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	return operation.NewGetArtifactQuarantineOK().WithPayload(&models.ArtifactQuarantine{
+		ArtifactID:     qt.ArtifactID,
+		RepositoryName: qt.RepositoryName,
+		Digest:         qt.Digest,
+		Reason:         qt.Reason,
+		CreationTime:   strfmt.DateTime(qt.CreationTime),
+		UpdateTime:     strfmt.DateTime(qt.UpdateTime),
+	})
+}
+
+func (a *artifactAPI) ReleaseArtifactQuarantine(ctx context.Context, params operation.ReleaseArtifactQuarantineParams) middleware.Responder {
+	if err := a.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionDelete, rbac.ResourceArtifactQuarantine); err != nil {
+		return a.SendError(ctx, err)
+	}
+	art, err := a.artCtl.GetByReference(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName), params.Reference, nil)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	if err = a.quarantineCtl.Release(ctx, art.ID); err != nil {
+		return a.SendError(ctx, err)
+	}
+	return operation.NewReleaseArtifactQuarantineOK()
+}
+
 func (a *artifactAPI) RequireLabelInProject(ctx context.Context, projectID, labelID int64) error {
 	l, err := a.labelMgr.Get(ctx, labelID)
 	if err != nil {
@@ -15,20 +15,38 @@
 package handler
 
 import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/go-openapi/swag"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/tag"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	pkgartifact "github.com/goharbor/harbor/src/pkg/artifact"
+	pinmodel "github.com/goharbor/harbor/src/pkg/pin/model"
+	repomodel "github.com/goharbor/harbor/src/pkg/repository/model"
 	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
 	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	pkg_tag "github.com/goharbor/harbor/src/pkg/tag/model/tag"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
 	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/artifact"
 	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	repotesting "github.com/goharbor/harbor/src/testing/controller/repository"
 	scantesting "github.com/goharbor/harbor/src/testing/controller/scan"
+	tagtesting "github.com/goharbor/harbor/src/testing/controller/tag"
 	"github.com/goharbor/harbor/src/testing/mock"
+	pintesting "github.com/goharbor/harbor/src/testing/pkg/pin"
 	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
 )
 
@@ -63,11 +81,44 @@ func TestParse(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestSetTagFilters(t *testing.T) {
+	query := q.New(q.KeyWords{"push_time": &q.Range{}})
+	err := setTagFilters(query, operation.ListRepositoryTagsParams{
+		Labels:       swag.String("1, 2"),
+		Signed:       swag.Bool(true),
+		MaxSeverity:  swag.String("High"),
+		PushedAfter:  swag.String("2024-01-01T00:00:00Z"),
+		PushedBefore: swag.String("2024-02-01T00:00:00Z"),
+	})
+	require.Nil(t, err)
+	assert.Equal(t, &q.AndList{Values: []interface{}{int64(1), int64(2)}}, query.Keywords["labels"])
+	assert.Equal(t, true, query.Keywords["signed"])
+	assert.Equal(t, "High", query.Keywords["max_severity"])
+	_, exist := query.Keywords["push_time"]
+	assert.False(t, exist)
+	r, ok := query.Keywords["PushTime"].(*q.Range)
+	require.True(t, ok)
+	assert.NotNil(t, r.Min)
+	assert.NotNil(t, r.Max)
+
+	// invalid label ID
+	err = setTagFilters(q.New(q.KeyWords{}), operation.ListRepositoryTagsParams{Labels: swag.String("a")})
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+
+	// invalid time
+	err = setTagFilters(q.New(q.KeyWords{}), operation.ListRepositoryTagsParams{PushedAfter: swag.String("2024-01-01")})
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+}
+
 type ArtifactTestSuite struct {
 	htesting.Suite
 
 	artCtl  *artifacttesting.Controller
+	repoCtl *repotesting.Controller
 	scanCtl *scantesting.Controller
+	tagCtl  *tagtesting.FakeController
+	pinMgr  *pintesting.Manager
+	copies  []string
 
 	report1 *scan.Report
 	report2 *scan.Report
@@ -75,12 +126,28 @@ type ArtifactTestSuite struct {
 
 func (suite *ArtifactTestSuite) SetupSuite() {
 	suite.artCtl = &artifacttesting.Controller{}
+	suite.repoCtl = &repotesting.Controller{}
 	suite.scanCtl = &scantesting.Controller{}
+	suite.tagCtl = &tagtesting.FakeController{}
+	suite.pinMgr = &pintesting.Manager{}
 
 	suite.Config = &restapi.Config{
 		ArtifactAPI: &artifactAPI{
 			artCtl:  suite.artCtl,
+			repoCtl: suite.repoCtl,
 			scanCtl: suite.scanCtl,
+			tagCtl:  suite.tagCtl,
+			pinMgr:  suite.pinMgr,
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				suite.copies = append(suite.copies, r.Method+" "+r.URL.EscapedPath()+"?"+r.URL.RawQuery)
+				if r.URL.Query().Get("from") == "library/forbidden:latest" {
+					w.WriteHeader(http.StatusForbidden)
+					w.Write([]byte(`{"errors":[{"code":"FORBIDDEN","message":"forbidden"}]}`))
+					return
+				}
+				w.Header().Set("Location", "/api/v2.0/projects/prod/repositories/app/artifacts/v1")
+				w.WriteHeader(http.StatusCreated)
+			}),
 		},
 	}
 
@@ -190,6 +257,259 @@ func (suite *ArtifactTestSuite) TestGetVulnerabilitiesAddition() {
 	}
 }
 
+func (suite *ArtifactTestSuite) TestDeleteTags() {
+	times := 2
+	suite.Security.On("IsAuthenticated").Return(true).Times(times)
+	suite.Security.On("IsSysAdmin").Return(true).Times(times)
+	mock.OnAnything(suite.Security, "Can").Return(true).Times(times)
+
+	url := "/projects/library/repositories/photon/tags"
+
+	{
+		// both names and selector are specified
+		body, err := json.Marshal(&models.TagDeletionRequest{Names: []string{"v1"}, Selector: "v*"})
+		suite.Require().NoError(err)
+		res, err := suite.DoReq(http.MethodDelete, url, bytes.NewReader(body))
+		suite.NoError(err)
+		suite.Equal(400, res.StatusCode)
+	}
+
+	{
+		tagV1 := &tag.Tag{Tag: pkg_tag.Tag{ID: 1, ArtifactID: 1, Name: "v1"}}
+		tagV2 := &tag.Tag{Tag: pkg_tag.Tag{ID: 2, ArtifactID: 1, Name: "v2"}}
+		mock.OnAnything(suite.repoCtl, "GetByName").Return(&repomodel.RepoRecord{RepositoryID: 1}, nil).Once()
+		suite.tagCtl.On("List").Return([]*tag.Tag{tagV1, tagV2}, nil).Once()
+		suite.tagCtl.On("BulkDelete").Return([]*tag.DeleteResult{
+			{Tag: tagV1},
+			{Tag: tagV2, Error: errors.PreconditionFailedError(nil).WithMessage("immutable")},
+		}).Once()
+		mock.OnAnything(suite.artCtl, "Get").Return(&artifact.Artifact{}, nil).Once()
+
+		body, err := json.Marshal(&models.TagDeletionRequest{Names: []string{"v1", "v2", "v3"}})
+		suite.Require().NoError(err)
+		res, err := suite.DoReq(http.MethodDelete, url, bytes.NewReader(body))
+		suite.NoError(err)
+		suite.Require().Equal(200, res.StatusCode)
+
+		var results []*models.TagDeletionResult
+		suite.Require().NoError(json.NewDecoder(res.Body).Decode(&results))
+		suite.Require().Len(results, 3)
+		suite.Equal("v3", results[0].Name)
+		suite.False(results[0].Success)
+		suite.Equal("v1", results[1].Name)
+		suite.True(results[1].Success)
+		suite.Equal("v2", results[2].Name)
+		suite.False(results[2].Success)
+		suite.NotEmpty(results[2].Error)
+	}
+}
+
+func (suite *ArtifactTestSuite) TestGetTag() {
+	times := 2
+	suite.Security.On("IsAuthenticated").Return(true).Times(times)
+	suite.Security.On("IsSysAdmin").Return(true).Times(times)
+	mock.OnAnything(suite.Security, "Can").Return(true).Times(times)
+
+	{
+		// the index is expanded into the per-platform children
+		mock.OnAnything(suite.artCtl, "GetByReference").Return(&artifact.Artifact{
+			Artifact: pkgartifact.Artifact{
+				ID:                1,
+				Digest:            "sha256:index",
+				ManifestMediaType: ocispec.MediaTypeImageIndex,
+				References: []*pkgartifact.Reference{
+					{ParentID: 1, ChildID: 2, Platform: &ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}},
+				},
+			},
+			Tags: []*tag.Tag{{Tag: pkg_tag.Tag{ID: 1, ArtifactID: 1, Name: "latest"}}},
+		}, nil).Once()
+		mock.OnAnything(suite.artCtl, "Get").Return(&artifact.Artifact{
+			Artifact: pkgartifact.Artifact{ID: 2, Digest: "sha256:arm", Size: 1024, ManifestMediaType: ocispec.MediaTypeImageManifest},
+		}, nil).Once()
+
+		detail := &models.TagDetail{}
+		res, err := suite.GetJSON("/projects/library/repositories/photon/tags/latest", detail)
+		suite.NoError(err)
+		suite.Require().Equal(200, res.StatusCode)
+		suite.Equal("latest", detail.Tag.Name)
+		suite.Equal("sha256:index", detail.Artifact.Digest)
+		suite.Require().Len(detail.Children, 1)
+		suite.Equal("sha256:arm", detail.Children[0].Digest)
+		suite.Equal(int64(1024), detail.Children[0].Size)
+		suite.Equal("linux", detail.Children[0].Os)
+		suite.Equal("arm", detail.Children[0].Architecture)
+		suite.Equal("v7", detail.Children[0].Variant)
+	}
+
+	{
+		// the reference is a digest rather than a tag
+		mock.OnAnything(suite.artCtl, "GetByReference").Return(&artifact.Artifact{
+			Artifact: pkgartifact.Artifact{ID: 1, Digest: "sha256:index"},
+		}, nil).Once()
+		res, err := suite.Get("/projects/library/repositories/photon/tags/sha256:index")
+		suite.NoError(err)
+		suite.Equal(404, res.StatusCode)
+	}
+}
+
+func (suite *ArtifactTestSuite) TestListRepositoryTags() {
+	times := 2
+	suite.Security.On("IsAuthenticated").Return(true).Times(times)
+	suite.Security.On("IsSysAdmin").Return(true).Times(times)
+	mock.OnAnything(suite.Security, "Can").Return(true).Times(times)
+
+	{
+		// invalid severity
+		res, err := suite.Get("/projects/library/repositories/photon/tags?max_severity=Severe")
+		suite.NoError(err)
+		suite.Equal(422, res.StatusCode)
+	}
+
+	{
+		mock.OnAnything(suite.repoCtl, "GetByName").Return(&repomodel.RepoRecord{RepositoryID: 1}, nil).Once()
+		suite.tagCtl.On("Count").Return(1, nil).Once()
+		suite.tagCtl.On("List").Return([]*tag.Tag{{Tag: pkg_tag.Tag{ID: 1, RepositoryID: 1, ArtifactID: 1, Name: "v1"}}}, nil).Once()
+
+		var tags []*models.Tag
+		res, err := suite.GetJSON("/projects/library/repositories/photon/tags?labels=1,2&signed=true&max_severity=High&pushed_after=2024-01-01T00:00:00Z", &tags)
+		suite.NoError(err)
+		suite.Require().Equal(200, res.StatusCode)
+		suite.Equal("1", res.Header.Get("X-Total-Count"))
+		suite.Require().Len(tags, 1)
+		suite.Equal("v1", tags[0].Name)
+	}
+}
+
+func (suite *ArtifactTestSuite) TestPinArtifact() {
+	times := 3
+	suite.Security.On("IsAuthenticated").Return(true).Times(times)
+	suite.Security.On("GetUsername").Return("admin").Times(2)
+	mock.OnAnything(suite.Security, "Can").Return(true).Times(times)
+	mock.OnAnything(suite.artCtl, "GetByReference").Return(&artifact.Artifact{
+		Artifact: pkgartifact.Artifact{ID: 1, ProjectID: 1, RepositoryName: "library/photon", Digest: "sha256:123"},
+	}, nil).Times(2)
+
+	{
+		// pin the digest of the tag
+		suite.pinMgr.On("Pin", mock.Anything, mock.MatchedBy(func(p *pinmodel.Pin) bool {
+			return p.RepositoryName == "library/photon" && p.Digest == "sha256:123" && p.Reason == "golden" && p.PinnedBy == "admin"
+		})).Return(int64(1), nil).Once()
+		body, err := json.Marshal(&models.ArtifactPinReq{Reason: "golden"})
+		suite.Require().NoError(err)
+		res, err := suite.DoReq(http.MethodPost, "/projects/library/repositories/photon/artifacts/latest/pin", bytes.NewReader(body))
+		suite.NoError(err)
+		suite.Equal(201, res.StatusCode)
+	}
+
+	{
+		// pinned already
+		mock.OnAnything(suite.pinMgr, "Pin").Return(int64(0), errors.ConflictError(nil)).Once()
+		res, err := suite.DoReq(http.MethodPost, "/projects/library/repositories/photon/artifacts/latest/pin", nil)
+		suite.NoError(err)
+		suite.Equal(409, res.StatusCode)
+	}
+
+	{
+		// the reason is too long
+		body, err := json.Marshal(&models.ArtifactPinReq{Reason: strings.Repeat("a", maxPinReasonLength+1)})
+		suite.Require().NoError(err)
+		res, err := suite.DoReq(http.MethodPost, "/projects/library/repositories/photon/artifacts/latest/pin", bytes.NewReader(body))
+		suite.NoError(err)
+		suite.Equal(400, res.StatusCode)
+	}
+}
+
+func (suite *ArtifactTestSuite) TestGetAndUnpinArtifact() {
+	times := 3
+	suite.Security.On("IsAuthenticated").Return(true).Times(times)
+	mock.OnAnything(suite.Security, "Can").Return(true).Times(times)
+	mock.OnAnything(suite.artCtl, "GetByReference").Return(&artifact.Artifact{
+		Artifact: pkgartifact.Artifact{ID: 1, ProjectID: 1, RepositoryName: "library/photon", Digest: "sha256:123"},
+	}, nil).Times(times)
+
+	{
+		suite.pinMgr.On("Get", mock.Anything, "library/photon", "sha256:123").Return(&pinmodel.Pin{
+			ID: 1, RepositoryName: "library/photon", Digest: "sha256:123", PinnedBy: "admin",
+		}, nil).Once()
+		p := &models.ArtifactPin{}
+		res, err := suite.GetJSON("/projects/library/repositories/photon/artifacts/latest/pin", p)
+		suite.NoError(err)
+		suite.Require().Equal(200, res.StatusCode)
+		suite.Equal("sha256:123", p.Digest)
+		suite.Equal("admin", p.PinnedBy)
+	}
+
+	{
+		suite.pinMgr.On("Unpin", mock.Anything, "library/photon", "sha256:123").Return(nil).Once()
+		res, err := suite.DoReq(http.MethodDelete, "/projects/library/repositories/photon/artifacts/latest/pin", nil)
+		suite.NoError(err)
+		suite.Equal(200, res.StatusCode)
+	}
+
+	{
+		// not pinned
+		mock.OnAnything(suite.pinMgr, "Unpin").Return(errors.NotFoundError(nil)).Once()
+		res, err := suite.DoReq(http.MethodDelete, "/projects/library/repositories/photon/artifacts/latest/pin", nil)
+		suite.NoError(err)
+		suite.Equal(404, res.StatusCode)
+	}
+}
+
+func (suite *ArtifactTestSuite) TestCopyTag() {
+	suite.copies = nil
+	suite.Security.On("IsAuthenticated").Return(true).Once()
+
+	body, err := json.Marshal(&models.CopyTarget{ProjectName: swag.String("prod"), RepositoryName: swag.String("team/app")})
+	suite.Require().NoError(err)
+	res, err := suite.DoReq(http.MethodPost, "/projects/library/repositories/app/tags/v1/copy", bytes.NewReader(body))
+	suite.NoError(err)
+	suite.Require().Equal(201, res.StatusCode)
+	suite.Equal("/api/v2.0/projects/prod/repositories/app/artifacts/v1", res.Header.Get("Location"))
+	suite.Equal([]string{"POST /api/v2.0/projects/prod/repositories/team%252Fapp/artifacts?from=library%2Fapp%3Av1&tag=v1"}, suite.copies)
+}
+
+func (suite *ArtifactTestSuite) TestCopyArtifacts() {
+	suite.Security.On("IsAuthenticated").Return(true).Times(2)
+
+	to := &models.CopyTarget{ProjectName: swag.String("prod"), RepositoryName: swag.String("app"), Tag: "v1"}
+	{
+		// all the items are copied
+		suite.copies = nil
+		body, err := json.Marshal(&models.CopyArtifactsReq{Items: []*models.CopyArtifactsItem{
+			{From: swag.String("library/app:latest"), To: to},
+			{From: swag.String("library/app@sha256:9572f7cdcee8591948c2963463447a53466950b3fc15a247fcad1917ca215a2f"),
+				To: &models.CopyTarget{ProjectName: swag.String("prod"), RepositoryName: swag.String("app")}},
+		}})
+		suite.Require().NoError(err)
+		res, err := suite.DoReq(http.MethodPost, "/artifacts/copy", bytes.NewReader(body))
+		suite.NoError(err)
+		suite.Require().Equal(200, res.StatusCode)
+
+		var results []*models.CopyArtifactsResult
+		suite.Require().NoError(json.NewDecoder(res.Body).Decode(&results))
+		suite.Require().Len(results, 2)
+		suite.Equal("library/app:latest", results[0].From)
+		suite.Equal("/api/v2.0/projects/prod/repositories/app/artifacts/v1", results[0].Location)
+		suite.Require().Len(suite.copies, 2)
+		suite.Contains(suite.copies[0], "tag=v1")
+		suite.NotContains(suite.copies[1], "tag=")
+	}
+
+	{
+		// stop at the first failure
+		suite.copies = nil
+		body, err := json.Marshal(&models.CopyArtifactsReq{Items: []*models.CopyArtifactsItem{
+			{From: swag.String("library/forbidden:latest"), To: to},
+			{From: swag.String("library/app:latest"), To: to},
+		}})
+		suite.Require().NoError(err)
+		res, err := suite.DoReq(http.MethodPost, "/artifacts/copy", bytes.NewReader(body))
+		suite.NoError(err)
+		suite.Equal(403, res.StatusCode)
+		suite.Len(suite.copies, 1)
+	}
+}
+
 func TestArtifactTestSuite(t *testing.T) {
 	suite.Run(t, &ArtifactTestSuite{})
 }
@@ -16,15 +16,22 @@ package assembler
 
 import (
 	"context"
+	"time"
 
 	"github.com/goharbor/harbor/src/controller/scan"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
 )
 
 const (
 	vulnerabilitiesAddition = "vulnerabilities"
+
+	// the max count of the artifacts assembled concurrently
+	assembleConcurrency = 10
+	// the timeout for assembling one artifact
+	assembleTimeout = 10 * time.Second
 )
 
 // NewVulAssembler returns vul assembler
@@ -59,31 +66,42 @@ func (assembler *VulAssembler) WithArtifacts(artifacts ...*model.Artifact) *VulA
 func (assembler *VulAssembler) Assemble(ctx context.Context) error {
 	version := lib.GetAPIVersion(ctx)
 
-	for _, artifact := range assembler.artifacts {
-		isScannable, err := assembler.scanChecker.IsScannable(ctx, &artifact.Artifact)
-		if err != nil {
-			log.Errorf("check the scannable status of %s@%s failed, error: %v", artifact.RepositoryName, artifact.Digest, err)
-			continue
+	// the queries in a transaction cannot run concurrently
+	if orm.InTransaction(ctx) {
+		for _, artifact := range assembler.artifacts {
+			assembler.assemble(ctx, artifact, version)
 		}
+		return nil
+	}
+	lib.RunConcurrently(ctx, assembleConcurrency, len(assembler.artifacts), assembleTimeout, func(ctx context.Context, i int) {
+		assembler.assemble(ctx, assembler.artifacts[i], version)
+	})
 
-		if !isScannable {
-			continue
-		}
+	return nil
+}
+
+func (assembler *VulAssembler) assemble(ctx context.Context, artifact *model.Artifact, version string) {
+	isScannable, err := assembler.scanChecker.IsScannable(ctx, &artifact.Artifact)
+	if err != nil {
+		log.Errorf("check the scannable status of %s@%s failed, error: %v", artifact.RepositoryName, artifact.Digest, err)
+		return
+	}
+
+	if !isScannable {
+		return
+	}
 
-		artifact.SetAdditionLink(vulnerabilitiesAddition, version)
-
-		if assembler.withScanOverview {
-			for _, mimeType := range assembler.mimeTypes {
-				overview, err := assembler.scanCtl.GetSummary(ctx, &artifact.Artifact, []string{mimeType})
-				if err != nil {
-					log.Warningf("get scan summary of artifact %s@%s for %s failed, error:%v", artifact.RepositoryName, artifact.Digest, mimeType, err)
-				} else if len(overview) > 0 {
-					artifact.ScanOverview = overview
-					break
-				}
+	artifact.SetAdditionLink(vulnerabilitiesAddition, version)
+
+	if assembler.withScanOverview {
+		for _, mimeType := range assembler.mimeTypes {
+			overview, err := assembler.scanCtl.GetSummary(ctx, &artifact.Artifact, []string{mimeType})
+			if err != nil {
+				log.Warningf("get scan summary of artifact %s@%s for %s failed, error:%v", artifact.RepositoryName, artifact.Digest, mimeType, err)
+			} else if len(overview) > 0 {
+				artifact.ScanOverview = overview
+				break
 			}
 		}
 	}
-
-	return nil
 }
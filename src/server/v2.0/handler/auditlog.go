@@ -2,8 +2,13 @@ package handler
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
+	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
 
@@ -12,12 +17,17 @@ import (
 	"github.com/goharbor/harbor/src/common/security/local"
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/audit"
+	"github.com/goharbor/harbor/src/pkg/audit/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 	"github.com/goharbor/harbor/src/server/v2.0/restapi/operations/auditlog"
 )
 
+// the page size of listing the audit logs to export
+const auditLogExportPageSize = 500
+
 func newAuditLogAPI() *auditlogAPI {
 	return &auditlogAPI{
 		auditMgr:   audit.Mgr,
@@ -39,36 +49,12 @@ func (a *auditlogAPI) ListAuditLogs(ctx context.Context, params auditlog.ListAud
 	if !secCtx.IsAuthenticated() {
 		return a.SendError(ctx, errors.UnauthorizedError(nil).WithMessage(secCtx.GetUsername()))
 	}
-	query, err := a.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	query, err := a.BuildCursorQuery(ctx, params.Q, params.Sort, params.Cursor, params.Page, params.PageSize)
 	if err != nil {
 		return a.SendError(ctx, err)
 	}
-
-	if err := a.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceAuditLog); err != nil {
-		ol := &q.OrList{}
-		if sc, ok := secCtx.(*local.SecurityContext); ok && sc.IsAuthenticated() {
-			user := sc.User()
-			member := &project.MemberQuery{
-				UserID:   user.UserID,
-				GroupIDs: user.GroupIDs,
-			}
-
-			projects, err := a.projectCtl.List(ctx, q.New(q.KeyWords{"member": member}), project.Metadata(false))
-			if err != nil {
-				return a.SendError(ctx, fmt.Errorf(
-					"failed to get projects of user %s: %v", secCtx.GetUsername(), err))
-			}
-			for _, project := range projects {
-				if a.HasProjectPermission(ctx, project.ProjectID, rbac.ActionList, rbac.ResourceLog) {
-					ol.Values = append(ol.Values, project.ProjectID)
-				}
-			}
-		}
-		// make sure no project will be selected with the query
-		if len(ol.Values) == 0 {
-			ol.Values = append(ol.Values, -1)
-		}
-		query.Keywords["ProjectID"] = ol
+	if err := a.limitToMemberProjects(ctx, secCtx, query); err != nil {
+		return a.SendError(ctx, err)
 	}
 
 	total, err := a.auditMgr.Count(ctx, query)
@@ -83,16 +69,110 @@ func (a *auditlogAPI) ListAuditLogs(ctx context.Context, params auditlog.ListAud
 	var auditLogs []*models.AuditLog
 	for _, log := range logs {
 		auditLogs = append(auditLogs, &models.AuditLog{
-			ID:           log.ID,
-			Resource:     log.Resource,
-			ResourceType: log.ResourceType,
-			Username:     log.Username,
-			Operation:    log.Operation,
-			OpTime:       strfmt.DateTime(log.OpTime),
+			ID:             log.ID,
+			ProjectID:      log.ProjectID,
+			Resource:       log.Resource,
+			ResourceType:   log.ResourceType,
+			Username:       log.Username,
+			Operation:      log.Operation,
+			OpTime:         strfmt.DateTime(log.OpTime),
+			BeforeSnapshot: log.BeforeSnapshot,
+			AfterSnapshot:  log.AfterSnapshot,
 		})
 	}
 	return auditlog.NewListAuditLogsOK().
 		WithXTotalCount(total).
-		WithLink(a.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithLink(a.CursorLinks(ctx, params.HTTPRequest.URL, total, query).String()).
 		WithPayload(auditLogs)
 }
+
+// ExportAuditLogs writes all the audit logs matching the query as CSV regardless of the pagination
+func (a *auditlogAPI) ExportAuditLogs(ctx context.Context, params auditlog.ExportAuditLogsParams) middleware.Responder {
+	secCtx, ok := security.FromContext(ctx)
+	if !ok {
+		return a.SendError(ctx, errors.UnauthorizedError(errors.New("security context not found")))
+	}
+	if !secCtx.IsAuthenticated() {
+		return a.SendError(ctx, errors.UnauthorizedError(nil).WithMessage(secCtx.GetUsername()))
+	}
+	pageNumber, pageSize := int64(1), int64(auditLogExportPageSize)
+	query, err := a.BuildQuery(ctx, params.Q, params.Sort, &pageNumber, &pageSize)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	if err := a.limitToMemberProjects(ctx, secCtx, query); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	// list the first page before writing the header to make sure the error can be sent
+	logs, err := a.auditMgr.List(ctx, query)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	return middleware.ResponderFunc(func(w http.ResponseWriter, _ runtime.Producer) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=auditlogs-%s.csv", time.Now().UTC().Format("20060102150405")))
+		writer := csv.NewWriter(w)
+		_ = writer.Write([]string{"id", "project_id", "operation", "resource_type", "resource", "username", "op_time", "before_snapshot", "after_snapshot"})
+		for {
+			for _, l := range logs {
+				_ = writer.Write(toAuditLogRecord(l))
+			}
+			if int64(len(logs)) < query.PageSize {
+				break
+			}
+			query.PageNumber++
+			if logs, err = a.auditMgr.List(ctx, query); err != nil {
+				// the header has been written, log the error only
+				log.Errorf("failed to list the audit logs for exporting: %v", err)
+				break
+			}
+		}
+		writer.Flush()
+	})
+}
+
+// limitToMemberProjects limits the query to the projects whose logs the user can list when the user cannot list all the logs
+func (a *auditlogAPI) limitToMemberProjects(ctx context.Context, secCtx security.Context, query *q.Query) error {
+	if err := a.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceAuditLog); err == nil {
+		return nil
+	}
+	ol := &q.OrList{}
+	if sc, ok := secCtx.(*local.SecurityContext); ok && sc.IsAuthenticated() {
+		user := sc.User()
+		member := &project.MemberQuery{
+			UserID:   user.UserID,
+			GroupIDs: user.GroupIDs,
+		}
+
+		projects, err := a.projectCtl.List(ctx, q.New(q.KeyWords{"member": member}), project.Metadata(false))
+		if err != nil {
+			return fmt.Errorf("failed to get projects of user %s: %v", secCtx.GetUsername(), err)
+		}
+		for _, project := range projects {
+			if a.HasProjectPermission(ctx, project.ProjectID, rbac.ActionList, rbac.ResourceLog) {
+				ol.Values = append(ol.Values, project.ProjectID)
+			}
+		}
+	}
+	// make sure no project will be selected with the query
+	if len(ol.Values) == 0 {
+		ol.Values = append(ol.Values, -1)
+	}
+	query.Keywords["ProjectID"] = ol
+	return nil
+}
+
+func toAuditLogRecord(l *model.AuditLog) []string {
+	return []string{
+		strconv.FormatInt(l.ID, 10),
+		strconv.FormatInt(l.ProjectID, 10),
+		l.Operation,
+		l.ResourceType,
+		l.Resource,
+		l.Username,
+		l.OpTime.UTC().Format(time.RFC3339),
+		l.BeforeSnapshot,
+		l.AfterSnapshot,
+	}
+}
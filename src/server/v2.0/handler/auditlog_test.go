@@ -1,3 +1,79 @@
 package handler
 
-// ToDo add api tests
+import (
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/audit/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	"github.com/goharbor/harbor/src/testing/mock"
+	audittesting "github.com/goharbor/harbor/src/testing/pkg/audit"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+)
+
+type auditLogTestSuite struct {
+	htesting.Suite
+	auditMgr *audittesting.Manager
+}
+
+func (a *auditLogTestSuite) SetupSuite() {
+	a.auditMgr = &audittesting.Manager{}
+	a.Config = &restapi.Config{AuditlogAPI: &auditlogAPI{auditMgr: a.auditMgr}}
+	a.Suite.SetupSuite()
+}
+
+func (a *auditLogTestSuite) SetupTest() {
+	a.auditMgr.ExpectedCalls = nil
+	a.auditMgr.Calls = nil
+	a.Security.On("IsAuthenticated").Return(true)
+	a.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true)
+}
+
+func (a *auditLogTestSuite) TestListAuditLogs() {
+	mock.OnAnything(a.auditMgr, "Count").Return(int64(11), nil)
+	mock.OnAnything(a.auditMgr, "List").Return([]*model.AuditLog{
+		{ID: 11, ProjectID: 1, ResourceType: "robot", BeforeSnapshot: `{"name":"robot"}`},
+	}, nil)
+
+	var logs []*models.AuditLog
+	res, err := a.GetJSON("/audit-logs?q=resource_type%3Drobot&page=2&page_size=10", &logs)
+	a.Require().NoError(err)
+	a.Equal(200, res.StatusCode)
+	a.Equal("11", res.Header.Get("X-Total-Count"))
+	a.Require().Len(logs, 1)
+	a.Equal(int64(1), logs[0].ProjectID)
+	a.Equal(`{"name":"robot"}`, logs[0].BeforeSnapshot)
+	query := a.auditMgr.Calls[0].Arguments.Get(1).(*q.Query)
+	a.Equal("robot", query.Keywords["resource_type"])
+	a.Equal(int64(2), query.PageNumber)
+	a.Equal(int64(10), query.PageSize)
+}
+
+func (a *auditLogTestSuite) TestExportAuditLogs() {
+	mock.OnAnything(a.auditMgr, "List").Return([]*model.AuditLog{
+		{ID: 1, ProjectID: 1, Operation: "delete", ResourceType: "project_member", Resource: "user",
+			Username: "admin", OpTime: time.Unix(0, 0), BeforeSnapshot: `{"role_id":1}`},
+	}, nil)
+
+	res, err := a.Get("/audit-logs/export?q=resource_type%3Dproject_member")
+	a.Require().NoError(err)
+	defer res.Body.Close()
+	a.Equal(200, res.StatusCode)
+	a.Equal("text/csv", res.Header.Get("Content-Type"))
+	records, err := csv.NewReader(res.Body).ReadAll()
+	a.Require().NoError(err)
+	a.Require().Len(records, 2)
+	a.Equal([]string{"1", "1", "delete", "project_member", "user", "admin", "1970-01-01T00:00:00Z", `{"role_id":1}`, ""}, records[1])
+	query := a.auditMgr.Calls[0].Arguments.Get(1).(*q.Query)
+	a.Equal("project_member", query.Keywords["resource_type"])
+	a.auditMgr.AssertNotCalled(a.T(), "Count", mock.Anything, mock.Anything)
+}
+
+func TestAuditLogTestSuite(t *testing.T) {
+	suite.Run(t, &auditLogTestSuite{})
+}
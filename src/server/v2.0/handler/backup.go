@@ -0,0 +1,198 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/backup"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/backup/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/backup"
+)
+
+type backupAPI struct {
+	BaseAPI
+	ctl backup.Controller
+}
+
+func newBackupAPI() *backupAPI {
+	return &backupAPI{
+		ctl: backup.Ctl,
+	}
+}
+
+func (b *backupAPI) StartBackup(ctx context.Context, params operation.StartBackupParams) middleware.Responder {
+	if err := b.RequireSystemAccess(ctx, rbac.ActionCreate, rbac.ResourceSystemBackup); err != nil {
+		return b.SendError(ctx, err)
+	}
+	hold := backup.DefaultHold
+	if params.Backup != nil && params.Backup.HoldMinutes != 0 {
+		hold = time.Duration(params.Backup.HoldMinutes) * time.Minute
+	}
+	id, err := b.ctl.Start(ctx, hold)
+	if err != nil {
+		return b.SendError(ctx, err)
+	}
+	location := fmt.Sprintf("%s/%d", params.HTTPRequest.URL.Path, id)
+	return operation.NewStartBackupCreated().WithLocation(location)
+}
+
+func (b *backupAPI) ListBackups(ctx context.Context, params operation.ListBackupsParams) middleware.Responder {
+	if err := b.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceSystemBackup); err != nil {
+		return b.SendError(ctx, err)
+	}
+	query, err := b.BuildQuery(ctx, nil, nil, params.Page, params.PageSize)
+	if err != nil {
+		return b.SendError(ctx, err)
+	}
+	total, err := b.ctl.Count(ctx, query)
+	if err != nil {
+		return b.SendError(ctx, err)
+	}
+	bs, err := b.ctl.List(ctx, query)
+	if err != nil {
+		return b.SendError(ctx, err)
+	}
+	var payload []*models.Backup
+	for _, bk := range bs {
+		payload = append(payload, toBackupModel(bk))
+	}
+	return operation.NewListBackupsOK().
+		WithXTotalCount(total).
+		WithLink(b.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(payload)
+}
+
+func (b *backupAPI) GetBackup(ctx context.Context, params operation.GetBackupParams) middleware.Responder {
+	if err := b.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceSystemBackup); err != nil {
+		return b.SendError(ctx, err)
+	}
+	bk, err := b.ctl.Get(ctx, params.BackupID)
+	if err != nil {
+		return b.SendError(ctx, err)
+	}
+	return operation.NewGetBackupOK().WithPayload(toBackupModel(bk))
+}
+
+func (b *backupAPI) CompleteBackup(ctx context.Context, params operation.CompleteBackupParams) middleware.Responder {
+	if err := b.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceSystemBackup); err != nil {
+		return b.SendError(ctx, err)
+	}
+	if err := b.ctl.Complete(ctx, params.BackupID); err != nil {
+		return b.SendError(ctx, err)
+	}
+	return operation.NewCompleteBackupOK()
+}
+
+func (b *backupAPI) GetBackupManifest(ctx context.Context, params operation.GetBackupManifestParams) middleware.Responder {
+	if err := b.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceSystemBackup); err != nil {
+		return b.SendError(ctx, err)
+	}
+	manifest, err := b.ctl.Manifest(ctx, params.BackupID)
+	if err != nil {
+		return b.SendError(ctx, err)
+	}
+	return operation.NewGetBackupManifestOK().WithPayload(toManifestModel(manifest))
+}
+
+func (b *backupAPI) ValidateRestore(ctx context.Context, params operation.ValidateRestoreParams) middleware.Responder {
+	if err := b.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceSystemBackup); err != nil {
+		return b.SendError(ctx, err)
+	}
+	if params.Manifest == nil || params.Manifest.Storage == nil {
+		return b.SendError(ctx, errors.BadRequestError(nil).WithMessage("the manifest of the backup is required"))
+	}
+	validation, err := b.ctl.Validate(ctx, fromManifestModel(params.Manifest))
+	if err != nil {
+		return b.SendError(ctx, err)
+	}
+	res := &models.RestoreValidation{
+		Ready:  validation.Ready,
+		Checks: []*models.PreflightCheck{},
+	}
+	for _, check := range validation.Checks {
+		res.Checks = append(res.Checks, &models.PreflightCheck{
+			Name:     check.Name,
+			Passed:   check.Passed,
+			Blocking: check.Blocking,
+			Message:  check.Message,
+		})
+	}
+	return operation.NewValidateRestoreOK().WithPayload(res)
+}
+
+func toBackupModel(b *backup.Backup) *models.Backup {
+	return &models.Backup{
+		ID:             b.ID,
+		Status:         b.Status,
+		StatusMessage:  b.StatusMessage,
+		Stage:          b.Stage,
+		Operator:       b.Operator,
+		HoldUntil:      strfmt.DateTime(b.HoldUntil),
+		ManifestDigest: b.ManifestDigest,
+		ManifestSize:   b.ManifestSize,
+		StartTime:      strfmt.DateTime(b.StartTime),
+		EndTime:        strfmt.DateTime(b.EndTime),
+	}
+}
+
+func toManifestModel(m *model.Manifest) *models.BackupManifest {
+	res := &models.BackupManifest{
+		HarborVersion: m.HarborVersion,
+		SchemaVersion: int64(m.SchemaVersion),
+		WalPosition:   m.WALPosition,
+		CreatedAt:     strfmt.DateTime(m.CreatedAt),
+		Tables:        m.Tables,
+	}
+	if m.Storage != nil {
+		res.Storage = &models.BackupStorage{
+			BlobCount: m.Storage.BlobCount,
+			TotalSize: m.Storage.TotalSize,
+			Checksum:  m.Storage.Checksum,
+			Blobs:     []*models.BackupBlob{},
+		}
+		for _, blob := range m.Storage.Blobs {
+			res.Storage.Blobs = append(res.Storage.Blobs, &models.BackupBlob{
+				Digest: blob.Digest,
+				Size:   blob.Size,
+			})
+		}
+	}
+	return res
+}
+
+// fromManifestModel converts the manifest in the request, the blobs aren't needed for the validation
+func fromManifestModel(m *models.BackupManifest) *model.Manifest {
+	return &model.Manifest{
+		HarborVersion: m.HarborVersion,
+		SchemaVersion: uint(m.SchemaVersion),
+		WALPosition:   m.WalPosition,
+		CreatedAt:     time.Time(m.CreatedAt),
+		Tables:        m.Tables,
+		Storage: &model.Storage{
+			BlobCount: m.Storage.BlobCount,
+			TotalSize: m.Storage.TotalSize,
+			Checksum:  m.Storage.Checksum,
+		},
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/backup"
+	"github.com/goharbor/harbor/src/pkg/backup/model"
+	migrationmodel "github.com/goharbor/harbor/src/pkg/migration/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	backuptesting "github.com/goharbor/harbor/src/testing/controller/backup"
+	"github.com/goharbor/harbor/src/testing/mock"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+)
+
+type backupTestSuite struct {
+	htesting.Suite
+	ctl *backuptesting.Controller
+}
+
+func (b *backupTestSuite) SetupSuite() {
+	b.ctl = &backuptesting.Controller{}
+	b.Config = &restapi.Config{BackupAPI: &backupAPI{ctl: b.ctl}}
+	b.Suite.SetupSuite()
+}
+
+func (b *backupTestSuite) SetupTest() {
+	b.ctl.ExpectedCalls = nil
+	b.Security.On("IsAuthenticated").Return(true)
+	b.Security.On("IsSysAdmin").Return(true)
+	b.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true)
+}
+
+func (b *backupTestSuite) TestStartBackup() {
+	b.ctl.On("Start", mock.Anything, backup.DefaultHold).Return(int64(1), nil).Once()
+	res, err := b.PostJSON("/system/backups", &models.BackupReq{})
+	b.Require().NoError(err)
+	b.Equal(201, res.StatusCode)
+	b.Equal("/api/v2.0/system/backups/1", res.Header.Get("Location"))
+
+	b.ctl.On("Start", mock.Anything, 30*time.Minute).Return(int64(2), nil).Once()
+	res, err = b.PostJSON("/system/backups", &models.BackupReq{HoldMinutes: 30})
+	b.Require().NoError(err)
+	b.Equal(201, res.StatusCode)
+	b.ctl.AssertExpectations(b.T())
+}
+
+func (b *backupTestSuite) TestGetBackupManifest() {
+	b.ctl.On("Manifest", mock.Anything, int64(1)).Return(&model.Manifest{
+		SchemaVersion: 100,
+		Tables:        map[string]int64{"blob": 1},
+		Storage: &model.Storage{
+			BlobCount: 1,
+			TotalSize: 10,
+			Checksum:  "sha256:abc",
+			Blobs:     []*model.Blob{{Digest: "sha256:def", Size: 10}},
+		},
+	}, nil)
+	manifest := &models.BackupManifest{}
+	res, err := b.GetJSON("/system/backups/1/manifest", manifest)
+	b.Require().NoError(err)
+	b.Equal(200, res.StatusCode)
+	b.Equal(int64(100), manifest.SchemaVersion)
+	b.Equal(int64(1), manifest.Tables["blob"])
+	b.Require().Len(manifest.Storage.Blobs, 1)
+	b.Equal("sha256:def", manifest.Storage.Blobs[0].Digest)
+}
+
+func (b *backupTestSuite) TestValidateRestore() {
+	res, err := b.PostJSON("/system/backups/validate", &models.BackupManifest{})
+	b.Require().NoError(err)
+	b.Equal(400, res.StatusCode)
+
+	b.ctl.On("Validate", mock.Anything, mock.MatchedBy(func(m *model.Manifest) bool {
+		return m.SchemaVersion == 100 && m.Storage.Checksum == "sha256:abc"
+	})).Return(&backup.Validation{
+		Ready:  false,
+		Checks: []*migrationmodel.Check{{Name: "blobs", Blocking: true}},
+	}, nil)
+	res, err = b.PostJSON("/system/backups/validate", &models.BackupManifest{
+		SchemaVersion: 100,
+		Storage:       &models.BackupStorage{BlobCount: 1, TotalSize: 10, Checksum: "sha256:abc"},
+	})
+	b.Require().NoError(err)
+	b.Equal(200, res.StatusCode)
+	b.ctl.AssertExpectations(b.T())
+}
+
+func TestBackupTestSuite(t *testing.T) {
+	suite.Run(t, &backupTestSuite{})
+}
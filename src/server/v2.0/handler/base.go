@@ -179,6 +179,53 @@ func (b *BaseAPI) BuildQuery(ctx context.Context, query, sort *string, pageNumbe
 	return q.Build(qs, st, pn, ps)
 }
 
+// BuildCursorQuery builds the query model according to the query string with the keyset pagination enabled,
+// the next cursor is populated in the returned query after listing the records
+func (b *BaseAPI) BuildCursorQuery(ctx context.Context, query, sort, cursor *string, pageNumber, pageSize *int64) (*q.Query, error) {
+	qry, err := b.BuildQuery(ctx, query, sort, pageNumber, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	qry.Cursor = q.NewCursor(lib.StringValue(cursor))
+	return qry, nil
+}
+
+// CursorLinks return Links based on the provided query built by "BuildCursorQuery".
+// The "next" link carries the cursor populated by the listing, and the "prev" link is
+// only returned when the current page is located by the page number
+func (b *BaseAPI) CursorLinks(ctx context.Context, u *url.URL, total int64, query *q.Query) lib.Links {
+	if query.Cursor == nil {
+		return b.Links(ctx, u, total, query.PageNumber, query.PageSize)
+	}
+	var links lib.Links
+	if len(query.Cursor.Value) == 0 {
+		for _, link := range b.Links(ctx, u, total, query.PageNumber, query.PageSize) {
+			if link.Rel == "prev" {
+				links = append(links, link)
+			}
+		}
+	}
+	if len(query.Cursor.Next) > 0 {
+		ul := *u
+		q := ul.Query()
+		q.Del("page")
+		q.Set("cursor", query.Cursor.Next)
+		q.Set("page_size", strconv.FormatInt(query.PageSize, 10))
+		ul.RawQuery = q.Encode()
+		// try to unescape the query
+		if escapedQuery, err := url.QueryUnescape(ul.RawQuery); err == nil {
+			ul.RawQuery = escapedQuery
+		} else {
+			log.Errorf("failed to unescape the query %s: %v", ul.RawQuery, err)
+		}
+		links = append(links, &lib.Link{
+			URL: ul.String(),
+			Rel: "next",
+		})
+	}
+	return links
+}
+
 // Links return Links based on the provided pagination information
 func (b *BaseAPI) Links(ctx context.Context, u *url.URL, total, pageNumber, pageSize int64) lib.Links {
 	var links lib.Links
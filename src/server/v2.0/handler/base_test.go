@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib/q"
 	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
 )
 
@@ -122,6 +123,33 @@ func (b *baseHandlerTestSuite) TestLinks() {
 	b.Equal("http://localhost/api/library%252Fhello-world/artifacts?page=3&page_size=1&q=a=~b", links[1].URL)
 }
 
+func (b *baseHandlerTestSuite) TestCursorLinks() {
+	// request the second page by page number, response contains the "prev" link and the "next" link with cursor
+	url, err := url.Parse("http://localhost/api/artifacts?page=2&page_size=1")
+	b.Require().Nil(err)
+	query := &q.Query{PageNumber: 2, PageSize: 1, Cursor: &q.Cursor{Next: "abc"}}
+	links := b.base.CursorLinks(nil, url, 3, query)
+	b.Require().Len(links, 2)
+	b.Equal("prev", links[0].Rel)
+	b.Equal("http://localhost/api/artifacts?page=1&page_size=1", links[0].URL)
+	b.Equal("next", links[1].Rel)
+	b.Equal("http://localhost/api/artifacts?cursor=abc&page_size=1", links[1].URL)
+
+	// request by cursor, response contains only the "next" link
+	url, err = url.Parse("http://localhost/api/artifacts?cursor=abc&page_size=1")
+	b.Require().Nil(err)
+	query = &q.Query{PageNumber: 1, PageSize: 1, Cursor: &q.Cursor{Value: "abc", Next: "def"}}
+	links = b.base.CursorLinks(nil, url, 3, query)
+	b.Require().Len(links, 1)
+	b.Equal("next", links[0].Rel)
+	b.Equal("http://localhost/api/artifacts?cursor=def&page_size=1", links[0].URL)
+
+	// request the last page by cursor, response contains no links
+	query = &q.Query{PageNumber: 1, PageSize: 1, Cursor: &q.Cursor{Value: "def"}}
+	links = b.base.CursorLinks(nil, url, 3, query)
+	b.Len(links, 0)
+}
+
 func TestBaseHandler(t *testing.T) {
 	suite.Run(t, &baseHandlerTestSuite{})
 }
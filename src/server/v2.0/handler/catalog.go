@@ -0,0 +1,209 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/repository"
+	"github.com/goharbor/harbor/src/controller/tag"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	pkgModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/catalog"
+)
+
+func newCatalogAPI() *catalogAPI {
+	return &catalogAPI{
+		proCtl:  project.Ctl,
+		repoCtl: repository.Ctl,
+		artCtl:  artifact.Ctl,
+		tagCtl:  tag.Ctl,
+	}
+}
+
+// catalogAPI serves the read only catalog of the public projects listed in it, the requests aren't
+// authenticated, so only the public projects with the "listed_in_catalog" metadata enabled are exposed
+type catalogAPI struct {
+	BaseAPI
+	proCtl  project.Controller
+	repoCtl repository.Controller
+	artCtl  artifact.Controller
+	tagCtl  tag.Controller
+}
+
+func (c *catalogAPI) Prepare(ctx context.Context, operation string, params interface{}) middleware.Responder {
+	if err := unescapePathParams(params, "RepositoryName"); err != nil {
+		return c.SendError(ctx, err)
+	}
+	return nil
+}
+
+func (c *catalogAPI) ListCatalogProjects(ctx context.Context, params operation.ListCatalogProjectsParams) middleware.Responder {
+	query, err := c.BuildQuery(ctx, nil, nil, params.Page, params.PageSize)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+	query.Keywords["public"] = true
+	query.Keywords["listed_in_catalog"] = true
+	if name := lib.StringValue(params.Name); len(name) > 0 {
+		query.Keywords["name"] = &q.FuzzyMatchValue{Value: name}
+	}
+
+	total, err := c.proCtl.Count(ctx, query)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+	projects, err := c.proCtl.List(ctx, query, project.Metadata(false))
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+
+	var results []*models.CatalogProject
+	for _, p := range projects {
+		count, err := c.repoCtl.Count(ctx, q.New(q.KeyWords{"project_id": p.ProjectID}))
+		if err != nil {
+			log.Errorf("failed to get the count of repositories under the project %s: %v", p.Name, err)
+		}
+		results = append(results, &models.CatalogProject{
+			Name:       p.Name,
+			RepoCount:  count,
+			UpdateTime: strfmt.DateTime(p.UpdateTime),
+		})
+	}
+	return operation.NewListCatalogProjectsOK().
+		WithXTotalCount(total).
+		WithLink(c.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(results)
+}
+
+func (c *catalogAPI) ListCatalogRepositories(ctx context.Context, params operation.ListCatalogRepositoriesParams) middleware.Responder {
+	p, err := c.getListedProject(ctx, params.ProjectName)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+	query, err := c.BuildQuery(ctx, nil, nil, params.Page, params.PageSize)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+	query.Keywords["ProjectID"] = p.ProjectID
+
+	total, err := c.repoCtl.Count(ctx, query)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+	repositories, err := c.repoCtl.List(ctx, query)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+
+	var results []*models.CatalogRepository
+	for _, repo := range repositories {
+		count, err := c.artCtl.Count(ctx, q.New(q.KeyWords{"RepositoryID": repo.RepositoryID}))
+		if err != nil {
+			log.Errorf("failed to get the count of artifacts under the repository %s: %v", repo.Name, err)
+		}
+		results = append(results, &models.CatalogRepository{
+			Name:          repo.Name,
+			Description:   repo.Description,
+			ArtifactCount: count,
+			PullCount:     repo.PullCount,
+			UpdateTime:    strfmt.DateTime(repo.UpdateTime),
+		})
+	}
+	return operation.NewListCatalogRepositoriesOK().
+		WithXTotalCount(total).
+		WithLink(c.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(results)
+}
+
+func (c *catalogAPI) ListCatalogTags(ctx context.Context, params operation.ListCatalogTagsParams) middleware.Responder {
+	if _, err := c.getListedProject(ctx, params.ProjectName); err != nil {
+		return c.SendError(ctx, err)
+	}
+	repo, err := c.repoCtl.GetByName(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName))
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+	query, err := c.BuildQuery(ctx, nil, nil, params.Page, params.PageSize)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+	query.Keywords["RepositoryID"] = repo.RepositoryID
+
+	total, err := c.tagCtl.Count(ctx, query)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+	tags, err := c.tagCtl.List(ctx, query, nil)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+
+	// list the artifacts that the tags are attached to in one query
+	artifacts := map[int64]*artifact.Artifact{}
+	if len(tags) > 0 {
+		ids := &q.OrList{}
+		for _, t := range tags {
+			ids.Values = append(ids.Values, t.ArtifactID)
+		}
+		arts, err := c.artCtl.List(ctx, q.New(q.KeyWords{"ID": ids}), nil)
+		if err != nil {
+			return c.SendError(ctx, err)
+		}
+		for _, art := range arts {
+			artifacts[art.ID] = art
+		}
+	}
+
+	var results []*models.CatalogTag
+	for _, t := range tags {
+		result := &models.CatalogTag{
+			Name:     t.Name,
+			PushTime: strfmt.DateTime(t.PushTime),
+		}
+		if art, exist := artifacts[t.ArtifactID]; exist {
+			result.Digest = art.Digest
+			result.Size = art.Size
+		}
+		results = append(results, result)
+	}
+	return operation.NewListCatalogTagsOK().
+		WithXTotalCount(total).
+		WithLink(c.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(results)
+}
+
+// getListedProject returns the project if it's listed in the catalog, the not found error is returned
+// for the projects not listed to avoid disclosing the existence of them
+func (c *catalogAPI) getListedProject(ctx context.Context, projectName string) (*pkgModels.Project, error) {
+	p, err := c.proCtl.GetByName(ctx, projectName)
+	if err != nil {
+		return nil, err
+	}
+	if !p.ListedInCatalog() {
+		return nil, errors.NotFoundError(nil).WithMessage("project %s not found", projectName)
+	}
+	return p, nil
+}
@@ -0,0 +1,153 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/tag"
+	pkgartifact "github.com/goharbor/harbor/src/pkg/artifact"
+	"github.com/goharbor/harbor/src/pkg/project/models"
+	repomodel "github.com/goharbor/harbor/src/pkg/repository/model"
+	pkg_tag "github.com/goharbor/harbor/src/pkg/tag/model/tag"
+	models2 "github.com/goharbor/harbor/src/server/v2.0/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	repotesting "github.com/goharbor/harbor/src/testing/controller/repository"
+	tagtesting "github.com/goharbor/harbor/src/testing/controller/tag"
+	"github.com/goharbor/harbor/src/testing/mock"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+)
+
+type CatalogTestSuite struct {
+	htesting.Suite
+
+	proCtl  *projecttesting.Controller
+	repoCtl *repotesting.Controller
+	artCtl  *artifacttesting.Controller
+	tagCtl  *tagtesting.FakeController
+}
+
+func (suite *CatalogTestSuite) SetupSuite() {
+	suite.proCtl = &projecttesting.Controller{}
+	suite.repoCtl = &repotesting.Controller{}
+	suite.artCtl = &artifacttesting.Controller{}
+	suite.tagCtl = &tagtesting.FakeController{}
+
+	suite.Config = &restapi.Config{
+		CatalogAPI: &catalogAPI{
+			proCtl:  suite.proCtl,
+			repoCtl: suite.repoCtl,
+			artCtl:  suite.artCtl,
+			tagCtl:  suite.tagCtl,
+		},
+	}
+
+	suite.Suite.SetupSuite()
+}
+
+func (suite *CatalogTestSuite) TestListCatalogProjects() {
+	mock.OnAnything(suite.proCtl, "Count").Return(int64(1), nil).Once()
+	mock.OnAnything(suite.proCtl, "List").Return([]*models.Project{{ProjectID: 1, Name: "library"}}, nil).Once()
+	mock.OnAnything(suite.repoCtl, "Count").Return(int64(2), nil).Once()
+
+	var projects []*models2.CatalogProject
+	res, err := suite.GetJSON("/catalog/projects?name=lib", &projects)
+	suite.NoError(err)
+	suite.Require().Equal(200, res.StatusCode)
+	suite.Equal("1", res.Header.Get("X-Total-Count"))
+	suite.Require().Len(projects, 1)
+	suite.Equal("library", projects[0].Name)
+	suite.Equal(int64(2), projects[0].RepoCount)
+}
+
+func (suite *CatalogTestSuite) TestListCatalogRepositories() {
+	{
+		// the public project isn't listed in the catalog
+		mock.OnAnything(suite.proCtl, "GetByName").Return(&models.Project{
+			ProjectID: 1,
+			Name:      "library",
+			Metadata:  map[string]string{models.ProMetaPublic: "true"},
+		}, nil).Once()
+		res, err := suite.Get("/catalog/projects/library/repositories")
+		suite.NoError(err)
+		suite.Equal(404, res.StatusCode)
+	}
+
+	{
+		// the private project can't be listed in the catalog
+		mock.OnAnything(suite.proCtl, "GetByName").Return(&models.Project{
+			ProjectID: 1,
+			Name:      "library",
+			Metadata:  map[string]string{models.ProMetaPublic: "false", models.ProMetaListedInCatalog: "true"},
+		}, nil).Once()
+		res, err := suite.Get("/catalog/projects/library/repositories")
+		suite.NoError(err)
+		suite.Equal(404, res.StatusCode)
+	}
+
+	{
+		mock.OnAnything(suite.proCtl, "GetByName").Return(&models.Project{
+			ProjectID: 1,
+			Name:      "library",
+			Metadata:  map[string]string{models.ProMetaPublic: "true", models.ProMetaListedInCatalog: "true"},
+		}, nil).Once()
+		mock.OnAnything(suite.repoCtl, "Count").Return(int64(1), nil).Once()
+		mock.OnAnything(suite.repoCtl, "List").Return([]*repomodel.RepoRecord{
+			{RepositoryID: 1, ProjectID: 1, Name: "library/hello-world", Description: "hello", PullCount: 10},
+		}, nil).Once()
+		mock.OnAnything(suite.artCtl, "Count").Return(int64(3), nil).Once()
+
+		var repositories []*models2.CatalogRepository
+		res, err := suite.GetJSON("/catalog/projects/library/repositories", &repositories)
+		suite.NoError(err)
+		suite.Require().Equal(200, res.StatusCode)
+		suite.Require().Len(repositories, 1)
+		suite.Equal("library/hello-world", repositories[0].Name)
+		suite.Equal(int64(3), repositories[0].ArtifactCount)
+		suite.Equal(int64(10), repositories[0].PullCount)
+	}
+}
+
+func (suite *CatalogTestSuite) TestListCatalogTags() {
+	mock.OnAnything(suite.proCtl, "GetByName").Return(&models.Project{
+		ProjectID: 1,
+		Name:      "library",
+		Metadata:  map[string]string{models.ProMetaPublic: "true", models.ProMetaListedInCatalog: "true"},
+	}, nil).Once()
+	mock.OnAnything(suite.repoCtl, "GetByName").Return(&repomodel.RepoRecord{RepositoryID: 1, ProjectID: 1, Name: "library/hello-world"}, nil).Once()
+	suite.tagCtl.On("Count").Return(1, nil).Once()
+	suite.tagCtl.On("List").Return([]*tag.Tag{{Tag: pkg_tag.Tag{ID: 1, RepositoryID: 1, ArtifactID: 2, Name: "latest"}}}, nil).Once()
+	mock.OnAnything(suite.artCtl, "List").Return([]*artifact.Artifact{
+		{Artifact: pkgartifact.Artifact{ID: 2, Digest: "sha256:digest", Size: 1024}},
+	}, nil).Once()
+
+	var tags []*models2.CatalogTag
+	res, err := suite.GetJSON("/catalog/projects/library/repositories/hello-world/tags", &tags)
+	suite.NoError(err)
+	suite.Require().Equal(200, res.StatusCode)
+	suite.Require().Len(tags, 1)
+	suite.Equal("latest", tags[0].Name)
+	suite.Equal("sha256:digest", tags[0].Digest)
+	suite.Equal(int64(1024), tags[0].Size)
+}
+
+func TestCatalogTestSuite(t *testing.T) {
+	suite.Run(t, &CatalogTestSuite{})
+}
@@ -19,23 +19,30 @@ import (
 	"encoding/json"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
 
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/common/security"
 	"github.com/goharbor/harbor/src/controller/config"
+	"github.com/goharbor/harbor/src/controller/configsync"
 	cfgModels "github.com/goharbor/harbor/src/lib/config/models"
 	"github.com/goharbor/harbor/src/lib/errors"
+	historyModel "github.com/goharbor/harbor/src/pkg/config/history/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 	"github.com/goharbor/harbor/src/server/v2.0/restapi/operations/configure"
 )
 
 type configAPI struct {
 	BaseAPI
-	controller config.Controller
+	controller    config.Controller
+	configSyncCtl configsync.Controller
 }
 
 func newConfigAPI() *configAPI {
-	return &configAPI{controller: config.Ctl}
+	return &configAPI{
+		controller:    config.Ctl,
+		configSyncCtl: configsync.Ctl,
+	}
 }
 
 func (c *configAPI) GetConfigurations(ctx context.Context, params configure.GetConfigurationsParams) middleware.Responder {
@@ -84,6 +91,9 @@ func (c *configAPI) UpdateConfigurations(ctx context.Context, params configure.U
 	if err != nil {
 		return c.SendError(ctx, err)
 	}
+	if err := c.configSyncCtl.CheckConfigs(ctx, cfgMap); err != nil {
+		return c.SendError(ctx, err)
+	}
 	err = c.controller.UpdateUserConfigs(ctx, cfgMap)
 	if err != nil {
 		return c.SendError(ctx, err)
@@ -91,6 +101,67 @@ func (c *configAPI) UpdateConfigurations(ctx context.Context, params configure.U
 	return configure.NewUpdateConfigurationsOK()
 }
 
+func (c *configAPI) ListConfigurationHistories(ctx context.Context, params configure.ListConfigurationHistoriesParams) middleware.Responder {
+	if err := c.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceConfiguration); err != nil {
+		return c.SendError(ctx, err)
+	}
+	query, err := c.BuildQuery(ctx, nil, nil, params.Page, params.PageSize)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+	total, err := c.controller.CountHistories(ctx, query)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+	histories, err := c.controller.ListHistories(ctx, query)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+	payload := make([]*models.ConfigurationHistory, 0, len(histories))
+	for _, history := range histories {
+		h, err := toHistoryModel(history)
+		if err != nil {
+			return c.SendError(ctx, err)
+		}
+		payload = append(payload, h)
+	}
+	return configure.NewListConfigurationHistoriesOK().
+		WithXTotalCount(total).
+		WithLink(c.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(payload)
+}
+
+func (c *configAPI) RollbackConfigurations(ctx context.Context, params configure.RollbackConfigurationsParams) middleware.Responder {
+	if err := c.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceConfiguration); err != nil {
+		return c.SendError(ctx, err)
+	}
+	if params.Version <= 0 {
+		return c.SendError(ctx, errors.BadRequestError(nil).WithMessage("invalid version %d", params.Version))
+	}
+	if err := c.controller.Rollback(ctx, params.Version); err != nil {
+		return c.SendError(ctx, err)
+	}
+	return configure.NewRollbackConfigurationsOK()
+}
+
+func toHistoryModel(history *historyModel.History) (*models.ConfigurationHistory, error) {
+	before, err := history.BeforeValues()
+	if err != nil {
+		return nil, err
+	}
+	after, err := history.AfterValues()
+	if err != nil {
+		return nil, err
+	}
+	return &models.ConfigurationHistory{
+		Version:      history.ID,
+		Operator:     history.Operator,
+		Before:       before,
+		After:        after,
+		CreationTime: strfmt.DateTime(history.CreationTime),
+	}, nil
+}
+
 func toCfgMap(conf *models.Configurations) (map[string]interface{}, error) {
 	var cfgMap map[string]interface{}
 	buf, err := json.Marshal(conf)
@@ -0,0 +1,210 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/controller/configsync"
+	"github.com/goharbor/harbor/src/controller/jobservice"
+	"github.com/goharbor/harbor/src/controller/task"
+	"github.com/goharbor/harbor/src/lib/errors"
+	taskPkg "github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/config_sync"
+)
+
+type configSyncAPI struct {
+	BaseAPI
+	configSyncCtl configsync.Controller
+	schedulerCtl  jobservice.SchedulerController
+	executionCtl  task.ExecutionController
+}
+
+func newConfigSyncAPI() *configSyncAPI {
+	return &configSyncAPI{
+		configSyncCtl: configsync.Ctl,
+		schedulerCtl:  jobservice.SchedulerCtl,
+		executionCtl:  task.ExecutionCtl,
+	}
+}
+
+func (c *configSyncAPI) CreateConfigSyncSchedule(ctx context.Context, params operation.CreateConfigSyncScheduleParams) middleware.Responder {
+	if err := c.RequireSystemAccess(ctx, rbac.ActionCreate, rbac.ResourceConfigSync); err != nil {
+		return c.SendError(ctx, err)
+	}
+	if params.Schedule == nil || params.Schedule.Schedule == nil {
+		return c.SendError(ctx, errors.BadRequestError(fmt.Errorf("schedule cann't be empty")))
+	}
+	id, err := c.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+	location := path.Join(params.HTTPRequest.URL.Path, fmt.Sprintf("../%d", id))
+	return operation.NewCreateConfigSyncScheduleCreated().WithLocation(location)
+}
+
+func (c *configSyncAPI) UpdateConfigSyncSchedule(ctx context.Context, params operation.UpdateConfigSyncScheduleParams) middleware.Responder {
+	if err := c.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceConfigSync); err != nil {
+		return c.SendError(ctx, err)
+	}
+	if params.Schedule == nil || params.Schedule.Schedule == nil {
+		return c.SendError(ctx, errors.BadRequestError(fmt.Errorf("schedule cann't be empty")))
+	}
+	if _, err := c.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron); err != nil {
+		return c.SendError(ctx, err)
+	}
+	return operation.NewUpdateConfigSyncScheduleOK()
+}
+
+func (c *configSyncAPI) kick(ctx context.Context, scheType string, cron string) (int64, error) {
+	var (
+		id  int64
+		err error
+	)
+	switch scheType {
+	case ScheduleManual:
+		id, err = c.configSyncCtl.Start(ctx, taskPkg.ExecutionTriggerManual)
+	case ScheduleNone:
+		err = c.schedulerCtl.Delete(ctx, configsync.VendorType)
+	case ScheduleHourly, ScheduleDaily, ScheduleWeekly, ScheduleCustom:
+		err = c.updateSchedule(ctx, scheType, cron)
+	default:
+		err = errors.BadRequestError(fmt.Errorf("unsupported schedule type %s", scheType))
+	}
+	return id, err
+}
+
+func (c *configSyncAPI) updateSchedule(ctx context.Context, cronType, cron string) error {
+	if cron == "" {
+		return errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("empty cron string for schedule")
+	}
+	if !c.configSyncCtl.Enabled(ctx) {
+		return errors.BadRequestError(nil).WithMessage("the config sync isn't enabled, set the config_sync_registry_id first")
+	}
+	if err := c.schedulerCtl.Delete(ctx, configsync.VendorType); err != nil {
+		return err
+	}
+	_, err := c.schedulerCtl.Create(ctx, configsync.VendorType, cronType, cron, configsync.SchedulerCallback, nil, nil)
+	return err
+}
+
+func (c *configSyncAPI) GetConfigSyncHistory(ctx context.Context, params operation.GetConfigSyncHistoryParams) middleware.Responder {
+	if err := c.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceConfigSync); err != nil {
+		return c.SendError(ctx, err)
+	}
+	query, err := c.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+	query.Keywords["VendorType"] = configsync.VendorType
+	total, err := c.executionCtl.Count(ctx, query)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+	execs, err := c.executionCtl.List(ctx, query)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+
+	var results []*models.ExecHistory
+	for _, exec := range execs {
+		h, err := toConfigSyncHistory(exec)
+		if err != nil {
+			return c.SendError(ctx, err)
+		}
+		results = append(results, h.ToSwagger())
+	}
+
+	return operation.NewGetConfigSyncHistoryOK().
+		WithXTotalCount(total).
+		WithLink(c.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(results)
+}
+
+func (c *configSyncAPI) GetConfigSync(ctx context.Context, params operation.GetConfigSyncParams) middleware.Responder {
+	if err := c.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceConfigSync); err != nil {
+		return c.SendError(ctx, err)
+	}
+	exec, err := c.executionCtl.Get(ctx, params.SyncID)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+	if exec.VendorType != configsync.VendorType {
+		return c.SendError(ctx, errors.NotFoundError(nil).WithMessage("config sync with id %d not found", params.SyncID))
+	}
+	h, err := toConfigSyncHistory(exec)
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+	return operation.NewGetConfigSyncOK().WithPayload(h.ToSwagger())
+}
+
+// toConfigSyncHistory converts the execution to the history, the parameters contain the ID of the
+// registry endpoint of the primary instance and the result of the sync
+func toConfigSyncHistory(exec *taskPkg.Execution) (*model.ExecHistory, error) {
+	extraAttrsString, err := json.Marshal(exec.ExtraAttrs)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ExecHistory{
+		ID:         exec.ID,
+		Name:       configsync.VendorType,
+		Kind:       exec.Trigger,
+		Parameters: string(extraAttrsString),
+		Schedule: &model.ScheduleParam{
+			Type: exec.Trigger,
+		},
+		Status:       exec.Status,
+		CreationTime: exec.StartTime,
+		UpdateTime:   exec.UpdateTime,
+	}, nil
+}
+
+func (c *configSyncAPI) GetConfigSyncSchedule(ctx context.Context, params operation.GetConfigSyncScheduleParams) middleware.Responder {
+	if err := c.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceConfigSync); err != nil {
+		return c.SendError(ctx, err)
+	}
+	sch, err := c.schedulerCtl.Get(ctx, configsync.VendorType)
+	if errors.IsNotFoundErr(err) {
+		return operation.NewGetConfigSyncScheduleOK()
+	}
+	if err != nil {
+		return c.SendError(ctx, err)
+	}
+	return operation.NewGetConfigSyncScheduleOK().WithPayload(&models.ExecHistory{
+		ID:        sch.ID,
+		JobKind:   sch.CRON,
+		JobStatus: sch.Status,
+		Schedule: &models.ScheduleObj{
+			Cron:              sch.CRON,
+			Type:              sch.CRONType,
+			NextScheduledTime: strfmt.DateTime(utils.NextSchedule(sch.CRON, time.Now())),
+		},
+		CreationTime: strfmt.DateTime(sch.CreationTime),
+		UpdateTime:   strfmt.DateTime(sch.UpdateTime),
+	})
+}
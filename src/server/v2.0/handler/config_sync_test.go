@@ -0,0 +1,78 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	configsynctesting "github.com/goharbor/harbor/src/testing/controller/configsync"
+	"github.com/goharbor/harbor/src/testing/mock"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+)
+
+type configSyncTestSuite struct {
+	htesting.Suite
+	ctl *configsynctesting.Controller
+}
+
+func (c *configSyncTestSuite) SetupSuite() {
+	c.ctl = &configsynctesting.Controller{}
+	c.Config = &restapi.Config{ConfigSyncAPI: &configSyncAPI{configSyncCtl: c.ctl}}
+	c.Suite.SetupSuite()
+}
+
+func (c *configSyncTestSuite) SetupTest() {
+	c.ctl.ExpectedCalls = nil
+	c.Security.On("IsAuthenticated").Return(true)
+	c.Security.On("IsSysAdmin").Return(true)
+	c.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true)
+}
+
+func (c *configSyncTestSuite) TestCreateConfigSyncSchedule() {
+	c.ctl.On("Start", mock.Anything, task.ExecutionTriggerManual).Return(int64(1), nil).Once()
+	res, err := c.PostJSON("/system/configsync/schedule", &models.Schedule{
+		Schedule: &models.ScheduleObj{Type: ScheduleManual},
+	})
+	c.Require().NoError(err)
+	c.Equal(201, res.StatusCode)
+	c.Equal("/api/v2.0/system/configsync/1", res.Header.Get("Location"))
+
+	c.ctl.On("Start", mock.Anything, task.ExecutionTriggerManual).Return(int64(0), errors.ConflictError(nil)).Once()
+	res, err = c.PostJSON("/system/configsync/schedule", &models.Schedule{
+		Schedule: &models.ScheduleObj{Type: ScheduleManual},
+	})
+	c.Require().NoError(err)
+	c.Equal(409, res.StatusCode)
+	c.ctl.AssertExpectations(c.T())
+}
+
+func (c *configSyncTestSuite) TestUpdateConfigSyncScheduleNotEnabled() {
+	c.ctl.On("Enabled", mock.Anything).Return(false)
+	res, err := c.PutJSON("/system/configsync/schedule", &models.Schedule{
+		Schedule: &models.ScheduleObj{Type: ScheduleHourly, Cron: "0 0 * * * *"},
+	})
+	c.Require().NoError(err)
+	c.Equal(400, res.StatusCode)
+}
+
+func TestConfigSyncTestSuite(t *testing.T) {
+	suite.Run(t, &configSyncTestSuite{})
+}
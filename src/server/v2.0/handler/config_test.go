@@ -0,0 +1,43 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	historyModel "github.com/goharbor/harbor/src/pkg/config/history/model"
+)
+
+func TestToHistoryModel(t *testing.T) {
+	now := time.Now()
+	h, err := toHistoryModel(&historyModel.History{
+		ID:           3,
+		Operator:     "admin",
+		Before:       `{"auth_mode":"db_auth"}`,
+		After:        `{"auth_mode":"ldap_auth"}`,
+		CreationTime: now,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), h.Version)
+	assert.Equal(t, "admin", h.Operator)
+	assert.Equal(t, map[string]interface{}{"auth_mode": "db_auth"}, h.Before)
+	assert.Equal(t, map[string]interface{}{"auth_mode": "ldap_auth"}, h.After)
+
+	_, err = toHistoryModel(&historyModel.History{Before: "invalid"})
+	assert.NotNil(t, err)
+}
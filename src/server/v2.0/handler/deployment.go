@@ -0,0 +1,172 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/opencontainers/go-digest"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg/deployment"
+	"github.com/goharbor/harbor/src/pkg/deployment/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/deployment"
+)
+
+// the max length of the deployment identifier, aligned with the column length
+const maxDeploymentLength = 255
+
+func newDeploymentAPI() *deploymentAPI {
+	return &deploymentAPI{
+		projectCtl:    project.Ctl,
+		artCtl:        artifact.Ctl,
+		deploymentMgr: deployment.Mgr,
+	}
+}
+
+// deploymentAPI serves the marks of the artifacts in use by the deployments of the external CD systems,
+// the marked artifacts cannot be deleted by the users, the tag retention or the garbage collection
+type deploymentAPI struct {
+	BaseAPI
+	projectCtl    project.Controller
+	artCtl        artifact.Controller
+	deploymentMgr deployment.Manager
+}
+
+func (d *deploymentAPI) ListDeploymentMarks(ctx context.Context, params operation.ListDeploymentMarksParams) middleware.Responder {
+	if len(params.Repository) == 0 {
+		return d.SendError(ctx, errors.BadRequestError(nil).WithMessage("the repository is required"))
+	}
+	if _, err := d.requireRepositoryAccess(ctx, params.Repository, rbac.ActionPull); err != nil {
+		return d.SendError(ctx, err)
+	}
+	query := q.New(q.KeyWords{"RepositoryName": params.Repository})
+	if dgt := lib.StringValue(params.Digest); len(dgt) > 0 {
+		query.Keywords["Digest"] = dgt
+	}
+	if dep := lib.StringValue(params.Deployment); len(dep) > 0 {
+		query.Keywords["Deployment"] = dep
+	}
+	marks, err := d.deploymentMgr.ListActive(ctx, query)
+	if err != nil {
+		return d.SendError(ctx, err)
+	}
+	var payload []*models.DeploymentMark
+	for _, mark := range marks {
+		payload = append(payload, toDeploymentMarkSwagger(mark))
+	}
+	return operation.NewListDeploymentMarksOK().WithPayload(payload)
+}
+
+// MarkDeployment marks the artifact in use by the deployment for the TTL in seconds, the CD system
+// renews the mark by sending the request again before the mark expires
+func (d *deploymentAPI) MarkDeployment(ctx context.Context, params operation.MarkDeploymentParams) middleware.Responder {
+	req := params.Mark
+	if err := validateDeploymentMark(req.Repository, req.Digest, req.Deployment); err != nil {
+		return d.SendError(ctx, err)
+	}
+	if req.TTL < 0 {
+		return d.SendError(ctx, errors.BadRequestError(nil).WithMessage("the TTL mustn't be negative"))
+	}
+	projectID, err := d.requireRepositoryAccess(ctx, req.Repository, rbac.ActionPush)
+	if err != nil {
+		return d.SendError(ctx, err)
+	}
+	// make sure the artifact exists
+	if _, err = d.artCtl.GetByReference(ctx, req.Repository, req.Digest, nil); err != nil {
+		return d.SendError(ctx, err)
+	}
+	secCtx, err := d.GetSecurityContext(ctx)
+	if err != nil {
+		return d.SendError(ctx, err)
+	}
+	mark, err := d.deploymentMgr.Mark(ctx, &model.Mark{
+		ProjectID:      projectID,
+		RepositoryName: req.Repository,
+		Digest:         req.Digest,
+		Deployment:     req.Deployment,
+		CreatedBy:      secCtx.GetUsername(),
+	}, time.Duration(req.TTL)*time.Second)
+	if err != nil {
+		return d.SendError(ctx, err)
+	}
+	return operation.NewMarkDeploymentOK().WithPayload(toDeploymentMarkSwagger(mark))
+}
+
+func (d *deploymentAPI) UnmarkDeployment(ctx context.Context, params operation.UnmarkDeploymentParams) middleware.Responder {
+	if err := validateDeploymentMark(params.Repository, params.Digest, params.Deployment); err != nil {
+		return d.SendError(ctx, err)
+	}
+	if _, err := d.requireRepositoryAccess(ctx, params.Repository, rbac.ActionPush); err != nil {
+		return d.SendError(ctx, err)
+	}
+	if err := d.deploymentMgr.Unmark(ctx, params.Repository, params.Digest, params.Deployment); err != nil {
+		return d.SendError(ctx, err)
+	}
+	return operation.NewUnmarkDeploymentOK()
+}
+
+// requireRepositoryAccess checks the principal can perform the action on the repository and returns the ID of the project
+func (d *deploymentAPI) requireRepositoryAccess(ctx context.Context, repository string, action rbac.Action) (int64, error) {
+	if err := d.RequireAuthenticated(ctx); err != nil {
+		return 0, err
+	}
+	projectName, _ := utils.ParseRepository(repository)
+	p, err := d.projectCtl.Get(ctx, projectName)
+	if err != nil {
+		return 0, err
+	}
+	if err = d.RequireProjectAccess(ctx, p.ProjectID, action, rbac.ResourceRepository); err != nil {
+		return 0, err
+	}
+	return p.ProjectID, nil
+}
+
+func validateDeploymentMark(repository, dgt, dep string) error {
+	if len(repository) == 0 {
+		return errors.BadRequestError(nil).WithMessage("the repository is required")
+	}
+	if _, err := digest.Parse(dgt); err != nil {
+		return errors.BadRequestError(err).WithMessage("invalid digest %s", dgt)
+	}
+	if len(dep) == 0 || len(dep) > maxDeploymentLength {
+		return errors.BadRequestError(nil).WithMessage("the deployment is required and mustn't exceed %d characters", maxDeploymentLength)
+	}
+	return nil
+}
+
+func toDeploymentMarkSwagger(mark *model.Mark) *models.DeploymentMark {
+	return &models.DeploymentMark{
+		ID:             mark.ID,
+		ProjectID:      mark.ProjectID,
+		RepositoryName: mark.RepositoryName,
+		Digest:         mark.Digest,
+		Deployment:     mark.Deployment,
+		CreatedBy:      mark.CreatedBy,
+		CreationTime:   strfmt.DateTime(mark.CreationTime),
+		UpdateTime:     strfmt.DateTime(mark.UpdateTime),
+		ExpireTime:     strfmt.DateTime(mark.ExpireTime),
+	}
+}
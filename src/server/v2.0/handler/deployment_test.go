@@ -0,0 +1,149 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/artifact"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/deployment/model"
+	projectmodels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	"github.com/goharbor/harbor/src/testing/mock"
+	deploymenttesting "github.com/goharbor/harbor/src/testing/pkg/deployment"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+)
+
+const deploymentTestDigest = "sha256:418fb88ec412e340cdbef913b8ca1bbe8f9e8dc705f9617414c1f2c8db980180"
+
+type deploymentTestSuite struct {
+	htesting.Suite
+	projectCtl    *projecttesting.Controller
+	artCtl        *artifacttesting.Controller
+	deploymentMgr *deploymenttesting.Manager
+}
+
+func (d *deploymentTestSuite) SetupSuite() {
+	d.projectCtl = &projecttesting.Controller{}
+	d.artCtl = &artifacttesting.Controller{}
+	d.deploymentMgr = &deploymenttesting.Manager{}
+	d.Config = &restapi.Config{
+		DeploymentAPI: &deploymentAPI{
+			projectCtl:    d.projectCtl,
+			artCtl:        d.artCtl,
+			deploymentMgr: d.deploymentMgr,
+		},
+	}
+	d.Suite.SetupSuite()
+}
+
+func (d *deploymentTestSuite) SetupTest() {
+	d.Security.ExpectedCalls = nil
+	d.artCtl.ExpectedCalls = nil
+	d.deploymentMgr.ExpectedCalls = nil
+	d.deploymentMgr.Calls = nil
+	d.Security.On("IsAuthenticated").Return(true)
+	d.Security.On("GetUsername").Return("robot$cd")
+	mock.OnAnything(d.projectCtl, "Get").Return(&projectmodels.Project{ProjectID: 1, Name: "library"}, nil)
+}
+
+func (d *deploymentTestSuite) TestMarkDeployment() {
+	mock.OnAnything(d.Security, "Can").Return(true)
+	mock.OnAnything(d.artCtl, "GetByReference").Return(&artifact.Artifact{}, nil)
+	mock.OnAnything(d.deploymentMgr, "Mark").Return(&model.Mark{ID: 1}, nil)
+
+	mark := &models.DeploymentMark{}
+	res, err := d.PostJSON("/deployment-marks", &models.DeploymentMarkReq{
+		Repository: "library/hello-world",
+		Digest:     deploymentTestDigest,
+		Deployment: "prod/web",
+		TTL:        600,
+	})
+	d.Require().NoError(err)
+	d.Equal(200, res.StatusCode)
+	d.Require().NoError(json.NewDecoder(res.Body).Decode(mark))
+	d.Equal(int64(1), mark.ID)
+	args := d.deploymentMgr.Calls[0].Arguments
+	m := args.Get(1).(*model.Mark)
+	d.Equal(int64(1), m.ProjectID)
+	d.Equal("library/hello-world", m.RepositoryName)
+	d.Equal("prod/web", m.Deployment)
+	d.Equal("robot$cd", m.CreatedBy)
+	d.Equal(10*time.Minute, args.Get(2))
+}
+
+func (d *deploymentTestSuite) TestMarkDeploymentInvalid() {
+	// invalid digest
+	res, err := d.PostJSON("/deployment-marks", &models.DeploymentMarkReq{Repository: "library/hello-world", Digest: "latest", Deployment: "prod/web"})
+	d.Require().NoError(err)
+	d.Equal(400, res.StatusCode)
+
+	// no deployment
+	res, err = d.PostJSON("/deployment-marks", &models.DeploymentMarkReq{Repository: "library/hello-world", Digest: deploymentTestDigest})
+	d.Require().NoError(err)
+	d.Equal(400, res.StatusCode)
+
+	// the artifact doesn't exist
+	mock.OnAnything(d.Security, "Can").Return(true)
+	mock.OnAnything(d.artCtl, "GetByReference").Return(nil, errors.NotFoundError(nil))
+	res, err = d.PostJSON("/deployment-marks", &models.DeploymentMarkReq{Repository: "library/hello-world", Digest: deploymentTestDigest, Deployment: "prod/web"})
+	d.Require().NoError(err)
+	d.Equal(404, res.StatusCode)
+	d.deploymentMgr.AssertNotCalled(d.T(), "Mark", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (d *deploymentTestSuite) TestMarkDeploymentForbidden() {
+	mock.OnAnything(d.Security, "Can").Return(false)
+	res, err := d.PostJSON("/deployment-marks", &models.DeploymentMarkReq{Repository: "library/hello-world", Digest: deploymentTestDigest, Deployment: "prod/web"})
+	d.Require().NoError(err)
+	d.Equal(403, res.StatusCode)
+}
+
+func (d *deploymentTestSuite) TestListDeploymentMarks() {
+	mock.OnAnything(d.Security, "Can").Return(true)
+	mock.OnAnything(d.deploymentMgr, "ListActive").Return([]*model.Mark{{ID: 1}}, nil)
+	var marks []*models.DeploymentMark
+	res, err := d.GetJSON("/deployment-marks?repository=library/hello-world&digest="+deploymentTestDigest, &marks)
+	d.Require().NoError(err)
+	d.Equal(200, res.StatusCode)
+	d.Require().Len(marks, 1)
+	d.Equal(int64(1), marks[0].ID)
+
+	// the repository is required
+	res, err = d.Get("/deployment-marks")
+	d.Require().NoError(err)
+	d.Equal(422, res.StatusCode)
+}
+
+func (d *deploymentTestSuite) TestUnmarkDeployment() {
+	mock.OnAnything(d.Security, "Can").Return(true)
+	d.deploymentMgr.On("Unmark", mock.Anything, "library/hello-world", deploymentTestDigest, "prod/web").Return(nil)
+	res, err := d.Delete("/deployment-marks?repository=library/hello-world&digest=" + deploymentTestDigest + "&deployment=prod/web")
+	d.Require().NoError(err)
+	d.Equal(200, res.StatusCode)
+	d.deploymentMgr.AssertExpectations(d.T())
+}
+
+func TestDeploymentTestSuite(t *testing.T) {
+	suite.Run(t, &deploymentTestSuite{})
+}
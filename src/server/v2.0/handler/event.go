@@ -0,0 +1,143 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/rbac/system"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/notification/stream"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/event"
+)
+
+// the interval to send the comment to keep the event stream alive
+var eventStreamKeepAlive = 30 * time.Second
+
+func newEventAPI() *eventAPI {
+	return &eventAPI{
+		projectCtl: project.Ctl,
+		broker:     stream.Default,
+	}
+}
+
+type eventAPI struct {
+	BaseAPI
+	projectCtl project.Controller
+	broker     func() stream.Broker
+}
+
+func (e *eventAPI) StreamEvents(ctx context.Context, params operation.StreamEventsParams) middleware.Responder {
+	if err := e.RequireAuthenticated(ctx); err != nil {
+		return e.SendError(ctx, err)
+	}
+
+	var projectID int64
+	if params.ProjectName != nil {
+		if err := e.RequireProjectAccess(ctx, *params.ProjectName, rbac.ActionList, rbac.ResourceRepository); err != nil {
+			return e.SendError(ctx, err)
+		}
+		p, err := e.projectCtl.GetByName(ctx, *params.ProjectName)
+		if err != nil {
+			return e.SendError(ctx, err)
+		}
+		projectID = p.ProjectID
+	}
+
+	types := map[string]struct{}{}
+	for _, t := range params.EventType {
+		types[t] = struct{}{}
+	}
+
+	// the permissions are cached during the stream to avoid evaluating them for each event
+	allowed := map[int64]bool{}
+	canSee := func(evt *stream.Event) bool {
+		if projectID != 0 && evt.ProjectID != projectID {
+			return false
+		}
+		if len(types) > 0 {
+			if _, ok := types[evt.Payload.Type]; !ok {
+				return false
+			}
+		}
+		can, ok := allowed[evt.ProjectID]
+		if !ok {
+			if evt.ProjectID == 0 {
+				can = e.HasPermission(ctx, rbac.ActionRead, system.NewNamespace().Resource(rbac.ResourceNotificationPolicy))
+			} else {
+				can = e.HasProjectPermission(ctx, evt.ProjectID, rbac.ActionList, rbac.ResourceRepository)
+			}
+			allowed[evt.ProjectID] = can
+		}
+		return can
+	}
+
+	return middleware.ResponderFunc(func(w http.ResponseWriter, _ runtime.Producer) {
+		w.Header().Set("Content-Type", mimeTypeEventStream)
+		w.Header().Set("Cache-Control", "no-cache")
+		// disable the response buffering of the nginx proxy
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		flusher, _ := w.(http.Flusher)
+		flush := func() {
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		flush()
+
+		events := e.broker().Subscribe(ctx)
+		ticker := time.NewTicker(eventStreamKeepAlive)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := w.Write([]byte(": keepalive\n\n")); err != nil {
+					return
+				}
+				flush()
+			case evt, ok := <-events:
+				if !ok {
+					return
+				}
+				if !canSee(evt) {
+					continue
+				}
+				data, err := json.Marshal(evt.Payload)
+				if err != nil {
+					log.Errorf("failed to marshal the payload of the %s event: %v", evt.Payload.Type, err)
+					continue
+				}
+				if _, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", evt.Payload.Type, data); err != nil {
+					log.Debugf("failed to write the %s event to the stream: %v", evt.Payload.Type, err)
+					return
+				}
+				flush()
+			}
+		}
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/pkg/notification/stream"
+	"github.com/goharbor/harbor/src/pkg/notifier/model"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/event"
+	securitytesting "github.com/goharbor/harbor/src/testing/common/security"
+	"github.com/goharbor/harbor/src/testing/mock"
+)
+
+// syncRecorder guards the recorder as the body is read when the stream is being written
+type syncRecorder struct {
+	*httptest.ResponseRecorder
+	lock sync.Mutex
+}
+
+func (s *syncRecorder) Write(data []byte) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.ResponseRecorder.Write(data)
+}
+
+func (s *syncRecorder) body() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.ResponseRecorder.Body.String()
+}
+
+func TestStreamEvents(t *testing.T) {
+	secCtx := &securitytesting.Context{}
+	secCtx.On("IsAuthenticated").Return(true)
+	// the caller can only list the repositories of the project 1 and cannot read the system level policies
+	secCtx.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(func(ctx context.Context, action rbac.Action, resource rbac.Resource) bool {
+		return action == rbac.ActionList && resource.String() == "/project/1/repository"
+	})
+	ctx, cancel := context.WithCancel(security.NewContext(context.Background(), secCtx))
+
+	api := newEventAPI()
+	w := &syncRecorder{ResponseRecorder: httptest.NewRecorder()}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		api.StreamEvents(ctx, operation.StreamEventsParams{EventType: []string{"PUSH_ARTIFACT", "CREATE_USER"}}).WriteResponse(w, nil)
+	}()
+
+	for !stream.Active(ctx) {
+		time.Sleep(time.Millisecond)
+	}
+	stream.Publish(ctx, 1, &model.Payload{Type: "PUSH_ARTIFACT", Operator: "admin"})
+	stream.Publish(ctx, 1, &model.Payload{Type: "PULL_ARTIFACT", Operator: "admin"})
+	stream.Publish(ctx, 2, &model.Payload{Type: "PUSH_ARTIFACT", Operator: "admin"})
+	stream.Publish(ctx, 0, &model.Payload{Type: "CREATE_USER", Operator: "admin"})
+	stream.Publish(ctx, 1, &model.Payload{Type: "PUSH_ARTIFACT", Operator: "user"})
+
+	// wait for the last event which is delivered
+	for !strings.Contains(w.body(), `"operator":"user"`) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, mimeTypeEventStream, w.Header().Get("Content-Type"))
+	assert.Equal(t, "event: PUSH_ARTIFACT\ndata: {\"type\":\"PUSH_ARTIFACT\",\"occur_at\":0,\"operator\":\"admin\"}\n\n"+
+		"event: PUSH_ARTIFACT\ndata: {\"type\":\"PUSH_ARTIFACT\",\"occur_at\":0,\"operator\":\"user\"}\n\n", w.Body.String())
+}
+
+func TestStreamEventsUnauthenticated(t *testing.T) {
+	secCtx := &securitytesting.Context{}
+	secCtx.On("IsAuthenticated").Return(false)
+	ctx := security.NewContext(context.Background(), secCtx)
+
+	w := httptest.NewRecorder()
+	newEventAPI().StreamEvents(ctx, operation.StreamEventsParams{}).WriteResponse(w, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,152 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+)
+
+// fieldSelector contains the top level fields of the resources specified by the "fields" query parameter,
+// all the fields are selected if it's empty
+type fieldSelector map[string]struct{}
+
+// newFieldSelector creates the field selector, the fields are validated against the JSON names of the swagger model
+func newFieldSelector(fields []string, model interface{}) (fieldSelector, error) {
+	names := jsonFieldNames(model)
+	selector := fieldSelector{}
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if len(field) == 0 {
+			continue
+		}
+		if _, exist := names[field]; !exist {
+			return nil, errors.BadRequestError(nil).WithMessage("unknown field %s", field)
+		}
+		selector[field] = struct{}{}
+	}
+	return selector, nil
+}
+
+// Selected returns true if all the fields are selected or any of the specified fields is selected
+func (f fieldSelector) Selected(fields ...string) bool {
+	if len(f) == 0 {
+		return true
+	}
+	for _, field := range fields {
+		if _, exist := f[field]; exist {
+			return true
+		}
+	}
+	return false
+}
+
+// Shape returns the responder which removes the unselected fields from the payload written by the specified responder
+func (f fieldSelector) Shape(responder middleware.Responder) middleware.Responder {
+	if len(f) == 0 {
+		return responder
+	}
+	return middleware.ResponderFunc(func(w http.ResponseWriter, p runtime.Producer) {
+		buffer := lib.NewResponseBuffer(w)
+		responder.WriteResponse(buffer, p)
+		data, err := f.shape(buffer.Buffer())
+		if !buffer.Success() || err != nil {
+			if err != nil {
+				log.Errorf("failed to shape the response: %v", err)
+			}
+			if _, err := buffer.Flush(); err != nil {
+				log.Errorf("failed to write the response: %v", err)
+			}
+			return
+		}
+		for key, values := range buffer.Header() {
+			for _, value := range values {
+				w.Header().Add(key, value)
+			}
+		}
+		w.WriteHeader(buffer.StatusCode())
+		if _, err := w.Write(data); err != nil {
+			log.Errorf("failed to write the response: %v", err)
+		}
+	})
+}
+
+// shape removes the unselected fields from the JSON object or the objects of the JSON array
+func (f fieldSelector) shape(data []byte) ([]byte, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return data, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// keep the precision of the integers
+	decoder.UseNumber()
+	var payload interface{}
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, err
+	}
+	switch v := payload.(type) {
+	case []interface{}:
+		for _, item := range v {
+			f.filter(item)
+		}
+	default:
+		f.filter(v)
+	}
+	// escape no HTML characters as the JSON producer of the API does
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(payload); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (f fieldSelector) filter(item interface{}) {
+	object, ok := item.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for key := range object {
+		if _, exist := f[key]; !exist {
+			delete(object, key)
+		}
+	}
+}
+
+// jsonFieldNames returns the JSON names of the fields of the struct
+func jsonFieldNames(model interface{}) map[string]struct{} {
+	names := map[string]struct{}{}
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if len(name) > 0 && name != "-" {
+			names[name] = struct{}{}
+		}
+	}
+	return names
+}
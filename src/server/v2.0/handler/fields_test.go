@@ -0,0 +1,71 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/repository"
+)
+
+func TestNewFieldSelector(t *testing.T) {
+	// no fields
+	fields, err := newFieldSelector(nil, models.Repository{})
+	require.Nil(t, err)
+	assert.True(t, fields.Selected("artifact_count"))
+
+	// valid fields
+	fields, err = newFieldSelector([]string{"name", " artifact_count", ""}, &models.Repository{})
+	require.Nil(t, err)
+	assert.Len(t, fields, 2)
+	assert.True(t, fields.Selected("artifact_count"))
+	assert.True(t, fields.Selected("pull_count", "name"))
+	assert.False(t, fields.Selected("pull_count"))
+
+	// unknown field
+	_, err = newFieldSelector([]string{"name", "unknown"}, models.Repository{})
+	require.NotNil(t, err)
+	assert.True(t, errors.IsErr(err, errors.BadRequestCode))
+}
+
+func TestFieldSelectorShape(t *testing.T) {
+	repos := []*models.Repository{
+		{ID: 1, Name: "library/hello-world", ArtifactCount: 2, PullCount: 10},
+		{ID: 2, Name: "library/<busybox>", ArtifactCount: 1},
+	}
+	responder := operation.NewListRepositoriesOK().WithXTotalCount(2).WithPayload(repos)
+
+	// all fields
+	fields, err := newFieldSelector(nil, models.Repository{})
+	require.Nil(t, err)
+	assert.Equal(t, responder, fields.Shape(responder))
+
+	// the selected fields only
+	fields, err = newFieldSelector([]string{"id", "name"}, models.Repository{})
+	require.Nil(t, err)
+	rec := httptest.NewRecorder()
+	fields.Shape(responder).WriteResponse(rec, runtime.JSONProducer())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("X-Total-Count"))
+	assert.JSONEq(t, `[{"id":1,"name":"library/hello-world"},{"id":2,"name":"library/<busybox>"}]`, rec.Body.String())
+}
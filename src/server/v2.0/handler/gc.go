@@ -11,6 +11,7 @@ import (
 
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/controller/gc"
+	taskCtl "github.com/goharbor/harbor/src/controller/task"
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/q"
@@ -22,12 +23,14 @@ import (
 
 type gcAPI struct {
 	BaseAPI
-	gcCtr gc.Controller
+	gcCtr   gc.Controller
+	taskCtl taskCtl.Controller
 }
 
 func newGCAPI() *gcAPI {
 	return &gcAPI{
-		gcCtr: gc.NewController(),
+		gcCtr:   gc.NewController(),
+		taskCtl: taskCtl.Ctl,
 	}
 }
 
@@ -155,22 +158,11 @@ func (g *gcAPI) GetGCHistory(ctx context.Context, params operation.GetGCHistoryP
 
 	var hs []*model.GCHistory
 	for _, exec := range execs {
-		extraAttrsString, err := json.Marshal(exec.ExtraAttrs)
+		h, err := toGCHistory(exec)
 		if err != nil {
 			return g.SendError(ctx, err)
 		}
-		hs = append(hs, &model.GCHistory{
-			ID:         exec.ID,
-			Name:       gc.GCVendorType,
-			Kind:       exec.Trigger,
-			Parameters: string(extraAttrsString),
-			Schedule: &model.ScheduleParam{
-				Type: exec.Trigger,
-			},
-			Status:       exec.Status,
-			CreationTime: exec.StartTime,
-			UpdateTime:   exec.UpdateTime,
-		})
+		hs = append(hs, h)
 	}
 
 	var results []*models.GCHistory
@@ -193,12 +185,40 @@ func (g *gcAPI) GetGC(ctx context.Context, params operation.GetGCParams) middlew
 		return g.SendError(ctx, err)
 	}
 
-	extraAttrsString, err := json.Marshal(exec.ExtraAttrs)
+	res, err := toGCHistory(exec)
 	if err != nil {
 		return g.SendError(ctx, err)
 	}
 
-	res := &model.GCHistory{
+	return operation.NewGetGCOK().WithPayload(res.ToSwagger())
+}
+
+func (g *gcAPI) GetLatestGC(ctx context.Context, params operation.GetLatestGCParams) middleware.Responder {
+	if err := g.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceGarbageCollection); err != nil {
+		return g.SendError(ctx, err)
+	}
+	execs, err := g.gcCtr.ListExecutions(ctx, q.New(q.KeyWords{}).First(q.NewSort("start_time", true)))
+	if err != nil {
+		return g.SendError(ctx, err)
+	}
+	if len(execs) == 0 {
+		return g.SendError(ctx, errors.New(nil).WithCode(errors.NotFoundCode).WithMessage("no garbage collection execution is found"))
+	}
+
+	res, err := toGCHistory(execs[0])
+	if err != nil {
+		return g.SendError(ctx, err)
+	}
+
+	return operation.NewGetLatestGCOK().WithPayload(res.ToSwagger())
+}
+
+func toGCHistory(exec *gc.Execution) (*model.GCHistory, error) {
+	extraAttrsString, err := json.Marshal(exec.ExtraAttrs)
+	if err != nil {
+		return nil, err
+	}
+	return &model.GCHistory{
 		ID:         exec.ID,
 		Name:       gc.GCVendorType,
 		Kind:       exec.Trigger,
@@ -209,9 +229,8 @@ func (g *gcAPI) GetGC(ctx context.Context, params operation.GetGCParams) middlew
 		},
 		CreationTime: exec.StartTime,
 		UpdateTime:   exec.UpdateTime,
-	}
-
-	return operation.NewGetGCOK().WithPayload(res.ToSwagger())
+		Statistics:   exec.Statistics,
+	}, nil
 }
 
 func (g *gcAPI) GetGCLog(ctx context.Context, params operation.GetGCLogParams) middleware.Responder {
@@ -227,6 +246,9 @@ func (g *gcAPI) GetGCLog(ctx context.Context, params operation.GetGCLogParams) m
 	if len(tasks) == 0 {
 		return g.SendError(ctx, errors.New(nil).WithCode(errors.NotFoundCode).WithMessage("garbage collection %d log is not found", params.GCID))
 	}
+	if params.Follow != nil && *params.Follow {
+		return followTaskLog(ctx, params.HTTPRequest, g.taskCtl, tasks[0].ID)
+	}
 	log, err := g.gcCtr.GetTaskLog(ctx, tasks[0].ID)
 	if err != nil {
 		return g.SendError(ctx, err)
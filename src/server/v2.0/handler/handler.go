@@ -24,6 +24,9 @@ import (
 	lib_http "github.com/goharbor/harbor/src/lib/http"
 	"github.com/goharbor/harbor/src/server/middleware"
 	"github.com/goharbor/harbor/src/server/middleware/blob"
+	"github.com/goharbor/harbor/src/server/middleware/conditional"
+	"github.com/goharbor/harbor/src/server/middleware/deprecation"
+	"github.com/goharbor/harbor/src/server/middleware/idempotency"
 	"github.com/goharbor/harbor/src/server/middleware/metric"
 	"github.com/goharbor/harbor/src/server/middleware/quota"
 	"github.com/goharbor/harbor/src/server/v2.0/restapi"
@@ -31,10 +34,15 @@ import (
 
 // New returns http handler for API V2.0
 func New() http.Handler {
+	applyAPI := newApplyAPI()
+	artifactAPI := newArtifactAPI()
 	h, api, err := restapi.HandlerAPI(restapi.Config{
-		ArtifactAPI:           newArtifactAPI(),
+		ArtifactAPI:           artifactAPI,
 		RepositoryAPI:         newRepositoryAPI(),
 		AuditlogAPI:           newAuditLogAPI(),
+		BackupAPI:             newBackupAPI(),
+		CatalogAPI:            newCatalogAPI(),
+		ConfigSyncAPI:         newConfigSyncAPI(),
 		ScannerAPI:            newScannerAPI(),
 		ScanAPI:               newScanAPI(),
 		ScanAllAPI:            newScanAllAPI(),
@@ -44,6 +52,7 @@ func New() http.Handler {
 		PreheatAPI:            newPreheatAPI(),
 		IconAPI:               newIconAPI(),
 		RobotAPI:              newRobotAPI(),
+		RoleAPI:               newRoleAPI(),
 		Robotv1API:            newRobotV1API(),
 		ReplicationAPI:        newReplicationAPI(),
 		RegistryAPI:           newRegistryAPI(),
@@ -63,21 +72,68 @@ func New() http.Handler {
 		UsergroupAPI:          newUserGroupAPI(),
 		UserAPI:               newUsersAPI(),
 		HealthAPI:             newHealthAPI(),
+		LoglevelAPI:           newLogLevelAPI(),
 		StatisticAPI:          newStatisticAPI(),
 		ProjectMetadataAPI:    newProjectMetadaAPI(),
 		PurgeAPI:              newPurgeAPI(),
+		PurgeUploadAPI:        newPurgeUploadAPI(),
 		ScanDataExportAPI:     newScanDataExportAPI(),
 		JobserviceAPI:         newJobServiceAPI(),
 		ScheduleAPI:           newScheduleAPI(),
+		PullTokenAPI:          newPullTokenAPI(),
+		PromotionAPI:          newPromotionAPI(),
+		ApplyAPI:              applyAPI,
+		EventAPI:              newEventAPI(),
+		ProfilingAPI:          newProfilingAPI(),
+		DeploymentAPI:         newDeploymentAPI(),
+		StorageAPI:            newStorageAPI(),
+		SecretAPI:             newSecretAPI(),
+		ImageimportAPI:        newImageImportAPI(),
+		ImageexportAPI:        newImageExportAPI(),
+		ImagecheckAPI:         newImageCheckAPI(),
+		InnerMiddleware:       deprecation.Middleware(),
 	})
 	if err != nil {
 		log.Fatal(err)
 	}
+	applyAPI.handler = h
+	artifactAPI.handler = h
 
 	api.RegisterMiddleware("CopyArtifact", middleware.Chain(quota.CopyArtifactMiddleware(), blob.CopyArtifactMiddleware()))
 	api.RegisterMiddleware("DeleteArtifact", quota.RefreshForProjectMiddleware())
 	api.RegisterMiddleware("DeleteRepository", quota.RefreshForProjectMiddleware())
 
+	// conditional requests, the read and update operations of each resource share the same URL
+	for read, update := range map[string]string{
+		"GetProject":                "UpdateProject",
+		"GetLabelByID":              "UpdateLabel",
+		"GetConfigurations":         "UpdateConfigurations",
+		"GetReplicationPolicy":      "UpdateReplicationPolicy",
+		"GetRetention":              "UpdateRetention",
+		"GetPolicy":                 "UpdatePolicy",
+		"GetWebhookPolicyOfProject": "UpdateWebhookPolicyOfProject",
+		"GetSystemWebhookPolicy":    "UpdateSystemWebhookPolicy",
+	} {
+		api.RegisterMiddleware(read, conditional.ReadMiddleware())
+		api.RegisterMiddleware(update, conditional.UpdateMiddleware(h))
+	}
+
+	// idempotency keys, the retried creations replay the original responses
+	for _, create := range []string{
+		"CreateProject",
+		"CreateLabel",
+		"CreateReplicationPolicy",
+		"CreateRetention",
+		"CreatePolicy",
+		"CreateImmuRule",
+		"CreateWebhookPolicyOfProject",
+		"CreateSystemWebhookPolicy",
+		"CreateRobot",
+		"CreateRobotV1",
+	} {
+		api.RegisterMiddleware(create, idempotency.Middleware())
+	}
+
 	api.BeforePrepare = beforePrepare
 	api.ServeError = serveError
 
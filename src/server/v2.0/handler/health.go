@@ -19,6 +19,7 @@ import (
 
 	"github.com/go-openapi/runtime/middleware"
 
+	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/controller/health"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 	operations "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/health"
@@ -49,3 +50,61 @@ func (r *healthAPI) GetHealth(ctx context.Context, params operations.GetHealthPa
 	}
 	return operations.NewGetHealthOK().WithPayload(s)
 }
+
+// GetLiveness reports the process of core is up, it doesn't check any dependency
+// so that core isn't restarted by the liveness probe when a dependency is down
+func (r *healthAPI) GetLiveness(ctx context.Context, params operations.GetLivenessParams) middleware.Responder {
+	return operations.NewGetLivenessOK().WithPayload(&models.Liveness{Status: "healthy"})
+}
+
+// GetReadiness can be accessed anonymously by the load balancers and kubelet,
+// so the errors of the probes are omitted, get them from the health detail
+func (r *healthAPI) GetReadiness(ctx context.Context, params operations.GetReadinessParams) middleware.Responder {
+	readiness := r.ctl.GetReadiness(ctx)
+	s := &models.Readiness{
+		Status:       readiness.Status,
+		Dependencies: []*models.ProbeResult{},
+	}
+	for _, d := range readiness.Dependencies {
+		s.Dependencies = append(s.Dependencies, &models.ProbeResult{
+			Latency: d.Latency,
+			Name:    d.Name,
+			Status:  d.Status,
+		})
+	}
+	if readiness.Status != "healthy" {
+		return operations.NewGetReadinessServiceUnavailable().WithPayload(s)
+	}
+	return operations.NewGetReadinessOK().WithPayload(s)
+}
+
+// GetHealthDetail is only for the system admin as the validation results contain the internal endpoints
+func (r *healthAPI) GetHealthDetail(ctx context.Context, params operations.GetHealthDetailParams) middleware.Responder {
+	if err := r.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceSystemHealth); err != nil {
+		return r.SendError(ctx, err)
+	}
+	detail := r.ctl.GetHealthDetail(ctx)
+	d := &models.HealthDetail{
+		Status:      detail.Status,
+		Components:  []*models.ComponentHealthStatus{},
+		Validations: []*models.ValidationResult{},
+	}
+	for _, c := range detail.Components {
+		d.Components = append(d.Components, &models.ComponentHealthStatus{
+			Error:  c.Error,
+			Name:   c.Name,
+			Status: c.Status,
+		})
+	}
+	for _, v := range detail.Validations {
+		d.Validations = append(d.Validations, &models.ValidationResult{
+			Duration:   v.Duration,
+			Error:      v.Error,
+			Fatal:      v.Fatal,
+			Name:       v.Name,
+			Status:     v.Status,
+			Suggestion: v.Suggestion,
+		})
+	}
+	return operations.NewGetHealthDetailOK().WithPayload(d)
+}
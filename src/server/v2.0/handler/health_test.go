@@ -0,0 +1,120 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/health"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	"github.com/goharbor/harbor/src/testing/mock"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+)
+
+type fakeHealthController struct {
+	health.Controller
+	detail    *health.HealthDetail
+	readiness *health.Readiness
+}
+
+func (f *fakeHealthController) GetReadiness(ctx context.Context) *health.Readiness {
+	return f.readiness
+}
+
+func (f *fakeHealthController) GetHealthDetail(ctx context.Context) *health.HealthDetail {
+	return f.detail
+}
+
+type healthTestSuite struct {
+	htesting.Suite
+	ctl *fakeHealthController
+}
+
+func (h *healthTestSuite) SetupSuite() {
+	h.ctl = &fakeHealthController{}
+	h.Config = &restapi.Config{HealthAPI: &healthAPI{ctl: h.ctl}}
+	h.Suite.SetupSuite()
+}
+
+func (h *healthTestSuite) TestGetLiveness() {
+	liveness := &models.Liveness{}
+	res, err := h.GetJSON("/health/live", liveness)
+	h.Require().NoError(err)
+	h.Equal(200, res.StatusCode)
+	h.Equal("healthy", liveness.Status)
+}
+
+func (h *healthTestSuite) TestGetReadiness() {
+	h.ctl.readiness = &health.Readiness{
+		Status: "healthy",
+		Dependencies: []*health.ProbeResult{
+			{Name: "database", Status: "healthy", Latency: "1ms"},
+		},
+	}
+	readiness := &models.Readiness{}
+	res, err := h.GetJSON("/health/ready", readiness)
+	h.Require().NoError(err)
+	h.Equal(200, res.StatusCode)
+	h.Require().Len(readiness.Dependencies, 1)
+	h.Equal("1ms", readiness.Dependencies[0].Latency)
+
+	h.ctl.readiness.Status = "unhealthy"
+	res, err = h.Get("/health/ready")
+	h.Require().NoError(err)
+	h.Equal(503, res.StatusCode)
+}
+
+func (h *healthTestSuite) TestGetHealthDetail() {
+	h.ctl.detail = &health.HealthDetail{
+		Status:     "unhealthy",
+		Components: []*health.ComponentHealthStatus{{Name: "core", Status: "healthy"}},
+		Validations: []*health.ValidationResult{
+			{Name: "storage", Status: "unhealthy", Fatal: true, Duration: "1ms", Error: "timeout", Suggestion: "check the storage"},
+		},
+	}
+	h.Security.On("IsAuthenticated").Return(true).Once()
+	h.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true).Once()
+	detail := &models.HealthDetail{}
+	res, err := h.GetJSON("/health/detail", detail)
+	h.Require().NoError(err)
+	h.Equal(200, res.StatusCode)
+	h.Equal("unhealthy", detail.Status)
+	h.Require().Len(detail.Components, 1)
+	h.Require().Len(detail.Validations, 1)
+	h.True(detail.Validations[0].Fatal)
+	h.Equal("check the storage", detail.Validations[0].Suggestion)
+}
+
+func (h *healthTestSuite) TestGetHealthDetailForbidden() {
+	h.Security.On("IsAuthenticated").Return(false).Once()
+	res, err := h.Get("/health/detail")
+	h.Require().NoError(err)
+	h.Equal(401, res.StatusCode)
+
+	h.Security.On("IsAuthenticated").Return(true).Once()
+	h.Security.On("GetUsername").Return("user").Once()
+	h.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(false).Once()
+	res, err = h.Get("/health/detail")
+	h.Require().NoError(err)
+	h.Equal(403, res.StatusCode)
+}
+
+func TestHealthTestSuite(t *testing.T) {
+	suite.Run(t, &healthTestSuite{})
+}
@@ -0,0 +1,95 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/goharbor/harbor/src/controller/imagecheck"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/imagecheck"
+)
+
+// the max count of the images checked in one request
+const maxImageCheckCount = 100
+
+func newImageCheckAPI() *imageCheckAPI {
+	return &imageCheckAPI{
+		ctl: imagecheck.Ctl,
+	}
+}
+
+// imageCheckAPI returns the verdicts of the images for the validating admission webhooks of Kubernetes,
+// so the admission decisions are driven by the data of Harbor with one call
+type imageCheckAPI struct {
+	BaseAPI
+	ctl imagecheck.Controller
+}
+
+func (i *imageCheckAPI) CheckImages(ctx context.Context, params operation.CheckImagesParams) middleware.Responder {
+	if err := i.RequireAuthenticated(ctx); err != nil {
+		return i.SendError(ctx, err)
+	}
+	images := params.Check.Images
+	if len(params.Check.Image) > 0 {
+		images = append([]string{params.Check.Image}, images...)
+	}
+	if len(images) == 0 {
+		return i.SendError(ctx, errors.BadRequestError(nil).WithMessage("the image is required"))
+	}
+	if len(images) > maxImageCheckCount {
+		return i.SendError(ctx, errors.BadRequestError(nil).WithMessage("at most %d images can be checked in one request", maxImageCheckCount))
+	}
+
+	result := &models.ImageCheckResult{Allowed: true}
+	for _, image := range images {
+		verdict, err := i.ctl.Check(ctx, image)
+		if err != nil {
+			return i.SendError(ctx, err)
+		}
+		result.Allowed = result.Allowed && verdict.Allowed
+		result.Images = append(result.Images, toImageCheckVerdictSwagger(verdict))
+	}
+	return operation.NewCheckImagesOK().WithPayload(result)
+}
+
+func toImageCheckVerdictSwagger(verdict *imagecheck.Verdict) *models.ImageCheckVerdict {
+	result := &models.ImageCheckVerdict{
+		Image:            verdict.Image,
+		Exists:           verdict.Exists,
+		Project:          verdict.Project,
+		Repository:       verdict.Repository,
+		Digest:           verdict.Digest,
+		Tags:             verdict.Tags,
+		Signed:           verdict.Signed,
+		Signatures:       verdict.Signatures,
+		Labels:           verdict.Labels,
+		Quarantined:      verdict.Quarantined,
+		QuarantineReason: verdict.QuarantineReason,
+		Allowed:          verdict.Allowed,
+		Violations:       verdict.Violations,
+	}
+	if verdict.Scan != nil {
+		result.Scan = &models.ImageCheckScan{
+			Status:          verdict.Scan.Status,
+			Severity:        verdict.Scan.Severity,
+			Vulnerabilities: int64(verdict.Scan.Vulnerabilities),
+		}
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/imagecheck"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	imagechecktesting "github.com/goharbor/harbor/src/testing/controller/imagecheck"
+	"github.com/goharbor/harbor/src/testing/mock"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+)
+
+type imageCheckTestSuite struct {
+	htesting.Suite
+	ctl *imagechecktesting.Controller
+}
+
+func (i *imageCheckTestSuite) SetupSuite() {
+	i.ctl = &imagechecktesting.Controller{}
+	i.Config = &restapi.Config{
+		ImagecheckAPI: &imageCheckAPI{ctl: i.ctl},
+	}
+	i.Suite.SetupSuite()
+}
+
+func (i *imageCheckTestSuite) SetupTest() {
+	i.Security.ExpectedCalls = nil
+	i.ctl.ExpectedCalls = nil
+}
+
+func (i *imageCheckTestSuite) TestUnauthenticated() {
+	i.Security.On("IsAuthenticated").Return(false)
+	res, err := i.PostJSON("/internal/imagecheck", &models.ImageCheckReq{Image: "library/hello-world"})
+	i.Require().NoError(err)
+	i.Equal(401, res.StatusCode)
+}
+
+func (i *imageCheckTestSuite) TestInvalidRequest() {
+	i.Security.On("IsAuthenticated").Return(true)
+	res, err := i.PostJSON("/internal/imagecheck", &models.ImageCheckReq{})
+	i.Require().NoError(err)
+	i.Equal(400, res.StatusCode)
+
+	images := strings.Split(strings.Repeat("a,", maxImageCheckCount)+"a", ",")
+	res, err = i.PostJSON("/internal/imagecheck", &models.ImageCheckReq{Images: images})
+	i.Require().NoError(err)
+	i.Equal(400, res.StatusCode)
+}
+
+func (i *imageCheckTestSuite) TestCheckImages() {
+	i.Security.On("IsAuthenticated").Return(true)
+	i.ctl.On("Check", mock.Anything, "library/hello-world").Return(&imagecheck.Verdict{
+		Image:   "library/hello-world",
+		Exists:  true,
+		Allowed: true,
+		Scan:    &imagecheck.Scan{Status: "Success", Vulnerabilities: 2},
+	}, nil)
+	i.ctl.On("Check", mock.Anything, "library/busybox").Return(&imagecheck.Verdict{Image: "library/busybox", Violations: []string{"the image doesn't exist"}}, nil)
+
+	result := &models.ImageCheckResult{}
+	res, err := i.PostJSON("/internal/imagecheck", &models.ImageCheckReq{Image: "library/hello-world", Images: []string{"library/busybox"}})
+	i.Require().NoError(err)
+	i.Require().Equal(200, res.StatusCode)
+	i.Require().NoError(json.NewDecoder(res.Body).Decode(result))
+	i.False(result.Allowed)
+	i.Require().Len(result.Images, 2)
+	i.Equal("library/hello-world", result.Images[0].Image)
+	i.True(result.Images[0].Allowed)
+	i.Equal(int64(2), result.Images[0].Scan.Vulnerabilities)
+	i.False(result.Images[1].Exists)
+}
+
+func TestImageCheckTestSuite(t *testing.T) {
+	suite.Run(t, &imageCheckTestSuite{})
+}
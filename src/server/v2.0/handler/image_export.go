@@ -0,0 +1,236 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/imageexport"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/config"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/imageexport"
+)
+
+func newImageExportAPI() *imageExportAPI {
+	return &imageExportAPI{
+		projectCtl:  project.Ctl,
+		exportCtl:   imageexport.Ctl,
+		extEndpoint: config.ExtEndpoint,
+	}
+}
+
+// imageExportAPI exports the selected tags of the repositories into the OCI layout archive by a job, the archive
+// is downloaded from the time-limited signed URL without the credentials of Harbor, so that it can be delivered
+// to the customers without the registry access
+type imageExportAPI struct {
+	BaseAPI
+	projectCtl  project.Controller
+	exportCtl   imageexport.Controller
+	extEndpoint func() (string, error)
+}
+
+func (i *imageExportAPI) ListImageExports(ctx context.Context, params operation.ListImageExportsParams) middleware.Responder {
+	projectID, err := i.requireProjectAccess(ctx, params.ProjectNameOrID, params.XIsResourceName, rbac.ActionPull)
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	page, pageSize := lib.Int64Value(params.Page), lib.Int64Value(params.PageSize)
+	total, err := i.exportCtl.Count(ctx, projectID)
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	exps, err := i.exportCtl.List(ctx, projectID, &q.Query{PageNumber: page, PageSize: pageSize})
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	payload := make([]*models.ImageExport, 0, len(exps))
+	for _, exp := range exps {
+		e, err := i.toImageExportSwagger(exp)
+		if err != nil {
+			return i.SendError(ctx, err)
+		}
+		payload = append(payload, e)
+	}
+	return operation.NewListImageExportsOK().
+		WithXTotalCount(total).
+		WithLink(i.Links(ctx, params.HTTPRequest.URL, total, page, pageSize).String()).
+		WithPayload(payload)
+}
+
+func (i *imageExportAPI) CreateImageExport(ctx context.Context, params operation.CreateImageExportParams) middleware.Responder {
+	projectID, err := i.requireProjectAccess(ctx, params.ProjectNameOrID, params.XIsResourceName, rbac.ActionPull)
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	var selections []*imageexport.Selection
+	for _, repo := range params.Export.Repositories {
+		selections = append(selections, &imageexport.Selection{
+			Repository: repo.Repository,
+			Tags:       repo.Tags,
+		})
+	}
+	ttl := imageexport.DefaultTTL
+	if params.Export.TTL != 0 {
+		ttl = time.Duration(params.Export.TTL) * time.Second
+	}
+	id, err := i.exportCtl.Create(ctx, projectID, selections, ttl)
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	exp, err := i.exportCtl.Get(ctx, id)
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	payload, err := i.toImageExportSwagger(exp)
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, "/"), id)
+	return operation.NewCreateImageExportCreated().WithLocation(location).WithPayload(payload)
+}
+
+func (i *imageExportAPI) GetImageExport(ctx context.Context, params operation.GetImageExportParams) middleware.Responder {
+	exp, err := i.getExport(ctx, params.ProjectNameOrID, params.XIsResourceName, params.ExportID, rbac.ActionPull)
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	payload, err := i.toImageExportSwagger(exp)
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	return operation.NewGetImageExportOK().WithPayload(payload)
+}
+
+// RevokeImageExport requires the push permission as the export may be created by others
+func (i *imageExportAPI) RevokeImageExport(ctx context.Context, params operation.RevokeImageExportParams) middleware.Responder {
+	if _, err := i.getExport(ctx, params.ProjectNameOrID, params.XIsResourceName, params.ExportID, rbac.ActionPush); err != nil {
+		return i.SendError(ctx, err)
+	}
+	if err := i.exportCtl.Revoke(ctx, params.ExportID); err != nil {
+		return i.SendError(ctx, err)
+	}
+	return operation.NewRevokeImageExportOK()
+}
+
+// DownloadImageExport sends the archive of the export after the signature of the URL is verified,
+// the download is authorized by the signature rather than the principal
+func (i *imageExportAPI) DownloadImageExport(ctx context.Context, params operation.DownloadImageExportParams) middleware.Responder {
+	exp, reader, err := i.exportCtl.Download(ctx, params.ExportID, params.Expires, params.Signature)
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	return middleware.ResponderFunc(func(w http.ResponseWriter, _ runtime.Producer) {
+		defer reader.Close()
+		w.Header().Set("Content-Type", "application/x-tar")
+		w.Header().Set("Content-Length", strconv.FormatInt(exp.Size, 10))
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s-export-%d.tar", exp.ProjectName, exp.ID)))
+		if _, err := io.Copy(w, reader); err != nil {
+			log.Errorf("failed to send the archive of the image export %d: %v", exp.ID, err)
+		}
+	})
+}
+
+// requireProjectAccess checks the principal can perform the action on the repositories of the project and returns the ID of the project
+func (i *imageExportAPI) requireProjectAccess(ctx context.Context, projectNameOrID string, isResourceName *bool, action rbac.Action) (int64, error) {
+	nameOrID := parseProjectNameOrID(projectNameOrID, isResourceName)
+	if err := i.RequireProjectAccess(ctx, nameOrID, action, rbac.ResourceRepository); err != nil {
+		return 0, err
+	}
+	p, err := i.projectCtl.Get(ctx, nameOrID)
+	if err != nil {
+		return 0, err
+	}
+	return p.ProjectID, nil
+}
+
+// getExport gets the export and makes sure it belongs to the project
+func (i *imageExportAPI) getExport(ctx context.Context, projectNameOrID string, isResourceName *bool, id int64, action rbac.Action) (*imageexport.Export, error) {
+	projectID, err := i.requireProjectAccess(ctx, projectNameOrID, isResourceName, action)
+	if err != nil {
+		return nil, err
+	}
+	exp, err := i.exportCtl.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if exp.ProjectID != projectID {
+		return nil, errors.NotFoundError(nil).WithMessage("image export %d not found", id)
+	}
+	return exp, nil
+}
+
+// toImageExportSwagger converts the export, the signed download URL is populated when the archive can be downloaded
+func (i *imageExportAPI) toImageExportSwagger(exp *imageexport.Export) (*models.ImageExport, error) {
+	result := &models.ImageExport{
+		ID:            exp.ID,
+		ProjectID:     exp.ProjectID,
+		ProjectName:   exp.ProjectName,
+		Status:        exp.Status,
+		StatusMessage: exp.StatusMessage,
+		Total:         int64(exp.Total),
+		Exported:      int64(exp.Exported),
+		Size:          exp.Size,
+		Digest:        exp.Digest,
+		Revoked:       exp.Revoked,
+		Operator:      exp.Operator,
+		StartTime:     strfmt.DateTime(exp.StartTime),
+		EndTime:       strfmt.DateTime(exp.EndTime),
+	}
+	for _, art := range exp.Artifacts {
+		result.Artifacts = append(result.Artifacts, &models.ImageExportArtifact{
+			Repository: art.Repository,
+			Tag:        art.Tag,
+		})
+	}
+	if exp.ExpiresAt != nil {
+		expiresAt := strfmt.DateTime(*exp.ExpiresAt)
+		result.ExpiresAt = &expiresAt
+	}
+	if !exp.Downloadable() {
+		return result, nil
+	}
+	expiresAt := exp.ExpiresAt.Unix()
+	signature, err := i.exportCtl.Sign(exp.ID, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := i.extEndpoint()
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt, 10))
+	query.Set("signature", signature)
+	result.DownloadURL = fmt.Sprintf("%s/api/v2.0/projects/%s/export/%d/download?%s",
+		strings.TrimSuffix(endpoint, "/"), exp.ProjectName, exp.ID, query.Encode())
+	return result, nil
+}
@@ -0,0 +1,160 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/imageexport"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/errors"
+	projectmodels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	imageexporttesting "github.com/goharbor/harbor/src/testing/controller/imageexport"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	"github.com/goharbor/harbor/src/testing/mock"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+)
+
+type imageExportTestSuite struct {
+	htesting.Suite
+	projectCtl *projecttesting.Controller
+	exportCtl  *imageexporttesting.Controller
+}
+
+func (i *imageExportTestSuite) SetupSuite() {
+	i.projectCtl = &projecttesting.Controller{}
+	i.exportCtl = &imageexporttesting.Controller{}
+	i.Config = &restapi.Config{
+		ImageexportAPI: &imageExportAPI{
+			projectCtl:  i.projectCtl,
+			exportCtl:   i.exportCtl,
+			extEndpoint: func() (string, error) { return "https://harbor.example.com", nil },
+		},
+	}
+	i.Suite.SetupSuite()
+}
+
+func (i *imageExportTestSuite) SetupTest() {
+	i.Security.ExpectedCalls = nil
+	i.Security.Calls = nil
+	i.exportCtl.ExpectedCalls = nil
+	i.exportCtl.Calls = nil
+	i.Security.On("IsAuthenticated").Return(true)
+	i.Security.On("GetUsername").Return("user")
+	mock.OnAnything(i.projectCtl, "Get").Return(&projectmodels.Project{ProjectID: 1, Name: "library"}, nil)
+}
+
+func (i *imageExportTestSuite) TestCreateImageExport() {
+	mock.OnAnything(i.Security, "Can").Return(true)
+	i.exportCtl.On("Create", mock.Anything, int64(1), []*imageexport.Selection{{Repository: "hello-world", Tags: []string{"latest"}}}, imageexport.DefaultTTL).Return(int64(2), nil)
+	i.exportCtl.On("Get", mock.Anything, int64(2)).Return(&imageexport.Export{ID: 2, ProjectID: 1, ProjectName: "library", Status: job.RunningStatus.String()}, nil)
+
+	req := &models.ImageExportReq{
+		Repositories: []*models.ImageExportSelection{{Repository: "hello-world", Tags: []string{"latest"}}},
+	}
+	res, err := i.PostJSON("/projects/1/export", req)
+	i.Require().NoError(err)
+	i.Equal(201, res.StatusCode)
+	i.Equal("/api/v2.0/projects/1/export/2", res.Header.Get("Location"))
+
+	res, err = i.Post("/projects/1/export", strings.NewReader("{"), map[string]string{"Content-Type": "application/json"})
+	i.Require().NoError(err)
+	i.Equal(422, res.StatusCode)
+}
+
+func (i *imageExportTestSuite) TestCreateImageExportPolicyViolation() {
+	mock.OnAnything(i.Security, "Can").Return(true)
+	i.exportCtl.On("Create", mock.Anything, int64(1), mock.Anything, imageexport.DefaultTTL).Return(int64(0),
+		errors.New(nil).WithCode(errors.PROJECTPOLICYVIOLATION).WithMessage("library/hello-world:latest cannot be exported"))
+
+	req := &models.ImageExportReq{
+		Repositories: []*models.ImageExportSelection{{Repository: "hello-world", Tags: []string{"latest"}}},
+	}
+	res, err := i.PostJSON("/projects/1/export", req)
+	i.Require().NoError(err)
+	i.Equal(412, res.StatusCode)
+}
+
+func (i *imageExportTestSuite) TestGetImageExport() {
+	mock.OnAnything(i.Security, "Can").Return(true)
+	expiresAt := time.Unix(time.Now().Add(time.Hour).Unix(), 0)
+	i.exportCtl.On("Get", mock.Anything, int64(2)).Return(&imageexport.Export{
+		ID:          2,
+		ProjectID:   1,
+		ProjectName: "library",
+		Status:      job.SuccessStatus.String(),
+		Digest:      "sha256:abc",
+		ExpiresAt:   &expiresAt,
+	}, nil)
+	i.exportCtl.On("Sign", int64(2), expiresAt.Unix()).Return("signature", nil)
+
+	exp := &models.ImageExport{}
+	res, err := i.GetJSON("/projects/1/export/2", exp)
+	i.Require().NoError(err)
+	i.Equal(200, res.StatusCode)
+	i.True(strings.HasPrefix(exp.DownloadURL, "https://harbor.example.com/api/v2.0/projects/library/export/2/download?"))
+	i.Contains(exp.DownloadURL, "signature=signature")
+
+	// the export belongs to another project
+	i.exportCtl.ExpectedCalls = nil
+	i.exportCtl.On("Get", mock.Anything, int64(3)).Return(&imageexport.Export{ID: 3, ProjectID: 2}, nil)
+	res, err = i.Get("/projects/1/export/3")
+	i.Require().NoError(err)
+	i.Equal(404, res.StatusCode)
+}
+
+func (i *imageExportTestSuite) TestRevokeImageExportForbidden() {
+	mock.OnAnything(i.Security, "Can").Return(false)
+	res, err := i.Delete("/projects/1/export/2")
+	i.Require().NoError(err)
+	i.Equal(403, res.StatusCode)
+	i.exportCtl.AssertNotCalled(i.T(), "Revoke", mock.Anything, mock.Anything)
+}
+
+func (i *imageExportTestSuite) TestDownloadImageExport() {
+	i.exportCtl.On("Download", mock.Anything, int64(2), int64(100), "valid").Return(
+		&imageexport.Export{ID: 2, ProjectName: "library", Size: 7}, io.NopCloser(strings.NewReader("archive")), nil)
+	i.exportCtl.On("Download", mock.Anything, int64(2), int64(100), "invalid").Return(nil, nil, errors.ForbiddenError(nil))
+
+	// no permission check for the download
+	res, err := i.Get("/projects/library/export/2/download?expires=100&signature=valid")
+	i.Require().NoError(err)
+	i.Equal(200, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	i.Require().NoError(err)
+	i.Equal("archive", string(body))
+	i.Equal("7", res.Header.Get("Content-Length"))
+	i.Security.AssertNotCalled(i.T(), "Can", mock.Anything, mock.Anything, mock.Anything)
+
+	res, err = i.Get("/projects/library/export/2/download?expires=100&signature=invalid")
+	i.Require().NoError(err)
+	i.Equal(403, res.StatusCode)
+
+	// the signature is required
+	res, err = i.Get("/projects/library/export/2/download?expires=100")
+	i.Require().NoError(err)
+	i.Equal(422, res.StatusCode)
+}
+
+func TestImageExportTestSuite(t *testing.T) {
+	suite.Run(t, &imageExportTestSuite{})
+}
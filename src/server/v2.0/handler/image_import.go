@@ -0,0 +1,215 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/imageimport"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/imageimport"
+)
+
+func newImageImportAPI() *imageImportAPI {
+	return &imageImportAPI{
+		projectCtl: project.Ctl,
+		importCtl:  imageimport.Ctl,
+	}
+}
+
+// imageImportAPI imports the images from the archive created by "docker save" or the OCI image layout in the
+// tar format, the archive is uploaded in chunks or in one go and then unpacked and pushed into the project by a job
+type imageImportAPI struct {
+	BaseAPI
+	projectCtl project.Controller
+	importCtl  imageimport.Controller
+}
+
+func (i *imageImportAPI) ListImageImports(ctx context.Context, params operation.ListImageImportsParams) middleware.Responder {
+	projectID, err := i.requireProjectAccess(ctx, params.ProjectNameOrID, params.XIsResourceName, rbac.ActionPull)
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	page, pageSize := lib.Int64Value(params.Page), lib.Int64Value(params.PageSize)
+	total, err := i.importCtl.Count(ctx, projectID)
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	imps, err := i.importCtl.List(ctx, projectID, &q.Query{PageNumber: page, PageSize: pageSize})
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	payload := make([]*models.ImageImport, 0, len(imps))
+	for _, imp := range imps {
+		payload = append(payload, toImageImportSwagger(imp))
+	}
+	return operation.NewListImageImportsOK().
+		WithXTotalCount(total).
+		WithLink(i.Links(ctx, params.HTTPRequest.URL, total, page, pageSize).String()).
+		WithPayload(payload)
+}
+
+// CreateImageImport creates the import, the archive is uploaded in one go if the body of the request carries the whole archive
+func (i *imageImportAPI) CreateImageImport(ctx context.Context, params operation.CreateImageImportParams) middleware.Responder {
+	projectID, err := i.requireProjectAccess(ctx, params.ProjectNameOrID, params.XIsResourceName, rbac.ActionPush)
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	id, err := i.importCtl.Create(ctx, projectID, params.Size, params.Digest, lib.StringValue(params.Repository))
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, "/"), id)
+	if params.Archive == nil || params.HTTPRequest.ContentLength <= 0 {
+		imp, err := i.importCtl.Get(ctx, id)
+		if err != nil {
+			return i.SendError(ctx, err)
+		}
+		return operation.NewCreateImageImportCreated().WithLocation(location).WithPayload(toImageImportSwagger(imp))
+	}
+
+	var imp *imageimport.Import
+	if params.HTTPRequest.ContentLength != params.Size {
+		err = errors.BadRequestError(nil).WithMessage("the body must carry the whole archive of %d bytes, upload the chunks by PATCH instead", params.Size)
+	} else {
+		imp, err = i.importCtl.Upload(ctx, id, params.Archive, 0, params.Size-1)
+	}
+	if err != nil {
+		// cancel the import rather than leaving it uploading as its ID isn't returned
+		if e := i.importCtl.Cancel(ctx, id); e != nil {
+			log.Errorf("failed to cancel the image import %d: %v", id, e)
+		}
+		return i.SendError(ctx, err)
+	}
+	return operation.NewCreateImageImportCreated().WithLocation(location).WithPayload(toImageImportSwagger(imp))
+}
+
+func (i *imageImportAPI) GetImageImport(ctx context.Context, params operation.GetImageImportParams) middleware.Responder {
+	imp, err := i.getImport(ctx, params.ProjectNameOrID, params.XIsResourceName, params.ImportID, rbac.ActionPull)
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	return operation.NewGetImageImportOK().WithPayload(toImageImportSwagger(imp))
+}
+
+// UploadImageImportChunk uploads the chunk of the archive specified by the "Content-Range" header
+func (i *imageImportAPI) UploadImageImportChunk(ctx context.Context, params operation.UploadImageImportChunkParams) middleware.Responder {
+	if _, err := i.getImport(ctx, params.ProjectNameOrID, params.XIsResourceName, params.ImportID, rbac.ActionPush); err != nil {
+		return i.SendError(ctx, err)
+	}
+	start, end, err := parseChunkRange(params.ContentRange)
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	if length := params.HTTPRequest.ContentLength; length >= 0 && length != end-start+1 {
+		return i.SendError(ctx, errors.BadRequestError(nil).WithMessage("the length of the chunk %d doesn't match the range %d-%d", length, start, end))
+	}
+	imp, err := i.importCtl.Upload(ctx, params.ImportID, params.Chunk, start, end)
+	if err != nil {
+		return i.SendError(ctx, err)
+	}
+	return operation.NewUploadImageImportChunkAccepted().
+		WithRange(fmt.Sprintf("0-%d", imp.Uploaded-1)).
+		WithPayload(toImageImportSwagger(imp))
+}
+
+// CancelImageImport cancels the uploading or running import
+func (i *imageImportAPI) CancelImageImport(ctx context.Context, params operation.CancelImageImportParams) middleware.Responder {
+	if _, err := i.getImport(ctx, params.ProjectNameOrID, params.XIsResourceName, params.ImportID, rbac.ActionPush); err != nil {
+		return i.SendError(ctx, err)
+	}
+	if err := i.importCtl.Cancel(ctx, params.ImportID); err != nil {
+		return i.SendError(ctx, err)
+	}
+	return operation.NewCancelImageImportOK()
+}
+
+// requireProjectAccess checks the principal can perform the action on the repositories of the project and returns the ID of the project
+func (i *imageImportAPI) requireProjectAccess(ctx context.Context, projectNameOrID string, isResourceName *bool, action rbac.Action) (int64, error) {
+	nameOrID := parseProjectNameOrID(projectNameOrID, isResourceName)
+	if err := i.RequireProjectAccess(ctx, nameOrID, action, rbac.ResourceRepository); err != nil {
+		return 0, err
+	}
+	p, err := i.projectCtl.Get(ctx, nameOrID)
+	if err != nil {
+		return 0, err
+	}
+	return p.ProjectID, nil
+}
+
+// getImport gets the import and makes sure it belongs to the project
+func (i *imageImportAPI) getImport(ctx context.Context, projectNameOrID string, isResourceName *bool, id int64, action rbac.Action) (*imageimport.Import, error) {
+	projectID, err := i.requireProjectAccess(ctx, projectNameOrID, isResourceName, action)
+	if err != nil {
+		return nil, err
+	}
+	imp, err := i.importCtl.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if imp.ProjectID != projectID {
+		return nil, errors.NotFoundError(nil).WithMessage("image import %d not found", id)
+	}
+	return imp, nil
+}
+
+// parseChunkRange parses the range of the chunk in the format "<start>-<end>" as the distribution spec does
+func parseChunkRange(value string) (int64, int64, error) {
+	parts := strings.SplitN(value, "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, errors.BadRequestError(nil).WithMessage("invalid Content-Range: %s", value)
+	}
+	start, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, errors.BadRequestError(nil).WithMessage("invalid Content-Range: %s", value)
+	}
+	end, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || end < start {
+		return 0, 0, errors.BadRequestError(nil).WithMessage("invalid Content-Range: %s", value)
+	}
+	return start, end, nil
+}
+
+func toImageImportSwagger(imp *imageimport.Import) *models.ImageImport {
+	return &models.ImageImport{
+		ID:            imp.ID,
+		ProjectID:     imp.ProjectID,
+		ProjectName:   imp.ProjectName,
+		Repository:    imp.Repository,
+		Status:        imp.Status,
+		StatusMessage: imp.StatusMessage,
+		Size:          imp.Size,
+		Digest:        imp.Digest,
+		Uploaded:      imp.Uploaded,
+		Total:         int64(imp.Total),
+		Imported:      int64(imp.Imported),
+		Failed:        int64(imp.Failed),
+		Operator:      imp.Operator,
+		StartTime:     strfmt.DateTime(imp.StartTime),
+		EndTime:       strfmt.DateTime(imp.EndTime),
+	}
+}
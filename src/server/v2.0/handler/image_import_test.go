@@ -0,0 +1,178 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/imageimport"
+	projectmodels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	imageimporttesting "github.com/goharbor/harbor/src/testing/controller/imageimport"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	"github.com/goharbor/harbor/src/testing/mock"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+)
+
+var octetStream = map[string]string{"Content-Type": "application/octet-stream"}
+
+type imageImportTestSuite struct {
+	htesting.Suite
+	projectCtl *projecttesting.Controller
+	importCtl  *imageimporttesting.Controller
+}
+
+func (i *imageImportTestSuite) SetupSuite() {
+	i.projectCtl = &projecttesting.Controller{}
+	i.importCtl = &imageimporttesting.Controller{}
+	i.Config = &restapi.Config{
+		ImageimportAPI: &imageImportAPI{
+			projectCtl: i.projectCtl,
+			importCtl:  i.importCtl,
+		},
+	}
+	i.Suite.SetupSuite()
+}
+
+func (i *imageImportTestSuite) SetupTest() {
+	i.Security.ExpectedCalls = nil
+	i.importCtl.ExpectedCalls = nil
+	i.importCtl.Calls = nil
+	i.Security.On("IsAuthenticated").Return(true)
+	i.Security.On("GetUsername").Return("user")
+	mock.OnAnything(i.projectCtl, "Get").Return(&projectmodels.Project{ProjectID: 1, Name: "library"}, nil)
+}
+
+func (i *imageImportTestSuite) TestCreateImageImport() {
+	mock.OnAnything(i.Security, "Can").Return(true)
+	i.importCtl.On("Create", mock.Anything, int64(1), int64(10), "sha256:abc", "").Return(int64(2), nil)
+	i.importCtl.On("Get", mock.Anything, int64(2)).Return(&imageimport.Import{ID: 2, ProjectID: 1, Size: 10, Status: imageimport.StatusUploading}, nil)
+	i.importCtl.On("Cancel", mock.Anything, int64(2)).Return(nil)
+
+	res, err := i.Post("/projects/1/import?size=10&digest=sha256:abc", nil)
+	i.Require().NoError(err)
+	i.Equal(201, res.StatusCode)
+	i.Equal("/api/v2.0/projects/1/import/2", res.Header.Get("Location"))
+	imp := &models.ImageImport{}
+	i.Require().NoError(json.NewDecoder(res.Body).Decode(imp))
+	i.Equal(imageimport.StatusUploading, imp.Status)
+
+	// the body carries part of the archive
+	res, err = i.Post("/projects/1/import?size=10&digest=sha256:abc", strings.NewReader("01234"), octetStream)
+	i.Require().NoError(err)
+	i.Equal(400, res.StatusCode)
+	i.importCtl.AssertCalled(i.T(), "Cancel", mock.Anything, int64(2))
+
+	// invalid size
+	res, err = i.Post("/projects/1/import?size=abc&digest=sha256:abc", nil)
+	i.Require().NoError(err)
+	i.Equal(422, res.StatusCode)
+}
+
+func (i *imageImportTestSuite) TestUploadImageImportChunk() {
+	mock.OnAnything(i.Security, "Can").Return(true)
+	i.importCtl.On("Get", mock.Anything, int64(2)).Return(&imageimport.Import{ID: 2, ProjectID: 1, Size: 10, Uploaded: 5}, nil)
+	i.importCtl.On("Upload", mock.Anything, int64(2), mock.Anything, int64(5), int64(9)).Return(func(ctx context.Context, id int64, chunk io.Reader, start, end int64) *imageimport.Import {
+		data, _ := io.ReadAll(chunk)
+		i.Equal("56789", string(data))
+		return &imageimport.Import{ID: 2, ProjectID: 1, Size: 10, Uploaded: 10, Status: "Running"}
+	}, nil)
+
+	res, err := i.Patch("/projects/1/import/2", strings.NewReader("56789"), map[string]string{
+		"Content-Type":  "application/octet-stream",
+		"Content-Range": "5-9",
+	})
+	i.Require().NoError(err)
+	i.Equal(202, res.StatusCode)
+	i.Equal("0-9", res.Header.Get("Range"))
+
+	// invalid Content-Range
+	res, err = i.Patch("/projects/1/import/2", strings.NewReader("56789"), map[string]string{
+		"Content-Type":  "application/octet-stream",
+		"Content-Range": "9-5",
+	})
+	i.Require().NoError(err)
+	i.Equal(400, res.StatusCode)
+
+	// the length mismatches the range
+	res, err = i.Patch("/projects/1/import/2", strings.NewReader("567"), map[string]string{
+		"Content-Type":  "application/octet-stream",
+		"Content-Range": "5-9",
+	})
+	i.Require().NoError(err)
+	i.Equal(400, res.StatusCode)
+	i.importCtl.AssertNumberOfCalls(i.T(), "Upload", 1)
+}
+
+func (i *imageImportTestSuite) TestGetImageImport() {
+	mock.OnAnything(i.Security, "Can").Return(true)
+	i.importCtl.On("Get", mock.Anything, int64(2)).Return(&imageimport.Import{ID: 2, ProjectID: 1, Total: 3, Imported: 2}, nil)
+	i.importCtl.On("Get", mock.Anything, int64(3)).Return(&imageimport.Import{ID: 3, ProjectID: 4}, nil)
+
+	imp := &models.ImageImport{}
+	res, err := i.GetJSON("/projects/1/import/2", imp)
+	i.Require().NoError(err)
+	i.Equal(200, res.StatusCode)
+	i.Equal(int64(3), imp.Total)
+	i.Equal(int64(2), imp.Imported)
+
+	// the import of another project
+	res, err = i.Get("/projects/1/import/3")
+	i.Require().NoError(err)
+	i.Equal(404, res.StatusCode)
+}
+
+func (i *imageImportTestSuite) TestListImageImports() {
+	mock.OnAnything(i.Security, "Can").Return(true)
+	i.importCtl.On("Count", mock.Anything, int64(1)).Return(int64(11), nil)
+	i.importCtl.On("List", mock.Anything, int64(1), mock.Anything).Return([]*imageimport.Import{{ID: 2, ProjectID: 1}}, nil)
+
+	var imps []*models.ImageImport
+	res, err := i.GetJSON("/projects/1/import?page=2&page_size=10", &imps)
+	i.Require().NoError(err)
+	i.Equal(200, res.StatusCode)
+	i.Equal("11", res.Header.Get("X-Total-Count"))
+	i.Len(imps, 1)
+}
+
+func (i *imageImportTestSuite) TestCancelImageImport() {
+	mock.OnAnything(i.Security, "Can").Return(true)
+	i.importCtl.On("Get", mock.Anything, int64(2)).Return(&imageimport.Import{ID: 2, ProjectID: 1}, nil)
+	i.importCtl.On("Cancel", mock.Anything, int64(2)).Return(nil)
+
+	res, err := i.Delete("/projects/1/import/2")
+	i.Require().NoError(err)
+	i.Equal(200, res.StatusCode)
+	i.importCtl.AssertExpectations(i.T())
+}
+
+func (i *imageImportTestSuite) TestForbidden() {
+	mock.OnAnything(i.Security, "Can").Return(false)
+	res, err := i.Post("/projects/1/import?size=10&digest=sha256:abc", nil)
+	i.Require().NoError(err)
+	i.Equal(403, res.StatusCode)
+	i.importCtl.AssertNotCalled(i.T(), "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImageImportTestSuite(t *testing.T) {
+	suite.Run(t, &imageImportTestSuite{})
+}
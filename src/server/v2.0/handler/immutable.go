@@ -8,6 +8,7 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 
 	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/configsync"
 	"github.com/goharbor/harbor/src/controller/immutable"
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/lib"
@@ -21,15 +22,17 @@ import (
 
 func newImmutableAPI() *immutableAPI {
 	return &immutableAPI{
-		immuCtl:    immutable.Ctr,
-		projectCtr: project.Ctl,
+		immuCtl:       immutable.Ctr,
+		projectCtr:    project.Ctl,
+		configSyncCtl: configsync.Ctl,
 	}
 }
 
 type immutableAPI struct {
 	BaseAPI
-	immuCtl    immutable.Controller
-	projectCtr project.Controller
+	immuCtl       immutable.Controller
+	projectCtr    project.Controller
+	configSyncCtl configsync.Controller
 }
 
 func (ia *immutableAPI) CreateImmuRule(ctx context.Context, params operation.CreateImmuRuleParams) middleware.Responder {
@@ -47,6 +50,9 @@ func (ia *immutableAPI) CreateImmuRule(ctx context.Context, params operation.Cre
 	if err != nil {
 		return ia.SendError(ctx, err)
 	}
+	if err := ia.configSyncCtl.CheckProject(ctx, projectID); err != nil {
+		return ia.SendError(ctx, err)
+	}
 	metadata.ProjectID = projectID
 
 	id, err := ia.immuCtl.CreateImmutableRule(ctx, &metadata)
@@ -68,6 +74,9 @@ func (ia *immutableAPI) DeleteImmuRule(ctx context.Context, params operation.Del
 	if err != nil {
 		return ia.SendError(ctx, err)
 	}
+	if err := ia.configSyncCtl.CheckProject(ctx, projectID); err != nil {
+		return ia.SendError(ctx, err)
+	}
 
 	if err := ia.requireRuleAccess(ctx, projectID, params.ImmutableRuleID); err != nil {
 		return ia.SendError(ctx, err)
@@ -98,6 +107,9 @@ func (ia *immutableAPI) UpdateImmuRule(ctx context.Context, params operation.Upd
 	if err != nil {
 		return ia.SendError(ctx, err)
 	}
+	if err := ia.configSyncCtl.CheckProject(ctx, projectID); err != nil {
+		return ia.SendError(ctx, err)
+	}
 	metadata.ProjectID = projectID
 
 	if err = ia.requireRuleAccess(ctx, projectID, metadata.ID); err != nil {
@@ -0,0 +1,73 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+)
+
+// sendInternalRequest sends the request to the v2.0 API handler in the same process and decodes the response body
+// into "out" if it isn't nil. The internal request shares the context with the caller, so it's authorized with the
+// same security context and runs in the same transaction. The header of the response is returned
+func sendInternalRequest(ctx context.Context, handler http.Handler, method, path string, query url.Values,
+	header http.Header, body, out interface{}) (http.Header, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	u := apiBasePath + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	res := lib.NewResponseBuffer(nil)
+	handler.ServeHTTP(res, req)
+	if !res.Success() {
+		errs := &models.Errors{}
+		if err := json.Unmarshal(res.Buffer(), errs); err != nil || len(errs.Errors) == 0 {
+			return nil, errors.UnknownError(nil).WithMessage("%s %s: %d %s", method, path, res.StatusCode(), string(res.Buffer()))
+		}
+		return nil, errors.New(nil).WithCode(errs.Errors[0].Code).WithMessage(errs.Errors[0].Message)
+	}
+	if out != nil && len(res.Buffer()) > 0 {
+		if err := json.Unmarshal(res.Buffer(), out); err != nil {
+			return nil, err
+		}
+	}
+	return res.Header(), nil
+}
@@ -0,0 +1,124 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+const mimeTypeEventStream = "text/event-stream"
+
+// the interval to poll the new log of the followed task
+var followLogInterval = 2 * time.Second
+
+// taskLogGetter gets the task and the log of the task, it's implemented by the task controller
+type taskLogGetter interface {
+	Get(ctx context.Context, id int64) (*task.Task, error)
+	GetLogFrom(ctx context.Context, id int64, offset int64) ([]byte, error)
+}
+
+// followTaskLog returns the responder which streams the log of the task until the task is done or the client disconnects.
+// The log is sent as server-sent events if the client accepts "text/event-stream", otherwise as chunked plain text
+func followTaskLog(ctx context.Context, req *http.Request, getter taskLogGetter, taskID int64) middleware.Responder {
+	return middleware.ResponderFunc(func(w http.ResponseWriter, _ runtime.Producer) {
+		sse := strings.Contains(req.Header.Get("Accept"), mimeTypeEventStream)
+		if sse {
+			w.Header().Set("Content-Type", mimeTypeEventStream)
+		} else {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		}
+		w.Header().Set("Cache-Control", "no-cache")
+		// disable the response buffering of the nginx proxy
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		flusher, _ := w.(http.Flusher)
+		var (
+			offset  int64
+			partial []byte // the incomplete last line which isn't sent as event yet
+		)
+		for {
+			// get the status before the log to make sure all the log is sent when the task is done
+			t, err := getter.Get(ctx, taskID)
+			if err != nil {
+				log.Errorf("failed to get the task %d when following the log: %v", taskID, err)
+				return
+			}
+			data, err := getter.GetLogFrom(ctx, taskID, offset)
+			if err != nil {
+				log.Errorf("failed to get the log of the task %d: %v", taskID, err)
+				return
+			}
+			offset += int64(len(data))
+
+			done := job.Status(t.Status).Final()
+			if sse {
+				data = append(partial, data...)
+				partial = nil
+				if i := bytes.LastIndexByte(data, '\n'); i < len(data)-1 && !done {
+					partial = append(partial, data[i+1:]...)
+					data = data[:i+1]
+				}
+				data = toEvents(data)
+			}
+			if len(data) > 0 {
+				if _, err = w.Write(data); err != nil {
+					log.Debugf("failed to write the log of the task %d: %v", taskID, err)
+					return
+				}
+			}
+			if done && sse {
+				_, _ = w.Write([]byte("event: end\ndata: " + t.Status + "\n\n"))
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+			if done {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(followLogInterval):
+			}
+		}
+	})
+}
+
+// toEvents converts the log lines to the server-sent events, one event per line
+func toEvents(data []byte) []byte {
+	if len(data) == 0 {
+		return nil
+	}
+	buf := &bytes.Buffer{}
+	for _, line := range bytes.Split(bytes.TrimSuffix(data, []byte("\n")), []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(bytes.TrimSuffix(line, []byte("\r")))
+		buf.WriteString("\n\n")
+	}
+	return buf.Bytes()
+}
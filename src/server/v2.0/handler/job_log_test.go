@@ -0,0 +1,86 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/task"
+)
+
+// fakeTaskLogGetter returns one more chunk of the log every time and the task is done after all the chunks are returned
+type fakeTaskLogGetter struct {
+	chunks []string
+	polled int
+}
+
+func (f *fakeTaskLogGetter) Get(ctx context.Context, id int64) (*task.Task, error) {
+	status := job.RunningStatus.String()
+	if f.polled >= len(f.chunks)-1 {
+		status = job.SuccessStatus.String()
+	}
+	return &task.Task{ID: id, Status: status}, nil
+}
+
+func (f *fakeTaskLogGetter) GetLogFrom(ctx context.Context, id int64, offset int64) ([]byte, error) {
+	var log string
+	for _, c := range f.chunks[:f.polled+1] {
+		log += c
+	}
+	f.polled++
+	return []byte(log[offset:]), nil
+}
+
+func TestFollowTaskLog(t *testing.T) {
+	followLogInterval = time.Millisecond
+	defer func() { followLogInterval = 2 * time.Second }()
+
+	chunks := []string{"line1\nli", "ne2\n", "line3"}
+
+	// plain text
+	req := httptest.NewRequest(http.MethodGet, "/log?follow=true", nil)
+	w := httptest.NewRecorder()
+	followTaskLog(context.TODO(), req, &fakeTaskLogGetter{chunks: chunks}, 1).WriteResponse(w, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "line1\nline2\nline3", w.Body.String())
+
+	// server-sent events
+	req = httptest.NewRequest(http.MethodGet, "/log?follow=true", nil)
+	req.Header.Set("Accept", mimeTypeEventStream)
+	w = httptest.NewRecorder()
+	followTaskLog(context.TODO(), req, &fakeTaskLogGetter{chunks: chunks}, 1).WriteResponse(w, nil)
+	assert.Equal(t, mimeTypeEventStream, w.Header().Get("Content-Type"))
+	assert.Equal(t, "data: line1\n\ndata: line2\n\ndata: line3\n\nevent: end\ndata: Success\n\n", w.Body.String())
+}
+
+func TestFollowTaskLogCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	req := httptest.NewRequest(http.MethodGet, "/log?follow=true", nil)
+	w := httptest.NewRecorder()
+	getter := &fakeTaskLogGetter{chunks: []string{"line1\n", "line2\n", "line3\n"}}
+	followTaskLog(ctx, req, getter, 1).WriteResponse(w, nil)
+	assert.Equal(t, "line1\n", w.Body.String())
+	assert.Equal(t, 1, getter.polled)
+}
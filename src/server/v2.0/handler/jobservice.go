@@ -16,6 +16,7 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -27,19 +28,45 @@ import (
 	"github.com/go-openapi/strfmt"
 
 	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/gc"
+	"github.com/goharbor/harbor/src/controller/jobarchive"
 	"github.com/goharbor/harbor/src/controller/jobmonitor"
+	"github.com/goharbor/harbor/src/controller/scan"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/log"
+	archivemodel "github.com/goharbor/harbor/src/pkg/jobarchive/model"
 	jm "github.com/goharbor/harbor/src/pkg/jobmonitor"
+	"github.com/goharbor/harbor/src/pkg/systemjob"
+	systemjobmodel "github.com/goharbor/harbor/src/pkg/systemjob/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 	"github.com/goharbor/harbor/src/server/v2.0/restapi/operations/jobservice"
 )
 
+// the jobs of the last 7 days are listed when the "since" isn't specified
+const defaultSystemJobPeriod = 7 * 24 * time.Hour
+
+// the execution vendor types of the system job types, the webhook jobs aren't executions
+var systemJobVendorTypes = map[string][]string{
+	systemjobmodel.TypeReplication: {job.Replication},
+	systemjobmodel.TypeScan:        {job.ImageScanJob, scan.VendorTypeScanAll},
+	systemjobmodel.TypeGC:          {gc.GCVendorType},
+	systemjobmodel.TypeRetention:   {job.Retention},
+}
+
 type jobServiceAPI struct {
 	BaseAPI
-	jobCtr jobmonitor.MonitorController
+	jobCtr       jobmonitor.MonitorController
+	archiveCtl   jobarchive.Controller
+	systemJobMgr systemjob.Manager
 }
 
 func newJobServiceAPI() *jobServiceAPI {
-	return &jobServiceAPI{jobCtr: jobmonitor.Ctl}
+	return &jobServiceAPI{
+		jobCtr:       jobmonitor.Ctl,
+		archiveCtl:   jobarchive.Ctl,
+		systemJobMgr: systemjob.Mgr,
+	}
 }
 
 func (j *jobServiceAPI) GetWorkerPools(ctx context.Context, params jobservice.GetWorkerPoolsParams) middleware.Responder {
@@ -158,10 +185,11 @@ func toQueueResponse(queues []*jm.Queue) []*models.JobQueue {
 	result := make([]*models.JobQueue, 0)
 	for _, q := range queues {
 		result = append(result, &models.JobQueue{
-			JobType: q.JobType,
-			Count:   q.Count,
-			Latency: q.Latency,
-			Paused:  q.Paused,
+			JobType:        q.JobType,
+			Count:          q.Count,
+			Latency:        q.Latency,
+			Paused:         q.Paused,
+			MaxConcurrency: int64(q.MaxConcurrency),
 		})
 	}
 	return result
@@ -196,3 +224,245 @@ func (j *jobServiceAPI) ActionPendingJobs(ctx context.Context, params jobservice
 	}
 	return jobservice.NewActionPendingJobsOK()
 }
+
+func (j *jobServiceAPI) UpdateJobQueueConcurrency(ctx context.Context, params jobservice.UpdateJobQueueConcurrencyParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	maxConcurrency := params.Concurrency.MaxConcurrency
+	if maxConcurrency == nil || *maxConcurrency < 0 {
+		return j.SendError(ctx, errors.BadRequestError(fmt.Errorf("invalid max concurrency")))
+	}
+	if err := j.jobCtr.UpdateMaxConcurrency(ctx, strings.ToUpper(params.JobType), uint(*maxConcurrency)); err != nil {
+		return j.SendError(ctx, err)
+	}
+	return jobservice.NewUpdateJobQueueConcurrencyOK()
+}
+
+func (j *jobServiceAPI) ListQueuedJobs(ctx context.Context, params jobservice.ListQueuedJobsParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	query, err := j.BuildQuery(ctx, nil, nil, params.Page, params.PageSize)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	filter := &jm.QueuedJobFilter{}
+	if params.Status != nil {
+		filter.Status = *params.Status
+	}
+	if params.PoolID != nil {
+		filter.PoolID = *params.PoolID
+	}
+	if params.MinAge != nil {
+		filter.MinAge = *params.MinAge
+	}
+	jobs, err := j.jobCtr.ListQueuedJobs(ctx, strings.ToUpper(params.JobType), filter)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	total := int64(len(jobs))
+	// the jobs are kept in the redis lists, paginate them in memory
+	start := (query.PageNumber - 1) * query.PageSize
+	end := start + query.PageSize
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+	return jobservice.NewListQueuedJobsOK().
+		WithPayload(toQueuedJobResponse(jobs[start:end])).
+		WithXTotalCount(total).
+		WithLink(j.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String())
+}
+
+func toQueuedJobResponse(jobs []*jm.QueuedJob) []*models.QueuedJob {
+	result := make([]*models.QueuedJob, 0)
+	for _, job := range jobs {
+		result = append(result, &models.QueuedJob{
+			ID:         job.ID,
+			JobType:    job.JobType,
+			Status:     job.Status,
+			PoolID:     job.PoolID,
+			EnqueuedAt: covertTime(job.EnqueuedAt),
+			Fails:      job.Fails,
+			LastError:  job.LastErr,
+		})
+	}
+	return result
+}
+
+func (j *jobServiceAPI) ActionQueuedJob(ctx context.Context, params jobservice.ActionQueuedJobParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionStop, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	jobType := strings.ToUpper(params.JobType)
+	var err error
+	switch strings.ToLower(params.ActionRequest.Action) {
+	case "requeue":
+		err = j.jobCtr.RequeueJob(ctx, jobType, params.JobID)
+	case "purge":
+		err = j.jobCtr.PurgeJob(ctx, jobType, params.JobID)
+	default:
+		err = errors.BadRequestError(fmt.Errorf("the action is not supported"))
+	}
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	return jobservice.NewActionQueuedJobOK()
+}
+
+func (j *jobServiceAPI) ListJobArchives(ctx context.Context, params jobservice.ListJobArchivesParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	query, err := j.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	total, err := j.archiveCtl.Count(ctx, query)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	archives, err := j.archiveCtl.List(ctx, query)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	return jobservice.NewListJobArchivesOK().
+		WithXTotalCount(total).
+		WithLink(j.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(toJobArchiveResponse(archives))
+}
+
+func toJobArchiveResponse(archives []*archivemodel.JobArchive) []*models.JobArchive {
+	var result []*models.JobArchive
+	for _, a := range archives {
+		archive := &models.JobArchive{
+			ID:            a.ID,
+			TaskID:        a.TaskID,
+			ExecutionID:   a.ExecutionID,
+			JobID:         a.JobID,
+			JobName:       a.JobName,
+			VendorType:    a.VendorType,
+			VendorID:      a.VendorID,
+			ProjectID:     a.ProjectID,
+			Status:        a.Status,
+			StatusMessage: a.StatusMessage,
+			Trigger:       a.Trigger,
+			StartTime:     strfmt.DateTime(a.StartTime),
+			EndTime:       strfmt.DateTime(a.EndTime),
+			Duration:      a.Duration,
+		}
+		if len(a.Parameters) > 0 {
+			if err := json.Unmarshal([]byte(a.Parameters), &archive.Parameters); err != nil {
+				log.Warningf("failed to decode the parameters of the archived job %d: %v", a.ID, err)
+			}
+		}
+		result = append(result, archive)
+	}
+	return result
+}
+
+func (j *jobServiceAPI) ListSystemJobs(ctx context.Context, params jobservice.ListSystemJobsParams) middleware.Responder {
+	if err := j.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceJobServiceMonitor); err != nil {
+		return j.SendError(ctx, err)
+	}
+	filter, err := parseSystemJobFilter(lib.StringValue(params.Type), lib.StringValue(params.Status), lib.StringValue(params.Since))
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	page, pageSize := lib.Int64Value(params.Page), lib.Int64Value(params.PageSize)
+
+	total, err := j.systemJobMgr.Count(ctx, filter)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	jobs, err := j.systemJobMgr.List(ctx, filter, page, pageSize)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	stats, err := j.systemJobMgr.Stats(ctx, filter)
+	if err != nil {
+		return j.SendError(ctx, err)
+	}
+	return jobservice.NewListSystemJobsOK().
+		WithXTotalCount(total).
+		WithLink(j.Links(ctx, params.HTTPRequest.URL, total, page, pageSize).String()).
+		WithPayload(toSystemJobsResponse(total, jobs, stats))
+}
+
+// parseSystemJobFilter parses the comma separated types and statuses and the "since" in RFC3339,
+// all the job types are selected if the types aren't specified
+func parseSystemJobFilter(types, statuses, since string) (*systemjobmodel.Filter, error) {
+	filter := &systemjobmodel.Filter{
+		VendorTypes: map[string][]string{},
+		Since:       time.Now().Add(-defaultSystemJobPeriod),
+	}
+	if len(types) == 0 {
+		for typ, vendorTypes := range systemJobVendorTypes {
+			filter.VendorTypes[typ] = vendorTypes
+		}
+		filter.Webhook = true
+	} else {
+		for _, typ := range strings.Split(types, ",") {
+			typ = strings.TrimSpace(typ)
+			if typ == systemjobmodel.TypeWebhook {
+				filter.Webhook = true
+				continue
+			}
+			vendorTypes, ok := systemJobVendorTypes[typ]
+			if !ok {
+				return nil, errors.BadRequestError(nil).WithMessage("unsupported job type %s, the supported ones are replication, scan, gc, retention and webhook", typ)
+			}
+			filter.VendorTypes[typ] = vendorTypes
+		}
+	}
+	for _, status := range strings.Split(statuses, ",") {
+		// accept the statuses in any case, e.g. "error" for "Error"
+		status = strings.TrimSpace(status)
+		if len(status) == 0 {
+			continue
+		}
+		filter.Statuses = append(filter.Statuses, strings.ToUpper(status[:1])+strings.ToLower(status[1:]))
+	}
+	if len(since) > 0 {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return nil, errors.BadRequestError(nil).WithMessage("invalid since %s, the format is RFC3339", since)
+		}
+		filter.Since = t
+	}
+	return filter, nil
+}
+
+func toSystemJobsResponse(total int64, jobs []*systemjobmodel.Job, stats []*systemjobmodel.Stats) *models.SystemJobs {
+	result := &models.SystemJobs{
+		Total: total,
+		Jobs:  []*models.SystemJob{},
+		Stats: []*models.SystemJobStats{},
+	}
+	for _, j := range jobs {
+		result.Jobs = append(result.Jobs, &models.SystemJob{
+			ID:         j.ID,
+			Type:       j.Type,
+			VendorType: j.VendorType,
+			VendorID:   j.VendorID,
+			Status:     j.Status,
+			Trigger:    j.Trigger,
+			StartTime:  strfmt.DateTime(j.StartTime),
+			EndTime:    strfmt.DateTime(j.EndTime),
+		})
+	}
+	for _, s := range stats {
+		result.Stats = append(result.Stats, &models.SystemJobStats{
+			Type:        s.Type,
+			Total:       s.Total,
+			Statuses:    s.Statuses,
+			DurationP50: s.DurationP50,
+			DurationP90: s.DurationP90,
+			DurationP99: s.DurationP99,
+		})
+	}
+	return result
+}
@@ -0,0 +1,111 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/pkg/systemjob/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	"github.com/goharbor/harbor/src/testing/mock"
+	systemjobtesting "github.com/goharbor/harbor/src/testing/pkg/systemjob"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+)
+
+type jobServiceTestSuite struct {
+	htesting.Suite
+	systemJobMgr *systemjobtesting.Manager
+}
+
+func (j *jobServiceTestSuite) SetupSuite() {
+	j.systemJobMgr = &systemjobtesting.Manager{}
+	j.Config = &restapi.Config{JobserviceAPI: &jobServiceAPI{systemJobMgr: j.systemJobMgr}}
+	j.Suite.SetupSuite()
+}
+
+func (j *jobServiceTestSuite) SetupTest() {
+	j.systemJobMgr.ExpectedCalls = nil
+	j.systemJobMgr.Calls = nil
+}
+
+func (j *jobServiceTestSuite) TestListSystemJobsForbidden() {
+	j.Security.On("IsAuthenticated").Return(true).Once()
+	j.Security.On("GetUsername").Return("user").Once()
+	j.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(false).Once()
+	res, err := j.Get("/system/jobs")
+	j.Require().NoError(err)
+	j.Equal(403, res.StatusCode)
+}
+
+func (j *jobServiceTestSuite) TestListSystemJobsInvalidType() {
+	j.Security.On("IsAuthenticated").Return(true).Once()
+	j.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true).Once()
+	res, err := j.Get("/system/jobs?type=unknown")
+	j.Require().NoError(err)
+	j.Equal(400, res.StatusCode)
+}
+
+func (j *jobServiceTestSuite) TestListSystemJobs() {
+	j.Security.On("IsAuthenticated").Return(true).Once()
+	j.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true).Once()
+	mock.OnAnything(j.systemJobMgr, "Count").Return(int64(21), nil)
+	mock.OnAnything(j.systemJobMgr, "List").Return([]*model.Job{
+		{ID: 1, Type: model.TypeGC, VendorType: "GARBAGE_COLLECTION", Status: "Error"},
+	}, nil)
+	mock.OnAnything(j.systemJobMgr, "Stats").Return([]*model.Stats{
+		{Type: model.TypeGC, Total: 21, Statuses: map[string]int64{"Error": 21}, DurationP50: 1.5},
+	}, nil)
+
+	result := &models.SystemJobs{}
+	res, err := j.GetJSON("/system/jobs?type=gc,webhook&status=error&since=2023-06-01T00:00:00Z&page=3&page_size=10", result)
+	j.Require().NoError(err)
+	j.Equal(200, res.StatusCode)
+	j.Equal("21", res.Header.Get("X-Total-Count"))
+	j.Equal(int64(21), result.Total)
+	j.Require().Len(result.Jobs, 1)
+	j.Require().Len(result.Stats, 1)
+	j.Equal(1.5, result.Stats[0].DurationP50)
+
+	args := j.systemJobMgr.Calls[1].Arguments
+	filter := args.Get(1).(*model.Filter)
+	j.Equal(map[string][]string{model.TypeGC: {"GARBAGE_COLLECTION"}}, filter.VendorTypes)
+	j.True(filter.Webhook)
+	j.Equal([]string{"Error"}, filter.Statuses)
+	j.Equal(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), filter.Since)
+	j.Equal(int64(3), args.Get(2))
+	j.Equal(int64(10), args.Get(3))
+}
+
+func (j *jobServiceTestSuite) TestParseSystemJobFilter() {
+	// all the job types by default
+	filter, err := parseSystemJobFilter("", "", "")
+	j.Require().Nil(err)
+	j.Len(filter.VendorTypes, 4)
+	j.True(filter.Webhook)
+	j.Empty(filter.Statuses)
+	j.WithinDuration(time.Now().Add(-defaultSystemJobPeriod), filter.Since, time.Minute)
+
+	// invalid since
+	_, err = parseSystemJobFilter("", "", "2023-06-01")
+	j.NotNil(err)
+}
+
+func TestJobServiceTestSuite(t *testing.T) {
+	suite.Run(t, &jobServiceTestSuite{})
+}
@@ -11,11 +11,13 @@ import (
 	"github.com/goharbor/harbor/src/common/dao"
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/common/rbac/system"
+	"github.com/goharbor/harbor/src/controller/configsync"
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
+	"github.com/goharbor/harbor/src/pkg"
 	"github.com/goharbor/harbor/src/pkg/label"
 	pkg_model "github.com/goharbor/harbor/src/pkg/label/model"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
@@ -25,15 +27,17 @@ import (
 
 func newLabelAPI() *labelAPI {
 	return &labelAPI{
-		labelMgr:   label.Mgr,
-		projectCtl: project.Ctl,
+		labelMgr:      pkg.LabelMgr,
+		projectCtl:    project.Ctl,
+		configSyncCtl: configsync.Ctl,
 	}
 }
 
 type labelAPI struct {
 	BaseAPI
-	labelMgr   label.Manager
-	projectCtl project.Controller
+	labelMgr      label.Manager
+	projectCtl    project.Controller
+	configSyncCtl configsync.Controller
 }
 
 func (lAPI *labelAPI) CreateLabel(ctx context.Context, params operation.CreateLabelParams) middleware.Responder {
@@ -185,7 +189,14 @@ func (lAPI *labelAPI) requireAccess(ctx context.Context, label *pkg_model.Label,
 	switch label.Scope {
 	case common.LabelScopeGlobal:
 		resource := system.NewNamespace().Resource(rbac.ResourceLabel)
-		return lAPI.RequireSystemAccess(ctx, action, resource)
+		if err := lAPI.RequireSystemAccess(ctx, action, resource); err != nil {
+			return err
+		}
+		// the global labels are synced from the primary instance when the config sync is enabled
+		if action != rbac.ActionRead {
+			return lAPI.configSyncCtl.CheckGlobal(ctx)
+		}
+		return nil
 	case common.LabelScopeProject:
 		if len(subresources) == 0 {
 			subresources = append(subresources, rbac.ResourceLabel)
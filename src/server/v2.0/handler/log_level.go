@@ -0,0 +1,79 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/loglevel"
+)
+
+func newLogLevelAPI() *logLevelAPI {
+	return &logLevelAPI{}
+}
+
+type logLevelAPI struct {
+	BaseAPI
+}
+
+func (l *logLevelAPI) GetLogLevels(ctx context.Context, params operation.GetLogLevelsParams) middleware.Responder {
+	if err := l.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceSystemLogLevel); err != nil {
+		return l.SendError(ctx, err)
+	}
+	return operation.NewGetLogLevelsOK().WithPayload(logLevels())
+}
+
+func (l *logLevelAPI) UpdateModuleLogLevel(ctx context.Context, params operation.UpdateModuleLogLevelParams) middleware.Responder {
+	if err := l.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceSystemLogLevel); err != nil {
+		return l.SendError(ctx, err)
+	}
+	req := params.Level
+	// the empty level resets the module to the level of the default logger
+	if len(req.Level) == 0 {
+		if err := log.ResetModuleLevel(req.Module); err != nil {
+			return l.SendError(ctx, errors.BadRequestError(err))
+		}
+		log.Infof("the log level of the module %s is reset", req.Module)
+		return operation.NewUpdateModuleLogLevelOK().WithPayload(logLevels())
+	}
+
+	lvl, err := log.ParseLevel(req.Level)
+	if err != nil {
+		return l.SendError(ctx, errors.BadRequestError(err))
+	}
+	if err := log.SetModuleLevel(req.Module, lvl); err != nil {
+		return l.SendError(ctx, errors.BadRequestError(err))
+	}
+	log.Infof("the log level of the module %s is set to %s", req.Module, lvl)
+	return operation.NewUpdateModuleLogLevelOK().WithPayload(logLevels())
+}
+
+func logLevels() *models.LogLevels {
+	levels := &models.LogLevels{
+		Level:   strings.ToLower(log.GetLevel().String()),
+		Modules: map[string]string{},
+	}
+	for module, lvl := range log.ModuleLevels() {
+		levels.Modules[module] = strings.ToLower(lvl.String())
+	}
+	return levels
+}
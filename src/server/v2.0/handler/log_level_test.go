@@ -0,0 +1,67 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	"github.com/goharbor/harbor/src/testing/mock"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+)
+
+type logLevelTestSuite struct {
+	htesting.Suite
+}
+
+func (l *logLevelTestSuite) SetupSuite() {
+	l.Config = &restapi.Config{LoglevelAPI: &logLevelAPI{}}
+	l.Suite.SetupSuite()
+}
+
+func (l *logLevelTestSuite) SetupTest() {
+	l.Security.On("IsAuthenticated").Return(true)
+	l.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true)
+}
+
+func (l *logLevelTestSuite) TestUpdateModuleLogLevel() {
+	res, err := l.PutJSON("/system/loglevels", &models.ModuleLogLevel{Module: "pkg/scan", Level: "trace"})
+	l.Require().NoError(err)
+	l.Equal(400, res.StatusCode)
+
+	res, err = l.PutJSON("/system/loglevels", &models.ModuleLogLevel{Module: "/pkg/scan/", Level: "debug"})
+	l.Require().NoError(err)
+	l.Equal(200, res.StatusCode)
+	levels := &models.LogLevels{}
+	res, err = l.GetJSON("/system/loglevels", levels)
+	l.Require().NoError(err)
+	l.Equal(200, res.StatusCode)
+	l.Equal("debug", levels.Modules["pkg/scan"])
+
+	res, err = l.PutJSON("/system/loglevels", &models.ModuleLogLevel{Module: "pkg/scan"})
+	l.Require().NoError(err)
+	l.Equal(200, res.StatusCode)
+	levels = &models.LogLevels{}
+	_, err = l.GetJSON("/system/loglevels", levels)
+	l.Require().NoError(err)
+	l.NotContains(levels.Modules, "pkg/scan")
+}
+
+func TestLogLevelTestSuite(t *testing.T) {
+	suite.Run(t, &logLevelTestSuite{})
+}
@@ -10,6 +10,7 @@ import (
 	"golang.org/x/text/language"
 
 	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/controller/gc"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
@@ -35,10 +36,22 @@ type GCHistory struct {
 	Deleted      bool           `json:"deleted"`
 	CreationTime time.Time      `json:"creation_time"`
 	UpdateTime   time.Time      `json:"update_time"`
+	Statistics   *gc.Statistics `json:"statistics"`
 }
 
 // ToSwagger converts the history to the swagger model
 func (h *GCHistory) ToSwagger() *models.GCHistory {
+	var statistics *models.GCStatistics
+	if h.Statistics != nil {
+		statistics = &models.GCStatistics{
+			DeletedBlobs:     int64(h.Statistics.DeletedBlobs),
+			DeletedManifests: int64(h.Statistics.DeletedManifests),
+			ReclaimedBytes:   h.Statistics.ReclaimedBytes,
+			Errors:           int64(h.Statistics.Errors),
+			Duration:         h.Statistics.Duration,
+			DryRun:           h.Statistics.DryRun,
+		}
+	}
 	return &models.GCHistory{
 		ID:            h.ID,
 		JobName:       h.Name,
@@ -55,6 +68,7 @@ func (h *GCHistory) ToSwagger() *models.GCHistory {
 		},
 		CreationTime: strfmt.DateTime(h.CreationTime),
 		UpdateTime:   strfmt.DateTime(h.UpdateTime),
+		Statistics:   statistics,
 	}
 }
 
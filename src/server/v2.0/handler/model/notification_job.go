@@ -32,3 +32,54 @@ func NewNotificationJob(j *model.Job) *NotificationJob {
 		Job: j,
 	}
 }
+
+// NotificationJobAttempt ...
+type NotificationJobAttempt struct {
+	*model.Attempt
+}
+
+// ToSwagger ...
+func (n *NotificationJobAttempt) ToSwagger() *models.WebhookJobAttempt {
+	return &models.WebhookJobAttempt{
+		ID:           n.ID,
+		JobID:        n.JobID,
+		Success:      n.Success,
+		StatusCode:   int64(n.StatusCode),
+		Latency:      n.Latency,
+		Response:     n.Response,
+		Error:        n.Error,
+		CreationTime: strfmt.DateTime(n.CreationTime),
+	}
+}
+
+// NewNotificationJobAttempt ...
+func NewNotificationJobAttempt(a *model.Attempt) *NotificationJobAttempt {
+	return &NotificationJobAttempt{
+		Attempt: a,
+	}
+}
+
+// NotificationDeadLetter ...
+type NotificationDeadLetter struct {
+	*model.DeadLetter
+}
+
+// ToSwagger ...
+func (n *NotificationDeadLetter) ToSwagger() *models.WebhookDeadLetter {
+	return &models.WebhookDeadLetter{
+		ID:           n.ID,
+		JobID:        n.JobID,
+		PolicyID:     n.PolicyID,
+		EventType:    n.EventType,
+		NotifyType:   n.NotifyType,
+		Reason:       n.Reason,
+		CreationTime: strfmt.DateTime(n.CreationTime),
+	}
+}
+
+// NewNotificationDeadLetter ...
+func NewNotificationDeadLetter(d *model.DeadLetter) *NotificationDeadLetter {
+	return &NotificationDeadLetter{
+		DeadLetter: d,
+	}
+}
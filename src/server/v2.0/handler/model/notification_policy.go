@@ -14,7 +14,7 @@ type NotifiactionPolicy struct {
 
 // ToSwagger ...
 func (n *NotifiactionPolicy) ToSwagger() *models.WebhookPolicy {
-	return &models.WebhookPolicy{
+	policy := &models.WebhookPolicy{
 		ID:           n.ID,
 		CreationTime: strfmt.DateTime(n.CreationTime),
 		UpdateTime:   strfmt.DateTime(n.UpdateTime),
@@ -26,6 +26,21 @@ func (n *NotifiactionPolicy) ToSwagger() *models.WebhookPolicy {
 		ProjectID:    n.ProjectID,
 		Targets:      n.ToTargets(),
 	}
+	if n.PullEventOptions != nil {
+		policy.PullEventOptions = &models.WebhookPullEventOptions{
+			ThrottleInterval: n.PullEventOptions.ThrottleInterval,
+			SampleRate:       n.PullEventOptions.SampleRate,
+		}
+	}
+	if n.Filters != nil {
+		policy.Filters = &models.WebhookEventFilters{
+			Repositories: n.Filters.Repositories,
+			TagPattern:   n.Filters.TagPattern,
+			LabelIds:     n.Filters.LabelIDs,
+			MinSeverity:  n.Filters.MinSeverity,
+		}
+	}
+	return policy
 }
 
 // ToTargets ...
@@ -36,6 +51,10 @@ func (n *NotifiactionPolicy) ToTargets() []*models.WebhookTargetObject {
 			Type:           t.Type,
 			Address:        t.Address,
 			AuthHeader:     t.AuthHeader,
+			Headers:        t.Headers,
+			PayloadFormat:  t.PayloadFormat,
+			Topic:          t.Topic,
+			Recipients:     t.Recipients,
 			SkipCertVerify: t.SkipCertVerify,
 		})
 	}
@@ -0,0 +1,50 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"github.com/go-openapi/strfmt"
+
+	ctl "github.com/goharbor/harbor/src/controller/pulltoken"
+	"github.com/goharbor/harbor/src/pkg/pulltoken/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+)
+
+// PullToken ...
+type PullToken struct {
+	*model.PullToken
+}
+
+// ToSwagger ...
+func (p *PullToken) ToSwagger() *models.PullToken {
+	return &models.PullToken{
+		ID:           p.ID,
+		Name:         ctl.Name(p.ID),
+		ProjectID:    p.ProjectID,
+		Repositories: p.RepositoryList(),
+		Description:  p.Description,
+		Creator:      p.Creator,
+		Revoked:      p.Revoked,
+		ExpiresAt:    strfmt.DateTime(p.ExpiresAt),
+		CreationTime: strfmt.DateTime(p.CreationTime),
+	}
+}
+
+// NewPullToken ...
+func NewPullToken(t *model.PullToken) *PullToken {
+	return &PullToken{
+		PullToken: t,
+	}
+}
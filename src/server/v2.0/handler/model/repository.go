@@ -3,6 +3,7 @@ package model
 import (
 	"github.com/go-openapi/strfmt"
 
+	"github.com/goharbor/harbor/src/controller/repository"
 	"github.com/goharbor/harbor/src/pkg/repository/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 )
@@ -23,15 +24,55 @@ func (r *RepoRecord) ToSwagger() *models.Repository {
 	return &models.Repository{
 		CreationTime: createTime,
 		Description:  r.Description,
+		Links:        r.SwaggerLinks(),
+		Metadata:     r.GetMetadata(),
 		ID:           r.RepositoryID,
 		Name:         r.Name,
 		ProjectID:    r.ProjectID,
 		PullCount:    r.PullCount,
 		UpdateTime:   strfmt.DateTime(r.UpdateTime),
+		PullTime:     strfmt.DateTime(r.PullTime),
+		PushTime:     strfmt.DateTime(r.PushTime),
 	}
 }
 
+// SwaggerLinks converts the links of the repository into the swagger models
+func (r *RepoRecord) SwaggerLinks() []*models.RepositoryLink {
+	links := []*models.RepositoryLink{}
+	for _, link := range r.GetLinks() {
+		links = append(links, &models.RepositoryLink{
+			Name: link.Name,
+			URL:  link.URL,
+		})
+	}
+	return links
+}
+
 // NewRepoRecord ...
 func NewRepoRecord(r *model.RepoRecord) *RepoRecord {
 	return &RepoRecord{RepoRecord: r}
 }
+
+// RepoDeletion model
+type RepoDeletion struct {
+	*repository.Deletion
+}
+
+// ToSwagger converts the repository deletion into the swagger model
+func (d *RepoDeletion) ToSwagger() *models.RepositoryDeletion {
+	return &models.RepositoryDeletion{
+		ID:             d.ID,
+		RepositoryName: d.RepositoryName,
+		Status:         d.Status,
+		StatusMessage:  d.StatusMessage,
+		Total:          int64(d.Total),
+		Deleted:        int64(d.Deleted),
+		StartTime:      strfmt.DateTime(d.StartTime),
+		EndTime:        strfmt.DateTime(d.EndTime),
+	}
+}
+
+// NewRepoDeletion ...
+func NewRepoDeletion(d *repository.Deletion) *RepoDeletion {
+	return &RepoDeletion{Deletion: d}
+}
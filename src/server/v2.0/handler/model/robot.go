@@ -37,6 +37,8 @@ func (r *Robot) ToSwagger() *models.Robot {
 		CreationTime: strfmt.DateTime(r.CreationTime),
 		UpdateTime:   strfmt.DateTime(r.UpdateTime),
 		Permissions:  perms,
+		IPAllowlist:  r.IPAllowlist,
+		IPDenylist:   r.IPDenylist,
 	}
 }
 
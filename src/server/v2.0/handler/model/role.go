@@ -0,0 +1,56 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/controller/role"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+)
+
+// Role ...
+type Role struct {
+	*role.Role
+}
+
+// ToSwagger ...
+func (r *Role) ToSwagger() *models.Role {
+	perms := []*models.Access{}
+	for _, p := range r.Permissions {
+		perms = append(perms, &models.Access{
+			Resource: p.Resource.String(),
+			Action:   p.Action.String(),
+			Effect:   p.Effect.String(),
+		})
+	}
+
+	return &models.Role{
+		RoleID:       int64(r.ID),
+		Name:         r.Name,
+		Description:  r.Description,
+		Custom:       r.Custom,
+		Permissions:  perms,
+		CreationTime: strfmt.DateTime(r.CreationTime),
+		UpdateTime:   strfmt.DateTime(r.UpdateTime),
+	}
+}
+
+// NewRole ...
+func NewRole(r *role.Role) *Role {
+	return &Role{
+		Role: r,
+	}
+}
@@ -14,6 +14,17 @@ type Tag struct {
 
 // ToSwagger converts the tag to the swagger model
 func (t *Tag) ToSwagger() *models.Tag {
+	var signatures []*models.TagSignature
+	for _, sig := range t.Signatures {
+		signature := &models.TagSignature{
+			Backend:  sig.Backend,
+			SignedBy: sig.SignedBy,
+		}
+		if !sig.SignedAt.IsZero() {
+			signature.SignedAt = strfmt.DateTime(sig.SignedAt)
+		}
+		signatures = append(signatures, signature)
+	}
 	return &models.Tag{
 		ArtifactID:   t.ArtifactID,
 		ID:           t.ID,
@@ -23,6 +34,8 @@ func (t *Tag) ToSwagger() *models.Tag {
 		RepositoryID: t.RepositoryID,
 		Immutable:    t.Immutable,
 		Signed:       t.Signed,
+		CosignSigned: t.CosignSigned,
+		Signatures:   signatures,
 	}
 }
 
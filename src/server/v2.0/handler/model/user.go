@@ -38,6 +38,8 @@ func (u *User) ToUserResp() *svrmodels.UserResp {
 		UserID:          int64(u.UserID),
 		Username:        u.Username,
 		SysadminFlag:    u.SysAdminFlag,
+		AuditorFlag:     u.AuditorFlag,
+		Deactivated:     u.Deactivated,
 		AdminRoleInAuth: u.AdminRoleInAuth,
 		CreationTime:    strfmt.DateTime(u.CreationTime),
 		UpdateTime:      strfmt.DateTime(u.UpdateTime),
@@ -7,8 +7,11 @@ import (
 
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/pkg"
+	"github.com/goharbor/harbor/src/pkg/notification"
 	"github.com/goharbor/harbor/src/pkg/notification/job"
+	jobModel "github.com/goharbor/harbor/src/pkg/notification/job/model"
 	"github.com/goharbor/harbor/src/pkg/notification/policy"
 	policyModel "github.com/goharbor/harbor/src/pkg/notification/policy/model"
 	"github.com/goharbor/harbor/src/pkg/project"
@@ -77,6 +80,184 @@ func (n *notificationJobAPI) ListWebhookJobs(ctx context.Context, params webhook
 		WithPayload(results)
 }
 
+func (n *notificationJobAPI) ListSystemWebhookJobs(ctx context.Context, params webhookjob.ListSystemWebhookJobsParams) middleware.Responder {
+	if err := n.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceNotificationPolicy); err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	policy, err := n.webhookPolicyMgr.Get(ctx, params.PolicyID)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+	if policy.ProjectID != policyModel.SystemLevelProjectID {
+		return n.SendError(ctx, errors.NotFoundError(errors.Errorf("system level webhook policy id: %d not found", policy.ID)))
+	}
+
+	query, err := n.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+	query.Keywords["PolicyID"] = policy.ID
+	if len(params.Status) != 0 {
+		query.Keywords["Status"] = params.Status
+	}
+
+	total, err := n.webhookjobMgr.Count(ctx, query)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	jobs, err := n.webhookjobMgr.List(ctx, query)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	var results []*models.WebhookJob
+	for _, j := range jobs {
+		results = append(results, model.NewNotificationJob(j).ToSwagger())
+	}
+
+	return webhookjob.NewListSystemWebhookJobsOK().
+		WithXTotalCount(total).
+		WithLink(n.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(results)
+}
+
+func (n *notificationJobAPI) ListWebhookJobAttempts(ctx context.Context, params webhookjob.ListWebhookJobAttemptsParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := n.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionList, rbac.ResourceNotificationPolicy); err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	if _, err := n.requireJobAccess(ctx, projectNameOrID, params.WebhookJobID); err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	query, err := n.BuildQuery(ctx, nil, nil, params.Page, params.PageSize)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+	query.Keywords["JobID"] = params.WebhookJobID
+
+	total, err := n.webhookjobMgr.CountAttempts(ctx, query)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	attempts, err := n.webhookjobMgr.ListAttempts(ctx, query)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	var results []*models.WebhookJobAttempt
+	for _, a := range attempts {
+		results = append(results, model.NewNotificationJobAttempt(a).ToSwagger())
+	}
+
+	return webhookjob.NewListWebhookJobAttemptsOK().
+		WithXTotalCount(total).
+		WithLink(n.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(results)
+}
+
+func (n *notificationJobAPI) RedeliverWebhookJob(ctx context.Context, params webhookjob.RedeliverWebhookJobParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := n.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionUpdate, rbac.ResourceNotificationPolicy); err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	if _, err := n.requireJobAccess(ctx, projectNameOrID, params.WebhookJobID); err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	if err := notification.HookManager.Redeliver(ctx, params.WebhookJobID); err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	return webhookjob.NewRedeliverWebhookJobOK()
+}
+
+func (n *notificationJobAPI) ListWebhookDeadLetters(ctx context.Context, params webhookjob.ListWebhookDeadLettersParams) middleware.Responder {
+	if err := n.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceNotificationPolicy); err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	query, err := n.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	total, err := n.webhookjobMgr.CountDeadLetters(ctx, query)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	letters, err := n.webhookjobMgr.ListDeadLetters(ctx, query)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	var results []*models.WebhookDeadLetter
+	for _, l := range letters {
+		results = append(results, model.NewNotificationDeadLetter(l).ToSwagger())
+	}
+
+	return webhookjob.NewListWebhookDeadLettersOK().
+		WithXTotalCount(total).
+		WithLink(n.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(results)
+}
+
+func (n *notificationJobAPI) DrainWebhookDeadLetters(ctx context.Context, params webhookjob.DrainWebhookDeadLettersParams) middleware.Responder {
+	if err := n.RequireSystemAccess(ctx, rbac.ActionDelete, rbac.ResourceNotificationPolicy); err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	redeliver := true
+	if params.Redeliver != nil {
+		redeliver = *params.Redeliver
+	}
+
+	letters, err := n.webhookjobMgr.ListDeadLetters(ctx, nil)
+	if err != nil {
+		return n.SendError(ctx, err)
+	}
+
+	result := &models.WebhookDeadLetterDrained{}
+	for _, l := range letters {
+		if redeliver {
+			// keep the dead letter if it cannot be redelivered, so it can be drained again later
+			if err := notification.HookManager.Redeliver(ctx, l.JobID); err != nil {
+				log.Errorf("failed to redeliver the webhook job %d: %v", l.JobID, err)
+				continue
+			}
+			result.Redelivered++
+		}
+		if err := n.webhookjobMgr.DeleteDeadLetter(ctx, l.ID); err != nil && !errors.IsNotFoundErr(err) {
+			return n.SendError(ctx, err)
+		}
+		result.Drained++
+	}
+
+	return webhookjob.NewDrainWebhookDeadLettersOK().WithPayload(result)
+}
+
+// requireJobAccess checks whether the webhook job belongs to the policy of the project.
+func (n *notificationJobAPI) requireJobAccess(ctx context.Context, projectNameOrID interface{}, jobID int64) (*jobModel.Job, error) {
+	j, err := n.webhookjobMgr.Get(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := n.webhookPolicyMgr.Get(ctx, j.PolicyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.requirePolicyAccess(ctx, projectNameOrID, policy); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
 // requirePolicyAccess checks whether the project has the permission to the policy.
 func (n *notificationJobAPI) requirePolicyAccess(ctx context.Context, projectNameIrID interface{}, policy *policyModel.Policy) error {
 	p, err := n.projectMgr.Get(ctx, projectNameIrID)
@@ -0,0 +1,73 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/pkg/notification"
+	policy_model "github.com/goharbor/harbor/src/pkg/notification/policy/model"
+	notifier_model "github.com/goharbor/harbor/src/pkg/notifier/model"
+)
+
+func TestValidateRecipients(t *testing.T) {
+	assert.Error(t, validateRecipients(nil))
+	assert.Error(t, validateRecipients([]string{"foo"}))
+	assert.Error(t, validateRecipients([]string{"Foo <foo@example.com>"}))
+	assert.Error(t, validateRecipients([]string{"foo@example.com", "foo@example.com\r\nBcc: bar@example.com"}))
+	assert.NoError(t, validateRecipients([]string{"foo@example.com", "bar@example.com"}))
+}
+
+func TestValidateEmailTargets(t *testing.T) {
+	notification.SupportedNotifyTypes = map[string]struct{}{
+		notifier_model.NotifyTypeHTTP:  {},
+		notifier_model.NotifyTypeEmail: {},
+	}
+	n := &notificationPolicyAPI{}
+	newPolicy := func(target policy_model.EventTarget) *policy_model.Policy {
+		return &policy_model.Policy{Name: "test", Targets: []policy_model.EventTarget{target}}
+	}
+
+	ok, err := n.validateTargets(newPolicy(policy_model.EventTarget{
+		Type:       notifier_model.NotifyTypeEmail,
+		Recipients: []string{"foo@example.com"},
+	}))
+	assert.True(t, ok)
+	assert.NoError(t, err)
+
+	// the address is not supported by the email target
+	ok, _ = n.validateTargets(newPolicy(policy_model.EventTarget{
+		Type:       notifier_model.NotifyTypeEmail,
+		Address:    "http://127.0.0.1",
+		Recipients: []string{"foo@example.com"},
+	}))
+	assert.False(t, ok)
+
+	// the recipients are required by the email target
+	ok, _ = n.validateTargets(newPolicy(policy_model.EventTarget{
+		Type: notifier_model.NotifyTypeEmail,
+	}))
+	assert.False(t, ok)
+
+	// the recipients are not supported by the http target
+	ok, _ = n.validateTargets(newPolicy(policy_model.EventTarget{
+		Type:       notifier_model.NotifyTypeHTTP,
+		Address:    "http://127.0.0.1",
+		Recipients: []string{"foo@example.com"},
+	}))
+	assert.False(t, ok)
+}
@@ -775,6 +775,10 @@ func (api *preheatAPI) GetPreheatLog(ctx context.Context, params operation.GetPr
 		return api.SendError(ctx, err)
 	}
 
+	if params.Follow != nil && *params.Follow {
+		return followTaskLog(ctx, params.HTTPRequest, api.taskCtl, params.TaskID)
+	}
+
 	l, err := api.taskCtl.GetLog(ctx, params.TaskID)
 	if err != nil {
 		return api.SendError(ctx, err)
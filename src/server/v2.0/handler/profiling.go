@@ -0,0 +1,107 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/pprof"
+	runtimepprof "runtime/pprof"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/profiling"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/profiling"
+)
+
+// the profiles served by the dedicated handlers of pprof rather than looked up from the runtime
+var pprofHandlers = map[string]http.HandlerFunc{
+	"cmdline": pprof.Cmdline,
+	"profile": pprof.Profile,
+	"symbol":  pprof.Symbol,
+	"trace":   pprof.Trace,
+}
+
+func newProfilingAPI() *profilingAPI {
+	return &profilingAPI{}
+}
+
+type profilingAPI struct {
+	BaseAPI
+}
+
+func (p *profilingAPI) Prepare(ctx context.Context, operation string, params interface{}) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceSystemProfiling); err != nil {
+		return p.SendError(ctx, err)
+	}
+	// hide the endpoints when the profiling isn't opted in
+	if !profiling.Enabled() {
+		return p.SendError(ctx, errors.NotFoundError(nil).WithMessage("the profiling isn't enabled"))
+	}
+	return nil
+}
+
+func (p *profilingAPI) GetProfilingReport(ctx context.Context, params operation.GetProfilingReportParams) middleware.Responder {
+	report := profiling.GetReport()
+	return operation.NewGetProfilingReportOK().WithPayload(&models.ProfilingReport{
+		Since:   strfmt.DateTime(report.Since),
+		Window:  report.Window,
+		Routes:  toProfilingEntries(report.Routes),
+		Queries: toProfilingEntries(report.Queries),
+	})
+}
+
+func (p *profilingAPI) ListPprofProfiles(ctx context.Context, params operation.ListPprofProfilesParams) middleware.Responder {
+	var profiles []*models.PprofProfile
+	for _, profile := range runtimepprof.Profiles() {
+		profiles = append(profiles, &models.PprofProfile{
+			Name:  profile.Name(),
+			Count: int64(profile.Count()),
+		})
+	}
+	return operation.NewListPprofProfilesOK().WithPayload(profiles)
+}
+
+func (p *profilingAPI) GetPprofProfile(ctx context.Context, params operation.GetPprofProfileParams) middleware.Responder {
+	handler, exist := pprofHandlers[params.ProfileName]
+	if !exist {
+		if runtimepprof.Lookup(params.ProfileName) == nil {
+			return p.SendError(ctx, errors.NotFoundError(nil).WithMessage("profile %s not found", params.ProfileName))
+		}
+		handler = pprof.Handler(params.ProfileName).ServeHTTP
+	}
+	// pprof reads the "debug" and "seconds" parameters from the request and sets the content type itself
+	return middleware.ResponderFunc(func(w http.ResponseWriter, _ runtime.Producer) {
+		handler(w, params.HTTPRequest)
+	})
+}
+
+func toProfilingEntries(entries []*profiling.Entry) []*models.ProfilingEntry {
+	var results []*models.ProfilingEntry
+	for _, entry := range entries {
+		results = append(results, &models.ProfilingEntry{
+			Name:  entry.Name,
+			Count: entry.Count,
+			MaxMs: entry.Max,
+			AvgMs: entry.Average,
+		})
+	}
+	return results
+}
@@ -0,0 +1,92 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/profiling"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	"github.com/goharbor/harbor/src/testing/mock"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+)
+
+type profilingTestSuite struct {
+	htesting.Suite
+}
+
+func (p *profilingTestSuite) SetupSuite() {
+	p.Config = &restapi.Config{ProfilingAPI: &profilingAPI{}}
+	p.Suite.SetupSuite()
+}
+
+func (p *profilingTestSuite) TestForbidden() {
+	p.Security.On("IsAuthenticated").Return(true).Twice()
+	p.Security.On("GetUsername").Return("user").Twice()
+	p.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(false).Twice()
+
+	res, err := p.Get("/system/profiling")
+	p.Require().NoError(err)
+	p.Equal(403, res.StatusCode)
+	res, err = p.Get("/system/pprof/heap")
+	p.Require().NoError(err)
+	p.Equal(403, res.StatusCode)
+}
+
+func (p *profilingTestSuite) TestProfiling() {
+	p.Security.On("IsAuthenticated").Return(true)
+	p.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true)
+
+	// disabled
+	res, err := p.Get("/system/profiling")
+	p.Require().NoError(err)
+	p.Equal(404, res.StatusCode)
+
+	profiling.Enable(time.Minute, 10)
+	profiling.Record(profiling.KindRoute, "GET listProjects", time.Second)
+	report := &models.ProfilingReport{}
+	res, err = p.GetJSON("/system/profiling", report)
+	p.Require().NoError(err)
+	p.Require().Equal(200, res.StatusCode)
+	p.Require().Len(report.Routes, 1)
+	p.Equal("GET listProjects", report.Routes[0].Name)
+	p.Equal(float64(1000), report.Routes[0].MaxMs)
+
+	var profiles []*models.PprofProfile
+	res, err = p.GetJSON("/system/pprof", &profiles)
+	p.Require().NoError(err)
+	p.Equal(200, res.StatusCode)
+	p.NotEmpty(profiles)
+
+	res, err = p.Get("/system/pprof/goroutine?debug=1")
+	p.Require().NoError(err)
+	p.Require().Equal(200, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	p.Require().NoError(err)
+	p.Contains(string(body), "goroutine profile")
+
+	res, err = p.Get("/system/pprof/unknown")
+	p.Require().NoError(err)
+	p.Equal(404, res.StatusCode)
+}
+
+func TestProfilingTestSuite(t *testing.T) {
+	suite.Run(t, &profilingTestSuite{})
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/goharbor/harbor/src/common/security"
 	"github.com/goharbor/harbor/src/common/security/local"
 	robotSec "github.com/goharbor/harbor/src/common/security/robot"
+	"github.com/goharbor/harbor/src/controller/configsync"
 	"github.com/goharbor/harbor/src/controller/p2p/preheat"
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/controller/quota"
@@ -36,7 +37,9 @@ import (
 	"github.com/goharbor/harbor/src/controller/repository"
 	"github.com/goharbor/harbor/src/controller/retention"
 	"github.com/goharbor/harbor/src/controller/scanner"
+	"github.com/goharbor/harbor/src/controller/statistic"
 	"github.com/goharbor/harbor/src/controller/user"
+	"github.com/goharbor/harbor/src/controller/usergroup"
 	"github.com/goharbor/harbor/src/core/api"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/config"
@@ -45,14 +48,22 @@ import (
 	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg"
+	"github.com/goharbor/harbor/src/pkg/activity"
+	activityModel "github.com/goharbor/harbor/src/pkg/activity/model"
 	"github.com/goharbor/harbor/src/pkg/audit"
-	"github.com/goharbor/harbor/src/pkg/member"
+	"github.com/goharbor/harbor/src/pkg/networkpolicy"
 	"github.com/goharbor/harbor/src/pkg/project/metadata"
 	pkgModels "github.com/goharbor/harbor/src/pkg/project/models"
+	"github.com/goharbor/harbor/src/pkg/project/readme"
+	readmeModel "github.com/goharbor/harbor/src/pkg/project/readme/model"
 	"github.com/goharbor/harbor/src/pkg/quota/types"
+	regModels "github.com/goharbor/harbor/src/pkg/reg/model"
 	"github.com/goharbor/harbor/src/pkg/retention/policy"
 	"github.com/goharbor/harbor/src/pkg/robot"
+	"github.com/goharbor/harbor/src/pkg/signature/cosign"
+	"github.com/goharbor/harbor/src/pkg/usage"
 	userModels "github.com/goharbor/harbor/src/pkg/user/models"
+	ugModel "github.com/goharbor/harbor/src/pkg/usergroup/model"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/project"
@@ -68,12 +79,17 @@ func newProjectAPI() *projectAPI {
 		userCtl:       user.Ctl,
 		repositoryCtl: repository.Ctl,
 		projectCtl:    project.Ctl,
-		memberMgr:     member.Mgr,
+		statisticCtl:  statistic.Ctl,
 		quotaCtl:      quota.Ctl,
 		robotMgr:      robot.Mgr,
 		preheatCtl:    preheat.Ctl,
 		retentionCtl:  retention.Ctl,
 		scannerCtl:    scanner.DefaultController,
+		userGroupCtl:  usergroup.Ctl,
+		readmeMgr:     readme.Mgr,
+		activityMgr:   activity.Mgr,
+		configSyncCtl: configsync.Ctl,
+		usageMgr:      usage.Mgr,
 	}
 }
 
@@ -84,12 +100,17 @@ type projectAPI struct {
 	userCtl       user.Controller
 	repositoryCtl repository.Controller
 	projectCtl    project.Controller
-	memberMgr     member.Manager
+	statisticCtl  statistic.Controller
 	quotaCtl      quota.Controller
 	robotMgr      robot.Manager
 	preheatCtl    preheat.Controller
 	retentionCtl  retention.Controller
 	scannerCtl    scanner.Controller
+	userGroupCtl  usergroup.Controller
+	readmeMgr     readme.Manager
+	activityMgr   activity.Manager
+	configSyncCtl configsync.Controller
+	usageMgr      usage.Manager
 }
 
 func (a *projectAPI) CreateProject(ctx context.Context, params operation.CreateProjectParams) middleware.Responder {
@@ -111,6 +132,11 @@ func (a *projectAPI) CreateProject(ctx context.Context, params operation.CreateP
 		log.Errorf("Only sys admin can create project")
 		return a.SendError(ctx, errors.ForbiddenError(nil).WithMessage("Only system admin can create project"))
 	}
+	if !onlyAdmin && !a.isSysAdmin(ctx, rbac.ActionCreate) {
+		if err := a.requireProjectCreationGroups(ctx, secCtx); err != nil {
+			return a.SendError(ctx, err)
+		}
+	}
 
 	req := params.Project
 
@@ -118,21 +144,33 @@ func (a *projectAPI) CreateProject(ctx context.Context, params operation.CreateP
 		return a.SendError(ctx, errors.ForbiddenError(nil).WithMessage("Only system admin can create proxy cache project"))
 	}
 
-	// populate storage limit
+	if req.Metadata != nil && req.Metadata.RegistryShard != nil && len(*req.Metadata.RegistryShard) > 0 &&
+		!a.isSysAdmin(ctx, rbac.ActionCreate) {
+		return a.SendError(ctx, errors.ForbiddenError(nil).WithMessage("Only system admin can assign the project to a registry shard"))
+	}
+
+	// populate storage and count limits
 	if config.QuotaPerProjectEnable(ctx) {
+		setting, err := config.QuotaSetting(ctx)
+		if err != nil {
+			log.Errorf("failed to get quota setting: %v", err)
+			return a.SendError(ctx, fmt.Errorf("failed to get quota setting: %v", err))
+		}
+		isSysAdmin := a.isSysAdmin(ctx, rbac.ActionCreate)
 		// the security context is not sys admin, set the StorageLimit the global StoragePerProject
-		if req.StorageLimit == nil || *req.StorageLimit == 0 || !a.isSysAdmin(ctx, rbac.ActionCreate) {
-			setting, err := config.QuotaSetting(ctx)
-			if err != nil {
-				log.Errorf("failed to get quota setting: %v", err)
-				return a.SendError(ctx, fmt.Errorf("failed to get quota setting: %v", err))
-			}
+		if req.StorageLimit == nil || *req.StorageLimit == 0 || !isSysAdmin {
 			defaultStorageLimit := setting.StoragePerProject
 			req.StorageLimit = &defaultStorageLimit
 		}
+		// the security context is not sys admin, set the CountLimit the global CountPerProject
+		if req.CountLimit == nil || *req.CountLimit == 0 || !isSysAdmin {
+			defaultCountLimit := setting.CountPerProject
+			req.CountLimit = &defaultCountLimit
+		}
 	} else {
-		// ignore storage limit when quota per project disabled
+		// ignore storage and count limits when quota per project disabled
 		req.StorageLimit = nil
+		req.CountLimit = nil
 	}
 
 	if req.Metadata == nil {
@@ -219,7 +257,7 @@ func (a *projectAPI) CreateProject(ctx context.Context, params operation.CreateP
 	// create the quota for the project
 	if req.StorageLimit != nil {
 		referenceID := quota.ReferenceID(projectID)
-		hardLimits := types.ResourceList{types.ResourceStorage: *req.StorageLimit}
+		hardLimits := projectHardLimits(req)
 		if _, err := a.quotaCtl.Create(ctx, quota.ProjectReference, referenceID, hardLimits); err != nil {
 			return a.SendError(ctx, fmt.Errorf("failed to create quota for project: %v", err))
 		}
@@ -254,6 +292,9 @@ func (a *projectAPI) DeleteProject(ctx context.Context, params operation.DeleteP
 	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionDelete); err != nil {
 		return a.SendError(ctx, err)
 	}
+	if err := a.configSyncCtl.CheckProject(ctx, projectNameOrID); err != nil {
+		return a.SendError(ctx, err)
+	}
 
 	p, result, err := a.deletable(ctx, projectNameOrID)
 	if err != nil {
@@ -288,6 +329,10 @@ func (a *projectAPI) DeleteProject(ctx context.Context, params operation.DeleteP
 		return a.SendError(ctx, err)
 	}
 
+	if err = a.readmeMgr.Delete(ctx, p.ProjectID); err != nil && !errors.IsNotFoundErr(err) {
+		return a.SendError(ctx, err)
+	}
+
 	return operation.NewDeleteProjectOK()
 }
 
@@ -299,7 +344,7 @@ func (a *projectAPI) GetLogs(ctx context.Context, params operation.GetLogsParams
 	if err != nil {
 		return a.SendError(ctx, err)
 	}
-	query, err := a.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	query, err := a.BuildCursorQuery(ctx, params.Q, params.Sort, params.Cursor, params.Page, params.PageSize)
 	if err != nil {
 		return a.SendError(ctx, err)
 	}
@@ -327,7 +372,7 @@ func (a *projectAPI) GetLogs(ctx context.Context, params operation.GetLogsParams
 	}
 	return operation.NewGetLogsOK().
 		WithXTotalCount(total).
-		WithLink(a.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithLink(a.CursorLinks(ctx, params.HTTPRequest.URL, total, query).String()).
 		WithPayload(auditLogs)
 }
 
@@ -370,9 +415,24 @@ func (a *projectAPI) GetProjectSummary(ctx context.Context, params operation.Get
 		return a.SendError(ctx, err)
 	}
 
+	// the counts are read from the pre-computed statistic of the project
+	st, err := a.statisticCtl.Get(ctx, p.ProjectID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
 	summary := &models.ProjectSummary{
 		ChartCount: int64(p.ChartCount),
-		RepoCount:  p.RepoCount,
+		RepoCount:  st.RepoCount,
+		TagCount:   st.TagCount,
+	}
+
+	if hasPerm := a.HasProjectPermission(ctx, p.ProjectID, rbac.ActionList, rbac.ResourceMember); hasPerm {
+		summary.ProjectAdminCount = st.ProjectAdminCount
+		summary.MaintainerCount = st.MaintainerCount
+		summary.DeveloperCount = st.DeveloperCount
+		summary.GuestCount = st.GuestCount
+		summary.LimitedGuestCount = st.LimitedGuestCount
 	}
 
 	var fetchSummaries []func(context.Context, *project.Project, *models.ProjectSummary)
@@ -381,10 +441,6 @@ func (a *projectAPI) GetProjectSummary(ctx context.Context, params operation.Get
 		fetchSummaries = append(fetchSummaries, getProjectQuotaSummary)
 	}
 
-	if hasPerm := a.HasProjectPermission(ctx, p.ProjectID, rbac.ActionList, rbac.ResourceMember); hasPerm {
-		fetchSummaries = append(fetchSummaries, a.getProjectMemberSummary)
-	}
-
 	if p.IsProxy() {
 		fetchSummaries = append(fetchSummaries, getProjectRegistrySummary)
 	}
@@ -404,6 +460,122 @@ func (a *projectAPI) GetProjectSummary(ctx context.Context, params operation.Get
 	return operation.NewGetProjectSummaryOK().WithPayload(summary)
 }
 
+func (a *projectAPI) ListProjectActivities(ctx context.Context, params operation.ListProjectActivitiesParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionList, rbac.ResourceLog); err != nil {
+		return a.SendError(ctx, err)
+	}
+	activityTypes, err := parseActivityTypes(lib.StringValue(params.Type))
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	page, pageSize := lib.Int64Value(params.Page), lib.Int64Value(params.PageSize)
+	query := &activityModel.Query{ProjectIDs: []int64{p.ProjectID}, Types: activityTypes}
+	total, activities, err := a.activityMgr.List(ctx, query, page, pageSize)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewListProjectActivitiesOK().
+		WithXTotalCount(total).
+		WithLink(a.Links(ctx, params.HTTPRequest.URL, total, page, pageSize).String()).
+		WithPayload(toActivitiesSwagger(activities))
+}
+
+// GetProjectUsage requires the permission to list the logs of the project as the usage tells who pulls and pushes the artifacts
+func (a *projectAPI) GetProjectUsage(ctx context.Context, params operation.GetProjectUsageParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionList, rbac.ResourceLog); err != nil {
+		return a.SendError(ctx, err)
+	}
+	from, to, err := parseUsageDays(params.From, params.To)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	report, err := a.usageMgr.GetProjectReport(ctx, p.ProjectID, from, to)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+	return operation.NewGetProjectUsageOK().WithPayload(toProjectUsageSwagger(report))
+}
+
+func (a *projectAPI) GetProjectReadme(ctx context.Context, params operation.GetProjectReadmeParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionRead); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	rd, err := a.readmeMgr.Get(ctx, p.ProjectID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewGetProjectReadmeOK().WithPayload(&models.ProjectReadme{
+		Content:    rd.Content,
+		UpdatedBy:  rd.UpdatedBy,
+		UpdateTime: strfmt.DateTime(rd.UpdateTime),
+	})
+}
+
+func (a *projectAPI) UpdateProjectReadme(ctx context.Context, params operation.UpdateProjectReadmeParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionUpdate); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	rd := &readmeModel.Readme{
+		ProjectID: p.ProjectID,
+		Content:   params.Readme.Content,
+	}
+	if secCtx, ok := security.FromContext(ctx); ok {
+		rd.UpdatedBy = secCtx.GetUsername()
+	}
+	if err := a.readmeMgr.Set(ctx, rd); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewUpdateProjectReadmeOK()
+}
+
+func (a *projectAPI) DeleteProjectReadme(ctx context.Context, params operation.DeleteProjectReadmeParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionUpdate); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	p, err := a.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	if err := a.readmeMgr.Delete(ctx, p.ProjectID); err != nil {
+		return a.SendError(ctx, err)
+	}
+
+	return operation.NewDeleteProjectReadmeOK()
+}
+
 func (a *projectAPI) HeadProject(ctx context.Context, params operation.HeadProjectParams) middleware.Responder {
 	if err := a.RequireAuthenticated(ctx); err != nil {
 		return a.SendError(ctx, err)
@@ -421,6 +593,10 @@ func (a *projectAPI) ListProjects(ctx context.Context, params operation.ListProj
 	if err != nil {
 		return a.SendError(ctx, err)
 	}
+	fields, err := newFieldSelector(params.Fields, models.Project{})
+	if err != nil {
+		return a.SendError(ctx, err)
+	}
 
 	if name := lib.StringValue(params.Name); name != "" {
 		query.Keywords["name"] = &q.FuzzyMatchValue{Value: name}
@@ -495,7 +671,15 @@ func (a *projectAPI) ListProjects(ctx context.Context, params operation.ListProj
 		return operation.NewListProjectsOK().WithXTotalCount(0).WithPayload([]*models.Project{})
 	}
 
-	projects, err := a.projectCtl.List(ctx, query, project.Detail(lib.BoolValue(params.WithDetail)), project.WithCVEAllowlist(), project.WithOwner())
+	// skip populating the properties that aren't selected
+	options := []project.Option{project.Detail(lib.BoolValue(params.WithDetail)), project.Metadata(fields.Selected("metadata"))}
+	if fields.Selected("cve_allowlist") {
+		options = append(options, project.WithCVEAllowlist())
+	}
+	if fields.Selected("owner_name") {
+		options = append(options, project.WithOwner())
+	}
+	projects, err := a.projectCtl.List(ctx, query, options...)
 	if err != nil {
 		return a.SendError(ctx, err)
 	}
@@ -506,7 +690,7 @@ func (a *projectAPI) ListProjects(ctx context.Context, params operation.ListProj
 		go func(p *project.Project) {
 			defer wg.Done()
 			// simultaneous queries in transaction will fail, so clone a ctx with new ormer here
-			if err := a.populateProperties(orm.Clone(ctx), p); err != nil {
+			if err := a.populateProperties(orm.Clone(ctx), p, fields); err != nil {
 				log.G(ctx).Errorf("failed to populate properties for project %s, error: %v", p.Name, err)
 			}
 		}(p)
@@ -518,10 +702,10 @@ func (a *projectAPI) ListProjects(ctx context.Context, params operation.ListProj
 		payload = append(payload, model.NewProject(p).ToSwagger())
 	}
 
-	return operation.NewListProjectsOK().
+	return fields.Shape(operation.NewListProjectsOK().
 		WithXTotalCount(total).
 		WithLink(a.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
-		WithPayload(payload)
+		WithPayload(payload))
 }
 
 func (a *projectAPI) UpdateProject(ctx context.Context, params operation.UpdateProjectParams) middleware.Responder {
@@ -529,6 +713,9 @@ func (a *projectAPI) UpdateProject(ctx context.Context, params operation.UpdateP
 	if err := a.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionUpdate); err != nil {
 		return a.SendError(ctx, err)
 	}
+	if err := a.configSyncCtl.CheckProject(ctx, projectNameOrID); err != nil {
+		return a.SendError(ctx, err)
+	}
 
 	p, err := a.projectCtl.Get(ctx, projectNameOrID, project.Metadata(false))
 	if err != nil {
@@ -554,6 +741,14 @@ func (a *projectAPI) UpdateProject(ctx context.Context, params operation.UpdateP
 	if params.Project.Metadata != nil && p.IsProxy() {
 		params.Project.Metadata.EnableContentTrust = nil
 	}
+	if err := validateProjectMetadata(params.Project.Metadata); err != nil {
+		return a.SendError(ctx, err)
+	}
+	if params.Project.Metadata != nil && params.Project.Metadata.RegistryShard != nil {
+		if err := a.validateRegistryShardChange(ctx, p, *params.Project.Metadata.RegistryShard); err != nil {
+			return a.SendError(ctx, err)
+		}
+	}
 	if err := lib.JSONCopy(&p.Metadata, params.Project.Metadata); err != nil {
 		log.Warningf("failed to call JSONCopy on project metadata when UpdateProject, error: %v", err)
 	}
@@ -664,8 +859,14 @@ func (a *projectAPI) deletable(ctx context.Context, projectNameOrID interface{})
 		return nil, nil, err
 	}
 
+	// count the repositories rather than reading the statistic which may lag behind
+	repoCount, err := a.repositoryCtl.Count(ctx, q.New(q.KeyWords{"project_id": p.ProjectID}))
+	if err != nil {
+		return nil, nil, err
+	}
+
 	result := &models.ProjectDeletable{Deletable: true}
-	if p.RepoCount > 0 {
+	if repoCount > 0 {
 		result.Deletable = false
 		result.Message = "the project contains repositories, can not be deleted"
 	} else if p.ChartCount > 0 {
@@ -682,7 +883,7 @@ func (a *projectAPI) getProject(ctx context.Context, projectNameOrID interface{}
 		return nil, err
 	}
 
-	if err := a.populateProperties(ctx, p); err != nil {
+	if err := a.populateProperties(ctx, p, nil); err != nil {
 		return nil, err
 	}
 
@@ -694,6 +895,10 @@ func (a *projectAPI) validateProjectReq(ctx context.Context, req *models.Project
 		return errors.BadRequestError(fmt.Errorf("the retention_id in the request's payload when creating a project should be omitted, alternatively passing an empty string"))
 	}
 
+	if err := validateProjectMetadata(req.Metadata); err != nil {
+		return err
+	}
+
 	if req.RegistryID != nil {
 		if *req.RegistryID <= 0 {
 			return errors.BadRequestError(fmt.Errorf("%d is invalid value of registry_id, it should be geater than 0", *req.RegistryID))
@@ -716,8 +921,7 @@ func (a *projectAPI) validateProjectReq(ctx context.Context, req *models.Project
 	}
 
 	if req.StorageLimit != nil {
-		hardLimits := types.ResourceList{types.ResourceStorage: *req.StorageLimit}
-		if err := quota.Validate(ctx, quota.ProjectReference, hardLimits); err != nil {
+		if err := quota.Validate(ctx, quota.ProjectReference, projectHardLimits(req)); err != nil {
 			return errors.BadRequestError(err)
 		}
 	}
@@ -725,8 +929,114 @@ func (a *projectAPI) validateProjectReq(ctx context.Context, req *models.Project
 	return nil
 }
 
-func (a *projectAPI) populateProperties(ctx context.Context, p *project.Project) error {
-	if secCtx, ok := security.FromContext(ctx); ok {
+// projectHardLimits returns the hard limits of the quota of the project to create, the count is unlimited if it's absent
+func projectHardLimits(req *models.ProjectReq) types.ResourceList {
+	hardLimits := types.ResourceList{
+		types.ResourceStorage: *req.StorageLimit,
+		types.ResourceCount:   types.UNLIMITED,
+	}
+	if req.CountLimit != nil {
+		hardLimits[types.ResourceCount] = *req.CountLimit
+	}
+	return hardLimits
+}
+
+// validateRegistryShardChange checks the registry shard of the project is changed by the system admin before
+// any repository is pushed into the project, as the existing content isn't moved between the registries
+func (a *projectAPI) validateRegistryShardChange(ctx context.Context, p *project.Project, shard string) error {
+	metas, err := a.metadataMgr.Get(ctx, p.ProjectID, pkgModels.ProMetaRegistryShard)
+	if err != nil {
+		return err
+	}
+	if metas[pkgModels.ProMetaRegistryShard] == shard {
+		return nil
+	}
+	if !a.isSysAdmin(ctx, rbac.ActionUpdate) {
+		return errors.ForbiddenError(nil).WithMessage("Only system admin can change the registry shard of the project")
+	}
+	count, err := a.repositoryCtl.Count(ctx, q.New(q.KeyWords{"project_id": p.ProjectID}))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.PreconditionFailedError(nil).WithMessage("the registry shard of the project %s can't be changed as it contains repositories", p.Name)
+	}
+	return nil
+}
+
+// validateProjectMetadata checks the cosign trusted keys in the metadata are valid PEM encoded public keys,
+// the content trust policy is supported, the TTLs of the proxy cache are non-negative seconds, the network policy
+// consists of the valid CIDRs and the registry shard is configured
+func validateProjectMetadata(metadata *models.ProjectMetadata) error {
+	if metadata == nil {
+		return nil
+	}
+	if keys := metadata.CosignTrustedKeys; keys != nil && len(*keys) > 0 {
+		if _, err := cosign.ParsePublicKeys(*keys); err != nil {
+			return errors.BadRequestError(nil).WithMessage("invalid cosign_trusted_keys: %v", err)
+		}
+	}
+	if policy := metadata.ContentTrustPolicy; policy != nil && len(*policy) > 0 &&
+		*policy != pkgModels.ContentTrustPolicyAll && *policy != pkgModels.ContentTrustPolicyAny {
+		return errors.BadRequestError(nil).WithMessage("invalid content_trust_policy: %s, it should be %q or %q",
+			*policy, pkgModels.ContentTrustPolicyAll, pkgModels.ContentTrustPolicyAny)
+	}
+	if mode := metadata.ProxyForeignLayerMode; mode != nil && len(*mode) > 0 &&
+		*mode != regModels.ForeignLayerModeSkip && *mode != regModels.ForeignLayerModePullThrough {
+		return errors.BadRequestError(nil).WithMessage("invalid proxy_foreign_layer_mode: %s, it should be %q or %q",
+			*mode, regModels.ForeignLayerModeSkip, regModels.ForeignLayerModePullThrough)
+	}
+	if listed := metadata.ListedInCatalog; listed != nil && len(*listed) > 0 && *listed != "true" && *listed != "false" {
+		return errors.BadRequestError(nil).WithMessage("invalid listed_in_catalog: %s, it should be 'true' or 'false'", *listed)
+	}
+	if quarantine := metadata.Quarantine; quarantine != nil && len(*quarantine) > 0 && *quarantine != "true" && *quarantine != "false" {
+		return errors.BadRequestError(nil).WithMessage("invalid quarantine: %s, it should be 'true' or 'false'", *quarantine)
+	}
+	if role := metadata.PromotionApproverRole; role != nil && len(*role) > 0 && !isPromotionApproverRole(*role) {
+		return errors.BadRequestError(nil).WithMessage("invalid promotion_approver_role: %s, it should be %q, %q or %q", *role,
+			pkgModels.PromotionApproverProjectAdmin, pkgModels.PromotionApproverMaintainer, pkgModels.PromotionApproverDeveloper)
+	}
+	policy := &networkpolicy.Policy{}
+	if metadata.IPAllowlist != nil {
+		policy.Allowlist = *metadata.IPAllowlist
+	}
+	if metadata.IPDenylist != nil {
+		policy.Denylist = *metadata.IPDenylist
+	}
+	if err := policy.Validate(); err != nil {
+		return errors.BadRequestError(nil).WithMessage("invalid network policy: %v", err)
+	}
+	if shard := metadata.RegistryShard; shard != nil && len(*shard) > 0 {
+		if _, exist := config.RegistryShards()[*shard]; !exist {
+			return errors.BadRequestError(nil).WithMessage("invalid registry_shard: %s, it isn't configured", *shard)
+		}
+	}
+	for name, ttl := range map[string]*string{
+		pkgModels.ProMetaProxyCacheTTL:         metadata.ProxyCacheTTL,
+		pkgModels.ProMetaProxyNegativeCacheTTL: metadata.ProxyNegativeCacheTTL,
+	} {
+		if ttl == nil || len(*ttl) == 0 {
+			continue
+		}
+		if v, err := strconv.ParseInt(*ttl, 10, 64); err != nil || v < 0 {
+			return errors.BadRequestError(nil).WithMessage("invalid %s: %s, it should be the non-negative seconds", name, *ttl)
+		}
+	}
+	return nil
+}
+
+func isPromotionApproverRole(role string) bool {
+	switch role {
+	case pkgModels.PromotionApproverProjectAdmin, pkgModels.PromotionApproverMaintainer, pkgModels.PromotionApproverDeveloper:
+		return true
+	default:
+		return false
+	}
+}
+
+// populateProperties populates the properties of the project that are selected by the "fields"
+func (a *projectAPI) populateProperties(ctx context.Context, p *project.Project, fields fieldSelector) error {
+	if secCtx, ok := security.FromContext(ctx); ok && fields.Selected("current_user_role_id", "current_user_role_ids") {
 		if sc, ok := secCtx.(*local.SecurityContext); ok {
 			roles, err := a.projectCtl.ListRoles(ctx, p.ProjectID, sc.User())
 			if err != nil {
@@ -737,14 +1047,16 @@ func (a *projectAPI) populateProperties(ctx context.Context, p *project.Project)
 		}
 	}
 
-	total, err := a.repositoryCtl.Count(ctx, q.New(q.KeyWords{"project_id": p.ProjectID}))
-	if err != nil {
-		return err
+	if fields.Selected("repo_count") {
+		st, err := a.statisticCtl.Get(ctx, p.ProjectID)
+		if err != nil {
+			return err
+		}
+		p.RepoCount = st.RepoCount
 	}
-	p.RepoCount = total
 
 	// Populate chart count property
-	if config.WithChartMuseum() {
+	if config.WithChartMuseum() && fields.Selected("chart_count") {
 		count, err := api.GetChartController().GetCountOfCharts([]string{p.Name})
 		if err != nil {
 			err = errors.Wrap(err, fmt.Sprintf("get chart count of project %d failed", p.ProjectID))
@@ -756,6 +1068,47 @@ func (a *projectAPI) populateProperties(ctx context.Context, p *project.Project)
 	return nil
 }
 
+// requireProjectCreationGroups checks whether the user is the member of the groups allowed to create projects
+func (a *projectAPI) requireProjectCreationGroups(ctx context.Context, secCtx security.Context) error {
+	allowed := config.ProjectCreationGroups(ctx)
+	if len(allowed) == 0 {
+		return nil
+	}
+	// the robots and the solution users are controlled by their own permissions
+	lsc, ok := secCtx.(*local.SecurityContext)
+	if !ok || lsc.User() == nil {
+		return nil
+	}
+	var groups []*ugModel.UserGroup
+	if ids := lsc.User().GroupIDs; len(ids) > 0 {
+		values := make([]interface{}, 0, len(ids))
+		for _, id := range ids {
+			values = append(values, id)
+		}
+		var err error
+		groups, err = a.userGroupCtl.List(ctx, q.New(q.KeyWords{"ID": &q.OrList{Values: values}}))
+		if err != nil {
+			return err
+		}
+	}
+	if !inGroups(groups, allowed) {
+		return errors.ForbiddenError(nil).WithMessage("Only the members of the groups %s can create project", strings.Join(allowed, "; "))
+	}
+	return nil
+}
+
+// inGroups returns whether any of the groups matches the names or LDAP DNs
+func inGroups(groups []*ugModel.UserGroup, names []string) bool {
+	for _, g := range groups {
+		for _, name := range names {
+			if g.GroupName == name || (len(g.LdapGroupDN) > 0 && strings.EqualFold(g.LdapGroupDN, name)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (a *projectAPI) isSysAdmin(ctx context.Context, action rbac.Action) bool {
 	if err := a.RequireSystemAccess(ctx, action, rbac.ResourceProject); err != nil {
 		return false
@@ -784,35 +1137,6 @@ func getProjectQuotaSummary(ctx context.Context, p *project.Project, summary *mo
 	}
 }
 
-func (a *projectAPI) getProjectMemberSummary(ctx context.Context, p *project.Project, summary *models.ProjectSummary) {
-	var wg sync.WaitGroup
-
-	for _, e := range []struct {
-		role  int
-		count *int64
-	}{
-		{common.RoleProjectAdmin, &summary.ProjectAdminCount},
-		{common.RoleMaintainer, &summary.MaintainerCount},
-		{common.RoleDeveloper, &summary.DeveloperCount},
-		{common.RoleGuest, &summary.GuestCount},
-		{common.RoleLimitedGuest, &summary.LimitedGuestCount},
-	} {
-		wg.Add(1)
-		go func(role int, count *int64) {
-			defer wg.Done()
-			total, err := a.memberMgr.GetTotalOfProjectMembers(orm.Clone(ctx), p.ProjectID, nil, role)
-			if err != nil {
-				log.Warningf("failed to get total of project members of role %d", role)
-				return
-			}
-
-			*count = int64(total)
-		}(e.role, e.count)
-	}
-
-	wg.Wait()
-}
-
 func getProjectRegistrySummary(ctx context.Context, p *project.Project, summary *models.ProjectSummary) {
 	if p.RegistryID <= 0 {
 		return
@@ -22,25 +22,31 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 
 	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/configsync"
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/controller/project/metadata"
 	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/networkpolicy"
 	proModels "github.com/goharbor/harbor/src/pkg/project/models"
+	regModels "github.com/goharbor/harbor/src/pkg/reg/model"
 	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	"github.com/goharbor/harbor/src/pkg/signature/cosign"
 	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/project_metadata"
 )
 
 func newProjectMetadaAPI() *projectMetadataAPI {
 	return &projectMetadataAPI{
-		ctl:    metadata.Ctl,
-		proCtl: project.Ctl,
+		ctl:           metadata.Ctl,
+		proCtl:        project.Ctl,
+		configSyncCtl: configsync.Ctl,
 	}
 }
 
 type projectMetadataAPI struct {
 	BaseAPI
-	ctl    metadata.Controller
-	proCtl project.Controller
+	ctl           metadata.Controller
+	proCtl        project.Controller
+	configSyncCtl configsync.Controller
 }
 
 func (p *projectMetadataAPI) AddProjectMetadatas(ctx context.Context, params operation.AddProjectMetadatasParams) middleware.Responder {
@@ -48,6 +54,9 @@ func (p *projectMetadataAPI) AddProjectMetadatas(ctx context.Context, params ope
 	if err := p.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionCreate, rbac.ResourceMetadata); err != nil {
 		return p.SendError(ctx, err)
 	}
+	if err := p.configSyncCtl.CheckProject(ctx, projectNameOrID); err != nil {
+		return p.SendError(ctx, err)
+	}
 	metadata := params.Metadata
 	metadata, err := p.validate(metadata)
 	if err != nil {
@@ -84,6 +93,9 @@ func (p *projectMetadataAPI) DeleteProjectMetadata(ctx context.Context, params o
 	if err := p.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionDelete, rbac.ResourceMetadata); err != nil {
 		return p.SendError(ctx, err)
 	}
+	if err := p.configSyncCtl.CheckProject(ctx, projectNameOrID); err != nil {
+		return p.SendError(ctx, err)
+	}
 	project, err := p.proCtl.Get(ctx, projectNameOrID)
 	if err != nil {
 		return p.SendError(ctx, err)
@@ -115,6 +127,9 @@ func (p *projectMetadataAPI) UpdateProjectMetadata(ctx context.Context, params o
 	if err := p.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionUpdate, rbac.ResourceMetadata); err != nil {
 		return p.SendError(ctx, err)
 	}
+	if err := p.configSyncCtl.CheckProject(ctx, projectNameOrID); err != nil {
+		return p.SendError(ctx, err)
+	}
 	metadata := map[string]string{
 		params.MetaName: params.Metadata[params.MetaName],
 	}
@@ -143,12 +158,41 @@ func (p *projectMetadataAPI) validate(metas map[string]string) (map[string]strin
 
 	switch key {
 	case proModels.ProMetaPublic, proModels.ProMetaEnableContentTrust, proModels.ProMetaEnableContentTrustCosign,
-		proModels.ProMetaPreventVul, proModels.ProMetaAutoScan, proModels.ProMetaReuseSysCVEAllowlist:
+		proModels.ProMetaPreventVul, proModels.ProMetaAutoScan, proModels.ProMetaReuseSysCVEAllowlist,
+		proModels.ProMetaEnableChartRepository, proModels.ProMetaListedInCatalog, proModels.ProMetaQuarantine:
 		v, err := strconv.ParseBool(value)
 		if err != nil {
 			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("invalid value: %s", value)
 		}
 		metas[key] = strconv.FormatBool(v)
+	case proModels.ProMetaCosignTrustedKeys:
+		if len(value) > 0 {
+			if _, err := cosign.ParsePublicKeys(value); err != nil {
+				return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("invalid value: %v", err)
+			}
+		}
+	case proModels.ProMetaContentTrustPolicy:
+		if value != proModels.ContentTrustPolicyAll && value != proModels.ContentTrustPolicyAny {
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("invalid value: %s", value)
+		}
+	case proModels.ProMetaPromotionApproverRole:
+		if !isPromotionApproverRole(value) {
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("invalid value: %s", value)
+		}
+	case proModels.ProMetaProxyForeignLayerMode:
+		if value != regModels.ForeignLayerModeSkip && value != regModels.ForeignLayerModePullThrough {
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("invalid value: %s", value)
+		}
+	case proModels.ProMetaIPAllowlist, proModels.ProMetaIPDenylist:
+		if _, err := networkpolicy.ParseCIDRs(value); err != nil {
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("invalid value: %v", err)
+		}
+	case proModels.ProMetaProxyCacheTTL, proModels.ProMetaProxyNegativeCacheTTL:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || v < 0 {
+			return nil, errors.New(nil).WithCode(errors.BadRequestCode).WithMessage("invalid value: %s", value)
+		}
+		metas[key] = strconv.FormatInt(v, 10)
 	case proModels.ProMetaSeverity:
 		severity := vuln.ParseSeverityVersion3(strings.ToLower(value))
 		if severity == vuln.Unknown {
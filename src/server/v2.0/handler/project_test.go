@@ -18,24 +18,34 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/goharbor/harbor/src/lib/errors"
+	activityModel "github.com/goharbor/harbor/src/pkg/activity/model"
 	"github.com/goharbor/harbor/src/pkg/project/models"
+	readmeModel "github.com/goharbor/harbor/src/pkg/project/readme/model"
 	"github.com/goharbor/harbor/src/pkg/scan/dao/scanner"
+	ugModel "github.com/goharbor/harbor/src/pkg/usergroup/model"
+	models2 "github.com/goharbor/harbor/src/server/v2.0/models"
 	"github.com/goharbor/harbor/src/server/v2.0/restapi"
 	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
 	scannertesting "github.com/goharbor/harbor/src/testing/controller/scanner"
 	"github.com/goharbor/harbor/src/testing/mock"
+	activitytesting "github.com/goharbor/harbor/src/testing/pkg/activity"
+	readmetesting "github.com/goharbor/harbor/src/testing/pkg/project/readme"
 	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
 )
 
 type ProjectTestSuite struct {
 	htesting.Suite
 
-	projectCtl *projecttesting.Controller
-	scannerCtl *scannertesting.Controller
-	project    *models.Project
-	reg        *scanner.Registration
+	projectCtl  *projecttesting.Controller
+	scannerCtl  *scannertesting.Controller
+	readmeMgr   *readmetesting.Manager
+	activityMgr *activitytesting.Manager
+	project     *models.Project
+	reg         *scanner.Registration
 }
 
 func (suite *ProjectTestSuite) SetupSuite() {
@@ -52,11 +62,15 @@ func (suite *ProjectTestSuite) SetupSuite() {
 
 	suite.projectCtl = &projecttesting.Controller{}
 	suite.scannerCtl = &scannertesting.Controller{}
+	suite.readmeMgr = &readmetesting.Manager{}
+	suite.activityMgr = &activitytesting.Manager{}
 
 	suite.Config = &restapi.Config{
 		ProjectAPI: &projectAPI{
-			projectCtl: suite.projectCtl,
-			scannerCtl: suite.scannerCtl,
+			projectCtl:  suite.projectCtl,
+			scannerCtl:  suite.scannerCtl,
+			readmeMgr:   suite.readmeMgr,
+			activityMgr: suite.activityMgr,
 		},
 	}
 
@@ -111,6 +125,74 @@ func (suite *ProjectTestSuite) TestGetScannerOfProject() {
 	}
 }
 
+func (suite *ProjectTestSuite) TestProjectReadme() {
+	times := 3
+	suite.Security.On("IsAuthenticated").Return(true).Times(times)
+	suite.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true).Times(times)
+	suite.Security.On("GetUsername").Return("admin").Once()
+
+	{
+		// the project has no README
+		mock.OnAnything(suite.projectCtl, "Get").Return(suite.project, nil).Once()
+		mock.OnAnything(suite.readmeMgr, "Get").Return(nil, errors.NotFoundError(nil)).Once()
+
+		res, err := suite.Get("/projects/1/readme")
+		suite.NoError(err)
+		suite.Equal(404, res.StatusCode)
+	}
+
+	{
+		mock.OnAnything(suite.projectCtl, "Get").Return(suite.project, nil).Once()
+		suite.readmeMgr.On("Set", mock.Anything, &readmeModel.Readme{ProjectID: 1, Content: "# library", UpdatedBy: "admin"}).Return(nil).Once()
+
+		res, err := suite.PutJSON("/projects/1/readme", &models2.ProjectReadme{Content: "# library"})
+		suite.NoError(err)
+		suite.Equal(200, res.StatusCode)
+	}
+
+	{
+		mock.OnAnything(suite.projectCtl, "Get").Return(suite.project, nil).Once()
+		mock.OnAnything(suite.readmeMgr, "Get").Return(&readmeModel.Readme{ProjectID: 1, Content: "# library", UpdatedBy: "admin"}, nil).Once()
+
+		var readme models2.ProjectReadme
+		res, err := suite.GetJSON("/projects/1/readme", &readme)
+		suite.NoError(err)
+		suite.Equal(200, res.StatusCode)
+		suite.Equal("# library", readme.Content)
+		suite.Equal("admin", readme.UpdatedBy)
+	}
+}
+
+func (suite *ProjectTestSuite) TestListProjectActivities() {
+	times := 2
+	suite.Security.On("IsAuthenticated").Return(true).Times(times)
+	suite.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true).Times(times)
+
+	{
+		// invalid type
+		res, err := suite.Get("/projects/1/activity?type=unknown")
+		suite.NoError(err)
+		suite.Equal(400, res.StatusCode)
+	}
+
+	{
+		mock.OnAnything(suite.projectCtl, "Get").Return(suite.project, nil).Once()
+		query := &activityModel.Query{ProjectIDs: []int64{1}, Types: []string{activityModel.TypePush, activityModel.TypeScan}}
+		suite.activityMgr.On("List", mock.Anything, query, int64(1), int64(10)).Return(int64(1), []*activityModel.Activity{
+			{Type: activityModel.TypePush, ProjectID: 1, Resource: "library/hello-world:latest", Operation: "create", Operator: "admin"},
+		}, nil).Once()
+
+		var activities []*models2.Activity
+		res, err := suite.GetJSON("/projects/1/activity?type=push,scan", &activities)
+		suite.NoError(err)
+		suite.Require().Equal(200, res.StatusCode)
+		suite.Equal("1", res.Header.Get("X-Total-Count"))
+		suite.Require().Len(activities, 1)
+		suite.Equal(activityModel.TypePush, activities[0].Type)
+		suite.Equal("admin", activities[0].Operator)
+	}
+}
+
 func (suite *ProjectTestSuite) TestListScannerCandidatesOfProject() {
 	times := 4
 	suite.Security.On("IsAuthenticated").Return(true).Times(times)
@@ -197,6 +279,54 @@ func (suite *ProjectTestSuite) TestSetScannerOfProject() {
 	}
 }
 
+func TestValidateProjectMetadata(t *testing.T) {
+	assert.Nil(t, validateProjectMetadata(nil))
+	for _, policy := range []string{"", models.ContentTrustPolicyAll, models.ContentTrustPolicyAny} {
+		assert.Nil(t, validateProjectMetadata(&models2.ProjectMetadata{ContentTrustPolicy: &policy}))
+	}
+	policy := "none"
+	assert.NotNil(t, validateProjectMetadata(&models2.ProjectMetadata{ContentTrustPolicy: &policy}))
+	ttl := "-1"
+	assert.NotNil(t, validateProjectMetadata(&models2.ProjectMetadata{ProxyCacheTTL: &ttl}))
+	mode := "pull_through"
+	assert.Nil(t, validateProjectMetadata(&models2.ProjectMetadata{ProxyForeignLayerMode: &mode}))
+	mode = "rewrite"
+	assert.NotNil(t, validateProjectMetadata(&models2.ProjectMetadata{ProxyForeignLayerMode: &mode}))
+	t.Setenv("REGISTRY_SHARDS", "large=http://registry-large:5000")
+	for _, shard := range []string{"", "large"} {
+		assert.Nil(t, validateProjectMetadata(&models2.ProjectMetadata{RegistryShard: &shard}))
+	}
+	shard := "small"
+	assert.NotNil(t, validateProjectMetadata(&models2.ProjectMetadata{RegistryShard: &shard}))
+	cidrs := "10.0.0.0/8,192.168.1.1"
+	assert.Nil(t, validateProjectMetadata(&models2.ProjectMetadata{IPAllowlist: &cidrs, IPDenylist: &cidrs}))
+	cidrs = "10.0.0.0/33"
+	assert.NotNil(t, validateProjectMetadata(&models2.ProjectMetadata{IPDenylist: &cidrs}))
+	listed := "true"
+	assert.Nil(t, validateProjectMetadata(&models2.ProjectMetadata{ListedInCatalog: &listed}))
+	listed = "yes"
+	assert.NotNil(t, validateProjectMetadata(&models2.ProjectMetadata{ListedInCatalog: &listed}))
+	role := "maintainer"
+	assert.Nil(t, validateProjectMetadata(&models2.ProjectMetadata{PromotionApproverRole: &role}))
+	role = "guest"
+	assert.NotNil(t, validateProjectMetadata(&models2.ProjectMetadata{PromotionApproverRole: &role}))
+	quarantine := "true"
+	assert.Nil(t, validateProjectMetadata(&models2.ProjectMetadata{Quarantine: &quarantine}))
+	quarantine = "on"
+	assert.NotNil(t, validateProjectMetadata(&models2.ProjectMetadata{Quarantine: &quarantine}))
+}
+
+func TestInGroups(t *testing.T) {
+	groups := []*ugModel.UserGroup{
+		{GroupName: "developers"},
+		{GroupName: "ops", LdapGroupDN: "cn=ops,ou=groups,dc=example,dc=com"},
+	}
+	assert.False(t, inGroups(nil, []string{"developers"}))
+	assert.False(t, inGroups(groups, []string{"Developers", "admins"}))
+	assert.True(t, inGroups(groups, []string{"admins", "developers"}))
+	assert.True(t, inGroups(groups, []string{"CN=ops,OU=groups,DC=example,DC=com"}))
+}
+
 func TestProjectTestSuite(t *testing.T) {
 	suite.Run(t, &ProjectTestSuite{})
 }
@@ -0,0 +1,229 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	commonmodels "github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/common/security/local"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/promotion"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/promotion/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/promotion"
+)
+
+func newPromotionAPI() *promotionAPI {
+	return &promotionAPI{
+		promotionCtl: promotion.Ctl,
+		projectCtl:   project.Ctl,
+	}
+}
+
+type promotionAPI struct {
+	BaseAPI
+	promotionCtl promotion.Controller
+	projectCtl   project.Controller
+}
+
+func (p *promotionAPI) CreatePromotion(ctx context.Context, params operation.CreatePromotionParams) middleware.Responder {
+	if err := p.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionCreate, rbac.ResourcePromotion); err != nil {
+		return p.SendError(ctx, err)
+	}
+	req := params.Promotion
+	if req == nil || len(req.RepositoryName) == 0 || len(req.Reference) == 0 {
+		return p.SendError(ctx, errors.BadRequestError(nil).WithMessage("the repository name and the reference are required"))
+	}
+
+	pro, err := p.projectCtl.Get(ctx, params.ProjectName)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	pm := &model.Promotion{
+		ProjectID:           pro.ProjectID,
+		RepositoryName:      fmt.Sprintf("%s/%s", pro.Name, req.RepositoryName),
+		ReplicationPolicyID: req.ReplicationPolicyID,
+		LabelID:             req.LabelID,
+		Comment:             req.Comment,
+	}
+	if len(req.TargetProjectName) > 0 {
+		target, err := p.projectCtl.Get(ctx, req.TargetProjectName)
+		if err != nil {
+			if errors.IsNotFoundErr(err) {
+				return p.SendError(ctx, errors.BadRequestError(nil).WithMessage("the target project %s not found", req.TargetProjectName))
+			}
+			return p.SendError(ctx, err)
+		}
+		pm.TargetProjectID = target.ProjectID
+	}
+	if secCtx, ok := security.FromContext(ctx); ok {
+		pm.Requester = secCtx.GetUsername()
+	}
+
+	id, err := p.promotionCtl.Create(ctx, pm, req.Reference)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, "/"), id)
+	return operation.NewCreatePromotionCreated().WithLocation(location)
+}
+
+func (p *promotionAPI) ListPromotions(ctx context.Context, params operation.ListPromotionsParams) middleware.Responder {
+	if err := p.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionList, rbac.ResourcePromotion); err != nil {
+		return p.SendError(ctx, err)
+	}
+
+	query, err := p.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	pro, err := p.projectCtl.Get(ctx, params.ProjectName)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	query.Keywords["ProjectID"] = pro.ProjectID
+
+	total, err := p.promotionCtl.Count(ctx, query)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	promotions, err := p.promotionCtl.List(ctx, query)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+
+	var results []*models.Promotion
+	for _, pm := range promotions {
+		results = append(results, toPromotionModel(pm))
+	}
+	return operation.NewListPromotionsOK().
+		WithXTotalCount(total).
+		WithLink(p.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(results)
+}
+
+func (p *promotionAPI) GetPromotion(ctx context.Context, params operation.GetPromotionParams) middleware.Responder {
+	pm, err := p.getPromotion(ctx, params.ProjectName, params.PromotionID)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	// the promotion can be read by the members of the project which the artifact is promoted to as well
+	if err = p.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionRead, rbac.ResourcePromotion); err != nil {
+		if pm.TargetProjectID == 0 || p.RequireProjectAccess(ctx, pm.TargetProjectID, rbac.ActionRead, rbac.ResourcePromotion) != nil {
+			return p.SendError(ctx, err)
+		}
+	}
+	return operation.NewGetPromotionOK().WithPayload(toPromotionModel(pm))
+}
+
+func (p *promotionAPI) ApprovePromotion(ctx context.Context, params operation.ApprovePromotionParams) middleware.Responder {
+	reviewer, err := p.requireReviewer(ctx)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	pm, err := p.getPromotion(ctx, params.ProjectName, params.PromotionID)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	var comment string
+	if params.Review != nil {
+		comment = params.Review.Comment
+	}
+	// the role of the reviewer is checked by the controller
+	pm, err = p.promotionCtl.Approve(ctx, pm.ID, reviewer, comment)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewApprovePromotionOK().WithPayload(toPromotionModel(pm))
+}
+
+func (p *promotionAPI) RejectPromotion(ctx context.Context, params operation.RejectPromotionParams) middleware.Responder {
+	reviewer, err := p.requireReviewer(ctx)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	pm, err := p.getPromotion(ctx, params.ProjectName, params.PromotionID)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	var comment string
+	if params.Review != nil {
+		comment = params.Review.Comment
+	}
+	if err = p.promotionCtl.Reject(ctx, pm.ID, reviewer, comment); err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewRejectPromotionOK()
+}
+
+// requireReviewer returns the user reviewing the promotion, the promotions can only be reviewed by the users
+// rather than the robot accounts
+func (p *promotionAPI) requireReviewer(ctx context.Context) (*commonmodels.User, error) {
+	if err := p.RequireAuthenticated(ctx); err != nil {
+		return nil, err
+	}
+	secCtx, _ := security.FromContext(ctx)
+	sc, ok := secCtx.(*local.SecurityContext)
+	if !ok {
+		return nil, errors.ForbiddenError(nil).WithMessage("the promotions can only be reviewed by the users")
+	}
+	return sc.User(), nil
+}
+
+// getPromotion returns the promotion of the project, the promotion of other projects is treated as not found
+func (p *promotionAPI) getPromotion(ctx context.Context, projectName string, id int64) (*model.Promotion, error) {
+	pro, err := p.projectCtl.Get(ctx, projectName)
+	if err != nil {
+		return nil, err
+	}
+	pm, err := p.promotionCtl.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pm.ProjectID != pro.ProjectID {
+		return nil, errors.NotFoundError(nil).WithMessage("promotion %d not found", id)
+	}
+	return pm, nil
+}
+
+func toPromotionModel(pm *model.Promotion) *models.Promotion {
+	return &models.Promotion{
+		ID:                  pm.ID,
+		ProjectID:           pm.ProjectID,
+		RepositoryName:      pm.RepositoryName,
+		Digest:              pm.Digest,
+		TargetProjectID:     pm.TargetProjectID,
+		ReplicationPolicyID: pm.ReplicationPolicyID,
+		LabelID:             pm.LabelID,
+		Status:              pm.Status,
+		StatusMessage:       pm.StatusMessage,
+		Requester:           pm.Requester,
+		Comment:             pm.Comment,
+		Reviewer:            pm.Reviewer,
+		ReviewComment:       pm.ReviewComment,
+		ExecutionID:         pm.ExecutionID,
+		CreationTime:        strfmt.DateTime(pm.CreationTime),
+		UpdateTime:          strfmt.DateTime(pm.UpdateTime),
+	}
+}
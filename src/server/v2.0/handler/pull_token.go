@@ -0,0 +1,152 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/controller/pulltoken"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/pulltoken/model"
+	handler_model "github.com/goharbor/harbor/src/server/v2.0/handler/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/pull_token"
+)
+
+func newPullTokenAPI() *pullTokenAPI {
+	return &pullTokenAPI{
+		pullTokenCtl: pulltoken.Ctl,
+		projectCtl:   project.Ctl,
+	}
+}
+
+type pullTokenAPI struct {
+	BaseAPI
+	pullTokenCtl pulltoken.Controller
+	projectCtl   project.Controller
+}
+
+func (p *pullTokenAPI) CreatePullToken(ctx context.Context, params operation.CreatePullTokenParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := p.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionCreate, rbac.ResourcePullToken); err != nil {
+		return p.SendError(ctx, err)
+	}
+	if params.Token == nil {
+		return p.SendError(ctx, errors.BadRequestError(nil).WithMessage("the pull token is required"))
+	}
+
+	pro, err := p.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	var repositories []string
+	for _, repo := range params.Token.Repositories {
+		// the repositories can be specified with or without the project name
+		repositories = append(repositories, strings.TrimPrefix(strings.TrimSpace(repo), pro.Name+"/"))
+	}
+	t := &model.PullToken{
+		ProjectID:    pro.ProjectID,
+		Repositories: strings.Join(repositories, ","),
+		Description:  params.Token.Description,
+	}
+	if secCtx, ok := security.FromContext(ctx); ok {
+		t.Creator = secCtx.GetUsername()
+	}
+
+	id, secret, err := p.pullTokenCtl.Create(ctx, t, params.Token.TTL)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, "/"), id)
+	return operation.NewCreatePullTokenCreated().WithLocation(location).WithPayload(&models.PullTokenCreated{
+		ID:        id,
+		Name:      pulltoken.Name(id),
+		Secret:    secret,
+		ExpiresAt: strfmt.DateTime(t.ExpiresAt),
+	})
+}
+
+func (p *pullTokenAPI) ListPullTokens(ctx context.Context, params operation.ListPullTokensParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := p.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionList, rbac.ResourcePullToken); err != nil {
+		return p.SendError(ctx, err)
+	}
+
+	query, err := p.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	pro, err := p.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	query.Keywords["ProjectID"] = pro.ProjectID
+
+	total, err := p.pullTokenCtl.Count(ctx, query)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	tokens, err := p.pullTokenCtl.List(ctx, query)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+
+	var results []*models.PullToken
+	for _, t := range tokens {
+		results = append(results, handler_model.NewPullToken(t).ToSwagger())
+	}
+
+	return operation.NewListPullTokensOK().
+		WithXTotalCount(total).
+		WithLink(p.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(results)
+}
+
+func (p *pullTokenAPI) RevokePullToken(ctx context.Context, params operation.RevokePullTokenParams) middleware.Responder {
+	projectNameOrID := parseProjectNameOrID(params.ProjectNameOrID, params.XIsResourceName)
+	if err := p.RequireProjectAccess(ctx, projectNameOrID, rbac.ActionDelete, rbac.ResourcePullToken); err != nil {
+		return p.SendError(ctx, err)
+	}
+
+	pro, err := p.projectCtl.Get(ctx, projectNameOrID)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	t, err := p.pullTokenCtl.Get(ctx, params.PullTokenID)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	// the pull token of other projects is treated as not found
+	if t.ProjectID != pro.ProjectID {
+		return p.SendError(ctx, errors.NotFoundError(nil).WithMessage("pull token %d not found", params.PullTokenID))
+	}
+	if t.Revoked || time.Now().After(t.ExpiresAt) {
+		return operation.NewRevokePullTokenOK()
+	}
+	if err := p.pullTokenCtl.Revoke(ctx, t.ID); err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewRevokePullTokenOK()
+}
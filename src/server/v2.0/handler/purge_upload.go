@@ -0,0 +1,295 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/controller/jobservice"
+	"github.com/goharbor/harbor/src/controller/purgeupload"
+	"github.com/goharbor/harbor/src/controller/task"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/q"
+	taskPkg "github.com/goharbor/harbor/src/pkg/task"
+	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/purge_upload"
+)
+
+type purgeUploadAPI struct {
+	BaseAPI
+	purgeUploadCtl purgeupload.Controller
+	schedulerCtl   jobservice.SchedulerController
+	taskCtl        task.Controller
+	executionCtl   task.ExecutionController
+}
+
+func newPurgeUploadAPI() *purgeUploadAPI {
+	return &purgeUploadAPI{
+		purgeUploadCtl: purgeupload.Ctrl,
+		schedulerCtl:   jobservice.SchedulerCtl,
+		taskCtl:        task.Ctl,
+		executionCtl:   task.ExecutionCtl,
+	}
+}
+
+func (p *purgeUploadAPI) CreatePurgeUploadSchedule(ctx context.Context, params operation.CreatePurgeUploadScheduleParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionCreate, rbac.ResourceGarbageCollection); err != nil {
+		return p.SendError(ctx, err)
+	}
+	if params.Schedule == nil || params.Schedule.Schedule == nil {
+		return p.SendError(ctx, errors.BadRequestError(fmt.Errorf("schedule cann't be empty")))
+	}
+	id, err := p.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, params.Schedule.Parameters)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	location := path.Join(params.HTTPRequest.URL.Path, fmt.Sprintf("../%d", id))
+	return operation.NewCreatePurgeUploadScheduleCreated().WithLocation(location)
+}
+
+func (p *purgeUploadAPI) UpdatePurgeUploadSchedule(ctx context.Context, params operation.UpdatePurgeUploadScheduleParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceGarbageCollection); err != nil {
+		return p.SendError(ctx, err)
+	}
+	if params.Schedule == nil || params.Schedule.Schedule == nil {
+		return p.SendError(ctx, errors.BadRequestError(fmt.Errorf("schedule cann't be empty")))
+	}
+	if _, err := p.kick(ctx, params.Schedule.Schedule.Type, params.Schedule.Schedule.Cron, params.Schedule.Parameters); err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewUpdatePurgeUploadScheduleOK()
+}
+
+// olderThanHours returns the age in hours of the uploads to be purged in the parameters, 0 if not provided
+func olderThanHours(m map[string]interface{}) (int, error) {
+	value, ok := m[common.PurgeUploadOlderThanHours]
+	if !ok {
+		return 0, nil
+	}
+	var hours int64
+	switch v := value.(type) {
+	case json.Number:
+		h, err := v.Int64()
+		if err != nil {
+			return 0, errors.BadRequestError(fmt.Errorf("%s should be integer format", common.PurgeUploadOlderThanHours))
+		}
+		hours = h
+	case float64:
+		hours = int64(v)
+	case int:
+		hours = int64(v)
+	default:
+		return 0, errors.BadRequestError(fmt.Errorf("%s should be integer format", common.PurgeUploadOlderThanHours))
+	}
+	if hours < 1 {
+		return 0, errors.BadRequestError(fmt.Errorf("%s should be greater than 0", common.PurgeUploadOlderThanHours))
+	}
+	return int(hours), nil
+}
+
+func (p *purgeUploadAPI) kick(ctx context.Context, scheType string, cron string, parameters map[string]interface{}) (int64, error) {
+	if parameters == nil {
+		parameters = make(map[string]interface{})
+	}
+	hours, err := olderThanHours(parameters)
+	if err != nil {
+		return 0, err
+	}
+	policy := purgeupload.JobPolicy{
+		OlderThanHours: hours,
+		ExtraAttrs:     parameters,
+	}
+	if dryRun, ok := parameters[common.PurgeUploadDryRun].(bool); ok {
+		policy.DryRun = dryRun
+	}
+
+	var id int64
+	switch scheType {
+	case ScheduleManual:
+		id, err = p.purgeUploadCtl.Start(ctx, policy, taskPkg.ExecutionTriggerManual)
+	case ScheduleNone:
+		err = p.schedulerCtl.Delete(ctx, purgeupload.VendorType)
+	case ScheduleHourly, ScheduleDaily, ScheduleWeekly, ScheduleCustom:
+		err = p.updateSchedule(ctx, scheType, cron, policy, parameters)
+	default:
+		err = errors.BadRequestError(fmt.Errorf("unsupported schedule type %s", scheType))
+	}
+	return id, err
+}
+
+func (p *purgeUploadAPI) updateSchedule(ctx context.Context, cronType, cron string, policy purgeupload.JobPolicy, extraParams map[string]interface{}) error {
+	if cron == "" {
+		return errors.New(nil).WithCode(errors.BadRequestCode).
+			WithMessage("empty cron string for schedule")
+	}
+	if err := p.schedulerCtl.Delete(ctx, purgeupload.VendorType); err != nil {
+		return err
+	}
+	_, err := p.schedulerCtl.Create(ctx, purgeupload.VendorType, cronType, cron, purgeupload.SchedulerCallback, policy, extraParams)
+	return err
+}
+
+func (p *purgeUploadAPI) GetPurgeUploadHistory(ctx context.Context, params operation.GetPurgeUploadHistoryParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceGarbageCollection); err != nil {
+		return p.SendError(ctx, err)
+	}
+	query, err := p.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	query.Keywords["VendorType"] = purgeupload.VendorType
+	total, err := p.executionCtl.Count(ctx, query)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	execs, err := p.executionCtl.List(ctx, query)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+
+	var results []*models.ExecHistory
+	for _, exec := range execs {
+		h, err := toPurgeUploadHistory(exec)
+		if err != nil {
+			return p.SendError(ctx, err)
+		}
+		results = append(results, h.ToSwagger())
+	}
+
+	return operation.NewGetPurgeUploadHistoryOK().
+		WithXTotalCount(total).
+		WithLink(p.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(results)
+}
+
+func (p *purgeUploadAPI) GetPurgeUploadJob(ctx context.Context, params operation.GetPurgeUploadJobParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceGarbageCollection); err != nil {
+		return p.SendError(ctx, err)
+	}
+	exec, err := p.executionCtl.Get(ctx, params.PurgeID)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	if exec.VendorType != purgeupload.VendorType {
+		return p.SendError(ctx, errors.NotFoundError(nil).WithMessage("upload purge job with id %d not found", params.PurgeID))
+	}
+	h, err := toPurgeUploadHistory(exec)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewGetPurgeUploadJobOK().WithPayload(h.ToSwagger())
+}
+
+// toPurgeUploadHistory converts the execution to the history, the parameters contain the result
+// of the purge(purged and reclaimed_bytes) checked in by the job
+func toPurgeUploadHistory(exec *taskPkg.Execution) (*model.ExecHistory, error) {
+	extraAttrsString, err := json.Marshal(exec.ExtraAttrs)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ExecHistory{
+		ID:         exec.ID,
+		Name:       purgeupload.VendorType,
+		Kind:       exec.Trigger,
+		Parameters: string(extraAttrsString),
+		Schedule: &model.ScheduleParam{
+			Type: exec.Trigger,
+		},
+		Status:       exec.Status,
+		CreationTime: exec.StartTime,
+		UpdateTime:   exec.UpdateTime,
+	}, nil
+}
+
+func (p *purgeUploadAPI) GetPurgeUploadJobLog(ctx context.Context, params operation.GetPurgeUploadJobLogParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceGarbageCollection); err != nil {
+		return p.SendError(ctx, err)
+	}
+	tasks, err := p.taskCtl.List(ctx, q.New(q.KeyWords{
+		"ExecutionID": params.PurgeID,
+		"VendorType":  purgeupload.VendorType,
+	}))
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	if len(tasks) == 0 {
+		return p.SendError(ctx,
+			errors.New(nil).WithCode(errors.NotFoundCode).
+				WithMessage("upload purge job with execution ID: %d taskLog is not found", params.PurgeID))
+	}
+	taskLog, err := p.taskCtl.GetLog(ctx, tasks[0].ID)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewGetPurgeUploadJobLogOK().WithPayload(string(taskLog))
+}
+
+func (p *purgeUploadAPI) GetPurgeUploadSchedule(ctx context.Context, params operation.GetPurgeUploadScheduleParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceGarbageCollection); err != nil {
+		return p.SendError(ctx, err)
+	}
+	sch, err := p.schedulerCtl.Get(ctx, purgeupload.VendorType)
+	if errors.IsNotFoundErr(err) {
+		return operation.NewGetPurgeUploadScheduleOK()
+	}
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	extraAttrsString, err := json.Marshal(sch.ExtraAttrs)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewGetPurgeUploadScheduleOK().WithPayload(&models.ExecHistory{
+		ID:            sch.ID,
+		JobKind:       sch.CRON,
+		JobParameters: string(extraAttrsString),
+		JobStatus:     sch.Status,
+		Schedule: &models.ScheduleObj{
+			Cron:              sch.CRON,
+			Type:              sch.CRONType,
+			NextScheduledTime: strfmt.DateTime(utils.NextSchedule(sch.CRON, time.Now())),
+		},
+		CreationTime: strfmt.DateTime(sch.CreationTime),
+		UpdateTime:   strfmt.DateTime(sch.UpdateTime),
+	})
+}
+
+func (p *purgeUploadAPI) StopPurgeUpload(ctx context.Context, params operation.StopPurgeUploadParams) middleware.Responder {
+	if err := p.RequireSystemAccess(ctx, rbac.ActionStop, rbac.ResourceGarbageCollection); err != nil {
+		return p.SendError(ctx, err)
+	}
+	exec, err := p.executionCtl.Get(ctx, params.PurgeID)
+	if err != nil {
+		return p.SendError(ctx, err)
+	}
+	if exec.VendorType != purgeupload.VendorType {
+		return p.SendError(ctx, errors.NotFoundError(nil).WithMessage("upload purge job with id %d not found", params.PurgeID))
+	}
+	if err := p.purgeUploadCtl.Stop(ctx, params.PurgeID); err != nil {
+		return p.SendError(ctx, err)
+	}
+	return operation.NewStopPurgeUploadOK()
+}
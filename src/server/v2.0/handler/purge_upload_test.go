@@ -0,0 +1,47 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goharbor/harbor/src/common"
+)
+
+func Test_olderThanHours(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       map[string]interface{}
+		want    int
+		wantErr bool
+	}{
+		{"not_provided", map[string]interface{}{}, 0, false},
+		{"json_number", map[string]interface{}{common.PurgeUploadOlderThanHours: json.Number("24")}, 24, false},
+		{"float", map[string]interface{}{common.PurgeUploadOlderThanHours: float64(48)}, 48, false},
+		{"not_integer", map[string]interface{}{common.PurgeUploadOlderThanHours: json.Number("1.5")}, 0, true},
+		{"string", map[string]interface{}{common.PurgeUploadOlderThanHours: "24"}, 0, true},
+		{"zero", map[string]interface{}{common.PurgeUploadOlderThanHours: json.Number("0")}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := olderThanHours(tt.m)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -41,6 +41,28 @@ type quotaAPI struct {
 	quotaCtl quota.Controller
 }
 
+func (qa *quotaAPI) ApplyDefaultQuotas(ctx context.Context, params operation.ApplyDefaultQuotasParams) middleware.Responder {
+	if err := qa.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceQuota); err != nil {
+		return qa.SendError(ctx, err)
+	}
+
+	if params.Previous == nil || len(params.Previous.Previous) == 0 {
+		return qa.SendError(ctx, errors.BadRequestError(nil).WithMessage("previous required in body"))
+	}
+
+	previous := make(types.ResourceList, len(params.Previous.Previous))
+	for name, value := range params.Previous.Previous {
+		previous[types.ResourceName(name)] = value
+	}
+
+	updated, err := qa.quotaCtl.ApplyDefaults(ctx, quota.ProjectReference, previous, lib.BoolValue(params.DryRun))
+	if err != nil {
+		return qa.SendError(ctx, err)
+	}
+
+	return operation.NewApplyDefaultQuotasOK().WithPayload(&models.QuotaDefaultsApplyResult{Updated: updated})
+}
+
 func (qa *quotaAPI) GetQuota(ctx context.Context, params operation.GetQuotaParams) middleware.Responder {
 	if err := qa.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceQuota); err != nil {
 		return qa.SendError(ctx, err)
@@ -103,7 +125,11 @@ func (qa *quotaAPI) UpdateQuota(ctx context.Context, params operation.UpdateQuot
 		return qa.SendError(ctx, err)
 	}
 
-	hard := make(types.ResourceList, len(params.Hard.Hard))
+	// the resources absent in the request keep the current hard limits
+	hard, err := q.GetHard()
+	if err != nil {
+		return qa.SendError(ctx, err)
+	}
 	for name, value := range params.Hard.Hard {
 		hard[types.ResourceName(name)] = value
 	}
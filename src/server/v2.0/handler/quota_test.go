@@ -86,6 +86,7 @@ func (suite *QuotaTestSuite) TestAuthorization() {
 		{http.MethodGet, "/quotas/1", nil},
 		{http.MethodGet, "/quotas", nil},
 		{http.MethodPut, "/quotas/1", quota},
+		{http.MethodPost, "/quotas/defaults/apply", models.QuotaDefaultsApplyReq{Previous: models.ResourceList{"storage": -1}}},
 	}
 
 	for _, req := range reqs {
@@ -278,6 +279,40 @@ func (suite *QuotaTestSuite) TestUpdateQuota() {
 	}
 }
 
+func (suite *QuotaTestSuite) TestApplyDefaultQuotas() {
+	times := 3
+	suite.Security.On("IsAuthenticated").Return(true).Times(times)
+	suite.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true).Times(times)
+
+	{
+		// previous required
+		res, err := suite.PostJSON("/quotas/defaults/apply", models.QuotaDefaultsApplyReq{})
+		suite.NoError(err)
+		suite.Equal(400, res.StatusCode)
+	}
+
+	{
+		// apply the defaults
+		mock.OnAnything(suite.quotaCtl, "ApplyDefaults").Return(int64(2), nil).Once()
+
+		var result models.QuotaDefaultsApplyResult
+		res, err := suite.PostJSON("/quotas/defaults/apply?dry_run=true", models.QuotaDefaultsApplyReq{Previous: models.ResourceList{"storage": -1}})
+		suite.NoError(err)
+		suite.Equal(200, res.StatusCode)
+		suite.NoError(json.NewDecoder(res.Body).Decode(&result))
+		suite.Equal(int64(2), result.Updated)
+	}
+
+	{
+		// apply the defaults failed
+		mock.OnAnything(suite.quotaCtl, "ApplyDefaults").Return(int64(0), errors.BadRequestError(nil)).Once()
+
+		res, err := suite.PostJSON("/quotas/defaults/apply", models.QuotaDefaultsApplyReq{Previous: models.ResourceList{"size": 1}})
+		suite.NoError(err)
+		suite.Equal(400, res.StatusCode)
+	}
+}
+
 func TestQuotaTestSuite(t *testing.T) {
 	suite.Run(t, &QuotaTestSuite{})
 }
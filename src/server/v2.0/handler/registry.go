@@ -60,6 +60,9 @@ func (r *registryAPI) CreateRegistry(ctx context.Context, params operation.Creat
 			AccessSecret: params.Registry.Credential.AccessSecret,
 		}
 	}
+	if params.Registry.Storage != nil {
+		registry.Storage = toStorageModel(params.Registry.Storage)
+	}
 
 	id, err := r.ctl.Create(ctx, registry)
 	if err != nil {
@@ -155,6 +158,14 @@ func (r *registryAPI) UpdateRegistry(ctx context.Context, params operation.Updat
 		if params.Registry.AccessSecret != nil {
 			registry.Credential.AccessSecret = *params.Registry.AccessSecret
 		}
+		if params.Registry.Storage != nil {
+			storage := toStorageModel(params.Registry.Storage)
+			// keep the secret key if it isn't changed
+			if len(storage.SecretKey) == 0 && registry.Storage != nil {
+				storage.SecretKey = registry.Storage.SecretKey
+			}
+			registry.Storage = storage
+		}
 	}
 	if err := r.ctl.Update(ctx, registry); err != nil {
 		return r.SendError(ctx, err)
@@ -308,3 +319,101 @@ func (r *registryAPI) ListRegistryProviderInfos(ctx context.Context, params oper
 
 	return operation.NewListRegistryProviderInfosOK().WithPayload(result)
 }
+
+func (r *registryAPI) ListRegistryProviderCapabilities(ctx context.Context, params operation.ListRegistryProviderCapabilitiesParams) middleware.Responder {
+	if err := r.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceReplicationAdapter); err != nil {
+		return r.SendError(ctx, err)
+	}
+
+	capabilities, err := r.ctl.ListRegistryProviderCapabilities(ctx)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+	infos, err := r.ctl.ListRegistryProviderInfos(ctx)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+
+	result := []*models.RegistryProviderCapability{}
+	for _, capability := range capabilities {
+		item := &models.RegistryProviderCapability{
+			Type:                                 capability.Type,
+			SupportedResourceTypes:               capability.SupportedResourceTypes,
+			SupportedTriggers:                    capability.SupportedTriggers,
+			SupportedCopyByChunk:                 capability.SupportedCopyByChunk,
+			SupportedRepositoryPathComponentType: capability.SupportedRepositoryPathComponentType,
+		}
+		for _, filter := range capability.SupportedResourceFilters {
+			item.SupportedResourceFilters = append(item.SupportedResourceFilters, &models.FilterStyle{
+				Style:  filter.Style,
+				Type:   filter.Type,
+				Values: filter.Values,
+			})
+		}
+		if info, exist := infos[capability.Type]; exist && info.CredentialPattern != nil {
+			item.CredentialPattern = &models.RegistryProviderCredentialPattern{
+				AccessKeyData:    info.CredentialPattern.AccessKeyData,
+				AccessKeyType:    info.CredentialPattern.AccessKeyType,
+				AccessSecretData: info.CredentialPattern.AccessSecretData,
+				AccessSecretType: info.CredentialPattern.AccessSecretType,
+			}
+		}
+		result = append(result, item)
+	}
+
+	return operation.NewListRegistryProviderCapabilitiesOK().WithPayload(result)
+}
+
+func (r *registryAPI) GetRegistryPeeringInfo(ctx context.Context, params operation.GetRegistryPeeringInfoParams) middleware.Responder {
+	if err := r.RequireAuthenticated(ctx); err != nil {
+		return r.SendError(ctx, err)
+	}
+
+	info, err := r.ctl.GetPeeringInfo(ctx)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+	return operation.NewGetRegistryPeeringInfoOK().WithPayload(&models.RegistryPeeringInfo{
+		APIVersion:   info.APIVersion,
+		Capabilities: info.Capabilities,
+	})
+}
+
+func (r *registryAPI) PeerRegistry(ctx context.Context, params operation.PeerRegistryParams) middleware.Responder {
+	if err := r.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceRegistry); err != nil {
+		return r.SendError(ctx, err)
+	}
+
+	peering, err := r.ctl.Peer(ctx, params.ID, &model.Peering{
+		CACertificate:     params.Peering.CaCertificate,
+		ClientCertificate: params.Peering.ClientCertificate,
+		ClientKey:         params.Peering.ClientKey,
+	})
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+	return operation.NewPeerRegistryOK().WithPayload(convertPeering(peering))
+}
+
+func (r *registryAPI) UnpeerRegistry(ctx context.Context, params operation.UnpeerRegistryParams) middleware.Responder {
+	if err := r.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceRegistry); err != nil {
+		return r.SendError(ctx, err)
+	}
+
+	if err := r.ctl.Unpeer(ctx, params.ID); err != nil {
+		return r.SendError(ctx, err)
+	}
+	return operation.NewUnpeerRegistryOK()
+}
+
+func toStorageModel(s *models.RegistryStorage) *model.Storage {
+	return &model.Storage{
+		Type:           s.Type,
+		Region:         s.Region,
+		RegionEndpoint: s.RegionEndpoint,
+		Bucket:         s.Bucket,
+		RootDirectory:  s.RootDirectory,
+		AccessKey:      s.AccessKey,
+		SecretKey:      s.SecretKey,
+	}
+}
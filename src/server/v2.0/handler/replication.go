@@ -26,6 +26,7 @@ import (
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/controller/replication"
 	repctlmodel "github.com/goharbor/harbor/src/controller/replication/model"
+	taskCtl "github.com/goharbor/harbor/src/controller/task"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/q"
@@ -37,13 +38,15 @@ import (
 
 func newReplicationAPI() *replicationAPI {
 	return &replicationAPI{
-		ctl: replication.Ctl,
+		ctl:     replication.Ctl,
+		taskCtl: taskCtl.Ctl,
 	}
 }
 
 type replicationAPI struct {
 	BaseAPI
-	ctl replication.Controller
+	ctl     replication.Controller
+	taskCtl taskCtl.Controller
 }
 
 func (r *replicationAPI) Prepare(ctx context.Context, operation string, params interface{}) middleware.Responder {
@@ -58,60 +61,8 @@ func (r *replicationAPI) CreateReplicationPolicy(ctx context.Context, params ope
 	if err != nil {
 		return r.SendError(ctx, err)
 	}
-	policy := &repctlmodel.Policy{
-		Name:              params.Policy.Name,
-		Description:       params.Policy.Description,
-		Creator:           sc.GetUsername(),
-		DestNamespace:     params.Policy.DestNamespace,
-		ReplicateDeletion: params.Policy.Deletion,
-		Override:          params.Policy.Override,
-		Enabled:           params.Policy.Enabled,
-	}
-	// Make this field be optional to keep backward compatibility
-	if params.Policy.DestNamespaceReplaceCount != nil {
-		policy.DestNamespaceReplaceCount = *params.Policy.DestNamespaceReplaceCount
-	} else {
-		policy.DestNamespaceReplaceCount = -1 // -1 mean the legacy mode
-	}
-	if params.Policy.SrcRegistry != nil {
-		policy.SrcRegistry = &model.Registry{
-			ID: params.Policy.SrcRegistry.ID,
-		}
-	}
-	if params.Policy.DestRegistry != nil {
-		policy.DestRegistry = &model.Registry{
-			ID: params.Policy.DestRegistry.ID,
-		}
-	}
-	if len(params.Policy.Filters) > 0 {
-		for _, filter := range params.Policy.Filters {
-			policy.Filters = append(policy.Filters, &model.Filter{
-				Type:       filter.Type,
-				Value:      filter.Value,
-				Decoration: filter.Decoration,
-			})
-		}
-	}
-	if params.Policy.Trigger != nil {
-		policy.Trigger = &model.Trigger{
-			Type: params.Policy.Trigger.Type,
-		}
-		if params.Policy.Trigger.TriggerSettings != nil {
-			policy.Trigger.Settings = &model.TriggerSettings{
-				Cron: params.Policy.Trigger.TriggerSettings.Cron,
-			}
-		}
-	}
-	if params.Policy.Speed != nil {
-		if *params.Policy.Speed < 0 {
-			*params.Policy.Speed = 0
-		}
-		policy.Speed = *params.Policy.Speed
-	}
-
-	if params.Policy.CopyByChunk != nil {
-		policy.CopyByChunk = *params.Policy.CopyByChunk
-	}
+	policy := toPolicyModel(params.Policy)
+	policy.Creator = sc.GetUsername()
 
 	id, err := r.ctl.CreatePolicy(ctx, policy)
 	if err != nil {
@@ -121,65 +72,59 @@ func (r *replicationAPI) CreateReplicationPolicy(ctx context.Context, params ope
 	return operation.NewCreateReplicationPolicyCreated().WithLocation(location)
 }
 
-func (r *replicationAPI) UpdateReplicationPolicy(ctx context.Context, params operation.UpdateReplicationPolicyParams) middleware.Responder {
-	if err := r.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceReplicationPolicy); err != nil {
+func (r *replicationAPI) ValidateReplicationPolicy(ctx context.Context, params operation.ValidateReplicationPolicyParams) middleware.Responder {
+	if err := r.RequireSystemAccess(ctx, rbac.ActionCreate, rbac.ResourceReplicationPolicy); err != nil {
 		return r.SendError(ctx, err)
 	}
-	policy := &repctlmodel.Policy{
-		ID:                params.ID,
-		Name:              params.Policy.Name,
-		Description:       params.Policy.Description,
-		DestNamespace:     params.Policy.DestNamespace,
-		ReplicateDeletion: params.Policy.Deletion,
-		Override:          params.Policy.Override,
-		Enabled:           params.Policy.Enabled,
+	findings, err := r.ctl.ValidatePolicy(ctx, toPolicyModel(params.Policy))
+	if err != nil {
+		return r.SendError(ctx, err)
 	}
-	// Make this field be optional to keep backward compatibility
-	if params.Policy.DestNamespaceReplaceCount != nil {
-		policy.DestNamespaceReplaceCount = *params.Policy.DestNamespaceReplaceCount
-	} else {
-		policy.DestNamespaceReplaceCount = -1 // -1 mean the legacy mode
+	result := &models.ReplicationPolicyValidation{
+		Valid:    true,
+		Findings: []*models.ReplicationPolicyFinding{},
 	}
-
-	if params.Policy.SrcRegistry != nil {
-		policy.SrcRegistry = &model.Registry{
-			ID: params.Policy.SrcRegistry.ID,
+	for _, finding := range findings {
+		if finding.Severity == repctlmodel.FindingSeverityError {
+			result.Valid = false
 		}
+		result.Findings = append(result.Findings, &models.ReplicationPolicyFinding{
+			Field:    finding.Field,
+			Severity: finding.Severity,
+			Message:  finding.Message,
+		})
 	}
-	if params.Policy.DestRegistry != nil {
-		policy.DestRegistry = &model.Registry{
-			ID: params.Policy.DestRegistry.ID,
-		}
+	return operation.NewValidateReplicationPolicyOK().WithPayload(result)
+}
+
+func (r *replicationAPI) PreviewReplicationRepositoryMappings(ctx context.Context, params operation.PreviewReplicationRepositoryMappingsParams) middleware.Responder {
+	if err := r.RequireSystemAccess(ctx, rbac.ActionCreate, rbac.ResourceReplicationPolicy); err != nil {
+		return r.SendError(ctx, err)
 	}
-	if len(params.Policy.Filters) > 0 {
-		for _, filter := range params.Policy.Filters {
-			policy.Filters = append(policy.Filters, &model.Filter{
-				Type:       filter.Type,
-				Value:      filter.Value,
-				Decoration: filter.Decoration,
-			})
-		}
+	if params.Preview.Policy == nil {
+		return r.SendError(ctx, errors.BadRequestError(nil).WithMessage("the policy is required"))
 	}
-	if params.Policy.Trigger != nil {
-		policy.Trigger = &model.Trigger{
-			Type: params.Policy.Trigger.Type,
-		}
-		if params.Policy.Trigger.TriggerSettings != nil {
-			policy.Trigger.Settings = &model.TriggerSettings{
-				Cron: params.Policy.Trigger.TriggerSettings.Cron,
-			}
-		}
+	mappings, err := r.ctl.PreviewRepositoryMappings(ctx, toPolicyModel(params.Preview.Policy), params.Preview.Repositories)
+	if err != nil {
+		return r.SendError(ctx, err)
 	}
-	if params.Policy.Speed != nil {
-		if *params.Policy.Speed < 0 {
-			*params.Policy.Speed = 0
-		}
-		policy.Speed = *params.Policy.Speed
+	result := []*models.ReplicationRepositoryMapping{}
+	for _, mapping := range mappings {
+		result = append(result, &models.ReplicationRepositoryMapping{
+			Source:      mapping.Source,
+			Destination: mapping.Destination,
+			Error:       mapping.Error,
+		})
 	}
+	return operation.NewPreviewReplicationRepositoryMappingsOK().WithPayload(result)
+}
 
-	if params.Policy.CopyByChunk != nil {
-		policy.CopyByChunk = *params.Policy.CopyByChunk
+func (r *replicationAPI) UpdateReplicationPolicy(ctx context.Context, params operation.UpdateReplicationPolicyParams) middleware.Responder {
+	if err := r.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceReplicationPolicy); err != nil {
+		return r.SendError(ctx, err)
 	}
+	policy := toPolicyModel(params.Policy)
+	policy.ID = params.ID
 
 	if err := r.ctl.UpdatePolicy(ctx, policy); err != nil {
 		return r.SendError(ctx, err)
@@ -273,11 +218,27 @@ func (r *replicationAPI) StopReplication(ctx context.Context, params operation.S
 	return nil
 }
 
+func (r *replicationAPI) RetryReplicationExecution(ctx context.Context, params operation.RetryReplicationExecutionParams) middleware.Responder {
+	if err := r.RequireSystemAccess(ctx, rbac.ActionCreate, rbac.ResourceReplication); err != nil {
+		return r.SendError(ctx, err)
+	}
+	scope := replication.RetryScopeFailed
+	if params.Scope != nil {
+		scope = *params.Scope
+	}
+	executionID, err := r.ctl.Retry(ctx, params.ID, scope)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, fmt.Sprintf("/%d/retry", params.ID)), executionID)
+	return operation.NewRetryReplicationExecutionCreated().WithLocation(location)
+}
+
 func (r *replicationAPI) ListReplicationExecutions(ctx context.Context, params operation.ListReplicationExecutionsParams) middleware.Responder {
 	if err := r.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceReplication); err != nil {
 		return r.SendError(ctx, err)
 	}
-	query, err := r.BuildQuery(ctx, nil, params.Sort, params.Page, params.PageSize)
+	query, err := r.BuildCursorQuery(ctx, nil, params.Sort, params.Cursor, params.Page, params.PageSize)
 	if err != nil {
 		return r.SendError(ctx, err)
 	}
@@ -331,7 +292,7 @@ func (r *replicationAPI) ListReplicationExecutions(ctx context.Context, params o
 
 	return operation.NewListReplicationExecutionsOK().
 		WithXTotalCount(total).
-		WithLink(r.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithLink(r.CursorLinks(ctx, params.HTTPRequest.URL, total, query).String()).
 		WithPayload(execs)
 }
 
@@ -417,6 +378,9 @@ func (r *replicationAPI) GetReplicationLog(ctx context.Context, params operation
 			WithCode(errors.NotFoundCode).
 			WithMessage("execution %d contains no task with ID %d", params.ID, params.TaskID))
 	}
+	if params.Follow != nil && *params.Follow {
+		return followTaskLog(ctx, params.HTTPRequest, r.taskCtl, params.TaskID)
+	}
 	log, err := r.ctl.GetTaskLog(ctx, params.TaskID)
 	if err != nil {
 		return r.SendError(ctx, err)
@@ -440,6 +404,8 @@ func convertReplicationPolicy(policy *repctlmodel.Policy) *models.ReplicationPol
 		Speed:                     &policy.Speed,
 		UpdateTime:                strfmt.DateTime(policy.UpdateTime),
 		CopyByChunk:               &policy.CopyByChunk,
+		CopyByStorage:             &policy.CopyByStorage,
+		ForeignLayerMode:          policy.ForeignLayerMode,
 	}
 	if policy.SrcRegistry != nil {
 		p.SrcRegistry = convertRegistry(policy.SrcRegistry)
@@ -467,6 +433,19 @@ func convertReplicationPolicy(policy *repctlmodel.Policy) *models.ReplicationPol
 		}
 		p.Trigger = trigger
 	}
+	if policy.NamespaceMapping != nil {
+		mapping := &models.ReplicationNamespaceMapping{
+			Flatten: policy.NamespaceMapping.Flatten,
+			Prefix:  policy.NamespaceMapping.Prefix,
+		}
+		for _, rule := range policy.NamespaceMapping.Rules {
+			mapping.Rules = append(mapping.Rules, &models.ReplicationNamespaceMappingRule{
+				Source:      rule.Source,
+				Destination: rule.Destination,
+			})
+		}
+		p.NamespaceMapping = mapping
+	}
 	return p
 }
 
@@ -492,9 +471,34 @@ func convertRegistry(registry *model.Registry) *models.Registry {
 		}
 		r.Credential = credential
 	}
+	if registry.Peering != nil {
+		r.Peering = convertPeering(registry.Peering)
+	}
+	if registry.Storage != nil {
+		storage := &models.RegistryStorage{
+			Type:           registry.Storage.Type,
+			Region:         registry.Storage.Region,
+			RegionEndpoint: registry.Storage.RegionEndpoint,
+			Bucket:         registry.Storage.Bucket,
+			RootDirectory:  registry.Storage.RootDirectory,
+			AccessKey:      registry.Storage.AccessKey,
+		}
+		if len(registry.Storage.SecretKey) > 0 {
+			storage.SecretKey = "*****"
+		}
+		r.Storage = storage
+	}
 	return r
 }
 
+func convertPeering(peering *model.Peering) *models.RegistryPeering {
+	return &models.RegistryPeering{
+		APIVersion:   peering.APIVersion,
+		Capabilities: peering.Capabilities,
+		PeeredTime:   strfmt.DateTime(peering.PeeredTime),
+	}
+}
+
 func convertExecution(execution *replication.Execution) *models.ReplicationExecution {
 	exec := &models.ReplicationExecution{
 		ID:         execution.ID,
@@ -560,3 +564,77 @@ func convertTask(task *replication.Task) *models.ReplicationTask {
 	}
 	return tk
 }
+
+// convert the swagger model of the policy into the controller model
+func toPolicyModel(p *models.ReplicationPolicy) *repctlmodel.Policy {
+	policy := &repctlmodel.Policy{
+		Name:              p.Name,
+		Description:       p.Description,
+		DestNamespace:     p.DestNamespace,
+		ReplicateDeletion: p.Deletion,
+		Override:          p.Override,
+		Enabled:           p.Enabled,
+	}
+	// Make this field be optional to keep backward compatibility
+	if p.DestNamespaceReplaceCount != nil {
+		policy.DestNamespaceReplaceCount = *p.DestNamespaceReplaceCount
+	} else {
+		policy.DestNamespaceReplaceCount = -1 // -1 mean the legacy mode
+	}
+	if p.SrcRegistry != nil {
+		policy.SrcRegistry = &model.Registry{
+			ID: p.SrcRegistry.ID,
+		}
+	}
+	if p.DestRegistry != nil {
+		policy.DestRegistry = &model.Registry{
+			ID: p.DestRegistry.ID,
+		}
+	}
+	if len(p.Filters) > 0 {
+		for _, filter := range p.Filters {
+			policy.Filters = append(policy.Filters, &model.Filter{
+				Type:       filter.Type,
+				Value:      filter.Value,
+				Decoration: filter.Decoration,
+			})
+		}
+	}
+	if p.Trigger != nil {
+		policy.Trigger = &model.Trigger{
+			Type: p.Trigger.Type,
+		}
+		if p.Trigger.TriggerSettings != nil {
+			policy.Trigger.Settings = &model.TriggerSettings{
+				Cron: p.Trigger.TriggerSettings.Cron,
+			}
+		}
+	}
+	if p.Speed != nil {
+		if *p.Speed < 0 {
+			*p.Speed = 0
+		}
+		policy.Speed = *p.Speed
+	}
+
+	if p.CopyByChunk != nil {
+		policy.CopyByChunk = *p.CopyByChunk
+	}
+	if p.CopyByStorage != nil {
+		policy.CopyByStorage = *p.CopyByStorage
+	}
+	policy.ForeignLayerMode = p.ForeignLayerMode
+	if p.NamespaceMapping != nil {
+		policy.NamespaceMapping = &model.NamespaceMapping{
+			Flatten: p.NamespaceMapping.Flatten,
+			Prefix:  p.NamespaceMapping.Prefix,
+		}
+		for _, rule := range p.NamespaceMapping.Rules {
+			policy.NamespaceMapping.Rules = append(policy.NamespaceMapping.Rules, &model.NamespaceMappingRule{
+				Source:      rule.Source,
+				Destination: rule.Destination,
+			})
+		}
+	}
+	return policy
+}
@@ -17,6 +17,8 @@ package handler
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/go-openapi/runtime/middleware"
 
@@ -25,34 +27,44 @@ import (
 	"github.com/goharbor/harbor/src/common/security/local"
 	"github.com/goharbor/harbor/src/common/security/robot"
 	"github.com/goharbor/harbor/src/controller/artifact"
-	"github.com/goharbor/harbor/src/controller/event/metadata"
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/controller/repository"
 	robotCtr "github.com/goharbor/harbor/src/controller/robot"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
-	"github.com/goharbor/harbor/src/pkg/notification"
 	pkgModels "github.com/goharbor/harbor/src/pkg/project/models"
 	repomodel "github.com/goharbor/harbor/src/pkg/repository/model"
+	"github.com/goharbor/harbor/src/pkg/usage"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/repository"
 )
 
+// the limitations of the links and the custom metadata of the repository
+const (
+	maxRepositoryLinks               = 20
+	maxRepositoryLinkNameLength      = 64
+	maxRepositoryMetadata            = 50
+	maxRepositoryMetadataKeyLength   = 128
+	maxRepositoryMetadataValueLength = 1024
+)
+
 func newRepositoryAPI() *repositoryAPI {
 	return &repositoryAPI{
-		proCtl:  project.Ctl,
-		repoCtl: repository.Ctl,
-		artCtl:  artifact.Ctl,
+		proCtl:   project.Ctl,
+		repoCtl:  repository.Ctl,
+		artCtl:   artifact.Ctl,
+		usageMgr: usage.Mgr,
 	}
 }
 
 type repositoryAPI struct {
 	BaseAPI
-	proCtl  project.Controller
-	repoCtl repository.Controller
-	artCtl  artifact.Controller
+	proCtl   project.Controller
+	repoCtl  repository.Controller
+	artCtl   artifact.Controller
+	usageMgr usage.Manager
 }
 
 func (r *repositoryAPI) Prepare(ctx context.Context, operation string, params interface{}) middleware.Responder {
@@ -65,7 +77,7 @@ func (r *repositoryAPI) Prepare(ctx context.Context, operation string, params in
 
 func (r *repositoryAPI) ListAllRepositories(ctx context.Context, params operation.ListAllRepositoriesParams) middleware.Responder {
 	// set query
-	query, err := r.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	query, err := r.BuildCursorQuery(ctx, params.Q, params.Sort, params.Cursor, params.Page, params.PageSize)
 	if err != nil {
 		return r.SendError(ctx, err)
 	}
@@ -73,7 +85,7 @@ func (r *repositoryAPI) ListAllRepositories(ctx context.Context, params operatio
 	if !ok {
 		return r.SendError(ctx, errors.UnauthorizedError(errors.New("security context not found")))
 	}
-	if !secCtx.IsSysAdmin() && !secCtx.IsSolutionUser() {
+	if !canViewAll(secCtx) && !secCtx.IsSolutionUser() {
 		projectIDs, err := r.listAuthorizedProjectIDs(ctx)
 		if err != nil {
 			return r.SendError(ctx, err)
@@ -82,7 +94,7 @@ func (r *repositoryAPI) ListAllRepositories(ctx context.Context, params operatio
 		if len(projectIDs) == 0 {
 			return operation.NewListAllRepositoriesOK().
 				WithXTotalCount(0).
-				WithLink(r.Links(ctx, params.HTTPRequest.URL, 0, query.PageNumber, query.PageSize).String()).
+				WithLink(r.CursorLinks(ctx, params.HTTPRequest.URL, 0, query).String()).
 				WithPayload(nil)
 		}
 		orList := &q.OrList{}
@@ -106,7 +118,7 @@ func (r *repositoryAPI) ListAllRepositories(ctx context.Context, params operatio
 	}
 	return operation.NewListAllRepositoriesOK().
 		WithXTotalCount(total).
-		WithLink(r.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithLink(r.CursorLinks(ctx, params.HTTPRequest.URL, total, query).String()).
 		WithPayload(repos)
 }
 
@@ -172,9 +184,13 @@ func (r *repositoryAPI) ListRepositories(ctx context.Context, params operation.L
 	if err != nil {
 		return r.SendError(ctx, err)
 	}
+	fields, err := newFieldSelector(params.Fields, models.Repository{})
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
 
 	// set query
-	query, err := r.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	query, err := r.BuildCursorQuery(ctx, params.Q, params.Sort, params.Cursor, params.Page, params.PageSize)
 	if err != nil {
 		return r.SendError(ctx, err)
 	}
@@ -190,12 +206,17 @@ func (r *repositoryAPI) ListRepositories(ctx context.Context, params operation.L
 	}
 	var repos []*models.Repository
 	for _, repository := range repositories {
+		// skip counting the artifacts if the count isn't selected
+		if !fields.Selected("artifact_count") {
+			repos = append(repos, model.NewRepoRecord(repository).ToSwagger())
+			continue
+		}
 		repos = append(repos, r.assembleRepository(ctx, model.NewRepoRecord(repository)))
 	}
-	return operation.NewListRepositoriesOK().
+	return fields.Shape(operation.NewListRepositoriesOK().
 		WithXTotalCount(total).
-		WithLink(r.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
-		WithPayload(repos)
+		WithLink(r.CursorLinks(ctx, params.HTTPRequest.URL, total, query).String()).
+		WithPayload(repos))
 }
 
 func (r *repositoryAPI) GetRepository(ctx context.Context, params operation.GetRepositoryParams) middleware.Responder {
@@ -209,6 +230,22 @@ func (r *repositoryAPI) GetRepository(ctx context.Context, params operation.GetR
 	return operation.NewGetRepositoryOK().WithPayload(r.assembleRepository(ctx, model.NewRepoRecord(repository)))
 }
 
+// GetRepositoryStats requires the permission to list the logs of the project as the statistics tell who pulls and pushes the artifacts
+func (r *repositoryAPI) GetRepositoryStats(ctx context.Context, params operation.GetRepositoryStatsParams) middleware.Responder {
+	if err := r.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionList, rbac.ResourceLog); err != nil {
+		return r.SendError(ctx, err)
+	}
+	from, to, err := parseUsageDays(params.From, params.To)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+	stats, err := r.usageMgr.GetRepositoryStats(ctx, fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName), from, to)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+	return operation.NewGetRepositoryStatsOK().WithPayload(toRepositoryStatsSwagger(stats))
+}
+
 func (r *repositoryAPI) assembleRepository(ctx context.Context, repository *model.RepoRecord) *models.Repository {
 	repo := repository.ToSwagger()
 	total, err := r.artCtl.Count(ctx, &q.Query{
@@ -232,14 +269,74 @@ func (r *repositoryAPI) UpdateRepository(ctx context.Context, params operation.U
 	if err != nil {
 		return r.SendError(ctx, err)
 	}
-	if err := r.repoCtl.Update(ctx, &repomodel.RepoRecord{
+	record := &repomodel.RepoRecord{
 		RepositoryID: repository.RepositoryID,
 		Name:         repository.Name,
 		Description:  params.Repository.Description,
-	}, "Description"); err != nil {
+	}
+	props := []string{"Description"}
+	// the links and metadata are replaced only when they are specified
+	if params.Repository.Links != nil {
+		links, err := toRepositoryLinks(params.Repository.Links)
+		if err != nil {
+			return r.SendError(ctx, err)
+		}
+		if err = record.SetLinks(links); err != nil {
+			return r.SendError(ctx, err)
+		}
+		props = append(props, "Links")
+	}
+	if params.Repository.Metadata != nil {
+		if err := validateRepositoryMetadata(params.Repository.Metadata); err != nil {
+			return r.SendError(ctx, err)
+		}
+		if err = record.SetMetadata(params.Repository.Metadata); err != nil {
+			return r.SendError(ctx, err)
+		}
+		props = append(props, "Metadata")
+	}
+	if err := r.repoCtl.Update(ctx, record, props...); err != nil {
 		return r.SendError(ctx, err)
 	}
-	return operation.NewDeleteRepositoryOK()
+	return operation.NewUpdateRepositoryOK()
+}
+
+// toRepositoryLinks validates the links of the repository and converts them into the models
+func toRepositoryLinks(links []*models.RepositoryLink) ([]*repomodel.Link, error) {
+	if len(links) > maxRepositoryLinks {
+		return nil, errors.BadRequestError(nil).WithMessage("the count of the links mustn't exceed %d", maxRepositoryLinks)
+	}
+	result := []*repomodel.Link{}
+	for _, link := range links {
+		if link == nil {
+			continue
+		}
+		if len(link.Name) == 0 || len(link.Name) > maxRepositoryLinkNameLength {
+			return nil, errors.BadRequestError(nil).WithMessage("the name of the link is required and mustn't exceed %d characters", maxRepositoryLinkNameLength)
+		}
+		u, err := url.Parse(link.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			return nil, errors.BadRequestError(nil).WithMessage("invalid URL %s of the link %s, only http and https are supported", link.URL, link.Name)
+		}
+		result = append(result, &repomodel.Link{Name: link.Name, URL: link.URL})
+	}
+	return result, nil
+}
+
+// validateRepositoryMetadata validates the custom key/values of the repository
+func validateRepositoryMetadata(metadata map[string]string) error {
+	if len(metadata) > maxRepositoryMetadata {
+		return errors.BadRequestError(nil).WithMessage("the count of the metadata mustn't exceed %d", maxRepositoryMetadata)
+	}
+	for key, value := range metadata {
+		if len(key) == 0 || len(key) > maxRepositoryMetadataKeyLength {
+			return errors.BadRequestError(nil).WithMessage("the key of the metadata is required and mustn't exceed %d characters", maxRepositoryMetadataKeyLength)
+		}
+		if len(value) > maxRepositoryMetadataValueLength {
+			return errors.BadRequestError(nil).WithMessage("the value of the metadata %s mustn't exceed %d characters", key, maxRepositoryMetadataValueLength)
+		}
+	}
+	return nil
 }
 
 func (r *repositoryAPI) DeleteRepository(ctx context.Context, params operation.DeleteRepositoryParams) middleware.Responder {
@@ -250,16 +347,31 @@ func (r *repositoryAPI) DeleteRepository(ctx context.Context, params operation.D
 	if err != nil {
 		return r.SendError(ctx, err)
 	}
-	if err := r.repoCtl.Delete(ctx, repository.RepositoryID); err != nil {
+	id, err := r.repoCtl.DeleteAsync(ctx, repository.RepositoryID)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+	deletion, err := r.repoCtl.GetDeletion(ctx, id)
+	if err != nil {
 		return r.SendError(ctx, err)
 	}
 
-	// fire event
-	notification.AddEvent(ctx, &metadata.DeleteRepositoryEventMetadata{
-		Ctx:        ctx,
-		Repository: repository.Name,
-		ProjectID:  repository.ProjectID,
-	})
+	location := fmt.Sprintf("%s/deletions/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, "/"), id)
+	return operation.NewDeleteRepositoryAccepted().WithLocation(location).
+		WithPayload(model.NewRepoDeletion(deletion).ToSwagger())
+}
 
-	return operation.NewDeleteRepositoryOK()
+func (r *repositoryAPI) GetRepositoryDeletion(ctx context.Context, params operation.GetRepositoryDeletionParams) middleware.Responder {
+	if err := r.RequireProjectAccess(ctx, params.ProjectName, rbac.ActionDelete, rbac.ResourceRepository); err != nil {
+		return r.SendError(ctx, err)
+	}
+	deletion, err := r.repoCtl.GetDeletion(ctx, params.DeletionID)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+	// the repository may have been deleted, so check the deletion against the name in the path
+	if deletion.RepositoryName != fmt.Sprintf("%s/%s", params.ProjectName, params.RepositoryName) {
+		return r.SendError(ctx, errors.NotFoundError(nil).WithMessage("repository deletion %d not found", params.DeletionID))
+	}
+	return operation.NewGetRepositoryDeletionOK().WithPayload(model.NewRepoDeletion(deletion).ToSwagger())
 }
@@ -0,0 +1,57 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+)
+
+func TestToRepositoryLinks(t *testing.T) {
+	links, err := toRepositoryLinks([]*models.RepositoryLink{
+		{Name: "Source code", URL: "https://github.com/goharbor/harbor"},
+		nil,
+	})
+	require.Nil(t, err)
+	require.Len(t, links, 1)
+	assert.Equal(t, "https://github.com/goharbor/harbor", links[0].URL)
+
+	// empty name
+	_, err = toRepositoryLinks([]*models.RepositoryLink{{URL: "https://github.com/goharbor/harbor"}})
+	assert.NotNil(t, err)
+
+	// unsupported scheme
+	_, err = toRepositoryLinks([]*models.RepositoryLink{{Name: "Source code", URL: "javascript:alert(1)"}})
+	assert.NotNil(t, err)
+
+	// too many links
+	var many []*models.RepositoryLink
+	for i := 0; i <= maxRepositoryLinks; i++ {
+		many = append(many, &models.RepositoryLink{Name: "link", URL: "https://goharbor.io"})
+	}
+	_, err = toRepositoryLinks(many)
+	assert.NotNil(t, err)
+}
+
+func TestValidateRepositoryMetadata(t *testing.T) {
+	assert.Nil(t, validateRepositoryMetadata(map[string]string{"team": "core"}))
+	assert.NotNil(t, validateRepositoryMetadata(map[string]string{"": "core"}))
+	assert.NotNil(t, validateRepositoryMetadata(map[string]string{"team": strings.Repeat("a", maxRepositoryMetadataValueLength+1)}))
+}
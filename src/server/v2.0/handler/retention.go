@@ -12,6 +12,7 @@ import (
 	"github.com/goharbor/harbor/src/common/rbac"
 	projectCtl "github.com/goharbor/harbor/src/controller/project"
 	retentionCtl "github.com/goharbor/harbor/src/controller/retention"
+	taskCtl "github.com/goharbor/harbor/src/controller/task"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/pkg"
@@ -28,6 +29,7 @@ func newRetentionAPI() *retentionAPI {
 		projectCtl:   projectCtl.Ctl,
 		retentionCtl: retentionCtl.Ctl,
 		proMetaMgr:   pkg.ProjectMetaMgr,
+		taskCtl:      taskCtl.Ctl,
 	}
 }
 
@@ -36,6 +38,7 @@ type retentionAPI struct {
 	proMetaMgr   metadata.Manager
 	retentionCtl retentionCtl.Controller
 	projectCtl   projectCtl.Controller
+	taskCtl      taskCtl.Controller
 }
 
 var (
@@ -65,6 +68,18 @@ var (
 					},
 				},
 			},
+			{
+				RuleTemplate: "latestSemverK",
+				DisplayText:  "the # highest semantic versions",
+				Action:       "retain",
+				Params: []*models.RetentionRuleParamMetadata{
+					{
+						Type:     "int",
+						Unit:     "COUNT",
+						Required: true,
+					},
+				},
+			},
 			{
 				RuleTemplate: "nDaysSinceLastPush",
 				DisplayText:  "pushed within the last # days",
@@ -380,6 +395,10 @@ func (r *retentionAPI) GetRetentionTaskLog(ctx context.Context, params operation
 		return r.SendError(ctx, err)
 	}
 
+	if params.Follow != nil && *params.Follow {
+		return followTaskLog(ctx, params.HTTPRequest, r.taskCtl, params.Tid)
+	}
+
 	log, err := r.retentionCtl.GetRetentionExecTaskLog(ctx, params.Tid)
 	if err != nil {
 		return r.SendError(ctx, err)
@@ -32,6 +32,7 @@ import (
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/networkpolicy"
 	pkg "github.com/goharbor/harbor/src/pkg/robot/model"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
@@ -58,6 +59,10 @@ func (rAPI *robotAPI) CreateRobot(ctx context.Context, params operation.CreateRo
 		return rAPI.SendError(ctx, err)
 	}
 
+	if err := validateNetworkPolicy(params.Robot.IPAllowlist, params.Robot.IPDenylist); err != nil {
+		return rAPI.SendError(ctx, err)
+	}
+
 	if err := rAPI.requireAccess(ctx, params.Robot.Level, params.Robot.Permissions[0].Namespace, rbac.ActionCreate); err != nil {
 		return rAPI.SendError(ctx, err)
 	}
@@ -68,6 +73,8 @@ func (rAPI *robotAPI) CreateRobot(ctx context.Context, params operation.CreateRo
 			Description: params.Robot.Description,
 			Duration:    params.Robot.Duration,
 			Visible:     true,
+			IPAllowlist: params.Robot.IPAllowlist,
+			IPDenylist:  params.Robot.IPDenylist,
 		},
 		Level: params.Robot.Level,
 	}
@@ -287,7 +294,7 @@ func (rAPI *robotAPI) validate(d int64, level string, permissions []*models.Robo
 	}
 
 	for _, perm := range permissions {
-		if len(perm.Access) == 0 {
+		if len(perm.Access) == 0 && perm.RoleID == 0 {
 			return errors.New(nil).WithMessage("bad request empty access").WithCode(errors.BadRequestCode)
 		}
 	}
@@ -303,6 +310,9 @@ func (rAPI *robotAPI) updateV2Robot(ctx context.Context, params operation.Update
 	if err := rAPI.validate(params.Robot.Duration, params.Robot.Level, params.Robot.Permissions); err != nil {
 		return err
 	}
+	if err := validateNetworkPolicy(params.Robot.IPAllowlist, params.Robot.IPDenylist); err != nil {
+		return err
+	}
 	if r.Level != robot.LEVELSYSTEM {
 		projectID, err := getProjectID(ctx, params.Robot.Permissions[0].Namespace)
 		if err != nil {
@@ -333,6 +343,8 @@ func (rAPI *robotAPI) updateV2Robot(ctx context.Context, params operation.Update
 
 	r.Description = params.Robot.Description
 	r.Disabled = params.Robot.Disable
+	r.IPAllowlist = params.Robot.IPAllowlist
+	r.IPDenylist = params.Robot.IPDenylist
 	if len(params.Robot.Permissions) != 0 {
 		if err := lib.JSONCopy(&r.Permissions, params.Robot.Permissions); err != nil {
 			log.Warningf("failed to call JSONCopy on robot permission when updateV2Robot, error: %v", err)
@@ -355,6 +367,15 @@ func isValidDuration(d int64) bool {
 	return d >= int64(-1) && d < math.MaxInt32
 }
 
+// validateNetworkPolicy validates the CIDRs restricting the networks the robot is usable from
+func validateNetworkPolicy(allowlist, denylist string) error {
+	policy := &networkpolicy.Policy{Allowlist: allowlist, Denylist: denylist}
+	if err := policy.Validate(); err != nil {
+		return errors.BadRequestError(nil).WithMessage("invalid network policy: %v", err)
+	}
+	return nil
+}
+
 // validateName validates the robot name, especially '+' cannot be a valid character
 func validateName(name string) error {
 	robotNameReg := `^[a-z0-9]+(?:[._-][a-z0-9]+)*$`
@@ -129,3 +129,25 @@ func TestValidateName(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateNetworkPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowlist string
+		denylist  string
+		expected  bool
+	}{
+		{"empty", "", "", true},
+		{"valid", "10.0.0.0/8, 192.168.1.1", "10.1.0.0/16", true},
+		{"invalid allowlist", "10.0.0.0/33", "", false},
+		{"invalid denylist", "", "example.com", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNetworkPolicy(tt.allowlist, tt.denylist)
+			if (err == nil) != tt.expected {
+				t.Errorf("validateNetworkPolicy(%s, %s) = %v, want %v", tt.allowlist, tt.denylist, err, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/role"
+	"github.com/goharbor/harbor/src/pkg/permission/types"
+	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/role"
+)
+
+func newRoleAPI() *roleAPI {
+	return &roleAPI{
+		roleCtl: role.Ctl,
+	}
+}
+
+type roleAPI struct {
+	BaseAPI
+	roleCtl role.Controller
+}
+
+func (r *roleAPI) ListRoles(ctx context.Context, params operation.ListRolesParams) middleware.Responder {
+	// all the authenticated users can list the roles as the project admins need them to manage the members
+	if err := r.RequireAuthenticated(ctx); err != nil {
+		return r.SendError(ctx, err)
+	}
+
+	query, err := r.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+
+	total, err := r.roleCtl.Count(ctx, query)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+
+	roles, err := r.roleCtl.List(ctx, query)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+
+	results := make([]*models.Role, 0, len(roles))
+	for _, rl := range roles {
+		results = append(results, model.NewRole(rl).ToSwagger())
+	}
+
+	return operation.NewListRolesOK().
+		WithXTotalCount(total).
+		WithLink(r.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithPayload(results)
+}
+
+func (r *roleAPI) GetRole(ctx context.Context, params operation.GetRoleParams) middleware.Responder {
+	if err := r.RequireAuthenticated(ctx); err != nil {
+		return r.SendError(ctx, err)
+	}
+
+	rl, err := r.roleCtl.Get(ctx, int(params.RoleID))
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+
+	return operation.NewGetRoleOK().WithPayload(model.NewRole(rl).ToSwagger())
+}
+
+func (r *roleAPI) CreateRole(ctx context.Context, params operation.CreateRoleParams) middleware.Responder {
+	if err := r.RequireSystemAccess(ctx, rbac.ActionCreate, rbac.ResourceRole); err != nil {
+		return r.SendError(ctx, err)
+	}
+
+	rl := toRole(params.Role)
+	id, err := r.roleCtl.Create(ctx, rl)
+	if err != nil {
+		return r.SendError(ctx, err)
+	}
+
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(params.HTTPRequest.URL.Path, "/"), id)
+	return operation.NewCreateRoleCreated().WithLocation(location)
+}
+
+func (r *roleAPI) UpdateRole(ctx context.Context, params operation.UpdateRoleParams) middleware.Responder {
+	if err := r.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceRole); err != nil {
+		return r.SendError(ctx, err)
+	}
+
+	rl := toRole(params.Role)
+	rl.ID = int(params.RoleID)
+	if err := r.roleCtl.Update(ctx, rl); err != nil {
+		return r.SendError(ctx, err)
+	}
+
+	return operation.NewUpdateRoleOK()
+}
+
+func (r *roleAPI) DeleteRole(ctx context.Context, params operation.DeleteRoleParams) middleware.Responder {
+	if err := r.RequireSystemAccess(ctx, rbac.ActionDelete, rbac.ResourceRole); err != nil {
+		return r.SendError(ctx, err)
+	}
+
+	if err := r.roleCtl.Delete(ctx, int(params.RoleID)); err != nil {
+		return r.SendError(ctx, err)
+	}
+
+	return operation.NewDeleteRoleOK()
+}
+
+func toRole(req *models.RoleReq) *role.Role {
+	rl := &role.Role{}
+	if req == nil {
+		return rl
+	}
+	rl.Name = req.Name
+	rl.Description = req.Description
+	for _, p := range req.Permissions {
+		if p == nil {
+			continue
+		}
+		rl.Permissions = append(rl.Permissions, &types.Policy{
+			Resource: types.Resource(p.Resource),
+			Action:   types.Action(p.Action),
+			Effect:   types.Effect(p.Effect),
+		})
+	}
+	return rl
+}
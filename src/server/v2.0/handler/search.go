@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/go-openapi/runtime"
@@ -26,7 +27,6 @@ import (
 
 	"github.com/goharbor/harbor/src/common/security"
 	"github.com/goharbor/harbor/src/common/security/local"
-	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/controller/artifact"
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/controller/repository"
@@ -35,7 +35,10 @@ import (
 	"github.com/goharbor/harbor/src/lib/config"
 	"github.com/goharbor/harbor/src/lib/errors"
 	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/lib/orm"
 	"github.com/goharbor/harbor/src/lib/q"
+	pkgSearch "github.com/goharbor/harbor/src/pkg/search"
+	searchModel "github.com/goharbor/harbor/src/pkg/search/model"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/search"
@@ -46,6 +49,7 @@ func newSearchAPI() *searchAPI {
 		artifactCtl:   artifact.Ctl,
 		projectCtl:    project.Ctl,
 		repositoryCtl: repository.Ctl,
+		searchMgr:     pkgSearch.Mgr,
 
 		chartMuseumEnabled: config.WithChartMuseum(),
 		searchCharts: func(q string, namespaces []string) ([]*search.Result, error) {
@@ -59,20 +63,46 @@ type searchAPI struct {
 	artifactCtl   artifact.Controller
 	projectCtl    project.Controller
 	repositoryCtl repository.Controller
+	searchMgr     pkgSearch.Manager
 
 	chartMuseumEnabled bool
 	searchCharts       func(string, []string) ([]*search.Result, error)
 }
 
+const (
+	searchTypeProject    = "project"
+	searchTypeRepository = "repository"
+	searchTypeChart      = "chart"
+)
+
 func (s *searchAPI) Search(ctx context.Context, params operation.SearchParams) middleware.Responder {
 	secCtx, ok := security.FromContext(ctx)
 	if !ok {
 		return s.SendError(ctx, fmt.Errorf("security not found in the context"))
 	}
+	// the search results tolerate the replication lag
+	ctx = orm.WithReplica(ctx)
 
-	kw := q.KeyWords{}
+	types, err := parseSearchTypes(lib.StringValue(params.Type))
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	query := &searchModel.Query{
+		Keyword:    params.Q,
+		LabelID:    lib.Int64Value(params.LabelID),
+		ScanStatus: lib.StringValue(params.ScanStatus),
+	}
+	// the facets only apply to the repositories
+	if query.LabelID > 0 || len(query.ScanStatus) > 0 {
+		delete(types, searchTypeProject)
+		delete(types, searchTypeChart)
+	}
+	page, pageSize := lib.Int64Value(params.Page), lib.Int64Value(params.PageSize)
 
-	if !secCtx.IsSysAdmin() {
+	// the accessible projects are listed for the users who cannot view all the projects
+	var accessible []*project.Project
+	if !canViewAll(secCtx) {
+		kw := q.KeyWords{}
 		if sc, ok := secCtx.(*local.SecurityContext); ok && sc.IsAuthenticated() {
 			user := sc.User()
 			kw["member"] = &project.MemberQuery{
@@ -83,84 +113,151 @@ func (s *searchAPI) Search(ctx context.Context, params operation.SearchParams) m
 		} else {
 			kw["public"] = true
 		}
+		accessible, err = s.projectCtl.List(ctx, q.New(kw), project.Metadata(false))
+		if err != nil {
+			return s.SendError(ctx, err)
+		}
+		query.ProjectIDs = []int64{}
+		for _, p := range accessible {
+			query.ProjectIDs = append(query.ProjectIDs, p.ProjectID)
+		}
 	}
 
-	projects, err := s.projectCtl.List(ctx, q.New(kw))
-	if err != nil {
-		return s.SendError(ctx, err)
+	payload := &models.Search{
+		Project:    []*models.Project{},
+		Repository: []*models.SearchRepository{},
+	}
+
+	if _, ok := types[searchTypeProject]; ok {
+		total, projects, err := s.searchProjects(ctx, secCtx, query, page, pageSize)
+		if err != nil {
+			return s.SendError(ctx, err)
+		}
+		payload.Project, payload.ProjectTotal = projects, total
+	}
+
+	if _, ok := types[searchTypeRepository]; ok {
+		total, repositories, err := s.searchRepositories(ctx, query, page, pageSize)
+		if err != nil {
+			log.Errorf("failed to filter repositories: %v", err)
+			return s.SendError(ctx, errors.Wrap(err, "failed to filter repositories"))
+		}
+		payload.Repository, payload.RepositoryTotal = repositories, total
+	}
+
+	if _, ok := types[searchTypeChart]; ok && s.chartMuseumEnabled {
+		if accessible == nil {
+			accessible, err = s.projectCtl.List(ctx, q.New(q.KeyWords{}), project.Metadata(false))
+			if err != nil {
+				return s.SendError(ctx, err)
+			}
+		}
+		proNames := []string{}
+		for _, p := range accessible {
+			proNames = append(proNames, p.Name)
+		}
+		charts, err := s.filterCharts(ctx, params.Q, proNames)
+		if err != nil {
+			log.Errorf("failed to filter charts: %v", err)
+			return s.SendError(ctx, errors.Wrap(err, "failed to filter charts"))
+		}
+		total := int64(len(charts))
+		payload.Chart, payload.ChartTotal = paginateCharts(sortCharts(charts, params.Q), page, pageSize), &total
+	}
+
+	return newSearchOK().WithPayload(payload)
+}
+
+// parseSearchTypes parses the comma separated types of the results, all the types are returned if it's empty
+func parseSearchTypes(s string) (map[string]struct{}, error) {
+	types := map[string]struct{}{}
+	for _, typ := range config.SplitAndTrim(s, ",") {
+		switch typ {
+		case searchTypeProject, searchTypeRepository, searchTypeChart:
+			types[typ] = struct{}{}
+		default:
+			return nil, errors.BadRequestError(nil).WithMessage("invalid search type %q, only %q, %q and %q are supported",
+				typ, searchTypeProject, searchTypeRepository, searchTypeChart)
+		}
+	}
+	if len(types) == 0 {
+		types = map[string]struct{}{searchTypeProject: {}, searchTypeRepository: {}, searchTypeChart: {}}
+	}
+	return types, nil
+}
+
+func (s *searchAPI) searchProjects(ctx context.Context, secCtx security.Context, query *searchModel.Query, page, pageSize int64) (int64, []*models.Project, error) {
+	result := []*models.Project{}
+	total, matched, err := s.searchMgr.SearchProjects(ctx, query, page, pageSize)
+	if err != nil || len(matched) == 0 {
+		return total, result, err
 	}
 
-	projectResult := []*models.Project{}
-	proNames := []string{}
+	// list the projects with the metadata and keep the order of the search
+	var ids []interface{}
+	for _, p := range matched {
+		ids = append(ids, p.ProjectID)
+	}
+	projects, err := s.projectCtl.List(ctx, q.New(q.KeyWords{"project_id": &q.OrList{Values: ids}}))
+	if err != nil {
+		return 0, nil, err
+	}
+	projectMap := map[int64]*project.Project{}
 	for _, p := range projects {
-		proNames = append(proNames, p.Name)
+		projectMap[p.ProjectID] = p
+	}
 
-		if params.Q != "" && !strings.Contains(p.Name, params.Q) {
+	for _, m := range matched {
+		p, ok := projectMap[m.ProjectID]
+		if !ok {
 			continue
 		}
 
 		if sc, ok := secCtx.(*local.SecurityContext); ok && sc.IsAuthenticated() {
 			roles, err := s.projectCtl.ListRoles(ctx, p.ProjectID, sc.User())
 			if err != nil {
-				return s.SendError(ctx, errors.Wrap(err, "failed to list roles"))
+				return 0, nil, errors.Wrap(err, "failed to list roles")
 			}
 			p.RoleList = roles
 			p.Role = highestRole(roles)
 		}
 
-		total, err := s.repositoryCtl.Count(ctx, q.New(q.KeyWords{"project_id": p.ProjectID}))
+		count, err := s.repositoryCtl.Count(ctx, q.New(q.KeyWords{"project_id": p.ProjectID}))
 		if err != nil {
 			log.Errorf("failed to get total of repositories of project %d: %v", p.ProjectID, err)
-			return s.SendError(ctx, errors.Wrapf(err, "failed to get total of repositories of project %d", p.ProjectID))
+			return 0, nil, errors.Wrapf(err, "failed to get total of repositories of project %d", p.ProjectID)
 		}
 
-		p.RepoCount = total
-
-		projectResult = append(projectResult, model.NewProject(p).ToSwagger())
-	}
-
-	repositoryResult, err := s.filterRepositories(ctx, projects, params.Q)
-	if err != nil {
-		log.Errorf("failed to filter repositories: %v", err)
-		return s.SendError(ctx, errors.Wrap(err, "failed to filter repositories"))
-	}
+		p.RepoCount = count
 
-	chartResult, err := s.filterCharts(ctx, params.Q, proNames)
-	if err != nil {
-		log.Errorf("failed to filter charts: %v", err)
-		return s.SendError(ctx, errors.Wrap(err, "failed to filter charts"))
+		result = append(result, model.NewProject(p).ToSwagger())
 	}
 
-	return newSearchOK().WithPayload(&models.Search{
-		Project:    projectResult,
-		Repository: repositoryResult,
-		Chart:      chartResult,
-	})
+	return total, result, nil
 }
 
-func (s *searchAPI) filterRepositories(ctx context.Context, projects []*project.Project, keyword string) ([]*models.SearchRepository, error) {
+func (s *searchAPI) searchRepositories(ctx context.Context, query *searchModel.Query, page, pageSize int64) (int64, []*models.SearchRepository, error) {
 	result := []*models.SearchRepository{}
-	if len(projects) == 0 {
-		return result, nil
+	total, repositories, err := s.searchMgr.SearchRepositories(ctx, query, page, pageSize)
+	if err != nil || len(repositories) == 0 {
+		return total, result, err
 	}
 
-	repositories, err := s.repositoryCtl.List(ctx, q.New(q.KeyWords{"name": &q.FuzzyMatchValue{Value: keyword}}))
-	if err != nil {
-		return nil, err
+	var ids []interface{}
+	for _, repository := range repositories {
+		ids = append(ids, repository.ProjectID)
 	}
-
-	if len(repositories) == 0 {
-		return result, nil
+	projects, err := s.projectCtl.List(ctx, q.New(q.KeyWords{"project_id": &q.OrList{Values: ids}}))
+	if err != nil {
+		return 0, nil, err
 	}
-
-	projectMap := map[string]*project.Project{}
-	for _, project := range projects {
-		projectMap[project.Name] = project
+	projectMap := map[int64]*project.Project{}
+	for _, p := range projects {
+		projectMap[p.ProjectID] = p
 	}
 
 	for _, repository := range repositories {
-		projectName, _ := utils.ParseRepository(repository.Name)
-		project, exist := projectMap[projectName]
+		project, exist := projectMap[repository.ProjectID]
 		if !exist {
 			continue
 		}
@@ -171,6 +268,9 @@ func (s *searchAPI) filterRepositories(ctx context.Context, projects []*project.
 			ProjectID:      repository.ProjectID,
 			ProjectPublic:  project.IsPublic(),
 			PullCount:      repository.PullCount,
+			Description:    repository.Description,
+			Links:          model.NewRepoRecord(repository).SwaggerLinks(),
+			Metadata:       repository.GetMetadata(),
 		}
 
 		count, err := s.artifactCtl.Count(ctx, q.New(q.KeyWords{"RepositoryID": repository.RepositoryID}))
@@ -184,7 +284,7 @@ func (s *searchAPI) filterRepositories(ctx context.Context, projects []*project.
 		result = append(result, &entry)
 	}
 
-	return result, nil
+	return total, result, nil
 }
 
 func (s *searchAPI) filterCharts(ctx context.Context, q string, namespaces []string) ([]*models.SearchResult, error) {
@@ -214,6 +314,50 @@ func (s *searchAPI) filterCharts(ctx context.Context, q string, namespaces []str
 	return result, nil
 }
 
+// sortCharts orders the charts by the relevance, the exact matches come first, then the prefix matches
+func sortCharts(charts []*models.SearchResult, keyword string) []*models.SearchResult {
+	keyword = strings.ToLower(keyword)
+	rank := func(name string) int {
+		name = strings.ToLower(name)
+		base := name[strings.LastIndex(name, "/")+1:]
+		switch {
+		case name == keyword || base == keyword:
+			return 0
+		case strings.HasPrefix(name, keyword) || strings.HasPrefix(base, keyword):
+			return 1
+		default:
+			return 2
+		}
+	}
+	sort.SliceStable(charts, func(i, j int) bool {
+		ri, rj := rank(charts[i].Name), rank(charts[j].Name)
+		if ri != rj {
+			return ri < rj
+		}
+		return charts[i].Name < charts[j].Name
+	})
+	return charts
+}
+
+// paginateCharts returns the charts of the page
+func paginateCharts(charts []*models.SearchResult, page, pageSize int64) []*models.SearchResult {
+	if pageSize <= 0 {
+		return charts
+	}
+	if page <= 0 {
+		page = 1
+	}
+	start := (page - 1) * pageSize
+	if start >= int64(len(charts)) {
+		return []*models.SearchResult{}
+	}
+	end := start + pageSize
+	if end > int64(len(charts)) {
+		end = int64(len(charts))
+	}
+	return charts[start:end]
+}
+
 // searchOK removing the chart from the response when the chartmuseum is disabled
 type searchOK struct {
 	Payload interface{}
@@ -222,12 +366,18 @@ type searchOK struct {
 func (o *searchOK) WithPayload(payload *models.Search) *searchOK {
 	if payload != nil {
 		p := &struct {
-			Chart      *[]*models.SearchResult    `json:"chart,omitempty"`
-			Project    []*models.Project          `json:"project"`
-			Repository []*models.SearchRepository `json:"repository"`
+			Chart           *[]*models.SearchResult    `json:"chart,omitempty"`
+			ChartTotal      *int64                     `json:"chart_total,omitempty"`
+			Project         []*models.Project          `json:"project"`
+			ProjectTotal    int64                      `json:"project_total"`
+			Repository      []*models.SearchRepository `json:"repository"`
+			RepositoryTotal int64                      `json:"repository_total"`
 		}{
-			Project:    payload.Project,
-			Repository: payload.Repository,
+			ChartTotal:      payload.ChartTotal,
+			Project:         payload.Project,
+			ProjectTotal:    payload.ProjectTotal,
+			Repository:      payload.Repository,
+			RepositoryTotal: payload.RepositoryTotal,
 		}
 
 		if payload.Chart != nil {
@@ -0,0 +1,115 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/pkg/project/models"
+	repoModel "github.com/goharbor/harbor/src/pkg/repository/model"
+	searchModel "github.com/goharbor/harbor/src/pkg/search/model"
+	swaggerModels "github.com/goharbor/harbor/src/server/v2.0/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	artifacttesting "github.com/goharbor/harbor/src/testing/controller/artifact"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	"github.com/goharbor/harbor/src/testing/mock"
+	searchtesting "github.com/goharbor/harbor/src/testing/pkg/search"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+)
+
+type SearchTestSuite struct {
+	htesting.Suite
+
+	artifactCtl *artifacttesting.Controller
+	projectCtl  *projecttesting.Controller
+	searchMgr   *searchtesting.Manager
+}
+
+func (suite *SearchTestSuite) SetupSuite() {
+	suite.artifactCtl = &artifacttesting.Controller{}
+	suite.projectCtl = &projecttesting.Controller{}
+	suite.searchMgr = &searchtesting.Manager{}
+
+	suite.Config = &restapi.Config{
+		SearchAPI: &searchAPI{
+			artifactCtl: suite.artifactCtl,
+			projectCtl:  suite.projectCtl,
+			searchMgr:   suite.searchMgr,
+		},
+	}
+
+	suite.Suite.SetupSuite()
+}
+
+func (suite *SearchTestSuite) TestSearchRepositories() {
+	suite.Security.On("IsSysAdmin").Return(true).Once()
+
+	suite.searchMgr.On("SearchRepositories", mock.Anything, &searchModel.Query{Keyword: "hello", LabelID: 1}, int64(2), int64(1)).
+		Return(int64(3), []*repoModel.RepoRecord{{RepositoryID: 1, Name: "library/hello", ProjectID: 1}}, nil).Once()
+	mock.OnAnything(suite.projectCtl, "List").Return([]*models.Project{{ProjectID: 1, Name: "library"}}, nil).Once()
+	mock.OnAnything(suite.artifactCtl, "Count").Return(int64(2), nil).Once()
+
+	var result swaggerModels.Search
+	// the projects and charts aren't searched when the label is set
+	res, err := suite.GetJSON("/search?q=hello&label_id=1&page=2&page_size=1", &result)
+	suite.NoError(err)
+	suite.Equal(200, res.StatusCode)
+	suite.Equal(int64(3), result.RepositoryTotal)
+	suite.Require().Len(result.Repository, 1)
+	suite.Equal("library/hello", result.Repository[0].RepositoryName)
+	suite.Equal(int64(2), result.Repository[0].ArtifactCount)
+	suite.Empty(result.Project)
+	suite.searchMgr.AssertExpectations(suite.T())
+}
+
+func (suite *SearchTestSuite) TestSearchInvalidType() {
+	suite.Security.On("IsSysAdmin").Return(true).Once()
+
+	res, err := suite.Get("/search?q=hello&type=user")
+	suite.NoError(err)
+	suite.Equal(400, res.StatusCode)
+}
+
+func TestSearchTestSuite(t *testing.T) {
+	suite.Run(t, &SearchTestSuite{})
+}
+
+func TestParseSearchTypes(t *testing.T) {
+	types, err := parseSearchTypes("")
+	assert.Nil(t, err)
+	assert.Len(t, types, 3)
+
+	types, err = parseSearchTypes("project, repository")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]struct{}{searchTypeProject: {}, searchTypeRepository: {}}, types)
+
+	_, err = parseSearchTypes("project,user")
+	assert.NotNil(t, err)
+}
+
+func TestSortAndPaginateCharts(t *testing.T) {
+	charts := []*swaggerModels.SearchResult{{Name: "library/nginx-ingress"}, {Name: "dev/my-nginx"}, {Name: "library/nginx"}}
+	charts = sortCharts(charts, "nginx")
+	assert.Equal(t, "library/nginx", charts[0].Name)
+	assert.Equal(t, "library/nginx-ingress", charts[1].Name)
+	assert.Equal(t, "dev/my-nginx", charts[2].Name)
+
+	assert.Len(t, paginateCharts(charts, 1, 2), 2)
+	assert.Len(t, paginateCharts(charts, 2, 2), 1)
+	assert.Empty(t, paginateCharts(charts, 3, 2))
+}
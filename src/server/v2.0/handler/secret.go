@@ -0,0 +1,126 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/registryctl"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/controller/event/operator"
+	"github.com/goharbor/harbor/src/controller/tokenkey"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/tokenkey/model"
+	"github.com/goharbor/harbor/src/registryctl/client"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/secret"
+)
+
+// the length of the generated HTTP secret of the registry
+const httpSecretLength = 32
+
+func newSecretAPI() *secretAPI {
+	return &secretAPI{
+		tokenKeyCtl: tokenkey.Ctl,
+		client:      func() client.Client { return registryctl.RegistryCtlClient },
+	}
+}
+
+// secretAPI rotates the key signing the registry tokens and the HTTP secret of the registry without the downtime
+type secretAPI struct {
+	BaseAPI
+	tokenKeyCtl tokenkey.Controller
+	client      func() client.Client
+}
+
+func (s *secretAPI) ListTokenKeys(ctx context.Context, params operation.ListTokenKeysParams) middleware.Responder {
+	if err := s.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceSystemSecret); err != nil {
+		return s.SendError(ctx, err)
+	}
+	keys, err := s.tokenKeyCtl.List(ctx)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	payload := make([]*models.TokenKey, 0, len(keys))
+	for _, k := range keys {
+		payload = append(payload, toTokenKeySwagger(k))
+	}
+	return operation.NewListTokenKeysOK().WithPayload(payload)
+}
+
+// RotateTokenKey switches the signing to a new key, the replaced key keeps verifying the issued tokens during the overlap window
+func (s *secretAPI) RotateTokenKey(ctx context.Context, params operation.RotateTokenKeyParams) middleware.Responder {
+	if err := s.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceSystemSecret); err != nil {
+		return s.SendError(ctx, err)
+	}
+	var overlap int64
+	if params.Rotation != nil {
+		overlap = params.Rotation.OverlapMinutes
+	}
+	if overlap < 0 {
+		return s.SendError(ctx, errors.BadRequestError(nil).WithMessage("the overlap_minutes should not be negative"))
+	}
+	key, err := s.tokenKeyCtl.Rotate(ctx, time.Duration(overlap)*time.Minute)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	notification.AddEvent(ctx, &metadata.ResourceChangeEventMetadata{
+		ResourceType: metadata.ResourceTypeTokenKey,
+		Resource:     key.KeyID,
+		Operation:    "rotate",
+		Operator:     operator.FromContext(ctx),
+	})
+	return operation.NewRotateTokenKeyOK().WithPayload(toTokenKeySwagger(key))
+}
+
+// RotateRegistryHTTPSecret generates a new HTTP secret and reloads the registry via the registry controller
+func (s *secretAPI) RotateRegistryHTTPSecret(ctx context.Context, params operation.RotateRegistryHTTPSecretParams) middleware.Responder {
+	if err := s.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceSystemSecret); err != nil {
+		return s.SendError(ctx, err)
+	}
+	// the uploads in progress are signed with the replaced secret and have to be restarted after the reloading
+	result, err := s.client().UpdateHTTPSecret(utils.GenerateRandomStringWithLen(httpSecretLength))
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	notification.AddEvent(ctx, &metadata.ResourceChangeEventMetadata{
+		ResourceType: metadata.ResourceTypeHTTPSecret,
+		Resource:     "registry",
+		Operation:    "rotate",
+		Operator:     operator.FromContext(ctx),
+	})
+	return operation.NewRotateRegistryHTTPSecretOK().WithPayload(&models.HTTPSecretRotationResult{Reloaded: result.Reloaded})
+}
+
+// toTokenKeySwagger converts the token key without the private key
+func toTokenKeySwagger(k *model.TokenKey) *models.TokenKey {
+	key := &models.TokenKey{
+		KeyID:        k.KeyID,
+		Status:       k.Status,
+		CreationTime: strfmt.DateTime(k.CreationTime),
+	}
+	if !k.ExpiresAt.IsZero() {
+		expiresAt := strfmt.DateTime(k.ExpiresAt)
+		key.ExpiresAt = &expiresAt
+	}
+	return key
+}
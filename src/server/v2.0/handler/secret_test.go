@@ -0,0 +1,133 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/tokenkey/model"
+	"github.com/goharbor/harbor/src/registryctl/client"
+	"github.com/goharbor/harbor/src/registryctl/storage"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	tokenkeytesting "github.com/goharbor/harbor/src/testing/controller/tokenkey"
+	"github.com/goharbor/harbor/src/testing/mock"
+	registryctltesting "github.com/goharbor/harbor/src/testing/registryctl"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+)
+
+type secretTestSuite struct {
+	htesting.Suite
+	tokenKeyCtl *tokenkeytesting.Controller
+	client      *registryctltesting.Mockclient
+}
+
+func (s *secretTestSuite) SetupSuite() {
+	s.tokenKeyCtl = &tokenkeytesting.Controller{}
+	s.client = &registryctltesting.Mockclient{}
+	s.Config = &restapi.Config{
+		SecretAPI: &secretAPI{
+			tokenKeyCtl: s.tokenKeyCtl,
+			client:      func() client.Client { return s.client },
+		},
+	}
+	s.Suite.SetupSuite()
+}
+
+func (s *secretTestSuite) SetupTest() {
+	s.Security.ExpectedCalls = nil
+	s.tokenKeyCtl.ExpectedCalls = nil
+	s.tokenKeyCtl.Calls = nil
+	s.client.ExpectedCalls = nil
+	s.Security.On("IsAuthenticated").Return(true)
+	s.Security.On("GetUsername").Return("admin").Maybe()
+}
+
+func (s *secretTestSuite) TestForbidden() {
+	s.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(false)
+	res, err := s.Post("/system/tokenkeys/rotate", nil)
+	s.Require().NoError(err)
+	s.Equal(403, res.StatusCode)
+	s.tokenKeyCtl.AssertNotCalled(s.T(), "Rotate", mock.Anything, mock.Anything)
+}
+
+func (s *secretTestSuite) TestListTokenKeys() {
+	s.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true)
+	s.tokenKeyCtl.On("List", mock.Anything).Return([]*model.TokenKey{
+		{ID: 2, KeyID: "new", Status: model.StatusActive, PrivateKey: "encrypted"},
+		{ID: 1, KeyID: "old", Status: model.StatusRetiring, ExpiresAt: time.Now().Add(time.Hour)},
+	}, nil)
+
+	res, err := s.Get("/system/tokenkeys")
+	s.Require().NoError(err)
+	s.Require().Equal(200, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	s.Require().NoError(err)
+	s.NotContains(string(body), "encrypted")
+	var keys []*models.TokenKey
+	s.Require().NoError(json.Unmarshal(body, &keys))
+	s.Require().Len(keys, 2)
+	s.Equal("new", keys[0].KeyID)
+	s.Nil(keys[0].ExpiresAt)
+	s.Equal(model.StatusRetiring, keys[1].Status)
+	s.NotNil(keys[1].ExpiresAt)
+}
+
+func (s *secretTestSuite) TestRotateTokenKey() {
+	s.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true)
+	s.tokenKeyCtl.On("Rotate", mock.Anything, 2*time.Hour).Return(&model.TokenKey{ID: 1, KeyID: "new", Status: model.StatusActive}, nil)
+	s.tokenKeyCtl.On("Rotate", mock.Anything, time.Duration(0)).Return(nil, errors.BadRequestError(nil))
+
+	key := &models.TokenKey{}
+	res, err := s.PostJSON("/system/tokenkeys/rotate", &models.TokenKeyRotation{OverlapMinutes: 120})
+	s.Require().NoError(err)
+	s.Require().Equal(200, res.StatusCode)
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(key))
+	s.Equal("new", key.KeyID)
+
+	// the controller rejects the overlap shorter than the token expiration
+	res, err = s.Post("/system/tokenkeys/rotate", nil)
+	s.Require().NoError(err)
+	s.Equal(400, res.StatusCode)
+
+	res, err = s.PostJSON("/system/tokenkeys/rotate", &models.TokenKeyRotation{OverlapMinutes: -1})
+	s.Require().NoError(err)
+	s.Equal(400, res.StatusCode)
+	s.tokenKeyCtl.AssertNumberOfCalls(s.T(), "Rotate", 2)
+}
+
+func (s *secretTestSuite) TestRotateRegistryHTTPSecret() {
+	s.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true)
+	s.client.On("UpdateHTTPSecret", mock.MatchedBy(func(secret string) bool {
+		return len(secret) == httpSecretLength
+	})).Return(&storage.UpdateResult{Reloaded: true}, nil)
+
+	result := &models.HTTPSecretRotationResult{}
+	res, err := s.Post("/system/registry/httpsecret/rotate", nil)
+	s.Require().NoError(err)
+	s.Require().Equal(200, res.StatusCode)
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(result))
+	s.True(result.Reloaded)
+}
+
+func TestSecretTestSuite(t *testing.T) {
+	suite.Run(t, &secretTestSuite{})
+}
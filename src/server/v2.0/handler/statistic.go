@@ -20,9 +20,8 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 
 	"github.com/goharbor/harbor/src/common/security/local"
-	"github.com/goharbor/harbor/src/controller/blob"
 	"github.com/goharbor/harbor/src/controller/project"
-	"github.com/goharbor/harbor/src/controller/repository"
+	"github.com/goharbor/harbor/src/controller/statistic"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/pkg/systemartifact"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
@@ -32,8 +31,7 @@ import (
 func newStatisticAPI() *statisticAPI {
 	return &statisticAPI{
 		proCtl:            project.Ctl,
-		repoCtl:           repository.Ctl,
-		blobCtl:           blob.Ctl,
+		statisticCtl:      statistic.Ctl,
 		systemArtifactMgr: systemartifact.Mgr,
 	}
 }
@@ -41,8 +39,7 @@ func newStatisticAPI() *statisticAPI {
 type statisticAPI struct {
 	BaseAPI
 	proCtl            project.Controller
-	repoCtl           repository.Controller
-	blobCtl           blob.Controller
+	statisticCtl      statistic.Controller
 	systemArtifactMgr systemartifact.Manager
 }
 
@@ -58,22 +55,14 @@ func (s *statisticAPI) GetStatistic(ctx context.Context, params operation.GetSta
 	}
 
 	statistic.PublicProjectCount = (int64)(len(pubProjs))
-	if len(pubProjs) == 0 {
-		statistic.PublicRepoCount = 0
-	} else {
-		var ids []interface{}
-		for _, p := range pubProjs {
-			ids = append(ids, p.ProjectID)
-		}
-		n, err := s.repoCtl.Count(ctx, &q.Query{
-			Keywords: map[string]interface{}{
-				"ProjectID": q.NewOrList(ids),
-			},
-		})
-		if err != nil {
-			return s.SendError(ctx, err)
-		}
-		statistic.PublicRepoCount = n
+	var pubProjIDs []int64
+	for _, p := range pubProjs {
+		pubProjIDs = append(pubProjIDs, p.ProjectID)
+	}
+	// the repository counts are read from the pre-computed statistics of the projects
+	statistic.PublicRepoCount, err = s.sumRepoCount(ctx, pubProjIDs)
+	if err != nil {
+		return s.SendError(ctx, err)
 	}
 
 	securityCtx, err := s.GetSecurityContext(ctx)
@@ -81,7 +70,7 @@ func (s *statisticAPI) GetStatistic(ctx context.Context, params operation.GetSta
 		return s.SendError(ctx, err)
 	}
 
-	if securityCtx.IsSysAdmin() {
+	if canViewAll(securityCtx) {
 		count, err := s.proCtl.Count(ctx, nil)
 		if err != nil {
 			return s.SendError(ctx, err)
@@ -89,26 +78,21 @@ func (s *statisticAPI) GetStatistic(ctx context.Context, params operation.GetSta
 		statistic.TotalProjectCount = count
 		statistic.PrivateProjectCount = count - statistic.PublicProjectCount
 
-		n, err := s.repoCtl.Count(ctx, nil)
-		if err != nil {
-			return s.SendError(ctx, err)
-		}
-		statistic.TotalRepoCount = n
-		statistic.PrivateRepoCount = n - statistic.PublicRepoCount
-
-		sum, err := s.blobCtl.CalculateTotalSize(ctx, true)
+		total, err := s.statisticCtl.Total(ctx)
 		if err != nil {
 			return s.SendError(ctx, err)
 		}
+		statistic.TotalRepoCount = total.RepoCount
+		statistic.PrivateRepoCount = total.RepoCount - statistic.PublicRepoCount
 
 		sysArtifactStorageSize, err := s.systemArtifactMgr.GetStorageSize(ctx)
 
 		if err != nil {
 			return s.SendError(ctx, err)
 		}
-		statistic.TotalStorageConsumption = sum + sysArtifactStorageSize
+		statistic.TotalStorageConsumption = total.Storage + sysArtifactStorageSize
 	} else {
-		var privProjectIDs []interface{}
+		var privProjectIDs []int64
 		if sc, ok := securityCtx.(*local.SecurityContext); ok && sc.IsAuthenticated() {
 			user := sc.User()
 			member := &project.MemberQuery{
@@ -126,20 +110,23 @@ func (s *statisticAPI) GetStatistic(ctx context.Context, params operation.GetSta
 		}
 
 		statistic.PrivateProjectCount = int64(len(privProjectIDs))
-		if statistic.PrivateProjectCount == 0 {
-			statistic.PrivateRepoCount = 0
-		} else {
-			n, err := s.repoCtl.Count(ctx, &q.Query{
-				Keywords: map[string]interface{}{
-					"ProjectID": q.NewOrList(privProjectIDs),
-				},
-			})
-			if err != nil {
-				return s.SendError(ctx, err)
-			}
-			statistic.PrivateRepoCount = n
+		statistic.PrivateRepoCount, err = s.sumRepoCount(ctx, privProjectIDs)
+		if err != nil {
+			return s.SendError(ctx, err)
 		}
 	}
 
 	return operation.NewGetStatisticOK().WithPayload(statistic)
 }
+
+func (s *statisticAPI) sumRepoCount(ctx context.Context, projectIDs []int64) (int64, error) {
+	statistics, err := s.statisticCtl.List(ctx, projectIDs...)
+	if err != nil {
+		return 0, err
+	}
+	var sum int64
+	for _, st := range statistics {
+		sum += st.RepoCount
+	}
+	return sum, nil
+}
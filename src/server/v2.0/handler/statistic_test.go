@@ -6,11 +6,11 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/goharbor/harbor/src/pkg/project/models"
+	statisticmodels "github.com/goharbor/harbor/src/pkg/project/statistic/models"
 	models2 "github.com/goharbor/harbor/src/server/v2.0/models"
 	"github.com/goharbor/harbor/src/server/v2.0/restapi"
-	blobtesting "github.com/goharbor/harbor/src/testing/controller/blob"
 	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
-	repositorytesting "github.com/goharbor/harbor/src/testing/controller/repository"
+	statistictesting "github.com/goharbor/harbor/src/testing/controller/statistic"
 	"github.com/goharbor/harbor/src/testing/mock"
 	systemartifacttesting "github.com/goharbor/harbor/src/testing/pkg/systemartifact"
 	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
@@ -19,21 +19,18 @@ import (
 type StatisticsTestSuite struct {
 	htesting.Suite
 	projectCtl     *projecttesting.Controller
-	repoCtl        *repositorytesting.Controller
-	blobCtl        *blobtesting.Controller
+	statisticCtl   *statistictesting.Controller
 	sysArtifactMgr *systemartifacttesting.Manager
 }
 
 func (suite *StatisticsTestSuite) SetupSuite() {
 	suite.projectCtl = &projecttesting.Controller{}
-	suite.repoCtl = &repositorytesting.Controller{}
-	suite.blobCtl = &blobtesting.Controller{}
+	suite.statisticCtl = &statistictesting.Controller{}
 	suite.sysArtifactMgr = &systemartifacttesting.Manager{}
 
 	suite.Config = &restapi.Config{StatisticAPI: &statisticAPI{
 		proCtl:            suite.projectCtl,
-		repoCtl:           suite.repoCtl,
-		blobCtl:           suite.blobCtl,
+		statisticCtl:      suite.statisticCtl,
 		systemArtifactMgr: suite.sysArtifactMgr,
 	}}
 	suite.Suite.SetupSuite()
@@ -43,8 +40,8 @@ func (suite *StatisticsTestSuite) TestGetStatistic() {
 	projects := make([]*models.Project, 0)
 	suite.projectCtl.On("List", mock.Anything, mock.Anything, mock.Anything).Return(projects, nil)
 	suite.projectCtl.On("Count", mock.Anything, mock.Anything).Return(int64(10), nil)
-	suite.repoCtl.On("Count", mock.Anything, mock.Anything).Return(int64(20), nil)
-	suite.blobCtl.On("CalculateTotalSize", mock.Anything, true).Return(int64(1000), nil)
+	suite.statisticCtl.On("List", mock.Anything).Return(map[int64]*statisticmodels.ProjectStatistic{}, nil)
+	suite.statisticCtl.On("Total", mock.Anything).Return(&statisticmodels.ProjectStatistic{RepoCount: 20, Storage: 1000}, nil)
 	suite.sysArtifactMgr.On("GetStorageSize", mock.Anything).Return(int64(1000), nil)
 
 	suite.Security.On("IsAuthenticated").Return(true)
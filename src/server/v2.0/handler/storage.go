@@ -0,0 +1,116 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/registryctl"
+	"github.com/goharbor/harbor/src/controller/event/metadata"
+	"github.com/goharbor/harbor/src/controller/event/operator"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/lib/log"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/registryctl/client"
+	"github.com/goharbor/harbor/src/registryctl/storage"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	operation "github.com/goharbor/harbor/src/server/v2.0/restapi/operations/storage"
+)
+
+func newStorageAPI() *storageAPI {
+	return &storageAPI{
+		client: func() client.Client { return registryctl.RegistryCtlClient },
+	}
+}
+
+// storageAPI manages the storage backend of the registry, the update is validated by writing, reading and
+// deleting a probe file with the new settings before it is applied and the registry is reloaded by the registry controller
+type storageAPI struct {
+	BaseAPI
+	client func() client.Client
+}
+
+func (s *storageAPI) GetRegistryStorage(ctx context.Context, params operation.GetRegistryStorageParams) middleware.Responder {
+	if err := s.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceSystemStorage); err != nil {
+		return s.SendError(ctx, err)
+	}
+	cfg, err := s.client().GetStorage()
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	return operation.NewGetRegistryStorageOK().WithPayload(toStorageBackendSwagger(cfg))
+}
+
+func (s *storageAPI) ValidateRegistryStorage(ctx context.Context, params operation.ValidateRegistryStorageParams) middleware.Responder {
+	if err := s.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceSystemStorage); err != nil {
+		return s.SendError(ctx, err)
+	}
+	cfg, err := toStorageConfig(params.Storage)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	if err = s.client().ValidateStorage(cfg); err != nil {
+		return s.SendError(ctx, err)
+	}
+	return operation.NewValidateRegistryStorageOK()
+}
+
+func (s *storageAPI) UpdateRegistryStorage(ctx context.Context, params operation.UpdateRegistryStorageParams) middleware.Responder {
+	if err := s.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceSystemStorage); err != nil {
+		return s.SendError(ctx, err)
+	}
+	cfg, err := toStorageConfig(params.Storage)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	before, err := s.client().GetStorage()
+	if err != nil {
+		log.Warningf("failed to get the current storage of the registry: %v", err)
+		before = nil
+	}
+	result, err := s.client().UpdateStorage(cfg)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	notification.AddEvent(ctx, &metadata.ResourceChangeEventMetadata{
+		ResourceType: metadata.ResourceTypeRegistryStorage,
+		Resource:     cfg.Type,
+		Operation:    "update",
+		Operator:     operator.FromContext(ctx),
+		Before:       before,
+		After:        cfg.Redact(),
+	})
+	return operation.NewUpdateRegistryStorageOK().WithPayload(&models.StorageBackendUpdateResult{Reloaded: result.Reloaded})
+}
+
+func toStorageConfig(backend *models.StorageBackend) (*storage.Config, error) {
+	if backend == nil || len(backend.Type) == 0 {
+		return nil, errors.BadRequestError(nil).WithMessage("the type of the storage is required")
+	}
+	return &storage.Config{
+		Type:       backend.Type,
+		Parameters: backend.Parameters,
+	}, nil
+}
+
+func toStorageBackendSwagger(cfg *storage.Config) *models.StorageBackend {
+	return &models.StorageBackend{
+		Type:       cfg.Type,
+		Parameters: cfg.Parameters,
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/registryctl/client"
+	"github.com/goharbor/harbor/src/registryctl/storage"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	"github.com/goharbor/harbor/src/testing/mock"
+	registryctltesting "github.com/goharbor/harbor/src/testing/registryctl"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+)
+
+type storageTestSuite struct {
+	htesting.Suite
+	client *registryctltesting.Mockclient
+}
+
+func (s *storageTestSuite) SetupSuite() {
+	s.client = &registryctltesting.Mockclient{}
+	s.Config = &restapi.Config{
+		StorageAPI: &storageAPI{client: func() client.Client { return s.client }},
+	}
+	s.Suite.SetupSuite()
+}
+
+func (s *storageTestSuite) SetupTest() {
+	s.Security.ExpectedCalls = nil
+	s.client.ExpectedCalls = nil
+	s.client.Calls = nil
+}
+
+func (s *storageTestSuite) TestForbidden() {
+	s.Security.On("IsAuthenticated").Return(true)
+	s.Security.On("GetUsername").Return("user")
+	s.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(false)
+	res, err := s.Get("/system/storage")
+	s.Require().NoError(err)
+	s.Equal(403, res.StatusCode)
+	s.client.AssertNotCalled(s.T(), "GetStorage")
+}
+
+func (s *storageTestSuite) TestGetRegistryStorage() {
+	s.Security.On("IsAuthenticated").Return(true)
+	s.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true)
+	s.client.On("GetStorage").Return(&storage.Config{
+		Type:       "s3",
+		Parameters: map[string]interface{}{"bucket": "harbor", "secretkey": storage.Redacted},
+	}, nil)
+
+	backend := &models.StorageBackend{}
+	res, err := s.GetJSON("/system/storage", backend)
+	s.Require().NoError(err)
+	s.Require().Equal(200, res.StatusCode)
+	s.Equal("s3", backend.Type)
+	s.Equal(storage.Redacted, backend.Parameters["secretkey"])
+}
+
+func (s *storageTestSuite) TestValidateRegistryStorage() {
+	s.Security.On("IsAuthenticated").Return(true)
+	s.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true)
+	s.client.On("ValidateStorage", mock.Anything).Return(errors.BadRequestError(nil).WithMessage("failed to write the storage")).Once()
+
+	res, err := s.PostJSON("/system/storage/validate", &models.StorageBackend{Type: "s3", Parameters: map[string]interface{}{"bucket": "harbor"}})
+	s.Require().NoError(err)
+	s.Equal(400, res.StatusCode)
+
+	// the type is required
+	res, err = s.PostJSON("/system/storage/validate", &models.StorageBackend{Parameters: map[string]interface{}{}})
+	s.Require().NoError(err)
+	s.Equal(400, res.StatusCode)
+	s.client.AssertNumberOfCalls(s.T(), "ValidateStorage", 1)
+}
+
+func (s *storageTestSuite) TestUpdateRegistryStorage() {
+	s.Security.On("IsAuthenticated").Return(true)
+	s.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true)
+	s.Security.On("GetUsername").Return("admin").Maybe()
+	s.client.On("GetStorage").Return(&storage.Config{Type: "filesystem"}, nil)
+	s.client.On("UpdateStorage", mock.MatchedBy(func(cfg *storage.Config) bool {
+		return cfg.Type == "s3" && cfg.Parameters["bucket"] == "harbor"
+	})).Return(&storage.UpdateResult{Reloaded: true}, nil)
+
+	result := &models.StorageBackendUpdateResult{}
+	res, err := s.PutJSON("/system/storage", &models.StorageBackend{
+		Type:       "s3",
+		Parameters: map[string]interface{}{"bucket": "harbor", "secretkey": "secret"},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(200, res.StatusCode)
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(result))
+	s.True(result.Reloaded)
+}
+
+func TestStorageTestSuite(t *testing.T) {
+	suite.Run(t, &storageTestSuite{})
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 
 	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/controller/configsync"
 	"github.com/goharbor/harbor/src/pkg/allowlist"
 	"github.com/goharbor/harbor/src/pkg/allowlist/models"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
@@ -28,12 +29,14 @@ import (
 
 type systemCVEAllowListAPI struct {
 	BaseAPI
-	mgr allowlist.Manager
+	mgr           allowlist.Manager
+	configSyncCtl configsync.Controller
 }
 
 func newSystemCVEAllowListAPI() *systemCVEAllowListAPI {
 	return &systemCVEAllowListAPI{
-		mgr: allowlist.NewDefaultManager(),
+		mgr:           allowlist.NewDefaultManager(),
+		configSyncCtl: configsync.Ctl,
 	}
 }
 
@@ -41,6 +44,9 @@ func (s systemCVEAllowListAPI) PutSystemCVEAllowlist(ctx context.Context, params
 	if err := s.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceConfiguration); err != nil {
 		return s.SendError(ctx, err)
 	}
+	if err := s.configSyncCtl.CheckGlobal(ctx); err != nil {
+		return s.SendError(ctx, err)
+	}
 	l := models.CVEAllowlist{}
 	l.ExpiresAt = params.Allowlist.ExpiresAt
 	for _, it := range params.Allowlist.Items {
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
@@ -9,6 +10,7 @@ import (
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/common/security"
 	si "github.com/goharbor/harbor/src/controller/systeminfo"
+	migrationmodel "github.com/goharbor/harbor/src/pkg/migration/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 	"github.com/goharbor/harbor/src/server/v2.0/restapi/operations/systeminfo"
 )
@@ -63,6 +65,69 @@ func (s *sysInfoAPI) GetVolumes(ctx context.Context, params systeminfo.GetVolume
 	})
 }
 
+func (s *sysInfoAPI) GetMigrationStatus(ctx context.Context, params systeminfo.GetMigrationStatusParams) middleware.Responder {
+	if err := s.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceSystemMigration); err != nil {
+		return s.SendError(ctx, err)
+	}
+	status, err := s.ctl.GetMigrationStatus(ctx)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	return systeminfo.NewGetMigrationStatusOK().WithPayload(convertMigrationStatus(status))
+}
+
+func (s *sysInfoAPI) GetMigrationPreflight(ctx context.Context, params systeminfo.GetMigrationPreflightParams) middleware.Responder {
+	if err := s.RequireSystemAccess(ctx, rbac.ActionRead, rbac.ResourceSystemMigration); err != nil {
+		return s.SendError(ctx, err)
+	}
+	preflight, err := s.ctl.MigrationPreflight(ctx)
+	if err != nil {
+		return s.SendError(ctx, err)
+	}
+	res := &models.MigrationPreflight{
+		Ready:  preflight.Ready,
+		Checks: []*models.PreflightCheck{},
+		Status: convertMigrationStatus(preflight.Status),
+	}
+	for _, check := range preflight.Checks {
+		res.Checks = append(res.Checks, &models.PreflightCheck{
+			Name:     check.Name,
+			Passed:   check.Passed,
+			Blocking: check.Blocking,
+			Message:  check.Message,
+		})
+	}
+	return systeminfo.NewGetMigrationPreflightOK().WithPayload(res)
+}
+
+func convertMigrationStatus(status *migrationmodel.Status) *models.MigrationStatus {
+	if status == nil {
+		return nil
+	}
+	res := &models.MigrationStatus{
+		CurrentVersion: int64(status.CurrentVersion),
+		Dirty:          status.Dirty,
+		LatestVersion:  int64(status.LatestVersion),
+		Pending:        []*models.PendingMigration{},
+	}
+	for _, migration := range status.Pending {
+		m := &models.PendingMigration{
+			Version:     int64(migration.Version),
+			Name:        migration.Name,
+			Tables:      []*models.MigrationTable{},
+			LongRunning: migration.LongRunning,
+		}
+		for _, table := range migration.Tables {
+			m.Tables = append(m.Tables, &models.MigrationTable{
+				Name:          table.Name,
+				EstimatedRows: table.EstimatedRows,
+			})
+		}
+		res.Pending = append(res.Pending, m)
+	}
+	return res
+}
+
 func (s *sysInfoAPI) convertInfo(d *si.Data) *models.GeneralInfo {
 	if d == nil {
 		return nil
@@ -82,6 +147,31 @@ func (s *sysInfoAPI) convertInfo(d *si.Data) *models.GeneralInfo {
 		}
 	}
 
+	if d.BannerMessage != nil {
+		res.BannerMessage = &models.BannerMessage{
+			Message:  d.BannerMessage.Message,
+			Type:     d.BannerMessage.Type,
+			Closable: d.BannerMessage.Closable,
+		}
+		if d.BannerMessage.FromTime != nil {
+			res.BannerMessage.FromTime = strfmt.DateTime(*d.BannerMessage.FromTime)
+		}
+		if d.BannerMessage.ToTime != nil {
+			res.BannerMessage.ToTime = strfmt.DateTime(*d.BannerMessage.ToTime)
+		}
+	}
+	if d.Maintenance != nil {
+		res.Maintenance = &models.MaintenanceInfo{
+			Active: d.Maintenance.Active(time.Now()),
+		}
+		if d.Maintenance.StartTime != nil {
+			res.Maintenance.StartTime = strfmt.DateTime(*d.Maintenance.StartTime)
+		}
+		if d.Maintenance.EndTime != nil {
+			res.Maintenance.EndTime = strfmt.DateTime(*d.Maintenance.EndTime)
+		}
+	}
+
 	if d.Protected != nil {
 		res.HasCaRoot = &d.Protected.HasCARoot
 		res.ProjectCreationRestriction = &d.Protected.ProjectCreationRestrict
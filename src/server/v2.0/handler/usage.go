@@ -0,0 +1,97 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"time"
+
+	"github.com/goharbor/harbor/src/lib"
+	"github.com/goharbor/harbor/src/lib/errors"
+	"github.com/goharbor/harbor/src/pkg/usage/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+)
+
+const (
+	dayLayout = "2006-01-02"
+	// the default days of the usages when the "from" isn't specified
+	defaultUsageDays = 30
+	// the max days of the usages to protect the database
+	maxUsageDays = 366
+)
+
+// parseUsageDays parses the "from" and "to" in the format "YYYY-MM-DD", the "to" defaults to today
+// and the "from" defaults to 30 days before the "to"
+func parseUsageDays(fromStr, toStr *string) (time.Time, time.Time, error) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if s := lib.StringValue(toStr); len(s) > 0 {
+		t, err := time.Parse(dayLayout, s)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.BadRequestError(nil).WithMessage("invalid to %s, the format is YYYY-MM-DD", s)
+		}
+		to = t
+	}
+	from := to.AddDate(0, 0, -(defaultUsageDays - 1))
+	if s := lib.StringValue(fromStr); len(s) > 0 {
+		f, err := time.Parse(dayLayout, s)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.BadRequestError(nil).WithMessage("invalid from %s, the format is YYYY-MM-DD", s)
+		}
+		from = f
+	}
+	if from.After(to) {
+		return time.Time{}, time.Time{}, errors.BadRequestError(nil).WithMessage("the from %s is after the to %s", from.Format(dayLayout), to.Format(dayLayout))
+	}
+	if to.Sub(from) >= maxUsageDays*24*time.Hour {
+		return time.Time{}, time.Time{}, errors.BadRequestError(nil).WithMessage("the range between the from and to mustn't exceed %d days", maxUsageDays)
+	}
+	return from, to, nil
+}
+
+func toUsageSummariesSwagger(summaries []*model.Summary) []*models.UsageSummary {
+	result := []*models.UsageSummary{}
+	for _, s := range summaries {
+		result = append(result, &models.UsageSummary{
+			Key:       s.Key,
+			PullCount: s.PullCount,
+			PushCount: s.PushCount,
+		})
+	}
+	return result
+}
+
+func toRepositoryStatsSwagger(stats *model.RepositoryStats) *models.RepositoryStats {
+	return &models.RepositoryStats{
+		RepositoryName: stats.RepositoryName,
+		From:           stats.From,
+		To:             stats.To,
+		PullCount:      stats.PullCount,
+		PushCount:      stats.PushCount,
+		Daily:          toUsageSummariesSwagger(stats.Daily),
+		Tags:           toUsageSummariesSwagger(stats.Tags),
+		Principals:     toUsageSummariesSwagger(stats.Principals),
+	}
+}
+
+func toProjectUsageSwagger(report *model.ProjectReport) *models.ProjectUsage {
+	return &models.ProjectUsage{
+		ProjectID:    report.ProjectID,
+		From:         report.From,
+		To:           report.To,
+		PullCount:    report.PullCount,
+		PushCount:    report.PushCount,
+		Repositories: toUsageSummariesSwagger(report.Repositories),
+		Principals:   toUsageSummariesSwagger(report.Principals),
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-openapi/swag"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/goharbor/harbor/src/controller/project"
+	"github.com/goharbor/harbor/src/pkg/usage/model"
+	"github.com/goharbor/harbor/src/server/v2.0/models"
+	"github.com/goharbor/harbor/src/server/v2.0/restapi"
+	projecttesting "github.com/goharbor/harbor/src/testing/controller/project"
+	"github.com/goharbor/harbor/src/testing/mock"
+	usagetesting "github.com/goharbor/harbor/src/testing/pkg/usage"
+	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
+)
+
+type usageTestSuite struct {
+	htesting.Suite
+	projectCtl *projecttesting.Controller
+	usageMgr   *usagetesting.Manager
+}
+
+func (u *usageTestSuite) SetupSuite() {
+	u.projectCtl = &projecttesting.Controller{}
+	u.usageMgr = &usagetesting.Manager{}
+	u.Config = &restapi.Config{
+		ProjectAPI:    &projectAPI{projectCtl: u.projectCtl, usageMgr: u.usageMgr},
+		RepositoryAPI: &repositoryAPI{usageMgr: u.usageMgr},
+	}
+	u.Suite.SetupSuite()
+	mock.OnAnything(projectCtlMock, "GetByName").Return(&project.Project{ProjectID: 1}, nil)
+}
+
+func (u *usageTestSuite) SetupTest() {
+	u.projectCtl.ExpectedCalls = nil
+	u.usageMgr.ExpectedCalls = nil
+	u.usageMgr.Calls = nil
+}
+
+func (u *usageTestSuite) TestGetRepositoryStats() {
+	u.Security.On("IsAuthenticated").Return(true).Once()
+	u.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true).Once()
+	mock.OnAnything(u.usageMgr, "GetRepositoryStats").Return(&model.RepositoryStats{
+		RepositoryName: "library/photon/hello-world",
+		PullCount:      3,
+		Tags:           []*model.Summary{{Key: "latest", PullCount: 3}},
+	}, nil)
+
+	stats := &models.RepositoryStats{}
+	res, err := u.GetJSON("/projects/library/repositories/photon%252Fhello-world/stats?from=2023-06-01&to=2023-06-30", stats)
+	u.Require().NoError(err)
+	u.Equal(200, res.StatusCode)
+	u.Equal(int64(3), stats.PullCount)
+	u.Require().Len(stats.Tags, 1)
+	u.Equal("latest", stats.Tags[0].Key)
+	args := u.usageMgr.Calls[0].Arguments
+	u.Equal("library/photon/hello-world", args.Get(1))
+	u.Equal(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), args.Get(2))
+	u.Equal(time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC), args.Get(3))
+}
+
+func (u *usageTestSuite) TestGetProjectUsageForbidden() {
+	u.Security.On("IsAuthenticated").Return(true).Once()
+	u.Security.On("GetUsername").Return("user").Once()
+	u.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(false).Once()
+
+	res, err := u.Get("/projects/1/usage")
+	u.Require().NoError(err)
+	u.Equal(403, res.StatusCode)
+	u.usageMgr.AssertNotCalled(u.T(), "GetProjectReport", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (u *usageTestSuite) TestGetProjectUsage() {
+	u.Security.On("IsAuthenticated").Return(true).Once()
+	u.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true).Once()
+	mock.OnAnything(u.projectCtl, "Get").Return(&project.Project{ProjectID: 1, Name: "library"}, nil)
+	mock.OnAnything(u.usageMgr, "GetProjectReport").Return(&model.ProjectReport{ProjectID: 1, PushCount: 2}, nil)
+
+	usage := &models.ProjectUsage{}
+	res, err := u.GetJSON("/projects/library/usage", usage)
+	u.Require().NoError(err)
+	u.Equal(200, res.StatusCode)
+	u.Equal(int64(2), usage.PushCount)
+	u.Equal(int64(1), u.usageMgr.Calls[0].Arguments.Get(1))
+}
+
+func (u *usageTestSuite) TestParseUsageDays() {
+	// default range
+	from, to, err := parseUsageDays(nil, nil)
+	u.Require().Nil(err)
+	u.Equal(time.Now().UTC().Truncate(24*time.Hour), to)
+	u.Equal(to.AddDate(0, 0, -29), from)
+
+	// invalid format
+	_, _, err = parseUsageDays(swag.String("2023/06/01"), nil)
+	u.NotNil(err)
+
+	// from after to
+	_, _, err = parseUsageDays(swag.String("2023-06-02"), swag.String("2023-06-01"))
+	u.NotNil(err)
+
+	// exceed the max range
+	_, _, err = parseUsageDays(swag.String("2022-01-01"), swag.String("2023-06-01"))
+	u.NotNil(err)
+}
+
+func TestUsageTestSuite(t *testing.T) {
+	suite.Run(t, &usageTestSuite{})
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/goharbor/harbor/src/common/security"
 	"github.com/goharbor/harbor/src/common/security/local"
 	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/controller/user"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/config"
@@ -37,6 +38,8 @@ import (
 	"github.com/goharbor/harbor/src/lib/log"
 	"github.com/goharbor/harbor/src/lib/q"
 	"github.com/goharbor/harbor/src/lib/retry"
+	"github.com/goharbor/harbor/src/pkg/activity"
+	activityModel "github.com/goharbor/harbor/src/pkg/activity/model"
 	"github.com/goharbor/harbor/src/pkg/permission/types"
 	"github.com/goharbor/harbor/src/server/v2.0/handler/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
@@ -47,14 +50,18 @@ var userResource = system.NewNamespace().Resource(rbac.ResourceUser)
 
 type usersAPI struct {
 	BaseAPI
-	ctl     user.Controller
-	getAuth func(ctx context.Context) (string, error) // For testing
+	ctl         user.Controller
+	projectCtl  project.Controller
+	activityMgr activity.Manager
+	getAuth     func(ctx context.Context) (string, error) // For testing
 }
 
 func newUsersAPI() *usersAPI {
 	return &usersAPI{
-		ctl:     user.Ctl,
-		getAuth: config.AuthMode,
+		ctl:         user.Ctl,
+		projectCtl:  project.Ctl,
+		activityMgr: activity.Mgr,
+		getAuth:     config.AuthMode,
 	}
 }
 
@@ -110,7 +117,7 @@ func (u *usersAPI) ListUsers(ctx context.Context, params operation.ListUsersPara
 	if err := u.RequireSystemAccess(ctx, rbac.ActionList, userResource); err != nil {
 		return u.SendError(ctx, err)
 	}
-	query, err := u.BuildQuery(ctx, params.Q, params.Sort, params.Page, params.PageSize)
+	query, err := u.BuildCursorQuery(ctx, params.Q, params.Sort, params.Cursor, params.Page, params.PageSize)
 	if err != nil {
 		return u.SendError(ctx, err)
 	}
@@ -140,10 +147,53 @@ func (u *usersAPI) ListUsers(ctx context.Context, params operation.ListUsersPara
 	}
 	return operation.NewListUsersOK().
 		WithPayload(payload).
-		WithLink(u.Links(ctx, params.HTTPRequest.URL, total, query.PageNumber, query.PageSize).String()).
+		WithLink(u.CursorLinks(ctx, params.HTTPRequest.URL, total, query).String()).
 		WithXTotalCount(total)
 }
 
+func (u *usersAPI) ListCurrentUserActivities(ctx context.Context, params operation.ListCurrentUserActivitiesParams) middleware.Responder {
+	if err := u.RequireAuthenticated(ctx); err != nil {
+		return u.SendError(ctx, err)
+	}
+	activityTypes, err := parseActivityTypes(lib.StringValue(params.Type))
+	if err != nil {
+		return u.SendError(ctx, err)
+	}
+	query := &activityModel.Query{Types: activityTypes}
+	// the users who cannot list all the audit logs only see the activities of the projects they are members of
+	if err := u.RequireSystemAccess(ctx, rbac.ActionList, rbac.ResourceAuditLog); err != nil {
+		query.ProjectIDs = []int64{}
+		sctx, _ := security.FromContext(ctx)
+		if sc, ok := sctx.(*local.SecurityContext); ok {
+			usr := sc.User()
+			member := &project.MemberQuery{
+				UserID:   usr.UserID,
+				GroupIDs: usr.GroupIDs,
+			}
+			projects, err := u.projectCtl.List(ctx, q.New(q.KeyWords{"member": member}), project.Metadata(false))
+			if err != nil {
+				return u.SendError(ctx, err)
+			}
+			for _, p := range projects {
+				if u.HasProjectPermission(ctx, p.ProjectID, rbac.ActionList, rbac.ResourceLog) {
+					query.ProjectIDs = append(query.ProjectIDs, p.ProjectID)
+				}
+			}
+		}
+	}
+
+	page, pageSize := lib.Int64Value(params.Page), lib.Int64Value(params.PageSize)
+	total, activities, err := u.activityMgr.List(ctx, query, page, pageSize)
+	if err != nil {
+		return u.SendError(ctx, err)
+	}
+
+	return operation.NewListCurrentUserActivitiesOK().
+		WithXTotalCount(total).
+		WithLink(u.Links(ctx, params.HTTPRequest.URL, total, page, pageSize).String()).
+		WithPayload(toActivitiesSwagger(activities))
+}
+
 func (u *usersAPI) GetCurrentUserPermissions(ctx context.Context, params operation.GetCurrentUserPermissionsParams) middleware.Responder {
 	if err := u.RequireAuthenticated(ctx); err != nil {
 		return u.SendError(ctx, err)
@@ -343,6 +393,47 @@ func (u *usersAPI) SetUserSysAdmin(ctx context.Context, params operation.SetUser
 	return operation.NewSetUserSysAdminOK()
 }
 
+func (u *usersAPI) SetUserAuditor(ctx context.Context, params operation.SetUserAuditorParams) middleware.Responder {
+	id := int(params.UserID)
+	if err := u.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceUser); err != nil {
+		return u.SendError(ctx, err)
+	}
+	if err := u.ctl.SetAuditor(ctx, id, params.AuditorFlag.AuditorFlag); err != nil {
+		return u.SendError(ctx, err)
+	}
+	return operation.NewSetUserAuditorOK()
+}
+
+func (u *usersAPI) DeactivateUser(ctx context.Context, params operation.DeactivateUserParams) middleware.Responder {
+	id := int(params.UserID)
+	if err := u.RequireSystemAccess(ctx, rbac.ActionUpdate, rbac.ResourceUser); err != nil {
+		return u.SendError(ctx, err)
+	}
+	sctx, _ := security.FromContext(ctx)
+	if matchUserID(sctx, id) || id == 1 {
+		return u.SendError(ctx, errors.ForbiddenError(nil).WithMessage("User with ID %d cannot be deactivated", id))
+	}
+	var transferTo int
+	if params.Deactivation != nil {
+		transferTo = int(params.Deactivation.TransferProjectsTo)
+	}
+	report, err := u.ctl.Deactivate(ctx, id, transferTo)
+	if err != nil {
+		return u.SendError(ctx, err)
+	}
+	return operation.NewDeactivateUserOK().WithPayload(&models.UserDeactivationReport{
+		UserID:              int64(report.UserID),
+		Username:            report.Username,
+		LoginDisabled:       report.LoginDisabled,
+		CliSecretRevoked:    report.CliSecretRevoked,
+		PullTokensRevoked:   report.PullTokensRevoked,
+		SysadminRevoked:     report.SysAdminRevoked,
+		AuditorRevoked:      report.AuditorRevoked,
+		TransferredProjects: report.TransferredProjects,
+		TransferredTo:       report.TransferredTo,
+	})
+}
+
 func (u *usersAPI) requireForCLISecret(ctx context.Context, id int) error {
 	a, err := u.getAuth(ctx)
 	if err != nil {
@@ -2,16 +2,20 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/controller/user"
+	activityModel "github.com/goharbor/harbor/src/pkg/activity/model"
 	"github.com/goharbor/harbor/src/server/v2.0/models"
 	"github.com/goharbor/harbor/src/server/v2.0/restapi"
 	usertesting "github.com/goharbor/harbor/src/testing/controller/user"
 	"github.com/goharbor/harbor/src/testing/mock"
+	activitytesting "github.com/goharbor/harbor/src/testing/pkg/activity"
 	htesting "github.com/goharbor/harbor/src/testing/server/v2.0/handler"
 )
 
@@ -36,14 +40,17 @@ func TestRequireValidSecret(t *testing.T) {
 
 type UserTestSuite struct {
 	htesting.Suite
-	uCtl *usertesting.Controller
+	uCtl        *usertesting.Controller
+	activityMgr *activitytesting.Manager
 }
 
 func (uts *UserTestSuite) SetupSuite() {
 	uts.uCtl = &usertesting.Controller{}
+	uts.activityMgr = &activitytesting.Manager{}
 	uts.Config = &restapi.Config{
 		UserAPI: &usersAPI{
-			ctl: uts.uCtl,
+			ctl:         uts.uCtl,
+			activityMgr: uts.activityMgr,
 			getAuth: func(ctx context.Context) (string, error) {
 				return common.DBAuth, nil
 			},
@@ -90,6 +97,59 @@ func (uts *UserTestSuite) TestUpdateUserPassword() {
 	}
 }
 
+func (uts *UserTestSuite) TestDeactivateUser() {
+	{
+		uts.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(false).Times(1)
+		uts.Security.On("GetUsername").Return("user").Times(1)
+		res, err := uts.Suite.PutJSON("/users/2/deactivate", &models.UserDeactivateReq{})
+		uts.NoError(err)
+		uts.Equal(403, res.StatusCode)
+	}
+	{
+		// the default admin can't be deactivated
+		uts.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true).Times(1)
+		res, err := uts.Suite.PutJSON("/users/1/deactivate", &models.UserDeactivateReq{})
+		uts.NoError(err)
+		uts.Equal(403, res.StatusCode)
+	}
+	{
+		uts.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true).Times(1)
+		uts.uCtl.On("Deactivate", mock.Anything, 2, 3).Return(&user.DeactivationReport{
+			UserID:              2,
+			Username:            "leaver",
+			LoginDisabled:       true,
+			PullTokensRevoked:   []int64{1},
+			TransferredProjects: []string{"library"},
+			TransferredTo:       "successor",
+		}, nil).Times(1)
+		report := &models.UserDeactivationReport{}
+		res, err := uts.Suite.PutJSON("/users/2/deactivate", &models.UserDeactivateReq{TransferProjectsTo: 3})
+		uts.NoError(err)
+		uts.Equal(200, res.StatusCode)
+		uts.NoError(json.NewDecoder(res.Body).Decode(report))
+		uts.True(report.LoginDisabled)
+		uts.Equal([]int64{1}, report.PullTokensRevoked)
+		uts.Equal([]string{"library"}, report.TransferredProjects)
+		uts.Equal("successor", report.TransferredTo)
+	}
+}
+
+func (uts *UserTestSuite) TestListCurrentUserActivities() {
+	// the users who can list all the audit logs see the activities of all the projects
+	uts.Security.On("Can", mock.Anything, mock.Anything, mock.Anything).Return(true).Times(1)
+	uts.activityMgr.On("List", mock.Anything, &activityModel.Query{}, int64(2), int64(5)).Return(int64(6), []*activityModel.Activity{
+		{Type: activityModel.TypeScan, ProjectID: 1, Resource: "library/hello-world@sha256:digest", Operation: "scan", Operator: "MANUAL", Status: "Success"},
+	}, nil).Times(1)
+
+	var activities []*models.Activity
+	res, err := uts.Suite.GetJSON("/users/current/activity?page=2&page_size=5", &activities)
+	uts.NoError(err)
+	uts.Require().Equal(200, res.StatusCode)
+	uts.Equal("6", res.Header.Get("X-Total-Count"))
+	uts.Require().Len(activities, 1)
+	uts.Equal("Success", activities[0].Status)
+}
+
 func (uts *UserTestSuite) TestGetRandomSecret() {
 	for i := 1; i < 5; i++ {
 		rSec, err := getRandomSecret()
@@ -22,6 +22,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/common/security/local"
 	"github.com/goharbor/harbor/src/controller/project"
 	"github.com/goharbor/harbor/src/lib"
 	"github.com/goharbor/harbor/src/lib/errors"
@@ -132,3 +134,13 @@ func getProjectID(ctx context.Context, projectNameOrID interface{}) (int64, erro
 	}
 	return 0, errors.New("unknown project identifier type")
 }
+
+// canViewAll returns whether the user of the security context can view the resources of all projects,
+// which is true for the system administrators and the auditors
+func canViewAll(secCtx security.Context) bool {
+	if secCtx.IsSysAdmin() {
+		return true
+	}
+	lsc, ok := secCtx.(*local.SecurityContext)
+	return ok && lsc.IsAuditor()
+}
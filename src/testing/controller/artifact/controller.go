@@ -56,6 +56,27 @@ func (_m *Controller) Copy(ctx context.Context, srcRepo string, reference string
 	return r0, r1
 }
 
+// CopyWithTag provides a mock function with given fields: ctx, srcRepo, reference, dstRepo, tag
+func (_m *Controller) CopyWithTag(ctx context.Context, srcRepo string, reference string, dstRepo string, tag string) (int64, error) {
+	ret := _m.Called(ctx, srcRepo, reference, dstRepo, tag)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) int64); ok {
+		r0 = rf(ctx, srcRepo, reference, dstRepo, tag)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, srcRepo, reference, dstRepo, tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Count provides a mock function with given fields: ctx, query
 func (_m *Controller) Count(ctx context.Context, query *q.Query) (int64, error) {
 	ret := _m.Called(ctx, query)
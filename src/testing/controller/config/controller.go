@@ -7,6 +7,10 @@ import (
 
 	models "github.com/goharbor/harbor/src/lib/config/models"
 	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/goharbor/harbor/src/pkg/config/history/model"
+
+	q "github.com/goharbor/harbor/src/lib/q"
 )
 
 // Controller is an autogenerated mock type for the Controller type
@@ -60,6 +64,50 @@ func (_m *Controller) ConvertForGet(ctx context.Context, cfg map[string]interfac
 	return r0, r1
 }
 
+// CountHistories provides a mock function with given fields: ctx, query
+func (_m *Controller) CountHistories(ctx context.Context, query *q.Query) (int64, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) int64); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListHistories provides a mock function with given fields: ctx, query
+func (_m *Controller) ListHistories(ctx context.Context, query *q.Query) ([]*model.History, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*model.History
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*model.History); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.History)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // OverwriteConfig provides a mock function with given fields: ctx
 func (_m *Controller) OverwriteConfig(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -74,6 +122,20 @@ func (_m *Controller) OverwriteConfig(ctx context.Context) error {
 	return r0
 }
 
+// Rollback provides a mock function with given fields: ctx, version
+func (_m *Controller) Rollback(ctx context.Context, version int64) error {
+	ret := _m.Called(ctx, version)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUserConfigs provides a mock function with given fields: ctx, conf
 func (_m *Controller) UpdateUserConfigs(ctx context.Context, conf map[string]interface{}) error {
 	ret := _m.Called(ctx, conf)
@@ -32,3 +32,12 @@ package controller
 //go:generate mockery --case snake --dir ../../controller/jobservice --name SchedulerController --output ./jobservice --outpkg jobservice
 //go:generate mockery --case snake --dir ../../controller/systemartifact --name Controller --output ./systemartifact --outpkg systemartifact
 //go:generate mockery --case snake --dir ../../controller/scandataexport --name Controller --output ./scandataexport --outpkg scandataexport
+//go:generate mockery --case snake --dir ../../controller/statistic --name Controller --output ./statistic --outpkg statistic
+//go:generate mockery --case snake --dir ../../controller/tokenkey --name Controller --output ./tokenkey --outpkg tokenkey
+//go:generate mockery --case snake --dir ../../controller/promotion --name Controller --output ./promotion --outpkg promotion
+//go:generate mockery --case snake --dir ../../controller/quarantine --name Controller --output ./quarantine --outpkg quarantine
+//go:generate mockery --case snake --dir ../../controller/imageimport --name Controller --output ./imageimport --outpkg imageimport
+//go:generate mockery --case snake --dir ../../controller/imageexport --name Controller --output ./imageexport --outpkg imageexport
+//go:generate mockery --case snake --dir ../../controller/backup --name Controller --output ./backup --outpkg backup
+//go:generate mockery --case snake --dir ../../controller/configsync --name Controller --output ./configsync --outpkg configsync
+//go:generate mockery --case snake --dir ../../controller/imagecheck --name Controller --output ./imagecheck --outpkg imagecheck
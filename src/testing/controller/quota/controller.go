@@ -20,6 +20,27 @@ type Controller struct {
 	mock.Mock
 }
 
+// ApplyDefaults provides a mock function with given fields: ctx, reference, previous, dryRun
+func (_m *Controller) ApplyDefaults(ctx context.Context, reference string, previous types.ResourceList, dryRun bool) (int64, error) {
+	ret := _m.Called(ctx, reference, previous, dryRun)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, types.ResourceList, bool) int64); ok {
+		r0 = rf(ctx, reference, previous, dryRun)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, types.ResourceList, bool) error); ok {
+		r1 = rf(ctx, reference, previous, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Count provides a mock function with given fields: ctx, query
 func (_m *Controller) Count(ctx context.Context, query *q.Query) (int64, error) {
 	ret := _m.Called(ctx, query)
@@ -258,6 +258,50 @@ func (_m *Controller) PolicyCount(ctx context.Context, query *q.Query) (int64, e
 	return r0, r1
 }
 
+// PreviewRepositoryMappings provides a mock function with given fields: ctx, policy, repositories
+func (_m *Controller) PreviewRepositoryMappings(ctx context.Context, policy *model.Policy, repositories []string) ([]*model.RepositoryMapping, error) {
+	ret := _m.Called(ctx, policy, repositories)
+
+	var r0 []*model.RepositoryMapping
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Policy, []string) []*model.RepositoryMapping); ok {
+		r0 = rf(ctx, policy, repositories)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.RepositoryMapping)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Policy, []string) error); ok {
+		r1 = rf(ctx, policy, repositories)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Retry provides a mock function with given fields: ctx, executionID, scope
+func (_m *Controller) Retry(ctx context.Context, executionID int64, scope string) (int64, error) {
+	ret := _m.Called(ctx, executionID, scope)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) int64); ok {
+		r0 = rf(ctx, executionID, scope)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, executionID, scope)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Start provides a mock function with given fields: ctx, policy, resource, trigger
 func (_m *Controller) Start(ctx context.Context, policy *model.Policy, resource *regmodel.Resource, trigger string) (int64, error) {
 	ret := _m.Called(ctx, policy, resource, trigger)
@@ -335,6 +379,29 @@ func (_m *Controller) UpdatePolicy(ctx context.Context, policy *model.Policy, pr
 	return r0
 }
 
+// ValidatePolicy provides a mock function with given fields: ctx, policy
+func (_m *Controller) ValidatePolicy(ctx context.Context, policy *model.Policy) ([]*model.Finding, error) {
+	ret := _m.Called(ctx, policy)
+
+	var r0 []*model.Finding
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Policy) []*model.Finding); ok {
+		r0 = rf(ctx, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Finding)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Policy) error); ok {
+		r1 = rf(ctx, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewController interface {
 	mock.TestingT
 	Cleanup(func())
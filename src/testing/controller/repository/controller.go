@@ -9,6 +9,10 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	q "github.com/goharbor/harbor/src/lib/q"
+
+	repository "github.com/goharbor/harbor/src/controller/repository"
+
+	time "time"
 )
 
 // Controller is an autogenerated mock type for the Controller type
@@ -65,6 +69,41 @@ func (_m *Controller) Delete(ctx context.Context, id int64) error {
 	return r0
 }
 
+// DeleteAsync provides a mock function with given fields: ctx, id
+func (_m *Controller) DeleteAsync(ctx context.Context, id int64) (int64, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteInBatches provides a mock function with given fields: ctx, id, batchSize, progress
+func (_m *Controller) DeleteInBatches(ctx context.Context, id int64, batchSize int, progress func(*repository.DeletionProgress) error) error {
+	ret := _m.Called(ctx, id, batchSize, progress)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, func(*repository.DeletionProgress) error) error); ok {
+		r0 = rf(ctx, id, batchSize, progress)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Ensure provides a mock function with given fields: ctx, name
 func (_m *Controller) Ensure(ctx context.Context, name string) (bool, int64, error) {
 	ret := _m.Called(ctx, name)
@@ -139,6 +178,29 @@ func (_m *Controller) GetByName(ctx context.Context, name string) (*model.RepoRe
 	return r0, r1
 }
 
+// GetDeletion provides a mock function with given fields: ctx, id
+func (_m *Controller) GetDeletion(ctx context.Context, id int64) (*repository.Deletion, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *repository.Deletion
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *repository.Deletion); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repository.Deletion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, query
 func (_m *Controller) List(ctx context.Context, query *q.Query) ([]*model.RepoRecord, error) {
 	ret := _m.Called(ctx, query)
@@ -183,6 +245,34 @@ func (_m *Controller) Update(ctx context.Context, _a1 *model.RepoRecord, propert
 	return r0
 }
 
+// UpdatePullTime provides a mock function with given fields: ctx, id, pullTime
+func (_m *Controller) UpdatePullTime(ctx context.Context, id int64, pullTime time.Time) error {
+	ret := _m.Called(ctx, id, pullTime)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = rf(ctx, id, pullTime)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePushTime provides a mock function with given fields: ctx, id, pushTime
+func (_m *Controller) UpdatePushTime(ctx context.Context, id int64, pushTime time.Time) error {
+	ret := _m.Called(ctx, id, pushTime)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = rf(ctx, id, pushTime)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewController interface {
 	mock.TestingT
 	Cleanup(func())
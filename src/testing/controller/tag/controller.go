@@ -69,3 +69,23 @@ func (f *FakeController) DeleteTags(ctx context.Context, ids []int64) (err error
 	args := f.Called()
 	return args.Error(0)
 }
+
+// BulkDelete ...
+func (f *FakeController) BulkDelete(ctx context.Context, tags []*tag.Tag) []*tag.DeleteResult {
+	args := f.Called()
+	var results []*tag.DeleteResult
+	if args.Get(0) != nil {
+		results = args.Get(0).([]*tag.DeleteResult)
+	}
+	return results
+}
+
+// ListNames ...
+func (f *FakeController) ListNames(ctx context.Context, repositoryID int64, last string, limit int) ([]string, error) {
+	args := f.Called()
+	var names []string
+	if args.Get(0) != nil {
+		names = args.Get(0).([]string)
+	}
+	return names, args.Error(1)
+}
@@ -62,6 +62,29 @@ func (_m *Controller) Create(ctx context.Context, u *models.User) (int, error) {
 	return r0, r1
 }
 
+// Deactivate provides a mock function with given fields: ctx, id, transferTo
+func (_m *Controller) Deactivate(ctx context.Context, id int, transferTo int) (*user.DeactivationReport, error) {
+	ret := _m.Called(ctx, id, transferTo)
+
+	var r0 *user.DeactivationReport
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *user.DeactivationReport); ok {
+		r0 = rf(ctx, id, transferTo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.DeactivationReport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, transferTo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: ctx, id
 func (_m *Controller) Delete(ctx context.Context, id int) error {
 	ret := _m.Called(ctx, id)
@@ -189,6 +212,34 @@ func (_m *Controller) OnboardOIDCUser(ctx context.Context, u *models.User) error
 	return r0
 }
 
+// Reactivate provides a mock function with given fields: ctx, id
+func (_m *Controller) Reactivate(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetAuditor provides a mock function with given fields: ctx, id, auditorFlag
+func (_m *Controller) SetAuditor(ctx context.Context, id int, auditorFlag bool) error {
+	ret := _m.Called(ctx, id, auditorFlag)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, bool) error); ok {
+		r0 = rf(ctx, id, auditorFlag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetCliSecret provides a mock function with given fields: ctx, id, secret
 func (_m *Controller) SetCliSecret(ctx context.Context, id int, secret string) error {
 	ret := _m.Called(ctx, id, secret)
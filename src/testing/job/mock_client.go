@@ -30,6 +30,18 @@ func (mjc *MockJobClient) GetJobLog(uuid string) ([]byte, error) {
 	return nil, &http.Error{404, "not Found"}
 }
 
+// GetJobLogFrom ...
+func (mjc *MockJobClient) GetJobLogFrom(uuid string, offset int64) ([]byte, error) {
+	data, err := mjc.GetJobLog(uuid)
+	if err != nil {
+		return nil, err
+	}
+	if offset >= int64(len(data)) {
+		return []byte{}, nil
+	}
+	return data[offset:], nil
+}
+
 // SubmitJob ...
 func (mjc *MockJobClient) SubmitJob(data *models.JobData) (string, error) {
 	uuid := fmt.Sprintf("u-%d", rand.Int())
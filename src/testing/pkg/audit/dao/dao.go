@@ -59,6 +59,20 @@ func (_m *DAO) Create(ctx context.Context, access *model.AuditLog) (int64, error
 	return r0, r1
 }
 
+// CreateBatch provides a mock function with given fields: ctx, accesses
+func (_m *DAO) CreateBatch(ctx context.Context, accesses []*model.AuditLog) error {
+	ret := _m.Called(ctx, accesses)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.AuditLog) error); ok {
+		r0 = rf(ctx, accesses)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: ctx, id
 func (_m *DAO) Delete(ctx context.Context, id int64) error {
 	ret := _m.Called(ctx, id)
@@ -5,6 +5,7 @@ package jobmonitor
 import (
 	context "context"
 
+	jobmonitor "github.com/goharbor/harbor/src/pkg/jobmonitor"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -36,6 +37,50 @@ func (_m *RedisClient) AllJobTypes(ctx context.Context) ([]string, error) {
 	return r0, r1
 }
 
+// ListJobs provides a mock function with given fields: ctx, jobType, status
+func (_m *RedisClient) ListJobs(ctx context.Context, jobType string, status string) ([]*jobmonitor.QueuedJob, error) {
+	ret := _m.Called(ctx, jobType, status)
+
+	var r0 []*jobmonitor.QueuedJob
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*jobmonitor.QueuedJob); ok {
+		r0 = rf(ctx, jobType, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*jobmonitor.QueuedJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, jobType, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MaxConcurrency provides a mock function with given fields: ctx, jobName
+func (_m *RedisClient) MaxConcurrency(ctx context.Context, jobName string) (uint, error) {
+	ret := _m.Called(ctx, jobName)
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(context.Context, string) uint); ok {
+		r0 = rf(ctx, jobName)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jobName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PauseJob provides a mock function with given fields: ctx, jobName
 func (_m *RedisClient) PauseJob(ctx context.Context, jobName string) error {
 	ret := _m.Called(ctx, jobName)
@@ -50,6 +95,48 @@ func (_m *RedisClient) PauseJob(ctx context.Context, jobName string) error {
 	return r0
 }
 
+// PurgeJob provides a mock function with given fields: ctx, job
+func (_m *RedisClient) PurgeJob(ctx context.Context, job *jobmonitor.QueuedJob) error {
+	ret := _m.Called(ctx, job)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *jobmonitor.QueuedJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RequeueJob provides a mock function with given fields: ctx, job
+func (_m *RedisClient) RequeueJob(ctx context.Context, job *jobmonitor.QueuedJob) error {
+	ret := _m.Called(ctx, job)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *jobmonitor.QueuedJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetMaxConcurrency provides a mock function with given fields: ctx, jobName, maxConcurrency
+func (_m *RedisClient) SetMaxConcurrency(ctx context.Context, jobName string, maxConcurrency uint) error {
+	ret := _m.Called(ctx, jobName, maxConcurrency)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, jobName, maxConcurrency)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StopPendingJobs provides a mock function with given fields: ctx, jobType
 func (_m *RedisClient) StopPendingJobs(ctx context.Context, jobType string) ([]string, error) {
 	ret := _m.Called(ctx, jobType)
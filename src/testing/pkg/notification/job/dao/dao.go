@@ -38,6 +38,48 @@ func (_m *DAO) Count(ctx context.Context, query *q.Query) (int64, error) {
 	return r0, r1
 }
 
+// CountAttempts provides a mock function with given fields: ctx, query
+func (_m *DAO) CountAttempts(ctx context.Context, query *q.Query) (int64, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) int64); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountDeadLetters provides a mock function with given fields: ctx, query
+func (_m *DAO) CountDeadLetters(ctx context.Context, query *q.Query) (int64, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) int64); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, n
 func (_m *DAO) Create(ctx context.Context, n *model.Job) (int64, error) {
 	ret := _m.Called(ctx, n)
@@ -59,6 +101,48 @@ func (_m *DAO) Create(ctx context.Context, n *model.Job) (int64, error) {
 	return r0, r1
 }
 
+// CreateAttempt provides a mock function with given fields: ctx, attempt
+func (_m *DAO) CreateAttempt(ctx context.Context, attempt *model.Attempt) (int64, error) {
+	ret := _m.Called(ctx, attempt)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Attempt) int64); ok {
+		r0 = rf(ctx, attempt)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Attempt) error); ok {
+		r1 = rf(ctx, attempt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDeadLetter provides a mock function with given fields: ctx, letter
+func (_m *DAO) CreateDeadLetter(ctx context.Context, letter *model.DeadLetter) (int64, error) {
+	ret := _m.Called(ctx, letter)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *model.DeadLetter) int64); ok {
+		r0 = rf(ctx, letter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.DeadLetter) error); ok {
+		r1 = rf(ctx, letter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: ctx, id
 func (_m *DAO) Delete(ctx context.Context, id int64) error {
 	ret := _m.Called(ctx, id)
@@ -87,6 +171,20 @@ func (_m *DAO) DeleteByPolicyID(ctx context.Context, policyID int64) error {
 	return r0
 }
 
+// DeleteDeadLetter provides a mock function with given fields: ctx, id
+func (_m *DAO) DeleteDeadLetter(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, id
 func (_m *DAO) Get(ctx context.Context, id int64) (*model.Job, error) {
 	ret := _m.Called(ctx, id)
@@ -156,6 +254,52 @@ func (_m *DAO) List(ctx context.Context, query *q.Query) ([]*model.Job, error) {
 	return r0, r1
 }
 
+// ListAttempts provides a mock function with given fields: ctx, query
+func (_m *DAO) ListAttempts(ctx context.Context, query *q.Query) ([]*model.Attempt, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*model.Attempt
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*model.Attempt); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Attempt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDeadLetters provides a mock function with given fields: ctx, query
+func (_m *DAO) ListDeadLetters(ctx context.Context, query *q.Query) ([]*model.DeadLetter, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*model.DeadLetter
+	if rf, ok := ret.Get(0).(func(context.Context, *q.Query) []*model.DeadLetter); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.DeadLetter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *q.Query) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, n, props
 func (_m *DAO) Update(ctx context.Context, n *model.Job, props ...string) error {
 	_va := make([]interface{}, len(props))
@@ -35,12 +35,17 @@ package pkg
 //go:generate mockery --case snake --dir ../../pkg/rbac/dao --name DAO --output ./rbac/dao --outpkg dao
 //go:generate mockery --case snake --dir ../../pkg/robot --name Manager --output ./robot --outpkg robot
 //go:generate mockery --case snake --dir ../../pkg/robot/dao --name DAO --output ./robot/dao --outpkg dao
+//go:generate mockery --case snake --dir ../../pkg/role --name Manager --output ./role --outpkg role
+//go:generate mockery --case snake --dir ../../pkg/pulltoken --name Manager --output ./pulltoken --outpkg pulltoken
+//go:generate mockery --case snake --dir ../../pkg/config/history --name Manager --output ./config/history --outpkg history
+//go:generate mockery --case snake --dir ../../pkg/tokenkey --name Manager --output ./tokenkey --outpkg tokenkey
 //go:generate mockery --case snake --dir ../../pkg/repository --name Manager --output ./repository --outpkg repository
 //go:generate mockery --case snake --dir ../../pkg/repository/dao --name DAO --output ./repository/dao --outpkg dao
 //go:generate mockery --case snake --dir ../../pkg/notification/job/dao --name DAO --output ./notification/job/dao --outpkg dao
 //go:generate mockery --case snake --dir ../../pkg/notification/policy/dao --name DAO --output ./notification/policy/dao --outpkg dao
 //go:generate mockery --case snake --dir ../../pkg/notification/policy --name Manager --output ./notification/policy --outpkg notification
 //go:generate mockery --case snake --dir ../../pkg/immutable/dao --name DAO --output ./immutable/dao --outpkg dao
+//go:generate mockery --case snake --dir ../../pkg/immutable --name Manager --output ./immutable --outpkg immutable
 //go:generate mockery --case snake --dir ../../pkg/ldap --name Manager --output ./ldap --outpkg ldap
 //go:generate mockery --case snake --dir ../../pkg/allowlist --name Manager --output ./allowlist --outpkg robot
 //go:generate mockery --case snake --dir ../../pkg/allowlist/dao --name DAO --output ./allowlist/dao --outpkg dao
@@ -58,6 +63,10 @@ package pkg
 //go:generate mockery --case snake --dir ../../pkg/accessory --name Manager --output ./accessory --outpkg accessory
 //go:generate mockery --case snake --dir ../../pkg/audit/dao --name DAO --output ./audit/dao --outpkg dao
 //go:generate mockery --case snake --dir ../../pkg/audit --name Manager --output ./audit --outpkg audit
+//go:generate mockery --case snake --dir ../../pkg/jobarchive/dao --name DAO --output ./jobarchive/dao --outpkg dao
+//go:generate mockery --case snake --dir ../../pkg/jobarchive --name Manager --output ./jobarchive --outpkg jobarchive
+//go:generate mockery --case snake --dir ../../pkg/project/statistic/dao --name DAO --output ./project/statistic/dao --outpkg dao
+//go:generate mockery --case snake --dir ../../pkg/project/statistic --name Manager --output ./project/statistic --outpkg statistic
 //go:generate mockery --case snake --dir ../../pkg/systemartifact --name Manager --output ./systemartifact --outpkg systemartifact
 //go:generate mockery --case snake --dir ../../pkg/systemartifact/ --name Selector --output ./systemartifact/cleanup --outpkg cleanup
 //go:generate mockery --case snake --dir ../../pkg/systemartifact/dao --name DAO --output ./systemartifact/dao --outpkg dao
@@ -74,3 +83,23 @@ package pkg
 //go:generate mockery --case snake --dir ../../pkg/jobmonitor --name QueueManager --output ./jobmonitor --outpkg jobmonitor
 //go:generate mockery --case snake --dir ../../pkg/jobmonitor --name RedisClient --output ./jobmonitor --outpkg jobmonitor
 //go:generate mockery --case snake --dir ../../pkg/queuestatus --name Manager --output ./queuestatus --outpkg queuestatus
+//go:generate mockery --case snake --dir ../../pkg/usage/dao --name DAO --output ./usage/dao --outpkg dao
+//go:generate mockery --case snake --dir ../../pkg/usage --name Manager --output ./usage --outpkg usage
+//go:generate mockery --case snake --dir ../../pkg/systemjob --name Manager --output ./systemjob --outpkg systemjob
+//go:generate mockery --case snake --dir ../../pkg/signature/cosign --name SignatureVerifier --output ./signature/cosign --outpkg cosign
+//go:generate mockery --case snake --dir ../../pkg/deployment/dao --name DAO --output ./deployment/dao --outpkg dao
+//go:generate mockery --case snake --dir ../../pkg/deployment --name Manager --output ./deployment --outpkg deployment
+//go:generate mockery --case snake --dir ../../pkg/pin/dao --name DAO --output ./pin/dao --outpkg dao
+//go:generate mockery --case snake --dir ../../pkg/pin --name Manager --output ./pin --outpkg pin
+//go:generate mockery --case snake --dir ../../pkg/project/readme/dao --name DAO --output ./project/readme/dao --outpkg dao
+//go:generate mockery --case snake --dir ../../pkg/project/readme --name Manager --output ./project/readme --outpkg readme
+//go:generate mockery --case snake --dir ../../pkg/search --name Manager --output ./search --outpkg search
+//go:generate mockery --case snake --dir ../../pkg/activity --name Manager --output ./activity --outpkg activity
+//go:generate mockery --case snake --dir ../../pkg/promotion/dao --name DAO --output ./promotion/dao --outpkg dao
+//go:generate mockery --case snake --dir ../../pkg/promotion --name Manager --output ./promotion --outpkg promotion
+//go:generate mockery --case snake --dir ../../pkg/quarantine/dao --name DAO --output ./quarantine/dao --outpkg dao
+//go:generate mockery --case snake --dir ../../pkg/quarantine --name Manager --output ./quarantine --outpkg quarantine
+//go:generate mockery --case snake --dir ../../pkg/migration/dao --name DAO --output ./migration/dao --outpkg dao
+//go:generate mockery --case snake --dir ../../pkg/migration --name Manager --output ./migration --outpkg migration
+//go:generate mockery --case snake --dir ../../pkg/backup/dao --name DAO --output ./backup/dao --outpkg dao
+//go:generate mockery --case snake --dir ../../pkg/backup --name Manager --output ./backup --outpkg backup
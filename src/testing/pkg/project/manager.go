@@ -72,6 +72,20 @@ func (_m *Manager) Delete(ctx context.Context, id int64) error {
 	return r0
 }
 
+// UpdateOwner provides a mock function with given fields: ctx, id, ownerID
+func (_m *Manager) UpdateOwner(ctx context.Context, id int64, ownerID int) error {
+	ret := _m.Called(ctx, id, ownerID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) error); ok {
+		r0 = rf(ctx, id, ownerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, idOrName
 func (_m *Manager) Get(ctx context.Context, idOrName interface{}) (*models.Project, error) {
 	ret := _m.Called(ctx, idOrName)
@@ -144,6 +144,29 @@ func (_m *Manager) List(ctx context.Context, query *q.Query) ([]*model.Registry,
 	return r0, r1
 }
 
+// ListRegistryProviderCapabilities provides a mock function with given fields: ctx
+func (_m *Manager) ListRegistryProviderCapabilities(ctx context.Context) ([]*model.RegistryInfo, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.RegistryInfo
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.RegistryInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.RegistryInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListRegistryProviderInfos provides a mock function with given fields: ctx
 func (_m *Manager) ListRegistryProviderInfos(ctx context.Context) (map[string]*model.AdapterPattern, error) {
 	ret := _m.Called(ctx)
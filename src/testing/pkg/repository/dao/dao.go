@@ -10,6 +10,8 @@ import (
 	model "github.com/goharbor/harbor/src/pkg/repository/model"
 
 	q "github.com/goharbor/harbor/src/lib/q"
+
+	time "time"
 )
 
 // DAO is an autogenerated mock type for the DAO type
@@ -177,6 +179,20 @@ func (_m *DAO) Update(ctx context.Context, repository *model.RepoRecord, props .
 	return r0
 }
 
+// UpdatePullTime provides a mock function with given fields: ctx, id, pullTime
+func (_m *DAO) UpdatePullTime(ctx context.Context, id int64, pullTime time.Time) error {
+	ret := _m.Called(ctx, id, pullTime)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = rf(ctx, id, pullTime)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewDAO interface {
 	mock.TestingT
 	Cleanup(func())
@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	q "github.com/goharbor/harbor/src/lib/q"
+
+	time "time"
 )
 
 // Manager is an autogenerated mock type for the Manager type
@@ -199,6 +201,20 @@ func (_m *Manager) Update(ctx context.Context, _a1 *model.RepoRecord, props ...s
 	return r0
 }
 
+// UpdatePullTime provides a mock function with given fields: ctx, id, pullTime
+func (_m *Manager) UpdatePullTime(ctx context.Context, id int64, pullTime time.Time) error {
+	ret := _m.Called(ctx, id, pullTime)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = rf(ctx, id, pullTime)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewManager interface {
 	mock.TestingT
 	Cleanup(func())
@@ -67,6 +67,27 @@ func (_m *Manager) Create(ctx context.Context, artifactRecord *model.SystemArtif
 	return r0, r1
 }
 
+// CreateChunked provides a mock function with given fields: ctx, artifactRecord, chunk, start, end, location
+func (_m *Manager) CreateChunked(ctx context.Context, artifactRecord *model.SystemArtifact, chunk io.Reader, start int64, end int64, location string) (string, error) {
+	ret := _m.Called(ctx, artifactRecord, chunk, start, end, location)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, *model.SystemArtifact, io.Reader, int64, int64, string) string); ok {
+		r0 = rf(ctx, artifactRecord, chunk, start, end, location)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.SystemArtifact, io.Reader, int64, int64, string) error); ok {
+		r1 = rf(ctx, artifactRecord, chunk, start, end, location)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: ctx, vendor, repository, digest
 func (_m *Manager) Delete(ctx context.Context, vendor string, repository string, digest string) error {
 	ret := _m.Called(ctx, vendor, repository, digest)
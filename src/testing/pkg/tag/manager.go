@@ -77,3 +77,13 @@ func (f *FakeManager) DeleteOfArtifact(ctx context.Context, artifactID int64) er
 	args := f.Called()
 	return args.Error(0)
 }
+
+// ListNames ...
+func (f *FakeManager) ListNames(ctx context.Context, repositoryID int64, last string, limit int) ([]string, error) {
+	args := f.Called()
+	var names []string
+	if args.Get(0) != nil {
+		names = args.Get(0).([]string)
+	}
+	return names, args.Error(1)
+}
@@ -134,6 +134,29 @@ func (_m *Manager) GetLog(ctx context.Context, id int64) ([]byte, error) {
 	return r0, r1
 }
 
+// GetLogFrom provides a mock function with given fields: ctx, id, offset
+func (_m *Manager) GetLogFrom(ctx context.Context, id int64, offset int64) ([]byte, error) {
+	ret := _m.Called(ctx, id, offset)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) []byte); ok {
+		r0 = rf(ctx, id, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, id, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, query
 func (_m *Manager) List(ctx context.Context, query *q.Query) ([]*task.Task, error) {
 	ret := _m.Called(ctx, query)
@@ -209,6 +209,34 @@ func (_m *Manager) Onboard(ctx context.Context, _a1 *commonmodels.User) error {
 	return r0
 }
 
+// SetAuditorFlag provides a mock function with given fields: ctx, id, auditor
+func (_m *Manager) SetAuditorFlag(ctx context.Context, id int, auditor bool) error {
+	ret := _m.Called(ctx, id, auditor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, bool) error); ok {
+		r0 = rf(ctx, id, auditor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetDeactivated provides a mock function with given fields: ctx, id, deactivated
+func (_m *Manager) SetDeactivated(ctx context.Context, id int, deactivated bool) error {
+	ret := _m.Called(ctx, id, deactivated)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, bool) error); ok {
+		r0 = rf(ctx, id, deactivated)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetSysAdminFlag provides a mock function with given fields: ctx, id, admin
 func (_m *Manager) SetSysAdminFlag(ctx context.Context, id int, admin bool) error {
 	ret := _m.Called(ctx, id, admin)
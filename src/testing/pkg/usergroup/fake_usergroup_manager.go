@@ -16,6 +16,20 @@ type Manager struct {
 	mock.Mock
 }
 
+// AddMember provides a mock function with given fields: ctx, groupID, userID
+func (_m *Manager) AddMember(ctx context.Context, groupID int, userID int) error {
+	ret := _m.Called(ctx, groupID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, groupID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Count provides a mock function with given fields: ctx, query
 func (_m *Manager) Count(ctx context.Context, query *q.Query) (int64, error) {
 	ret := _m.Called(ctx, query)
@@ -118,6 +132,52 @@ func (_m *Manager) List(ctx context.Context, query *q.Query) ([]*model.UserGroup
 	return r0, r1
 }
 
+// ListGroupIDsByUser provides a mock function with given fields: ctx, userID
+func (_m *Manager) ListGroupIDsByUser(ctx context.Context, userID int) ([]int, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, int) []int); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMemberIDs provides a mock function with given fields: ctx, groupID
+func (_m *Manager) ListMemberIDs(ctx context.Context, groupID int) ([]int, error) {
+	ret := _m.Called(ctx, groupID)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, int) []int); ok {
+		r0 = rf(ctx, groupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, groupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Onboard provides a mock function with given fields: ctx, g
 func (_m *Manager) Onboard(ctx context.Context, g *model.UserGroup) error {
 	ret := _m.Called(ctx, g)
@@ -155,6 +215,20 @@ func (_m *Manager) Populate(ctx context.Context, userGroups []model.UserGroup) (
 	return r0, r1
 }
 
+// RemoveMember provides a mock function with given fields: ctx, groupID, userID
+func (_m *Manager) RemoveMember(ctx context.Context, groupID int, userID int) error {
+	ret := _m.Called(ctx, groupID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, groupID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateName provides a mock function with given fields: ctx, id, groupName
 func (_m *Manager) UpdateName(ctx context.Context, id int, groupName string) error {
 	ret := _m.Called(ctx, id, groupName)
@@ -2,6 +2,8 @@ package registryctl
 
 import (
 	"github.com/stretchr/testify/mock"
+
+	"github.com/goharbor/harbor/src/registryctl/storage"
 )
 
 type Mockclient struct {
@@ -22,3 +24,49 @@ func (c *Mockclient) DeleteBlob(reference string) (err error) {
 func (c *Mockclient) DeleteManifest(repository, reference string) (err error) {
 	return nil
 }
+
+// GetStorage ...
+func (c *Mockclient) GetStorage() (*storage.Config, error) {
+	args := c.Called()
+	var cfg *storage.Config
+	if args.Get(0) != nil {
+		cfg = args.Get(0).(*storage.Config)
+	}
+	return cfg, args.Error(1)
+}
+
+// ValidateStorage ...
+func (c *Mockclient) ValidateStorage(cfg *storage.Config) error {
+	args := c.Called(cfg)
+	return args.Error(0)
+}
+
+// UpdateStorage ...
+func (c *Mockclient) UpdateStorage(cfg *storage.Config) (*storage.UpdateResult, error) {
+	args := c.Called(cfg)
+	var result *storage.UpdateResult
+	if args.Get(0) != nil {
+		result = args.Get(0).(*storage.UpdateResult)
+	}
+	return result, args.Error(1)
+}
+
+// PurgeUploads ...
+func (c *Mockclient) PurgeUploads(olderThanHours int, dryRun bool) (*storage.PurgeUploadsResult, error) {
+	args := c.Called(olderThanHours, dryRun)
+	var result *storage.PurgeUploadsResult
+	if args.Get(0) != nil {
+		result = args.Get(0).(*storage.PurgeUploadsResult)
+	}
+	return result, args.Error(1)
+}
+
+// UpdateHTTPSecret ...
+func (c *Mockclient) UpdateHTTPSecret(secret string) (*storage.UpdateResult, error) {
+	args := c.Called(secret)
+	var result *storage.UpdateResult
+	if args.Get(0) != nil {
+		result = args.Get(0).(*storage.UpdateResult)
+	}
+	return result, args.Error(1)
+}
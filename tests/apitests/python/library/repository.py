@@ -90,10 +90,10 @@ class Repository(base.Base, object):
         if self.image_exists(repository, tag, **kwargs):
             raise Exception("image %s:%s exists" % (repository, tag))
 
-    def delete_repository(self, project_name, repo_name, expect_status_code = 200, expect_response_body = None, **kwargs):
+    def delete_repository(self, project_name, repo_name, expect_status_code = 202, expect_response_body = None, expect_deletion_status = "Success", **kwargs):
         client = self._get_client(**kwargs)
         try:
-            _, status_code, _ = client.delete_repository_with_http_info(project_name, repo_name)
+            deletion, status_code, _ = client.delete_repository_with_http_info(project_name, repo_name)
         except Exception as e:
             base._assert_status_code(expect_status_code, e.status)
             if expect_response_body is not None:
@@ -101,8 +101,27 @@ class Repository(base.Base, object):
             return
         else:
             base._assert_status_code(expect_status_code, status_code)
-            base._assert_status_code(200, status_code)
+            base._assert_status_code(202, status_code)
+        # the repository is deleted in background, wait until the deletion completes
+        deletion = self.wait_repository_deletion(project_name, repo_name, deletion.id, **kwargs)
+        if deletion.status != expect_deletion_status:
+            raise Exception("The status of the deletion of repository {} is {}, expected {}".format(repo_name, deletion.status, expect_deletion_status))
 
+    def get_repository_deletion(self, project_name, repo_name, deletion_id, **kwargs):
+        client = self._get_client(**kwargs)
+        data, status_code, _ = client.get_repository_deletion_with_http_info(project_name, repo_name, deletion_id)
+        base._assert_status_code(200, status_code)
+        return data
+
+    def wait_repository_deletion(self, project_name, repo_name, deletion_id, timeout_count = 60, **kwargs):
+        while True:
+            deletion = self.get_repository_deletion(project_name, repo_name, deletion_id, **kwargs)
+            if deletion.status in ("Success", "Error", "Stopped"):
+                return deletion
+            timeout_count = timeout_count - 1
+            if timeout_count == 0:
+                raise Exception("Timeout while waiting for the deletion of repository {}".format(repo_name))
+            time.sleep(2)
 
     def list_repositories(self, project_name, **kwargs):
         client = self._get_client(**kwargs)